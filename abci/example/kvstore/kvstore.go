@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	dbm "github.com/cometbft/cometbft-db"
+	gogotypes "github.com/cosmos/gogoproto/types"
 
 	"github.com/cometbft/cometbft/abci/types"
 	cryptoencoding "github.com/cometbft/cometbft/crypto/encoding"
@@ -37,10 +38,14 @@ var _ types.Application = (*Application)(nil)
 type Application struct {
 	types.BaseApplication
 
-	state        State
-	RetainBlocks int64 // blocks to retain after commit (via ResponseCommit.RetainHeight)
-	stagedTxs    [][]byte
-	logger       log.Logger
+	state State
+	// committedState is the state as of the last Commit, from which the
+	// blocks are executed: the state resulting from a previous FinalizeBlock
+	// at the same height, e.g. an optimistic execution, is discarded.
+	committedState State
+	RetainBlocks   int64 // blocks to retain after commit (via ResponseCommit.RetainHeight)
+	stagedTxs      [][]byte
+	logger         log.Logger
 
 	// validator set
 	valUpdates         []types.ValidatorUpdate
@@ -53,9 +58,11 @@ type Application struct {
 
 // NewApplication creates an instance of the kvstore from the provided database
 func NewApplication(db dbm.DB) *Application {
+	state := loadState(db)
 	return &Application{
 		logger:             log.NewNopLogger(),
-		state:              loadState(db),
+		state:              state,
+		committedState:     state,
 		valAddrToPubKeyMap: make(map[string]cryptoproto.PublicKey),
 	}
 }
@@ -103,6 +110,8 @@ func (app *Application) Info(context.Context, *types.RequestInfo) (*types.Respon
 		AppVersion:       AppVersion,
 		LastBlockHeight:  app.state.Height,
 		LastBlockAppHash: app.state.Hash(),
+		// FinalizeBlock executes the blocks from the committed state.
+		OptimisticExecution: true,
 	}, nil
 }
 
@@ -161,7 +170,12 @@ func isValidTx(tx []byte) bool {
 // quite a trivial example of transaction modification.
 // NOTE: we assume that Tendermint will never provide more transactions than can fit in a block.
 func (app *Application) PrepareProposal(ctx context.Context, req *types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error) {
-	return &types.ResponsePrepareProposal{Txs: app.formatTxs(ctx, req.Txs)}, nil
+	txs := app.formatTxs(ctx, req.Txs)
+	// Each transaction wants 1 gas, as reported by CheckTx.
+	return &types.ResponsePrepareProposal{
+		Txs:       txs,
+		GasWanted: &gogotypes.Int64Value{Value: int64(len(txs))},
+	}, nil
 }
 
 // formatTxs validates and excludes invalid transactions
@@ -185,7 +199,11 @@ func (app *Application) ProcessProposal(ctx context.Context, req *types.RequestP
 			return &types.ResponseProcessProposal{Status: types.ResponseProcessProposal_REJECT}, nil
 		}
 	}
-	return &types.ResponseProcessProposal{Status: types.ResponseProcessProposal_ACCEPT}, nil
+	// Each transaction wants 1 gas, as reported by CheckTx.
+	return &types.ResponseProcessProposal{
+		Status:    types.ResponseProcessProposal_ACCEPT,
+		GasWanted: &gogotypes.Int64Value{Value: int64(len(req.Txs))},
+	}, nil
 }
 
 // FinalizeBlock executes the block against the application state. It punishes validators who equivocated and
@@ -193,9 +211,10 @@ func (app *Application) ProcessProposal(ctx context.Context, req *types.RequestP
 // updates and are cached in memory and will be persisted once Commit is called.
 // ConsensusParams are never changed.
 func (app *Application) FinalizeBlock(_ context.Context, req *types.RequestFinalizeBlock) (*types.ResponseFinalizeBlock, error) {
-	// reset valset changes
+	// reset valset changes, and the state of a previous call at this height
 	app.valUpdates = make([]types.ValidatorUpdate, 0)
 	app.stagedTxs = make([][]byte, 0)
+	app.state = app.committedState
 
 	// Punish validators who committed equivocation.
 	for _, ev := range req.Misbehavior {
@@ -345,6 +364,7 @@ func (app *Application) Commit(context.Context, *types.RequestCommit) (*types.Re
 
 	// persist the state (i.e. size and height)
 	saveState(app.state)
+	app.committedState = app.state
 
 	resp := &types.ResponseCommit{}
 	if app.RetainBlocks > 0 && app.state.Height >= app.RetainBlocks {
@@ -11,8 +11,9 @@ import (
 	_ "github.com/cosmos/gogoproto/gogoproto"
 	grpc1 "github.com/cosmos/gogoproto/grpc"
 	proto "github.com/cosmos/gogoproto/proto"
-	_ "github.com/cosmos/gogoproto/types"
 	github_com_cosmos_gogoproto_types "github.com/cosmos/gogoproto/types"
+	types "github.com/cosmos/gogoproto/types"
+	_ "github.com/golang/protobuf/ptypes/duration"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -1574,7 +1575,6 @@ func (m *RequestFinalizeBlock) GetProposerAddress() []byte {
 
 type Response struct {
 	// Types that are valid to be assigned to Value:
-	//
 	//	*Response_Exception
 	//	*Response_Echo
 	//	*Response_Flush
@@ -1984,6 +1984,11 @@ type ResponseInfo struct {
 	AppVersion       uint64 `protobuf:"varint,3,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
 	LastBlockHeight  int64  `protobuf:"varint,4,opt,name=last_block_height,json=lastBlockHeight,proto3" json:"last_block_height,omitempty"`
 	LastBlockAppHash []byte `protobuf:"bytes,5,opt,name=last_block_app_hash,json=lastBlockAppHash,proto3" json:"last_block_app_hash,omitempty"`
+	// optimistic_execution is true if the application supports FinalizeBlock
+	// being called again at a height before Commit, for the same or another
+	// block, discarding the state resulting from the previous call. The
+	// consensus.optimistic_execution option of the node is ignored otherwise.
+	OptimisticExecution bool `protobuf:"varint,6,opt,name=optimistic_execution,json=optimisticExecution,proto3" json:"optimistic_execution,omitempty"`
 }
 
 func (m *ResponseInfo) Reset()         { *m = ResponseInfo{} }
@@ -2054,6 +2059,13 @@ func (m *ResponseInfo) GetLastBlockAppHash() []byte {
 	return nil
 }
 
+func (m *ResponseInfo) GetOptimisticExecution() bool {
+	if m != nil {
+		return m.OptimisticExecution
+	}
+	return false
+}
+
 type ResponseInitChain struct {
 	ConsensusParams *types1.ConsensusParams `protobuf:"bytes,1,opt,name=consensus_params,json=consensusParams,proto3" json:"consensus_params,omitempty"`
 	Validators      []ValidatorUpdate       `protobuf:"bytes,2,rep,name=validators,proto3" json:"validators"`
@@ -2232,6 +2244,36 @@ type ResponseCheckTx struct {
 	GasUsed   int64   `protobuf:"varint,6,opt,name=gas_used,proto3" json:"gas_used,omitempty"`
 	Events    []Event `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
 	Codespace string  `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	// Sender of the transaction, e.g. its signer. The mempool reaps the
+	// transactions of the same sender in increasing order of sequence. Empty
+	// means the transaction is not ordered with respect to any other.
+	Sender string `protobuf:"bytes,19,opt,name=sender,proto3" json:"sender,omitempty"`
+	// Priority of the transaction, used by the "priority" mempool to order the
+	// transactions and to evict the lowest-priority ones when full.
+	Priority int64 `protobuf:"varint,18,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Class of the transaction, used by the mempool to apply per-class quotas
+	// and ordering weights. Empty means the default class.
+	Class string `protobuf:"bytes,12,opt,name=class,proto3" json:"class,omitempty"`
+	// Sequence number of the transaction among those of its sender, e.g. the
+	// account nonce. Ignored if sender is empty.
+	Sequence uint64 `protobuf:"varint,13,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// Number of blocks after which the transaction expires and is evicted from
+	// the mempool if it was not included. 0 means the node's default. The
+	// node's default, if set, caps the value.
+	TTLNumBlocks int64 `protobuf:"varint,14,opt,name=ttl_num_blocks,json=ttlNumBlocks,proto3" json:"ttl_num_blocks,omitempty"`
+	// Duration after which the transaction expires and is evicted from the
+	// mempool if it was not included. 0 means the node's default. The node's
+	// default, if set, caps the value.
+	TTLDuration time.Duration `protobuf:"bytes,15,opt,name=ttl_duration,json=ttlDuration,proto3,stdduration" json:"ttl_duration"`
+	// Number of blocks during which the node drops, without adding them to the
+	// mempool, the transactions of the sender of this transaction. 0 means the
+	// sender is not banned for a number of blocks. Ignored if the sender is not
+	// set.
+	BanSenderNumBlocks int64 `protobuf:"varint,16,opt,name=ban_sender_num_blocks,json=banSenderNumBlocks,proto3" json:"ban_sender_num_blocks,omitempty"`
+	// Duration during which the node drops, without adding them to the mempool,
+	// the transactions of the sender of this transaction. 0 means the sender is
+	// not banned for a duration. Ignored if the sender is not set.
+	BanSenderDuration time.Duration `protobuf:"bytes,17,opt,name=ban_sender_duration,json=banSenderDuration,proto3,stdduration" json:"ban_sender_duration"`
 }
 
 func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
@@ -2323,6 +2365,62 @@ func (m *ResponseCheckTx) GetCodespace() string {
 	return ""
 }
 
+func (m *ResponseCheckTx) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *ResponseCheckTx) GetPriority() int64 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetClass() string {
+	if m != nil {
+		return m.Class
+	}
+	return ""
+}
+
+func (m *ResponseCheckTx) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetTTLNumBlocks() int64 {
+	if m != nil {
+		return m.TTLNumBlocks
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetTTLDuration() time.Duration {
+	if m != nil {
+		return m.TTLDuration
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetBanSenderNumBlocks() int64 {
+	if m != nil {
+		return m.BanSenderNumBlocks
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetBanSenderDuration() time.Duration {
+	if m != nil {
+		return m.BanSenderDuration
+	}
+	return 0
+}
+
 type ResponseCommit struct {
 	RetainHeight int64 `protobuf:"varint,3,opt,name=retain_height,json=retainHeight,proto3" json:"retain_height,omitempty"`
 }
@@ -2561,6 +2659,9 @@ func (m *ResponseApplySnapshotChunk) GetRejectSenders() []string {
 
 type ResponsePrepareProposal struct {
 	Txs [][]byte `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	// the total gas wanted by txs; the proposal is not made if it exceeds the
+	// max gas of a block. The max gas is not enforced if it is not set.
+	GasWanted *types.Int64Value `protobuf:"bytes,2,opt,name=gas_wanted,json=gasWanted,proto3" json:"gas_wanted,omitempty"`
 }
 
 func (m *ResponsePrepareProposal) Reset()         { *m = ResponsePrepareProposal{} }
@@ -2603,8 +2704,19 @@ func (m *ResponsePrepareProposal) GetTxs() [][]byte {
 	return nil
 }
 
+func (m *ResponsePrepareProposal) GetGasWanted() *types.Int64Value {
+	if m != nil {
+		return m.GasWanted
+	}
+	return nil
+}
+
 type ResponseProcessProposal struct {
 	Status ResponseProcessProposal_ProposalStatus `protobuf:"varint,1,opt,name=status,proto3,enum=tendermint.abci.ResponseProcessProposal_ProposalStatus" json:"status,omitempty"`
+	// the total gas wanted by the transactions of the block; the block is
+	// rejected if it exceeds the max gas of a block. The max gas is not enforced
+	// if it is not set.
+	GasWanted *types.Int64Value `protobuf:"bytes,2,opt,name=gas_wanted,json=gasWanted,proto3" json:"gas_wanted,omitempty"`
 }
 
 func (m *ResponseProcessProposal) Reset()         { *m = ResponseProcessProposal{} }
@@ -2647,6 +2759,13 @@ func (m *ResponseProcessProposal) GetStatus() ResponseProcessProposal_ProposalSt
 	return ResponseProcessProposal_UNKNOWN
 }
 
+func (m *ResponseProcessProposal) GetGasWanted() *types.Int64Value {
+	if m != nil {
+		return m.GasWanted
+	}
+	return nil
+}
+
 type ResponseExtendVote struct {
 	VoteExtension []byte `protobuf:"bytes,1,opt,name=vote_extension,json=voteExtension,proto3" json:"vote_extension,omitempty"`
 }
@@ -3664,205 +3783,220 @@ func init() {
 func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
 
 var fileDescriptor_252557cfdd89a31a = []byte{
-	// 3167 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0xcb, 0x73, 0x23, 0xd5,
-	0xd5, 0x57, 0xeb, 0xad, 0xa3, 0x87, 0xdb, 0xd7, 0x9e, 0x41, 0x23, 0x06, 0xdb, 0x34, 0x05, 0x0c,
-	0x03, 0xd8, 0x7c, 0x9e, 0x6f, 0x78, 0xd4, 0xc0, 0x57, 0x25, 0x6b, 0x34, 0x9f, 0xec, 0x31, 0xb6,
-	0x69, 0xcb, 0x43, 0x91, 0x07, 0x4d, 0x5b, 0xba, 0xb2, 0x9a, 0x91, 0xd4, 0x4d, 0xf7, 0x95, 0x91,
-	0x59, 0xa5, 0x42, 0x52, 0x95, 0x62, 0x45, 0x55, 0xb2, 0x60, 0x11, 0x16, 0x59, 0x64, 0x93, 0xbf,
-	0x20, 0xab, 0x64, 0x93, 0x05, 0x8b, 0x2c, 0x58, 0x66, 0x45, 0x52, 0xb0, 0x63, 0x9b, 0x45, 0xb6,
-	0xa9, 0xfb, 0xe8, 0x97, 0xa4, 0xb6, 0xa4, 0x81, 0x2c, 0x52, 0xc9, 0xae, 0xef, 0xe9, 0x73, 0xce,
-	0xed, 0x7b, 0xee, 0xb9, 0xe7, 0xf1, 0xeb, 0x0b, 0x8f, 0x13, 0x3c, 0x68, 0x63, 0xbb, 0x6f, 0x0c,
-	0xc8, 0x96, 0x7e, 0xda, 0x32, 0xb6, 0xc8, 0x85, 0x85, 0x9d, 0x4d, 0xcb, 0x36, 0x89, 0x89, 0x96,
-	0xfc, 0x97, 0x9b, 0xf4, 0x65, 0xe5, 0x89, 0x00, 0x77, 0xcb, 0xbe, 0xb0, 0x88, 0xb9, 0x65, 0xd9,
-	0xa6, 0xd9, 0xe1, 0xfc, 0x95, 0xeb, 0x93, 0xaf, 0x1f, 0xe2, 0x0b, 0xa1, 0x2d, 0x24, 0xcc, 0x66,
-	0xd9, 0xb2, 0x74, 0x5b, 0xef, 0xbb, 0xaf, 0x37, 0x26, 0x5e, 0x9f, 0xeb, 0x3d, 0xa3, 0xad, 0x13,
-	0xd3, 0x16, 0x1c, 0xeb, 0x67, 0xa6, 0x79, 0xd6, 0xc3, 0x5b, 0x6c, 0x74, 0x3a, 0xec, 0x6c, 0x11,
-	0xa3, 0x8f, 0x1d, 0xa2, 0xf7, 0x2d, 0xc1, 0xb0, 0x7a, 0x66, 0x9e, 0x99, 0xec, 0x71, 0x8b, 0x3e,
-	0x71, 0xaa, 0xf2, 0xc7, 0x1c, 0x64, 0x54, 0xfc, 0xc1, 0x10, 0x3b, 0x04, 0x6d, 0x43, 0x12, 0xb7,
-	0xba, 0x66, 0x59, 0xda, 0x90, 0x6e, 0xe4, 0xb7, 0xaf, 0x6f, 0x8e, 0x2d, 0x70, 0x53, 0xf0, 0xd5,
-	0x5b, 0x5d, 0xb3, 0x11, 0x53, 0x19, 0x2f, 0xba, 0x0d, 0xa9, 0x4e, 0x6f, 0xe8, 0x74, 0xcb, 0x71,
-	0x26, 0xf4, 0x44, 0x94, 0xd0, 0x3d, 0xca, 0xd4, 0x88, 0xa9, 0x9c, 0x9b, 0x4e, 0x65, 0x0c, 0x3a,
-	0x66, 0x39, 0x71, 0xf9, 0x54, 0xbb, 0x83, 0x0e, 0x9b, 0x8a, 0xf2, 0xa2, 0x1d, 0x00, 0x63, 0x60,
-	0x10, 0xad, 0xd5, 0xd5, 0x8d, 0x41, 0x39, 0xc5, 0x24, 0x9f, 0x8c, 0x96, 0x34, 0x48, 0x8d, 0x32,
-	0x36, 0x62, 0x6a, 0xce, 0x70, 0x07, 0xf4, 0x73, 0x3f, 0x18, 0x62, 0xfb, 0xa2, 0x9c, 0xbe, 0xfc,
-	0x73, 0xdf, 0xa2, 0x4c, 0xf4, 0x73, 0x19, 0x37, 0x7a, 0x1d, 0xb2, 0xad, 0x2e, 0x6e, 0x3d, 0xd4,
-	0xc8, 0xa8, 0x9c, 0x65, 0x92, 0xeb, 0x51, 0x92, 0x35, 0xca, 0xd7, 0x1c, 0x35, 0x62, 0x6a, 0xa6,
-	0xc5, 0x1f, 0xd1, 0xab, 0x90, 0x6e, 0x99, 0xfd, 0xbe, 0x41, 0xca, 0x79, 0x26, 0xbb, 0x16, 0x29,
-	0xcb, 0xb8, 0x1a, 0x31, 0x55, 0xf0, 0xa3, 0x03, 0x28, 0xf5, 0x0c, 0x87, 0x68, 0xce, 0x40, 0xb7,
-	0x9c, 0xae, 0x49, 0x9c, 0x72, 0x81, 0x69, 0x78, 0x3a, 0x4a, 0xc3, 0xbe, 0xe1, 0x90, 0x63, 0x97,
-	0xb9, 0x11, 0x53, 0x8b, 0xbd, 0x20, 0x81, 0xea, 0x33, 0x3b, 0x1d, 0x6c, 0x7b, 0x0a, 0xcb, 0xc5,
-	0xcb, 0xf5, 0x1d, 0x52, 0x6e, 0x57, 0x9e, 0xea, 0x33, 0x83, 0x04, 0xf4, 0x43, 0x58, 0xe9, 0x99,
-	0x7a, 0xdb, 0x53, 0xa7, 0xb5, 0xba, 0xc3, 0xc1, 0xc3, 0x72, 0x89, 0x29, 0x7d, 0x2e, 0xf2, 0x23,
-	0x4d, 0xbd, 0xed, 0xaa, 0xa8, 0x51, 0x81, 0x46, 0x4c, 0x5d, 0xee, 0x8d, 0x13, 0xd1, 0xbb, 0xb0,
-	0xaa, 0x5b, 0x56, 0xef, 0x62, 0x5c, 0xfb, 0x12, 0xd3, 0x7e, 0x33, 0x4a, 0x7b, 0x95, 0xca, 0x8c,
-	0xab, 0x47, 0xfa, 0x04, 0x15, 0x35, 0x41, 0xb6, 0x6c, 0x6c, 0xe9, 0x36, 0xd6, 0x2c, 0xdb, 0xb4,
-	0x4c, 0x47, 0xef, 0x95, 0x65, 0xa6, 0xfb, 0xd9, 0x28, 0xdd, 0x47, 0x9c, 0xff, 0x48, 0xb0, 0x37,
-	0x62, 0xea, 0x92, 0x15, 0x26, 0x71, 0xad, 0x66, 0x0b, 0x3b, 0x8e, 0xaf, 0x75, 0x79, 0x96, 0x56,
-	0xc6, 0x1f, 0xd6, 0x1a, 0x22, 0xa1, 0x3a, 0xe4, 0xf1, 0x88, 0x8a, 0x6b, 0xe7, 0x26, 0xc1, 0x65,
-	0xc4, 0x14, 0x2a, 0x91, 0x27, 0x94, 0xb1, 0x3e, 0x30, 0x09, 0x6e, 0xc4, 0x54, 0xc0, 0xde, 0x08,
-	0xe9, 0x70, 0xe5, 0x1c, 0xdb, 0x46, 0xe7, 0x82, 0xa9, 0xd1, 0xd8, 0x1b, 0xc7, 0x30, 0x07, 0xe5,
-	0x15, 0xa6, 0xf0, 0xf9, 0x28, 0x85, 0x0f, 0x98, 0x10, 0x55, 0x51, 0x77, 0x45, 0x1a, 0x31, 0x75,
-	0xe5, 0x7c, 0x92, 0x4c, 0x5d, 0xac, 0x63, 0x0c, 0xf4, 0x9e, 0xf1, 0x11, 0xd6, 0x4e, 0x7b, 0x66,
-	0xeb, 0x61, 0x79, 0xf5, 0x72, 0x17, 0xbb, 0x27, 0xb8, 0x77, 0x28, 0x33, 0x75, 0xb1, 0x4e, 0x90,
-	0xb0, 0x93, 0x81, 0xd4, 0xb9, 0xde, 0x1b, 0xe2, 0xbd, 0x64, 0x36, 0x29, 0xa7, 0xf6, 0x92, 0xd9,
-	0x8c, 0x9c, 0xdd, 0x4b, 0x66, 0x73, 0x32, 0xec, 0x25, 0xb3, 0x20, 0xe7, 0x95, 0x67, 0x21, 0x1f,
-	0x08, 0x4c, 0xa8, 0x0c, 0x99, 0x3e, 0x76, 0x1c, 0xfd, 0x0c, 0xb3, 0x38, 0x96, 0x53, 0xdd, 0xa1,
-	0x52, 0x82, 0x42, 0x30, 0x18, 0x29, 0x9f, 0x4a, 0x9e, 0x24, 0x8d, 0x33, 0x54, 0xf2, 0x1c, 0xdb,
-	0xcc, 0x1c, 0x42, 0x52, 0x0c, 0xd1, 0x53, 0x50, 0x64, 0x4b, 0xd1, 0xdc, 0xf7, 0x34, 0xd8, 0x25,
-	0xd5, 0x02, 0x23, 0x3e, 0x10, 0x4c, 0xeb, 0x90, 0xb7, 0xb6, 0x2d, 0x8f, 0x25, 0xc1, 0x58, 0xc0,
-	0xda, 0xb6, 0x5c, 0x86, 0x27, 0xa1, 0x40, 0xd7, 0xed, 0x71, 0x24, 0xd9, 0x24, 0x79, 0x4a, 0x13,
-	0x2c, 0xca, 0x9f, 0xe3, 0x20, 0x8f, 0x07, 0x30, 0xf4, 0x2a, 0x24, 0x69, 0x2c, 0x17, 0x61, 0xb9,
-	0xb2, 0xc9, 0x03, 0xfd, 0xa6, 0x1b, 0xe8, 0x37, 0x9b, 0x6e, 0xa0, 0xdf, 0xc9, 0x7e, 0xf1, 0xd5,
-	0x7a, 0xec, 0xd3, 0xbf, 0xae, 0x4b, 0x2a, 0x93, 0x40, 0xd7, 0x68, 0xd8, 0xd2, 0x8d, 0x81, 0x66,
-	0xb4, 0xd9, 0x27, 0xe7, 0x68, 0x4c, 0xd2, 0x8d, 0xc1, 0x6e, 0x1b, 0xed, 0x83, 0xdc, 0x32, 0x07,
-	0x0e, 0x1e, 0x38, 0x43, 0x47, 0xe3, 0xa9, 0x46, 0x04, 0xe3, 0x50, 0x48, 0xe5, 0x09, 0xaf, 0xe6,
-	0x72, 0x1e, 0x31, 0x46, 0x75, 0xa9, 0x15, 0x26, 0xa0, 0x7b, 0x00, 0x5e, 0x3e, 0x72, 0xca, 0xc9,
-	0x8d, 0xc4, 0x8d, 0xfc, 0xf6, 0xc6, 0xc4, 0x86, 0x3f, 0x70, 0x59, 0x4e, 0xac, 0xb6, 0x4e, 0xf0,
-	0x4e, 0x92, 0x7e, 0xae, 0x1a, 0x90, 0x44, 0xcf, 0xc0, 0x92, 0x6e, 0x59, 0x9a, 0x43, 0x74, 0x82,
-	0xb5, 0xd3, 0x0b, 0x82, 0x1d, 0x16, 0xe7, 0x0b, 0x6a, 0x51, 0xb7, 0xac, 0x63, 0x4a, 0xdd, 0xa1,
-	0x44, 0xf4, 0x34, 0x94, 0x68, 0x4c, 0x37, 0xf4, 0x9e, 0xd6, 0xc5, 0xc6, 0x59, 0x97, 0xb0, 0x78,
-	0x9e, 0x50, 0x8b, 0x82, 0xda, 0x60, 0x44, 0xa5, 0xed, 0xed, 0x38, 0x8b, 0xe7, 0x08, 0x41, 0xb2,
-	0xad, 0x13, 0x9d, 0x59, 0xb2, 0xa0, 0xb2, 0x67, 0x4a, 0xb3, 0x74, 0xd2, 0x15, 0xf6, 0x61, 0xcf,
-	0xe8, 0x2a, 0xa4, 0x85, 0xda, 0x04, 0x53, 0x2b, 0x46, 0x68, 0x15, 0x52, 0x96, 0x6d, 0x9e, 0x63,
-	0xb6, 0x75, 0x59, 0x95, 0x0f, 0x14, 0x15, 0x4a, 0xe1, 0xd8, 0x8f, 0x4a, 0x10, 0x27, 0x23, 0x31,
-	0x4b, 0x9c, 0x8c, 0xd0, 0x4b, 0x90, 0xa4, 0x86, 0x64, 0x73, 0x94, 0xa6, 0x64, 0x3b, 0x21, 0xd7,
-	0xbc, 0xb0, 0xb0, 0xca, 0x38, 0x95, 0x25, 0x28, 0x86, 0x72, 0x82, 0x72, 0x15, 0x56, 0xa7, 0x85,
-	0x78, 0xa5, 0xeb, 0xd1, 0x43, 0xa1, 0x1a, 0xdd, 0x86, 0xac, 0x17, 0xe3, 0xb9, 0xe3, 0x5c, 0x9b,
-	0x98, 0xd6, 0x65, 0x56, 0x3d, 0x56, 0xea, 0x31, 0x74, 0x03, 0xba, 0xba, 0xc8, 0xe8, 0x05, 0x35,
-	0xa3, 0x5b, 0x56, 0x43, 0x77, 0xba, 0xca, 0x7b, 0x50, 0x8e, 0x8a, 0xdf, 0x01, 0x83, 0x49, 0xcc,
-	0xed, 0x5d, 0x83, 0x5d, 0x85, 0x74, 0xc7, 0xb4, 0xfb, 0x3a, 0x61, 0xca, 0x8a, 0xaa, 0x18, 0x51,
-	0x43, 0xf2, 0x58, 0x9e, 0x60, 0x64, 0x3e, 0x50, 0x34, 0xb8, 0x16, 0x19, 0xc3, 0xa9, 0x88, 0x31,
-	0x68, 0x63, 0x6e, 0xd6, 0xa2, 0xca, 0x07, 0xbe, 0x22, 0xfe, 0xb1, 0x7c, 0x40, 0xa7, 0x75, 0xd8,
-	0x5a, 0x99, 0xfe, 0x9c, 0x2a, 0x46, 0xca, 0x67, 0x09, 0xb8, 0x3a, 0x3d, 0x92, 0xa3, 0x0d, 0x28,
-	0xf4, 0xf5, 0x91, 0x46, 0x46, 0xc2, 0xed, 0x24, 0xb6, 0xf1, 0xd0, 0xd7, 0x47, 0xcd, 0x11, 0xf7,
-	0x39, 0x19, 0x12, 0x64, 0xe4, 0x94, 0xe3, 0x1b, 0x89, 0x1b, 0x05, 0x95, 0x3e, 0xa2, 0x13, 0x58,
-	0xee, 0x99, 0x2d, 0xbd, 0xa7, 0xf5, 0x74, 0x87, 0x68, 0x22, 0xc5, 0xf3, 0x43, 0xf4, 0xd4, 0x84,
-	0xb1, 0x79, 0x4c, 0xc6, 0x6d, 0xbe, 0x9f, 0x34, 0xe0, 0x08, 0xff, 0x5f, 0x62, 0x3a, 0xf6, 0x75,
-	0x77, 0xab, 0xd1, 0x5d, 0xc8, 0xf7, 0x0d, 0xe7, 0x14, 0x77, 0xf5, 0x73, 0xc3, 0xb4, 0xc5, 0x69,
-	0x9a, 0x74, 0x9a, 0x37, 0x7d, 0x1e, 0xa1, 0x29, 0x28, 0x16, 0xd8, 0x92, 0x54, 0xc8, 0x87, 0xdd,
-	0x68, 0x92, 0x5e, 0x38, 0x9a, 0xbc, 0x04, 0xab, 0x03, 0x3c, 0x22, 0x9a, 0x7f, 0x5e, 0xb9, 0x9f,
-	0x64, 0x98, 0xe9, 0x11, 0x7d, 0xe7, 0x9d, 0x70, 0x87, 0xba, 0x0c, 0x7a, 0x8e, 0xe5, 0x42, 0xcb,
-	0x74, 0xb0, 0xad, 0xe9, 0xed, 0xb6, 0x8d, 0x1d, 0x87, 0x95, 0x4f, 0x05, 0x96, 0xe0, 0x18, 0xbd,
-	0xca, 0xc9, 0xca, 0x2f, 0x82, 0x5b, 0x13, 0xce, 0x7d, 0xc2, 0xf0, 0x92, 0x6f, 0xf8, 0x63, 0x58,
-	0x15, 0xf2, 0xed, 0x90, 0xed, 0x79, 0x0d, 0xfa, 0xf8, 0xe4, 0xf9, 0x1a, 0xb7, 0x39, 0x72, 0xc5,
-	0xa3, 0xcd, 0x9e, 0x78, 0x34, 0xb3, 0x23, 0x48, 0x32, 0xa3, 0x24, 0x79, 0x88, 0xa1, 0xcf, 0xff,
-	0x6e, 0x5b, 0xf1, 0x71, 0x02, 0x96, 0x27, 0x0a, 0x09, 0x6f, 0x61, 0xd2, 0xd4, 0x85, 0xc5, 0xa7,
-	0x2e, 0x2c, 0xb1, 0xf0, 0xc2, 0xc4, 0x5e, 0x27, 0x67, 0xef, 0x75, 0xea, 0x7b, 0xdc, 0xeb, 0xf4,
-	0xa3, 0xed, 0xf5, 0xbf, 0x74, 0x17, 0x7e, 0x2d, 0x41, 0x25, 0xba, 0xfa, 0x9a, 0xba, 0x1d, 0xcf,
-	0xc3, 0xb2, 0xf7, 0x29, 0x9e, 0x7a, 0x1e, 0x18, 0x65, 0xef, 0x85, 0xd0, 0x1f, 0x99, 0xe3, 0x9e,
-	0x86, 0xd2, 0x58, 0x6d, 0xc8, 0x5d, 0xb9, 0x78, 0x1e, 0x9c, 0x5f, 0xf9, 0x59, 0xc2, 0x4b, 0x3c,
-	0xa1, 0x02, 0x6e, 0xca, 0x69, 0x7d, 0x0b, 0x56, 0xda, 0xb8, 0x65, 0xb4, 0x1f, 0xf5, 0xb0, 0x2e,
-	0x0b, 0xe9, 0xff, 0x9e, 0xd5, 0x49, 0x2f, 0xf9, 0x15, 0x40, 0x56, 0xc5, 0x8e, 0x45, 0xeb, 0x31,
-	0xb4, 0x03, 0x39, 0x3c, 0x6a, 0x61, 0x8b, 0xb8, 0x25, 0xec, 0xf4, 0x16, 0x81, 0x73, 0xd7, 0x5d,
-	0x4e, 0xda, 0x20, 0x7b, 0x62, 0xe8, 0x96, 0xc0, 0x00, 0xa2, 0xdb, 0x79, 0x21, 0x1e, 0x04, 0x01,
-	0x5e, 0x76, 0x41, 0x80, 0x44, 0x64, 0x7f, 0xcb, 0xa5, 0xc6, 0x50, 0x80, 0x5b, 0x02, 0x05, 0x48,
-	0xce, 0x98, 0x2c, 0x04, 0x03, 0xd4, 0x42, 0x30, 0x40, 0x7a, 0xc6, 0x32, 0x23, 0x70, 0x80, 0x97,
-	0x5d, 0x1c, 0x20, 0x33, 0xe3, 0x8b, 0xc7, 0x80, 0x80, 0x37, 0x02, 0x40, 0x40, 0x8e, 0x89, 0x6e,
-	0x44, 0x8a, 0x4e, 0x41, 0x02, 0x5e, 0xf3, 0x90, 0x80, 0x42, 0x24, 0x8a, 0x20, 0x84, 0xc7, 0xa1,
-	0x80, 0xc3, 0x09, 0x28, 0x80, 0xb7, 0xee, 0xcf, 0x44, 0xaa, 0x98, 0x81, 0x05, 0x1c, 0x4e, 0x60,
-	0x01, 0xa5, 0x19, 0x0a, 0x67, 0x80, 0x01, 0x3f, 0x9a, 0x0e, 0x06, 0x44, 0xb7, 0xeb, 0xe2, 0x33,
-	0xe7, 0x43, 0x03, 0xb4, 0x08, 0x34, 0x40, 0x8e, 0xec, 0x5c, 0xb9, 0xfa, 0xb9, 0xe1, 0x80, 0x93,
-	0x29, 0x70, 0x00, 0x6f, 0xdc, 0x6f, 0x44, 0x2a, 0x9f, 0x03, 0x0f, 0x38, 0x99, 0x82, 0x07, 0xa0,
-	0x99, 0x6a, 0x67, 0x02, 0x02, 0xf7, 0xc2, 0x80, 0xc0, 0x4a, 0x44, 0xd5, 0xe9, 0x9f, 0xf6, 0x08,
-	0x44, 0xe0, 0x34, 0x0a, 0x11, 0xe0, 0x5d, 0xfb, 0x0b, 0x91, 0x1a, 0x17, 0x80, 0x04, 0x0e, 0x27,
-	0x20, 0x81, 0x2b, 0x33, 0x3c, 0x6d, 0x7e, 0x4c, 0x20, 0x25, 0xa7, 0xf7, 0x92, 0xd9, 0xac, 0x9c,
-	0xe3, 0x68, 0xc0, 0x5e, 0x32, 0x9b, 0x97, 0x0b, 0xca, 0x73, 0xb4, 0x82, 0x19, 0x8b, 0x73, 0xb4,
-	0x57, 0xc0, 0xb6, 0x6d, 0xda, 0xa2, 0xbb, 0xe7, 0x03, 0xe5, 0x06, 0xed, 0x11, 0xfd, 0x98, 0x76,
-	0x09, 0x7e, 0xc0, 0x7a, 0xb2, 0x40, 0x1c, 0x53, 0x7e, 0x2f, 0xf9, 0xb2, 0x0c, 0x41, 0x08, 0xf6,
-	0x97, 0x39, 0xd1, 0x5f, 0x06, 0x50, 0x85, 0x78, 0x18, 0x55, 0x58, 0x87, 0x3c, 0xed, 0xb5, 0xc6,
-	0x00, 0x03, 0xdd, 0xf2, 0x00, 0x83, 0x9b, 0xb0, 0xcc, 0x12, 0x26, 0xc7, 0x1e, 0x44, 0x5a, 0x4a,
-	0xb2, 0xb4, 0xb4, 0x44, 0x5f, 0x70, 0xeb, 0xf0, 0xfc, 0xf4, 0x22, 0xac, 0x04, 0x78, 0xbd, 0x1e,
-	0x8e, 0x77, 0xcf, 0xb2, 0xc7, 0x5d, 0x15, 0xcd, 0xdc, 0x9f, 0x24, 0xdf, 0x42, 0x3e, 0xd2, 0x30,
-	0x0d, 0x14, 0x90, 0xbe, 0x27, 0x50, 0x20, 0xfe, 0xc8, 0xa0, 0x40, 0xb0, 0x27, 0x4d, 0x84, 0x7b,
-	0xd2, 0x7f, 0x48, 0xfe, 0x9e, 0x78, 0x2d, 0x7e, 0xcb, 0x6c, 0x63, 0xd1, 0x25, 0xb2, 0x67, 0x5a,
-	0x92, 0xf4, 0xcc, 0x33, 0xd1, 0x0b, 0xd2, 0x47, 0xca, 0xe5, 0x25, 0x9e, 0x9c, 0xc8, 0x2b, 0x5e,
-	0x83, 0xc9, 0x13, 0xbf, 0x68, 0x30, 0x65, 0x48, 0x3c, 0xc4, 0x1c, 0x2e, 0x2e, 0xa8, 0xf4, 0x91,
-	0xf2, 0x31, 0xe7, 0x13, 0x09, 0x9c, 0x0f, 0xd0, 0xab, 0x90, 0x63, 0x60, 0xbf, 0x66, 0x5a, 0x8e,
-	0x80, 0x88, 0x43, 0xa5, 0x0d, 0x47, 0xfc, 0x37, 0x8f, 0x28, 0xcf, 0xa1, 0xe5, 0xa8, 0x59, 0x4b,
-	0x3c, 0x05, 0x2a, 0x8e, 0x5c, 0xa8, 0xe2, 0xb8, 0x0e, 0x39, 0xfa, 0xf5, 0x8e, 0xa5, 0xb7, 0x70,
-	0x19, 0xd8, 0x87, 0xfa, 0x04, 0xe5, 0x77, 0x71, 0x58, 0x1a, 0x4b, 0x34, 0x53, 0xd7, 0xee, 0xba,
-	0x64, 0x3c, 0x00, 0x79, 0xcc, 0x67, 0x8f, 0x35, 0x80, 0x33, 0xdd, 0xd1, 0x3e, 0xd4, 0x07, 0x04,
-	0xb7, 0x85, 0x51, 0x02, 0x14, 0x54, 0x81, 0x2c, 0x1d, 0x0d, 0x1d, 0xdc, 0x16, 0xe8, 0x8b, 0x37,
-	0x46, 0x0d, 0x48, 0xe3, 0x73, 0x3c, 0x20, 0x4e, 0x39, 0xc3, 0xb6, 0xfd, 0xea, 0x64, 0x3b, 0x4c,
-	0x5f, 0xef, 0x94, 0xe9, 0x66, 0x7f, 0xfb, 0xd5, 0xba, 0xcc, 0xb9, 0x5f, 0x30, 0xfb, 0x06, 0xc1,
-	0x7d, 0x8b, 0x5c, 0xa8, 0x42, 0x3e, 0x6c, 0x85, 0xec, 0x98, 0x15, 0x18, 0x0e, 0x58, 0x70, 0xdb,
-	0x7b, 0x6a, 0x53, 0xc3, 0xb4, 0x0d, 0x72, 0xa1, 0x16, 0xfb, 0xb8, 0x6f, 0x99, 0x66, 0x4f, 0xe3,
-	0x67, 0xbc, 0x0a, 0xa5, 0x70, 0x5e, 0x45, 0x4f, 0x41, 0xd1, 0xc6, 0x44, 0x37, 0x06, 0x5a, 0xa8,
-	0x08, 0x2e, 0x70, 0x22, 0x3f, 0x53, 0x7b, 0xc9, 0xac, 0x24, 0xc7, 0xf7, 0x92, 0xd9, 0xb8, 0x9c,
-	0x50, 0x8e, 0xe0, 0xca, 0xd4, 0xbc, 0x8a, 0x5e, 0x81, 0x9c, 0x9f, 0x92, 0x25, 0xb6, 0xda, 0x4b,
-	0x90, 0x16, 0x9f, 0x57, 0xf9, 0x83, 0xe4, 0xab, 0x0c, 0x63, 0x37, 0x75, 0x48, 0xdb, 0xd8, 0x19,
-	0xf6, 0x38, 0x9a, 0x52, 0xda, 0x7e, 0x71, 0xbe, 0x8c, 0x4c, 0xa9, 0xc3, 0x1e, 0x51, 0x85, 0xb0,
-	0xf2, 0x2e, 0xa4, 0x39, 0x05, 0xe5, 0x21, 0x73, 0x72, 0x70, 0xff, 0xe0, 0xf0, 0xed, 0x03, 0x39,
-	0x86, 0x00, 0xd2, 0xd5, 0x5a, 0xad, 0x7e, 0xd4, 0x94, 0x25, 0x94, 0x83, 0x54, 0x75, 0xe7, 0x50,
-	0x6d, 0xca, 0x71, 0x4a, 0x56, 0xeb, 0x7b, 0xf5, 0x5a, 0x53, 0x4e, 0xa0, 0x65, 0x28, 0xf2, 0x67,
-	0xed, 0xde, 0xa1, 0xfa, 0x66, 0xb5, 0x29, 0x27, 0x03, 0xa4, 0xe3, 0xfa, 0xc1, 0xdd, 0xba, 0x2a,
-	0xa7, 0x94, 0xff, 0x81, 0x6b, 0x91, 0x39, 0xdc, 0x07, 0x66, 0xa4, 0x00, 0x30, 0xa3, 0x7c, 0x16,
-	0xa7, 0x4d, 0x4d, 0x54, 0x62, 0x46, 0x7b, 0x63, 0x0b, 0xdf, 0x5e, 0x20, 0xab, 0x8f, 0xad, 0x9e,
-	0xf6, 0x31, 0x36, 0xee, 0x60, 0xd2, 0xea, 0xf2, 0x42, 0x81, 0x47, 0xa0, 0xa2, 0x5a, 0x14, 0x54,
-	0x26, 0xe4, 0x70, 0xb6, 0xf7, 0x71, 0x8b, 0x68, 0xdc, 0x89, 0x1c, 0xd6, 0x4c, 0xe4, 0x28, 0x1b,
-	0xa5, 0x1e, 0x73, 0xa2, 0xf2, 0xde, 0x42, 0xb6, 0xcc, 0x41, 0x4a, 0xad, 0x37, 0xd5, 0x77, 0xe4,
-	0x04, 0x42, 0x50, 0x62, 0x8f, 0xda, 0xf1, 0x41, 0xf5, 0xe8, 0xb8, 0x71, 0x48, 0x6d, 0xb9, 0x02,
-	0x4b, 0xae, 0x2d, 0x5d, 0x62, 0x4a, 0x79, 0x1e, 0x1e, 0x8b, 0xa8, 0x2a, 0x26, 0x5b, 0x2a, 0xe5,
-	0x37, 0x52, 0x90, 0x3b, 0x5c, 0x19, 0x1c, 0x42, 0xda, 0x21, 0x3a, 0x19, 0x3a, 0xc2, 0x88, 0xaf,
-	0xcc, 0x5b, 0x66, 0x6c, 0xba, 0x0f, 0xc7, 0x4c, 0x5c, 0x15, 0x6a, 0x94, 0xdb, 0x50, 0x0a, 0xbf,
-	0x89, 0xb6, 0x81, 0xef, 0x44, 0x71, 0xe5, 0x0e, 0xa0, 0xc9, 0xea, 0x63, 0x4a, 0x7b, 0x29, 0x4d,
-	0x6b, 0x2f, 0x7f, 0x2b, 0xc1, 0xe3, 0x97, 0x54, 0x1a, 0xe8, 0xad, 0xb1, 0x45, 0xbe, 0xb6, 0x48,
-	0x9d, 0xb2, 0xc9, 0x69, 0x63, 0xcb, 0xbc, 0x05, 0x85, 0x20, 0x7d, 0xbe, 0x45, 0x7e, 0x1b, 0xf7,
-	0x0f, 0x71, 0xb8, 0x0f, 0xf6, 0x43, 0xa0, 0xf4, 0x1d, 0x43, 0xe0, 0xeb, 0x00, 0x64, 0xa4, 0x71,
-	0xb7, 0x76, 0xf3, 0xe8, 0x13, 0x53, 0xf0, 0x45, 0xdc, 0x6a, 0x8e, 0xc4, 0x21, 0xc8, 0x11, 0xf1,
-	0xe4, 0xa0, 0xe3, 0x20, 0x28, 0x30, 0x64, 0x39, 0xd6, 0x11, 0x0d, 0xf3, 0xbc, 0xc9, 0xd8, 0x07,
-	0x0f, 0x38, 0xd9, 0x41, 0xef, 0xc0, 0x63, 0x63, 0x85, 0x82, 0xa7, 0x3a, 0x39, 0x6f, 0xbd, 0x70,
-	0x25, 0x5c, 0x2f, 0xb8, 0xaa, 0x83, 0xd9, 0x3e, 0x15, 0xce, 0xf6, 0xef, 0x00, 0xf8, 0xe0, 0x00,
-	0x8d, 0x30, 0xb6, 0x39, 0x1c, 0xb4, 0x99, 0x07, 0xa4, 0x54, 0x3e, 0x40, 0xb7, 0x21, 0x45, 0x3d,
-	0xc9, 0xb5, 0xd3, 0x64, 0x28, 0xa6, 0x9e, 0x10, 0x00, 0x17, 0x38, 0xb7, 0x62, 0x00, 0x9a, 0x04,
-	0x68, 0x23, 0xa6, 0x78, 0x23, 0x3c, 0xc5, 0x93, 0x91, 0x50, 0xef, 0xf4, 0xa9, 0x3e, 0x82, 0x14,
-	0xdb, 0x79, 0x9a, 0x74, 0xd9, 0x5f, 0x01, 0x51, 0x2d, 0xd2, 0x67, 0xf4, 0x63, 0x00, 0x9d, 0x10,
-	0xdb, 0x38, 0x1d, 0xfa, 0x13, 0xac, 0x4f, 0xf7, 0x9c, 0xaa, 0xcb, 0xb7, 0x73, 0x5d, 0xb8, 0xd0,
-	0xaa, 0x2f, 0x1a, 0x70, 0xa3, 0x80, 0x42, 0xe5, 0x00, 0x4a, 0x61, 0x59, 0xb7, 0xbe, 0xe1, 0xdf,
-	0x10, 0xae, 0x6f, 0x78, 0xb9, 0x2a, 0xea, 0x1b, 0xaf, 0x3a, 0x4a, 0xf0, 0x5f, 0x1f, 0x6c, 0xa0,
-	0xfc, 0x24, 0x0e, 0x85, 0xa0, 0xe3, 0xfd, 0xe7, 0x95, 0x20, 0xca, 0xcf, 0x25, 0xc8, 0x7a, 0xcb,
-	0x0f, 0xff, 0x07, 0x09, 0xfd, 0x38, 0xe2, 0xd6, 0x8b, 0x07, 0x7f, 0x5e, 0xf0, 0xdf, 0x44, 0x09,
-	0xef, 0x37, 0xd1, 0x1d, 0x2f, 0xfd, 0x45, 0x01, 0x22, 0x41, 0x5b, 0x0b, 0xaf, 0x72, 0xb3, 0xfd,
-	0x1d, 0xc8, 0x79, 0xa7, 0x97, 0x36, 0x1d, 0x2e, 0x70, 0x24, 0x89, 0x33, 0x24, 0x60, 0xbf, 0x55,
-	0x48, 0x59, 0xe6, 0x87, 0xe2, 0xcf, 0x48, 0x42, 0xe5, 0x03, 0xa5, 0x0d, 0x4b, 0x63, 0x47, 0x1f,
-	0xdd, 0x81, 0x8c, 0x35, 0x3c, 0xd5, 0x5c, 0xe7, 0x18, 0x83, 0xd7, 0xdc, 0x72, 0x76, 0x78, 0xda,
-	0x33, 0x5a, 0xf7, 0xf1, 0x85, 0xfb, 0x31, 0xd6, 0xf0, 0xf4, 0x3e, 0xf7, 0x21, 0x3e, 0x4b, 0x3c,
-	0x38, 0xcb, 0x2f, 0x25, 0xc8, 0xba, 0x67, 0x02, 0xfd, 0x1f, 0xe4, 0xbc, 0xb0, 0xe2, 0xfd, 0xda,
-	0x8c, 0x8c, 0x47, 0x42, 0xbf, 0x2f, 0x82, 0xaa, 0xee, 0x3f, 0x59, 0xa3, 0xad, 0x75, 0x7a, 0x3a,
-	0xf7, 0xa5, 0x52, 0xd8, 0x66, 0x3c, 0xf0, 0xb0, 0x78, 0xbc, 0x7b, 0xf7, 0x5e, 0x4f, 0x3f, 0x53,
-	0xf3, 0x4c, 0x66, 0xb7, 0x4d, 0x07, 0xa2, 0xb2, 0xfb, 0xbb, 0x04, 0xf2, 0xf8, 0x89, 0xfd, 0xce,
-	0x5f, 0x37, 0x99, 0xe6, 0x12, 0x53, 0xd2, 0x1c, 0xda, 0x82, 0x15, 0x8f, 0x43, 0x73, 0x8c, 0xb3,
-	0x81, 0x4e, 0x86, 0x36, 0x16, 0x80, 0x24, 0xf2, 0x5e, 0x1d, 0xbb, 0x6f, 0x26, 0x57, 0x9d, 0x7a,
-	0xc4, 0x55, 0x7f, 0x1c, 0x87, 0x7c, 0x00, 0x1e, 0x45, 0xff, 0x1b, 0x08, 0x46, 0xa5, 0x29, 0x99,
-	0x21, 0xc0, 0xeb, 0xff, 0xa6, 0x0c, 0x9b, 0x29, 0xbe, 0xb8, 0x99, 0xa2, 0x40, 0x68, 0x17, 0x6d,
-	0x4d, 0x2e, 0x8c, 0xb6, 0xbe, 0x00, 0x88, 0x98, 0x44, 0xef, 0x69, 0xe7, 0x26, 0x31, 0x06, 0x67,
-	0x1a, 0x77, 0x43, 0x1e, 0x3a, 0x64, 0xf6, 0xe6, 0x01, 0x7b, 0x71, 0xc4, 0x3c, 0xf2, 0xa7, 0x12,
-	0x64, 0xbd, 0xb2, 0x7b, 0xd1, 0x9f, 0x98, 0x57, 0x21, 0x2d, 0x2a, 0x4b, 0xfe, 0x17, 0x53, 0x8c,
-	0xa6, 0xc2, 0xca, 0x15, 0xc8, 0xf6, 0x31, 0xd1, 0x59, 0x1c, 0xe4, 0x59, 0xcd, 0x1b, 0xdf, 0x7c,
-	0x0d, 0xf2, 0x81, 0x1f, 0xc0, 0x34, 0x34, 0x1e, 0xd4, 0xdf, 0x96, 0x63, 0x95, 0xcc, 0x27, 0x9f,
-	0x6f, 0x24, 0x0e, 0xf0, 0x87, 0xf4, 0x34, 0xab, 0xf5, 0x5a, 0xa3, 0x5e, 0xbb, 0x2f, 0x4b, 0x95,
-	0xfc, 0x27, 0x9f, 0x6f, 0x64, 0x54, 0xcc, 0x10, 0xc5, 0x9b, 0xf7, 0x61, 0x69, 0x6c, 0x63, 0xc2,
-	0x65, 0x0b, 0x82, 0xd2, 0xdd, 0x93, 0xa3, 0xfd, 0xdd, 0x5a, 0xb5, 0x59, 0xd7, 0x1e, 0x1c, 0x36,
-	0xeb, 0xb2, 0x84, 0x1e, 0x83, 0x95, 0xfd, 0xdd, 0xff, 0x6f, 0x34, 0xb5, 0xda, 0xfe, 0x6e, 0xfd,
-	0xa0, 0xa9, 0x55, 0x9b, 0xcd, 0x6a, 0xed, 0xbe, 0x1c, 0xdf, 0xfe, 0x3c, 0x0f, 0xc9, 0xea, 0x4e,
-	0x6d, 0x17, 0xd5, 0x20, 0xc9, 0xa0, 0x90, 0x4b, 0x6f, 0x80, 0x55, 0x2e, 0xc7, 0x86, 0xd1, 0x3d,
-	0x48, 0x31, 0x94, 0x04, 0x5d, 0x7e, 0x25, 0xac, 0x32, 0x03, 0x2c, 0xa6, 0x1f, 0xc3, 0x4e, 0xe4,
-	0xa5, 0x77, 0xc4, 0x2a, 0x97, 0x63, 0xc7, 0x68, 0x1f, 0x32, 0x6e, 0x93, 0x3c, 0xeb, 0xe2, 0x56,
-	0x65, 0x26, 0xa0, 0x4b, 0x97, 0xc6, 0xc1, 0x86, 0xcb, 0xaf, 0x8f, 0x55, 0x66, 0xa0, 0xca, 0x68,
-	0x17, 0xd2, 0xa2, 0x1d, 0x9d, 0x71, 0x23, 0xac, 0x32, 0x0b, 0x27, 0x46, 0x2a, 0xe4, 0x7c, 0x18,
-	0x67, 0xf6, 0xa5, 0xb8, 0xca, 0x1c, 0x80, 0x39, 0x7a, 0x17, 0x8a, 0xe1, 0x56, 0x77, 0xbe, 0x5b,
-	0x67, 0x95, 0x39, 0x11, 0x69, 0xaa, 0x3f, 0xdc, 0xf7, 0xce, 0x77, 0x0b, 0xad, 0x32, 0x27, 0x40,
-	0x8d, 0xde, 0x87, 0xe5, 0xc9, 0xbe, 0x74, 0xfe, 0x4b, 0x69, 0x95, 0x05, 0x20, 0x6b, 0xd4, 0x07,
-	0x34, 0xa5, 0x9f, 0x5d, 0xe0, 0x8e, 0x5a, 0x65, 0x11, 0x04, 0x1b, 0xb5, 0x61, 0x69, 0xbc, 0x49,
-	0x9c, 0xf7, 0xce, 0x5a, 0x65, 0x6e, 0x34, 0x9b, 0xcf, 0x12, 0x6e, 0x2e, 0xe7, 0xbd, 0xc3, 0x56,
-	0x99, 0x1b, 0xdc, 0x46, 0x27, 0x00, 0x81, 0xfe, 0x70, 0x8e, 0x3b, 0x6d, 0x95, 0x79, 0x60, 0x6e,
-	0x64, 0xc1, 0xca, 0xb4, 0xc6, 0x71, 0x91, 0x2b, 0x6e, 0x95, 0x85, 0xd0, 0x6f, 0xea, 0xcf, 0xe1,
-	0x16, 0x70, 0xbe, 0x2b, 0x6f, 0x95, 0x39, 0x61, 0xf0, 0x9d, 0xea, 0x17, 0x5f, 0xaf, 0x49, 0x5f,
-	0x7e, 0xbd, 0x26, 0xfd, 0xed, 0xeb, 0x35, 0xe9, 0xd3, 0x6f, 0xd6, 0x62, 0x5f, 0x7e, 0xb3, 0x16,
-	0xfb, 0xcb, 0x37, 0x6b, 0xb1, 0x1f, 0x3c, 0x7b, 0x66, 0x90, 0xee, 0xf0, 0x74, 0xb3, 0x65, 0xf6,
-	0xb7, 0x5a, 0x66, 0x1f, 0x93, 0xd3, 0x0e, 0xf1, 0x1f, 0xfc, 0x9b, 0xcb, 0xa7, 0x69, 0x96, 0x41,
-	0x6f, 0xfd, 0x33, 0x00, 0x00, 0xff, 0xff, 0x64, 0xd0, 0x90, 0x6e, 0xd9, 0x2c, 0x00, 0x00,
+	// 3399 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0xcd, 0x73, 0x1b, 0xc7,
+	0xb1, 0xc7, 0xe2, 0x8b, 0x40, 0xe3, 0x83, 0xcb, 0x21, 0x25, 0x41, 0xb0, 0x4c, 0xd2, 0xeb, 0xb2,
+	0x2d, 0xcb, 0x36, 0x69, 0x53, 0xb6, 0x6c, 0x3f, 0xd9, 0xaf, 0x8a, 0x80, 0xa0, 0x07, 0x52, 0x34,
+	0x49, 0x2f, 0x41, 0xb9, 0xfc, 0x3e, 0xbc, 0x5e, 0x00, 0x43, 0x62, 0x2d, 0x00, 0xbb, 0xde, 0x1d,
+	0x50, 0xa0, 0x4f, 0xaf, 0x9e, 0x5f, 0xaa, 0x52, 0x3e, 0xb9, 0x2a, 0x39, 0xf8, 0x10, 0x1f, 0xf3,
+	0x6f, 0x24, 0x55, 0xa9, 0x1c, 0x7c, 0xc8, 0xc1, 0xc7, 0x5c, 0xa2, 0xa4, 0xe4, 0x9b, 0x73, 0xcc,
+	0x21, 0xd7, 0xd4, 0x7c, 0xec, 0x62, 0x17, 0xd8, 0x25, 0x00, 0xd9, 0x39, 0xa4, 0x92, 0xdb, 0x4e,
+	0x4f, 0x77, 0xcf, 0xec, 0x4c, 0x4f, 0x4f, 0xf7, 0xaf, 0x07, 0x9e, 0x22, 0xb8, 0xdf, 0xc6, 0x76,
+	0xcf, 0xe8, 0x93, 0x4d, 0xbd, 0xd9, 0x32, 0x36, 0xc9, 0xb9, 0x85, 0x9d, 0x0d, 0xcb, 0x36, 0x89,
+	0x89, 0x16, 0x47, 0x9d, 0x1b, 0xb4, 0xb3, 0xfc, 0xb4, 0x8f, 0xbb, 0x65, 0x9f, 0x5b, 0xc4, 0xdc,
+	0xb4, 0x6c, 0xd3, 0x3c, 0xe1, 0xfc, 0xe5, 0x6b, 0x93, 0xdd, 0x0f, 0xf0, 0xb9, 0xd0, 0x16, 0x10,
+	0x66, 0xa3, 0x6c, 0x5a, 0xba, 0xad, 0xf7, 0xdc, 0xee, 0xf5, 0x89, 0xee, 0x33, 0xbd, 0x6b, 0xb4,
+	0x75, 0x62, 0xda, 0x82, 0x63, 0xed, 0xd4, 0x34, 0x4f, 0xbb, 0x78, 0x93, 0xb5, 0x9a, 0x83, 0x93,
+	0x4d, 0x62, 0xf4, 0xb0, 0x43, 0xf4, 0x9e, 0x25, 0x18, 0x56, 0xc7, 0x19, 0xda, 0x03, 0x5b, 0x27,
+	0x86, 0xd9, 0x8f, 0xea, 0x7f, 0x68, 0xeb, 0x96, 0x85, 0x6d, 0x77, 0x0a, 0x2b, 0xa7, 0xe6, 0xa9,
+	0xc9, 0x3e, 0x37, 0xe9, 0x17, 0xa7, 0x2a, 0xbf, 0xce, 0xc2, 0x82, 0x8a, 0x3f, 0x1d, 0x60, 0x87,
+	0xa0, 0x2d, 0x48, 0xe2, 0x56, 0xc7, 0x2c, 0x49, 0xeb, 0xd2, 0xf5, 0xdc, 0xd6, 0xb5, 0x8d, 0xb1,
+	0x05, 0xda, 0x10, 0x7c, 0xb5, 0x56, 0xc7, 0xac, 0xc7, 0x54, 0xc6, 0x8b, 0xde, 0x80, 0xd4, 0x49,
+	0x77, 0xe0, 0x74, 0x4a, 0x71, 0x26, 0xf4, 0x74, 0x94, 0xd0, 0x5d, 0xca, 0x54, 0x8f, 0xa9, 0x9c,
+	0x9b, 0x0e, 0x65, 0xf4, 0x4f, 0xcc, 0x52, 0xe2, 0xe2, 0xa1, 0x76, 0xfa, 0x27, 0x6c, 0x28, 0xca,
+	0x8b, 0x2a, 0x00, 0x46, 0xdf, 0x20, 0x5a, 0xab, 0xa3, 0x1b, 0xfd, 0x52, 0x8a, 0x49, 0x3e, 0x13,
+	0x2d, 0x69, 0x90, 0x2a, 0x65, 0xac, 0xc7, 0xd4, 0xac, 0xe1, 0x36, 0xe8, 0x74, 0x3f, 0x1d, 0x60,
+	0xfb, 0xbc, 0x94, 0xbe, 0x78, 0xba, 0xef, 0x53, 0x26, 0x3a, 0x5d, 0xc6, 0x8d, 0xde, 0x81, 0x4c,
+	0xab, 0x83, 0x5b, 0x0f, 0x34, 0x32, 0x2c, 0x65, 0x98, 0xe4, 0x5a, 0x94, 0x64, 0x95, 0xf2, 0x35,
+	0x86, 0xf5, 0x98, 0xba, 0xd0, 0xe2, 0x9f, 0xe8, 0x2d, 0x48, 0xb7, 0xcc, 0x5e, 0xcf, 0x20, 0xa5,
+	0x1c, 0x93, 0x5d, 0x8d, 0x94, 0x65, 0x5c, 0xf5, 0x98, 0x2a, 0xf8, 0xd1, 0x3e, 0x14, 0xbb, 0x86,
+	0x43, 0x34, 0xa7, 0xaf, 0x5b, 0x4e, 0xc7, 0x24, 0x4e, 0x29, 0xcf, 0x34, 0x3c, 0x17, 0xa5, 0x61,
+	0xcf, 0x70, 0xc8, 0x91, 0xcb, 0x5c, 0x8f, 0xa9, 0x85, 0xae, 0x9f, 0x40, 0xf5, 0x99, 0x27, 0x27,
+	0xd8, 0xf6, 0x14, 0x96, 0x0a, 0x17, 0xeb, 0x3b, 0xa0, 0xdc, 0xae, 0x3c, 0xd5, 0x67, 0xfa, 0x09,
+	0xe8, 0xbf, 0x60, 0xb9, 0x6b, 0xea, 0x6d, 0x4f, 0x9d, 0xd6, 0xea, 0x0c, 0xfa, 0x0f, 0x4a, 0x45,
+	0xa6, 0xf4, 0xc5, 0xc8, 0x49, 0x9a, 0x7a, 0xdb, 0x55, 0x51, 0xa5, 0x02, 0xf5, 0x98, 0xba, 0xd4,
+	0x1d, 0x27, 0xa2, 0x8f, 0x60, 0x45, 0xb7, 0xac, 0xee, 0xf9, 0xb8, 0xf6, 0x45, 0xa6, 0xfd, 0x46,
+	0x94, 0xf6, 0x6d, 0x2a, 0x33, 0xae, 0x1e, 0xe9, 0x13, 0x54, 0xd4, 0x00, 0xd9, 0xb2, 0xb1, 0xa5,
+	0xdb, 0x58, 0xb3, 0x6c, 0xd3, 0x32, 0x1d, 0xbd, 0x5b, 0x92, 0x99, 0xee, 0x17, 0xa2, 0x74, 0x1f,
+	0x72, 0xfe, 0x43, 0xc1, 0x5e, 0x8f, 0xa9, 0x8b, 0x56, 0x90, 0xc4, 0xb5, 0x9a, 0x2d, 0xec, 0x38,
+	0x23, 0xad, 0x4b, 0xd3, 0xb4, 0x32, 0xfe, 0xa0, 0xd6, 0x00, 0x09, 0xd5, 0x20, 0x87, 0x87, 0x54,
+	0x5c, 0x3b, 0x33, 0x09, 0x2e, 0x21, 0xa6, 0x50, 0x89, 0x3c, 0xa1, 0x8c, 0xf5, 0xbe, 0x49, 0x70,
+	0x3d, 0xa6, 0x02, 0xf6, 0x5a, 0x48, 0x87, 0x4b, 0x67, 0xd8, 0x36, 0x4e, 0xce, 0x99, 0x1a, 0x8d,
+	0xf5, 0x38, 0x86, 0xd9, 0x2f, 0x2d, 0x33, 0x85, 0x2f, 0x45, 0x29, 0xbc, 0xcf, 0x84, 0xa8, 0x8a,
+	0x9a, 0x2b, 0x52, 0x8f, 0xa9, 0xcb, 0x67, 0x93, 0x64, 0x6a, 0x62, 0x27, 0x46, 0x5f, 0xef, 0x1a,
+	0x9f, 0x61, 0xad, 0xd9, 0x35, 0x5b, 0x0f, 0x4a, 0x2b, 0x17, 0x9b, 0xd8, 0x5d, 0xc1, 0x5d, 0xa1,
+	0xcc, 0xd4, 0xc4, 0x4e, 0xfc, 0x84, 0xca, 0x02, 0xa4, 0xce, 0xf4, 0xee, 0x00, 0xef, 0x26, 0x33,
+	0x49, 0x39, 0xb5, 0x9b, 0xcc, 0x2c, 0xc8, 0x99, 0xdd, 0x64, 0x26, 0x2b, 0xc3, 0x6e, 0x32, 0x03,
+	0x72, 0x4e, 0x79, 0x01, 0x72, 0x3e, 0xc7, 0x84, 0x4a, 0xb0, 0xd0, 0xc3, 0x8e, 0xa3, 0x9f, 0x62,
+	0xe6, 0xc7, 0xb2, 0xaa, 0xdb, 0x54, 0x8a, 0x90, 0xf7, 0x3b, 0x23, 0xe5, 0x4b, 0x09, 0x72, 0x3e,
+	0x3f, 0x43, 0x25, 0xcf, 0xb0, 0xcd, 0x96, 0x43, 0x48, 0x8a, 0x26, 0x7a, 0x16, 0x0a, 0xec, 0x57,
+	0x34, 0xb7, 0x9f, 0x3a, 0xbb, 0xa4, 0x9a, 0x67, 0xc4, 0xfb, 0x82, 0x69, 0x0d, 0x72, 0xd6, 0x96,
+	0xe5, 0xb1, 0x24, 0x18, 0x0b, 0x58, 0x5b, 0x96, 0xcb, 0xf0, 0x0c, 0xe4, 0xe9, 0x7f, 0x7b, 0x1c,
+	0x49, 0x36, 0x48, 0x8e, 0xd2, 0x04, 0x8b, 0xf2, 0xbb, 0x38, 0xc8, 0xe3, 0x0e, 0x0c, 0xbd, 0x05,
+	0x49, 0x7a, 0x17, 0x08, 0xb7, 0x5c, 0xde, 0xe0, 0x7e, 0x7e, 0xc3, 0xf5, 0xf3, 0x1b, 0x0d, 0xf7,
+	0xa2, 0xa8, 0x64, 0xbe, 0x79, 0xb4, 0x16, 0xfb, 0xf2, 0x8f, 0x6b, 0x92, 0xca, 0x24, 0xd0, 0x55,
+	0xea, 0xb6, 0x74, 0xa3, 0xaf, 0x19, 0x6d, 0x36, 0xe5, 0x2c, 0xf5, 0x49, 0xba, 0xd1, 0xdf, 0x69,
+	0xa3, 0x3d, 0x90, 0x5b, 0x66, 0xdf, 0xc1, 0x7d, 0x67, 0xe0, 0x68, 0xfc, 0xaa, 0x2a, 0x25, 0x26,
+	0x5d, 0x2a, 0xbf, 0x30, 0xab, 0x2e, 0xe7, 0x21, 0x63, 0x54, 0x17, 0x5b, 0x41, 0x02, 0xba, 0x0b,
+	0xe0, 0xdd, 0x67, 0x4e, 0x29, 0xb9, 0x9e, 0xb8, 0x9e, 0xdb, 0x5a, 0x9f, 0xd8, 0xf0, 0xfb, 0x2e,
+	0xcb, 0xb1, 0xd5, 0xd6, 0x09, 0xae, 0x24, 0xe9, 0x74, 0x55, 0x9f, 0x24, 0x7a, 0x1e, 0x16, 0x75,
+	0xcb, 0xd2, 0x1c, 0xa2, 0x13, 0xac, 0x35, 0xcf, 0x09, 0x76, 0x98, 0x9f, 0xcf, 0xab, 0x05, 0xdd,
+	0xb2, 0x8e, 0x28, 0xb5, 0x42, 0x89, 0xe8, 0x39, 0x28, 0x52, 0x9f, 0x6e, 0xe8, 0x5d, 0xad, 0x83,
+	0x8d, 0xd3, 0x0e, 0x61, 0xfe, 0x3c, 0xa1, 0x16, 0x04, 0xb5, 0xce, 0x88, 0x4a, 0x1b, 0xf2, 0x7e,
+	0x7f, 0x8e, 0x10, 0x24, 0xdb, 0x3a, 0xd1, 0xd9, 0x4a, 0xe6, 0x55, 0xf6, 0x4d, 0x69, 0x96, 0x4e,
+	0x3a, 0x62, 0x7d, 0xd8, 0x37, 0xba, 0x0c, 0x69, 0xa1, 0x36, 0xc1, 0xd4, 0x8a, 0x16, 0x5a, 0x81,
+	0x94, 0x65, 0x9b, 0x67, 0x98, 0x6d, 0x5d, 0x46, 0xe5, 0x0d, 0x45, 0x85, 0x62, 0xd0, 0xf7, 0xa3,
+	0x22, 0xc4, 0xc9, 0x50, 0x8c, 0x12, 0x27, 0x43, 0xf4, 0x2a, 0x24, 0xe9, 0x42, 0xb2, 0x31, 0x8a,
+	0x21, 0xb7, 0x9d, 0x90, 0x6b, 0x9c, 0x5b, 0x58, 0x65, 0x9c, 0xca, 0x22, 0x14, 0x02, 0x77, 0x82,
+	0x72, 0x19, 0x56, 0xc2, 0x5c, 0xbc, 0xd2, 0x81, 0x95, 0x30, 0x57, 0x8d, 0xde, 0x80, 0x8c, 0xe7,
+	0xe3, 0xb9, 0xe1, 0x5c, 0x9d, 0x18, 0xd6, 0x65, 0x56, 0x3d, 0x56, 0x6a, 0x31, 0x74, 0x03, 0x3a,
+	0xba, 0xb8, 0xd1, 0xf3, 0xea, 0x82, 0x6e, 0x59, 0x75, 0xdd, 0xe9, 0x28, 0x1f, 0x43, 0x29, 0xca,
+	0x7f, 0xfb, 0x16, 0x4c, 0x62, 0x66, 0x2f, 0x5a, 0x94, 0x7e, 0x62, 0xda, 0x3d, 0x9d, 0x30, 0x65,
+	0x05, 0x55, 0xb4, 0xe8, 0x42, 0x72, 0x5f, 0x9e, 0x60, 0x64, 0xde, 0x50, 0x34, 0xb8, 0x1a, 0xe9,
+	0xc3, 0xa9, 0x88, 0xd1, 0x6f, 0x63, 0xbe, 0xac, 0x05, 0x95, 0x37, 0x46, 0x8a, 0xf8, 0x64, 0x79,
+	0x83, 0x0e, 0xeb, 0xb0, 0x7f, 0x65, 0xfa, 0xb3, 0xaa, 0x68, 0x29, 0x5f, 0x25, 0xe0, 0x72, 0xb8,
+	0x27, 0x47, 0xeb, 0x90, 0xef, 0xe9, 0x43, 0x8d, 0x0c, 0x85, 0xd9, 0x49, 0x6c, 0xe3, 0xa1, 0xa7,
+	0x0f, 0x1b, 0x43, 0x6e, 0x73, 0x32, 0x24, 0xc8, 0xd0, 0x29, 0xc5, 0xd7, 0x13, 0xd7, 0xf3, 0x2a,
+	0xfd, 0x44, 0xc7, 0xb0, 0xd4, 0x35, 0x5b, 0x7a, 0x57, 0xeb, 0xea, 0x0e, 0xd1, 0xc4, 0x15, 0xcf,
+	0x0f, 0xd1, 0xb3, 0x13, 0x8b, 0xcd, 0x7d, 0x32, 0x6e, 0xf3, 0xfd, 0xa4, 0x0e, 0x47, 0xd8, 0xff,
+	0x22, 0xd3, 0xb1, 0xa7, 0xbb, 0x5b, 0x8d, 0xee, 0x40, 0xae, 0x67, 0x38, 0x4d, 0xdc, 0xd1, 0xcf,
+	0x0c, 0xd3, 0x16, 0xa7, 0x69, 0xd2, 0x68, 0xde, 0x1b, 0xf1, 0x08, 0x4d, 0x7e, 0x31, 0xdf, 0x96,
+	0xa4, 0x02, 0x36, 0xec, 0x7a, 0x93, 0xf4, 0xdc, 0xde, 0xe4, 0x55, 0x58, 0xe9, 0xe3, 0x21, 0xd1,
+	0x46, 0xe7, 0x95, 0xdb, 0xc9, 0x02, 0x5b, 0x7a, 0x44, 0xfb, 0xbc, 0x13, 0xee, 0x50, 0x93, 0x41,
+	0x2f, 0xb2, 0xbb, 0xd0, 0x32, 0x1d, 0x6c, 0x6b, 0x7a, 0xbb, 0x6d, 0x63, 0xc7, 0x61, 0xe1, 0x53,
+	0x5e, 0x5d, 0x74, 0xe9, 0xdb, 0x9c, 0xac, 0xfc, 0xd4, 0xbf, 0x35, 0xc1, 0xbb, 0x4f, 0x2c, 0xbc,
+	0x34, 0x5a, 0xf8, 0x23, 0x58, 0x11, 0xf2, 0xed, 0xc0, 0xda, 0xf3, 0x18, 0xf4, 0xa9, 0xc9, 0xf3,
+	0x35, 0xbe, 0xe6, 0xc8, 0x15, 0x8f, 0x5e, 0xf6, 0xc4, 0x93, 0x2d, 0x3b, 0x82, 0x24, 0x5b, 0x94,
+	0x24, 0x77, 0x31, 0xf4, 0xfb, 0x1f, 0x6d, 0x2b, 0x3e, 0x4f, 0xc0, 0xd2, 0x44, 0x20, 0xe1, 0xfd,
+	0x98, 0x14, 0xfa, 0x63, 0xf1, 0xd0, 0x1f, 0x4b, 0xcc, 0xfd, 0x63, 0x62, 0xaf, 0x93, 0xd3, 0xf7,
+	0x3a, 0xf5, 0x23, 0xee, 0x75, 0xfa, 0xc9, 0xf6, 0xfa, 0xef, 0xba, 0x0b, 0xbf, 0x90, 0xa0, 0x1c,
+	0x1d, 0x7d, 0x85, 0x6e, 0xc7, 0x4b, 0xb0, 0xe4, 0x4d, 0xc5, 0x53, 0xcf, 0x1d, 0xa3, 0xec, 0x75,
+	0x08, 0xfd, 0x91, 0x77, 0xdc, 0x73, 0x50, 0x1c, 0x8b, 0x0d, 0xb9, 0x29, 0x17, 0xce, 0xfc, 0xe3,
+	0x2b, 0xff, 0x9f, 0x80, 0x95, 0xb0, 0x00, 0x2e, 0xe4, 0xb4, 0xbe, 0x0f, 0xcb, 0x6d, 0xdc, 0x32,
+	0xda, 0x4f, 0x7a, 0x58, 0x97, 0x84, 0xf4, 0xbf, 0xce, 0xea, 0xa4, 0x95, 0xfc, 0x1c, 0x20, 0xa3,
+	0x62, 0xc7, 0x32, 0xfb, 0x0e, 0x46, 0x15, 0xc8, 0xe2, 0x61, 0x0b, 0x5b, 0xc4, 0x0d, 0x61, 0xc3,
+	0x53, 0x04, 0xce, 0x5d, 0x73, 0x39, 0x69, 0x82, 0xec, 0x89, 0xa1, 0x9b, 0x02, 0x03, 0x88, 0x4e,
+	0xe7, 0x85, 0xb8, 0x1f, 0x04, 0xb8, 0xe5, 0x82, 0x00, 0x89, 0xc8, 0xfc, 0x96, 0x4b, 0x8d, 0xa1,
+	0x00, 0x37, 0x05, 0x0a, 0x90, 0x9c, 0x32, 0x58, 0x00, 0x06, 0xa8, 0x06, 0x60, 0x80, 0xf4, 0x94,
+	0xdf, 0x8c, 0xc0, 0x01, 0x6e, 0xb9, 0x38, 0xc0, 0xc2, 0x94, 0x19, 0x8f, 0x01, 0x01, 0xef, 0xfa,
+	0x80, 0x80, 0xec, 0xba, 0x14, 0x1a, 0xe6, 0xba, 0xa2, 0x21, 0x48, 0xc0, 0xdb, 0x1e, 0x12, 0x90,
+	0x8f, 0x44, 0x11, 0x84, 0xf0, 0x38, 0x14, 0x70, 0x30, 0x01, 0x05, 0xf0, 0xd4, 0xfd, 0xf9, 0x48,
+	0x15, 0x53, 0xb0, 0x80, 0x83, 0x09, 0x2c, 0xa0, 0x38, 0x45, 0xe1, 0x14, 0x30, 0xe0, 0xbf, 0xc3,
+	0xc1, 0x80, 0xe8, 0x74, 0x5d, 0x4c, 0x73, 0x36, 0x34, 0x40, 0x8b, 0x40, 0x03, 0xe4, 0xc8, 0xcc,
+	0x95, 0xab, 0x9f, 0x19, 0x0e, 0x38, 0x0e, 0x81, 0x03, 0x78, 0xe2, 0x7e, 0x3d, 0x52, 0xf9, 0x0c,
+	0x78, 0xc0, 0x71, 0x08, 0x1e, 0x80, 0xa6, 0xaa, 0x9d, 0x0a, 0x08, 0xdc, 0x0d, 0x02, 0x02, 0xcb,
+	0x11, 0x51, 0xe7, 0xe8, 0xb4, 0x47, 0x20, 0x02, 0xcd, 0x28, 0x44, 0x80, 0x67, 0xed, 0x2f, 0x47,
+	0x6a, 0x9c, 0x03, 0x12, 0x38, 0x98, 0x80, 0x04, 0x2e, 0x4d, 0xb1, 0xb4, 0xd9, 0x31, 0x81, 0x94,
+	0x9c, 0xde, 0x4d, 0x66, 0x32, 0x72, 0x96, 0xa3, 0x01, 0xbb, 0xc9, 0x4c, 0x4e, 0xce, 0x2b, 0x2f,
+	0xc2, 0x92, 0xab, 0xca, 0xf3, 0x73, 0x34, 0x57, 0xc0, 0xb6, 0x6d, 0xda, 0x22, 0xbb, 0xe7, 0x0d,
+	0xe5, 0x3a, 0xe4, 0x3d, 0xd6, 0x8b, 0xf1, 0x03, 0x96, 0x93, 0xf9, 0xfc, 0x98, 0xf2, 0x67, 0x09,
+	0xf2, 0x7e, 0x17, 0x15, 0xc8, 0x2f, 0xb3, 0x22, 0xbf, 0xf4, 0xa1, 0x0a, 0xf1, 0x20, 0xaa, 0xb0,
+	0x06, 0x39, 0x9a, 0x6b, 0x8d, 0x01, 0x06, 0xba, 0xe5, 0x01, 0x06, 0x37, 0x60, 0x89, 0x5d, 0x98,
+	0x1c, 0x7b, 0x10, 0xd7, 0x52, 0x92, 0x5d, 0x4b, 0x8b, 0xb4, 0x83, 0xaf, 0x0e, 0x23, 0xa3, 0x57,
+	0x60, 0xd9, 0xc7, 0xeb, 0xe5, 0x70, 0x3c, 0x7b, 0x96, 0x3d, 0xee, 0x6d, 0x9e, 0xcc, 0xa1, 0xd7,
+	0x60, 0xc5, 0xb4, 0x88, 0xd1, 0x33, 0x1c, 0x62, 0xb4, 0x34, 0x3c, 0xc4, 0xad, 0x01, 0xbb, 0x35,
+	0xd2, 0x2c, 0xb1, 0x5d, 0x1e, 0xf5, 0xd5, 0xdc, 0x2e, 0xe5, 0xb7, 0x12, 0x2c, 0x4d, 0x78, 0xd5,
+	0x50, 0x1c, 0x41, 0xfa, 0x91, 0x70, 0x84, 0xf8, 0x13, 0xe3, 0x08, 0xfe, 0x34, 0x36, 0x11, 0x4c,
+	0x63, 0xff, 0x2a, 0x41, 0x21, 0xe0, 0xdc, 0xe9, 0xae, 0xb5, 0xcc, 0x36, 0x16, 0x89, 0x25, 0xfb,
+	0xa6, 0x51, 0x4c, 0xd7, 0x3c, 0x15, 0xe9, 0x23, 0xfd, 0xa4, 0x5c, 0xde, 0x5d, 0x95, 0x15, 0x57,
+	0x91, 0x97, 0x93, 0xf2, 0x58, 0x81, 0x37, 0xa8, 0xec, 0x03, 0xcc, 0x11, 0xe6, 0xbc, 0x4a, 0x3f,
+	0xd1, 0x8a, 0xb0, 0x57, 0x71, 0xe7, 0xf3, 0x06, 0x7a, 0x0b, 0xb2, 0xac, 0xbe, 0xa0, 0x99, 0x96,
+	0x53, 0xca, 0x4c, 0x46, 0x43, 0xbc, 0xc8, 0xb0, 0x71, 0x48, 0x79, 0x0e, 0x2c, 0x47, 0xcd, 0x58,
+	0xe2, 0xcb, 0x17, 0xa4, 0x64, 0x03, 0x41, 0xca, 0x35, 0xc8, 0xd2, 0xd9, 0x3b, 0x96, 0xde, 0xc2,
+	0x25, 0x60, 0x13, 0x1d, 0x11, 0x94, 0xdf, 0xa4, 0x60, 0x71, 0xec, 0x6e, 0x0a, 0xfd, 0x77, 0xd7,
+	0x8a, 0xe3, 0x3e, 0x94, 0x64, 0xb6, 0xf5, 0x58, 0x05, 0x38, 0xd5, 0x1d, 0xed, 0xa1, 0xde, 0x27,
+	0xb8, 0x2d, 0x16, 0xc5, 0x47, 0x41, 0x65, 0xc8, 0xd0, 0xd6, 0xc0, 0xc1, 0x6d, 0x01, 0xd8, 0x78,
+	0x6d, 0x54, 0x87, 0x34, 0x3e, 0xc3, 0x7d, 0xe2, 0x94, 0x16, 0xd8, 0xb6, 0x5f, 0x9e, 0xcc, 0xa0,
+	0x69, 0x77, 0xa5, 0x44, 0x37, 0xfb, 0xfb, 0x47, 0x6b, 0x32, 0xe7, 0x7e, 0xd9, 0xec, 0x19, 0x04,
+	0xf7, 0x2c, 0x72, 0xae, 0x0a, 0xf9, 0xe0, 0x2a, 0x64, 0xc6, 0x56, 0xc1, 0x87, 0x0d, 0x2c, 0xfb,
+	0xb1, 0x01, 0x3a, 0x37, 0xcb, 0x36, 0x4c, 0xdb, 0x20, 0xe7, 0xcc, 0x3f, 0x27, 0x54, 0xaf, 0xcd,
+	0x50, 0x86, 0xae, 0xee, 0x70, 0xf4, 0x3d, 0xab, 0xf2, 0x06, 0x95, 0x70, 0x68, 0x04, 0xdc, 0x6f,
+	0x61, 0x76, 0x17, 0x27, 0x55, 0xaf, 0x8d, 0x6e, 0x41, 0x91, 0x90, 0xae, 0xd6, 0x1f, 0xf4, 0xf8,
+	0x89, 0x74, 0xd8, 0xe5, 0x9a, 0xa8, 0xc8, 0x8f, 0x1f, 0xad, 0xe5, 0x1b, 0x8d, 0xbd, 0xfd, 0x41,
+	0x8f, 0x9d, 0x47, 0x47, 0xcd, 0x13, 0xd2, 0xf5, 0x5a, 0xe8, 0x18, 0x68, 0x5b, 0x73, 0x4b, 0x3b,
+	0xe2, 0xf2, 0xbc, 0x3a, 0x11, 0x6e, 0xde, 0x11, 0x0c, 0x95, 0x2b, 0x74, 0x39, 0x1e, 0x3f, 0x5a,
+	0xcb, 0x35, 0x1a, 0x7b, 0x2e, 0xf1, 0x2b, 0x1a, 0x7c, 0xe6, 0x08, 0xe9, 0xba, 0x04, 0xb4, 0x03,
+	0x97, 0x9a, 0x7a, 0x5f, 0xe3, 0xbf, 0xea, 0x9f, 0x95, 0xcc, 0x66, 0x75, 0xf9, 0xf1, 0xa3, 0x35,
+	0x54, 0xd1, 0xfb, 0x47, 0xac, 0x7f, 0x34, 0x37, 0xd4, 0x9c, 0xa0, 0xa1, 0x0e, 0x2c, 0xfb, 0x54,
+	0x79, 0x13, 0x5d, 0x9a, 0x36, 0xd1, 0xa7, 0xc5, 0x44, 0x97, 0xbc, 0x71, 0x02, 0xd3, 0x5d, 0x6a,
+	0x8e, 0x93, 0x19, 0xc8, 0x9b, 0x57, 0x0b, 0x3d, 0xdc, 0xb3, 0x4c, 0xb3, 0xab, 0x71, 0x77, 0xbd,
+	0x0d, 0x45, 0xcf, 0x86, 0x79, 0x60, 0xf4, 0x2c, 0x14, 0x6c, 0x4c, 0x28, 0xca, 0x19, 0xc8, 0x67,
+	0xf2, 0x9c, 0xc8, 0xdd, 0xe3, 0x6e, 0x32, 0x23, 0xc9, 0xf1, 0xdd, 0x64, 0x26, 0x2e, 0x27, 0x94,
+	0x43, 0xb8, 0x14, 0x1a, 0x22, 0xa1, 0x37, 0x21, 0x3b, 0x8a, 0xae, 0xa4, 0xf5, 0xc4, 0xc5, 0xa0,
+	0xd9, 0x88, 0x57, 0xf9, 0x95, 0x04, 0x97, 0x42, 0x83, 0x24, 0x54, 0x83, 0xb4, 0x8d, 0x9d, 0x41,
+	0x97, 0x03, 0x63, 0xc5, 0xad, 0x57, 0x66, 0x0b, 0xae, 0x28, 0x75, 0xd0, 0x25, 0xaa, 0x10, 0x56,
+	0x3e, 0x82, 0x34, 0xa7, 0xa0, 0x1c, 0x2c, 0x1c, 0xef, 0xdf, 0xdb, 0x3f, 0xf8, 0x60, 0x5f, 0x8e,
+	0x21, 0x80, 0xf4, 0x76, 0xb5, 0x5a, 0x3b, 0x6c, 0xc8, 0x12, 0xca, 0x42, 0x6a, 0xbb, 0x72, 0xa0,
+	0x36, 0xe4, 0x38, 0x25, 0xab, 0xb5, 0xdd, 0x5a, 0xb5, 0x21, 0x27, 0xd0, 0x12, 0x14, 0xf8, 0xb7,
+	0x76, 0xf7, 0x40, 0x7d, 0x6f, 0xbb, 0x21, 0x27, 0x7d, 0xa4, 0xa3, 0xda, 0xfe, 0x9d, 0x9a, 0x2a,
+	0xa7, 0x94, 0xd7, 0xe0, 0xaa, 0x3b, 0x8f, 0x49, 0x70, 0xcf, 0xc3, 0xd8, 0x24, 0x1f, 0xc6, 0xa6,
+	0x7c, 0x15, 0x87, 0xb2, 0x2b, 0x13, 0x02, 0xd7, 0xed, 0x8e, 0xfd, 0xf8, 0xd6, 0x1c, 0x01, 0xda,
+	0xd8, 0xdf, 0xd3, 0x94, 0xd4, 0xc6, 0x27, 0x98, 0xb4, 0x3a, 0x3c, 0xe6, 0xe3, 0x37, 0x43, 0x41,
+	0x2d, 0x08, 0x2a, 0x13, 0x72, 0x38, 0xdb, 0x27, 0xb8, 0x45, 0x84, 0x71, 0x3a, 0x2c, 0x2f, 0xcc,
+	0xaa, 0x05, 0x4e, 0xe5, 0xd6, 0xe5, 0x28, 0x1f, 0xcf, 0xb5, 0x96, 0x59, 0x48, 0xa9, 0xb5, 0x86,
+	0xfa, 0xa1, 0x9c, 0x40, 0x08, 0x8a, 0xec, 0x53, 0x3b, 0xda, 0xdf, 0x3e, 0x3c, 0xaa, 0x1f, 0xd0,
+	0xb5, 0x5c, 0x86, 0x45, 0x77, 0x2d, 0x5d, 0x62, 0x4a, 0x39, 0x85, 0x2b, 0x11, 0x01, 0x62, 0x48,
+	0x76, 0xfc, 0x6f, 0x01, 0x9f, 0xe9, 0x26, 0xc5, 0xe3, 0xc7, 0x68, 0xa7, 0x4f, 0x6e, 0xbd, 0x7e,
+	0x9f, 0x5e, 0x1b, 0x6a, 0xf6, 0x54, 0x77, 0x3e, 0x60, 0xdc, 0xca, 0x1f, 0x24, 0xff, 0x48, 0xc1,
+	0x00, 0xf1, 0x00, 0xd2, 0x0e, 0xd1, 0xc9, 0xc0, 0x11, 0x1b, 0xf0, 0xe6, 0xac, 0xd1, 0xe6, 0x86,
+	0xfb, 0x71, 0xc4, 0xc4, 0x55, 0xa1, 0xe6, 0x07, 0x4d, 0xf4, 0x0d, 0x28, 0x06, 0xb5, 0x46, 0xaf,
+	0xfd, 0xc8, 0x78, 0xe3, 0xca, 0x6d, 0x40, 0x93, 0x01, 0x6c, 0x08, 0x42, 0x21, 0x85, 0x21, 0x14,
+	0xbf, 0x94, 0xe0, 0xa9, 0x0b, 0x82, 0x55, 0xf4, 0xfe, 0xd8, 0x02, 0xbd, 0x3d, 0x4f, 0xa8, 0xbb,
+	0xc1, 0x69, 0xc1, 0x25, 0x52, 0x6e, 0x42, 0xde, 0x4f, 0x9f, 0xed, 0x27, 0xbf, 0x8f, 0xc3, 0xa5,
+	0xd0, 0xb8, 0xd7, 0x77, 0x25, 0x4a, 0x3f, 0xf0, 0x4a, 0x7c, 0x07, 0x80, 0x0c, 0x35, 0x7e, 0x9c,
+	0xdc, 0xb8, 0x6a, 0x32, 0xdd, 0xa6, 0xb1, 0x5e, 0x63, 0x28, 0x0e, 0x5f, 0x96, 0x88, 0x2f, 0x0a,
+	0xc1, 0xf9, 0x70, 0xa5, 0x01, 0x8b, 0xb9, 0x9c, 0x52, 0x62, 0xae, 0xe0, 0x4c, 0x3e, 0x0b, 0x92,
+	0x1d, 0xf4, 0x21, 0x5c, 0x19, 0x0b, 0x1c, 0x3d, 0xd5, 0xc9, 0x59, 0xe3, 0xc7, 0x4b, 0xc1, 0xf8,
+	0xd1, 0x55, 0xed, 0x8f, 0xfe, 0x52, 0xc1, 0xe8, 0xef, 0x43, 0x80, 0x11, 0xbe, 0x44, 0x3d, 0x9b,
+	0x6d, 0x0e, 0xfa, 0x6d, 0x66, 0x01, 0x29, 0x95, 0x37, 0xe8, 0x1b, 0x01, 0x6a, 0x49, 0xee, 0x3a,
+	0x4d, 0x5e, 0x01, 0xd4, 0x12, 0x7c, 0xf8, 0x14, 0xe7, 0x56, 0x0c, 0x40, 0x93, 0x18, 0x7f, 0xc4,
+	0x10, 0xef, 0x06, 0x87, 0x78, 0x26, 0xb2, 0x5a, 0x10, 0x3e, 0xd4, 0x67, 0x90, 0x62, 0x3b, 0x4f,
+	0x83, 0x30, 0x56, 0x58, 0x12, 0x09, 0x07, 0xfd, 0x46, 0xff, 0x03, 0xa0, 0x13, 0x62, 0x1b, 0xcd,
+	0xc1, 0x68, 0x80, 0xb5, 0x70, 0xcb, 0xd9, 0x76, 0xf9, 0x2a, 0xd7, 0x84, 0x09, 0xad, 0x8c, 0x44,
+	0x7d, 0x66, 0xe4, 0x53, 0xa8, 0xec, 0x43, 0x31, 0x28, 0xeb, 0xc6, 0xbb, 0x7c, 0x0e, 0xc1, 0x78,
+	0x97, 0x67, 0x3c, 0xbc, 0x31, 0x8a, 0x96, 0x13, 0xbc, 0x7a, 0xc6, 0x1a, 0xca, 0xff, 0xc6, 0x21,
+	0xef, 0x37, 0xbc, 0x7f, 0xbe, 0x90, 0x54, 0xf9, 0x89, 0x04, 0x19, 0xef, 0xf7, 0x83, 0xa5, 0xb4,
+	0x40, 0xed, 0x91, 0xaf, 0x5e, 0xdc, 0x5f, 0xff, 0xe2, 0x95, 0xc6, 0x84, 0x57, 0x69, 0xbc, 0xed,
+	0x5d, 0xbb, 0x51, 0x98, 0x9a, 0x7f, 0xad, 0x85, 0x55, 0xb9, 0x51, 0xc6, 0x6d, 0xc8, 0x7a, 0xa7,
+	0x97, 0xe6, 0xad, 0x2e, 0xf6, 0x28, 0x89, 0x33, 0xc4, 0x9b, 0x74, 0x26, 0x96, 0xf9, 0x50, 0x14,
+	0xd7, 0x12, 0x2a, 0x6f, 0x28, 0x6d, 0x58, 0x1c, 0x3b, 0xfa, 0xe8, 0x36, 0x2c, 0x58, 0x83, 0xa6,
+	0xe6, 0x1a, 0xc7, 0x18, 0x42, 0xeb, 0xa6, 0x37, 0x83, 0x66, 0xd7, 0x68, 0xdd, 0xc3, 0xe7, 0xee,
+	0x64, 0xac, 0x41, 0xf3, 0x1e, 0xb7, 0x21, 0x3e, 0x4a, 0xdc, 0x3f, 0xca, 0xcf, 0x24, 0xc8, 0xb8,
+	0x67, 0x02, 0xfd, 0x3b, 0x64, 0x3d, 0xb7, 0xe2, 0x55, 0xc7, 0x23, 0xfd, 0x91, 0xd0, 0x3f, 0x12,
+	0x41, 0xdb, 0x6e, 0x59, 0xdf, 0x68, 0x6b, 0x27, 0x5d, 0x9d, 0xdb, 0x52, 0x31, 0xb8, 0x66, 0xdc,
+	0xf1, 0x30, 0x7f, 0xbc, 0x73, 0xe7, 0x6e, 0x57, 0x3f, 0x55, 0x73, 0x4c, 0x66, 0xa7, 0x4d, 0x1b,
+	0x22, 0xa2, 0xfc, 0x8b, 0x04, 0xf2, 0xf8, 0x89, 0xfd, 0xc1, 0xb3, 0x9b, 0xbc, 0xe6, 0x12, 0x21,
+	0xd7, 0x1c, 0xda, 0x84, 0x65, 0x8f, 0x43, 0x73, 0x8c, 0xd3, 0xbe, 0x4e, 0x06, 0x36, 0x16, 0x98,
+	0x36, 0xf2, 0xba, 0x8e, 0xdc, 0x9e, 0xc9, 0xbf, 0x4e, 0x3d, 0xe1, 0x5f, 0x7f, 0x1e, 0x87, 0x9c,
+	0x0f, 0x61, 0x47, 0xaf, 0xfb, 0x9c, 0x51, 0x31, 0xe4, 0x66, 0xf0, 0xf1, 0x8e, 0x2a, 0xdd, 0xc1,
+	0x65, 0x8a, 0xcf, 0xbf, 0x4c, 0x51, 0x75, 0x0c, 0x17, 0xb0, 0x4f, 0xce, 0x0d, 0xd8, 0xbf, 0x0c,
+	0x88, 0x98, 0x44, 0xef, 0x52, 0x44, 0xcc, 0xe8, 0x9f, 0x6a, 0xdc, 0x0c, 0xb9, 0xeb, 0x90, 0x59,
+	0xcf, 0x7d, 0xd6, 0x71, 0xc8, 0x2c, 0xf2, 0xff, 0x24, 0xc8, 0x78, 0xe1, 0xfe, 0xbc, 0x75, 0xf0,
+	0xcb, 0x90, 0x16, 0x11, 0x2d, 0x2f, 0x84, 0x8b, 0x56, 0x68, 0x65, 0xa2, 0x0c, 0x99, 0x1e, 0x26,
+	0x3a, 0xf3, 0x83, 0xfc, 0x56, 0xf3, 0xda, 0x37, 0xde, 0x86, 0x9c, 0xef, 0x0d, 0x01, 0x75, 0x8d,
+	0xfb, 0xb5, 0x0f, 0xe4, 0x58, 0x79, 0xe1, 0x8b, 0xaf, 0xd7, 0x13, 0xfb, 0xf8, 0x21, 0x3d, 0xcd,
+	0x6a, 0xad, 0x5a, 0xaf, 0x55, 0xef, 0xc9, 0x52, 0x39, 0xf7, 0xc5, 0xd7, 0xeb, 0x0b, 0x2a, 0x66,
+	0xa0, 0xf4, 0x8d, 0x7b, 0xb0, 0x38, 0xb6, 0x31, 0xc1, 0xb0, 0x05, 0x41, 0xf1, 0xce, 0xf1, 0xe1,
+	0xde, 0x4e, 0x75, 0xbb, 0x51, 0xd3, 0xee, 0x1f, 0x34, 0x6a, 0xb2, 0x84, 0xae, 0xc0, 0xf2, 0xde,
+	0xce, 0x7f, 0xd4, 0x1b, 0x5a, 0x75, 0x6f, 0xa7, 0xb6, 0xdf, 0xd0, 0xb6, 0x1b, 0x8d, 0xed, 0xea,
+	0x3d, 0x39, 0xbe, 0xf5, 0x75, 0x0e, 0x92, 0xdb, 0x95, 0xea, 0x0e, 0xaa, 0x42, 0x92, 0xa1, 0x69,
+	0x17, 0x3e, 0x22, 0x2c, 0x5f, 0x5c, 0x5e, 0x40, 0x77, 0x21, 0xc5, 0x80, 0x36, 0x74, 0xf1, 0xab,
+	0xc2, 0xf2, 0x94, 0x7a, 0x03, 0x9d, 0x0c, 0x3b, 0x91, 0x17, 0x3e, 0x33, 0x2c, 0x5f, 0x5c, 0x7e,
+	0x40, 0x7b, 0xb0, 0xe0, 0x82, 0x26, 0xd3, 0xde, 0xfe, 0x95, 0xa7, 0xd6, 0x04, 0xe8, 0xaf, 0x71,
+	0xf0, 0xe9, 0xe2, 0x17, 0x88, 0xe5, 0x29, 0x85, 0x09, 0xb4, 0x03, 0x69, 0x91, 0x06, 0x4f, 0x79,
+	0x54, 0x58, 0x9e, 0x56, 0x6a, 0x40, 0x2a, 0x64, 0x47, 0xb0, 0xde, 0xf4, 0x77, 0x95, 0xe5, 0x19,
+	0x6a, 0x2e, 0xe8, 0x23, 0x28, 0x04, 0x53, 0xec, 0xd9, 0x1e, 0x2e, 0x96, 0x67, 0x2c, 0x6a, 0x50,
+	0xfd, 0xc1, 0x7c, 0x7b, 0xb6, 0x87, 0x8c, 0xe5, 0x19, 0x6b, 0x1c, 0xe8, 0x13, 0x58, 0x9a, 0xcc,
+	0x87, 0x67, 0x7f, 0xd7, 0x58, 0x9e, 0xa3, 0xea, 0x81, 0x7a, 0x80, 0x42, 0xf2, 0xe8, 0x39, 0x9e,
+	0x39, 0x96, 0xe7, 0x29, 0x82, 0xa0, 0x36, 0x2c, 0x8e, 0x27, 0xa7, 0xb3, 0x3e, 0x7b, 0x2c, 0xcf,
+	0x5c, 0x10, 0xe1, 0xa3, 0x04, 0x13, 0xd3, 0x59, 0x9f, 0x41, 0x96, 0x67, 0xae, 0x8f, 0xa0, 0x63,
+	0x00, 0x5f, 0x7e, 0x38, 0xc3, 0xb3, 0xc8, 0xf2, 0x2c, 0x95, 0x12, 0x64, 0xc1, 0x72, 0x58, 0xe2,
+	0x38, 0xcf, 0x2b, 0xc9, 0xf2, 0x5c, 0x05, 0x14, 0x6a, 0xcf, 0xc1, 0x14, 0x70, 0xb6, 0x57, 0x93,
+	0xe5, 0x19, 0x2b, 0x29, 0x95, 0xed, 0x6f, 0x1e, 0xaf, 0x4a, 0xdf, 0x3e, 0x5e, 0x95, 0xfe, 0xf4,
+	0x78, 0x55, 0xfa, 0xf2, 0xbb, 0xd5, 0xd8, 0xb7, 0xdf, 0xad, 0xc6, 0x7e, 0xff, 0xdd, 0x6a, 0xec,
+	0x3f, 0x5f, 0x38, 0x35, 0x48, 0x67, 0xd0, 0xdc, 0x68, 0x99, 0xbd, 0xcd, 0x96, 0xd9, 0xc3, 0xa4,
+	0x79, 0x42, 0x46, 0x1f, 0xa3, 0xc7, 0xf3, 0xcd, 0x34, 0xbb, 0x41, 0x6f, 0xfe, 0x6d, 0x00, 0xf1,
+	0x49, 0x0f, 0x3f, 0x5c, 0x2f, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -6205,6 +6339,16 @@ func (m *ResponseInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.OptimisticExecution {
+		i--
+		if m.OptimisticExecution {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.LastBlockAppHash) > 0 {
 		i -= len(m.LastBlockAppHash)
 		copy(dAtA[i:], m.LastBlockAppHash)
@@ -6400,6 +6544,64 @@ func (m *ResponseCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	if m.Priority != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
+	n47, err47 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.BanSenderDuration, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.BanSenderDuration):])
+	if err47 != nil {
+		return 0, err47
+	}
+	i -= n47
+	i = encodeVarintTypes(dAtA, i, uint64(n47))
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0x8a
+	if m.BanSenderNumBlocks != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BanSenderNumBlocks))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	n48, err48 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.TTLDuration, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.TTLDuration):])
+	if err48 != nil {
+		return 0, err48
+	}
+	i -= n48
+	i = encodeVarintTypes(dAtA, i, uint64(n48))
+	i--
+	dAtA[i] = 0x7a
+	if m.TTLNumBlocks != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.TTLNumBlocks))
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.Sequence != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x68
+	}
+	if len(m.Class) > 0 {
+		i -= len(m.Class)
+		copy(dAtA[i:], m.Class)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Class)))
+		i--
+		dAtA[i] = 0x62
+	}
 	if len(m.Codespace) > 0 {
 		i -= len(m.Codespace)
 		copy(dAtA[i:], m.Codespace)
@@ -6613,20 +6815,20 @@ func (m *ResponseApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 		}
 	}
 	if len(m.RefetchChunks) > 0 {
-		dAtA48 := make([]byte, len(m.RefetchChunks)*10)
-		var j47 int
+		dAtA50 := make([]byte, len(m.RefetchChunks)*10)
+		var j49 int
 		for _, num := range m.RefetchChunks {
 			for num >= 1<<7 {
-				dAtA48[j47] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA50[j49] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j47++
+				j49++
 			}
-			dAtA48[j47] = uint8(num)
-			j47++
+			dAtA50[j49] = uint8(num)
+			j49++
 		}
-		i -= j47
-		copy(dAtA[i:], dAtA48[:j47])
-		i = encodeVarintTypes(dAtA, i, uint64(j47))
+		i -= j49
+		copy(dAtA[i:], dAtA50[:j49])
+		i = encodeVarintTypes(dAtA, i, uint64(j49))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -6658,6 +6860,18 @@ func (m *ResponsePrepareProposal) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	_ = i
 	var l int
 	_ = l
+	if m.GasWanted != nil {
+		{
+			size, err := m.GasWanted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.Txs) > 0 {
 		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Txs[iNdEx])
@@ -6690,6 +6904,18 @@ func (m *ResponseProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	_ = i
 	var l int
 	_ = l
+	if m.GasWanted != nil {
+		{
+			size, err := m.GasWanted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
 	if m.Status != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Status))
 		i--
@@ -7333,12 +7559,12 @@ func (m *Misbehavior) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x28
 	}
-	n54, err54 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Time):])
-	if err54 != nil {
-		return 0, err54
+	n58, err58 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Time):])
+	if err58 != nil {
+		return 0, err58
 	}
-	i -= n54
-	i = encodeVarintTypes(dAtA, i, uint64(n54))
+	i -= n58
+	i = encodeVarintTypes(dAtA, i, uint64(n58))
 	i--
 	dAtA[i] = 0x22
 	if m.Height != 0 {
@@ -8278,6 +8504,9 @@ func (m *ResponseInfo) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.OptimisticExecution {
+		n += 2
+	}
 	return n
 }
 
@@ -8383,6 +8612,30 @@ func (m *ResponseCheckTx) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	l = len(m.Class)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Sequence != 0 {
+		n += 1 + sovTypes(uint64(m.Sequence))
+	}
+	if m.TTLNumBlocks != 0 {
+		n += 1 + sovTypes(uint64(m.TTLNumBlocks))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.TTLDuration)
+	n += 1 + l + sovTypes(uint64(l))
+	if m.BanSenderNumBlocks != 0 {
+		n += 2 + sovTypes(uint64(m.BanSenderNumBlocks))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.BanSenderDuration)
+	n += 2 + l + sovTypes(uint64(l))
+	if m.Priority != 0 {
+		n += 2 + sovTypes(uint64(m.Priority))
+	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 2 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -8475,6 +8728,10 @@ func (m *ResponsePrepareProposal) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	if m.GasWanted != nil {
+		l = m.GasWanted.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -8487,6 +8744,10 @@ func (m *ResponseProcessProposal) Size() (n int) {
 	if m.Status != 0 {
 		n += 1 + sovTypes(uint64(m.Status))
 	}
+	if m.GasWanted != nil {
+		l = m.GasWanted.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -13050,6 +13311,26 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 				m.LastBlockAppHash = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OptimisticExecution", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.OptimisticExecution = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13782,6 +14063,212 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 			}
 			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Class", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Class = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TTLNumBlocks", wireType)
+			}
+			m.TTLNumBlocks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TTLNumBlocks |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TTLDuration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.TTLDuration, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BanSenderNumBlocks", wireType)
+			}
+			m.BanSenderNumBlocks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BanSenderNumBlocks |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BanSenderDuration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.BanSenderDuration, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -14347,6 +14834,42 @@ func (m *ResponsePrepareProposal) Unmarshal(dAtA []byte) error {
 			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
 			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasWanted", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.GasWanted == nil {
+				m.GasWanted = &types.Int64Value{}
+			}
+			if err := m.GasWanted.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -14416,6 +14939,42 @@ func (m *ResponseProcessProposal) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasWanted", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.GasWanted == nil {
+				m.GasWanted = &types.Int64Value{}
+			}
+			if err := m.GasWanted.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
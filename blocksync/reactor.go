@@ -2,7 +2,9 @@ package blocksync
 
 import (
 	"fmt"
+	"math"
 	"reflect"
+	"sync/atomic"
 	"time"
 
 	"github.com/cometbft/cometbft/libs/log"
@@ -66,9 +68,30 @@ type Reactor struct {
 
 	switchToConsensusMs int
 
+	// syncing is whether the blocks are being synced, and rate the bits of
+	// the moving average of the number of blocks synced per second.
+	syncing atomic.Bool
+	rate    atomic.Uint64
+
 	metrics *Metrics
 }
 
+// SyncStatus is the progress of block sync.
+type SyncStatus struct {
+	// Whether the blocks are being synced.
+	Syncing bool
+	// Height of the next block to sync.
+	Height int64
+	// Highest height reported by the peers.
+	MaxPeerHeight int64
+	// Moving average of the number of blocks synced per second, updated every
+	// 100 blocks. 0 if unknown.
+	BlocksPerSecond float64
+	// Estimated time left to sync up to MaxPeerHeight at BlocksPerSecond. 0
+	// if unknown.
+	Remaining time.Duration
+}
+
 // NewReactor returns new reactor instance.
 func NewReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockStore,
 	blockSync bool, metrics *Metrics, offlineStateSyncHeight int64,
@@ -286,6 +309,8 @@ func (bcR *Reactor) Receive(e p2p.Envelope) {
 func (bcR *Reactor) poolRoutine(stateSynced bool) {
 	bcR.metrics.Syncing.Set(1)
 	defer bcR.metrics.Syncing.Set(0)
+	bcR.syncing.Store(true)
+	defer bcR.syncing.Store(false)
 
 	trySyncTicker := time.NewTicker(trySyncIntervalMS * time.Millisecond)
 	defer trySyncTicker.Stop()
@@ -520,7 +545,13 @@ FOR_LOOP:
 			blocksSynced++
 
 			if blocksSynced%100 == 0 {
-				lastRate = 0.9*lastRate + 0.1*(100/time.Since(lastHundred).Seconds())
+				rate := 100 / time.Since(lastHundred).Seconds()
+				if lastRate == 0 {
+					lastRate = rate
+				} else {
+					lastRate = 0.9*lastRate + 0.1*rate
+				}
+				bcR.rate.Store(math.Float64bits(lastRate))
 				bcR.Logger.Info("Block Sync Rate", "height", bcR.pool.height,
 					"max_peer_height", bcR.pool.MaxPeerHeight(), "blocks/s", lastRate)
 				lastHundred = time.Now()
@@ -534,6 +565,22 @@ FOR_LOOP:
 	}
 }
 
+// SyncStatus returns the progress of block sync.
+func (bcR *Reactor) SyncStatus() SyncStatus {
+	height, _, _ := bcR.pool.GetStatus()
+	status := SyncStatus{
+		Syncing:         bcR.syncing.Load(),
+		Height:          height,
+		MaxPeerHeight:   bcR.pool.MaxPeerHeight(),
+		BlocksPerSecond: math.Float64frombits(bcR.rate.Load()),
+	}
+	if status.Syncing && status.BlocksPerSecond > 0 && status.MaxPeerHeight >= status.Height {
+		blocksLeft := float64(status.MaxPeerHeight - status.Height + 1)
+		status.Remaining = time.Duration(blocksLeft / status.BlocksPerSecond * float64(time.Second))
+	}
+	return status
+}
+
 // BroadcastStatusRequest broadcasts `BlockStore` base and height.
 func (bcR *Reactor) BroadcastStatusRequest() {
 	bcR.Switch.Broadcast(p2p.Envelope{
@@ -0,0 +1,290 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cometbft/cometbft/crypto"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+var (
+	auditFrom   int64
+	auditTo     int64
+	auditOutput string
+)
+
+func init() {
+	AuditCmd.Flags().Int64Var(&auditFrom, "from", 0,
+		"the lowest height to audit (0 means the base of the block store)")
+	AuditCmd.Flags().Int64Var(&auditTo, "to", 0,
+		"the highest height to audit (0 means the height of the block store)")
+	AuditCmd.Flags().StringVar(&auditOutput, "output", "",
+		"the file to write the signed audit report to (defaults to the standard output)")
+
+	AuditCmd.AddCommand(AuditVerifyCmd)
+}
+
+// AuditCmd constructs a command to check the integrity of the chain data of
+// a node.
+var AuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "check the integrity of the chain data and produce a signed report",
+	Long: `
+audit is an offline tooling to check the integrity of the blocks and validator sets
+stored by the node in a range of heights, for example to verify a backup. For every
+height, it:
+
+- recomputes the data, evidence and last commit hashes of the block, and the block
+  hash, and compares them with the header and the block ID stored in the block store.
+- checks that the block links to the previous one, with its last block ID, its last
+  commit, and its validators hash matching the next validators hash of the previous
+  header.
+- verifies the signatures of the last commit against the validator set of the
+  previous height in the state store.
+- cross-checks the validator sets of the state store against the validators and next
+  validators hashes of the header.
+
+The report lists the problems found, and is signed with the node key, so that it can
+be checked with the "audit verify" command. The command fails if any problem was found.
+
+The node must be stopped while running this command.
+	`,
+	Example: `
+	cometbft audit
+	cometbft audit --from 100 --to 200 --output audit.json
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
+		if err != nil {
+			return fmt.Errorf("failed to load the node key signing the report: %w", err)
+		}
+
+		bs, ss, err := loadStateAndBlockStore(config)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = bs.Close()
+			_ = ss.Close()
+		}()
+
+		st, err := ss.Load()
+		if err != nil {
+			return err
+		}
+		from, to := auditFrom, auditTo
+		if from == 0 {
+			from = bs.Base()
+		}
+		if to == 0 {
+			to = bs.Height()
+		}
+		if from < bs.Base() || to > bs.Height() || from > to || from <= 0 {
+			return fmt.Errorf("invalid height range [%d, %d], the block store holds heights [%d, %d]",
+				from, to, bs.Base(), bs.Height())
+		}
+
+		report := &auditReport{
+			ChainID:  st.ChainID,
+			From:     from,
+			To:       to,
+			Time:     cmttime.Now(),
+			Problems: auditChain(bs, ss, st.ChainID, from, to),
+		}
+		if err := report.sign(nodeKey.PrivKey); err != nil {
+			return fmt.Errorf("failed to sign the audit report: %w", err)
+		}
+		bz, err := cmtjson.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		if auditOutput == "" {
+			fmt.Println(string(bz))
+		} else if err := os.WriteFile(auditOutput, bz, 0o644); err != nil {
+			return err
+		}
+
+		if len(report.Problems) > 0 {
+			return fmt.Errorf("found %d integrity problem(s) in heights [%d, %d]", len(report.Problems), from, to)
+		}
+		return nil
+	},
+}
+
+// AuditVerifyCmd constructs a command to verify the signature of an audit
+// report.
+var AuditVerifyCmd = &cobra.Command{
+	Use:   "verify [report]",
+	Short: "verify the signature of an audit report",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		bz, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		var report auditReport
+		if err := cmtjson.Unmarshal(bz, &report); err != nil {
+			return fmt.Errorf("failed to decode the audit report: %w", err)
+		}
+		if err := report.verify(); err != nil {
+			return err
+		}
+		fmt.Printf("valid report of heights [%d, %d] of chain %s, signed by node %s: %d problem(s)\n",
+			report.From, report.To, report.ChainID, p2p.PubKeyToID(report.PubKey), len(report.Problems))
+		return nil
+	},
+}
+
+// auditProblem is an integrity problem found at a height.
+type auditProblem struct {
+	Height      int64  `json:"height"`
+	Check       string `json:"check"`
+	Description string `json:"description"`
+}
+
+// auditReport is the result of an audit of the chain data, signed by the
+// node key.
+type auditReport struct {
+	ChainID   string         `json:"chain_id"`
+	From      int64          `json:"from"`
+	To        int64          `json:"to"`
+	Time      time.Time      `json:"time"`
+	Problems  []auditProblem `json:"problems"`
+	PubKey    crypto.PubKey  `json:"pub_key"`
+	Signature []byte         `json:"signature"`
+}
+
+// signBytes returns the bytes signed by the node key: the JSON encoding of
+// the report without its signature.
+func (r *auditReport) signBytes() ([]byte, error) {
+	unsigned := *r
+	unsigned.Signature = nil
+	return cmtjson.Marshal(unsigned)
+}
+
+func (r *auditReport) sign(privKey crypto.PrivKey) error {
+	r.PubKey = privKey.PubKey()
+	bz, err := r.signBytes()
+	if err != nil {
+		return err
+	}
+	r.Signature, err = privKey.Sign(bz)
+	return err
+}
+
+func (r *auditReport) verify() error {
+	if r.PubKey == nil {
+		return errors.New("the audit report is not signed")
+	}
+	bz, err := r.signBytes()
+	if err != nil {
+		return err
+	}
+	if !r.PubKey.VerifySignature(bz, r.Signature) {
+		return errors.New("invalid audit report signature")
+	}
+	return nil
+}
+
+// auditChain checks the integrity of the blocks and validator sets of the
+// given chain in the heights [from, to], and returns the problems found.
+func auditChain(bs state.BlockStore, ss state.Store, chainID string, from, to int64) []auditProblem {
+	var problems []auditProblem
+	report := func(height int64, check, format string, args ...any) {
+		problems = append(problems, auditProblem{
+			Height:      height,
+			Check:       check,
+			Description: fmt.Sprintf(format, args...),
+		})
+	}
+
+	var prev *types.Block
+	var prevMeta *types.BlockMeta
+	for h := from; h <= to; h++ {
+		block, meta := bs.LoadBlock(h), bs.LoadBlockMeta(h)
+		if block == nil || meta == nil {
+			report(h, "block", "block not found in the block store")
+			prev, prevMeta = nil, nil
+			continue
+		}
+
+		// The hashes of the block contents.
+		if block.ChainID != chainID {
+			report(h, "chain_id", "header chain ID %q, expected %q", block.ChainID, chainID)
+		}
+		if hash := block.Data.Hash(); !bytes.Equal(hash, block.DataHash) {
+			report(h, "data_hash", "data hash %X, header has %X", hash, block.DataHash)
+		}
+		if hash := block.Evidence.Hash(); !bytes.Equal(hash, block.EvidenceHash) {
+			report(h, "evidence_hash", "evidence hash %X, header has %X", hash, block.EvidenceHash)
+		}
+		if hash := block.LastCommit.Hash(); !bytes.Equal(hash, block.LastCommitHash) {
+			report(h, "last_commit_hash", "last commit hash %X, header has %X", hash, block.LastCommitHash)
+		}
+		if hash := block.Hash(); !bytes.Equal(hash, meta.BlockID.Hash) {
+			report(h, "block_id", "block hash %X, block meta has %X", hash, meta.BlockID.Hash)
+		}
+
+		// The links to the previous block.
+		if prev != nil {
+			if !block.LastBlockID.Equals(prevMeta.BlockID) {
+				report(h, "last_block_id", "last block ID %v, previous block has %v", block.LastBlockID, prevMeta.BlockID)
+			}
+			if !bytes.Equal(block.ValidatorsHash, prev.NextValidatorsHash) {
+				report(h, "validators_hash", "validators hash %X, previous header has next validators hash %X",
+					block.ValidatorsHash, prev.NextValidatorsHash)
+			}
+		}
+		if block.LastCommit != nil && block.LastCommit.Height > 0 {
+			if !block.LastCommit.BlockID.Equals(block.LastBlockID) {
+				report(h, "last_commit", "last commit for block %v, header has last block ID %v",
+					block.LastCommit.BlockID, block.LastBlockID)
+			} else if vals, err := ss.LoadValidators(h - 1); err == nil {
+				if err := vals.VerifyCommitLight(chainID, block.LastBlockID, h-1, block.LastCommit); err != nil {
+					report(h, "last_commit", "invalid last commit: %v", err)
+				}
+			}
+		}
+
+		// The validator sets of the state store.
+		if vals, err := ss.LoadValidators(h); err != nil {
+			report(h, "state_validators", "cannot load the validators: %v", err)
+		} else if hash := vals.Hash(); !bytes.Equal(hash, block.ValidatorsHash) {
+			report(h, "state_validators", "validators hash %X in the state store, header has %X", hash, block.ValidatorsHash)
+		}
+		if vals, err := ss.LoadValidators(h + 1); err != nil {
+			report(h, "state_validators", "cannot load the next validators: %v", err)
+		} else if hash := vals.Hash(); !bytes.Equal(hash, block.NextValidatorsHash) {
+			report(h, "state_validators", "next validators hash %X in the state store, header has %X",
+				hash, block.NextValidatorsHash)
+		}
+
+		prev, prevMeta = block, meta
+	}
+
+	// The commit of the last height is only part of the next block, if any.
+	if prevMeta != nil {
+		commit := bs.LoadBlockCommit(to)
+		if commit == nil {
+			commit = bs.LoadSeenCommit(to)
+		}
+		if commit == nil {
+			report(to, "commit", "commit not found in the block store")
+		} else if vals, err := ss.LoadValidators(to); err == nil {
+			if err := vals.VerifyCommitLight(chainID, prevMeta.BlockID, to, commit); err != nil {
+				report(to, "commit", "invalid commit: %v", err)
+			}
+		}
+	}
+	return problems
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/cometbft/cometbft/rpc/grpc/client/privileged"
+)
+
+var (
+	backupNodeGRPCAddr string
+	backupCertFile     string
+	backupKeyFile      string
+	backupCAFile       string
+)
+
+func init() {
+	BackupCmd.Flags().StringVar(&backupNodeGRPCAddr, "grpc-laddr", "",
+		"the running node's privileged gRPC address (<host>:<port>), which must serve the admin service")
+	BackupCmd.Flags().StringVar(&backupCertFile, "tls-cert-file", "",
+		"the client certificate to authenticate to the admin service with")
+	BackupCmd.Flags().StringVar(&backupKeyFile, "tls-key-file", "",
+		"the private key matching the client certificate")
+	BackupCmd.Flags().StringVar(&backupCAFile, "tls-ca-file", "",
+		"the certificates of the authorities that sign the node's certificate, the system ones if empty")
+}
+
+var BackupCmd = &cobra.Command{
+	Use:   "backup [output-directory]",
+	Short: "back up the block and state stores of a running node",
+	Long: `
+Back up the block and state stores of a running node, without downtime. The node
+takes a consistent snapshot of its stores and writes it to new "blockstore.db"
+and "state.db" databases in the output directory, which is created on the node's
+host and must not exist or be empty. The backup is taken through the admin service
+of the node's privileged gRPC server, which authenticates the client with the given
+certificate.
+
+To restore a node from the backup, stop it and replace the databases of the same
+names in its data directory with the backed up ones. The block store in the backup
+may be one height ahead of the state, in which case the last block is replayed to
+the application on restart.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := filepath.Abs(args[0])
+		if err != nil {
+			return err
+		}
+		if backupNodeGRPCAddr == "" {
+			return errors.New("the node's privileged gRPC address must be set with --grpc-laddr")
+		}
+		creds, err := adminClientCredentials(backupNodeGRPCAddr, backupCertFile, backupKeyFile, backupCAFile)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		client, err := privileged.New(ctx, backupNodeGRPCAddr,
+			privileged.WithPruningServiceEnabled(false),
+			privileged.WithGRPCDialOption(ggrpc.WithTransportCredentials(creds)),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create admin service client: %w", err)
+		}
+		defer client.Close()
+
+		info, err := client.Backup(ctx, dir)
+		if err != nil {
+			return fmt.Errorf("failed to back up node: %w", err)
+		}
+
+		fmt.Printf("Backed up block store at height %d and state at height %d to %s\n",
+			info.BlockStoreHeight, info.StateHeight, info.Dir)
+		return nil
+	},
+}
+
+// adminClientCredentials returns the TLS credentials of a client of the admin
+// service at addr, authenticating with the given certificate and key, and
+// verifying the node's certificate with the authorities of caFile.
+func adminClientCredentials(addr, certFile, keyFile, caFile string) (credentials.TransportCredentials, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("the admin service requires a client certificate: " +
+			"--tls-cert-file and --tls-key-file must be set")
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading client certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		tlsConfig.ServerName = host
+	}
+	if caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA file: %w", err)
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("no certificates found in CA file")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/pex"
+)
+
+var dnsSeedKeyFile string
+
+func init() {
+	DNSSeedRecordsCmd.Flags().StringVar(&dnsSeedKeyFile, "key-file", "",
+		"the node key file of the key signing the records (defaults to the node key of this node)")
+}
+
+// DNSSeedRecordsCmd prints the TXT records publishing seed addresses on a
+// domain.
+var DNSSeedRecordsCmd = &cobra.Command{
+	Use:   "dns-seed-records [domain] [seed address]...",
+	Short: "Generate the signed TXT records publishing seed addresses on a domain",
+	Long: `
+Generate the TXT records publishing the given seed addresses (<ID>@<host>:<port>)
+on the domain, signed with an ed25519 node key. The nodes which list the printed
+"<public key>@<domain>" entry in p2p.dns_seeds then dial the published seeds.
+
+The records replace all the previous seed records of the domain. Publishing a
+new set of records, signed by the same key, rotates the seeds of the network
+without editing the config of the nodes.
+`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keyFile := dnsSeedKeyFile
+		if keyFile == "" {
+			keyFile = config.NodeKeyFile()
+		}
+		nodeKey, err := p2p.LoadNodeKey(keyFile)
+		if err != nil {
+			return err
+		}
+
+		domain := strings.TrimSuffix(args[0], ".")
+		records, err := pex.DNSSeedRecords(nodeKey.PrivKey, domain, args[1:])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("dns_seeds entry: %X@%s\n\n", nodeKey.PubKey().Bytes(), domain)
+		for _, record := range records {
+			fmt.Printf("%s. IN TXT %q\n", domain, record)
+		}
+		return nil
+	},
+}
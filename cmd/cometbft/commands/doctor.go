@@ -0,0 +1,244 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cometbft/cometbft/state"
+)
+
+var doctorFix bool
+
+func init() {
+	DoctorCmd.Flags().BoolVar(&doctorFix, "fix", false,
+		"truncate the stores that are ahead to the last consistent height")
+}
+
+// DoctorCmd constructs a command to check the consistency of the stores of
+// a node.
+var DoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "check the consistency of the block store, state store and indexes",
+	Long: `
+doctor is an offline tooling to check that the heights of the block store, the state
+store, the block index and the tx index agree with each other, for example after a
+crash. The state store height is taken as the reference:
+
+- the block store may be at most one block ahead of the state, as the last block is
+  replayed on startup. Blocks above this height are removed by --fix.
+- the block and tx indexes must not be ahead of the state. Heights indexed above the
+  state height are removed by --fix.
+- the block store and the indexes must not be behind the state. This cannot be repaired
+  by truncation: restore the block store from a backup or state sync the node, and
+  re-index missing heights with the reindex-event command.
+
+Only the kv indexer can be checked. The node must be stopped while running this command.
+	`,
+	Example: `
+	cometbft doctor
+	cometbft doctor --fix
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		bs, ss, err := loadStateAndBlockStore(config)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = bs.Close()
+			_ = ss.Close()
+		}()
+
+		dArgs := doctorArgs{
+			blockStore: bs,
+			stateStore: ss,
+		}
+
+		switch strings.ToLower(config.TxIndex.Indexer) {
+		case "kv":
+			bi, ti, err := loadEventSinks(config, "")
+			if err != nil {
+				return err
+			}
+			dArgs.blockIndex = bi.(truncatableIndex)
+			dArgs.txIndex = ti.(truncatableIndex)
+		default:
+			fmt.Printf("skipping the indexes: the %q indexer cannot be checked\n", config.TxIndex.Indexer)
+		}
+
+		report, err := diagnoseStores(dArgs)
+		if err != nil {
+			return err
+		}
+		report.print()
+
+		if len(report.problems) == 0 {
+			fmt.Println("the stores are consistent")
+			return nil
+		}
+		if !doctorFix {
+			return errors.New("the stores are inconsistent, run with --fix to repair the problems that can be repaired")
+		}
+
+		unrepaired := 0
+		for _, p := range report.problems {
+			if p.repair == nil {
+				unrepaired++
+				continue
+			}
+			if err := p.repair(); err != nil {
+				return fmt.Errorf("failed to repair %q: %w", p.description, err)
+			}
+			fmt.Printf("repaired: %s\n", p.description)
+		}
+		if unrepaired > 0 {
+			return fmt.Errorf("%d problem(s) cannot be repaired automatically", unrepaired)
+		}
+		return nil
+	},
+}
+
+// truncatableIndex is implemented by the indexers whose indexed heights can
+// be inspected and truncated.
+type truncatableIndex interface {
+	LatestHeight() (int64, error)
+	TruncateAbove(height int64) (int64, error)
+}
+
+type doctorArgs struct {
+	blockStore state.BlockStore
+	stateStore state.Store
+	// The indexes are nil if they are not checked.
+	blockIndex truncatableIndex
+	txIndex    truncatableIndex
+}
+
+// storeProblem is an inconsistency between the stores, along with the
+// function repairing it, or nil if it cannot be repaired automatically.
+type storeProblem struct {
+	description string
+	repair      func() error
+}
+
+type storesReport struct {
+	blockStoreBase   int64
+	blockStoreHeight int64
+	stateHeight      int64
+	// The latest indexed heights, or -1 if the index was not checked.
+	blockIndexHeight int64
+	txIndexHeight    int64
+
+	problems []storeProblem
+	warnings []string
+}
+
+func (r *storesReport) print() {
+	fmt.Printf("block store: base %d, height %d\n", r.blockStoreBase, r.blockStoreHeight)
+	fmt.Printf("state store: height %d\n", r.stateHeight)
+	if r.blockIndexHeight >= 0 {
+		fmt.Printf("block index: height %d\n", r.blockIndexHeight)
+	}
+	if r.txIndexHeight >= 0 {
+		fmt.Printf("tx index: latest tx at height %d\n", r.txIndexHeight)
+	}
+	for _, w := range r.warnings {
+		fmt.Printf("warning: %s\n", w)
+	}
+	for _, p := range r.problems {
+		if p.repair != nil {
+			fmt.Printf("problem: %s (repaired by --fix)\n", p.description)
+		} else {
+			fmt.Printf("problem: %s (cannot be repaired automatically)\n", p.description)
+		}
+	}
+}
+
+// diagnoseStores checks the heights of the stores against the state height.
+func diagnoseStores(args doctorArgs) (*storesReport, error) {
+	st, err := args.stateStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state: %w", err)
+	}
+
+	report := &storesReport{
+		blockStoreBase:   args.blockStore.Base(),
+		blockStoreHeight: args.blockStore.Height(),
+		stateHeight:      st.LastBlockHeight,
+		blockIndexHeight: -1,
+		txIndexHeight:    -1,
+	}
+
+	if st.IsEmpty() {
+		if report.blockStoreHeight > 0 {
+			report.problems = append(report.problems, storeProblem{
+				description: fmt.Sprintf("the state store is empty but the block store has blocks up to height %d",
+					report.blockStoreHeight),
+			})
+		}
+		return report, nil
+	}
+
+	switch {
+	case report.blockStoreHeight > report.stateHeight+1:
+		target := report.stateHeight + 1
+		numBlocks := report.blockStoreHeight - target
+		report.problems = append(report.problems, storeProblem{
+			description: fmt.Sprintf("the block store (height %d) is ahead of the state (height %d): truncate to height %d",
+				report.blockStoreHeight, report.stateHeight, target),
+			repair: func() error {
+				for i := int64(0); i < numBlocks; i++ {
+					if err := args.blockStore.DeleteLatestBlock(); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		})
+	case report.blockStoreHeight < report.stateHeight:
+		report.problems = append(report.problems, storeProblem{
+			description: fmt.Sprintf("the block store (height %d) is behind the state (height %d)",
+				report.blockStoreHeight, report.stateHeight),
+		})
+	}
+
+	if args.blockIndex != nil {
+		report.blockIndexHeight, err = args.blockIndex.LatestHeight()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the block index: %w", err)
+		}
+		checkIndex(report, "block index", report.blockIndexHeight, args.blockIndex)
+		if report.blockIndexHeight < report.stateHeight {
+			report.warnings = append(report.warnings, fmt.Sprintf(
+				"the block index (height %d) is behind the state (height %d): re-index the missing heights with reindex-event",
+				report.blockIndexHeight, report.stateHeight))
+		}
+	}
+
+	if args.txIndex != nil {
+		report.txIndexHeight, err = args.txIndex.LatestHeight()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the tx index: %w", err)
+		}
+		checkIndex(report, "tx index", report.txIndexHeight, args.txIndex)
+	}
+
+	return report, nil
+}
+
+// checkIndex reports the index as a problem if it is ahead of the state.
+func checkIndex(report *storesReport, name string, indexHeight int64, index truncatableIndex) {
+	if indexHeight <= report.stateHeight {
+		return
+	}
+	stateHeight := report.stateHeight
+	report.problems = append(report.problems, storeProblem{
+		description: fmt.Sprintf("the %s (height %d) is ahead of the state (height %d): truncate to height %d",
+			name, indexHeight, stateHeight, stateHeight),
+		repair: func() error {
+			_, err := index.TruncateAbove(stateHeight)
+			return err
+		},
+	})
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/state"
+	blockidxkv "github.com/cometbft/cometbft/state/indexer/block/kv"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/state/txindex/kv"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestDiagnoseStores(t *testing.T) {
+	const stateHeight int64 = 10
+
+	testCases := []struct {
+		name             string
+		blockStoreHeight int64
+		indexHeight      int64
+		expProblems      int
+		expRepairable    int
+		expWarnings      int
+	}{
+		{"consistent", stateHeight, stateHeight, 0, 0, 0},
+		{"block store one block ahead", stateHeight + 1, stateHeight, 0, 0, 0},
+		{"block store ahead", stateHeight + 3, stateHeight, 1, 1, 0},
+		{"block store behind", stateHeight - 1, stateHeight, 1, 0, 0},
+		{"indexes ahead", stateHeight, stateHeight + 2, 2, 2, 0},
+		{"indexes behind", stateHeight, stateHeight - 2, 0, 0, 1},
+		{"all ahead", stateHeight + 5, stateHeight + 5, 3, 3, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stateStore := &mocks.Store{}
+			stateStore.On("Load").Return(state.State{
+				ChainID:         "test",
+				InitialHeight:   1,
+				LastBlockHeight: stateHeight,
+				Validators:      types.NewValidatorSet(nil),
+			}, nil)
+
+			blockStore := &mocks.BlockStore{}
+			blockStore.
+				On("Base").Return(int64(1)).
+				On("Height").Return(tc.blockStoreHeight)
+			if tc.blockStoreHeight > stateHeight+1 {
+				blockStore.On("DeleteLatestBlock").Return(nil).Times(int(tc.blockStoreHeight - stateHeight - 1))
+			}
+
+			store := dbm.NewMemDB()
+			txIndex := kv.NewTxIndex(store)
+			blockIndex := blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
+			for h := int64(1); h <= tc.indexHeight; h++ {
+				require.NoError(t, blockIndex.Index(types.EventDataNewBlockEvents{Height: h}))
+				require.NoError(t, txIndex.Index(&abcitypes.TxResult{
+					Height: h,
+					Tx:     types.Tx(fmt.Sprintf("tx%d", h)),
+				}))
+			}
+
+			report, err := diagnoseStores(doctorArgs{
+				blockStore: blockStore,
+				stateStore: stateStore,
+				blockIndex: blockIndex,
+				txIndex:    txIndex,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.blockStoreHeight, report.blockStoreHeight)
+			require.Equal(t, stateHeight, report.stateHeight)
+			require.Equal(t, tc.indexHeight, report.blockIndexHeight)
+			require.Equal(t, tc.indexHeight, report.txIndexHeight)
+			require.Len(t, report.problems, tc.expProblems)
+			require.Len(t, report.warnings, tc.expWarnings)
+
+			repairable := 0
+			for _, p := range report.problems {
+				if p.repair == nil {
+					continue
+				}
+				repairable++
+				require.NoError(t, p.repair())
+			}
+			require.Equal(t, tc.expRepairable, repairable)
+			blockStore.AssertExpectations(t)
+
+			if tc.indexHeight > stateHeight {
+				latest, err := blockIndex.LatestHeight()
+				require.NoError(t, err)
+				require.Equal(t, stateHeight, latest)
+
+				latest, err = txIndex.LatestHeight()
+				require.NoError(t, err)
+				require.Equal(t, stateHeight, latest)
+			}
+		})
+	}
+}
+
+func TestDiagnoseEmptyState(t *testing.T) {
+	stateStore := &mocks.Store{}
+	stateStore.On("Load").Return(state.State{}, nil)
+
+	blockStore := &mocks.BlockStore{}
+	blockStore.
+		On("Base").Return(int64(1)).
+		On("Height").Return(int64(3))
+
+	report, err := diagnoseStores(doctorArgs{blockStore: blockStore, stateStore: stateStore})
+	require.NoError(t, err)
+	require.Len(t, report.problems, 1)
+	require.Nil(t, report.problems[0].repair)
+}
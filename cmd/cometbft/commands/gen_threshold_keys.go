@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cometbft/cometbft/crypto/threshold"
+)
+
+var thresholdKeysThreshold int
+
+func init() {
+	GenThresholdKeysCmd.Flags().IntVar(&thresholdKeysThreshold, "threshold", 0,
+		"number of decryption shares needed to decrypt (default: more than 2/3 of the shares)")
+}
+
+// GenThresholdKeysCmd generates the threshold encryption keys of the
+// encrypted mempool transactions, as a trusted dealer.
+var GenThresholdKeysCmd = &cobra.Command{
+	Use:     "gen-threshold-keys [output-directory] [number-of-shares]",
+	Aliases: []string{"gen_threshold_keys"},
+	Short:   "Generate the threshold encryption keys of the validators",
+	Long: `
+gen-threshold-keys generates a threshold public key and the given number of key
+shares, and writes the key file of each share, threshold_key_<index>.json, to the
+output directory, which must not exist.
+
+Each validator must be given its own key file, and set mempool.experimental_threshold_key_file
+to its path. Anyone holding more key files than the threshold can decrypt the
+encrypted transactions: the key files must be deleted once distributed.
+`,
+	Args: cobra.ExactArgs(2),
+	RunE: genThresholdKeys,
+}
+
+func genThresholdKeys(_ *cobra.Command, args []string) error {
+	n, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid number of shares %q: %w", args[1], err)
+	}
+	t := thresholdKeysThreshold
+	if t == 0 {
+		t = n*2/3 + 1
+	}
+	pk, shares, err := threshold.GenerateKeys(n, t)
+	if err != nil {
+		return err
+	}
+
+	dir := args[0]
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		return err
+	}
+	for _, share := range shares {
+		file := filepath.Join(dir, fmt.Sprintf("threshold_key_%d.json", share.Index))
+		if err := (&threshold.KeyFile{PublicKey: pk, Share: share}).Save(file); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Generated %d threshold key files, %d of which are needed to decrypt, in %s\n", n, t, dir)
+	return nil
+}
@@ -7,8 +7,14 @@ import (
 
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/privval"
+	"github.com/cometbft/cometbft/types"
 )
 
+func init() {
+	GenValidatorCmd.Flags().StringVar(&keyType, "key-type", types.ABCIPubKeyTypeEd25519,
+		"type of the private validator key: ed25519 or bls12_381")
+}
+
 // GenValidatorCmd allows the generation of a keypair for a
 // validator.
 var GenValidatorCmd = &cobra.Command{
@@ -19,7 +25,11 @@ var GenValidatorCmd = &cobra.Command{
 }
 
 func genValidator(*cobra.Command, []string) {
-	pv := privval.GenFilePV("", "")
+	privKey, err := genPrivKey(keyType)
+	if err != nil {
+		panic(err)
+	}
+	pv := privval.NewFilePV(privKey, "", "")
 	jsbz, err := cmtjson.Marshal(pv)
 	if err != nil {
 		panic(err)
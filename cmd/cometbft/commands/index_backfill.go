@@ -0,0 +1,267 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/progressbar"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/state/indexer"
+	"github.com/cometbft/cometbft/state/txindex"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	backfillFailed = "index backfill failed: "
+)
+
+// ErrBackfillVerification is returned when data fetched from the archive node
+// does not match the hashes committed to by the local chain.
+var ErrBackfillVerification = errors.New("archive data does not match the local chain")
+
+var (
+	backfillRPCAddr     string
+	backfillStartHeight int64
+	backfillEndHeight   int64
+)
+
+func init() {
+	IndexBackfillCmd.Flags().StringVar(&backfillRPCAddr, "rpc", "",
+		"RPC address of the trusted archive node to fetch historical blocks from (e.g. http://archive:26657)")
+	IndexBackfillCmd.Flags().Int64Var(&backfillStartHeight, "start-height", 1,
+		"the lowest block height to backfill")
+	IndexBackfillCmd.Flags().Int64Var(&backfillEndHeight, "end-height", 0,
+		"the highest block height to backfill (0 means the latest height that can be verified)")
+
+	IndexCmd.AddCommand(IndexBackfillCmd)
+}
+
+// IndexCmd groups the commands operating on the event indexers.
+var IndexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "manage the block and transaction event indexes",
+}
+
+// IndexBackfillCmd constructs a command to index historical blocks fetched
+// from an archive node.
+var IndexBackfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "index historical blocks fetched from a trusted archive node",
+	Long: `
+backfill is an offline tooling to index block and tx events of heights for which the
+node has no local data, for example because it was state synced. The blocks and their
+results are fetched from a trusted archive node and fed through the local event sinks.
+
+The fetched data is verified against the local block store: starting from the latest
+local header, every block is checked against the hash committed to by the block above
+it, and every set of results against the LastResultsHash and AppHash of the block above
+it. For this reason, the latest local height cannot be backfilled, and heights below the
+local base require fetching the headers of all the blocks in between. Note that the
+block events returned by the archive node are not covered by any hash and are trusted.
+
+The node must be stopped while running this command.
+	`,
+	Example: `
+	cometbft index backfill --rpc http://archive:26657
+	cometbft index backfill --rpc http://archive:26657 --start-height 100 --end-height 200
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if backfillRPCAddr == "" {
+			return errors.New("the --rpc flag is required")
+		}
+
+		bs, ss, err := loadStateAndBlockStore(config)
+		if err != nil {
+			return fmt.Errorf("%s%w", backfillFailed, err)
+		}
+
+		state, err := ss.Load()
+		if err != nil {
+			return fmt.Errorf("%s%w", backfillFailed, err)
+		}
+
+		bi, ti, err := loadEventSinks(config, state.ChainID)
+		if err != nil {
+			return fmt.Errorf("%s%w", backfillFailed, err)
+		}
+
+		client, err := rpchttp.New(backfillRPCAddr)
+		if err != nil {
+			return fmt.Errorf("%sfailed to create RPC client: %w", backfillFailed, err)
+		}
+
+		bfArgs := indexBackfillArgs{
+			startHeight:  backfillStartHeight,
+			endHeight:    backfillEndHeight,
+			client:       client,
+			blockIndexer: bi,
+			txIndexer:    ti,
+			blockStore:   bs,
+		}
+		if err := indexBackfill(cmd.Context(), bfArgs); err != nil {
+			return fmt.Errorf("%s%w", backfillFailed, err)
+		}
+
+		fmt.Println("index backfill finished")
+		return nil
+	},
+}
+
+// backfillClient is the subset of the RPC client used to fetch historical
+// data from the archive node.
+type backfillClient interface {
+	Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error)
+	Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error)
+	BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error)
+}
+
+type indexBackfillArgs struct {
+	startHeight  int64
+	endHeight    int64
+	client       backfillClient
+	blockIndexer indexer.BlockIndexer
+	txIndexer    txindex.TxIndexer
+	blockStore   state.BlockStore
+}
+
+// indexBackfill walks the chain down from the latest local header to the
+// start height, verifying every fetched block against the hash committed to
+// by the block above it, and indexes the blocks in the requested range.
+func indexBackfill(ctx context.Context, args indexBackfillArgs) error {
+	top := args.blockStore.Height()
+	if top == 0 {
+		return errors.New("the block store is empty")
+	}
+	base := args.blockStore.Base()
+
+	if args.startHeight < 1 {
+		args.startHeight = 1
+	}
+	if args.endHeight == 0 || args.endHeight >= top {
+		args.endHeight = top - 1
+	}
+	if args.endHeight < args.startHeight {
+		return fmt.Errorf("%w (start height: %d, end height: %d, latest verifiable height: %d)",
+			ErrInvalidRequest, args.startHeight, args.endHeight, top-1)
+	}
+
+	meta := args.blockStore.LoadBlockMeta(top)
+	if meta == nil {
+		return fmt.Errorf("not able to load block meta at height %d from the blockstore", top)
+	}
+	next := meta.Header
+
+	var bar progressbar.Bar
+	bar.NewOption(0, args.endHeight-args.startHeight+1)
+
+	fmt.Printf("start backfilling the index from height %d to %d:\n", args.startHeight, args.endHeight)
+	defer bar.Finish()
+	for height := top - 1; height >= args.startHeight; height-- {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("index backfill terminated at height %d: %w", height, ctx.Err())
+		default:
+		}
+
+		if height > args.endHeight {
+			header, err := backfillHeader(ctx, args, base, height, next)
+			if err != nil {
+				return err
+			}
+			next = *header
+			continue
+		}
+
+		block, err := backfillBlock(ctx, args, height, next)
+		if err != nil {
+			return err
+		}
+		next = block.Header
+
+		bar.Play(args.endHeight - height + 1)
+	}
+
+	return nil
+}
+
+// backfillHeader returns the header at the given height, loading it from the
+// block store if available and fetching and verifying it otherwise.
+func backfillHeader(
+	ctx context.Context,
+	args indexBackfillArgs,
+	base, height int64,
+	next types.Header,
+) (*types.Header, error) {
+	if height >= base {
+		meta := args.blockStore.LoadBlockMeta(height)
+		if meta == nil {
+			return nil, fmt.Errorf("not able to load block meta at height %d from the blockstore", height)
+		}
+		return &meta.Header, nil
+	}
+
+	res, err := args.client.Header(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("fetching header at height %d: %w", height, err)
+	}
+	if res.Header == nil || !bytes.Equal(res.Header.Hash(), next.LastBlockID.Hash) {
+		return nil, fmt.Errorf("%w: header hash mismatch at height %d", ErrBackfillVerification, height)
+	}
+	return res.Header, nil
+}
+
+// backfillBlock fetches the block and results at the given height, verifies
+// them against the header of the block above and indexes them.
+func backfillBlock(
+	ctx context.Context,
+	args indexBackfillArgs,
+	height int64,
+	next types.Header,
+) (*types.Block, error) {
+	resBlock, err := args.client.Block(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("fetching block at height %d: %w", height, err)
+	}
+	block := resBlock.Block
+	if block == nil || block.Height != height {
+		return nil, fmt.Errorf("%w: missing block at height %d", ErrBackfillVerification, height)
+	}
+	if err := block.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("%w: invalid block at height %d: %v", ErrBackfillVerification, height, err)
+	}
+	if !bytes.Equal(block.Hash(), next.LastBlockID.Hash) {
+		return nil, fmt.Errorf("%w: block hash mismatch at height %d", ErrBackfillVerification, height)
+	}
+
+	results, err := args.client.BlockResults(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("fetching block results at height %d: %w", height, err)
+	}
+	if len(results.TxsResults) != len(block.Txs) {
+		return nil, fmt.Errorf("%w: expected %d tx results at height %d, got %d",
+			ErrBackfillVerification, len(block.Txs), height, len(results.TxsResults))
+	}
+	if !bytes.Equal(types.NewResults(results.TxsResults).Hash(), next.LastResultsHash) {
+		return nil, fmt.Errorf("%w: results hash mismatch at height %d", ErrBackfillVerification, height)
+	}
+	if !bytes.Equal(results.AppHash, next.AppHash) {
+		return nil, fmt.Errorf("%w: app hash mismatch at height %d", ErrBackfillVerification, height)
+	}
+
+	resp := &abcitypes.ResponseFinalizeBlock{
+		Events:    results.FinalizeBlockEvents,
+		TxResults: results.TxsResults,
+	}
+	if err := txindex.IndexBlockResults(args.blockIndexer, args.txIndexer, height, block.Txs, resp); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
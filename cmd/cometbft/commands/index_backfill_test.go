@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/test"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	blockidxkv "github.com/cometbft/cometbft/state/indexer/block/kv"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/state/txindex/kv"
+	"github.com/cometbft/cometbft/types"
+	"github.com/cometbft/cometbft/version"
+)
+
+// archiveClient serves a chain of blocks and results from memory.
+type archiveClient struct {
+	blocks  map[int64]*types.Block
+	results map[int64]*ctypes.ResultBlockResults
+}
+
+func (c *archiveClient) Header(_ context.Context, height *int64) (*ctypes.ResultHeader, error) {
+	block, ok := c.blocks[*height]
+	if !ok {
+		return nil, fmt.Errorf("height %d not available", *height)
+	}
+	return &ctypes.ResultHeader{Header: &block.Header}, nil
+}
+
+func (c *archiveClient) Block(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	block, ok := c.blocks[*height]
+	if !ok {
+		return nil, fmt.Errorf("height %d not available", *height)
+	}
+	return &ctypes.ResultBlock{Block: block}, nil
+}
+
+func (c *archiveClient) BlockResults(_ context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+	results, ok := c.results[*height]
+	if !ok {
+		return nil, fmt.Errorf("height %d not available", *height)
+	}
+	return results, nil
+}
+
+// makeArchiveChain builds a hash-linked chain of the given number of blocks
+// and returns an archive client serving it along with the block metas.
+func makeArchiveChain(t *testing.T, numBlocks int64) (*archiveClient, map[int64]*types.BlockMeta) {
+	t.Helper()
+
+	client := &archiveClient{
+		blocks:  make(map[int64]*types.Block),
+		results: make(map[int64]*ctypes.ResultBlockResults),
+	}
+	metas := make(map[int64]*types.BlockMeta)
+
+	var (
+		lastBlockID     types.BlockID
+		lastResultsHash []byte
+		appHash         []byte
+	)
+	for h := int64(1); h <= numBlocks; h++ {
+		lastCommit := &types.Commit{}
+		if h > 1 {
+			lastCommit = &types.Commit{
+				Height:     h - 1,
+				BlockID:    lastBlockID,
+				Signatures: []types.CommitSig{types.NewCommitSigAbsent()},
+			}
+		}
+
+		block := types.MakeBlock(h, test.MakeNTxs(h, 2), lastCommit, nil)
+		block.Version.Block = version.BlockProtocol
+		block.ChainID = test.DefaultTestChainID
+		block.LastBlockID = lastBlockID
+		block.ValidatorsHash = test.RandomHash()
+		block.NextValidatorsHash = test.RandomHash()
+		block.ConsensusHash = test.RandomHash()
+		block.AppHash = appHash
+		block.LastResultsHash = lastResultsHash
+		block.ProposerAddress = test.RandomAddress()
+		require.NoError(t, block.ValidateBasic())
+
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+
+		txResults := make([]*abcitypes.ExecTxResult, len(block.Txs))
+		for i := range block.Txs {
+			txResults[i] = &abcitypes.ExecTxResult{Data: []byte{byte(h), byte(i)}}
+		}
+
+		client.blocks[h] = block
+		client.results[h] = &ctypes.ResultBlockResults{
+			Height:     h,
+			TxsResults: txResults,
+			FinalizeBlockEvents: []abcitypes.Event{{
+				Type: "begin_event",
+				Attributes: []abcitypes.EventAttribute{
+					{Key: "proposer", Value: "FCAA001", Index: true},
+				},
+			}},
+			AppHash: test.RandomHash(),
+		}
+		metas[h] = types.NewBlockMeta(block, partSet)
+
+		lastBlockID = metas[h].BlockID
+		lastResultsHash = types.NewResults(txResults).Hash()
+		appHash = client.results[h].AppHash
+	}
+
+	return client, metas
+}
+
+func TestIndexBackfill(t *testing.T) {
+	const (
+		localBase   int64 = 5
+		localHeight int64 = 6
+	)
+
+	testCases := []struct {
+		name        string
+		startHeight int64
+		endHeight   int64
+		tamper      func(c *archiveClient)
+		indexed     []int64
+		expErr      error
+	}{
+		{"all heights", 0, 0, nil, []int64{1, 2, 3, 4, 5}, nil},
+		{"below base", 2, 3, nil, []int64{2, 3}, nil},
+		{"end height above local height", 5, 10, nil, []int64{5}, nil},
+		{"start height above end height", 4, 3, nil, nil, ErrInvalidRequest},
+		{
+			"tampered tx", 1, 2,
+			func(c *archiveClient) {
+				c.blocks[2].Data = types.Data{Txs: types.Txs{types.Tx("forged"), c.blocks[2].Txs[1]}}
+			},
+			nil, ErrBackfillVerification,
+		},
+		{
+			"tampered tx results", 1, 3,
+			func(c *archiveClient) { c.results[2].TxsResults[0].Code = 1 },
+			[]int64{3}, ErrBackfillVerification,
+		},
+		{
+			"tampered header", 1, 2,
+			func(c *archiveClient) { c.blocks[3].Header.Time = c.blocks[3].Header.Time.Add(1) },
+			nil, ErrBackfillVerification,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, metas := makeArchiveChain(t, localHeight)
+			if tc.tamper != nil {
+				tc.tamper(client)
+			}
+
+			blockStore := &mocks.BlockStore{}
+			blockStore.
+				On("Base").Return(localBase).
+				On("Height").Return(localHeight)
+			for h := localBase; h <= localHeight; h++ {
+				blockStore.On("LoadBlockMeta", h).Return(metas[h]).Maybe()
+			}
+
+			store := dbm.NewMemDB()
+			txIndexer := kv.NewTxIndex(store)
+			blockIndexer := blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
+
+			err := indexBackfill(context.Background(), indexBackfillArgs{
+				startHeight:  tc.startHeight,
+				endHeight:    tc.endHeight,
+				client:       client,
+				blockIndexer: blockIndexer,
+				txIndexer:    txIndexer,
+				blockStore:   blockStore,
+			})
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			for h := int64(1); h <= localHeight; h++ {
+				expIndexed := false
+				for _, ih := range tc.indexed {
+					expIndexed = expIndexed || ih == h
+				}
+
+				has, err := blockIndexer.Has(h)
+				require.NoError(t, err)
+				require.Equal(t, expIndexed, has, "height %d", h)
+
+				txResult, err := txIndexer.Get(client.blocks[h].Txs[1].Hash())
+				require.NoError(t, err)
+				require.Equal(t, expIndexed, txResult != nil, "height %d", h)
+			}
+		})
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"github.com/spf13/cobra"
 
 	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bls12381"
+	"github.com/cometbft/cometbft/crypto/ed25519"
 	cmtos "github.com/cometbft/cometbft/libs/os"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
 	"github.com/cometbft/cometbft/p2p"
@@ -14,6 +17,13 @@ import (
 	cmttime "github.com/cometbft/cometbft/types/time"
 )
 
+var keyType string
+
+func init() {
+	InitFilesCmd.Flags().StringVar(&keyType, "key-type", types.ABCIPubKeyTypeEd25519,
+		"type of the private validator key: ed25519 or bls12_381")
+}
+
 // InitFilesCmd initializes a fresh CometBFT instance.
 var InitFilesCmd = &cobra.Command{
 	Use:   "init",
@@ -31,11 +41,15 @@ func initFilesWithConfig(config *cfg.Config) error {
 	privValStateFile := config.PrivValidatorStateFile()
 	var pv *privval.FilePV
 	if cmtos.FileExists(privValKeyFile) {
-		pv = privval.LoadFilePV(privValKeyFile, privValStateFile)
+		pv = privval.LoadFilePVEmptyState(privValKeyFile, privValStateFile)
 		logger.Info("Found private validator", "keyFile", privValKeyFile,
 			"stateFile", privValStateFile)
 	} else {
-		pv = privval.GenFilePV(privValKeyFile, privValStateFile)
+		privKey, err := genPrivKey(keyType)
+		if err != nil {
+			return err
+		}
+		pv = privval.NewFilePV(privKey, privValKeyFile, privValStateFile)
 		pv.Save()
 		logger.Info("Generated private validator", "keyFile", privValKeyFile,
 			"stateFile", privValStateFile)
@@ -65,6 +79,9 @@ func initFilesWithConfig(config *cfg.Config) error {
 		if err != nil {
 			return fmt.Errorf("can't get pubkey: %w", err)
 		}
+		if pubKey.Type() == bls12381.KeyType {
+			genDoc.ConsensusParams.Validator.PubKeyTypes = []string{types.ABCIPubKeyTypeBls12381}
+		}
 		genDoc.Validators = []types.GenesisValidator{{
 			Address: pubKey.Address(),
 			PubKey:  pubKey,
@@ -79,3 +96,15 @@ func initFilesWithConfig(config *cfg.Config) error {
 
 	return nil
 }
+
+// genPrivKey generates a private validator key of the given type.
+func genPrivKey(keyType string) (crypto.PrivKey, error) {
+	switch keyType {
+	case types.ABCIPubKeyTypeEd25519:
+		return ed25519.GenPrivKey(), nil
+	case types.ABCIPubKeyTypeBls12381:
+		return bls12381.GenPrivKey(), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", keyType)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 
 	dbm "github.com/cometbft/cometbft-db"
@@ -19,6 +20,8 @@ import (
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	cmtos "github.com/cometbft/cometbft/libs/os"
 	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	lhttp "github.com/cometbft/cometbft/light/provider/http"
 	lproxy "github.com/cometbft/cometbft/light/proxy"
 	lrpc "github.com/cometbft/cometbft/light/rpc"
 	dbs "github.com/cometbft/cometbft/light/store/db"
@@ -57,6 +60,8 @@ var (
 	listenAddr         string
 	primaryAddr        string
 	witnessAddrsJoined string
+	witnessPoolJoined  string
+	evidenceRecvJoined string
 	chainID            string
 	home               string
 	maxOpenConnections int
@@ -67,6 +72,12 @@ var (
 	trustedHash    []byte
 	trustLevelStr  string
 
+	maxWitnessFailures uint16
+	prometheusAddr     string
+
+	pruningSize uint16
+	pruningAge  time.Duration
+
 	verbose bool
 
 	primaryKey   = []byte("primary")
@@ -80,6 +91,18 @@ func init() {
 		"connect to a CometBFT node at this address")
 	LightCmd.Flags().StringVarP(&witnessAddrsJoined, "witnesses", "w", "",
 		"CometBFT nodes to cross-check the primary node, comma-separated")
+	LightCmd.Flags().StringVar(&witnessPoolJoined, "witness-pool", "",
+		"CometBFT nodes replacing the witnesses which misbehave or fail to respond, comma-separated")
+	LightCmd.Flags().StringVar(&evidenceRecvJoined, "evidence-receivers", "",
+		"CometBFT nodes to submit the evidence of the detected attacks to, comma-separated")
+	LightCmd.Flags().Uint16Var(&maxWitnessFailures, "max-witness-failures", 3,
+		"number of requests in a row a witness can fail before being replaced by a node of the witness pool")
+	LightCmd.Flags().Uint16Var(&pruningSize, "pruning-size", 1000,
+		"maximum number of trusted headers to keep in the store (0 to keep them all)")
+	LightCmd.Flags().DurationVar(&pruningAge, "pruning-age", 0,
+		"maximum age of the trusted headers to keep in the store, relative to the latest one (0 to disable)")
+	LightCmd.Flags().StringVar(&prometheusAddr, "prometheus-laddr", "",
+		"serve the Prometheus metrics of the light client on the given address, e.g. :26660 (disabled if empty)")
 	LightCmd.Flags().StringVar(&home, "home-dir", os.ExpandEnv(filepath.Join("$HOME", ".cometbft-light")),
 		"specify the home directory")
 	LightCmd.Flags().IntVar(
@@ -146,8 +169,22 @@ func runProxy(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("can't parse trust level: %w", err)
 	}
 
+	witnessPool, err := httpProviders(witnessPoolJoined)
+	if err != nil {
+		return fmt.Errorf("can't create witness pool: %w", err)
+	}
+	evidenceReceivers, err := httpProviders(evidenceRecvJoined)
+	if err != nil {
+		return fmt.Errorf("can't create evidence receivers: %w", err)
+	}
+
 	options := []light.Option{
 		light.Logger(logger),
+		light.WitnessPool(witnessPool),
+		light.EvidenceReceivers(evidenceReceivers),
+		light.MaxWitnessFailures(maxWitnessFailures),
+		light.PruningSize(pruningSize),
+		light.PruningAge(pruningAge),
 		light.ConfirmationFunction(func(action string) bool {
 			fmt.Println(action)
 			scanner := bufio.NewScanner(os.Stdin)
@@ -166,6 +203,21 @@ func runProxy(_ *cobra.Command, args []string) error {
 		}),
 	}
 
+	if prometheusAddr != "" {
+		options = append(options, light.WithMetrics(light.PrometheusMetrics("cometbft", "chain_id", chainID)))
+		go func() {
+			srv := &http.Server{
+				Addr:              prometheusAddr,
+				Handler:           promhttp.Handler(),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			logger.Info("Serving Prometheus metrics...", "laddr", prometheusAddr)
+			if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+				logger.Error("Prometheus HTTP server ListenAndServe", "err", err)
+			}
+		}()
+	}
+
 	if sequential {
 		options = append(options, light.SequentialVerification())
 	} else {
@@ -231,6 +283,22 @@ func runProxy(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+// httpProviders returns the providers of the comma-separated addresses.
+func httpProviders(addrsJoined string) ([]provider.Provider, error) {
+	providers := []provider.Provider{}
+	if addrsJoined == "" {
+		return providers, nil
+	}
+	for _, addr := range strings.Split(addrsJoined, ",") {
+		p, err := lhttp.New(chainID, addr)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", addr, err)
+		}
+		providers = append(providers, p)
+	}
+	return providers, nil
+}
+
 func checkForExistingProviders(db dbm.DB) (string, []string, error) {
 	primaryBytes, err := db.Get(primaryKey)
 	if err != nil {
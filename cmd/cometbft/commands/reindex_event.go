@@ -9,7 +9,6 @@ import (
 
 	dbm "github.com/cometbft/cometbft-db"
 
-	abcitypes "github.com/cometbft/cometbft/abci/types"
 	cmtcfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/libs/progressbar"
 	"github.com/cometbft/cometbft/state"
@@ -18,7 +17,6 @@ import (
 	"github.com/cometbft/cometbft/state/indexer/sink/psql"
 	"github.com/cometbft/cometbft/state/txindex"
 	"github.com/cometbft/cometbft/state/txindex/kv"
-	"github.com/cometbft/cometbft/types"
 )
 
 const (
@@ -122,7 +120,11 @@ func loadEventSinks(cfg *cmtcfg.Config, chainID string) (indexer.BlockIndexer, t
 			return nil, nil, err
 		}
 
-		txIndexer := kv.NewTxIndex(store)
+		var options []kv.TxIndexOption
+		if cfg.Storage.Compression.Compresses(cmtcfg.CompressedStoreTxIndex) {
+			options = append(options, kv.WithCompression())
+		}
+		txIndexer := kv.NewTxIndex(store, options...)
 		blockIndexer := blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
 		return blockIndexer, txIndexer, nil
 	default:
@@ -160,37 +162,8 @@ func eventReIndex(cmd *cobra.Command, args eventReIndexArgs) error {
 				return fmt.Errorf("not able to load ABCI Response at height %d from the statestore", height)
 			}
 
-			e := types.EventDataNewBlockEvents{
-				Height: height,
-				Events: resp.Events,
-			}
-
-			numTxs := len(resp.TxResults)
-
-			var batch *txindex.Batch
-			if numTxs > 0 {
-				batch = txindex.NewBatch(int64(numTxs))
-
-				for idx, txResult := range resp.TxResults {
-					tr := abcitypes.TxResult{
-						Height: height,
-						Index:  uint32(idx),
-						Tx:     block.Txs[idx],
-						Result: *txResult,
-					}
-
-					if err = batch.Add(&tr); err != nil {
-						return fmt.Errorf("adding tx to batch: %w", err)
-					}
-				}
-
-				if err := args.txIndexer.AddBatch(batch); err != nil {
-					return fmt.Errorf("tx event re-index at height %d failed: %w", height, err)
-				}
-			}
-
-			if err := args.blockIndexer.Index(e); err != nil {
-				return fmt.Errorf("block event re-index at height %d failed: %w", height, err)
+			if err := txindex.IndexBlockResults(args.blockIndexer, args.txIndexer, height, block.Txs, resp); err != nil {
+				return err
 			}
 		}
 
@@ -86,7 +86,7 @@ func resetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, logg
 		removeAddrBook(addrBookFile, logger)
 	}
 
-	if err := os.RemoveAll(dbDir); err == nil {
+	if err := removeDBDir(dbDir); err == nil {
 		logger.Info("Removed all blockchain history", "dir", dbDir)
 	} else {
 		logger.Error("Error removing all blockchain history", "dir", dbDir, "err", err)
@@ -175,6 +175,29 @@ func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) {
 	}
 }
 
+// removeDBDir removes the databases, except the one of the address book if
+// it is kept.
+func removeDBDir(dbDir string) error {
+	if !keepAddrBook {
+		return os.RemoveAll(dbDir)
+	}
+	entries, err := os.ReadDir(dbDir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Name() == "addrbook.db" {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dbDir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func removeAddrBook(addrBookFile string, logger log.Logger) {
 	if err := os.Remove(addrBookFile); err == nil {
 		logger.Info("Removed existing address book", "file", addrBookFile)
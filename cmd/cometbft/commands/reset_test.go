@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -31,6 +32,25 @@ func Test_ResetAll(t *testing.T) {
 	require.Equal(t, int64(0), pv.LastSignState.Height)
 }
 
+func Test_ResetAllKeepAddrBook(t *testing.T) {
+	config := cfg.TestConfig()
+	dir := t.TempDir()
+	config.SetRoot(dir)
+	cfg.EnsureRoot(dir)
+	require.NoError(t, initFilesWithConfig(config))
+	for _, db := range []string{"addrbook.db", "blockstore.db"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(config.DBDir(), db), 0o700))
+	}
+
+	keepAddrBook = true
+	t.Cleanup(func() { keepAddrBook = false })
+	require.NoError(t, resetAll(config.DBDir(), config.P2P.AddrBookFile(), config.PrivValidatorKeyFile(),
+		config.PrivValidatorStateFile(), logger))
+	require.DirExists(t, filepath.Join(config.DBDir(), "addrbook.db"))
+	require.NoDirExists(t, filepath.Join(config.DBDir(), "blockstore.db"))
+	require.FileExists(t, config.PrivValidatorStateFile())
+}
+
 func Test_ResetState(t *testing.T) {
 	config := cfg.TestConfig()
 	dir := t.TempDir()
@@ -6,8 +6,6 @@ import (
 
 	"github.com/spf13/cobra"
 
-	dbm "github.com/cometbft/cometbft-db"
-
 	cfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/libs/os"
 	"github.com/cometbft/cometbft/state"
@@ -67,14 +65,12 @@ func RollbackState(config *cfg.Config, removeBlock bool) (int64, []byte, error)
 }
 
 func loadStateAndBlockStore(config *cfg.Config) (*store.BlockStore, state.Store, error) {
-	dbType := dbm.BackendType(config.DBBackend)
-
 	if !os.FileExists(filepath.Join(config.DBDir(), "blockstore.db")) {
 		return nil, nil, fmt.Errorf("no blockstore found in %v", config.DBDir())
 	}
 
 	// Get BlockStore
-	blockStoreDB, err := dbm.NewDB("blockstore", dbType, config.DBDir())
+	blockStoreDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "blockstore", Config: config})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -85,13 +81,23 @@ func loadStateAndBlockStore(config *cfg.Config) (*store.BlockStore, state.Store,
 	}
 
 	// Get StateStore
-	stateDB, err := dbm.NewDB("state", dbType, config.DBDir())
+	stateDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "state", Config: config})
 	if err != nil {
 		return nil, nil, err
 	}
-	stateStore := state.NewStore(stateDB, state.StoreOptions{
-		DiscardABCIResponses: config.Storage.DiscardABCIResponses,
+	backend := config.Storage.StateStore
+	if backend == "" {
+		backend = state.DefaultStoreBackend
+	}
+	stateStore, err := state.NewStoreFromBackend(backend, stateDB, state.StoreOptions{
+		DiscardABCIResponses:  config.Storage.DiscardABCIResponses,
+		VerifyChecksums:       config.Storage.VerifyStateChecksums,
+		CompressABCIResponses: config.Storage.Compression.Compresses(cfg.CompressedStoreABCIResponses),
+		BackendConn:           config.Storage.StateStorePsqlConn,
 	})
+	if err != nil {
+		return nil, nil, err
+	}
 
 	return blockStore, stateStore, nil
 }
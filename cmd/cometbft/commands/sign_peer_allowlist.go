@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cometbft/cometbft/libs/tempfile"
+	"github.com/cometbft/cometbft/p2p"
+)
+
+var peerAllowlistKeyFile string
+
+func init() {
+	SignPeerAllowlistCmd.Flags().StringVar(&peerAllowlistKeyFile, "key-file", "",
+		"the node key file of the key signing the list (defaults to the node key of this node)")
+}
+
+// SignPeerAllowlistCmd writes a peer allowlist file signed with a node key.
+var SignPeerAllowlistCmd = &cobra.Command{
+	Use:   "sign-peer-allowlist [file] [node ID]...",
+	Short: "Write a signed list of the only peers allowed to connect to a node",
+	Long: `
+Write the peer allowlist file allowing the given node IDs, signed with an ed25519
+node key. The nodes which set p2p.peer_allowlist_file to the file, and
+p2p.peer_allowlist_key to the printed public key, only connect to the listed
+peers.
+
+The running nodes reload the file when it is replaced, and disconnect the peers
+removed from the list.
+`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keyFile := peerAllowlistKeyFile
+		if keyFile == "" {
+			keyFile = config.NodeKeyFile()
+		}
+		nodeKey, err := p2p.LoadNodeKey(keyFile)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]p2p.ID, len(args)-1)
+		for i, id := range args[1:] {
+			ids[i] = p2p.ID(id)
+		}
+		content, err := p2p.SignPeerAllowlist(nodeKey.PrivKey, ids)
+		if err != nil {
+			return err
+		}
+		if err := tempfile.WriteFileAtomic(args[0], content, 0o644); err != nil {
+			return err
+		}
+
+		fmt.Printf("peer_allowlist_key: %X\n", nodeKey.PubKey().Bytes())
+		return nil
+	},
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/statesync"
+)
+
+var snapshotExportHeight uint64
+
+func init() {
+	SnapshotExportCmd.Flags().Uint64Var(&snapshotExportHeight, "height", 0,
+		"the height of the snapshot to export (0 means the latest snapshot of the application)")
+	SnapshotExportCmd.Flags().String("proxy_app", config.ProxyApp,
+		"proxy app address of the application serving the snapshots, or one of: 'kvstore',"+
+			" 'persistent_kvstore' or 'noop' for local testing.")
+
+	SnapshotCmd.AddCommand(SnapshotExportCmd)
+}
+
+// SnapshotCmd groups the commands handling the state sync snapshots of the
+// application.
+var SnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "handle the state sync snapshots of the application",
+}
+
+// SnapshotExportCmd exports a snapshot of the application to a local
+// directory, for other nodes to state sync from.
+var SnapshotExportCmd = &cobra.Command{
+	Use:   "export [output-directory]",
+	Short: "export a state sync snapshot of the application to a directory",
+	Long: `
+export writes a state sync snapshot of the application, its latest one by default, to
+the output directory, which must not exist. The application is queried for the snapshot
+and its chunks over the ABCI snapshot connection, so it must be running if it is not
+built in.
+
+Other nodes can restore the snapshot, e.g. after copying the directory, or a tar
+archive of its content, from object storage, by setting statesync.local_snapshot to
+its path. Their light client still verifies the app hash of the snapshot.
+`,
+	Example: `
+	cometbft snapshot export /path/to/snapshot --proxy_app tcp://127.0.0.1:26658
+	cometbft snapshot export /path/to/snapshot --height 1000
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		proxyApp := proxy.NewAppConns(proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+			proxy.NopMetrics())
+		proxyApp.SetLogger(logger.With("module", "proxy"))
+		if err := proxyApp.Start(); err != nil {
+			return fmt.Errorf("failed to start proxy app: %w", err)
+		}
+		defer func() { _ = proxyApp.Stop() }()
+
+		snapshot, err := statesync.ExportSnapshot(context.Background(), proxyApp.Snapshot(),
+			snapshotExportHeight, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to export snapshot: %w", err)
+		}
+		fmt.Printf("Exported snapshot at height %d (format %d, %d chunks) to %s\n",
+			snapshot.Height, snapshot.Format, snapshot.Chunks, args[0])
+		return nil
+	},
+}
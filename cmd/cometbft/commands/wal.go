@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cs "github.com/cometbft/cometbft/consensus"
+	auto "github.com/cometbft/cometbft/libs/autofile"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+var (
+	walFromHeight int64
+	walToHeight   int64
+	walTypes      string
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{WALDecodeCmd, WALReplayCmd} {
+		cmd.Flags().Int64Var(&walFromHeight, "from-height", 0,
+			"the lowest height of the messages (0 means no bound for decode, and the last height of the node for replay)")
+		cmd.Flags().Int64Var(&walToHeight, "to-height", 0,
+			"the highest height of the messages (0 means no bound)")
+		cmd.Flags().StringVar(&walTypes, "types", "",
+			"comma-separated types of the messages: round_state, proposal, block_part, vote, timeout, end_height, other (empty means all types)")
+	}
+
+	WALReplayCmd.Flags().String("proxy_app", config.ProxyApp,
+		"proxy app address of the application to replay the blocks on, or one of: 'kvstore',"+
+			" 'persistent_kvstore' or 'noop' for local testing.")
+
+	WALCmd.AddCommand(WALDecodeCmd)
+	WALCmd.AddCommand(WALReplayCmd)
+}
+
+// WALCmd groups the commands inspecting the consensus WAL.
+var WALCmd = &cobra.Command{
+	Use:   "wal",
+	Short: "inspect the consensus write-ahead log",
+}
+
+// WALDecodeCmd decodes the messages of the consensus WAL to JSON.
+var WALDecodeCmd = &cobra.Command{
+	Use:   "decode [wal-file]",
+	Short: "decode the messages of the consensus WAL to JSON",
+	Long: `
+decode prints the messages of the consensus WAL, one JSON object per line, filtered by
+height and type. The messages of all the files of the WAL are decoded, starting from
+the oldest one. The WAL file defaults to the one of the node.
+`,
+	Example: `
+	cometbft wal decode
+	cometbft wal decode --from-height 100 --to-height 101 --types proposal,vote
+	cometbft wal decode /path/to/cs.wal/wal
+	`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dec, closer, err := openWALFilterDecoder(args, walFromHeight)
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+
+		for {
+			msg, _, err := dec.Decode()
+			if errors.Is(err, io.EOF) {
+				return nil
+			} else if err != nil {
+				return fmt.Errorf("failed to decode message: %w", err)
+			}
+			bz, err := cmtjson.Marshal(msg)
+			if err != nil {
+				return fmt.Errorf("failed to marshal message: %w", err)
+			}
+			if _, err := fmt.Fprintf(os.Stdout, "%s\n", bz); err != nil {
+				return err
+			}
+		}
+	},
+}
+
+// WALReplayCmd re-feeds the messages of the consensus WAL into a consensus
+// state.
+var WALReplayCmd = &cobra.Command{
+	Use:   "replay [wal-file]",
+	Short: "re-feed the messages of the consensus WAL into a consensus state",
+	Long: `
+replay feeds the messages of the consensus WAL, filtered by height and type, into a
+consensus state at the --from-height, logging the messages and the steps of the
+consensus, for debugging. It then prints the round state reached.
+
+The consensus state is built from in-memory copies of the blocks of the node, which are
+replayed on the application of the node with a handshake, so the application must be
+at its genesis, e.g. a fresh instance of it. The data of the node is not modified, but
+the node must be stopped while running this command. The messages that are not at the
+height of the consensus state are ignored, so a replay past the --from-height only
+continues if the WAL commits the block of the height.
+`,
+	Example: `
+	cometbft wal replay --proxy_app kvstore
+	cometbft wal replay --from-height 100 --proxy_app tcp://127.0.0.1:36658
+	`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		blockStore, stateStore, err := loadStateAndBlockStore(config)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = blockStore.Close()
+			_ = stateStore.Close()
+		}()
+		height := walFromHeight
+		if height == 0 {
+			height = blockStore.Height() + 1
+		}
+
+		genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+		if err != nil {
+			return err
+		}
+		proxyApp := proxy.NewAppConns(proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+			proxy.NopMetrics())
+		proxyApp.SetLogger(logger.With("module", "proxy"))
+		if err := proxyApp.Start(); err != nil {
+			return fmt.Errorf("failed to start proxy app: %w", err)
+		}
+		defer func() { _ = proxyApp.Stop() }()
+
+		consensusState, err := cs.NewWALReplayState(context.Background(), config.Consensus, blockStore, genDoc,
+			proxyApp, height, logger.With("module", "consensus"))
+		if err != nil {
+			return err
+		}
+
+		dec, closer, err := openWALFilterDecoder(args, height)
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+		n, err := consensusState.ReplayWAL(dec)
+		if err != nil {
+			return fmt.Errorf("failed to replay message %d: %w", n+1, err)
+		}
+
+		rs, err := consensusState.GetRoundStateSimpleJSON()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Replayed %d messages, reaching round state:\n%s\n", n, rs)
+		return nil
+	},
+}
+
+// openWALFilterDecoder opens the WAL file given in args, or the one of the
+// node, decoding the messages matching the flags from the given height.
+func openWALFilterDecoder(args []string, fromHeight int64) (*cs.WALFilterDecoder, io.Closer, error) {
+	msgTypes, err := cs.ParseWALMessageTypes(walTypes)
+	if err != nil {
+		return nil, nil, err
+	}
+	filter := cs.WALFilter{FromHeight: fromHeight, ToHeight: walToHeight, Types: msgTypes}
+	if err := filter.ValidateBasic(); err != nil {
+		return nil, nil, err
+	}
+
+	walFile := config.Consensus.WalFile()
+	if len(args) > 0 {
+		walFile = args[0]
+	}
+	if _, err := os.Stat(walFile); err != nil {
+		return nil, nil, fmt.Errorf("failed to open WAL file: %w", err)
+	}
+	group, err := auto.OpenGroup(walFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open WAL file: %w", err)
+	}
+	gr, err := group.NewReader(group.MinIndex())
+	if err != nil {
+		_ = group.Head.Close()
+		return nil, nil, fmt.Errorf("failed to open WAL file: %w", err)
+	}
+	return cs.NewWALFilterDecoder(gr, filter), closerFunc(func() error {
+		_ = gr.Close()
+		return group.Head.Close()
+	}), nil
+}
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error { return f() }
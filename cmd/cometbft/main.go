@@ -24,10 +24,19 @@ func main() {
 		cmd.TestnetFilesCmd,
 		cmd.ShowNodeIDCmd,
 		cmd.GenNodeKeyCmd,
+		cmd.GenThresholdKeysCmd,
 		cmd.VersionCmd,
 		cmd.RollbackStateCmd,
 		cmd.CompactGoLevelDBCmd,
+		cmd.BackupCmd,
+		cmd.IndexCmd,
+		cmd.DoctorCmd,
+		cmd.AuditCmd,
 		cmd.InspectCmd,
+		cmd.WALCmd,
+		cmd.SnapshotCmd,
+		cmd.DNSSeedRecordsCmd,
+		cmd.SignPeerAllowlistCmd,
 		debug.DebugCmd,
 		cli.NewCompletionCmd(rootCmd, true),
 	)
@@ -4,13 +4,18 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/cometbft/cometbft/libs/atrest"
 	cmterrors "github.com/cometbft/cometbft/types/errors"
 
 	"github.com/cometbft/cometbft/version"
@@ -45,6 +50,37 @@ const (
 
 	DefaultPruningInterval = 10 * time.Second
 
+	DefaultStorageForecastInterval = time.Minute
+	DefaultStorageForecastWindow   = 24 * time.Hour
+
+	MempoolTypeFlood    = "flood"
+	MempoolTypePriority = "priority"
+
+	MempoolGossipPush = "push"
+	MempoolGossipPull = "pull"
+
+	WALCompressionNone   = "none"
+	WALCompressionSnappy = "snappy"
+	WALCompressionZstd   = "zstd"
+
+	P2PTransportTCP  = "tcp"
+	P2PTransportQUIC = "quic"
+
+	P2PNATNone = "none"
+	P2PNATAny  = "any"
+	P2PNATUPnP = "upnp"
+	P2PNATPMP  = "pmp"
+
+	SlowClientPolicyClose = "close"
+	SlowClientPolicyDrop  = "drop"
+
+	P2PCompressionNone   = "none"
+	P2PCompressionSnappy = "snappy"
+	P2PCompressionZstd   = "zstd"
+
+	P2PAddrBookStoreFile = "file"
+	P2PAddrBookStoreDB   = "db"
+
 	v0 = "v0"
 	v1 = "v1"
 	v2 = "v2"
@@ -131,6 +167,7 @@ func (cfg *Config) SetRoot(root string) *Config {
 	cfg.P2P.RootDir = root
 	cfg.Mempool.RootDir = root
 	cfg.Consensus.RootDir = root
+	cfg.GRPC.Privileged.RootDir = root
 	return cfg
 }
 
@@ -164,6 +201,9 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Storage.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [storage] section: %w", err)
 	}
+	if err := cfg.TxIndex.ValidateBasic(); err != nil {
+		return ErrInSection{Section: "tx_index", Err: err}
+	}
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return ErrInSection{Section: "instrumentation", Err: err}
 	}
@@ -201,6 +241,7 @@ type BaseConfig struct { //nolint: maligned
 	// * goleveldb (github.com/syndtr/goleveldb - most popular implementation)
 	//   - pure go
 	//   - stable
+	//   - supports all the features of the node, and cross-compiles (default)
 	// * cleveldb (uses levigo wrapper)
 	//   - fast
 	//   - requires gcc
@@ -216,6 +257,8 @@ type BaseConfig struct { //nolint: maligned
 	// * badgerdb (uses github.com/dgraph-io/badger)
 	//   - EXPERIMENTAL
 	//   - use badgerdb build tag (go build -tags badgerdb)
+	// The node warns on startup if the backend requires cgo (cleveldb, rocksdb)
+	// or does not support a configured storage feature.
 	DBBackend string `mapstructure:"db_backend"`
 
 	// Database directory
@@ -240,6 +283,12 @@ type BaseConfig struct { //nolint: maligned
 	// connections from an external PrivValidator process
 	PrivValidatorListenAddr string `mapstructure:"priv_validator_laddr"`
 
+	// URL of an external, strongly-consistent store of the highest height,
+	// round and step signed by the validator, shared by its instances in an
+	// active-passive setup so that they can't double sign on failover. Only
+	// used by the file-based private validator.
+	PrivValidatorWatermarkStore string `mapstructure:"priv_validator_watermark_store"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node_key_file"`
 
@@ -317,6 +366,13 @@ func (cfg BaseConfig) ValidateBasic() error {
 	default:
 		return errors.New("unknown log_format (must be 'plain' or 'json')")
 	}
+
+	if cfg.PrivValidatorWatermarkStore != "" {
+		u, err := url.Parse(cfg.PrivValidatorWatermarkStore)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return errors.New("priv_validator_watermark_store must be an http or https URL")
+		}
+	}
 	return nil
 }
 
@@ -342,9 +398,6 @@ type RPCConfig struct {
 	// A list of non simple headers the client is allowed to use with cross-domain requests.
 	CORSAllowedHeaders []string `mapstructure:"cors_allowed_headers"`
 
-	// Activate unsafe RPC commands like /dial_persistent_peers and /unsafe_flush_mempool
-	Unsafe bool `mapstructure:"unsafe"`
-
 	// Maximum number of simultaneous connections (including WebSocket).
 	// If you want to accept a larger number than the default, make sure
 	// you increase your OS limits.
@@ -358,13 +411,13 @@ type RPCConfig struct {
 	// of broadcast_tx_commit calls per block.
 	MaxSubscriptionClients int `mapstructure:"max_subscription_clients"`
 
-	// Maximum number of unique queries a given client can /subscribe to. If
-	// you're using /broadcast_tx_commit, set to the estimated maximum number
-	// of broadcast_tx_commit calls per block.
+	// Maximum number of unique queries a given client, i.e. a WebSocket
+	// connection, can /subscribe to. If you're using /broadcast_tx_commit, set
+	// to the estimated maximum number of broadcast_tx_commit calls per block.
 	MaxSubscriptionsPerClient int `mapstructure:"max_subscriptions_per_client"`
 
 	// The number of events that can be buffered per subscription before
-	// returning `ErrOutOfCapacity`.
+	// applying SlowClientPolicy.
 	SubscriptionBufferSize int `mapstructure:"experimental_subscription_buffer_size"`
 
 	// The maximum number of responses that can be buffered per WebSocket
@@ -377,27 +430,116 @@ type RPCConfig struct {
 	// connections may be dropped unnecessarily.
 	WebSocketWriteBufferSize int `mapstructure:"experimental_websocket_write_buffer_size"`
 
-	// If a WebSocket client cannot read fast enough, at present we may
-	// silently drop events instead of generating an error or disconnecting the
-	// client.
-	//
-	// Enabling this parameter will cause the WebSocket connection to be closed
-	// instead if it cannot read fast enough, allowing for greater
-	// predictability in subscription behavior.
-	CloseOnSlowClient bool `mapstructure:"experimental_close_on_slow_client"`
-
-	// How long to wait for a tx to be committed during /broadcast_tx_commit
+	// What happens to the subscriptions of a WebSocket client not reading
+	// fast enough, i.e. whose subscription buffer is full or whose events
+	// cannot be written within 10s:
+	//   - "close": the subscription is closed with an error.
+	//   - "drop": the events are dropped, and the subscription kept.
+	// Either way, the event bus never waits for a slow client.
+	SlowClientPolicy string `mapstructure:"websocket_slow_client_policy"`
+
+	// Interval of the pings sent by the server to the WebSocket clients. A
+	// client not answering with a pong, nor sending anything, for 10/9 of the
+	// interval is disconnected.
+	WebSocketPingInterval time.Duration `mapstructure:"websocket_ping_interval"`
+
+	// How long to wait for a tx to be committed during /broadcast_tx_commit,
+	// unless a shorter timeout is requested.
 	// WARNING: Using a value larger than 10s will result in increasing the
 	// global HTTP write timeout, which applies to all connections and endpoints.
 	// See https://github.com/tendermint/tendermint/issues/3435
 	TimeoutBroadcastTxCommit time.Duration `mapstructure:"timeout_broadcast_tx_commit"`
 
+	// Maximum number of idempotency keys remembered by the /broadcast_tx_*
+	// endpoints. A request repeating a remembered key returns the outcome of
+	// the original request instead of broadcasting the tx again.
+	// 0 disables idempotency keys.
+	IdempotencyKeyCacheSize int `mapstructure:"idempotency_key_cache_size"`
+
+	// How long an idempotency key is remembered.
+	IdempotencyKeyTTL time.Duration `mapstructure:"idempotency_key_ttl"`
+
+	// Maximum number of results returned by /tx_search and /block_search.
+	// A search exceeding it returns a job ID instead of results, and the
+	// results are written to a temporary file in the background, from which
+	// they can be paginated with /search_job.
+	// 0 means no limit.
+	MaxSearchResults int `mapstructure:"max_search_results"`
+
+	// Maximum number of search jobs kept at the same time.
+	MaxSearchJobs int `mapstructure:"max_search_jobs"`
+
+	// How long the results of a search job are kept.
+	SearchJobTTL time.Duration `mapstructure:"search_job_ttl"`
+
+	// Maximum length, in bytes, of the queries of /subscribe, /tx_search,
+	// /block_search and /unconfirmed_txs. 0 means no limit.
+	MaxQueryLength int `mapstructure:"max_query_length"`
+
+	// Maximum number of conditions of the queries, e.g. 2 for
+	// "tm.event = 'Tx' AND tx.height > 5". 0 means no limit.
+	MaxQueryConditions int `mapstructure:"max_query_conditions"`
+
+	// The /tx_search and /block_search requests taking longer are logged,
+	// with the query, the number of results and the number of index keys
+	// scanned, to find the expensive queries. 0 disables the log.
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"`
+
+	// Maximum number of requests per second of each client IP to each
+	// method, above which the requests are rejected until the client slows
+	// down. 0 disables the rate limiting.
+	RateLimit float64 `mapstructure:"rate_limit"`
+
+	// Maximum number of requests of a client IP to a method accepted at once,
+	// before being limited to the rate. 0 means the rate, rounded up.
+	RateLimitBurst int `mapstructure:"rate_limit_burst"`
+
+	// Comma separated list of "<method>=<rate>" entries overriding the
+	// rate_limit of some methods, e.g. "broadcast_tx_commit=1,tx_search=0.5".
+	// A rate of 0 disables the limit of the method.
+	MethodRateLimits string `mapstructure:"method_rate_limits"`
+
+	// Client IPs and CIDR ranges, e.g. "10.0.0.0/8", not rate limited.
+	RateLimitAllowlist []string `mapstructure:"rate_limit_allowlist"`
+
+	// Path to a JSON file of API keys, and of JWT issuers, each giving access
+	// to some methods. If set, the clients of the RPC server and of the gRPC
+	// server must send a key, or a JWT, giving access to the methods they
+	// call, except the anonymous ones.
+	// Might be either absolute path or path related to CometBFT's config
+	// directory.
+	APIKeys string `mapstructure:"api_keys_file"`
+
+	// The methods callable without an API key, if api_keys_file is set.
+	AnonymousMethods []string `mapstructure:"anonymous_methods"`
+
+	// Maximum number of requests in a JSON-RPC batch request. 0 means no
+	// limit.
+	MaxRequestBatchSize int `mapstructure:"max_request_batch_size"`
+
+	// Maximum number of the requests of a JSON-RPC batch request executed
+	// concurrently. The responses are in the order of the requests
+	// regardless. 1 executes the requests one by one, in order.
+	BatchRequestConcurrency int `mapstructure:"batch_request_concurrency"`
+
 	// Maximum size of request body, in bytes
 	MaxBodyBytes int64 `mapstructure:"max_body_bytes"`
 
 	// Maximum size of request header, in bytes
 	MaxHeaderBytes int `mapstructure:"max_header_bytes"`
 
+	// Compress the responses with gzip or deflate for the clients accepting
+	// it in the Accept-Encoding header of their requests.
+	CompressResponses bool `mapstructure:"compress_responses"`
+
+	// Minimum number of peers of a healthy node, as reported by
+	// /health?verbose=true. 0 means no minimum.
+	HealthMinPeers int `mapstructure:"health_min_peers"`
+
+	// Maximum number of blocks the indexer of a healthy node lags behind the
+	// block store, as reported by /health?verbose=true. 0 means no maximum.
+	HealthMaxIndexerLag int64 `mapstructure:"health_max_indexer_lag"`
+
 	// The path to a file containing certificate that is used to create the HTTPS server.
 	// Might be either absolute path or path related to CometBFT's config directory.
 	//
@@ -429,7 +571,6 @@ func DefaultRPCConfig() *RPCConfig {
 		CORSAllowedMethods: []string{http.MethodHead, http.MethodGet, http.MethodPost},
 		CORSAllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "X-Server-Time"},
 
-		Unsafe:             false,
 		MaxOpenConnections: 900,
 
 		MaxSubscriptionClients:    100,
@@ -437,10 +578,39 @@ func DefaultRPCConfig() *RPCConfig {
 		SubscriptionBufferSize:    defaultSubscriptionBufferSize,
 		TimeoutBroadcastTxCommit:  10 * time.Second,
 		WebSocketWriteBufferSize:  defaultSubscriptionBufferSize,
+		SlowClientPolicy:          SlowClientPolicyClose,
+		WebSocketPingInterval:     27 * time.Second,
+
+		IdempotencyKeyCacheSize: 10000,
+		IdempotencyKeyTTL:       time.Hour,
+
+		MaxSearchResults: 0,
+		MaxSearchJobs:    10,
+		SearchJobTTL:     10 * time.Minute,
+
+		MaxQueryLength:     512,
+		MaxQueryConditions: 0,
+		SlowQueryThreshold: time.Second,
+
+		RateLimit:          0,
+		RateLimitBurst:     0,
+		MethodRateLimits:   "",
+		RateLimitAllowlist: []string{},
+
+		APIKeys:          "",
+		AnonymousMethods: []string{"health"},
+
+		MaxRequestBatchSize:     0,
+		BatchRequestConcurrency: 1,
 
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
 
+		CompressResponses: true,
+
+		HealthMinPeers:      1,
+		HealthMaxIndexerLag: 100,
+
 		TLSCertFile: "",
 		TLSKeyFile:  "",
 	}
@@ -450,7 +620,6 @@ func DefaultRPCConfig() *RPCConfig {
 func TestRPCConfig() *RPCConfig {
 	cfg := DefaultRPCConfig()
 	cfg.ListenAddress = "tcp://127.0.0.1:36657"
-	cfg.Unsafe = true
 	return cfg
 }
 
@@ -475,18 +644,138 @@ func (cfg *RPCConfig) ValidateBasic() error {
 			cfg.SubscriptionBufferSize,
 		)
 	}
+	switch cfg.SlowClientPolicy {
+	case SlowClientPolicyClose, SlowClientPolicyDrop:
+	default:
+		return fmt.Errorf("unknown websocket_slow_client_policy %q, expected %q or %q",
+			cfg.SlowClientPolicy, SlowClientPolicyClose, SlowClientPolicyDrop)
+	}
+	if cfg.WebSocketPingInterval <= 0 {
+		return errors.New("websocket_ping_interval must be positive")
+	}
 	if cfg.TimeoutBroadcastTxCommit < 0 {
 		return cmterrors.ErrNegativeField{Field: "timeout_broadcast_tx_commit"}
 	}
+	if cfg.IdempotencyKeyCacheSize < 0 {
+		return cmterrors.ErrNegativeField{Field: "idempotency_key_cache_size"}
+	}
+	if cfg.IdempotencyKeyTTL < 0 {
+		return cmterrors.ErrNegativeField{Field: "idempotency_key_ttl"}
+	}
+	if cfg.MaxSearchResults < 0 {
+		return cmterrors.ErrNegativeField{Field: "max_search_results"}
+	}
+	if cfg.MaxSearchJobs < 0 {
+		return cmterrors.ErrNegativeField{Field: "max_search_jobs"}
+	}
+	if cfg.SearchJobTTL < 0 {
+		return cmterrors.ErrNegativeField{Field: "search_job_ttl"}
+	}
+	if cfg.MaxQueryLength < 0 {
+		return cmterrors.ErrNegativeField{Field: "max_query_length"}
+	}
+	if cfg.MaxQueryConditions < 0 {
+		return cmterrors.ErrNegativeField{Field: "max_query_conditions"}
+	}
+	if cfg.SlowQueryThreshold < 0 {
+		return cmterrors.ErrNegativeField{Field: "slow_query_threshold"}
+	}
+	if cfg.MaxSearchResults > 0 && cfg.MaxSearchJobs == 0 {
+		return errors.New("max_search_jobs must be greater than 0 when max_search_results is set")
+	}
+	if cfg.RateLimit < 0 {
+		return cmterrors.ErrNegativeField{Field: "rate_limit"}
+	}
+	if cfg.RateLimitBurst < 0 {
+		return cmterrors.ErrNegativeField{Field: "rate_limit_burst"}
+	}
+	if _, err := parseMethodRateLimits(cfg.MethodRateLimits); err != nil {
+		return fmt.Errorf("invalid method_rate_limits: %w", err)
+	}
+	for _, entry := range cfg.RateLimitAllowlist {
+		if _, _, err := net.ParseCIDR(entry); err != nil && net.ParseIP(entry) == nil {
+			return fmt.Errorf("invalid rate_limit_allowlist entry %q: expected an IP or a CIDR range", entry)
+		}
+	}
+	if cfg.MaxRequestBatchSize < 0 {
+		return cmterrors.ErrNegativeField{Field: "max_request_batch_size"}
+	}
+	if cfg.BatchRequestConcurrency < 1 {
+		return errors.New("batch_request_concurrency must be at least 1")
+	}
 	if cfg.MaxBodyBytes < 0 {
 		return cmterrors.ErrNegativeField{Field: "max_body_bytes"}
 	}
 	if cfg.MaxHeaderBytes < 0 {
 		return cmterrors.ErrNegativeField{Field: "max_header_bytes"}
 	}
+	if cfg.HealthMinPeers < 0 {
+		return cmterrors.ErrNegativeField{Field: "health_min_peers"}
+	}
+	if cfg.HealthMaxIndexerLag < 0 {
+		return cmterrors.ErrNegativeField{Field: "health_max_indexer_lag"}
+	}
 	return nil
 }
 
+// IsRateLimitEnabled returns true if the requests of some methods are rate
+// limited.
+func (cfg *RPCConfig) IsRateLimitEnabled() bool {
+	if cfg.RateLimit > 0 {
+		return true
+	}
+	rates, _ := parseMethodRateLimits(cfg.MethodRateLimits)
+	for _, rate := range rates {
+		if rate > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// MethodRateLimitMap returns the rates, in requests per second, overriding
+// the rate_limit of the methods in method_rate_limits.
+func (cfg *RPCConfig) MethodRateLimitMap() map[string]float64 {
+	rates, _ := parseMethodRateLimits(cfg.MethodRateLimits)
+	return rates
+}
+
+// parseMethodRateLimits parses a comma separated list of "<method>=<rate>"
+// entries.
+func parseMethodRateLimits(s string) (map[string]float64, error) {
+	rates := make(map[string]float64)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		method, rate, ok := strings.Cut(entry, "=")
+		method = strings.TrimSpace(method)
+		if !ok || method == "" {
+			return nil, fmt.Errorf("entry %q: expected <method>=<rate>", entry)
+		}
+		r, err := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+		if err != nil || r < 0 {
+			return nil, fmt.Errorf("entry %q: rate must be a non-negative number", entry)
+		}
+		rates[method] = r
+	}
+	return rates, nil
+}
+
+// APIKeysFile returns the full path to the API keys file, or an empty string
+// if it is not set.
+func (cfg *RPCConfig) APIKeysFile() string {
+	if cfg.APIKeys == "" {
+		return ""
+	}
+	path := cfg.APIKeys
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(DefaultConfigDir, path), cfg.RootDir)
+}
+
 // IsCorsEnabled returns true if cross-origin resource sharing is enabled.
 func (cfg *RPCConfig) IsCorsEnabled() bool {
 	return len(cfg.CORSAllowedOrigins) != 0
@@ -536,6 +825,18 @@ type GRPCConfig struct {
 	// If no height is provided, the block results of the latest height are returned
 	BlockResultsService *GRPCBlockResultsServiceConfig `mapstructure:"block_results_service"`
 
+	// The gRPC mempool service streams the transactions accepted into the
+	// mempool, with the response of the application to CheckTx.
+	MempoolService *GRPCMempoolServiceConfig `mapstructure:"mempool_service"`
+
+	// The gRPC events service streams the events matching a query, as the
+	// /subscribe RPC endpoint.
+	EventsService *GRPCEventsServiceConfig `mapstructure:"events_service"`
+
+	// The gRPC query service provides the blocks, the indexed transactions,
+	// the block and transaction searches and the status of the node.
+	QueryService *GRPCQueryServiceConfig `mapstructure:"query_service"`
+
 	// The "privileged" section provides configuration for the gRPC server
 	// dedicated to privileged clients.
 	Privileged *GRPCPrivilegedConfig `mapstructure:"privileged"`
@@ -547,6 +848,9 @@ func DefaultGRPCConfig() *GRPCConfig {
 		VersionService:      DefaultGRPCVersionServiceConfig(),
 		BlockService:        DefaultGRPCBlockServiceConfig(),
 		BlockResultsService: DefaultGRPCBlockResultsServiceConfig(),
+		MempoolService:      DefaultGRPCMempoolServiceConfig(),
+		EventsService:       DefaultGRPCEventsServiceConfig(),
+		QueryService:        DefaultGRPCQueryServiceConfig(),
 		Privileged:          DefaultGRPCPrivilegedConfig(),
 	}
 }
@@ -557,6 +861,9 @@ func TestGRPCConfig() *GRPCConfig {
 		VersionService:      TestGRPCVersionServiceConfig(),
 		BlockService:        TestGRPCBlockServiceConfig(),
 		BlockResultsService: DefaultGRPCBlockResultsServiceConfig(),
+		MempoolService:      TestGRPCMempoolServiceConfig(),
+		EventsService:       TestGRPCEventsServiceConfig(),
+		QueryService:        TestGRPCQueryServiceConfig(),
 		Privileged:          TestGRPCPrivilegedConfig(),
 	}
 }
@@ -571,6 +878,12 @@ func (cfg *GRPCConfig) ValidateBasic() error {
 			)
 		}
 	}
+	if err := cfg.EventsService.ValidateBasic(); err != nil {
+		return fmt.Errorf("events_service: %w", err)
+	}
+	if err := cfg.Privileged.ValidateBasic(); err != nil {
+		return fmt.Errorf("privileged: %w", err)
+	}
 	return nil
 }
 
@@ -616,25 +929,114 @@ func TestGRPCBlockServiceConfig() *GRPCBlockServiceConfig {
 	}
 }
 
+type GRPCMempoolServiceConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+func DefaultGRPCMempoolServiceConfig() *GRPCMempoolServiceConfig {
+	return &GRPCMempoolServiceConfig{
+		Enabled: false,
+	}
+}
+
+func TestGRPCMempoolServiceConfig() *GRPCMempoolServiceConfig {
+	return &GRPCMempoolServiceConfig{
+		Enabled: true,
+	}
+}
+
+type GRPCEventsServiceConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	// Maximum number of events buffered per stream while its client does not
+	// read them. The clients may request a smaller buffer.
+	MaxBufferSize int `mapstructure:"max_buffer_size"`
+}
+
+func DefaultGRPCEventsServiceConfig() *GRPCEventsServiceConfig {
+	return &GRPCEventsServiceConfig{
+		Enabled:       false,
+		MaxBufferSize: defaultSubscriptionBufferSize,
+	}
+}
+
+func TestGRPCEventsServiceConfig() *GRPCEventsServiceConfig {
+	return &GRPCEventsServiceConfig{
+		Enabled:       true,
+		MaxBufferSize: defaultSubscriptionBufferSize,
+	}
+}
+
+// ValidateBasic performs basic validation.
+func (cfg *GRPCEventsServiceConfig) ValidateBasic() error {
+	if cfg.MaxBufferSize <= 0 {
+		return errors.New("max_buffer_size must be positive")
+	}
+	return nil
+}
+
+type GRPCQueryServiceConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+func DefaultGRPCQueryServiceConfig() *GRPCQueryServiceConfig {
+	return &GRPCQueryServiceConfig{
+		Enabled: false,
+	}
+}
+
+func TestGRPCQueryServiceConfig() *GRPCQueryServiceConfig {
+	return &GRPCQueryServiceConfig{
+		Enabled: true,
+	}
+}
+
 //-----------------------------------------------------------------------------
 // GRPCPrivilegedConfig
 
 // GRPCPrivilegedConfig defines the configuration for the CometBFT gRPC server
 // exposing privileged endpoints.
 type GRPCPrivilegedConfig struct {
+	RootDir string `mapstructure:"home"`
+
 	// TCP or Unix socket address for the gRPC server for privileged clients
 	// to listen on. If empty, the privileged gRPC server will be disabled.
 	ListenAddress string `mapstructure:"laddr"`
 
+	// The path to a file containing the certificate used by the privileged
+	// gRPC server for TLS. Might be either absolute path or path related to
+	// CometBFT's config directory.
+	//
+	// NOTE: both tls_cert_file and tls_key_file must be present for the
+	// privileged gRPC server to use TLS.
+	TLSCertFile string `mapstructure:"tls_cert_file"`
+
+	// The path to a file containing the private key matching tls_cert_file.
+	// Might be either absolute path or path related to CometBFT's config
+	// directory.
+	TLSKeyFile string `mapstructure:"tls_key_file"`
+
+	// The path to a file containing the certificates of the certificate
+	// authorities that sign the certificates of the clients. If set, the
+	// privileged gRPC server requires clients to authenticate with a
+	// certificate signed by one of them. Might be either absolute path or path
+	// related to CometBFT's config directory.
+	TLSClientCAFile string `mapstructure:"tls_client_ca_file"`
+
 	// The gRPC pruning service provides control over the depth of block
 	// storage information that the node
 	PruningService *GRPCPruningServiceConfig `mapstructure:"pruning_service"`
+
+	// The gRPC admin service provides the privileged operations on the node,
+	// such as peer management, mempool management and halting the node.
+	AdminService *GRPCAdminServiceConfig `mapstructure:"admin_service"`
 }
 
 func DefaultGRPCPrivilegedConfig() *GRPCPrivilegedConfig {
 	return &GRPCPrivilegedConfig{
 		ListenAddress:  "",
 		PruningService: DefaultGRPCPruningServiceConfig(),
+		AdminService:   DefaultGRPCAdminServiceConfig(),
 	}
 }
 
@@ -642,9 +1044,55 @@ func TestGRPCPrivilegedConfig() *GRPCPrivilegedConfig {
 	return &GRPCPrivilegedConfig{
 		ListenAddress:  "tcp://127.0.0.1:36671",
 		PruningService: TestGRPCPruningServiceConfig(),
+		AdminService:   DefaultGRPCAdminServiceConfig(),
 	}
 }
 
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *GRPCPrivilegedConfig) ValidateBasic() error {
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return errors.New("both tls_cert_file and tls_key_file must be set to enable TLS")
+	}
+	if cfg.TLSClientCAFile != "" && !cfg.IsTLSEnabled() {
+		return errors.New("tls_client_ca_file requires tls_cert_file and tls_key_file to be set")
+	}
+	if cfg.AdminService != nil && cfg.AdminService.Enabled && !cfg.IsClientAuthEnabled() {
+		return errors.New("the admin service requires client certificate authentication " +
+			"(tls_cert_file, tls_key_file and tls_client_ca_file must be set)")
+	}
+	return nil
+}
+
+func (cfg GRPCPrivilegedConfig) CertFile() string {
+	return cfg.rootifyPath(cfg.TLSCertFile)
+}
+
+func (cfg GRPCPrivilegedConfig) KeyFile() string {
+	return cfg.rootifyPath(cfg.TLSKeyFile)
+}
+
+func (cfg GRPCPrivilegedConfig) ClientCAFile() string {
+	return cfg.rootifyPath(cfg.TLSClientCAFile)
+}
+
+func (cfg GRPCPrivilegedConfig) rootifyPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(DefaultConfigDir, path), cfg.RootDir)
+}
+
+func (cfg GRPCPrivilegedConfig) IsTLSEnabled() bool {
+	return cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
+}
+
+// IsClientAuthEnabled returns true if the privileged gRPC server requires
+// clients to authenticate with a certificate.
+func (cfg GRPCPrivilegedConfig) IsClientAuthEnabled() bool {
+	return cfg.IsTLSEnabled() && cfg.TLSClientCAFile != ""
+}
+
 type GRPCPruningServiceConfig struct {
 	Enabled bool `mapstructure:"enabled"`
 }
@@ -661,6 +1109,16 @@ func TestGRPCPruningServiceConfig() *GRPCPruningServiceConfig {
 	}
 }
 
+type GRPCAdminServiceConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+func DefaultGRPCAdminServiceConfig() *GRPCAdminServiceConfig {
+	return &GRPCAdminServiceConfig{
+		Enabled: false,
+	}
+}
+
 //-----------------------------------------------------------------------------
 // P2PConfig
 
@@ -674,16 +1132,49 @@ type P2PConfig struct { //nolint: maligned
 	// Address to advertise to peers for them to dial
 	ExternalAddress string `mapstructure:"external_address"`
 
+	// Transport of the connections to the peers:
+	// 1) "tcp" - (default) secret connections over TCP
+	// 2) "quic" - QUIC connections over UDP, with a stream per channel
+	Transport string `mapstructure:"transport"`
+
+	// Port mapping on the NAT gateway of the local network, when
+	// external_address is empty:
+	// 1) "none" - (default) no port mapping
+	// 2) "any" - map the port of laddr with UPnP or NAT-PMP
+	// 3) "upnp" - map the port of laddr with UPnP
+	// 4) "pmp" - map the port of laddr with NAT-PMP
+	// The mapped external address of the gateway is then advertised to the
+	// peers as the external address of the node.
+	NAT string `mapstructure:"nat"`
+
+	// Compression of the large messages (e.g. block parts, snapshot chunks)
+	// sent to the peers which support it:
+	// 1) "none" - (default) no compression
+	// 2) "snappy" - fast compression
+	// 3) "zstd" - better compression, for more CPU
+	// A node with compression decodes both snappy and zstd messages.
+	Compression string `mapstructure:"compression"`
+
 	// Comma separated list of seed nodes to connect to
 	// We only use these if we can’t connect to peers in the addrbook
 	Seeds string `mapstructure:"seeds"`
 
+	// Comma separated list of "<public key>@<domain>" DNS seeds, domains
+	// publishing the addresses of seed nodes in TXT records signed by the
+	// given hex encoded ed25519 key. Used along with the seeds.
+	DNSSeeds string `mapstructure:"dns_seeds"`
+
 	// Comma separated list of nodes to keep persistent connections to
 	PersistentPeers string `mapstructure:"persistent_peers"`
 
 	// Path to address book
 	AddrBook string `mapstructure:"addr_book_file"`
 
+	// Where the address book is saved:
+	// 1) "file" - (default) the JSON file at addr_book_file
+	// 2) "db" - the "addrbook" database of the node, using DBBackend
+	AddrBookStore string `mapstructure:"addr_book_store"`
+
 	// Set true for strict address routability rules
 	// Set false for private or local networks
 	AddrBookStrict bool `mapstructure:"addr_book_strict"`
@@ -691,6 +1182,13 @@ type P2PConfig struct { //nolint: maligned
 	// Maximum number of inbound peers
 	MaxNumInboundPeers int `mapstructure:"max_num_inbound_peers"`
 
+	// Maximum number of simultaneous inbound connections from the same IP,
+	// and from the same /24 (IPv4) or /64 (IPv6) subnet, including the
+	// connections still in their handshake (0 - unlimited). They also apply
+	// to the unconditional peers.
+	MaxInboundConnsPerIP     int `mapstructure:"max_inbound_conns_per_ip"`
+	MaxInboundConnsPerSubnet int `mapstructure:"max_inbound_conns_per_subnet"`
+
 	// Maximum number of outbound peers to connect to, excluding persistent peers
 	MaxNumOutboundPeers int `mapstructure:"max_num_outbound_peers"`
 
@@ -712,19 +1210,54 @@ type P2PConfig struct { //nolint: maligned
 	// Rate at which packets can be received, in bytes/second
 	RecvRate int64 `mapstructure:"recv_rate"`
 
+	// Comma separated lists of "<reactor>=<rate>" entries limiting the rates,
+	// in bytes/second, at which each channel of the reactors (blocksync,
+	// consensus, evidence, mempool, pex, statesync) can send and receive
+	// packets on a connection, within the send_rate and recv_rate.
+	ChannelSendRates string `mapstructure:"channel_send_rates"`
+	ChannelRecvRates string `mapstructure:"channel_recv_rates"`
+
 	// Set true to enable the peer-exchange reactor
 	PexReactor bool `mapstructure:"pex"`
 
 	// Seed mode, in which node constantly crawls the network and looks for
 	// peers. If another node asks it for addresses, it responds and disconnects.
+	// A seed only runs the peer-exchange reactor: it does not sync, gossip or
+	// commit blocks, and only advertises the peer-exchange channel.
 	//
 	// Does not work if the peer-exchange reactor is disabled.
 	SeedMode bool `mapstructure:"seed_mode"`
 
+	// In seed mode, the interval between two crawls of the network, and the
+	// minimum time between two crawls of the same peer.
+	SeedCrawlPeriod   time.Duration `mapstructure:"seed_crawl_period"`
+	SeedRecrawlPeriod time.Duration `mapstructure:"seed_recrawl_period"`
+
+	// In seed mode, how long the seed stays connected to the peers it dialed,
+	// for them to become good peers before being given out.
+	SeedDisconnectWaitPeriod time.Duration `mapstructure:"seed_disconnect_wait_period"`
+
+	// In seed mode, the maximum number of addresses of the same autonomous
+	// system (AS) crawled at once, or given to a peer, to spread the peers
+	// across the networks. Unlimited if 0.
+	SeedMaxAddrsPerAS int `mapstructure:"seed_max_addrs_per_as"`
+
+	// Path to a file mapping the IP prefixes to their AS, with one
+	// "<IP prefix> <AS number>" line per prefix. Without it, the AS of an
+	// address is approximated by its /16 (IPv4) or /32 (IPv6) network.
+	SeedASMap string `mapstructure:"seed_asmap_file"`
+
 	// Comma separated list of peer IDs to keep private (will not be gossiped to
 	// other peers)
 	PrivatePeerIDs string `mapstructure:"private_peer_ids"`
 
+	// Path to a list of peer IDs signed by the hex encoded ed25519 key
+	// PeerAllowlistKey. If set, only the listed peers can connect to the node,
+	// or be dialed by it. The list is reloaded when the file changes, and the
+	// peers removed from it are disconnected.
+	PeerAllowlist    string `mapstructure:"peer_allowlist_file"`
+	PeerAllowlistKey string `mapstructure:"peer_allowlist_key"`
+
 	// Toggle to disable guard against peers connecting from the same ip.
 	AllowDuplicateIP bool `mapstructure:"allow_duplicate_ip"`
 
@@ -745,9 +1278,15 @@ func DefaultP2PConfig() *P2PConfig {
 	return &P2PConfig{
 		ListenAddress:                "tcp://0.0.0.0:26656",
 		ExternalAddress:              "",
+		Transport:                    P2PTransportTCP,
+		NAT:                          P2PNATNone,
+		Compression:                  P2PCompressionNone,
 		AddrBook:                     defaultAddrBookPath,
+		AddrBookStore:                P2PAddrBookStoreFile,
 		AddrBookStrict:               true,
 		MaxNumInboundPeers:           40,
+		MaxInboundConnsPerIP:         0,
+		MaxInboundConnsPerSubnet:     0,
 		MaxNumOutboundPeers:          10,
 		PersistentPeersMaxDialPeriod: 0 * time.Second,
 		FlushThrottleTimeout:         100 * time.Millisecond,
@@ -756,6 +1295,11 @@ func DefaultP2PConfig() *P2PConfig {
 		RecvRate:                     5120000, // 5 mB/s
 		PexReactor:                   true,
 		SeedMode:                     false,
+		SeedCrawlPeriod:              30 * time.Second,
+		SeedRecrawlPeriod:            2 * time.Minute,
+		SeedDisconnectWaitPeriod:     28 * time.Hour,
+		SeedMaxAddrsPerAS:            0,
+		SeedASMap:                    "",
 		AllowDuplicateIP:             false,
 		HandshakeTimeout:             20 * time.Second,
 		DialTimeout:                  3 * time.Second,
@@ -779,12 +1323,56 @@ func (cfg *P2PConfig) AddrBookFile() string {
 	return rootify(cfg.AddrBook, cfg.RootDir)
 }
 
+// PeerAllowlistFile returns the full path to the peer allowlist, or an empty
+// string if it is not set.
+func (cfg *P2PConfig) PeerAllowlistFile() string {
+	if cfg.PeerAllowlist == "" {
+		return ""
+	}
+	return rootify(cfg.PeerAllowlist, cfg.RootDir)
+}
+
+// SeedASMapFile returns the full path to the AS map of the seed, or an empty
+// string if it is not set.
+func (cfg *P2PConfig) SeedASMapFile() string {
+	if cfg.SeedASMap == "" {
+		return ""
+	}
+	return rootify(cfg.SeedASMap, cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *P2PConfig) ValidateBasic() error {
+	switch cfg.Transport {
+	case P2PTransportTCP, P2PTransportQUIC:
+	default:
+		return fmt.Errorf("unknown transport: %q", cfg.Transport)
+	}
+	switch cfg.NAT {
+	case P2PNATNone, P2PNATAny, P2PNATUPnP, P2PNATPMP:
+	default:
+		return fmt.Errorf("unknown nat: %q", cfg.NAT)
+	}
+	switch cfg.Compression {
+	case P2PCompressionNone, P2PCompressionSnappy, P2PCompressionZstd:
+	default:
+		return fmt.Errorf("unknown compression: %q", cfg.Compression)
+	}
+	switch cfg.AddrBookStore {
+	case P2PAddrBookStoreFile, P2PAddrBookStoreDB:
+	default:
+		return fmt.Errorf("unknown addr_book_store: %q", cfg.AddrBookStore)
+	}
 	if cfg.MaxNumInboundPeers < 0 {
 		return cmterrors.ErrNegativeField{Field: "max_num_inbound_peers"}
 	}
+	if cfg.MaxInboundConnsPerIP < 0 {
+		return cmterrors.ErrNegativeField{Field: "max_inbound_conns_per_ip"}
+	}
+	if cfg.MaxInboundConnsPerSubnet < 0 {
+		return cmterrors.ErrNegativeField{Field: "max_inbound_conns_per_subnet"}
+	}
 	if cfg.MaxNumOutboundPeers < 0 {
 		return cmterrors.ErrNegativeField{Field: "max_num_outbound_peers"}
 	}
@@ -803,9 +1391,87 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return cmterrors.ErrNegativeField{Field: "recv_rate"}
 	}
+	if cfg.SeedCrawlPeriod < 0 {
+		return cmterrors.ErrNegativeField{Field: "seed_crawl_period"}
+	}
+	if cfg.SeedRecrawlPeriod < 0 {
+		return cmterrors.ErrNegativeField{Field: "seed_recrawl_period"}
+	}
+	if cfg.SeedDisconnectWaitPeriod < 0 {
+		return cmterrors.ErrNegativeField{Field: "seed_disconnect_wait_period"}
+	}
+	if cfg.SeedMaxAddrsPerAS < 0 {
+		return cmterrors.ErrNegativeField{Field: "seed_max_addrs_per_as"}
+	}
+	for _, entry := range strings.Split(cfg.DNSSeeds, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		key, domain, ok := strings.Cut(entry, "@")
+		if !ok || domain == "" {
+			return fmt.Errorf("invalid dns_seeds entry %q: expected <public key>@<domain>", entry)
+		}
+		if bz, err := hex.DecodeString(key); err != nil || len(bz) != 32 {
+			return fmt.Errorf("invalid public key in dns_seeds entry %q: expected a hex encoded ed25519 key", entry)
+		}
+	}
+	if cfg.PeerAllowlist != "" || cfg.PeerAllowlistKey != "" {
+		if cfg.PeerAllowlist == "" {
+			return errors.New("peer_allowlist_key is set without peer_allowlist_file")
+		}
+		if bz, err := hex.DecodeString(cfg.PeerAllowlistKey); err != nil || len(bz) != 32 {
+			return errors.New("invalid peer_allowlist_key: expected a hex encoded ed25519 key")
+		}
+	}
+	if _, err := parseChannelRates(cfg.ChannelSendRates); err != nil {
+		return fmt.Errorf("invalid channel_send_rates: %w", err)
+	}
+	if _, err := parseChannelRates(cfg.ChannelRecvRates); err != nil {
+		return fmt.Errorf("invalid channel_recv_rates: %w", err)
+	}
 	return nil
 }
 
+// ChannelSendRate returns the rate, in bytes/second, at which each channel of
+// the given reactor can send packets, or 0 if it is only limited by the
+// send_rate.
+func (cfg *P2PConfig) ChannelSendRate(reactor string) int64 {
+	rates, _ := parseChannelRates(cfg.ChannelSendRates)
+	return rates[strings.ToLower(reactor)]
+}
+
+// ChannelRecvRate returns the rate, in bytes/second, at which each channel of
+// the given reactor can receive packets, or 0 if it is only limited by the
+// recv_rate.
+func (cfg *P2PConfig) ChannelRecvRate(reactor string) int64 {
+	rates, _ := parseChannelRates(cfg.ChannelRecvRates)
+	return rates[strings.ToLower(reactor)]
+}
+
+// parseChannelRates parses a comma separated list of "<reactor>=<rate>"
+// entries.
+func parseChannelRates(s string) (map[string]int64, error) {
+	rates := make(map[string]int64)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		reactor, rate, ok := strings.Cut(entry, "=")
+		reactor = strings.ToLower(strings.TrimSpace(reactor))
+		if !ok || reactor == "" {
+			return nil, fmt.Errorf("entry %q: expected <reactor>=<rate>", entry)
+		}
+		r, err := strconv.ParseInt(strings.TrimSpace(rate), 10, 64)
+		if err != nil || r < 0 {
+			return nil, fmt.Errorf("entry %q: rate must be a non-negative integer", entry)
+		}
+		rates[reactor] = r
+	}
+	return rates, nil
+}
+
 // FuzzConnConfig is a FuzzedConnection configuration.
 type FuzzConnConfig struct {
 	Mode         int
@@ -840,22 +1506,49 @@ type MempoolConfig struct {
 	// the $CMTHOME env variable or --home cmd flag rather than overriding this
 	// struct field.
 	RootDir string `mapstructure:"home"`
+	// The type of mempool for this node to use.
+	//
+	// Possible types:
+	// - "flood" : concurrent linked list of transactions, reaped in order of
+	// arrival and flooded to all the peers.
+	// - "priority" : like "flood", but transactions are reaped in decreasing
+	// order of the priority assigned by the application in CheckTx, and the
+	// lowest-priority transactions are evicted when the mempool is full.
+	Type string `mapstructure:"type"`
 	// Recheck (default: true) defines whether CometBFT should recheck the
 	// validity for all remaining transaction in the mempool after a block.
 	// Since a block affects the application state, some transactions in the
 	// mempool may become invalid. If this does not apply to your application,
 	// you can disable rechecking.
 	Recheck bool `mapstructure:"recheck"`
+	// RecheckConcurrency (default: 1) is the maximum number of CheckTx calls
+	// issued concurrently to the application when rechecking the transactions
+	// after a block. With a value above 1, the recheck completes before the
+	// mempool is unlocked for the next block, which shortens the time it
+	// takes on busy chains when the application handles CheckTx calls in
+	// parallel. Values of 0 and 1 keep the sequential recheck.
+	RecheckConcurrency int `mapstructure:"recheck_concurrency"`
 	// Broadcast (default: true) defines whether the mempool should relay
 	// transactions to other peers. Setting this to false will stop the mempool
 	// from relaying transactions to other peers until they are included in a
 	// block. In other words, if Broadcast is disabled, only the peer you send
 	// the tx to will see it until it is included in a block.
 	Broadcast bool `mapstructure:"broadcast"`
+	// GossipMode (default: "push") defines how transactions are relayed to
+	// peers. Possible values are:
+	// - "push": every transaction is sent in full to every peer which did not
+	// send it to this node.
+	// - "pull": only the hashes of the transactions are announced to peers,
+	// which request the transactions they don't have yet from the first peer
+	// announcing them. This avoids receiving the same transaction from most
+	// peers, at the cost of an extra round trip.
+	GossipMode string `mapstructure:"gossip_mode"`
 	// WalPath (default: "") configures the location of the Write Ahead Log
 	// (WAL) for the mempool. The WAL is disabled by default. To enable, set
 	// WalPath to where you want the WAL to be written (e.g.
-	// "data/mempool.wal").
+	// "data/mempool.wal"). The transactions added to and removed from the
+	// mempool are journaled to the WAL, and the transactions still in the
+	// mempool are resubmitted to CheckTx on startup after a crash.
 	WalPath string `mapstructure:"wal_dir"`
 	// Maximum number of transactions in the mempool
 	Size int `mapstructure:"size"`
@@ -876,20 +1569,96 @@ type MempoolConfig struct {
 	// Including space needed by encoding (one varint per transaction).
 	// XXX: Unused due to https://github.com/tendermint/tendermint/issues/5796
 	MaxBatchBytes int `mapstructure:"max_batch_bytes"`
+	// ExperimentalEncryptedTxs (default: false) enables support for
+	// threshold-encrypted transactions. Encrypted transactions are stored and
+	// gossiped as ciphertexts and only reaped for the block at their
+	// decryption height, whose proposer receives the decryption shares via
+	// the vote extensions of the previous height. Encrypted transactions that
+	// were not included at their decryption height are evicted. The vote
+	// extensions only carry the decryption shares once the
+	// abci.encrypted_txs_enable_height consensus param is reached.
+	ExperimentalEncryptedTxs bool `mapstructure:"experimental_encrypted_txs"`
+	// ExperimentalThresholdKeyFile (default: "") is the path to the file
+	// holding the threshold public key of the validators and the key share of
+	// this node. When set, the node adds its decryption shares of the
+	// encrypted transactions of the next height to its vote extensions, and
+	// decrypts the encrypted transactions of the blocks it proposes before
+	// passing them to PrepareProposal. It requires the
+	// abci.encrypted_txs_enable_height consensus param to be reached. The
+	// validators without it extend their votes with no decryption shares.
+	ExperimentalThresholdKeyFile string `mapstructure:"experimental_threshold_key_file"`
+	// TxClasses configures per-class quotas and ordering weights, keyed by
+	// the class the application assigns to transactions in CheckTx.
+	// Transactions without a class belong to the "default" class. When no
+	// classes are configured, transactions are reaped in FIFO order.
+	TxClasses map[string]MempoolTxClassConfig `mapstructure:"tx_classes"`
+	// TTLNumBlocks (default: 0) is the number of blocks after which a
+	// transaction that was not included is evicted from the mempool and the
+	// cache. The application can set a shorter TTL for a transaction in
+	// CheckTx. 0 disables the default TTL.
+	TTLNumBlocks int64 `mapstructure:"ttl_num_blocks"`
+	// TTLDuration (default: 0) is the duration after which a transaction that
+	// was not included is evicted from the mempool and the cache, checked
+	// after each block. The application can set a shorter TTL for a
+	// transaction in CheckTx. 0 disables the default TTL.
+	TTLDuration time.Duration `mapstructure:"ttl_duration"`
+	// PersistPath (default: "") configures the file to which the transactions
+	// of the mempool are saved on shutdown, to be resubmitted to CheckTx on
+	// startup. Persistence is disabled by default. To enable, set PersistPath
+	// to where you want the file to be written (e.g. "data/mempool.json").
+	PersistPath string `mapstructure:"persist_path"`
+	// PeerMaxTxsPerSecond (default: 0) is the maximum rate of transactions
+	// received from a single peer which are checked. Transactions received
+	// above this rate are dropped. 0 disables the limit.
+	PeerMaxTxsPerSecond int `mapstructure:"peer_max_txs_per_sec"`
+	// PeerMaxBytesPerSecond (default: 0) is the maximum rate in bytes of
+	// transactions received from a single peer which are checked.
+	// Transactions received above this rate are dropped. 0 disables the
+	// limit.
+	PeerMaxBytesPerSecond int64 `mapstructure:"peer_max_bytes_per_sec"`
+	// MaxTxsPerSender (default: 0) is the maximum number of transactions of
+	// a single sender in the mempool, the sender of a transaction being
+	// assigned by the application in CheckTx. 0 disables the limit.
+	MaxTxsPerSender int `mapstructure:"max_txs_per_sender"`
+	// MaxTxsBytesPerSender (default: 0) is the maximum total size in bytes of
+	// the transactions of a single sender in the mempool. 0 disables the
+	// limit.
+	MaxTxsBytesPerSender int64 `mapstructure:"max_txs_bytes_per_sender"`
+	// ReplaceByFeeFactor (default: 0) is the factor by which the priority of
+	// a transaction must exceed the priority of the transaction of the same
+	// sender and sequence in the mempool to replace it. 0 disables the
+	// replacement of transactions.
+	ReplaceByFeeFactor float64 `mapstructure:"replace_by_fee_factor"`
+}
+
+// MempoolTxClassConfig defines the quota and ordering weight of a class of
+// transactions in the mempool.
+type MempoolTxClassConfig struct {
+	// MaxTxs is the maximum number of transactions of the class in the
+	// mempool. 0 means the class is only limited by the mempool size.
+	MaxTxs int `mapstructure:"max_txs"`
+	// Weight is the number of transactions of the class reaped in each round
+	// when interleaving classes in a proposal. 0 is treated as 1.
+	Weight int `mapstructure:"weight"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the CometBFT mempool
 func DefaultMempoolConfig() *MempoolConfig {
 	return &MempoolConfig{
-		Recheck:   true,
-		Broadcast: true,
-		WalPath:   "",
+		Type:               MempoolTypeFlood,
+		Recheck:            true,
+		RecheckConcurrency: 1,
+		Broadcast:          true,
+		GossipMode:         MempoolGossipPush,
+		WalPath:            "",
 		// Each signature verification takes .5ms, Size reduced until we implement
 		// ABCI Recheck
-		Size:        5000,
-		MaxTxsBytes: 1024 * 1024 * 1024, // 1GB
-		CacheSize:   10000,
-		MaxTxBytes:  1024 * 1024, // 1MB
+		Size:         5000,
+		MaxTxsBytes:  1024 * 1024 * 1024, // 1GB
+		CacheSize:    10000,
+		MaxTxBytes:   1024 * 1024, // 1MB
+		TTLNumBlocks: 0,
+		TTLDuration:  0 * time.Second,
 	}
 }
 
@@ -910,9 +1679,41 @@ func (cfg *MempoolConfig) WalEnabled() bool {
 	return cfg.WalPath != ""
 }
 
+// PersistFile returns the full path to the file the mempool transactions are
+// saved to on shutdown.
+func (cfg *MempoolConfig) PersistFile() string {
+	return rootify(cfg.PersistPath, cfg.RootDir)
+}
+
+// PersistEnabled returns true if the mempool transactions are saved on
+// shutdown.
+func (cfg *MempoolConfig) PersistEnabled() bool {
+	return cfg.PersistPath != ""
+}
+
+// ThresholdKeyFile returns the full path to the threshold key file.
+func (cfg *MempoolConfig) ThresholdKeyFile() string {
+	return rootify(cfg.ExperimentalThresholdKeyFile, cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *MempoolConfig) ValidateBasic() error {
+	switch cfg.Type {
+	case MempoolTypeFlood, MempoolTypePriority:
+	case "":
+		return errors.New("mempool.type cannot be empty")
+	default:
+		return fmt.Errorf("unknown mempool type: %q", cfg.Type)
+	}
+	if cfg.RecheckConcurrency < 0 {
+		return cmterrors.ErrNegativeField{Field: "recheck_concurrency"}
+	}
+	switch cfg.GossipMode {
+	case MempoolGossipPush, MempoolGossipPull:
+	default:
+		return fmt.Errorf("unknown mempool gossip mode: %q", cfg.GossipMode)
+	}
 	if cfg.Size < 0 {
 		return cmterrors.ErrNegativeField{Field: "size"}
 	}
@@ -925,6 +1726,41 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.MaxTxBytes < 0 {
 		return cmterrors.ErrNegativeField{Field: "max_tx_bytes"}
 	}
+	if cfg.TTLNumBlocks < 0 {
+		return cmterrors.ErrNegativeField{Field: "ttl_num_blocks"}
+	}
+	if cfg.TTLDuration < 0 {
+		return cmterrors.ErrNegativeField{Field: "ttl_duration"}
+	}
+	if cfg.PeerMaxTxsPerSecond < 0 {
+		return cmterrors.ErrNegativeField{Field: "peer_max_txs_per_sec"}
+	}
+	if cfg.PeerMaxBytesPerSecond < 0 {
+		return cmterrors.ErrNegativeField{Field: "peer_max_bytes_per_sec"}
+	}
+	if cfg.MaxTxsPerSender < 0 {
+		return cmterrors.ErrNegativeField{Field: "max_txs_per_sender"}
+	}
+	if cfg.MaxTxsBytesPerSender < 0 {
+		return cmterrors.ErrNegativeField{Field: "max_txs_bytes_per_sender"}
+	}
+	if cfg.ExperimentalThresholdKeyFile != "" && !cfg.ExperimentalEncryptedTxs {
+		return errors.New("experimental_threshold_key_file requires experimental_encrypted_txs")
+	}
+	if cfg.ReplaceByFeeFactor != 0 && cfg.ReplaceByFeeFactor < 1 {
+		return fmt.Errorf("replace_by_fee_factor must be 0 or at least 1, got %v", cfg.ReplaceByFeeFactor)
+	}
+	for name, class := range cfg.TxClasses {
+		if name == "" || strings.ToLower(name) != name {
+			return fmt.Errorf("invalid tx class name %q: must be non-empty and lower case", name)
+		}
+		if class.MaxTxs < 0 {
+			return cmterrors.ErrNegativeField{Field: "tx_classes." + name + ".max_txs"}
+		}
+		if class.Weight < 0 {
+			return cmterrors.ErrNegativeField{Field: "tx_classes." + name + ".weight"}
+		}
+	}
 	return nil
 }
 
@@ -942,6 +1778,8 @@ type StateSyncConfig struct {
 	DiscoveryTime       time.Duration `mapstructure:"discovery_time"`
 	ChunkRequestTimeout time.Duration `mapstructure:"chunk_request_timeout"`
 	ChunkFetchers       int32         `mapstructure:"chunk_fetchers"`
+	LocalSnapshot       string        `mapstructure:"local_snapshot"`
+	SnapshotSources     []string      `mapstructure:"snapshot_sources"`
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -1013,6 +1851,16 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 		if cfg.ChunkFetchers <= 0 {
 			return cmterrors.ErrRequiredField{Field: "chunk_fetchers"}
 		}
+
+		for _, source := range cfg.SnapshotSources {
+			u, err := url.Parse(source)
+			if err != nil {
+				return fmt.Errorf("invalid snapshot_sources entry %q: %w", source, err)
+			}
+			if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "s3" {
+				return fmt.Errorf("invalid snapshot_sources entry %q: scheme must be http, https or s3", source)
+			}
+		}
 	}
 
 	return nil
@@ -1060,6 +1908,14 @@ type ConsensusConfig struct {
 	WalPath string `mapstructure:"wal_file"`
 	walFile string // overrides WalPath if set
 
+	// Compression of the WAL entries: "none", "snappy" or "zstd"
+	WalCompression string `mapstructure:"wal_compression"`
+	// Size of a WAL segment, in bytes, after which it is rotated
+	WalSegmentSize int64 `mapstructure:"wal_segment_size"`
+	// Total size of the WAL segments, in bytes, above which the oldest ones
+	// are removed. 0 keeps all the segments.
+	WalMaxTotalSize int64 `mapstructure:"wal_max_total_size"`
+
 	// How long we wait for a proposal block before prevoting nil
 	TimeoutPropose time.Duration `mapstructure:"timeout_propose"`
 	// How much timeout_propose increases with each round
@@ -1078,6 +1934,31 @@ type ConsensusConfig struct {
 	// NOTE: when modifying, make sure to update time_iota_ms genesis parameter
 	TimeoutCommit time.Duration `mapstructure:"timeout_commit"`
 
+	// Factor by which the timeouts above and create_empty_blocks_interval are
+	// scaled, e.g. 0.1 to run a test network 10 times faster. 0 is treated as
+	// 1. Intended for testing only; it can be set via the
+	// CMT_CONSENSUS_TIMEOUT_SCALE environment variable. The timeouts of the
+	// consensus params are not scaled. The times of the blocks are compressed
+	// by the same factor, see BlockTime.
+	TimeoutScale float64 `mapstructure:"timeout_scale"`
+
+	// Set to true to adapt timeout_propose, timeout_prevote and
+	// timeout_precommit to the durations of the propose, prevote and
+	// precommit steps observed in the recent rounds, within
+	// [AdaptiveTimeoutsMinFactor, AdaptiveTimeoutsMaxFactor] times the
+	// configured timeouts. The deltas still apply to the later rounds. The
+	// timeouts of the consensus params are not adapted.
+	AdaptiveTimeouts bool `mapstructure:"adaptive_timeouts"`
+	// Number of most recent observed durations of each step from which the
+	// timeouts are adapted.
+	AdaptiveTimeoutsWindow int `mapstructure:"adaptive_timeouts_window"`
+	// Percentile of the observed durations of each step, between 0 and 1,
+	// from which its timeout is adapted.
+	AdaptiveTimeoutsPercentile float64 `mapstructure:"adaptive_timeouts_percentile"`
+	// Bounds of the adapted timeouts, as factors of the configured timeouts.
+	AdaptiveTimeoutsMinFactor float64 `mapstructure:"adaptive_timeouts_min_factor"`
+	AdaptiveTimeoutsMaxFactor float64 `mapstructure:"adaptive_timeouts_max_factor"`
+
 	// Make progress as soon as we have all the precommits (as if TimeoutCommit = 0)
 	SkipTimeoutCommit bool `mapstructure:"skip_timeout_commit"`
 
@@ -1091,12 +1972,67 @@ type ConsensusConfig struct {
 	PeerGossipIntraloopSleepDuration time.Duration `mapstructure:"peer_gossip_intraloop_sleep_duration"` // upper bound on randomly selected values
 
 	DoubleSignCheckHeight int64 `mapstructure:"double_sign_check_height"`
+
+	// Comma separated list of "<validator address>=<node ID>@<host>:<port>"
+	// entries registering the nodes run by other validators. The node keeps
+	// direct links to them and, if it is a validator, pushes its own proposals
+	// and votes to the connected ones whose validator is in the validator set
+	// of the height, bypassing multi-hop gossip. The validator peers are only
+	// taken from this list, not discovered from the validator set, and the
+	// messages are pushed to all of them, regardless of their latency.
+	DirectValidatorPeers string `mapstructure:"direct_validator_peers"`
+
+	// Set to true to send to the peers lagging behind by more than one height
+	// the commit of their height in a single message, a commit certificate,
+	// which they verify and add at once, instead of its precommits one by one.
+	// The peers must also enable it, as older nodes disconnect from the peers
+	// sending it.
+	CatchupCommitCertificates bool `mapstructure:"catchup_commit_certificates"`
+
+	// Set to true to also gossip the parity parts of the proposal block,
+	// computed with a Reed-Solomon code, to the peers enabling it, so they can
+	// reconstruct the block from any parts and parity parts received from
+	// different peers, as many as the block has parts.
+	ErasureCodedBlockParts bool `mapstructure:"erasure_coded_block_parts"`
+
+	// Set to true to relay the proposal block to the peers enabling it as a
+	// compact block, carrying the keys of its transactions instead of the
+	// transactions, which the peers look up in their mempool to reconstruct
+	// the block, requesting only the missing ones.
+	CompactBlocks bool `mapstructure:"compact_blocks"`
+
+	// Number of most recent heights for which all the received votes are
+	// persisted and can be exported via the /recorded_votes RPC endpoint.
+	// 0 disables vote recording.
+	VoteRecordHeights int64 `mapstructure:"vote_record_heights"`
+
+	// Path of the file the step transitions, message receipts and timeouts of
+	// the consensus are traced to, one JSON object per line, for the analysis
+	// of slow blocks. Empty disables tracing.
+	TracePath string `mapstructure:"trace_file"`
+
+	// Set to true to start executing a proposed block, with FinalizeBlock, as
+	// soon as the node prevotes for it, and use the result if the block is
+	// decided. The result is discarded when the round changes. The
+	// application must support FinalizeBlock being called again at the same
+	// height, for the same or another block, discarding the state resulting
+	// from the previous call, and declare it in ResponseInfo: the option is
+	// ignored otherwise.
+	//
+	// The blocks of the heights at which vote extensions are enabled are not
+	// executed optimistically: ExtendVote and VerifyVoteExtension may be
+	// called after the node prevotes, and the application is guaranteed that
+	// they are not called at a height once FinalizeBlock is.
+	OptimisticExecution bool `mapstructure:"optimistic_execution"`
 }
 
 // DefaultConsensusConfig returns a default configuration for the consensus service
 func DefaultConsensusConfig() *ConsensusConfig {
 	return &ConsensusConfig{
 		WalPath:                          filepath.Join(DefaultDataDir, "cs.wal", "wal"),
+		WalCompression:                   WALCompressionNone,
+		WalSegmentSize:                   10 * 1024 * 1024,   // 10MB
+		WalMaxTotalSize:                  1024 * 1024 * 1024, // 1GB
 		TimeoutPropose:                   3000 * time.Millisecond,
 		TimeoutProposeDelta:              500 * time.Millisecond,
 		TimeoutPrevote:                   1000 * time.Millisecond,
@@ -1104,6 +2040,12 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		TimeoutPrecommit:                 1000 * time.Millisecond,
 		TimeoutPrecommitDelta:            500 * time.Millisecond,
 		TimeoutCommit:                    1000 * time.Millisecond,
+		TimeoutScale:                     1,
+		AdaptiveTimeouts:                 false,
+		AdaptiveTimeoutsWindow:           100,
+		AdaptiveTimeoutsPercentile:       0.99,
+		AdaptiveTimeoutsMinFactor:        0.5,
+		AdaptiveTimeoutsMaxFactor:        2,
 		SkipTimeoutCommit:                false,
 		CreateEmptyBlocks:                true,
 		CreateEmptyBlocksInterval:        0 * time.Second,
@@ -1111,6 +2053,13 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		PeerQueryMaj23SleepDuration:      2000 * time.Millisecond,
 		PeerGossipIntraloopSleepDuration: 0 * time.Second,
 		DoubleSignCheckHeight:            int64(0),
+		DirectValidatorPeers:             "",
+		CatchupCommitCertificates:        false,
+		ErasureCodedBlockParts:           false,
+		CompactBlocks:                    false,
+		VoteRecordHeights:                0,
+		TracePath:                        "",
+		OptimisticExecution:              false,
 	}
 }
 
@@ -1139,29 +2088,60 @@ func (cfg *ConsensusConfig) WaitForTxs() bool {
 
 // Propose returns the amount of time to wait for a proposal
 func (cfg *ConsensusConfig) Propose(round int32) time.Duration {
-	return time.Duration(
+	return cfg.scaleTimeout(time.Duration(
 		cfg.TimeoutPropose.Nanoseconds()+cfg.TimeoutProposeDelta.Nanoseconds()*int64(round),
-	) * time.Nanosecond
+	) * time.Nanosecond)
 }
 
 // Prevote returns the amount of time to wait for straggler votes after receiving any +2/3 prevotes
 func (cfg *ConsensusConfig) Prevote(round int32) time.Duration {
-	return time.Duration(
+	return cfg.scaleTimeout(time.Duration(
 		cfg.TimeoutPrevote.Nanoseconds()+cfg.TimeoutPrevoteDelta.Nanoseconds()*int64(round),
-	) * time.Nanosecond
+	) * time.Nanosecond)
 }
 
 // Precommit returns the amount of time to wait for straggler votes after receiving any +2/3 precommits
 func (cfg *ConsensusConfig) Precommit(round int32) time.Duration {
-	return time.Duration(
+	return cfg.scaleTimeout(time.Duration(
 		cfg.TimeoutPrecommit.Nanoseconds()+cfg.TimeoutPrecommitDelta.Nanoseconds()*int64(round),
-	) * time.Nanosecond
+	) * time.Nanosecond)
 }
 
 // Commit returns the amount of time to wait for straggler votes after receiving +2/3 precommits
 // for a single block (ie. a commit).
 func (cfg *ConsensusConfig) Commit(t time.Time) time.Time {
-	return t.Add(cfg.TimeoutCommit)
+	return t.Add(cfg.scaleTimeout(cfg.TimeoutCommit))
+}
+
+// EmptyBlocksInterval returns the amount of time to wait for txs before
+// proposing an empty block, 0 meaning that empty blocks are proposed without
+// waiting.
+func (cfg *ConsensusConfig) EmptyBlocksInterval() time.Duration {
+	return cfg.scaleTimeout(cfg.CreateEmptyBlocksInterval)
+}
+
+// scaleTimeout scales the given timeout by TimeoutScale. The result only
+// depends on the configuration, so that all the nodes of a test network
+// configured with the same scale wait for the same scaled durations.
+func (cfg *ConsensusConfig) scaleTimeout(d time.Duration) time.Duration {
+	if cfg.TimeoutScale <= 0 || cfg.TimeoutScale == 1 {
+		return d
+	}
+	return time.Duration(float64(d) * cfg.TimeoutScale)
+}
+
+// BlockTime returns the time of the blocks at t, the time of the local clock,
+// on a chain started at genesisTime. The time elapsed since genesisTime is
+// divided by TimeoutScale, so that the times of the blocks advance as in a
+// test network run at the normal pace, however faster the blocks are made.
+// The result only depends on the configuration, the genesis time and t, so
+// that all the nodes of a test network configured with the same scale
+// compress the time of their blocks in the same way.
+func (cfg *ConsensusConfig) BlockTime(genesisTime, t time.Time) time.Time {
+	if cfg.TimeoutScale <= 0 || cfg.TimeoutScale == 1 {
+		return t
+	}
+	return genesisTime.Add(time.Duration(float64(t.Sub(genesisTime)) / cfg.TimeoutScale))
 }
 
 // WalFile returns the full path to the write-ahead log file
@@ -1172,6 +2152,15 @@ func (cfg *ConsensusConfig) WalFile() string {
 	return rootify(cfg.WalPath, cfg.RootDir)
 }
 
+// TraceFile returns the full path to the trace file, empty if tracing is
+// disabled.
+func (cfg *ConsensusConfig) TraceFile() string {
+	if cfg.TracePath == "" {
+		return ""
+	}
+	return rootify(cfg.TracePath, cfg.RootDir)
+}
+
 // SetWalFile sets the path to the write-ahead log file
 func (cfg *ConsensusConfig) SetWalFile(walFile string) {
 	cfg.walFile = walFile
@@ -1180,6 +2169,20 @@ func (cfg *ConsensusConfig) SetWalFile(walFile string) {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *ConsensusConfig) ValidateBasic() error {
+	switch cfg.WalCompression {
+	case WALCompressionNone, WALCompressionSnappy, WALCompressionZstd:
+	default:
+		return fmt.Errorf("unknown wal_compression: %q", cfg.WalCompression)
+	}
+	if cfg.WalSegmentSize <= 0 {
+		return errors.New("wal_segment_size must be positive")
+	}
+	if cfg.WalMaxTotalSize < 0 {
+		return cmterrors.ErrNegativeField{Field: "wal_max_total_size"}
+	}
+	if cfg.WalMaxTotalSize > 0 && cfg.WalMaxTotalSize < cfg.WalSegmentSize {
+		return errors.New("wal_max_total_size must be 0 or at least wal_segment_size")
+	}
 	if cfg.TimeoutPropose < 0 {
 		return cmterrors.ErrNegativeField{Field: "timeout_propose"}
 	}
@@ -1201,6 +2204,23 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.TimeoutCommit < 0 {
 		return cmterrors.ErrNegativeField{Field: "timeout_commit"}
 	}
+	if cfg.TimeoutScale < 0 {
+		return cmterrors.ErrNegativeField{Field: "timeout_scale"}
+	}
+	if cfg.AdaptiveTimeouts {
+		if cfg.AdaptiveTimeoutsWindow <= 0 {
+			return errors.New("adaptive_timeouts_window must be positive")
+		}
+		if cfg.AdaptiveTimeoutsPercentile <= 0 || cfg.AdaptiveTimeoutsPercentile > 1 {
+			return errors.New("adaptive_timeouts_percentile must be in (0, 1]")
+		}
+		if cfg.AdaptiveTimeoutsMinFactor <= 0 || cfg.AdaptiveTimeoutsMinFactor > 1 {
+			return errors.New("adaptive_timeouts_min_factor must be in (0, 1]")
+		}
+		if cfg.AdaptiveTimeoutsMaxFactor < 1 {
+			return errors.New("adaptive_timeouts_max_factor must be at least 1")
+		}
+	}
 	if cfg.CreateEmptyBlocksInterval < 0 {
 		return cmterrors.ErrNegativeField{Field: "create_empty_blocks_interval"}
 	}
@@ -1213,6 +2233,22 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.DoubleSignCheckHeight < 0 {
 		return cmterrors.ErrNegativeField{Field: "double_sign_check_height"}
 	}
+	if cfg.VoteRecordHeights < 0 {
+		return cmterrors.ErrNegativeField{Field: "vote_record_heights"}
+	}
+	for _, entry := range strings.Split(cfg.DirectValidatorPeers, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		valAddr, nodeAddr, ok := strings.Cut(entry, "=")
+		if !ok || nodeAddr == "" {
+			return fmt.Errorf("invalid direct_validator_peers entry %q: expected <validator address>=<node address>", entry)
+		}
+		if _, err := hex.DecodeString(valAddr); err != nil || valAddr == "" {
+			return fmt.Errorf("invalid validator address in direct_validator_peers entry %q", entry)
+		}
+	}
 	return nil
 }
 
@@ -1226,8 +2262,36 @@ type StorageConfig struct {
 	// required for `/block_results` RPC queries, and to reindex events in the
 	// command-line tool.
 	DiscardABCIResponses bool `mapstructure:"discard_abci_responses"`
+	// Set to true to verify the checksums of the records loaded from the state
+	// store (states, validator sets, consensus params and ABCI responses). A
+	// record that does not match its checksum is reported as corrupted.
+	VerifyStateChecksums bool `mapstructure:"verify_state_checksums"`
+	// The backend keeping the states, validator sets, consensus params and
+	// ABCI responses.
+	//
+	// Options:
+	//   1) "kv" (default) - the state database of the node (see DBBackend).
+	//   2) "psql" - a PostgreSQL database, with the schema defined in
+	//      state/psql/schema.sql.
+	StateStore string `mapstructure:"state_store"`
+	// The PostgreSQL connection configuration of the "psql" state store, the
+	// connection format:
+	// postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
+	StateStorePsqlConn string `mapstructure:"state_store_psql_conn"`
+	// Set to true to serve the RPC and gRPC queries from read replicas of the
+	// block store and state store, isolating the historical reads from the
+	// stores used by consensus. The replicas never write, and read the base and
+	// height of the block store from the database rather than from the writer.
+	// The "psql" state store replica uses its own database connections.
+	ReadReplica bool `mapstructure:"read_replica"`
 	// Configuration related to storage pruning.
 	Pruning *PruningConfig `mapstructure:"pruning"`
+	// Configuration related to storage usage forecasting.
+	Forecast *StorageForecastConfig `mapstructure:"forecast"`
+	// Configuration related to the encryption of sensitive stores at rest.
+	Encryption *StorageEncryptionConfig `mapstructure:"encryption"`
+	// Configuration related to the compression of the stores.
+	Compression *StorageCompressionConfig `mapstructure:"compression"`
 
 	// Hex representation of the hash of the genesis file.
 	// This is an optional parameter set when an operator provides
@@ -1243,7 +2307,11 @@ type StorageConfig struct {
 func DefaultStorageConfig() *StorageConfig {
 	return &StorageConfig{
 		DiscardABCIResponses: false,
+		StateStore:           "kv",
 		Pruning:              DefaultPruningConfig(),
+		Forecast:             DefaultStorageForecastConfig(),
+		Encryption:           DefaultStorageEncryptionConfig(),
+		Compression:          DefaultStorageCompressionConfig(),
 		GenesisHash:          "",
 	}
 }
@@ -1253,15 +2321,37 @@ func DefaultStorageConfig() *StorageConfig {
 func TestStorageConfig() *StorageConfig {
 	return &StorageConfig{
 		DiscardABCIResponses: false,
+		StateStore:           "kv",
 		Pruning:              TestPruningConfig(),
+		Forecast:             TestStorageForecastConfig(),
+		Encryption:           DefaultStorageEncryptionConfig(),
+		Compression:          DefaultStorageCompressionConfig(),
 		GenesisHash:          "",
 	}
 }
 
 func (cfg *StorageConfig) ValidateBasic() error {
+	if cfg.StateStore == "psql" && cfg.StateStorePsqlConn == "" {
+		return errors.New("state_store_psql_conn must be set when state_store is \"psql\"")
+	}
 	if err := cfg.Pruning.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [pruning] section: %w", err)
 	}
+	if err := cfg.Forecast.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [forecast] section: %w", err)
+	}
+	if err := cfg.Encryption.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [encryption] section: %w", err)
+	}
+	if cfg.Encryption.Encrypts(EncryptedStoreState) && cfg.StateStore == "psql" {
+		return errors.New("the state store cannot be encrypted when state_store is \"psql\"")
+	}
+	if err := cfg.Compression.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [compression] section: %w", err)
+	}
+	if cfg.Compression.Compresses(CompressedStoreABCIResponses) && cfg.StateStore == "psql" {
+		return errors.New("the ABCI responses cannot be compressed when state_store is \"psql\"")
+	}
 	return nil
 }
 
@@ -1291,13 +2381,53 @@ type TxIndexConfig struct {
 	// The PostgreSQL connection configuration, the connection format:
 	// postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 	PsqlConn string `mapstructure:"psql-conn"`
+
+	// Maximum size in bytes of the serialized result of a tx, with its
+	// events, published on the event bus to the indexer and to the RPC
+	// subscribers. 0 means unlimited.
+	MaxEventSize int `mapstructure:"max_event_size"`
+
+	// What to do with the events of a tx whose result exceeds MaxEventSize.
+	//
+	// Options:
+	//   1) "truncate_attributes" (default) - truncate the longest attribute
+	//      values until the result fits.
+	//   2) "drop_tx_data" - drop the data returned by the application for
+	//      the tx from the result.
+	//   3) "drop_event" - do not publish the result.
+	// If the result is still too large, it is not published, and the tx is
+	// not indexed.
+	OversizedEventPolicy string `mapstructure:"oversized_event_policy"`
 }
 
+// Policies for the events of a tx exceeding the maximum event size.
+const (
+	OversizedEventTruncateAttributes = "truncate_attributes"
+	OversizedEventDropTxData         = "drop_tx_data"
+	OversizedEventDrop               = "drop_event"
+)
+
 // DefaultTxIndexConfig returns a default configuration for the transaction indexer.
 func DefaultTxIndexConfig() *TxIndexConfig {
 	return &TxIndexConfig{
-		Indexer: "kv",
+		Indexer:              "kv",
+		MaxEventSize:         0,
+		OversizedEventPolicy: OversizedEventTruncateAttributes,
+	}
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *TxIndexConfig) ValidateBasic() error {
+	if cfg.MaxEventSize < 0 {
+		return cmterrors.ErrNegativeField{Field: "max_event_size"}
 	}
+	switch cfg.OversizedEventPolicy {
+	case OversizedEventTruncateAttributes, OversizedEventDropTxData, OversizedEventDrop:
+	default:
+		return fmt.Errorf("unknown oversized_event_policy: %q", cfg.OversizedEventPolicy)
+	}
+	return nil
 }
 
 // TestTxIndexConfig returns a default configuration for the transaction indexer.
@@ -1390,6 +2520,8 @@ func getDefaultMoniker() string {
 type PruningConfig struct {
 	// The time period between automated background pruning operations.
 	Interval time.Duration `mapstructure:"interval"`
+	// Number of most recent heights to keep for each type of data.
+	KeepRecent *KeepRecentPruningConfig `mapstructure:"keep_recent"`
 	// Data companion-related pruning configuration.
 	DataCompanion *DataCompanionPruningConfig `mapstructure:"data_companion"`
 }
@@ -1397,6 +2529,7 @@ type PruningConfig struct {
 func DefaultPruningConfig() *PruningConfig {
 	return &PruningConfig{
 		Interval:      DefaultPruningInterval,
+		KeepRecent:    DefaultKeepRecentPruningConfig(),
 		DataCompanion: DefaultDataCompanionPruningConfig(),
 	}
 }
@@ -1404,6 +2537,7 @@ func DefaultPruningConfig() *PruningConfig {
 func TestPruningConfig() *PruningConfig {
 	return &PruningConfig{
 		Interval:      DefaultPruningInterval,
+		KeepRecent:    DefaultKeepRecentPruningConfig(),
 		DataCompanion: TestDataCompanionPruningConfig(),
 	}
 }
@@ -1412,12 +2546,213 @@ func (cfg *PruningConfig) ValidateBasic() error {
 	if cfg.Interval <= 0 {
 		return errors.New("interval must be > 0")
 	}
+	if err := cfg.KeepRecent.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [keep_recent] section: %w", err)
+	}
 	if err := cfg.DataCompanion.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [data_companion] section: %w", err)
 	}
 	return nil
 }
 
+//-----------------------------------------------------------------------------
+// KeepRecentPruningConfig
+
+// KeepRecentPruningConfig is the number of most recent heights to keep for
+// each type of data. The pruner prunes each type independently to its own
+// window, on top of the retain heights set by the application and the data
+// companion. 0 disables the window for that type of data.
+type KeepRecentPruningConfig struct {
+	// Number of most recent blocks to keep, along with their state.
+	Blocks int64 `mapstructure:"blocks"`
+	// Number of most recent heights to keep the ABCI responses of.
+	ABCIResponses int64 `mapstructure:"abci_responses"`
+	// Number of most recent heights to keep in the tx index.
+	TxIndex int64 `mapstructure:"tx_index"`
+	// Number of most recent heights to keep in the block index.
+	BlockIndex int64 `mapstructure:"block_index"`
+}
+
+func DefaultKeepRecentPruningConfig() *KeepRecentPruningConfig {
+	return &KeepRecentPruningConfig{}
+}
+
+func (cfg *KeepRecentPruningConfig) ValidateBasic() error {
+	if cfg.Blocks < 0 {
+		return cmterrors.ErrNegativeField{Field: "blocks"}
+	}
+	if cfg.ABCIResponses < 0 {
+		return cmterrors.ErrNegativeField{Field: "abci_responses"}
+	}
+	if cfg.TxIndex < 0 {
+		return cmterrors.ErrNegativeField{Field: "tx_index"}
+	}
+	if cfg.BlockIndex < 0 {
+		return cmterrors.ErrNegativeField{Field: "block_index"}
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+// StorageForecastConfig
+
+type StorageForecastConfig struct {
+	// The time period between measurements of the size of the databases. Set
+	// to 0 to disable storage usage forecasting.
+	Interval time.Duration `mapstructure:"interval"`
+	// The time period over which the growth rate of the databases is
+	// computed.
+	Window time.Duration `mapstructure:"window"`
+	// A warning is logged when the volume holding the databases is projected
+	// to be full in less than this number of days. Set to 0 to disable
+	// warnings.
+	WarnDaysUntilFull uint `mapstructure:"warn_days_until_full"`
+}
+
+func DefaultStorageForecastConfig() *StorageForecastConfig {
+	return &StorageForecastConfig{
+		Interval:          DefaultStorageForecastInterval,
+		Window:            DefaultStorageForecastWindow,
+		WarnDaysUntilFull: 7,
+	}
+}
+
+func TestStorageForecastConfig() *StorageForecastConfig {
+	return &StorageForecastConfig{
+		Interval:          0,
+		Window:            DefaultStorageForecastWindow,
+		WarnDaysUntilFull: 7,
+	}
+}
+
+func (cfg *StorageForecastConfig) ValidateBasic() error {
+	if cfg.Interval < 0 {
+		return cmterrors.ErrNegativeField{Field: "interval"}
+	}
+	if cfg.Interval > 0 && cfg.Window < cfg.Interval {
+		return errors.New("window must be greater than or equal to interval")
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+// StorageEncryptionConfig
+
+// The stores that can be encrypted at rest.
+const (
+	EncryptedStorePrivValState = "privval_state"
+	EncryptedStoreAddrBook     = "addrbook"
+	EncryptedStoreEvidence     = "evidence"
+	EncryptedStoreState        = "state"
+)
+
+var encryptedStores = []string{
+	EncryptedStorePrivValState,
+	EncryptedStoreAddrBook,
+	EncryptedStoreEvidence,
+	EncryptedStoreState,
+}
+
+// StorageEncryptionConfig controls the encryption of sensitive stores at
+// rest with AES-256-GCM.
+type StorageEncryptionConfig struct {
+	// The name of the environment variable holding the hex-encoded 32-byte
+	// encryption key. Encryption is disabled if empty.
+	KeyEnv string `mapstructure:"key_env"`
+	// The stores to encrypt, among "privval_state", "addrbook", "evidence"
+	// and "state".
+	Stores []string `mapstructure:"stores"`
+}
+
+func DefaultStorageEncryptionConfig() *StorageEncryptionConfig {
+	return &StorageEncryptionConfig{
+		KeyEnv: "",
+		Stores: []string{
+			EncryptedStorePrivValState,
+			EncryptedStoreAddrBook,
+			EncryptedStoreEvidence,
+		},
+	}
+}
+
+// IsEnabled returns true if the stores are encrypted at rest.
+func (cfg *StorageEncryptionConfig) IsEnabled() bool {
+	return cfg != nil && cfg.KeyEnv != ""
+}
+
+// Encrypts returns true if the given store is encrypted at rest.
+func (cfg *StorageEncryptionConfig) Encrypts(store string) bool {
+	return cfg.IsEnabled() && slices.Contains(cfg.Stores, store)
+}
+
+// Cipher returns the cipher encrypting the stores, using the key read from
+// the environment, or nil if encryption is disabled.
+func (cfg *StorageEncryptionConfig) Cipher() (*atrest.Cipher, error) {
+	if !cfg.IsEnabled() {
+		return nil, nil
+	}
+	key, err := atrest.KeyFromEnv(cfg.KeyEnv)
+	if err != nil {
+		return nil, err
+	}
+	return atrest.NewCipher(key)
+}
+
+func (cfg *StorageEncryptionConfig) ValidateBasic() error {
+	if cfg == nil {
+		return nil
+	}
+	for _, store := range cfg.Stores {
+		if !slices.Contains(encryptedStores, store) {
+			return fmt.Errorf("unknown store %q in stores, expected one of %v", store, encryptedStores)
+		}
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+// StorageCompressionConfig
+
+// The stores that can be compressed.
+const (
+	CompressedStoreABCIResponses = "abci_responses"
+	CompressedStoreTxIndex       = "tx_index"
+)
+
+var compressedStores = []string{
+	CompressedStoreABCIResponses,
+	CompressedStoreTxIndex,
+}
+
+// StorageCompressionConfig controls the compression of the stores with zstd.
+type StorageCompressionConfig struct {
+	// The stores to compress, among "abci_responses" and "tx_index".
+	Stores []string `mapstructure:"stores"`
+}
+
+func DefaultStorageCompressionConfig() *StorageCompressionConfig {
+	return &StorageCompressionConfig{
+		Stores: []string{},
+	}
+}
+
+// Compresses returns true if the given store is compressed.
+func (cfg *StorageCompressionConfig) Compresses(store string) bool {
+	return cfg != nil && slices.Contains(cfg.Stores, store)
+}
+
+func (cfg *StorageCompressionConfig) ValidateBasic() error {
+	if cfg == nil {
+		return nil
+	}
+	for _, store := range cfg.Stores {
+		if !slices.Contains(compressedStores, store) {
+			return fmt.Errorf("unknown store %q in stores, expected one of %v", store, compressedStores)
+		}
+	}
+	return nil
+}
+
 //-----------------------------------------------------------------------------
 // DataCompanionPruningConfig
 
@@ -2,9 +2,11 @@ package config
 
 import (
 	"context"
+	"fmt"
 
 	dbm "github.com/cometbft/cometbft-db"
 
+	"github.com/cometbft/cometbft/libs/atrest"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/libs/service"
 )
@@ -22,9 +24,56 @@ type DBContext struct {
 type DBProvider func(*DBContext) (dbm.DB, error)
 
 // DefaultDBProvider returns a database using the DBBackend and DBDir
-// specified in the Config.
+// specified in the Config. The values of the evidence and state databases are
+// encrypted if configured in the [storage.encryption] section.
 func DefaultDBProvider(ctx *DBContext) (dbm.DB, error) {
 	dbType := dbm.BackendType(ctx.Config.DBBackend)
 
-	return dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
+	var cipher *atrest.Cipher
+	if ctx.Config.Storage != nil && ctx.Config.Storage.Encryption.Encrypts(ctx.ID) {
+		var err error
+		if cipher, err = ctx.Config.Storage.Encryption.Cipher(); err != nil {
+			return nil, err
+		}
+	}
+	db, err := dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
+	if err != nil || cipher == nil {
+		return db, err
+	}
+	return atrest.NewDB(db, cipher), nil
+}
+
+// DBBackendRequiresCgo returns true if the database backend wraps a C
+// library, and thus requires cgo, which prevents cross-compiling the node.
+// The default goleveldb backend is written in pure Go.
+func DBBackendRequiresCgo(backend string) bool {
+	switch dbm.BackendType(backend) {
+	case dbm.CLevelDBBackend, dbm.RocksDBBackend:
+		return true
+	default:
+		return false
+	}
+}
+
+// StorageParityWarnings returns a warning for each configured storage feature
+// which requires a cgo backend or is not supported by the configured database
+// backend. All the features of the node are supported by the pure-Go goleveldb
+// backend.
+func (cfg *Config) StorageParityWarnings() []string {
+	var warnings []string
+	if DBBackendRequiresCgo(cfg.DBBackend) {
+		if !cgoEnabled {
+			warnings = append(warnings, fmt.Sprintf(
+				"db_backend %q requires cgo, but the node was built without it: the databases cannot be opened", cfg.DBBackend))
+		} else {
+			warnings = append(warnings, fmt.Sprintf(
+				"db_backend %q requires cgo, which prevents cross-compiling the node; the pure-Go goleveldb backend supports all its features",
+				cfg.DBBackend))
+		}
+	}
+	if dbm.BackendType(cfg.DBBackend) == dbm.MemDBBackend &&
+		cfg.Storage != nil && cfg.Storage.Forecast != nil && cfg.Storage.Forecast.Interval > 0 {
+		warnings = append(warnings, "storage.forecast is enabled, but the memdb backend has no files to measure")
+	}
+	return warnings
 }
@@ -0,0 +1,6 @@
+//go:build !cgo
+
+package config
+
+// cgoEnabled is true if the node was built with cgo.
+const cgoEnabled = false
@@ -93,6 +93,7 @@ moniker = "{{ .BaseConfig.Moniker }}"
 # * goleveldb (github.com/syndtr/goleveldb - most popular implementation)
 #   - pure go
 #   - stable
+#   - supports all the features of the node, and cross-compiles (default)
 # * cleveldb (uses levigo wrapper)
 #   - fast
 #   - requires gcc
@@ -108,6 +109,8 @@ moniker = "{{ .BaseConfig.Moniker }}"
 # * badgerdb (uses github.com/dgraph-io/badger)
 #   - EXPERIMENTAL
 #   - use badgerdb build tag (go build -tags badgerdb)
+# The node warns on startup if the backend requires cgo (cleveldb, rocksdb)
+# or does not support a configured storage feature.
 db_backend = "{{ .BaseConfig.DBBackend }}"
 
 # Database directory
@@ -134,6 +137,15 @@ priv_validator_state_file = "{{ js .BaseConfig.PrivValidatorState }}"
 # connections from an external PrivValidator process
 priv_validator_laddr = "{{ .BaseConfig.PrivValidatorListenAddr }}"
 
+# URL of an external, strongly-consistent store (e.g. a plugin in front of etcd)
+# of the highest height, round and step signed by the validator. It's shared by
+# the instances of the validator in an active-passive setup: an instance only
+# signs after advancing the watermark in the store, so that it can't double sign
+# on failover. The store is only used by the file-based private validator, and
+# must reply to POST requests with 200 if the watermark was advanced and 409
+# otherwise. Disabled if empty.
+priv_validator_watermark_store = "{{ .BaseConfig.PrivValidatorWatermarkStore }}"
+
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node_key_file = "{{ js .BaseConfig.NodeKey }}"
 
@@ -168,9 +180,6 @@ cors_allowed_methods = [{{ range .RPC.CORSAllowedMethods }}{{ printf "%q, " . }}
 # A list of non simple headers the client is allowed to use with cross-domain requests
 cors_allowed_headers = [{{ range .RPC.CORSAllowedHeaders }}{{ printf "%q, " . }}{{end}}]
 
-# Activate unsafe RPC commands like /dial_seeds and /unsafe_flush_mempool
-unsafe = {{ .RPC.Unsafe }}
-
 # Maximum number of simultaneous connections (including WebSocket).
 # If you want to accept a larger number than the default, make sure
 # you increase your OS limits.
@@ -184,15 +193,16 @@ max_open_connections = {{ .RPC.MaxOpenConnections }}
 # of broadcast_tx_commit calls per block.
 max_subscription_clients = {{ .RPC.MaxSubscriptionClients }}
 
-# Maximum number of unique queries a given client can /subscribe to.
+# Maximum number of unique queries a given client, i.e. a WebSocket
+# connection, can /subscribe to.
 # If you're using /broadcast_tx_commit, set to the estimated maximum number
 # of broadcast_tx_commit calls per block.
 max_subscriptions_per_client = {{ .RPC.MaxSubscriptionsPerClient }}
 
 # Experimental parameter to specify the maximum number of events a node will
-# buffer, per subscription, before returning an error and closing the
-# subscription. Must be set to at least 100, but higher values will accommodate
-# higher event throughput rates (and will use more memory).
+# buffer, per subscription, before applying websocket_slow_client_policy. Must
+# be set to at least 100, but higher values will accommodate higher event
+# throughput rates (and will use more memory).
 experimental_subscription_buffer_size = {{ .RPC.SubscriptionBufferSize }}
 
 # Experimental parameter to specify the maximum number of RPC responses that
@@ -207,27 +217,124 @@ experimental_subscription_buffer_size = {{ .RPC.SubscriptionBufferSize }}
 # accommodate non-subscription-related RPC responses.
 experimental_websocket_write_buffer_size = {{ .RPC.WebSocketWriteBufferSize }}
 
-# If a WebSocket client cannot read fast enough, at present we may
-# silently drop events instead of generating an error or disconnecting the
-# client.
-#
-# Enabling this experimental parameter will cause the WebSocket connection to
-# be closed instead if it cannot read fast enough, allowing for greater
-# predictability in subscription behavior.
-experimental_close_on_slow_client = {{ .RPC.CloseOnSlowClient }}
+# What happens to the subscriptions of a WebSocket client not reading fast
+# enough, i.e. whose subscription buffer is full or whose events cannot be
+# written within 10s:
+#   - "close": the subscription is closed with an error.
+#   - "drop": the events are dropped, and the subscription kept.
+# Either way, the event bus never waits for a slow client.
+websocket_slow_client_policy = "{{ .RPC.SlowClientPolicy }}"
+
+# Interval of the pings sent by the server to the WebSocket clients. A client
+# not answering with a pong, nor sending anything, for 10/9 of the interval is
+# disconnected.
+websocket_ping_interval = "{{ .RPC.WebSocketPingInterval }}"
 
 # How long to wait for a tx to be committed during /broadcast_tx_commit.
+# Requests can set a shorter timeout with their timeout parameter.
 # WARNING: Using a value larger than 10s will result in increasing the
 # global HTTP write timeout, which applies to all connections and endpoints.
 # See https://github.com/tendermint/tendermint/issues/3435
 timeout_broadcast_tx_commit = "{{ .RPC.TimeoutBroadcastTxCommit }}"
 
+# Maximum number of idempotency keys remembered by the /broadcast_tx_*
+# endpoints. A request repeating a remembered key, with the same tx, returns
+# the outcome of the original request instead of broadcasting the tx again;
+# /broadcast_tx_commit also returns the result of the tx once committed.
+# 0 disables idempotency keys.
+idempotency_key_cache_size = {{ .RPC.IdempotencyKeyCacheSize }}
+
+# How long an idempotency key is remembered.
+idempotency_key_ttl = "{{ .RPC.IdempotencyKeyTTL }}"
+
+# Maximum number of results returned by /tx_search and /block_search. A search
+# exceeding it returns a job ID instead of results, while the results are
+# written to a temporary file in the background, from which they can be
+# paginated with /search_job.
+# 0 means no limit.
+max_search_results = {{ .RPC.MaxSearchResults }}
+
+# Maximum number of search jobs kept at the same time.
+max_search_jobs = {{ .RPC.MaxSearchJobs }}
+
+# How long the results of a search job are kept.
+search_job_ttl = "{{ .RPC.SearchJobTTL }}"
+
+# Maximum length, in bytes, of the queries of /subscribe, /tx_search,
+# /block_search and /unconfirmed_txs.
+# 0 means no limit.
+max_query_length = {{ .RPC.MaxQueryLength }}
+
+# Maximum number of conditions of the queries, e.g. 2 for
+# "tm.event = 'Tx' AND tx.height > 5".
+# 0 means no limit.
+max_query_conditions = {{ .RPC.MaxQueryConditions }}
+
+# The /tx_search and /block_search requests taking longer are logged, with the
+# query, the number of results and the number of index keys scanned, to find
+# the expensive queries.
+# 0 disables the log.
+slow_query_threshold = "{{ .RPC.SlowQueryThreshold }}"
+
+# Maximum number of requests per second of each client IP to each method,
+# above which the requests are rejected, with a 429 status over HTTP, until the
+# client slows down. The limit applies to the address of the connection: behind
+# a proxy, it is the address of the proxy.
+# 0 disables the rate limiting.
+rate_limit = {{ .RPC.RateLimit }}
+
+# Maximum number of requests of a client IP to a method accepted at once,
+# before being limited to the rate. 0 means the rate, rounded up.
+rate_limit_burst = {{ .RPC.RateLimitBurst }}
+
+# Comma separated list of "<method>=<rate>" entries overriding the rate_limit
+# of some methods, e.g. "broadcast_tx_commit=1,tx_search=0.5". A rate of 0
+# disables the limit of the method.
+method_rate_limits = "{{ .RPC.MethodRateLimits }}"
+
+# Client IPs and CIDR ranges, e.g. "10.0.0.0/8", not rate limited.
+rate_limit_allowlist = [{{ range .RPC.RateLimitAllowlist }}{{ printf "%q, " . }}{{end}}]
+
+# Path to a JSON file of API keys, and of JWT issuers, each giving access to
+# some methods. If set, the clients of the RPC server, and of the gRPC server,
+# must send a key, or a JWT, giving access to the methods they call in an
+# "Authorization: Bearer <key>" header, except for the anonymous_methods. See the "Running in production" docs for
+# the format of the file.
+# Might be either absolute path or path related to CometBFT's config directory.
+api_keys_file = "{{ js .RPC.APIKeys }}"
+
+# The methods callable without an API key, if api_keys_file is set.
+anonymous_methods = [{{ range .RPC.AnonymousMethods }}{{ printf "%q, " . }}{{end}}]
+
+# Maximum number of requests in a JSON-RPC batch request, i.e. an array of
+# requests in a single HTTP POST. 0 means no limit.
+max_request_batch_size = {{ .RPC.MaxRequestBatchSize }}
+
+# Maximum number of the requests of a JSON-RPC batch request executed
+# concurrently. The responses are in the order of the requests regardless, but
+# requests executed concurrently, e.g. broadcasting transactions, may complete
+# in any order. 1 executes the requests one by one, in order.
+batch_request_concurrency = {{ .RPC.BatchRequestConcurrency }}
+
 # Maximum size of request body, in bytes
 max_body_bytes = {{ .RPC.MaxBodyBytes }}
 
 # Maximum size of request header, in bytes
 max_header_bytes = {{ .RPC.MaxHeaderBytes }}
 
+# Compress the responses with gzip or deflate for the clients accepting it in
+# the Accept-Encoding header of their requests. The responses of
+# block_results and tx_search, in particular, shrink a lot.
+compress_responses = {{ .RPC.CompressResponses }}
+
+# Minimum number of peers of a healthy node, as reported by
+# /health?verbose=true. 0 means no minimum.
+health_min_peers = {{ .RPC.HealthMinPeers }}
+
+# Maximum number of blocks the indexer of a healthy node lags behind the block
+# store, as reported by /health?verbose=true. 0 means no maximum.
+health_max_indexer_lag = {{ .RPC.HealthMaxIndexerLag }}
+
 # The path to a file containing certificate that is used to create the HTTPS server.
 # Might be either absolute path or path related to CometBFT's config directory.
 # If the certificate is signed by a certificate authority,
@@ -285,6 +392,27 @@ enabled = {{ .GRPC.BlockService.Enabled }}
 [grpc.block_results_service]
 enabled = {{ .GRPC.BlockResultsService.Enabled }}
 
+# The gRPC mempool service streams the transactions accepted into the mempool,
+# with the response of the application to CheckTx.
+[grpc.mempool_service]
+enabled = {{ .GRPC.MempoolService.Enabled }}
+
+# The gRPC events service streams the events matching a query, as the
+# /subscribe RPC endpoint.
+[grpc.events_service]
+enabled = {{ .GRPC.EventsService.Enabled }}
+
+# Maximum number of events buffered per stream while its client does not read
+# them. The clients may request a smaller buffer, and whether the events are
+# dropped or the stream canceled once the buffer is full.
+max_buffer_size = {{ .GRPC.EventsService.MaxBufferSize }}
+
+# The gRPC query service provides the blocks, the indexed transactions, the
+# block and transaction searches, streaming the transactions found, and the
+# status of the node.
+[grpc.query_service]
+enabled = {{ .GRPC.QueryService.Enabled }}
+
 #
 # Configuration for privileged gRPC endpoints, which should **never** be exposed
 # to the public internet.
@@ -293,6 +421,23 @@ enabled = {{ .GRPC.BlockResultsService.Enabled }}
 # The host/port on which to expose privileged gRPC endpoints.
 laddr = "{{ .GRPC.Privileged.ListenAddress }}"
 
+# The path to a file containing the certificate used by the privileged gRPC
+# server for TLS. Might be either absolute path or path related to CometBFT's
+# config directory.
+# NOTE: both tls_cert_file and tls_key_file must be present for the privileged
+# gRPC server to use TLS. Otherwise, it serves plaintext connections.
+tls_cert_file = "{{ .GRPC.Privileged.TLSCertFile }}"
+
+# The path to a file containing the private key matching tls_cert_file. Might be
+# either absolute path or path related to CometBFT's config directory.
+tls_key_file = "{{ .GRPC.Privileged.TLSKeyFile }}"
+
+# The path to a file containing the certificates of the certificate authorities
+# signing the certificates of the clients. If set, clients must authenticate
+# with a certificate signed by one of them. Might be either absolute path or path
+# related to CometBFT's config directory.
+tls_client_ca_file = "{{ .GRPC.Privileged.TLSClientCAFile }}"
+
 #
 # Configuration specifically for the gRPC pruning service, which is considered a
 # privileged service.
@@ -306,6 +451,22 @@ laddr = "{{ .GRPC.Privileged.ListenAddress }}"
 # Disabled by default.
 enabled = {{ .GRPC.Privileged.PruningService.Enabled }}
 
+#
+# Configuration specifically for the gRPC admin service, which is considered a
+# privileged service. It provides peer management (dialing seeds and peers,
+# disconnecting peers), mempool management (flushing and dumping the mempool),
+# control over pruning (pausing and resuming it), backups of the stores and
+# halting the node.
+#
+[grpc.privileged.admin_service]
+
+# The admin service can only be enabled if the privileged gRPC server requires
+# clients to authenticate with a certificate, i.e. if tls_cert_file,
+# tls_key_file and tls_client_ca_file are set.
+#
+# Disabled by default.
+enabled = {{ .GRPC.Privileged.AdminService.Enabled }}
+
 #######################################################
 ###           P2P Configuration Options             ###
 #######################################################
@@ -319,15 +480,55 @@ laddr = "{{ .P2P.ListenAddress }}"
 # address. IP and port are required. Example: 159.89.10.97:26656
 external_address = "{{ .P2P.ExternalAddress }}"
 
+# Transport of the connections to the peers:
+# 1) "tcp" - (default) secret connections over TCP
+# 2) "quic" - QUIC connections over UDP, sending the messages of each channel
+# on their own stream. The node then listens on the UDP port of laddr. The
+# peers must use the same transport to connect to each other.
+transport = "{{ .P2P.Transport }}"
+
+# Port mapping on the NAT gateway of the local network, when external_address
+# is empty:
+# 1) "none" - (default) no port mapping
+# 2) "any" - map the port of laddr with UPnP or NAT-PMP
+# 3) "upnp" - map the port of laddr with UPnP
+# 4) "pmp" - map the port of laddr with NAT-PMP
+# The external address of the gateway, with the mapped port, is then
+# advertised to the peers, so that a node behind a home router can be dialed
+# without configuring the router by hand. The mapping is renewed while the
+# node runs, and deleted on shutdown.
+nat = "{{ .P2P.NAT }}"
+
+# Compression of the large messages (e.g. block parts, snapshot chunks) sent to
+# the peers which support it:
+# 1) "none" - (default) no compression
+# 2) "snappy" - fast compression
+# 3) "zstd" - better compression, for more CPU
+# A node with compression decodes both snappy and zstd messages. Compression
+# cuts the bandwidth used between the nodes, e.g. of seed and archive nodes.
+compression = "{{ .P2P.Compression }}"
+
 # Comma separated list of seed nodes to connect to
 seeds = "{{ .P2P.Seeds }}"
 
+# Comma separated list of "<public key>@<domain>" DNS seeds, domains publishing
+# the addresses of seed nodes in TXT records signed by the given hex encoded
+# ed25519 key, so that the seeds of a network can be rotated without editing
+# the config of the nodes. Used along with the seeds. The records can be
+# generated with the "dns-seed-records" command.
+dns_seeds = "{{ .P2P.DNSSeeds }}"
+
 # Comma separated list of nodes to keep persistent connections to
 persistent_peers = "{{ .P2P.PersistentPeers }}"
 
 # Path to address book
 addr_book_file = "{{ js .P2P.AddrBook }}"
 
+# Where the address book is saved:
+# 1) "file" - (default) the JSON file at addr_book_file
+# 2) "db" - the "addrbook" database of the node, using db_backend
+addr_book_store = "{{ .P2P.AddrBookStore }}"
+
 # Set true for strict address routability rules
 # Set false for private or local networks
 addr_book_strict = {{ .P2P.AddrBookStrict }}
@@ -335,6 +536,13 @@ addr_book_strict = {{ .P2P.AddrBookStrict }}
 # Maximum number of inbound peers
 max_num_inbound_peers = {{ .P2P.MaxNumInboundPeers }}
 
+# Maximum number of simultaneous inbound connections from the same IP, and
+# from the same /24 (IPv4) or /64 (IPv6) subnet, including the connections
+# still in their handshake (0 - unlimited). They also apply to the
+# unconditional peers.
+max_inbound_conns_per_ip = {{ .P2P.MaxInboundConnsPerIP }}
+max_inbound_conns_per_subnet = {{ .P2P.MaxInboundConnsPerSubnet }}
+
 # Maximum number of outbound peers to connect to, excluding persistent peers
 max_num_outbound_peers = {{ .P2P.MaxNumOutboundPeers }}
 
@@ -356,18 +564,60 @@ send_rate = {{ .P2P.SendRate }}
 # Rate at which packets can be received, in bytes/second
 recv_rate = {{ .P2P.RecvRate }}
 
+# Comma separated lists of "<reactor>=<rate>" entries limiting the rates, in
+# bytes/second, at which each channel of the reactors (blocksync, consensus,
+# evidence, mempool, pex, statesync) can send and receive packets on a
+# connection, within the send_rate and recv_rate. For instance,
+# "blocksync=1024000,statesync=1024000" keeps a node serving blocks or
+# snapshots from starving its consensus traffic. As the connections to the
+# peers are multiplexed, reaching the receive rate of a channel pauses the
+# reading of the whole connection, unless using the QUIC transport.
+channel_send_rates = "{{ .P2P.ChannelSendRates }}"
+channel_recv_rates = "{{ .P2P.ChannelRecvRates }}"
+
 # Set true to enable the peer-exchange reactor
 pex = {{ .P2P.PexReactor }}
 
 # Seed mode, in which node constantly crawls the network and looks for
 # peers. If another node asks it for addresses, it responds and disconnects.
+# A seed only runs the peer-exchange reactor: it does not sync, gossip or
+# commit blocks, and only advertises the peer-exchange channel.
 #
 # Does not work if the peer-exchange reactor is disabled.
 seed_mode = {{ .P2P.SeedMode }}
 
+# In seed mode, the interval between two crawls of the network, and the
+# minimum time between two crawls of the same peer.
+seed_crawl_period = "{{ .P2P.SeedCrawlPeriod }}"
+seed_recrawl_period = "{{ .P2P.SeedRecrawlPeriod }}"
+
+# In seed mode, how long the seed stays connected to the peers it dialed, for
+# them to become good peers before being given out.
+seed_disconnect_wait_period = "{{ .P2P.SeedDisconnectWaitPeriod }}"
+
+# In seed mode, the maximum number of addresses of the same autonomous system
+# (AS) crawled at once, or given to a peer, to spread the peers across the
+# networks. Unlimited if 0.
+seed_max_addrs_per_as = {{ .P2P.SeedMaxAddrsPerAS }}
+
+# Path to a file mapping the IP prefixes to their AS, with one
+# "<IP prefix> <AS number>" line per prefix, e.g. "1.2.0.0/16 13335". Without
+# it, the AS of an address is approximated by its /16 (IPv4) or /32 (IPv6)
+# network.
+seed_asmap_file = "{{ js .P2P.SeedASMap }}"
+
 # Comma separated list of peer IDs to keep private (will not be gossiped to other peers)
 private_peer_ids = "{{ .P2P.PrivatePeerIDs }}"
 
+# Path to a list of peer IDs signed by the hex encoded ed25519 key
+# peer_allowlist_key. If set, only the listed peers can connect to the node, or
+# be dialed by it, e.g. for the validator and sentry nodes of a private
+# network. The list is reloaded when the file changes, and the peers removed
+# from it are disconnected. The list can be signed with the
+# "sign-peer-allowlist" command.
+peer_allowlist_file = "{{ js .P2P.PeerAllowlist }}"
+peer_allowlist_key = "{{ .P2P.PeerAllowlistKey }}"
+
 # Toggle to disable guard against peers connecting from the same ip.
 allow_duplicate_ip = {{ .P2P.AllowDuplicateIP }}
 
@@ -380,6 +630,16 @@ dial_timeout = "{{ .P2P.DialTimeout }}"
 #######################################################
 [mempool]
 
+# The type of mempool for this node to use.
+#
+#  Possible types:
+#  - "flood" : concurrent linked list of transactions, reaped in order of
+#  arrival and flooded to all the peers.
+#  - "priority" : like "flood", but transactions are reaped in decreasing order
+#  of the priority assigned by the application in CheckTx, and the
+#  lowest-priority transactions are evicted when the mempool is full.
+type = "{{ .Mempool.Type }}"
+
 # recheck (default: true) defines whether CometBFT should recheck the
 # validity for all remaining transaction in the mempool after a block.
 # Since a block affects the application state, some transactions in the
@@ -387,6 +647,14 @@ dial_timeout = "{{ .P2P.DialTimeout }}"
 # you can disable rechecking.
 recheck = {{ .Mempool.Recheck }}
 
+# recheck_concurrency (default: 1) is the maximum number of CheckTx calls
+# issued concurrently to the application when rechecking the transactions
+# after a block. With a value above 1, the recheck completes before the
+# mempool is unlocked for the next block, which shortens the time it takes on
+# busy chains when the application handles CheckTx calls in parallel. Values
+# of 0 and 1 keep the sequential recheck.
+recheck_concurrency = {{ .Mempool.RecheckConcurrency }}
+
 # broadcast (default: true) defines whether the mempool should relay
 # transactions to other peers. Setting this to false will stop the mempool
 # from relaying transactions to other peers until they are included in a
@@ -394,10 +662,23 @@ recheck = {{ .Mempool.Recheck }}
 # the tx to will see it until it is included in a block.
 broadcast = {{ .Mempool.Broadcast }}
 
+# gossip_mode (default: "push") defines how transactions are relayed to peers.
+#   1) "push" - every transaction is sent in full to every peer which did not
+#   send it to this node.
+#   2) "pull" - only the hashes of the transactions are announced to peers,
+#   which request the transactions they don't have yet from the first peer
+#   announcing them. This avoids receiving the same transaction from most
+#   peers, at the cost of an extra round trip. The transactions are still
+#   sent in full to the peers running older versions.
+gossip_mode = "{{ .Mempool.GossipMode }}"
+
 # wal_dir (default: "") configures the location of the Write Ahead Log
 # (WAL) for the mempool. The WAL is disabled by default. To enable, set
 # wal_dir to where you want the WAL to be written (e.g.
 # "data/mempool.wal").
+# The transactions added to and removed from the mempool are journaled to the
+# WAL, and the transactions still in the mempool are resubmitted to CheckTx on
+# startup after a crash.
 wal_dir = "{{ js .Mempool.WalPath }}"
 
 # Maximum number of transactions in the mempool
@@ -425,6 +706,101 @@ max_tx_bytes = {{ .Mempool.MaxTxBytes }}
 # XXX: Unused due to https://github.com/tendermint/tendermint/issues/5796
 max_batch_bytes = {{ .Mempool.MaxBatchBytes }}
 
+# Experimental parameter to enable support for threshold-encrypted
+# transactions. Encrypted transactions are stored and gossiped as ciphertexts
+# tagged with a decryption height, and are only reaped for the block at that
+# height. Encrypted transactions not included at their decryption height are
+# evicted from the mempool. The vote extensions of the validators only carry
+# their decryption shares once the abci.encrypted_txs_enable_height consensus
+# param is reached.
+experimental_encrypted_txs = {{ .Mempool.ExperimentalEncryptedTxs }}
+
+# Experimental path to the file holding the threshold public key of the
+# validators and the key share of this node, as generated by
+# "cometbft gen-threshold-keys". When set, the node delivers its decryption
+# shares of the encrypted transactions of the next height in its vote
+# extensions, and the proposer decrypts them before passing them to
+# PrepareProposal. Requires experimental_encrypted_txs and the
+# abci.encrypted_txs_enable_height consensus param.
+# The validators without a key share extend their votes with no decryption
+# shares, and the encrypted transactions are decrypted once the shares of
+# enough validators are in the last commit.
+experimental_threshold_key_file = "{{ js .Mempool.ExperimentalThresholdKeyFile }}"
+
+# ttl_num_blocks (default: 0) is the number of blocks after which a transaction
+# that was not included is evicted from the mempool and the cache. The
+# application can set a shorter TTL for a transaction in its CheckTx response.
+# 0 disables the default TTL.
+ttl_num_blocks = {{ .Mempool.TTLNumBlocks }}
+
+# ttl_duration (default: 0s) is the duration after which a transaction that was
+# not included is evicted from the mempool and the cache. Expiration is checked
+# after each block. The application can set a shorter TTL for a transaction in
+# its CheckTx response. 0 disables the default TTL.
+ttl_duration = "{{ .Mempool.TTLDuration }}"
+
+# persist_path (default: "") is the file to which the transactions of the
+# mempool, along with their CheckTx metadata, are saved on shutdown. They are
+# resubmitted to CheckTx on startup, keeping the height at which they entered
+# the mempool and their expiry, and the file is removed. Persistence is
+# disabled by default. To enable, set it to e.g. "data/mempool.json".
+persist_path = "{{ js .Mempool.PersistPath }}"
+
+# peer_max_txs_per_sec (default: 0) is the maximum rate of transactions
+# received from a single peer which are checked. Transactions received above
+# this rate are dropped, so that a spamming peer cannot saturate CheckTx for
+# the whole node. 0 disables the limit.
+peer_max_txs_per_sec = {{ .Mempool.PeerMaxTxsPerSecond }}
+
+# peer_max_bytes_per_sec (default: 0) is the maximum rate in bytes of
+# transactions received from a single peer which are checked. Transactions
+# received above this rate are dropped. 0 disables the limit.
+peer_max_bytes_per_sec = {{ .Mempool.PeerMaxBytesPerSecond }}
+
+# max_txs_per_sender (default: 0) is the maximum number of transactions of a
+# single sender in the mempool, the sender of a transaction being assigned by
+# the application in its CheckTx response. Transactions beyond the limit are
+# rejected, so that a single account cannot fill the mempool. Transactions
+# without a sender are not limited. 0 disables the limit.
+max_txs_per_sender = {{ .Mempool.MaxTxsPerSender }}
+
+# max_txs_bytes_per_sender (default: 0) is the maximum total size in bytes of
+# the transactions of a single sender in the mempool. 0 disables the limit.
+max_txs_bytes_per_sender = {{ .Mempool.MaxTxsBytesPerSender }}
+
+# replace_by_fee_factor (default: 0) allows a transaction to replace the
+# transaction of the same sender and sequence in the mempool, as assigned by the
+# application in its CheckTx response, if its priority is at least this factor
+# times the priority of the replaced transaction, e.g. 1.1 for a 10% bump. This
+# lets users bump the fee of a stuck transaction. 0 disables the replacement.
+replace_by_fee_factor = {{ .Mempool.ReplaceByFeeFactor }}
+
+# Per-class transaction quotas and ordering weights. The application assigns a
+# class to a transaction in its CheckTx response; transactions without a class
+# belong to the "default" class. Class names must be lower case.
+#
+# max_txs (default: 0) is the maximum number of transactions of the class in
+# the mempool, 0 meaning the class is only limited by the mempool size.
+# weight (default: 1) is the number of transactions of the class reaped in each
+# round when interleaving the classes in a proposal. Classes not listed here
+# have no quota and a weight of 1. When no classes are configured, transactions
+# are reaped in FIFO order.
+#
+# Example:
+#
+# [mempool.tx_classes.default]
+# max_txs = 4000
+# weight = 1
+#
+# [mempool.tx_classes.governance]
+# max_txs = 0
+# weight = 4
+{{ range $name, $class := .Mempool.TxClasses }}
+[mempool.tx_classes.{{ $name }}]
+max_txs = {{ $class.MaxTxs }}
+weight = {{ $class.Weight }}
+{{ end }}
+
 #######################################################
 ###         State Sync Configuration Options        ###
 #######################################################
@@ -461,6 +837,21 @@ chunk_request_timeout = "{{ .StateSync.ChunkRequestTimeout }}"
 # The number of concurrent chunk fetchers to run (default: 1).
 chunk_fetchers = "{{ .StateSync.ChunkFetchers }}"
 
+# URLs (comma-separated) of snapshots exported by "cometbft snapshot export"
+# and served over HTTP(S), or from an S3 bucket allowing anonymous reads with
+# s3://<bucket>/<prefix>, e.g. for networks with few peers serving snapshots.
+# The snapshots are discovered along with the ones of the peers, and their
+# chunks are fetched from these URLs, falling back to the peers.
+snapshot_sources = "{{ StringsJoin .StateSync.SnapshotSources "," }}"
+
+# Path to a local snapshot to restore instead of discovering and fetching
+# snapshots from peers, e.g. copied from object storage: a directory written by
+# "cometbft snapshot export" on another node, or a tar archive (optionally
+# gzip compressed) of its content. The app hash of the snapshot is still
+# verified with the light client, which needs the rpc_servers and the trusted
+# height and hash above.
+local_snapshot = "{{ js .StateSync.LocalSnapshot }}"
+
 #######################################################
 ###       Block Sync Configuration Options          ###
 #######################################################
@@ -481,6 +872,24 @@ version = "{{ .BlockSync.Version }}"
 
 wal_file = "{{ js .Consensus.WalPath }}"
 
+# Compression of the WAL entries, reducing the size of the WAL at the cost of
+# some CPU. Nodes can switch between them, the entries being read whatever
+# their compression, but older versions cannot read compressed entries.
+#
+# Options:
+#   1) "none" (default)
+#   2) "snappy" - fast, with a moderate compression ratio
+#   3) "zstd" - slower, with a better compression ratio
+wal_compression = "{{ .Consensus.WalCompression }}"
+
+# Size of a WAL segment, in bytes. Once the current segment reaches this size,
+# it is rotated and a new one is started.
+wal_segment_size = {{ .Consensus.WalSegmentSize }}
+
+# Total size of the WAL segments, in bytes. Once it is exceeded, the oldest
+# segments are removed. 0 keeps all the segments.
+wal_max_total_size = {{ .Consensus.WalMaxTotalSize }}
+
 # How long we wait for a proposal block before prevoting nil
 timeout_propose = "{{ .Consensus.TimeoutPropose }}"
 # How much timeout_propose increases with each round
@@ -498,6 +907,28 @@ timeout_precommit_delta = "{{ .Consensus.TimeoutPrecommitDelta }}"
 # though we already have +2/3).
 timeout_commit = "{{ .Consensus.TimeoutCommit }}"
 
+# Factor by which all the timeouts above and create_empty_blocks_interval are
+# scaled, e.g. 0.1 to run a test network 10 times faster. Intended for testing
+# only. Can be set with the CMT_CONSENSUS_TIMEOUT_SCALE environment variable.
+# Does not apply to the timeouts set in the consensus params. The times of the
+# blocks are compressed by the same factor: the time elapsed since the genesis
+# time is divided by it, so that they advance as at the normal pace.
+timeout_scale = {{ .Consensus.TimeoutScale }}
+
+# Set to true to adapt timeout_propose, timeout_prevote and timeout_precommit
+# to the durations of the propose, prevote and precommit steps observed in the
+# recent rounds, so that the timeouts follow the latency of the validator set
+# without manual tuning. Only the steps completed before their timeout are
+# observed. Each timeout is set to 1.5 times the given percentile of the last
+# observed durations of its step, bounded by the given factors of the
+# configured timeout. The deltas still apply to the later rounds. The timeouts
+# set in the consensus params are not adapted.
+adaptive_timeouts = {{ .Consensus.AdaptiveTimeouts }}
+adaptive_timeouts_window = {{ .Consensus.AdaptiveTimeoutsWindow }}
+adaptive_timeouts_percentile = {{ .Consensus.AdaptiveTimeoutsPercentile }}
+adaptive_timeouts_min_factor = {{ .Consensus.AdaptiveTimeoutsMinFactor }}
+adaptive_timeouts_max_factor = {{ .Consensus.AdaptiveTimeoutsMaxFactor }}
+
 # How many blocks to look back to check existence of the node's consensus votes before joining consensus
 # When non-zero, the node will panic upon restart
 # if the same consensus key was used to sign {double_sign_check_height} last blocks.
@@ -516,6 +947,63 @@ peer_gossip_sleep_duration = "{{ .Consensus.PeerGossipSleepDuration }}"
 peer_gossip_intraloop_sleep_duration = "{{ .Consensus.PeerGossipIntraloopSleepDuration }}"
 peer_query_maj23_sleep_duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 
+# Comma separated list of "<validator address>=<node ID>@<host>:<port>" entries
+# registering the nodes run by other validators. The node keeps direct links to
+# them and, if it is a validator, pushes its own proposals and votes to the
+# connected ones whose validator is in the validator set of the height,
+# bypassing multi-hop gossip. The validator peers are only taken from this list,
+# not discovered from the validator set, and the messages are pushed to all of
+# them, regardless of their latency.
+direct_validator_peers = "{{ .Consensus.DirectValidatorPeers }}"
+
+# Set to true to send to the peers lagging behind by more than one height the
+# commit of their height in a single message, a commit certificate, which they
+# verify and add at once, instead of its precommits one by one, so they catch up
+# without switching to block sync. The certificates are only sent to the peers
+# enabling it too.
+catchup_commit_certificates = {{ .Consensus.CatchupCommitCertificates }}
+
+# Set to true to also gossip the parity parts of the proposal block, computed
+# with a Reed-Solomon code, to the peers enabling it. A block of K parts then
+# has up to K parity parts, and any K of its parts and parity parts, received
+# from different peers, are enough to reconstruct it, which relieves the
+# proposer of uploading every part and speeds up the propagation of large
+# blocks. Peers not enabling it only receive the block parts.
+erasure_coded_block_parts = {{ .Consensus.ErasureCodedBlockParts }}
+
+# Set to true to relay the proposal block to the peers enabling it as a compact
+# block: the block without its transactions, identified by their keys, which
+# the peers look up in their mempool to reconstruct the block, requesting only
+# the missing ones. This saves most of the bandwidth used to propagate blocks on
+# networks where the mempools are well synchronized. The block parts are still
+# gossiped to the peers which fail to reconstruct the block within a second.
+compact_blocks = {{ .Consensus.CompactBlocks }}
+
+# Number of most recent heights for which all the votes received by the node,
+# and not only those in the canonical commit, are persisted in the "votes"
+# database. Recorded votes can be exported via the /recorded_votes RPC endpoint,
+# e.g. for accountability analysis. 0 disables vote recording.
+vote_record_heights = {{ .Consensus.VoteRecordHeights }}
+
+# Path of the file, relative to the home directory if not absolute, every step
+# transition, message receipt and timeout of the consensus is traced to, with
+# its time, as one JSON object per line, for the round-by-round analysis of slow
+# blocks. The file is appended to. Empty disables tracing.
+trace_file = "{{ js .Consensus.TracePath }}"
+
+# Set to true to start executing a proposed block, with FinalizeBlock, as soon
+# as the node prevotes for it, and use the result if the block is decided, which
+# cuts the latency of the blocks of compute-heavy applications. Otherwise, the
+# result is discarded, as it is when the round changes, and the decided block is
+# executed. The application must then support FinalizeBlock being called again
+# at the same height, for the same or another block, discarding the state
+# resulting from the previous call, and declare it with the optimistic_execution
+# field of ResponseInfo: the option is ignored otherwise.
+# The blocks of the heights at which vote extensions are enabled are not executed
+# optimistically, as ExtendVote and VerifyVoteExtension must not be called at a
+# height once FinalizeBlock is.
+optimistic_execution = {{ .Consensus.OptimisticExecution }}
+
 #######################################################
 ###         Storage Configuration Options           ###
 #######################################################
@@ -527,11 +1015,60 @@ peer_query_maj23_sleep_duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 # reindex events in the command-line tool.
 discard_abci_responses = {{ .Storage.DiscardABCIResponses}}
 
+# Set to true to verify the checksums of the records loaded from the state store
+# (states, validator sets, consensus params and ABCI responses), reporting the
+# records that do not match their checksum as corrupted, e.g. after disk bitrot.
+# Records written by versions of CometBFT prior to checksums being introduced
+# cannot be verified.
+verify_state_checksums = {{ .Storage.VerifyStateChecksums }}
+
+# The backend keeping the states, validator sets, consensus params and ABCI
+# responses.
+#
+# Options:
+#   1) "kv" (default) - the state database of the node (see db_backend).
+#   2) "psql" - a PostgreSQL database, with the schema defined in
+#      state/psql/schema.sql.
+state_store = "{{ .Storage.StateStore }}"
+
+# The PostgreSQL connection configuration of the "psql" state store, the
+# connection format:
+#   postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
+state_store_psql_conn = "{{ .Storage.StateStorePsqlConn }}"
+
+# Set to true to serve the RPC and gRPC queries from read replicas of the
+# block store and state store, isolating the historical reads from the stores
+# used by consensus. The replicas never write, and the "psql" state store
+# replica uses its own database connections.
+read_replica = {{ .Storage.ReadReplica }}
+
 [storage.pruning]
 
 # The time period between automated background pruning operations.
 interval = "{{ .Storage.Pruning.Interval }}"
 
+#
+# Number of most recent heights to keep for each type of data, for example to
+# keep 100000 blocks but only 10000 heights of ABCI responses. The pruner prunes
+# each type of data independently to its own window. Pruning never goes beyond
+# the retain heights set by the data companion, if enabled, while the
+# application retain height may prune blocks further. 0 disables the window for
+# that type of data.
+#
+[storage.pruning.keep_recent]
+
+# Number of most recent blocks to keep, along with their state.
+blocks = {{ .Storage.Pruning.KeepRecent.Blocks }}
+
+# Number of most recent heights to keep the ABCI responses of.
+abci_responses = {{ .Storage.Pruning.KeepRecent.ABCIResponses }}
+
+# Number of most recent heights to keep in the tx index.
+tx_index = {{ .Storage.Pruning.KeepRecent.TxIndex }}
+
+# Number of most recent heights to keep in the block index.
+block_index = {{ .Storage.Pruning.KeepRecent.BlockIndex }}
+
 #
 # Storage pruning configuration relating only to the data companion.
 #
@@ -563,6 +1100,64 @@ initial_block_results_retain_height = {{ .Storage.Pruning.DataCompanion.InitialB
 # the node is not able to boot.
 genesis_hash = "{{ .Storage.GenesisHash }}"
 
+#
+# Storage usage forecasting configuration.
+#
+[storage.forecast]
+
+# The time period between measurements of the size of the databases, from
+# which their growth rate and the time until the volume holding them is full
+# are projected. Set to 0 to disable storage usage forecasting.
+interval = "{{ .Storage.Forecast.Interval }}"
+
+# The time period over which the growth rate of the databases is computed.
+window = "{{ .Storage.Forecast.Window }}"
+
+# A warning is logged when the volume holding the databases is projected to be
+# full in less than this number of days. Set to 0 to disable warnings.
+warn_days_until_full = {{ .Storage.Forecast.WarnDaysUntilFull }}
+
+#
+# Encryption of sensitive stores at rest, with AES-256-GCM.
+#
+[storage.encryption]
+
+# The name of the environment variable holding the hex-encoded 32-byte
+# encryption key, e.g. populated from a key management service before starting
+# the node. Encryption is disabled if empty.
+#
+# The stores written before encryption got enabled are still read, and their
+# records are encrypted the next time they are written.
+key_env = "{{ .Storage.Encryption.KeyEnv }}"
+
+# The stores to encrypt.
+#
+# Options:
+#   1) "privval_state" - the last sign state of the file-based private
+#      validator.
+#   2) "addrbook" - the address book.
+#   3) "evidence" - the evidence database.
+#   4) "state" - the state database. Cannot be combined with the "psql"
+#      state store.
+stores = [{{ range .Storage.Encryption.Stores }}{{ printf "%q, " . }}{{end}}]
+
+#
+# Compression of the stores with zstd. Event-heavy ABCI responses and tx
+# results typically shrink several times.
+#
+[storage.compression]
+
+# The stores to compress.
+#
+# The records written before compression got enabled, or after it got
+# disabled, are still read.
+#
+# Options:
+#   1) "abci_responses" - the FinalizeBlock responses of the state store.
+#      Cannot be combined with the "psql" state store.
+#   2) "tx_index" - the tx results of the "kv" tx indexer.
+stores = [{{ range .Storage.Compression.Stores }}{{ printf "%q, " . }}{{end}}]
+
 #######################################################
 ###   Transaction Indexer Configuration Options     ###
 #######################################################
@@ -585,6 +1180,23 @@ indexer = "{{ .TxIndex.Indexer }}"
 #   postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 psql-conn = "{{ .TxIndex.PsqlConn }}"
 
+# Maximum size in bytes of the serialized result of a tx, with its events,
+# published on the event bus to the indexer and to the RPC subscribers.
+# 0 means unlimited.
+max_event_size = {{ .TxIndex.MaxEventSize }}
+
+# What to do with the events of a tx whose result exceeds max_event_size.
+#
+# Options:
+#   1) "truncate_attributes" (default) - truncate the longest attribute values
+#   until the result fits.
+#   2) "drop_tx_data" - drop the data returned by the application for the tx
+#   from the result.
+#   3) "drop_event" - do not publish the result.
+# If the result is still too large, it is not published, and the tx is not
+# indexed.
+oversized_event_policy = "{{ .TxIndex.OversizedEventPolicy }}"
+
 #######################################################
 ###       Instrumentation Configuration Options     ###
 #######################################################
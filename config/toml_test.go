@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -59,6 +60,27 @@ func TestEnsureTestRoot(t *testing.T) {
 	ensureFiles(t, rootDir, config.DefaultDataDir, baseConfig.Genesis, baseConfig.PrivValidatorKey, baseConfig.PrivValidatorState)
 }
 
+func TestMempoolTxClassesRoundTrip(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Mempool.TxClasses = map[string]config.MempoolTxClassConfig{
+		"default":    {MaxTxs: 4000, Weight: 1},
+		"governance": {MaxTxs: 0, Weight: 4},
+	}
+
+	configFile := filepath.Join(t.TempDir(), config.DefaultConfigFileName)
+	config.WriteConfigFile(configFile, cfg)
+
+	v := viper.New()
+	v.SetConfigFile(configFile)
+	require.NoError(t, v.ReadInConfig())
+
+	loaded := config.DefaultConfig()
+	require.NoError(t, v.Unmarshal(loaded))
+	require.Equal(t, cfg.Mempool.TxClasses, loaded.Mempool.TxClasses)
+	require.Equal(t, cfg.Mempool.ExperimentalEncryptedTxs, loaded.Mempool.ExperimentalEncryptedTxs)
+	require.Equal(t, cfg.StateSync.ChunkFetchers, loaded.StateSync.ChunkFetchers)
+}
+
 func assertValidConfig(t *testing.T, configFile string) {
 	t.Helper()
 	// list of words we expect in the config
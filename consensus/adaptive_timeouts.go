@@ -0,0 +1,223 @@
+package consensus
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	cfg "github.com/cometbft/cometbft/config"
+	cstypes "github.com/cometbft/cometbft/consensus/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	// Factor by which the percentile of the observed durations of a step is
+	// multiplied to adapt its timeout, leaving room for slower rounds.
+	adaptiveTimeoutMargin = 1.5
+
+	// Minimum number of observed durations of a step before its timeout is
+	// adapted.
+	adaptiveTimeoutMinSamples = 10
+)
+
+// timedStep is a step of a round whose timeout is adapted.
+type timedStep int
+
+const (
+	timedStepNone timedStep = iota - 1
+	timedStepPropose
+	timedStepPrevote
+	timedStepPrecommit
+	numTimedSteps
+)
+
+var timedStepNames = [numTimedSteps]string{"propose", "prevote", "precommit"}
+
+// timedStepOf returns the timed step which the given round step belongs to.
+func timedStepOf(step cstypes.RoundStepType) timedStep {
+	switch step {
+	case cstypes.RoundStepPropose:
+		return timedStepPropose
+	case cstypes.RoundStepPrevote, cstypes.RoundStepPrevoteWait:
+		return timedStepPrevote
+	case cstypes.RoundStepPrecommit, cstypes.RoundStepPrecommitWait:
+		return timedStepPrecommit
+	default:
+		return timedStepNone
+	}
+}
+
+// adaptiveTimeouts returns the timeouts of the consensus steps: those of the
+// consensus params if set, or else those of the node configuration.
+//
+// Unless the timeouts of the consensus params are set, it also observes the durations of the propose, prevote and precommit steps,
+// from entering the step to entering the next one, and adapts their timeouts
+// to a percentile of the recent durations, if enabled. The steps which timed
+// out are not observed, their duration only reflecting the timeout.
+//
+// Only used by the receive routine of the consensus state.
+type adaptiveTimeouts struct {
+	// Configuration of the node, and the same with the timeouts of the
+	// consensus params, if set.
+	nodeConfig *cfg.ConsensusConfig
+	config     *cfg.ConsensusConfig
+	params     types.TimeoutParams
+	metrics    *Metrics
+
+	// Last observed durations of each step, in a ring buffer.
+	durations [numTimedSteps][]time.Duration
+	next      [numTimedSteps]int
+	// Adapted timeouts of each step in round 0, zero until enough durations
+	// are observed.
+	adapted [numTimedSteps]time.Duration
+
+	// Step being observed, and the round step at which it was entered.
+	height    int64
+	round     int32
+	roundStep cstypes.RoundStepType
+	step      timedStep
+	start     time.Time
+	timedOut  bool
+}
+
+func newAdaptiveTimeouts(config *cfg.ConsensusConfig, metrics *Metrics) *adaptiveTimeouts {
+	return &adaptiveTimeouts{
+		nodeConfig: config,
+		config:     config,
+		metrics:    metrics,
+		step:       timedStepNone,
+	}
+}
+
+// setParams sets the timeouts of the consensus params, which override those
+// of the node configuration if set. The timeouts of the consensus params are
+// neither adapted nor scaled, so that all the validators use the same values.
+// The durations observed and the timeouts adapted under the previous params
+// are discarded when they change.
+func (at *adaptiveTimeouts) setParams(params types.TimeoutParams) {
+	if params == at.params {
+		return
+	}
+	at.params = params
+	at.reset()
+	if !params.IsSet() {
+		at.config = at.nodeConfig
+		return
+	}
+	config := *at.nodeConfig
+	config.TimeoutPropose = params.Propose
+	config.TimeoutProposeDelta = params.ProposeDelta
+	config.TimeoutPrevote = params.Prevote
+	config.TimeoutPrevoteDelta = params.PrevoteDelta
+	config.TimeoutPrecommit = params.Precommit
+	config.TimeoutPrecommitDelta = params.PrecommitDelta
+	config.TimeoutCommit = params.Commit
+	config.TimeoutScale = 1
+	config.AdaptiveTimeouts = false
+	at.config = &config
+}
+
+// reset discards the observed durations and the adapted timeouts, along with
+// the step being observed.
+func (at *adaptiveTimeouts) reset() {
+	at.durations = [numTimedSteps][]time.Duration{}
+	at.next = [numTimedSteps]int{}
+	at.adapted = [numTimedSteps]time.Duration{}
+	at.step, at.timedOut = timedStepNone, false
+}
+
+// Propose returns the timeout of the propose step of the given round.
+func (at *adaptiveTimeouts) Propose(round int32) time.Duration {
+	return at.timeout(timedStepPropose, at.config.Propose(0), at.config.Propose(round))
+}
+
+// Prevote returns the timeout of the prevote step of the given round.
+func (at *adaptiveTimeouts) Prevote(round int32) time.Duration {
+	return at.timeout(timedStepPrevote, at.config.Prevote(0), at.config.Prevote(round))
+}
+
+// Precommit returns the timeout of the precommit step of the given round.
+func (at *adaptiveTimeouts) Precommit(round int32) time.Duration {
+	return at.timeout(timedStepPrecommit, at.config.Precommit(0), at.config.Precommit(round))
+}
+
+// Commit returns the time at which to start the next height, after
+// committing a block at the given time.
+func (at *adaptiveTimeouts) Commit(t time.Time) time.Time {
+	return at.config.Commit(t)
+}
+
+// timeout returns the timeout of the given step in a round, given the
+// configured timeouts of the step in round 0 and in that round.
+func (at *adaptiveTimeouts) timeout(step timedStep, configured0, configured time.Duration) time.Duration {
+	if !at.config.AdaptiveTimeouts || at.adapted[step] == 0 {
+		return configured
+	}
+	// The deltas of the later rounds apply to the adapted timeout.
+	return at.adapted[step] + configured - configured0
+}
+
+// enterStep records that the consensus entered the given step of a round,
+// which completes the step being observed if it did not time out.
+func (at *adaptiveTimeouts) enterStep(height int64, round int32, step cstypes.RoundStepType, now time.Time) {
+	if !at.config.AdaptiveTimeouts {
+		return
+	}
+	next := timedStepOf(step)
+	sameRound := height == at.height && round == at.round
+	if sameRound && next == at.step {
+		// e.g. from prevote to prevote wait
+		return
+	}
+	if at.step != timedStepNone && sameRound && step > at.roundStep && !at.timedOut {
+		at.observe(at.step, now.Sub(at.start))
+	}
+	at.height, at.round, at.roundStep = height, round, step
+	at.step, at.start, at.timedOut = next, now, false
+}
+
+// timeoutFired records that the timeout of the given step of a round fired.
+func (at *adaptiveTimeouts) timeoutFired(height int64, round int32, step cstypes.RoundStepType) {
+	if height == at.height && round == at.round && timedStepOf(step) == at.step {
+		at.timedOut = true
+	}
+}
+
+// observe records a duration of the given step, and adapts its timeout.
+func (at *adaptiveTimeouts) observe(step timedStep, d time.Duration) {
+	window := at.config.AdaptiveTimeoutsWindow
+	if len(at.durations[step]) < window {
+		at.durations[step] = append(at.durations[step], d)
+	} else {
+		at.durations[step][at.next[step]%window] = d
+	}
+	at.next[step]++
+	if len(at.durations[step]) < adaptiveTimeoutMinSamples {
+		return
+	}
+
+	var configured time.Duration
+	switch step {
+	case timedStepPropose:
+		configured = at.config.Propose(0)
+	case timedStepPrevote:
+		configured = at.config.Prevote(0)
+	case timedStepPrecommit:
+		configured = at.config.Precommit(0)
+	}
+	adapted := time.Duration(float64(percentile(at.durations[step], at.config.AdaptiveTimeoutsPercentile)) * adaptiveTimeoutMargin)
+	minTimeout := time.Duration(float64(configured) * at.config.AdaptiveTimeoutsMinFactor)
+	maxTimeout := time.Duration(float64(configured) * at.config.AdaptiveTimeoutsMaxFactor)
+	at.adapted[step] = max(minTimeout, min(adapted, maxTimeout))
+	at.metrics.AdaptedTimeoutSeconds.With("step", timedStepNames[step]).Set(at.adapted[step].Seconds())
+}
+
+// percentile returns the given percentile, between 0 and 1, of the given
+// durations, using the nearest-rank method.
+func percentile(durations []time.Duration, p float64) time.Duration {
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
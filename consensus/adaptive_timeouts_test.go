@@ -0,0 +1,171 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/cometbft/cometbft/config"
+	cstypes "github.com/cometbft/cometbft/consensus/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestAdaptiveTimeouts(t *testing.T) {
+	config := cfg.DefaultConsensusConfig()
+	config.TimeoutPropose = time.Second
+	config.TimeoutProposeDelta = 100 * time.Millisecond
+	config.TimeoutPrevote = time.Second
+	config.TimeoutPrecommit = time.Second
+	config.AdaptiveTimeouts = true
+	config.AdaptiveTimeoutsWindow = 20
+	config.AdaptiveTimeoutsPercentile = 0.5
+	at := newAdaptiveTimeouts(config, NopMetrics())
+
+	start := time.Now()
+	runHeight := func(height int64, proposeTimedOut bool) {
+		steps := []struct {
+			step   cstypes.RoundStepType
+			offset time.Duration
+		}{
+			{cstypes.RoundStepNewHeight, 0},
+			{cstypes.RoundStepNewRound, 0},
+			{cstypes.RoundStepPropose, 0},
+			{cstypes.RoundStepPrevote, 400 * time.Millisecond},
+			{cstypes.RoundStepPrevoteWait, 500 * time.Millisecond},
+			{cstypes.RoundStepPrecommit, 600 * time.Millisecond},
+			{cstypes.RoundStepCommit, 2600 * time.Millisecond},
+		}
+		for _, s := range steps {
+			if s.step == cstypes.RoundStepPrevote && proposeTimedOut {
+				at.timeoutFired(height, 0, cstypes.RoundStepPropose)
+			}
+			at.enterStep(height, 0, s.step, start.Add(time.Duration(height)*time.Minute+s.offset))
+		}
+	}
+
+	// The timeouts are not adapted until enough durations are observed.
+	for h := int64(1); h < adaptiveTimeoutMinSamples; h++ {
+		runHeight(h, false)
+	}
+	require.Equal(t, time.Second, at.Propose(0))
+
+	// The timeouts are then adapted within the bounds, and the deltas still
+	// apply to the later rounds.
+	runHeight(adaptiveTimeoutMinSamples, false)
+	require.Equal(t, 600*time.Millisecond, at.Propose(0))
+	require.Equal(t, 800*time.Millisecond, at.Propose(2))
+	require.Equal(t, 500*time.Millisecond, at.Prevote(0))
+	require.Equal(t, 2*time.Second, at.Precommit(0))
+
+	// The steps which timed out are not observed.
+	runHeight(adaptiveTimeoutMinSamples+1, true)
+	require.Len(t, at.durations[timedStepPropose], adaptiveTimeoutMinSamples)
+	require.Len(t, at.durations[timedStepPrevote], adaptiveTimeoutMinSamples+1)
+
+	// The configured timeouts apply if adaptive timeouts are disabled.
+	config.AdaptiveTimeouts = false
+	require.Equal(t, time.Second, at.Propose(0))
+}
+
+func TestPercentile(t *testing.T) {
+	durations := []time.Duration{5, 1, 4, 2, 3, 10, 6, 9, 8, 7}
+	require.Equal(t, time.Duration(10), percentile(durations, 0.99))
+	require.Equal(t, time.Duration(5), percentile(durations, 0.5))
+	require.Equal(t, time.Duration(1), percentile(durations, 0.01))
+	require.Equal(t, time.Duration(5), durations[0])
+}
+
+func TestTimeoutParams(t *testing.T) {
+	config := cfg.DefaultConsensusConfig()
+	at := newAdaptiveTimeouts(config, NopMetrics())
+	now := time.Now()
+
+	// The timeouts of the consensus params override those of the
+	// configuration, if set.
+	at.setParams(types.TimeoutParams{
+		Propose:        2 * time.Second,
+		ProposeDelta:   200 * time.Millisecond,
+		Prevote:        300 * time.Millisecond,
+		PrevoteDelta:   30 * time.Millisecond,
+		Precommit:      400 * time.Millisecond,
+		PrecommitDelta: 40 * time.Millisecond,
+		Commit:         500 * time.Millisecond,
+	})
+	require.Equal(t, 2400*time.Millisecond, at.Propose(2))
+	require.Equal(t, 360*time.Millisecond, at.Prevote(2))
+	require.Equal(t, 480*time.Millisecond, at.Precommit(2))
+	require.Equal(t, now.Add(500*time.Millisecond), at.Commit(now))
+
+	at.setParams(types.DefaultTimeoutParams())
+	require.Equal(t, config.Propose(2), at.Propose(2))
+	require.Equal(t, config.Commit(now), at.Commit(now))
+}
+
+func TestTimeoutParamsNotAdaptedNorScaled(t *testing.T) {
+	config := cfg.DefaultConsensusConfig()
+	config.TimeoutPropose = time.Second
+	config.TimeoutScale = 0.5
+	config.AdaptiveTimeouts = true
+	config.AdaptiveTimeoutsPercentile = 0.5
+	at := newAdaptiveTimeouts(config, NopMetrics())
+	now := time.Now()
+	params := types.TimeoutParams{
+		Propose:   time.Second,
+		Prevote:   time.Second,
+		Precommit: time.Second,
+		Commit:    time.Second,
+	}
+
+	// The timeouts of the configuration are scaled and adapted.
+	require.Equal(t, 500*time.Millisecond, at.Propose(0))
+	for i := 0; i < adaptiveTimeoutMinSamples; i++ {
+		at.observe(timedStepPropose, 200*time.Millisecond)
+	}
+	require.Equal(t, 300*time.Millisecond, at.Propose(0))
+
+	// Those of the consensus params are neither, and the durations are no
+	// longer observed.
+	at.setParams(params)
+	require.Equal(t, time.Second, at.Propose(0))
+	require.Equal(t, now.Add(time.Second), at.Commit(now))
+	require.Empty(t, at.durations[timedStepPropose])
+	for h := int64(1); h <= adaptiveTimeoutMinSamples; h++ {
+		at.enterStep(h, 0, cstypes.RoundStepPropose, now)
+		at.enterStep(h, 0, cstypes.RoundStepPrevote, now.Add(200*time.Millisecond))
+	}
+	require.Empty(t, at.durations[timedStepPropose])
+	require.Equal(t, time.Second, at.Propose(0))
+
+	// The timeouts of the configuration apply again, once the params are
+	// unset, adapted from scratch.
+	at.setParams(types.DefaultTimeoutParams())
+	require.Equal(t, 500*time.Millisecond, at.Propose(0))
+	require.Empty(t, at.durations[timedStepPropose])
+}
+
+func TestAdaptiveTimeoutsResetOnParamsChange(t *testing.T) {
+	config := cfg.DefaultConsensusConfig()
+	config.TimeoutPropose = time.Second
+	config.AdaptiveTimeouts = true
+	config.AdaptiveTimeoutsPercentile = 0.5
+	at := newAdaptiveTimeouts(config, NopMetrics())
+
+	for i := 0; i < adaptiveTimeoutMinSamples; i++ {
+		at.observe(timedStepPropose, 400*time.Millisecond)
+	}
+	require.Equal(t, 600*time.Millisecond, at.Propose(0))
+
+	// The adapted timeouts are kept while the params are unchanged.
+	at.setParams(types.DefaultTimeoutParams())
+	require.Equal(t, 600*time.Millisecond, at.Propose(0))
+
+	// The timeouts adapted under the previous params are discarded when they
+	// change, until enough durations are observed again.
+	at.setParams(types.TimeoutParams{Propose: 3 * time.Second})
+	at.setParams(types.DefaultTimeoutParams())
+	require.Equal(t, time.Second, at.Propose(0))
+	require.Empty(t, at.durations[timedStepPropose])
+	at.observe(timedStepPropose, 400*time.Millisecond)
+	require.Equal(t, time.Second, at.Propose(0))
+}
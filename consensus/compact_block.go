@@ -0,0 +1,317 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	cstypes "github.com/cometbft/cometbft/consensus/types"
+	"github.com/cometbft/cometbft/p2p"
+	cmtcons "github.com/cometbft/cometbft/proto/tendermint/consensus"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+// CapabilityCompactBlocks is advertised by the nodes reconstructing the
+// compact blocks (see CompactBlockMessage) they receive from their mempool.
+// The proposal blocks are only relayed as compact blocks to the peers
+// advertising it.
+const CapabilityCompactBlocks = "consensus/compact-blocks"
+
+// compactBlockFallbackDelay is how long the parts of a proposal block are not
+// gossiped to a peer it was sent as a compact block, for the peer to
+// reconstruct it, fetching its missing transactions if needed.
+const compactBlockFallbackDelay = time.Second
+
+// TxSource looks up transactions by key. The mempool is the usual source.
+type TxSource interface {
+	GetTxByKey(txKey types.TxKey) (types.Tx, bool)
+}
+
+// ReactorTxSource sets the source, usually the mempool, of the transactions of
+// the compact blocks received, which are only supported if set.
+func ReactorTxSource(txSource TxSource) ReactorOption {
+	return func(conR *Reactor) { conR.txSource = txSource }
+}
+
+// cachedCompactBlock holds the compact block of a complete proposal block,
+// nil if the compact block exceeds the maximum message size.
+type cachedCompactBlock struct {
+	header types.PartSetHeader
+	block  *cmtproto.Block
+	txKeys [][]byte
+}
+
+// proposalCompactBlock returns the compact block of the given complete
+// proposal block, computed on the first call for it.
+func (conR *Reactor) proposalCompactBlock(block *types.Block, parts *types.PartSet) *cachedCompactBlock {
+	conR.compactMtx.Lock()
+	defer conR.compactMtx.Unlock()
+
+	header := parts.Header()
+	if conR.compactBlock != nil && conR.compactBlock.header.Equals(header) {
+		return conR.compactBlock
+	}
+	conR.compactBlock = &cachedCompactBlock{header: header}
+	pb, err := block.ToProto()
+	if err != nil {
+		conR.Logger.Error("Failed to convert the proposal block to proto", "err", err)
+		return conR.compactBlock
+	}
+	pb.Data.Txs = nil
+	txKeys := make([][]byte, len(block.Txs))
+	size := proto.Size(pb)
+	for i, tx := range block.Txs {
+		key := tx.Key()
+		txKeys[i] = key[:]
+		size += len(key) + 2
+	}
+	// The header, evidence and last commit of the block may be too large to
+	// be sent in a single message, in which case its parts are gossiped.
+	if size < maxMsgSize-1024 {
+		conR.compactBlock.block, conR.compactBlock.txKeys = pb, txKeys
+	}
+	return conR.compactBlock
+}
+
+// pickSendCompactBlock sends the peer the complete proposal block as a compact
+// block, if it supports compact blocks and knows of none of the block parts.
+// Returns true if the compact block was sent, or if it was sent less than
+// compactBlockFallbackDelay ago, in which case the block parts must not be
+// sent yet.
+func (conR *Reactor) pickSendCompactBlock(
+	rs *cstypes.RoundState,
+	prs *cstypes.PeerRoundState,
+	ps *PeerState,
+) bool {
+	if !conR.conS.config.CompactBlocks || rs.ProposalBlock == nil || !rs.ProposalBlockParts.IsComplete() ||
+		!p2p.PeerHasCapability(ps.peer, CapabilityCompactBlocks) {
+		return false
+	}
+	header := rs.ProposalBlockParts.Header()
+	if sentAt, ok := ps.compactBlockSent(header); ok {
+		if time.Since(sentAt) >= compactBlockFallbackDelay {
+			return false
+		}
+		time.Sleep(conR.conS.config.PeerGossipSleepDuration)
+		return true
+	}
+	if !prs.ProposalBlockParts.IsEmpty() {
+		return false
+	}
+	compact := conR.proposalCompactBlock(rs.ProposalBlock, rs.ProposalBlockParts)
+	if compact.block == nil {
+		return false
+	}
+
+	if ps.peer.Send(p2p.Envelope{
+		ChannelID: DataChannel,
+		Message: &cmtcons.CompactBlock{
+			Height:        rs.Height,
+			Round:         rs.Round,
+			PartSetHeader: header.ToProto(),
+			Block:         compact.block,
+			TxKeys:        compact.txKeys,
+		},
+	}) {
+		ps.SetCompactBlockSent(header, cmttime.Now())
+		conR.Metrics.CompactBlocks.With("status", "sent").Add(1)
+	}
+	return true
+}
+
+// pendingCompactBlock is a compact block received from a peer, waiting for
+// the transactions missing from the mempool.
+type pendingCompactBlock struct {
+	msg     *CompactBlockMessage
+	txs     types.Txs
+	missing []uint32 // indexes of the missing txs, in request order
+}
+
+// handleCompactBlock reconstructs the block of a compact block received from
+// the peer with the transactions of the mempool, or requests the missing ones
+// from the peer.
+func (conR *Reactor) handleCompactBlock(msg *CompactBlockMessage, ps *PeerState) {
+	if !conR.conS.config.CompactBlocks || conR.txSource == nil {
+		return
+	}
+	rs := conR.getRoundState()
+	if rs.Height != msg.Height ||
+		(rs.ProposalBlockParts.HasHeader(msg.PartSetHeader) && rs.ProposalBlockParts.IsComplete()) {
+		return
+	}
+
+	txs := make(types.Txs, len(msg.TxKeys))
+	var missing []uint32
+	for i, key := range msg.TxKeys {
+		if tx, ok := conR.txSource.GetTxByKey(key); ok {
+			txs[i] = tx
+		} else {
+			missing = append(missing, uint32(i))
+		}
+	}
+	if len(missing) == 0 {
+		conR.completeCompactBlock(msg, txs, ps)
+		return
+	}
+
+	conR.Logger.Debug("Requesting the missing txs of a compact block",
+		"height", msg.Height, "txs", len(msg.TxKeys), "missing", len(missing), "peer", ps.peer.ID())
+	ps.setPendingCompactBlock(&pendingCompactBlock{msg: msg, txs: txs, missing: missing})
+	conR.Metrics.CompactBlockMissingTxs.Add(float64(len(missing)))
+	ps.peer.Send(p2p.Envelope{
+		ChannelID: DataChannel,
+		Message: &cmtcons.MissingTxsRequest{
+			Height:        msg.Height,
+			Round:         msg.Round,
+			PartSetHeader: msg.PartSetHeader.ToProto(),
+			Indexes:       missing,
+		},
+	})
+}
+
+// handleMissingTxsRequest sends the peer the requested transactions of the
+// complete proposal block, in as many messages as needed.
+func (conR *Reactor) handleMissingTxsRequest(msg *MissingTxsRequestMessage, ps *PeerState) {
+	rs := conR.getRoundState()
+	if rs.ProposalBlock == nil || !rs.ProposalBlockParts.HasHeader(msg.PartSetHeader) ||
+		!rs.ProposalBlockParts.IsComplete() {
+		return
+	}
+
+	send := func(txs types.Txs) bool {
+		return ps.peer.Send(p2p.Envelope{
+			ChannelID: DataChannel,
+			Message: &cmtcons.MissingTxs{
+				Height:        msg.Height,
+				Round:         msg.Round,
+				PartSetHeader: msg.PartSetHeader.ToProto(),
+				Txs:           txs.ToSliceOfBytes(),
+			},
+		})
+	}
+	var (
+		batch types.Txs
+		size  int
+	)
+	for _, index := range msg.Indexes {
+		if int(index) >= len(rs.ProposalBlock.Txs) {
+			conR.Logger.Debug("Ignoring a request for missing txs out of range", "peer", ps.peer.ID())
+			return
+		}
+		tx := rs.ProposalBlock.Txs[index]
+		if len(tx) >= maxMsgSize-1024 {
+			// The peer falls back to the block parts.
+			return
+		}
+		if len(batch) > 0 && size+len(tx) >= maxMsgSize-1024 {
+			if !send(batch) {
+				return
+			}
+			batch, size = nil, 0
+		}
+		batch = append(batch, tx)
+		size += len(tx) + 4
+	}
+	send(batch)
+}
+
+// handleMissingTxs adds the transactions received from the peer to the
+// pending compact block, whose block is reconstructed once complete.
+func (conR *Reactor) handleMissingTxs(msg *MissingTxsMessage, ps *PeerState) {
+	pending := ps.getPendingCompactBlock(msg.PartSetHeader)
+	if pending == nil {
+		return
+	}
+	if len(msg.Txs) > len(pending.missing) {
+		conR.Logger.Debug("Received too many missing txs", "peer", ps.peer.ID())
+		ps.setPendingCompactBlock(nil)
+		return
+	}
+	for i, tx := range msg.Txs {
+		index := pending.missing[i]
+		if tx.Key() != pending.msg.TxKeys[index] {
+			conR.Logger.Debug("Received an unexpected missing tx", "peer", ps.peer.ID())
+			ps.setPendingCompactBlock(nil)
+			return
+		}
+		pending.txs[index] = tx
+	}
+	pending.missing = pending.missing[len(msg.Txs):]
+	if len(pending.missing) > 0 {
+		return
+	}
+	ps.setPendingCompactBlock(nil)
+	conR.completeCompactBlock(pending.msg, pending.txs, ps)
+}
+
+// completeCompactBlock rebuilds the block of the compact block with the given
+// transactions, and passes its parts to the consensus state as if they had
+// been received from the peer.
+func (conR *Reactor) completeCompactBlock(msg *CompactBlockMessage, txs types.Txs, ps *PeerState) {
+	block := &types.Block{
+		Header:     msg.Block.Header,
+		Data:       types.Data{Txs: txs},
+		Evidence:   msg.Block.Evidence,
+		LastCommit: msg.Block.LastCommit,
+	}
+	var parts *types.PartSet
+	err := block.ValidateBasic()
+	if err == nil {
+		parts, err = block.MakePartSet(types.BlockPartSizeBytes)
+	}
+	if err != nil || !parts.HasHeader(msg.PartSetHeader) {
+		conR.Logger.Debug("Received an invalid compact block", "height", msg.Height, "peer", ps.peer.ID(), "err", err)
+		conR.Metrics.CompactBlocks.With("status", "invalid").Add(1)
+		return
+	}
+	conR.Metrics.CompactBlocks.With("status", "reconstructed").Add(1)
+
+	for i := 0; i < int(parts.Total()); i++ {
+		ps.SetHasProposalBlockPart(msg.Height, msg.Round, i)
+		conR.conS.peerMsgQueue <- msgInfo{&BlockPartMessage{msg.Height, msg.Round, parts.GetPart(i)}, ps.peer.ID()}
+	}
+}
+
+// SetCompactBlockSent records that the proposal block with the given part set
+// header was sent to the peer as a compact block at the given time.
+func (ps *PeerState) SetCompactBlockSent(header types.PartSetHeader, sentAt time.Time) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.compactBlockHeader = header
+	ps.compactBlockSentAt = sentAt
+}
+
+// compactBlockSent returns the time the proposal block with the given part set
+// header was sent to the peer as a compact block, if it was.
+func (ps *PeerState) compactBlockSent(header types.PartSetHeader) (time.Time, bool) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.compactBlockSentAt.IsZero() || !ps.compactBlockHeader.Equals(header) {
+		return time.Time{}, false
+	}
+	return ps.compactBlockSentAt, true
+}
+
+func (ps *PeerState) setPendingCompactBlock(pending *pendingCompactBlock) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.pendingCompact = pending
+}
+
+// getPendingCompactBlock returns the compact block received from the peer
+// with the given part set header waiting for its missing transactions, if
+// any.
+func (ps *PeerState) getPendingCompactBlock(header types.PartSetHeader) *pendingCompactBlock {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.pendingCompact == nil || !ps.pendingCompact.msg.PartSetHeader.Equals(header) {
+		return nil
+	}
+	return ps.pendingCompact
+}
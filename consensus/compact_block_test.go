@@ -0,0 +1,146 @@
+package consensus
+
+import (
+	"bytes"
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestCompactBlockMessageToProto(t *testing.T) {
+	cs1, _ := randState(1)
+	cs1.mtx.Lock()
+	block, err := cs1.createProposalBlock(context.Background())
+	cs1.mtx.Unlock()
+	require.NoError(t, err)
+	txs := types.Txs{cmtrand.Bytes(100), cmtrand.Bytes(200)}
+	block.Data = types.Data{Txs: txs}
+	block.DataHash = block.Data.Hash()
+	parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(t, err)
+
+	compact := &types.Block{
+		Header:     block.Header,
+		Evidence:   block.Evidence,
+		LastCommit: block.LastCommit,
+	}
+	msg := &CompactBlockMessage{
+		Height:        block.Height,
+		Round:         1,
+		PartSetHeader: parts.Header(),
+		Block:         compact,
+		TxKeys:        []types.TxKey{txs[0].Key(), txs[1].Key()},
+	}
+	require.NoError(t, msg.ValidateBasic())
+	pb, err := MsgToProto(msg)
+	require.NoError(t, err)
+	decoded, err := MsgFromProto(pb)
+	require.NoError(t, err)
+	decodedMsg := decoded.(*CompactBlockMessage)
+	assert.Equal(t, msg.TxKeys, decodedMsg.TxKeys)
+	assert.Equal(t, msg.PartSetHeader, decodedMsg.PartSetHeader)
+
+	// The block rebuilt with the transactions matches the original one.
+	rebuilt := &types.Block{
+		Header:     decodedMsg.Block.Header,
+		Data:       types.Data{Txs: txs},
+		Evidence:   decodedMsg.Block.Evidence,
+		LastCommit: decodedMsg.Block.LastCommit,
+	}
+	rebuiltParts, err := rebuilt.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(t, err)
+	assert.Equal(t, parts.Header(), rebuiltParts.Header())
+
+	// A compact block must not carry transactions.
+	msg.Block = block
+	require.Error(t, msg.ValidateBasic())
+}
+
+// countingTxSource counts the transactions looked up and not found.
+type countingTxSource struct {
+	TxSource
+	missing atomic.Int32
+}
+
+func (s *countingTxSource) GetTxByKey(txKey types.TxKey) (types.Tx, bool) {
+	tx, ok := s.TxSource.GetTxByKey(txKey)
+	if !ok {
+		s.missing.Add(1)
+	}
+	return tx, ok
+}
+
+func TestReactorCompactBlocks(t *testing.T) {
+	N := 4
+	css, cleanup := randConsensusNet(t, N, "consensus_compact_blocks_test", newMockTickerFunc(true), newKVStore,
+		func(c *cfg.Config) {
+			c.Consensus.CompactBlocks = true
+		})
+	defer cleanup()
+
+	reactors := make([]*Reactor, N)
+	txSources := make([]*countingTxSource, N)
+	blocksSubs := make([]types.Subscription, N)
+	eventBuses := make([]*types.EventBus, N)
+	for i := 0; i < N; i++ {
+		txSources[i] = &countingTxSource{TxSource: assertMempool(css[i].txNotifier).(TxSource)}
+		reactors[i] = NewReactor(css[i], true, ReactorTxSource(txSources[i]))
+		reactors[i].SetLogger(css[i].Logger)
+		eventBuses[i] = css[i].eventBus
+		reactors[i].SetEventBus(eventBuses[i])
+
+		var err error
+		blocksSubs[i], err = eventBuses[i].Subscribe(context.Background(), testSubscriber, types.EventQueryNewBlock)
+		require.NoError(t, err)
+		require.NoError(t, css[i].blockExec.Store().Save(css[i].state))
+	}
+	switches := p2p.MakeConnectedSwitches(config.P2P, N, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("CONSENSUS", reactors[i])
+		s.SetLogger(reactors[i].conS.Logger.With("module", "p2p"))
+		return s
+	}, p2p.Connect2Switches)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+	for _, sw := range switches {
+		for _, peer := range sw.Peers().List() {
+			require.True(t, p2p.PeerHasCapability(peer, CapabilityCompactBlocks))
+		}
+	}
+
+	// The tx is only in the mempool of the first node: the other nodes
+	// request it when they receive its compact block.
+	tx := kvstore.NewTxFromID(1)
+	reqRes, err := assertMempool(css[0].txNotifier).CheckTx(tx)
+	require.NoError(t, err)
+	require.False(t, reqRes.Response.GetCheckTx().IsErr())
+
+	for i := 0; i < N; i++ {
+		reactors[i].SwitchToConsensus(reactors[i].conS.GetState(), false)
+	}
+
+	// Wait till everyone commits the block with the tx.
+	timeoutWaitGroup(N, func(j int) {
+		for {
+			msg := <-blocksSubs[j].Out()
+			block := msg.Data().(types.EventDataNewBlock).Block
+			if len(block.Txs) > 0 {
+				assert.True(t, bytes.Equal(tx, block.Txs[0]))
+				return
+			}
+		}
+	})
+	missing := int32(0)
+	for i := 1; i < N; i++ {
+		missing += txSources[i].missing.Load()
+	}
+	assert.Positive(t, missing)
+}
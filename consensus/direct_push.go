@@ -0,0 +1,179 @@
+package consensus
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/p2p"
+	cmtcons "github.com/cometbft/cometbft/proto/tendermint/consensus"
+	"github.com/cometbft/cometbft/types"
+)
+
+// peerValidatorAddress returns the address of the validator of the peer, as
+// registered in the validator peers. It returns nil if the peer is not a
+// registered validator peer.
+//
+// NOTE: the validators are only taken from the registry, as the node ID of a
+// peer is authenticated by the secret connection whereas any validator it
+// would claim itself is not.
+func (conR *Reactor) peerValidatorAddress(peer p2p.Peer) types.Address {
+	return conR.validatorPeers[peer.ID()]
+}
+
+// ValidatorPeer associates the address of a validator with the network
+// address of the node it runs on.
+type ValidatorPeer struct {
+	ValidatorAddress types.Address
+	NodeAddress      *p2p.NetAddress
+}
+
+// ParseValidatorPeers parses a list of "<validator address>=<node ID>@<host>:<port>"
+// entries, as found in the direct_validator_peers configuration option.
+func ParseValidatorPeers(entries []string) ([]ValidatorPeer, error) {
+	peers := make([]ValidatorPeer, 0, len(entries))
+	for _, entry := range entries {
+		valAddr, nodeAddr, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid validator peer %q: expected <validator address>=<node address>", entry)
+		}
+		addr, err := hex.DecodeString(valAddr)
+		if err != nil || len(addr) != crypto.AddressSize {
+			return nil, fmt.Errorf("invalid validator address in validator peer %q", entry)
+		}
+		netAddr, err := p2p.NewNetAddressString(nodeAddr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node address in validator peer %q: %w", entry, err)
+		}
+		peers = append(peers, ValidatorPeer{ValidatorAddress: addr, NodeAddress: netAddr})
+	}
+	return peers, nil
+}
+
+// ReactorValidatorPeers enables the direct push of the proposals and votes of
+// the local validator, bypassing the regular gossip routines, and registers
+// the validators of the given peers. At each height, they are pushed to the
+// connected validator peers whose validator is part of the validator set. The
+// direct push is not enabled without peers.
+func ReactorValidatorPeers(peers []ValidatorPeer) ReactorOption {
+	return func(conR *Reactor) {
+		if len(peers) == 0 {
+			return
+		}
+		conR.validatorPeers = make(map[p2p.ID]types.Address, len(peers))
+		for _, peer := range peers {
+			conR.validatorPeers[peer.NodeAddress.ID] = peer.ValidatorAddress
+		}
+	}
+}
+
+// directPushPeers returns the states of the connected peers whose validator
+// is part of the validator set of the current height.
+//
+// NOTE: it must only be called from event listeners, which run on the
+// consensus routine while it holds the consensus state lock.
+func (conR *Reactor) directPushPeers(height int64) []*PeerState {
+	if conR.validatorPeers == nil || conR.conS.Validators == nil {
+		return nil
+	}
+	var states []*PeerState
+	for _, peer := range conR.Switch.Peers().List() {
+		valAddr := conR.peerValidatorAddress(peer)
+		if valAddr == nil || !conR.conS.Validators.HasAddress(valAddr) {
+			continue
+		}
+		ps, ok := peer.Get(types.PeerStateKey).(*PeerState)
+		if !ok {
+			continue
+		}
+		// Skip peers known to be at another height. The height of a peer is
+		// unknown (zero) until its first NewRoundStep message is received,
+		// which may happen after our first proposal or vote was made.
+		if h := ps.GetHeight(); h != 0 && h != height {
+			continue
+		}
+		states = append(states, ps)
+	}
+	return states
+}
+
+// ownAddress returns the address of the local validator, or nil if the node
+// is not a validator.
+//
+// NOTE: see directPushPeers.
+func (conR *Reactor) ownAddress() crypto.Address {
+	if conR.conS.privValidatorPubKey == nil {
+		return nil
+	}
+	return conR.conS.privValidatorPubKey.Address()
+}
+
+// pushVote sends a vote signed by the local validator directly to the
+// validator peers.
+func (conR *Reactor) pushVote(vote *types.Vote) {
+	if conR.validatorPeers == nil {
+		return
+	}
+	if addr := conR.ownAddress(); addr == nil || !bytes.Equal(vote.ValidatorAddress, addr) {
+		return
+	}
+	for _, ps := range conR.directPushPeers(vote.Height) {
+		if ps.peer.TrySend(p2p.Envelope{
+			ChannelID: VoteChannel,
+			Message:   &cmtcons.Vote{Vote: vote.ToProto()},
+		}) {
+			ps.SetHasVote(vote)
+			conR.Metrics.DirectPushMessages.With("message_type", "vote").Add(1)
+		}
+	}
+}
+
+// pushProposalBlockPart sends a block part of a proposal made by the local
+// validator, preceded by the proposal itself if needed, directly to the
+// validator peers.
+func (conR *Reactor) pushProposalBlockPart(msg *BlockPartMessage) {
+	if conR.validatorPeers == nil {
+		return
+	}
+	proposal := conR.conS.Proposal
+	if proposal == nil || proposal.Height != msg.Height || proposal.Round != msg.Round {
+		return
+	}
+	if addr := conR.ownAddress(); addr == nil || !conR.conS.isProposer(addr) {
+		return
+	}
+	part, err := msg.Part.ToProto()
+	if err != nil {
+		conR.Logger.Error("Failed to convert block part to proto", "err", err)
+		return
+	}
+	for _, ps := range conR.directPushPeers(msg.Height) {
+		prs := ps.GetRoundState()
+		if prs.Height == msg.Height && prs.Round > msg.Round {
+			continue
+		}
+		if !prs.Proposal {
+			if !ps.peer.TrySend(p2p.Envelope{
+				ChannelID: DataChannel,
+				Message:   &cmtcons.Proposal{Proposal: *proposal.ToProto()},
+			}) {
+				continue
+			}
+			ps.SetHasProposal(proposal)
+			conR.Metrics.DirectPushMessages.With("message_type", "proposal").Add(1)
+		}
+		if ps.peer.TrySend(p2p.Envelope{
+			ChannelID: DataChannel,
+			Message: &cmtcons.BlockPart{
+				Height: msg.Height,
+				Round:  msg.Round,
+				Part:   *part,
+			},
+		}) {
+			ps.SetHasProposalBlockPart(msg.Height, msg.Round, int(msg.Part.Index))
+			conR.Metrics.DirectPushMessages.With("message_type", "block_part").Add(1)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestParseValidatorPeers(t *testing.T) {
+	const (
+		valAddr  = "0A1B2C3D4E5F60718293A4B5C6D7E8F901234567"
+		nodeAddr = "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef@127.0.0.1:26656"
+	)
+
+	peers, err := ParseValidatorPeers([]string{valAddr + "=" + nodeAddr})
+	require.NoError(t, err)
+	require.Len(t, peers, 1)
+	assert.Equal(t, valAddr, peers[0].ValidatorAddress.String())
+	assert.Equal(t, p2p.ID("deadbeefdeadbeefdeadbeefdeadbeefdeadbeef"), peers[0].NodeAddress.ID)
+
+	peers, err = ParseValidatorPeers(nil)
+	require.NoError(t, err)
+	assert.Empty(t, peers)
+
+	invalid := []string{
+		valAddr,                               // missing node address
+		"0A1B=" + nodeAddr,                    // short validator address
+		"not-hex=" + nodeAddr,                 // invalid validator address
+		valAddr + "=127.0.0.1:26656",          // missing node ID
+		valAddr + "=deadbeef@127.0.0.1:26656", // invalid node ID
+	}
+	for _, entry := range invalid {
+		_, err := ParseValidatorPeers([]string{entry})
+		assert.Error(t, err, entry)
+	}
+}
+
+func TestReactorDirectPush(t *testing.T) {
+	N := 4
+	css, cleanup := randConsensusNet(t, N, "consensus_direct_push_test", newMockTickerFunc(true), newKVStore,
+		func(c *cfg.Config) {
+			// Stall the gossip routines, so that blocks can only be made
+			// thanks to the direct push of proposals and votes.
+			c.Consensus.PeerGossipSleepDuration = time.Hour
+		})
+	defer cleanup()
+
+	reactors := make([]*Reactor, N)
+	blocksSubs := make([]types.Subscription, N)
+	eventBuses := make([]*types.EventBus, N)
+	for i := 0; i < N; i++ {
+		reactors[i] = NewReactor(css[i], true)
+		reactors[i].SetLogger(css[i].Logger)
+		eventBuses[i] = css[i].eventBus
+		reactors[i].SetEventBus(eventBuses[i])
+
+		var err error
+		blocksSubs[i], err = eventBuses[i].Subscribe(context.Background(), testSubscriber, types.EventQueryNewBlock)
+		require.NoError(t, err)
+		require.NoError(t, css[i].blockExec.Store().Save(css[i].state))
+	}
+	switches := p2p.MakeConnectedSwitches(config.P2P, N, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("CONSENSUS", reactors[i])
+		s.SetLogger(reactors[i].conS.Logger.With("module", "p2p"))
+		return s
+	}, p2p.Connect2Switches)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+	// Register every other validator as a validator peer.
+	for i := 0; i < N; i++ {
+		reactors[i].validatorPeers = make(map[p2p.ID]types.Address)
+		for j := 0; j < N; j++ {
+			if i == j {
+				continue
+			}
+			pubKey, err := css[j].privValidator.GetPubKey()
+			require.NoError(t, err)
+			reactors[i].validatorPeers[switches[j].NodeInfo().ID()] = pubKey.Address()
+			peer := switches[i].Peers().Get(switches[j].NodeInfo().ID())
+			require.NotNil(t, peer)
+			assert.Equal(t, pubKey.Address(), reactors[i].peerValidatorAddress(peer))
+		}
+	}
+
+	for i := 0; i < N; i++ {
+		reactors[i].SwitchToConsensus(reactors[i].conS.GetState(), false)
+	}
+
+	// wait till everyone makes the first new block
+	timeoutWaitGroup(N, func(j int) {
+		<-blocksSubs[j].Out()
+	})
+}
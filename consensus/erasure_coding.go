@@ -0,0 +1,200 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+
+	cstypes "github.com/cometbft/cometbft/consensus/types"
+	"github.com/cometbft/cometbft/libs/bits"
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	"github.com/cometbft/cometbft/p2p"
+	cmtcons "github.com/cometbft/cometbft/proto/tendermint/consensus"
+	"github.com/cometbft/cometbft/types"
+)
+
+// CapabilityErasureCodedBlockParts is advertised by the nodes gossiping the
+// parity parts of the proposal blocks (see BlockParityPartMessage), which are
+// only sent to the peers advertising it too.
+const CapabilityErasureCodedBlockParts = "consensus/erasure-coded-block-parts"
+
+// blockParity holds the parity parts of a complete proposal block.
+type blockParity struct {
+	header       types.PartSetHeader
+	parts        [][]byte
+	lastPartSize uint32
+}
+
+// proposalParity returns the parity parts of the given complete part set,
+// computed on the first call for it.
+func (conR *Reactor) proposalParity(parts *types.PartSet) *blockParity {
+	conR.parityMtx.Lock()
+	defer conR.parityMtx.Unlock()
+
+	header := parts.Header()
+	if conR.parity != nil && conR.parity.header.Equals(header) {
+		return conR.parity
+	}
+	parity, err := parts.ParityParts()
+	if err != nil {
+		conR.Logger.Error("Failed to compute the parity parts of the proposal block", "err", err)
+	}
+	conR.parity = &blockParity{
+		header:       header,
+		parts:        parity,
+		lastPartSize: uint32(len(parts.GetPart(int(header.Total) - 1).Bytes)),
+	}
+	return conR.parity
+}
+
+// pickSendParityPart sends the peer a parity part of the complete proposal
+// block in place of one of the block parts it lacks, if it supports
+// erasure-coded block parts. The part to send is picked at random among the
+// block parts the peer lacks, given by missing, and the parity parts it does
+// not know of. Returns true if a parity part was picked.
+func (conR *Reactor) pickSendParityPart(
+	rs *cstypes.RoundState,
+	ps *PeerState,
+	missing *bits.BitArray,
+) bool {
+	if !conR.conS.config.ErasureCodedBlockParts || !rs.ProposalBlockParts.IsComplete() ||
+		!p2p.PeerHasCapability(ps.peer, CapabilityErasureCodedBlockParts) {
+		return false
+	}
+	parity := conR.proposalParity(rs.ProposalBlockParts)
+	if len(parity.parts) == 0 {
+		return false
+	}
+	unknown := ps.unknownParityParts(parity.header)
+	numMissing, numUnknown := countTrue(missing), countTrue(unknown)
+	if numUnknown == 0 || cmtrand.Intn(numMissing+numUnknown) < numMissing {
+		return false
+	}
+
+	index, _ := unknown.PickRandom()
+	if ps.peer.Send(p2p.Envelope{
+		ChannelID: DataChannel,
+		Message: &cmtcons.BlockParityPart{
+			Height:        rs.Height,
+			Round:         rs.Round,
+			PartSetHeader: parity.header.ToProto(),
+			Index:         uint32(index),
+			LastPartSize:  parity.lastPartSize,
+			Bytes:         parity.parts[index],
+		},
+	}) {
+		ps.SetHasParityPart(parity.header, index)
+	}
+	return true
+}
+
+// countTrue returns the number of bits set in the bit array.
+func countTrue(ba *bits.BitArray) int {
+	n := 0
+	for i := 0; i < ba.Size(); i++ {
+		if ba.GetIndex(i) {
+			n++
+		}
+	}
+	return n
+}
+
+// SetHasParityPart sets the given parity part of the proposal block with the
+// given part set header as known for the peer.
+func (ps *PeerState) SetHasParityPart(header types.PartSetHeader, index int) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.ensureParityParts(header)
+	ps.parityParts.SetIndex(index, true)
+}
+
+// unknownParityParts returns the parity parts of the proposal block with the
+// given part set header the peer does not know of.
+func (ps *PeerState) unknownParityParts(header types.PartSetHeader) *bits.BitArray {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.ensureParityParts(header)
+	return ps.parityParts.Not()
+}
+
+func (ps *PeerState) ensureParityParts(header types.PartSetHeader) {
+	if ps.parityParts == nil || !ps.parityHeader.Equals(header) {
+		ps.parityHeader = header
+		ps.parityParts = bits.NewBitArray(int(types.NumParityParts(header.Total)))
+	}
+}
+
+// proposalParityParts holds the parity parts received for the proposal block
+// with the given part set header, nil if missing.
+type proposalParityParts struct {
+	header       types.PartSetHeader
+	lastPartSize uint32
+	parts        [][]byte
+	count        int
+}
+
+// addProposalParityPart adds a parity part of the proposal block, then
+// reconstructs its missing parts if enough parts and parity parts were
+// received. Returns true if block parts were added.
+func (cs *State) addProposalParityPart(msg *BlockParityPartMessage, peerID p2p.ID) (bool, error) {
+	if !cs.config.ErasureCodedBlockParts || cs.Height != msg.Height || cs.ProposalBlockParts == nil ||
+		!cs.ProposalBlockParts.HasHeader(msg.PartSetHeader) || cs.ProposalBlockParts.IsComplete() {
+		cs.Logger.Debug(
+			"received a parity part we are not expecting",
+			"height", msg.Height,
+			"round", msg.Round,
+			"index", msg.Index,
+			"peer", peerID,
+		)
+		return false, nil
+	}
+
+	if cs.parityParts == nil || !cs.parityParts.header.Equals(msg.PartSetHeader) {
+		cs.parityParts = &proposalParityParts{
+			header:       msg.PartSetHeader,
+			lastPartSize: msg.LastPartSize,
+			parts:        make([][]byte, types.NumParityParts(msg.PartSetHeader.Total)),
+		}
+	}
+	if msg.LastPartSize != cs.parityParts.lastPartSize {
+		return false, errors.New("parity part with a different last part size")
+	}
+	if cs.parityParts.parts[msg.Index] != nil {
+		return false, nil
+	}
+	cs.parityParts.parts[msg.Index] = msg.Bytes
+	cs.parityParts.count++
+
+	return cs.reconstructProposalBlockParts(msg.Height, msg.Round, peerID)
+}
+
+// reconstructProposalBlockParts reconstructs and adds the missing parts of the
+// proposal block, if as many of its parts and parity parts as it has parts
+// were received. Returns true if block parts were added.
+func (cs *State) reconstructProposalBlockParts(height int64, round int32, peerID p2p.ID) (added bool, err error) {
+	pp, parts := cs.parityParts, cs.ProposalBlockParts
+	if pp == nil || parts == nil || !parts.HasHeader(pp.header) || parts.IsComplete() ||
+		int(parts.Count())+pp.count < int(parts.Total()) {
+		return false, nil
+	}
+
+	missing, err := parts.ReconstructParts(pp.parts, pp.lastPartSize)
+	if err != nil {
+		// One of the parity parts is invalid. Drop them all: the missing
+		// block parts are still gossiped.
+		cs.parityParts = nil
+		return false, fmt.Errorf("failed to reconstruct the proposal block parts: %w", err)
+	}
+	cs.Logger.Debug("reconstructed proposal block parts", "height", height, "parts", len(missing))
+	cs.metrics.ReconstructedBlockParts.Add(float64(len(missing)))
+
+	for _, part := range missing {
+		partAdded, err := cs.addProposalBlockPart(&BlockPartMessage{Height: height, Round: round, Part: part}, peerID)
+		added = added || partAdded
+		if err != nil {
+			return added, err
+		}
+	}
+	return added, nil
+}
@@ -0,0 +1,124 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestBlockParityPartMessageValidateBasic(t *testing.T) {
+	header := types.PartSetHeader{Total: 3, Hash: cmtrand.Bytes(32)}
+	valid := func() *BlockParityPartMessage {
+		return &BlockParityPartMessage{
+			Height:        1,
+			PartSetHeader: header,
+			Index:         2,
+			LastPartSize:  10,
+			Bytes:         make([]byte, types.BlockPartSizeBytes),
+		}
+	}
+	require.NoError(t, valid().ValidateBasic())
+
+	for name, malleate := range map[string]func(*BlockParityPartMessage){
+		"negative height":     func(m *BlockParityPartMessage) { m.Height = -1 },
+		"negative round":      func(m *BlockParityPartMessage) { m.Round = -1 },
+		"index out of range":  func(m *BlockParityPartMessage) { m.Index = 3 },
+		"single part":         func(m *BlockParityPartMessage) { m.PartSetHeader.Total, m.Index = 1, 0 },
+		"short parity part":   func(m *BlockParityPartMessage) { m.Bytes = m.Bytes[1:] },
+		"zero last part size": func(m *BlockParityPartMessage) { m.LastPartSize = 0 },
+		"large last part":     func(m *BlockParityPartMessage) { m.LastPartSize = types.BlockPartSizeBytes + 1 },
+	} {
+		msg := valid()
+		malleate(msg)
+		assert.Error(t, msg.ValidateBasic(), name)
+	}
+}
+
+func TestStateReconstructProposalBlockParts(t *testing.T) {
+	cs1, _ := randState(1)
+	cs1.config.ErasureCodedBlockParts = true
+	height, round := cs1.Height, cs1.Round
+
+	// A block of several parts.
+	cs1.mtx.Lock()
+	block, err := cs1.createProposalBlock(context.Background())
+	cs1.mtx.Unlock()
+	require.NoError(t, err)
+	var txs types.Txs
+	for i := 0; i < 3; i++ {
+		txs = append(txs, cmtrand.Bytes(int(types.BlockPartSizeBytes)))
+	}
+	block.Data = types.Data{Txs: txs}
+	block.DataHash = block.Data.Hash()
+	parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(t, err)
+	total := int(parts.Total())
+	require.Greater(t, total, 2)
+	parity, err := parts.ParityParts()
+	require.NoError(t, err)
+	lastPartSize := uint32(len(parts.GetPart(total - 1).Bytes))
+
+	parityMsg := func(index int, bz []byte) *BlockParityPartMessage {
+		return &BlockParityPartMessage{
+			Height:        height,
+			Round:         round,
+			PartSetHeader: parts.Header(),
+			Index:         uint32(index),
+			LastPartSize:  lastPartSize,
+			Bytes:         bz,
+		}
+	}
+
+	cs1.mtx.Lock()
+	defer cs1.mtx.Unlock()
+	cs1.ProposalBlockParts = types.NewPartSetFromHeader(parts.Header())
+
+	// Parity parts of another block are ignored.
+	other := parityMsg(0, parity[0])
+	other.PartSetHeader = types.PartSetHeader{Total: parts.Total(), Hash: cmtrand.Bytes(32)}
+	added, err := cs1.addProposalParityPart(other, "peer")
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.Nil(t, cs1.parityParts)
+
+	// An invalid parity part fails the reconstruction, and is dropped.
+	added, err = cs1.addProposalBlockPart(&BlockPartMessage{height, round, parts.GetPart(0)}, "peer")
+	require.NoError(t, err)
+	require.True(t, added)
+	for i := 0; i < total-2; i++ {
+		added, err = cs1.addProposalParityPart(parityMsg(i, parity[i]), "peer")
+		require.NoError(t, err)
+		assert.False(t, added)
+	}
+	added, err = cs1.addProposalParityPart(parityMsg(total-2, cmtrand.Bytes(int(types.BlockPartSizeBytes))), "peer")
+	require.ErrorIs(t, err, types.ErrPartSetInvalidReconstruction)
+	assert.False(t, added)
+	assert.Nil(t, cs1.parityParts)
+
+	// The first part and any total-1 parity parts are enough.
+	for i := 1; i < total; i++ {
+		added, err = cs1.addProposalParityPart(parityMsg(i, parity[i]), "peer")
+		require.NoError(t, err)
+		assert.Equal(t, i == total-1, added)
+	}
+	require.True(t, cs1.ProposalBlockParts.IsComplete())
+	require.NotNil(t, cs1.ProposalBlock)
+	assert.Equal(t, block.Hash(), cs1.ProposalBlock.Hash())
+}
+
+func TestReactorCapabilities(t *testing.T) {
+	cs1, _ := randState(1)
+	conR := NewReactor(cs1, false)
+	assert.Empty(t, conR.Capabilities())
+
+	cs1.config.ErasureCodedBlockParts = true
+	assert.Equal(t, []string{CapabilityErasureCodedBlockParts}, conR.Capabilities())
+
+	cs1.config.CatchupCommitCertificates = true
+	assert.Equal(t, []string{CapabilityErasureCodedBlockParts, CapabilityCommitCertificates}, conR.Capabilities())
+}
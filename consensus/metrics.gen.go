@@ -192,6 +192,66 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "late_votes",
 			Help:      "LateVotes stores the number of votes that were received by this node that correspond to earlier heights and rounds than this node is currently in.",
 		}, append(labels, "vote_type")).With(labelsAndValues...),
+		AdaptedTimeoutSeconds: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "adapted_timeout_seconds",
+			Help:      "AdaptedTimeoutSeconds is the timeout of the propose, prevote and precommit steps in round 0, in seconds, adapted to the durations of the steps observed in the recent rounds, if adaptive timeouts are enabled.",
+		}, append(labels, "step")).With(labelsAndValues...),
+		DirectPushMessages: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "direct_push_messages",
+			Help:      "DirectPushMessages is the number of proposals, block parts and votes of the local validator pushed directly to validator peers, bypassing the gossip routines. The metric is labeled by message type.",
+		}, append(labels, "message_type")).With(labelsAndValues...),
+		ReplayProgress: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "replay_progress",
+			Help:      "ReplayProgress is the fraction of the replay on startup completed, by phase: the blocks replayed to the application by the handshake, or the messages of the last height replayed from the WAL.",
+		}, append(labels, "phase")).With(labelsAndValues...),
+		ReplayRemainingSeconds: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "replay_remaining_seconds",
+			Help:      "ReplayRemainingSeconds is the estimated time left to complete the replay on startup, in seconds, by phase.",
+		}, append(labels, "phase")).With(labelsAndValues...),
+		ValidatorMissedPrevotes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "validator_missed_prevotes",
+			Help:      "ValidatorMissedPrevotes is the number of rounds in which the prevote of a validator was not received.",
+		}, append(labels, "validator_address")).With(labelsAndValues...),
+		ValidatorMissedPrecommits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "validator_missed_precommits",
+			Help:      "ValidatorMissedPrecommits is the number of rounds in which the precommit of a validator was not received.",
+		}, append(labels, "validator_address")).With(labelsAndValues...),
+		ValidatorProposals: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "validator_proposals",
+			Help:      "ValidatorProposals is the number of rounds in which a validator was the proposer, by status of its proposal: timely if it was received during the propose step, late if received afterwards, missed otherwise.",
+		}, append(labels, "validator_address", "status")).With(labelsAndValues...),
+		ReconstructedBlockParts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "reconstructed_block_parts",
+			Help:      "ReconstructedBlockParts is the number of proposal block parts reconstructed from erasure-coded parity parts instead of being received.",
+		}, labels).With(labelsAndValues...),
+		CompactBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "compact_blocks",
+			Help:      "CompactBlocks is the number of proposal blocks relayed as compact blocks, by status: sent, or received and reconstructed or invalid.",
+		}, append(labels, "status")).With(labelsAndValues...),
+		CompactBlockMissingTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "compact_block_missing_txs",
+			Help:      "CompactBlockMissingTxs is the number of transactions of the compact blocks received missing from the mempool, and requested from the peers.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -226,5 +286,15 @@ func NopMetrics() *Metrics {
 		ProposalCreateCount:       discard.NewCounter(),
 		RoundVotingPowerPercent:   discard.NewGauge(),
 		LateVotes:                 discard.NewCounter(),
+		AdaptedTimeoutSeconds:     discard.NewGauge(),
+		DirectPushMessages:        discard.NewCounter(),
+		ReplayProgress:            discard.NewGauge(),
+		ReplayRemainingSeconds:    discard.NewGauge(),
+		ValidatorMissedPrevotes:   discard.NewCounter(),
+		ValidatorMissedPrecommits: discard.NewCounter(),
+		ValidatorProposals:        discard.NewCounter(),
+		ReconstructedBlockParts:   discard.NewCounter(),
+		CompactBlocks:             discard.NewCounter(),
+		CompactBlockMissingTxs:    discard.NewCounter(),
 	}
 }
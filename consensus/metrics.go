@@ -122,6 +122,51 @@ type Metrics struct {
 	// correspond to earlier heights and rounds than this node is currently
 	// in.
 	LateVotes metrics.Counter `metrics_labels:"vote_type"`
+
+	// AdaptedTimeoutSeconds is the timeout of the propose, prevote and
+	// precommit steps in round 0, in seconds, adapted to the durations of
+	// the steps observed in the recent rounds, if adaptive timeouts are
+	// enabled.
+	AdaptedTimeoutSeconds metrics.Gauge `metrics_labels:"step"`
+
+	// DirectPushMessages is the number of proposals, block parts and votes of
+	// the local validator pushed directly to validator peers, bypassing the
+	// gossip routines. The metric is labeled by message type.
+	DirectPushMessages metrics.Counter `metrics_labels:"message_type"`
+
+	// ReplayProgress is the fraction of the replay on startup completed, by
+	// phase: the blocks replayed to the application by the handshake, or the
+	// messages of the last height replayed from the WAL.
+	ReplayProgress metrics.Gauge `metrics_labels:"phase"`
+
+	// ReplayRemainingSeconds is the estimated time left to complete the
+	// replay on startup, in seconds, by phase.
+	ReplayRemainingSeconds metrics.Gauge `metrics_labels:"phase"`
+
+	// ValidatorMissedPrevotes is the number of rounds in which the prevote of
+	// a validator was not received.
+	ValidatorMissedPrevotes metrics.Counter `metrics_labels:"validator_address"`
+
+	// ValidatorMissedPrecommits is the number of rounds in which the precommit
+	// of a validator was not received.
+	ValidatorMissedPrecommits metrics.Counter `metrics_labels:"validator_address"`
+
+	// ValidatorProposals is the number of rounds in which a validator was the
+	// proposer, by status of its proposal: timely if it was received during
+	// the propose step, late if received afterwards, missed otherwise.
+	ValidatorProposals metrics.Counter `metrics_labels:"validator_address, status"`
+
+	// ReconstructedBlockParts is the number of proposal block parts
+	// reconstructed from erasure-coded parity parts instead of being received.
+	ReconstructedBlockParts metrics.Counter
+
+	// CompactBlocks is the number of proposal blocks relayed as compact
+	// blocks, by status: sent, or received and reconstructed or invalid.
+	CompactBlocks metrics.Counter `metrics_labels:"status"`
+
+	// CompactBlockMissingTxs is the number of transactions of the compact
+	// blocks received missing from the mempool, and requested from the peers.
+	CompactBlockMissingTxs metrics.Counter
 }
 
 func (m *Metrics) MarkProposalProcessed(accepted bool) {
@@ -1,6 +1,7 @@
 package consensus
 
 import (
+	"errors"
 	"fmt"
 
 	cmterrors "github.com/cometbft/cometbft/types/errors"
@@ -117,6 +118,54 @@ func MsgToProto(msg Message) (proto.Message, error) {
 
 		pb = vsb
 
+	case *CommitCertificateMessage:
+		pb = &cmtcons.CommitCertificate{
+			Commit: msg.Commit.ToProto(),
+		}
+
+	case *BlockParityPartMessage:
+		pb = &cmtcons.BlockParityPart{
+			Height:        msg.Height,
+			Round:         msg.Round,
+			PartSetHeader: msg.PartSetHeader.ToProto(),
+			Index:         msg.Index,
+			LastPartSize:  msg.LastPartSize,
+			Bytes:         msg.Bytes,
+		}
+
+	case *CompactBlockMessage:
+		block, err := msg.Block.ToProto()
+		if err != nil {
+			return nil, cmterrors.ErrMsgToProto{MessageName: "CompactBlock", Err: err}
+		}
+		keys := make([][]byte, len(msg.TxKeys))
+		for i := range msg.TxKeys {
+			keys[i] = msg.TxKeys[i][:]
+		}
+		pb = &cmtcons.CompactBlock{
+			Height:        msg.Height,
+			Round:         msg.Round,
+			PartSetHeader: msg.PartSetHeader.ToProto(),
+			Block:         block,
+			TxKeys:        keys,
+		}
+
+	case *MissingTxsRequestMessage:
+		pb = &cmtcons.MissingTxsRequest{
+			Height:        msg.Height,
+			Round:         msg.Round,
+			PartSetHeader: msg.PartSetHeader.ToProto(),
+			Indexes:       msg.Indexes,
+		}
+
+	case *MissingTxsMessage:
+		pb = &cmtcons.MissingTxs{
+			Height:        msg.Height,
+			Round:         msg.Round,
+			PartSetHeader: msg.PartSetHeader.ToProto(),
+			Txs:           msg.Txs.ToSliceOfBytes(),
+		}
+
 	default:
 		return nil, ErrConsensusMessageNotRecognized{msg}
 	}
@@ -238,6 +287,72 @@ func MsgFromProto(p proto.Message) (Message, error) {
 			BlockID: *bi,
 			Votes:   bits,
 		}
+	case *cmtcons.CommitCertificate:
+		commit, err := types.ExtendedCommitFromProto(msg.Commit)
+		if err != nil {
+			return nil, cmterrors.ErrMsgToProto{MessageName: "CommitCertificate", Err: err}
+		}
+		pb = &CommitCertificateMessage{
+			Commit: commit,
+		}
+	case *cmtcons.BlockParityPart:
+		psh, err := types.PartSetHeaderFromProto(&msg.PartSetHeader)
+		if err != nil {
+			return nil, cmterrors.ErrMsgToProto{MessageName: "BlockParityPart", Err: err}
+		}
+		pb = &BlockParityPartMessage{
+			Height:        msg.Height,
+			Round:         msg.Round,
+			PartSetHeader: *psh,
+			Index:         msg.Index,
+			LastPartSize:  msg.LastPartSize,
+			Bytes:         msg.Bytes,
+		}
+	case *cmtcons.CompactBlock:
+		psh, err := types.PartSetHeaderFromProto(&msg.PartSetHeader)
+		if err != nil {
+			return nil, cmterrors.ErrMsgToProto{MessageName: "CompactBlock", Err: err}
+		}
+		block, err := compactBlockFromProto(msg.Block)
+		if err != nil {
+			return nil, cmterrors.ErrMsgToProto{MessageName: "CompactBlock", Err: err}
+		}
+		keys := make([]types.TxKey, len(msg.TxKeys))
+		for i, key := range msg.TxKeys {
+			if len(key) != len(keys[i]) {
+				return nil, cmterrors.ErrMsgToProto{MessageName: "CompactBlock", Err: fmt.Errorf("invalid tx key size %d", len(key))}
+			}
+			copy(keys[i][:], key)
+		}
+		pb = &CompactBlockMessage{
+			Height:        msg.Height,
+			Round:         msg.Round,
+			PartSetHeader: *psh,
+			Block:         block,
+			TxKeys:        keys,
+		}
+	case *cmtcons.MissingTxsRequest:
+		psh, err := types.PartSetHeaderFromProto(&msg.PartSetHeader)
+		if err != nil {
+			return nil, cmterrors.ErrMsgToProto{MessageName: "MissingTxsRequest", Err: err}
+		}
+		pb = &MissingTxsRequestMessage{
+			Height:        msg.Height,
+			Round:         msg.Round,
+			PartSetHeader: *psh,
+			Indexes:       msg.Indexes,
+		}
+	case *cmtcons.MissingTxs:
+		psh, err := types.PartSetHeaderFromProto(&msg.PartSetHeader)
+		if err != nil {
+			return nil, cmterrors.ErrMsgToProto{MessageName: "MissingTxs", Err: err}
+		}
+		pb = &MissingTxsMessage{
+			Height:        msg.Height,
+			Round:         msg.Round,
+			PartSetHeader: *psh,
+			Txs:           types.ToTxs(msg.Txs),
+		}
 	default:
 		return nil, ErrConsensusMessageNotRecognized{msg}
 	}
@@ -249,6 +364,37 @@ func MsgFromProto(p proto.Message) (Message, error) {
 	return pb, nil
 }
 
+// compactBlockFromProto converts the block of a compact block, whose
+// transactions are missing. Unlike types.BlockFromProto, it does not validate
+// the block, which does not match its data hash until its transactions are
+// added.
+func compactBlockFromProto(bp *cmtproto.Block) (*types.Block, error) {
+	if bp == nil {
+		return nil, errors.New("nil block")
+	}
+	if len(bp.Data.Txs) != 0 {
+		return nil, errors.New("compact block with transactions")
+	}
+
+	b := new(types.Block)
+	h, err := types.HeaderFromProto(&bp.Header)
+	if err != nil {
+		return nil, err
+	}
+	b.Header = h
+	if err := b.Evidence.FromProto(&bp.Evidence); err != nil {
+		return nil, err
+	}
+	if bp.LastCommit != nil {
+		lc, err := types.CommitFromProto(bp.LastCommit)
+		if err != nil {
+			return nil, err
+		}
+		b.LastCommit = lc
+	}
+	return b, nil
+}
+
 // WALToProto takes a WAL message and return a proto walMessage and error
 func WALToProto(msg WALMessage) (*cmtcons.WALMessage, error) {
 	var pb cmtcons.WALMessage
@@ -70,6 +70,20 @@ func TestMsgToProto(t *testing.T) {
 	)
 	pbVote := vote.ToProto()
 
+	extCommit := &types.ExtendedCommit{
+		Height:             vote.Height,
+		Round:              vote.Round,
+		BlockID:            bi,
+		ExtendedSignatures: []types.ExtendedCommitSig{vote.ExtendedCommitSig()},
+	}
+	pbExtCommit := extCommit.ToProto()
+
+	parityPsh := types.PartSetHeader{
+		Total: 2,
+		Hash:  cmtrand.Bytes(32),
+	}
+	parity := cmtrand.Bytes(int(types.BlockPartSizeBytes))
+
 	testsCases := []struct {
 		testName string
 		msg      Message
@@ -169,6 +183,56 @@ func TestMsgToProto(t *testing.T) {
 			Votes:   *pbBits,
 		},
 
+			false},
+		{"successful CommitCertificate", &CommitCertificateMessage{
+			Commit: extCommit,
+		}, &cmtcons.CommitCertificate{
+			Commit: pbExtCommit,
+		},
+
+			false},
+		{"successful BlockParityPart", &BlockParityPartMessage{
+			Height:        2,
+			Round:         1,
+			PartSetHeader: parityPsh,
+			Index:         0,
+			LastPartSize:  1,
+			Bytes:         parity,
+		}, &cmtcons.BlockParityPart{
+			Height:        2,
+			Round:         1,
+			PartSetHeader: parityPsh.ToProto(),
+			Index:         0,
+			LastPartSize:  1,
+			Bytes:         parity,
+		},
+
+			false},
+		{"successful MissingTxsRequest", &MissingTxsRequestMessage{
+			Height:        2,
+			Round:         1,
+			PartSetHeader: psh,
+			Indexes:       []uint32{0, 3},
+		}, &cmtcons.MissingTxsRequest{
+			Height:        2,
+			Round:         1,
+			PartSetHeader: pbPsh,
+			Indexes:       []uint32{0, 3},
+		},
+
+			false},
+		{"successful MissingTxs", &MissingTxsMessage{
+			Height:        2,
+			Round:         1,
+			PartSetHeader: psh,
+			Txs:           types.Txs{types.Tx("tx1"), types.Tx("tx2")},
+		}, &cmtcons.MissingTxs{
+			Height:        2,
+			Round:         1,
+			PartSetHeader: pbPsh,
+			Txs:           [][]byte{[]byte("tx1"), []byte("tx2")},
+		},
+
 			false},
 		{"failure", nil, &cmtcons.Message{}, true},
 	}
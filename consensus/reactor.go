@@ -50,6 +50,21 @@ type Reactor struct {
 	rsMtx cmtsync.Mutex
 	rs    *cstypes.RoundState
 
+	// validators of the registered validator peers, indexed by node ID, nil
+	// if own proposals and votes are not pushed directly
+	validatorPeers map[p2p.ID]types.Address
+
+	// parity parts of the last complete proposal block gossiped, computed once
+	parityMtx cmtsync.Mutex
+	parity    *blockParity
+
+	// source of the transactions of the compact blocks received, nil if
+	// compact blocks are not supported, and the compact block of the last
+	// complete proposal block gossiped, computed once
+	txSource     TxSource
+	compactMtx   cmtsync.Mutex
+	compactBlock *cachedCompactBlock
+
 	Metrics *Metrics
 }
 
@@ -150,6 +165,23 @@ conR:
 	}
 }
 
+var _ p2p.CapabilityReactor = (*Reactor)(nil)
+
+// Capabilities implements p2p.CapabilityReactor.
+func (conR *Reactor) Capabilities() []string {
+	var capabilities []string
+	if conR.conS.config.ErasureCodedBlockParts {
+		capabilities = append(capabilities, CapabilityErasureCodedBlockParts)
+	}
+	if conR.conS.config.CompactBlocks && conR.txSource != nil {
+		capabilities = append(capabilities, CapabilityCompactBlocks)
+	}
+	if conR.conS.config.CatchupCommitCertificates {
+		capabilities = append(capabilities, CapabilityCommitCertificates)
+	}
+	return capabilities
+}
+
 // GetChannels implements Reactor
 func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 	// TODO optimize
@@ -340,6 +372,15 @@ func (conR *Reactor) Receive(e p2p.Envelope) {
 			ps.SetHasProposalBlockPart(msg.Height, msg.Round, int(msg.Part.Index))
 			conR.Metrics.BlockParts.With("peer_id", string(e.Src.ID())).Add(1)
 			conR.conS.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
+		case *BlockParityPartMessage:
+			ps.SetHasParityPart(msg.PartSetHeader, int(msg.Index))
+			conR.conS.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
+		case *CompactBlockMessage:
+			conR.handleCompactBlock(msg, ps)
+		case *MissingTxsRequestMessage:
+			conR.handleMissingTxsRequest(msg, ps)
+		case *MissingTxsMessage:
+			conR.handleMissingTxs(msg, ps)
 		default:
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 		}
@@ -361,6 +402,16 @@ func (conR *Reactor) Receive(e p2p.Envelope) {
 
 			cs.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
 
+		case *CommitCertificateMessage:
+			cs := conR.conS
+			cs.mtx.RLock()
+			height, valSize := cs.Height, cs.Validators.Size()
+			cs.mtx.RUnlock()
+			ps.EnsureVoteBitArrays(height, valSize)
+			ps.SetHasCommitCertificate(msg.Commit)
+
+			cs.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
+
 		default:
 			// don't punish (leave room for soft upgrades)
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
@@ -437,6 +488,7 @@ func (conR *Reactor) subscribeToBroadcastEvents() {
 	if err := conR.conS.evsw.AddListenerForEvent(subscriber, types.EventVote,
 		func(data cmtevents.EventData) {
 			conR.broadcastHasVoteMessage(data.(*types.Vote))
+			conR.pushVote(data.(*types.Vote))
 		}); err != nil {
 		conR.Logger.Error("Error adding listener for events (Vote)", "err", err)
 	}
@@ -444,6 +496,7 @@ func (conR *Reactor) subscribeToBroadcastEvents() {
 	if err := conR.conS.evsw.AddListenerForEvent(subscriber, types.EventProposalBlockPart,
 		func(data cmtevents.EventData) {
 			conR.broadcastHasProposalBlockPartMessage(data.(*BlockPartMessage))
+			conR.pushProposalBlockPart(data.(*BlockPartMessage))
 		}); err != nil {
 		conR.Logger.Error("Error adding listener for events (ProposalBlockPart)", "err", err)
 	}
@@ -589,7 +642,11 @@ OUTER_LOOP:
 
 		// Send proposal Block parts?
 		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
-			if index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
+			missing := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy())
+			if index, ok := missing.PickRandom(); ok {
+				if conR.pickSendCompactBlock(rs, prs, ps) || conR.pickSendParityPart(rs, ps, missing) {
+					continue OUTER_LOOP
+				}
 				part := rs.ProposalBlockParts.GetPart(index)
 				parts, err := part.ToProto()
 				if err != nil {
@@ -811,6 +868,13 @@ OUTER_LOOP:
 			if ec == nil {
 				continue
 			}
+			// The votes of an aggregated commit can't be sent one by one, nor
+			// in a certificate.
+			if conR.conS.config.CatchupCommitCertificates && len(ec.AggregatedSignature) == 0 &&
+				ps.PickSendCommitCertificate(ec) {
+				logger.Debug("Picked Catchup commit certificate to send", "height", prs.Height)
+				continue OUTER_LOOP
+			}
 			if ps.PickSendVote(ec) {
 				logger.Debug("Picked Catchup commit to send", "height", prs.Height)
 				continue OUTER_LOOP
@@ -1073,6 +1137,18 @@ type PeerState struct {
 	mtx   sync.Mutex             // NOTE: Modify below using setters, never directly.
 	PRS   cstypes.PeerRoundState `json:"round_state"` // Exposed.
 	Stats *peerStateStats        `json:"stats"`       // Exposed.
+
+	// parity parts of the proposal block with the given header known to the
+	// peer, if it supports erasure-coded block parts
+	parityHeader types.PartSetHeader
+	parityParts  *bits.BitArray
+
+	// compact block of the proposal block with the given header sent to the
+	// peer, and compact block received from the peer waiting for its missing
+	// transactions, if it supports compact blocks
+	compactBlockHeader types.PartSetHeader
+	compactBlockSentAt time.Time
+	pendingCompact     *pendingCompactBlock
 }
 
 // peerStateStats holds internal statistics for a peer.
@@ -1216,6 +1292,43 @@ func (ps *PeerState) PickSendVote(votes types.VoteSetReader) bool {
 	return false
 }
 
+// PickSendCommitCertificate sends the given commit to the peer as a commit
+// certificate if the peer supports commit certificates and misses some of its
+// precommits, which are then marked as known by the peer. Returns true if the
+// certificate was sent.
+func (ps *PeerState) PickSendCommitCertificate(ec *types.ExtendedCommit) bool {
+	if !p2p.PeerHasCapability(ps.peer, CapabilityCommitCertificates) {
+		return false
+	}
+	height, round, size := ec.Height, ec.Round, ec.Size()
+	ps.mtx.Lock()
+	ps.ensureCatchupCommitRound(height, round, size)
+	ps.ensureVoteBitArrays(height, size)
+	psVotes := ps.getVoteBitArray(height, round, cmtproto.PrecommitType)
+	missing := psVotes != nil && !ec.BitArray().Sub(psVotes).IsEmpty()
+	ps.mtx.Unlock()
+	if !missing {
+		return false
+	}
+
+	ps.logger.Debug("Sending commit certificate", "ps", ps, "height", height, "round", round)
+	if !ps.peer.Send(p2p.Envelope{
+		ChannelID: VoteChannel,
+		Message:   &cmtcons.CommitCertificate{Commit: ec.ToProto()},
+	}) {
+		return false
+	}
+
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	for idx, sig := range ec.ExtendedSignatures {
+		if sig.BlockIDFlag != types.BlockIDFlagAbsent {
+			ps.setHasVote(height, round, cmtproto.PrecommitType, int32(idx))
+		}
+	}
+	return true
+}
+
 // PickVoteToSend picks a vote to send to the peer.
 // Returns true if a vote was picked.
 // NOTE: `votes` must be the correct Size() for the Height().
@@ -1239,10 +1352,19 @@ func (ps *PeerState) PickVoteToSend(votes types.VoteSetReader) (vote *types.Vote
 	if psVotes == nil {
 		return nil, false // Not something worth sending
 	}
-	if index, ok := votes.BitArray().Sub(psVotes).PickRandom(); ok {
-		return votes.GetByIndex(int32(index)), true
+	candidates := votes.BitArray().Sub(psVotes)
+	for {
+		index, ok := candidates.PickRandom()
+		if !ok {
+			return nil, false
+		}
+		// The votes of an aggregated commit have no signature and can't be
+		// sent, see types.Commit.ToVoteSet.
+		if vote := votes.GetByIndex(int32(index)); len(vote.Signature) != 0 {
+			return vote, true
+		}
+		candidates.SetIndex(index, false)
 	}
-	return nil, false
 }
 
 func (ps *PeerState) getVoteBitArray(height int64, round int32, votesType cmtproto.SignedMsgType) *bits.BitArray {
@@ -1397,6 +1519,19 @@ func (ps *PeerState) SetHasVote(vote *types.Vote) {
 	ps.setHasVote(vote.Height, vote.Round, vote.Type, vote.ValidatorIndex)
 }
 
+// SetHasCommitCertificate sets the precommits of the given commit as known
+// by the peer.
+func (ps *PeerState) SetHasCommitCertificate(ec *types.ExtendedCommit) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	for idx, sig := range ec.ExtendedSignatures {
+		if sig.BlockIDFlag != types.BlockIDFlagAbsent {
+			ps.setHasVote(ec.Height, ec.Round, cmtproto.PrecommitType, int32(idx))
+		}
+	}
+}
+
 func (ps *PeerState) setHasVote(height int64, round int32, voteType cmtproto.SignedMsgType, index int32) {
 	ps.logger.Debug("setHasVote",
 		"peerH/R",
@@ -1584,6 +1719,11 @@ func init() {
 	cmtjson.RegisterType(&HasProposalBlockPartMessage{}, "tendermint/HasProposalBlockPart")
 	cmtjson.RegisterType(&VoteSetMaj23Message{}, "tendermint/VoteSetMaj23")
 	cmtjson.RegisterType(&VoteSetBitsMessage{}, "tendermint/VoteSetBits")
+	cmtjson.RegisterType(&CommitCertificateMessage{}, "tendermint/CommitCertificate")
+	cmtjson.RegisterType(&BlockParityPartMessage{}, "tendermint/BlockParityPart")
+	cmtjson.RegisterType(&CompactBlockMessage{}, "tendermint/CompactBlock")
+	cmtjson.RegisterType(&MissingTxsRequestMessage{}, "tendermint/MissingTxsRequest")
+	cmtjson.RegisterType(&MissingTxsMessage{}, "tendermint/MissingTxs")
 }
 
 //-------------------------------------
@@ -1915,3 +2055,186 @@ func (m *HasProposalBlockPartMessage) ValidateBasic() error {
 func (m *HasProposalBlockPartMessage) String() string {
 	return fmt.Sprintf("[HasProposalBlockPart PI:%v HR:{%v/%02d}]", m.Index, m.Height, m.Round)
 }
+
+//-------------------------------------
+
+// CapabilityCommitCertificates is advertised by the nodes sending commit
+// certificates (see CommitCertificateMessage), which are only sent to the peers
+// advertising it too.
+const CapabilityCommitCertificates = "consensus/commit-certificates"
+
+// CommitCertificateMessage is sent to a peer lagging behind with the commit
+// of its height, for it to add all the precommits at once.
+type CommitCertificateMessage struct {
+	Commit *types.ExtendedCommit
+}
+
+// ValidateBasic performs basic validation.
+func (m *CommitCertificateMessage) ValidateBasic() error {
+	if m.Commit == nil {
+		return cmterrors.ErrRequiredField{Field: "commit"}
+	}
+	if err := m.Commit.ValidateBasic(); err != nil {
+		return cmterrors.ErrWrongField{Field: "Commit", Err: err}
+	}
+	if m.Commit.Height < 1 {
+		return cmterrors.ErrInvalidField{Field: "Height", Reason: "( < 1 )"}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CommitCertificateMessage) String() string {
+	return fmt.Sprintf("[CommitCertificate %v/%02d %v]", m.Commit.Height, m.Commit.Round, m.Commit.BlockID)
+}
+
+//-------------------------------------
+
+// BlockParityPartMessage is sent when gossiping a parity part of the proposal
+// block, from which the missing parts of the block can be reconstructed.
+type BlockParityPartMessage struct {
+	Height        int64
+	Round         int32
+	PartSetHeader types.PartSetHeader
+	Index         uint32
+	LastPartSize  uint32
+	Bytes         []byte
+}
+
+// ValidateBasic performs basic validation.
+func (m *BlockParityPartMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return cmterrors.ErrNegativeField{Field: "Height"}
+	}
+	if m.Round < 0 {
+		return cmterrors.ErrNegativeField{Field: "Round"}
+	}
+	if err := m.PartSetHeader.ValidateBasic(); err != nil {
+		return cmterrors.ErrWrongField{Field: "PartSetHeader", Err: err}
+	}
+	if numParity := types.NumParityParts(m.PartSetHeader.Total); m.Index >= numParity {
+		return cmterrors.ErrInvalidField{Field: "Index", Reason: fmt.Sprintf("must be less than %d", numParity)}
+	}
+	// The parity parts are the size of the block parts but the last.
+	if len(m.Bytes) != int(types.BlockPartSizeBytes) {
+		return cmterrors.ErrInvalidField{Field: "Bytes", Reason: fmt.Sprintf("must be %d bytes", types.BlockPartSizeBytes)}
+	}
+	if m.LastPartSize == 0 || m.LastPartSize > types.BlockPartSizeBytes {
+		return cmterrors.ErrInvalidField{Field: "LastPartSize", Reason: fmt.Sprintf("must be in [1, %d]", types.BlockPartSizeBytes)}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *BlockParityPartMessage) String() string {
+	return fmt.Sprintf("[BlockParityPart H:%v R:%v I:%v %v]", m.Height, m.Round, m.Index, m.PartSetHeader)
+}
+
+//-------------------------------------
+
+// CompactBlockMessage is sent instead of the parts of the proposal block. It
+// carries the block without its transactions, identified by their keys.
+type CompactBlockMessage struct {
+	Height        int64
+	Round         int32
+	PartSetHeader types.PartSetHeader
+	Block         *types.Block // without its transactions
+	TxKeys        []types.TxKey
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return cmterrors.ErrNegativeField{Field: "Height"}
+	}
+	if m.Round < 0 {
+		return cmterrors.ErrNegativeField{Field: "Round"}
+	}
+	if err := m.PartSetHeader.ValidateBasic(); err != nil {
+		return cmterrors.ErrWrongField{Field: "PartSetHeader", Err: err}
+	}
+	if m.Block == nil {
+		return cmterrors.ErrRequiredField{Field: "Block"}
+	}
+	if m.Block.Height != m.Height {
+		return cmterrors.ErrInvalidField{Field: "Block", Reason: fmt.Sprintf("height %d, expected %d", m.Block.Height, m.Height)}
+	}
+	if len(m.Block.Txs) != 0 {
+		return cmterrors.ErrInvalidField{Field: "Block", Reason: "must not contain transactions"}
+	}
+	if err := m.Block.Header.ValidateBasic(); err != nil {
+		return cmterrors.ErrWrongField{Field: "Block", Err: err}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockMessage) String() string {
+	return fmt.Sprintf("[CompactBlock H:%v R:%v Txs:%v %v]", m.Height, m.Round, len(m.TxKeys), m.PartSetHeader)
+}
+
+//-------------------------------------
+
+// MissingTxsRequestMessage is sent to request the transactions of a compact
+// block missing from the mempool, by index.
+type MissingTxsRequestMessage struct {
+	Height        int64
+	Round         int32
+	PartSetHeader types.PartSetHeader
+	Indexes       []uint32
+}
+
+// ValidateBasic performs basic validation.
+func (m *MissingTxsRequestMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return cmterrors.ErrNegativeField{Field: "Height"}
+	}
+	if m.Round < 0 {
+		return cmterrors.ErrNegativeField{Field: "Round"}
+	}
+	if err := m.PartSetHeader.ValidateBasic(); err != nil {
+		return cmterrors.ErrWrongField{Field: "PartSetHeader", Err: err}
+	}
+	if len(m.Indexes) == 0 {
+		return cmterrors.ErrRequiredField{Field: "Indexes"}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *MissingTxsRequestMessage) String() string {
+	return fmt.Sprintf("[MissingTxsRequest H:%v R:%v Txs:%v %v]", m.Height, m.Round, len(m.Indexes), m.PartSetHeader)
+}
+
+//-------------------------------------
+
+// MissingTxsMessage is sent in response to a MissingTxsRequestMessage, with
+// the requested transactions in the requested order.
+type MissingTxsMessage struct {
+	Height        int64
+	Round         int32
+	PartSetHeader types.PartSetHeader
+	Txs           types.Txs
+}
+
+// ValidateBasic performs basic validation.
+func (m *MissingTxsMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return cmterrors.ErrNegativeField{Field: "Height"}
+	}
+	if m.Round < 0 {
+		return cmterrors.ErrNegativeField{Field: "Round"}
+	}
+	if err := m.PartSetHeader.ValidateBasic(); err != nil {
+		return cmterrors.ErrWrongField{Field: "PartSetHeader", Err: err}
+	}
+	if len(m.Txs) == 0 {
+		return cmterrors.ErrRequiredField{Field: "Txs"}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *MissingTxsMessage) String() string {
+	return fmt.Sprintf("[MissingTxs H:%v R:%v Txs:%v %v]", m.Height, m.Round, len(m.Txs), m.PartSetHeader)
+}
@@ -30,6 +30,7 @@ import (
 	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/p2p"
 	p2pmock "github.com/cometbft/cometbft/p2p/mock"
+	p2pmocks "github.com/cometbft/cometbft/p2p/mocks"
 	cmtcons "github.com/cometbft/cometbft/proto/tendermint/consensus"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/cometbft/cometbft/proxy"
@@ -1097,6 +1098,36 @@ func TestVoteSetBitsMessageValidateBasic(t *testing.T) {
 	}
 }
 
+func TestPeerStatePickSendCommitCertificate(t *testing.T) {
+	const height, round = 10, 0
+	ec := &types.ExtendedCommit{
+		Height: height,
+		Round:  round,
+		ExtendedSignatures: []types.ExtendedCommitSig{
+			{CommitSig: types.CommitSig{BlockIDFlag: types.BlockIDFlagCommit}},
+			types.NewExtendedCommitSigAbsent(),
+		},
+	}
+
+	// The certificate is not sent to the peers not supporting it...
+	ps := NewPeerState(p2pmock.NewPeer(nil))
+	ps.PRS.Height = height
+	assert.False(t, ps.PickSendCommitCertificate(ec))
+
+	// ...and sent once to the others.
+	peer := &p2pmocks.Peer{}
+	peer.On("NodeInfo").Return(p2p.DefaultNodeInfo{Capabilities: []string{CapabilityCommitCertificates}})
+	peer.On("Send", mock.MatchedBy(func(e p2p.Envelope) bool {
+		_, ok := e.Message.(*cmtcons.CommitCertificate)
+		return ok && e.ChannelID == VoteChannel
+	})).Return(true).Once()
+	ps = NewPeerState(peer)
+	ps.PRS.Height = height
+	assert.True(t, ps.PickSendCommitCertificate(ec))
+	assert.False(t, ps.PickSendCommitCertificate(ec))
+	peer.AssertExpectations(t)
+}
+
 func TestMarshalJSONPeerState(t *testing.T) {
 	ps := NewPeerState(nil)
 	data, err := json.Marshal(ps)
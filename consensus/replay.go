@@ -9,11 +9,16 @@ import (
 	"reflect"
 	"time"
 
+	dbm "github.com/cometbft/cometbft-db"
+
 	abci "github.com/cometbft/cometbft/abci/types"
+	cfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/crypto/merkle"
+	auto "github.com/cometbft/cometbft/libs/autofile"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/proxy"
 	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/store"
 	"github.com/cometbft/cometbft/types"
 )
 
@@ -80,6 +85,14 @@ func (cs *State) readReplayMessage(msg *TimedWALMessage, newStepSub types.Subscr
 		}
 
 		cs.handleMsg(m)
+		if pm, ok := m.Msg.(*ProposalMessage); ok {
+			// The proposal was received when it was written to the WAL.
+			cs.mtx.Lock()
+			if cs.Proposal == pm.Proposal {
+				cs.ProposalReceiveTime = cs.blockExec.BlockTime(msg.Time)
+			}
+			cs.mtx.Unlock()
+		}
 	case timeoutInfo:
 		cs.Logger.Info("Replay: Timeout", "height", m.Height, "round", m.Round, "step", m.Step, "dur", m.Duration)
 		cs.handleTimeout(m, cs.RoundState)
@@ -89,6 +102,119 @@ func (cs *State) readReplayMessage(msg *TimedWALMessage, newStepSub types.Subscr
 	return nil
 }
 
+// ReplayWAL feeds the messages decoded by dec into the consensus state, as
+// when catching up with the WAL on start, and returns the number of messages
+// fed. It's meant for inspecting a WAL segment: the state must not be
+// started, the messages that are not at its height are ignored, and the only
+// timeouts are the ones read from the WAL.
+func (cs *State) ReplayWAL(dec *WALFilterDecoder) (int, error) {
+	cs.replayMode = true
+	defer func() { cs.replayMode = false }()
+	cs.SetTimeoutTicker(nopTimeoutTicker{})
+	if cs.eventBus == nil {
+		eventBus := types.NewEventBus()
+		eventBus.SetLogger(cs.Logger.With("module", "events"))
+		if err := eventBus.Start(); err != nil {
+			return 0, err
+		}
+		defer func() { _ = eventBus.Stop() }()
+		cs.SetEventBus(eventBus)
+	}
+
+	n := 0
+	for {
+		msg, _, err := dec.Decode()
+		if err == io.EOF {
+			return n, nil
+		} else if err != nil {
+			return n, err
+		}
+		if err := cs.readReplayMessage(msg, nil); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// NewWALReplayState returns a consensus state at the given height, for
+// replaying the messages of a WAL segment with ReplayWAL. The state is built
+// by applying in-memory copies of the blocks from blockStore below the
+// height on the given application, which must be at its genesis. blockStore
+// is not modified.
+func NewWALReplayState(
+	ctx context.Context,
+	config *cfg.ConsensusConfig,
+	blockStore sm.BlockStore,
+	genDoc *types.GenesisDoc,
+	proxyApp proxy.AppConns,
+	height int64,
+	logger log.Logger,
+) (*State, error) {
+	state, err := sm.MakeGenesisState(genDoc)
+	if err != nil {
+		return nil, err
+	}
+	if height < state.InitialHeight {
+		return nil, fmt.Errorf("height %d is lower than the initial height %d", height, state.InitialHeight)
+	}
+	if height-1 > blockStore.Height() {
+		return nil, fmt.Errorf("height %d is greater than the block store height %d + 1", height, blockStore.Height())
+	}
+	if height > state.InitialHeight && blockStore.Base() > state.InitialHeight {
+		return nil, fmt.Errorf("the blocks below height %d were pruned from the block store", blockStore.Base())
+	}
+
+	// The handshake only initializes the chain, as there are no blocks yet.
+	memBlockStore := store.NewBlockStore(dbm.NewMemDB())
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	if err := stateStore.Save(state); err != nil {
+		return nil, err
+	}
+	handshaker := NewHandshaker(stateStore, state, memBlockStore, genDoc)
+	handshaker.SetLogger(logger)
+	if err := handshaker.Handshake(ctx, proxyApp); err != nil {
+		return nil, fmt.Errorf("error during handshake: %w", err)
+	}
+	if state, err = stateStore.Load(); err != nil {
+		return nil, err
+	}
+
+	mempool, evpool := emptyMempool{}, sm.EmptyEvidencePool{}
+	blockExec := sm.NewBlockExecutor(stateStore, logger, proxyApp.Consensus(), mempool, evpool, memBlockStore)
+	for h := state.InitialHeight; h < height; h++ {
+		block, meta := blockStore.LoadBlock(h), blockStore.LoadBlockMeta(h)
+		if block == nil || meta == nil {
+			return nil, fmt.Errorf("block at height %d not found", h)
+		}
+		parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+		if err != nil {
+			return nil, err
+		}
+		// The consensus state needs the extended commit of the last block if
+		// vote extensions are enabled.
+		extCommit := blockStore.LoadBlockExtendedCommit(h)
+		if h == height-1 && extCommit != nil {
+			memBlockStore.SaveBlockWithExtendedCommit(block, parts, extCommit)
+		} else {
+			commit := blockStore.LoadBlockCommit(h)
+			if commit == nil {
+				commit = blockStore.LoadSeenCommit(h)
+			}
+			if commit == nil {
+				return nil, fmt.Errorf("commit of block at height %d not found", h)
+			}
+			memBlockStore.SaveBlock(block, parts, commit)
+		}
+		if state, err = blockExec.ApplyBlock(state, meta.BlockID, block); err != nil {
+			return nil, fmt.Errorf("failed to apply block at height %d: %w", h, err)
+		}
+	}
+
+	cs := NewState(config, state.Copy(), blockExec, memBlockStore, mempool, evpool)
+	cs.SetLogger(logger)
+	return cs, nil
+}
+
 // Replay only those messages since the last block.  `timeoutRoutine` should
 // run concurrently to read off tickChan.
 func (cs *State) catchupReplay(csHeight int64) error {
@@ -139,8 +265,21 @@ func (cs *State) catchupReplay(csHeight int64) error {
 
 	cs.Logger.Info("Catchup by replaying consensus messages", "height", csHeight)
 
-	var msg *TimedWALMessage
-	dec := WALDecoder{gr}
+	var total int64
+	if rd, ok := gr.(*auto.GroupReader); ok {
+		if total, err = rd.Remaining(); err != nil {
+			cs.Logger.Error("Replay: failed to estimate the size of the WAL to replay", "err", err)
+		}
+	}
+	cs.replayProgress.start(ReplayPhaseWAL, csHeight, total)
+	defer cs.replayProgress.finish()
+
+	var (
+		msg      *TimedWALMessage
+		messages int64
+	)
+	counter := &countingReader{rd: gr}
+	dec := WALDecoder{counter}
 
 LOOP:
 	for {
@@ -161,6 +300,8 @@ LOOP:
 		if err := cs.readReplayMessage(msg, nil); err != nil {
 			return err
 		}
+		messages++
+		cs.replayProgress.update(csHeight, counter.n, messages)
 	}
 	cs.Logger.Info("Replay: Done")
 	return nil
@@ -205,6 +346,7 @@ type Handshaker struct {
 	eventBus     types.BlockEventPublisher
 	genDoc       *types.GenesisDoc
 	logger       log.Logger
+	progress     *ReplayProgress
 
 	nBlocks int // number of blocks applied to the state
 }
@@ -219,6 +361,7 @@ func NewHandshaker(stateStore sm.Store, state sm.State,
 		eventBus:     types.NopEventBus{},
 		genDoc:       genDoc,
 		logger:       log.NewNopLogger(),
+		progress:     NewReplayProgress(NopMetrics()),
 		nBlocks:      0,
 	}
 }
@@ -233,6 +376,11 @@ func (h *Handshaker) SetEventBus(eventBus types.BlockEventPublisher) {
 	h.eventBus = eventBus
 }
 
+// SetReplayProgress sets the tracker of the progress of the blocks replay.
+func (h *Handshaker) SetReplayProgress(progress *ReplayProgress) {
+	h.progress = progress
+}
+
 // NBlocks returns the number of blocks applied to the state.
 func (h *Handshaker) NBlocks() int {
 	return h.nBlocks
@@ -470,6 +618,8 @@ func (h *Handshaker) replayBlocks(
 	if firstBlock == 1 {
 		firstBlock = state.InitialHeight
 	}
+	h.progress.start(ReplayPhaseBlocks, firstBlock-1, storeBlockHeight-firstBlock+1)
+	defer h.progress.finish()
 	for i := firstBlock; i <= finalBlock; i++ {
 		select {
 		case <-ctx.Done():
@@ -490,6 +640,7 @@ func (h *Handshaker) replayBlocks(
 		}
 
 		h.nBlocks++
+		h.progress.update(i, i-firstBlock+1, 0)
 	}
 
 	if mutateState {
@@ -499,6 +650,7 @@ func (h *Handshaker) replayBlocks(
 			return nil, err
 		}
 		appHash = state.AppHash
+		h.progress.update(storeBlockHeight, storeBlockHeight-firstBlock+1, 0)
 	}
 
 	assertAppHashEqualsOneFromState(appHash, state)
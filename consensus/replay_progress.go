@@ -0,0 +1,159 @@
+package consensus
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+// Phases of the replay on startup.
+const (
+	// The blocks are replayed to the application by the Handshaker.
+	ReplayPhaseBlocks = "blocks"
+	// The messages of the last height are replayed from the WAL by the
+	// consensus state.
+	ReplayPhaseWAL = "wal"
+)
+
+// Interval between the logs of the progress of a replay.
+const replayProgressLogInterval = 10 * time.Second
+
+// ReplayStatus is the progress of a replay on startup.
+type ReplayStatus struct {
+	// Phase of the replay, see ReplayPhaseBlocks and ReplayPhaseWAL. Empty if
+	// no replay was started.
+	Phase string
+	// Whether the replay is in progress. Otherwise, the status is the one of
+	// the last replay.
+	Running bool
+	// Height of the last block replayed, or of the height whose messages are
+	// replayed from the WAL.
+	Height int64
+	// Work done and total work of the replay: blocks for the blocks phase,
+	// and bytes of the WAL read for the wal phase, whose total is estimated
+	// from the size of the WAL files left to read.
+	Done  int64
+	Total int64
+	// Number of messages replayed from the WAL.
+	Messages int64
+	Started  time.Time
+	Elapsed  time.Duration
+	// Estimated time left to complete the replay, from the rate of the work
+	// done so far. 0 if unknown.
+	Remaining time.Duration
+}
+
+// ReplayProgress tracks the progress of the replays on startup, reporting it
+// in the logs and metrics, so that a node replaying a large WAL or many blocks
+// does not appear hung.
+//
+// It's safe for concurrent use.
+type ReplayProgress struct {
+	metrics *Metrics
+	logger  log.Logger
+
+	mtx     sync.Mutex
+	status  ReplayStatus
+	lastLog time.Time
+}
+
+// NewReplayProgress returns a ReplayProgress updating the given metrics.
+func NewReplayProgress(metrics *Metrics) *ReplayProgress {
+	return &ReplayProgress{
+		metrics: metrics,
+		logger:  log.NewNopLogger(),
+	}
+}
+
+// SetLogger sets the logger of the progress reports.
+func (p *ReplayProgress) SetLogger(l log.Logger) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.logger = l
+}
+
+// Status returns the progress of the current replay, or of the last one.
+func (p *ReplayProgress) Status() ReplayStatus {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	status := p.status
+	if status.Running {
+		status.Elapsed = time.Since(status.Started)
+	}
+	return status
+}
+
+// start starts tracking a replay of the given phase and total work.
+func (p *ReplayProgress) start(phase string, height, total int64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	now := time.Now()
+	p.status = ReplayStatus{
+		Phase:   phase,
+		Running: true,
+		Height:  height,
+		Total:   total,
+		Started: now,
+	}
+	p.lastLog = now
+	p.metrics.ReplayProgress.With("phase", phase).Set(0)
+	p.logger.Info("Replay started", "phase", phase, "height", height, "total", total)
+}
+
+// update records the progress of the current replay, and reports it if
+// replayProgressLogInterval elapsed since the last report.
+func (p *ReplayProgress) update(height, done, messages int64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	now := time.Now()
+	s := &p.status
+	s.Height, s.Done, s.Messages = height, done, messages
+	s.Elapsed = now.Sub(s.Started)
+	s.Remaining = 0
+	if s.Done > 0 && s.Total > s.Done {
+		s.Remaining = time.Duration(float64(s.Elapsed) * float64(s.Total-s.Done) / float64(s.Done))
+	}
+
+	if s.Total > 0 {
+		p.metrics.ReplayProgress.With("phase", s.Phase).Set(min(float64(s.Done)/float64(s.Total), 1))
+	}
+	p.metrics.ReplayRemainingSeconds.With("phase", s.Phase).Set(s.Remaining.Seconds())
+
+	if now.Sub(p.lastLog) >= replayProgressLogInterval {
+		p.lastLog = now
+		p.logger.Info("Replay progress", "phase", s.Phase, "height", s.Height,
+			"done", s.Done, "total", s.Total, "messages", s.Messages,
+			"elapsed", s.Elapsed, "remaining", s.Remaining)
+	}
+}
+
+// finish ends the current replay.
+func (p *ReplayProgress) finish() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	s := &p.status
+	if !s.Running {
+		return
+	}
+	s.Running = false
+	s.Elapsed = time.Since(s.Started)
+	s.Remaining = 0
+	p.metrics.ReplayProgress.With("phase", s.Phase).Set(1)
+	p.metrics.ReplayRemainingSeconds.With("phase", s.Phase).Set(0)
+	p.logger.Info("Replay finished", "phase", s.Phase, "height", s.Height,
+		"done", s.Done, "messages", s.Messages, "elapsed", s.Elapsed)
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	rd io.Reader
+	n  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.rd.Read(p)
+	r.n += int64(n)
+	return n, err
+}
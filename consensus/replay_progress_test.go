@@ -0,0 +1,50 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayProgress(t *testing.T) {
+	p := NewReplayProgress(NopMetrics())
+	require.Empty(t, p.Status().Phase)
+
+	p.start(ReplayPhaseBlocks, 10, 4)
+	status := p.Status()
+	require.Equal(t, ReplayPhaseBlocks, status.Phase)
+	require.True(t, status.Running)
+	require.EqualValues(t, 10, status.Height)
+	require.Zero(t, status.Remaining)
+
+	// Pretend the first block took a second to replay.
+	p.mtx.Lock()
+	p.status.Started = time.Now().Add(-time.Second)
+	p.mtx.Unlock()
+	p.update(11, 1, 0)
+	status = p.Status()
+	require.EqualValues(t, 11, status.Height)
+	require.EqualValues(t, 1, status.Done)
+	// 3 blocks left, at about a block per second.
+	assert.InDelta(t, 3*time.Second, status.Remaining, float64(100*time.Millisecond))
+
+	p.update(14, 4, 0)
+	require.Zero(t, p.Status().Remaining)
+
+	p.finish()
+	status = p.Status()
+	require.False(t, status.Running)
+	require.EqualValues(t, 14, status.Height)
+	require.EqualValues(t, 4, status.Done)
+
+	// The WAL phase replaces the status of the blocks phase.
+	p.start(ReplayPhaseWAL, 15, 0)
+	p.update(15, 100, 3)
+	status = p.Status()
+	require.Equal(t, ReplayPhaseWAL, status.Phase)
+	require.EqualValues(t, 3, status.Messages)
+	// The remaining time is unknown without a total.
+	require.Zero(t, status.Remaining)
+}
@@ -29,8 +29,10 @@ func (txmp emptyMempool) RemoveTxByKey(types.TxKey) error {
 	return nil
 }
 
-func (emptyMempool) ReapMaxBytesMaxGas(int64, int64) types.Txs { return types.Txs{} }
-func (emptyMempool) ReapMaxTxs(int) types.Txs                  { return types.Txs{} }
+func (emptyMempool) ReapMaxBytesMaxGas(int64, int64) types.Txs        { return types.Txs{} }
+func (emptyMempool) ReapMaxTxs(int) types.Txs                         { return types.Txs{} }
+func (emptyMempool) ReapMatchingTxs(int, mempl.TxMatchFunc) types.Txs { return types.Txs{} }
+func (emptyMempool) EncryptedTxs(int64) types.Txs                     { return types.Txs{} }
 func (emptyMempool) Update(
 	int64,
 	types.Txs,
@@ -713,6 +713,8 @@ func testHandshakeReplay(t *testing.T, config *cfg.Config, nBlocks int, mode uin
 	genDoc, err := sm.MakeGenesisDocFromFile(testConfig.GenesisFile())
 	require.NoError(t, err)
 	handshaker := NewHandshaker(stateStore, state, store, genDoc)
+	progress := NewReplayProgress(NopMetrics())
+	handshaker.SetReplayProgress(progress)
 	proxyApp := proxy.NewAppConns(clientCreator2, proxy.NopMetrics())
 	if err := proxyApp.Start(); err != nil {
 		t.Fatalf("Error starting proxy app connections: %v", err)
@@ -764,6 +766,14 @@ func testHandshakeReplay(t *testing.T, config *cfg.Config, nBlocks int, mode uin
 	if handshaker.NBlocks() != expectedBlocksToSync {
 		t.Fatalf("Expected handshake to sync %d blocks, got %d", expectedBlocksToSync, handshaker.NBlocks())
 	}
+
+	// the progress of the blocks replay, if any, should be complete
+	if status := progress.Status(); status.Phase != "" {
+		require.Equal(t, ReplayPhaseBlocks, status.Phase)
+		require.False(t, status.Running)
+		require.Equal(t, status.Total, status.Done)
+		require.Equal(t, store.Height(), status.Height)
+	}
 }
 
 func applyBlock(t *testing.T, stateStore sm.Store, mempool mempool.Mempool, evpool sm.EvidencePool, st sm.State, blk *types.Block, proxyApp proxy.AppConns, bs sm.BlockStore) sm.State {
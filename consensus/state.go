@@ -16,6 +16,7 @@ import (
 	cfg "github.com/cometbft/cometbft/config"
 	cstypes "github.com/cometbft/cometbft/consensus/types"
 	"github.com/cometbft/cometbft/crypto"
+	auto "github.com/cometbft/cometbft/libs/autofile"
 	cmtevents "github.com/cometbft/cometbft/libs/events"
 	"github.com/cometbft/cometbft/libs/fail"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
@@ -121,7 +122,7 @@ type State struct {
 	// some functions can be overwritten for testing
 	decideProposal func(height int64, round int32)
 	doPrevote      func(height int64, round int32)
-	setProposal    func(proposal *types.Proposal) error
+	setProposal    func(proposal *types.Proposal, recvTime time.Time) error
 
 	// closed when we finish shutting down
 	done chan struct{}
@@ -135,6 +136,25 @@ type State struct {
 
 	// offline state sync height indicating to which height the node synced offline
 	offlineStateSyncHeight int64
+
+	// records the received votes, nil if vote recording is disabled
+	voteRecorder *VoteRecorder
+
+	// timeouts of the propose, prevote and precommit steps
+	timeouts *adaptiveTimeouts
+
+	// progress of the WAL replay on startup
+	replayProgress *ReplayProgress
+
+	// participation of the validators in the consensus
+	validatorsPerformance *ValidatorsPerformance
+
+	// traces the steps, messages and timeouts, nil if tracing is disabled
+	traceRecorder *TraceRecorder
+
+	// parity parts received for the proposal block, if erasure-coded block
+	// parts are enabled
+	parityParts *proposalParityParts
 }
 
 // StateOption sets an optional parameter on the State.
@@ -169,6 +189,13 @@ func NewState(
 	for _, option := range options {
 		option(cs)
 	}
+	cs.timeouts = newAdaptiveTimeouts(config, cs.metrics)
+	if cs.replayProgress == nil {
+		cs.replayProgress = NewReplayProgress(cs.metrics)
+	}
+	if cs.validatorsPerformance == nil {
+		cs.validatorsPerformance = NewValidatorsPerformance(cs.metrics)
+	}
 	// set function defaults (may be overwritten before calling Start)
 	cs.decideProposal = cs.defaultDecideProposal
 	cs.doPrevote = cs.defaultDoPrevote
@@ -220,6 +247,29 @@ func OfflineStateSyncHeight(height int64) StateOption {
 	return func(cs *State) { cs.offlineStateSyncHeight = height }
 }
 
+// StateVoteRecorder sets the recorder persisting the received votes.
+func StateVoteRecorder(recorder *VoteRecorder) StateOption {
+	return func(cs *State) { cs.voteRecorder = recorder }
+}
+
+// StateReplayProgress sets the tracker of the progress of the WAL replay on
+// startup.
+func StateReplayProgress(progress *ReplayProgress) StateOption {
+	return func(cs *State) { cs.replayProgress = progress }
+}
+
+// StateValidatorsPerformance sets the tracker of the participation of the
+// validators in the consensus.
+func StateValidatorsPerformance(performance *ValidatorsPerformance) StateOption {
+	return func(cs *State) { cs.validatorsPerformance = performance }
+}
+
+// StateTraceRecorder sets the recorder tracing the steps, messages and
+// timeouts of the consensus.
+func StateTraceRecorder(recorder *TraceRecorder) StateOption {
+	return func(cs *State) { cs.traceRecorder = recorder }
+}
+
 // String returns a string.
 func (cs *State) String() string {
 	// better not to access shared variables
@@ -440,11 +490,18 @@ func (cs *State) Wait() {
 // OpenWAL opens a file to log all consensus messages and timeouts for
 // deterministic accountability.
 func (cs *State) OpenWAL(walFile string) (WAL, error) {
-	wal, err := NewWAL(walFile)
+	wal, err := NewWAL(walFile,
+		auto.GroupHeadSizeLimit(cs.config.WalSegmentSize),
+		auto.GroupTotalSizeLimit(cs.config.WalMaxTotalSize))
 	if err != nil {
 		cs.Logger.Error("failed to open WAL", "file", walFile, "err", err)
 		return nil, err
 	}
+	if err := wal.SetCompression(cs.config.WalCompression); err != nil {
+		cs.Logger.Error("failed to set WAL compression", "err", err)
+		wal.Group().Close()
+		return nil, err
+	}
 
 	wal.SetLogger(cs.Logger.With("wal", walFile))
 
@@ -537,6 +594,7 @@ func (cs *State) updateRoundStep(round int32, step cstypes.RoundStepType) {
 		if cs.Step != step {
 			cs.metrics.MarkStep(cs.Step)
 		}
+		cs.timeouts.enterStep(cs.Height, round, step, cmttime.Now())
 	}
 	cs.Round = round
 	cs.Step = step
@@ -625,6 +683,13 @@ func (cs *State) votesFromSeenCommit(state sm.State) (*types.VoteSet, error) {
 		return nil, fmt.Errorf("heights don't match in votesFromSeenCommit %v!=%v",
 			commit.Height, state.LastBlockHeight)
 	}
+	// The signatures of an aggregated commit are not verified one by one as
+	// its votes are added to the vote set, so they are verified at once here.
+	if commit.IsAggregated() {
+		if err := state.LastValidators.VerifyCommit(state.ChainID, commit.BlockID, commit.Height, commit); err != nil {
+			return nil, fmt.Errorf("invalid aggregated commit for height %v: %w", commit.Height, err)
+		}
+	}
 	vs := commit.ToVoteSet(state.ChainID, state.LastValidators)
 	if !vs.HasTwoThirdsMajority() {
 		return nil, ErrCommitQuorumNotMet
@@ -708,6 +773,7 @@ func (cs *State) updateToState(state sm.State) {
 	// RoundState fields
 	cs.updateHeight(height)
 	cs.updateRoundStep(0, cstypes.RoundStepNewHeight)
+	cs.timeouts.setParams(state.ConsensusParams.Timeout)
 
 	if cs.CommitTime.IsZero() {
 		// "Now" makes it easier to sync up dev nodes.
@@ -715,15 +781,16 @@ func (cs *State) updateToState(state sm.State) {
 		// to be gathered for the first block.
 		// And alternative solution that relies on clocks:
 		// cs.StartTime = state.LastBlockTime.Add(timeoutCommit)
-		cs.StartTime = cs.config.Commit(cmttime.Now())
+		cs.StartTime = cs.timeouts.Commit(cmttime.Now())
 	} else {
-		cs.StartTime = cs.config.Commit(cs.CommitTime)
+		cs.StartTime = cs.timeouts.Commit(cs.CommitTime)
 	}
 
 	cs.Validators = validators
 	cs.Proposal = nil
 	cs.ProposalBlock = nil
 	cs.ProposalBlockParts = nil
+	cs.parityParts = nil
 	cs.LockedRound = -1
 	cs.LockedBlock = nil
 	cs.LockedBlockParts = nil
@@ -755,6 +822,7 @@ func (cs *State) newStep() {
 
 	// newStep is called by updateToState in NewState before the eventBus is set!
 	if cs.eventBus != nil {
+		cs.trace(traceStepEvent(&cs.RoundState, cmttime.Now()))
 		if err := cs.eventBus.PublishEventNewRoundStep(rs); err != nil {
 			cs.Logger.Error("failed publishing new round step", "err", err)
 		}
@@ -871,16 +939,23 @@ func (cs *State) handleMsg(mi msgInfo) {
 	)
 
 	msg, peerID := mi.Msg, mi.PeerID
+	cs.trace(traceMessageEvent(&cs.RoundState, mi, cmttime.Now()))
 
 	switch msg := msg.(type) {
 	case *ProposalMessage:
 		// will not cause transition.
-		// once proposal is set, we can receive block parts
-		err = cs.setProposal(msg.Proposal)
+		// once proposal is set, we can receive block parts. The receive time
+		// is checked against the time of the block under PBTS.
+		err = cs.setProposal(msg.Proposal, cs.blockExec.BlockTime(cmttime.Now()))
 
 	case *BlockPartMessage:
 		// if the proposal is complete, we'll enterPrevote or tryFinalizeCommit
 		added, err = cs.addProposalBlockPart(msg, peerID)
+		if added && err == nil {
+			// The part may complete the parts and parity parts required to
+			// reconstruct the missing parts.
+			_, err = cs.reconstructProposalBlockParts(msg.Height, msg.Round, peerID)
+		}
 
 		// We unlock here to yield to any routines that need to read the the RoundState.
 		// Previously, this code held the lock from the point at which the final block
@@ -936,6 +1011,15 @@ func (cs *State) handleMsg(mi msgInfo) {
 		// the peer is sending us CatchupCommit precommits.
 		// We could make note of this and help filter in broadcastHasVoteMessage().
 
+	case *CommitCertificateMessage:
+		err = cs.addCommitCertificate(msg.Commit, peerID)
+
+	case *BlockParityPartMessage:
+		added, err = cs.addProposalParityPart(msg, peerID)
+		if added && cs.ProposalBlockParts.IsComplete() {
+			cs.handleCompleteProposal(msg.Height)
+		}
+
 	default:
 		cs.Logger.Error("unknown msg type", "type", fmt.Sprintf("%T", msg))
 		return
@@ -965,6 +1049,11 @@ func (cs *State) handleTimeout(ti timeoutInfo, rs cstypes.RoundState) {
 	// the timeout will now cause a state transition
 	cs.mtx.Lock()
 	defer cs.mtx.Unlock()
+	cs.trace(traceTimeoutEvent(ti, cmttime.Now()))
+
+	if !cs.replayMode {
+		cs.timeouts.timeoutFired(ti.Height, ti.Round, ti.Step)
+	}
 
 	switch ti.Step {
 	case cstypes.RoundStepNewHeight:
@@ -1074,8 +1163,14 @@ func (cs *State) enterNewRound(height int64, round int32) {
 	if round != 0 {
 		logger.Info("resetting proposal info", "proposer", propAddress)
 		cs.Proposal = nil
+		cs.ProposalReceiveTime = time.Time{}
 		cs.ProposalBlock = nil
 		cs.ProposalBlockParts = nil
+		// Another block may be decided in this round, and the block executed
+		// optimistically will be executed again if prevoted anew.
+		if cs.config.OptimisticExecution {
+			cs.blockExec.DiscardOptimisticExecution()
+		}
 	}
 
 	logger.Debug("entering new round",
@@ -1085,6 +1180,7 @@ func (cs *State) enterNewRound(height int64, round int32) {
 
 	cs.Votes.SetRound(cmtmath.SafeAddInt32(round, 1)) // also track next round (round+1) to allow round-skipping
 	cs.TriggeredTimeoutPrecommit = false
+	cs.validatorsPerformance.enterRound(height, round, propAddress)
 
 	if err := cs.eventBus.PublishEventNewRound(cs.NewRoundEvent()); err != nil {
 		cs.Logger.Error("failed publishing new round", "err", err)
@@ -1095,7 +1191,7 @@ func (cs *State) enterNewRound(height int64, round int32) {
 	waitForTxs := cs.config.WaitForTxs() && round == 0 && !cs.needProofBlock(height)
 	if waitForTxs {
 		if cs.config.CreateEmptyBlocksInterval > 0 {
-			cs.scheduleTimeout(cs.config.CreateEmptyBlocksInterval, height, round,
+			cs.scheduleTimeout(cs.config.EmptyBlocksInterval(), height, round,
 				cstypes.RoundStepNewRound)
 		}
 	} else {
@@ -1137,6 +1233,18 @@ func (cs *State) enterPropose(height int64, round int32) {
 		return
 	}
 
+	// Under PBTS, the time of the block is the time of its proposer, which
+	// must be after the time of the last block: if the clock of the proposer
+	// isn't past it yet, wait before proposing.
+	if cs.state.ConsensusParams.Synchrony.PBTSEnabled(height) && cs.privValidatorPubKey != nil &&
+		cs.isProposer(cs.privValidatorPubKey.Address()) {
+		if wait := proposerWaitTime(cs.state.LastBlockTime, cs.blockExec.BlockTime(cmttime.Now())); wait > 0 {
+			logger.Debug("waiting for the clock to be past the last block time before proposing", "wait", wait)
+			cs.scheduleTimeout(wait, height, round, cstypes.RoundStepNewRound)
+			return
+		}
+	}
+
 	logger.Debug("entering propose step", "current", log.NewLazySprintf("%v/%v/%v", cs.Height, cs.Round, cs.Step))
 
 	defer func() {
@@ -1153,7 +1261,7 @@ func (cs *State) enterPropose(height int64, round int32) {
 	}()
 
 	// If we don't get the proposal and all block parts quick enough, enterPrevote
-	cs.scheduleTimeout(cs.config.Propose(round), height, round, cstypes.RoundStepPropose)
+	cs.scheduleTimeout(cs.timeouts.Propose(round), height, round, cstypes.RoundStepPropose)
 
 	// Nothing more to do if we're not a validator
 	if cs.privValidator == nil {
@@ -1186,6 +1294,15 @@ func (cs *State) enterPropose(height int64, round int32) {
 	}
 }
 
+// proposerWaitTime returns how long the proposer must wait for its clock,
+// currently at now, to be past the time of the last block under PBTS.
+func proposerWaitTime(lastBlockTime, now time.Time) time.Duration {
+	if now.After(lastBlockTime) {
+		return 0
+	}
+	return lastBlockTime.Sub(now) + time.Millisecond
+}
+
 func (cs *State) isProposer(address []byte) bool {
 	return bytes.Equal(cs.Validators.GetProposer().Address, address)
 }
@@ -1225,6 +1342,11 @@ func (cs *State) defaultDecideProposal(height int64, round int32) {
 	// Make proposal
 	propBlockID := types.BlockID{Hash: block.Hash(), PartSetHeader: blockParts.Header()}
 	proposal := types.NewProposal(height, round, cs.ValidRound, propBlockID)
+	if cs.state.ConsensusParams.Synchrony.PBTSEnabled(height) {
+		// Under PBTS, the validators check the timeliness of the proposal
+		// against the time of its block.
+		proposal.Timestamp = block.Time
+	}
 	p := proposal.ToProto()
 	if err := cs.privValidator.SignProposal(cs.state.ChainID, p); err == nil {
 		proposal.Signature = p.Signature
@@ -1243,6 +1365,13 @@ func (cs *State) defaultDecideProposal(height int64, round int32) {
 	}
 }
 
+// proposalIsTimely returns true if the proposal was received in time under
+// PBTS, according to the synchrony params of its round.
+func (cs *State) proposalIsTimely() bool {
+	sp := cs.state.ConsensusParams.Synchrony.InRound(cs.Proposal.Round)
+	return cs.Proposal.IsTimely(cs.ProposalReceiveTime, sp)
+}
+
 // Returns true if the proposal block is complete &&
 // (if POLRound was proposed, we have +2/3 prevotes from there).
 func (cs *State) isProposalComplete() bool {
@@ -1354,6 +1483,23 @@ func (cs *State) defaultDoPrevote(height int64, round int32) {
 		return
 	}
 
+	// Under PBTS, the time of the block must be the one of the proposal and,
+	// for a new block, the proposal must have been received in time.
+	if cs.state.ConsensusParams.Synchrony.PBTSEnabled(height) {
+		if !cs.Proposal.Timestamp.Equal(cs.ProposalBlock.Time) {
+			logger.Debug("prevote step: proposal timestamp not equal to the block time; prevoting nil",
+				"proposal_timestamp", cs.Proposal.Timestamp, "block_time", cs.ProposalBlock.Time)
+			cs.signAddVote(cmtproto.PrevoteType, nil, types.PartSetHeader{}, nil)
+			return
+		}
+		if cs.Proposal.POLRound == -1 && cs.LockedRound == -1 && !cs.proposalIsTimely() {
+			logger.Debug("prevote step: proposal is not timely; prevoting nil",
+				"proposal_timestamp", cs.Proposal.Timestamp, "receive_time", cs.ProposalReceiveTime)
+			cs.signAddVote(cmtproto.PrevoteType, nil, types.PartSetHeader{}, nil)
+			return
+		}
+	}
+
 	/*
 		22: upon <PROPOSAL, h_p, round_p, v, −1> from proposer(h_p, round_p) while step_p = propose do
 		23: if valid(v) && (lockedRound_p = −1 || lockedValue_p = v) then
@@ -1502,7 +1648,7 @@ func (cs *State) enterPrevoteWait(height int64, round int32) {
 	}()
 
 	// Wait for some more prevotes; enterPrecommit
-	cs.scheduleTimeout(cs.config.Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
+	cs.scheduleTimeout(cs.timeouts.Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
 }
 
 // Enter: `timeoutPrevote` after any +2/3 prevotes.
@@ -1641,7 +1787,7 @@ func (cs *State) enterPrecommitWait(height int64, round int32) {
 	}()
 
 	// wait for some more precommits; enterNewRound
-	cs.scheduleTimeout(cs.config.Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
+	cs.scheduleTimeout(cs.timeouts.Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
 }
 
 // Enter: +2/3 precommits for block
@@ -1837,6 +1983,7 @@ func (cs *State) finalizeCommit(height int64) {
 
 	// must be called before we update state
 	cs.recordMetrics(height, block)
+	cs.validatorsPerformance.commit(height, cs.Validators, cs.Votes, cs.CommitRound, blockID)
 
 	// NewHeightStep!
 	cs.updateToState(stateCopy)
@@ -1948,7 +2095,7 @@ func (cs *State) recordMetrics(height int64, block *types.Block) {
 
 //-----------------------------------------------------------------------------
 
-func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
+func (cs *State) defaultSetProposal(proposal *types.Proposal, recvTime time.Time) error {
 	// Already have one
 	// TODO: possibly catch double proposals
 	if cs.Proposal != nil {
@@ -1977,6 +2124,7 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 
 	proposal.Signature = p.Signature
 	cs.Proposal = proposal
+	cs.ProposalReceiveTime = recvTime
 	// We don't update cs.ProposalBlockParts if it is already set.
 	// This happens if we're already in cstypes.RoundStepCommit or if there is a valid block in the current round.
 	// TODO: We can check if Proposal is for a different block as this is a sign of misbehavior!
@@ -1985,6 +2133,8 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 	}
 
 	cs.Logger.Info("received proposal", "proposal", proposal, "proposer", pubKey.Address())
+	cs.validatorsPerformance.receiveProposal(proposal.Height, proposal.Round, pubKey.Address(),
+		cs.Step <= cstypes.RoundStepPropose)
 	return nil
 }
 
@@ -2106,9 +2256,36 @@ func (cs *State) handleCompleteProposal(blockHeight int64) {
 	}
 }
 
+// addCommitCertificate verifies the given commit for the current height and
+// adds its precommits, which commits the block if it is complete.
+// Certificates for other heights, or received once committing, are ignored.
+func (cs *State) addCommitCertificate(ec *types.ExtendedCommit, peerID p2p.ID) error {
+	if ec.Height != cs.Height || cs.Step >= cstypes.RoundStepCommit {
+		cs.Logger.Debug("ignoring commit certificate", "height", ec.Height, "cs_height", cs.Height, "cs_step", cs.Step)
+		return nil
+	}
+
+	commit := ec.ToCommit()
+	if err := cs.Validators.VerifyCommit(cs.state.ChainID, commit.BlockID, ec.Height, commit); err != nil {
+		return fmt.Errorf("invalid commit certificate: %w", err)
+	}
+
+	cs.Logger.Debug("adding commit certificate", "height", ec.Height, "round", ec.Round, "peer", peerID)
+	for idx, sig := range ec.ExtendedSignatures {
+		if sig.BlockIDFlag == types.BlockIDFlagAbsent {
+			continue
+		}
+		if _, err := cs.tryAddVote(ec.GetExtendedVote(int32(idx)), peerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Attempt to add the vote. if its a duplicate signature, dupeout the validator
 func (cs *State) tryAddVote(vote *types.Vote, peerID p2p.ID) (bool, error) {
 	added, err := cs.addVote(vote, peerID)
+	cs.recordVote(vote, peerID, added, err)
 	// NOTE: some of these errors are swallowed here
 	if err != nil {
 		// If the vote height is off, we'll just ignore it,
@@ -2158,6 +2335,32 @@ func (cs *State) tryAddVote(vote *types.Vote, peerID p2p.ID) (bool, error) {
 	return added, nil
 }
 
+// trace records the given event, unless tracing is disabled or the WAL is
+// being replayed.
+func (cs *State) trace(ev TraceEvent) {
+	if cs.traceRecorder == nil || cs.replayMode {
+		return
+	}
+	if err := cs.traceRecorder.Record(ev); err != nil {
+		cs.Logger.Error("failed to record trace event", "type", ev.Type, "err", err)
+	}
+}
+
+// recordVote persists the vote if it was valid, including if it conflicts with
+// another vote of the same validator.
+func (cs *State) recordVote(vote *types.Vote, peerID p2p.ID, added bool, err error) {
+	if cs.voteRecorder == nil {
+		return
+	}
+	var conflictErr *types.ErrVoteConflictingVotes
+	if !added && !errors.As(err, &conflictErr) {
+		return
+	}
+	if err := cs.voteRecorder.Record(vote, peerID, cmttime.Now()); err != nil {
+		cs.Logger.Error("failed to record vote", "height", vote.Height, "err", err)
+	}
+}
+
 func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error) {
 	cs.Logger.Debug(
 		"adding vote",
@@ -2240,7 +2443,7 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 				return false, err
 			}
 
-			err := cs.blockExec.VerifyVoteExtension(context.TODO(), vote)
+			err := cs.blockExec.VerifyVoteExtension(context.TODO(), vote, cs.state)
 			cs.metrics.MarkVoteExtensionReceived(err == nil)
 			if err != nil {
 				return false, err
@@ -2427,7 +2630,12 @@ func (cs *State) signVote(
 }
 
 func (cs *State) voteTime() time.Time {
-	now := cmttime.Now()
+	now := cs.blockExec.BlockTime(cmttime.Now())
+	// Under PBTS, the time of the blocks doesn't depend on the time of the
+	// votes.
+	if cs.state.ConsensusParams.Synchrony.PBTSEnabled(cs.Height) {
+		return now
+	}
 	minVoteTime := now
 	// Minimum time increment between blocks
 	const timeIota = time.Millisecond
@@ -2484,6 +2692,12 @@ func (cs *State) signAddVote(
 	}
 	cs.sendInternalMessage(msgInfo{&VoteMessage{vote}, ""})
 	cs.Logger.Debug("signed and pushed vote", "height", cs.Height, "round", cs.Round, "vote", vote)
+
+	// Start executing the block we prevoted for, in case it's decided.
+	if cs.config.OptimisticExecution && vote.Type == cmtproto.PrevoteType &&
+		!vote.BlockID.IsNil() && cs.ProposalBlock.HashesTo(vote.BlockID.Hash) {
+		cs.blockExec.ExecuteBlockOptimistically(cs.state, cs.ProposalBlock)
+	}
 }
 
 // updatePrivValidatorPubKey get's the private validator public key and
@@ -12,6 +12,8 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	dbm "github.com/cometbft/cometbft-db"
+
 	"github.com/cometbft/cometbft/abci/example/kvstore"
 	abci "github.com/cometbft/cometbft/abci/types"
 	abcimocks "github.com/cometbft/cometbft/abci/types/mocks"
@@ -23,6 +25,7 @@ import (
 	"github.com/cometbft/cometbft/libs/protoio"
 	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	p2pmock "github.com/cometbft/cometbft/p2p/mock"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/cometbft/cometbft/types"
@@ -263,6 +266,111 @@ func TestStateBadProposal(t *testing.T) {
 	signAddVotes(cs1, cmtproto.PrecommitType, propBlock.Hash(), bps2.Header(), true, vs2)
 }
 
+func TestStatePBTSTimelyProposal(t *testing.T) {
+	cs1, vss := randState(2)
+	cs1.state.ConsensusParams.Synchrony.PBTSEnableHeight = cs1.Height
+	height, round := cs1.Height, cs1.Round
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	voteCh := subscribe(cs1.eventBus, types.EventQueryVote)
+
+	startTestRound(cs1, height, round)
+
+	// The proposal is timestamped with the time of its block, and prevoted.
+	ensureNewProposal(proposalCh, height, round)
+	rs := cs1.GetRoundState()
+	require.True(t, rs.Proposal.Timestamp.Equal(rs.ProposalBlock.Time))
+	require.True(t, rs.ProposalBlock.Time.After(cs1.state.LastBlockTime))
+
+	ensurePrevote(voteCh, height, round)
+	validatePrevote(t, cs1, round, vss[0], rs.ProposalBlock.Hash())
+}
+
+func TestStatePBTSUntimelyProposal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs1, vss := randState(2)
+	cs1.state.ConsensusParams.Synchrony.PBTSEnableHeight = cs1.Height
+	height, round := cs1.Height, cs1.Round
+	vs2 := vss[1]
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	voteCh := subscribe(cs1.eventBus, types.EventQueryVote)
+
+	propBlock, err := cs1.createProposalBlock(ctx)
+	require.NoError(t, err)
+
+	// make the second validator the proposer by incrementing round
+	round++
+	incrementRound(vss[1:]...)
+
+	// the block is timestamped ahead of the clock of the node by more than
+	// the precision
+	propBlock.Time = propBlock.Time.Add(time.Hour)
+	propBlockParts, err := propBlock.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
+	proposal := types.NewProposal(vs2.Height, round, -1, blockID)
+	proposal.Timestamp = propBlock.Time
+	p := proposal.ToProto()
+	err = vs2.SignProposal(cs1.state.ChainID, p)
+	require.NoError(t, err)
+	proposal.Signature = p.Signature
+
+	err = cs1.SetProposalAndBlock(proposal, propBlock, propBlockParts, "some peer")
+	require.NoError(t, err)
+
+	startTestRound(cs1, height, round)
+	ensureProposal(proposalCh, height, round, blockID)
+
+	// the proposal is valid but not timely, so the node prevotes nil
+	ensurePrevote(voteCh, height, round)
+	validatePrevote(t, cs1, round, vss[0], nil)
+}
+
+func TestStatePBTSProposalTimestampMismatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs1, vss := randState(2)
+	cs1.state.ConsensusParams.Synchrony.PBTSEnableHeight = cs1.Height
+	height, round := cs1.Height, cs1.Round
+	vs2 := vss[1]
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	voteCh := subscribe(cs1.eventBus, types.EventQueryVote)
+
+	propBlock, err := cs1.createProposalBlock(ctx)
+	require.NoError(t, err)
+
+	// make the second validator the proposer by incrementing round
+	round++
+	incrementRound(vss[1:]...)
+
+	// the block is valid and timely, but its time is not the timestamp of
+	// the proposal
+	propBlockParts, err := propBlock.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
+	proposal := types.NewProposal(vs2.Height, round, -1, blockID)
+	proposal.Timestamp = propBlock.Time.Add(time.Millisecond)
+	p := proposal.ToProto()
+	err = vs2.SignProposal(cs1.state.ChainID, p)
+	require.NoError(t, err)
+	proposal.Signature = p.Signature
+
+	err = cs1.SetProposalAndBlock(proposal, propBlock, propBlockParts, "some peer")
+	require.NoError(t, err)
+
+	startTestRound(cs1, height, round)
+	ensureProposal(proposalCh, height, round, blockID)
+
+	// the node prevotes nil
+	ensurePrevote(voteCh, height, round)
+	validatePrevote(t, cs1, round, vss[0], nil)
+}
+
 func TestStateOversizedBlock(t *testing.T) {
 	const maxBytes = 2000
 
@@ -391,6 +499,33 @@ func TestStateFullRound1(t *testing.T) {
 	validateLastPrecommit(t, cs, vss[0], propBlockHash)
 }
 
+// Runs a full round with vote recording enabled and checks that the votes of
+// the validator were recorded.
+func TestStateRecordsVotes(t *testing.T) {
+	cs, _ := randState(1)
+	cs.voteRecorder = NewVoteRecorder(dbm.NewMemDB(), 1000)
+	height, round := cs.Height, cs.Round
+
+	newRoundCh := subscribe(cs.eventBus, types.EventQueryNewRound)
+	startTestRound(cs, height, round)
+	ensureNewRound(newRoundCh, height, round)
+	ensureNewRound(newRoundCh, height+1, 0)
+
+	// The precommit is recorded once it has been processed, which may be after
+	// the next height started.
+	var votes []*cstypes.RecordedVote
+	require.Eventually(t, func() bool {
+		var err error
+		votes, err = cs.voteRecorder.Votes(height)
+		return err == nil && len(votes) == 2
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, cmtproto.PrevoteType, votes[0].Vote.Type)
+	require.Equal(t, cmtproto.PrecommitType, votes[1].Vote.Type)
+	for _, v := range votes {
+		require.Empty(t, v.PeerID)
+	}
+}
+
 // nil is proposed, so prevote and precommit nil
 func TestStateFullRoundNil(t *testing.T) {
 	cs, _ := randState(1)
@@ -442,6 +577,47 @@ func TestStateFullRound2(t *testing.T) {
 	ensureNewBlock(newBlockCh, height)
 }
 
+// a commit certificate with 2/3+ of the precommits commits the block, while
+// one without them is rejected
+func TestStateCommitCertificate(t *testing.T) {
+	cs1, vss := randState(4)
+	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
+	height, round := cs1.Height, cs1.Round
+
+	voteCh := subscribe(cs1.eventBus, types.EventQueryVote)
+	newBlockCh := subscribe(cs1.eventBus, types.EventQueryNewBlock)
+
+	// start round and wait for propose and prevote
+	startTestRound(cs1, height, round)
+	ensurePrevote(voteCh, height, round)
+
+	rs := cs1.GetRoundState()
+	blockID := types.BlockID{Hash: rs.ProposalBlock.Hash(), PartSetHeader: rs.ProposalBlockParts.Header()}
+	makeCertificate := func(vss ...*validatorStub) *types.ExtendedCommit {
+		ec := &types.ExtendedCommit{
+			Height:             height,
+			Round:              round,
+			BlockID:            blockID,
+			ExtendedSignatures: make([]types.ExtendedCommitSig, len(rs.Validators.Validators)),
+		}
+		for i := range ec.ExtendedSignatures {
+			ec.ExtendedSignatures[i] = types.NewExtendedCommitSigAbsent()
+		}
+		for _, vote := range signVotes(cmtproto.PrecommitType, blockID.Hash, blockID.PartSetHeader, true, vss...) {
+			ec.ExtendedSignatures[vote.ValidatorIndex] = vote.ExtendedCommitSig()
+		}
+		return ec
+	}
+
+	cs1.mtx.Lock()
+	err := cs1.addCommitCertificate(makeCertificate(vs2), "peer")
+	cs1.mtx.Unlock()
+	require.Error(t, err)
+
+	cs1.peerMsgQueue <- msgInfo{Msg: &CommitCertificateMessage{makeCertificate(vs2, vs3, vs4)}, PeerID: "peer"}
+	ensureNewBlock(newBlockCh, height)
+}
+
 //------------------------------------------------------------------------------------------
 // LockSuite
 
@@ -847,6 +1023,97 @@ func TestStateLockPOLRelock(t *testing.T) {
 	validatePrecommit(t, cs1, round, round, vss[0], theBlockHash, theBlockHash)
 }
 
+// finalizeRecordingApp records the hashes of the blocks passed to
+// FinalizeBlock, signaling each call on started.
+type finalizeRecordingApp struct {
+	*kvstore.Application
+
+	mtx       cmtsync.Mutex
+	finalized [][]byte
+	started   chan struct{}
+}
+
+func (app *finalizeRecordingApp) FinalizeBlock(
+	ctx context.Context,
+	req *abci.RequestFinalizeBlock,
+) (*abci.ResponseFinalizeBlock, error) {
+	app.mtx.Lock()
+	app.finalized = append(app.finalized, req.Hash)
+	app.mtx.Unlock()
+	app.started <- struct{}{}
+	return app.Application.FinalizeBlock(ctx, req)
+}
+
+// TestStateOptimisticExecutionRoundChange tests that the optimistic execution
+// of a block is discarded when the round changes, and the block executed again
+// when prevoted and decided in the next round.
+func TestStateOptimisticExecutionRoundChange(t *testing.T) {
+	app := &finalizeRecordingApp{
+		Application: kvstore.NewInMemoryApplication(),
+		started:     make(chan struct{}, 10),
+	}
+	// The blocks are not executed optimistically with vote extensions.
+	cs1, vss := randStateWithAppWithHeight(4, app, 0)
+	cs1.config.OptimisticExecution = true
+	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
+	height, round := cs1.Height, cs1.Round
+
+	timeoutWaitCh := subscribe(cs1.eventBus, types.EventQueryTimeoutWait)
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	newRoundCh := subscribe(cs1.eventBus, types.EventQueryNewRound)
+	newBlockCh := subscribe(cs1.eventBus, types.EventQueryNewBlock)
+	pv1, err := cs1.privValidator.GetPubKey()
+	require.NoError(t, err)
+	voteCh := subscribeToVoter(cs1, pv1.Address())
+
+	// Round 0: cs1 proposes and prevotes B, which it starts executing, but
+	// the others precommit nil.
+	startTestRound(cs1, height, round)
+	ensureNewRound(newRoundCh, height, round)
+	ensureNewProposal(proposalCh, height, round)
+	rs := cs1.GetRoundState()
+	theBlock := rs.ProposalBlock
+	theBlockHash := rs.ProposalBlock.Hash()
+	theBlockParts := rs.ProposalBlockParts
+
+	ensurePrevote(voteCh, height, round)
+	<-app.started
+
+	signAddVotes(cs1, cmtproto.PrevoteType, theBlockHash, theBlockParts.Header(), false, vs2, vs3, vs4)
+	ensurePrecommit(voteCh, height, round)
+	signAddVotes(cs1, cmtproto.PrecommitType, nil, types.PartSetHeader{}, false, vs2, vs3, vs4)
+	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
+
+	// Round 1: the optimistic execution of round 0 is discarded. B is
+	// proposed again, prevoted and executed anew, then decided.
+	incrementRound(vs2, vs3, vs4)
+	round++
+	propBlockID := types.BlockID{Hash: theBlockHash, PartSetHeader: theBlockParts.Header()}
+	propR1 := types.NewProposal(height, round, cs1.ValidRound, propBlockID)
+	p := propR1.ToProto()
+	if err := vs2.SignProposal(cs1.state.ChainID, p); err != nil {
+		t.Fatalf("error signing proposal: %s", err)
+	}
+	propR1.Signature = p.Signature
+	if err := cs1.SetProposalAndBlock(propR1, theBlock, theBlockParts, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	ensureNewRound(newRoundCh, height, round)
+	ensureNewProposal(proposalCh, height, round)
+	ensurePrevote(voteCh, height, round)
+	validatePrevote(t, cs1, round, vss[0], theBlockHash)
+
+	signAddVotes(cs1, cmtproto.PrevoteType, theBlockHash, theBlockParts.Header(), false, vs2, vs3, vs4)
+	ensurePrecommit(voteCh, height, round)
+	signAddVotes(cs1, cmtproto.PrecommitType, theBlockHash, theBlockParts.Header(), false, vs2, vs3, vs4)
+	ensureNewBlock(newBlockCh, height)
+
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	assert.Equal(t, [][]byte{theBlockHash, theBlockHash}, app.finalized)
+}
+
 // TestStateLockPrevoteNilWhenLockedAndMissProposal tests that a validator prevotes nil
 // if it is locked on a block and misses the proposal in a round.
 func TestStateLockPrevoteNilWhenLockedAndMissProposal(t *testing.T) {
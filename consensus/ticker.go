@@ -132,3 +132,17 @@ func (t *timeoutTicker) timeoutRoutine() {
 		}
 	}
 }
+
+//-------------------------------------------------------------
+
+// nopTimeoutTicker is a TimeoutTicker that never fires, for replaying the
+// timeouts from the WAL only.
+type nopTimeoutTicker struct{}
+
+var _ TimeoutTicker = nopTimeoutTicker{}
+
+func (nopTimeoutTicker) Start() error                { return nil }
+func (nopTimeoutTicker) Stop() error                 { return nil }
+func (nopTimeoutTicker) Chan() <-chan timeoutInfo    { return nil }
+func (nopTimeoutTicker) ScheduleTimeout(timeoutInfo) {}
+func (nopTimeoutTicker) SetLogger(log.Logger)        {}
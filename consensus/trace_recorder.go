@@ -0,0 +1,120 @@
+package consensus
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	cstypes "github.com/cometbft/cometbft/consensus/types"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/p2p"
+)
+
+// Types of the consensus trace events.
+const (
+	TraceEventStep    = "step"
+	TraceEventMessage = "message"
+	TraceEventTimeout = "timeout"
+)
+
+// TraceEvent is an event of the consensus traced by a TraceRecorder.
+type TraceEvent struct {
+	Time   time.Time `json:"time"`
+	Type   string    `json:"type"`
+	Height int64     `json:"height"`
+	Round  int32     `json:"round"`
+	// Step entered, or of the timeout.
+	Step string `json:"step,omitempty"`
+	// Type of the message received, and the peer it was received from, empty
+	// for the messages of the node itself.
+	Message string `json:"msg,omitempty"`
+	Peer    p2p.ID `json:"peer,omitempty"`
+	// Duration of the timeout.
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// TraceRecorder writes the consensus trace events, one JSON object per line,
+// for the post-mortem analysis of the rounds.
+type TraceRecorder struct {
+	mtx cmtsync.Mutex
+	w   io.WriteCloser
+	enc *json.Encoder
+}
+
+// NewTraceRecorder returns a recorder writing the events to w.
+func NewTraceRecorder(w io.WriteCloser) *TraceRecorder {
+	return &TraceRecorder{
+		w:   w,
+		enc: json.NewEncoder(w),
+	}
+}
+
+// OpenTraceRecorder returns a recorder appending the events to the file at
+// the given path, which is created if needed.
+func OpenTraceRecorder(path string) (*TraceRecorder, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create trace directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace file: %w", err)
+	}
+	return NewTraceRecorder(f), nil
+}
+
+// Record writes the given event.
+func (r *TraceRecorder) Record(ev TraceEvent) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.enc.Encode(ev)
+}
+
+// Close closes the underlying writer.
+func (r *TraceRecorder) Close() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.w.Close()
+}
+
+func traceStepEvent(rs *cstypes.RoundState, now time.Time) TraceEvent {
+	return TraceEvent{
+		Time:   now,
+		Type:   TraceEventStep,
+		Height: rs.Height,
+		Round:  rs.Round,
+		Step:   rs.Step.String(),
+	}
+}
+
+func traceMessageEvent(rs *cstypes.RoundState, mi msgInfo, now time.Time) TraceEvent {
+	name := "<nil>"
+	if t := reflect.TypeOf(mi.Msg); t != nil {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		name = t.Name()
+	}
+	return TraceEvent{
+		Time:    now,
+		Type:    TraceEventMessage,
+		Height:  rs.Height,
+		Round:   rs.Round,
+		Message: name,
+		Peer:    mi.PeerID,
+	}
+}
+
+func traceTimeoutEvent(ti timeoutInfo, now time.Time) TraceEvent {
+	return TraceEvent{
+		Time:     now,
+		Type:     TraceEventTimeout,
+		Height:   ti.Height,
+		Round:    ti.Round,
+		Step:     ti.Step.String(),
+		Duration: ti.Duration,
+	}
+}
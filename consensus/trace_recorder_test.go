@@ -0,0 +1,92 @@
+package consensus
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/cometbft/cometbft/consensus/types"
+	cmtos "github.com/cometbft/cometbft/libs/os"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestStateTraceRecorder(t *testing.T) {
+	traceFile := filepath.Join(t.TempDir(), "trace", "consensus.jsonl")
+	recorder, err := OpenTraceRecorder(traceFile)
+	require.NoError(t, err)
+
+	cs1, vss := randState(2)
+	cs1.traceRecorder = recorder
+	vs2 := vss[1]
+	height, round := cs1.Height, cs1.Round
+
+	voteCh := subscribe(cs1.eventBus, types.EventQueryVote)
+	newRoundCh := subscribe(cs1.eventBus, types.EventQueryNewRound)
+	timeoutCh := subscribe(cs1.eventBus, types.EventQueryTimeoutPropose)
+
+	startTestRound(cs1, height, round)
+	ensureNewRound(newRoundCh, height, round)
+	ensurePrevote(voteCh, height, round)
+
+	rs := cs1.GetRoundState()
+	propBlockHash, propPartSetHeader := rs.ProposalBlock.Hash(), rs.ProposalBlockParts.Header()
+	signAddVotes(cs1, cmtproto.PrevoteType, propBlockHash, propPartSetHeader, false, vs2)
+	ensurePrevote(voteCh, height, round)
+	ensurePrecommit(voteCh, height, round)
+	signAddVotes(cs1, cmtproto.PrecommitType, propBlockHash, propPartSetHeader, true, vs2)
+	ensurePrecommit(voteCh, height, round)
+
+	// vs2 proposes at the next height, but it doesn't
+	ensureNewRound(newRoundCh, height+1, 0)
+	ensureNewTimeout(timeoutCh, height+1, 0, cs1.config.TimeoutPropose.Nanoseconds())
+	cs1.mtx.Lock()
+	require.NoError(t, recorder.Close())
+	cs1.traceRecorder = nil
+	cs1.mtx.Unlock()
+
+	bz, err := cmtos.ReadFile(traceFile)
+	require.NoError(t, err)
+	var (
+		steps    []string
+		votes    int
+		timeouts []string
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(bz))
+	for scanner.Scan() {
+		var ev TraceEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &ev))
+		assert.False(t, ev.Time.IsZero())
+		switch ev.Type {
+		case TraceEventStep:
+			if ev.Height == height {
+				steps = append(steps, ev.Step)
+			}
+		case TraceEventMessage:
+			if ev.Message == "VoteMessage" && ev.Height == height {
+				votes++
+			}
+		case TraceEventTimeout:
+			timeouts = append(timeouts, ev.Step)
+			assert.Positive(t, ev.Duration)
+		default:
+			t.Fatalf("unexpected event type %q", ev.Type)
+		}
+	}
+	require.NoError(t, scanner.Err())
+
+	assert.Subset(t, steps, []string{
+		cstypes.RoundStepPropose.String(),
+		cstypes.RoundStepPrevote.String(),
+		cstypes.RoundStepPrecommit.String(),
+		cstypes.RoundStepCommit.String(),
+	})
+	// the prevotes and precommits of both validators
+	assert.Equal(t, 4, votes)
+	assert.Contains(t, timeouts, cstypes.RoundStepPropose.String())
+}
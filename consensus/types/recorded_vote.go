@@ -0,0 +1,17 @@
+package types
+
+import (
+	"time"
+
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/types"
+)
+
+// RecordedVote is a vote received by the node, along with the peer it was
+// received from and when.
+type RecordedVote struct {
+	Vote *types.Vote `json:"vote"`
+	// PeerID is empty for the votes of the node itself.
+	PeerID     p2p.ID    `json:"peer_id"`
+	ReceivedAt time.Time `json:"received_at"`
+}
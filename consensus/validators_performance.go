@@ -0,0 +1,215 @@
+package consensus
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	cstypes "github.com/cometbft/cometbft/consensus/types"
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Statuses of the proposals of the validators.
+const (
+	// The proposal was received during the propose step.
+	ProposalStatusTimely = "timely"
+	// The proposal was received after the propose step.
+	ProposalStatusLate = "late"
+	// The proposal was not received.
+	ProposalStatusMissed = "missed"
+)
+
+// ValidatorStats is the participation of a validator in the consensus, as
+// observed by the node since it started.
+type ValidatorStats struct {
+	Address crypto.Address
+	// Number of heights committed while in the validator set, and number of
+	// them whose commit includes a precommit of the validator for the block.
+	Heights          int64
+	Signed           int64
+	LastSignedHeight int64
+	// Number of rounds observed while in the validator set, and number of
+	// them in which a prevote and a precommit of the validator were received.
+	Rounds     int64
+	Prevotes   int64
+	Precommits int64
+	// Number of rounds in which the validator was the proposer, by status of
+	// its proposal.
+	ProposerRounds  int64
+	ProposalsTimely int64
+	ProposalsLate   int64
+	ProposalsMissed int64
+}
+
+// ValidatorsPerformanceReport is the participation of all the validators
+// observed by the node.
+type ValidatorsPerformanceReport struct {
+	// First and last heights observed, 0 if none.
+	SinceHeight int64
+	LastHeight  int64
+	// Validators, sorted by address.
+	Validators []ValidatorStats
+}
+
+// roundProposer is the proposer of a round and the status of its proposal.
+type roundProposer struct {
+	address  crypto.Address
+	received bool
+	timely   bool
+}
+
+// ValidatorsPerformance tracks, for each validator, the rounds in which its
+// prevotes and precommits were received, the commits it signed, and whether
+// its proposals were received in time, reporting them in the metrics. The
+// participation is the one observed by the node: a vote or proposal that did
+// not reach the node counts as missed.
+//
+// It's safe for concurrent use.
+type ValidatorsPerformance struct {
+	metrics *Metrics
+
+	mtx         sync.Mutex
+	sinceHeight int64
+	lastHeight  int64
+	stats       map[string]*ValidatorStats
+	// Rounds entered at the current height.
+	height int64
+	rounds map[int32]*roundProposer
+}
+
+// NewValidatorsPerformance returns a ValidatorsPerformance updating the given
+// metrics.
+func NewValidatorsPerformance(metrics *Metrics) *ValidatorsPerformance {
+	return &ValidatorsPerformance{
+		metrics: metrics,
+		stats:   make(map[string]*ValidatorStats),
+		rounds:  make(map[int32]*roundProposer),
+	}
+}
+
+// Report returns the participation of the validators observed so far.
+func (p *ValidatorsPerformance) Report() ValidatorsPerformanceReport {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	report := ValidatorsPerformanceReport{
+		SinceHeight: p.sinceHeight,
+		LastHeight:  p.lastHeight,
+		Validators:  make([]ValidatorStats, 0, len(p.stats)),
+	}
+	for _, s := range p.stats {
+		report.Validators = append(report.Validators, *s)
+	}
+	sort.Slice(report.Validators, func(i, j int) bool {
+		return bytes.Compare(report.Validators[i].Address, report.Validators[j].Address) < 0
+	})
+	return report
+}
+
+// enterRound records that the node entered the given round, whose proposer
+// is the given validator.
+func (p *ValidatorsPerformance) enterRound(height int64, round int32, proposer crypto.Address) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.round(height, round, proposer)
+}
+
+// receiveProposal records that the proposal of the given round was received
+// from its proposer, during the propose step if timely is true. The proposal
+// of round 0 may be received before the node enters the round.
+func (p *ValidatorsPerformance) receiveProposal(height int64, round int32, proposer crypto.Address, timely bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	rp := p.round(height, round, proposer)
+	if rp.received {
+		return
+	}
+	rp.received, rp.timely = true, timely
+}
+
+// round returns the given round, which is added if it's not tracked yet.
+func (p *ValidatorsPerformance) round(height int64, round int32, proposer crypto.Address) *roundProposer {
+	if height != p.height {
+		p.height = height
+		p.rounds = make(map[int32]*roundProposer)
+	}
+	rp, ok := p.rounds[round]
+	if !ok {
+		rp = &roundProposer{address: proposer}
+		p.rounds[round] = rp
+	}
+	return rp
+}
+
+// commit records the participation of the validators in the rounds of the
+// given height, whose block is committed with the precommits of commitRound.
+func (p *ValidatorsPerformance) commit(
+	height int64,
+	vals *types.ValidatorSet,
+	votes *cstypes.HeightVoteSet,
+	commitRound int32,
+	blockID types.BlockID,
+) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if height == p.height {
+		for round, rp := range p.rounds {
+			prevotes, precommits := votes.Prevotes(round), votes.Precommits(round)
+			for idx, val := range vals.Validators {
+				s := p.validatorStats(val.Address)
+				s.Rounds++
+				if prevotes.GetByIndex(int32(idx)) != nil {
+					s.Prevotes++
+				} else {
+					p.metrics.ValidatorMissedPrevotes.With("validator_address", val.Address.String()).Add(1)
+				}
+				if precommits.GetByIndex(int32(idx)) != nil {
+					s.Precommits++
+				} else {
+					p.metrics.ValidatorMissedPrecommits.With("validator_address", val.Address.String()).Add(1)
+				}
+			}
+
+			s := p.validatorStats(rp.address)
+			s.ProposerRounds++
+			status := ProposalStatusMissed
+			switch {
+			case rp.timely:
+				s.ProposalsTimely++
+				status = ProposalStatusTimely
+			case rp.received:
+				s.ProposalsLate++
+				status = ProposalStatusLate
+			default:
+				s.ProposalsMissed++
+			}
+			p.metrics.ValidatorProposals.With("validator_address", rp.address.String(), "status", status).Add(1)
+		}
+	}
+
+	precommits := votes.Precommits(commitRound)
+	for idx, val := range vals.Validators {
+		s := p.validatorStats(val.Address)
+		s.Heights++
+		if vote := precommits.GetByIndex(int32(idx)); vote != nil && vote.BlockID.Equals(blockID) {
+			s.Signed++
+			s.LastSignedHeight = height
+		}
+	}
+
+	if p.sinceHeight == 0 {
+		p.sinceHeight = height
+	}
+	p.lastHeight = height
+	p.rounds = make(map[int32]*roundProposer)
+}
+
+func (p *ValidatorsPerformance) validatorStats(address crypto.Address) *ValidatorStats {
+	s, ok := p.stats[string(address)]
+	if !ok {
+		s = &ValidatorStats{Address: address}
+		p.stats[string(address)] = s
+	}
+	return s
+}
@@ -0,0 +1,86 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/cometbft/cometbft/consensus/types"
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestValidatorsPerformance(t *testing.T) {
+	const (
+		chainID = "test_chain_id"
+		height  = int64(5)
+	)
+	valSet, privVals := types.RandValidatorSet(4, 10)
+	votes := cstypes.NewHeightVoteSet(chainID, height, valSet)
+	votes.SetRound(1)
+	blockID := types.BlockID{
+		Hash:          cmtrand.Bytes(32),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: cmtrand.Bytes(32)},
+	}
+	addVote := func(valIdx int32, round int32, voteType cmtproto.SignedMsgType, blockID types.BlockID) {
+		vote := types.MakeVoteNoError(t, privVals[valIdx], chainID, valIdx, height, round, voteType, blockID, time.Now())
+		vote.ExtensionSignature = nil
+		added, err := votes.AddVote(vote, "", false)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	p := NewValidatorsPerformance(NopMetrics())
+
+	// Round 0: no proposal, 3 prevotes and 2 precommits for nil.
+	p.enterRound(height, 0, valSet.Validators[0].Address)
+	for i := int32(0); i < 3; i++ {
+		addVote(i, 0, cmtproto.PrevoteType, types.BlockID{})
+	}
+	for i := int32(0); i < 2; i++ {
+		addVote(i, 0, cmtproto.PrecommitType, types.BlockID{})
+	}
+
+	// Round 1: the proposal is received before entering the round, 4
+	// prevotes and 3 precommits for the block.
+	p.receiveProposal(height, 1, valSet.Validators[1].Address, true)
+	p.enterRound(height, 1, valSet.Validators[1].Address)
+	for i := int32(0); i < 4; i++ {
+		addVote(i, 1, cmtproto.PrevoteType, blockID)
+	}
+	for i := int32(0); i < 3; i++ {
+		addVote(i, 1, cmtproto.PrecommitType, blockID)
+	}
+	p.commit(height, valSet, votes, 1, blockID)
+
+	report := p.Report()
+	assert.Equal(t, height, report.SinceHeight)
+	assert.Equal(t, height, report.LastHeight)
+	require.Len(t, report.Validators, 4)
+
+	stats := make(map[string]ValidatorStats)
+	for _, s := range report.Validators {
+		stats[string(s.Address)] = s
+	}
+	statsOf := func(valIdx int) ValidatorStats {
+		return stats[string(valSet.Validators[valIdx].Address)]
+	}
+	assert.Equal(t, ValidatorStats{
+		Address: valSet.Validators[0].Address, Heights: 1, Signed: 1, LastSignedHeight: height,
+		Rounds: 2, Prevotes: 2, Precommits: 2, ProposerRounds: 1, ProposalsMissed: 1,
+	}, statsOf(0))
+	assert.Equal(t, ValidatorStats{
+		Address: valSet.Validators[1].Address, Heights: 1, Signed: 1, LastSignedHeight: height,
+		Rounds: 2, Prevotes: 2, Precommits: 2, ProposerRounds: 1, ProposalsTimely: 1,
+	}, statsOf(1))
+	assert.Equal(t, ValidatorStats{
+		Address: valSet.Validators[2].Address, Heights: 1, Signed: 1, LastSignedHeight: height,
+		Rounds: 2, Prevotes: 2, Precommits: 1,
+	}, statsOf(2))
+	assert.Equal(t, ValidatorStats{
+		Address: valSet.Validators[3].Address, Heights: 1, Rounds: 2, Prevotes: 1,
+	}, statsOf(3))
+}
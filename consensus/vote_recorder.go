@@ -0,0 +1,121 @@
+package consensus
+
+import (
+	"fmt"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	cstypes "github.com/cometbft/cometbft/consensus/types"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/types"
+)
+
+// VoteRecorder persists all the votes received by the node for a number of
+// recent heights, including the votes that did not make it into a commit,
+// such as the votes of previous rounds and conflicting votes.
+type VoteRecorder struct {
+	db            dbm.DB
+	retainHeights int64
+
+	mtx        cmtsync.Mutex
+	lastHeight int64 // highest height a vote was recorded for
+}
+
+// NewVoteRecorder returns a recorder keeping the votes of the given number of
+// most recent heights in db.
+func NewVoteRecorder(db dbm.DB, retainHeights int64) *VoteRecorder {
+	return &VoteRecorder{
+		db:            db,
+		retainHeights: retainHeights,
+	}
+}
+
+// Record persists a vote received from the given peer. The votes of the
+// heights that fall out of the retained window are pruned.
+func (r *VoteRecorder) Record(vote *types.Vote, peerID p2p.ID, receivedAt time.Time) error {
+	bz, err := cmtjson.Marshal(&cstypes.RecordedVote{
+		Vote:       vote,
+		PeerID:     peerID,
+		ReceivedAt: receivedAt,
+	})
+	if err != nil {
+		return err
+	}
+	if err := r.db.Set(recordedVoteKey(vote), bz); err != nil {
+		return err
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if vote.Height <= r.lastHeight {
+		return nil
+	}
+	r.lastHeight = vote.Height
+	return r.pruneBelow(r.lastHeight - r.retainHeights + 1)
+}
+
+// Votes returns the votes recorded for the given height, ordered by round,
+// type and validator index.
+func (r *VoteRecorder) Votes(height int64) ([]*cstypes.RecordedVote, error) {
+	it, err := r.db.Iterator(recordedVotesPrefix(height), recordedVotesPrefix(height+1))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	votes := make([]*cstypes.RecordedVote, 0)
+	for ; it.Valid(); it.Next() {
+		vote := new(cstypes.RecordedVote)
+		if err := cmtjson.Unmarshal(it.Value(), vote); err != nil {
+			return nil, fmt.Errorf("failed to decode recorded vote %q: %w", it.Key(), err)
+		}
+		votes = append(votes, vote)
+	}
+	return votes, it.Error()
+}
+
+// Close closes the underlying database.
+func (r *VoteRecorder) Close() error {
+	return r.db.Close()
+}
+
+// pruneBelow deletes the votes of the heights lower than the given height.
+func (r *VoteRecorder) pruneBelow(height int64) error {
+	if height <= 1 {
+		return nil
+	}
+	it, err := r.db.Iterator(recordedVotesPrefix(0), recordedVotesPrefix(height))
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	batch := r.db.NewBatch()
+	defer batch.Close()
+	for ; it.Valid(); it.Next() {
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// The keys sort by height, round, type and validator index. They end with
+// the hash of the signature to keep the conflicting votes of a validator
+// apart.
+func recordedVotesPrefix(height int64) []byte {
+	return []byte(fmt.Sprintf("V:%020d:", height))
+}
+
+func recordedVoteKey(vote *types.Vote) []byte {
+	return []byte(fmt.Sprintf("V:%020d:%010d:%03d:%010d:%X",
+		vote.Height, vote.Round, vote.Type, vote.ValidatorIndex, tmhash.Sum(vote.Signature)))
+}
@@ -0,0 +1,72 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/p2p"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestVoteRecorder(t *testing.T) {
+	recorder := NewVoteRecorder(dbm.NewMemDB(), 2)
+	now := time.Now().UTC().Round(0)
+
+	vote := func(height int64, round int32, voteType cmtproto.SignedMsgType, valIndex int32, sig string) *types.Vote {
+		return &types.Vote{
+			Type:           voteType,
+			Height:         height,
+			Round:          round,
+			ValidatorIndex: valIndex,
+			Signature:      []byte(sig),
+		}
+	}
+
+	votes := []*types.Vote{
+		vote(1, 0, cmtproto.PrecommitType, 0, "a"),
+		vote(1, 0, cmtproto.PrevoteType, 1, "b"),
+		vote(1, 0, cmtproto.PrevoteType, 0, "c"),
+		// a conflicting vote of the same validator is kept
+		vote(1, 0, cmtproto.PrevoteType, 0, "d"),
+		vote(1, 1, cmtproto.PrevoteType, 0, "e"),
+	}
+	for i, v := range votes {
+		require.NoError(t, recorder.Record(v, p2p.ID("peer"), now.Add(time.Duration(i))))
+	}
+	// recording the same vote again is a no-op
+	require.NoError(t, recorder.Record(votes[0], p2p.ID("other"), now))
+
+	recorded, err := recorder.Votes(1)
+	require.NoError(t, err)
+	require.Len(t, recorded, len(votes))
+	// The conflicting votes come first, in no particular order.
+	require.ElementsMatch(t,
+		[][]byte{votes[2].Signature, votes[3].Signature},
+		[][]byte{recorded[0].Vote.Signature, recorded[1].Vote.Signature})
+	for i, expected := range []*types.Vote{votes[1], votes[0], votes[4]} {
+		require.Equal(t, expected.Signature, recorded[i+2].Vote.Signature)
+	}
+	require.Equal(t, p2p.ID("peer"), recorded[2].PeerID)
+	require.Equal(t, now.Add(1), recorded[2].ReceivedAt)
+
+	// Only the votes of the last two heights are kept.
+	require.NoError(t, recorder.Record(vote(2, 0, cmtproto.PrevoteType, 0, "f"), "", now))
+	recorded, err = recorder.Votes(1)
+	require.NoError(t, err)
+	require.Len(t, recorded, len(votes))
+
+	require.NoError(t, recorder.Record(vote(3, 0, cmtproto.PrevoteType, 0, "g"), "", now))
+	recorded, err = recorder.Votes(1)
+	require.NoError(t, err)
+	require.Empty(t, recorded)
+	for _, height := range []int64{2, 3} {
+		recorded, err = recorder.Votes(height)
+		require.NoError(t, err)
+		require.Len(t, recorded, 1)
+	}
+}
@@ -7,10 +7,14 @@ import (
 	"hash/crc32"
 	"io"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/cosmos/gogoproto/proto"
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
 
+	cfg "github.com/cometbft/cometbft/config"
 	auto "github.com/cometbft/cometbft/libs/autofile"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
@@ -27,8 +31,32 @@ const (
 
 	// how often the WAL should be sync'd during period sync'ing
 	walDefaultFlushInterval = 2 * time.Second
+
+	// Bound of the size of a compressed msg, the compressed data being larger
+	// than the msg in the worst case.
+	maxCompressedMsgSizeBytes = maxMsgSizeBytes + maxMsgSizeBytes/6 + 32
+
+	// The compression of an entry is stored in the most significant byte of
+	// its length, the length of a msg fitting in the 3 other bytes.
+	walLengthBits = 24
+	walLengthMask = 1<<walLengthBits - 1
+)
+
+// Compression of the WAL entries.
+const (
+	walCompressionNone byte = iota
+	walCompressionSnappy
+	walCompressionZstd
 )
 
+// zstdWALDecoder decodes the WAL entries compressed with zstd. It's safe for
+// concurrent use.
+var zstdWALDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+	return zstd.NewReader(nil,
+		zstd.WithDecoderConcurrency(1),
+		zstd.WithDecoderMaxMemory(maxMsgSizeBytes))
+})
+
 //--------------------------------------------------------
 // types and functions for savings consensus messages
 
@@ -113,6 +141,12 @@ func (wal *BaseWAL) SetFlushInterval(i time.Duration) {
 	wal.flushInterval = i
 }
 
+// SetCompression sets the compression of the entries written to the WAL. See
+// WALEncoder.SetCompression. Must be called before Start.
+func (wal *BaseWAL) SetCompression(compression string) error {
+	return wal.enc.SetCompression(compression)
+}
+
 func (wal *BaseWAL) Group() *auto.Group {
 	return wal.group
 }
@@ -287,13 +321,44 @@ func (wal *BaseWAL) SearchForEndHeight(
 // A WALEncoder writes custom-encoded WAL messages to an output stream.
 //
 // Format: 4 bytes CRC sum + 4 bytes length + arbitrary-length value
+//
+// The most significant byte of the length is the compression of the value,
+// which is 0 for uncompressed values. The CRC sum is computed over the stored,
+// possibly compressed, value.
 type WALEncoder struct {
 	wr io.Writer
+
+	compression byte
+	zstdEnc     *zstd.Encoder
 }
 
-// NewWALEncoder returns a new encoder that writes to wr.
+// NewWALEncoder returns a new encoder that writes to wr, without compression.
 func NewWALEncoder(wr io.Writer) *WALEncoder {
-	return &WALEncoder{wr}
+	return &WALEncoder{wr: wr}
+}
+
+// SetCompression sets the compression of the encoded messages: "none",
+// "snappy" or "zstd". The messages which do not shrink are written
+// uncompressed.
+func (enc *WALEncoder) SetCompression(compression string) error {
+	switch compression {
+	case cfg.WALCompressionNone, "":
+		enc.compression = walCompressionNone
+	case cfg.WALCompressionSnappy:
+		enc.compression = walCompressionSnappy
+	case cfg.WALCompressionZstd:
+		if enc.zstdEnc == nil {
+			zstdEnc, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+			if err != nil {
+				return err
+			}
+			enc.zstdEnc = zstdEnc
+		}
+		enc.compression = walCompressionZstd
+	default:
+		return fmt.Errorf("unknown WAL compression: %q", compression)
+	}
+	return nil
 }
 
 // Encode writes the custom encoding of v to the stream. It returns an error if
@@ -314,22 +379,41 @@ func (enc *WALEncoder) Encode(v *TimedWALMessage) error {
 		panic(fmt.Errorf("encode timed wall message failure: %w", err))
 	}
 
+	if len(data) > maxMsgSizeBytes {
+		return fmt.Errorf("msg is too big: %d bytes, max: %d bytes", len(data), maxMsgSizeBytes)
+	}
+
+	compression := walCompressionNone
+	if compressed := enc.compress(data); compressed != nil && len(compressed) < len(data) {
+		data, compression = compressed, enc.compression
+	}
+
 	crc := crc32.Checksum(data, crc32c)
 	length := uint32(len(data))
-	if length > maxMsgSizeBytes {
-		return fmt.Errorf("msg is too big: %d bytes, max: %d bytes", length, maxMsgSizeBytes)
-	}
 	totalLength := 8 + int(length)
 
 	msg := make([]byte, totalLength)
 	binary.BigEndian.PutUint32(msg[0:4], crc)
-	binary.BigEndian.PutUint32(msg[4:8], length)
+	binary.BigEndian.PutUint32(msg[4:8], uint32(compression)<<walLengthBits|length)
 	copy(msg[8:], data)
 
 	_, err = enc.wr.Write(msg)
 	return err
 }
 
+// compress returns data compressed with the compression of the encoder, or nil
+// if there is none.
+func (enc *WALEncoder) compress(data []byte) []byte {
+	switch enc.compression {
+	case walCompressionSnappy:
+		return snappy.Encode(nil, data)
+	case walCompressionZstd:
+		return enc.zstdEnc.EncodeAll(data, nil)
+	default:
+		return nil
+	}
+}
+
 // IsDataCorruptionError returns true if data has been corrupted inside WAL.
 func IsDataCorruptionError(err error) bool {
 	_, ok := err.(DataCorruptionError)
@@ -382,12 +466,18 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 		return nil, DataCorruptionError{fmt.Errorf("failed to read length: %v", err)}
 	}
 	length := binary.BigEndian.Uint32(b)
+	compression := byte(length >> walLengthBits)
+	length &= walLengthMask
 
-	if length > maxMsgSizeBytes {
+	maxLength := uint32(maxMsgSizeBytes)
+	if compression != walCompressionNone {
+		maxLength = maxCompressedMsgSizeBytes
+	}
+	if length > maxLength {
 		return nil, DataCorruptionError{fmt.Errorf(
 			"length %d exceeded maximum possible value of %d bytes",
 			length,
-			maxMsgSizeBytes)}
+			maxLength)}
 	}
 
 	data := make([]byte, length)
@@ -402,6 +492,11 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 		return nil, DataCorruptionError{fmt.Errorf("checksums do not match: read: %v, actual: %v", crc, actualCRC)}
 	}
 
+	data, err = decompressWALData(data, compression)
+	if err != nil {
+		return nil, DataCorruptionError{fmt.Errorf("failed to decompress data: %v", err)}
+	}
+
 	res := new(cmtcons.TimedWALMessage)
 	err = proto.Unmarshal(data, res)
 	if err != nil {
@@ -420,6 +515,31 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 	return tMsgWal, err
 }
 
+// decompressWALData returns data decompressed with the given compression.
+func decompressWALData(data []byte, compression byte) ([]byte, error) {
+	switch compression {
+	case walCompressionNone:
+		return data, nil
+	case walCompressionSnappy:
+		n, err := snappy.DecodedLen(data)
+		if err != nil {
+			return nil, err
+		}
+		if n > maxMsgSizeBytes {
+			return nil, fmt.Errorf("decompressed length %d exceeded maximum possible value of %d bytes", n, maxMsgSizeBytes)
+		}
+		return snappy.Decode(nil, data)
+	case walCompressionZstd:
+		dec, err := zstdWALDecoder()
+		if err != nil {
+			return nil, err
+		}
+		return dec.DecodeAll(data, nil)
+	default:
+		return nil, fmt.Errorf("unknown compression %d", compression)
+	}
+}
+
 type nilWAL struct{}
 
 var _ WAL = nilWAL{}
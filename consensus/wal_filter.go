@@ -0,0 +1,187 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cometbft/cometbft/types"
+)
+
+// Types of the WAL messages, as used to filter them.
+const (
+	WALMessageTypeRoundState = "round_state"
+	WALMessageTypeProposal   = "proposal"
+	WALMessageTypeBlockPart  = "block_part"
+	WALMessageTypeVote       = "vote"
+	WALMessageTypeTimeout    = "timeout"
+	WALMessageTypeEndHeight  = "end_height"
+	WALMessageTypeOther      = "other"
+)
+
+var walMessageTypes = []string{
+	WALMessageTypeRoundState,
+	WALMessageTypeProposal,
+	WALMessageTypeBlockPart,
+	WALMessageTypeVote,
+	WALMessageTypeTimeout,
+	WALMessageTypeEndHeight,
+	WALMessageTypeOther,
+}
+
+// WALMessageType returns the type of the given WAL message.
+func WALMessageType(msg WALMessage) string {
+	switch m := msg.(type) {
+	case types.EventDataRoundState:
+		return WALMessageTypeRoundState
+	case msgInfo:
+		switch m.Msg.(type) {
+		case *ProposalMessage:
+			return WALMessageTypeProposal
+		case *BlockPartMessage:
+			return WALMessageTypeBlockPart
+		case *VoteMessage:
+			return WALMessageTypeVote
+		}
+	case timeoutInfo:
+		return WALMessageTypeTimeout
+	case EndHeightMessage:
+		return WALMessageTypeEndHeight
+	}
+	return WALMessageTypeOther
+}
+
+// walMessageHeight returns the height of the given WAL message, and false if
+// it does not have one.
+func walMessageHeight(msg WALMessage) (int64, bool) {
+	switch m := msg.(type) {
+	case types.EventDataRoundState:
+		return m.Height, true
+	case msgInfo:
+		switch mi := m.Msg.(type) {
+		case *ProposalMessage:
+			return mi.Proposal.Height, true
+		case *BlockPartMessage:
+			return mi.Height, true
+		case *VoteMessage:
+			return mi.Vote.Height, true
+		}
+	case timeoutInfo:
+		return m.Height, true
+	case EndHeightMessage:
+		return m.Height, true
+	}
+	return 0, false
+}
+
+// WALFilter selects WAL messages by height and type.
+type WALFilter struct {
+	// Heights of the messages, inclusive. 0 means no bound.
+	FromHeight int64
+	ToHeight   int64
+	// Types of the messages (see WALMessageType). Empty means all types.
+	Types []string
+}
+
+// ParseWALMessageTypes parses a comma-separated list of WAL message types.
+func ParseWALMessageTypes(s string) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var res []string
+	for _, t := range strings.Split(s, ",") {
+		t = strings.TrimSpace(t)
+		known := false
+		for _, wt := range walMessageTypes {
+			known = known || t == wt
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown WAL message type %q, expected one of %s",
+				t, strings.Join(walMessageTypes, ", "))
+		}
+		res = append(res, t)
+	}
+	return res, nil
+}
+
+// ValidateBasic performs basic validation.
+func (f WALFilter) ValidateBasic() error {
+	if f.FromHeight < 0 || f.ToHeight < 0 {
+		return errors.New("negative height")
+	}
+	if f.ToHeight > 0 && f.FromHeight > f.ToHeight {
+		return fmt.Errorf("from height %d is greater than to height %d", f.FromHeight, f.ToHeight)
+	}
+	return nil
+}
+
+func (f WALFilter) matchType(msg WALMessage) bool {
+	if len(f.Types) == 0 {
+		return true
+	}
+	t := WALMessageType(msg)
+	for _, ft := range f.Types {
+		if t == ft {
+			return true
+		}
+	}
+	return false
+}
+
+// WALFilterDecoder decodes the WAL messages matching a filter.
+//
+// The messages without a height, such as the ones about the peers, are at the
+// height following the last EndHeightMessage decoded, if any, so they are only
+// filtered out by height once an EndHeightMessage was decoded.
+type WALFilterDecoder struct {
+	dec    *WALDecoder
+	filter WALFilter
+
+	// Height of the last EndHeightMessage decoded, -1 if none.
+	lastEndHeight int64
+}
+
+// NewWALFilterDecoder returns a decoder reading from rd the messages matching
+// the given filter.
+func NewWALFilterDecoder(rd io.Reader, filter WALFilter) *WALFilterDecoder {
+	return &WALFilterDecoder{
+		dec:           NewWALDecoder(rd),
+		filter:        filter,
+		lastEndHeight: -1,
+	}
+}
+
+// Decode returns the next message matching the filter, and its height (0 if
+// unknown), or io.EOF if there are no more.
+func (dec *WALFilterDecoder) Decode() (*TimedWALMessage, int64, error) {
+	for {
+		if dec.filter.ToHeight > 0 && dec.lastEndHeight >= dec.filter.ToHeight {
+			// All the following messages are at greater heights.
+			return nil, 0, io.EOF
+		}
+		msg, err := dec.dec.Decode()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		height, ok := walMessageHeight(msg.Msg)
+		if !ok && dec.lastEndHeight >= 0 {
+			height, ok = dec.lastEndHeight+1, true
+		}
+		if m, isEnd := msg.Msg.(EndHeightMessage); isEnd {
+			dec.lastEndHeight = m.Height
+		}
+
+		if ok {
+			if height < dec.filter.FromHeight ||
+				(dec.filter.ToHeight > 0 && height > dec.filter.ToHeight) {
+				continue
+			}
+		}
+		if !dec.filter.matchType(msg.Msg) {
+			continue
+		}
+		return msg, height, nil
+	}
+}
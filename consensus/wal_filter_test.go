@@ -0,0 +1,73 @@
+package consensus
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWALFilterDecoder(t *testing.T) {
+	walBody, err := WALWithNBlocks(t, 5, getConfig(t))
+	require.NoError(t, err)
+
+	decodeAll := func(filter WALFilter) []*TimedWALMessage {
+		dec := NewWALFilterDecoder(bytes.NewReader(walBody), filter)
+		var msgs []*TimedWALMessage
+		for {
+			msg, height, err := dec.Decode()
+			if err == io.EOF {
+				return msgs
+			}
+			require.NoError(t, err)
+			h, ok := walMessageHeight(msg.Msg)
+			if ok {
+				assert.Equal(t, h, height)
+			}
+			msgs = append(msgs, msg)
+		}
+	}
+
+	all := decodeAll(WALFilter{})
+	require.NotEmpty(t, all)
+
+	msgs := decodeAll(WALFilter{FromHeight: 2, ToHeight: 3, Types: []string{WALMessageTypeVote, WALMessageTypeEndHeight}})
+	require.NotEmpty(t, msgs)
+	var endHeights []int64
+	for _, msg := range msgs {
+		height, _ := walMessageHeight(msg.Msg)
+		assert.GreaterOrEqual(t, height, int64(2))
+		assert.LessOrEqual(t, height, int64(3))
+		switch m := msg.Msg.(type) {
+		case EndHeightMessage:
+			endHeights = append(endHeights, m.Height)
+		default:
+			assert.Equal(t, WALMessageTypeVote, WALMessageType(msg.Msg))
+		}
+	}
+	assert.Equal(t, []int64{2, 3}, endHeights)
+
+	// The heights are only bounded below. The WAL ends with height 4.
+	msgs = decodeAll(WALFilter{FromHeight: 4, Types: []string{WALMessageTypeEndHeight}})
+	require.Len(t, msgs, 1)
+	assert.Equal(t, EndHeightMessage{Height: 4}, msgs[0].Msg)
+}
+
+func TestParseWALMessageTypes(t *testing.T) {
+	types, err := ParseWALMessageTypes("")
+	require.NoError(t, err)
+	assert.Empty(t, types)
+
+	types, err = ParseWALMessageTypes("vote, proposal")
+	require.NoError(t, err)
+	assert.Equal(t, []string{WALMessageTypeVote, WALMessageTypeProposal}, types)
+
+	_, err = ParseWALMessageTypes("vote,unknown")
+	require.Error(t, err)
+
+	assert.Error(t, WALFilter{FromHeight: 3, ToHeight: 2}.ValidateBasic())
+	assert.Error(t, WALFilter{FromHeight: -1}.ValidateBasic())
+	assert.NoError(t, WALFilter{FromHeight: 3}.ValidateBasic())
+}
@@ -107,6 +107,54 @@ func TestWALEncoderDecoder(t *testing.T) {
 	}
 }
 
+func TestWALEncoderDecoderCompression(t *testing.T) {
+	now := cmttime.Now()
+	compressible := msgInfo{Msg: &BlockPartMessage{
+		Height: 1,
+		Round:  1,
+		Part: &cmttypes.Part{
+			Index: 1,
+			Bytes: make([]byte, 64*1024),
+			Proof: merkle.Proof{Total: 1, Index: 1, LeafHash: make([]byte, 32)},
+		},
+	}}
+	msgs := []TimedWALMessage{
+		{Time: now, Msg: EndHeightMessage{0}},
+		{Time: now, Msg: compressible},
+	}
+
+	for _, compression := range []string{"none", "snappy", "zstd"} {
+		t.Run(compression, func(t *testing.T) {
+			b := new(bytes.Buffer)
+			enc := NewWALEncoder(b)
+			require.NoError(t, enc.SetCompression(compression))
+
+			for _, msg := range msgs {
+				msg := msg
+
+				b.Reset()
+				require.NoError(t, enc.Encode(&msg))
+
+				// Only the compressible msg is compressed.
+				stored := b.Bytes()[4]
+				if _, ok := msg.Msg.(msgInfo); ok && compression != "none" {
+					assert.NotEqual(t, walCompressionNone, stored)
+					assert.Less(t, b.Len(), 64*1024)
+				} else {
+					assert.Equal(t, walCompressionNone, stored)
+				}
+
+				decoded, err := NewWALDecoder(b).Decode()
+				require.NoError(t, err)
+				assert.Equal(t, msg.Time.UTC(), decoded.Time)
+				assert.Equal(t, msg.Msg, decoded.Msg)
+			}
+		})
+	}
+
+	require.Error(t, NewWALEncoder(new(bytes.Buffer)).SetCompression("gzip"))
+}
+
 func TestWALWrite(t *testing.T) {
 	walDir, err := os.MkdirTemp("", "wal")
 	require.NoError(t, err)
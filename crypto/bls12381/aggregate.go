@@ -0,0 +1,55 @@
+package bls12381
+
+import (
+	"errors"
+	"fmt"
+
+	bls "github.com/cloudflare/circl/ecc/bls12381"
+
+	"github.com/cometbft/cometbft/crypto"
+)
+
+// AggregateSignatures aggregates the given signatures into a single signature,
+// which can be verified with VerifyAggregateSignature. An aggregated signature
+// can itself be aggregated with other signatures.
+func AggregateSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("bls12381: no signatures to aggregate")
+	}
+	agg := new(bls.G2)
+	agg.SetIdentity()
+	for i, sig := range sigs {
+		s, err := signatureFromBytes(sig)
+		if err != nil {
+			return nil, fmt.Errorf("signature #%d: %w", i, err)
+		}
+		agg.Add(agg, s)
+	}
+	return agg.BytesCompressed(), nil
+}
+
+// VerifyAggregateSignature verifies that the aggregated signature is the
+// aggregation of the signatures of the messages by the corresponding public
+// keys, all of which must be BLS12-381 keys.
+func VerifyAggregateSignature(pubKeys []crypto.PubKey, msgs [][]byte, sig []byte) bool {
+	if len(pubKeys) == 0 || len(pubKeys) != len(msgs) {
+		return false
+	}
+	s, err := signatureFromBytes(sig)
+	if err != nil {
+		return false
+	}
+	pks := make([]*bls.G1, len(pubKeys))
+	hashes := make([]*bls.G2, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		pk, ok := pubKey.(PubKey)
+		if !ok {
+			return false
+		}
+		if pks[i], err = pk.point(); err != nil {
+			return false
+		}
+		hashes[i] = hashToG2(pk, msgs[i])
+	}
+	return verifyPairing(pks, hashes, s)
+}
@@ -0,0 +1,87 @@
+package bls12381_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bls12381"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+)
+
+func TestSignAndValidateBls12381(t *testing.T) {
+	privKey := bls12381.GenPrivKey()
+	pubKey := privKey.PubKey()
+	require.Len(t, pubKey.Bytes(), bls12381.PubKeySize)
+
+	msg := crypto.CRandBytes(128)
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, bls12381.SignatureSize)
+
+	// Test the signature
+	assert.True(t, pubKey.VerifySignature(msg, sig))
+	assert.False(t, pubKey.VerifySignature(crypto.CRandBytes(128), sig))
+	assert.False(t, bls12381.GenPrivKey().PubKey().VerifySignature(msg, sig))
+
+	// Mutate the signature, just one bit.
+	sig[7] ^= byte(0x01)
+	assert.False(t, pubKey.VerifySignature(msg, sig))
+}
+
+func TestGenPrivKeyFromSecret(t *testing.T) {
+	privKey := bls12381.GenPrivKeyFromSecret([]byte("secret"))
+	assert.True(t, privKey.Equals(bls12381.GenPrivKeyFromSecret([]byte("secret"))))
+	assert.False(t, privKey.Equals(bls12381.GenPrivKeyFromSecret([]byte("other secret"))))
+}
+
+func TestAggregateSignatures(t *testing.T) {
+	const n = 10
+	pubKeys := make([]crypto.PubKey, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		privKey := bls12381.GenPrivKey()
+		pubKeys[i] = privKey.PubKey()
+		// Half of the validators sign the same message.
+		msgs[i] = []byte("message")
+		if i%2 == 0 {
+			msgs[i] = crypto.CRandBytes(32)
+		}
+		var err error
+		sigs[i], err = privKey.Sign(msgs[i])
+		require.NoError(t, err)
+	}
+
+	agg, err := bls12381.AggregateSignatures(sigs)
+	require.NoError(t, err)
+	require.Len(t, agg, bls12381.SignatureSize)
+	assert.True(t, bls12381.VerifyAggregateSignature(pubKeys, msgs, agg))
+
+	// Aggregating an aggregated signature with the other signatures.
+	partial, err := bls12381.AggregateSignatures(sigs[:n/2])
+	require.NoError(t, err)
+	agg2, err := bls12381.AggregateSignatures(append([][]byte{partial}, sigs[n/2:]...))
+	require.NoError(t, err)
+	assert.Equal(t, agg, agg2)
+
+	// Missing signature.
+	assert.False(t, bls12381.VerifyAggregateSignature(pubKeys, msgs, partial))
+	// Wrong message.
+	wrongMsgs := append([][]byte{}, msgs...)
+	wrongMsgs[3] = []byte("other message")
+	assert.False(t, bls12381.VerifyAggregateSignature(pubKeys, wrongMsgs, agg))
+	// Wrong key type.
+	wrongKeys := append([]crypto.PubKey{}, pubKeys...)
+	wrongKeys[0] = ed25519.GenPrivKey().PubKey()
+	assert.False(t, bls12381.VerifyAggregateSignature(wrongKeys, msgs, agg))
+	// Mismatched lengths.
+	assert.False(t, bls12381.VerifyAggregateSignature(pubKeys[1:], msgs, agg))
+
+	_, err = bls12381.AggregateSignatures(nil)
+	require.Error(t, err)
+	_, err = bls12381.AggregateSignatures([][]byte{[]byte("invalid")})
+	require.Error(t, err)
+}
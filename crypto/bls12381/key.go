@@ -0,0 +1,255 @@
+package bls12381
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+
+	bls "github.com/cloudflare/circl/ecc/bls12381"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+)
+
+var (
+	ErrInvalidPubKey    = errors.New("bls12381: invalid public key")
+	ErrInvalidSignature = errors.New("bls12381: invalid signature")
+)
+
+var _ crypto.PrivKey = PrivKey{}
+
+const (
+	PrivKeyName = "tendermint/PrivKeyBls12_381"
+	PubKeyName  = "tendermint/PubKeyBls12_381"
+	// PrivKeySize is the size, in bytes, of private keys as used in this
+	// package: a big-endian scalar.
+	PrivKeySize = bls.ScalarSize
+	// PubKeySize is the size, in bytes, of public keys as used in this
+	// package: a compressed point of G1.
+	PubKeySize = bls.G1SizeCompressed
+	// SignatureSize is the size, in bytes, of signatures as used in this
+	// package: a compressed point of G2.
+	SignatureSize = bls.G2SizeCompressed
+
+	KeyType = "bls12_381"
+)
+
+// Domain separation tag of the hash of the messages to G2, for the message
+// augmentation scheme of the IETF BLS signatures draft: the public key of the
+// signer is prepended to the message, so that the aggregated signatures of
+// identical messages are not subject to rogue key attacks, without requiring
+// proofs of possession.
+var dst = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG_")
+
+func init() {
+	cmtjson.RegisterType(PubKey{}, PubKeyName)
+	cmtjson.RegisterType(PrivKey{}, PrivKeyName)
+}
+
+// PrivKey implements crypto.PrivKey for the BLS signature scheme over the
+// BLS12-381 curve, with public keys in G1 and signatures in G2.
+type PrivKey []byte
+
+// Bytes returns the privkey byte format.
+func (privKey PrivKey) Bytes() []byte {
+	return []byte(privKey)
+}
+
+// Sign produces a signature on the provided message.
+func (privKey PrivKey) Sign(msg []byte) ([]byte, error) {
+	sk, err := privKey.scalar()
+	if err != nil {
+		return nil, err
+	}
+	pubKey := privKey.PubKey().Bytes()
+
+	sig := hashToG2(pubKey, msg)
+	sig.ScalarMult(sk, sig)
+	return sig.BytesCompressed(), nil
+}
+
+// PubKey gets the corresponding public key from the private key.
+//
+// Panics if the private key is not valid.
+func (privKey PrivKey) PubKey() crypto.PubKey {
+	sk, err := privKey.scalar()
+	if err != nil {
+		panic(err)
+	}
+	pk := new(bls.G1)
+	pk.ScalarMult(sk, bls.G1Generator())
+	return PubKey(pk.BytesCompressed())
+}
+
+// Equals - you probably don't need to use this.
+// Runs in constant time based on length of the keys.
+func (privKey PrivKey) Equals(other crypto.PrivKey) bool {
+	if otherBls, ok := other.(PrivKey); ok {
+		return subtle.ConstantTimeCompare(privKey[:], otherBls[:]) == 1
+	}
+
+	return false
+}
+
+func (privKey PrivKey) Type() string {
+	return KeyType
+}
+
+func (privKey PrivKey) scalar() (*bls.Scalar, error) {
+	if len(privKey) != PrivKeySize {
+		return nil, fmt.Errorf("bls12381: invalid private key length: got %d, want %d", len(privKey), PrivKeySize)
+	}
+	sk := new(bls.Scalar)
+	if err := sk.UnmarshalBinary(privKey); err != nil {
+		return nil, err
+	}
+	if sk.IsZero() == 1 {
+		return nil, errors.New("bls12381: private key is zero")
+	}
+	return sk, nil
+}
+
+// GenPrivKey generates a new BLS12-381 private key.
+// It uses OS randomness in conjunction with the current global random seed
+// in cometbft/libs/rand to generate the private key.
+func GenPrivKey() PrivKey {
+	return genPrivKey(crypto.CReader())
+}
+
+// genPrivKey generates a new BLS12-381 private key using the provided reader.
+func genPrivKey(rand io.Reader) PrivKey {
+	sk := new(bls.Scalar)
+	for sk.IsZero() == 1 {
+		if err := sk.Random(rand); err != nil {
+			panic(err)
+		}
+	}
+	bz, err := sk.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return PrivKey(bz)
+}
+
+// GenPrivKeyFromSecret hashes the secret with SHA2, and uses
+// that 32 byte output to create the private key.
+// NOTE: secret should be the output of a KDF like bcrypt,
+// if it's derived from user input.
+func GenPrivKeyFromSecret(secret []byte) PrivKey {
+	sk := new(bls.Scalar)
+	sk.SetBytes(crypto.Sha256(secret)) // Reduced modulo the order of the group.
+	if sk.IsZero() == 1 {
+		panic("bls12381: private key derived from the secret is zero")
+	}
+	bz, err := sk.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return PrivKey(bz)
+}
+
+//-------------------------------------
+
+var _ crypto.PubKey = PubKey{}
+
+// PubKey implements crypto.PubKey for the BLS signature scheme over the
+// BLS12-381 curve.
+type PubKey []byte
+
+// Address is the SHA256-20 of the raw pubkey bytes.
+func (pubKey PubKey) Address() crypto.Address {
+	if len(pubKey) != PubKeySize {
+		panic("pubkey is incorrect size")
+	}
+	return crypto.Address(tmhash.SumTruncated(pubKey))
+}
+
+// Bytes returns the PubKey byte format.
+func (pubKey PubKey) Bytes() []byte {
+	return []byte(pubKey)
+}
+
+func (pubKey PubKey) VerifySignature(msg []byte, sig []byte) bool {
+	pk, err := pubKey.point()
+	if err != nil {
+		return false
+	}
+	s, err := signatureFromBytes(sig)
+	if err != nil {
+		return false
+	}
+	return verifyPairing([]*bls.G1{pk}, []*bls.G2{hashToG2(pubKey, msg)}, s)
+}
+
+func (pubKey PubKey) String() string {
+	return fmt.Sprintf("PubKeyBls12_381{%X}", []byte(pubKey))
+}
+
+func (pubKey PubKey) Type() string {
+	return KeyType
+}
+
+func (pubKey PubKey) Equals(other crypto.PubKey) bool {
+	if otherBls, ok := other.(PubKey); ok {
+		return bytes.Equal(pubKey[:], otherBls[:])
+	}
+
+	return false
+}
+
+// point decodes the public key, which must be a point of G1 other than the
+// identity.
+func (pubKey PubKey) point() (*bls.G1, error) {
+	if len(pubKey) != PubKeySize {
+		return nil, ErrInvalidPubKey
+	}
+	pk := new(bls.G1)
+	if err := pk.SetBytes(pubKey); err != nil || pk.IsIdentity() {
+		return nil, ErrInvalidPubKey
+	}
+	return pk, nil
+}
+
+//-------------------------------------
+
+// hashToG2 hashes the message augmented with the public key of its signer to
+// a point of G2.
+func hashToG2(pubKey, msg []byte) *bls.G2 {
+	input := make([]byte, 0, len(pubKey)+len(msg))
+	input = append(input, pubKey...)
+	input = append(input, msg...)
+	h := new(bls.G2)
+	h.Hash(input, dst)
+	return h
+}
+
+// signatureFromBytes decodes a signature, which must be a point of G2.
+func signatureFromBytes(sig []byte) (*bls.G2, error) {
+	if len(sig) != SignatureSize {
+		return nil, ErrInvalidSignature
+	}
+	s := new(bls.G2)
+	if err := s.SetBytes(sig); err != nil {
+		return nil, ErrInvalidSignature
+	}
+	return s, nil
+}
+
+// verifyPairing checks that the product of the pairings of the public keys
+// and the hashes of their messages equals the pairing of the generator of G1
+// and the signature.
+func verifyPairing(pks []*bls.G1, hashes []*bls.G2, sig *bls.G2) bool {
+	points := append(make([]*bls.G1, 0, len(pks)+1), pks...)
+	points = append(points, bls.G1Generator())
+	qs := append(make([]*bls.G2, 0, len(hashes)+1), hashes...)
+	qs = append(qs, sig)
+	signs := make([]int, len(points))
+	for i := range signs {
+		signs[i] = 1
+	}
+	signs[len(signs)-1] = -1
+	return bls.ProdPairFrac(points, qs, signs).IsIdentity()
+}
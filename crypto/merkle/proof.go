@@ -56,6 +56,14 @@ type Proof struct {
 	Aunts    [][]byte `json:"aunts"`     // Hashes from leaf's sibling to a root's child.
 }
 
+// ProofSibling is a hash hashed with the hash of the proven item, or of one of
+// its ancestors, to compute the root hash.
+type ProofSibling struct {
+	Hash []byte `json:"hash"`
+	// Left is true if the sibling is on the left, i.e. hashed first.
+	Left bool `json:"left"`
+}
+
 // ProofsFromByteSlices computes inclusion proof for given items.
 // proofs[0] is the proof for items[0].
 func ProofsFromByteSlices(items [][]byte) (rootHash []byte, proofs []*Proof) {
@@ -111,6 +119,43 @@ func (sp *Proof) Verify(rootHash []byte, leaf []byte) error {
 	return nil
 }
 
+// Siblings returns the aunts of the proof with their sides, from the leaf's
+// sibling to a root's child, for the verifiers not implementing the split of
+// the tree of CometBFT: the root hash is the leaf hash hashed in turn with
+// each sibling, as by innerHash. It returns nil if the proof is malformed.
+func (sp *Proof) Siblings() []ProofSibling {
+	if sp.Index < 0 || sp.Index >= sp.Total {
+		return nil
+	}
+	return siblingsFromAunts(sp.Index, sp.Total, sp.Aunts)
+}
+
+// siblingsFromAunts follows the split of the tree as computeHashFromAunts.
+func siblingsFromAunts(index, total int64, innerHashes [][]byte) []ProofSibling {
+	if total == 1 {
+		if len(innerHashes) != 0 {
+			return nil
+		}
+		return []ProofSibling{}
+	}
+	if len(innerHashes) == 0 {
+		return nil
+	}
+	numLeft := getSplitPoint(total)
+	top := ProofSibling{Hash: innerHashes[len(innerHashes)-1]}
+	var siblings []ProofSibling
+	if index < numLeft {
+		siblings = siblingsFromAunts(index, numLeft, innerHashes[:len(innerHashes)-1])
+	} else {
+		top.Left = true
+		siblings = siblingsFromAunts(index-numLeft, total-numLeft, innerHashes[:len(innerHashes)-1])
+	}
+	if siblings == nil {
+		return nil
+	}
+	return append(siblings, top)
+}
+
 // Compute the root hash given a leaf hash.
 func (sp *Proof) computeRootHash() ([]byte, error) {
 	return computeHashFromAunts(
@@ -0,0 +1,96 @@
+package merkle
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+)
+
+const ProofOpLeaf = "simple:leaf"
+
+// LeafOp takes a single item of a list as argument and produces the root
+// hash of the tree of the list, as computed by HashFromByteSlices, e.g. the
+// hash of the txs of a block. Its key is the decimal index of the item, e.g.
+// the key path "/3" for the fourth item.
+//
+// If the produced root hash matches the expected hash, the proof is good.
+type LeafOp struct {
+	// To encode in ProofOp.Data
+	Proof *Proof `json:"proof"`
+}
+
+var _ ProofOperator = LeafOp{}
+
+func NewLeafOp(proof *Proof) LeafOp {
+	return LeafOp{
+		Proof: proof,
+	}
+}
+
+func LeafOpDecoder(pop cmtcrypto.ProofOp) (ProofOperator, error) {
+	if pop.Type != ProofOpLeaf {
+		return nil, ErrInvalidProof{
+			Err: fmt.Errorf("unexpected ProofOp.Type; got %v, want %v", pop.Type, ProofOpLeaf),
+		}
+	}
+	var pbproof cmtcrypto.Proof
+	err := pbproof.Unmarshal(pop.Data)
+	if err != nil {
+		return nil, ErrInvalidProof{
+			Err: fmt.Errorf("decoding ProofOp.Data into Proof: %w", err),
+		}
+	}
+
+	sp, err := ProofFromProto(&pbproof)
+	if err != nil {
+		return nil, err
+	}
+	op := NewLeafOp(sp)
+	if !bytes.Equal(pop.Key, op.GetKey()) {
+		return nil, ErrInvalidKey{
+			Err: fmt.Errorf("key %q does not match the index %d of the proof", pop.Key, sp.Index),
+		}
+	}
+	return op, nil
+}
+
+func (op LeafOp) ProofOp() cmtcrypto.ProofOp {
+	bz, err := op.Proof.ToProto().Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return cmtcrypto.ProofOp{
+		Type: ProofOpLeaf,
+		Key:  op.GetKey(),
+		Data: bz,
+	}
+}
+
+func (op LeafOp) String() string {
+	return fmt.Sprintf("LeafOp{%d/%d}", op.Proof.Index, op.Proof.Total)
+}
+
+func (op LeafOp) Run(args [][]byte) ([][]byte, error) {
+	if len(args) != 1 {
+		return nil, ErrTooManyArgs
+	}
+	if lhash := leafHash(args[0]); !bytes.Equal(lhash, op.Proof.LeafHash) {
+		return nil, ErrInvalidHash{
+			Err: fmt.Errorf("leaf %x, want %x", lhash, op.Proof.LeafHash),
+		}
+	}
+
+	rootHash, err := op.Proof.computeRootHash()
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{
+		rootHash,
+	}, nil
+}
+
+func (op LeafOp) GetKey() []byte {
+	return []byte(strconv.FormatInt(op.Proof.Index, 10))
+}
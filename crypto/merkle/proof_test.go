@@ -208,6 +208,51 @@ func TestVoteProtobuf(t *testing.T) {
 	}
 }
 
+func TestLeafOp(t *testing.T) {
+	items := [][]byte{[]byte("apple"), []byte("watermelon"), []byte("kiwi"), []byte("melon"), []byte("pear")}
+	root, proofs := ProofsFromByteSlices(items)
+	prt := DefaultProofRuntime()
+
+	for i, proof := range proofs {
+		ops := &cmtcrypto.ProofOps{Ops: []cmtcrypto.ProofOp{NewLeafOp(proof).ProofOp()}}
+		keyPath := fmt.Sprintf("/%d", i)
+		require.NoError(t, prt.VerifyValue(ops, root, keyPath, items[i]), i)
+		require.Error(t, prt.VerifyValue(ops, root, keyPath, []byte("banana")), i)
+		require.Error(t, prt.VerifyValue(ops, tmhash.Sum(nil), keyPath, items[i]), i)
+		require.Error(t, prt.VerifyValue(ops, root, fmt.Sprintf("/%d", i+1), items[i]), i)
+	}
+}
+
+func TestProofSiblings(t *testing.T) {
+	for total := 1; total <= 9; total++ {
+		items := make([][]byte, total)
+		for i := range items {
+			items[i] = []byte{byte(i)}
+		}
+		root, proofs := ProofsFromByteSlices(items)
+		for i, proof := range proofs {
+			siblings := proof.Siblings()
+			require.Len(t, siblings, len(proof.Aunts), "%d/%d", i, total)
+			// The root is computed without the split of the tree.
+			hash := proof.LeafHash
+			for _, sibling := range siblings {
+				if sibling.Left {
+					hash = innerHash(sibling.Hash, hash)
+				} else {
+					hash = innerHash(hash, sibling.Hash)
+				}
+			}
+			require.Equal(t, root, hash, "%d/%d", i, total)
+		}
+	}
+
+	_, proofs := ProofsFromByteSlices([][]byte{[]byte("apple"), []byte("kiwi")})
+	proofs[0].Aunts = nil
+	assert.Nil(t, proofs[0].Siblings())
+	proofs[1].Index = 2
+	assert.Nil(t, proofs[1].Siblings())
+}
+
 // TestVsa2022_100 verifies https://blog.verichains.io/p/vsa-2022-100-tendermint-forging-membership-proof
 func TestVsa2022_100(t *testing.T) {
 	// a fake key-value pair and its hash
@@ -0,0 +1,53 @@
+package threshold
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/cometbft/cometbft/libs/tempfile"
+)
+
+// KeyFile is the content of the key file of a holder: the public key, and
+// its key share.
+type KeyFile struct {
+	PublicKey *PublicKey `json:"public_key"`
+	Share     *KeyShare  `json:"share"`
+}
+
+// ValidateBasic checks that the public key and the key share are well
+// formed, and match.
+func (kf *KeyFile) ValidateBasic() error {
+	if kf.PublicKey == nil || kf.Share == nil {
+		return fmt.Errorf("threshold: key file without public key or key share")
+	}
+	if err := kf.PublicKey.ValidateBasic(); err != nil {
+		return err
+	}
+	return kf.Share.ValidateBasic(kf.PublicKey)
+}
+
+// Save writes the key file to the path, readable by its owner only.
+func (kf *KeyFile) Save(path string) error {
+	bz, err := json.MarshalIndent(kf, "", "  ")
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(path, bz, 0o600)
+}
+
+// LoadKeyFile reads and validates the key file at the path.
+func LoadKeyFile(path string) (*KeyFile, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	kf := new(KeyFile)
+	if err := json.Unmarshal(bz, kf); err != nil {
+		return nil, fmt.Errorf("threshold: failed to parse key file %v: %w", path, err)
+	}
+	if err := kf.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid key file %v: %w", path, err)
+	}
+	return kf, nil
+}
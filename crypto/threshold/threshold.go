@@ -0,0 +1,506 @@
+// Package threshold implements a (t, n) threshold encryption scheme: anyone
+// can encrypt a message to the public key, but decrypting it requires the
+// decryption shares of t of the n holders of a key share.
+//
+// The scheme is TDH2 of Shoup and Gennaro [1] over ristretto255, as a hashed
+// ElGamal key encapsulation whose secret key is Shamir-shared [2] by a
+// trusted dealer, and whose key seals the message with ChaCha20-Poly1305
+// rather than by a one-time pad. A ciphertext carries the ephemeral elements
+// U = r·G and Ū = r·Ḡ, Ḡ being a second generator of unknown discrete
+// logarithm, the proof of their equal discrete logarithms bound to the sealed
+// message and the label, and the message sealed under a key derived from r·P,
+// P being the public key. The proof makes the ciphertexts non-malleable: the
+// holders only compute decryption shares for valid ciphertexts, so the
+// ephemeral element of a ciphertext cannot be reused under another label to
+// get it decrypted early. A decryption share of the holder of the key share
+// s_i is s_i·U, with a Chaum-Pedersen proof [3] of the equality of the
+// discrete logarithms of s_i·G and s_i·U, so invalid shares are detected
+// before they are combined. Both proofs are made non-interactive with the
+// Fiat-Shamir transform.
+//
+// [1]: V. Shoup and R. Gennaro, "Securing Threshold Cryptosystems against
+// Chosen Ciphertext Attack", Journal of Cryptology 15(2), 2002.
+// [2]: A. Shamir, "How to Share a Secret", Communications of the ACM 22(11),
+// 1979.
+// [3]: D. Chaum and T. P. Pedersen, "Wallet Databases with Observers",
+// CRYPTO '92.
+package threshold
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	// ElementSize is the size of an encoded group element.
+	ElementSize = curve.CompressedPointSize
+	// ScalarSize is the size of an encoded scalar.
+	ScalarSize = scalar.ScalarSize
+	// proofSize is the size of an encoded DLEQ proof: a challenge and a
+	// response scalar.
+	proofSize = 2 * ScalarSize
+	// DecryptionShareSize is the size of an encoded decryption share: the
+	// index of the key share, the share and its proof.
+	DecryptionShareSize = 4 + ElementSize + proofSize
+	// ciphertextHeaderSize is the size of the ephemeral elements U and Ū of a
+	// ciphertext and of the proof of its validity.
+	ciphertextHeaderSize = 2*ElementSize + proofSize
+	// Overhead is the size of a ciphertext minus the size of its message.
+	Overhead = ciphertextHeaderSize + chacha20poly1305.Overhead
+)
+
+var (
+	proofDomain      = []byte("cometbft-threshold-decryption-share")
+	ciphertextDomain = []byte("cometbft-threshold-ciphertext")
+	kdfDomain        = []byte("cometbft-threshold-encryption-key")
+	generatorDomain  = []byte("cometbft-threshold-second-generator")
+)
+
+// secondGenerator is the generator Ḡ of the ciphertexts, hashed to the group
+// so that its discrete logarithm is unknown.
+var secondGenerator = func() *curve.RistrettoPoint {
+	h := sha512.Sum512(generatorDomain)
+	p, err := curve.NewRistrettoPoint().SetUniformBytes(h[:])
+	if err != nil {
+		panic(err)
+	}
+	return p
+}()
+
+var (
+	// ErrInvalidCiphertext is returned for ciphertexts that cannot be
+	// decrypted.
+	ErrInvalidCiphertext = errors.New("threshold: invalid ciphertext")
+	// ErrInvalidShare is returned for decryption shares that are malformed or
+	// whose proof does not verify.
+	ErrInvalidShare = errors.New("threshold: invalid decryption share")
+	// ErrNotEnoughShares is returned when combining fewer valid decryption
+	// shares than the threshold.
+	ErrNotEnoughShares = errors.New("threshold: not enough decryption shares")
+)
+
+// PublicKey is the public key of the scheme, with the public verification
+// keys of the key shares, used to verify the decryption shares.
+type PublicKey struct {
+	// Threshold is the number of decryption shares needed to decrypt.
+	Threshold int `json:"threshold"`
+	// Key is the encoded public key.
+	Key []byte `json:"key"`
+	// Shares are the encoded verification keys of the key shares, the one of
+	// the key share of index i at position i-1.
+	Shares [][]byte `json:"shares"`
+}
+
+// KeyShare is the secret key share of one of the holders.
+type KeyShare struct {
+	// Index is the index of the share, starting at 1.
+	Index uint32 `json:"index"`
+	// Secret is the encoded secret scalar.
+	Secret []byte `json:"secret"`
+}
+
+// DecryptionShare is the share of a holder for the decryption of a
+// ciphertext, with the proof that it was computed with the key share of its
+// index.
+type DecryptionShare struct {
+	Index uint32
+	share *curve.RistrettoPoint
+	// challenge and response of the proof of the equality of the discrete
+	// logarithms of the verification key and the share.
+	challenge, response *scalar.Scalar
+}
+
+// GenerateKeys generates a public key and n key shares, t of which are needed
+// to decrypt. The dealer must erase the shares once distributed.
+func GenerateKeys(n, t int) (*PublicKey, []*KeyShare, error) {
+	if t < 1 || n < t {
+		return nil, nil, fmt.Errorf("threshold: invalid threshold %d of %d shares", t, n)
+	}
+	if n > 1<<16 {
+		return nil, nil, fmt.Errorf("threshold: too many shares (%d)", n)
+	}
+	// The secret is the constant term of a random polynomial of degree t-1,
+	// and share i is its value at i.
+	coeffs := make([]*scalar.Scalar, t)
+	for i := range coeffs {
+		c, err := randomScalar()
+		if err != nil {
+			return nil, nil, err
+		}
+		coeffs[i] = c
+	}
+	pk := &PublicKey{Threshold: t, Key: encodeElement(mulBase(coeffs[0])), Shares: make([][]byte, n)}
+	shares := make([]*KeyShare, n)
+	for i := 1; i <= n; i++ {
+		x := scalar.NewFromUint64(uint64(i))
+		s := scalar.New()
+		for j := t - 1; j >= 0; j-- {
+			s.Mul(s, x)
+			s.Add(s, coeffs[j])
+		}
+		pk.Shares[i-1] = encodeElement(mulBase(s))
+		shares[i-1] = &KeyShare{Index: uint32(i), Secret: encodeScalar(s)}
+	}
+	return pk, shares, nil
+}
+
+// ValidateBasic checks that the public key is well formed.
+func (pk *PublicKey) ValidateBasic() error {
+	if pk.Threshold < 1 || len(pk.Shares) < pk.Threshold {
+		return fmt.Errorf("threshold: invalid threshold %d of %d shares", pk.Threshold, len(pk.Shares))
+	}
+	if _, err := decodeElement(pk.Key); err != nil {
+		return fmt.Errorf("threshold: invalid public key: %w", err)
+	}
+	for i, share := range pk.Shares {
+		if _, err := decodeElement(share); err != nil {
+			return fmt.Errorf("threshold: invalid verification key %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// ValidateBasic checks that the key share is well formed, and is the one of
+// the verification key of its index in the public key.
+func (ks *KeyShare) ValidateBasic(pk *PublicKey) error {
+	s, verificationKey, err := ks.decode(pk)
+	if err != nil {
+		return err
+	}
+	if mulBase(s).Equal(verificationKey) != 1 {
+		return errors.New("threshold: key share does not match the public key")
+	}
+	return nil
+}
+
+func (ks *KeyShare) decode(pk *PublicKey) (*scalar.Scalar, *curve.RistrettoPoint, error) {
+	if ks.Index < 1 || int(ks.Index) > len(pk.Shares) {
+		return nil, nil, fmt.Errorf("threshold: invalid key share index %d", ks.Index)
+	}
+	s, err := decodeScalar(ks.Secret)
+	if err != nil {
+		return nil, nil, fmt.Errorf("threshold: invalid key share: %w", err)
+	}
+	verificationKey, err := decodeElement(pk.Shares[ks.Index-1])
+	if err != nil {
+		return nil, nil, fmt.Errorf("threshold: invalid verification key %d: %w", ks.Index, err)
+	}
+	return s, verificationKey, nil
+}
+
+// Encrypt encrypts the message to the public key. The label is authenticated
+// but not encrypted: decrypting the ciphertext, or computing decryption shares
+// for it, requires the same label.
+func Encrypt(pk *PublicKey, msg, label []byte) ([]byte, error) {
+	key, err := decodeElement(pk.Key)
+	if err != nil {
+		return nil, fmt.Errorf("threshold: invalid public key: %w", err)
+	}
+	r, err := randomScalar()
+	if err != nil {
+		return nil, err
+	}
+	u, uBar := mulBase(r), curve.NewRistrettoPoint().Mul(secondGenerator, r)
+	aead, err := newAEAD(curve.NewRistrettoPoint().Mul(key, r))
+	if err != nil {
+		return nil, err
+	}
+	// The key is only used once, so the nonce can be constant.
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	sealed := aead.Seal(nil, nonce, msg, additionalData(encodeElement(u), label))
+
+	// Proof that log_G(U) = log_Ḡ(Ū), bound to the sealed message and the
+	// label.
+	k, err := randomScalar()
+	if err != nil {
+		return nil, err
+	}
+	challenge := ciphertextChallenge(sealed, label, u, mulBase(k), uBar, curve.NewRistrettoPoint().Mul(secondGenerator, k))
+	response := scalar.New().Mul(challenge, r)
+	response.Add(response, k)
+
+	ciphertext := make([]byte, 0, ciphertextHeaderSize+len(sealed))
+	ciphertext = append(ciphertext, encodeElement(u)...)
+	ciphertext = append(ciphertext, encodeElement(uBar)...)
+	ciphertext = append(ciphertext, encodeScalar(challenge)...)
+	ciphertext = append(ciphertext, encodeScalar(response)...)
+	return append(ciphertext, sealed...), nil
+}
+
+// NewDecryptionShare returns the decryption share of the holder of the key
+// share for the ciphertext, which must be valid for the label.
+func NewDecryptionShare(pk *PublicKey, ks *KeyShare, ciphertext, label []byte) (*DecryptionShare, error) {
+	s, verificationKey, err := ks.decode(pk)
+	if err != nil {
+		return nil, err
+	}
+	u, err := verifyCiphertext(ciphertext, label)
+	if err != nil {
+		return nil, err
+	}
+	share := curve.NewRistrettoPoint().Mul(u, s)
+
+	// Chaum-Pedersen proof that log_G(verificationKey) = log_U(share).
+	k, err := randomScalar()
+	if err != nil {
+		return nil, err
+	}
+	challenge := proofChallenge(verificationKey, u, share, mulBase(k), curve.NewRistrettoPoint().Mul(u, k))
+	response := scalar.New().Mul(challenge, s)
+	response.Add(response, k)
+	return &DecryptionShare{Index: ks.Index, share: share, challenge: challenge, response: response}, nil
+}
+
+// Verify checks that the decryption share is the one of the key share of its
+// index for the ciphertext.
+func (ds *DecryptionShare) Verify(pk *PublicKey, ciphertext []byte) error {
+	if ds.Index < 1 || int(ds.Index) > len(pk.Shares) {
+		return ErrInvalidShare
+	}
+	u, err := ciphertextElement(ciphertext)
+	if err != nil {
+		return err
+	}
+	verificationKey, err := decodeElement(pk.Shares[ds.Index-1])
+	if err != nil {
+		return err
+	}
+	// The commitments of the prover are recovered from the response.
+	negChallenge := scalar.New().Neg(ds.challenge)
+	r1 := curve.NewRistrettoPoint().DoubleScalarMulBasepointVartime(negChallenge, verificationKey, ds.response)
+	r2 := curve.NewRistrettoPoint().MultiscalarMulVartime(
+		[]*scalar.Scalar{ds.response, negChallenge}, []*curve.RistrettoPoint{u, ds.share})
+	if proofChallenge(verificationKey, u, ds.share, r1, r2).Equal(ds.challenge) != 1 {
+		return ErrInvalidShare
+	}
+	return nil
+}
+
+// Bytes returns the encoding of the decryption share, of DecryptionShareSize
+// bytes.
+func (ds *DecryptionShare) Bytes() []byte {
+	bz := make([]byte, 4, DecryptionShareSize)
+	binary.BigEndian.PutUint32(bz, ds.Index)
+	bz = append(bz, encodeElement(ds.share)...)
+	bz = append(bz, encodeScalar(ds.challenge)...)
+	return append(bz, encodeScalar(ds.response)...)
+}
+
+// DecryptionShareFromBytes decodes a decryption share encoded by Bytes. The
+// share is not verified.
+func DecryptionShareFromBytes(bz []byte) (*DecryptionShare, error) {
+	if len(bz) != DecryptionShareSize {
+		return nil, ErrInvalidShare
+	}
+	share, err := decodeElement(bz[4 : 4+ElementSize])
+	if err != nil {
+		return nil, ErrInvalidShare
+	}
+	challenge, err := decodeScalar(bz[4+ElementSize : 4+ElementSize+ScalarSize])
+	if err != nil {
+		return nil, ErrInvalidShare
+	}
+	response, err := decodeScalar(bz[4+ElementSize+ScalarSize:])
+	if err != nil {
+		return nil, ErrInvalidShare
+	}
+	return &DecryptionShare{
+		Index:     binary.BigEndian.Uint32(bz),
+		share:     share,
+		challenge: challenge,
+		response:  response,
+	}, nil
+}
+
+// Decrypt combines the decryption shares of the ciphertext, and decrypts it.
+// The shares that do not verify are ignored, so the decryption only fails if
+// the ciphertext is not valid for the label, or if there are fewer valid
+// shares of distinct holders than the threshold.
+func Decrypt(pk *PublicKey, ciphertext, label []byte, shares []*DecryptionShare) ([]byte, error) {
+	if _, err := verifyCiphertext(ciphertext, label); err != nil {
+		return nil, err
+	}
+	valid := make([]*DecryptionShare, 0, pk.Threshold)
+	seen := make(map[uint32]bool, pk.Threshold)
+	for _, ds := range shares {
+		if len(valid) == pk.Threshold {
+			break
+		}
+		if seen[ds.Index] || ds.Verify(pk, ciphertext) != nil {
+			continue
+		}
+		seen[ds.Index] = true
+		valid = append(valid, ds)
+	}
+	if len(valid) < pk.Threshold {
+		return nil, fmt.Errorf("%w: %d valid of %d needed", ErrNotEnoughShares, len(valid), pk.Threshold)
+	}
+
+	// s·U is interpolated at 0 from the shares s_i·U, with the Lagrange
+	// coefficients of their indexes.
+	coeffs := make([]*scalar.Scalar, len(valid))
+	points := make([]*curve.RistrettoPoint, len(valid))
+	for i, ds := range valid {
+		xi := scalar.NewFromUint64(uint64(ds.Index))
+		num, den := scalar.One(), scalar.One()
+		for j, other := range valid {
+			if i == j {
+				continue
+			}
+			xj := scalar.NewFromUint64(uint64(other.Index))
+			num.Mul(num, xj)
+			den.Mul(den, scalar.New().Sub(xj, xi))
+		}
+		coeffs[i] = num.Mul(num, scalar.New().Invert(den))
+		points[i] = ds.share
+	}
+	aead, err := newAEAD(curve.NewRistrettoPoint().MultiscalarMulVartime(coeffs, points))
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	u := ciphertext[:ElementSize]
+	msg, err := aead.Open(nil, nonce, ciphertext[ciphertextHeaderSize:], additionalData(u, label))
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	return msg, nil
+}
+
+func ciphertextElement(ciphertext []byte) (*curve.RistrettoPoint, error) {
+	if len(ciphertext) < Overhead {
+		return nil, ErrInvalidCiphertext
+	}
+	u, err := decodeElement(ciphertext[:ElementSize])
+	if err != nil || u.IsIdentity() {
+		return nil, ErrInvalidCiphertext
+	}
+	return u, nil
+}
+
+// verifyCiphertext checks the proof of validity of the ciphertext for the
+// label, and returns its ephemeral element U.
+func verifyCiphertext(ciphertext, label []byte) (*curve.RistrettoPoint, error) {
+	u, err := ciphertextElement(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	uBar, err := decodeElement(ciphertext[ElementSize : 2*ElementSize])
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	challenge, err := decodeScalar(ciphertext[2*ElementSize : 2*ElementSize+ScalarSize])
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	response, err := decodeScalar(ciphertext[2*ElementSize+ScalarSize : ciphertextHeaderSize])
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	// The commitments of the prover are recovered from the response.
+	negChallenge := scalar.New().Neg(challenge)
+	w := curve.NewRistrettoPoint().DoubleScalarMulBasepointVartime(negChallenge, u, response)
+	wBar := curve.NewRistrettoPoint().MultiscalarMulVartime(
+		[]*scalar.Scalar{response, negChallenge}, []*curve.RistrettoPoint{secondGenerator, uBar})
+	if ciphertextChallenge(ciphertext[ciphertextHeaderSize:], label, u, w, uBar, wBar).Equal(challenge) != 1 {
+		return nil, ErrInvalidCiphertext
+	}
+	return u, nil
+}
+
+func ciphertextChallenge(sealed, label []byte, u, w, uBar, wBar *curve.RistrettoPoint) *scalar.Scalar {
+	h := sha512.New()
+	h.Write(ciphertextDomain)
+	// The lengths delimit the sealed message and the label.
+	var lengths [16]byte
+	binary.BigEndian.PutUint64(lengths[:8], uint64(len(sealed)))
+	binary.BigEndian.PutUint64(lengths[8:], uint64(len(label)))
+	h.Write(lengths[:])
+	h.Write(sealed)
+	h.Write(label)
+	for _, p := range []*curve.RistrettoPoint{u, w, uBar, wBar} {
+		h.Write(encodeElement(p))
+	}
+	c, err := scalar.NewFromBytesModOrderWide(h.Sum(nil))
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func proofChallenge(verificationKey, u, share, r1, r2 *curve.RistrettoPoint) *scalar.Scalar {
+	h := sha512.New()
+	h.Write(proofDomain)
+	for _, p := range []*curve.RistrettoPoint{verificationKey, u, share, r1, r2} {
+		h.Write(encodeElement(p))
+	}
+	c, err := scalar.NewFromBytesModOrderWide(h.Sum(nil))
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func newAEAD(shared *curve.RistrettoPoint) (cipher.AEAD, error) {
+	h := sha256.New()
+	h.Write(kdfDomain)
+	h.Write(encodeElement(shared))
+	return chacha20poly1305.New(h.Sum(nil))
+}
+
+func additionalData(u, label []byte) []byte {
+	ad := make([]byte, 0, len(u)+len(label))
+	ad = append(ad, u...)
+	return append(ad, label...)
+}
+
+func randomScalar() (*scalar.Scalar, error) {
+	return scalar.New().SetRandom(rand.Reader)
+}
+
+func mulBase(s *scalar.Scalar) *curve.RistrettoPoint {
+	return curve.NewRistrettoPoint().MulBasepoint(curve.RISTRETTO_BASEPOINT_TABLE, s)
+}
+
+func encodeElement(p *curve.RistrettoPoint) []byte {
+	bz, err := p.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func encodeScalar(s *scalar.Scalar) []byte {
+	bz, err := s.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func decodeElement(bz []byte) (*curve.RistrettoPoint, error) {
+	if len(bz) != ElementSize {
+		return nil, fmt.Errorf("invalid element size %d", len(bz))
+	}
+	p := curve.NewRistrettoPoint()
+	if err := p.UnmarshalBinary(bz); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func decodeScalar(bz []byte) (*scalar.Scalar, error) {
+	if len(bz) != ScalarSize {
+		return nil, fmt.Errorf("invalid scalar size %d", len(bz))
+	}
+	return scalar.NewFromCanonicalBytes(bz)
+}
@@ -0,0 +1,143 @@
+package threshold
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decryptionShares(t *testing.T, pk *PublicKey, keyShares []*KeyShare, ciphertext, label []byte) []*DecryptionShare {
+	t.Helper()
+	shares := make([]*DecryptionShare, len(keyShares))
+	for i, ks := range keyShares {
+		ds, err := NewDecryptionShare(pk, ks, ciphertext, label)
+		require.NoError(t, err)
+		// The shares are exchanged encoded.
+		shares[i], err = DecryptionShareFromBytes(ds.Bytes())
+		require.NoError(t, err)
+		require.NoError(t, shares[i].Verify(pk, ciphertext))
+	}
+	return shares
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	pk, keyShares, err := GenerateKeys(5, 3)
+	require.NoError(t, err)
+	require.NoError(t, pk.ValidateBasic())
+	for _, ks := range keyShares {
+		require.NoError(t, ks.ValidateBasic(pk))
+	}
+
+	msg, label := []byte("transfer 10 atoms"), []byte("height=10")
+	ciphertext, err := Encrypt(pk, msg, label)
+	require.NoError(t, err)
+	assert.Len(t, ciphertext, len(msg)+Overhead)
+
+	// Any 3 shares decrypt the ciphertext.
+	shares := decryptionShares(t, pk, keyShares, ciphertext, label)
+	for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}} {
+		var s []*DecryptionShare
+		for _, i := range subset {
+			s = append(s, shares[i])
+		}
+		decrypted, err := Decrypt(pk, ciphertext, label, s)
+		require.NoError(t, err)
+		assert.Equal(t, msg, decrypted)
+	}
+
+	// 2 distinct shares are not enough, even if repeated.
+	_, err = Decrypt(pk, ciphertext, label, []*DecryptionShare{shares[0], shares[1], shares[1]})
+	require.ErrorIs(t, err, ErrNotEnoughShares)
+
+	// The label is authenticated.
+	_, err = Decrypt(pk, ciphertext, []byte("height=11"), shares)
+	require.ErrorIs(t, err, ErrInvalidCiphertext)
+}
+
+func TestNewDecryptionShare_invalidCiphertext(t *testing.T) {
+	pk, keyShares, err := GenerateKeys(3, 2)
+	require.NoError(t, err)
+	label := []byte("height=10")
+	ciphertext, err := Encrypt(pk, []byte("msg"), label)
+	require.NoError(t, err)
+	otherCiphertext, err := Encrypt(pk, []byte("other msg"), label)
+	require.NoError(t, err)
+
+	// The ephemeral elements of a ciphertext cannot be reused under another
+	// label, or with another sealed message, to get shares for them.
+	_, err = NewDecryptionShare(pk, keyShares[0], ciphertext, []byte("height=9"))
+	require.ErrorIs(t, err, ErrInvalidCiphertext)
+	mauled := append(append([]byte{}, ciphertext[:ciphertextHeaderSize]...), otherCiphertext[ciphertextHeaderSize:]...)
+	_, err = NewDecryptionShare(pk, keyShares[0], mauled, label)
+	require.ErrorIs(t, err, ErrInvalidCiphertext)
+	mauled = append([]byte{}, ciphertext...)
+	mauled[len(mauled)-1] ^= 1
+	_, err = NewDecryptionShare(pk, keyShares[0], mauled, label)
+	require.ErrorIs(t, err, ErrInvalidCiphertext)
+	_, err = NewDecryptionShare(pk, keyShares[0], ciphertext[:Overhead-1], label)
+	require.ErrorIs(t, err, ErrInvalidCiphertext)
+
+	// Nor can the shares of the ciphertext decrypt the mauled one.
+	shares := decryptionShares(t, pk, keyShares, ciphertext, label)
+	_, err = Decrypt(pk, mauled, label, shares)
+	require.ErrorIs(t, err, ErrInvalidCiphertext)
+}
+
+func TestDecryptionShare_Verify(t *testing.T) {
+	pk, keyShares, err := GenerateKeys(4, 2)
+	require.NoError(t, err)
+	ciphertext, err := Encrypt(pk, []byte("msg"), nil)
+	require.NoError(t, err)
+	otherCiphertext, err := Encrypt(pk, []byte("msg"), nil)
+	require.NoError(t, err)
+
+	shares := decryptionShares(t, pk, keyShares, ciphertext, nil)
+	otherShares := decryptionShares(t, pk, keyShares, otherCiphertext, nil)
+
+	// A share of another ciphertext, or claiming the index of another key
+	// share, does not verify.
+	require.ErrorIs(t, otherShares[0].Verify(pk, ciphertext), ErrInvalidShare)
+	forged := *shares[0]
+	forged.Index = 2
+	require.ErrorIs(t, forged.Verify(pk, ciphertext), ErrInvalidShare)
+	forged.Index = 5
+	require.ErrorIs(t, forged.Verify(pk, ciphertext), ErrInvalidShare)
+
+	// Invalid shares are ignored when decrypting.
+	_, err = Decrypt(pk, ciphertext, nil, []*DecryptionShare{otherShares[0], &forged, shares[3]})
+	require.ErrorIs(t, err, ErrNotEnoughShares)
+	msg, err := Decrypt(pk, ciphertext, nil, []*DecryptionShare{otherShares[0], &forged, shares[3], shares[1]})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("msg"), msg)
+
+	_, err = DecryptionShareFromBytes(shares[0].Bytes()[1:])
+	require.ErrorIs(t, err, ErrInvalidShare)
+}
+
+func TestGenerateKeys_invalid(t *testing.T) {
+	for _, tc := range [][2]int{{0, 0}, {3, 0}, {2, 3}} {
+		_, _, err := GenerateKeys(tc[0], tc[1])
+		require.Error(t, err)
+	}
+}
+
+func TestKeyFile(t *testing.T) {
+	pk, keyShares, err := GenerateKeys(3, 2)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "threshold_key.json")
+	kf := &KeyFile{PublicKey: pk, Share: keyShares[1]}
+	require.NoError(t, kf.Save(path))
+
+	loaded, err := LoadKeyFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, kf, loaded)
+
+	// A key share of another public key is invalid.
+	otherPK, _, err := GenerateKeys(3, 2)
+	require.NoError(t, err)
+	require.NoError(t, (&KeyFile{PublicKey: otherPK, Share: keyShares[1]}).Save(path))
+	_, err = LoadKeyFile(path)
+	require.Error(t, err)
+}
@@ -22,6 +22,7 @@ import (
 	httpclient "github.com/cometbft/cometbft/rpc/client/http"
 	indexermocks "github.com/cometbft/cometbft/state/indexer/mocks"
 	statemocks "github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/state/txindex"
 	txindexmocks "github.com/cometbft/cometbft/state/txindex/mocks"
 	"github.com/cometbft/cometbft/types"
 )
@@ -120,11 +121,11 @@ func TestTxSearch(t *testing.T) {
 	blockStoreMock.On("Close").Return(nil)
 	txIndexerMock := &txindexmocks.TxIndexer{}
 	blkIdxMock := &indexermocks.BlockIndexer{}
-	txIndexerMock.On("Search", mock.Anything,
+	txIndexerMock.On("SearchPage", mock.Anything,
 		mock.MatchedBy(func(q *query.Query) bool {
 			return testQuery == strings.ReplaceAll(q.String(), " ", "")
-		})).
-		Return([]*abcitypes.TxResult{testTxResult}, nil)
+		}), txindex.Pagination{Limit: 1}).
+		Return(&txindex.Page{Results: []*abcitypes.TxResult{testTxResult}, TotalCount: 1}, nil)
 
 	rpcConfig := config.TestRPCConfig()
 	d := inspect.New(rpcConfig, blockStoreMock, stateStoreMock, txIndexerMock, blkIdxMock)
@@ -146,8 +147,8 @@ func TestTxSearch(t *testing.T) {
 	cli, err := httpclient.New(rpcConfig.ListenAddress + "/v1")
 	require.NoError(t, err)
 
-	page := 1
-	resultTxSearch, err := cli.TxSearch(context.Background(), testQuery, false, &page, &page, "")
+	perPage := 1
+	resultTxSearch, err := cli.TxSearch(context.Background(), testQuery, false, "", &perPage, "")
 	require.NoError(t, err)
 	require.Len(t, resultTxSearch.Txs, 1)
 	require.Equal(t, types.Tx(testTx), resultTxSearch.Txs[0].Tx)
@@ -0,0 +1,141 @@
+package atrest
+
+import (
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/require"
+
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+)
+
+func newTestCipher(t *testing.T) *Cipher {
+	t.Helper()
+	c, err := NewCipher(cmtrand.Bytes(KeySize))
+	require.NoError(t, err)
+	return c
+}
+
+func TestCipher(t *testing.T) {
+	_, err := NewCipher(cmtrand.Bytes(16))
+	require.Error(t, err)
+
+	c := newTestCipher(t)
+	sealed := c.Seal([]byte("secret"), []byte("key"))
+	require.True(t, IsEncrypted(sealed))
+	require.NotContains(t, string(sealed), "secret")
+
+	opened, err := c.Open(sealed, []byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("secret"), opened)
+
+	// The additional data authenticates the data.
+	_, err = c.Open(sealed, []byte("other"))
+	require.ErrorIs(t, err, ErrDecrypt)
+	// So does the key.
+	_, err = newTestCipher(t).Open(sealed, []byte("key"))
+	require.ErrorIs(t, err, ErrDecrypt)
+
+	// Plaintext is returned as is.
+	opened, err = c.Open([]byte("plain"), nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("plain"), opened)
+
+	// Empty values are not mistaken for missing ones.
+	opened, err = c.Open(c.Seal([]byte{}, nil), nil)
+	require.NoError(t, err)
+	require.NotNil(t, opened)
+	require.Empty(t, opened)
+}
+
+func TestKeyFromEnv(t *testing.T) {
+	const name = "CMT_TEST_ATREST_KEY"
+	_, err := KeyFromEnv(name)
+	require.Error(t, err)
+
+	t.Setenv(name, "not hex")
+	_, err = KeyFromEnv(name)
+	require.Error(t, err)
+
+	key := cmtrand.Bytes(KeySize)
+	t.Setenv(name, hex.EncodeToString(key))
+	got, err := KeyFromEnv(name)
+	require.NoError(t, err)
+	require.Equal(t, key, got)
+}
+
+func TestDB(t *testing.T) {
+	raw := dbm.NewMemDB()
+	c := newTestCipher(t)
+	db := NewDB(raw, c)
+
+	// The values written before encryption got enabled are still read.
+	require.NoError(t, raw.Set([]byte("a"), []byte("legacy")))
+	require.NoError(t, db.Set([]byte("b"), []byte("value-b")))
+	require.NoError(t, db.SetSync([]byte("c"), []byte("value-c")))
+	batch := db.NewBatch()
+	require.NoError(t, batch.Set([]byte("d"), []byte("value-d")))
+	require.NoError(t, batch.WriteSync())
+	require.NoError(t, batch.Close())
+	require.Error(t, db.Set([]byte("e"), nil))
+
+	for _, key := range []string{"b", "c", "d"} {
+		value, err := raw.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, IsEncrypted(value))
+	}
+
+	value, err := db.Get([]byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("legacy"), value)
+	value, err = db.Get([]byte("b"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value-b"), value)
+	value, err = db.Get([]byte("missing"))
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	itr, err := db.ReverseIterator(nil, nil)
+	require.NoError(t, err)
+	var values []string
+	for ; itr.Valid(); itr.Next() {
+		values = append(values, string(itr.Value()))
+	}
+	require.NoError(t, itr.Error())
+	require.NoError(t, itr.Close())
+	require.Equal(t, []string{"value-d", "value-c", "value-b", "legacy"}, values)
+
+	// A value moved to another key is not decrypted.
+	sealed, err := raw.Get([]byte("b"))
+	require.NoError(t, err)
+	require.NoError(t, raw.Set([]byte("f"), sealed))
+	_, err = db.Get([]byte("f"))
+	require.ErrorIs(t, err, ErrDecrypt)
+	itr, err = db.Iterator([]byte("f"), nil)
+	require.NoError(t, err)
+	require.Nil(t, itr.Value())
+	require.ErrorIs(t, itr.Error(), ErrDecrypt)
+	require.NoError(t, itr.Close())
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.json")
+	c := newTestCipher(t)
+
+	require.NoError(t, WriteFile(path, []byte(`{"a":1}`), 0o600, nil))
+	data, err := ReadFile(path, c)
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"a":1}`), data)
+
+	require.NoError(t, WriteFile(path, []byte(`{"a":2}`), 0o600, c))
+	data, err = ReadFile(path, c)
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"a":2}`), data)
+
+	_, err = ReadFile(path, nil)
+	require.ErrorIs(t, err, ErrDecrypt)
+	_, err = ReadFile(path, newTestCipher(t))
+	require.ErrorIs(t, err, ErrDecrypt)
+}
@@ -0,0 +1,108 @@
+// Package atrest encrypts data at rest with AES-256-GCM. It provides a
+// wrapper encrypting the values of a database, and helpers reading and writing
+// encrypted files.
+//
+// The data encrypted by this package is prefixed with a magic header, so that
+// the data written before encryption got enabled is still read transparently,
+// and is encrypted the next time it is written.
+package atrest
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// KeySize is the size of the encryption keys, in bytes.
+const KeySize = 32
+
+// magic prefixes the encrypted data. Its leading zero byte never starts the
+// JSON files encrypted by the node.
+var magic = []byte("\x00cmtenc\x01")
+
+// ErrDecrypt is returned when encrypted data cannot be decrypted, because it
+// was encrypted with another key or was tampered with.
+var ErrDecrypt = errors.New("cannot decrypt data: wrong key or corrupted data")
+
+// Cipher encrypts and authenticates data with AES-256-GCM. It is safe for
+// concurrent use.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher returns a Cipher using the given key, which must be KeySize bytes
+// long.
+func NewCipher(key []byte) (*Cipher, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("invalid encryption key size: expected %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// KeyFromEnv reads a hex-encoded encryption key from the environment variable
+// with the given name.
+func KeyFromEnv(name string) ([]byte, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return nil, fmt.Errorf("encryption key environment variable %s is not set", name)
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key environment variable %s is not hex-encoded: %w", name, err)
+	}
+	return key, nil
+}
+
+// Seal encrypts plaintext, authenticating it along with additionalData, which
+// must be passed again to Open.
+func (c *Cipher) Seal(plaintext, additionalData []byte) []byte {
+	nonceSize := c.aead.NonceSize()
+	out := make([]byte, len(magic)+nonceSize, len(magic)+nonceSize+len(plaintext)+c.aead.Overhead())
+	copy(out, magic)
+	nonce := out[len(magic):]
+	if _, err := rand.Read(nonce); err != nil {
+		panic(fmt.Sprintf("cannot generate nonce: %v", err))
+	}
+	return c.aead.Seal(out, nonce, plaintext, additionalData)
+}
+
+// Open decrypts data sealed with the given additional data. Data that is not
+// encrypted is returned as is.
+func (c *Cipher) Open(data, additionalData []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+	nonceSize := c.aead.NonceSize()
+	if len(data) < len(magic)+nonceSize {
+		return nil, ErrDecrypt
+	}
+	nonce := data[len(magic) : len(magic)+nonceSize]
+	plaintext, err := c.aead.Open(nil, nonce, data[len(magic)+nonceSize:], additionalData)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	if plaintext == nil {
+		// Distinguish empty values from missing ones.
+		plaintext = []byte{}
+	}
+	return plaintext, nil
+}
+
+// IsEncrypted reports whether data was encrypted by a Cipher.
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, magic)
+}
@@ -0,0 +1,110 @@
+package atrest
+
+import (
+	dbm "github.com/cometbft/cometbft-db"
+)
+
+// encryptedDB encrypts the values written to a database, and decrypts the
+// values read from it. The keys are left in plaintext, so that the ordering
+// of the iterators is preserved, and authenticate the values they map to.
+type encryptedDB struct {
+	dbm.DB
+	cipher *Cipher
+}
+
+var _ dbm.DB = (*encryptedDB)(nil)
+
+// NewDB returns a view of db encrypting the values with the given cipher.
+// The values written to db before encryption got enabled are still read.
+// Closing the view closes db.
+func NewDB(db dbm.DB, c *Cipher) dbm.DB {
+	return &encryptedDB{DB: db, cipher: c}
+}
+
+// Get implements dbm.DB.
+func (db *encryptedDB) Get(key []byte) ([]byte, error) {
+	value, err := db.DB.Get(key)
+	if err != nil || value == nil {
+		return value, err
+	}
+	return db.cipher.Open(value, key)
+}
+
+// Set implements dbm.DB.
+func (db *encryptedDB) Set(key, value []byte) error {
+	if value == nil {
+		// Let the database reject the nil value.
+		return db.DB.Set(key, value)
+	}
+	return db.DB.Set(key, db.cipher.Seal(value, key))
+}
+
+// SetSync implements dbm.DB.
+func (db *encryptedDB) SetSync(key, value []byte) error {
+	if value == nil {
+		return db.DB.SetSync(key, value)
+	}
+	return db.DB.SetSync(key, db.cipher.Seal(value, key))
+}
+
+// Iterator implements dbm.DB.
+func (db *encryptedDB) Iterator(start, end []byte) (dbm.Iterator, error) {
+	itr, err := db.DB.Iterator(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedIterator{Iterator: itr, cipher: db.cipher}, nil
+}
+
+// ReverseIterator implements dbm.DB.
+func (db *encryptedDB) ReverseIterator(start, end []byte) (dbm.Iterator, error) {
+	itr, err := db.DB.ReverseIterator(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedIterator{Iterator: itr, cipher: db.cipher}, nil
+}
+
+// NewBatch implements dbm.DB.
+func (db *encryptedDB) NewBatch() dbm.Batch {
+	return &encryptedBatch{Batch: db.DB.NewBatch(), cipher: db.cipher}
+}
+
+// encryptedIterator decrypts the values of an iterator. The values that
+// cannot be decrypted are returned as nil, and fail the iteration.
+type encryptedIterator struct {
+	dbm.Iterator
+	cipher *Cipher
+	err    error
+}
+
+// Value implements dbm.Iterator.
+func (itr *encryptedIterator) Value() []byte {
+	value, err := itr.cipher.Open(itr.Iterator.Value(), itr.Iterator.Key())
+	if err != nil {
+		itr.err = err
+		return nil
+	}
+	return value
+}
+
+// Error implements dbm.Iterator.
+func (itr *encryptedIterator) Error() error {
+	if itr.err != nil {
+		return itr.err
+	}
+	return itr.Iterator.Error()
+}
+
+type encryptedBatch struct {
+	dbm.Batch
+	cipher *Cipher
+}
+
+// Set implements dbm.Batch.
+func (b *encryptedBatch) Set(key, value []byte) error {
+	if value == nil {
+		return b.Batch.Set(key, value)
+	}
+	return b.Batch.Set(key, b.cipher.Seal(value, key))
+}
@@ -0,0 +1,32 @@
+package atrest
+
+import (
+	"os"
+
+	"github.com/cometbft/cometbft/libs/tempfile"
+)
+
+// WriteFile atomically writes data to the file at path, encrypted with the
+// given cipher. The data is written in plaintext if the cipher is nil.
+func WriteFile(path string, data []byte, perm os.FileMode, c *Cipher) error {
+	if c != nil {
+		data = c.Seal(data, nil)
+	}
+	return tempfile.WriteFileAtomic(path, data, perm)
+}
+
+// ReadFile reads the file at path, decrypting it with the given cipher if it
+// is encrypted. Decrypting a file without a cipher fails with ErrDecrypt.
+func ReadFile(path string, c *Cipher) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+	if c == nil {
+		return nil, ErrDecrypt
+	}
+	return c.Open(data, nil)
+}
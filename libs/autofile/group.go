@@ -295,6 +295,13 @@ func (g *Group) checkTotalSizeLimit() {
 			return
 		}
 		totalSize -= fInfo.Size()
+
+		// Otherwise the readers would recreate the removed file.
+		g.mtx.Lock()
+		if g.minIndex <= index {
+			g.minIndex = index + 1
+		}
+		g.mtx.Unlock()
 	}
 }
 
@@ -426,6 +433,7 @@ type GroupReader struct {
 	curIndex  int
 	curFile   *os.File
 	curReader *bufio.Reader
+	curPos    int64 // bytes read from curFile
 	curLine   []byte
 }
 
@@ -478,6 +486,7 @@ func (gr *GroupReader) Read(p []byte) (n int, err error) {
 	for {
 		nn, err = gr.curReader.Read(p[n:])
 		n += nn
+		gr.curPos += int64(nn)
 		switch {
 		case err == io.EOF:
 			if n >= lenP {
@@ -520,6 +529,7 @@ func (gr *GroupReader) openFile(index int) error {
 	gr.curIndex = index
 	gr.curFile = curFile
 	gr.curReader = curReader
+	gr.curPos = 0
 	gr.curLine = nil
 	return nil
 }
@@ -531,6 +541,25 @@ func (gr *GroupReader) CurIndex() int {
 	return gr.curIndex
 }
 
+// Remaining returns the number of bytes left to read, from the cursor to the
+// end of the head.
+func (gr *GroupReader) Remaining() (int64, error) {
+	gr.mtx.Lock()
+	defer gr.mtx.Unlock()
+	gr.Group.mtx.Lock()
+	defer gr.Group.mtx.Unlock()
+
+	remaining := -gr.curPos
+	for index := gr.curIndex; index <= gr.Group.maxIndex; index++ {
+		fInfo, err := os.Stat(filePathForIndex(gr.Head.Path, index, gr.Group.maxIndex))
+		if err != nil {
+			return 0, err
+		}
+		remaining += fInfo.Size()
+	}
+	return remaining, nil
+}
+
 // SetIndex sets the cursor's file index to index by opening a file at this
 // position.
 func (gr *GroupReader) SetIndex(index int) error {
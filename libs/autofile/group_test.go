@@ -226,6 +226,36 @@ func TestGroupReaderRead(t *testing.T) {
 	destroyTestGroup(t, g)
 }
 
+func TestGroupReaderRemaining(t *testing.T) {
+	g := createTestGroupWithHeadSizeLimit(t, 0)
+
+	_, err := g.Write([]byte("Professor Monster"))
+	require.NoError(t, err)
+	require.NoError(t, g.FlushAndSync())
+	g.RotateFile()
+	_, err = g.Write([]byte("Frankenstein's Monster"))
+	require.NoError(t, err)
+	require.NoError(t, g.FlushAndSync())
+
+	gr, err := g.NewReader(0)
+	require.NoError(t, err)
+	defer gr.Close()
+
+	remaining, err := gr.Remaining()
+	require.NoError(t, err)
+	assert.EqualValues(t, 39, remaining)
+
+	// Read past the first file.
+	_, err = gr.Read(make([]byte, 20))
+	require.NoError(t, err)
+	remaining, err = gr.Remaining()
+	require.NoError(t, err)
+	assert.EqualValues(t, 19, remaining)
+
+	// Cleanup
+	destroyTestGroup(t, g)
+}
+
 // test that Read returns an error if number of bytes read < size of
 // the given slice. Subsequent call should return 0, io.EOF.
 func TestGroupReaderRead2(t *testing.T) {
@@ -272,6 +302,27 @@ func TestMinIndex(t *testing.T) {
 	destroyTestGroup(t, g)
 }
 
+func TestCheckTotalSizeLimit(t *testing.T) {
+	g := createTestGroupWithHeadSizeLimit(t, 0)
+	g.totalSizeLimit = 2500
+
+	// Write 3 files of 1000 bytes.
+	for i := 0; i < 3; i++ {
+		err := g.WriteLine(cmtrand.Str(999))
+		require.NoError(t, err)
+		g.RotateFile()
+	}
+	assertGroupInfo(t, g.ReadGroupInfo(), 0, 3, 3000, 0)
+
+	// The oldest file is removed, and no longer part of the group.
+	g.checkTotalSizeLimit()
+	assertGroupInfo(t, g.ReadGroupInfo(), 1, 3, 2000, 0)
+	assert.Equal(t, 1, g.MinIndex())
+
+	// Cleanup
+	destroyTestGroup(t, g)
+}
+
 func TestMaxIndex(t *testing.T) {
 	g := createTestGroupWithHeadSizeLimit(t, 0)
 
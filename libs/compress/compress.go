@@ -0,0 +1,68 @@
+// Package compress compresses the values of the stores with zstd.
+//
+// The data compressed by this package is prefixed with a magic header, so that
+// the values written before compression got enabled, or after it got disabled,
+// are still read transparently.
+package compress
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// magic prefixes the compressed data. Its leading zero byte never starts a
+// protobuf message, as field numbers start at 1.
+var magic = []byte("\x00cmtzst\x01")
+
+// maxDecompressedSize bounds the memory used to decompress a value.
+const maxDecompressedSize = 1 << 30
+
+var (
+	encoder = sync.OnceValue(func() *zstd.Encoder {
+		enc, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			panic(err)
+		}
+		return enc
+	})
+	decoder = sync.OnceValue(func() *zstd.Decoder {
+		dec, err := zstd.NewReader(nil,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderMaxMemory(maxDecompressedSize))
+		if err != nil {
+			panic(err)
+		}
+		return dec
+	})
+)
+
+// Compress returns the data compressed with zstd, prefixed with the magic
+// header. The data is returned as is if it does not shrink.
+func Compress(data []byte) []byte {
+	compressed := encoder().EncodeAll(data, append([]byte{}, magic...))
+	if len(compressed) >= len(data) {
+		return data
+	}
+	return compressed
+}
+
+// Decompress returns the data decompressed, if it was compressed by Compress,
+// or as is otherwise.
+func Decompress(data []byte) ([]byte, error) {
+	if !IsCompressed(data) {
+		return data, nil
+	}
+	decompressed, err := decoder().DecodeAll(data[len(magic):], nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decompress data: %w", err)
+	}
+	return decompressed, nil
+}
+
+// IsCompressed returns true if the data was compressed by Compress.
+func IsCompressed(data []byte) bool {
+	return bytes.HasPrefix(data, magic)
+}
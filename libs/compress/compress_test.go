@@ -0,0 +1,33 @@
+package compress
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressDecompress(t *testing.T) {
+	data := bytes.Repeat([]byte("event.attribute=value;"), 100)
+	compressed := Compress(data)
+	assert.True(t, IsCompressed(compressed))
+	assert.Less(t, len(compressed), len(data))
+
+	decompressed, err := Decompress(compressed)
+	require.NoError(t, err)
+	assert.Equal(t, data, decompressed)
+
+	// The data that does not shrink, and the data written uncompressed, are
+	// read as is.
+	small := []byte{0x0a, 0x01, 0x02}
+	assert.Equal(t, small, Compress(small))
+	decompressed, err = Decompress(small)
+	require.NoError(t, err)
+	assert.Equal(t, small, decompressed)
+
+	// Corrupted data is not decompressed.
+	compressed[len(compressed)-1] ^= 0xff
+	_, err = Decompress(compressed)
+	require.Error(t, err)
+}
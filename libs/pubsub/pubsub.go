@@ -38,6 +38,7 @@ import (
 	"errors"
 	"fmt"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/libs/service"
 	cmtsync "github.com/cometbft/cometbft/libs/sync"
 )
@@ -72,6 +73,14 @@ type Query interface {
 	String() string
 }
 
+// EventsQuery is a Query which can also match the events a message was
+// published with by PublishWithABCIEvents, before they were flattened into a
+// map, e.g., to match the attributes of a single event.
+type EventsQuery interface {
+	Query
+	MatchesEvents(flattened map[string][]string, events []abci.Event) (bool, error)
+}
+
 type cmd struct {
 	op operation
 
@@ -81,8 +90,9 @@ type cmd struct {
 	clientID     string
 
 	// publish
-	msg    interface{}
-	events map[string][]string
+	msg        interface{}
+	events     map[string][]string
+	abciEvents []abci.Event
 }
 
 // Server allows clients to subscribe/unsubscribe for messages, publishing
@@ -159,17 +169,40 @@ func (s *Server) Subscribe(
 		outCap = outCapacity[0]
 	}
 
-	return s.subscribe(ctx, clientID, query, outCap)
+	return s.subscribe(ctx, clientID, query, NewSubscription(outCap))
+}
+
+// SubscribeDropping does the same as Subscribe, except the messages published
+// while the channel of the subscription is full are dropped, and counted by
+// Subscription#Dropped, instead of canceling the subscription with
+// ErrOutOfCapacity. Panics if outCapacity is less than or equal to zero.
+func (s *Server) SubscribeDropping(
+	ctx context.Context,
+	clientID string,
+	query Query,
+	outCapacity int,
+) (*Subscription, error) {
+	if outCapacity <= 0 {
+		panic("Negative or zero capacity")
+	}
+	subscription := NewSubscription(outCapacity)
+	subscription.dropWhenFull = true
+	return s.subscribe(ctx, clientID, query, subscription)
 }
 
 // SubscribeUnbuffered does the same as Subscribe, except it returns a
 // subscription with unbuffered channel. Use with caution as it can freeze the
 // server.
 func (s *Server) SubscribeUnbuffered(ctx context.Context, clientID string, query Query) (*Subscription, error) {
-	return s.subscribe(ctx, clientID, query, 0)
+	return s.subscribe(ctx, clientID, query, NewSubscription(0))
 }
 
-func (s *Server) subscribe(ctx context.Context, clientID string, query Query, outCapacity int) (*Subscription, error) {
+func (s *Server) subscribe(
+	ctx context.Context,
+	clientID string,
+	query Query,
+	subscription *Subscription,
+) (*Subscription, error) {
 	s.mtx.RLock()
 	clientSubscriptions, ok := s.subscriptions[clientID]
 	if ok {
@@ -180,7 +213,6 @@ func (s *Server) subscribe(ctx context.Context, clientID string, query Query, ou
 		return nil, ErrAlreadySubscribed
 	}
 
-	subscription := NewSubscription(outCapacity)
 	select {
 	case s.cmds <- cmd{op: sub, clientID: clientID, query: query, subscription: subscription}:
 		s.mtx.Lock()
@@ -284,6 +316,25 @@ func (s *Server) PublishWithEvents(ctx context.Context, msg interface{}, events
 	}
 }
 
+// PublishWithABCIEvents publishes the given message with the set of events, as
+// PublishWithEvents, and the ABCI events the set was flattened from, which are
+// matched with the client queries implementing EventsQuery.
+func (s *Server) PublishWithABCIEvents(
+	ctx context.Context,
+	msg interface{},
+	events map[string][]string,
+	abciEvents []abci.Event,
+) error {
+	select {
+	case s.cmds <- cmd{op: pub, msg: msg, events: events, abciEvents: abciEvents}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.Quit():
+		return nil
+	}
+}
+
 // OnStop implements Service.OnStop by shutting down the server.
 func (s *Server) OnStop() {
 	s.cmds <- cmd{op: shutdown}
@@ -334,7 +385,7 @@ loop:
 		case sub:
 			state.add(cmd.clientID, cmd.query, cmd.subscription)
 		case pub:
-			if err := state.send(cmd.msg, cmd.events); err != nil {
+			if err := state.send(cmd.msg, cmd.events, cmd.abciEvents); err != nil {
 				s.Logger.Error("Error querying for events", "err", err)
 			}
 		}
@@ -403,11 +454,17 @@ func (state *state) removeAll(reason error) {
 	}
 }
 
-func (state *state) send(msg interface{}, events map[string][]string) error {
+func (state *state) send(msg interface{}, events map[string][]string, abciEvents []abci.Event) error {
 	for qStr, clientSubscriptions := range state.subscriptions {
 		q := state.queries[qStr].q
 
-		match, err := q.Matches(events)
+		var match bool
+		var err error
+		if eq, ok := q.(EventsQuery); ok && abciEvents != nil {
+			match, err = eq.MatchesEvents(events, abciEvents)
+		} else {
+			match, err = q.Matches(events)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to match against query %s: %w", q.String(), err)
 		}
@@ -422,7 +479,11 @@ func (state *state) send(msg interface{}, events map[string][]string) error {
 					select {
 					case subscription.out <- NewMessage(msg, events):
 					default:
-						state.remove(clientID, qStr, ErrOutOfCapacity)
+						if subscription.dropWhenFull {
+							subscription.dropped.Add(1)
+						} else {
+							state.remove(clientID, qStr, ErrOutOfCapacity)
+						}
 					}
 				}
 			}
@@ -149,6 +149,34 @@ func TestSlowClientIsRemovedWithErrOutOfCapacity(t *testing.T) {
 	assertCancelled(t, subscription, pubsub.ErrOutOfCapacity)
 }
 
+func TestSlowClientDropsMessages(t *testing.T) {
+	s := pubsub.NewServer()
+	s.SetLogger(log.TestingLogger())
+	err := s.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	subscription, err := s.SubscribeDropping(ctx, clientID, query.All, 1)
+	require.NoError(t, err)
+	err = s.Publish(ctx, "Fat Cobra")
+	require.NoError(t, err)
+	err = s.Publish(ctx, "Viper")
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return subscription.Dropped() == 1 }, time.Second, 10*time.Millisecond)
+	assertReceive(t, "Fat Cobra", subscription.Out())
+	require.NoError(t, subscription.Err())
+
+	err = s.Publish(ctx, "Whiplash")
+	require.NoError(t, err)
+	assertReceive(t, "Whiplash", subscription.Out())
+}
+
 func TestDifferentClients(t *testing.T) {
 	s := pubsub.NewServer()
 	s.SetLogger(log.TestingLogger())
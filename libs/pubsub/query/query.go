@@ -22,10 +22,17 @@ import (
 // All is a query that matches all events.
 var All *Query
 
+// MatchEventsKey is the tag of the "match.events = 1" condition, which
+// requires the conditions on the attributes of an event type to be matched
+// by the attributes of a single event of that type, as the indexers do, when
+// the query is matched against the events of a message (see MatchesEvents).
+const MatchEventsKey = "match.events"
+
 // A Query is the compiled form of a query.
 type Query struct {
-	ast   syntax.Query
-	conds []condition
+	ast         syntax.Query
+	conds       []condition
+	matchEvents bool
 }
 
 // New parses and compiles the query expression into an executable query.
@@ -52,15 +59,23 @@ func MustCompile(query string) *Query {
 
 // Compile compiles the given query AST so it can be used to match events.
 func Compile(ast syntax.Query) (*Query, error) {
-	conds := make([]condition, len(ast))
-	for i, q := range ast {
+	conds := make([]condition, 0, len(ast))
+	matchEvents := false
+	for _, q := range ast {
+		if q.Tag == MatchEventsKey {
+			if q.Op != syntax.TEq || q.Arg == nil || q.Arg.Type != syntax.TNumber || q.Arg.Value() != "1" {
+				return nil, fmt.Errorf("compile %s: only %s = 1 is supported", q, MatchEventsKey)
+			}
+			matchEvents = true
+			continue
+		}
 		cond, err := compileCondition(q)
 		if err != nil {
 			return nil, fmt.Errorf("compile %s: %w", q, err)
 		}
-		conds[i] = cond
+		conds = append(conds, cond)
 	}
-	return &Query{ast: ast, conds: conds}, nil
+	return &Query{ast: ast, conds: conds, matchEvents: matchEvents}, nil
 }
 
 func ExpandEvents(flattenedEvents map[string][]string) []types.Event {
@@ -95,6 +110,56 @@ func (q *Query) Matches(events map[string][]string) (bool, error) {
 	return q.matchesEvents(ExpandEvents(events)), nil
 }
 
+// MatchesEvents satisfies part of the pubsub.EventsQuery interface. It
+// reports whether the query matches the given flattened events, as Matches,
+// unless the query has the "match.events = 1" condition. In which case the
+// conditions on the attributes of an event type present in the given events
+// must all be matched by the attributes of a single one of them, the other
+// conditions being matched against the flattened events. This implementation
+// never reports an error. A nil *Query matches all events.
+func (q *Query) MatchesEvents(flattened map[string][]string, events []types.Event) (bool, error) {
+	if q == nil {
+		return true, nil
+	}
+	if !q.matchEvents {
+		return q.Matches(flattened)
+	}
+
+	expanded := ExpandEvents(flattened)
+	eventTypes := make(map[string][]condition)
+	for _, cond := range q.conds {
+		typ := cond.eventType()
+		if !hasEventType(events, typ) {
+			if !cond.matchesAny(expanded) {
+				return false, nil
+			}
+			continue
+		}
+		eventTypes[typ] = append(eventTypes[typ], cond)
+	}
+	for typ, conds := range eventTypes {
+		if !matchesOneEvent(conds, typ, events) {
+			return false, nil
+		}
+	}
+	return len(expanded) != 0, nil
+}
+
+// Conditions returns the conditions of q to be matched by the attributes of
+// the events, i.e., without the "match.events = 1" condition.
+func (q *Query) Conditions() []syntax.Condition {
+	if q == nil {
+		return nil
+	}
+	conds := make([]syntax.Condition, 0, len(q.ast))
+	for _, cond := range q.ast {
+		if cond.Tag != MatchEventsKey {
+			conds = append(conds, cond)
+		}
+	}
+	return conds
+}
+
 // String matches part of the pubsub.Query interface.
 func (q *Query) String() string {
 	if q == nil {
@@ -129,6 +194,45 @@ type condition struct {
 	match func(s string) bool
 }
 
+// eventType returns the event type of the condition tag, e.g., "tx" for
+// "tx.hash".
+func (c condition) eventType() string {
+	if i := strings.LastIndex(c.tag, "."); i >= 0 {
+		return c.tag[:i]
+	}
+	return c.tag
+}
+
+func hasEventType(events []types.Event, typ string) bool {
+	for _, event := range events {
+		if event.Type == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesOneEvent reports whether a single one of the events of the given
+// type matches all the conditions.
+func matchesOneEvent(conds []condition, typ string, events []types.Event) bool {
+	for _, event := range events {
+		if event.Type != typ {
+			continue
+		}
+		matches := true
+		for _, cond := range conds {
+			if !cond.matchesEvent(event) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
 // findAttr returns a slice of attribute values from event matching the
 // condition tag, and reports whether the event type strictly equals the
 // condition tag.
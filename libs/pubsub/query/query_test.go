@@ -16,7 +16,7 @@ import (
 	"github.com/cometbft/cometbft/libs/pubsub/query/syntax"
 )
 
-var _ pubsub.Query = (*query.Query)(nil)
+var _ pubsub.EventsQuery = (*query.Query)(nil)
 
 // Example events from the OpenAPI documentation:
 //
@@ -436,6 +436,55 @@ func TestExpandEvents(t *testing.T) {
 	}
 }
 
+func TestMatchesEvents(t *testing.T) {
+	events := []types.Event{
+		{Type: "transfer", Attributes: []types.EventAttribute{
+			{Key: "sender", Value: "AddrA"},
+			{Key: "amount", Value: "10"},
+		}},
+		{Type: "transfer", Attributes: []types.EventAttribute{
+			{Key: "sender", Value: "AddrB"},
+			{Key: "amount", Value: "200"},
+		}},
+	}
+	flattened := map[string][]string{
+		"tm.event":        {"Tx"},
+		"transfer.sender": {"AddrA", "AddrB"},
+		"transfer.amount": {"10", "200"},
+	}
+
+	tests := []struct {
+		s     string
+		match bool
+	}{
+		// Without match.events, the attributes can match across events.
+		{`transfer.sender = 'AddrA' AND transfer.amount > 100`, true},
+		{`transfer.sender = 'AddrA' AND transfer.amount > 100 AND match.events = 1`, false},
+		{`transfer.sender = 'AddrB' AND transfer.amount > 100 AND match.events = 1`, true},
+		{`transfer.sender CONTAINS 'Addr' AND transfer.amount <= 10 AND match.events = 1`, true},
+		{`transfer.sender = 'AddrA' AND transfer.amount EXISTS AND match.events = 1`, true},
+		// The conditions on other event types match the flattened events.
+		{`tm.event = 'Tx' AND transfer.sender = 'AddrB' AND transfer.amount = 200 AND match.events = 1`, true},
+		{`tm.event = 'NewBlock' AND transfer.sender = 'AddrB' AND match.events = 1`, false},
+		{`transfer.recipient EXISTS AND match.events = 1`, false},
+	}
+	for _, test := range tests {
+		q, err := query.New(test.s)
+		require.NoError(t, err)
+		match, err := q.MatchesEvents(flattened, events)
+		require.NoError(t, err)
+		require.Equal(t, test.match, match, test.s)
+	}
+
+	for _, s := range []string{`match.events = 2`, `match.events > 0`, `match.events EXISTS`} {
+		_, err := query.New(s)
+		require.Error(t, err, s)
+	}
+
+	q := query.MustCompile(`transfer.amount > 100 AND match.events = 1`)
+	require.Equal(t, syntax.Query{q.Syntax()[0]}, syntax.Query(q.Conditions()))
+}
+
 func TestAllMatchesAll(t *testing.T) {
 	events := newTestEvents(
 		``,
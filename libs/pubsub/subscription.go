@@ -2,6 +2,7 @@ package pubsub
 
 import (
 	"errors"
+	"sync/atomic"
 
 	cmtsync "github.com/cometbft/cometbft/libs/sync"
 )
@@ -26,6 +27,11 @@ type Subscription struct {
 	canceled chan struct{}
 	mtx      cmtsync.RWMutex
 	err      error
+
+	// dropWhenFull drops the messages published while out is full, instead
+	// of canceling the subscription with ErrOutOfCapacity.
+	dropWhenFull bool
+	dropped      atomic.Uint64
 }
 
 // NewSubscription returns a new subscription with the given outCapacity.
@@ -43,6 +49,13 @@ func (s *Subscription) Out() <-chan Message {
 	return s.out
 }
 
+// Dropped returns the number of messages dropped because the channel
+// returned by Out was full, if the subscription was created with
+// SubscribeDropping.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
 // Canceled returns a channel that's closed when the subscription is
 // terminated and supposed to be used in a select statement.
 func (s *Subscription) Canceled() <-chan struct{} {
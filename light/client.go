@@ -5,7 +5,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"sort"
 	"sync"
 	"time"
 
@@ -23,8 +22,12 @@ const (
 	sequential mode = iota + 1
 	skipping
 
-	defaultPruningSize      = 1000
-	defaultMaxRetryAttempts = 10
+	defaultPruningSize        = 1000
+	defaultMaxRetryAttempts   = 10
+	defaultMaxWitnessFailures = 3
+
+	defaultEvidenceAttempts      = 3
+	defaultEvidenceRetryInterval = time.Second
 	// For verifySkipping, when using the cache of headers from the previous batch,
 	// they will always be at a height greater than 1/2 (normal verifySkipping) so to
 	// find something in between the range, 9/16 is used.
@@ -41,6 +44,21 @@ const (
 	defaultMaxBlockLag = 10 * time.Second
 )
 
+// The paths of the verification of a light block, labelling the metrics.
+const (
+	verificationPathSequential = "sequential"
+	verificationPathSkipping   = "skipping"
+	verificationPathBackwards  = "backwards"
+)
+
+// verificationPath returns the path of the forwards verification in the mode.
+func (m mode) verificationPath() string {
+	if m == sequential {
+		return verificationPathSequential
+	}
+	return verificationPathSkipping
+}
+
 // Option sets a parameter for the light client.
 type Option func(*Client)
 
@@ -79,6 +97,17 @@ func PruningSize(h uint16) Option {
 	}
 }
 
+// PruningAge option sets the maximum age of the light blocks that the light
+// client stores, relative to the time of the latest trusted light block. The
+// older light blocks, except the latest trusted one, are removed whenever a
+// light block is stored. It applies in addition to PruningSize.
+// Default: 0, i.e. the light blocks are not pruned by age.
+func PruningAge(d time.Duration) Option {
+	return func(c *Client) {
+		c.pruningAge = d
+	}
+}
+
 // ConfirmationFunction option can be used to prompt to confirm an action. For
 // example, remove newer headers if the light client is being reset with an
 // older header. No confirmation is required by default!
@@ -125,6 +154,51 @@ func MaxBlockLag(d time.Duration) Option {
 	}
 }
 
+// WitnessPool option sets the providers recruited as witnesses, in order,
+// when a witness is removed for misbehaving, promoted to primary or demoted
+// for failing MaxWitnessFailures requests in a row. Demoted witnesses are
+// moved to the back of the pool. Default: no pool, i.e. the witnesses are only
+// removed.
+func WitnessPool(providers []provider.Provider) Option {
+	return func(c *Client) {
+		c.witnessPool = providers
+	}
+}
+
+// MaxWitnessFailures option sets the number of requests in a row a witness
+// can fail to respond to, or to provide the light block of, before being
+// replaced by a provider of the witness pool. Default: 3.
+func MaxWitnessFailures(max uint16) Option {
+	return func(c *Client) {
+		c.maxWitnessFailures = max
+	}
+}
+
+// EvidenceReceivers option sets the full nodes the evidence of the attacks
+// detected by the light client is submitted to, in addition to the primary or
+// the witness the attack is not attributed to. Default: none.
+func EvidenceReceivers(receivers []provider.Provider) Option {
+	return func(c *Client) {
+		c.evidenceReceivers = receivers
+	}
+}
+
+// EvidenceAttempts option sets the number of attempts at submitting evidence
+// to a provider, and the interval between them. Default: 3 attempts, 1s apart.
+func EvidenceAttempts(attempts uint16, interval time.Duration) Option {
+	return func(c *Client) {
+		c.evidenceAttempts = attempts
+		c.evidenceRetryInterval = interval
+	}
+}
+
+// WithMetrics option sets the metrics of the client. Default: no metrics.
+func WithMetrics(metrics *Metrics) Option {
+	return func(c *Client) {
+		c.metrics = metrics
+	}
+}
+
 // Client represents a light client, connected to a single chain, which gets
 // light blocks from a primary provider, verifies them either sequentially or by
 // skipping some and stores them in a trusted store (usually, a local FS).
@@ -145,6 +219,22 @@ type Client struct {
 	primary provider.Provider
 	// Providers used to "witness" new headers.
 	witnesses []provider.Provider
+	// Providers recruited as witnesses. See WitnessPool option
+	witnessPool []provider.Provider
+	// See MaxWitnessFailures option
+	maxWitnessFailures uint16
+
+	// Mutex for the failures of the witnesses, recorded concurrently while
+	// comparing headers
+	witnessFailuresMtx cmtsync.Mutex
+	// Number of requests in a row each witness failed
+	witnessFailures map[provider.Provider]uint16
+
+	// Full nodes the evidence is submitted to. See EvidenceReceivers option
+	evidenceReceivers []provider.Provider
+	// See EvidenceAttempts option
+	evidenceAttempts      uint16
+	evidenceRetryInterval time.Duration
 
 	// Where trusted light blocks are stored.
 	trustedStore store.Store
@@ -153,12 +243,15 @@ type Client struct {
 
 	// See RemoveNoLongerTrustedHeadersPeriod option
 	pruningSize uint16
+	// See PruningAge option
+	pruningAge time.Duration
 	// See ConfirmationFunction option
 	confirmationFn func(action string) bool
 
 	quit chan struct{}
 
-	logger log.Logger
+	logger  log.Logger
+	metrics *Metrics
 }
 
 // NewClient returns a new light client. It returns an error if it fails to
@@ -232,6 +325,13 @@ func NewClientFromTrustedStore(
 		confirmationFn:   func(action string) bool { return true },
 		quit:             make(chan struct{}),
 		logger:           log.NewNopLogger(),
+		metrics:          NopMetrics(),
+
+		maxWitnessFailures: defaultMaxWitnessFailures,
+		witnessFailures:    make(map[provider.Provider]uint16),
+
+		evidenceAttempts:      defaultEvidenceAttempts,
+		evidenceRetryInterval: defaultEvidenceRetryInterval,
 	}
 
 	for _, o := range options {
@@ -250,6 +350,19 @@ func NewClientFromTrustedStore(
 				i, w, w.ChainID(), chainID)
 		}
 	}
+	for i, w := range c.witnessPool {
+		if w.ChainID() != chainID {
+			return nil, fmt.Errorf("witness pool #%d: %v is on another chain %s, expected %s",
+				i, w, w.ChainID(), chainID)
+		}
+	}
+	for i, r := range c.evidenceReceivers {
+		if r.ChainID() != chainID {
+			return nil, fmt.Errorf("evidence receiver #%d: %v is on another chain %s, expected %s",
+				i, r, r.ChainID(), chainID)
+		}
+	}
+	c.updateWitnessMetrics()
 
 	// Validate trust level.
 	if err := ValidateTrustLevel(c.trustLevel); err != nil {
@@ -278,6 +391,7 @@ func (c *Client) restoreTrustedLightBlock() error {
 		c.latestTrustedBlock = trustedBlock
 		c.logger.Info("Restored trusted light block", "height", lastHeight)
 	}
+	c.updateStoreMetrics()
 
 	return nil
 }
@@ -512,7 +626,12 @@ func (c *Client) VerifyLightBlockAtHeight(ctx context.Context, height int64, now
 // requested and the light client does not have it, VerifyHeader will perform:
 //
 //	a) verifySkipping verification if nearest trusted header is found & not expired
-//	b) backwards verification in all other cases
+//	b) backwards verification in all other cases, from the nearest trusted
+//	   header after it, walking back through the LastBlockID hashes
+//
+// Backwards verification does not depend on the trusting period, so historical
+// headers can be verified as long as a later header is trusted, without
+// another trust anchor.
 //
 // It returns ErrOldHeaderExpired if the latest trusted header expired.
 //
@@ -577,6 +696,8 @@ func (c *Client) verifyLightBlock(ctx context.Context, newLightBlock *types.Ligh
 		return fmt.Errorf("can't get first light block height: %w", err)
 	}
 
+	start := time.Now()
+	path := c.verificationMode.verificationPath()
 	switch {
 	// Verifying forwards
 	case newLightBlock.Height >= c.latestTrustedBlock.Height:
@@ -584,6 +705,7 @@ func (c *Client) verifyLightBlock(ctx context.Context, newLightBlock *types.Ligh
 
 	// Verifying backwards
 	case newLightBlock.Height < firstBlockHeight:
+		path = verificationPathBackwards
 		var firstBlock *types.LightBlock
 		firstBlock, err = c.trustedStore.LightBlock(firstBlockHeight)
 		if err != nil {
@@ -598,12 +720,27 @@ func (c *Client) verifyLightBlock(ctx context.Context, newLightBlock *types.Ligh
 		if err != nil {
 			return fmt.Errorf("can't get signed header before height %d: %w", newLightBlock.Height, err)
 		}
-		err = verifyFunc(ctx, closestBlock, newLightBlock, now)
+		if !HeaderExpired(closestBlock.SignedHeader, c.trustingPeriod, now) {
+			err = verifyFunc(ctx, closestBlock, newLightBlock, now)
+			break
+		}
+		// The closest light block before has expired, so verify backwards from
+		// the closest one after, which does not depend on the trusting period.
+		path = verificationPathBackwards
+		var nextBlock *types.LightBlock
+		nextBlock, err = c.trustedStore.LightBlockAfter(newLightBlock.Height)
+		if err != nil {
+			return fmt.Errorf("can't get signed header after height %d: %w", newLightBlock.Height, err)
+		}
+		err = c.backwards(ctx, nextBlock.Header, newLightBlock.Header)
 	}
+	c.metrics.VerificationDurationSeconds.With("path", path).Observe(time.Since(start).Seconds())
 	if err != nil {
+		c.metrics.Verifications.With("path", path, "status", "failure").Add(1)
 		c.logger.Error("Can't verify", "err", err)
 		return err
 	}
+	c.metrics.Verifications.With("path", path, "status", "success").Add(1)
 
 	// Once verified, save and return
 	return c.updateTrustedLightBlock(newLightBlock)
@@ -873,7 +1010,9 @@ func (c *Client) Witnesses() []provider.Provider {
 func (c *Client) Cleanup() error {
 	c.logger.Info("Removing all the data")
 	c.latestTrustedBlock = nil
-	return c.trustedStore.Prune(0)
+	err := c.trustedStore.Prune(0)
+	c.updateStoreMetrics()
+	return err
 }
 
 // cleanupAfter deletes all headers & validator sets after +height+. It also
@@ -924,9 +1063,23 @@ func (c *Client) updateTrustedLightBlock(l *types.LightBlock) error {
 		c.latestTrustedBlock = l
 	}
 
+	if c.pruningAge > 0 {
+		if err := c.trustedStore.PruneOlderThan(c.latestTrustedBlock.Time.Add(-c.pruningAge)); err != nil {
+			return fmt.Errorf("prune by age: %w", err)
+		}
+	}
+	c.updateStoreMetrics()
+
 	return nil
 }
 
+func (c *Client) updateStoreMetrics() {
+	c.metrics.TrustedStoreSize.Set(float64(c.trustedStore.Size()))
+	if c.latestTrustedBlock != nil {
+		c.metrics.LatestTrustedHeight.Set(float64(c.latestTrustedBlock.Height))
+	}
+}
+
 // backwards verification (see VerifyHeaderBackwards func in the spec) verifies
 // headers before a trusted header. If a sent header is invalid the primary is
 // replaced with another provider and the operation is repeated.
@@ -1015,22 +1168,124 @@ func (c *Client) lightBlockFromPrimary(ctx context.Context, height int64) (*type
 	}
 }
 
+// removeWitnesses removes the witnesses of the given indexes, replacing them
+// with providers of the witness pool, if any, appended to the witnesses. It
+// returns the number of witnesses recruited.
+//
 // NOTE: requires a providerMutex lock
-func (c *Client) removeWitnesses(indexes []int) error {
+func (c *Client) removeWitnesses(indexes []int) (int, error) {
 	// check that we will still have witnesses remaining
-	if len(c.witnesses) <= len(indexes) {
-		return ErrNoWitnesses
+	if len(c.witnesses)+len(c.witnessPool) <= len(indexes) {
+		return 0, ErrNoWitnesses
+	}
+
+	c.dropWitnesses(indexes)
+	return c.recruitWitnesses(len(indexes)), nil
+}
+
+// dropWitnesses removes the witnesses of the given indexes, keeping the order
+// of the others, and returns them.
+//
+// NOTE: requires a providerMutex lock
+func (c *Client) dropWitnesses(indexes []int) []provider.Provider {
+	drop := make(map[int]bool, len(indexes))
+	for _, i := range indexes {
+		drop[i] = true
 	}
 
-	// we need to make sure that we remove witnesses by index in the reverse
-	// order so as to not affect the indexes themselves
-	sort.Ints(indexes)
-	for i := len(indexes) - 1; i >= 0; i-- {
-		c.witnesses[indexes[i]] = c.witnesses[len(c.witnesses)-1]
-		c.witnesses = c.witnesses[:len(c.witnesses)-1]
+	c.witnessFailuresMtx.Lock()
+	defer c.witnessFailuresMtx.Unlock()
+
+	var (
+		dropped   = make([]provider.Provider, 0, len(indexes))
+		witnesses = make([]provider.Provider, 0, len(c.witnesses))
+	)
+	for i, witness := range c.witnesses {
+		if drop[i] {
+			dropped = append(dropped, witness)
+			delete(c.witnessFailures, witness)
+		} else {
+			witnesses = append(witnesses, witness)
+		}
 	}
+	c.witnesses = witnesses
 
-	return nil
+	return dropped
+}
+
+// recruitWitnesses moves up to n providers from the front of the witness pool
+// to the back of the witnesses, and returns their number.
+//
+// NOTE: requires a providerMutex lock
+func (c *Client) recruitWitnesses(n int) int {
+	recruited := 0
+	for ; recruited < n && len(c.witnessPool) > 0; recruited++ {
+		witness := c.witnessPool[0]
+		c.witnessPool = c.witnessPool[1:]
+		c.witnesses = append(c.witnesses, witness)
+		c.logger.Info("Recruited witness from the pool", "witness", witness)
+	}
+	c.metrics.WitnessesRecruited.Add(float64(recruited))
+	c.updateWitnessMetrics()
+	return recruited
+}
+
+// demoteFailingWitnesses moves the witnesses which failed maxWitnessFailures
+// requests in a row to the back of the witness pool, and replaces them with
+// providers from the front of the pool. Only as many witnesses as there are
+// providers in the pool are demoted, so that the number of witnesses does not
+// change. Without a pool, the witnesses are kept.
+//
+// NOTE: requires a providerMutex lock
+func (c *Client) demoteFailingWitnesses() {
+	if len(c.witnessPool) == 0 {
+		return
+	}
+
+	c.witnessFailuresMtx.Lock()
+	failing := make([]int, 0)
+	for i, witness := range c.witnesses {
+		if len(failing) < len(c.witnessPool) && c.witnessFailures[witness] >= c.maxWitnessFailures {
+			failing = append(failing, i)
+		}
+	}
+	c.witnessFailuresMtx.Unlock()
+
+	if len(failing) == 0 {
+		return
+	}
+
+	demoted := c.dropWitnesses(failing)
+	// the replacements are recruited before the demoted witnesses are added to
+	// the pool, so that they are only recruited again after the rest of it
+	c.recruitWitnesses(len(demoted))
+	for _, witness := range demoted {
+		c.logger.Info("Demoted witness failing to respond to the pool", "witness", witness)
+	}
+	c.witnessPool = append(c.witnessPool, demoted...)
+	c.metrics.WitnessesDemoted.Add(float64(len(demoted)))
+	c.updateWitnessMetrics()
+}
+
+// recordWitnessSuccess resets the failures of the witness.
+func (c *Client) recordWitnessSuccess(witness provider.Provider) {
+	c.witnessFailuresMtx.Lock()
+	defer c.witnessFailuresMtx.Unlock()
+	delete(c.witnessFailures, witness)
+}
+
+// recordWitnessFailure counts a request the witness did not respond to, or
+// did not have the light block of.
+func (c *Client) recordWitnessFailure(witness provider.Provider) {
+	c.witnessFailuresMtx.Lock()
+	defer c.witnessFailuresMtx.Unlock()
+	c.witnessFailures[witness]++
+	c.metrics.WitnessFailures.Add(1)
+}
+
+func (c *Client) updateWitnessMetrics() {
+	c.metrics.Witnesses.Set(float64(len(c.witnesses)))
+	c.metrics.WitnessPoolSize.Set(float64(len(c.witnessPool)))
 }
 
 type witnessResponse struct {
@@ -1095,9 +1350,10 @@ func (c *Client) findNewPrimary(ctx context.Context, height int64, remove bool)
 
 			// remove witnesses marked as bad (the client must do this before we alter the witness slice and change the indexes
 			// of witnesses). Removal is done in descending order
-			if err := c.removeWitnesses(witnessesToRemove); err != nil {
+			if _, err := c.removeWitnesses(witnessesToRemove); err != nil {
 				return nil, err
 			}
+			c.demoteFailingWitnesses()
 
 			// return the light block that new primary responded with
 			return response.lb, nil
@@ -1107,6 +1363,7 @@ func (c *Client) findNewPrimary(ctx context.Context, height int64, remove bool)
 			lastError = response.err
 			c.logger.Debug("error on light block request from witness",
 				"error", response.err, "primary", c.witnesses[response.witnessIndex])
+			c.recordWitnessFailure(c.witnesses[response.witnessIndex])
 			continue
 
 		// process malevolent errors like ErrUnreliableProvider and ErrBadLightBlock by removing the witness
@@ -1119,9 +1376,10 @@ func (c *Client) findNewPrimary(ctx context.Context, height int64, remove bool)
 	}
 
 	// remove witnesses marked as bad. Removal is done in descending order
-	if err := c.removeWitnesses(witnessesToRemove); err != nil {
+	if _, err := c.removeWitnesses(witnessesToRemove); err != nil {
 		c.logger.Error("failed to remove witnesses", "err", err, "witnessesToRemove", witnessesToRemove)
 	}
+	c.demoteFailingWitnesses()
 
 	return nil, lastError
 }
@@ -1176,9 +1434,10 @@ and remove witness. Otherwise, use the different primary`, e.WitnessIndex), "wit
 	}
 
 	// remove witnesses that have misbehaved
-	if err := c.removeWitnesses(witnessesToRemove); err != nil {
+	if _, err := c.removeWitnesses(witnessesToRemove); err != nil {
 		c.logger.Error("failed to remove witnesses", "err", err, "witnessesToRemove", witnessesToRemove)
 	}
+	c.demoteFailingWitnesses()
 
 	return nil
 }
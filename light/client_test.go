@@ -3,10 +3,14 @@ package light_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -378,6 +382,113 @@ func TestClient_SkippingVerification(t *testing.T) {
 	}
 }
 
+// The trusted validators of an aggregated commit can't verify its signature
+// once the validator set changed, but bisection still finds the headers
+// signed by enough of them.
+func TestClient_SkippingVerification_AggregatedCommits(t *testing.T) {
+	var (
+		blsKeys    = genBLSPrivKeys(4)
+		blsVals    = blsKeys.ToValidators(10, 1)
+		newBLSKeys = genBLSPrivKeys(4)
+		newBLSVals = newBLSKeys.ToValidators(10, 1)
+	)
+	aggregated := func(sh *types.SignedHeader) *types.SignedHeader {
+		commit, err := sh.Commit.AggregateSignatures()
+		require.NoError(t, err)
+		return &types.SignedHeader{Header: sh.Header, Commit: commit}
+	}
+
+	// The validator set changes 100% at height 2.
+	headers := map[int64]*types.SignedHeader{
+		1: aggregated(blsKeys.GenSignedHeader(chainID, 1, bTime, nil, blsVals, blsVals,
+			hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(blsKeys))),
+		2: aggregated(blsKeys.GenSignedHeader(chainID, 2, bTime.Add(1*time.Hour), nil, blsVals, newBLSVals,
+			hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(blsKeys))),
+		3: aggregated(newBLSKeys.GenSignedHeader(chainID, 3, bTime.Add(2*time.Hour), nil, newBLSVals, newBLSVals,
+			hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(newBLSKeys))),
+	}
+	vals := map[int64]*types.ValidatorSet{
+		1: blsVals,
+		2: blsVals,
+		3: newBLSVals,
+	}
+	primary := mockp.New(chainID, headers, vals)
+
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		light.TrustOptions{
+			Period: 4 * time.Hour,
+			Height: 1,
+			Hash:   headers[1].Hash(),
+		},
+		primary,
+		[]provider.Provider{mockp.New(chainID, headers, vals)},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.SkippingVerification(light.DefaultTrustLevel),
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+
+	l3, err := c.VerifyLightBlockAtHeight(ctx, 3, bTime.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, headers[3].Hash(), l3.Hash())
+	// The primary is not replaced for failing the verification.
+	assert.Equal(t, primary, c.Primary())
+}
+
+func TestClientVerificationMetrics(t *testing.T) {
+	testCases := []struct {
+		name      string
+		namespace string
+		mode      light.Option
+		path      string
+	}{
+		{"sequential", "light_test_sequential", light.SequentialVerification(), "sequential"},
+		{"skipping", "light_test_skipping", light.SkippingVerification(light.DefaultTrustLevel), "skipping"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := light.NewClient(
+				ctx,
+				chainID,
+				trustOptions,
+				fullNode,
+				[]provider.Provider{fullNode},
+				dbs.New(dbm.NewMemDB(), chainID),
+				tc.mode,
+				light.WithMetrics(light.PrometheusMetrics(tc.namespace)),
+			)
+			require.NoError(t, err)
+
+			_, err = c.VerifyLightBlockAtHeight(ctx, 3, bTime.Add(3*time.Hour))
+			require.NoError(t, err)
+
+			// The verification is counted under the path of the mode, and the
+			// store holds the trusted and the verified light blocks.
+			expected := fmt.Sprintf(`
+# HELP %[1]s_light_verifications Number of verifications of light blocks, by path (sequential, skipping or backwards) and status (success or failure).
+# TYPE %[1]s_light_verifications counter
+%[1]s_light_verifications{path="%[2]s",status="success"} 1
+# HELP %[1]s_light_trusted_store_size Number of light blocks in the trusted store.
+# TYPE %[1]s_light_trusted_store_size gauge
+%[1]s_light_trusted_store_size 2
+# HELP %[1]s_light_latest_trusted_height Height of the latest trusted light block.
+# TYPE %[1]s_light_latest_trusted_height gauge
+%[1]s_light_latest_trusted_height 3
+`, tc.namespace, tc.path)
+			err = testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(expected),
+				tc.namespace+"_light_verifications",
+				tc.namespace+"_light_trusted_store_size",
+				tc.namespace+"_light_latest_trusted_height",
+			)
+			require.NoError(t, err)
+		})
+	}
+}
+
 // start from a large light block to make sure that the pivot height doesn't select a height outside
 // the appropriate range
 func TestClientLargeBisectionVerification(t *testing.T) {
@@ -826,9 +937,20 @@ func TestClient_BackwardsVerification(t *testing.T) {
 		assert.Error(t, err)
 
 		// 5) Try bisection method, but closest header (at 7) has expired
-		// so expect error
-		_, err = c.VerifyLightBlockAtHeight(ctx, 8, bTime.Add(12*time.Minute))
-		assert.Error(t, err)
+		// so verify backwards from the next trusted header (at 9) instead
+		h, err = c.VerifyLightBlockAtHeight(ctx, 8, bTime.Add(12*time.Minute))
+		require.NoError(t, err)
+		if assert.NotNil(t, h) {
+			assert.EqualValues(t, 8, h.Height)
+		}
+
+		// 6) Headers are verified backwards long after the trusting period of
+		// the trusted headers
+		h, err = c.VerifyLightBlockAtHeight(ctx, 1, bTime.Add(time.Hour))
+		require.NoError(t, err)
+		if assert.NotNil(t, h) {
+			assert.EqualValues(t, 1, h.Height)
+		}
 
 	}
 	{
@@ -968,6 +1090,84 @@ func TestClientRemovesWitnessIfItSendsUsIncorrectHeader(t *testing.T) {
 	assert.EqualValues(t, 1, len(c.Witnesses()))
 }
 
+func TestClientRecruitsWitnessFromPoolWhenRemovingWitness(t *testing.T) {
+	// different headers hash then primary plus less than 1/3 signed (no fork)
+	badProvider := mockp.New(
+		chainID,
+		map[int64]*types.SignedHeader{
+			1: h1,
+			2: keys.GenSignedHeaderLastBlockID(chainID, 2, bTime.Add(30*time.Minute), nil, vals, vals,
+				hash("app_hash2"), hash("cons_hash"), hash("results_hash"),
+				len(keys), len(keys), types.BlockID{Hash: h1.Hash()}),
+		},
+		map[int64]*types.ValidatorSet{
+			1: vals,
+			2: vals,
+		},
+	)
+
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		trustOptions,
+		fullNode,
+		[]provider.Provider{badProvider},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+		light.WitnessPool([]provider.Provider{fullNode}),
+	)
+	require.NoError(t, err)
+
+	// witness behaves incorrectly -> replaced with the witness of the pool
+	_, err = c.VerifyLightBlockAtHeight(ctx, 2, bTime.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []provider.Provider{fullNode}, c.Witnesses())
+
+	// the recruited witness cross-checks the primary
+	l, err := c.VerifyLightBlockAtHeight(ctx, 3, bTime.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, l.Height)
+}
+
+func TestClientDemotesFailingWitnessToPool(t *testing.T) {
+	// witness doesn't have the second light block
+	partialNode := mockp.New(
+		chainID,
+		map[int64]*types.SignedHeader{1: h1, 3: h3},
+		map[int64]*types.ValidatorSet{1: vals, 3: vals},
+	)
+
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		trustOptions,
+		fullNode,
+		[]provider.Provider{partialNode, deadNode},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+		light.WitnessPool([]provider.Provider{fullNode}),
+		light.MaxWitnessFailures(2),
+	)
+	require.NoError(t, err)
+	// the dead witness failed once
+	assert.Equal(t, []provider.Provider{partialNode, deadNode}, c.Witnesses())
+
+	// no witness has the light block -> the dead witness failed twice and is
+	// demoted, the partial witness is kept
+	_, err = c.VerifyLightBlockAtHeight(ctx, 2, bTime.Add(2*time.Hour))
+	assert.Equal(t, light.ErrFailedHeaderCrossReferencing, err)
+	assert.Equal(t, []provider.Provider{partialNode, fullNode}, c.Witnesses())
+
+	// the recruited witness cross-checks the primary
+	l, err := c.VerifyLightBlockAtHeight(ctx, 2, bTime.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, l.Height)
+
+	// the partial witness failed twice, but the pool only has the dead
+	// witness, which is recruited again
+	assert.Equal(t, []provider.Provider{fullNode, deadNode}, c.Witnesses())
+}
+
 func TestClient_TrustedValidatorSet(t *testing.T) {
 	differentVals, _ := types.RandValidatorSet(10, 100)
 	badValSetNode := mockp.New(
@@ -1028,6 +1228,36 @@ func TestClientPrunesHeadersAndValidatorSets(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestClientPrunesHeadersAndValidatorSetsByAge(t *testing.T) {
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		trustOptions,
+		fullNode,
+		[]provider.Provider{fullNode},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+		light.PruningAge(45*time.Minute),
+	)
+	require.NoError(t, err)
+
+	h, err := c.VerifyLightBlockAtHeight(ctx, 2, bTime.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), h.Height)
+	// 30 minutes older than the latest light block
+	_, err = c.TrustedLightBlock(1)
+	require.NoError(t, err)
+
+	h, err = c.Update(ctx, bTime.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(3), h.Height)
+	// 1 hour older than the latest light block
+	_, err = c.TrustedLightBlock(1)
+	assert.Error(t, err)
+	_, err = c.TrustedLightBlock(2)
+	assert.NoError(t, err)
+}
+
 func TestClientEnsureValidHeadersAndValSets(t *testing.T) {
 	emptyValSet := &types.ValidatorSet{
 		Validators: nil,
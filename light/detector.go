@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/cometbft/cometbft/light/provider"
@@ -25,6 +26,10 @@ import (
 //
 // If there are no conflicting headers, the light client deems the verified target header
 // trusted and saves it to the trusted store.
+//
+// The misbehaving witnesses are replaced with providers of the witness pool, which are
+// compared with in turn if no witness matched the header, and the witnesses failing to
+// respond too many times in a row are demoted to the pool.
 func (c *Client) detectDivergence(ctx context.Context, primaryTrace []*types.LightBlock, now time.Time) error {
 	if primaryTrace == nil || len(primaryTrace) < 2 {
 		return errors.New("nil or single block primary trace")
@@ -44,55 +49,68 @@ func (c *Client) detectDivergence(ctx context.Context, primaryTrace []*types.Lig
 		return ErrNoWitnesses
 	}
 
-	// launch one goroutine per witness to retrieve the light block of the target height
-	// and compare it with the header from the primary
-	errc := make(chan error, len(c.witnesses))
-	for i, witness := range c.witnesses {
-		go c.compareNewHeaderWithWitness(ctx, errc, lastVerifiedHeader, witness, i)
-	}
+	// compare the header with the witnesses, then with the witnesses recruited
+	// from the pool to replace the removed ones, until one of them matches
+	for from := 0; ; {
+		// launch one goroutine per witness to retrieve the light block of the target height
+		// and compare it with the header from the primary
+		errc := make(chan error, len(c.witnesses)-from)
+		for i := from; i < len(c.witnesses); i++ {
+			go c.compareNewHeaderWithWitness(ctx, errc, lastVerifiedHeader, c.witnesses[i], i)
+		}
 
-	// handle errors from the header comparisons as they come in
-	for i := 0; i < cap(errc); i++ {
-		err := <-errc
-
-		switch e := err.(type) {
-		case nil: // at least one header matched
-			headerMatched = true
-		case errConflictingHeaders:
-			// We have conflicting headers. This could possibly imply an attack on the light client.
-			// First we need to verify the witness's header using the same skipping verification and then we
-			// need to find the point that the headers diverge and examine this for any evidence of an attack.
-			//
-			// We combine these actions together, verifying the witnesses headers and outputting the trace
-			// which captures the bifurcation point and if successful provides the information to create valid evidence.
-			err := c.handleConflictingHeaders(ctx, primaryTrace, e.Block, e.WitnessIndex, now)
-			if err != nil {
-				// return information of the attack
-				return err
-			}
-			// if attempt to generate conflicting headers failed then remove witness
-			witnessesToRemove = append(witnessesToRemove, e.WitnessIndex)
-
-		case errBadWitness:
-			// these are all malevolent errors and should result in removing the
-			// witness
-			c.logger.Info("witness returned an error during header comparison, removing...",
-				"witness", c.witnesses[e.WitnessIndex], "err", err)
-			witnessesToRemove = append(witnessesToRemove, e.WitnessIndex)
-		default:
-			// Benign errors which can be ignored unless there was a context
-			// canceled
-			if errors.Is(e, context.Canceled) || errors.Is(e, context.DeadlineExceeded) {
-				return e
+		// handle errors from the header comparisons as they come in
+		for i := 0; i < cap(errc); i++ {
+			err := <-errc
+
+			switch e := err.(type) {
+			case nil: // at least one header matched
+				headerMatched = true
+			case errConflictingHeaders:
+				// We have conflicting headers. This could possibly imply an attack on the light client.
+				// First we need to verify the witness's header using the same skipping verification and then we
+				// need to find the point that the headers diverge and examine this for any evidence of an attack.
+				//
+				// We combine these actions together, verifying the witnesses headers and outputting the trace
+				// which captures the bifurcation point and if successful provides the information to create valid evidence.
+				err := c.handleConflictingHeaders(ctx, primaryTrace, e.Block, e.WitnessIndex, now)
+				if err != nil {
+					// return information of the attack
+					return err
+				}
+				// if attempt to generate conflicting headers failed then remove witness
+				witnessesToRemove = append(witnessesToRemove, e.WitnessIndex)
+
+			case errBadWitness:
+				// these are all malevolent errors and should result in removing the
+				// witness
+				c.logger.Info("witness returned an error during header comparison, removing...",
+					"witness", c.witnesses[e.WitnessIndex], "err", err)
+				witnessesToRemove = append(witnessesToRemove, e.WitnessIndex)
+			default:
+				// Benign errors which can be ignored unless there was a context
+				// canceled
+				if errors.Is(e, context.Canceled) || errors.Is(e, context.DeadlineExceeded) {
+					return e
+				}
+				c.logger.Info("error in light block request to witness", "err", err)
 			}
-			c.logger.Info("error in light block request to witness", "err", err)
 		}
-	}
 
-	// remove witnesses that have misbehaved
-	if err := c.removeWitnesses(witnessesToRemove); err != nil {
-		return err
+		// remove witnesses that have misbehaved
+		recruited, err := c.removeWitnesses(witnessesToRemove)
+		if err != nil {
+			return err
+		}
+		if headerMatched || recruited == 0 {
+			break
+		}
+		// the recruited witnesses are appended to the witnesses
+		from = len(c.witnesses) - recruited
+		witnessesToRemove = witnessesToRemove[:0]
 	}
+	// replace the witnesses which keep failing to respond
+	c.demoteFailingWitnesses()
 
 	// 1. If we had at least one witness that returned the same header then we
 	// conclude that we can trust the header
@@ -104,17 +122,29 @@ func (c *Client) detectDivergence(ctx context.Context, primaryTrace []*types.Lig
 	return ErrFailedHeaderCrossReferencing
 }
 
-// compareNewHeaderWithWitness takes the verified header from the primary and compares it with a
-// header from a specified witness. The function can return one of three errors:
+// compareNewHeaderWithWitness compares the header with the one of the witness,
+// records the result in the witness failures and the metrics and sends it to
+// errc. See compareHeaderWithWitness.
+func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan error, h *types.SignedHeader,
+	witness provider.Provider, witnessIndex int) {
+
+	err := c.compareHeaderWithWitness(ctx, h, witness, witnessIndex)
+	c.recordComparison(witness, err)
+	errc <- err
+}
+
+// compareHeaderWithWitness takes the verified header from the primary and compares it with a
+// header from a specified witness. The function can return one of four errors:
 //
 // 1: errConflictingHeaders -> there may have been an attack on this light client
-// 2: errBadWitness -> the witness has either not responded, doesn't have the header or has given us an invalid one
+// 2: errBadWitness -> the witness has given us an invalid header
 //
 //	Note: In the case of an invalid header we remove the witness
 //
-// 3: nil -> the hashes of the two headers match
-func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan error, h *types.SignedHeader,
-	witness provider.Provider, witnessIndex int) {
+// 3: benign errors -> the witness has not responded or doesn't have the header
+// 4: nil -> the hashes of the two headers match
+func (c *Client) compareHeaderWithWitness(ctx context.Context, h *types.SignedHeader,
+	witness provider.Provider, witnessIndex int) error {
 
 	lightBlock, err := witness.LightBlock(ctx, h.Height)
 	switch err {
@@ -125,8 +155,7 @@ func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan erro
 	// the witness hasn't been helpful in comparing headers, we mark the response and continue
 	// comparing with the rest of the witnesses
 	case provider.ErrNoResponse, provider.ErrLightBlockNotFound, context.DeadlineExceeded, context.Canceled:
-		errc <- err
-		return
+		return err
 
 	// the witness' head of the blockchain is lower than the height of the primary. This could be one of
 	// two things:
@@ -137,8 +166,7 @@ func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan erro
 		var isTargetHeight bool
 		isTargetHeight, lightBlock, err = c.getTargetBlockOrLatest(ctx, h.Height, witness)
 		if err != nil {
-			errc <- err
-			return
+			return err
 		}
 
 		// if the witness caught up and has returned a block of the target height then we can
@@ -150,8 +178,7 @@ func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan erro
 		// witness' last header is below the primary's header. We check the times to see if the blocks
 		// have conflicting times
 		if !lightBlock.Time.Before(h.Time) {
-			errc <- errConflictingHeaders{Block: lightBlock, WitnessIndex: witnessIndex}
-			return
+			return errConflictingHeaders{Block: lightBlock, WitnessIndex: witnessIndex}
 		}
 
 		// the witness is behind. We wait for a period WAITING = 2 * DRIFT + LAG.
@@ -162,11 +189,9 @@ func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan erro
 		isTargetHeight, lightBlock, err = c.getTargetBlockOrLatest(ctx, h.Height, witness)
 		if err != nil {
 			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-				errc <- err
-			} else {
-				errc <- errBadWitness{Reason: err, WitnessIndex: witnessIndex}
+				return err
 			}
-			return
+			return errBadWitness{Reason: err, WitnessIndex: witnessIndex}
 		}
 		if isTargetHeight {
 			break
@@ -175,8 +200,7 @@ func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan erro
 		// the witness still doesn't have a block at the height of the primary.
 		// Check if there is a conflicting time
 		if !lightBlock.Time.Before(h.Time) {
-			errc <- errConflictingHeaders{Block: lightBlock, WitnessIndex: witnessIndex}
-			return
+			return errConflictingHeaders{Block: lightBlock, WitnessIndex: witnessIndex}
 		}
 
 		// Following this request response procedure, the witness has been unable to produce a block
@@ -186,30 +210,84 @@ func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan erro
 		// NOTE: If the clock drift / lag has been miscalibrated it is feasible that the light client has
 		// drifted too far ahead for any witness to be able provide a comparable block and thus may allow
 		// for a malicious primary to attack it
-		errc <- provider.ErrNoResponse
-		return
+		return provider.ErrNoResponse
 
 	default:
 		// all other errors (i.e. invalid block, closed connection or unreliable provider) we mark the
 		// witness as bad and remove it
-		errc <- errBadWitness{Reason: err, WitnessIndex: witnessIndex}
-		return
+		return errBadWitness{Reason: err, WitnessIndex: witnessIndex}
 	}
 
 	if !bytes.Equal(h.Hash(), lightBlock.Hash()) {
-		errc <- errConflictingHeaders{Block: lightBlock, WitnessIndex: witnessIndex}
+		return errConflictingHeaders{Block: lightBlock, WitnessIndex: witnessIndex}
 	}
 
 	c.logger.Debug("Matching header received by witness", "height", h.Height, "witness", witnessIndex)
-	errc <- nil
+	return nil
+}
+
+// recordComparison records the result of a header comparison in the failures
+// of the witness and in the metrics.
+func (c *Client) recordComparison(witness provider.Provider, err error) {
+	switch err.(type) {
+	case nil:
+		c.metrics.WitnessAgreements.Add(1)
+		c.recordWitnessSuccess(witness)
+	case errConflictingHeaders:
+		c.metrics.WitnessConflicts.Add(1)
+	case errBadWitness:
+		// the witness is removed
+	default:
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return
+		}
+		c.recordWitnessFailure(witness)
+	}
 }
 
-// sendEvidence sends evidence to a provider on a best effort basis.
+// sendEvidence submits evidence, concurrently, to the receiver and to the
+// evidence receivers, on a best effort basis. It returns once the evidence is
+// submitted to all of them, or the attempts at submitting it are exhausted.
 func (c *Client) sendEvidence(ctx context.Context, ev *types.LightClientAttackEvidence, receiver provider.Provider) {
-	err := receiver.ReportEvidence(ctx, ev)
-	if err != nil {
-		c.logger.Error("Failed to report evidence to provider", "ev", ev, "provider", receiver)
+	var wg sync.WaitGroup
+	for _, r := range append([]provider.Provider{receiver}, c.evidenceReceivers...) {
+		wg.Add(1)
+		go func(r provider.Provider) {
+			defer wg.Done()
+			c.submitEvidence(ctx, ev, r)
+		}(r)
 	}
+	wg.Wait()
+}
+
+// submitEvidence submits evidence to a provider, in up to evidenceAttempts
+// attempts. Every attempt is logged, for the operators to audit the
+// submissions.
+func (c *Client) submitEvidence(ctx context.Context, ev *types.LightClientAttackEvidence, receiver provider.Provider) {
+attempts:
+	for attempt := 1; ; attempt++ {
+		err := receiver.ReportEvidence(ctx, ev)
+		if err == nil {
+			c.logger.Info("Submitted light client attack evidence", "evidence", ev.Hash(), "height", ev.Height(),
+				"provider", receiver, "attempt", attempt)
+			c.metrics.EvidenceSubmitted.Add(1)
+			return
+		}
+		c.logger.Error("Failed to report evidence to provider", "evidence", ev.Hash(), "height", ev.Height(),
+			"provider", receiver, "attempt", attempt, "err", err)
+
+		if attempt >= int(c.evidenceAttempts) {
+			break
+		}
+		select {
+		case <-time.After(c.evidenceRetryInterval):
+		case <-ctx.Done():
+			break attempts
+		}
+	}
+
+	c.logger.Error("Gave up submitting light client attack evidence", "evidence", ev.Hash(), "provider", receiver)
+	c.metrics.EvidenceSubmissionFailures.Add(1)
 }
 
 // handleConflictingHeaders handles the primary style of attack, which is where a primary and witness have
@@ -1,6 +1,9 @@
 package light_test
 
 import (
+	"context"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -89,6 +92,102 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 	assert.True(t, primary.HasEvidence(evAgainstWitness))
 }
 
+// flakyEvidenceReceiver fails to receive the first evidence reported.
+type flakyEvidenceReceiver struct {
+	*mockp.Mock
+
+	mtx      sync.Mutex
+	failures int
+	reports  int
+}
+
+func (p *flakyEvidenceReceiver) ReportEvidence(ctx context.Context, ev types.Evidence) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.reports++
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("unavailable")
+	}
+	return p.Mock.ReportEvidence(ctx, ev)
+}
+
+func TestLightClientAttackEvidence_SubmittedToReceivers(t *testing.T) {
+	// primary performs a lunatic attack
+	var (
+		latestHeight      = int64(10)
+		valSize           = 5
+		divergenceHeight  = int64(6)
+		primaryHeaders    = make(map[int64]*types.SignedHeader, latestHeight)
+		primaryValidators = make(map[int64]*types.ValidatorSet, latestHeight)
+	)
+
+	witnessHeaders, witnessValidators, chainKeys := genMockNodeWithKeys(chainID, latestHeight, valSize, 2, bTime)
+	witness := mockp.New(chainID, witnessHeaders, witnessValidators)
+	forgedKeys := chainKeys[divergenceHeight-1].ChangeKeys(3) // we change 3 out of the 5 validators (still 2/5 remain)
+	forgedVals := forgedKeys.ToValidators(2, 0)
+
+	for height := int64(1); height <= latestHeight; height++ {
+		if height < divergenceHeight {
+			primaryHeaders[height] = witnessHeaders[height]
+			primaryValidators[height] = witnessValidators[height]
+			continue
+		}
+		primaryHeaders[height] = forgedKeys.GenSignedHeader(chainID, height, bTime.Add(time.Duration(height)*time.Minute),
+			nil, forgedVals, forgedVals, hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(forgedKeys))
+		primaryValidators[height] = forgedVals
+	}
+	primary := mockp.New(chainID, primaryHeaders, primaryValidators)
+
+	// full nodes receiving the evidence, the first one after a failed attempt,
+	// the second one never
+	receiver := &flakyEvidenceReceiver{Mock: mockp.New(chainID, witnessHeaders, witnessValidators), failures: 1}
+	deadReceiver := &flakyEvidenceReceiver{Mock: mockp.New(chainID, witnessHeaders, witnessValidators), failures: 100}
+
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		light.TrustOptions{
+			Period: 4 * time.Hour,
+			Height: 1,
+			Hash:   primaryHeaders[1].Hash(),
+		},
+		primary,
+		[]provider.Provider{witness},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+		light.MaxRetryAttempts(1),
+		light.EvidenceReceivers([]provider.Provider{receiver, deadReceiver}),
+		light.EvidenceAttempts(3, time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	_, err = c.VerifyLightBlockAtHeight(ctx, 10, bTime.Add(1*time.Hour))
+	require.Equal(t, light.ErrLightClientAttack, err)
+
+	evAgainstPrimary := &types.LightClientAttackEvidence{
+		ConflictingBlock: &types.LightBlock{
+			SignedHeader: primaryHeaders[10],
+			ValidatorSet: primaryValidators[10],
+		},
+		CommonHeight: 4,
+	}
+	evAgainstWitness := &types.LightClientAttackEvidence{
+		ConflictingBlock: &types.LightBlock{
+			SignedHeader: witnessHeaders[7],
+			ValidatorSet: witnessValidators[7],
+		},
+		CommonHeight: 4,
+	}
+	assert.True(t, witness.HasEvidence(evAgainstPrimary))
+	assert.True(t, primary.HasEvidence(evAgainstWitness))
+	// the receivers get both evidence
+	assert.True(t, receiver.HasEvidence(evAgainstPrimary))
+	assert.True(t, receiver.HasEvidence(evAgainstWitness))
+	assert.Equal(t, 3, receiver.reports)
+	assert.Equal(t, 6, deadReceiver.reports)
+}
+
 func TestLightClientAttackEvidence_Equivocation(t *testing.T) {
 	verificationOptions := map[string]light.Option{
 		"sequential": light.SequentialVerification(),
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
@@ -31,6 +32,16 @@ func genPrivKeys(n int) privKeys {
 	return res
 }
 
+// genBLSPrivKeys produces an array of BLS12-381 private keys to generate
+// commits whose signatures can be aggregated.
+func genBLSPrivKeys(n int) privKeys {
+	res := make(privKeys, n)
+	for i := range res {
+		res[i] = bls12381.GenPrivKey()
+	}
+	return res
+}
+
 // // Change replaces the key at index i.
 // func (pkz privKeys) Change(i int) privKeys {
 // 	res := make(privKeys, len(pkz))
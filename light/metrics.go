@@ -0,0 +1,63 @@
+package light
+
+import (
+	"github.com/go-kit/kit/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "light"
+)
+
+//go:generate go run ../scripts/metricsgen -struct=Metrics
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Duration of the verifications of light blocks, including the
+	// cross-checking with the witnesses, by path: sequential, skipping or
+	// backwards.
+	VerificationDurationSeconds metrics.Histogram `metrics_labels:"path" metrics_buckettype:"exprange" metrics_bucketsizes:"0.01, 100, 10"`
+
+	// Number of verifications of light blocks, by path (sequential, skipping
+	// or backwards) and status (success or failure).
+	Verifications metrics.Counter `metrics_labels:"path, status"`
+
+	// Number of light blocks in the trusted store.
+	TrustedStoreSize metrics.Gauge
+
+	// Height of the latest trusted light block.
+	LatestTrustedHeight metrics.Gauge
+
+	// Number of witnesses cross-checking the primary.
+	Witnesses metrics.Gauge
+
+	// Number of providers in the witness pool, recruited as witnesses when
+	// a witness is removed or demoted.
+	WitnessPoolSize metrics.Gauge
+
+	// Number of header comparisons where the witness agreed with the primary.
+	WitnessAgreements metrics.Counter
+
+	// Number of header comparisons where the witness returned a header
+	// conflicting with the primary.
+	WitnessConflicts metrics.Counter
+
+	// Number of requests to witnesses which did not respond or did not have
+	// the light block.
+	WitnessFailures metrics.Counter
+
+	// Number of witnesses moved back to the witness pool after failing too
+	// many requests in a row.
+	WitnessesDemoted metrics.Counter
+
+	// Number of providers of the witness pool recruited as witnesses.
+	WitnessesRecruited metrics.Counter
+
+	// Number of light client attack evidence submitted to providers.
+	EvidenceSubmitted metrics.Counter
+
+	// Number of light client attack evidence which could not be submitted to
+	// a provider after all the attempts.
+	EvidenceSubmissionFailures metrics.Counter
+}
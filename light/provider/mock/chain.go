@@ -0,0 +1,169 @@
+package mock
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmtversion "github.com/cometbft/cometbft/proto/tendermint/version"
+	"github.com/cometbft/cometbft/types"
+	"github.com/cometbft/cometbft/version"
+)
+
+// Chain is a chain of light blocks signed by a fixed set of validators, to
+// feed mock providers in tests of light client consumers.
+type Chain struct {
+	ChainID string
+	Headers map[int64]*types.SignedHeader
+	Vals    map[int64]*types.ValidatorSet
+
+	keys     []crypto.PrivKey
+	valSet   *types.ValidatorSet
+	start    time.Time
+	interval time.Duration
+}
+
+// GenChain generates a chain of the given number of blocks, signed by
+// numVals validators of equal power. The first block has the given time, and
+// the following ones are interval apart.
+func GenChain(chainID string, numBlocks int64, numVals int, start time.Time, interval time.Duration) *Chain {
+	if numBlocks < 1 || numVals < 1 {
+		panic(fmt.Sprintf("invalid chain of %d blocks and %d validators", numBlocks, numVals))
+	}
+	keys := make([]crypto.PrivKey, numVals)
+	vals := make([]*types.Validator, numVals)
+	for i := range keys {
+		keys[i] = ed25519.GenPrivKey()
+		vals[i] = types.NewValidator(keys[i].PubKey(), 10)
+	}
+	c := &Chain{
+		ChainID:  chainID,
+		Headers:  make(map[int64]*types.SignedHeader, numBlocks),
+		Vals:     make(map[int64]*types.ValidatorSet, numBlocks),
+		keys:     keys,
+		valSet:   types.NewValidatorSet(vals),
+		start:    start,
+		interval: interval,
+	}
+	c.extend(1, numBlocks, tmhash.Sum([]byte("app_hash")))
+	return c
+}
+
+// Height returns the height of the last block of the chain.
+func (c *Chain) Height() int64 {
+	return int64(len(c.Headers))
+}
+
+// LightBlock returns the light block of the chain at the given height, or
+// nil if there is none.
+func (c *Chain) LightBlock(height int64) *types.LightBlock {
+	sh, ok := c.Headers[height]
+	if !ok {
+		return nil
+	}
+	return &types.LightBlock{SignedHeader: sh, ValidatorSet: c.Vals[height]}
+}
+
+// Fork returns a copy of the chain which diverges from it at the given
+// height: the blocks from that height on have a different app hash, and are
+// signed by the same validators. A light client seeing both chains detects
+// an attack.
+func (c *Chain) Fork(height int64) *Chain {
+	if height < 2 || height > c.Height() {
+		panic(fmt.Sprintf("cannot fork a chain of height %d at height %d", c.Height(), height))
+	}
+	fork := &Chain{
+		ChainID:  c.ChainID,
+		Headers:  make(map[int64]*types.SignedHeader, len(c.Headers)),
+		Vals:     make(map[int64]*types.ValidatorSet, len(c.Vals)),
+		keys:     c.keys,
+		valSet:   c.valSet,
+		start:    c.start,
+		interval: c.interval,
+	}
+	for h := int64(1); h < height; h++ {
+		fork.Headers[h] = c.Headers[h]
+		fork.Vals[h] = c.Vals[h]
+	}
+	fork.extend(height, c.Height(), tmhash.Sum([]byte(fmt.Sprintf("fork_app_hash_%d", height))))
+	return fork
+}
+
+// Extend adds the given number of blocks to the chain.
+func (c *Chain) Extend(numBlocks int64) {
+	from := c.Height() + 1
+	c.extend(from, from+numBlocks-1, c.Headers[from-1].AppHash)
+}
+
+// Provider returns a mock provider serving the blocks of the chain.
+func (c *Chain) Provider() *Mock {
+	headers := make(map[int64]*types.SignedHeader, len(c.Headers))
+	vals := make(map[int64]*types.ValidatorSet, len(c.Vals))
+	for h, sh := range c.Headers {
+		headers[h] = sh
+		vals[h] = c.Vals[h]
+	}
+	return New(c.ChainID, headers, vals)
+}
+
+// extend generates the blocks in the heights [from, to] with the given app
+// hash.
+func (c *Chain) extend(from, to int64, appHash []byte) {
+	for h := from; h <= to; h++ {
+		header := &types.Header{
+			Version:            cmtversion.Consensus{Block: version.BlockProtocol, App: 0},
+			ChainID:            c.ChainID,
+			Height:             h,
+			Time:               c.start.Add(time.Duration(h-1) * c.interval),
+			ValidatorsHash:     c.valSet.Hash(),
+			NextValidatorsHash: c.valSet.Hash(),
+			DataHash:           types.Txs{}.Hash(),
+			AppHash:            appHash,
+			ConsensusHash:      tmhash.Sum([]byte("cons_hash")),
+			LastResultsHash:    tmhash.Sum([]byte("results_hash")),
+			ProposerAddress:    c.valSet.Validators[0].Address,
+		}
+		if prev, ok := c.Headers[h-1]; ok {
+			header.LastBlockID = types.BlockID{Hash: prev.Hash()}
+		}
+		c.Headers[h] = &types.SignedHeader{Header: header, Commit: c.sign(header)}
+		c.Vals[h] = c.valSet
+	}
+}
+
+// sign returns a commit for the header signed by all the validators.
+func (c *Chain) sign(header *types.Header) *types.Commit {
+	blockID := types.BlockID{
+		Hash:          header.Hash(),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: make([]byte, tmhash.Size)},
+	}
+	sigs := make([]types.CommitSig, c.valSet.Size())
+	for _, key := range c.keys {
+		addr := key.PubKey().Address()
+		idx, _ := c.valSet.GetByAddress(addr)
+		vote := &types.Vote{
+			Type:             cmtproto.PrecommitType,
+			Height:           header.Height,
+			Round:            1,
+			BlockID:          blockID,
+			Timestamp:        header.Time,
+			ValidatorAddress: addr,
+			ValidatorIndex:   idx,
+		}
+		sig, err := key.Sign(types.VoteSignBytes(header.ChainID, vote.ToProto()))
+		if err != nil {
+			panic(err)
+		}
+		vote.Signature = sig
+		sigs[idx] = vote.CommitSig()
+	}
+	return &types.Commit{
+		Height:     header.Height,
+		Round:      1,
+		BlockID:    blockID,
+		Signatures: sigs,
+	}
+}
@@ -0,0 +1,39 @@
+package mock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is an adjustable clock, to pass as the current time to the light
+// client in tests, for example to make the trusted header expire.
+type Clock struct {
+	mtx sync.Mutex
+	now time.Time
+}
+
+// NewClock returns a clock set to the given time.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns the time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.now
+}
+
+// Set sets the time of the clock.
+func (c *Clock) Set(now time.Time) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.now = now
+}
+
+// Advance moves the clock forward by the given duration.
+func (c *Clock) Advance(d time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.now = c.now.Add(d)
+}
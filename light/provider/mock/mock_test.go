@@ -0,0 +1,104 @@
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	"github.com/cometbft/cometbft/light/provider/mock"
+	dbs "github.com/cometbft/cometbft/light/store/db"
+)
+
+const chainID = "mock-chain"
+
+var bTime = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newClient(t *testing.T, chain *mock.Chain, primary provider.Provider, witnesses ...provider.Provider) *light.Client {
+	c, err := light.NewClient(
+		context.Background(),
+		chainID,
+		light.TrustOptions{
+			Period: 4 * time.Hour,
+			Height: 1,
+			Hash:   chain.Headers[1].Hash(),
+		},
+		primary,
+		witnesses,
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+	return c
+}
+
+func TestChainVerifies(t *testing.T) {
+	chain := mock.GenChain(chainID, 10, 4, bTime, time.Minute)
+	clock := mock.NewClock(bTime.Add(time.Hour))
+	c := newClient(t, chain, chain.Provider(), chain.Provider())
+
+	lb, err := c.VerifyLightBlockAtHeight(context.Background(), 10, clock.Now())
+	require.NoError(t, err)
+	require.Equal(t, chain.Headers[10].Hash(), lb.Hash())
+
+	// The trusted header expires.
+	chain.Extend(5)
+	require.EqualValues(t, 15, chain.Height())
+	c = newClient(t, chain, chain.Provider(), chain.Provider())
+	clock.Advance(5 * time.Hour)
+	_, err = c.VerifyLightBlockAtHeight(context.Background(), 15, clock.Now())
+	require.ErrorAs(t, err, &light.ErrOldHeaderExpired{})
+}
+
+func TestForkDetected(t *testing.T) {
+	chain := mock.GenChain(chainID, 10, 4, bTime, time.Minute)
+	fork := chain.Fork(6)
+	require.Equal(t, chain.Headers[5].Hash(), fork.Headers[5].Hash())
+	require.NotEqual(t, chain.Headers[6].Hash(), fork.Headers[6].Hash())
+
+	c := newClient(t, chain, fork.Provider(), chain.Provider())
+	_, err := c.VerifyLightBlockAtHeight(context.Background(), 10, bTime.Add(time.Hour))
+	require.ErrorIs(t, err, light.ErrLightClientAttack)
+}
+
+func TestScripted(t *testing.T) {
+	chain := mock.GenChain(chainID, 3, 1, bTime, time.Minute)
+	errScripted := errors.New("scripted")
+	p := mock.NewScripted(chain.Provider())
+	p.Script(2,
+		mock.Step{Err: errScripted},
+		mock.Step{LightBlock: chain.LightBlock(3)},
+		mock.Step{Delay: time.Second},
+	)
+
+	ctx := context.Background()
+	_, err := p.LightBlock(ctx, 2)
+	require.ErrorIs(t, err, errScripted)
+	lb, err := p.LightBlock(ctx, 2)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, lb.Height)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = p.LightBlock(timeoutCtx, 2)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The script is consumed.
+	lb, err = p.LightBlock(ctx, 2)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, lb.Height)
+	require.Equal(t, 4, p.Calls(2))
+
+	p.SetLatency(time.Second)
+	timeoutCtx, cancel = context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = p.LightBlock(timeoutCtx, 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
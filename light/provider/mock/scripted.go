@@ -0,0 +1,100 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/light/provider"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Step is a scripted response of a Scripted provider to a light block
+// request. The provider waits for Delay, then returns Err if it is set,
+// LightBlock if it is set, or the light block of the underlying provider.
+type Step struct {
+	Delay      time.Duration
+	Err        error
+	LightBlock *types.LightBlock
+}
+
+// Scripted is a provider wrapping another one, which injects latency and
+// scripted responses, so that light client consumers can test their handling
+// of slow, failing or forked providers.
+type Scripted struct {
+	provider.Provider
+
+	mtx     sync.Mutex
+	latency time.Duration
+	steps   map[int64][]Step
+	calls   map[int64]int
+}
+
+var _ provider.Provider = (*Scripted)(nil)
+
+// NewScripted returns a scripted provider serving the light blocks of the
+// given provider.
+func NewScripted(p provider.Provider) *Scripted {
+	return &Scripted{
+		Provider: p,
+		steps:    make(map[int64][]Step),
+		calls:    make(map[int64]int),
+	}
+}
+
+// SetLatency sets the delay of the responses which are not scripted.
+func (p *Scripted) SetLatency(d time.Duration) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.latency = d
+}
+
+// Script appends steps to the responses to the requests of the light block
+// at the given height (0 for the latest). Each request consumes one step;
+// once they are all consumed, the requests are served by the underlying
+// provider.
+func (p *Scripted) Script(height int64, steps ...Step) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.steps[height] = append(p.steps[height], steps...)
+}
+
+// Calls returns the number of requests of the light block at the given
+// height (0 for the latest).
+func (p *Scripted) Calls(height int64) int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.calls[height]
+}
+
+func (p *Scripted) String() string {
+	return fmt.Sprintf("Scripted{%v}", p.Provider)
+}
+
+// LightBlock implements provider.Provider.
+func (p *Scripted) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	p.mtx.Lock()
+	p.calls[height]++
+	step := Step{Delay: p.latency}
+	if steps := p.steps[height]; len(steps) > 0 {
+		step, p.steps[height] = steps[0], steps[1:]
+	}
+	p.mtx.Unlock()
+
+	if step.Delay > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(step.Delay):
+		}
+	}
+	switch {
+	case step.Err != nil:
+		return nil, step.Err
+	case step.LightBlock != nil:
+		return step.LightBlock, nil
+	default:
+		return p.Provider.LightBlock(ctx, height)
+	}
+}
@@ -28,16 +28,16 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"header":               rpcserver.NewRPCFunc(makeHeaderFunc(c), "height", rpcserver.Cacheable("height")),
 		"header_by_hash":       rpcserver.NewRPCFunc(makeHeaderByHashFunc(c), "hash", rpcserver.Cacheable()),
 		"block_by_hash":        rpcserver.NewRPCFunc(makeBlockByHashFunc(c), "hash", rpcserver.Cacheable()),
-		"block_results":        rpcserver.NewRPCFunc(makeBlockResultsFunc(c), "height", rpcserver.Cacheable("height")),
+		"block_results":        rpcserver.NewRPCFunc(makeBlockResultsFunc(c), "height,event_types,omit_tx_results", rpcserver.Cacheable("height")),
 		"commit":               rpcserver.NewRPCFunc(makeCommitFunc(c), "height", rpcserver.Cacheable("height")),
 		"tx":                   rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove", rpcserver.Cacheable()),
-		"tx_search":            rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,page,per_page,order_by"),
+		"tx_search":            rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,cursor,per_page,order_by"),
 		"block_search":         rpcserver.NewRPCFunc(makeBlockSearchFunc(c), "query,page,per_page,order_by"),
 		"validators":           rpcserver.NewRPCFunc(makeValidatorsFunc(c), "height,page,per_page", rpcserver.Cacheable("height")),
 		"dump_consensus_state": rpcserver.NewRPCFunc(makeDumpConsensusStateFunc(c), ""),
 		"consensus_state":      rpcserver.NewRPCFunc(makeConsensusStateFunc(c), ""),
 		"consensus_params":     rpcserver.NewRPCFunc(makeConsensusParamsFunc(c), "height", rpcserver.Cacheable("height")),
-		"unconfirmed_txs":      rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit"),
+		"unconfirmed_txs":      rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit,query"),
 		"num_unconfirmed_txs":  rpcserver.NewRPCFunc(makeNumUnconfirmedTxsFunc(c), ""),
 
 		// tx broadcast API
@@ -134,11 +134,24 @@ func makeBlockByHashFunc(c *lrpc.Client) rpcBlockByHashFunc {
 	}
 }
 
-type rpcBlockResultsFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultBlockResults, error)
+type rpcBlockResultsFunc func(
+	ctx *rpctypes.Context,
+	height *int64,
+	eventTypes []string,
+	omitTxResults bool,
+) (*ctypes.ResultBlockResults, error)
 
 func makeBlockResultsFunc(c *lrpc.Client) rpcBlockResultsFunc {
-	return func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultBlockResults, error) {
-		return c.BlockResults(ctx.Context(), height)
+	return func(
+		ctx *rpctypes.Context,
+		height *int64,
+		eventTypes []string,
+		omitTxResults bool,
+	) (*ctypes.ResultBlockResults, error) {
+		return c.BlockResultsWithOptions(ctx.Context(), height, rpcclient.BlockResultsOptions{
+			EventTypes:    eventTypes,
+			OmitTxResults: omitTxResults,
+		})
 	}
 }
 
@@ -162,7 +175,8 @@ type rpcTxSearchFunc func(
 	ctx *rpctypes.Context,
 	query string,
 	prove bool,
-	page, perPage *int,
+	cursor string,
+	perPage *int,
 	orderBy string,
 ) (*ctypes.ResultTxSearch, error)
 
@@ -171,10 +185,11 @@ func makeTxSearchFunc(c *lrpc.Client) rpcTxSearchFunc {
 		ctx *rpctypes.Context,
 		query string,
 		prove bool,
-		page, perPage *int,
+		cursor string,
+		perPage *int,
 		orderBy string,
 	) (*ctypes.ResultTxSearch, error) {
-		return c.TxSearch(ctx.Context(), query, prove, page, perPage, orderBy)
+		return c.TxSearch(ctx.Context(), query, prove, cursor, perPage, orderBy)
 	}
 }
 
@@ -231,11 +246,11 @@ func makeConsensusParamsFunc(c *lrpc.Client) rpcConsensusParamsFunc {
 	}
 }
 
-type rpcUnconfirmedTxsFunc func(ctx *rpctypes.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error)
+type rpcUnconfirmedTxsFunc func(ctx *rpctypes.Context, limit *int, query string) (*ctypes.ResultUnconfirmedTxs, error)
 
 func makeUnconfirmedTxsFunc(c *lrpc.Client) rpcUnconfirmedTxsFunc {
-	return func(ctx *rpctypes.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
-		return c.UnconfirmedTxs(ctx.Context(), limit)
+	return func(ctx *rpctypes.Context, limit *int, query string) (*ctypes.ResultUnconfirmedTxs, error) {
+		return c.UnconfirmedTxsWithQuery(ctx.Context(), query, limit)
 	}
 }
 
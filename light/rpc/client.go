@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"time"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/crypto/merkle"
 	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
@@ -17,7 +18,6 @@ import (
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 	"github.com/cometbft/cometbft/state"
 	"github.com/cometbft/cometbft/types"
-	cmterrors "github.com/cometbft/cometbft/types/errors"
 )
 
 var errNegOrZeroHeight = errors.New("negative or zero height")
@@ -49,6 +49,9 @@ type Client struct {
 	// proof runtime used to verify values returned by ABCIQuery
 	prt       *merkle.ProofRuntime
 	keyPathFn KeyPathFunc
+
+	// returns the current time, to verify the light blocks
+	now func() time.Time
 }
 
 var _ rpcclient.Client = (*Client)(nil)
@@ -65,6 +68,15 @@ func KeyPathFn(fn KeyPathFunc) Option {
 	}
 }
 
+// NowFn option can be used to set the function returning the current time
+// used to verify the light blocks. It defaults to time.Now, and can be set to
+// an adjustable clock in tests.
+func NowFn(fn func() time.Time) Option {
+	return func(c *Client) {
+		c.now = fn
+	}
+}
+
 // DefaultMerkleKeyPathFn creates a function used to generate merkle key paths
 // from a path string and a key. This is the default used by the cosmos SDK.
 // This merkle key paths are required when verifying /abci_query calls
@@ -92,6 +104,7 @@ func NewClient(next rpcclient.Client, lc LightClient, opts ...Option) *Client {
 		next: next,
 		lc:   lc,
 		prt:  merkle.DefaultProofRuntime(),
+		now:  time.Now,
 	}
 	c.BaseService = *service.NewBaseService(nil, "Client", c)
 	for _, o := range opts {
@@ -128,7 +141,10 @@ func (c *Client) ABCIQuery(ctx context.Context, path string, data cmtbytes.HexBy
 	return c.ABCIQueryWithOptions(ctx, path, data, rpcclient.DefaultABCIQueryOptions)
 }
 
-// ABCIQueryWithOptions returns an error if opts.Prove is false.
+// ABCIQueryWithOptions always requests the proof of the response, and verifies
+// it. The responses without a proof, e.g. the errors or the responses of the
+// queries of something else than a key of a store, are returned with the
+// ctypes.UnverifiedResponse marker.
 func (c *Client) ABCIQueryWithOptions(ctx context.Context, path string, data cmtbytes.HexBytes,
 	opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
 
@@ -141,16 +157,12 @@ func (c *Client) ABCIQueryWithOptions(ctx context.Context, path string, data cmt
 	}
 	resp := res.Response
 
-	// Validate the response.
-	if resp.IsErr() {
-		return nil, fmt.Errorf("err response code: %v", resp.Code)
-	}
-	if len(resp.Key) == 0 {
-		return nil, cmterrors.ErrRequiredField{Field: "key"}
-	}
-	if resp.ProofOps == nil || len(resp.ProofOps.Ops) == 0 {
-		return nil, errors.New("no proof ops")
+	// The responses without a proof can't be verified.
+	if resp.IsErr() || len(resp.Key) == 0 || resp.ProofOps == nil || len(resp.ProofOps.Ops) == 0 {
+		return &ctypes.ResultABCIQuery{Response: resp, Unverified: []string{ctypes.UnverifiedResponse}}, nil
 	}
+
+	// Validate the response.
 	if resp.Height <= 0 {
 		return nil, errNegOrZeroHeight
 	}
@@ -206,6 +218,14 @@ func (c *Client) UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.Result
 	return c.next.UnconfirmedTxs(ctx, limit)
 }
 
+func (c *Client) UnconfirmedTxsWithQuery(
+	ctx context.Context,
+	query string,
+	limit *int,
+) (*ctypes.ResultUnconfirmedTxs, error) {
+	return c.next.UnconfirmedTxsWithQuery(ctx, query, limit)
+}
+
 func (c *Client) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
 	return c.next.NumUnconfirmedTxs(ctx)
 }
@@ -378,7 +398,8 @@ func (c *Client) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBl
 
 // BlockResults returns the block results for the given height. If no height is
 // provided, the results of the block preceding the latest are returned.
-// NOTE: Light client only verifies the tx results
+// NOTE: Light client only verifies the tx results and the app hash, the other
+// fields are listed in the Unverified field of the results.
 func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
 	var h int64
 	if height == nil {
@@ -410,16 +431,53 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.Resul
 		return nil, err
 	}
 
+	if err := verifyBlockResults(res, trustedBlock); err != nil {
+		return nil, err
+	}
+
+	res.Unverified = []string{
+		ctypes.UnverifiedTxsResultsEvents,
+		ctypes.UnverifiedFinalizeBlockEvents,
+		ctypes.UnverifiedValidatorUpdates,
+		ctypes.UnverifiedConsensusParamUpdates,
+	}
+	return res, nil
+}
+
+// verifyBlockResults verifies the tx results and the app hash of the results
+// of a block against the trusted header of the next block.
+func verifyBlockResults(res *ctypes.ResultBlockResults, nextBlock *types.LightBlock) error {
 	// Build a Merkle tree out of the above 3 binary slices.
 	rH := state.TxResultsHash(res.TxsResults)
 
 	// Verify block results.
-	if !bytes.Equal(rH, trustedBlock.LastResultsHash) {
-		return nil, fmt.Errorf("last results %X does not match with trusted last results %X",
-			rH, trustedBlock.LastResultsHash)
+	if !bytes.Equal(rH, nextBlock.LastResultsHash) {
+		return fmt.Errorf("last results %X does not match with trusted last results %X",
+			rH, nextBlock.LastResultsHash)
 	}
 
-	return res, nil
+	// Verify the app hash.
+	if !bytes.Equal(res.AppHash, nextBlock.AppHash) {
+		return fmt.Errorf("app hash %X does not match with trusted app hash %X",
+			res.AppHash, nextBlock.AppHash)
+	}
+
+	return nil
+}
+
+// BlockResultsWithOptions returns the block results for the given height,
+// filtered by the options. The results are fetched in full, as the tx results
+// are needed to verify them, and filtered once verified.
+func (c *Client) BlockResultsWithOptions(
+	ctx context.Context,
+	height *int64,
+	opts rpcclient.BlockResultsOptions,
+) (*ctypes.ResultBlockResults, error) {
+	res, err := c.BlockResults(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return res.Filter(opts.EventTypes, opts.OmitTxResults), nil
 }
 
 // Header fetches and verifies the header directly via the light client
@@ -470,37 +528,158 @@ func (c *Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommi
 	}, nil
 }
 
-// Tx calls rpcclient#Tx method and then verifies the proof if such was
-// requested.
+// Tx calls rpcclient#Tx method, always requesting the proof of the tx, and
+// verifies the tx against the trusted header of its block. The result of the
+// tx is verified against the trusted header of the next block, if produced;
+// otherwise, it is listed in the Unverified field of the result. The proof is
+// only returned if requested.
 func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
-	res, err := c.next.Tx(ctx, hash, prove)
-	if err != nil || !prove {
-		return res, err
+	res, err := c.next.Tx(ctx, hash, true)
+	if err != nil {
+		return nil, err
 	}
 
-	// Validate res.
-	if res.Height <= 0 {
-		return nil, errNegOrZeroHeight
+	if !bytes.Equal(res.Hash, hash) {
+		return nil, fmt.Errorf("tx hash %X does not match with the requested hash %X", res.Hash, hash)
 	}
 
-	// Update the light client if we're behind.
-	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
-	if err != nil {
+	if err := c.verifyTx(ctx, res, make(map[int64]*ctypes.ResultBlockResults)); err != nil {
 		return nil, err
 	}
 
-	// Validate the proof.
-	return res, res.Proof.Validate(l.DataHash)
+	if !prove {
+		res.Proof = types.TxProof{}
+	}
+	return res, nil
 }
 
+// TxSearch calls rpcclient#TxSearch method, always requesting the proofs of
+// the txs, and verifies each tx as Tx does. Whether the txs found are all the
+// txs matching the query can't be verified, and is listed in the Unverified
+// field of the result.
 func (c *Client) TxSearch(
 	ctx context.Context,
 	query string,
 	prove bool,
-	page, perPage *int,
+	cursor string,
+	perPage *int,
 	orderBy string,
 ) (*ctypes.ResultTxSearch, error) {
-	return c.next.TxSearch(ctx, query, prove, page, perPage, orderBy)
+	res, err := c.next.TxSearch(ctx, query, true, cursor, perPage, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// the results of the blocks of the txs, verified once per block
+	blockResults := make(map[int64]*ctypes.ResultBlockResults)
+	for _, tx := range res.Txs {
+		if err := c.verifyTx(ctx, tx, blockResults); err != nil {
+			return nil, fmt.Errorf("tx %X: %w", tx.Hash, err)
+		}
+		if !prove {
+			tx.Proof = types.TxProof{}
+		}
+	}
+
+	res.Unverified = []string{ctypes.UnverifiedTotalCount}
+	return res, nil
+}
+
+// verifyTx verifies the tx of the result, with its proof, against the trusted
+// header of its block, and sets the timestamp of the result to the time of the
+// header. The deterministic fields of the tx result are verified against the
+// results of the block, fetched once per height into blockResults, if the next
+// block is produced; the other fields are listed in the Unverified field.
+func (c *Client) verifyTx(
+	ctx context.Context,
+	res *ctypes.ResultTx,
+	blockResults map[int64]*ctypes.ResultBlockResults,
+) error {
+	// Validate res.
+	if res.Height <= 0 {
+		return errNegOrZeroHeight
+	}
+	if !bytes.Equal(res.Hash, res.Tx.Hash()) {
+		return fmt.Errorf("tx hash %X does not match with the hash of the tx %X", res.Hash, res.Tx.Hash())
+	}
+	if !bytes.Equal(res.Proof.Data, res.Tx) || res.Proof.Proof.Index != int64(res.Index) {
+		return errors.New("tx proof does not match with the tx")
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return err
+	}
+
+	// Validate the proof.
+	if err := res.Proof.Validate(l.DataHash); err != nil {
+		return fmt.Errorf("verify tx proof: %w", err)
+	}
+	res.Timestamp = l.Time.Format(time.RFC3339)
+
+	results, ok := blockResults[res.Height]
+	if !ok {
+		results, err = c.verifiedBlockResults(ctx, res.Height)
+		if err != nil {
+			return err
+		}
+		blockResults[res.Height] = results
+	}
+	if results == nil {
+		res.Unverified = []string{ctypes.UnverifiedTxResult}
+		return nil
+	}
+
+	// Validate the tx result.
+	if int(res.Index) >= len(results.TxsResults) {
+		return fmt.Errorf("tx index %d out of the %d trusted tx results", res.Index, len(results.TxsResults))
+	}
+	txResult, err := abci.DeterministicExecTxResult(&res.TxResult).Marshal()
+	if err != nil {
+		return err
+	}
+	trustedTxResult, err := abci.DeterministicExecTxResult(results.TxsResults[res.Index]).Marshal()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(txResult, trustedTxResult) {
+		return errors.New("tx result does not match with the trusted tx result")
+	}
+	res.Unverified = []string{ctypes.UnverifiedTxResultEvents}
+
+	return nil
+}
+
+// verifiedBlockResults returns the results of the block at the given height,
+// with their tx results and app hash verified against the trusted header of
+// the next block, or nil if the next block is not produced yet.
+func (c *Client) verifiedBlockResults(ctx context.Context, height int64) (*ctypes.ResultBlockResults, error) {
+	nextHeight := height + 1
+	nextBlock, err := c.lc.TrustedLightBlock(nextHeight)
+	if err != nil {
+		status, err := c.next.Status(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("can't get latest height: %w", err)
+		}
+		if status.SyncInfo.LatestBlockHeight < nextHeight {
+			return nil, nil
+		}
+
+		nextBlock, err = c.updateLightClientIfNeededTo(ctx, &nextHeight)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := c.next.BlockResults(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyBlockResults(res, nextBlock); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
 func (c *Client) BlockSearch(
@@ -566,9 +745,9 @@ func (c *Client) updateLightClientIfNeededTo(ctx context.Context, height *int64)
 		err error
 	)
 	if height == nil {
-		l, err = c.lc.Update(ctx, time.Now())
+		l, err = c.lc.Update(ctx, c.now())
 	} else {
-		l, err = c.lc.VerifyLightBlockAtHeight(ctx, *height, time.Now())
+		l, err = c.lc.VerifyLightBlockAtHeight(ctx, *height, c.now())
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to update light client to %d: %w", *height, err)
@@ -0,0 +1,176 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	lrpc "github.com/cometbft/cometbft/light/rpc"
+	lcmock "github.com/cometbft/cometbft/light/rpc/mocks"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	rpcmock "github.com/cometbft/cometbft/rpc/client/mocks"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/types"
+)
+
+var bTime = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// testBlock is a block of txs at height 2, with its results committed to by
+// the header at height 3.
+type testBlock struct {
+	txs          types.Txs
+	results      *ctypes.ResultBlockResults
+	lightBlock   *types.LightBlock
+	nextBlock    *types.LightBlock
+	primary      *rpcmock.Client
+	lightClient  *lcmock.LightClient
+	rpcClient    *lrpc.Client
+	latestHeight int64
+}
+
+func newTestBlock() *testBlock {
+	b := &testBlock{
+		txs: types.Txs{types.Tx("a=1"), types.Tx("b=2")},
+		results: &ctypes.ResultBlockResults{
+			Height: 2,
+			TxsResults: []*abci.ExecTxResult{
+				{Code: 0, Data: []byte("ok"), GasUsed: 10},
+				{Code: 1, Log: "failed", GasUsed: 5},
+			},
+			AppHash: []byte("app_hash"),
+		},
+		primary:      &rpcmock.Client{},
+		lightClient:  &lcmock.LightClient{},
+		latestHeight: 3,
+	}
+	b.lightBlock = &types.LightBlock{SignedHeader: &types.SignedHeader{Header: &types.Header{
+		Height:   2,
+		Time:     bTime,
+		DataHash: b.txs.Hash(),
+	}}}
+	b.nextBlock = &types.LightBlock{SignedHeader: &types.SignedHeader{Header: &types.Header{
+		Height:          3,
+		Time:            bTime.Add(time.Second),
+		LastResultsHash: state.TxResultsHash(b.results.TxsResults),
+		AppHash:         b.results.AppHash,
+	}}}
+
+	b.lightClient.On("VerifyLightBlockAtHeight", mock.Anything, int64(2), mock.Anything).Return(b.lightBlock, nil)
+	b.lightClient.On("VerifyLightBlockAtHeight", mock.Anything, int64(3), mock.Anything).Return(b.nextBlock, nil)
+	b.lightClient.On("TrustedLightBlock", int64(3)).Return(nil, errors.New("not found"))
+	b.primary.On("Status", mock.Anything).Return(func(context.Context) *ctypes.ResultStatus {
+		return &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: b.latestHeight}}
+	}, nil)
+	b.primary.On("BlockResults", mock.Anything, mock.Anything).Return(b.results, nil)
+
+	b.rpcClient = lrpc.NewClient(b.primary, b.lightClient)
+	return b
+}
+
+// resultTx returns the result of the tx of the given index, as returned by
+// the primary.
+func (b *testBlock) resultTx(index int) *ctypes.ResultTx {
+	return &ctypes.ResultTx{
+		Hash:     b.txs[index].Hash(),
+		Height:   2,
+		Index:    uint32(index),
+		TxResult: *b.results.TxsResults[index],
+		Tx:       b.txs[index],
+		Proof:    b.txs.Proof(index),
+	}
+}
+
+func TestClientTx(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("verified", func(t *testing.T) {
+		b := newTestBlock()
+		b.primary.On("Tx", mock.Anything, []byte(b.txs[1].Hash()), true).Return(b.resultTx(1), nil)
+
+		res, err := b.rpcClient.Tx(ctx, b.txs[1].Hash(), false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{ctypes.UnverifiedTxResultEvents}, res.Unverified)
+		assert.Equal(t, bTime.Format(time.RFC3339), res.Timestamp)
+		// the proof was not requested
+		assert.Empty(t, res.Proof.Data)
+	})
+
+	t.Run("next block not produced", func(t *testing.T) {
+		b := newTestBlock()
+		b.latestHeight = 2
+		b.primary.On("Tx", mock.Anything, []byte(b.txs[0].Hash()), true).Return(b.resultTx(0), nil)
+
+		res, err := b.rpcClient.Tx(ctx, b.txs[0].Hash(), true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{ctypes.UnverifiedTxResult}, res.Unverified)
+		assert.Equal(t, b.txs[0], types.Tx(res.Proof.Data))
+	})
+
+	t.Run("invalid tx result", func(t *testing.T) {
+		b := newTestBlock()
+		res := b.resultTx(0)
+		res.TxResult.Code = 1
+		b.primary.On("Tx", mock.Anything, []byte(b.txs[0].Hash()), true).Return(res, nil)
+
+		_, err := b.rpcClient.Tx(ctx, b.txs[0].Hash(), false)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid proof", func(t *testing.T) {
+		b := newTestBlock()
+		res := b.resultTx(0)
+		res.Proof = b.txs.Proof(1)
+		b.primary.On("Tx", mock.Anything, []byte(b.txs[0].Hash()), true).Return(res, nil)
+
+		_, err := b.rpcClient.Tx(ctx, b.txs[0].Hash(), false)
+		require.Error(t, err)
+	})
+}
+
+func TestClientTxSearch(t *testing.T) {
+	b := newTestBlock()
+	b.primary.On("TxSearch", mock.Anything, "tx.height=2", true, "", (*int)(nil), "").
+		Return(&ctypes.ResultTxSearch{Txs: []*ctypes.ResultTx{b.resultTx(0), b.resultTx(1)}, TotalCount: 2}, nil)
+
+	res, err := b.rpcClient.TxSearch(context.Background(), "tx.height=2", false, "", nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{ctypes.UnverifiedTotalCount}, res.Unverified)
+	require.Len(t, res.Txs, 2)
+	for _, tx := range res.Txs {
+		assert.Equal(t, []string{ctypes.UnverifiedTxResultEvents}, tx.Unverified)
+	}
+	// the results of the block were fetched once
+	b.primary.AssertNumberOfCalls(t, "BlockResults", 1)
+}
+
+func TestClientBlockResults(t *testing.T) {
+	b := newTestBlock()
+	height := int64(2)
+
+	res, err := b.rpcClient.BlockResults(context.Background(), &height)
+	require.NoError(t, err)
+	assert.Contains(t, res.Unverified, ctypes.UnverifiedFinalizeBlockEvents)
+
+	b.results.AppHash = []byte("other_app_hash")
+	_, err = b.rpcClient.BlockResults(context.Background(), &height)
+	require.Error(t, err)
+}
+
+func TestClientABCIQueryUnverified(t *testing.T) {
+	b := newTestBlock()
+	b.primary.On("ABCIQueryWithOptions", mock.Anything, "/app/version", mock.Anything,
+		rpcclient.ABCIQueryOptions{Prove: true}).
+		Return(&ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: []byte("1")}}, nil)
+
+	res, err := b.rpcClient.ABCIQuery(context.Background(), "/app/version", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{ctypes.UnverifiedResponse}, res.Unverified)
+	assert.Equal(t, []byte("1"), res.Response.Value)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"time"
 
 	dbm "github.com/cometbft/cometbft-db"
 	cmterrors "github.com/cometbft/cometbft/types/errors"
@@ -215,6 +216,39 @@ func (s *dbs) LightBlockBefore(height int64) (*types.LightBlock, error) {
 	return nil, store.ErrLightBlockNotFound
 }
 
+// LightBlockAfter iterates over light blocks until it finds a block after
+// the given height. It returns ErrLightBlockNotFound if no such block exists.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) LightBlockAfter(height int64) (*types.LightBlock, error) {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	itr, err := s.db.Iterator(
+		s.lbKey(height+1),
+		append(s.lbKey(1<<63-1), byte(0x00)),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer itr.Close()
+
+	for itr.Valid() {
+		key := itr.Key()
+		_, existingHeight, ok := parseLbKey(key)
+		if ok {
+			return s.LightBlock(existingHeight)
+		}
+		itr.Next()
+	}
+	if err = itr.Error(); err != nil {
+		return nil, err
+	}
+
+	return nil, store.ErrLightBlockNotFound
+}
+
 // Prune prunes header & validator set pairs until there are only size pairs
 // left.
 //
@@ -278,6 +312,82 @@ func (s *dbs) Prune(size uint16) error {
 	return nil
 }
 
+// PruneOlderThan prunes the header & validator set pairs with a header time
+// before t, except the last one.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) PruneOlderThan(t time.Time) error {
+	lastHeight, err := s.LastLightBlockHeight()
+	if err != nil {
+		return err
+	}
+	if lastHeight == -1 { // empty store
+		return nil
+	}
+
+	// 1) Iterate over headers, from the oldest, and perform a batch operation.
+	itr, err := s.db.Iterator(
+		s.lbKey(1),
+		s.lbKey(lastHeight),
+	)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+
+	b := s.db.NewBatch()
+	defer b.Close()
+
+	pruned := 0
+	for ; itr.Valid(); itr.Next() {
+		_, height, ok := parseLbKey(itr.Key())
+		if !ok {
+			continue
+		}
+
+		var lbpb cmtproto.LightBlock
+		if err := lbpb.Unmarshal(itr.Value()); err != nil {
+			return fmt.Errorf("unmarshal error: %w", err)
+		}
+		if lbpb.SignedHeader == nil || lbpb.SignedHeader.Header == nil {
+			return fmt.Errorf("light block at height %d has no header", height)
+		}
+		// the headers are ordered by time
+		if !lbpb.SignedHeader.Header.Time.Before(t) {
+			break
+		}
+
+		if err = b.Delete(s.lbKey(height)); err != nil {
+			return err
+		}
+		pruned++
+	}
+	if err = itr.Error(); err != nil {
+		return err
+	}
+
+	if pruned == 0 {
+		return nil
+	}
+
+	err = b.WriteSync()
+	if err != nil {
+		return err
+	}
+
+	// 2) Update size.
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.size -= uint16(pruned)
+
+	if wErr := s.db.SetSync(sizeKey, marshalSize(s.size)); wErr != nil {
+		return fmt.Errorf("failed to persist size: %w", wErr)
+	}
+
+	return nil
+}
+
 // Size returns the number of header & validator set pairs.
 //
 // Safe for concurrent use by multiple goroutines.
@@ -13,6 +13,7 @@ import (
 	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	"github.com/cometbft/cometbft/light/store"
 	cmtversion "github.com/cometbft/cometbft/proto/tendermint/version"
 	"github.com/cometbft/cometbft/types"
 	"github.com/cometbft/cometbft/version"
@@ -90,6 +91,34 @@ func Test_LightBlockBefore(t *testing.T) {
 	}
 }
 
+func Test_LightBlockAfter(t *testing.T) {
+	dbStore := New(dbm.NewMemDB(), "Test_LightBlockAfter")
+
+	assert.Panics(t, func() {
+		_, _ = dbStore.LightBlockAfter(0)
+	})
+
+	for _, height := range []int64{2, 5} {
+		err := dbStore.SaveLightBlock(randLightBlock(height))
+		require.NoError(t, err)
+	}
+
+	h, err := dbStore.LightBlockAfter(2)
+	require.NoError(t, err)
+	if assert.NotNil(t, h) {
+		assert.EqualValues(t, 5, h.Height)
+	}
+
+	h, err = dbStore.LightBlockAfter(1)
+	require.NoError(t, err)
+	if assert.NotNil(t, h) {
+		assert.EqualValues(t, 2, h.Height)
+	}
+
+	_, err = dbStore.LightBlockAfter(5)
+	require.ErrorIs(t, err, store.ErrLightBlockNotFound)
+}
+
 func Test_Prune(t *testing.T) {
 	dbStore := New(dbm.NewMemDB(), "Test_Prune")
 
@@ -127,6 +156,42 @@ func Test_Prune(t *testing.T) {
 	assert.EqualValues(t, 7, dbStore.Size())
 }
 
+func Test_PruneOlderThan(t *testing.T) {
+	dbStore := New(dbm.NewMemDB(), "Test_PruneOlderThan")
+	now := time.Now()
+
+	// Empty store
+	err := dbStore.PruneOlderThan(now)
+	require.NoError(t, err)
+
+	// Headers of 10 to 1 minutes ago
+	for i := 1; i <= 10; i++ {
+		lb := randLightBlock(int64(i))
+		lb.Time = now.Add(time.Duration(i-11) * time.Minute)
+		err = dbStore.SaveLightBlock(lb)
+		require.NoError(t, err)
+	}
+
+	err = dbStore.PruneOlderThan(now.Add(-10 * time.Minute))
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, dbStore.Size())
+
+	err = dbStore.PruneOlderThan(now.Add(-5*time.Minute - time.Second))
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, dbStore.Size())
+	height, err := dbStore.FirstLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, height)
+
+	// The last header is kept
+	err = dbStore.PruneOlderThan(now)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, dbStore.Size())
+	height, err = dbStore.FirstLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, height)
+}
+
 func Test_Concurrency(t *testing.T) {
 	dbStore := New(dbm.NewMemDB(), "Test_Prune")
 
@@ -1,6 +1,10 @@
 package store
 
-import "github.com/cometbft/cometbft/types"
+import (
+	"time"
+
+	"github.com/cometbft/cometbft/types"
+)
 
 // Store is anything that can persistently store headers.
 type Store interface {
@@ -39,10 +43,22 @@ type Store interface {
 	// height must be > 0 && <= LastLightBlockHeight.
 	LightBlockBefore(height int64) (*types.LightBlock, error)
 
+	// LightBlockAfter returns the LightBlock after a certain height.
+	//
+	// height must be > 0 && >= FirstLightBlockHeight.
+	//
+	// If there is no LightBlock after height, ErrLightBlockNotFound is
+	// returned.
+	LightBlockAfter(height int64) (*types.LightBlock, error)
+
 	// Prune removes headers & the associated validator sets when Store reaches a
 	// defined size (number of header & validator set pairs).
 	Prune(size uint16) error
 
+	// PruneOlderThan removes headers & the associated validator sets with a
+	// header time before t, except the last (newest) one.
+	PruneOlderThan(t time.Time) error
+
 	// Size returns a number of currently existing header & validator set pairs.
 	Size() uint16
 }
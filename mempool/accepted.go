@@ -0,0 +1,25 @@
+package mempool
+
+import (
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// AcceptedFunc is called when a new tx is accepted into the mempool, with the
+// response of the application to CheckTx.
+type AcceptedFunc func(tx types.Tx, res *abci.ResponseCheckTx)
+
+// WithAcceptedCallback sets a function called, with the mempool locked, for
+// every new tx accepted into the mempool. Txs accepted again when rechecked
+// are not reported.
+func WithAcceptedCallback(f AcceptedFunc) CListMempoolOption {
+	return func(mem *CListMempool) { mem.onAccepted = f }
+}
+
+// notifyAccepted reports the acceptance of the tx to the accepted callback, if
+// any.
+func (mem *CListMempool) notifyAccepted(tx types.Tx, res *abci.ResponseCheckTx) {
+	if mem.onAccepted != nil {
+		mem.onAccepted(tx, res)
+	}
+}
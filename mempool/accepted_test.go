@@ -0,0 +1,45 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestMempoolAcceptedCallback(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	var accepted types.Txs
+	WithAcceptedCallback(func(tx types.Tx, res *abci.ResponseCheckTx) {
+		require.Equal(t, abci.CodeTypeOK, res.Code)
+		accepted = append(accepted, tx)
+	})(mp)
+
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=2")}
+	callCheckTx(t, mp, txs)
+	require.Equal(t, txs, accepted)
+
+	// Invalid txs are not reported.
+	_, err := mp.CheckTx(types.Tx("invalid"))
+	require.NoError(t, err)
+	require.NoError(t, mp.FlushAppConn())
+	require.Equal(t, txs, accepted)
+
+	// Txs accepted again when rechecked are not reported.
+	mp.Lock()
+	err = mp.Update(1, txs[:1], abciResponses(1, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	require.NoError(t, mp.FlushAppConn())
+	require.Equal(t, txs, accepted)
+}
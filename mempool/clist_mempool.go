@@ -5,6 +5,7 @@ import (
 	"context"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	abcicli "github.com/cometbft/cometbft/abci/client"
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -35,6 +36,14 @@ type CListMempool struct {
 	// from the mempool.
 	removeTxOnReactorCb func(txKey types.TxKey)
 
+	// Function called when a transaction is evicted from the mempool.
+	onEvicted EvictionFunc
+	// Function called when a new transaction is accepted into the mempool.
+	onAccepted AcceptedFunc
+
+	// Log of the transactions rejected or evicted from the mempool.
+	dropLog *DropLog
+
 	config *config.MempoolConfig
 
 	// Exclusive mutex for Update method to prevent concurrent execution of
@@ -42,6 +51,7 @@ type CListMempool struct {
 	updateMtx cmtsync.RWMutex
 	preCheck  PreCheckFunc
 	postCheck PostCheckFunc
+	txSender  TxSenderFunc
 
 	proxyAppConn proxy.AppConnMempool
 
@@ -57,10 +67,33 @@ type CListMempool struct {
 	txs    *clist.CList
 	txsMap sync.Map
 
+	// Number of txs in the mempool per class, used to enforce the per-class
+	// quotas.
+	classMtx   cmtsync.Mutex
+	classSizes map[string]int
+
+	// Number and size of the txs in the mempool per sender, used to enforce
+	// the per-sender caps, and txs by sender and sequence, used to replace
+	// them by fee.
+	senderMtx   cmtsync.Mutex
+	senderSizes map[string]senderUsage
+	senderSeqs  map[senderSequence]types.TxKey
+
+	// Senders banned by the application in CheckTx.
+	bannedSendersMtx cmtsync.Mutex
+	bannedSenders    map[string]senderBan
+
 	// Keep a cache of already-seen txs.
 	// This reduces the pressure on the proxyApp.
 	cache TxCache
 
+	// Journal of the txs of the mempool, nil if the WAL is disabled.
+	wal *mempoolWAL
+
+	// Metadata of the txs resubmitted by LoadTxs, until they are checked.
+	restoredMtx cmtsync.Mutex
+	restored    map[types.TxKey]persistedTx
+
 	logger  log.Logger
 	metrics *Metrics
 }
@@ -82,6 +115,11 @@ func NewCListMempool(
 		config:        cfg,
 		proxyAppConn:  proxyAppConn,
 		txs:           clist.New(),
+		classSizes:    make(map[string]int),
+		senderSizes:   make(map[string]senderUsage),
+		senderSeqs:    make(map[senderSequence]types.TxKey),
+		bannedSenders: make(map[string]senderBan),
+		dropLog:       newDropLog(),
 		height:        height,
 		recheckCursor: nil,
 		recheckEnd:    nil,
@@ -116,6 +154,15 @@ func (mem *CListMempool) InMempool(txKey types.TxKey) bool {
 	return ok
 }
 
+// GetTxByKey returns the transaction with the given key, if in the mempool.
+func (mem *CListMempool) GetTxByKey(txKey types.TxKey) (types.Tx, bool) {
+	e, ok := mem.getCElement(txKey)
+	if !ok {
+		return nil, false
+	}
+	return e.Value.(*mempoolTx).tx, true
+}
+
 func (mem *CListMempool) addToCache(tx types.Tx) bool {
 	return mem.cache.Push(tx)
 }
@@ -144,6 +191,10 @@ func (mem *CListMempool) removeAllTxs() {
 		mem.invokeRemoveTxOnReactor(key.(types.TxKey))
 		return true
 	})
+
+	mem.resetClasses()
+	mem.resetSenders()
+	mem.compactWAL(true)
 }
 
 // NOTE: not thread safe - should only be called once, on startup
@@ -182,6 +233,14 @@ func WithPostCheck(f PostCheckFunc) CListMempoolOption {
 	return func(mem *CListMempool) { mem.postCheck = f }
 }
 
+// WithTxSender sets the function extracting the sender of a tx, with which
+// the mempool drops the txs of the senders banned by the application without
+// checking them. Without it, the txs of a banned sender are only dropped once
+// the application returned their sender in CheckTx.
+func WithTxSender(f TxSenderFunc) CListMempoolOption {
+	return func(mem *CListMempool) { mem.txSender = f }
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *Metrics) CListMempoolOption {
 	return func(mem *CListMempool) { mem.metrics = metrics }
@@ -202,6 +261,13 @@ func (mem *CListMempool) Size() int {
 	return mem.txs.Len()
 }
 
+// getHeight returns the height of the last block the mempool was updated to.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) getHeight() int64 {
+	return atomic.LoadInt64(&mem.height)
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) SizeBytes() int64 {
 	return atomic.LoadInt64(&mem.txsBytes)
@@ -255,23 +321,54 @@ func (mem *CListMempool) CheckTx(tx types.Tx) (*abcicli.ReqRes, error) {
 
 	txSize := len(tx)
 
-	if err := mem.isFull(txSize); err != nil {
-		return nil, err
+	// The priority mempool may make room for the tx by evicting lower-priority
+	// ones, and the tx may replace another one of the same sender and
+	// sequence, which is only known once the application checked it.
+	if !mem.prioritized() && !mem.replacesByFee() {
+		if err := mem.isFull(txSize); err != nil {
+			mem.recordRejected(tx, RejectionMempoolFull, nil, err)
+			return nil, err
+		}
 	}
 
 	if txSize > mem.config.MaxTxBytes {
-		return nil, ErrTxTooLarge{
+		err := ErrTxTooLarge{
 			Max:    mem.config.MaxTxBytes,
 			Actual: txSize,
 		}
+		mem.recordRejected(tx, RejectionTooLarge, nil, err)
+		return nil, err
 	}
 
 	if mem.preCheck != nil {
 		if err := mem.preCheck(tx); err != nil {
+			mem.recordRejected(tx, RejectionPreCheck, nil, err)
 			return nil, ErrPreCheck{Err: err}
 		}
 	}
 
+	if mem.txSender != nil {
+		if sender := mem.txSender(tx); mem.isSenderBanned(sender) {
+			err := ErrSenderBanned{Sender: sender}
+			mem.metrics.BannedSenderTxs.Add(1)
+			mem.recordRejected(tx, RejectionBannedSender, nil, err)
+			return nil, err
+		}
+	}
+
+	if mem.config.ExperimentalEncryptedTxs && IsEncryptedTx(tx) {
+		decryptionHeight, _, err := DecodeEncryptedTx(tx)
+		if err != nil {
+			mem.recordRejected(tx, RejectionEncryptedTx, nil, err)
+			return nil, err
+		}
+		if decryptionHeight <= mem.height {
+			err := ErrEncryptedTxExpired{DecryptionHeight: decryptionHeight, Height: mem.height}
+			mem.recordRejected(tx, RejectionEncryptedTx, nil, err)
+			return nil, err
+		}
+	}
+
 	// NOTE: proxyAppConn may error if tx buffer is full
 	if err := mem.proxyAppConn.Error(); err != nil {
 		return nil, ErrAppConnMempool{Err: err}
@@ -279,6 +376,7 @@ func (mem *CListMempool) CheckTx(tx types.Tx) (*abcicli.ReqRes, error) {
 
 	if added := mem.addToCache(tx); !added {
 		mem.metrics.AlreadyReceivedTxs.Add(1)
+		mem.recordRejected(tx, RejectionInCache, nil, nil)
 		// TODO: consider punishing peer for dups,
 		// its non-trivial since invalid txs can become valid,
 		// but they can spam the same tx with little cost to them atm.
@@ -329,6 +427,10 @@ func (mem *CListMempool) addTx(memTx *mempoolTx) {
 	e := mem.txs.PushBack(memTx)
 	mem.txsMap.Store(memTx.tx.Key(), e)
 	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.tx)))
+	mem.addToClass(memTx.class)
+	mem.addToSender(memTx.sender, len(memTx.tx))
+	mem.addToSequences(memTx)
+	mem.journalAdd(memTx.tx)
 	mem.metrics.TxSizeBytes.Observe(float64(len(memTx.tx)))
 }
 
@@ -344,8 +446,12 @@ func (mem *CListMempool) RemoveTxByKey(txKey types.TxKey) error {
 		mem.txs.Remove(elem)
 		elem.DetachPrev()
 		mem.txsMap.Delete(txKey)
-		tx := elem.Value.(*mempoolTx).tx
-		atomic.AddInt64(&mem.txsBytes, int64(-len(tx)))
+		memTx := elem.Value.(*mempoolTx)
+		atomic.AddInt64(&mem.txsBytes, int64(-len(memTx.tx)))
+		mem.removeFromClass(memTx.class)
+		mem.removeFromSender(memTx.sender, len(memTx.tx))
+		mem.removeFromSequences(memTx)
+		mem.journalRemove(txKey)
 		return nil
 	}
 	return ErrTxNotFound
@@ -384,12 +490,64 @@ func (mem *CListMempool) resCbFirstTime(
 			postCheckErr = mem.postCheck(tx, r.CheckTx)
 		}
 		txKey := types.Tx(tx).Key()
+		restored, isRestored := mem.takeRestored(txKey)
+		// A ban requested in the response applies to the next txs of the
+		// sender, whether the tx is valid or not.
+		banned := mem.isSenderBanned(r.CheckTx.Sender)
+		mem.banSender(r.CheckTx)
 		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil {
+			if banned {
+				err := ErrSenderBanned{Sender: r.CheckTx.Sender}
+				mem.forceRemoveFromCache(tx) // may be resubmitted once the ban expired
+				mem.logger.Debug(err.Error(), "tx", types.Tx(tx).Hash())
+				mem.metrics.BannedSenderTxs.Add(1)
+				mem.recordRejected(tx, RejectionBannedSender, r.CheckTx, err)
+				return
+			}
+
+			// Find the tx of the same sender and sequence which the tx
+			// replaces, if its priority is high enough.
+			replaced, err := mem.replacedTx(txKey, r.CheckTx)
+			if err != nil {
+				mem.forceRemoveFromCache(tx) // may be resubmitted with a higher priority
+				mem.logger.Debug(err.Error(), "tx", types.Tx(tx).Hash())
+				mem.metrics.RejectedTxs.Add(1)
+				mem.recordRejected(tx, RejectionUnderpriced, r.CheckTx, err)
+				return
+			}
+
 			// Check mempool isn't full again to reduce the chance of exceeding the
-			// limits.
-			if err := mem.isFull(len(tx)); err != nil {
+			// limits. The priority mempool instead tries to evict lower-priority
+			// txs below, once the tx is known to be admissible otherwise.
+			fullErr := mem.isFullReplacing(len(tx), replaced)
+			if fullErr != nil && !mem.prioritized() {
 				mem.forceRemoveFromCache(tx) // mempool might have space later
-				mem.logger.Error(err.Error())
+				mem.logger.Error(fullErr.Error())
+				mem.recordRejected(tx, RejectionMempoolFull, r.CheckTx, fullErr)
+				return
+			}
+
+			// Check the quota of the tx class, so that a class cannot crowd
+			// out the others.
+			class := txClass(r.CheckTx)
+			if replaced == nil || replaced.class != class {
+				err = mem.isClassFull(class)
+			}
+			if err != nil {
+				mem.forceRemoveFromCache(tx) // class might have space later
+				mem.logger.Debug(err.Error(), "tx", types.Tx(tx).Hash())
+				mem.metrics.RejectedTxs.Add(1)
+				mem.recordRejected(tx, RejectionClassFull, r.CheckTx, err)
+				return
+			}
+
+			// Check the caps of the tx sender, so that a single sender cannot
+			// fill the mempool.
+			if err := mem.isSenderFull(r.CheckTx.Sender, len(tx), replaced); err != nil {
+				mem.forceRemoveFromCache(tx) // sender might have space later
+				mem.logger.Debug(err.Error(), "tx", types.Tx(tx).Hash())
+				mem.metrics.RejectedTxs.Add(1)
+				mem.recordRejected(tx, RejectionSenderFull, r.CheckTx, err)
 				return
 			}
 
@@ -405,11 +563,37 @@ func (mem *CListMempool) resCbFirstTime(
 				return
 			}
 
-			mem.addTx(&mempoolTx{
+			if fullErr != nil && !mem.evictForTx(r.CheckTx.Priority, len(tx)) {
+				mem.forceRemoveFromCache(tx) // mempool might have space later
+				mem.logger.Debug(fullErr.Error(), "tx", types.Tx(tx).Hash(), "priority", r.CheckTx.Priority)
+				mem.metrics.RejectedTxs.Add(1)
+				mem.recordRejected(tx, RejectionMempoolFull, r.CheckTx, fullErr)
+				return
+			}
+			if replaced != nil {
+				mem.replaceTx(replaced, r.CheckTx.Priority)
+			}
+
+			memTx := &mempoolTx{
 				height:    mem.height,
 				gasWanted: r.CheckTx.GasWanted,
 				tx:        tx,
-			})
+				class:     class,
+				priority:  r.CheckTx.Priority,
+				sender:    r.CheckTx.Sender,
+				sequence:  r.CheckTx.Sequence,
+				events:    r.CheckTx.Events,
+			}
+			mem.setExpiry(memTx, r.CheckTx, time.Now())
+			if isRestored {
+				memTx.restoreMetadata(restored)
+			}
+			if mem.config.ExperimentalEncryptedTxs && IsEncryptedTx(tx) {
+				// The envelope was already validated in CheckTx.
+				memTx.decryptionHeight, _, _ = DecodeEncryptedTx(tx)
+			}
+			mem.addTx(memTx)
+			mem.notifyAccepted(tx, r.CheckTx)
 			mem.logger.Debug(
 				"added valid transaction",
 				"tx", types.Tx(tx).Hash(),
@@ -427,6 +611,11 @@ func (mem *CListMempool) resCbFirstTime(
 				"err", postCheckErr,
 			)
 			mem.metrics.FailedTxs.Add(1)
+			if postCheckErr != nil {
+				mem.recordRejected(tx, RejectionPostCheck, r.CheckTx, postCheckErr)
+			} else {
+				mem.recordRejected(tx, RejectionCheckTx, r.CheckTx, nil)
+			}
 		}
 
 	default:
@@ -472,19 +661,7 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 			memTx = mem.recheckCursor.Value.(*mempoolTx)
 		}
 
-		var postCheckErr error
-		if mem.postCheck != nil {
-			postCheckErr = mem.postCheck(tx, r.CheckTx)
-		}
-
-		if (r.CheckTx.Code != abci.CodeTypeOK) || postCheckErr != nil {
-			// Tx became invalidated due to newly committed block.
-			mem.logger.Debug("tx is no longer valid", "tx", types.Tx(tx).Hash(), "res", r, "err", postCheckErr)
-			if err := mem.RemoveTxByKey(memTx.tx.Key()); err != nil {
-				mem.logger.Debug("Transaction could not be removed from mempool", "err", err)
-			}
-			mem.tryRemoveFromCache(tx)
-		}
+		mem.handleRecheckResult(memTx, r.CheckTx)
 		if mem.recheckCursor == mem.recheckEnd {
 			mem.recheckCursor = nil
 		} else {
@@ -504,6 +681,29 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 	}
 }
 
+// handleRecheckResult removes the tx from the mempool if the application
+// rejected it when rechecking it, or updates its priority otherwise.
+func (mem *CListMempool) handleRecheckResult(memTx *mempoolTx, res *abci.ResponseCheckTx) {
+	var postCheckErr error
+	if mem.postCheck != nil {
+		postCheckErr = mem.postCheck(memTx.tx, res)
+	}
+
+	if (res.Code != abci.CodeTypeOK) || postCheckErr != nil {
+		// Tx became invalidated due to newly committed block.
+		mem.logger.Debug("tx is no longer valid", "tx", memTx.tx.Hash(), "res", res, "err", postCheckErr)
+		if err := mem.RemoveTxByKey(memTx.tx.Key()); err != nil {
+			mem.logger.Debug("Transaction could not be removed from mempool", "err", err)
+		} else {
+			mem.notifyEvicted(memTx.tx, EvictionRecheckFailed)
+		}
+		mem.tryRemoveFromCache(memTx.tx)
+	} else {
+		// The priority of the tx may change with the state of the application.
+		atomic.StoreInt64(&memTx.priority, res.Priority)
+	}
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) TxsAvailable() <-chan struct{} {
 	return mem.txsAvailable
@@ -536,10 +736,9 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	// TODO: we will get a performance boost if we have a good estimate of avg
 	// size per tx, and set the initial capacity based off of that.
 	// txs := make([]types.Tx, 0, cmtmath.MinInt(mem.txs.Len(), max/mem.avgTxSize))
-	txs := make([]types.Tx, 0, mem.txs.Len())
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
-
+	memTxs := mem.reapableTxs()
+	txs := make([]types.Tx, 0, len(memTxs))
+	for _, memTx := range memTxs {
 		txs = append(txs, memTx.tx)
 
 		dataSize := types.ComputeProtoSizeForTxs([]types.Tx{memTx.tx})
@@ -573,14 +772,61 @@ func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
 		max = mem.txs.Len()
 	}
 
-	txs := make([]types.Tx, 0, cmtmath.MinInt(mem.txs.Len(), max))
-	for e := mem.txs.Front(); e != nil && len(txs) <= max; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
+	memTxs := mem.reapableTxs()
+	txs := make([]types.Tx, 0, cmtmath.MinInt(len(memTxs), max))
+	for _, memTx := range memTxs {
+		if len(txs) > max {
+			break
+		}
 		txs = append(txs, memTx.tx)
 	}
 	return txs
 }
 
+// ReapMatchingTxs reaps, in the order of ReapMaxTxs, up to max transactions
+// for which match returns true. If max is negative, all the matching
+// transactions are returned.
+func (mem *CListMempool) ReapMatchingTxs(max int, match TxMatchFunc) types.Txs {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	txs := make([]types.Tx, 0)
+	for _, memTx := range mem.reapableTxs() {
+		if max >= 0 && len(txs) >= max {
+			break
+		}
+		if match(memTx.tx, memTx.sender, memTx.events) {
+			txs = append(txs, memTx.tx)
+		}
+	}
+	return txs
+}
+
+// EncryptedTxs returns the encrypted transactions of the mempool whose
+// decryption height is the given height, in FIFO order. They are returned
+// whether or not they are reapable yet.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) EncryptedTxs(decryptionHeight int64) types.Txs {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	txs := make([]types.Tx, 0)
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if memTx.decryptionHeight == decryptionHeight {
+			txs = append(txs, memTx.tx)
+		}
+	}
+	return txs
+}
+
+// isReapable returns false for encrypted txs that cannot be proposed in the
+// next block because their decryption height has not been reached yet.
+func (mem *CListMempool) isReapable(memTx *mempoolTx) bool {
+	return memTx.decryptionHeight == 0 || memTx.decryptionHeight <= mem.height+1
+}
+
 // Lock() must be help by the caller during execution.
 // TODO: this function always returns nil; remove the return value
 func (mem *CListMempool) Update(
@@ -591,7 +837,7 @@ func (mem *CListMempool) Update(
 	postCheck PostCheckFunc,
 ) error {
 	// Set height
-	mem.height = height
+	atomic.StoreInt64(&mem.height, height)
 	mem.notifiedTxsAvailable = false
 
 	if preCheck != nil {
@@ -626,20 +872,32 @@ func (mem *CListMempool) Update(
 		}
 	}
 
+	if mem.config.ExperimentalEncryptedTxs {
+		mem.removeExpiredEncryptedTxs(height)
+	}
+	mem.removeExpiredTxs(height, time.Now())
+
 	// Either recheck non-committed txs to see if they became invalid
 	// or just notify there're some txs left.
 	if mem.Size() > 0 {
-		if mem.config.Recheck {
+		switch {
+		case mem.config.Recheck && mem.config.RecheckConcurrency > 1:
+			mem.logger.Debug("recheck txs", "numtxs", mem.Size(), "height", height,
+				"concurrency", mem.config.RecheckConcurrency)
+			mem.recheckTxsParallel()
+		case mem.config.Recheck:
 			mem.logger.Debug("recheck txs", "numtxs", mem.Size(), "height", height)
 			mem.recheckTxs()
 			// At this point, mem.txs are being rechecked.
 			// mem.recheckCursor re-scans mem.txs and possibly removes some txs.
 			// Before mem.Reap(), we should wait for mem.recheckCursor to be nil.
-		} else {
+		default:
 			mem.notifyTxsAvailable()
 		}
 	}
 
+	mem.compactWAL(false)
+
 	// Update metrics
 	mem.metrics.Size.Set(float64(mem.Size()))
 	mem.metrics.SizeBytes.Set(float64(mem.SizeBytes()))
@@ -647,6 +905,29 @@ func (mem *CListMempool) Update(
 	return nil
 }
 
+// removeExpiredEncryptedTxs removes the encrypted txs whose decryption height
+// is not above the given committed height. The decryption shares for those
+// txs are no longer available, so they can never be proposed.
+//
+// Lock() must be held by the caller during execution.
+func (mem *CListMempool) removeExpiredEncryptedTxs(height int64) {
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if memTx.decryptionHeight == 0 || memTx.decryptionHeight > height {
+			continue
+		}
+		if err := mem.RemoveTxByKey(memTx.tx.Key()); err != nil {
+			mem.logger.Debug("Expired encrypted transaction could not be removed from mempool", "err", err)
+			continue
+		}
+		mem.notifyEvicted(memTx.tx, EvictionDecryptionHeightPassed)
+		mem.logger.Debug("removed expired encrypted transaction",
+			"tx", memTx.tx.Hash(),
+			"decryption_height", memTx.decryptionHeight,
+			"height", height)
+	}
+}
+
 func (mem *CListMempool) recheckTxs() {
 	if mem.Size() == 0 {
 		panic("recheckTxs is called, but the mempool is empty")
@@ -673,3 +954,71 @@ func (mem *CListMempool) recheckTxs() {
 	// all pending messages to the app. There doesn't seem to be any need here as the buffer
 	// will get flushed regularly or when filled.
 }
+
+// recheckTxsParallel rechecks all the txs in the mempool with up to
+// config.RecheckConcurrency concurrent CheckTx calls to the application, and
+// removes the txs that became invalid. Unlike recheckTxs, it returns once all
+// the txs have been rechecked.
+//
+// The recheck cursor stays nil, so that globalCb ignores the responses to
+// these calls if the client also reports them to the response callback.
+//
+// Lock() must be held by the caller during execution.
+func (mem *CListMempool) recheckTxsParallel() {
+	if mem.Size() == 0 {
+		panic("recheckTxsParallel is called, but the mempool is empty")
+	}
+
+	memTxs := make([]*mempoolTx, 0, mem.Size())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTxs = append(memTxs, e.Value.(*mempoolTx))
+	}
+	results := make([]*abci.ResponseCheckTx, len(memTxs))
+
+	workers := mem.config.RecheckConcurrency
+	if workers > len(memTxs) {
+		workers = len(memTxs)
+	}
+	var (
+		next int64 = -1
+		wg   sync.WaitGroup
+	)
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				idx := int(atomic.AddInt64(&next, 1))
+				if idx >= len(memTxs) {
+					return
+				}
+				res, err := mem.proxyAppConn.CheckTx(context.TODO(), &abci.RequestCheckTx{
+					Tx:   memTxs[idx].tx,
+					Type: abci.CheckTxType_Recheck,
+				})
+				if err != nil {
+					// The tx stays in the mempool, as in recheckTxs.
+					mem.logger.Error("recheckTx", "err", err)
+					continue
+				}
+				results[idx] = res
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Apply the results in the order of the txs in the mempool, as the
+	// sequential recheck does.
+	for i, res := range results {
+		if res == nil {
+			continue
+		}
+		mem.metrics.RecheckTimes.Add(1)
+		mem.handleRecheckResult(memTxs[i], res)
+	}
+	mem.logger.Debug("done rechecking txs")
+
+	if mem.Size() > 0 {
+		mem.notifyTxsAvailable()
+	}
+}
@@ -21,6 +21,7 @@ import (
 	abciserver "github.com/cometbft/cometbft/abci/server"
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/threshold"
 	"github.com/cometbft/cometbft/internal/test"
 	"github.com/cometbft/cometbft/libs/log"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
@@ -176,6 +177,24 @@ func TestReapMaxBytesMaxGas(t *testing.T) {
 	}
 }
 
+func TestMempoolGetTxByKey(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	txs := checkTxs(t, mp, 2)
+	for _, tx := range txs {
+		got, ok := mp.GetTxByKey(tx.Key())
+		require.True(t, ok)
+		require.Equal(t, tx, got)
+	}
+
+	require.NoError(t, mp.RemoveTxByKey(txs[0].Key()))
+	_, ok := mp.GetTxByKey(txs[0].Key())
+	require.False(t, ok)
+}
+
 func TestMempoolFilters(t *testing.T) {
 	app := kvstore.NewInMemoryApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -728,6 +747,64 @@ func TestMempoolRemoteAppConcurrency(t *testing.T) {
 	require.NoError(t, mp.FlushAppConn())
 }
 
+// recheckApp is a kvstore application rejecting the stale txs when they are
+// rechecked.
+type recheckApp struct {
+	*kvstore.Application
+	stale map[string]bool
+}
+
+func (app *recheckApp) CheckTx(ctx context.Context, req *abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	if req.Type == abci.CheckTxType_Recheck && app.stale[string(req.Tx)] {
+		return &abci.ResponseCheckTx{Code: kvstore.CodeTypeInvalidTxFormat}, nil
+	}
+	return app.Application.CheckTx(ctx, req)
+}
+
+func TestMempoolParallelRecheck(t *testing.T) {
+	sockPath := fmt.Sprintf("unix:///tmp/echo_%v.sock", cmtrand.Str(6))
+	app := &recheckApp{Application: kvstore.NewInMemoryApplication(), stale: make(map[string]bool)}
+	_, server := newRemoteApp(t, sockPath, app)
+	t.Cleanup(func() {
+		if err := server.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.RecheckConcurrency = 4
+	mp, cleanup := newMempoolWithAppAndConfig(proxy.NewRemoteClientCreator(sockPath, "socket", true), cfg)
+	defer cleanup()
+	mp.EnableTxsAvailable()
+
+	var txs, valid types.Txs
+	for i := 0; i < 50; i++ {
+		tx := types.Tx(fmt.Sprintf("key%d=value", i))
+		txs = append(txs, tx)
+		if i%3 == 0 {
+			app.stale[string(tx)] = true
+		} else {
+			valid = append(valid, tx)
+		}
+	}
+	callCheckTx(t, mp, txs)
+	require.NoError(t, mp.FlushAppConn())
+	require.Equal(t, len(txs), mp.Size())
+	ensureFire(t, mp.TxsAvailable(), 100)
+
+	// The recheck is done when Update returns, and keeps the order of the
+	// remaining txs.
+	mp.Lock()
+	err := mp.Update(1, types.Txs{}, abciResponses(0, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	require.Equal(t, valid, mp.ReapMaxTxs(-1))
+	for tx := range app.stale {
+		require.False(t, mp.InMempool(types.Tx(tx).Key()))
+	}
+	ensureFire(t, mp.TxsAvailable(), 100)
+}
+
 // caller must close server
 func newRemoteApp(t *testing.T, addr string, app abci.Application) (abciclient.Client, service.Service) {
 	clientCreator, err := abciclient.NewClient(addr, "socket", true)
@@ -767,3 +844,81 @@ func doCommit(t require.TestingT, mp Mempool, app abci.Application, txs types.Tx
 	require.True(t, e == nil)
 	mp.Unlock()
 }
+
+func TestMempoolEncryptedTxs(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.ExperimentalEncryptedTxs = true
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	plainTx := kvstore.NewTxFromID(0)
+	encTx2 := EncodeEncryptedTx(2, []byte("enc=2"))
+	encTx3 := EncodeEncryptedTx(3, []byte("enc=3"))
+
+	height, ciphertext, err := DecodeEncryptedTx(encTx3)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), height)
+	require.Equal(t, []byte("enc=3"), ciphertext)
+
+	_, _, err = DecodeEncryptedTx(EncodeEncryptedTx(0, []byte("enc=0")))
+	require.ErrorIs(t, err, ErrInvalidEncryptedTx)
+
+	callCheckTx(t, mp, types.Txs{plainTx, encTx2, encTx3})
+	require.Equal(t, 3, mp.Size())
+
+	// At height 0, only the plaintext tx can be proposed in the next block.
+	require.Equal(t, types.Txs{plainTx}, mp.ReapMaxBytesMaxGas(-1, -1))
+	require.Equal(t, types.Txs{plainTx}, mp.ReapMaxTxs(-1))
+	// The validators compute their decryption shares before the txs can be
+	// reaped.
+	require.Equal(t, types.Txs{encTx3}, mp.EncryptedTxs(3))
+
+	// Once height 1 is committed, the tx to be decrypted at height 2 is
+	// released.
+	mp.Lock()
+	err = mp.Update(1, types.Txs{plainTx}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	require.Equal(t, types.Txs{encTx2}, mp.ReapMaxBytesMaxGas(-1, -1))
+
+	// The tx to be decrypted at height 2 was not included, so it is evicted.
+	mp.Lock()
+	err = mp.Update(2, types.Txs{}, abciResponses(0, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	require.Equal(t, types.Txs{encTx3}, mp.ReapMaxBytesMaxGas(-1, -1))
+	require.Equal(t, 1, mp.Size())
+
+	// Encrypted txs whose decryption height has passed are rejected.
+	_, err = mp.CheckTx(EncodeEncryptedTx(2, []byte("enc=late")))
+	require.ErrorAs(t, err, &ErrEncryptedTxExpired{})
+}
+
+func TestEncryptDecryptTx(t *testing.T) {
+	pk, keyShares, err := threshold.GenerateKeys(2, 2)
+	require.NoError(t, err)
+	tx, err := EncryptTx(pk, 5, types.Tx("secret"))
+	require.NoError(t, err)
+	height, ciphertext, err := DecodeEncryptedTx(tx)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), height)
+
+	shares := make([]*threshold.DecryptionShare, len(keyShares))
+	for i, ks := range keyShares {
+		shares[i], err = NewTxDecryptionShare(pk, ks, tx)
+		require.NoError(t, err)
+	}
+	plaintext, err := DecryptTx(pk, tx, shares)
+	require.NoError(t, err)
+	require.Equal(t, types.Tx("secret"), plaintext)
+
+	// The decryption height is authenticated, and the shares of the
+	// ciphertext are not released for another one.
+	forged := append(EncodeEncryptedTx(4, nil), ciphertext...)
+	_, err = DecryptTx(pk, forged, shares)
+	require.ErrorIs(t, err, threshold.ErrInvalidCiphertext)
+	_, err = NewTxDecryptionShare(pk, keyShares[0], forged)
+	require.ErrorIs(t, err, threshold.ErrInvalidCiphertext)
+}
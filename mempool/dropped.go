@@ -0,0 +1,215 @@
+package mempool
+
+import (
+	"sort"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Number of dropped txs retained by the DropLog.
+const droppedTxsRetained = 1000
+
+// DropKind tells whether a dropped tx was rejected before entering the
+// mempool or evicted from it.
+type DropKind string
+
+const (
+	DropRejected DropKind = "rejected"
+	DropEvicted  DropKind = "evicted"
+)
+
+// RejectionReason is the reason a tx was rejected from the mempool.
+type RejectionReason string
+
+const (
+	// RejectionInCache means that the tx was already received.
+	RejectionInCache RejectionReason = "in_cache"
+	// RejectionMempoolFull means that the mempool had no room for the tx.
+	RejectionMempoolFull RejectionReason = "mempool_full"
+	// RejectionTooLarge means that the tx exceeds the maximum tx size.
+	RejectionTooLarge RejectionReason = "too_large"
+	// RejectionPreCheck means that the tx failed the pre-check.
+	RejectionPreCheck RejectionReason = "pre_check"
+	// RejectionEncryptedTx means that the envelope of an encrypted tx is
+	// invalid, or that its decryption height was committed.
+	RejectionEncryptedTx RejectionReason = "encrypted_tx"
+	// RejectionCheckTx means that the application rejected the tx in CheckTx.
+	RejectionCheckTx RejectionReason = "check_tx"
+	// RejectionPostCheck means that the tx failed the post-check.
+	RejectionPostCheck RejectionReason = "post_check"
+	// RejectionClassFull means that the quota of the class of the tx was
+	// reached.
+	RejectionClassFull RejectionReason = "class_full"
+	// RejectionSenderFull means that the caps of the sender of the tx were
+	// reached.
+	RejectionSenderFull RejectionReason = "sender_full"
+	// RejectionUnderpriced means that the tx did not have a priority high
+	// enough to replace the tx of the same sender and sequence.
+	RejectionUnderpriced RejectionReason = "underpriced"
+	// RejectionRateLimited means that the peer which sent the tx exceeded the
+	// rate limits.
+	RejectionRateLimited RejectionReason = "rate_limited"
+	// RejectionBannedSender means that the sender of the tx was banned by the
+	// application.
+	RejectionBannedSender RejectionReason = "banned_sender"
+)
+
+// retained returns false for the rejections which are only counted, as they
+// happen for most txs received from peers.
+func (r RejectionReason) retained() bool {
+	switch r {
+	case RejectionInCache, RejectionRateLimited, RejectionBannedSender:
+		return false
+	default:
+		return true
+	}
+}
+
+// DroppedTx records a tx rejected or evicted from the mempool.
+type DroppedTx struct {
+	Hash   []byte
+	Kind   DropKind
+	Reason string
+	// Response of the application, if the tx was rejected in CheckTx.
+	Code      uint32
+	Codespace string
+	// Log of the application, or error of the pre- or post-check.
+	Log    string
+	Height int64
+	Time   time.Time
+}
+
+// DropCount is the number of txs dropped for a reason.
+type DropCount struct {
+	Kind   DropKind
+	Reason string
+	Count  int64
+}
+
+type dropKey struct {
+	kind   DropKind
+	reason string
+}
+
+// DropLog counts the txs dropped from the mempool by reason, and retains the
+// last dropped txs, so that operators can find out why a tx disappeared.
+type DropLog struct {
+	mtx    cmtsync.Mutex
+	counts map[dropKey]int64
+	txs    []DroppedTx // ring buffer
+	next   int
+}
+
+func newDropLog() *DropLog {
+	return &DropLog{
+		counts: make(map[dropKey]int64),
+		txs:    make([]DroppedTx, 0, droppedTxsRetained),
+	}
+}
+
+// count counts a tx dropped for the reason, without retaining it.
+func (l *DropLog) count(kind DropKind, reason string) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.counts[dropKey{kind, reason}]++
+}
+
+// record counts and retains the dropped tx.
+func (l *DropLog) record(dropped DroppedTx) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.counts[dropKey{dropped.Kind, dropped.Reason}]++
+	if len(l.txs) < droppedTxsRetained {
+		l.txs = append(l.txs, dropped)
+	} else {
+		l.txs[l.next] = dropped
+	}
+	l.next = (l.next + 1) % droppedTxsRetained
+}
+
+// Counts returns the number of txs dropped for each reason since the node
+// started, sorted by kind and reason.
+func (l *DropLog) Counts() []DropCount {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	counts := make([]DropCount, 0, len(l.counts))
+	for key, count := range l.counts {
+		counts = append(counts, DropCount{Kind: key.kind, Reason: key.reason, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Kind != counts[j].Kind {
+			return counts[i].Kind < counts[j].Kind
+		}
+		return counts[i].Reason < counts[j].Reason
+	})
+	return counts
+}
+
+// Txs returns the last dropped txs retained, most recent first, or only
+// those with the given key if not nil. The txs rejected because they were
+// already received or because of the peer which sent them are not retained.
+func (l *DropLog) Txs(txKey *types.TxKey) []DroppedTx {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	txs := make([]DroppedTx, 0)
+	for i := 1; i <= len(l.txs); i++ {
+		dropped := l.txs[(l.next-i+len(l.txs))%len(l.txs)]
+		if txKey == nil || types.TxKey(dropped.Hash) == *txKey {
+			txs = append(txs, dropped)
+		}
+	}
+	return txs
+}
+
+// DropLog returns the log of the txs dropped from the mempool.
+func (mem *CListMempool) DropLog() *DropLog {
+	return mem.dropLog
+}
+
+// recordRejected records the rejection of the tx, with the response of the
+// application or the error causing it, if any.
+func (mem *CListMempool) recordRejected(
+	tx types.Tx,
+	reason RejectionReason,
+	res *abci.ResponseCheckTx,
+	err error,
+) {
+	mem.metrics.DroppedTxs.With("kind", string(DropRejected), "reason", string(reason)).Add(1)
+	if !reason.retained() {
+		mem.dropLog.count(DropRejected, string(reason))
+		return
+	}
+	dropped := DroppedTx{
+		Hash:   tx.Hash(),
+		Kind:   DropRejected,
+		Reason: string(reason),
+		Height: mem.getHeight(),
+		Time:   time.Now(),
+	}
+	if res != nil {
+		dropped.Code, dropped.Codespace, dropped.Log = res.Code, res.Codespace, res.Log
+	}
+	if err != nil {
+		dropped.Log = err.Error()
+	}
+	mem.dropLog.record(dropped)
+}
+
+// recordEvicted records the eviction of the tx.
+func (mem *CListMempool) recordEvicted(tx types.Tx, reason EvictionReason) {
+	mem.metrics.DroppedTxs.With("kind", string(DropEvicted), "reason", string(reason)).Add(1)
+	mem.dropLog.record(DroppedTx{
+		Hash:   tx.Hash(),
+		Kind:   DropEvicted,
+		Reason: string(reason),
+		Height: mem.getHeight(),
+		Time:   time.Now(),
+	})
+}
@@ -0,0 +1,73 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestMempoolDropLog(t *testing.T) {
+	app := &ttlApp{kvstore.NewInMemoryApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.MaxTxBytes = 10
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	expiring, large, invalid := types.Tx("expiring=1"), types.Tx("large=00000"), types.Tx("invalid")
+	callCheckTx(t, mp, types.Txs{expiring})
+	_, err := mp.CheckTx(expiring)
+	require.ErrorIs(t, err, ErrTxInCache)
+	_, err = mp.CheckTx(large)
+	require.Error(t, err)
+	_, err = mp.CheckTx(invalid)
+	require.NoError(t, err)
+	require.NoError(t, mp.FlushAppConn())
+	mp.Lock()
+	require.NoError(t, mp.Update(1, types.Txs{}, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	mp.Unlock()
+
+	require.Equal(t, []DropCount{
+		{Kind: DropEvicted, Reason: string(EvictionExpired), Count: 1},
+		{Kind: DropRejected, Reason: string(RejectionCheckTx), Count: 1},
+		{Kind: DropRejected, Reason: string(RejectionInCache), Count: 1},
+		{Kind: DropRejected, Reason: string(RejectionTooLarge), Count: 1},
+	}, mp.DropLog().Counts())
+
+	// The cache hits are not retained, and the last dropped txs come first.
+	txs := mp.DropLog().Txs(nil)
+	require.Len(t, txs, 3)
+	require.Equal(t, []byte(expiring.Hash()), txs[0].Hash)
+	require.Equal(t, string(EvictionExpired), txs[0].Reason)
+	require.EqualValues(t, 1, txs[0].Height)
+	require.Equal(t, []byte(invalid.Hash()), txs[1].Hash)
+	require.Equal(t, string(RejectionCheckTx), txs[1].Reason)
+	require.Equal(t, kvstore.CodeTypeInvalidTxFormat, txs[1].Code)
+	require.Equal(t, []byte(large.Hash()), txs[2].Hash)
+	require.NotEmpty(t, txs[2].Log)
+
+	invalidKey := invalid.Key()
+	txs = mp.DropLog().Txs(&invalidKey)
+	require.Len(t, txs, 1)
+	require.Equal(t, string(RejectionCheckTx), txs[0].Reason)
+}
+
+func TestDropLogRetained(t *testing.T) {
+	l := newDropLog()
+	for i := 0; i < droppedTxsRetained+10; i++ {
+		l.record(DroppedTx{Kind: DropRejected, Reason: string(RejectionCheckTx), Height: int64(i)})
+	}
+	require.Equal(t, []DropCount{
+		{Kind: DropRejected, Reason: string(RejectionCheckTx), Count: droppedTxsRetained + 10},
+	}, l.Counts())
+	txs := l.Txs(nil)
+	require.Len(t, txs, droppedTxsRetained)
+	require.EqualValues(t, droppedTxsRetained+9, txs[0].Height)
+	require.EqualValues(t, 10, txs[droppedTxsRetained-1].Height)
+}
@@ -0,0 +1,86 @@
+package mempool
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/cometbft/cometbft/crypto/threshold"
+	"github.com/cometbft/cometbft/types"
+)
+
+// encryptedTxPrefix marks a transaction as a threshold-encrypted envelope.
+// The envelope layout is:
+//
+//	prefix (4 bytes) | decryption height (8 bytes, big endian) | ciphertext
+var encryptedTxPrefix = []byte{0xce, 0x7e, 0x0e, 0x01}
+
+const encryptedTxHeaderSize = 4 + 8
+
+// ErrInvalidEncryptedTx is returned when a transaction carries the encrypted
+// envelope prefix but cannot be decoded.
+var ErrInvalidEncryptedTx = errors.New("invalid encrypted tx envelope")
+
+// EncodeEncryptedTx wraps a ciphertext into an encrypted transaction envelope
+// that can only be decrypted once the decryption shares for decryptionHeight
+// are available.
+func EncodeEncryptedTx(decryptionHeight int64, ciphertext []byte) types.Tx {
+	tx := make([]byte, encryptedTxHeaderSize+len(ciphertext))
+	copy(tx, encryptedTxPrefix)
+	binary.BigEndian.PutUint64(tx[len(encryptedTxPrefix):], uint64(decryptionHeight))
+	copy(tx[encryptedTxHeaderSize:], ciphertext)
+	return tx
+}
+
+// IsEncryptedTx returns true if tx carries the encrypted envelope prefix.
+func IsEncryptedTx(tx types.Tx) bool {
+	return bytes.HasPrefix(tx, encryptedTxPrefix)
+}
+
+// DecodeEncryptedTx returns the decryption height and ciphertext of an
+// encrypted transaction envelope.
+func DecodeEncryptedTx(tx types.Tx) (int64, []byte, error) {
+	if !IsEncryptedTx(tx) || len(tx) < encryptedTxHeaderSize {
+		return 0, nil, ErrInvalidEncryptedTx
+	}
+	height := int64(binary.BigEndian.Uint64(tx[len(encryptedTxPrefix):encryptedTxHeaderSize]))
+	if height <= 0 {
+		return 0, nil, ErrInvalidEncryptedTx
+	}
+	return height, tx[encryptedTxHeaderSize:], nil
+}
+
+// EncryptTx encrypts the transaction to the threshold public key of the
+// validators, into an envelope for decryptionHeight. The header of the
+// envelope is authenticated, so the decryption height cannot be changed.
+func EncryptTx(pk *threshold.PublicKey, decryptionHeight int64, tx types.Tx) (types.Tx, error) {
+	if decryptionHeight <= 0 {
+		return nil, ErrInvalidEncryptedTx
+	}
+	header := EncodeEncryptedTx(decryptionHeight, nil)
+	ciphertext, err := threshold.Encrypt(pk, tx, header)
+	if err != nil {
+		return nil, err
+	}
+	return append(header, ciphertext...), nil
+}
+
+// NewTxDecryptionShare returns the decryption share of the holder of the key
+// share for the ciphertext of the encrypted transaction. The ciphertext must
+// be valid for the header of the envelope, so the shares of a ciphertext are
+// not released before its decryption height.
+func NewTxDecryptionShare(pk *threshold.PublicKey, ks *threshold.KeyShare, tx types.Tx) (*threshold.DecryptionShare, error) {
+	if _, _, err := DecodeEncryptedTx(tx); err != nil {
+		return nil, err
+	}
+	return threshold.NewDecryptionShare(pk, ks, tx[encryptedTxHeaderSize:], tx[:encryptedTxHeaderSize])
+}
+
+// DecryptTx combines the decryption shares of the ciphertext of the encrypted
+// transaction, and returns the plaintext transaction.
+func DecryptTx(pk *threshold.PublicKey, tx types.Tx, shares []*threshold.DecryptionShare) (types.Tx, error) {
+	if _, _, err := DecodeEncryptedTx(tx); err != nil {
+		return nil, err
+	}
+	return threshold.Decrypt(pk, tx[encryptedTxHeaderSize:], tx[:encryptedTxHeaderSize], shares)
+}
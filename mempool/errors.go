@@ -41,6 +41,70 @@ func (e ErrMempoolIsFull) Error() string {
 	)
 }
 
+// ErrTxClassIsFull defines an error where the mempool holds the maximum number
+// of transactions of a class.
+type ErrTxClassIsFull struct {
+	Class  string
+	NumTxs int
+	MaxTxs int
+}
+
+func (e ErrTxClassIsFull) Error() string {
+	return fmt.Sprintf("tx class %q is full: number of txs %d (max: %d)", e.Class, e.NumTxs, e.MaxTxs)
+}
+
+// ErrSenderIsFull defines an error where the transactions of a sender in the
+// mempool reach the per-sender caps.
+type ErrSenderIsFull struct {
+	Sender      string
+	NumTxs      int
+	MaxTxs      int
+	TxsBytes    int64
+	MaxTxsBytes int64
+}
+
+func (e ErrSenderIsFull) Error() string {
+	return fmt.Sprintf("sender %q is full: number of txs %d (max: %d), total txs bytes %d (max: %d)",
+		e.Sender, e.NumTxs, e.MaxTxs, e.TxsBytes, e.MaxTxsBytes)
+}
+
+// ErrSenderBanned defines an error where the sender of a transaction was
+// banned by the application.
+type ErrSenderBanned struct {
+	Sender string
+}
+
+func (e ErrSenderBanned) Error() string {
+	return fmt.Sprintf("sender %q is banned", e.Sender)
+}
+
+// ErrTxUnderpriced defines an error where a transaction does not have a
+// priority high enough to replace the transaction of the same sender and
+// sequence in the mempool.
+type ErrTxUnderpriced struct {
+	Sender      string
+	Sequence    uint64
+	Priority    int64
+	MinPriority int64
+}
+
+func (e ErrTxUnderpriced) Error() string {
+	return fmt.Sprintf("tx of sender %q with sequence %d is underpriced: priority %d (min: %d)",
+		e.Sender, e.Sequence, e.Priority, e.MinPriority)
+}
+
+// ErrEncryptedTxExpired defines an error where an encrypted transaction can
+// no longer be decrypted because its decryption height has already passed.
+type ErrEncryptedTxExpired struct {
+	DecryptionHeight int64
+	Height           int64
+}
+
+func (e ErrEncryptedTxExpired) Error() string {
+	return fmt.Sprintf("encrypted tx expired: decryption height %d, current height %d",
+		e.DecryptionHeight, e.Height)
+}
+
 // ErrPreCheck defines an error where a transaction fails a pre-check.
 type ErrPreCheck struct {
 	Err error
@@ -0,0 +1,44 @@
+package mempool
+
+import (
+	"github.com/cometbft/cometbft/types"
+)
+
+// EvictionReason is the reason a tx accepted in the mempool was removed from
+// it without being included in a block.
+type EvictionReason string
+
+const (
+	// EvictionMempoolFull means that the tx was evicted to make room for a
+	// tx of higher priority.
+	EvictionMempoolFull EvictionReason = "mempool_full"
+	// EvictionExpired means that the TTL of the tx expired.
+	EvictionExpired EvictionReason = "expired"
+	// EvictionRecheckFailed means that the application rejected the tx when
+	// rechecking it after a block.
+	EvictionRecheckFailed EvictionReason = "recheck_failed"
+	// EvictionDecryptionHeightPassed means that the decryption height of an
+	// encrypted tx was committed before the tx was included.
+	EvictionDecryptionHeightPassed EvictionReason = "decryption_height_passed"
+	// EvictionReplaced means that the tx was replaced by a tx of the same
+	// sender and sequence with a higher priority.
+	EvictionReplaced EvictionReason = "replaced"
+)
+
+// EvictionFunc is called when a tx is evicted from the mempool.
+type EvictionFunc func(tx types.Tx, reason EvictionReason)
+
+// WithEvictionCallback sets a function called, with the mempool locked, for
+// every tx evicted from the mempool.
+func WithEvictionCallback(f EvictionFunc) CListMempoolOption {
+	return func(mem *CListMempool) { mem.onEvicted = f }
+}
+
+// notifyEvicted records the eviction of the tx, and reports it to the
+// eviction callback, if any.
+func (mem *CListMempool) notifyEvicted(tx types.Tx, reason EvictionReason) {
+	mem.recordEvicted(tx, reason)
+	if mem.onEvicted != nil {
+		mem.onEvicted(tx, reason)
+	}
+}
@@ -0,0 +1,63 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestMempoolEvictionCallback(t *testing.T) {
+	app := &ttlApp{kvstore.NewInMemoryApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	evicted := make(map[string]EvictionReason)
+	WithEvictionCallback(func(tx types.Tx, reason EvictionReason) {
+		evicted[string(tx)] = reason
+	})(mp)
+
+	expiring, kept := types.Tx("expiring=1"), types.Tx("kept=0")
+	callCheckTx(t, mp, types.Txs{expiring, kept})
+
+	mp.Lock()
+	err := mp.Update(1, types.Txs{}, abciResponses(0, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	require.Equal(t, map[string]EvictionReason{string(expiring): EvictionExpired}, evicted)
+
+	// Committed txs are not evicted.
+	mp.Lock()
+	err = mp.Update(2, types.Txs{kept}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	require.Len(t, evicted, 1)
+}
+
+func TestMempoolEvictionCallbackPriority(t *testing.T) {
+	app := &priorityApp{kvstore.NewInMemoryApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.Type = config.MempoolTypePriority
+	cfg.Mempool.Size = 1
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	var evicted types.Txs
+	WithEvictionCallback(func(tx types.Tx, reason EvictionReason) {
+		require.Equal(t, EvictionMempoolFull, reason)
+		evicted = append(evicted, tx)
+	})(mp)
+
+	low, high := types.Tx("low=1"), types.Tx("high=2")
+	callCheckTx(t, mp, types.Txs{low, high})
+	require.Equal(t, types.Txs{low}, evicted)
+}
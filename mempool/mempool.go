@@ -44,6 +44,16 @@ type Mempool interface {
 	// (~ all available transactions).
 	ReapMaxTxs(max int) types.Txs
 
+	// ReapMatchingTxs reaps, in the order of ReapMaxTxs, up to max
+	// transactions for which match returns true. If max is negative, all the
+	// matching transactions are returned.
+	ReapMatchingTxs(max int, match TxMatchFunc) types.Txs
+
+	// EncryptedTxs returns the threshold-encrypted transactions whose
+	// decryption height is the given height, including those that cannot be
+	// reaped yet.
+	EncryptedTxs(decryptionHeight int64) types.Txs
+
 	// Lock locks the mempool. The consensus must be able to hold lock to safely
 	// update.
 	Lock()
@@ -97,11 +107,21 @@ type Mempool interface {
 	SizeBytes() int64
 }
 
+// TxMatchFunc reports whether a transaction in the mempool matches, given
+// the sender assigned to it and the events emitted by the application when it
+// first checked it in CheckTx.
+type TxMatchFunc func(tx types.Tx, sender string, events []abci.Event) bool
+
 // PreCheckFunc is an optional filter executed before CheckTx and rejects
 // transaction if false is returned. An example would be to ensure that a
 // transaction doesn't exceeded the block size.
 type PreCheckFunc func(types.Tx) error
 
+// TxSenderFunc is an optional function returning the sender of a transaction,
+// as assigned by the application in CheckTx, without checking it. It returns
+// an empty sender if it's unknown.
+type TxSenderFunc func(types.Tx) string
+
 // PostCheckFunc is an optional filter executed after CheckTx and rejects
 // transaction if false is returned. An example would be to ensure a
 // transaction doesn't require more gas than available for the block.
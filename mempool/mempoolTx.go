@@ -2,7 +2,9 @@ package mempool
 
 import (
 	"sync/atomic"
+	"time"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/types"
 )
 
@@ -11,9 +13,41 @@ type mempoolTx struct {
 	height    int64    // height that this tx had been validated in
 	gasWanted int64    // amount of gas this tx states it will require
 	tx        types.Tx // validated by the application
+
+	// decryptionHeight is the only height at which an encrypted tx can be
+	// proposed. It is zero for plaintext txs.
+	decryptionHeight int64
+
+	// class is the class assigned to the tx by the application in CheckTx.
+	class string
+
+	// priority is the priority assigned to the tx by the application in
+	// CheckTx, updated when the tx is rechecked.
+	priority int64
+
+	// sender and sequence are assigned to the tx by the application in
+	// CheckTx. The txs of the same sender are reaped in increasing order of
+	// sequence.
+	sender   string
+	sequence uint64
+
+	// events are the events emitted by the application when it first checked
+	// the tx, for the txs to be searched.
+	events []abci.Event
+
+	// expiresAtHeight and expiresAt are the committed height and the time at
+	// which the tx expires and is evicted if it was not included. Zero values
+	// mean the tx does not expire.
+	expiresAtHeight int64
+	expiresAt       time.Time
 }
 
 // Height returns the height for this transaction
 func (memTx *mempoolTx) Height() int64 {
 	return atomic.LoadInt64(&memTx.height)
 }
+
+// Priority returns the priority of this transaction.
+func (memTx *mempoolTx) Priority() int64 {
+	return atomic.LoadInt64(&memTx.priority)
+}
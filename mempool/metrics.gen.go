@@ -20,6 +20,12 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "size",
 			Help:      "Number of uncommitted transactions in the mempool.",
 		}, labels).With(labelsAndValues...),
+		ClassSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "class_size",
+			Help:      "Number of uncommitted transactions in the mempool per tx class.",
+		}, append(labels, "class")).With(labelsAndValues...),
 		SizeBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -46,12 +52,42 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "rejected_txs",
 			Help:      "Number of rejected transactions.",
 		}, labels).With(labelsAndValues...),
+		EvictedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "evicted_txs",
+			Help:      "Number of evicted transactions.",
+		}, labels).With(labelsAndValues...),
+		ExpiredTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "expired_txs",
+			Help:      "Number of expired transactions.",
+		}, labels).With(labelsAndValues...),
 		RecheckTimes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "recheck_times",
 			Help:      "Number of times transactions are rechecked in the mempool.",
 		}, labels).With(labelsAndValues...),
+		RateLimitedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rate_limited_txs",
+			Help:      "Number of transactions dropped due to the per-peer rate limits.",
+		}, labels).With(labelsAndValues...),
+		BannedSenderTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "banned_sender_txs",
+			Help:      "Number of transactions dropped because their sender was banned by the application.",
+		}, labels).With(labelsAndValues...),
+		DroppedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "dropped_txs",
+			Help:      "Number of transactions rejected or evicted from the mempool, by reason.",
+		}, append(labels, "kind", "reason")).With(labelsAndValues...),
 		AlreadyReceivedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -64,11 +100,17 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 func NopMetrics() *Metrics {
 	return &Metrics{
 		Size:               discard.NewGauge(),
+		ClassSize:          discard.NewGauge(),
 		SizeBytes:          discard.NewGauge(),
 		TxSizeBytes:        discard.NewHistogram(),
 		FailedTxs:          discard.NewCounter(),
 		RejectedTxs:        discard.NewCounter(),
+		EvictedTxs:         discard.NewCounter(),
+		ExpiredTxs:         discard.NewCounter(),
 		RecheckTimes:       discard.NewCounter(),
+		RateLimitedTxs:     discard.NewCounter(),
+		BannedSenderTxs:    discard.NewCounter(),
+		DroppedTxs:         discard.NewCounter(),
 		AlreadyReceivedTxs: discard.NewCounter(),
 	}
 }
@@ -18,6 +18,9 @@ type Metrics struct {
 	// Number of uncommitted transactions in the mempool.
 	Size metrics.Gauge
 
+	// Number of uncommitted transactions in the mempool per tx class.
+	ClassSize metrics.Gauge `metrics_labels:"class"`
+
 	// Total size of the mempool in bytes.
 	SizeBytes metrics.Gauge
 
@@ -34,9 +37,36 @@ type Metrics struct {
 	//metrics:Number of rejected transactions.
 	RejectedTxs metrics.Counter
 
+	// EvictedTxs defines the number of evicted transactions. These are valid
+	// transactions that passed CheckTx and existed in the mempool but were
+	// later evicted to make room for higher priority valid transactions.
+	//metrics:Number of evicted transactions.
+	EvictedTxs metrics.Counter
+
+	// ExpiredTxs defines the number of expired transactions. These are valid
+	// transactions that were evicted from the mempool because they were not
+	// included before their TTL elapsed.
+	//metrics:Number of expired transactions.
+	ExpiredTxs metrics.Counter
+
 	// Number of times transactions are rechecked in the mempool.
 	RecheckTimes metrics.Counter
 
+	// RateLimitedTxs defines the number of transactions received from peers
+	// that were dropped because the peer exceeded the configured rate limits.
+	//metrics:Number of transactions dropped due to the per-peer rate limits.
+	RateLimitedTxs metrics.Counter
+
+	// BannedSenderTxs defines the number of transactions that were dropped
+	// because the application banned their sender in CheckTx.
+	//metrics:Number of transactions dropped because their sender was banned by the application.
+	BannedSenderTxs metrics.Counter
+
+	// DroppedTxs defines the number of transactions rejected or evicted from
+	// the mempool, by kind (rejected or evicted) and reason.
+	//metrics:Number of transactions rejected or evicted from the mempool, by reason.
+	DroppedTxs metrics.Counter `metrics_labels:"kind, reason"`
+
 	// Number of times transactions were received more than once.
 	//metrics:Number of duplicate transaction reception.
 	AlreadyReceivedTxs metrics.Counter
@@ -49,6 +49,22 @@ func (_m *Mempool) EnableTxsAvailable() {
 	_m.Called()
 }
 
+// EncryptedTxs provides a mock function with given fields: decryptionHeight
+func (_m *Mempool) EncryptedTxs(decryptionHeight int64) types.Txs {
+	ret := _m.Called(decryptionHeight)
+
+	var r0 types.Txs
+	if rf, ok := ret.Get(0).(func(int64) types.Txs); ok {
+		r0 = rf(decryptionHeight)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Txs)
+		}
+	}
+
+	return r0
+}
+
 // Flush provides a mock function with given fields:
 func (_m *Mempool) Flush() {
 	_m.Called()
@@ -105,6 +121,22 @@ func (_m *Mempool) ReapMaxTxs(max int) types.Txs {
 	return r0
 }
 
+// ReapMatchingTxs provides a mock function with given fields: max, match
+func (_m *Mempool) ReapMatchingTxs(max int, match mempool.TxMatchFunc) types.Txs {
+	ret := _m.Called(max, match)
+
+	var r0 types.Txs
+	if rf, ok := ret.Get(0).(func(int, mempool.TxMatchFunc) types.Txs); ok {
+		r0 = rf(max, match)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Txs)
+		}
+	}
+
+	return r0
+}
+
 // RemoveTxByKey provides a mock function with given fields: txKey
 func (_m *Mempool) RemoveTxByKey(txKey types.TxKey) error {
 	ret := _m.Called(txKey)
@@ -0,0 +1,81 @@
+package mempool
+
+import (
+	"time"
+)
+
+// tokenBucket is a token bucket refilled at a constant rate, up to a burst.
+type tokenBucket struct {
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst float64, now time.Time) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: burst, tokens: burst, last: now}
+}
+
+// refill adds the tokens accumulated since the last refill.
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+}
+
+// peerIngressLimiter limits the rate of the transactions received from a
+// peer, in transactions and in bytes per second. A nil bucket is unlimited.
+type peerIngressLimiter struct {
+	txs   *tokenBucket
+	bytes *tokenBucket
+
+	// Whether the last transaction received from the peer was dropped.
+	throttled bool
+}
+
+// newPeerIngressLimiter returns a limiter for a peer, or nil if the config
+// sets no limits. The bytes burst is at least the maximum transaction size,
+// so that any transaction may eventually be accepted.
+func newPeerIngressLimiter(maxTxsPerSecond int, maxBytesPerSecond int64, maxTxBytes int, now time.Time) *peerIngressLimiter {
+	if maxTxsPerSecond <= 0 && maxBytesPerSecond <= 0 {
+		return nil
+	}
+	l := &peerIngressLimiter{}
+	if maxTxsPerSecond > 0 {
+		l.txs = newTokenBucket(float64(maxTxsPerSecond), float64(maxTxsPerSecond), now)
+	}
+	if maxBytesPerSecond > 0 {
+		burst := float64(maxBytesPerSecond)
+		if float64(maxTxBytes) > burst {
+			burst = float64(maxTxBytes)
+		}
+		l.bytes = newTokenBucket(float64(maxBytesPerSecond), burst, now)
+	}
+	return l
+}
+
+// allow returns true, and consumes the tokens for it, if a transaction of the
+// given size received at the given time is within the limits.
+func (l *peerIngressLimiter) allow(txSize int, now time.Time) bool {
+	if l.txs != nil {
+		l.txs.refill(now)
+		if l.txs.tokens < 1 {
+			return false
+		}
+	}
+	if l.bytes != nil {
+		l.bytes.refill(now)
+		if l.bytes.tokens < float64(txSize) {
+			return false
+		}
+		l.bytes.tokens -= float64(txSize)
+	}
+	if l.txs != nil {
+		l.txs.tokens--
+	}
+	return true
+}
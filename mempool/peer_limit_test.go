@@ -0,0 +1,38 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerIngressLimiter(t *testing.T) {
+	now := time.Now()
+	require.Nil(t, newPeerIngressLimiter(0, 0, 100, now))
+
+	// At most 2 txs per second.
+	l := newPeerIngressLimiter(2, 0, 100, now)
+	require.True(t, l.allow(10, now))
+	require.True(t, l.allow(10, now))
+	require.False(t, l.allow(10, now))
+	require.False(t, l.allow(10, now.Add(100*time.Millisecond)))
+	require.True(t, l.allow(10, now.Add(500*time.Millisecond)))
+	// The bucket does not fill beyond one second worth of txs.
+	now = now.Add(time.Hour)
+	require.True(t, l.allow(10, now))
+	require.True(t, l.allow(10, now))
+	require.False(t, l.allow(10, now))
+
+	// At most 50 bytes per second, with a burst of the max tx size.
+	l = newPeerIngressLimiter(0, 50, 100, now)
+	require.True(t, l.allow(100, now))
+	require.False(t, l.allow(1, now))
+	require.True(t, l.allow(25, now.Add(500*time.Millisecond)))
+	require.False(t, l.allow(25, now.Add(500*time.Millisecond)))
+
+	// A tx rejected by the bytes limit does not consume a tx token.
+	l = newPeerIngressLimiter(1, 10, 10, now)
+	require.False(t, l.allow(20, now))
+	require.True(t, l.allow(10, now))
+}
@@ -0,0 +1,136 @@
+package mempool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/tempfile"
+	"github.com/cometbft/cometbft/types"
+)
+
+// persistedTx is a tx saved to disk on shutdown, along with the metadata
+// assigned to it in CheckTx.
+type persistedTx struct {
+	Tx              types.Tx  `json:"tx"`
+	Height          int64     `json:"height"`
+	GasWanted       int64     `json:"gas_wanted"`
+	Class           string    `json:"class,omitempty"`
+	Priority        int64     `json:"priority,omitempty"`
+	Sender          string    `json:"sender,omitempty"`
+	Sequence        uint64    `json:"sequence,omitempty"`
+	ExpiresAtHeight int64     `json:"expires_at_height,omitempty"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
+
+type persistedMempool struct {
+	Height int64         `json:"height"`
+	Txs    []persistedTx `json:"txs"`
+}
+
+// SaveTxs saves the txs of the mempool, in order, to the file at the given
+// path, along with the metadata assigned to them in CheckTx. It is meant to
+// be called on shutdown, once the mempool no longer receives txs.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) SaveTxs(path string) error {
+	mem.updateMtx.RLock()
+	pm := persistedMempool{
+		Height: mem.height,
+		Txs:    make([]persistedTx, 0, mem.Size()),
+	}
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		pm.Txs = append(pm.Txs, persistedTx{
+			Tx:              memTx.tx,
+			Height:          memTx.Height(),
+			GasWanted:       memTx.gasWanted,
+			Class:           memTx.class,
+			Priority:        memTx.Priority(),
+			Sender:          memTx.sender,
+			Sequence:        memTx.sequence,
+			ExpiresAtHeight: memTx.expiresAtHeight,
+			ExpiresAt:       memTx.expiresAt,
+		})
+	}
+	mem.updateMtx.RUnlock()
+
+	jsonBytes, err := json.Marshal(pm)
+	if err != nil {
+		return err
+	}
+	if err := tempfile.WriteFileAtomic(path, jsonBytes, 0o600); err != nil {
+		return err
+	}
+	mem.logger.Info("Saved mempool transactions", "file", path, "txs", len(pm.Txs))
+	return nil
+}
+
+// LoadTxs resubmits the txs saved to the file at the given path to CheckTx,
+// in order, and removes the file. The txs accepted by the application keep
+// the height at which they entered the mempool and their expiry, while the
+// other metadata is assigned again by CheckTx. It returns the number of txs
+// resubmitted, 0 if the file does not exist.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) LoadTxs(path string) (int, error) {
+	jsonBytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	var pm persistedMempool
+	if err := json.Unmarshal(jsonBytes, &pm); err != nil {
+		return 0, fmt.Errorf("failed to decode mempool transactions from %s: %w", path, err)
+	}
+
+	mem.restoredMtx.Lock()
+	mem.restored = make(map[types.TxKey]persistedTx, len(pm.Txs))
+	for _, ptx := range pm.Txs {
+		mem.restored[ptx.Tx.Key()] = ptx
+	}
+	mem.restoredMtx.Unlock()
+
+	n := 0
+	for _, ptx := range pm.Txs {
+		if _, err := mem.CheckTx(ptx.Tx); err != nil {
+			mem.takeRestored(ptx.Tx.Key())
+			mem.logger.Debug("Saved transaction not resubmitted", "tx", ptx.Tx.Hash(), "err", err)
+			continue
+		}
+		n++
+	}
+	if err := os.Remove(path); err != nil {
+		return n, err
+	}
+	mem.logger.Info("Resubmitted saved mempool transactions", "file", path, "txs", n, "saved", len(pm.Txs))
+	return n, nil
+}
+
+// takeRestored returns and forgets the metadata of the given tx, if it was
+// resubmitted by LoadTxs.
+func (mem *CListMempool) takeRestored(txKey types.TxKey) (persistedTx, bool) {
+	mem.restoredMtx.Lock()
+	defer mem.restoredMtx.Unlock()
+	ptx, ok := mem.restored[txKey]
+	if ok {
+		delete(mem.restored, txKey)
+	}
+	return ptx, ok
+}
+
+// restoreMetadata restores the height at which the given tx, resubmitted by
+// LoadTxs, entered the mempool, and its expiry if earlier than the one just
+// assigned.
+func (memTx *mempoolTx) restoreMetadata(ptx persistedTx) {
+	memTx.height = ptx.Height
+	if ptx.ExpiresAtHeight > 0 && (memTx.expiresAtHeight == 0 || ptx.ExpiresAtHeight < memTx.expiresAtHeight) {
+		memTx.expiresAtHeight = ptx.ExpiresAtHeight
+	}
+	if !ptx.ExpiresAt.IsZero() && (memTx.expiresAt.IsZero() || ptx.ExpiresAt.Before(memTx.expiresAt)) {
+		memTx.expiresAt = ptx.ExpiresAt
+	}
+}
@@ -0,0 +1,63 @@
+package mempool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestMempoolSaveLoadTxs(t *testing.T) {
+	app := &ttlApp{kvstore.NewInMemoryApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	path := filepath.Join(t.TempDir(), "mempool.json")
+
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	// Loading without a saved file is a no-op.
+	n, err := mp.LoadTxs(path)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	txs := types.Txs{types.Tx("c=2"), types.Tx("a=0"), types.Tx("b=0")}
+	callCheckTx(t, mp, txs)
+	require.NoError(t, mp.SaveTxs(path))
+
+	// The txs are resubmitted in order, to a mempool at a later height.
+	mp2, cleanup2 := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup2()
+	mp2.Lock()
+	require.NoError(t, mp2.Update(1, types.Txs{}, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	mp2.Unlock()
+
+	n, err = mp2.LoadTxs(path)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, txs, mp2.ReapMaxTxs(-1))
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+
+	// The txs keep the height at which they entered the mempool, and their
+	// expiry.
+	e, ok := mp2.getCElement(txs[0].Key())
+	require.True(t, ok)
+	memTx := e.Value.(*mempoolTx)
+	require.Zero(t, memTx.Height())
+	require.EqualValues(t, 2, memTx.expiresAtHeight)
+	require.Empty(t, mp2.restored)
+
+	mp2.Lock()
+	require.NoError(t, mp2.Update(2, types.Txs{}, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	mp2.Unlock()
+	require.False(t, mp2.InMempool(txs[0].Key()))
+	require.Equal(t, 2, mp2.Size())
+}
@@ -0,0 +1,76 @@
+package mempool
+
+import (
+	"sort"
+
+	"github.com/cometbft/cometbft/config"
+)
+
+// prioritized returns true if the mempool orders transactions by the priority
+// the application assigned to them in CheckTx.
+func (mem *CListMempool) prioritized() bool {
+	return mem.config.Type == config.MempoolTypePriority
+}
+
+// sortByPriority sorts transactions in decreasing order of priority,
+// preserving the FIFO order of the transactions with the same priority.
+func sortByPriority(memTxs []*mempoolTx) {
+	sort.SliceStable(memTxs, func(i, j int) bool {
+		return memTxs[i].Priority() > memTxs[j].Priority()
+	})
+}
+
+// evictForTx evicts transactions of lower priority than the given one to make
+// room for a transaction of the given priority and size. The lowest-priority
+// transactions are evicted first and, among those with the same priority, the
+// most recent ones. It returns false, without evicting anything, if not
+// enough room can be made.
+func (mem *CListMempool) evictForTx(priority int64, txSize int) bool {
+	var candidates []*mempoolTx
+	for e := mem.txs.Back(); e != nil; e = e.Prev() {
+		memTx := e.Value.(*mempoolTx)
+		if memTx.Priority() < priority {
+			candidates = append(candidates, memTx)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Priority() < candidates[j].Priority()
+	})
+
+	var (
+		numTxs   = mem.Size()
+		txsBytes = mem.SizeBytes()
+		evicted  []*mempoolTx
+	)
+	hasRoom := func() bool {
+		return numTxs < mem.config.Size && int64(txSize)+txsBytes <= mem.config.MaxTxsBytes
+	}
+	for _, memTx := range candidates {
+		if hasRoom() {
+			break
+		}
+		evicted = append(evicted, memTx)
+		numTxs--
+		txsBytes -= int64(len(memTx.tx))
+	}
+	if !hasRoom() {
+		return false
+	}
+
+	for _, memTx := range evicted {
+		if err := mem.RemoveTxByKey(memTx.tx.Key()); err != nil {
+			continue
+		}
+		// The transaction may be resubmitted once the mempool has room again.
+		mem.forceRemoveFromCache(memTx.tx)
+		mem.metrics.EvictedTxs.Add(1)
+		mem.notifyEvicted(memTx.tx, EvictionMempoolFull)
+		mem.logger.Debug(
+			"evicted lower-priority transaction",
+			"tx", memTx.tx.Hash(),
+			"priority", memTx.Priority(),
+			"new_priority", priority,
+		)
+	}
+	return true
+}
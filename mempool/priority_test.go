@@ -0,0 +1,86 @@
+package mempool
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+// priorityApp is a kvstore application assigning the value of a tx as its
+// priority.
+type priorityApp struct {
+	*kvstore.Application
+}
+
+func (app *priorityApp) CheckTx(ctx context.Context, req *abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	res, err := app.Application.CheckTx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	parts := bytes.SplitN(req.Tx, []byte("="), 2)
+	if len(parts) == 2 {
+		res.Priority, _ = strconv.ParseInt(string(parts[1]), 10, 64)
+	}
+	return res, nil
+}
+
+func TestPriorityMempool(t *testing.T) {
+	app := &priorityApp{kvstore.NewInMemoryApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.Type = config.MempoolTypePriority
+	cfg.Mempool.Size = 3
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	low, high, mid := types.Tx("low=1"), types.Tx("high=3"), types.Tx("mid=2")
+	callCheckTx(t, mp, types.Txs{low, high, mid})
+	require.Equal(t, types.Txs{high, mid, low}, mp.ReapMaxTxs(-1))
+	require.Equal(t, types.Txs{high, mid}, mp.ReapMaxBytesMaxGas(types.ComputeProtoSizeForTxs(types.Txs{high, mid}), -1))
+
+	// A higher-priority tx evicts the lowest-priority one when full.
+	top := types.Tx("top=5")
+	callCheckTx(t, mp, types.Txs{top})
+	require.Equal(t, types.Txs{top, high, mid}, mp.ReapMaxTxs(-1))
+	require.False(t, mp.InMempool(low.Key()))
+
+	// Txs whose priority is not higher than any tx in the mempool are rejected.
+	callCheckTx(t, mp, types.Txs{types.Tx("tie=2"), types.Tx("zero=0")})
+	require.Equal(t, types.Txs{top, high, mid}, mp.ReapMaxTxs(-1))
+
+	// The evicted tx was removed from the cache, so it can be resubmitted once
+	// the mempool has room again.
+	mp.Lock()
+	err := mp.Update(1, types.Txs{top}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	callCheckTx(t, mp, types.Txs{low})
+	require.Equal(t, types.Txs{high, mid, low}, mp.ReapMaxTxs(-1))
+}
+
+func TestPriorityMempoolEvictsMostRecentOfSamePriority(t *testing.T) {
+	app := &priorityApp{kvstore.NewInMemoryApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.Type = config.MempoolTypePriority
+	cfg.Mempool.Size = 3
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=1"), types.Tx("c=1")}
+	callCheckTx(t, mp, txs)
+	require.Equal(t, txs, mp.ReapMaxTxs(-1))
+
+	callCheckTx(t, mp, types.Txs{types.Tx("d=2")})
+	require.Equal(t, types.Txs{types.Tx("d=2"), txs[0], txs[1]}, mp.ReapMaxTxs(-1))
+}
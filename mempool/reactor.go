@@ -33,22 +33,49 @@ type Reactor struct {
 	// already has it.
 	txSenders    map[types.TxKey]map[p2p.ID]bool
 	txSendersMtx cmtsync.Mutex
+
+	// Limits of the rate of the transactions received from each peer, if
+	// configured.
+	peerLimiters    map[p2p.ID]*peerIngressLimiter
+	peerLimitersMtx cmtsync.Mutex
+
+	// In pull gossip mode, `requestedTxs` maps the transactions announced by
+	// peers and requested from one of them to the time of the request, so
+	// that they are not requested again from the other peers announcing them
+	// until the request times out.
+	requestedTxs    map[types.TxKey]time.Time
+	requestedTxsMtx cmtsync.Mutex
 }
 
+// CapabilityPullGossip is advertised by the nodes handling the HaveTxs and
+// WantTxs messages of the pull gossip mode, whatever their own gossip mode.
+// In the pull gossip mode, transactions are only announced to the peers
+// advertising it, and sent in full to the others.
+const CapabilityPullGossip = "mempool/tx-have-want"
+
+// txRequestTimeout is the time after which a transaction requested from a
+// peer which did not send it may be requested from another peer.
+const txRequestTimeout = 5 * time.Second
+
 // NewReactor returns a new Reactor with the given config and mempool.
 func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool, waitSync bool) *Reactor {
 	memR := &Reactor{
-		config:    config,
-		mempool:   mempool,
-		waitSync:  atomic.Bool{},
-		txSenders: make(map[types.TxKey]map[p2p.ID]bool),
+		config:       config,
+		mempool:      mempool,
+		waitSync:     atomic.Bool{},
+		txSenders:    make(map[types.TxKey]map[p2p.ID]bool),
+		peerLimiters: make(map[p2p.ID]*peerIngressLimiter),
+		requestedTxs: make(map[types.TxKey]time.Time),
 	}
 	memR.BaseReactor = *p2p.NewBaseReactor("Mempool", memR)
 	if waitSync {
 		memR.waitSync.Store(true)
 		memR.waitSyncCh = make(chan struct{})
 	}
-	memR.mempool.SetTxRemovedCallback(func(txKey types.TxKey) { memR.removeSenders(txKey) })
+	memR.mempool.SetTxRemovedCallback(func(txKey types.TxKey) {
+		memR.removeSenders(txKey)
+		memR.removeRequested(txKey)
+	})
 	return memR
 }
 
@@ -65,10 +92,19 @@ func (memR *Reactor) OnStart() error {
 	}
 	if !memR.config.Broadcast {
 		memR.Logger.Info("Tx broadcasting is disabled")
+	} else if memR.config.GossipMode == cfg.MempoolGossipPull {
+		memR.Logger.Info("Announcing tx hashes to peers instead of sending txs")
 	}
 	return nil
 }
 
+var _ p2p.CapabilityReactor = (*Reactor)(nil)
+
+// Capabilities implements p2p.CapabilityReactor.
+func (memR *Reactor) Capabilities() []string {
+	return []string{CapabilityPullGossip}
+}
+
 // GetChannels implements Reactor by returning the list of channels for this
 // reactor.
 func (memR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
@@ -97,8 +133,16 @@ func (memR *Reactor) AddPeer(peer p2p.Peer) {
 	}
 }
 
+// RemovePeer implements Reactor.
+func (memR *Reactor) RemovePeer(peer p2p.Peer, _ interface{}) {
+	memR.peerLimitersMtx.Lock()
+	defer memR.peerLimitersMtx.Unlock()
+	delete(memR.peerLimiters, peer.ID())
+}
+
 // Receive implements Reactor.
-// It adds any received transactions to the mempool.
+// It adds any received transactions to the mempool, requests the announced
+// transactions missing from the mempool and sends the requested ones.
 func (memR *Reactor) Receive(e p2p.Envelope) {
 	memR.Logger.Debug("Receive", "src", e.Src, "chId", e.ChannelID, "msg", e.Message)
 	switch msg := e.Message.(type) {
@@ -116,6 +160,11 @@ func (memR *Reactor) Receive(e p2p.Envelope) {
 
 		for _, txBytes := range protoTxs {
 			tx := types.Tx(txBytes)
+			memR.removeRequested(tx.Key())
+			if !memR.allowTxFromPeer(e.Src, len(tx)) {
+				memR.mempool.recordRejected(tx, RejectionRateLimited, nil, nil)
+				continue
+			}
 			reqRes, err := memR.mempool.CheckTx(tx)
 			if errors.Is(err, ErrTxInCache) {
 				memR.Logger.Debug("Tx already exists in cache", "tx", tx.String())
@@ -135,6 +184,61 @@ func (memR *Reactor) Receive(e p2p.Envelope) {
 				})
 			}
 		}
+	case *protomem.HaveTxs:
+		if memR.WaitSync() {
+			memR.Logger.Debug("Ignored message received while syncing", "msg", msg)
+			return
+		}
+
+		var wanted [][]byte
+		now := time.Now()
+		for _, hash := range msg.GetHashes() {
+			txKey, err := txKeyFromHash(hash)
+			if err != nil {
+				memR.Switch.StopPeerForError(e.Src, err)
+				return
+			}
+			if !memR.mempool.InMempool(txKey) && memR.requestTx(txKey, now) {
+				wanted = append(wanted, hash)
+			}
+		}
+		if len(wanted) == 0 {
+			return
+		}
+		success := e.Src.Send(p2p.Envelope{
+			ChannelID: MempoolChannel,
+			Message:   &protomem.WantTxs{Hashes: wanted},
+		})
+		if !success {
+			// Let the other peers announcing the transactions serve them.
+			for _, hash := range wanted {
+				txKey, _ := txKeyFromHash(hash)
+				memR.removeRequested(txKey)
+			}
+		}
+	case *protomem.WantTxs:
+		for _, hash := range msg.GetHashes() {
+			txKey, err := txKeyFromHash(hash)
+			if err != nil {
+				memR.Switch.StopPeerForError(e.Src, err)
+				return
+			}
+			elem, ok := memR.mempool.getCElement(txKey)
+			if !ok {
+				// The transaction was removed from the mempool since it was
+				// announced.
+				continue
+			}
+			memTx := elem.Value.(*mempoolTx)
+			success := e.Src.Send(p2p.Envelope{
+				ChannelID: MempoolChannel,
+				Message:   &protomem.Txs{Txs: [][]byte{memTx.tx}},
+			})
+			if !success {
+				memR.Logger.Debug("Could not send requested tx", "peer", e.Src.ID(), "tx", memTx.tx.String())
+				return
+			}
+		}
 	default:
 		memR.Logger.Error("unknown message type", "src", e.Src, "chId", e.ChannelID, "msg", e.Message)
 		memR.Switch.StopPeerForError(e.Src, fmt.Errorf("mempool cannot handle message of type: %T", e.Message))
@@ -230,7 +334,7 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 		if !memR.isSender(memTx.tx.Key(), peer.ID()) {
 			success := peer.Send(p2p.Envelope{
 				ChannelID: MempoolChannel,
-				Message:   &protomem.Txs{Txs: [][]byte{memTx.tx}},
+				Message:   memR.txMessage(peer, memTx.tx),
 			})
 			if !success {
 				time.Sleep(PeerCatchupSleepIntervalMS * time.Millisecond)
@@ -250,6 +354,88 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 	}
 }
 
+// txMessage returns the message gossiping the transaction to the peer: its
+// announcement in the pull gossip mode, if the peer supports it, and the
+// transaction itself otherwise.
+func (memR *Reactor) txMessage(peer p2p.Peer, tx types.Tx) p2p.Wrapper {
+	if memR.config.GossipMode == cfg.MempoolGossipPull && p2p.PeerHasCapability(peer, CapabilityPullGossip) {
+		txKey := tx.Key()
+		return &protomem.HaveTxs{Hashes: [][]byte{txKey[:]}}
+	}
+	return &protomem.Txs{Txs: [][]byte{tx}}
+}
+
+// allowTxFromPeer returns false if a transaction of the given size received
+// from the peer exceeds the configured rate limits, in which case it must be
+// dropped.
+func (memR *Reactor) allowTxFromPeer(peer p2p.Peer, txSize int) bool {
+	if memR.config.PeerMaxTxsPerSecond <= 0 && memR.config.PeerMaxBytesPerSecond <= 0 {
+		return true
+	}
+
+	memR.peerLimitersMtx.Lock()
+	defer memR.peerLimitersMtx.Unlock()
+
+	now := time.Now()
+	limiter, ok := memR.peerLimiters[peer.ID()]
+	if !ok {
+		limiter = newPeerIngressLimiter(memR.config.PeerMaxTxsPerSecond, memR.config.PeerMaxBytesPerSecond,
+			memR.config.MaxTxBytes, now)
+		memR.peerLimiters[peer.ID()] = limiter
+	}
+
+	allowed := limiter.allow(txSize, now)
+	if !allowed {
+		memR.mempool.metrics.RateLimitedTxs.Add(1)
+		if !limiter.throttled {
+			memR.Logger.Info("Throttling transactions from peer exceeding the rate limits", "peer", peer.ID())
+		}
+	} else if limiter.throttled {
+		memR.Logger.Debug("Peer is back within the transaction rate limits", "peer", peer.ID())
+	}
+	limiter.throttled = !allowed
+	return allowed
+}
+
+// requestTx returns true, and records the request, if the announced
+// transaction has not been requested from a peer within the request timeout.
+func (memR *Reactor) requestTx(txKey types.TxKey, now time.Time) bool {
+	memR.requestedTxsMtx.Lock()
+	defer memR.requestedTxsMtx.Unlock()
+
+	if requestedAt, ok := memR.requestedTxs[txKey]; ok && now.Sub(requestedAt) < txRequestTimeout {
+		return false
+	}
+	// Forget the requests which timed out, as the peers may never send the
+	// transactions.
+	if len(memR.requestedTxs) >= memR.config.Size {
+		for key, requestedAt := range memR.requestedTxs {
+			if now.Sub(requestedAt) >= txRequestTimeout {
+				delete(memR.requestedTxs, key)
+			}
+		}
+	}
+	memR.requestedTxs[txKey] = now
+	return true
+}
+
+func (memR *Reactor) removeRequested(txKey types.TxKey) {
+	memR.requestedTxsMtx.Lock()
+	defer memR.requestedTxsMtx.Unlock()
+
+	delete(memR.requestedTxs, txKey)
+}
+
+// txKeyFromHash returns the key of the transaction with the given hash.
+func txKeyFromHash(hash []byte) (types.TxKey, error) {
+	var txKey types.TxKey
+	if len(hash) != types.TxKeySize {
+		return txKey, fmt.Errorf("invalid tx hash length: expected %d, got %d", types.TxKeySize, len(hash))
+	}
+	copy(txKey[:], hash)
+	return txKey, nil
+}
+
 func (memR *Reactor) isSender(txKey types.TxKey, peerID p2p.ID) bool {
 	memR.txSendersMtx.Lock()
 	defer memR.txSendersMtx.Unlock()
@@ -17,6 +17,8 @@ import (
 	cfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/p2p"
+	p2pmock "github.com/cometbft/cometbft/p2p/mock"
+	p2pmocks "github.com/cometbft/cometbft/p2p/mocks"
 	memproto "github.com/cometbft/cometbft/proto/tendermint/mempool"
 	"github.com/cometbft/cometbft/proxy"
 	"github.com/cometbft/cometbft/types"
@@ -265,6 +267,35 @@ func TestReactorTxSendersLocal(t *testing.T) {
 	require.True(t, reactor.isSender(types.Tx(tx2).Key(), "peer1"))
 }
 
+func TestReactorPeerRateLimit(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Mempool.PeerMaxTxsPerSecond = 5
+	reactors, _ := makeAndConnectReactors(config, 1)
+	defer func() {
+		for _, r := range reactors {
+			if err := r.Stop(); err != nil {
+				assert.NoError(t, err)
+			}
+		}
+	}()
+	reactor := reactors[0]
+
+	spammer, other := p2pmock.NewPeer(nil), p2pmock.NewPeer(nil)
+	txs := newUniqueTxs(20)
+	reactor.Receive(p2p.Envelope{Src: spammer, ChannelID: MempoolChannel, Message: &memproto.Txs{Txs: txs.ToSliceOfBytes()}})
+	waitForNumTxsInMempool(5, reactor.mempool)
+	require.Equal(t, types.Txs(txs[:5]), reactor.mempool.ReapMaxTxs(-1))
+
+	// The limits apply per peer.
+	reactor.Receive(p2p.Envelope{Src: other, ChannelID: MempoolChannel, Message: &memproto.Txs{Txs: txs[5:6].ToSliceOfBytes()}})
+	waitForNumTxsInMempool(6, reactor.mempool)
+
+	reactor.RemovePeer(spammer, nil)
+	reactor.peerLimitersMtx.Lock()
+	require.NotContains(t, reactor.peerLimiters, spammer.ID())
+	reactor.peerLimitersMtx.Unlock()
+}
+
 // Test that:
 // - If a transaction came from a peer AND if the transaction is added to the
 // mempool, it must have a non-empty list of senders in the reactor.
@@ -372,6 +403,82 @@ func mempoolLogger() log.Logger {
 	})
 }
 
+func TestReactorPullGossip(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Mempool.GossipMode = cfg.MempoolGossipPull
+	const N = 3
+	reactors, _ := makeAndConnectReactors(config, N)
+	defer func() {
+		for _, r := range reactors {
+			if err := r.Stop(); err != nil {
+				assert.NoError(t, err)
+			}
+		}
+	}()
+	for _, r := range reactors {
+		for _, peer := range r.Switch.Peers().List() {
+			peer.Set(types.PeerStateKey, peerState{1})
+		}
+	}
+
+	txs := checkTxs(t, reactors[0].mempool, 100)
+	waitForReactors(t, txs, reactors, checkTxsInMempool)
+}
+
+func TestReactorPullGossipFallback(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Mempool.GossipMode = cfg.MempoolGossipPull
+	reactors, _ := makeAndConnectReactors(config, 1)
+	defer func() {
+		for _, r := range reactors {
+			if err := r.Stop(); err != nil {
+				assert.NoError(t, err)
+			}
+		}
+	}()
+	reactor := reactors[0]
+	tx := types.Tx("tx")
+	txKey := tx.Key()
+
+	// The tx is announced to the peers supporting the pull gossip...
+	peer := &p2pmocks.Peer{}
+	peer.On("NodeInfo").Return(p2p.DefaultNodeInfo{Capabilities: []string{CapabilityPullGossip}})
+	require.Equal(t, &memproto.HaveTxs{Hashes: [][]byte{txKey[:]}}, reactor.txMessage(peer, tx))
+
+	// ...and sent in full to the others.
+	require.Equal(t, &memproto.Txs{Txs: [][]byte{tx}}, reactor.txMessage(p2pmock.NewPeer(nil), tx))
+
+	require.Contains(t, reactor.Capabilities(), CapabilityPullGossip)
+}
+
+func TestReactorRequestTx(t *testing.T) {
+	config := cfg.TestConfig()
+	reactors, _ := makeAndConnectReactors(config, 1)
+	defer func() {
+		for _, r := range reactors {
+			if err := r.Stop(); err != nil {
+				assert.NoError(t, err)
+			}
+		}
+	}()
+	reactor := reactors[0]
+
+	txKey := types.Tx("tx").Key()
+	now := time.Now()
+	require.True(t, reactor.requestTx(txKey, now))
+	// The tx is not requested again from another peer announcing it...
+	require.False(t, reactor.requestTx(txKey, now.Add(time.Second)))
+	// ...unless the request timed out.
+	require.True(t, reactor.requestTx(txKey, now.Add(txRequestTimeout)))
+
+	// Receiving the tx completes the request.
+	reactor.removeRequested(txKey)
+	require.True(t, reactor.requestTx(txKey, now.Add(txRequestTimeout)))
+
+	_, err := txKeyFromHash([]byte("short"))
+	require.Error(t, err)
+}
+
 // connect N mempool reactors through N switches
 func makeAndConnectReactors(config *cfg.Config, n int) ([]*Reactor, []*p2p.Switch) {
 	reactors := make([]*Reactor, n)
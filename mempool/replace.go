@@ -0,0 +1,134 @@
+package mempool
+
+import (
+	"math"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// senderSequence identifies the tx of a sender with a given sequence.
+type senderSequence struct {
+	sender   string
+	sequence uint64
+}
+
+// replacesByFee returns true if a tx may replace the tx of the same sender
+// and sequence in the mempool.
+func (mem *CListMempool) replacesByFee() bool {
+	return mem.config.ReplaceByFeeFactor > 0
+}
+
+// replacedTx returns the tx of the mempool of the same sender and sequence as
+// the tx with the given key and CheckTx response, which the tx replaces, if
+// any. It returns an error if the priority of the tx is not high enough to
+// replace it.
+func (mem *CListMempool) replacedTx(txKey types.TxKey, res *abci.ResponseCheckTx) (*mempoolTx, error) {
+	if res.Sender == "" || !mem.replacesByFee() {
+		return nil, nil
+	}
+
+	mem.senderMtx.Lock()
+	replacedKey, ok := mem.senderSeqs[senderSequence{res.Sender, res.Sequence}]
+	mem.senderMtx.Unlock()
+	if !ok || replacedKey == txKey {
+		return nil, nil
+	}
+	elem, ok := mem.getCElement(replacedKey)
+	if !ok {
+		return nil, nil
+	}
+
+	replaced := elem.Value.(*mempoolTx)
+	minPriority := minReplacementPriority(replaced.Priority(), mem.config.ReplaceByFeeFactor)
+	if res.Priority < minPriority {
+		return nil, ErrTxUnderpriced{
+			Sender:      res.Sender,
+			Sequence:    res.Sequence,
+			Priority:    res.Priority,
+			MinPriority: minPriority,
+		}
+	}
+	return replaced, nil
+}
+
+// minReplacementPriority returns the minimum priority of a tx replacing a tx
+// of the given priority: higher than it by the given factor, and by at least
+// one.
+func minReplacementPriority(priority int64, factor float64) int64 {
+	bump := math.Max(math.Ceil(math.Abs(float64(priority))*(factor-1)), 1)
+	if float64(priority)+bump >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return priority + int64(bump)
+}
+
+// isFullReplacing returns an error if the mempool has no room for a tx of the
+// given size replacing the given tx, if any.
+func (mem *CListMempool) isFullReplacing(txSize int, replaced *mempoolTx) error {
+	if replaced == nil {
+		return mem.isFull(txSize)
+	}
+
+	var (
+		memSize  = mem.Size() - 1
+		txsBytes = mem.SizeBytes() - int64(len(replaced.tx))
+	)
+	if memSize >= mem.config.Size || int64(txSize)+txsBytes > mem.config.MaxTxsBytes {
+		return ErrMempoolIsFull{
+			NumTxs:      memSize,
+			MaxTxs:      mem.config.Size,
+			TxsBytes:    txsBytes,
+			MaxTxsBytes: mem.config.MaxTxsBytes,
+		}
+	}
+	return nil
+}
+
+// replaceTx removes the given tx, replaced by a tx of the given priority. The
+// replaced tx stays in the cache, so that it is not added again when received
+// from the peers which do not have its replacement yet.
+func (mem *CListMempool) replaceTx(replaced *mempoolTx, priority int64) {
+	if err := mem.RemoveTxByKey(replaced.tx.Key()); err != nil {
+		// The tx was already removed, e.g. evicted to make room.
+		return
+	}
+	mem.metrics.EvictedTxs.Add(1)
+	mem.notifyEvicted(replaced.tx, EvictionReplaced)
+	mem.logger.Debug(
+		"replaced transaction",
+		"tx", replaced.tx.Hash(),
+		"sender", replaced.sender,
+		"sequence", replaced.sequence,
+		"priority", replaced.Priority(),
+		"new_priority", priority,
+	)
+}
+
+// addToSequences indexes the tx by sender and sequence, so that it can be
+// replaced.
+func (mem *CListMempool) addToSequences(memTx *mempoolTx) {
+	if memTx.sender == "" || !mem.replacesByFee() {
+		return
+	}
+
+	mem.senderMtx.Lock()
+	defer mem.senderMtx.Unlock()
+
+	mem.senderSeqs[senderSequence{memTx.sender, memTx.sequence}] = memTx.tx.Key()
+}
+
+// removeFromSequences removes the tx from the index by sender and sequence.
+func (mem *CListMempool) removeFromSequences(memTx *mempoolTx) {
+	if memTx.sender == "" || !mem.replacesByFee() {
+		return
+	}
+
+	mem.senderMtx.Lock()
+	defer mem.senderMtx.Unlock()
+
+	seq := senderSequence{memTx.sender, memTx.sequence}
+	if mem.senderSeqs[seq] == memTx.tx.Key() {
+		delete(mem.senderSeqs, seq)
+	}
+}
@@ -0,0 +1,108 @@
+package mempool
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+// replaceApp is a kvstore application assigning the key of a tx as its
+// sender, and the value, of the form sequence/priority, as its sequence and
+// priority.
+type replaceApp struct {
+	*kvstore.Application
+}
+
+func (app *replaceApp) CheckTx(ctx context.Context, req *abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	res, err := app.Application.CheckTx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	parts := bytes.SplitN(req.Tx, []byte("="), 2)
+	if len(parts) == 2 {
+		res.Sender = string(parts[0])
+		if seq, priority, ok := bytes.Cut(parts[1], []byte("/")); ok {
+			res.Sequence, _ = strconv.ParseUint(string(seq), 10, 64)
+			res.Priority, _ = strconv.ParseInt(string(priority), 10, 64)
+		}
+	}
+	return res, nil
+}
+
+func TestMempoolReplaceByFee(t *testing.T) {
+	app := &replaceApp{kvstore.NewInMemoryApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.ReplaceByFeeFactor = 1.5
+	cfg.Mempool.Size = 2
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	evicted := make(map[string]EvictionReason)
+	WithEvictionCallback(func(tx types.Tx, reason EvictionReason) {
+		evicted[string(tx)] = reason
+	})(mp)
+
+	a1, b1 := types.Tx("a=1/10"), types.Tx("b=1/1")
+	callCheckTx(t, mp, types.Txs{a1, b1})
+
+	// A tx whose priority is not high enough is rejected, and may be
+	// resubmitted with a higher priority.
+	underpriced := types.Tx("a=1/14")
+	callCheckTx(t, mp, types.Txs{underpriced})
+	require.Equal(t, types.Txs{a1, b1}, mp.ReapMaxTxs(-1))
+	dropped := mp.DropLog().Txs(nil)
+	require.Len(t, dropped, 1)
+	require.Equal(t, string(RejectionUnderpriced), dropped[0].Reason)
+
+	// The replacement takes the place of the replaced tx in the full mempool.
+	replacement := types.Tx("a=1/15")
+	callCheckTx(t, mp, types.Txs{replacement})
+	require.Equal(t, types.Txs{b1, replacement}, mp.ReapMaxTxs(-1))
+	require.Equal(t, map[string]EvictionReason{string(a1): EvictionReplaced}, evicted)
+	require.Equal(t, map[senderSequence]types.TxKey{
+		{"a", 1}: replacement.Key(),
+		{"b", 1}: b1.Key(),
+	}, mp.senderSeqs)
+
+	// The replaced tx stays in the cache.
+	_, err := mp.CheckTx(a1)
+	require.ErrorIs(t, err, ErrTxInCache)
+
+	// Txs of other sequences replace nothing, and are rejected from the full
+	// mempool once checked.
+	callCheckTx(t, mp, types.Txs{types.Tx("a=2/100")})
+	require.Equal(t, types.Txs{b1, replacement}, mp.ReapMaxTxs(-1))
+	require.Equal(t, string(RejectionMempoolFull), mp.DropLog().Txs(nil)[0].Reason)
+
+	require.NoError(t, mp.RemoveTxByKey(replacement.Key()))
+	require.Equal(t, map[senderSequence]types.TxKey{{"b", 1}: b1.Key()}, mp.senderSeqs)
+}
+
+func TestMinReplacementPriority(t *testing.T) {
+	testCases := []struct {
+		priority int64
+		factor   float64
+		expected int64
+	}{
+		{10, 1.5, 15},
+		{10, 1.01, 11},
+		{10, 1, 11},
+		{0, 2, 1},
+		{-10, 1.5, -5},
+		{math.MaxInt64, 1.1, math.MaxInt64},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, minReplacementPriority(tc.priority, tc.factor), "priority %d, factor %v", tc.priority, tc.factor)
+	}
+}
@@ -0,0 +1,126 @@
+package mempool
+
+import (
+	"sort"
+
+	"github.com/cometbft/cometbft/types"
+)
+
+// orderBySender reorders the given transactions so that the transactions of
+// each sender are in increasing order of sequence, transactions with the same
+// sequence being kept in their relative order. The transactions of a sender
+// take the positions they had before, so that senders remain interleaved as
+// they were, and any prefix of the result holds the transactions of each
+// sender with the lowest sequences. Transactions without a sender are left in
+// place.
+func orderBySender(memTxs []*mempoolTx) {
+	positions := make(map[string][]int)
+	for i, memTx := range memTxs {
+		if memTx.sender != "" {
+			positions[memTx.sender] = append(positions[memTx.sender], i)
+		}
+	}
+
+	for _, idxs := range positions {
+		if len(idxs) < 2 {
+			continue
+		}
+		lane := make([]*mempoolTx, len(idxs))
+		for i, idx := range idxs {
+			lane[i] = memTxs[idx]
+		}
+		sort.SliceStable(lane, func(i, j int) bool {
+			return lane[i].sequence < lane[j].sequence
+		})
+		for i, idx := range idxs {
+			memTxs[idx] = lane[i]
+		}
+	}
+}
+
+// senderUsage is the number of txs, and their size in bytes, that a sender
+// has in the mempool.
+type senderUsage struct {
+	numTxs   int
+	txsBytes int64
+}
+
+// sendersCapped returns true if the txs of each sender are capped.
+func (mem *CListMempool) sendersCapped() bool {
+	return mem.config.MaxTxsPerSender > 0 || mem.config.MaxTxsBytesPerSender > 0
+}
+
+// isSenderFull returns an error if adding a transaction of the given size,
+// replacing the given transaction of the same sender if any, would exceed the
+// caps of its sender. Transactions without a sender are not capped.
+func (mem *CListMempool) isSenderFull(sender string, txSize int, replaced *mempoolTx) error {
+	if sender == "" || !mem.sendersCapped() {
+		return nil
+	}
+
+	mem.senderMtx.Lock()
+	defer mem.senderMtx.Unlock()
+
+	usage := mem.senderSizes[sender]
+	if replaced != nil {
+		usage.numTxs--
+		usage.txsBytes -= int64(len(replaced.tx))
+	}
+	if (mem.config.MaxTxsPerSender > 0 && usage.numTxs >= mem.config.MaxTxsPerSender) ||
+		(mem.config.MaxTxsBytesPerSender > 0 && usage.txsBytes+int64(txSize) > mem.config.MaxTxsBytesPerSender) {
+		return ErrSenderIsFull{
+			Sender:      sender,
+			NumTxs:      usage.numTxs,
+			MaxTxs:      mem.config.MaxTxsPerSender,
+			TxsBytes:    usage.txsBytes,
+			MaxTxsBytes: mem.config.MaxTxsBytesPerSender,
+		}
+	}
+	return nil
+}
+
+// addToSender accounts for a transaction of the given sender being added to
+// the mempool.
+func (mem *CListMempool) addToSender(sender string, txSize int) {
+	if sender == "" || !mem.sendersCapped() {
+		return
+	}
+
+	mem.senderMtx.Lock()
+	defer mem.senderMtx.Unlock()
+
+	usage := mem.senderSizes[sender]
+	usage.numTxs++
+	usage.txsBytes += int64(txSize)
+	mem.senderSizes[sender] = usage
+}
+
+// removeFromSender accounts for a transaction of the given sender being
+// removed from the mempool.
+func (mem *CListMempool) removeFromSender(sender string, txSize int) {
+	if sender == "" || !mem.sendersCapped() {
+		return
+	}
+
+	mem.senderMtx.Lock()
+	defer mem.senderMtx.Unlock()
+
+	usage := mem.senderSizes[sender]
+	usage.numTxs--
+	usage.txsBytes -= int64(txSize)
+	if usage.numTxs <= 0 {
+		delete(mem.senderSizes, sender)
+		return
+	}
+	mem.senderSizes[sender] = usage
+}
+
+// resetSenders clears the per-sender accounting after all the transactions
+// were removed from the mempool.
+func (mem *CListMempool) resetSenders() {
+	mem.senderMtx.Lock()
+	defer mem.senderMtx.Unlock()
+
+	mem.senderSizes = make(map[string]senderUsage)
+	mem.senderSeqs = make(map[senderSequence]types.TxKey)
+}
@@ -0,0 +1,80 @@
+package mempool
+
+import (
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+)
+
+// senderBan is a ban of a sender requested by the application in CheckTx,
+// during which the transactions of the sender are dropped.
+type senderBan struct {
+	untilHeight int64 // banned while the mempool height is lower
+	until       time.Time
+}
+
+// active returns true if the ban holds at the given height and time.
+func (b senderBan) active(height int64, now time.Time) bool {
+	return height < b.untilHeight || now.Before(b.until)
+}
+
+// banSender bans the sender of a transaction, if requested by the response of
+// the application to CheckTx. The sender is the one assigned to the
+// transaction by the application, so the peers relaying the transactions of a
+// banned sender are not affected. A ban never shortens an active ban of the
+// sender.
+func (mem *CListMempool) banSender(res *abci.ResponseCheckTx) {
+	if res.Sender == "" || (res.BanSenderNumBlocks <= 0 && res.BanSenderDuration <= 0) {
+		return
+	}
+	height, now := mem.getHeight(), time.Now()
+	var ban senderBan
+	if res.BanSenderNumBlocks > 0 {
+		ban.untilHeight = height + res.BanSenderNumBlocks
+	}
+	if res.BanSenderDuration > 0 {
+		ban.until = now.Add(res.BanSenderDuration)
+	}
+
+	mem.bannedSendersMtx.Lock()
+	defer mem.bannedSendersMtx.Unlock()
+
+	if prev, ok := mem.bannedSenders[res.Sender]; ok {
+		ban.untilHeight = max(ban.untilHeight, prev.untilHeight)
+		if prev.until.After(ban.until) {
+			ban.until = prev.until
+		}
+	} else {
+		mem.logger.Info("Banning sender as requested by the application",
+			"sender", res.Sender, "blocks", res.BanSenderNumBlocks, "duration", res.BanSenderDuration)
+	}
+	mem.bannedSenders[res.Sender] = ban
+
+	// Forget the expired bans.
+	for sender, b := range mem.bannedSenders {
+		if !b.active(height, now) {
+			delete(mem.bannedSenders, sender)
+		}
+	}
+}
+
+// isSenderBanned returns true if the transactions of the sender must be
+// dropped.
+func (mem *CListMempool) isSenderBanned(sender string) bool {
+	if sender == "" {
+		return false
+	}
+
+	mem.bannedSendersMtx.Lock()
+	defer mem.bannedSendersMtx.Unlock()
+
+	ban, ok := mem.bannedSenders[sender]
+	if !ok {
+		return false
+	}
+	if !ban.active(mem.getHeight(), time.Now()) {
+		delete(mem.bannedSenders, sender)
+		return false
+	}
+	return true
+}
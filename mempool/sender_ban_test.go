@@ -0,0 +1,106 @@
+package mempool
+
+import (
+	"bytes"
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	p2pmock "github.com/cometbft/cometbft/p2p/mock"
+	memproto "github.com/cometbft/cometbft/proto/tendermint/mempool"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+// banApp assigns the txs "<sender>/<key>=<value>" to their sender, and rejects
+// the txs with the "spam" key, banning their sender for 2 blocks.
+type banApp struct {
+	*kvstore.Application
+	checked atomic.Int32
+}
+
+func (app *banApp) CheckTx(ctx context.Context, req *abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	app.checked.Add(1)
+	sender, kv, _ := bytes.Cut(req.Tx, []byte("/"))
+	if bytes.HasPrefix(kv, []byte("spam=")) {
+		return &abci.ResponseCheckTx{Code: 1, Sender: string(sender), BanSenderNumBlocks: 2}, nil
+	}
+	res, err := app.Application.CheckTx(ctx, &abci.RequestCheckTx{Tx: kv, Type: req.Type})
+	if err != nil {
+		return nil, err
+	}
+	res.Sender = string(sender)
+	return res, nil
+}
+
+func TestMempoolSenderBan(t *testing.T) {
+	app := &banApp{Application: kvstore.NewInMemoryApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+	reactor := NewReactor(cfg.Mempool, mp, false)
+	reactor.SetLogger(log.TestingLogger())
+
+	relay := p2pmock.NewPeer(nil)
+	receive := func(tx types.Tx) {
+		reactor.Receive(p2p.Envelope{Src: relay, ChannelID: MempoolChannel, Message: &memproto.Txs{Txs: [][]byte{tx}}})
+	}
+
+	receive(types.Tx("alice/spam=1"))
+	require.True(t, mp.isSenderBanned("alice"))
+
+	// The txs of the banned sender are dropped, while the peer relaying them
+	// is not banned.
+	receive(types.Tx("alice/a=1"))
+	receive(types.Tx("bob/b=1"))
+	require.Equal(t, types.Txs{types.Tx("bob/b=1")}, mp.ReapMaxTxs(-1))
+	require.EqualValues(t, 3, app.checked.Load())
+
+	// Knowing the sender of the txs, the mempool drops them without checking
+	// them.
+	WithTxSender(func(tx types.Tx) string {
+		sender, _, _ := bytes.Cut(tx, []byte("/"))
+		return string(sender)
+	})(mp)
+	_, err := mp.CheckTx(types.Tx("alice/a=2"))
+	require.ErrorIs(t, err, ErrSenderBanned{Sender: "alice"})
+	require.EqualValues(t, 3, app.checked.Load())
+
+	// The ban expires after 2 blocks.
+	mp.Lock()
+	require.NoError(t, mp.Update(1, types.Txs{}, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	mp.Unlock()
+	require.True(t, mp.isSenderBanned("alice"))
+	mp.Lock()
+	require.NoError(t, mp.Update(2, types.Txs{}, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	mp.Unlock()
+	require.False(t, mp.isSenderBanned("alice"))
+	receive(types.Tx("alice/a=1"))
+	require.Equal(t, types.Txs{types.Tx("bob/b=1"), types.Tx("alice/a=1")}, mp.ReapMaxTxs(-1))
+}
+
+func TestSenderBanActive(t *testing.T) {
+	now := time.Now()
+	ban := senderBan{untilHeight: 5}
+	require.True(t, ban.active(4, now))
+	require.False(t, ban.active(5, now))
+
+	ban = senderBan{until: now.Add(time.Minute)}
+	require.True(t, ban.active(100, now))
+	require.False(t, ban.active(100, now.Add(time.Minute)))
+
+	// Both bans must expire.
+	ban = senderBan{untilHeight: 5, until: now.Add(time.Minute)}
+	require.True(t, ban.active(10, now))
+	require.True(t, ban.active(1, now.Add(time.Hour)))
+	require.False(t, ban.active(10, now.Add(time.Hour)))
+}
@@ -0,0 +1,131 @@
+package mempool
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+// senderApp is a kvstore application assigning the key of a tx as its sender
+// and the value as its sequence.
+type senderApp struct {
+	*kvstore.Application
+}
+
+func (app *senderApp) CheckTx(ctx context.Context, req *abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	res, err := app.Application.CheckTx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	parts := bytes.SplitN(req.Tx, []byte("="), 2)
+	if len(parts) == 2 {
+		res.Sender = string(parts[0])
+		res.Sequence, _ = strconv.ParseUint(string(parts[1]), 10, 64)
+	}
+	return res, nil
+}
+
+func TestOrderBySender(t *testing.T) {
+	tx := func(sender string, sequence uint64) *mempoolTx {
+		return &mempoolTx{
+			tx:       types.Tx(sender + "=" + strconv.FormatUint(sequence, 10)),
+			sender:   sender,
+			sequence: sequence,
+		}
+	}
+	a3, a1, b2, c, a2, b1 := tx("a", 3), tx("a", 1), tx("b", 2), tx("", 0), tx("a", 2), tx("b", 1)
+
+	memTxs := []*mempoolTx{a3, a1, b2, c, a2, b1}
+	orderBySender(memTxs)
+	require.Equal(t, []*mempoolTx{a1, a2, b1, c, a3, b2}, memTxs)
+}
+
+func TestMempoolSenderOrdering(t *testing.T) {
+	app := &senderApp{kvstore.NewInMemoryApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	// Txs arriving out of order are reaped in sequence order per sender, while
+	// senders stay interleaved.
+	a2, b1, a1, b2 := types.Tx("a=2"), types.Tx("b=1"), types.Tx("a=1"), types.Tx("b=2")
+	callCheckTx(t, mp, types.Txs{a2, b1, a1, b2})
+	require.Equal(t, types.Txs{a1, b1, a2, b2}, mp.ReapMaxTxs(-1))
+	require.Equal(t, types.Txs{a1}, mp.ReapMaxBytesMaxGas(types.ComputeProtoSizeForTxs(types.Txs{a1}), -1))
+}
+
+func TestPriorityMempoolSenderOrdering(t *testing.T) {
+	app := &senderApp{kvstore.NewInMemoryApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.Type = config.MempoolTypePriority
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	// The sequence order of a sender takes precedence over priority order,
+	// which here is the order of arrival as all priorities are equal.
+	a3, a1, a2 := types.Tx("a=3"), types.Tx("a=1"), types.Tx("a=2")
+	callCheckTx(t, mp, types.Txs{a3, a1, a2})
+	require.Equal(t, types.Txs{a1, a2, a3}, mp.ReapMaxTxs(-1))
+}
+
+func TestMempoolSenderCaps(t *testing.T) {
+	app := &senderApp{kvstore.NewInMemoryApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.MaxTxsPerSender = 2
+	cfg.Mempool.MaxTxsBytesPerSender = 8
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	// The third tx of sender a exceeds the count cap, and the second tx of
+	// sender b the bytes cap, while the other senders are not affected.
+	a1, a2, a3 := types.Tx("a=1"), types.Tx("a=2"), types.Tx("a=3")
+	b1, b2 := types.Tx("b=100"), types.Tx("b=200")
+	c1 := types.Tx("c=1")
+	callCheckTx(t, mp, types.Txs{a1, a2, a3, b1, b2, c1})
+	require.Equal(t, types.Txs{a1, a2, b1, c1}, mp.ReapMaxTxs(-1))
+	require.Equal(t, map[string]senderUsage{
+		"a": {numTxs: 2, txsBytes: 6},
+		"b": {numTxs: 1, txsBytes: 5},
+		"c": {numTxs: 1, txsBytes: 3},
+	}, mp.senderSizes)
+
+	// The rejected tx is accepted once the sender has room again.
+	require.NoError(t, mp.RemoveTxByKey(a1.Key()))
+	callCheckTx(t, mp, types.Txs{a3})
+	require.Equal(t, 2, mp.senderSizes["a"].numTxs)
+
+	mp.Flush()
+	require.Empty(t, mp.senderSizes)
+}
+
+func TestMempoolReapMatchingTxs(t *testing.T) {
+	app := &senderApp{kvstore.NewInMemoryApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	a1, b1, a2 := types.Tx("a=1"), types.Tx("b=1"), types.Tx("a=2")
+	callCheckTx(t, mp, types.Txs{a1, b1, a2})
+
+	fromA := func(_ types.Tx, sender string, _ []abci.Event) bool { return sender == "a" }
+	require.Equal(t, types.Txs{a1, a2}, mp.ReapMatchingTxs(-1, fromA))
+	require.Equal(t, types.Txs{a1}, mp.ReapMatchingTxs(1, fromA))
+	require.Empty(t, mp.ReapMatchingTxs(0, fromA))
+
+	none := func(types.Tx, string, []abci.Event) bool { return false }
+	require.Empty(t, mp.ReapMatchingTxs(-1, none))
+}
@@ -0,0 +1,59 @@
+package mempool
+
+import (
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+)
+
+// setExpiry sets the height and time at which the given tx, entering the
+// mempool, expires. The TTLs assigned by the application in CheckTx apply if
+// set, capped by the TTLs of the configuration, which apply otherwise.
+func (mem *CListMempool) setExpiry(memTx *mempoolTx, res *abci.ResponseCheckTx, now time.Time) {
+	ttlNumBlocks := mem.config.TTLNumBlocks
+	if res.TTLNumBlocks > 0 && (ttlNumBlocks == 0 || res.TTLNumBlocks < ttlNumBlocks) {
+		ttlNumBlocks = res.TTLNumBlocks
+	}
+	if ttlNumBlocks > 0 {
+		memTx.expiresAtHeight = mem.height + ttlNumBlocks
+	}
+
+	ttlDuration := mem.config.TTLDuration
+	if res.TTLDuration > 0 && (ttlDuration == 0 || res.TTLDuration < ttlDuration) {
+		ttlDuration = res.TTLDuration
+	}
+	if ttlDuration > 0 {
+		memTx.expiresAt = now.Add(ttlDuration)
+	}
+}
+
+// expired returns true if the tx expired at the given committed height and
+// time.
+func (memTx *mempoolTx) expired(height int64, now time.Time) bool {
+	return (memTx.expiresAtHeight > 0 && height >= memTx.expiresAtHeight) ||
+		(!memTx.expiresAt.IsZero() && !now.Before(memTx.expiresAt))
+}
+
+// removeExpiredTxs removes the txs that expired at the given committed height
+// and time from the mempool and from the cache, so that they can be
+// resubmitted.
+//
+// Lock() must be held by the caller during execution.
+func (mem *CListMempool) removeExpiredTxs(height int64, now time.Time) {
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if !memTx.expired(height, now) {
+			continue
+		}
+		if err := mem.RemoveTxByKey(memTx.tx.Key()); err != nil {
+			mem.logger.Debug("Expired transaction could not be removed from mempool", "err", err)
+			continue
+		}
+		mem.forceRemoveFromCache(memTx.tx)
+		mem.metrics.ExpiredTxs.Add(1)
+		mem.notifyEvicted(memTx.tx, EvictionExpired)
+		mem.logger.Debug("removed expired transaction",
+			"tx", memTx.tx.Hash(),
+			"height", height)
+	}
+}
@@ -0,0 +1,88 @@
+package mempool
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+// ttlApp is a kvstore application assigning the value of a tx as its TTL in
+// blocks.
+type ttlApp struct {
+	*kvstore.Application
+}
+
+func (app *ttlApp) CheckTx(ctx context.Context, req *abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	res, err := app.Application.CheckTx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	parts := bytes.SplitN(req.Tx, []byte("="), 2)
+	if len(parts) == 2 {
+		res.TTLNumBlocks, _ = strconv.ParseInt(string(parts[1]), 10, 64)
+	}
+	return res, nil
+}
+
+func TestMempoolTTLNumBlocks(t *testing.T) {
+	app := &ttlApp{kvstore.NewInMemoryApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.TTLNumBlocks = 3
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	// The TTL of the tx applies if shorter than the configured one.
+	short, dflt, long := types.Tx("short=1"), types.Tx("default=0"), types.Tx("long=5")
+	callCheckTx(t, mp, types.Txs{short, dflt, long})
+	require.Equal(t, 3, mp.Size())
+
+	update := func(height int64) {
+		mp.Lock()
+		defer mp.Unlock()
+		require.NoError(t, mp.Update(height, types.Txs{}, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	}
+
+	update(1)
+	require.False(t, mp.InMempool(short.Key()))
+	require.Equal(t, 2, mp.Size())
+
+	// Expired txs are removed from the cache, so they can be resubmitted.
+	callCheckTx(t, mp, types.Txs{short})
+	require.True(t, mp.InMempool(short.Key()))
+
+	update(2)
+	require.False(t, mp.InMempool(short.Key()))
+	require.Equal(t, 2, mp.Size())
+	update(3)
+	require.Zero(t, mp.Size())
+}
+
+func TestMempoolTTLDuration(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.TTLDuration = time.Minute
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	tx := types.Tx("a=1")
+	callCheckTx(t, mp, types.Txs{tx})
+
+	mp.Lock()
+	mp.removeExpiredTxs(mp.height, time.Now())
+	require.True(t, mp.InMempool(tx.Key()))
+	mp.removeExpiredTxs(mp.height, time.Now().Add(time.Minute))
+	require.False(t, mp.InMempool(tx.Key()))
+	mp.Unlock()
+}
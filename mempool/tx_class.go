@@ -0,0 +1,136 @@
+package mempool
+
+import (
+	"sort"
+	"strings"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+)
+
+// DefaultTxClass is the class of the transactions the application did not
+// assign a class to in CheckTx.
+const DefaultTxClass = "default"
+
+// txClass returns the class the application assigned to a transaction in its
+// CheckTx response.
+func txClass(res *abci.ResponseCheckTx) string {
+	if res.Class == "" {
+		return DefaultTxClass
+	}
+	return strings.ToLower(res.Class)
+}
+
+// isClassFull returns an error if the quota of the given class has been
+// reached.
+func (mem *CListMempool) isClassFull(class string) error {
+	maxTxs := mem.config.TxClasses[class].MaxTxs
+	if maxTxs == 0 {
+		return nil
+	}
+
+	mem.classMtx.Lock()
+	defer mem.classMtx.Unlock()
+
+	if numTxs := mem.classSizes[class]; numTxs >= maxTxs {
+		return ErrTxClassIsFull{
+			Class:  class,
+			NumTxs: numTxs,
+			MaxTxs: maxTxs,
+		}
+	}
+	return nil
+}
+
+// addToClass accounts for a transaction of the given class being added to the
+// mempool.
+func (mem *CListMempool) addToClass(class string) {
+	mem.classMtx.Lock()
+	defer mem.classMtx.Unlock()
+
+	mem.classSizes[class]++
+	mem.metrics.ClassSize.With("class", class).Set(float64(mem.classSizes[class]))
+}
+
+// removeFromClass accounts for a transaction of the given class being removed
+// from the mempool.
+func (mem *CListMempool) removeFromClass(class string) {
+	mem.classMtx.Lock()
+	defer mem.classMtx.Unlock()
+
+	mem.classSizes[class]--
+	mem.metrics.ClassSize.With("class", class).Set(float64(mem.classSizes[class]))
+	if mem.classSizes[class] <= 0 {
+		delete(mem.classSizes, class)
+	}
+}
+
+// resetClasses clears the per-class accounting after all the transactions
+// were removed from the mempool.
+func (mem *CListMempool) resetClasses() {
+	mem.classMtx.Lock()
+	defer mem.classMtx.Unlock()
+
+	for class := range mem.classSizes {
+		mem.metrics.ClassSize.With("class", class).Set(0)
+	}
+	mem.classSizes = make(map[string]int)
+}
+
+// classWeight returns the number of transactions of the given class reaped in
+// each round when interleaving classes.
+func (mem *CListMempool) classWeight(class string) int {
+	return cmtmath.MaxInt(mem.config.TxClasses[class].Weight, 1)
+}
+
+// reapableTxs returns the reapable transactions in the order in which they
+// should be proposed. If no classes are configured, this is the FIFO order of
+// the mempool, or the decreasing order of priority for the priority mempool.
+// Otherwise, classes are interleaved in weighted round-robin, in decreasing
+// order of weight and each contributing up to its weight in transactions per
+// round, while preserving the order of the transactions within each class.
+// In all cases, the transactions of each sender are then put in increasing
+// order of sequence, see orderBySender.
+func (mem *CListMempool) reapableTxs() []*mempoolTx {
+	memTxs := make([]*mempoolTx, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if mem.isReapable(memTx) {
+			memTxs = append(memTxs, memTx)
+		}
+	}
+
+	if mem.prioritized() {
+		sortByPriority(memTxs)
+	}
+	if len(mem.config.TxClasses) == 0 {
+		orderBySender(memTxs)
+		return memTxs
+	}
+
+	// Classes are initially ordered by their first transaction, so that
+	// classes with the same weight are served in FIFO or priority order.
+	var classes []string
+	queues := make(map[string][]*mempoolTx)
+	for _, memTx := range memTxs {
+		if _, ok := queues[memTx.class]; !ok {
+			classes = append(classes, memTx.class)
+		}
+		queues[memTx.class] = append(queues[memTx.class], memTx)
+	}
+	sort.SliceStable(classes, func(i, j int) bool {
+		return mem.classWeight(classes[i]) > mem.classWeight(classes[j])
+	})
+
+	ordered := make([]*mempoolTx, 0, len(memTxs))
+	for len(ordered) < len(memTxs) {
+		for _, class := range classes {
+			queue := queues[class]
+			n := cmtmath.MinInt(mem.classWeight(class), len(queue))
+			ordered = append(ordered, queue[:n]...)
+			queues[class] = queue[n:]
+		}
+	}
+	orderBySender(ordered)
+	return ordered
+}
@@ -0,0 +1,92 @@
+package mempool
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+// classApp is a kvstore application assigning the governance class to the
+// txs whose key starts with "gov".
+type classApp struct {
+	*kvstore.Application
+}
+
+func (app *classApp) CheckTx(ctx context.Context, req *abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	res, err := app.Application.CheckTx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(req.Tx, []byte("gov")) {
+		res.Class = "Governance"
+	}
+	return res, nil
+}
+
+func TestMempoolTxClasses(t *testing.T) {
+	app := &classApp{kvstore.NewInMemoryApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.TxClasses = map[string]config.MempoolTxClassConfig{
+		DefaultTxClass: {MaxTxs: 3},
+		"governance":   {Weight: 2},
+	}
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	xfers := types.Txs{
+		types.Tx("xfer0=0"), types.Tx("xfer1=1"), types.Tx("xfer2=2"),
+		types.Tx("xfer3=3"), types.Tx("xfer4=4"),
+	}
+	govs := types.Txs{types.Tx("gov0=0"), types.Tx("gov1=1"), types.Tx("gov2=2")}
+
+	// The transfers beyond the quota of the default class are rejected, while
+	// the governance txs are still accepted.
+	callCheckTx(t, mp, xfers)
+	callCheckTx(t, mp, govs)
+	require.Equal(t, 6, mp.Size())
+	require.Equal(t, map[string]int{DefaultTxClass: 3, "governance": 3}, mp.classSizes)
+
+	// Governance txs have a higher weight, so they are reaped first and then
+	// interleaved two to one with the transfers.
+	expected := types.Txs{govs[0], govs[1], xfers[0], govs[2], xfers[1], xfers[2]}
+	require.Equal(t, expected, mp.ReapMaxTxs(-1))
+	require.Equal(t, expected, mp.ReapMaxBytesMaxGas(-1, -1))
+	require.Equal(t, expected[:3], mp.ReapMaxBytesMaxGas(types.ComputeProtoSizeForTxs(expected[:3]), -1))
+
+	// Committing a transfer frees up space in the default class. The rejected
+	// transfers were not kept in the cache, so they can be resubmitted.
+	mp.Lock()
+	err := mp.Update(1, types.Txs{xfers[0]}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{DefaultTxClass: 2, "governance": 3}, mp.classSizes)
+
+	callCheckTx(t, mp, xfers[3:])
+	require.Equal(t, 6, mp.Size())
+	require.Equal(t, map[string]int{DefaultTxClass: 3, "governance": 3}, mp.classSizes)
+
+	mp.Flush()
+	require.Empty(t, mp.classSizes)
+}
+
+func TestMempoolWithoutTxClassesReapsInFIFOOrder(t *testing.T) {
+	app := &classApp{kvstore.NewInMemoryApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	txs := types.Txs{types.Tx("xfer0=0"), types.Tx("gov0=0"), types.Tx("xfer1=1"), types.Tx("gov1=1")}
+	callCheckTx(t, mp, txs)
+	require.Equal(t, txs, mp.ReapMaxTxs(-1))
+	require.Equal(t, txs, mp.ReapMaxBytesMaxGas(-1, -1))
+}
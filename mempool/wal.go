@@ -0,0 +1,319 @@
+package mempool
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cmtos "github.com/cometbft/cometbft/libs/os"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	walFileName       = "wal"
+	walReplayFileName = "wal.replay"
+
+	// Number of records in the WAL beyond twice the number of txs in the
+	// mempool above which the WAL is compacted after a block.
+	walCompactionSlack = 1000
+)
+
+// mempoolWAL journals the txs added to and removed from the mempool, one
+// record per line, so that the txs of the mempool survive a crash.
+//
+// A record is an operation ("+" for an added tx, "-" for a removed tx), the
+// CRC-32 checksum of its payload, and its payload: the base64-encoded tx, or
+// the hex-encoded key of the removed tx. A record torn by a crash fails the
+// checksum, and ends the replay.
+type mempoolWAL struct {
+	mtx     cmtsync.Mutex
+	dir     string
+	file    *os.File
+	records int
+}
+
+// InitWAL opens the WAL in the configured directory, in which the txs added to
+// and removed from the mempool are journaled from then on. The txs journaled
+// by a previous run are set aside to be resubmitted by ReplayWAL.
+//
+// NOTE: not thread safe - should only be called once, on startup
+func (mem *CListMempool) InitWAL() error {
+	dir := mem.config.WalDir()
+	if err := cmtos.EnsureDir(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create mempool WAL directory: %w", err)
+	}
+
+	// Merge the txs of the previous run, including those of a replay cut
+	// short by a crash, into the replay file, from which they are resubmitted.
+	var txs types.Txs
+	for _, name := range []string{walReplayFileName, walFileName} {
+		walTxs, err := readWAL(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		txs = append(txs, walTxs...)
+	}
+	if len(txs) > 0 {
+		if err := writeWAL(filepath.Join(dir, walReplayFileName), dedupTxs(txs)); err != nil {
+			return err
+		}
+	}
+
+	if err := writeWAL(filepath.Join(dir, walFileName), nil); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Join(dir, walFileName), os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	mem.wal = &mempoolWAL{dir: dir, file: file}
+	return nil
+}
+
+// ReplayWAL resubmits the txs journaled by the previous run to CheckTx, in
+// order, and returns their number. InitWAL must have been called.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) ReplayWAL() (int, error) {
+	path := filepath.Join(mem.wal.dir, walReplayFileName)
+	txs, err := readWAL(path)
+	if err != nil {
+		return 0, err
+	}
+
+	// The txs accepted by CheckTx are journaled again to the new WAL, before
+	// the replay file is removed.
+	n := 0
+	for _, tx := range txs {
+		if _, err := mem.CheckTx(tx); err != nil {
+			mem.logger.Debug("Journaled transaction not resubmitted", "tx", tx.Hash(), "err", err)
+			continue
+		}
+		n++
+	}
+	if err := mem.FlushAppConn(); err != nil {
+		return n, err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return n, err
+	}
+	if len(txs) > 0 {
+		mem.logger.Info("Resubmitted journaled mempool transactions", "txs", n, "journaled", len(txs))
+	}
+	return n, nil
+}
+
+// CloseWAL closes the WAL, if open.
+func (mem *CListMempool) CloseWAL() error {
+	if mem.wal == nil {
+		return nil
+	}
+	mem.wal.mtx.Lock()
+	defer mem.wal.mtx.Unlock()
+	return mem.wal.file.Close()
+}
+
+// journalAdd journals a tx added to the mempool.
+func (mem *CListMempool) journalAdd(tx types.Tx) {
+	if mem.wal == nil {
+		return
+	}
+	if err := mem.wal.append(walRecord('+', tx)); err != nil {
+		// The tx stays in the mempool, and is only lost on a crash.
+		mem.logger.Error("Failed to journal tx to mempool WAL", "tx", tx.Hash(), "err", err)
+	}
+}
+
+// journalRemove journals a tx removed from the mempool.
+func (mem *CListMempool) journalRemove(txKey types.TxKey) {
+	if mem.wal == nil {
+		return
+	}
+	if err := mem.wal.append(walRecord('-', txKey[:])); err != nil {
+		mem.logger.Error("Failed to journal tx removal to mempool WAL", "err", err)
+	}
+}
+
+// compactWAL rewrites the WAL with the txs of the mempool, once it holds
+// enough records of txs no longer in the mempool.
+//
+// Lock() must be held by the caller during execution.
+func (mem *CListMempool) compactWAL(force bool) {
+	if mem.wal == nil {
+		return
+	}
+	mem.wal.mtx.Lock()
+	defer mem.wal.mtx.Unlock()
+
+	if !force && mem.wal.records <= 2*mem.Size()+walCompactionSlack {
+		return
+	}
+	txs := make(types.Txs, 0, mem.Size())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		txs = append(txs, e.Value.(*mempoolTx).tx)
+	}
+
+	path := filepath.Join(mem.wal.dir, walFileName)
+	if err := writeWAL(path, txs); err != nil {
+		mem.logger.Error("Failed to compact mempool WAL", "err", err)
+		return
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		mem.logger.Error("Failed to reopen mempool WAL", "err", err)
+		return
+	}
+	mem.wal.file.Close()
+	mem.wal.file = file
+	mem.wal.records = len(txs)
+}
+
+func (w *mempoolWAL) append(record string) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if _, err := io.WriteString(w.file, record); err != nil {
+		return err
+	}
+	w.records++
+	return nil
+}
+
+func walRecord(op byte, payload []byte) string {
+	var encoded string
+	if op == '+' {
+		encoded = base64.StdEncoding.EncodeToString(payload)
+	} else {
+		encoded = hex.EncodeToString(payload)
+	}
+	return fmt.Sprintf("%c %08x %s\n", op, crc32.ChecksumIEEE(payload), encoded)
+}
+
+// readWAL returns the txs still in the mempool according to the records of
+// the WAL at the given path, in order, or nothing if the file does not exist.
+// It stops at the first invalid record, which was torn by a crash.
+func readWAL(path string) (types.Txs, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var (
+		txs     types.Txs
+		removed = make(map[int]bool)
+		// Indexes of the added txs not removed yet, by tx key.
+		added = make(map[types.TxKey][]int)
+	)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<30)
+	for scanner.Scan() {
+		op, payload, ok := parseWALRecord(scanner.Text())
+		if !ok {
+			break
+		}
+		if op == '+' {
+			tx := types.Tx(payload)
+			added[tx.Key()] = append(added[tx.Key()], len(txs))
+			txs = append(txs, tx)
+			continue
+		}
+		var txKey types.TxKey
+		copy(txKey[:], payload)
+		if idxs := added[txKey]; len(idxs) > 0 {
+			removed[idxs[len(idxs)-1]] = true
+			added[txKey] = idxs[:len(idxs)-1]
+		}
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, bufio.ErrTooLong) {
+		return nil, err
+	}
+
+	live := make(types.Txs, 0, len(txs)-len(removed))
+	for i, tx := range txs {
+		if !removed[i] {
+			live = append(live, tx)
+		}
+	}
+	return live, nil
+}
+
+func parseWALRecord(line string) (byte, []byte, bool) {
+	fields := strings.SplitN(line, " ", 3)
+	if len(fields) != 3 || len(fields[0]) != 1 {
+		return 0, nil, false
+	}
+	var (
+		op      = fields[0][0]
+		payload []byte
+		err     error
+	)
+	switch op {
+	case '+':
+		payload, err = base64.StdEncoding.DecodeString(fields[2])
+	case '-':
+		payload, err = hex.DecodeString(fields[2])
+		if err == nil && len(payload) != types.TxKeySize {
+			err = errors.New("invalid tx key")
+		}
+	default:
+		return 0, nil, false
+	}
+	if err != nil || fmt.Sprintf("%08x", crc32.ChecksumIEEE(payload)) != fields[1] {
+		return 0, nil, false
+	}
+	return op, payload, true
+}
+
+// writeWAL atomically replaces the WAL at the given path with one holding the
+// given txs.
+func writeWAL(path string, txs types.Txs) error {
+	tmp := path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(file)
+	for _, tx := range txs {
+		if _, err := w.WriteString(walRecord('+', tx)); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// dedupTxs returns the txs without the duplicates, keeping the first
+// occurrence of each tx.
+func dedupTxs(txs types.Txs) types.Txs {
+	seen := make(map[types.TxKey]bool, len(txs))
+	deduped := txs[:0]
+	for _, tx := range txs {
+		if !seen[tx.Key()] {
+			seen[tx.Key()] = true
+			deduped = append(deduped, tx)
+		}
+	}
+	return deduped
+}
@@ -0,0 +1,90 @@
+package mempool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestMempoolWAL(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.WalPath = t.TempDir()
+
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+	require.NoError(t, mp.InitWAL())
+	n, err := mp.ReplayWAL()
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	txs := types.Txs{types.Tx("c=2"), types.Tx("a=0"), types.Tx("b=0")}
+	callCheckTx(t, mp, txs)
+	mp.Lock()
+	require.NoError(t, mp.Update(1, txs[1:2], abciResponses(1, abci.CodeTypeOK), nil, nil))
+	mp.Unlock()
+
+	// Simulate a crash tearing the last record.
+	require.NoError(t, mp.CloseWAL())
+	f, err := os.OpenFile(filepath.Join(cfg.Mempool.WalDir(), walFileName), os.O_WRONLY|os.O_APPEND, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(walRecord('+', types.Tx("d=3"))[:10])
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// The txs still in the mempool are resubmitted in order, the committed tx
+	// and the torn record being skipped.
+	mp2, cleanup2 := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup2()
+	require.NoError(t, mp2.InitWAL())
+	n, err = mp2.ReplayWAL()
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, types.Txs{txs[0], txs[2]}, mp2.ReapMaxTxs(-1))
+	_, err = os.Stat(filepath.Join(cfg.Mempool.WalDir(), walReplayFileName))
+	require.True(t, os.IsNotExist(err))
+
+	// The resubmitted txs were journaled again.
+	journaled, err := readWAL(filepath.Join(cfg.Mempool.WalDir(), walFileName))
+	require.NoError(t, err)
+	require.Equal(t, types.Txs{txs[0], txs[2]}, journaled)
+
+	// Compacting the WAL keeps the txs of the mempool only.
+	require.NoError(t, mp2.RemoveTxByKey(txs[0].Key()))
+	mp2.Lock()
+	mp2.compactWAL(true)
+	mp2.Unlock()
+	require.Equal(t, 1, mp2.wal.records)
+	journaled, err = readWAL(filepath.Join(cfg.Mempool.WalDir(), walFileName))
+	require.NoError(t, err)
+	require.Equal(t, types.Txs{txs[2]}, journaled)
+	require.NoError(t, mp2.CloseWAL())
+}
+
+func TestReadWAL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), walFileName)
+	a, b := types.Tx("a"), types.Tx("b")
+	aKey, bKey := a.Key(), b.Key()
+	records := walRecord('+', a) + walRecord('+', b) + walRecord('-', aKey[:]) +
+		walRecord('+', a) + walRecord('-', bKey[:]) + "+ 00000000 YQ==\n" + walRecord('+', b)
+	require.NoError(t, os.WriteFile(path, []byte(records), 0o600))
+
+	// A tx removed and added again is kept, and the replay stops at the
+	// record failing the checksum.
+	txs, err := readWAL(path)
+	require.NoError(t, err)
+	require.Equal(t, types.Txs{a}, txs)
+
+	txs, err = readWAL(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	require.Empty(t, txs)
+}
@@ -8,15 +8,20 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"syscall"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
+	"google.golang.org/grpc"
+
+	dbm "github.com/cometbft/cometbft-db"
 
 	bc "github.com/cometbft/cometbft/blocksync"
 	cfg "github.com/cometbft/cometbft/config"
 	cs "github.com/cometbft/cometbft/consensus"
+	"github.com/cometbft/cometbft/crypto/threshold"
 	"github.com/cometbft/cometbft/evidence"
 	"github.com/cometbft/cometbft/light"
 
@@ -25,11 +30,16 @@ import (
 	"github.com/cometbft/cometbft/libs/service"
 	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/nat"
 	"github.com/cometbft/cometbft/p2p/pex"
 	"github.com/cometbft/cometbft/proxy"
+	rpcauth "github.com/cometbft/cometbft/rpc/auth"
 	rpccore "github.com/cometbft/cometbft/rpc/core"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	grpcserver "github.com/cometbft/cometbft/rpc/grpc/server"
 	grpcprivserver "github.com/cometbft/cometbft/rpc/grpc/server/privileged"
+	"github.com/cometbft/cometbft/rpc/grpc/server/services/adminservice"
+	"github.com/cometbft/cometbft/rpc/grpc/server/services/queryservice"
 	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
 	sm "github.com/cometbft/cometbft/state"
 	"github.com/cometbft/cometbft/state/indexer"
@@ -54,32 +64,53 @@ type Node struct {
 	genesisDoc    *types.GenesisDoc   // initial validator set
 	privValidator types.PrivValidator // local node's validator key
 
+	// genesisDocPending is true if genesisDoc was restored from the genesis
+	// checkpoint, lacking its app state, which is loaded through
+	// genesisDocProvider on demand.
+	genesisDocPending  bool
+	genesisDocProvider GenesisDocProvider
+
 	// network
 	transport   *p2p.MultiplexTransport
 	sw          *p2p.Switch  // p2p connections
 	addrBook    pex.AddrBook // known peers
+	addrBookDB  dbm.DB       // nil if the address book is saved to its file
 	nodeInfo    p2p.NodeInfo
 	nodeKey     *p2p.NodeKey // our node privkey
 	isListening bool
+	portMapper  *nat.PortMapper // nil if the port mapping is disabled
+
+	// validators to push the proposals and votes to, parsed from the
+	// direct_validator_peers field
+	validatorPeers []cs.ValidatorPeer
 
 	// services
 	eventBus          *types.EventBus // pub/sub for services
 	stateStore        sm.Store
+	stateDB           dbm.DB
 	blockStore        *store.BlockStore // store the blockchain to disk
+	rpcStateStore     sm.Store          // read replica of stateStore, if enabled
+	rpcBlockStore     *store.BlockStore // read replica of blockStore, if enabled
 	pruner            *sm.Pruner
+	storageForecaster *sm.StorageForecaster // nil if storage forecasting is disabled
+	executionReporter *sm.ExecutionReporter
 	bcReactor         p2p.Reactor    // for block-syncing
 	mempoolReactor    *mempl.Reactor // for gossipping transactions
 	mempool           mempl.Mempool
-	stateSync         bool                    // whether the node should state sync on startup
-	stateSyncReactor  *statesync.Reactor      // for hosting and restoring state sync snapshots
-	stateSyncProvider statesync.StateProvider // provides state data for bootstrapping a node
-	stateSyncGenesis  sm.State                // provides the genesis state for state sync
-	consensusState    *cs.State               // latest consensus state
-	consensusReactor  *cs.Reactor             // for participating in the consensus
-	pexReactor        *pex.Reactor            // for exchanging peer addresses
-	evidencePool      *evidence.Pool          // tracking evidence
-	proxyApp          proxy.AppConns          // connection to the application
-	rpcListeners      []net.Listener          // rpc servers
+	stateSync         bool                      // whether the node should state sync on startup
+	stateSyncReactor  *statesync.Reactor        // for hosting and restoring state sync snapshots
+	stateSyncProvider statesync.StateProvider   // provides state data for bootstrapping a node
+	stateSyncGenesis  sm.State                  // provides the genesis state for state sync
+	consensusState    *cs.State                 // latest consensus state
+	consensusReactor  *cs.Reactor               // for participating in the consensus
+	voteRecorder      *cs.VoteRecorder          // nil if vote recording is disabled
+	traceRecorder     *cs.TraceRecorder         // nil if consensus tracing is disabled
+	replayProgress    *cs.ReplayProgress        // progress of the replays on startup
+	validatorsPerf    *cs.ValidatorsPerformance // participation of the validators
+	pexReactor        *pex.Reactor              // for exchanging peer addresses
+	evidencePool      *evidence.Pool            // tracking evidence
+	proxyApp          proxy.AppConns            // connection to the application
+	rpcListeners      []net.Listener            // rpc servers
 	txIndexer         txindex.TxIndexer
 	blockIndexer      indexer.BlockIndexer
 	indexerService    *txindex.IndexerService
@@ -139,6 +170,27 @@ func StateProvider(stateProvider statesync.StateProvider) Option {
 	}
 }
 
+// SnapshotSources adds sources of snapshots to state sync, in addition to the ones of the
+// statesync.snapshot_sources config. Their snapshots are discovered along with the ones of the
+// peers, and their chunks are fetched from them, falling back to the peers.
+// WARNING: this interface is considered unstable and subject to change.
+func SnapshotSources(sources ...statesync.SnapshotSource) Option {
+	return func(n *Node) {
+		n.stateSyncReactor.AddSnapshotSources(sources...)
+	}
+}
+
+// TxSender sets the function extracting the sender of a transaction, with which
+// the mempool drops the transactions of the senders banned by the application
+// without calling CheckTx.
+func TxSender(f mempl.TxSenderFunc) Option {
+	return func(n *Node) {
+		if mp, ok := n.mempool.(*mempl.CListMempool); ok {
+			mempl.WithTxSender(f)(mp)
+		}
+	}
+}
+
 // BootstrapState synchronizes the stores with the application after state sync
 // has been performed offline. It is expected that the block store and state
 // store are empty at the time the function is called.
@@ -176,9 +228,10 @@ func BootstrapState(ctx context.Context, config *cfg.Config, dbProvider cfg.DBPr
 		return fmt.Errorf("blockstore not empty, trying to initialize non empty state")
 	}
 
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: config.Storage.DiscardABCIResponses,
-	})
+	stateStore, err := createStateStore(config, stateDB)
+	if err != nil {
+		return err
+	}
 
 	defer func() {
 		if derr := stateStore.Close(); derr != nil {
@@ -196,7 +249,7 @@ func BootstrapState(ctx context.Context, config *cfg.Config, dbProvider cfg.DBPr
 		return fmt.Errorf("state not empty, trying to initialize non empty state")
 	}
 
-	genState, _, err := LoadStateFromDBOrGenesisDocProvider(stateDB, DefaultGenesisDocProviderFunc(config), config.Storage.GenesisHash)
+	genState, genDoc, err := LoadStateFromDBOrGenesisDocProvider(stateDB, DefaultGenesisDocProviderFunc(config), config.Storage.GenesisHash)
 	if err != nil {
 		return err
 	}
@@ -208,7 +261,8 @@ func BootstrapState(ctx context.Context, config *cfg.Config, dbProvider cfg.DBPr
 			Period: config.StateSync.TrustPeriod,
 			Height: config.StateSync.TrustHeight,
 			Hash:   config.StateSync.TrustHashBytes(),
-		}, logger.With("module", "light"))
+		}, logger.With("module", "light"),
+		statesync.StateProviderBlockTime(blockTimeFunc(config.Consensus, genDoc.GenesisTime)))
 	if err != nil {
 		return fmt.Errorf("failed to set up light client state provider: %w", err)
 	}
@@ -271,20 +325,38 @@ func NewNode(ctx context.Context,
 	logger log.Logger,
 	options ...Option,
 ) (*Node, error) {
+	for _, warning := range config.StorageParityWarnings() {
+		logger.Error(warning)
+	}
+
 	blockStore, stateDB, err := initDBs(config, dbProvider)
 	if err != nil {
 		return nil, err
 	}
 
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: config.Storage.DiscardABCIResponses,
-	})
+	stateStore, err := createStateStore(config, stateDB)
+	if err != nil {
+		return nil, err
+	}
 
-	state, genDoc, err := LoadStateFromDBOrGenesisDocProvider(stateDB, genesisDocProvider, config.Storage.GenesisHash)
+	rpcBlockStore, rpcStateStore, err := createReadReplicas(config, blockStore, stateStore, stateDB)
 	if err != nil {
 		return nil, err
 	}
 
+	// Skip parsing the genesis file on restarts, using the checkpoint stored
+	// in the state database instead.
+	state, csGenDoc, err := loadStateFromStoreOrGenesisDocProvider(
+		stateDB,
+		stateStore,
+		genesisDocProviderFromCheckpoint(stateDB, config.GenesisFile(), genesisDocProvider),
+		config.Storage.GenesisHash,
+	)
+	if err != nil {
+		return nil, err
+	}
+	genDoc := csGenDoc.GenesisDoc
+
 	// The key will be deleted if it existed.
 	// Not checking whether the key is there in case the genesis file was larger than
 	// the max size of a value (in rocksDB for example), which would cause the check
@@ -308,13 +380,13 @@ func NewNode(ctx context.Context,
 	// we might need to index the txs of the replayed block as this might not have happened
 	// when the node stopped last time (i.e. the node stopped after it saved the block
 	// but before it indexed the txs)
-	eventBus, err := createAndStartEventBus(logger)
+	eventBus, err := createAndStartEventBus(config, smMetrics, logger)
 	if err != nil {
 		return nil, err
 	}
 
 	indexerService, txIndexer, blockIndexer, err := createAndStartIndexerService(config,
-		genDoc.ChainID, dbProvider, eventBus, logger)
+		genDoc.ChainID, dbProvider, eventBus, stateDB, stateStore, blockStore, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -336,6 +408,10 @@ func NewNode(ctx context.Context,
 
 	// Determine whether we should attempt state sync.
 	stateSync := config.StateSync.Enable && !onlyValidatorIsUs(state, pubKey)
+	if stateSync && config.P2P.SeedMode {
+		logger.Info("Running in seed mode, skipping state sync")
+		stateSync = false
+	}
 	if stateSync && state.LastBlockHeight > 0 {
 		logger.Info("Found local state with non-zero height, skipping state sync")
 		stateSync = false
@@ -344,8 +420,26 @@ func NewNode(ctx context.Context,
 	// Create the handshaker, which calls RequestInfo, sets the AppVersion on the state,
 	// and replays any blocks as necessary to sync CometBFT with the app.
 	consensusLogger := logger.With("module", "consensus")
+	replayProgress := cs.NewReplayProgress(csMetrics)
+	replayProgress.SetLogger(consensusLogger)
 	if !stateSync {
-		if err := doHandshake(ctx, stateStore, state, blockStore, genDoc, eventBus, proxyApp, consensusLogger); err != nil {
+		// The app state of the genesis doc is needed if the app has to be
+		// initialized, which may happen even if blocks have been committed
+		// (e.g. the app's data was removed).
+		csGenDoc, err = completeGenesisDoc(ctx, proxyApp, csGenDoc, genesisDocProvider)
+		if err != nil {
+			return nil, err
+		}
+		genDoc = csGenDoc.GenesisDoc
+		// The RPC server is not started yet, so the progress of the blocks
+		// replay is served by a temporary one.
+		stopReplayStatusRPC, err := startReplayStatusRPC(config, replayProgress, logger)
+		if err != nil {
+			return nil, err
+		}
+		err = doHandshake(ctx, stateStore, state, blockStore, genDoc, eventBus, proxyApp, replayProgress, consensusLogger)
+		stopReplayStatusRPC()
+		if err != nil {
 			return nil, err
 		}
 
@@ -366,7 +460,8 @@ func NewNode(ctx context.Context,
 	logNodeStartupInfo(state, pubKey, logger, consensusLogger)
 
 	// Make MempoolReactor
-	mempool, mempoolReactor := createMempoolAndMempoolReactor(config, proxyApp, state, waitSync, memplMetrics, logger)
+	mempool, mempoolReactor := createMempoolAndMempoolReactor(config, proxyApp, state, waitSync, eventBus,
+		memplMetrics, logger)
 
 	// Make Evidence Reactor
 	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateStore, blockStore, logger)
@@ -387,6 +482,34 @@ func NewNode(ctx context.Context,
 		return nil, fmt.Errorf("failed to create pruner: %w", err)
 	}
 
+	storageForecaster := createStorageForecaster(config, smMetrics, logger.With("module", "state"))
+	executionReporter := sm.NewExecutionReporter(smMetrics)
+
+	blockExecOptions := []sm.BlockExecutorOption{
+		sm.BlockExecutorWithPruner(pruner),
+		sm.BlockExecutorWithMetrics(smMetrics),
+		sm.BlockExecutorWithExecutionReporter(executionReporter),
+		sm.BlockExecutorWithBlockTime(blockTimeFunc(config.Consensus, genDoc.GenesisTime)),
+	}
+	if config.Mempool.ExperimentalThresholdKeyFile != "" {
+		thresholdKey, err := threshold.LoadKeyFile(config.Mempool.ThresholdKeyFile())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load threshold key file: %w", err)
+		}
+		blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithThresholdDecryption(thresholdKey))
+	}
+	if config.Consensus.OptimisticExecution {
+		supported, err := optimisticExecutionSupported(ctx, proxyApp)
+		if err != nil {
+			return nil, err
+		}
+		if supported {
+			blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithOptimisticExecution())
+		} else {
+			logger.Error("The application does not support the optimistic execution of the blocks, ignoring consensus.optimistic_execution")
+		}
+	}
+
 	// make block executor for consensus and blocksync reactors to execute blocks
 	blockExec := sm.NewBlockExecutor(
 		stateStore,
@@ -395,8 +518,7 @@ func NewNode(ctx context.Context,
 		mempool,
 		evidencePool,
 		blockStore,
-		sm.BlockExecutorWithPruner(pruner),
-		sm.BlockExecutorWithMetrics(smMetrics),
+		blockExecOptions...,
 	)
 
 	offlineStateSyncHeight := int64(0)
@@ -412,10 +534,27 @@ func NewNode(ctx context.Context,
 		return nil, fmt.Errorf("could not create blocksync reactor: %w", err)
 	}
 
+	validatorPeers, err := cs.ParseValidatorPeers(splitAndTrimEmpty(config.Consensus.DirectValidatorPeers, ",", " "))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse direct_validator_peers field: %w", err)
+	}
+
+	voteRecorder, err := createVoteRecorder(config, dbProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	traceRecorder, err := createTraceRecorder(config)
+	if err != nil {
+		return nil, err
+	}
+
 	// Make ConsensusReactor
+	validatorsPerf := cs.NewValidatorsPerformance(csMetrics)
 	consensusReactor, consensusState := createConsensusReactor(
 		config, state, blockExec, blockStore, mempool, evidencePool,
 		privValidator, csMetrics, waitSync, eventBus, consensusLogger, offlineStateSyncHeight,
+		validatorPeers, voteRecorder, traceRecorder, replayProgress, validatorsPerf,
 	)
 
 	err = stateStore.SetOfflineStateSyncHeight(0)
@@ -433,8 +572,18 @@ func NewNode(ctx context.Context,
 		ssMetrics,
 	)
 	stateSyncReactor.SetLogger(logger.With("module", "statesync"))
+	snapshotSources, err := createSnapshotSources(config.StateSync)
+	if err != nil {
+		return nil, err
+	}
+	stateSyncReactor.AddSnapshotSources(snapshotSources...)
 
-	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state)
+	// Map the p2p port on the NAT gateway, before advertising the external
+	// address in the NodeInfo.
+	portMapper := createPortMapper(config, logger.With("module", "nat"))
+
+	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state,
+		mempoolReactor, bcReactor, stateSyncReactor, consensusReactor, evidenceReactor)
 	if err != nil {
 		return nil, err
 	}
@@ -444,8 +593,12 @@ func NewNode(ctx context.Context,
 
 	// Setup Switch.
 	p2pLogger := logger.With("module", "p2p")
+	peerAllowlist, err := createPeerAllowlist(config, p2pLogger)
+	if err != nil {
+		return nil, err
+	}
 	sw := createSwitch(
-		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
+		config, transport, p2pMetrics, peerFilters, peerAllowlist, mempoolReactor, bcReactor,
 		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
 	)
 
@@ -459,7 +612,16 @@ func NewNode(ctx context.Context,
 		return nil, fmt.Errorf("could not add peer ids from unconditional_peer_ids field: %w", err)
 	}
 
-	addrBook, err := createAddrBookAndSetOnSwitch(config, sw, p2pLogger, nodeKey)
+	// Maintain direct links to the validator peers, regardless of the peer limits.
+	validatorPeerAddrs, validatorPeerIDs := validatorPeerAddresses(validatorPeers)
+	if err = sw.AddPersistentPeers(validatorPeerAddrs); err != nil {
+		return nil, fmt.Errorf("could not add peers from direct_validator_peers field: %w", err)
+	}
+	if err = sw.AddUnconditionalPeerIDs(validatorPeerIDs); err != nil {
+		return nil, fmt.Errorf("could not add peer ids from direct_validator_peers field: %w", err)
+	}
+
+	addrBook, addrBookDB, err := createAddrBookAndSetOnSwitch(config, dbProvider, sw, p2pLogger, nodeKey)
 	if err != nil {
 		return nil, fmt.Errorf("could not create addrbook: %w", err)
 	}
@@ -478,41 +640,59 @@ func NewNode(ctx context.Context,
 	// Note we currently use the addrBook regardless at least for AddOurAddress
 	var pexReactor *pex.Reactor
 	if config.P2P.PexReactor {
-		pexReactor = createPEXReactorAndAddToSwitch(addrBook, config, sw, logger)
+		pexReactor, err = createPEXReactorAndAddToSwitch(addrBook, config, sw, logger)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Add private IDs to addrbook to block those peers being added
 	addrBook.AddPrivateIDs(splitAndTrimEmpty(config.P2P.PrivatePeerIDs, ",", " "))
 
 	node := &Node{
-		config:        config,
-		genesisDoc:    genDoc,
-		privValidator: privValidator,
-
-		transport: transport,
-		sw:        sw,
-		addrBook:  addrBook,
-		nodeInfo:  nodeInfo,
-		nodeKey:   nodeKey,
-
-		stateStore:       stateStore,
-		blockStore:       blockStore,
-		pruner:           pruner,
-		bcReactor:        bcReactor,
-		mempoolReactor:   mempoolReactor,
-		mempool:          mempool,
-		consensusState:   consensusState,
-		consensusReactor: consensusReactor,
-		stateSyncReactor: stateSyncReactor,
-		stateSync:        stateSync,
-		stateSyncGenesis: state, // Shouldn't be necessary, but need a way to pass the genesis state
-		pexReactor:       pexReactor,
-		evidencePool:     evidencePool,
-		proxyApp:         proxyApp,
-		txIndexer:        txIndexer,
-		indexerService:   indexerService,
-		blockIndexer:     blockIndexer,
-		eventBus:         eventBus,
+		config:             config,
+		genesisDoc:         genDoc,
+		genesisDocPending:  csGenDoc.fromCheckpoint,
+		genesisDocProvider: genesisDocProvider,
+		privValidator:      privValidator,
+
+		transport:  transport,
+		sw:         sw,
+		addrBook:   addrBook,
+		addrBookDB: addrBookDB,
+		nodeInfo:   nodeInfo,
+		nodeKey:    nodeKey,
+		portMapper: portMapper,
+
+		validatorPeers: validatorPeers,
+
+		stateStore:        stateStore,
+		stateDB:           stateDB,
+		blockStore:        blockStore,
+		rpcStateStore:     rpcStateStore,
+		rpcBlockStore:     rpcBlockStore,
+		pruner:            pruner,
+		storageForecaster: storageForecaster,
+		executionReporter: executionReporter,
+		bcReactor:         bcReactor,
+		mempoolReactor:    mempoolReactor,
+		mempool:           mempool,
+		consensusState:    consensusState,
+		consensusReactor:  consensusReactor,
+		voteRecorder:      voteRecorder,
+		traceRecorder:     traceRecorder,
+		replayProgress:    replayProgress,
+		validatorsPerf:    validatorsPerf,
+		stateSyncReactor:  stateSyncReactor,
+		stateSync:         stateSync,
+		stateSyncGenesis:  state, // Shouldn't be necessary, but need a way to pass the genesis state
+		pexReactor:        pexReactor,
+		evidencePool:      evidencePool,
+		proxyApp:          proxyApp,
+		txIndexer:         txIndexer,
+		indexerService:    indexerService,
+		blockIndexer:      blockIndexer,
+		eventBus:          eventBus,
 	}
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
@@ -542,6 +722,27 @@ func (n *Node) OnStart() error {
 		n.prometheusSrv = n.startPrometheusServer()
 	}
 
+	// Resubmit the mempool txs saved on shutdown, ahead of the new ones
+	if n.config.Mempool.PersistEnabled() {
+		if mp, ok := n.mempool.(*mempl.CListMempool); ok {
+			if _, err := mp.LoadTxs(n.config.Mempool.PersistFile()); err != nil {
+				n.Logger.Error("Error resubmitting saved mempool transactions", "err", err)
+			}
+		}
+	}
+
+	// Resubmit the mempool txs journaled before an unclean shutdown
+	if n.config.Mempool.WalEnabled() {
+		if mp, ok := n.mempool.(*mempl.CListMempool); ok {
+			if err := mp.InitWAL(); err != nil {
+				return fmt.Errorf("failed to open mempool WAL: %w", err)
+			}
+			if _, err := mp.ReplayWAL(); err != nil {
+				n.Logger.Error("Error resubmitting journaled mempool transactions", "err", err)
+			}
+		}
+	}
+
 	// Start the RPC server before the P2P server
 	// so we can eg. receive txs for the first block
 	if n.config.RPC.ListenAddress != "" {
@@ -563,6 +764,12 @@ func (n *Node) OnStart() error {
 
 	n.isListening = true
 
+	if n.portMapper != nil {
+		if err := n.portMapper.Start(); err != nil {
+			return fmt.Errorf("failed to start port mapper: %w", err)
+		}
+	}
+
 	// Start the switch (the P2P server).
 	err = n.sw.Start()
 	if err != nil {
@@ -575,6 +782,12 @@ func (n *Node) OnStart() error {
 		return fmt.Errorf("could not dial peers from persistent_peers field: %w", err)
 	}
 
+	// Always connect to the direct validator peers
+	validatorPeerAddrs, _ := validatorPeerAddresses(n.validatorPeers)
+	if err = n.sw.DialPeersAsync(validatorPeerAddrs); err != nil {
+		return fmt.Errorf("could not dial peers from direct_validator_peers field: %w", err)
+	}
+
 	// Run state sync
 	if n.stateSync {
 		bcR, ok := n.bcReactor.(blockSyncReactor)
@@ -582,7 +795,8 @@ func (n *Node) OnStart() error {
 			return fmt.Errorf("this blocksync reactor does not support switching from state sync")
 		}
 		err := startStateSync(n.stateSyncReactor, bcR, n.stateSyncProvider,
-			n.config.StateSync, n.stateStore, n.blockStore, n.stateSyncGenesis)
+			n.config.StateSync, n.stateStore, n.blockStore, n.stateSyncGenesis,
+			blockTimeFunc(n.config.Consensus, n.genesisDoc.GenesisTime))
 		if err != nil {
 			return fmt.Errorf("failed to start state sync: %w", err)
 		}
@@ -593,6 +807,12 @@ func (n *Node) OnStart() error {
 		return fmt.Errorf("failed to start background pruning routine: %w", err)
 	}
 
+	if n.storageForecaster != nil {
+		if err := n.storageForecaster.Start(); err != nil {
+			return fmt.Errorf("failed to start storage forecaster: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -606,6 +826,11 @@ func (n *Node) OnStop() {
 	if err := n.pruner.Stop(); err != nil {
 		n.Logger.Error("Error stopping the pruning service", "err", err)
 	}
+	if n.storageForecaster != nil {
+		if err := n.storageForecaster.Stop(); err != nil {
+			n.Logger.Error("Error stopping the storage forecaster", "err", err)
+		}
+	}
 	if err := n.eventBus.Stop(); err != nil {
 		n.Logger.Error("Error closing eventBus", "err", err)
 	}
@@ -622,8 +847,24 @@ func (n *Node) OnStop() {
 		n.Logger.Error("Error closing transport", "err", err)
 	}
 
+	if n.addrBookDB != nil {
+		// Wait for the address book to be saved on stop.
+		if book, ok := n.addrBook.(interface{ Wait() }); ok {
+			book.Wait()
+		}
+		if err := n.addrBookDB.Close(); err != nil {
+			n.Logger.Error("Error closing address book database", "err", err)
+		}
+	}
+
 	n.isListening = false
 
+	if n.portMapper != nil {
+		if err := n.portMapper.Stop(); err != nil {
+			n.Logger.Error("Error stopping the port mapper", "err", err)
+		}
+	}
+
 	// finally stop the listeners / external services
 	for _, l := range n.rpcListeners {
 		n.Logger.Info("Closing rpc listener", "listener", l)
@@ -632,6 +873,23 @@ func (n *Node) OnStop() {
 		}
 	}
 
+	// save the mempool txs once neither consensus nor the RPC use the mempool
+	if n.config.Mempool.PersistEnabled() {
+		if mp, ok := n.mempool.(*mempl.CListMempool); ok {
+			if err := mp.SaveTxs(n.config.Mempool.PersistFile()); err != nil {
+				n.Logger.Error("Error saving mempool transactions", "err", err)
+			}
+		}
+	}
+
+	if n.config.Mempool.WalEnabled() {
+		if mp, ok := n.mempool.(*mempl.CListMempool); ok {
+			if err := mp.CloseWAL(); err != nil {
+				n.Logger.Error("Error closing mempool WAL", "err", err)
+			}
+		}
+	}
+
 	if pvsc, ok := n.privValidator.(service.Service); ok {
 		if err := pvsc.Stop(); err != nil {
 			n.Logger.Error("Error closing private validator", "err", err)
@@ -661,12 +919,30 @@ func (n *Node) OnStop() {
 			n.Logger.Error("problem closing statestore", "err", err)
 		}
 	}
+	if n.rpcStateStore != nil && n.rpcStateStore != n.stateStore {
+		n.Logger.Info("Closing statestore read replica")
+		if err := n.rpcStateStore.Close(); err != nil {
+			n.Logger.Error("problem closing statestore read replica", "err", err)
+		}
+	}
 	if n.evidencePool != nil {
 		n.Logger.Info("Closing evidencestore")
 		if err := n.EvidencePool().Close(); err != nil {
 			n.Logger.Error("problem closing evidencestore", "err", err)
 		}
 	}
+	if n.voteRecorder != nil {
+		n.Logger.Info("Closing votestore")
+		if err := n.voteRecorder.Close(); err != nil {
+			n.Logger.Error("problem closing votestore", "err", err)
+		}
+	}
+	if n.traceRecorder != nil {
+		n.Logger.Info("Closing consensus trace file")
+		if err := n.traceRecorder.Close(); err != nil {
+			n.Logger.Error("problem closing consensus trace file", "err", err)
+		}
+	}
 }
 
 // ConfigureRPC makes sure RPC has all the objects it needs to operate.
@@ -675,29 +951,55 @@ func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 	if pubKey == nil || err != nil {
 		return nil, fmt.Errorf("can't get pubkey: %w", err)
 	}
+	var mempoolDropLog *mempl.DropLog
+	if mp, ok := n.mempool.(*mempl.CListMempool); ok {
+		mempoolDropLog = mp.DropLog()
+	}
 	rpcCoreEnv := rpccore.Environment{
 		ProxyAppQuery:   n.proxyApp.Query(),
 		ProxyAppMempool: n.proxyApp.Mempool(),
 
-		StateStore:     n.stateStore,
-		BlockStore:     n.blockStore,
+		StateStore:     n.rpcStateStore,
+		BlockStore:     n.rpcBlockStore,
 		EvidencePool:   n.evidencePool,
 		ConsensusState: n.consensusState,
 		P2PPeers:       n.sw,
 		P2PTransport:   n,
 		PubKey:         pubKey,
 
-		GenDoc:           n.genesisDoc,
-		TxIndexer:        n.txIndexer,
-		BlockIndexer:     n.blockIndexer,
-		ConsensusReactor: n.consensusReactor,
-		MempoolReactor:   n.mempoolReactor,
-		EventBus:         n.eventBus,
-		Mempool:          n.mempool,
+		GenDoc:            n.genesisDoc,
+		GenDocPending:     n.genesisDocPending,
+		GenDocLoader:      n.loadGenesisDoc,
+		TxIndexer:         n.txIndexer,
+		BlockIndexer:      n.blockIndexer,
+		ConsensusReactor:  n.consensusReactor,
+		MempoolReactor:    n.mempoolReactor,
+		EventBus:          n.eventBus,
+		Mempool:           n.mempool,
+		MempoolDropLog:    mempoolDropLog,
+		Pruner:            n.pruner,
+		StorageForecaster: n.storageForecaster,
+		ExecutionReporter: n.executionReporter,
+		VoteRecorder:      n.voteRecorder,
+		ReplayProgress:    n.replayProgress,
+		ValidatorsPerf:    n.validatorsPerf,
 
 		Logger: n.Logger.With("module", "rpc"),
 
-		Config: *n.config.RPC,
+		Config:   *n.config.RPC,
+		Features: nodeFeatures(n.config, n.nodeInfo),
+	}
+	if bcR, ok := n.bcReactor.(*bc.Reactor); ok {
+		rpcCoreEnv.BlockSyncReactor = bcR
+	}
+	if n.stateSyncReactor != nil {
+		rpcCoreEnv.StateSyncReactor = n.stateSyncReactor
+	}
+	if n.indexerService != nil {
+		rpcCoreEnv.IndexerService = n.indexerService
+	}
+	if n.stateDB != nil {
+		rpcCoreEnv.CheckDBWritable = n.checkDBWritable
 	}
 	if err := rpcCoreEnv.InitGenesisChunks(); err != nil {
 		return nil, err
@@ -705,6 +1007,18 @@ func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 	return &rpcCoreEnv, nil
 }
 
+// checkDBWritable writes and deletes a key in the state database, for the
+// health checks.
+func (n *Node) checkDBWritable() error {
+	if err := n.stateDB.Set(healthCheckKey, []byte{1}); err != nil {
+		return fmt.Errorf("failed to write to the state database: %w", err)
+	}
+	if err := n.stateDB.Delete(healthCheckKey); err != nil {
+		return fmt.Errorf("failed to delete from the state database: %w", err)
+	}
+	return nil
+}
+
 func (n *Node) startRPC() ([]net.Listener, error) {
 	env, err := n.ConfigureRPC()
 	if err != nil {
@@ -714,14 +1028,11 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 	listenAddrs := splitAndTrimEmpty(n.config.RPC.ListenAddress, ",", " ")
 	routes := env.GetRoutes()
 
-	if n.config.RPC.Unsafe {
-		env.AddUnsafeRoutes(routes)
-	}
-
 	config := rpcserver.DefaultConfig()
 	config.MaxBodyBytes = n.config.RPC.MaxBodyBytes
 	config.MaxHeaderBytes = n.config.RPC.MaxHeaderBytes
 	config.MaxOpenConnections = n.config.RPC.MaxOpenConnections
+	config.CompressResponses = n.config.RPC.CompressResponses
 	// If necessary adjust global WriteTimeout to ensure it's greater than
 	// TimeoutBroadcastTxCommit.
 	// See https://github.com/tendermint/tendermint/issues/3435
@@ -729,6 +1040,46 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
+	// The rate limits apply to the requests over all the listeners.
+	var rateLimiter *rpcserver.RateLimiter
+	if n.config.RPC.IsRateLimitEnabled() {
+		rateLimiter, err = rpcserver.NewRateLimiter(
+			n.config.RPC.RateLimit,
+			n.config.RPC.RateLimitBurst,
+			n.config.RPC.MethodRateLimitMap(),
+			n.config.RPC.RateLimitAllowlist,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The API keys authorize the requests to the RPC and gRPC servers.
+	var (
+		authorizer  rpcserver.Authorizer
+		grpcAPIKeys *rpcauth.APIKeys
+	)
+	if file := n.config.RPC.APIKeysFile(); file != "" {
+		keysFile, err := rpcauth.LoadKeysFile(file)
+		if err != nil {
+			return nil, err
+		}
+		apiKeys, err := rpcauth.NewAPIKeys(keysFile, rpccore.RouteGroup, n.config.RPC.AnonymousMethods)
+		if err != nil {
+			return nil, err
+		}
+		authorizer = apiKeys
+		// The health of the gRPC server can be checked by the probes without
+		// a key.
+		grpcAPIKeys, err = rpcauth.NewAPIKeys(keysFile, grpcserver.MethodGroup,
+			append(grpcserver.HealthCheckMethods, n.config.RPC.AnonymousMethods...))
+		if err != nil {
+			return nil, err
+		}
+		n.Logger.Info("Requiring API keys for the RPC requests",
+			"keys", len(keysFile.Keys), "jwt_issuers", len(keysFile.JWTIssuers))
+	}
+
 	// we may expose the rpc over both a unix and tcp socket
 	listeners := make([]net.Listener, len(listenAddrs))
 	for i, listenAddr := range listenAddrs {
@@ -744,11 +1095,23 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 			}),
 			rpcserver.ReadLimit(config.MaxBodyBytes),
 			rpcserver.WriteChanCapacity(n.config.RPC.WebSocketWriteBufferSize),
+			rpcserver.RateLimit(rateLimiter),
+			rpcserver.Authorization(authorizer),
+			rpcserver.PingPeriod(n.config.RPC.WebSocketPingInterval),
+			// A connection is closed if nothing is read after a ping for
+			// the rest of the period plus 1/9 of it, as by default.
+			rpcserver.ReadWait((n.config.RPC.WebSocketPingInterval*10)/9),
 		)
 		wm.SetLogger(wmLogger)
 		mux.HandleFunc("/websocket", wm.WebsocketHandler)
 		mux.HandleFunc("/v1/websocket", wm.WebsocketHandler)
-		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
+		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger,
+			rpcserver.WithRateLimiter(rateLimiter),
+			rpcserver.WithAuthorizer(authorizer),
+			rpcserver.WithMaxBatchSize(n.config.RPC.MaxRequestBatchSize),
+			rpcserver.WithBatchConcurrency(n.config.RPC.BatchRequestConcurrency),
+			rpcserver.WithOpenAPIInfo("CometBFT RPC", version.TMCoreSemVer),
+		)
 		listener, err := rpcserver.Listen(
 			listenAddr,
 			config.MaxOpenConnections,
@@ -803,14 +1166,39 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		opts := []grpcserver.Option{
 			grpcserver.WithLogger(n.Logger),
 		}
+		if grpcAPIKeys != nil {
+			opts = append(opts,
+				grpcserver.WithGRPCOption(grpc.ChainUnaryInterceptor(grpcAPIKeys.UnaryServerInterceptor())),
+				grpcserver.WithGRPCOption(grpc.ChainStreamInterceptor(grpcAPIKeys.StreamServerInterceptor())),
+			)
+		}
 		if n.config.GRPC.VersionService.Enabled {
 			opts = append(opts, grpcserver.WithVersionService())
 		}
 		if n.config.GRPC.BlockService.Enabled {
-			opts = append(opts, grpcserver.WithBlockService(n.blockStore, n.eventBus, n.Logger))
+			opts = append(opts, grpcserver.WithBlockService(n.rpcBlockStore, n.eventBus, n.Logger))
 		}
 		if n.config.GRPC.BlockResultsService.Enabled {
-			opts = append(opts, grpcserver.WithBlockResultsService(n.blockStore, n.stateStore, n.Logger))
+			opts = append(opts, grpcserver.WithBlockResultsService(n.rpcBlockStore, n.rpcStateStore, n.Logger))
+		}
+		if n.config.GRPC.MempoolService.Enabled {
+			opts = append(opts, grpcserver.WithMempoolService(n.eventBus, n.Logger))
+		}
+		if n.config.GRPC.EventsService.Enabled {
+			opts = append(opts, grpcserver.WithEventsService(
+				n.eventBus,
+				n.config.RPC.MaxSubscriptionClients,
+				n.config.GRPC.EventsService.MaxBufferSize,
+				n.Logger,
+			))
+		}
+		if n.config.GRPC.QueryService.Enabled {
+			opts = append(opts, grpcserver.WithQueryService(queryservice.Environment{
+				BlockStore:   n.rpcBlockStore,
+				TxIndexer:    n.txIndexer,
+				BlockIndexer: n.blockIndexer,
+				Status:       func() (*ctypes.ResultStatus, error) { return env.Status(nil) },
+			}, n.Logger))
 		}
 		go func() {
 			if err := grpcserver.Serve(listener, opts...); err != nil {
@@ -828,9 +1216,29 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		opts := []grpcprivserver.Option{
 			grpcprivserver.WithLogger(n.Logger),
 		}
+		if n.config.GRPC.Privileged.IsTLSEnabled() {
+			creds, err := privilegedGRPCCredentials(n.config.GRPC.Privileged)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, grpcprivserver.WithGRPCOption(grpc.Creds(creds)))
+		}
 		if n.config.GRPC.Privileged.PruningService.Enabled {
 			opts = append(opts, grpcprivserver.WithPruningService(n.pruner, n.Logger))
 		}
+		if n.config.GRPC.Privileged.AdminService.Enabled {
+			adminEnv := adminservice.Environment{
+				Peers:   n.sw,
+				Mempool: n.mempool,
+				Pruner:  n.pruner,
+				Backup:  n.BackupStores,
+				Halt:    n.halt,
+			}
+			if n.pexReactor != nil {
+				adminEnv.AddrBook = n.pexReactor
+			}
+			opts = append(opts, grpcprivserver.WithAdminService(adminEnv, n.Logger))
+		}
 		go func() {
 			if err := grpcprivserver.Serve(listener, opts...); err != nil {
 				n.Logger.Error("Error starting privileged gRPC server", "err", err)
@@ -864,6 +1272,62 @@ func (n *Node) startPrometheusServer() *http.Server {
 	return srv
 }
 
+// startReplayStatusRPC serves the replay_status RPC endpoint alone on the RPC
+// listen addresses, until the returned function is called. It's used while the
+// blocks are replayed by the handshake, before the RPC server is started.
+func startReplayStatusRPC(config *cfg.Config, progress *cs.ReplayProgress, logger log.Logger) (func(), error) {
+	listenAddrs := splitAndTrimEmpty(config.RPC.ListenAddress, ",", " ")
+	env := &rpccore.Environment{ReplayProgress: progress}
+	routes := rpccore.RoutesMap{
+		"replay_status": rpcserver.NewRPCFunc(env.ReplayStatus, ""),
+	}
+	rpcLogger := logger.With("module", "rpc-server")
+
+	var (
+		servers   []*http.Server
+		listeners []net.Listener
+	)
+	stop := func() {
+		for _, srv := range servers {
+			if err := srv.Close(); err != nil {
+				rpcLogger.Error("Error closing the replay status RPC server", "err", err)
+			}
+		}
+		// The servers may not serve their listener yet, which must be closed
+		// before the RPC server listens on the same addresses.
+		for _, listener := range listeners {
+			_ = listener.Close()
+		}
+	}
+	for _, listenAddr := range listenAddrs {
+		mux := http.NewServeMux()
+		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
+		listener, err := rpcserver.Listen(listenAddr, config.RPC.MaxOpenConnections)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+		srv := &http.Server{
+			Handler:           rpcserver.RecoverAndLogHandler(mux, rpcLogger),
+			ReadHeaderTimeout: readHeaderTimeout,
+		}
+		servers = append(servers, srv)
+		listeners = append(listeners, listener)
+		go func() {
+			var err error
+			if config.RPC.IsTLSEnabled() {
+				err = srv.ServeTLS(listener, config.RPC.CertFile(), config.RPC.KeyFile())
+			} else {
+				err = srv.Serve(listener)
+			}
+			if err != http.ErrServerClosed {
+				rpcLogger.Error("Error serving the replay status RPC", "err", err)
+			}
+		}()
+	}
+	return stop, nil
+}
+
 // starts a ppro
 func (n *Node) startPprofServer() *http.Server {
 	srv := &http.Server{
@@ -926,6 +1390,46 @@ func (n *Node) PrivValidator() types.PrivValidator {
 	return n.privValidator
 }
 
+// BackupStores takes a snapshot of the block and state stores while the node
+// is running, and writes it to new databases in the given directory. See
+// store.Backup.
+func (n *Node) BackupStores(dir string) (*store.BackupInfo, error) {
+	n.Logger.Info("Backing up block and state stores", "dir", dir)
+	info, err := store.Backup(n.blockStore, n.stateDB, dbm.BackendType(n.config.DBBackend), dir)
+	if err != nil {
+		return nil, err
+	}
+	n.Logger.Info("Backed up block and state stores",
+		"dir", dir,
+		"stateHeight", info.StateHeight,
+		"blockStoreHeight", info.BlockStoreHeight,
+	)
+	return info, nil
+}
+
+// halt initiates the graceful shutdown of the node by sending SIGTERM to the
+// process, which is trapped by the command running the node.
+func (n *Node) halt() error {
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		return err
+	}
+	return p.Signal(syscall.SIGTERM)
+}
+
+// loadGenesisDoc loads the full genesis doc through the node's genesis doc
+// provider.
+func (n *Node) loadGenesisDoc() (*types.GenesisDoc, error) {
+	csGenDoc, err := n.genesisDocProvider()
+	if err != nil {
+		return nil, err
+	}
+	if err := csGenDoc.GenesisDoc.ValidateAndComplete(); err != nil {
+		return nil, fmt.Errorf("error in genesis doc: %w", err)
+	}
+	return csGenDoc.GenesisDoc, nil
+}
+
 // GenesisDoc returns the Node's GenesisDoc.
 func (n *Node) GenesisDoc() *types.GenesisDoc {
 	return n.genesisDoc
@@ -964,6 +1468,7 @@ func makeNodeInfo(
 	txIndexer txindex.TxIndexer,
 	genDoc *types.GenesisDoc,
 	state sm.State,
+	reactors ...p2p.Reactor,
 ) (p2p.DefaultNodeInfo, error) {
 	txIndexerStatus := "on"
 	if _, ok := txIndexer.(*null.TxIndex); ok {
@@ -991,12 +1496,23 @@ func makeNodeInfo(
 			TxIndex:    txIndexerStatus,
 			RPCAddress: config.RPC.ListenAddress,
 		},
+		Capabilities: p2p.ReactorCapabilities(reactors...),
 	}
 
 	if config.P2P.PexReactor {
 		nodeInfo.Channels = append(nodeInfo.Channels, pex.PexChannel)
 	}
 
+	// A seed only exchanges addresses.
+	if config.P2P.SeedMode {
+		nodeInfo.Channels = []byte{pex.PexChannel}
+		nodeInfo.Capabilities = nil
+	}
+
+	if config.P2P.Compression != cfg.P2PCompressionNone {
+		nodeInfo.Capabilities = append(nodeInfo.Capabilities, p2p.CapabilityCompression)
+	}
+
 	lAddr := config.P2P.ExternalAddress
 
 	if lAddr == "" {
@@ -1025,6 +1541,12 @@ func createPruner(
 	prunerOpts := []sm.PrunerOption{
 		sm.WithPrunerInterval(config.Storage.Pruning.Interval),
 		sm.WithPrunerMetrics(metrics),
+		sm.WithPrunerKeepRecent(sm.PrunerKeepRecent{
+			Blocks:        config.Storage.Pruning.KeepRecent.Blocks,
+			ABCIResponses: config.Storage.Pruning.KeepRecent.ABCIResponses,
+			TxIndex:       config.Storage.Pruning.KeepRecent.TxIndex,
+			BlockIndex:    config.Storage.Pruning.KeepRecent.BlockIndex,
+		}),
 	}
 
 	if config.Storage.Pruning.DataCompanion.Enabled {
@@ -1042,6 +1564,18 @@ func createPruner(
 	return sm.NewPruner(stateStore, blockStore, blockIndexer, txIndexer, logger, prunerOpts...), nil
 }
 
+func createStorageForecaster(config *cfg.Config, metrics *sm.Metrics, logger log.Logger) *sm.StorageForecaster {
+	if config.Storage.Forecast.Interval == 0 {
+		return nil
+	}
+	return sm.NewStorageForecaster(config.DBDir(), logger,
+		sm.WithStorageForecasterInterval(config.Storage.Forecast.Interval),
+		sm.WithStorageForecasterWindow(config.Storage.Forecast.Window),
+		sm.WithStorageForecasterWarnDays(config.Storage.Forecast.WarnDaysUntilFull),
+		sm.WithStorageForecasterMetrics(metrics),
+	)
+}
+
 // Set the initial application retain height to 0 to avoid the data companion
 // pruning blocks before the application indicates it is OK. We set this to 0
 // only if the retain height was not set before by the application.
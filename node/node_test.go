@@ -6,9 +6,11 @@ import (
 	"encoding/hex"
 
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
@@ -20,10 +22,12 @@ import (
 
 	"github.com/cometbft/cometbft/abci/example/kvstore"
 	cfg "github.com/cometbft/cometbft/config"
+	cs "github.com/cometbft/cometbft/consensus"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	"github.com/cometbft/cometbft/evidence"
 	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/libs/atrest"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
 	cmtos "github.com/cometbft/cometbft/libs/os"
@@ -83,6 +87,40 @@ func TestNodeStartStop(t *testing.T) {
 	}
 }
 
+// TestNodeGoLevelDBFeatures runs the storage features of the node with the
+// pure-Go goleveldb backend, which must support all of them.
+func TestNodeGoLevelDBFeatures(t *testing.T) {
+	config := test.ResetTestRoot("node_goleveldb_test")
+	defer os.RemoveAll(config.RootDir)
+	config.DBBackend = string(dbm.GoLevelDBBackend)
+	config.Storage.ReadReplica = true
+	config.Storage.Forecast.Interval = 100 * time.Millisecond
+	config.Storage.Encryption.KeyEnv = "CMT_TEST_STORAGE_KEY"
+	config.Storage.Encryption.Stores = append(config.Storage.Encryption.Stores, cfg.EncryptedStoreState)
+	t.Setenv(config.Storage.Encryption.KeyEnv, strings.Repeat("ab", atrest.KeySize))
+	require.Empty(t, config.StorageParityWarnings())
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer func() {
+		require.NoError(t, n.Stop())
+	}()
+
+	require.Eventually(t, func() bool {
+		return n.BlockStore().Height() >= 2
+	}, 10*time.Second, 100*time.Millisecond)
+
+	info, err := n.BackupStores(t.TempDir())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, info.BlockStoreHeight, int64(2))
+
+	require.Eventually(t, func() bool {
+		forecast, ok := n.storageForecaster.Forecast()
+		return ok && forecast.Stores["blockstore"].Size > 0
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
 func TestSplitAndTrimEmpty(t *testing.T) {
 	testCases := []struct {
 		s        string
@@ -263,6 +301,36 @@ func testFreeAddr(t *testing.T) string {
 	return fmt.Sprintf("127.0.0.1:%d", ln.Addr().(*net.TCPAddr).Port)
 }
 
+func TestReplayStatusRPC(t *testing.T) {
+	config := test.ResetTestRoot("node_replay_status_rpc_test")
+	defer os.RemoveAll(config.RootDir)
+	addr := testFreeAddr(t)
+	config.RPC.ListenAddress = "tcp://" + addr
+
+	progress := cs.NewReplayProgress(cs.NopMetrics())
+	stop, err := startReplayStatusRPC(config, progress, log.TestingLogger())
+	require.NoError(t, err)
+
+	// The replay status is served, but not the other endpoints.
+	resp, err := http.Get("http://" + addr + "/replay_status")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"running":false`)
+
+	resp, err = http.Get("http://" + addr + "/status")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Once stopped, the address is free for the RPC server.
+	stop()
+	ln, err := net.Listen("tcp", addr)
+	require.NoError(t, err)
+	ln.Close()
+}
+
 // create a proposal block using real and full
 // mempool and evidence pool and validate it.
 func TestCreateProposalBlock(t *testing.T) {
@@ -676,6 +744,86 @@ func TestNodeGenesisHashFlagMismatch(t *testing.T) {
 	require.False(t, genHashMismatch)
 }
 
+func TestGenesisDocProviderFromCheckpoint(t *testing.T) {
+	config := test.ResetTestRoot("node_genesis_doc_checkpoint")
+	defer os.RemoveAll(config.RootDir)
+
+	providerCalls := 0
+	provider := func() (ChecksummedGenesisDoc, error) {
+		providerCalls++
+		return DefaultGenesisDocProviderFunc(config)()
+	}
+	stateDB := dbm.NewMemDB()
+	checkpointProvider := genesisDocProviderFromCheckpoint(stateDB, config.GenesisFile(), provider)
+
+	// The genesis file is parsed on the first start, and the checkpoint saved.
+	state, genDoc, err := LoadStateFromDBOrGenesisDocProvider(stateDB, checkpointProvider, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, providerCalls)
+	checkpoint, err := stateDB.Get(genesisDocCheckpointKey)
+	require.NoError(t, err)
+	require.NotEmpty(t, checkpoint)
+
+	// The checkpoint is not used until blocks have been committed.
+	_, _, err = LoadStateFromDBOrGenesisDocProvider(stateDB, checkpointProvider, "")
+	require.NoError(t, err)
+	require.Equal(t, 2, providerCalls)
+
+	state.LastBlockHeight = 1
+	state.LastValidators = state.Validators.Copy()
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
+	require.NoError(t, stateStore.Save(state))
+
+	_, restored, err := LoadStateFromDBOrGenesisDocProvider(stateDB, checkpointProvider, "")
+	require.NoError(t, err)
+	require.Equal(t, 2, providerCalls)
+	assert.Equal(t, genDoc.ChainID, restored.ChainID)
+	assert.Equal(t, genDoc.Validators, restored.Validators)
+	assert.Nil(t, restored.AppState)
+
+	// The genesis doc restored from the checkpoint is completed only if the
+	// application has to be initialized.
+	csGenDoc, err := checkpointProvider()
+	require.NoError(t, err)
+	require.True(t, csGenDoc.fromCheckpoint)
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(kvstore.NewInMemoryApplication()), proxy.NopMetrics())
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+	completed, err := completeGenesisDoc(context.Background(), proxyApp, csGenDoc, provider)
+	require.NoError(t, err)
+	require.False(t, completed.fromCheckpoint)
+	assert.Equal(t, genDoc, completed.GenesisDoc)
+	require.Equal(t, 3, providerCalls)
+
+	// A modified genesis file invalidates the checkpoint.
+	genDoc.ChainID = "different-chain-id"
+	require.NoError(t, genDoc.SaveAs(config.GenesisFile()))
+	_, _, err = LoadStateFromDBOrGenesisDocProvider(stateDB, checkpointProvider, "")
+	require.Error(t, err)
+	require.Equal(t, 4, providerCalls)
+}
+
+func TestNodeFeatures(t *testing.T) {
+	config := cfg.DefaultConfig()
+	config.Consensus.OptimisticExecution = true
+	nodeInfo := p2p.DefaultNodeInfo{Capabilities: []string{
+		mempl.CapabilityPullGossip,
+		cs.CapabilityCommitCertificates,
+		"mempool/future-capability",
+	}}
+
+	features := nodeFeatures(config, nodeInfo)
+	assert.True(t, features["optimistic_execution"])
+	assert.False(t, features["direct_validator_push"])
+	assert.True(t, features["mempool_tx_have_want"])
+	assert.True(t, features["consensus_commit_certificates"])
+	assert.True(t, features["mempool_future_capability"])
+	// The known capabilities are listed even if not advertised.
+	enabled, ok := features["p2p_compression"]
+	assert.True(t, ok)
+	assert.False(t, enabled)
+}
+
 func state(nVals int, height int64) (sm.State, dbm.DB, []types.PrivValidator) {
 	privVals := make([]types.PrivValidator, nVals)
 	vals := make([]types.GenesisValidator, nVals)
@@ -3,15 +3,22 @@ package node
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	_ "net/http/pprof" //nolint: gosec // securely exposed on separate, optional port
 
+	"google.golang.org/grpc/credentials"
+
 	dbm "github.com/cometbft/cometbft-db"
 
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -19,21 +26,27 @@ import (
 	cfg "github.com/cometbft/cometbft/config"
 	cs "github.com/cometbft/cometbft/consensus"
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	"github.com/cometbft/cometbft/evidence"
 	"github.com/cometbft/cometbft/statesync"
 
+	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
+	cmtnet "github.com/cometbft/cometbft/libs/net"
 	"github.com/cometbft/cometbft/light"
 	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/nat"
 	"github.com/cometbft/cometbft/p2p/pex"
 	"github.com/cometbft/cometbft/privval"
 	"github.com/cometbft/cometbft/proxy"
 	sm "github.com/cometbft/cometbft/state"
 	"github.com/cometbft/cometbft/state/indexer"
 	"github.com/cometbft/cometbft/state/indexer/block"
+	_ "github.com/cometbft/cometbft/state/psql" // register the psql state store
 	"github.com/cometbft/cometbft/state/txindex"
+	"github.com/cometbft/cometbft/state/txindex/null"
 	"github.com/cometbft/cometbft/store"
 	"github.com/cometbft/cometbft/types"
 	"github.com/cometbft/cometbft/version"
@@ -48,6 +61,10 @@ const readHeaderTimeout = 10 * time.Second
 type ChecksummedGenesisDoc struct {
 	GenesisDoc     *types.GenesisDoc
 	Sha256Checksum []byte
+
+	// fromCheckpoint is true if GenesisDoc was restored from the genesis
+	// checkpoint in the state database, in which case it lacks the app state.
+	fromCheckpoint bool
 }
 
 // GenesisDocProvider returns a GenesisDoc together with its SHA256 checksum.
@@ -57,21 +74,22 @@ type GenesisDocProvider func() (ChecksummedGenesisDoc, error)
 
 // DefaultGenesisDocProviderFunc returns a GenesisDocProvider that loads
 // the GenesisDoc from the config.GenesisFile() on the filesystem.
+// The file is streamed through the JSON parser and the checksum computation,
+// so that it is never fully buffered in memory: only its app state is, once.
 func DefaultGenesisDocProviderFunc(config *cfg.Config) GenesisDocProvider {
 	return func() (ChecksummedGenesisDoc, error) {
-		// FIXME: find a way to stream the file incrementally,
-		// for the JSON	parser and the checksum computation.
-		// https://github.com/cometbft/cometbft/issues/1302
-		jsonBlob, err := os.ReadFile(config.GenesisFile())
+		f, err := os.Open(config.GenesisFile())
 		if err != nil {
 			return ChecksummedGenesisDoc{}, fmt.Errorf("couldn't read GenesisDoc file: %w", err)
 		}
-		incomingChecksum := tmhash.Sum(jsonBlob)
-		genDoc, err := types.GenesisDocFromJSON(jsonBlob)
+		defer f.Close()
+
+		hasher := tmhash.New()
+		genDoc, err := types.GenesisDocFromReader(io.TeeReader(f, hasher))
 		if err != nil {
 			return ChecksummedGenesisDoc{}, err
 		}
-		return ChecksummedGenesisDoc{GenesisDoc: genDoc, Sha256Checksum: incomingChecksum}, nil
+		return ChecksummedGenesisDoc{GenesisDoc: genDoc, Sha256Checksum: hasher.Sum(nil)}, nil
 	}
 }
 
@@ -87,8 +105,24 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 		return nil, fmt.Errorf("failed to load or gen node key %s: %w", config.NodeKeyFile(), err)
 	}
 
+	var pvOptions []privval.FilePVOption
+	if config.Storage.Encryption.Encrypts(cfg.EncryptedStorePrivValState) {
+		cipher, err := config.Storage.Encryption.Cipher()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the privval state encryption key: %w", err)
+		}
+		pvOptions = append(pvOptions, privval.WithStateCipher(cipher))
+	}
+	if config.PrivValidatorWatermarkStore != "" {
+		store, err := privval.NewHTTPWatermarkStore(config.PrivValidatorWatermarkStore)
+		if err != nil {
+			return nil, err
+		}
+		pvOptions = append(pvOptions, privval.WithWatermarkStore(store))
+	}
+
 	return NewNode(context.Background(), config,
-		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), pvOptions...),
 		nodeKey,
 		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
 		DefaultGenesisDocProviderFunc(config),
@@ -140,6 +174,46 @@ func initDBs(config *cfg.Config, dbProvider cfg.DBProvider) (blockStore *store.B
 	return
 }
 
+// createStateStore creates the state store with the backend set in the
+// configuration. The default backend keeps the state in stateDB.
+func createStateStore(config *cfg.Config, stateDB dbm.DB) (sm.Store, error) {
+	backend, options := stateStoreBackend(config)
+	return sm.NewStoreFromBackend(backend, stateDB, options)
+}
+
+func stateStoreBackend(config *cfg.Config) (string, sm.StoreOptions) {
+	backend := config.Storage.StateStore
+	if backend == "" {
+		backend = sm.DefaultStoreBackend
+	}
+	return backend, sm.StoreOptions{
+		DiscardABCIResponses:  config.Storage.DiscardABCIResponses,
+		VerifyChecksums:       config.Storage.VerifyStateChecksums,
+		CompressABCIResponses: config.Storage.Compression.Compresses(cfg.CompressedStoreABCIResponses),
+		BackendConn:           config.Storage.StateStorePsqlConn,
+	}
+}
+
+// createReadReplicas creates the stores serving the RPC and gRPC queries:
+// read replicas of the block store and state store if enabled in the
+// configuration, or the stores themselves otherwise.
+func createReadReplicas(
+	config *cfg.Config,
+	blockStore *store.BlockStore,
+	stateStore sm.Store,
+	stateDB dbm.DB,
+) (*store.BlockStore, sm.Store, error) {
+	if !config.Storage.ReadReplica {
+		return blockStore, stateStore, nil
+	}
+	backend, options := stateStoreBackend(config)
+	stateStoreReplica, err := sm.NewReadReplicaFromBackend(backend, stateDB, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create state store read replica: %w", err)
+	}
+	return blockStore.ReadReplica(), stateStoreReplica, nil
+}
+
 func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.Logger, metrics *proxy.Metrics) (proxy.AppConns, error) {
 	proxyApp := proxy.NewAppConns(clientCreator, metrics)
 	proxyApp.SetLogger(logger.With("module", "proxy"))
@@ -149,8 +223,11 @@ func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.L
 	return proxyApp, nil
 }
 
-func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
-	eventBus := types.NewEventBus()
+func createAndStartEventBus(config *cfg.Config, smMetrics *sm.Metrics, logger log.Logger) (*types.EventBus, error) {
+	eventBus := types.NewEventBus(
+		types.WithMaxEventSize(config.TxIndex.MaxEventSize, types.OversizedEventPolicy(config.TxIndex.OversizedEventPolicy)),
+		types.WithOversizedEventsCounter(smMetrics.OversizedTxEvents),
+	)
 	eventBus.SetLogger(logger.With("module", "events"))
 	if err := eventBus.Start(); err != nil {
 		return nil, err
@@ -163,6 +240,9 @@ func createAndStartIndexerService(
 	chainID string,
 	dbProvider cfg.DBProvider,
 	eventBus *types.EventBus,
+	stateDB dbm.DB,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
 	logger log.Logger,
 ) (*txindex.IndexerService, txindex.TxIndexer, indexer.BlockIndexer, error) {
 	var (
@@ -176,7 +256,13 @@ func createAndStartIndexerService(
 
 	txIndexer.SetLogger(logger.With("module", "txindex"))
 	blockIndexer.SetLogger(logger.With("module", "txindex"))
-	indexerService := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false)
+	var options []txindex.IndexerServiceOption
+	if _, ok := txIndexer.(*null.TxIndex); !ok {
+		// Keep the intents in the state database, for all indexer backends.
+		options = append(options, txindex.WithIntentLog(
+			txindex.NewIntentLog(stateDB), indexerResultsLoader(blockStore, stateStore)))
+	}
+	indexerService := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false, options...)
 	indexerService.SetLogger(logger.With("module", "txindex"))
 
 	if err := indexerService.Start(); err != nil {
@@ -186,6 +272,22 @@ func createAndStartIndexerService(
 	return indexerService, txIndexer, blockIndexer, nil
 }
 
+// indexerResultsLoader returns the loader of the blocks and results to
+// re-index the heights with a pending index intent.
+func indexerResultsLoader(blockStore sm.BlockStore, stateStore sm.Store) txindex.BlockResultsLoader {
+	return func(height int64) (types.Txs, *abci.ResponseFinalizeBlock, error) {
+		block := blockStore.LoadBlock(height)
+		if block == nil {
+			return nil, nil, fmt.Errorf("block at height %d not found in the block store", height)
+		}
+		resp, err := stateStore.LoadFinalizeBlockResponse(height)
+		if err != nil {
+			return nil, nil, err
+		}
+		return block.Txs, resp, nil
+	}
+}
+
 func doHandshake(
 	ctx context.Context,
 	stateStore sm.Store,
@@ -194,11 +296,13 @@ func doHandshake(
 	genDoc *types.GenesisDoc,
 	eventBus types.BlockEventPublisher,
 	proxyApp proxy.AppConns,
+	replayProgress *cs.ReplayProgress,
 	consensusLogger log.Logger,
 ) error {
 	handshaker := cs.NewHandshaker(stateStore, state, blockStore, genDoc)
 	handshaker.SetLogger(consensusLogger)
 	handshaker.SetEventBus(eventBus)
+	handshaker.SetReplayProgress(replayProgress)
 	if err := handshaker.Handshake(ctx, proxyApp); err != nil {
 		return fmt.Errorf("error during handshake: %v", err)
 	}
@@ -245,6 +349,7 @@ func createMempoolAndMempoolReactor(
 	proxyApp proxy.AppConns,
 	state sm.State,
 	waitSync bool,
+	eventBus *types.EventBus,
 	memplMetrics *mempl.Metrics,
 	logger log.Logger,
 ) (mempl.Mempool, *mempl.Reactor) {
@@ -256,6 +361,18 @@ func createMempoolAndMempoolReactor(
 		mempl.WithMetrics(memplMetrics),
 		mempl.WithPreCheck(sm.TxPreCheck(state)),
 		mempl.WithPostCheck(sm.TxPostCheck(state)),
+		mempl.WithEvictionCallback(func(tx types.Tx, reason mempl.EvictionReason) {
+			err := eventBus.PublishEventEvictedTx(types.EventDataEvictedTx{Tx: tx, Reason: string(reason)})
+			if err != nil {
+				logger.Error("failed publishing evicted tx event", "tx", tx.Hash(), "err", err)
+			}
+		}),
+		mempl.WithAcceptedCallback(func(tx types.Tx, res *abci.ResponseCheckTx) {
+			err := eventBus.PublishEventNewMempoolTx(types.EventDataNewMempoolTx{Tx: tx, Result: *res})
+			if err != nil {
+				logger.Error("failed publishing new mempool tx event", "tx", tx.Hash(), "err", err)
+			}
+		}),
 	)
 
 	mp.SetLogger(logger)
@@ -324,7 +441,24 @@ func createConsensusReactor(config *cfg.Config,
 	eventBus *types.EventBus,
 	consensusLogger log.Logger,
 	offlineStateSyncHeight int64,
+	validatorPeers []cs.ValidatorPeer,
+	voteRecorder *cs.VoteRecorder,
+	traceRecorder *cs.TraceRecorder,
+	replayProgress *cs.ReplayProgress,
+	validatorsPerf *cs.ValidatorsPerformance,
 ) (*cs.Reactor, *cs.State) {
+	options := []cs.StateOption{
+		cs.StateMetrics(csMetrics),
+		cs.OfflineStateSyncHeight(offlineStateSyncHeight),
+		cs.StateReplayProgress(replayProgress),
+		cs.StateValidatorsPerformance(validatorsPerf),
+	}
+	if voteRecorder != nil {
+		options = append(options, cs.StateVoteRecorder(voteRecorder))
+	}
+	if traceRecorder != nil {
+		options = append(options, cs.StateTraceRecorder(traceRecorder))
+	}
 	consensusState := cs.NewState(
 		config.Consensus,
 		state.Copy(),
@@ -332,14 +466,20 @@ func createConsensusReactor(config *cfg.Config,
 		blockStore,
 		mempool,
 		evidencePool,
-		cs.StateMetrics(csMetrics),
-		cs.OfflineStateSyncHeight(offlineStateSyncHeight),
+		options...,
 	)
 	consensusState.SetLogger(consensusLogger)
 	if privValidator != nil {
 		consensusState.SetPrivValidator(privValidator)
 	}
-	consensusReactor := cs.NewReactor(consensusState, waitSync, cs.ReactorMetrics(csMetrics))
+	reactorOptions := []cs.ReactorOption{
+		cs.ReactorMetrics(csMetrics),
+		cs.ReactorValidatorPeers(validatorPeers),
+	}
+	if txSource, ok := mempool.(cs.TxSource); ok {
+		reactorOptions = append(reactorOptions, cs.ReactorTxSource(txSource))
+	}
+	consensusReactor := cs.NewReactor(consensusState, waitSync, reactorOptions...)
 	consensusReactor.SetLogger(consensusLogger)
 	// services which will be publishing and/or subscribing for messages (events)
 	// consensusReactor will set it on consensusState and blockExecutor
@@ -347,6 +487,92 @@ func createConsensusReactor(config *cfg.Config,
 	return consensusReactor, consensusState
 }
 
+// createVoteRecorder returns the recorder persisting the received votes, or
+// nil if vote recording is disabled.
+func createVoteRecorder(config *cfg.Config, dbProvider cfg.DBProvider) (*cs.VoteRecorder, error) {
+	if config.Consensus.VoteRecordHeights == 0 {
+		return nil, nil
+	}
+	voteDB, err := dbProvider(&cfg.DBContext{ID: "votes", Config: config})
+	if err != nil {
+		return nil, err
+	}
+	return cs.NewVoteRecorder(voteDB, config.Consensus.VoteRecordHeights), nil
+}
+
+// createTraceRecorder returns the recorder tracing the consensus, or nil if
+// consensus tracing is disabled.
+func createTraceRecorder(config *cfg.Config) (*cs.TraceRecorder, error) {
+	traceFile := config.Consensus.TraceFile()
+	if traceFile == "" {
+		return nil, nil
+	}
+	return cs.OpenTraceRecorder(traceFile)
+}
+
+// natDiscoveryTimeout bounds the discovery of the NAT gateway and the mapping
+// of the p2p port on startup.
+const natDiscoveryTimeout = 5 * time.Second
+
+// createPortMapper maps the p2p port on the NAT gateway of the local network,
+// and sets the mapped address as the external address of the node. It returns
+// nil if the port mapping is disabled or fails, as the node can still dial its
+// peers then.
+func createPortMapper(config *cfg.Config, logger log.Logger) *nat.PortMapper {
+	if config.P2P.NAT == cfg.P2PNATNone {
+		return nil
+	}
+	if config.P2P.ExternalAddress != "" {
+		logger.Info("Skipping the port mapping, as the external address is set",
+			"externalAddress", config.P2P.ExternalAddress)
+		return nil
+	}
+
+	_, laddr := cmtnet.ProtocolAndAddress(config.P2P.ListenAddress)
+	_, portStr, err := net.SplitHostPort(laddr)
+	if err != nil {
+		logger.Error("Failed to map the p2p port", "laddr", config.P2P.ListenAddress, "err", err)
+		return nil
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		logger.Error("Failed to map the p2p port", "laddr", config.P2P.ListenAddress, "err", err)
+		return nil
+	}
+	protocol := nat.TCP
+	if config.P2P.Transport == cfg.P2PTransportQUIC {
+		protocol = nat.UDP
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), natDiscoveryTimeout)
+	defer cancel()
+	gateway, err := nat.Discover(ctx, config.P2P.NAT)
+	if err != nil {
+		logger.Error("Failed to map the p2p port", "err", err)
+		return nil
+	}
+	portMapper := nat.NewPortMapper(gateway, protocol, port, logger)
+	extAddr, err := portMapper.Map(ctx)
+	if err != nil {
+		logger.Error("Failed to map the p2p port", "nat", gateway, "err", err)
+		return nil
+	}
+
+	logger.Info("Mapped the p2p port on the NAT gateway", "nat", gateway, "externalAddress", extAddr)
+	config.P2P.ExternalAddress = extAddr
+	return portMapper
+}
+
+// validatorPeerAddresses returns the node addresses and IDs of the given
+// validator peers.
+func validatorPeerAddresses(peers []cs.ValidatorPeer) (addrs []string, ids []string) {
+	for _, peer := range peers {
+		addrs = append(addrs, peer.NodeAddress.String())
+		ids = append(ids, string(peer.NodeAddress.ID))
+	}
+	return addrs, ids
+}
+
 func createTransport(
 	config *cfg.Config,
 	nodeInfo p2p.NodeInfo,
@@ -412,14 +638,40 @@ func createTransport(
 	// Limit the number of incoming connections.
 	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
 	p2p.MultiplexTransportMaxIncomingConnections(max)(transport)
+	p2p.MultiplexTransportMaxIncomingConnectionsPerIP(config.P2P.MaxInboundConnsPerIP)(transport)
+	p2p.MultiplexTransportMaxIncomingConnectionsPerSubnet(config.P2P.MaxInboundConnsPerSubnet)(transport)
+
+	if config.P2P.Transport == cfg.P2PTransportQUIC {
+		p2p.MultiplexTransportQUIC()(transport)
+	}
 
 	return transport, peerFilters
 }
 
+// createPeerAllowlist loads the peer allowlist, or returns nil if it is not
+// set.
+func createPeerAllowlist(config *cfg.Config, p2pLogger log.Logger) (*p2p.PeerAllowlist, error) {
+	if config.P2P.PeerAllowlist == "" {
+		return nil, nil
+	}
+	keyBytes, err := hex.DecodeString(config.P2P.PeerAllowlistKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p2p.peer_allowlist_key: %w", err)
+	}
+	allowlist, err := p2p.NewPeerAllowlist(config.P2P.PeerAllowlistFile(), ed25519.PubKey(keyBytes))
+	if err != nil {
+		return nil, err
+	}
+	allowlist.SetLogger(p2pLogger.With("allowlist", config.P2P.PeerAllowlistFile()))
+	p2pLogger.Info("Only connecting to the peers of the peer allowlist", "peers", allowlist.Size())
+	return allowlist, nil
+}
+
 func createSwitch(config *cfg.Config,
 	transport p2p.Transport,
 	p2pMetrics *p2p.Metrics,
 	peerFilters []p2p.PeerFilterFunc,
+	peerAllowlist *p2p.PeerAllowlist,
 	mempoolReactor p2p.Reactor,
 	bcReactor p2p.Reactor,
 	stateSyncReactor *statesync.Reactor,
@@ -429,18 +681,24 @@ func createSwitch(config *cfg.Config,
 	nodeKey *p2p.NodeKey,
 	p2pLogger log.Logger,
 ) *p2p.Switch {
-	sw := p2p.NewSwitch(
-		config.P2P,
-		transport,
+	options := []p2p.SwitchOption{
 		p2p.WithMetrics(p2pMetrics),
 		p2p.SwitchPeerFilters(peerFilters...),
-	)
+	}
+	if peerAllowlist != nil {
+		options = append(options, p2p.SwitchPeerAllowlist(peerAllowlist))
+	}
+	sw := p2p.NewSwitch(config.P2P, transport, options...)
 	sw.SetLogger(p2pLogger)
-	sw.AddReactor("MEMPOOL", mempoolReactor)
-	sw.AddReactor("BLOCKSYNC", bcReactor)
-	sw.AddReactor("CONSENSUS", consensusReactor)
-	sw.AddReactor("EVIDENCE", evidenceReactor)
-	sw.AddReactor("STATESYNC", stateSyncReactor)
+	// A seed only runs the PEX reactor, added later, so the other reactors
+	// are never started: it neither syncs nor commits blocks.
+	if !config.P2P.SeedMode {
+		sw.AddReactor("MEMPOOL", mempoolReactor)
+		sw.AddReactor("BLOCKSYNC", bcReactor)
+		sw.AddReactor("CONSENSUS", consensusReactor)
+		sw.AddReactor("EVIDENCE", evidenceReactor)
+		sw.AddReactor("STATESYNC", stateSyncReactor)
+	}
 
 	sw.SetNodeInfo(nodeInfo)
 	sw.SetNodeKey(nodeKey)
@@ -449,55 +707,119 @@ func createSwitch(config *cfg.Config,
 	return sw
 }
 
-func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
+// createAddrBookAndSetOnSwitch returns the address book, and its database if
+// it is saved to the "addrbook" database rather than to its file.
+func createAddrBookAndSetOnSwitch(config *cfg.Config, dbProvider cfg.DBProvider, sw *p2p.Switch,
 	p2pLogger log.Logger, nodeKey *p2p.NodeKey,
-) (pex.AddrBook, error) {
-	addrBook := pex.NewAddrBook(config.P2P.AddrBookFile(), config.P2P.AddrBookStrict)
-	addrBook.SetLogger(p2pLogger.With("book", config.P2P.AddrBookFile()))
-
+) (pex.AddrBook, dbm.DB, error) {
 	// Add ourselves to addrbook to prevent dialing ourselves
+	var ourAddrs []*p2p.NetAddress
 	if config.P2P.ExternalAddress != "" {
 		addr, err := p2p.NewNetAddressString(p2p.IDAddressString(nodeKey.ID(), config.P2P.ExternalAddress))
 		if err != nil {
-			return nil, fmt.Errorf("p2p.external_address is incorrect: %w", err)
+			return nil, nil, fmt.Errorf("p2p.external_address is incorrect: %w", err)
 		}
-		addrBook.AddOurAddress(addr)
+		ourAddrs = append(ourAddrs, addr)
 	}
 	if config.P2P.ListenAddress != "" {
 		addr, err := p2p.NewNetAddressString(p2p.IDAddressString(nodeKey.ID(), config.P2P.ListenAddress))
 		if err != nil {
-			return nil, fmt.Errorf("p2p.laddr is incorrect: %w", err)
+			return nil, nil, fmt.Errorf("p2p.laddr is incorrect: %w", err)
 		}
+		ourAddrs = append(ourAddrs, addr)
+	}
+
+	var (
+		options    []pex.AddrBookOption
+		addrBookDB dbm.DB
+		book       = config.P2P.AddrBookFile()
+	)
+	switch config.P2P.AddrBookStore {
+	case cfg.P2PAddrBookStoreDB:
+		// The database is encrypted by the provider, if needed.
+		var err error
+		addrBookDB, err = dbProvider(&cfg.DBContext{ID: "addrbook", Config: config})
+		if err != nil {
+			return nil, nil, err
+		}
+		options = append(options, pex.WithStore(pex.NewDBAddrBookStore(addrBookDB)))
+		book = "addrbook database"
+	default:
+		if config.Storage.Encryption.Encrypts(cfg.EncryptedStoreAddrBook) {
+			cipher, err := config.Storage.Encryption.Cipher()
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to load the address book encryption key: %w", err)
+			}
+			options = append(options, pex.WithCipher(cipher))
+		}
+	}
+	addrBook := pex.NewAddrBook(config.P2P.AddrBookFile(), config.P2P.AddrBookStrict, options...)
+	addrBook.SetLogger(p2pLogger.With("book", book))
+	for _, addr := range ourAddrs {
 		addrBook.AddOurAddress(addr)
 	}
 
 	sw.SetAddrBook(addrBook)
 
-	return addrBook, nil
+	return addrBook, addrBookDB, nil
 }
 
 func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 	sw *p2p.Switch, logger log.Logger,
-) *pex.Reactor {
+) (*pex.Reactor, error) {
+	var asMap *pex.ASMap
+	if file := config.P2P.SeedASMapFile(); file != "" {
+		var err error
+		if asMap, err = pex.LoadASMap(file); err != nil {
+			return nil, fmt.Errorf("failed to load the AS map: %w", err)
+		}
+	}
+
 	// TODO persistent peers ? so we can have their DNS addrs saved
 	pexReactor := pex.NewReactor(addrBook,
 		&pex.ReactorConfig{
 			Seeds:    splitAndTrimEmpty(config.P2P.Seeds, ",", " "),
+			DNSSeeds: splitAndTrimEmpty(config.P2P.DNSSeeds, ",", " "),
 			SeedMode: config.P2P.SeedMode,
 			// See consensus/reactor.go: blocksToContributeToBecomeGoodPeer 10000
-			// blocks assuming 10s blocks ~ 28 hours.
+			// blocks assuming 10s blocks ~ 28 hours, the default.
 			// TODO (melekes): make it dynamic based on the actual block latencies
 			// from the live network.
 			// https://github.com/tendermint/tendermint/issues/3523
-			SeedDisconnectWaitPeriod:     28 * time.Hour,
+			SeedDisconnectWaitPeriod:     config.P2P.SeedDisconnectWaitPeriod,
 			PersistentPeersMaxDialPeriod: config.P2P.PersistentPeersMaxDialPeriod,
+			CrawlPeriod:                  config.P2P.SeedCrawlPeriod,
+			RecrawlPeriod:                config.P2P.SeedRecrawlPeriod,
+			MaxAddrsPerAS:                config.P2P.SeedMaxAddrsPerAS,
+			ASMap:                        asMap,
 		})
 	pexReactor.SetLogger(logger.With("module", "pex"))
 	sw.AddReactor("PEX", pexReactor)
-	return pexReactor
+	return pexReactor, nil
+}
+
+// blockTimeFunc returns the time of the blocks at each time of the local clock,
+// compressed by the timeout scale of the configuration.
+func blockTimeFunc(config *cfg.ConsensusConfig, genesisTime time.Time) func(time.Time) time.Time {
+	return func(t time.Time) time.Time {
+		return config.BlockTime(genesisTime, t)
+	}
 }
 
 // startStateSync starts an asynchronous state sync process, then switches to block sync mode.
+// createSnapshotSources returns the HTTP(S) and S3 snapshot sources of the state sync config.
+func createSnapshotSources(config *cfg.StateSyncConfig) ([]statesync.SnapshotSource, error) {
+	sources := make([]statesync.SnapshotSource, 0, len(config.SnapshotSources))
+	for _, rawURL := range config.SnapshotSources {
+		source, err := statesync.NewHTTPSnapshotSource(rawURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
 func startStateSync(
 	ssR *statesync.Reactor,
 	bcR blockSyncReactor,
@@ -506,6 +828,7 @@ func startStateSync(
 	stateStore sm.Store,
 	blockStore *store.BlockStore,
 	state sm.State,
+	blockTime func(time.Time) time.Time,
 ) error {
 	ssR.Logger.Info("Starting state sync")
 
@@ -520,14 +843,23 @@ func startStateSync(
 				Period: config.TrustPeriod,
 				Height: config.TrustHeight,
 				Hash:   config.TrustHashBytes(),
-			}, ssR.Logger.With("module", "light"))
+			}, ssR.Logger.With("module", "light"), statesync.StateProviderBlockTime(blockTime))
 		if err != nil {
 			return fmt.Errorf("failed to set up light client state provider: %w", err)
 		}
 	}
 
 	go func() {
-		state, commit, err := ssR.Sync(stateProvider, config.DiscoveryTime)
+		var (
+			state  sm.State
+			commit *types.Commit
+			err    error
+		)
+		if config.LocalSnapshot != "" {
+			state, commit, err = ssR.SyncLocal(stateProvider, config.LocalSnapshot)
+		} else {
+			state, commit, err = ssR.Sync(stateProvider, config.DiscoveryTime)
+		}
 		if err != nil {
 			ssR.Logger.Error("State sync failed", "err", err)
 			return
@@ -557,6 +889,114 @@ func startStateSync(
 var genesisDocKey = []byte("genesisDoc")
 var genesisDocHashKey = []byte("genesisDocHash")
 
+// healthCheckKey is written and deleted by the health checks of the database.
+var healthCheckKey = []byte("healthCheck")
+
+// genesisDocCheckpointKey stores the genesis doc, without its app state, so
+// that restarts of an already initialized node can skip parsing the genesis
+// file.
+var genesisDocCheckpointKey = []byte("genesisDocCheckpoint")
+
+// genesisDocProviderFromCheckpoint returns a GenesisDocProvider restoring the
+// genesis doc from the checkpoint in stateDB if the node has already committed
+// blocks and the checksum of genesisFile matches the stored genesis hash.
+// Otherwise, the genesis doc is loaded through provider.
+//
+// The restored genesis doc lacks the app state, which is only needed to
+// initialize the application; use completeGenesisDoc to load it on demand.
+func genesisDocProviderFromCheckpoint(
+	stateDB dbm.DB,
+	genesisFile string,
+	provider GenesisDocProvider,
+) GenesisDocProvider {
+	return func() (ChecksummedGenesisDoc, error) {
+		genDocHash, err := stateDB.Get(genesisDocHashKey)
+		if err != nil || len(genDocHash) == 0 {
+			return provider()
+		}
+		checkpoint, err := stateDB.Get(genesisDocCheckpointKey)
+		if err != nil || len(checkpoint) == 0 {
+			return provider()
+		}
+		state, err := sm.NewStore(stateDB, sm.StoreOptions{}).Load()
+		if err != nil || state.LastBlockHeight == 0 {
+			return provider()
+		}
+		checksum, err := genesisFileChecksum(genesisFile)
+		if err != nil || !bytes.Equal(checksum, genDocHash) {
+			return provider()
+		}
+
+		genDoc := &types.GenesisDoc{}
+		if err := cmtjson.Unmarshal(checkpoint, genDoc); err != nil {
+			return provider()
+		}
+		return ChecksummedGenesisDoc{GenesisDoc: genDoc, Sha256Checksum: checksum, fromCheckpoint: true}, nil
+	}
+}
+
+// genesisFileChecksum computes the SHA256 checksum of the genesis file
+// without reading it fully in memory.
+func genesisFileChecksum(genesisFile string) ([]byte, error) {
+	f, err := os.Open(genesisFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	hasher := tmhash.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return nil, err
+	}
+	return hasher.Sum(nil), nil
+}
+
+// saveGenesisDocCheckpoint stores the genesis doc, without its app state, in
+// stateDB.
+func saveGenesisDocCheckpoint(stateDB dbm.DB, genDoc *types.GenesisDoc) error {
+	checkpoint := *genDoc
+	checkpoint.AppState = nil
+	bz, err := cmtjson.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	return stateDB.SetSync(genesisDocCheckpointKey, bz)
+}
+
+// completeGenesisDoc returns the full genesis doc, loaded through provider, if
+// the application has not been initialized yet and the genesis doc was
+// restored from the genesis checkpoint. Otherwise, the genesis doc is returned
+// as is, still lacking its app state if restored from the checkpoint.
+func completeGenesisDoc(
+	ctx context.Context,
+	proxyApp proxy.AppConns,
+	csGenDoc ChecksummedGenesisDoc,
+	provider GenesisDocProvider,
+) (ChecksummedGenesisDoc, error) {
+	if !csGenDoc.fromCheckpoint {
+		return csGenDoc, nil
+	}
+	res, err := proxyApp.Query().Info(ctx, proxy.RequestInfo)
+	if err != nil {
+		return ChecksummedGenesisDoc{}, fmt.Errorf("error calling Info: %v", err)
+	}
+	if res.LastBlockHeight > 0 {
+		return csGenDoc, nil
+	}
+	return provider()
+}
+
+// optimisticExecutionSupported returns true if the application declares that
+// it supports the optimistic execution of the blocks, i.e. FinalizeBlock being
+// called again at a height before Commit.
+func optimisticExecutionSupported(ctx context.Context, proxyApp proxy.AppConns) (bool, error) {
+	res, err := proxyApp.Query().Info(ctx, proxy.RequestInfo)
+	if err != nil {
+		return false, fmt.Errorf("error calling Info: %v", err)
+	}
+	return res.OptimisticExecution, nil
+}
+
 // LoadStateFromDBOrGenesisDocProvider attempts to load the state from the
 // database, or creates one using the given genesisDocProvider. On success this also
 // returns the genesis doc loaded through the given provider.
@@ -565,50 +1005,70 @@ func LoadStateFromDBOrGenesisDocProvider(
 	genesisDocProvider GenesisDocProvider,
 	operatorGenesisHashHex string,
 ) (sm.State, *types.GenesisDoc, error) {
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	state, csGenDoc, err := loadStateFromStoreOrGenesisDocProvider(stateDB, stateStore, genesisDocProvider, operatorGenesisHashHex)
+	return state, csGenDoc.GenesisDoc, err
+}
+
+// loadStateFromStoreOrGenesisDocProvider is like
+// LoadStateFromDBOrGenesisDocProvider, loading the state from the given state
+// store, which may not keep the state in stateDB, and returning the genesis
+// doc as returned by the provider.
+func loadStateFromStoreOrGenesisDocProvider(
+	stateDB dbm.DB,
+	stateStore sm.Store,
+	genesisDocProvider GenesisDocProvider,
+	operatorGenesisHashHex string,
+) (sm.State, ChecksummedGenesisDoc, error) {
 	// Get genesis doc hash
 	genDocHash, err := stateDB.Get(genesisDocHashKey)
 	if err != nil {
-		return sm.State{}, nil, fmt.Errorf("error retrieving genesis doc hash: %w", err)
+		return sm.State{}, ChecksummedGenesisDoc{}, fmt.Errorf("error retrieving genesis doc hash: %w", err)
 	}
 	csGenDoc, err := genesisDocProvider()
 	if err != nil {
-		return sm.State{}, nil, err
+		return sm.State{}, ChecksummedGenesisDoc{}, err
 	}
 
 	if err = csGenDoc.GenesisDoc.ValidateAndComplete(); err != nil {
-		return sm.State{}, nil, fmt.Errorf("error in genesis doc: %w", err)
+		return sm.State{}, ChecksummedGenesisDoc{}, fmt.Errorf("error in genesis doc: %w", err)
 	}
 
 	// Validate that existing or recently saved genesis file hash matches optional --genesis_hash passed by operator
 	if operatorGenesisHashHex != "" {
 		decodedOperatorGenesisHash, err := hex.DecodeString(operatorGenesisHashHex)
 		if err != nil {
-			return sm.State{}, nil, fmt.Errorf("genesis hash provided by operator cannot be decoded")
+			return sm.State{}, ChecksummedGenesisDoc{}, fmt.Errorf("genesis hash provided by operator cannot be decoded")
 		}
 		if !bytes.Equal(csGenDoc.Sha256Checksum, decodedOperatorGenesisHash) {
-			return sm.State{}, nil, fmt.Errorf("genesis doc hash in db does not match passed --genesis_hash value")
+			return sm.State{}, ChecksummedGenesisDoc{}, fmt.Errorf("genesis doc hash in db does not match passed --genesis_hash value")
 		}
 	}
 
 	if len(genDocHash) == 0 {
 		// Save the genDoc hash in the store if it doesn't already exist for future verification
 		if err = stateDB.SetSync(genesisDocHashKey, csGenDoc.Sha256Checksum); err != nil {
-			return sm.State{}, nil, fmt.Errorf("failed to save genesis doc hash to db: %w", err)
+			return sm.State{}, ChecksummedGenesisDoc{}, fmt.Errorf("failed to save genesis doc hash to db: %w", err)
 		}
 	} else {
 		if !bytes.Equal(genDocHash, csGenDoc.Sha256Checksum) {
-			return sm.State{}, nil, fmt.Errorf("genesis doc hash in db does not match loaded genesis doc")
+			return sm.State{}, ChecksummedGenesisDoc{}, fmt.Errorf("genesis doc hash in db does not match loaded genesis doc")
+		}
+	}
+
+	if !csGenDoc.fromCheckpoint {
+		if err = saveGenesisDocCheckpoint(stateDB, csGenDoc.GenesisDoc); err != nil {
+			return sm.State{}, ChecksummedGenesisDoc{}, fmt.Errorf("failed to save genesis doc checkpoint to db: %w", err)
 		}
 	}
 
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
 	state, err := stateStore.LoadFromDBOrGenesisDoc(csGenDoc.GenesisDoc)
 	if err != nil {
-		return sm.State{}, nil, err
+		return sm.State{}, ChecksummedGenesisDoc{}, err
 	}
-	return state, csGenDoc.GenesisDoc, nil
+	return state, csGenDoc, nil
 }
 
 func createAndStartPrivValidatorSocketClient(
@@ -641,6 +1101,93 @@ func createAndStartPrivValidatorSocketClient(
 	return pvscWithRetries, nil
 }
 
+// nodeFeatures returns the optional features of the node and whether they are
+// enabled: those enabled in the given configuration, and the capabilities
+// advertised by the reactors of the node, in its node info, named after them
+// (e.g. "consensus/commit-certificates" as consensus_commit_certificates).
+func nodeFeatures(config *cfg.Config, nodeInfo p2p.NodeInfo) map[string]bool {
+	features := map[string]bool{
+		"tx_index":                           config.TxIndex.Indexer != "null",
+		"discard_abci_responses":             config.Storage.DiscardABCIResponses,
+		"data_companion_pruning":             config.Storage.Pruning.DataCompanion.Enabled,
+		"storage_forecast":                   config.Storage.Forecast.Interval > 0,
+		"abci_responses_compression":         config.Storage.Compression.Compresses(cfg.CompressedStoreABCIResponses),
+		"tx_index_compression":               config.Storage.Compression.Compresses(cfg.CompressedStoreTxIndex),
+		"vote_recording":                     config.Consensus.VoteRecordHeights > 0,
+		"optimistic_execution":               config.Consensus.OptimisticExecution,
+		"adaptive_timeouts":                  config.Consensus.AdaptiveTimeouts,
+		"direct_validator_push":              config.Consensus.DirectValidatorPeers != "",
+		"wal_compression":                    config.Consensus.WalCompression != cfg.WALCompressionNone,
+		"statesync":                          config.StateSync.Enable,
+		"pex":                                config.P2P.PexReactor,
+		"mempool_recheck":                    config.Mempool.Recheck,
+		"mempool_broadcast":                  config.Mempool.Broadcast,
+		"mempool_experimental_encrypted_txs": config.Mempool.ExperimentalEncryptedTxs,
+		"rpc_compress_responses":             config.RPC.CompressResponses,
+		"rpc_rate_limit":                     config.RPC.RateLimit > 0,
+		"grpc":                               config.GRPC.ListenAddress != "",
+		"grpc_version_service":               config.GRPC.VersionService.Enabled,
+		"grpc_block_service":                 config.GRPC.BlockService.Enabled,
+		"grpc_block_results_service":         config.GRPC.BlockResultsService.Enabled,
+		"grpc_mempool_service":               config.GRPC.MempoolService.Enabled,
+		"grpc_events_service":                config.GRPC.EventsService.Enabled,
+		"grpc_query_service":                 config.GRPC.QueryService.Enabled,
+		"grpc_privileged":                    config.GRPC.Privileged.ListenAddress != "",
+		"grpc_privileged_pruning_service":    config.GRPC.Privileged.PruningService.Enabled,
+		"grpc_privileged_admin_service":      config.GRPC.Privileged.AdminService.Enabled,
+		"prometheus":                         config.Instrumentation.Prometheus,
+	}
+
+	// The capabilities known to this version are listed even if disabled.
+	for _, capability := range []string{
+		mempl.CapabilityPullGossip,
+		cs.CapabilityErasureCodedBlockParts,
+		cs.CapabilityCompactBlocks,
+		cs.CapabilityCommitCertificates,
+		p2p.CapabilityCompression,
+	} {
+		features[capabilityFeature(capability)] = false
+	}
+	if info, ok := nodeInfo.(p2p.DefaultNodeInfo); ok {
+		for _, capability := range info.Capabilities {
+			features[capabilityFeature(capability)] = true
+		}
+	}
+	return features
+}
+
+// capabilityFeature returns the name of the feature of a capability.
+func capabilityFeature(capability string) string {
+	return strings.NewReplacer("/", "_", "-", "_").Replace(capability)
+}
+
+// privilegedGRPCCredentials returns the TLS credentials of the privileged gRPC
+// server, which require clients to present a certificate signed by one of the
+// configured certificate authorities if client authentication is enabled.
+func privilegedGRPCCredentials(config *cfg.GRPCPrivilegedConfig) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(config.CertFile(), config.KeyFile())
+	if err != nil {
+		return nil, fmt.Errorf("loading privileged gRPC server certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if config.IsClientAuthEnabled() {
+		caPEM, err := os.ReadFile(config.ClientCAFile())
+		if err != nil {
+			return nil, fmt.Errorf("reading privileged gRPC client CA file: %w", err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("no certificates found in privileged gRPC client CA file")
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
 // splitAndTrimEmpty slices s into all subslices separated by sep and returns a
 // slice of the string s with all leading and trailing Unicode code points
 // contained in cutset removed. If sep is empty, SplitAndTrim splits after each
@@ -0,0 +1,67 @@
+package p2p
+
+import (
+	"sort"
+
+	"github.com/cometbft/cometbft/p2p/conn"
+)
+
+// CapabilityCompression is advertised by the nodes with compression. Two such
+// nodes send each other their messages prefixed with their compression, and
+// compress the large ones (see conn.MConnConfig.Compression).
+const CapabilityCompression = "p2p/compression"
+
+// CapabilityReactor is implemented by the reactors with optional wire
+// features, which must only be used with the peers supporting them.
+//
+// The capabilities of the reactors are advertised to the peers during the
+// handshake, in the node info. Reactors should then check PeerHasCapability
+// before using a feature with a peer, and fall back to the messages all the
+// peers understand otherwise. This allows rolling out new wire features
+// incrementally across a network of nodes running different versions.
+type CapabilityReactor interface {
+	Reactor
+
+	// Capabilities returns the names of the optional wire features the reactor
+	// supports, e.g. "mempool/tx-have-want".
+	Capabilities() []string
+}
+
+// ReactorCapabilities returns the sorted, deduplicated capabilities of the
+// given reactors implementing CapabilityReactor.
+func ReactorCapabilities(reactors ...Reactor) []string {
+	set := make(map[string]struct{})
+	for _, reactor := range reactors {
+		if r, ok := reactor.(CapabilityReactor); ok {
+			for _, c := range r.Capabilities() {
+				set[c] = struct{}{}
+			}
+		}
+	}
+
+	capabilities := make([]string, 0, len(set))
+	for c := range set {
+		capabilities = append(capabilities, c)
+	}
+	sort.Strings(capabilities)
+	return capabilities
+}
+
+// connConfigWithPeer returns the connection config for a peer, with the
+// compression disabled unless both nodes advertise CapabilityCompression.
+func connConfigWithPeer(mConfig conn.MConnConfig, ourInfo, peerInfo NodeInfo) conn.MConnConfig {
+	ours, ok1 := ourInfo.(DefaultNodeInfo)
+	theirs, ok2 := peerInfo.(DefaultNodeInfo)
+	if !ok1 || !ok2 || !ours.HasCapability(CapabilityCompression) || !theirs.HasCapability(CapabilityCompression) {
+		mConfig.Compression = ""
+	}
+	return mConfig
+}
+
+// PeerHasCapability returns true if the peer advertised the given capability
+// during the handshake. Peers running versions that predate capabilities are
+// treated as supporting none.
+func PeerHasCapability(peer Peer, capability string) bool {
+	info, ok := peer.NodeInfo().(DefaultNodeInfo)
+	return ok && info.HasCapability(capability)
+}
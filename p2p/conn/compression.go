@@ -0,0 +1,107 @@
+package conn
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/cometbft/cometbft/config"
+)
+
+// minCompressedMsgSize is the size from which the messages are compressed.
+// The smaller messages, e.g. votes, hardly shrink.
+const minCompressedMsgSize = 1024
+
+// On the connections with compression, each message starts with a byte
+// telling its compression. The messages which do not shrink are sent
+// uncompressed.
+const (
+	compressionTagNone byte = iota
+	compressionTagSnappy
+	compressionTagZstd
+
+	compressionTagSize = 1
+)
+
+// zstdMsgEncoder and zstdMsgDecoder compress and decompress the messages
+// with zstd. They're safe for concurrent use.
+var (
+	zstdMsgEncoder = sync.OnceValues(func() (*zstd.Encoder, error) {
+		return zstd.NewWriter(nil)
+	})
+	zstdMsgDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+		return zstd.NewReader(nil, zstd.WithDecodeAllCapLimit(true))
+	})
+)
+
+// compressMsg returns the message prefixed with its compression, compressed
+// with the given compression if it is large enough and shrinks.
+func compressMsg(compression string, msg []byte) []byte {
+	var compressed []byte
+	tag := compressionTagNone
+	if len(msg) >= minCompressedMsgSize {
+		switch compression {
+		case config.P2PCompressionSnappy:
+			compressed = snappy.Encode(nil, msg)
+			tag = compressionTagSnappy
+		case config.P2PCompressionZstd:
+			if enc, err := zstdMsgEncoder(); err == nil {
+				compressed = enc.EncodeAll(msg, nil)
+				tag = compressionTagZstd
+			}
+		}
+	}
+	if compressed == nil || len(compressed) >= len(msg) {
+		compressed, tag = msg, compressionTagNone
+	}
+
+	framed := make([]byte, compressionTagSize+len(compressed))
+	framed[0] = tag
+	copy(framed[compressionTagSize:], compressed)
+	return framed
+}
+
+// decompressMsg returns the message prefixed with its compression,
+// decompressed. It fails if the decompressed message would exceed maxSize.
+func decompressMsg(framed []byte, maxSize int) ([]byte, error) {
+	if len(framed) < compressionTagSize {
+		return nil, errors.New("message without compression")
+	}
+	tag, msg := framed[0], framed[compressionTagSize:]
+	switch tag {
+	case compressionTagNone:
+		return msg, nil
+	case compressionTagSnappy:
+		n, err := snappy.DecodedLen(msg)
+		if err != nil {
+			return nil, err
+		}
+		if n > maxSize {
+			return nil, fmt.Errorf("decompressed message exceeds available capacity: %v > %v", n, maxSize)
+		}
+		return snappy.Decode(nil, msg)
+	case compressionTagZstd:
+		var header zstd.Header
+		if err := header.Decode(msg); err != nil {
+			return nil, err
+		}
+		if !header.HasFCS {
+			return nil, errors.New("zstd message without content size")
+		}
+		if header.FrameContentSize > uint64(maxSize) {
+			return nil, fmt.Errorf("decompressed message exceeds available capacity: %v > %v",
+				header.FrameContentSize, maxSize)
+		}
+		dec, err := zstdMsgDecoder()
+		if err != nil {
+			return nil, err
+		}
+		// The decoder is limited to the capacity of the destination.
+		return dec.DecodeAll(msg, make([]byte, 0, header.FrameContentSize))
+	default:
+		return nil, fmt.Errorf("unknown message compression %d", tag)
+	}
+}
@@ -138,6 +138,11 @@ type MConnConfig struct {
 	// Maximum wait time for pongs
 	PongTimeout time.Duration `mapstructure:"pong_timeout"`
 
+	// Compression of the messages sent on the connection: "none", "snappy" or
+	// "zstd". Empty if either end does not support compression, in which
+	// case the messages are sent as is, without their compression.
+	Compression string `mapstructure:"compression"`
+
 	// Fuzz connection
 	TestFuzz       bool                   `mapstructure:"test_fuzz"`
 	TestFuzzConfig *config.FuzzConnConfig `mapstructure:"test_fuzz_config"`
@@ -368,6 +373,9 @@ func (c *MConnection) Send(chID byte, msgBytes []byte) bool {
 		c.Logger.Error(fmt.Sprintf("Cannot send bytes, unknown channel %X", chID))
 		return false
 	}
+	if c.config.Compression != "" {
+		msgBytes = compressMsg(c.config.Compression, msgBytes)
+	}
 
 	success := channel.sendBytes(msgBytes)
 	if success {
@@ -397,6 +405,9 @@ func (c *MConnection) TrySend(chID byte, msgBytes []byte) bool {
 		c.Logger.Error(fmt.Sprintf("Cannot send bytes, unknown channel %X", chID))
 		return false
 	}
+	if c.config.Compression != "" {
+		msgBytes = compressMsg(c.config.Compression, msgBytes)
+	}
 
 	ok = channel.trySendBytes(msgBytes)
 	if ok {
@@ -425,6 +436,18 @@ func (c *MConnection) CanSend(chID byte) bool {
 	return channel.canSend()
 }
 
+// SetChannelPriority sets the send priority of a channel, overriding the
+// priority of its descriptor. It returns false if the channel is unknown or
+// the priority isn't positive.
+func (c *MConnection) SetChannelPriority(chID byte, priority int) bool {
+	channel, ok := c.channelsIdx[chID]
+	if !ok || priority <= 0 {
+		return false
+	}
+	atomic.StoreInt32(&channel.priority, int32(priority))
+	return true
+}
+
 // sendRoutine polls for packets to send from channels.
 func (c *MConnection) sendRoutine() {
 	defer c._recover()
@@ -528,20 +551,32 @@ func (c *MConnection) sendPacketMsg() bool {
 	// Choose a channel to create a PacketMsg from.
 	// The chosen channel will be the one whose recentlySent/priority is the least.
 	var leastRatio float32 = math.MaxFloat32
-	var leastChannel *Channel
+	var leastChannel, throttledChannel *Channel
 	for _, channel := range c.channels {
 		// If nothing to send, skip this channel
 		if !channel.isSendPending() {
 			continue
 		}
+		// If the channel exceeds its rate, skip it for now
+		if channel.sendThrottled(c._maxPacketMsgSize) {
+			throttledChannel = channel
+			continue
+		}
 		// Get ratio, and keep track of lowest ratio.
-		ratio := float32(channel.recentlySent) / float32(channel.desc.Priority)
+		ratio := float32(channel.recentlySent) / float32(channel.loadPriority())
 		if ratio < leastRatio {
 			leastRatio = ratio
 			leastChannel = channel
 		}
 	}
 
+	// Only throttled channels have something to send? Block until one of
+	// them can send, as for the throttling of the connection.
+	if leastChannel == nil && throttledChannel != nil {
+		throttledChannel.sendMonitor.Limit(c._maxPacketMsgSize, throttledChannel.desc.SendRate, true)
+		leastChannel = throttledChannel
+	}
+
 	// Nothing to send?
 	if leastChannel == nil {
 		return true
@@ -556,6 +591,7 @@ func (c *MConnection) sendPacketMsg() bool {
 		return true
 	}
 	c.sendMonitor.Update(_n)
+	leastChannel.sendMonitor.Update(_n)
 	c.flushTimer.Set()
 	return false
 }
@@ -649,11 +685,24 @@ FOR_LOOP:
 				}
 				break FOR_LOOP
 			}
+			if msgBytes != nil && c.config.Compression != "" {
+				msgBytes, err = decompressMsg(msgBytes, channel.desc.RecvMessageCapacity)
+				if err != nil {
+					c.Logger.Debug("Connection failed @ recvRoutine", "conn", c, "err", err)
+					c.stopForError(err)
+					break FOR_LOOP
+				}
+			}
 			if msgBytes != nil {
 				c.Logger.Debug("Received bytes", "chID", channelID, "msgBytes", msgBytes)
 				// NOTE: This means the reactor.Receive runs in the same thread as the p2p recv routine
 				c.onReceive(channelID, msgBytes)
 			}
+
+			// Block until the channel is back within its rate. As the
+			// channels share the connection, this pauses them all.
+			channel.recvMonitor.Update(_n)
+			channel.recvMonitor.Limit(c._maxPacketMsgSize, channel.desc.RecvRate, true)
 		default:
 			err := fmt.Errorf("unknown message type %v", reflect.TypeOf(packet))
 			c.Logger.Error("Connection failed @ recvRoutine", "conn", c, "err", err)
@@ -718,7 +767,7 @@ func (c *MConnection) Status() ConnectionStatus {
 			ID:                channel.desc.ID,
 			SendQueueCapacity: cap(channel.sendQueue),
 			SendQueueSize:     int(atomic.LoadInt32(&channel.sendQueueSize)),
-			Priority:          channel.desc.Priority,
+			Priority:          channel.loadPriority(),
 			RecentlySent:      atomic.LoadInt64(&channel.recentlySent),
 		}
 	}
@@ -734,6 +783,11 @@ type ChannelDescriptor struct {
 	RecvBufferCapacity  int
 	RecvMessageCapacity int
 	MessageType         proto.Message
+
+	// Rates at which the channel can send and receive packets, in
+	// bytes/second, within the rates of the connection. Unlimited if 0.
+	SendRate int64
+	RecvRate int64
 }
 
 func (chDesc ChannelDescriptor) FillDefaults() (filled ChannelDescriptor) {
@@ -760,6 +814,9 @@ type Channel struct {
 	recving       []byte
 	sending       []byte
 	recentlySent  int64 // exponential moving average
+	priority      int32 // atomic, initially desc.Priority
+	sendMonitor   *flow.Monitor
+	recvMonitor   *flow.Monitor
 
 	maxPacketMsgPayloadSize int
 
@@ -774,8 +831,11 @@ func newChannel(conn *MConnection, desc ChannelDescriptor) *Channel {
 	return &Channel{
 		conn:                    conn,
 		desc:                    desc,
+		priority:                int32(desc.Priority),
 		sendQueue:               make(chan []byte, desc.SendQueueCapacity),
 		recving:                 make([]byte, 0, desc.RecvBufferCapacity),
+		sendMonitor:             flow.New(0, 0),
+		recvMonitor:             flow.New(0, 0),
 		maxPacketMsgPayloadSize: conn.config.MaxPacketMsgPayloadSize,
 	}
 }
@@ -815,6 +875,11 @@ func (ch *Channel) loadSendQueueSize() (size int) {
 	return int(atomic.LoadInt32(&ch.sendQueueSize))
 }
 
+// Goroutine-safe
+func (ch *Channel) loadPriority() int {
+	return int(atomic.LoadInt32(&ch.priority))
+}
+
 // Goroutine-safe
 // Use only as a heuristic.
 func (ch *Channel) canSend() bool {
@@ -834,6 +899,13 @@ func (ch *Channel) isSendPending() bool {
 	return true
 }
 
+// Returns true if the channel can't send a packet of the given size without
+// exceeding its rate.
+// Not goroutine-safe
+func (ch *Channel) sendThrottled(size int) bool {
+	return ch.desc.SendRate > 0 && ch.sendMonitor.Limit(size, ch.desc.SendRate, false) == 0
+}
+
 // Creates a new PacketMsg to send.
 // Not goroutine-safe
 func (ch *Channel) nextPacketMsg() tmp2p.PacketMsg {
@@ -866,6 +938,10 @@ func (ch *Channel) writePacketMsgTo(w io.Writer) (n int, err error) {
 func (ch *Channel) recvPacketMsg(packet tmp2p.PacketMsg) ([]byte, error) {
 	ch.Logger.Debug("Read PacketMsg", "conn", ch.conn, "packet", packet)
 	recvCap, recvReceived := ch.desc.RecvMessageCapacity, len(ch.recving)+len(packet.Data)
+	if ch.conn.config.Compression != "" {
+		// The messages start with their compression.
+		recvCap += compressionTagSize
+	}
 	if recvCap < recvReceived {
 		return nil, fmt.Errorf("received message exceeds available capacity: %v < %v", recvCap, recvReceived)
 	}
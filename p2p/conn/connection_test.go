@@ -1,6 +1,8 @@
 package conn
 
 import (
+	"bytes"
+	"crypto/rand"
 	"encoding/hex"
 	"net"
 	"testing"
@@ -11,8 +13,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/libs/protoio"
+	"github.com/cometbft/cometbft/libs/timer"
 	tmp2p "github.com/cometbft/cometbft/proto/tendermint/p2p"
 	"github.com/cometbft/cometbft/proto/tendermint/types"
 )
@@ -618,6 +622,153 @@ func TestMConnectionChannelOverflow(t *testing.T) {
 
 }
 
+func TestMConnectionChannelSendRate(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	type received struct {
+		chID byte
+		at   time.Time
+	}
+	receivedCh := make(chan received, 20)
+	onReceive := func(chID byte, msgBytes []byte) {
+		receivedCh <- received{chID, time.Now()}
+	}
+	onError := func(r interface{}) {}
+
+	// The messages of 0x01 are sent at ~10 kB/s, while 0x02 is unlimited.
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1, SendQueueCapacity: 10, SendRate: 10240},
+		{ID: 0x02, Priority: 1},
+	}
+	mconn1 := NewMConnectionWithConfig(client, chDescs, onReceive, onError, DefaultMConnConfig())
+	mconn1.SetLogger(log.TestingLogger())
+	mconn2 := NewMConnectionWithConfig(server, chDescs, onReceive, onError, DefaultMConnConfig())
+	mconn2.SetLogger(log.TestingLogger())
+	require.NoError(t, mconn1.Start())
+	require.NoError(t, mconn2.Start())
+	t.Cleanup(stopAll(t, mconn1, mconn2))
+
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		require.True(t, mconn1.Send(0x01, make([]byte, 1000)))
+	}
+	require.True(t, mconn1.Send(0x02, []byte("consensus")))
+
+	var throttled []time.Time
+	var unthrottled time.Time
+	for len(throttled) < 10 || unthrottled.IsZero() {
+		select {
+		case r := <-receivedCh:
+			if r.chID == 0x01 {
+				throttled = append(throttled, r.at)
+			} else {
+				unthrottled = r.at
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the messages")
+		}
+	}
+	// 0x02 is not delayed by the backlog of 0x01.
+	assert.True(t, unthrottled.Before(throttled[len(throttled)-1]))
+	assert.GreaterOrEqual(t, throttled[len(throttled)-1].Sub(start), 300*time.Millisecond)
+}
+
+func TestMConnectionSetChannelPriority(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	chDescs := []*ChannelDescriptor{{ID: 0x01, Priority: 1}, {ID: 0x02, Priority: 1}}
+	mconn := NewMConnectionWithConfig(client, chDescs, func(byte, []byte) {}, func(interface{}) {}, DefaultMConnConfig())
+	mconn.SetLogger(log.TestingLogger())
+	// The packets are picked without starting the connection, so that its
+	// send routine does not pick them first.
+	mconn.flushTimer = timer.NewThrottleTimer("flush", time.Hour)
+	defer mconn.flushTimer.Stop()
+
+	assert.False(t, mconn.SetChannelPriority(0x03, 5))
+	assert.False(t, mconn.SetChannelPriority(0x02, 0))
+	require.True(t, mconn.SetChannelPriority(0x02, 10))
+	assert.Equal(t, 10, mconn.Status().Channels[1].Priority)
+
+	// With the same recently sent bytes, 0x02 is picked first, despite the
+	// equal priorities of the descriptors.
+	ch1, ch2 := mconn.channelsIdx[0x01], mconn.channelsIdx[0x02]
+	ch1.recentlySent, ch2.recentlySent = 100, 100
+	require.True(t, ch1.trySendBytes([]byte("block part")))
+	require.True(t, ch2.trySendBytes([]byte("vote")))
+	require.False(t, mconn.sendPacketMsg())
+	assert.Equal(t, 1, ch1.loadSendQueueSize())
+	assert.Zero(t, ch2.loadSendQueueSize())
+}
+
+func TestMConnectionCompression(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	receivedCh := make(chan []byte, 10)
+	onReceive := func(chID byte, msgBytes []byte) {
+		receivedCh <- msgBytes
+	}
+	onError := func(r interface{}) {}
+
+	// Each end compresses the messages it sends with its own compression.
+	chDescs := []*ChannelDescriptor{{ID: 0x01, Priority: 1, RecvMessageCapacity: 1 << 20}}
+	snappyCfg, zstdCfg := DefaultMConnConfig(), DefaultMConnConfig()
+	snappyCfg.Compression, zstdCfg.Compression = config.P2PCompressionSnappy, config.P2PCompressionZstd
+	mconn1 := NewMConnectionWithConfig(client, chDescs, onReceive, onError, snappyCfg)
+	mconn1.SetLogger(log.TestingLogger())
+	mconn2 := NewMConnectionWithConfig(server, chDescs, onReceive, onError, zstdCfg)
+	mconn2.SetLogger(log.TestingLogger())
+	require.NoError(t, mconn1.Start())
+	require.NoError(t, mconn2.Start())
+	t.Cleanup(stopAll(t, mconn1, mconn2))
+
+	compressible := bytes.Repeat([]byte("block part "), 10000)
+	incompressible := make([]byte, 10000)
+	_, err := rand.Read(incompressible)
+	require.NoError(t, err)
+	for _, mconn := range []*MConnection{mconn1, mconn2} {
+		for _, msg := range [][]byte{[]byte("vote"), compressible, incompressible} {
+			require.True(t, mconn.Send(0x01, msg))
+			select {
+			case received := <-receivedCh:
+				assert.Equal(t, msg, received)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the message")
+			}
+		}
+		// The compressible message is sent in a fraction of its size.
+		assert.Less(t, mconn.Status().SendMonitor.Bytes, int64(len(compressible)+len(incompressible))*2/3)
+	}
+}
+
+func TestDecompressMsg(t *testing.T) {
+	msg := bytes.Repeat([]byte("snapshot chunk "), 1000)
+	for _, compression := range []string{config.P2PCompressionNone, config.P2PCompressionSnappy, config.P2PCompressionZstd} {
+		framed := compressMsg(compression, msg)
+		decompressed, err := decompressMsg(framed, len(msg))
+		require.NoError(t, err, compression)
+		assert.Equal(t, msg, decompressed, compression)
+
+		if compression != config.P2PCompressionNone {
+			assert.Less(t, len(framed), len(msg)/2, compression)
+			// The messages decompressing to more than the capacity of the
+			// channel are rejected, before being decompressed.
+			_, err = decompressMsg(framed, len(msg)-1)
+			require.ErrorContains(t, err, "exceeds available capacity", compression)
+		}
+	}
+
+	_, err := decompressMsg([]byte{0x07, 0x01}, 10)
+	require.ErrorContains(t, err, "unknown message compression")
+	_, err = decompressMsg(nil, 10)
+	require.Error(t, err)
+}
+
 type stopper interface {
 	Stop() error
 }
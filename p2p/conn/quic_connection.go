@@ -0,0 +1,427 @@
+package conn
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	flow "github.com/cometbft/cometbft/libs/flowrate"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+)
+
+// quicFlushLinger is how long FlushStop waits, after closing the streams, for
+// the peer to receive the data still in flight before closing the connection.
+const quicFlushLinger = time.Second
+
+/*
+QUICConnection is the counterpart of MConnection for the peers connected over
+QUIC. Instead of multiplexing the channels over a single stream, it sends the
+messages of each channel on their own unidirectional QUIC stream, opened on the
+first message, so that a lost packet only delays the messages of its channel.
+
+Each stream starts with the ID of its channel, followed by the messages, each
+prefixed with its length as an uvarint. The encryption and the keepalives are
+handled by QUIC.
+*/
+type QUICConnection struct {
+	service.BaseService
+
+	conn        quic.Connection
+	sendMonitor *flow.Monitor
+	recvMonitor *flow.Monitor
+	channels    []*quicChannel
+	channelsIdx map[byte]*quicChannel
+	onReceive   receiveCbFunc
+	onError     errorCbFunc
+	errored     uint32
+	config      MConnConfig
+
+	// Closing quitSendRoutines makes the send routines close their streams
+	// and return, after sending the queued messages if flush is set.
+	// doneSendRoutines is done once they all returned.
+	quitSendRoutines chan struct{}
+	doneSendRoutines sync.WaitGroup
+	flush            bool
+	stopOnce         sync.Once
+
+	created time.Time // time of creation
+}
+
+// quicChannel is a channel of a QUICConnection.
+type quicChannel struct {
+	desc          ChannelDescriptor
+	sendQueue     chan []byte
+	sendQueueSize int32 // atomic.
+	priority      int32 // atomic, initially desc.Priority
+	sendMonitor   *flow.Monitor
+	recvMonitor   *flow.Monitor
+}
+
+// NewQUICConnection wraps a QUIC connection and creates a connection sending
+// the messages of each channel on its own stream.
+func NewQUICConnection(
+	conn quic.Connection,
+	chDescs []*ChannelDescriptor,
+	onReceive receiveCbFunc,
+	onError errorCbFunc,
+	config MConnConfig,
+) *QUICConnection {
+	qconn := &QUICConnection{
+		conn:        conn,
+		sendMonitor: flow.New(0, 0),
+		recvMonitor: flow.New(0, 0),
+		channelsIdx: map[byte]*quicChannel{},
+		onReceive:   onReceive,
+		onError:     onError,
+		config:      config,
+		created:     time.Now(),
+	}
+	for _, desc := range chDescs {
+		desc := desc.FillDefaults()
+		channel := &quicChannel{
+			desc:        desc,
+			priority:    int32(desc.Priority),
+			sendQueue:   make(chan []byte, desc.SendQueueCapacity),
+			sendMonitor: flow.New(0, 0),
+			recvMonitor: flow.New(0, 0),
+		}
+		qconn.channelsIdx[desc.ID] = channel
+		qconn.channels = append(qconn.channels, channel)
+	}
+	qconn.BaseService = *service.NewBaseService(nil, "QUICConnection", qconn)
+	return qconn
+}
+
+// OnStart implements BaseService
+func (c *QUICConnection) OnStart() error {
+	if err := c.BaseService.OnStart(); err != nil {
+		return err
+	}
+	c.quitSendRoutines = make(chan struct{})
+	for _, channel := range c.channels {
+		c.doneSendRoutines.Add(1)
+		go c.sendRoutine(channel)
+	}
+	go c.acceptStreamsRoutine()
+	return nil
+}
+
+// stopServices stops the BaseService and the send routines, and returns true
+// if it was already done.
+func (c *QUICConnection) stopServices(flush bool) (alreadyStopped bool) {
+	alreadyStopped = true
+	c.stopOnce.Do(func() {
+		alreadyStopped = false
+		c.BaseService.OnStop()
+		c.flush = flush
+		close(c.quitSendRoutines)
+	})
+	return alreadyStopped
+}
+
+// stopped returns true once the connection is stopping.
+func (c *QUICConnection) stopped() bool {
+	select {
+	case <-c.quitSendRoutines:
+		return true
+	default:
+		return false
+	}
+}
+
+// FlushStop replicates the logic of OnStop. It additionally sends all the
+// messages queued by successful .Send() calls before closing the connection.
+// As QUIC has no way to wait for the data to be acknowledged, the data still
+// in flight after quicFlushLinger may be lost.
+func (c *QUICConnection) FlushStop() {
+	if c.stopServices(true) {
+		return
+	}
+	c.doneSendRoutines.Wait()
+	select {
+	case <-c.conn.Context().Done():
+	case <-time.After(quicFlushLinger):
+	}
+	_ = c.conn.CloseWithError(0, "")
+}
+
+// OnStop implements BaseService
+func (c *QUICConnection) OnStop() {
+	if c.stopServices(false) {
+		return
+	}
+	_ = c.conn.CloseWithError(0, "")
+}
+
+func (c *QUICConnection) String() string {
+	return fmt.Sprintf("QUICConn{%v}", c.conn.RemoteAddr())
+}
+
+// Catch panics, usually caused by remote disconnects.
+func (c *QUICConnection) _recover() {
+	if r := recover(); r != nil {
+		c.Logger.Error("QUICConnection panicked", "err", r, "stack", string(debug.Stack()))
+		c.stopForError(fmt.Errorf("recovered from panic: %v", r))
+	}
+}
+
+func (c *QUICConnection) stopForError(r interface{}) {
+	if err := c.Stop(); err != nil {
+		c.Logger.Error("Error stopping connection", "err", err)
+	}
+	if atomic.CompareAndSwapUint32(&c.errored, 0, 1) {
+		if c.onError != nil {
+			c.onError(r)
+		}
+	}
+}
+
+// Send queues a message to be sent to channel, and times out (returning
+// false) after defaultSendTimeout.
+func (c *QUICConnection) Send(chID byte, msgBytes []byte) bool {
+	if !c.IsRunning() {
+		return false
+	}
+
+	c.Logger.Debug("Send", "channel", chID, "conn", c, "msgBytes", log.NewLazySprintf("%X", msgBytes))
+
+	channel, ok := c.channelsIdx[chID]
+	if !ok {
+		c.Logger.Error(fmt.Sprintf("Cannot send bytes, unknown channel %X", chID))
+		return false
+	}
+	if c.config.Compression != "" {
+		msgBytes = compressMsg(c.config.Compression, msgBytes)
+	}
+
+	select {
+	case channel.sendQueue <- msgBytes:
+		atomic.AddInt32(&channel.sendQueueSize, 1)
+		return true
+	case <-time.After(defaultSendTimeout):
+		c.Logger.Debug("Send failed", "channel", chID, "conn", c, "msgBytes", log.NewLazySprintf("%X", msgBytes))
+		return false
+	}
+}
+
+// TrySend queues a message to be sent to channel.
+// Nonblocking, returns true if successful.
+func (c *QUICConnection) TrySend(chID byte, msgBytes []byte) bool {
+	if !c.IsRunning() {
+		return false
+	}
+
+	c.Logger.Debug("TrySend", "channel", chID, "conn", c, "msgBytes", log.NewLazySprintf("%X", msgBytes))
+
+	channel, ok := c.channelsIdx[chID]
+	if !ok {
+		c.Logger.Error(fmt.Sprintf("Cannot send bytes, unknown channel %X", chID))
+		return false
+	}
+	if c.config.Compression != "" {
+		msgBytes = compressMsg(c.config.Compression, msgBytes)
+	}
+
+	select {
+	case channel.sendQueue <- msgBytes:
+		atomic.AddInt32(&channel.sendQueueSize, 1)
+		return true
+	default:
+		return false
+	}
+}
+
+// CanSend returns true if you can send more data onto the chID, false
+// otherwise. Use only as a heuristic.
+func (c *QUICConnection) CanSend(chID byte) bool {
+	if !c.IsRunning() {
+		return false
+	}
+
+	channel, ok := c.channelsIdx[chID]
+	if !ok {
+		c.Logger.Error(fmt.Sprintf("Unknown channel %X", chID))
+		return false
+	}
+	return int(atomic.LoadInt32(&channel.sendQueueSize)) < defaultSendQueueCapacity
+}
+
+// SetChannelPriority sets the priority of a channel, overriding the priority
+// of its descriptor. As the channels are sent on their own streams, scheduled
+// by QUIC, the priority is only reported in the status. It returns false if
+// the channel is unknown or the priority isn't positive.
+func (c *QUICConnection) SetChannelPriority(chID byte, priority int) bool {
+	channel, ok := c.channelsIdx[chID]
+	if !ok || priority <= 0 {
+		return false
+	}
+	atomic.StoreInt32(&channel.priority, int32(priority))
+	return true
+}
+
+// Status returns the status of the connection. The channels have no recently
+// sent estimate, as they don't compete for a single stream.
+func (c *QUICConnection) Status() ConnectionStatus {
+	var status ConnectionStatus
+	status.Duration = time.Since(c.created)
+	status.SendMonitor = c.sendMonitor.Status()
+	status.RecvMonitor = c.recvMonitor.Status()
+	status.Channels = make([]ChannelStatus, len(c.channels))
+	for i, channel := range c.channels {
+		status.Channels[i] = ChannelStatus{
+			ID:                channel.desc.ID,
+			SendQueueCapacity: cap(channel.sendQueue),
+			SendQueueSize:     int(atomic.LoadInt32(&channel.sendQueueSize)),
+			Priority:          int(atomic.LoadInt32(&channel.priority)),
+		}
+	}
+	return status
+}
+
+// sendRoutine writes the messages queued on the channel to its stream, which
+// is opened on the first message.
+func (c *QUICConnection) sendRoutine(channel *quicChannel) {
+	defer c.doneSendRoutines.Done()
+	defer c._recover()
+
+	var stream quic.SendStream
+	defer func() {
+		if stream != nil {
+			_ = stream.Close()
+		}
+	}()
+	send := func(msgBytes []byte) error {
+		atomic.AddInt32(&channel.sendQueueSize, -1)
+		if stream == nil {
+			var err error
+			if stream, err = c.conn.OpenUniStreamSync(c.conn.Context()); err != nil {
+				return err
+			}
+			if _, err := stream.Write([]byte{channel.desc.ID}); err != nil {
+				return err
+			}
+		}
+		bz := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+len(msgBytes)), uint64(len(msgBytes)))
+		bz = append(bz, msgBytes...)
+		channel.sendMonitor.Limit(len(bz), channel.desc.SendRate, true)
+		c.sendMonitor.Limit(len(bz), c.config.SendRate, true)
+		n, err := stream.Write(bz)
+		channel.sendMonitor.Update(n)
+		c.sendMonitor.Update(n)
+		return err
+	}
+
+	for {
+		select {
+		case msgBytes := <-channel.sendQueue:
+			if err := send(msgBytes); err != nil {
+				if !c.stopped() {
+					c.stopForError(err)
+				}
+				return
+			}
+		case <-c.quitSendRoutines:
+			if !c.flush {
+				return
+			}
+			for {
+				select {
+				case msgBytes := <-channel.sendQueue:
+					if err := send(msgBytes); err != nil {
+						c.Logger.Debug("QUICConnection flush failed", "err", err)
+						return
+					}
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// acceptStreamsRoutine accepts the streams opened by the peer for its
+// channels.
+func (c *QUICConnection) acceptStreamsRoutine() {
+	defer c._recover()
+
+	for {
+		stream, err := c.conn.AcceptUniStream(c.conn.Context())
+		if err != nil {
+			if !c.stopped() {
+				c.Logger.Debug("Connection failed @ acceptStreamsRoutine", "conn", c, "err", err)
+				c.stopForError(err)
+			}
+			return
+		}
+		go c.recvRoutine(stream)
+	}
+}
+
+// recvRoutine reads the messages of a stream and passes them to onReceive.
+func (c *QUICConnection) recvRoutine(stream quic.ReceiveStream) {
+	defer c._recover()
+
+	err := c.recvMessages(stream)
+	if err != nil && !errors.Is(err, io.EOF) && !c.stopped() {
+		c.Logger.Debug("Connection failed @ recvRoutine", "conn", c, "err", err)
+		c.stopForError(err)
+	}
+}
+
+func (c *QUICConnection) recvMessages(stream quic.ReceiveStream) error {
+	rd := bufio.NewReaderSize(stream, minReadBufferSize)
+	chID, err := rd.ReadByte()
+	if err != nil {
+		return err
+	}
+	channel, ok := c.channelsIdx[chID]
+	if !ok {
+		return fmt.Errorf("unknown channel %X", chID)
+	}
+
+	maxSize := channel.desc.RecvMessageCapacity
+	if c.config.Compression != "" {
+		// The messages start with their compression.
+		maxSize += compressionTagSize
+	}
+	for {
+		size, err := binary.ReadUvarint(rd)
+		if err != nil {
+			return err
+		}
+		if size > uint64(maxSize) {
+			return fmt.Errorf("received message exceeds available capacity: %v > %v",
+				size, channel.desc.RecvMessageCapacity)
+		}
+		// As each channel has its own stream, only this channel is paused
+		// when exceeding its rate.
+		channel.recvMonitor.Limit(int(size), channel.desc.RecvRate, true)
+		c.recvMonitor.Limit(int(size), c.config.RecvRate, true)
+		msgBytes := make([]byte, size)
+		if _, err := io.ReadFull(rd, msgBytes); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		n := binary.PutUvarint(make([]byte, binary.MaxVarintLen64), size) + int(size)
+		channel.recvMonitor.Update(n)
+		c.recvMonitor.Update(n)
+		if c.config.Compression != "" {
+			if msgBytes, err = decompressMsg(msgBytes, channel.desc.RecvMessageCapacity); err != nil {
+				return err
+			}
+		}
+		c.Logger.Debug("Received bytes", "chID", chID, "msgBytes", msgBytes)
+		c.onReceive(chID, msgBytes)
+	}
+}
@@ -0,0 +1,104 @@
+package p2p
+
+import (
+	"net"
+	"sync"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// The subnets of the inbound connections limited by
+// MultiplexTransportMaxIncomingConnectionsPerSubnet.
+const (
+	inboundSubnetBitsIPv4 = 24
+	inboundSubnetBitsIPv6 = 64
+)
+
+// inboundConnLimiter limits the number of concurrent inbound connections from
+// the same IP and from the same subnet, to keep a few hosts from taking all
+// the inbound slots. The connections are counted from their acceptance, before
+// their handshake, until they're closed.
+type inboundConnLimiter struct {
+	maxPerIP     int // unlimited if 0
+	maxPerSubnet int // unlimited if 0
+
+	mtx       cmtsync.Mutex
+	perIP     map[string]int
+	perSubnet map[string]int
+}
+
+func newInboundConnLimiter(maxPerIP, maxPerSubnet int) *inboundConnLimiter {
+	return &inboundConnLimiter{
+		maxPerIP:     maxPerIP,
+		maxPerSubnet: maxPerSubnet,
+		perIP:        make(map[string]int),
+		perSubnet:    make(map[string]int),
+	}
+}
+
+// inboundSubnet returns the /24 (IPv4) or /64 (IPv6) subnet of the IP.
+func inboundSubnet(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(net.CIDRMask(inboundSubnetBitsIPv4, 8*net.IPv4len)).String()
+	}
+	return ip.Mask(net.CIDRMask(inboundSubnetBitsIPv6, 8*net.IPv6len)).String()
+}
+
+// acquire counts a new connection from the IP, and returns false, without
+// counting it, if it exceeds one of the limits.
+func (l *inboundConnLimiter) acquire(ip net.IP) bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	ipKey, subnetKey := ip.String(), inboundSubnet(ip)
+	if l.maxPerIP > 0 && l.perIP[ipKey] >= l.maxPerIP {
+		return false
+	}
+	if l.maxPerSubnet > 0 && l.perSubnet[subnetKey] >= l.maxPerSubnet {
+		return false
+	}
+	l.perIP[ipKey]++
+	l.perSubnet[subnetKey]++
+	return true
+}
+
+// release uncounts a connection from the IP.
+func (l *inboundConnLimiter) release(ip net.IP) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	ipKey, subnetKey := ip.String(), inboundSubnet(ip)
+	if l.perIP[ipKey]--; l.perIP[ipKey] <= 0 {
+		delete(l.perIP, ipKey)
+	}
+	if l.perSubnet[subnetKey]--; l.perSubnet[subnetKey] <= 0 {
+		delete(l.perSubnet, subnetKey)
+	}
+}
+
+// limitedConn is an inbound connection counted by the limiter, until it's
+// closed.
+type limitedConn struct {
+	net.Conn
+
+	releaseOnce sync.Once
+	release     func()
+}
+
+func (c *limitedConn) Close() error {
+	err := c.Conn.Close()
+	c.releaseOnce.Do(c.release)
+	return err
+}
+
+// remoteIP returns the IP of the remote address, or nil if it isn't an IP
+// address.
+func remoteIP(addr net.Addr) net.IP {
+	switch addr := addr.(type) {
+	case *net.TCPAddr:
+		return addr.IP
+	case *net.UDPAddr:
+		return addr.IP
+	}
+	return nil
+}
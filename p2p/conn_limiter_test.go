@@ -0,0 +1,29 @@
+package p2p
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInboundConnLimiter(t *testing.T) {
+	l := newInboundConnLimiter(2, 3)
+	ip1, ip2, ip3 := net.ParseIP("192.0.2.1"), net.ParseIP("192.0.2.2"), net.ParseIP("198.51.100.1")
+
+	assert.True(t, l.acquire(ip1))
+	assert.True(t, l.acquire(ip1))
+	assert.False(t, l.acquire(ip1))
+	assert.True(t, l.acquire(ip2))
+	// 192.0.2.0/24 is full.
+	assert.False(t, l.acquire(ip2))
+	assert.True(t, l.acquire(ip3))
+
+	l.release(ip1)
+	assert.True(t, l.acquire(ip2))
+	assert.False(t, l.acquire(ip1))
+
+	v6a, v6b := net.ParseIP("2001:db8:0:1::1"), net.ParseIP("2001:db8:0:2::1")
+	assert.Equal(t, "2001:db8:0:1::", inboundSubnet(v6a))
+	assert.NotEqual(t, inboundSubnet(v6a), inboundSubnet(v6b))
+}
@@ -0,0 +1,98 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+)
+
+// dialFallbackDelay is the delay after which the next IP of an address
+// resolving to both IPv4 and IPv6 is dialed, while the previous dials are
+// still in progress (Happy Eyeballs, RFC 8305).
+const dialFallbackDelay = 250 * time.Millisecond
+
+// dualStackDialOrder returns the order in which the IPs are dialed, with the
+// IPv6 and IPv4 addresses interleaved and IPv6 first, or nil if they are not
+// of both families.
+func dualStackDialOrder(ips []net.IP) []net.IP {
+	var v4, v6 []net.IP
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			v4 = append(v4, ip)
+		} else {
+			v6 = append(v6, ip)
+		}
+	}
+	if len(v4) == 0 || len(v6) == 0 {
+		return nil
+	}
+
+	order := make([]net.IP, 0, len(ips))
+	for i := 0; i < len(v4) || i < len(v6); i++ {
+		if i < len(v6) {
+			order = append(order, v6[i])
+		}
+		if i < len(v4) {
+			order = append(order, v4[i])
+		}
+	}
+	return order
+}
+
+// dialRace dials the IPs in order, starting the next dial when the previous
+// one fails or after the fallback delay, and returns the first established
+// connection. The other connections are closed. It returns the first error if
+// all the dials fail.
+func dialRace(ctx context.Context, ips []net.IP, port uint16, fallbackDelay time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type dialResult struct {
+		conn net.Conn
+		err  error
+	}
+	results := make(chan dialResult, len(ips))
+	var dialer net.Dialer
+	next, pending := 0, 0
+	var fallback <-chan time.Time
+	dialNext := func() {
+		if next == len(ips) {
+			return
+		}
+		addr := net.JoinHostPort(ips[next].String(), strconv.FormatUint(uint64(port), 10))
+		go func() {
+			conn, err := dialer.DialContext(ctx, "tcp", addr)
+			results <- dialResult{conn, err}
+		}()
+		next++
+		pending++
+		fallback = time.After(fallbackDelay)
+	}
+
+	var firstErr error
+	dialNext()
+	for pending > 0 {
+		select {
+		case <-fallback:
+			dialNext()
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				go func(n int) {
+					for ; n > 0; n-- {
+						if r := <-results; r.err == nil {
+							r.conn.Close()
+						}
+					}
+				}(pending)
+				return res.conn, nil
+			}
+			if firstErr == nil {
+				firstErr = res.err
+			}
+			dialNext()
+		}
+	}
+	return nil, firstErr
+}
@@ -0,0 +1,54 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDualStackDialOrder(t *testing.T) {
+	v4a, v4b := net.ParseIP("192.0.2.1"), net.ParseIP("192.0.2.2")
+	v6a, v6b := net.ParseIP("2001:db8::1"), net.ParseIP("2001:db8::2")
+
+	assert.Nil(t, dualStackDialOrder([]net.IP{v4a, v4b}))
+	assert.Nil(t, dualStackDialOrder([]net.IP{v6a}))
+	assert.Equal(t, []net.IP{v6a, v4a, v6b, v4b}, dualStackDialOrder([]net.IP{v4a, v4b, v6a, v6b}))
+	assert.Equal(t, []net.IP{v6a, v4a, v4b}, dualStackDialOrder([]net.IP{v4a, v6a, v4b}))
+}
+
+func TestDialRace(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	port := uint16(ln.Addr().(*net.TCPAddr).Port)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The first IP is not reachable, the dial falls back to the second one
+	// without waiting for the first dial to time out.
+	start := time.Now()
+	conn, err := dialRace(ctx, []net.IP{net.ParseIP("192.0.2.1"), net.ParseIP("127.0.0.1")}, port, 50*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", conn.RemoteAddr().(*net.TCPAddr).IP.String())
+	assert.Less(t, time.Since(start), time.Second)
+	conn.Close()
+
+	// All the dials fail.
+	require.NoError(t, ln.Close())
+	_, err = dialRace(ctx, []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("127.0.0.1")}, port, 50*time.Millisecond)
+	require.Error(t, err)
+}
@@ -32,12 +32,30 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "peer_send_bytes_total",
 			Help:      "Number of bytes sent to a given peer.",
 		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		PeerReceiveMessagesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_receive_messages_total",
+			Help:      "Number of messages received from a given peer.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		PeerSendMessagesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_send_messages_total",
+			Help:      "Number of messages sent to a given peer.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
 		PeerPendingSendBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "peer_pending_send_bytes",
 			Help:      "Pending bytes to be sent to a given peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerChannelPendingSendMessages: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_channel_pending_send_messages",
+			Help:      "Number of messages queued to be sent to a given peer, per channel.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
 		NumTxs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -61,12 +79,15 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Peers:                    discard.NewGauge(),
-		PeerReceiveBytesTotal:    discard.NewCounter(),
-		PeerSendBytesTotal:       discard.NewCounter(),
-		PeerPendingSendBytes:     discard.NewGauge(),
-		NumTxs:                   discard.NewGauge(),
-		MessageReceiveBytesTotal: discard.NewCounter(),
-		MessageSendBytesTotal:    discard.NewCounter(),
+		Peers:                          discard.NewGauge(),
+		PeerReceiveBytesTotal:          discard.NewCounter(),
+		PeerSendBytesTotal:             discard.NewCounter(),
+		PeerReceiveMessagesTotal:       discard.NewCounter(),
+		PeerSendMessagesTotal:          discard.NewCounter(),
+		PeerPendingSendBytes:           discard.NewGauge(),
+		PeerChannelPendingSendMessages: discard.NewGauge(),
+		NumTxs:                         discard.NewGauge(),
+		MessageReceiveBytesTotal:       discard.NewCounter(),
+		MessageSendBytesTotal:          discard.NewCounter(),
 	}
 }
@@ -32,8 +32,14 @@ type Metrics struct {
 	PeerReceiveBytesTotal metrics.Counter `metrics_labels:"peer_id,chID"`
 	// Number of bytes sent to a given peer.
 	PeerSendBytesTotal metrics.Counter `metrics_labels:"peer_id,chID"`
+	// Number of messages received from a given peer.
+	PeerReceiveMessagesTotal metrics.Counter `metrics_labels:"peer_id,chID"`
+	// Number of messages sent to a given peer.
+	PeerSendMessagesTotal metrics.Counter `metrics_labels:"peer_id,chID"`
 	// Pending bytes to be sent to a given peer.
 	PeerPendingSendBytes metrics.Gauge `metrics_labels:"peer_id"`
+	// Number of messages queued to be sent to a given peer, per channel.
+	PeerChannelPendingSendMessages metrics.Gauge `metrics_labels:"peer_id,chID"`
 	// Number of transactions submitted by each peer.
 	NumTxs metrics.Gauge `metrics_labels:"peer_id"`
 	// Number of bytes of each message type received.
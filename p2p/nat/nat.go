@@ -0,0 +1,206 @@
+// Package nat maps the p2p port of a node on the NAT gateway of its network,
+// with UPnP or NAT-PMP, and discovers the external address of the gateway, so
+// that a node behind a home router can be dialed without configuring the
+// router by hand.
+package nat
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// Protocols of the port mappings.
+const (
+	TCP = "TCP"
+	UDP = "UDP"
+)
+
+// Methods of discovery of the gateway.
+const (
+	MethodAny  = "any"
+	MethodUPnP = "upnp"
+	MethodPMP  = "pmp"
+)
+
+const (
+	// mappingLifetime is the lifetime of the port mappings, which are renewed
+	// when half of it elapsed.
+	mappingLifetime = 20 * time.Minute
+
+	// requestTimeout bounds the requests to the gateway.
+	requestTimeout = 5 * time.Second
+
+	mappingDescription = "cometbft p2p"
+)
+
+// Interface is a NAT gateway.
+type Interface interface {
+	// ExternalIP returns the external address of the gateway.
+	ExternalIP(ctx context.Context) (net.IP, error)
+
+	// AddPortMapping maps the external port of the gateway to the internal
+	// port of the node for the given lifetime, and returns the external port
+	// actually mapped, which may differ from the requested one.
+	AddPortMapping(ctx context.Context, protocol string, extPort, intPort int, desc string,
+		lifetime time.Duration) (int, error)
+
+	// DeletePortMapping deletes the mapping of the external port.
+	DeletePortMapping(ctx context.Context, protocol string, extPort, intPort int) error
+
+	String() string
+}
+
+// Discover looks for a gateway supporting the given method, or any of them,
+// on the local network.
+func Discover(ctx context.Context, method string) (Interface, error) {
+	var discoverers []func(context.Context) (Interface, error)
+	switch method {
+	case MethodAny:
+		discoverers = append(discoverers, discoverUPnP, discoverPMP)
+	case MethodUPnP:
+		discoverers = append(discoverers, discoverUPnP)
+	case MethodPMP:
+		discoverers = append(discoverers, discoverPMP)
+	default:
+		return nil, fmt.Errorf("unknown NAT method %q", method)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		nat Interface
+		err error
+	}
+	results := make(chan result, len(discoverers))
+	for _, discover := range discoverers {
+		go func(discover func(context.Context) (Interface, error)) {
+			nat, err := discover(ctx)
+			results <- result{nat, err}
+		}(discover)
+	}
+
+	var errs []string
+	for range discoverers {
+		res := <-results
+		if res.err == nil {
+			return res.nat, nil
+		}
+		errs = append(errs, res.err.Error())
+	}
+	return nil, fmt.Errorf("no NAT gateway found: %s", strings.Join(errs, "; "))
+}
+
+// PortMapper keeps a port of the gateway mapped to a port of the node,
+// renewing the mapping until stopped.
+type PortMapper struct {
+	service.BaseService
+
+	nat      Interface
+	protocol string
+	port     int
+
+	mtx     cmtsync.Mutex
+	extPort int
+}
+
+// NewPortMapper returns a mapper of the given port of the node, for the given
+// protocol.
+func NewPortMapper(nat Interface, protocol string, port int, logger log.Logger) *PortMapper {
+	m := &PortMapper{
+		nat:      nat,
+		protocol: protocol,
+		port:     port,
+		extPort:  port,
+	}
+	m.BaseService = *service.NewBaseService(logger, "PortMapper", m)
+	return m
+}
+
+// Map maps the port, preferably to the same external port, and returns the
+// external address of the node.
+func (m *PortMapper) Map(ctx context.Context) (string, error) {
+	extPort, err := m.nat.AddPortMapping(ctx, m.protocol, m.getExtPort(), m.port, mappingDescription, mappingLifetime)
+	if err != nil {
+		return "", fmt.Errorf("failed to map port %d: %w", m.port, err)
+	}
+	m.setExtPort(extPort)
+
+	ip, err := m.nat.ExternalIP(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get external address: %w", err)
+	}
+	return net.JoinHostPort(ip.String(), strconv.Itoa(extPort)), nil
+}
+
+// OnStart implements service.Service.
+func (m *PortMapper) OnStart() error {
+	go m.renewRoutine()
+	return nil
+}
+
+// OnStop implements service.Service by deleting the mapping.
+func (m *PortMapper) OnStop() {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	extPort := m.getExtPort()
+	if err := m.nat.DeletePortMapping(ctx, m.protocol, extPort, m.port); err != nil {
+		m.Logger.Error("Failed to delete port mapping", "nat", m.nat, "port", extPort, "err", err)
+	}
+}
+
+func (m *PortMapper) getExtPort() int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.extPort
+}
+
+func (m *PortMapper) setExtPort(extPort int) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.extPort = extPort
+}
+
+func (m *PortMapper) renewRoutine() {
+	ticker := time.NewTicker(mappingLifetime / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			oldExtPort := m.getExtPort()
+			ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+			extPort, err := m.nat.AddPortMapping(ctx, m.protocol, oldExtPort, m.port, mappingDescription, mappingLifetime)
+			cancel()
+			if err != nil {
+				m.Logger.Error("Failed to renew port mapping", "nat", m.nat, "port", oldExtPort, "err", err)
+				continue
+			}
+			if extPort != oldExtPort {
+				m.Logger.Error("Port mapping changed, the external address of the node is no longer valid",
+					"nat", m.nat, "old", oldExtPort, "new", extPort)
+				m.setExtPort(extPort)
+			}
+		case <-m.Quit():
+			return
+		}
+	}
+}
+
+// localIPTo returns the address of the node on the route to the given host.
+func localIPTo(host string) (net.IP, error) {
+	conn, err := net.Dial("udp4", net.JoinHostPort(host, "1"))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
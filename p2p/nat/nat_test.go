@@ -0,0 +1,206 @@
+package nat
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// servePMP runs a NAT-PMP server mapping the ports to extPortOffset + the
+// requested port, and records the lifetimes of the mappings by internal port.
+func servePMP(t *testing.T, extPortOffset uint16) (addr string, lifetimes func() map[uint16]uint32) {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	var mtx cmtsync.Mutex
+	mappings := make(map[uint16]uint32)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, from, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			req := buf[:n]
+			var resp []byte
+			switch {
+			case n == 2 && req[1] == pmpOpExternalAddress:
+				resp = []byte{0, 128, 0, 0, 0, 0, 0, 1, 203, 0, 113, 7}
+			case n == 12 && (req[1] == pmpOpMapTCP || req[1] == pmpOpMapUDP):
+				intPort := binary.BigEndian.Uint16(req[4:6])
+				lifetime := binary.BigEndian.Uint32(req[8:12])
+				mtx.Lock()
+				mappings[intPort] = lifetime
+				mtx.Unlock()
+				resp = make([]byte, 16)
+				resp[1] = 128 + req[1]
+				binary.BigEndian.PutUint16(resp[8:10], intPort)
+				binary.BigEndian.PutUint16(resp[10:12], intPort+extPortOffset)
+				copy(resp[12:16], req[8:12])
+			default:
+				resp = []byte{0, 128 + req[1], 0, 5, 0, 0, 0, 1}
+			}
+			_, _ = conn.WriteTo(resp, from)
+		}
+	}()
+
+	return conn.LocalAddr().String(), func() map[uint16]uint32 {
+		mtx.Lock()
+		defer mtx.Unlock()
+		copied := make(map[uint16]uint32, len(mappings))
+		for port, lifetime := range mappings {
+			copied[port] = lifetime
+		}
+		return copied
+	}
+}
+
+func TestPMPGateway(t *testing.T) {
+	addr, lifetimes := servePMP(t, 1000)
+	g := &pmpGateway{addr: addr}
+	ctx := context.Background()
+
+	ip, err := g.ExternalIP(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.7", ip.String())
+
+	extPort, err := g.AddPortMapping(ctx, TCP, 26656, 26656, "test", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 27656, extPort)
+	assert.Equal(t, uint32(3600), lifetimes()[26656])
+
+	require.NoError(t, g.DeletePortMapping(ctx, TCP, extPort, 26656))
+	assert.Zero(t, lifetimes()[26656])
+
+	_, err = g.AddPortMapping(ctx, "SCTP", 26656, 26656, "test", time.Hour)
+	require.Error(t, err)
+}
+
+func TestPMPGatewayTimeout(t *testing.T) {
+	// Nothing answers on this address.
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	g := &pmpGateway{addr: conn.LocalAddr().String()}
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	_, err = g.ExternalIP(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+const testIGDDescription = `<?xml version="1.0"?>
+<root xmlns="urn:schemas-upnp-org:device-1-0">
+  <device>
+    <deviceType>urn:schemas-upnp-org:device:InternetGatewayDevice:1</deviceType>
+    <deviceList>
+      <device>
+        <deviceType>urn:schemas-upnp-org:device:WANDevice:1</deviceType>
+        <deviceList>
+          <device>
+            <deviceType>urn:schemas-upnp-org:device:WANConnectionDevice:1</deviceType>
+            <serviceList>
+              <service>
+                <serviceType>urn:schemas-upnp-org:service:WANIPConnection:1</serviceType>
+                <controlURL>/ctl/IPConn</controlURL>
+              </service>
+            </serviceList>
+          </device>
+        </deviceList>
+      </device>
+    </deviceList>
+  </device>
+</root>`
+
+func TestUPnPGateway(t *testing.T) {
+	var (
+		mtx     cmtsync.Mutex
+		actions []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/desc.xml":
+			fmt.Fprint(w, testIGDDescription)
+		case "/ctl/IPConn":
+			body, _ := io.ReadAll(r.Body)
+			action := r.Header.Get("SOAPAction")
+			mtx.Lock()
+			actions = append(actions, action+" "+string(body))
+			mtx.Unlock()
+			switch {
+			case strings.HasSuffix(action, `#GetExternalIPAddress"`):
+				fmt.Fprint(w, `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/">`+
+					`<s:Body><u:GetExternalIPAddressResponse xmlns:u="urn:schemas-upnp-org:service:WANIPConnection:1">`+
+					`<NewExternalIPAddress>203.0.113.7</NewExternalIPAddress>`+
+					`</u:GetExternalIPAddressResponse></s:Body></s:Envelope>`)
+			case strings.HasSuffix(action, `#AddPortMapping"`), strings.HasSuffix(action, `#DeletePortMapping"`):
+				if strings.Contains(string(body), "<NewExternalPort>1</NewExternalPort>") {
+					w.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprint(w, `<s:Envelope><s:Body><s:Fault><detail><UPnPError>`+
+						`<errorCode>718</errorCode><errorDescription>ConflictInMappingEntry</errorDescription>`+
+						`</UPnPError></detail></s:Fault></s:Body></s:Envelope>`)
+				}
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	g, err := newUPnPGateway(ctx, srv.URL+"/desc.xml")
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL+"/ctl/IPConn", g.controlURL)
+	assert.Equal(t, "urn:schemas-upnp-org:service:WANIPConnection:1", g.serviceType)
+
+	ip, err := g.ExternalIP(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.7", ip.String())
+
+	extPort, err := g.AddPortMapping(ctx, TCP, 26656, 26656, "test", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 26656, extPort)
+	require.NoError(t, g.DeletePortMapping(ctx, TCP, 26656, 26656))
+
+	_, err = g.AddPortMapping(ctx, TCP, 1, 26656, "test", time.Hour)
+	require.ErrorContains(t, err, "ConflictInMappingEntry")
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	require.Len(t, actions, 4)
+	assert.Contains(t, actions[1], "<NewInternalClient>127.0.0.1</NewInternalClient>")
+	assert.Contains(t, actions[1], "<NewLeaseDuration>3600</NewLeaseDuration>")
+	assert.Contains(t, actions[2], `WANIPConnection:1#DeletePortMapping"`)
+}
+
+func TestPortMapper(t *testing.T) {
+	addr, lifetimes := servePMP(t, 0)
+	m := NewPortMapper(&pmpGateway{addr: addr}, TCP, 26656, log.TestingLogger())
+
+	extAddr, err := m.Map(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.7:26656", extAddr)
+	assert.Equal(t, uint32(mappingLifetime/time.Second), lifetimes()[26656])
+
+	require.NoError(t, m.Start())
+	require.NoError(t, m.Stop())
+	assert.Zero(t, lifetimes()[26656])
+}
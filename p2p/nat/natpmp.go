@@ -0,0 +1,215 @@
+package nat
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	// pmpPort is the port of the NAT-PMP servers (RFC 6886).
+	pmpPort = 5351
+
+	pmpVersion           = 0
+	pmpOpExternalAddress = 0
+	pmpOpMapUDP          = 1
+	pmpOpMapTCP          = 2
+
+	// pmpInitialTimeout is the timeout of the first request, doubled on each
+	// retransmission.
+	pmpInitialTimeout = 250 * time.Millisecond
+	pmpMaxRetries     = 5
+)
+
+// pmpResultCodes are the errors returned by the NAT-PMP servers.
+var pmpResultCodes = map[uint16]string{
+	1: "unsupported version",
+	2: "not authorized or refused",
+	3: "network failure",
+	4: "out of resources",
+	5: "unsupported opcode",
+}
+
+// pmpGateway is a NAT gateway supporting NAT-PMP.
+type pmpGateway struct {
+	addr string // host:port
+}
+
+var _ Interface = (*pmpGateway)(nil)
+
+func (g *pmpGateway) String() string {
+	return fmt.Sprintf("NAT-PMP(%s)", g.addr)
+}
+
+// ExternalIP implements Interface.
+func (g *pmpGateway) ExternalIP(ctx context.Context) (net.IP, error) {
+	resp, err := g.request(ctx, []byte{pmpVersion, pmpOpExternalAddress}, 12)
+	if err != nil {
+		return nil, err
+	}
+	return net.IP(resp[8:12]), nil
+}
+
+// AddPortMapping implements Interface.
+func (g *pmpGateway) AddPortMapping(
+	ctx context.Context,
+	protocol string,
+	extPort, intPort int,
+	_ string,
+	lifetime time.Duration,
+) (int, error) {
+	resp, err := g.mapPort(ctx, protocol, extPort, intPort, lifetime)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(resp[10:12])), nil
+}
+
+// DeletePortMapping implements Interface. The mappings are deleted by
+// mapping the internal port for a lifetime of 0.
+func (g *pmpGateway) DeletePortMapping(ctx context.Context, protocol string, _, intPort int) error {
+	_, err := g.mapPort(ctx, protocol, 0, intPort, 0)
+	return err
+}
+
+func (g *pmpGateway) mapPort(
+	ctx context.Context,
+	protocol string,
+	extPort, intPort int,
+	lifetime time.Duration,
+) ([]byte, error) {
+	var op byte
+	switch protocol {
+	case TCP:
+		op = pmpOpMapTCP
+	case UDP:
+		op = pmpOpMapUDP
+	default:
+		return nil, fmt.Errorf("unknown protocol %q", protocol)
+	}
+	req := make([]byte, 12)
+	req[0], req[1] = pmpVersion, op
+	binary.BigEndian.PutUint16(req[4:6], uint16(intPort))
+	binary.BigEndian.PutUint16(req[6:8], uint16(extPort))
+	binary.BigEndian.PutUint32(req[8:12], uint32(lifetime/time.Second))
+	return g.request(ctx, req, 16)
+}
+
+// request sends the request to the gateway, retransmitting it until a response
+// of the given size is received.
+func (g *pmpGateway) request(ctx context.Context, req []byte, respSize int) ([]byte, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp4", g.addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	timeout := pmpInitialTimeout
+	resp := make([]byte, 16)
+	for i := 0; i < pmpMaxRetries; i++ {
+		if _, err := conn.Write(req); err != nil {
+			return nil, err
+		}
+		deadline := time.Now().Add(timeout)
+		if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+			deadline = d
+		}
+		if err := conn.SetReadDeadline(deadline); err != nil {
+			return nil, err
+		}
+		n, err := conn.Read(resp)
+		if err != nil {
+			var netErr net.Error
+			if !errors.As(err, &netErr) || !netErr.Timeout() {
+				return nil, err
+			}
+			if d, ok := ctx.Deadline(); ok && !time.Now().Before(d) {
+				return nil, context.DeadlineExceeded
+			}
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			timeout *= 2
+			continue
+		}
+		if n < respSize || resp[0] != pmpVersion || resp[1] != req[1]|0x80 {
+			return nil, fmt.Errorf("invalid NAT-PMP response %X", resp[:n])
+		}
+		if code := binary.BigEndian.Uint16(resp[2:4]); code != 0 {
+			return nil, fmt.Errorf("NAT-PMP request failed: %s (%d)", pmpResultCodes[code], code)
+		}
+		return resp[:n], nil
+	}
+	return nil, errors.New("NAT-PMP request timed out")
+}
+
+// discoverPMP looks for a NAT-PMP server on the likely gateways of the local
+// networks, the first address of each network.
+func discoverPMP(ctx context.Context) (Interface, error) {
+	gateways, err := potentialGateways()
+	if err != nil {
+		return nil, err
+	}
+	if len(gateways) == 0 {
+		return nil, errors.New("NAT-PMP: no private network")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	found := make(chan *pmpGateway, len(gateways))
+	for _, ip := range gateways {
+		go func(ip net.IP) {
+			g := &pmpGateway{addr: net.JoinHostPort(ip.String(), strconv.Itoa(pmpPort))}
+			if _, err := g.ExternalIP(ctx); err != nil {
+				found <- nil
+				return
+			}
+			found <- g
+		}(ip)
+	}
+	for range gateways {
+		if g := <-found; g != nil {
+			return g, nil
+		}
+	}
+	return nil, errors.New("NAT-PMP: no gateway answered")
+}
+
+// potentialGateways returns the first address of the private IPv4 networks of
+// the interfaces of the node.
+func potentialGateways() ([]net.IP, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	var gateways []net.IP
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+			ip := ipNet.IP.To4()
+			if ip == nil || !ip.IsPrivate() {
+				continue
+			}
+			gateway := ip.Mask(ipNet.Mask)
+			gateway[3] |= 1
+			gateways = append(gateways, gateway)
+		}
+	}
+	return gateways, nil
+}
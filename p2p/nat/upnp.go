@@ -0,0 +1,303 @@
+package nat
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ssdpAddr       = "239.255.255.250:1900"
+	upnpDeviceType = "urn:schemas-upnp-org:device:InternetGatewayDevice:1"
+
+	// upnpMaxResponseSize bounds the size of the responses of the gateways.
+	upnpMaxResponseSize = 1 << 20
+)
+
+// upnpServiceTypes are the services of the gateways mapping the ports, in
+// order of preference.
+var upnpServiceTypes = []string{
+	"urn:schemas-upnp-org:service:WANIPConnection:2",
+	"urn:schemas-upnp-org:service:WANIPConnection:1",
+	"urn:schemas-upnp-org:service:WANPPPConnection:1",
+}
+
+// upnpGateway is a NAT gateway supporting UPnP, an Internet Gateway Device.
+type upnpGateway struct {
+	controlURL  string
+	serviceType string
+	// localIP is the address of the node on the route to the gateway, to
+	// which the ports are mapped.
+	localIP net.IP
+	client  *http.Client
+}
+
+var _ Interface = (*upnpGateway)(nil)
+
+func (g *upnpGateway) String() string {
+	return fmt.Sprintf("UPnP(%s)", g.controlURL)
+}
+
+// ExternalIP implements Interface.
+func (g *upnpGateway) ExternalIP(ctx context.Context) (net.IP, error) {
+	resp, err := g.soapRequest(ctx, "GetExternalIPAddress", nil)
+	if err != nil {
+		return nil, err
+	}
+	ipStr, err := soapResponseValue(resp, "NewExternalIPAddress")
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid external address %q", ipStr)
+	}
+	return ip, nil
+}
+
+// AddPortMapping implements Interface. The gateways map the requested
+// external port, or fail.
+func (g *upnpGateway) AddPortMapping(
+	ctx context.Context,
+	protocol string,
+	extPort, intPort int,
+	desc string,
+	lifetime time.Duration,
+) (int, error) {
+	_, err := g.soapRequest(ctx, "AddPortMapping", [][2]string{
+		{"NewRemoteHost", ""},
+		{"NewExternalPort", strconv.Itoa(extPort)},
+		{"NewProtocol", protocol},
+		{"NewInternalPort", strconv.Itoa(intPort)},
+		{"NewInternalClient", g.localIP.String()},
+		{"NewEnabled", "1"},
+		{"NewPortMappingDescription", desc},
+		{"NewLeaseDuration", strconv.Itoa(int(lifetime / time.Second))},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return extPort, nil
+}
+
+// DeletePortMapping implements Interface.
+func (g *upnpGateway) DeletePortMapping(ctx context.Context, protocol string, extPort, _ int) error {
+	_, err := g.soapRequest(ctx, "DeletePortMapping", [][2]string{
+		{"NewRemoteHost", ""},
+		{"NewExternalPort", strconv.Itoa(extPort)},
+		{"NewProtocol", protocol},
+	})
+	return err
+}
+
+// soapRequest calls the action of the service of the gateway with the given
+// arguments, in order, and returns the response body.
+func (g *upnpGateway) soapRequest(ctx context.Context, action string, args [][2]string) ([]byte, error) {
+	var body bytes.Buffer
+	body.WriteString(`<?xml version="1.0"?>` +
+		`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" ` +
+		`s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><s:Body>`)
+	fmt.Fprintf(&body, `<u:%s xmlns:u="%s">`, action, g.serviceType)
+	for _, arg := range args {
+		fmt.Fprintf(&body, "<%s>", arg[0])
+		if err := xml.EscapeText(&body, []byte(arg[1])); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&body, "</%s>", arg[0])
+	}
+	fmt.Fprintf(&body, `</u:%s></s:Body></s:Envelope>`, action)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.controlURL, &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+	req.Header.Set("SOAPAction", fmt.Sprintf(`"%s#%s"`, g.serviceType, action))
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, upnpMaxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		if desc, err := soapResponseValue(respBody, "errorDescription"); err == nil {
+			return nil, fmt.Errorf("UPnP %s failed: %s", action, desc)
+		}
+		return nil, fmt.Errorf("UPnP %s failed: %s", action, resp.Status)
+	}
+	return respBody, nil
+}
+
+// soapResponseValue returns the text of the first element of the response
+// with the given name.
+func soapResponseValue(resp []byte, name string) (string, error) {
+	dec := xml.NewDecoder(bytes.NewReader(resp))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", fmt.Errorf("no %s in response: %w", name, err)
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local == name {
+			var value string
+			if err := dec.DecodeElement(&value, &start); err != nil {
+				return "", err
+			}
+			return strings.TrimSpace(value), nil
+		}
+	}
+}
+
+// upnpDevice is a device of the description of a gateway.
+type upnpDevice struct {
+	Services []struct {
+		ServiceType string `xml:"serviceType"`
+		ControlURL  string `xml:"controlURL"`
+	} `xml:"serviceList>service"`
+	Devices []upnpDevice `xml:"deviceList>device"`
+}
+
+// newUPnPGateway fetches the description of the gateway at the given location
+// and looks for a service mapping the ports.
+func newUPnPGateway(ctx context.Context, location string) (*upnpGateway, error) {
+	client := &http.Client{Timeout: requestTimeout}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get UPnP device description: %s", resp.Status)
+	}
+	var root struct {
+		URLBase string     `xml:"URLBase"`
+		Device  upnpDevice `xml:"device"`
+	}
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, upnpMaxResponseSize)).Decode(&root); err != nil {
+		return nil, fmt.Errorf("invalid UPnP device description: %w", err)
+	}
+
+	base, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	if root.URLBase != "" {
+		if base, err = url.Parse(root.URLBase); err != nil {
+			return nil, fmt.Errorf("invalid URLBase: %w", err)
+		}
+	}
+
+	for _, serviceType := range upnpServiceTypes {
+		controlURL, ok := findUPnPService(root.Device, serviceType)
+		if !ok {
+			continue
+		}
+		ref, err := url.Parse(controlURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid controlURL: %w", err)
+		}
+		u := base.ResolveReference(ref)
+		localIP, err := localIPTo(u.Hostname())
+		if err != nil {
+			return nil, err
+		}
+		return &upnpGateway{
+			controlURL:  u.String(),
+			serviceType: serviceType,
+			localIP:     localIP,
+			client:      client,
+		}, nil
+	}
+	return nil, errors.New("UPnP device has no WAN connection service")
+}
+
+// findUPnPService returns the control URL of the service of the given type of
+// the device, or of its embedded devices.
+func findUPnPService(device upnpDevice, serviceType string) (string, bool) {
+	for _, service := range device.Services {
+		if service.ServiceType == serviceType {
+			return service.ControlURL, true
+		}
+	}
+	for _, embedded := range device.Devices {
+		if controlURL, ok := findUPnPService(embedded, serviceType); ok {
+			return controlURL, true
+		}
+	}
+	return "", false
+}
+
+// discoverUPnP looks for an Internet Gateway Device with SSDP, and returns
+// the first one supporting the port mappings.
+func discoverUPnP(ctx context.Context) (Interface, error) {
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	dst, err := net.ResolveUDPAddr("udp4", ssdpAddr)
+	if err != nil {
+		return nil, err
+	}
+	search := "M-SEARCH * HTTP/1.1\r\n" +
+		"HOST: " + ssdpAddr + "\r\n" +
+		"ST: " + upnpDeviceType + "\r\n" +
+		"MAN: \"ssdp:discover\"\r\n" +
+		"MX: 2\r\n\r\n"
+	if _, err := conn.WriteTo([]byte(search), dst); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 2048)
+	var lastErr error
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				if lastErr != nil {
+					return nil, lastErr
+				}
+				return nil, fmt.Errorf("UPnP: no gateway answered: %w", ctx.Err())
+			}
+			return nil, err
+		}
+		resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(buf[:n])), nil)
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+		location := resp.Header.Get("Location")
+		if resp.StatusCode != http.StatusOK || location == "" ||
+			!strings.Contains(resp.Header.Get("St"), "InternetGatewayDevice") {
+			continue
+		}
+		g, err := newUPnPGateway(ctx, location)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return g, nil
+	}
+}
@@ -5,6 +5,7 @@
 package p2p
 
 import (
+	"context"
 	"encoding/hex"
 	"errors"
 	"flag"
@@ -26,6 +27,10 @@ type NetAddress struct {
 	ID   ID     `json:"id"`
 	IP   net.IP `json:"ip"`
 	Port uint16 `json:"port"`
+
+	// dialIPs are the IPs dialed concurrently, in order, if the host of the
+	// address resolved to both IPv4 and IPv6 addresses.
+	dialIPs []net.IP
 }
 
 // IDAddressString returns id@hostPort. It strips the leading
@@ -92,6 +97,7 @@ func NewNetAddressString(addr string) (*NetAddress, error) {
 			errors.New("host is empty")}
 	}
 
+	var dialIPs []net.IP
 	ip := net.ParseIP(host)
 	if ip == nil {
 		ips, err := net.LookupIP(host)
@@ -99,6 +105,7 @@ func NewNetAddressString(addr string) (*NetAddress, error) {
 			return nil, ErrNetAddressLookup{host, err}
 		}
 		ip = ips[0]
+		dialIPs = dualStackDialOrder(ips)
 	}
 
 	port, err := strconv.ParseUint(portStr, 10, 16)
@@ -108,6 +115,7 @@ func NewNetAddressString(addr string) (*NetAddress, error) {
 
 	na := NewNetAddressIPPort(ip, uint16(port))
 	na.ID = id
+	na.dialIPs = dialIPs
 	return na, nil
 }
 
@@ -231,8 +239,12 @@ func (na *NetAddress) DialString() string {
 	)
 }
 
-// Dial calls net.Dial on the address.
+// Dial calls net.Dial on the address. If the host of the address resolved to
+// both IPv4 and IPv6 addresses, they are raced as in DialTimeout.
 func (na *NetAddress) Dial() (net.Conn, error) {
+	if len(na.dialIPs) > 0 {
+		return dialRace(context.Background(), na.dialIPs, na.Port, dialFallbackDelay)
+	}
 	conn, err := net.Dial("tcp", na.DialString())
 	if err != nil {
 		return nil, err
@@ -240,8 +252,16 @@ func (na *NetAddress) Dial() (net.Conn, error) {
 	return conn, nil
 }
 
-// DialTimeout calls net.DialTimeout on the address.
+// DialTimeout calls net.DialTimeout on the address. If the host of the
+// address resolved to both IPv4 and IPv6 addresses, they are dialed one after
+// the other with a short delay, without waiting for the previous dials to
+// fail, and the first established connection is kept.
 func (na *NetAddress) DialTimeout(timeout time.Duration) (net.Conn, error) {
+	if len(na.dialIPs) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		return dialRace(ctx, na.dialIPs, na.Port, dialFallbackDelay)
+	}
 	conn, err := net.DialTimeout("tcp", na.DialString(), timeout)
 	if err != nil {
 		return nil, err
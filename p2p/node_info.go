@@ -15,6 +15,9 @@ import (
 const (
 	maxNodeInfoSize = 10240 // 10KB
 	maxNumChannels  = 16    // plenty of room for upgrades, for now
+
+	maxNumCapabilities = 32
+	maxCapabilityLen   = 64
 )
 
 // Max size of the NodeInfo struct
@@ -93,6 +96,10 @@ type DefaultNodeInfo struct {
 	// ASCIIText fields
 	Moniker string               `json:"moniker"` // arbitrary moniker
 	Other   DefaultNodeInfoOther `json:"other"`   // other application specific data
+
+	// Capabilities are the optional wire features this node supports (see
+	// PeerHasCapability). Nodes that predate capabilities advertise none.
+	Capabilities []string `json:"capabilities,omitempty"`
 }
 
 // DefaultNodeInfoOther is the misc. applcation specific data
@@ -170,6 +177,22 @@ func (info DefaultNodeInfo) Validate() error {
 		return fmt.Errorf("info.Other.RPCAddress=%v must be valid ASCII text without tabs", rpcAddr)
 	}
 
+	// Validate Capabilities - ensure max and check for duplicates.
+	if len(info.Capabilities) > maxNumCapabilities {
+		return fmt.Errorf("info.Capabilities is too long (%v). Max is %v", len(info.Capabilities), maxNumCapabilities)
+	}
+	capabilities := make(map[string]struct{}, len(info.Capabilities))
+	for _, c := range info.Capabilities {
+		if len(c) > maxCapabilityLen || !cmtstrings.IsASCIIText(c) || cmtstrings.ASCIITrim(c) != c {
+			return fmt.Errorf("info.Capabilities must be non-empty ASCII text of at most %v characters, "+
+				"without tabs or surrounding spaces, but got %q", maxCapabilityLen, c)
+		}
+		if _, ok := capabilities[c]; ok {
+			return fmt.Errorf("info.Capabilities contains duplicate capability %v", c)
+		}
+		capabilities[c] = struct{}{}
+	}
+
 	return nil
 }
 
@@ -227,6 +250,16 @@ func (info DefaultNodeInfo) HasChannel(chID byte) bool {
 	return bytes.Contains(info.Channels, []byte{chID})
 }
 
+// HasCapability returns true if the node advertises the given capability.
+func (info DefaultNodeInfo) HasCapability(capability string) bool {
+	for _, c := range info.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
 func (info DefaultNodeInfo) ToProto() *tmp2p.DefaultNodeInfo {
 
 	dni := new(tmp2p.DefaultNodeInfo)
@@ -246,6 +279,7 @@ func (info DefaultNodeInfo) ToProto() *tmp2p.DefaultNodeInfo {
 		TxIndex:    info.Other.TxIndex,
 		RPCAddress: info.Other.RPCAddress,
 	}
+	dni.Capabilities = info.Capabilities
 
 	return dni
 }
@@ -270,6 +304,7 @@ func DefaultNodeInfoFromToProto(pb *tmp2p.DefaultNodeInfo) (DefaultNodeInfo, err
 			TxIndex:    pb.Other.TxIndex,
 			RPCAddress: pb.Other.RPCAddress,
 		},
+		Capabilities: pb.Capabilities,
 	}
 
 	return dni, nil
@@ -1,9 +1,12 @@
 package p2p
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/crypto/ed25519"
 )
@@ -26,6 +29,11 @@ func TestNodeInfoValidate(t *testing.T) {
 	emptyTab := "\t"
 	emptySpace := "  "
 
+	tooManyCapabilities := make([]string, maxNumCapabilities+1)
+	for i := range tooManyCapabilities {
+		tooManyCapabilities[i] = fmt.Sprintf("cap-%d", i)
+	}
+
 	testCases := []struct {
 		testName         string
 		malleateNodeInfo func(*DefaultNodeInfo)
@@ -64,6 +72,16 @@ func TestNodeInfoValidate(t *testing.T) {
 		{"Empty space RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = emptySpace }, true},
 		{"Empty RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = "" }, false},
 		{"Good RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = "0.0.0.0:26657" }, false},
+
+		{"Too Many Capabilities", func(ni *DefaultNodeInfo) { ni.Capabilities = tooManyCapabilities }, true},
+		{"Duplicate Capability", func(ni *DefaultNodeInfo) { ni.Capabilities = []string{"a", "b", "a"} }, true},
+		{"Empty Capability", func(ni *DefaultNodeInfo) { ni.Capabilities = []string{""} }, true},
+		{"Non-ASCII Capability", func(ni *DefaultNodeInfo) { ni.Capabilities = []string{nonASCII} }, true},
+		{"Padded Capability", func(ni *DefaultNodeInfo) { ni.Capabilities = []string{" a"} }, true},
+		{"Too Long Capability", func(ni *DefaultNodeInfo) {
+			ni.Capabilities = []string{strings.Repeat("a", maxCapabilityLen+1)}
+		}, true},
+		{"Good Capabilities", func(ni *DefaultNodeInfo) { ni.Capabilities = []string{"mempool/tx-have-want"} }, false},
 	}
 
 	nodeKey := NodeKey{PrivKey: ed25519.GenPrivKey()}
@@ -126,3 +144,38 @@ func TestNodeInfoCompatible(t *testing.T) {
 		assert.Error(t, ni1.CompatibleWith(ni))
 	}
 }
+
+type capabilityReactor struct {
+	*TestReactor
+	capabilities []string
+}
+
+func (r capabilityReactor) Capabilities() []string { return r.capabilities }
+
+func TestNodeInfoCapabilities(t *testing.T) {
+	nodeKey := NodeKey{PrivKey: ed25519.GenPrivKey()}
+
+	reactors := []Reactor{
+		capabilityReactor{NewTestReactor(nil, false), []string{"b", "a"}},
+		NewTestReactor(nil, false),
+		capabilityReactor{NewTestReactor(nil, false), []string{"a", "c"}},
+	}
+	ni := testNodeInfo(nodeKey.ID(), "testing").(DefaultNodeInfo)
+	ni.Capabilities = ReactorCapabilities(reactors...)
+	require.Equal(t, []string{"a", "b", "c"}, ni.Capabilities)
+	require.NoError(t, ni.Validate())
+
+	// Capabilities survive the handshake encoding.
+	decoded, err := DefaultNodeInfoFromToProto(ni.ToProto())
+	require.NoError(t, err)
+	require.Equal(t, ni, decoded)
+
+	p := &peer{nodeInfo: decoded}
+	assert.True(t, PeerHasCapability(p, "b"))
+	assert.False(t, PeerHasCapability(p, "d"))
+
+	// Peers that predate capabilities support none.
+	p = &peer{nodeInfo: testNodeInfo(nodeKey.ID(), "testing")}
+	assert.False(t, PeerHasCapability(p, "a"))
+	assert.NoError(t, ni.CompatibleWith(p.NodeInfo()))
+}
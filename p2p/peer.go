@@ -49,6 +49,26 @@ type Peer interface {
 
 //----------------------------------------------------------
 
+// multiplexConn is the connection multiplexing the channels of a peer: an
+// MConnection over TCP, or a QUICConnection.
+type multiplexConn interface {
+	service.Service
+	FlushStop()
+
+	Status() cmtconn.ConnectionStatus
+	Send(chID byte, msgBytes []byte) bool
+	TrySend(chID byte, msgBytes []byte) bool
+	CanSend(chID byte) bool
+	SetChannelPriority(chID byte, priority int) bool
+}
+
+var (
+	_ multiplexConn = (*cmtconn.MConnection)(nil)
+	_ multiplexConn = (*cmtconn.QUICConnection)(nil)
+)
+
+//----------------------------------------------------------
+
 // peerConn contains the raw connection and its config.
 type peerConn struct {
 	outbound   bool
@@ -110,7 +130,7 @@ type peer struct {
 
 	// raw peerConn and the multiplex connection
 	peerConn
-	mconn *cmtconn.MConnection
+	mconn multiplexConn
 
 	// peer's node info and the channel it knows about
 	// channels = nodeInfo.Channels
@@ -152,15 +172,27 @@ func newPeer(
 		mlc:           mlc,
 	}
 
-	p.mconn = createMConnection(
-		pc.conn,
-		p,
-		reactorsByCh,
-		msgTypeByChID,
-		chDescs,
-		onPeerError,
-		mConfig,
-	)
+	if qc, ok := pc.conn.(*quicConn); ok {
+		p.mconn = createQUICConnection(
+			qc,
+			p,
+			reactorsByCh,
+			msgTypeByChID,
+			chDescs,
+			onPeerError,
+			mConfig,
+		)
+	} else {
+		p.mconn = createMConnection(
+			pc.conn,
+			p,
+			reactorsByCh,
+			msgTypeByChID,
+			chDescs,
+			onPeerError,
+			mConfig,
+		)
+	}
 	p.BaseService = *service.NewBaseService(nil, "Peer", p)
 	for _, option := range options {
 		option(p)
@@ -289,6 +321,7 @@ func (p *peer) send(chID byte, msg proto.Message, sendFunc func(byte, []byte) bo
 			"chID", fmt.Sprintf("%#x", chID),
 		}
 		p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+		p.metrics.PeerSendMessagesTotal.With(labels...).Add(1)
 		p.metrics.MessageSendBytesTotal.With("message_type", metricLabelValue).Add(float64(len(msgBytes)))
 	}
 	return res
@@ -359,6 +392,12 @@ func (p *peer) CanSend(chID byte) bool {
 	return p.mconn.CanSend(chID)
 }
 
+// SetChannelPriority sets the send priority of a channel of the peer. It
+// returns false if the channel is unknown or the priority isn't positive.
+func (p *peer) SetChannelPriority(chID byte, priority int) bool {
+	return p.mconn.SetChannelPriority(chID, priority)
+}
+
 //---------------------------------------------------
 
 func PeerMetrics(metrics *Metrics) PeerOption {
@@ -375,10 +414,22 @@ func (p *peer) metricsReporter() {
 			var sendQueueSize float64
 			for _, chStatus := range status.Channels {
 				sendQueueSize += float64(chStatus.SendQueueSize)
+				p.metrics.PeerChannelPendingSendMessages.With(
+					"peer_id", string(p.ID()),
+					"chID", fmt.Sprintf("%#x", chStatus.ID),
+				).Set(float64(chStatus.SendQueueSize))
 			}
 
 			p.metrics.PeerPendingSendBytes.With("peer_id", string(p.ID())).Set(sendQueueSize)
 		case <-p.Quit():
+			// Do not report the queues of the disconnected peer as pending.
+			for _, chStatus := range p.mconn.Status().Channels {
+				p.metrics.PeerChannelPendingSendMessages.With(
+					"peer_id", string(p.ID()),
+					"chID", fmt.Sprintf("%#x", chStatus.ID),
+				).Set(0)
+			}
+			p.metrics.PeerPendingSendBytes.With("peer_id", string(p.ID())).Set(0)
 			return
 		}
 	}
@@ -396,8 +447,49 @@ func createMConnection(
 	onPeerError func(Peer, interface{}),
 	config cmtconn.MConnConfig,
 ) *cmtconn.MConnection {
+	onError := func(r interface{}) {
+		onPeerError(p, r)
+	}
+
+	return cmtconn.NewMConnectionWithConfig(
+		conn,
+		chDescs,
+		peerReceiveFunc(p, reactorsByCh, msgTypeByChID),
+		onError,
+		config,
+	)
+}
 
-	onReceive := func(chID byte, msgBytes []byte) {
+func createQUICConnection(
+	conn *quicConn,
+	p *peer,
+	reactorsByCh map[byte]Reactor,
+	msgTypeByChID map[byte]proto.Message,
+	chDescs []*cmtconn.ChannelDescriptor,
+	onPeerError func(Peer, interface{}),
+	config cmtconn.MConnConfig,
+) *cmtconn.QUICConnection {
+	onError := func(r interface{}) {
+		onPeerError(p, r)
+	}
+
+	return cmtconn.NewQUICConnection(
+		conn.conn,
+		chDescs,
+		peerReceiveFunc(p, reactorsByCh, msgTypeByChID),
+		onError,
+		config,
+	)
+}
+
+// peerReceiveFunc returns the function decoding the messages received from
+// the peer and passing them to the reactors of their channels.
+func peerReceiveFunc(
+	p *peer,
+	reactorsByCh map[byte]Reactor,
+	msgTypeByChID map[byte]proto.Message,
+) func(chID byte, msgBytes []byte) {
+	return func(chID byte, msgBytes []byte) {
 		reactor := reactorsByCh[chID]
 		if reactor == nil {
 			// Note that its ok to panic here as it's caught in the conn._recover,
@@ -421,6 +513,7 @@ func createMConnection(
 			}
 		}
 		p.metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+		p.metrics.PeerReceiveMessagesTotal.With(labels...).Add(1)
 		p.metrics.MessageReceiveBytesTotal.With("message_type", p.mlc.ValueToMetricLabel(msg)).Add(float64(len(msgBytes)))
 		reactor.Receive(Envelope{
 			ChannelID: chID,
@@ -428,16 +521,4 @@ func createMConnection(
 			Message:   msg,
 		})
 	}
-
-	onError := func(r interface{}) {
-		onPeerError(p, r)
-	}
-
-	return cmtconn.NewMConnectionWithConfig(
-		conn,
-		chDescs,
-		onReceive,
-		onError,
-		config,
-	)
 }
@@ -0,0 +1,168 @@
+package p2p
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/libs/service"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// peerAllowlistReloadInterval is the interval at which the peer allowlist
+// file is checked for changes.
+const peerAllowlistReloadInterval = 10 * time.Second
+
+// errNotAllowed is the error of the peers rejected by the peer allowlist.
+var errNotAllowed = errors.New("not in the peer allowlist")
+
+// signedPeerAllowlist is the JSON content of a peer allowlist file.
+type signedPeerAllowlist struct {
+	PeerIDs   []ID   `json:"peer_ids"`
+	Signature []byte `json:"signature"`
+}
+
+// PeerAllowlistSignBytes returns the bytes signed by the operator of the
+// nodes to allow the given peer IDs, in any order.
+func PeerAllowlistSignBytes(ids []ID) []byte {
+	sorted := make([]string, len(ids))
+	for i, id := range ids {
+		sorted[i] = strings.ToLower(string(id))
+	}
+	sort.Strings(sorted)
+	return []byte("cometbft-peer-allowlist:\n" + strings.Join(sorted, "\n"))
+}
+
+// SignPeerAllowlist returns the content of a peer allowlist file allowing the
+// given peer IDs, signed with the given key.
+func SignPeerAllowlist(privKey crypto.PrivKey, ids []ID) ([]byte, error) {
+	for _, id := range ids {
+		if err := validateID(id); err != nil {
+			return nil, fmt.Errorf("invalid peer ID %q: %w", id, err)
+		}
+	}
+	sig, err := privKey.Sign(PeerAllowlistSignBytes(ids))
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(signedPeerAllowlist{PeerIDs: ids, Signature: sig}, "", "  ")
+}
+
+// PeerAllowlist is the set of the IDs of the only peers that a node can be
+// connected to, loaded from a file signed by a trusted key. The file is
+// reloaded when it changes, as long as its new content is correctly signed.
+type PeerAllowlist struct {
+	service.BaseService
+
+	file   string
+	pubKey crypto.PubKey
+
+	mtx     cmtsync.RWMutex
+	content []byte
+	ids     map[ID]struct{}
+
+	// onUpdate is called after the list is reloaded.
+	onUpdate func()
+}
+
+// NewPeerAllowlist loads the peer allowlist file, and checks that it is
+// signed by the given key.
+func NewPeerAllowlist(file string, pubKey crypto.PubKey) (*PeerAllowlist, error) {
+	al := &PeerAllowlist{
+		file:   file,
+		pubKey: pubKey,
+	}
+	al.BaseService = *service.NewBaseService(nil, "PeerAllowlist", al)
+	if _, err := al.Reload(); err != nil {
+		return nil, err
+	}
+	return al, nil
+}
+
+// Allowed returns true if the peer with the given ID is in the list.
+func (al *PeerAllowlist) Allowed(id ID) bool {
+	al.mtx.RLock()
+	defer al.mtx.RUnlock()
+	_, ok := al.ids[id]
+	return ok
+}
+
+// Size returns the number of peers in the list.
+func (al *PeerAllowlist) Size() int {
+	al.mtx.RLock()
+	defer al.mtx.RUnlock()
+	return len(al.ids)
+}
+
+// Reload reads the file again, and replaces the list if the file changed.
+// It returns true if the list was replaced. The list is kept unchanged if the
+// new content of the file is invalid.
+func (al *PeerAllowlist) Reload() (bool, error) {
+	content, err := os.ReadFile(al.file)
+	if err != nil {
+		return false, fmt.Errorf("failed to read the peer allowlist: %w", err)
+	}
+
+	al.mtx.RLock()
+	unchanged := al.ids != nil && bytes.Equal(content, al.content)
+	al.mtx.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	var list signedPeerAllowlist
+	if err := json.Unmarshal(content, &list); err != nil {
+		return false, fmt.Errorf("invalid peer allowlist %s: %w", al.file, err)
+	}
+	if !al.pubKey.VerifySignature(PeerAllowlistSignBytes(list.PeerIDs), list.Signature) {
+		return false, fmt.Errorf("invalid peer allowlist %s: invalid signature", al.file)
+	}
+	ids := make(map[ID]struct{}, len(list.PeerIDs))
+	for _, id := range list.PeerIDs {
+		if err := validateID(id); err != nil {
+			return false, fmt.Errorf("invalid peer ID %q in the peer allowlist: %w", id, err)
+		}
+		ids[ID(strings.ToLower(string(id)))] = struct{}{}
+	}
+
+	al.mtx.Lock()
+	al.content, al.ids = content, ids
+	al.mtx.Unlock()
+	return true, nil
+}
+
+// OnStart implements service.Service by reloading the list when the file
+// changes.
+func (al *PeerAllowlist) OnStart() error {
+	go al.reloadRoutine()
+	return nil
+}
+
+func (al *PeerAllowlist) reloadRoutine() {
+	ticker := time.NewTicker(peerAllowlistReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			reloaded, err := al.Reload()
+			if err != nil {
+				al.Logger.Error("Failed to reload the peer allowlist, keeping the previous one", "err", err)
+				continue
+			}
+			if reloaded {
+				al.Logger.Info("Reloaded the peer allowlist", "peers", al.Size())
+				if al.onUpdate != nil {
+					al.onUpdate()
+				}
+			}
+		case <-al.Quit():
+			return
+		}
+	}
+}
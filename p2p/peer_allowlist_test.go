@@ -0,0 +1,65 @@
+package p2p
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+)
+
+func writePeerAllowlist(t *testing.T, file string, privKey crypto.PrivKey, ids ...ID) {
+	t.Helper()
+	content, err := SignPeerAllowlist(privKey, ids)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, content, 0o600))
+}
+
+func TestPeerAllowlist(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	file := filepath.Join(t.TempDir(), "peer_allowlist.json")
+	id1, id2 := PubKeyToID(ed25519.GenPrivKey().PubKey()), PubKeyToID(ed25519.GenPrivKey().PubKey())
+
+	writePeerAllowlist(t, file, privKey, ID(strings.ToUpper(string(id1))))
+	al, err := NewPeerAllowlist(file, privKey.PubKey())
+	require.NoError(t, err)
+	assert.True(t, al.Allowed(id1))
+	assert.False(t, al.Allowed(id2))
+
+	reloaded, err := al.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	writePeerAllowlist(t, file, privKey, id2)
+	reloaded, err = al.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.False(t, al.Allowed(id1))
+	assert.True(t, al.Allowed(id2))
+
+	// A list signed by another key is rejected, and the previous one kept.
+	writePeerAllowlist(t, file, ed25519.GenPrivKey(), id1, id2)
+	_, err = al.Reload()
+	require.ErrorContains(t, err, "invalid signature")
+	assert.False(t, al.Allowed(id1))
+	assert.True(t, al.Allowed(id2))
+	_, err = NewPeerAllowlist(file, privKey.PubKey())
+	require.ErrorContains(t, err, "invalid signature")
+
+	// So is a list whose IDs were changed after it was signed.
+	writePeerAllowlist(t, file, privKey, id2)
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, []byte(strings.Replace(string(content), string(id2), string(id1), 1)), 0o600))
+	_, err = al.Reload()
+	require.ErrorContains(t, err, "invalid signature")
+	assert.True(t, al.Allowed(id2))
+
+	_, err = SignPeerAllowlist(privKey, []ID{"not-an-id"})
+	require.Error(t, err)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/minio/highwayhash"
 
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/libs/atrest"
 	"github.com/cometbft/cometbft/libs/log"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
@@ -77,6 +78,9 @@ type AddrBook interface {
 
 	Size() int
 
+	// The quality data of the known addresses, including the banned ones
+	KnownAddresses() []KnownAddressInfo
+
 	// Persist to disk
 	Save()
 }
@@ -105,6 +109,8 @@ type addrBook struct {
 	key               string // random prefix for bucket placement
 	routabilityStrict bool
 	hasher            hash.Hash64
+	cipher            *atrest.Cipher
+	store             AddrBookStore
 
 	wg sync.WaitGroup
 }
@@ -120,7 +126,7 @@ func mustNewHasher() hash.Hash64 {
 
 // NewAddrBook creates a new address book.
 // Use Start to begin processing asynchronous address updates.
-func NewAddrBook(filePath string, routabilityStrict bool) AddrBook {
+func NewAddrBook(filePath string, routabilityStrict bool, options ...AddrBookOption) AddrBook {
 	am := &addrBook{
 		rand:              cmtrand.NewRand(),
 		ourAddrs:          make(map[string]struct{}),
@@ -132,11 +138,33 @@ func NewAddrBook(filePath string, routabilityStrict bool) AddrBook {
 	}
 	am.init()
 	am.BaseService = *service.NewBaseService(nil, "AddrBook", am)
+	for _, option := range options {
+		option(am)
+	}
+	if am.store == nil {
+		am.store = NewFileAddrBookStore(filePath, am.cipher)
+	}
 	return am
 }
 
+// AddrBookOption sets an optional parameter on the address book.
+type AddrBookOption func(*addrBook)
+
+// WithCipher encrypts the address book file at rest with the given cipher.
+// The address book saved in plaintext is still loaded, and is encrypted the
+// next time it is saved.
+func WithCipher(c *atrest.Cipher) AddrBookOption {
+	return func(a *addrBook) { a.cipher = c }
+}
+
+// WithStore saves the address book to the given store instead of the file.
+// The cipher set by WithCipher only applies to the file.
+func WithStore(store AddrBookStore) AddrBookOption {
+	return func(a *addrBook) { a.store = store }
+}
+
 // Initialize the buckets.
-// When modifying this, don't forget to update loadFromFile()
+// When modifying this, don't forget to update loadFromStore()
 func (a *addrBook) init() {
 	a.key = crypto.CRandHex(24) // 24/2 * 8 = 96 bits
 	// New addr buckets
@@ -157,7 +185,7 @@ func (a *addrBook) OnStart() error {
 	if err := a.BaseService.OnStart(); err != nil {
 		return err
 	}
-	a.loadFromFile(a.filePath)
+	a.loadFromStore()
 
 	// wg.Add to ensure that any invocation of .Wait()
 	// later on will wait for saveRoutine to terminate.
@@ -429,6 +457,21 @@ func (a *addrBook) GetSelection() []*p2p.NetAddress {
 	return allAddr[:numAddresses]
 }
 
+// KnownAddresses implements AddrBook.
+func (a *addrBook) KnownAddresses() []KnownAddressInfo {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	infos := make([]KnownAddressInfo, 0, len(a.addrLookup)+len(a.badPeers))
+	for _, ka := range a.addrLookup {
+		infos = append(infos, ka.info())
+	}
+	for _, ka := range a.badPeers {
+		infos = append(infos, ka.info())
+	}
+	return infos
+}
+
 func percentageOfNum(p, n int) int {
 	return int(math.Round((float64(p) / float64(100)) * float64(n)))
 }
@@ -489,9 +532,9 @@ func (a *addrBook) size() int {
 
 //----------------------------------------------------------
 
-// Save persists the address book to disk.
+// Save persists the address book to its store.
 func (a *addrBook) Save() {
-	a.saveToFile(a.filePath) // thread safe
+	a.saveToStore() // thread safe
 }
 
 func (a *addrBook) saveRoutine() {
@@ -502,13 +545,13 @@ out:
 	for {
 		select {
 		case <-saveFileTicker.C:
-			a.saveToFile(a.filePath)
+			a.saveToStore()
 		case <-a.Quit():
 			break out
 		}
 	}
 	saveFileTicker.Stop()
-	a.saveToFile(a.filePath)
+	a.saveToStore()
 }
 
 //----------------------------------------------------------
@@ -12,6 +12,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/libs/atrest"
 	"github.com/cometbft/cometbft/libs/log"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
@@ -92,6 +95,69 @@ func TestAddrBookSaveLoad(t *testing.T) {
 	assert.Equal(t, 100, book.Size())
 }
 
+func TestAddrBookSaveLoadEncrypted(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	c, err := atrest.NewCipher(cmtrand.Bytes(atrest.KeySize))
+	require.NoError(t, err)
+
+	book := NewAddrBook(fname, true, WithCipher(c))
+	book.SetLogger(log.TestingLogger())
+	for _, addrSrc := range randNetAddressPairs(t, 10) {
+		require.NoError(t, book.AddAddress(addrSrc.addr, addrSrc.src))
+	}
+	book.Save()
+
+	data, err := os.ReadFile(fname)
+	require.NoError(t, err)
+	require.True(t, atrest.IsEncrypted(data))
+
+	book = NewAddrBook(fname, true, WithCipher(c))
+	book.SetLogger(log.TestingLogger())
+	require.NoError(t, book.Start())
+	assert.Equal(t, 10, book.Size())
+	require.NoError(t, book.Stop())
+
+	// The encrypted address book cannot be loaded without the cipher.
+	book = NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	require.Panics(t, func() { _ = book.Start() })
+}
+
+func TestAddrBookSaveLoadDB(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+	db := dbm.NewMemDB()
+
+	// Nothing saved yet.
+	book := NewAddrBook(fname, true, WithStore(NewDBAddrBookStore(db)))
+	book.SetLogger(log.TestingLogger())
+	require.NoError(t, book.Start())
+	assert.True(t, book.Empty())
+	require.NoError(t, book.Stop())
+
+	for _, addrSrc := range randNetAddressPairs(t, 10) {
+		require.NoError(t, book.AddAddress(addrSrc.addr, addrSrc.src))
+	}
+	book.Save()
+	data, err := os.ReadFile(fname)
+	require.NoError(t, err)
+	assert.Empty(t, data, "saved to the file")
+
+	book = NewAddrBook(fname, true, WithStore(NewDBAddrBookStore(db)))
+	book.SetLogger(log.TestingLogger())
+	require.NoError(t, book.Start())
+	assert.Equal(t, 10, book.Size())
+	require.NoError(t, book.Stop())
+
+	// A corrupt address book is not loaded.
+	require.NoError(t, db.Set(addrBookKey, []byte("{")))
+	book = NewAddrBook(fname, true, WithStore(NewDBAddrBookStore(db)))
+	book.SetLogger(log.TestingLogger())
+	require.Panics(t, func() { _ = book.Start() })
+}
+
 func TestAddrBookLookup(t *testing.T) {
 	fname := createTempFileName("addrbook_test")
 	defer deleteTempFile(fname)
@@ -0,0 +1,120 @@
+package pex
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cometbft/cometbft/p2p"
+)
+
+// ASMap maps the IP prefixes to the autonomous systems (AS) announcing them,
+// to spread the addresses given out by the seeds across the networks.
+type ASMap struct {
+	// prefix length -> masked IP (16 bytes) -> AS number
+	prefixes map[int]map[string]uint32
+	// the prefix lengths in the map, longest first
+	lengths []int
+}
+
+// LoadASMap reads the AS map file, with one "<IP prefix> <AS number>" line
+// per prefix, e.g. "1.2.0.0/16 13335" or "2001:db8::/32 AS64496". The empty
+// lines and the lines starting with '#' are ignored.
+func LoadASMap(file string) (*ASMap, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	m := &ASMap{prefixes: make(map[int]map[string]uint32)}
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected \"<IP prefix> <AS number>\", got %q", file, n, line)
+		}
+		_, ipNet, err := net.ParseCIDR(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", file, n, err)
+		}
+		asn, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(fields[1]), "AS"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid AS number %q", file, n, fields[1])
+		}
+		m.add(ipNet, uint32(asn))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *ASMap) add(ipNet *net.IPNet, asn uint32) {
+	ones, bits := ipNet.Mask.Size()
+	if bits == 8*net.IPv4len {
+		// The IPv4 addresses are looked up in their 16 bytes form.
+		ones += 8 * (net.IPv6len - net.IPv4len)
+	}
+	if _, ok := m.prefixes[ones]; !ok {
+		m.prefixes[ones] = make(map[string]uint32)
+		m.lengths = append(m.lengths, ones)
+		sort.Sort(sort.Reverse(sort.IntSlice(m.lengths)))
+	}
+	m.prefixes[ones][string(ipNet.IP.To16())] = asn
+}
+
+// Lookup returns the AS announcing the longest prefix of the IP, or false if
+// none of the prefixes contains it.
+func (m *ASMap) Lookup(ip net.IP) (uint32, bool) {
+	ip = ip.To16()
+	if ip == nil {
+		return 0, false
+	}
+	for _, ones := range m.lengths {
+		masked := ip.Mask(net.CIDRMask(ones, 8*net.IPv6len))
+		if asn, ok := m.prefixes[ones][string(masked)]; ok {
+			return asn, true
+		}
+	}
+	return 0, false
+}
+
+// networkOf returns the AS of the address if the map is not nil and has it,
+// and its network group in the address book otherwise.
+func (m *ASMap) networkOf(addr *p2p.NetAddress) string {
+	if m != nil {
+		if asn, ok := m.Lookup(addr.IP); ok {
+			return fmt.Sprintf("AS%d", asn)
+		}
+	}
+	return groupKeyFor(addr, false)
+}
+
+// diverseSelection returns the addresses, in the same order, keeping at most
+// maxPerNetwork addresses of each AS (see ASMap.networkOf). All the addresses
+// are kept if maxPerNetwork is 0.
+func diverseSelection(addrs []*p2p.NetAddress, asMap *ASMap, maxPerNetwork int) []*p2p.NetAddress {
+	if maxPerNetwork <= 0 {
+		return addrs
+	}
+	perNetwork := make(map[string]int)
+	selection := make([]*p2p.NetAddress, 0, len(addrs))
+	for _, addr := range addrs {
+		network := asMap.networkOf(addr)
+		if perNetwork[network] >= maxPerNetwork {
+			continue
+		}
+		perNetwork[network]++
+		selection = append(selection, addr)
+	}
+	return selection
+}
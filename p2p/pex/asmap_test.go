@@ -0,0 +1,73 @@
+package pex
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/p2p"
+)
+
+func writeASMap(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "asmap.txt")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+	return file
+}
+
+func TestLoadASMap(t *testing.T) {
+	file := writeASMap(t, `# test map
+1.2.0.0/16 13335
+1.2.3.0/24 AS64496
+
+2001:db8::/32 as64497
+`)
+	asMap, err := LoadASMap(file)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		ip  string
+		asn uint32
+		ok  bool
+	}{
+		{"1.2.3.4", 64496, true},
+		{"1.2.4.4", 13335, true},
+		{"1.3.3.4", 0, false},
+		{"2001:db8::1", 64497, true},
+		{"2001:db9::1", 0, false},
+	}
+	for _, tc := range testCases {
+		asn, ok := asMap.Lookup(net.ParseIP(tc.ip))
+		assert.Equal(t, tc.ok, ok, tc.ip)
+		assert.Equal(t, tc.asn, asn, tc.ip)
+	}
+
+	for _, content := range []string{"1.2.0.0/16", "1.2.0.0 13335", "1.2.0.0/16 ASX"} {
+		_, err := LoadASMap(writeASMap(t, content))
+		require.Error(t, err, content)
+	}
+}
+
+func TestDiverseSelection(t *testing.T) {
+	addrs := make([]*p2p.NetAddress, 0, 4)
+	for _, s := range []string{"1.2.3.4:26656", "1.2.4.4:26656", "1.3.3.4:26656", "1.3.4.4:26656"} {
+		addr, err := p2p.NewNetAddressString(p2p.IDAddressString(p2p.ID("deadbeefdeadbeefdeadbeefdeadbeefdeadbeef"), s))
+		require.NoError(t, err)
+		addrs = append(addrs, addr)
+	}
+
+	assert.Equal(t, addrs, diverseSelection(addrs, nil, 0))
+
+	// Without a map, the addresses are grouped by /16.
+	assert.Equal(t, []*p2p.NetAddress{addrs[0], addrs[2]}, diverseSelection(addrs, nil, 1))
+
+	asMap, err := LoadASMap(writeASMap(t, "1.2.3.0/24 1\n1.2.4.0/24 2\n1.3.0.0/16 1\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []*p2p.NetAddress{addrs[0], addrs[1]}, diverseSelection(addrs, asMap, 1))
+	assert.Equal(t, addrs, diverseSelection(addrs, asMap, 3))
+	assert.Equal(t, "AS2", asMap.networkOf(addrs[1]))
+}
@@ -0,0 +1,117 @@
+package pex
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/p2p"
+)
+
+// The seeds of a DNS seed are published as TXT records of its domain, one
+// "cmt-seed=<ID>@<host>:<port>" record per seed, along with a single
+// "cmt-sig=<base64 signature>" record signing them all (see
+// DNSSeedsSignBytes). The other TXT records of the domain are ignored.
+const (
+	dnsSeedRecordPrefix = "cmt-seed="
+	dnsSigRecordPrefix  = "cmt-sig="
+)
+
+// DNSSeed is a domain publishing the addresses of seed nodes, signed by the
+// key of the operator of the network, so that the seeds can be rotated without
+// editing the config of the nodes.
+type DNSSeed struct {
+	PubKey crypto.PubKey
+	Domain string
+}
+
+// ParseDNSSeed parses a "<hex ed25519 public key>@<domain>" DNS seed.
+func ParseDNSSeed(s string) (DNSSeed, error) {
+	keyHex, domain, ok := strings.Cut(s, "@")
+	if !ok || domain == "" {
+		return DNSSeed{}, fmt.Errorf("invalid DNS seed %q: expected <public key>@<domain>", s)
+	}
+	key, err := hex.DecodeString(keyHex)
+	if err != nil || len(key) != ed25519.PubKeySize {
+		return DNSSeed{}, fmt.Errorf("invalid DNS seed %q: expected a hex encoded ed25519 public key", s)
+	}
+	return DNSSeed{PubKey: ed25519.PubKey(key), Domain: strings.TrimSuffix(domain, ".")}, nil
+}
+
+func (s DNSSeed) String() string {
+	return fmt.Sprintf("%X@%s", s.PubKey.Bytes(), s.Domain)
+}
+
+// DNSSeedsSignBytes returns the bytes signed by the operator of the DNS seed
+// of the given domain to publish the given seed addresses, in any order.
+func DNSSeedsSignBytes(domain string, addrs []string) []byte {
+	sorted := append([]string(nil), addrs...)
+	sort.Strings(sorted)
+	return []byte("cometbft-dns-seeds:" + strings.TrimSuffix(domain, ".") + "\n" + strings.Join(sorted, "\n"))
+}
+
+// DNSSeedRecords returns the TXT records publishing the given seed addresses
+// on the domain, signed with the given key.
+func DNSSeedRecords(privKey crypto.PrivKey, domain string, addrs []string) ([]string, error) {
+	for _, addr := range addrs {
+		if _, err := p2p.NewNetAddressString(addr); err != nil {
+			var lookupErr p2p.ErrNetAddressLookup
+			if !errors.As(err, &lookupErr) {
+				return nil, err
+			}
+		}
+	}
+	sig, err := privKey.Sign(DNSSeedsSignBytes(domain, addrs))
+	if err != nil {
+		return nil, err
+	}
+	records := make([]string, 0, len(addrs)+1)
+	for _, addr := range addrs {
+		records = append(records, dnsSeedRecordPrefix+addr)
+	}
+	return append(records, dnsSigRecordPrefix+base64.StdEncoding.EncodeToString(sig)), nil
+}
+
+// lookupTXTFunc returns the TXT records of a domain.
+type lookupTXTFunc func(ctx context.Context, domain string) ([]string, error)
+
+// resolveDNSSeed looks up the TXT records of the DNS seed, and returns the
+// seed addresses they publish if they are signed by its key.
+func resolveDNSSeed(ctx context.Context, lookupTXT lookupTXTFunc, seed DNSSeed) ([]string, error) {
+	records, err := lookupTXT(ctx, seed.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		addrs []string
+		sig   []byte
+	)
+	for _, record := range records {
+		switch {
+		case strings.HasPrefix(record, dnsSeedRecordPrefix):
+			addrs = append(addrs, strings.TrimPrefix(record, dnsSeedRecordPrefix))
+		case strings.HasPrefix(record, dnsSigRecordPrefix):
+			if sig != nil {
+				return nil, errors.New("multiple signature records")
+			}
+			sig, err = base64.StdEncoding.DecodeString(strings.TrimPrefix(record, dnsSigRecordPrefix))
+			if err != nil {
+				return nil, fmt.Errorf("invalid signature record: %w", err)
+			}
+		}
+	}
+	if sig == nil {
+		return nil, errors.New("no signature record")
+	}
+	if !seed.PubKey.VerifySignature(DNSSeedsSignBytes(seed.Domain, addrs), sig) {
+		return nil, errors.New("invalid signature")
+	}
+	return addrs, nil
+}
@@ -0,0 +1,104 @@
+package pex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/p2p"
+)
+
+func staticTXT(records ...string) lookupTXTFunc {
+	return func(context.Context, string) ([]string, error) {
+		return records, nil
+	}
+}
+
+func TestParseDNSSeed(t *testing.T) {
+	pubKey := ed25519.GenPrivKey().PubKey()
+
+	seed, err := ParseDNSSeed(fmt.Sprintf("%x@seeds.example.com.", pubKey.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, pubKey, seed.PubKey)
+	assert.Equal(t, "seeds.example.com", seed.Domain)
+
+	for _, s := range []string{
+		"seeds.example.com",
+		fmt.Sprintf("%x@", pubKey.Bytes()),
+		"abcd@seeds.example.com",
+		"zz@seeds.example.com",
+	} {
+		_, err := ParseDNSSeed(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestResolveDNSSeed(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	seed := DNSSeed{PubKey: privKey.PubKey(), Domain: "seeds.example.com"}
+	addrs := []string{
+		"0123456789abcdef0123456789abcdef01234567@1.2.3.4:26656",
+		"fedcba9876543210fedcba9876543210fedcba98@5.6.7.8:26656",
+	}
+	records, err := DNSSeedRecords(privKey, seed.Domain, addrs)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	ctx := context.Background()
+
+	// The records are verified in any order, ignoring the unrelated ones.
+	resolved, err := resolveDNSSeed(ctx, staticTXT("v=spf1 -all", records[2], records[1], records[0]), seed)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, addrs, resolved)
+
+	// A seed record added, or removed, by a third party is detected.
+	_, err = resolveDNSSeed(ctx, staticTXT(append(records, dnsSeedRecordPrefix+"evil@9.9.9.9:26656")...), seed)
+	require.ErrorContains(t, err, "invalid signature")
+	_, err = resolveDNSSeed(ctx, staticTXT(records[1:]...), seed)
+	require.ErrorContains(t, err, "invalid signature")
+
+	// The records of another domain, or signed by another key, are rejected.
+	_, err = resolveDNSSeed(ctx, staticTXT(records...), DNSSeed{PubKey: seed.PubKey, Domain: "other.example.com"})
+	require.ErrorContains(t, err, "invalid signature")
+	_, err = resolveDNSSeed(ctx, staticTXT(records...), DNSSeed{PubKey: ed25519.GenPrivKey().PubKey(), Domain: seed.Domain})
+	require.ErrorContains(t, err, "invalid signature")
+
+	_, err = resolveDNSSeed(ctx, staticTXT(records[:2]...), seed)
+	require.ErrorContains(t, err, "no signature record")
+
+	lookupErr := errors.New("no such host")
+	_, err = resolveDNSSeed(ctx, func(context.Context, string) ([]string, error) { return nil, lookupErr }, seed)
+	require.ErrorIs(t, err, lookupErr)
+}
+
+func TestPEXReactorUsesDNSSeeds(t *testing.T) {
+	// directory to store address books
+	dir, err := os.MkdirTemp("", "pex_reactor")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// 1. create seed
+	seed := testCreateSeed(dir, 0, []*p2p.NetAddress{}, []*p2p.NetAddress{})
+	require.Nil(t, seed.Start())
+	defer seed.Stop() //nolint:errcheck // ignore for tests
+
+	// 2. create usual peer with only a DNS seed publishing the seed.
+	privKey := ed25519.GenPrivKey()
+	records, err := DNSSeedRecords(privKey, "seeds.example.com", []string{seed.NetAddress().String()})
+	require.NoError(t, err)
+	peer := testCreatePeerWithConfig(dir, 1, &ReactorConfig{
+		DNSSeeds: []string{fmt.Sprintf("%X@seeds.example.com", privKey.PubKey().Bytes())},
+	})
+	peer.Reactor("pex").(*Reactor).lookupTXT = staticTXT(records...)
+	require.Nil(t, peer.Start())
+	defer peer.Stop() //nolint:errcheck // ignore for tests
+
+	// 3. check that the peer connects to seed immediately
+	assertPeersWithTimeout(t, []*p2p.Switch{peer}, 10*time.Millisecond, 3*time.Second, 1)
+}
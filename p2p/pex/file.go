@@ -3,9 +3,6 @@ package pex
 import (
 	"encoding/json"
 	"fmt"
-	"os"
-
-	"github.com/cometbft/cometbft/libs/tempfile"
 )
 
 /* Loading & Saving */
@@ -15,11 +12,11 @@ type addrBookJSON struct {
 	Addrs []*knownAddress `json:"addrs"`
 }
 
-func (a *addrBook) saveToFile(filePath string) {
+func (a *addrBook) saveToStore() {
 	a.mtx.Lock()
 	defer a.mtx.Unlock()
 
-	a.Logger.Info("Saving AddrBook to file", "size", a.size())
+	a.Logger.Info("Saving AddrBook", "size", a.size())
 
 	addrs := make([]*knownAddress, 0, len(a.addrLookup))
 	for _, ka := range a.addrLookup {
@@ -32,35 +29,31 @@ func (a *addrBook) saveToFile(filePath string) {
 
 	jsonBytes, err := json.MarshalIndent(aJSON, "", "\t")
 	if err != nil {
-		a.Logger.Error("Failed to save AddrBook to file", "err", err)
+		a.Logger.Error("Failed to save AddrBook", "err", err)
 		return
 	}
-	err = tempfile.WriteFileAtomic(filePath, jsonBytes, 0644)
-	if err != nil {
-		a.Logger.Error("Failed to save AddrBook to file", "file", filePath, "err", err)
+	if err := a.store.Save(jsonBytes); err != nil {
+		a.Logger.Error("Failed to save AddrBook", "err", err)
 	}
 }
 
-// Returns false if file does not exist.
-// cmn.Panics if file is corrupt.
-func (a *addrBook) loadFromFile(filePath string) bool {
-	// If doesn't exist, do nothing.
-	_, err := os.Stat(filePath)
-	if os.IsNotExist(err) {
+// Returns false if no address book was saved.
+// cmn.Panics if the saved address book is corrupt.
+func (a *addrBook) loadFromStore() bool {
+	jsonBytes, err := a.store.Load()
+	if err != nil {
+		panic(fmt.Sprintf("Error loading the address book: %v", err))
+	}
+	// If nothing was saved, do nothing.
+	if jsonBytes == nil {
 		return false
 	}
 
 	// Load addrBookJSON{}
-	r, err := os.Open(filePath)
-	if err != nil {
-		panic(fmt.Sprintf("Error opening file %s: %v", filePath, err))
-	}
-	defer r.Close()
 	aJSON := &addrBookJSON{}
-	dec := json.NewDecoder(r)
-	err = dec.Decode(aJSON)
+	err = json.Unmarshal(jsonBytes, aJSON)
 	if err != nil {
-		panic(fmt.Sprintf("Error reading file %s: %v", filePath, err))
+		panic(fmt.Sprintf("Error reading the address book: %v", err))
 	}
 
 	// Restore all the fields...
@@ -19,6 +19,25 @@ type knownAddress struct {
 	LastBanTime time.Time       `json:"last_ban_time"`
 }
 
+// KnownAddressInfo is the quality data of an address of the address book.
+type KnownAddressInfo struct {
+	Addr *p2p.NetAddress
+	// the peer which gave the address
+	Src *p2p.NetAddress
+	// true if the peer was successfully dialed, i.e. the address is in an
+	// old bucket
+	Old         bool
+	Attempts    int32
+	LastAttempt time.Time
+	LastSuccess time.Time
+	// zero if the address is not banned
+	BannedUntil time.Time
+	// zero if the address was never crawled by the seed
+	LastCrawled time.Time
+	// the AS of the address, or its network group without an AS map
+	Network string
+}
+
 func newKnownAddress(addr *p2p.NetAddress, src *p2p.NetAddress) *knownAddress {
 	return &knownAddress{
 		Addr:        addr,
@@ -137,3 +156,18 @@ func (ka *knownAddress) isBad() bool {
 
 	return false
 }
+
+func (ka *knownAddress) info() KnownAddressInfo {
+	info := KnownAddressInfo{
+		Addr:        ka.Addr,
+		Src:         ka.Src,
+		Old:         ka.isOld(),
+		Attempts:    ka.Attempts,
+		LastAttempt: ka.LastAttempt,
+		LastSuccess: ka.LastSuccess,
+	}
+	if ka.isBanned() {
+		info.BannedUntil = ka.LastBanTime
+	}
+	return info
+}
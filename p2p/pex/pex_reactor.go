@@ -1,8 +1,10 @@
 package pex
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
 	"sync"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
 	"github.com/cometbft/cometbft/libs/service"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/p2p/conn"
 	tmp2p "github.com/cometbft/cometbft/proto/tendermint/p2p"
@@ -33,12 +36,16 @@ const (
 	// ensure we have enough peers
 	defaultEnsurePeersPeriod = 30 * time.Second
 
+	// dnsSeedLookupTimeout bounds the resolution of a DNS seed.
+	dnsSeedLookupTimeout = 10 * time.Second
+
 	// Seed/Crawler constants
 
-	// minTimeBetweenCrawls is a minimum time between attempts to crawl a peer.
+	// minTimeBetweenCrawls is the default minimum time between attempts to
+	// crawl a peer.
 	minTimeBetweenCrawls = 2 * time.Minute
 
-	// check some peers every this
+	// check some peers every this, by default
 	crawlPeerPeriod = 30 * time.Second
 
 	maxAttemptsToDial = 16 // ~ 35h in total (last attempt - 18h)
@@ -91,10 +98,18 @@ type Reactor struct {
 
 	seedAddrs []*p2p.NetAddress
 
+	// DNS seeds, resolved when dialing the seeds. The addresses of each seed
+	// are kept from the last successful resolution.
+	dnsSeeds     []DNSSeed
+	lookupTXT    lookupTXTFunc
+	dnsSeedsMtx  cmtsync.Mutex
+	dnsSeedAddrs map[string][]*p2p.NetAddress // domain -> addresses
+
 	attemptsToDial sync.Map // address (string) -> {number of attempts (int), last time dialed (time.Time)}
 
 	// seed/crawled mode fields
-	crawlPeerInfos map[p2p.ID]crawlPeerInfo
+	crawlPeerInfosMtx cmtsync.Mutex
+	crawlPeerInfos    map[p2p.ID]crawlPeerInfo
 }
 
 func (r *Reactor) minReceiveRequestInterval() time.Duration {
@@ -119,6 +134,25 @@ type ReactorConfig struct {
 	// Seeds is a list of addresses reactor may use
 	// if it can't connect to peers in the addrbook.
 	Seeds []string
+
+	// DNSSeeds is a list of "<public key>@<domain>" DNS seeds publishing
+	// seed addresses, used along with Seeds.
+	DNSSeeds []string
+
+	// In seed mode, the interval between two crawls of the network, and the
+	// minimum time between two crawls of the same peer. The defaults are used
+	// if zero.
+	CrawlPeriod   time.Duration
+	RecrawlPeriod time.Duration
+
+	// In seed mode, the maximum number of addresses of the same AS crawled at
+	// once, or given to a peer. Unlimited if zero.
+	MaxAddrsPerAS int
+
+	// ASMap maps the addresses to their AS. If nil, or if it does not have
+	// an address, the network group of the address in the address book is
+	// used instead.
+	ASMap *ASMap
 }
 
 type _attemptsToDial struct {
@@ -135,6 +169,8 @@ func NewReactor(b AddrBook, config *ReactorConfig) *Reactor {
 		requestsSent:         cmap.NewCMap(),
 		lastReceivedRequests: cmap.NewCMap(),
 		crawlPeerInfos:       make(map[p2p.ID]crawlPeerInfo),
+		lookupTXT:            net.DefaultResolver.LookupTXT,
+		dnsSeedAddrs:         make(map[string][]*p2p.NetAddress),
 	}
 	r.BaseReactor = *p2p.NewBaseReactor("PEX", r)
 	return r
@@ -147,14 +183,24 @@ func (r *Reactor) OnStart() error {
 		return err
 	}
 
+	dnsSeeds := make([]DNSSeed, 0, len(r.config.DNSSeeds))
+	for _, s := range r.config.DNSSeeds {
+		dnsSeed, err := ParseDNSSeed(s)
+		if err != nil {
+			return fmt.Errorf("DNS seed configuration has error: %w", err)
+		}
+		dnsSeeds = append(dnsSeeds, dnsSeed)
+	}
+
 	numOnline, seedAddrs, err := r.checkSeeds()
 	if err != nil {
 		return err
-	} else if numOnline == 0 && r.book.Empty() {
+	} else if numOnline == 0 && len(dnsSeeds) == 0 && r.book.Empty() {
 		return errors.New("address book is empty and couldn't resolve any seed nodes")
 	}
 
 	r.seedAddrs = seedAddrs
+	r.dnsSeeds = dnsSeeds
 
 	// Check if this node should run
 	// in seed/crawler mode
@@ -258,7 +304,7 @@ func (r *Reactor) Receive(e p2p.Envelope) {
 			r.lastReceivedRequests.Set(id, time.Now())
 
 			// Send addrs and disconnect
-			r.SendAddrs(e.Src, r.book.GetSelectionWithBias(biasToSelectNewPeers))
+			r.SendAddrs(e.Src, r.seedSelection(r.book.GetSelectionWithBias(biasToSelectNewPeers)))
 			go func() {
 				// In a go-routine so it doesn't block .Receive.
 				e.Src.FlushStop()
@@ -362,7 +408,7 @@ func (r *Reactor) ReceiveAddrs(addrs []*p2p.NetAddress, src Peer) error {
 	}
 
 	srcIsSeed := false
-	for _, seedAddr := range r.seedAddrs {
+	for _, seedAddr := range r.allSeedAddrs() {
 		if seedAddr.Equals(srcAddr) {
 			srcIsSeed = true
 			break
@@ -615,13 +661,51 @@ func (r *Reactor) checkSeeds() (numOnline int, netAddrs []*p2p.NetAddress, err e
 	return numOnline, netAddrs, nil
 }
 
+// resolveDNSSeeds resolves the DNS seeds, keeping the previous addresses of
+// the seeds which fail to resolve.
+func (r *Reactor) resolveDNSSeeds() {
+	for _, dnsSeed := range r.dnsSeeds {
+		ctx, cancel := context.WithTimeout(context.Background(), dnsSeedLookupTimeout)
+		addrStrs, err := resolveDNSSeed(ctx, r.lookupTXT, dnsSeed)
+		cancel()
+		if err != nil {
+			r.Logger.Error("Failed to resolve DNS seed", "seed", dnsSeed.Domain, "err", err)
+			continue
+		}
+		addrs, errs := p2p.NewNetAddressStrings(addrStrs)
+		for _, err := range errs {
+			r.Logger.Error("Invalid address from DNS seed", "seed", dnsSeed.Domain, "err", err)
+		}
+		r.Logger.Debug("Resolved DNS seed", "seed", dnsSeed.Domain, "addrs", addrs)
+
+		r.dnsSeedsMtx.Lock()
+		r.dnsSeedAddrs[dnsSeed.Domain] = addrs
+		r.dnsSeedsMtx.Unlock()
+	}
+}
+
+// allSeedAddrs returns the addresses of the seeds and of the DNS seeds.
+func (r *Reactor) allSeedAddrs() []*p2p.NetAddress {
+	r.dnsSeedsMtx.Lock()
+	defer r.dnsSeedsMtx.Unlock()
+
+	addrs := append([]*p2p.NetAddress(nil), r.seedAddrs...)
+	for _, dnsSeedAddrs := range r.dnsSeedAddrs {
+		addrs = append(addrs, dnsSeedAddrs...)
+	}
+	return addrs
+}
+
 // randomly dial seeds until we connect to one or exhaust them
 func (r *Reactor) dialSeeds() {
-	perm := cmtrand.Perm(len(r.seedAddrs))
+	r.resolveDNSSeeds()
+	seedAddrs := r.allSeedAddrs()
+
+	perm := cmtrand.Perm(len(seedAddrs))
 	// perm := r.Switch.rng.Perm(lSeeds)
 	for _, i := range perm {
 		// dial a random seed
-		seedAddr := r.seedAddrs[i]
+		seedAddr := seedAddrs[i]
 		err := r.Switch.DialPeerWithAddress(seedAddr)
 
 		switch err.(type) {
@@ -631,7 +715,7 @@ func (r *Reactor) dialSeeds() {
 		r.Switch.Logger.Error("Error dialing seed", "err", err, "seed", seedAddr)
 	}
 	// do not write error message if there were no seeds specified in config
-	if len(r.seedAddrs) > 0 {
+	if len(seedAddrs) > 0 {
 		r.Switch.Logger.Error("Couldn't connect to any seeds")
 	}
 }
@@ -653,21 +737,21 @@ func (r *Reactor) AttemptsToDial(addr *p2p.NetAddress) int {
 // from peers, except other seed nodes.
 func (r *Reactor) crawlPeersRoutine() {
 	// If we have any seed nodes, consult them first
-	if len(r.seedAddrs) > 0 {
+	if len(r.seedAddrs) > 0 || len(r.dnsSeeds) > 0 {
 		r.dialSeeds()
 	} else {
 		// Do an initial crawl
-		r.crawlPeers(r.book.GetSelection())
+		r.crawlPeers(r.seedSelection(r.book.GetSelection()))
 	}
 
 	// Fire periodically
-	ticker := time.NewTicker(crawlPeerPeriod)
+	ticker := time.NewTicker(r.crawlPeriod())
 
 	for {
 		select {
 		case <-ticker.C:
 			r.attemptDisconnects()
-			r.crawlPeers(r.book.GetSelection())
+			r.crawlPeers(r.seedSelection(r.book.GetSelection()))
 			r.cleanupCrawlPeerInfos()
 		case <-r.Quit():
 			return
@@ -675,6 +759,42 @@ func (r *Reactor) crawlPeersRoutine() {
 	}
 }
 
+func (r *Reactor) crawlPeriod() time.Duration {
+	if r.config.CrawlPeriod > 0 {
+		return r.config.CrawlPeriod
+	}
+	return crawlPeerPeriod
+}
+
+func (r *Reactor) recrawlPeriod() time.Duration {
+	if r.config.RecrawlPeriod > 0 {
+		return r.config.RecrawlPeriod
+	}
+	return minTimeBetweenCrawls
+}
+
+// seedSelection keeps at most MaxAddrsPerAS addresses of each AS among the
+// addresses selected by the seed.
+func (r *Reactor) seedSelection(addrs []*p2p.NetAddress) []*p2p.NetAddress {
+	return diverseSelection(addrs, r.config.ASMap, r.config.MaxAddrsPerAS)
+}
+
+// KnownAddresses returns the quality data of the addresses of the address
+// book, along with their last crawl in seed mode.
+func (r *Reactor) KnownAddresses() []KnownAddressInfo {
+	infos := r.book.KnownAddresses()
+
+	r.crawlPeerInfosMtx.Lock()
+	defer r.crawlPeerInfosMtx.Unlock()
+	for i := range infos {
+		if info, ok := r.crawlPeerInfos[infos[i].Addr.ID]; ok {
+			infos[i].LastCrawled = info.LastCrawled
+		}
+		infos[i].Network = r.config.ASMap.networkOf(infos[i].Addr)
+	}
+	return infos
+}
+
 // nodeHasSomePeersOrDialingAny returns true if the node is connected to some
 // peers or dialing them currently.
 func (r *Reactor) nodeHasSomePeersOrDialingAny() bool {
@@ -695,10 +815,12 @@ func (r *Reactor) crawlPeers(addrs []*p2p.NetAddress) {
 	now := time.Now()
 
 	for _, addr := range addrs {
+		r.crawlPeerInfosMtx.Lock()
 		peerInfo, ok := r.crawlPeerInfos[addr.ID]
 
 		// Do not attempt to connect with peers we recently crawled.
-		if ok && now.Sub(peerInfo.LastCrawled) < minTimeBetweenCrawls {
+		if ok && now.Sub(peerInfo.LastCrawled) < r.recrawlPeriod() {
+			r.crawlPeerInfosMtx.Unlock()
 			continue
 		}
 
@@ -707,6 +829,7 @@ func (r *Reactor) crawlPeers(addrs []*p2p.NetAddress) {
 			Addr:        addr,
 			LastCrawled: now,
 		}
+		r.crawlPeerInfosMtx.Unlock()
 
 		err := r.dialPeer(addr)
 		if err != nil {
@@ -727,6 +850,8 @@ func (r *Reactor) crawlPeers(addrs []*p2p.NetAddress) {
 }
 
 func (r *Reactor) cleanupCrawlPeerInfos() {
+	r.crawlPeerInfosMtx.Lock()
+	defer r.crawlPeerInfosMtx.Unlock()
 	for id, info := range r.crawlPeerInfos {
 		// If we did not crawl a peer for 24 hours, it means the peer was removed
 		// from the addrbook => remove
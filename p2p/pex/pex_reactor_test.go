@@ -701,3 +701,35 @@ func TestPexVectors(t *testing.T) {
 		require.Equal(t, tc.expBytes, hex.EncodeToString(bz), tc.testName)
 	}
 }
+
+func TestPEXReactorKnownAddressesInSeedMode(t *testing.T) {
+	pexR, book := createReactor(&ReactorConfig{SeedMode: true, MaxAddrsPerAS: 1})
+	defer teardownReactor(book)
+
+	addrs := make([]*p2p.NetAddress, 0, 3)
+	for _, s := range []string{"1.2.3.4:26656", "1.2.4.4:26656", "1.3.3.4:26656"} {
+		addr, err := p2p.NewNetAddressString(p2p.IDAddressString(mock.NewPeer(nil).ID(), s))
+		require.NoError(t, err)
+		require.NoError(t, book.AddAddress(addr, addr))
+		addrs = append(addrs, addr)
+	}
+
+	// Only one address of 1.2.0.0/16 is given out or crawled.
+	selection := pexR.seedSelection(addrs)
+	assert.Equal(t, []*p2p.NetAddress{addrs[0], addrs[2]}, selection)
+
+	crawled := time.Now()
+	pexR.crawlPeerInfos[addrs[0].ID] = crawlPeerInfo{Addr: addrs[0], LastCrawled: crawled}
+
+	infos := pexR.KnownAddresses()
+	require.Len(t, infos, 3)
+	for _, info := range infos {
+		assert.False(t, info.Old)
+		assert.Equal(t, groupKeyFor(info.Addr, false), info.Network)
+		if info.Addr.Equals(addrs[0]) {
+			assert.Equal(t, crawled, info.LastCrawled)
+		} else {
+			assert.True(t, info.LastCrawled.IsZero())
+		}
+	}
+}
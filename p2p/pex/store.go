@@ -0,0 +1,75 @@
+package pex
+
+import (
+	"os"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/libs/atrest"
+)
+
+// AddrBookStore persists the address book across restarts. The address book
+// is saved as a whole, in an opaque encoding, and loaded once on start.
+//
+// The default store is the JSON file of the address book. Implementations
+// backed by another storage, e.g. shared by a fleet of nodes, can be passed
+// to NewAddrBook with WithStore.
+type AddrBookStore interface {
+	// Load returns the saved address book, or nil if none was saved.
+	Load() ([]byte, error)
+	// Save replaces the saved address book.
+	Save(data []byte) error
+}
+
+type fileAddrBookStore struct {
+	path   string
+	cipher *atrest.Cipher
+}
+
+var _ AddrBookStore = (*fileAddrBookStore)(nil)
+
+// NewFileAddrBookStore returns a store saving the address book to the file
+// at path, encrypted with the given cipher if not nil.
+func NewFileAddrBookStore(path string, c *atrest.Cipher) AddrBookStore {
+	return &fileAddrBookStore{path: path, cipher: c}
+}
+
+// Load implements AddrBookStore.
+func (s *fileAddrBookStore) Load() ([]byte, error) {
+	data, err := atrest.ReadFile(s.path, s.cipher)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Save implements AddrBookStore.
+func (s *fileAddrBookStore) Save(data []byte) error {
+	return atrest.WriteFile(s.path, data, 0o644, s.cipher)
+}
+
+// addrBookKey is the key of the address book in its database.
+var addrBookKey = []byte("addrbook")
+
+type dbAddrBookStore struct {
+	db dbm.DB
+}
+
+var _ AddrBookStore = (*dbAddrBookStore)(nil)
+
+// NewDBAddrBookStore returns a store saving the address book to the given
+// database. Unlike the file, which can be left truncated by a crash, the
+// database is synced on every save.
+func NewDBAddrBookStore(db dbm.DB) AddrBookStore {
+	return &dbAddrBookStore{db: db}
+}
+
+// Load implements AddrBookStore.
+func (s *dbAddrBookStore) Load() ([]byte, error) {
+	return s.db.Get(addrBookKey)
+}
+
+// Save implements AddrBookStore.
+func (s *dbAddrBookStore) Save(data []byte) error {
+	return s.db.SetSync(addrBookKey, data)
+}
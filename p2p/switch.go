@@ -12,6 +12,7 @@ import (
 	"github.com/cometbft/cometbft/libs/cmap"
 	"github.com/cometbft/cometbft/libs/rand"
 	"github.com/cometbft/cometbft/libs/service"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	"github.com/cometbft/cometbft/p2p/conn"
 )
 
@@ -39,6 +40,7 @@ func MConnConfig(cfg *config.P2PConfig) conn.MConnConfig {
 	mConfig.SendRate = cfg.SendRate
 	mConfig.RecvRate = cfg.RecvRate
 	mConfig.MaxPacketMsgPayloadSize = cfg.MaxPacketMsgPayloadSize
+	mConfig.Compression = cfg.Compression
 	mConfig.TestFuzz = cfg.TestFuzz
 	mConfig.TestFuzzConfig = cfg.TestFuzzConfig
 	return mConfig
@@ -91,6 +93,13 @@ type Switch struct {
 
 	filterTimeout time.Duration
 	peerFilters   []PeerFilterFunc
+	// only the peers of the allowlist are connected, if set
+	allowlist *PeerAllowlist
+
+	// send priorities set by the reactors, overriding the priorities of the
+	// channel descriptors
+	chPrioritiesMtx cmtsync.Mutex
+	chPriorities    map[byte]int
 
 	rng *rand.Rand // seed for randomizing dial times and orders
 
@@ -120,6 +129,7 @@ func NewSwitch(
 		chDescs:              make([]*conn.ChannelDescriptor, 0),
 		reactorsByCh:         make(map[byte]Reactor),
 		msgTypeByChID:        make(map[byte]proto.Message),
+		chPriorities:         make(map[byte]int),
 		peers:                NewPeerSet(),
 		dialing:              cmap.NewCMap(),
 		reconnecting:         cmap.NewCMap(),
@@ -153,6 +163,13 @@ func SwitchPeerFilters(filters ...PeerFilterFunc) SwitchOption {
 	return func(sw *Switch) { sw.peerFilters = filters }
 }
 
+// SwitchPeerAllowlist restricts the peers, inbound and outbound, to the peers
+// of the allowlist. The switch starts and stops the allowlist, and disconnects
+// the peers removed from it when it is reloaded.
+func SwitchPeerAllowlist(allowlist *PeerAllowlist) SwitchOption {
+	return func(sw *Switch) { sw.allowlist = allowlist }
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *Metrics) SwitchOption {
 	return func(sw *Switch) { sw.metrics = metrics }
@@ -165,12 +182,15 @@ func WithMetrics(metrics *Metrics) SwitchOption {
 // NOTE: Not goroutine safe.
 func (sw *Switch) AddReactor(name string, reactor Reactor) Reactor {
 	for _, chDesc := range reactor.GetChannels() {
+		chDesc := *chDesc
+		chDesc.SendRate = sw.config.ChannelSendRate(name)
+		chDesc.RecvRate = sw.config.ChannelRecvRate(name)
 		chID := chDesc.ID
 		// No two reactors can share the same channel.
 		if sw.reactorsByCh[chID] != nil {
 			panic(fmt.Sprintf("Channel %X has multiple reactors %v & %v", chID, sw.reactorsByCh[chID], reactor))
 		}
-		sw.chDescs = append(sw.chDescs, chDesc)
+		sw.chDescs = append(sw.chDescs, &chDesc)
 		sw.reactorsByCh[chID] = reactor
 		sw.msgTypeByChID[chID] = chDesc.MessageType
 	}
@@ -240,6 +260,13 @@ func (sw *Switch) OnStart() error {
 		}
 	}
 
+	if sw.allowlist != nil {
+		sw.allowlist.onUpdate = sw.stopDisallowedPeers
+		if err := sw.allowlist.Start(); err != nil {
+			return fmt.Errorf("failed to start the peer allowlist: %w", err)
+		}
+	}
+
 	// Start accepting Peers.
 	go sw.acceptRoutine()
 
@@ -248,6 +275,12 @@ func (sw *Switch) OnStart() error {
 
 // OnStop implements BaseService. It stops all peers and reactors.
 func (sw *Switch) OnStop() {
+	if sw.allowlist != nil {
+		if err := sw.allowlist.Stop(); err != nil {
+			sw.Logger.Error("error while stopping the peer allowlist", "error", err)
+		}
+	}
+
 	// Stop peers
 	for _, p := range sw.peers.List() {
 		sw.stopAndRemovePeer(p, nil)
@@ -295,6 +328,51 @@ func (sw *Switch) Broadcast(e Envelope) chan bool {
 	return successChan
 }
 
+// channelPrioritySetter is implemented by the peers whose channel priorities
+// can be changed.
+type channelPrioritySetter interface {
+	SetChannelPriority(chID byte, priority int) bool
+}
+
+// SetChannelPriority sets the send priority of a channel for all the peers,
+// current and future, overriding the priority of its descriptor. It allows
+// the reactors to favor their channel while it matters, e.g. the blocks while
+// catching up, and then to restore the priority of the descriptor.
+func (sw *Switch) SetChannelPriority(chID byte, priority int) error {
+	if _, ok := sw.reactorsByCh[chID]; !ok {
+		return fmt.Errorf("unknown channel %#x", chID)
+	}
+	if priority <= 0 {
+		return fmt.Errorf("channel priority must be positive, got %d", priority)
+	}
+
+	sw.chPrioritiesMtx.Lock()
+	defer sw.chPrioritiesMtx.Unlock()
+	sw.chPriorities[chID] = priority
+	for _, p := range sw.peers.List() {
+		if ps, ok := p.(channelPrioritySetter); ok {
+			ps.SetChannelPriority(chID, priority)
+		}
+	}
+	return nil
+}
+
+// ChannelPriority returns the send priority of a channel, set by
+// SetChannelPriority or else by its descriptor.
+func (sw *Switch) ChannelPriority(chID byte) int {
+	sw.chPrioritiesMtx.Lock()
+	defer sw.chPrioritiesMtx.Unlock()
+	if priority, ok := sw.chPriorities[chID]; ok {
+		return priority
+	}
+	for _, chDesc := range sw.chDescs {
+		if chDesc.ID == chID {
+			return chDesc.Priority
+		}
+	}
+	return 0
+}
+
 // NumPeers returns the count of outbound/inbound and outbound-dialing peers.
 // unconditional peers are not counted here.
 func (sw *Switch) NumPeers() (outbound, inbound, dialing int) {
@@ -552,6 +630,9 @@ func (sw *Switch) DialPeerWithAddress(addr *NetAddress) error {
 	if sw.IsDialingOrExistingAddress(addr) {
 		return ErrCurrentlyDialingOrExistingAddress{addr.String()}
 	}
+	if sw.allowlist != nil && !sw.allowlist.Allowed(addr.ID) {
+		return ErrRejected{id: addr.ID, err: errNotAllowed, isFiltered: true}
+	}
 
 	sw.dialing.Set(string(addr.ID), addr)
 	defer sw.dialing.Delete(string(addr.ID))
@@ -784,6 +865,10 @@ func (sw *Switch) filterPeer(p Peer) error {
 		return ErrRejected{id: p.ID(), isDuplicate: true}
 	}
 
+	if sw.allowlist != nil && !sw.allowlist.Allowed(p.ID()) {
+		return ErrRejected{id: p.ID(), err: errNotAllowed, isFiltered: true}
+	}
+
 	errc := make(chan error, len(sw.peerFilters))
 
 	for _, f := range sw.peerFilters {
@@ -806,6 +891,17 @@ func (sw *Switch) filterPeer(p Peer) error {
 	return nil
 }
 
+// stopDisallowedPeers disconnects the peers which are no longer in the
+// allowlist.
+func (sw *Switch) stopDisallowedPeers() {
+	for _, p := range sw.peers.List() {
+		if !sw.allowlist.Allowed(p.ID()) {
+			sw.Logger.Info("Stopping peer removed from the peer allowlist", "peer", p.ID())
+			sw.StopPeerGracefully(p)
+		}
+	}
+}
+
 // addPeer starts up the Peer and adds it to the Switch. Error is returned if
 // the peer is filtered out or failed to start or can't be added.
 func (sw *Switch) addPeer(p Peer) error {
@@ -852,6 +948,15 @@ func (sw *Switch) addPeer(p Peer) error {
 	}
 	sw.metrics.Peers.Add(float64(1))
 
+	// Apply the priorities set before the peer was added to the set.
+	if ps, ok := p.(channelPrioritySetter); ok {
+		sw.chPrioritiesMtx.Lock()
+		for chID, priority := range sw.chPriorities {
+			ps.SetChannelPriority(chID, priority)
+		}
+		sw.chPrioritiesMtx.Unlock()
+	}
+
 	// Start all the reactor protocols on the peer.
 	for _, reactor := range sw.reactors {
 		reactor.AddPeer(p)
@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"sync/atomic"
@@ -173,6 +174,163 @@ func TestSwitches(t *testing.T) {
 		s2.Reactor("bar").(*TestReactor), 200*time.Millisecond, 5*time.Second)
 }
 
+func TestSwitchesQUIC(t *testing.T) {
+	quicCfg := *cfg
+	quicCfg.Transport = config.P2PTransportQUIC
+
+	// The channels of the reactors must not include testCh, which is already
+	// in the NodeInfo of the switches, for them to be dialed.
+	chIDs := []byte{0x02, 0x03, 0x04}
+	initSwitch := func(_ int, sw *Switch) *Switch {
+		sw.SetAddrBook(&AddrBookMock{
+			Addrs:    make(map[string]struct{}),
+			OurAddrs: make(map[string]struct{}),
+		})
+		chDescs := make([]*conn.ChannelDescriptor, len(chIDs))
+		for i, chID := range chIDs {
+			chDescs[i] = &conn.ChannelDescriptor{ID: chID, Priority: 10, MessageType: &p2pproto.Message{}}
+		}
+		sw.AddReactor("foo", NewTestReactor(chDescs, true))
+		return sw
+	}
+
+	s1 := MakeSwitch(&quicCfg, 1, initSwitch)
+	s2 := MakeSwitch(&quicCfg, 2, initSwitch)
+	for _, sw := range []*Switch{s1, s2} {
+		sw := sw
+		require.NoError(t, sw.Start())
+		t.Cleanup(func() {
+			if err := sw.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	require.NoError(t, s1.DialPeerWithAddress(s2.NetAddress()))
+	waitUntilSwitchHasAtLeastNPeers(s2, 1)
+	require.Equal(t, 1, s1.Peers().Size())
+	require.Equal(t, 1, s2.Peers().Size())
+
+	// Each channel is sent on its own stream.
+	for i, chID := range chIDs {
+		msg := &p2pproto.PexAddrs{Addrs: []p2pproto.NetAddress{{ID: strconv.Itoa(i)}}}
+		s1.Broadcast(Envelope{ChannelID: chID, Message: msg})
+		assertMsgReceivedWithTimeout(t, msg, chID,
+			s2.Reactor("foo").(*TestReactor), 10*time.Millisecond, 5*time.Second)
+	}
+
+	// The reconnection resumes the TLS session and sends its first data
+	// without waiting for the end of the QUIC handshake.
+	s1.StopPeerGracefully(s1.Peers().List()[0])
+	assert.Eventually(t, func() bool {
+		return s1.Peers().Size() == 0 && s2.Peers().Size() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, s1.DialPeerWithAddress(s2.NetAddress()))
+	p := s1.Peers().List()[0]
+	assert.True(t, p.(*peer).conn.(*quicConn).conn.ConnectionState().Used0RTT)
+}
+
+func TestSwitchChannelRates(t *testing.T) {
+	rateCfg := *cfg
+	rateCfg.ChannelSendRates = "foo=1024000"
+	rateCfg.ChannelRecvRates = "bar=512000"
+
+	sw := MakeSwitch(&rateCfg, 1, initSwitchFunc)
+	for _, chDesc := range sw.chDescs {
+		switch chDesc.ID {
+		case 0x00, 0x01:
+			assert.Equal(t, int64(1024000), chDesc.SendRate)
+			assert.Zero(t, chDesc.RecvRate)
+		default:
+			assert.Zero(t, chDesc.SendRate)
+			assert.Equal(t, int64(512000), chDesc.RecvRate)
+		}
+	}
+}
+
+func TestSwitchesCompression(t *testing.T) {
+	compCfg := *cfg
+	compCfg.Compression = config.P2PCompressionZstd
+
+	switches := MakeConnectedSwitches(&compCfg, 2, initSwitchFunc, Connect2Switches)
+	s1, s2 := switches[0], switches[1]
+	for _, sw := range switches {
+		sw := sw
+		t.Cleanup(func() {
+			if err := sw.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	addrs := make([]p2pproto.NetAddress, 100)
+	for i := range addrs {
+		addrs[i] = p2pproto.NetAddress{ID: "0123456789abcdef0123456789abcdef01234567", IP: "1.2.3.4", Port: 26656}
+	}
+	msg := &p2pproto.PexAddrs{Addrs: addrs}
+	s1.Broadcast(Envelope{ChannelID: byte(0x00), Message: msg})
+	assertMsgReceivedWithTimeout(t, msg, byte(0x00),
+		s2.Reactor("foo").(*TestReactor), 10*time.Millisecond, 5*time.Second)
+
+	// The message was sent compressed.
+	status := s1.Peers().List()[0].Status()
+	assert.Less(t, status.SendMonitor.Bytes, int64(proto.Size(msg)/2))
+
+	// The compression is disabled with the peers which do not advertise it.
+	mConfig := MConnConfig(&compCfg)
+	ni := s1.NodeInfo().(DefaultNodeInfo)
+	assert.Equal(t, config.P2PCompressionZstd, connConfigWithPeer(mConfig, ni, s2.NodeInfo()).Compression)
+	other := s2.NodeInfo().(DefaultNodeInfo)
+	other.Capabilities = nil
+	assert.Empty(t, connConfigWithPeer(mConfig, ni, other).Compression)
+	assert.Empty(t, connConfigWithPeer(mConfig, other, ni).Compression)
+}
+
+func TestSwitchSetChannelPriority(t *testing.T) {
+	switches := MakeConnectedSwitches(cfg, 2, initSwitchFunc, Connect2Switches)
+	s1, s2 := switches[0], switches[1]
+	for _, sw := range switches {
+		sw := sw
+		t.Cleanup(func() {
+			if err := sw.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	require.Error(t, s1.SetChannelPriority(0x05, 20))
+	require.Error(t, s1.SetChannelPriority(0x02, 0))
+	assert.Equal(t, 10, s1.ChannelPriority(0x02))
+
+	// The priority is set for the current peers, and the future ones.
+	require.NoError(t, s1.SetChannelPriority(0x02, 20))
+	assert.Equal(t, 20, s1.ChannelPriority(0x02))
+	channelPriority := func(p Peer, chID byte) int {
+		for _, ch := range p.Status().Channels {
+			if ch.ID == chID {
+				return ch.Priority
+			}
+		}
+		return 0
+	}
+	p := s1.Peers().Get(s2.NodeInfo().ID())
+	assert.Equal(t, 20, channelPriority(p, 0x02))
+	assert.Equal(t, 10, channelPriority(p, 0x03))
+
+	s3 := MakeSwitch(cfg, 3, initSwitchFunc)
+	require.NoError(t, s3.Start())
+	t.Cleanup(func() {
+		if err := s3.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	Connect2Switches([]*Switch{s1, s3}, 0, 1)
+	p = s1.Peers().Get(s3.NodeInfo().ID())
+	require.NotNil(t, p)
+	assert.Equal(t, 20, channelPriority(p, 0x02))
+}
+
 func assertMsgReceivedWithTimeout(
 	t *testing.T,
 	msg proto.Message,
@@ -186,11 +344,11 @@ func assertMsgReceivedWithTimeout(
 		select {
 		case <-ticker.C:
 			msgs := reactor.getMsgs(channel)
-			expectedBytes, err := proto.Marshal(msgs[0].Contents)
-			require.NoError(t, err)
-			gotBytes, err := proto.Marshal(msg)
-			require.NoError(t, err)
 			if len(msgs) > 0 {
+				expectedBytes, err := proto.Marshal(msgs[0].Contents)
+				require.NoError(t, err)
+				gotBytes, err := proto.Marshal(msg)
+				require.NoError(t, err)
 				if !bytes.Equal(expectedBytes, gotBytes) {
 					t.Fatalf("Unexpected message bytes. Wanted: %X, Got: %X", msg, msgs[0].Counter)
 				}
@@ -408,6 +566,61 @@ func TestSwitchStopsNonPersistentPeerOnError(t *testing.T) {
 	assert.False(p.IsRunning())
 }
 
+func TestSwitchPeerAllowlist(t *testing.T) {
+	// The channels of the reactors must not include testCh, which is already
+	// in the NodeInfo of the switches, for them to be dialed.
+	initSwitch := func(_ int, sw *Switch) *Switch {
+		sw.AddReactor("bar", NewTestReactor([]*conn.ChannelDescriptor{
+			{ID: byte(0x02), Priority: 10, MessageType: &p2pproto.Message{}},
+		}, true))
+		return sw
+	}
+	switches := make([]*Switch, 3)
+	for i := range switches {
+		switches[i] = MakeSwitch(cfg, i, initSwitch)
+	}
+	sw, allowed, other := switches[0], switches[1], switches[2]
+
+	privKey := ed25519.GenPrivKey()
+	file := filepath.Join(t.TempDir(), "peer_allowlist.json")
+	writePeerAllowlist(t, file, privKey, allowed.NodeInfo().ID())
+	allowlist, err := NewPeerAllowlist(file, privKey.PubKey())
+	require.NoError(t, err)
+	SwitchPeerAllowlist(allowlist)(sw)
+
+	require.NoError(t, StartSwitches(switches))
+	t.Cleanup(func() {
+		for _, s := range switches {
+			if err := s.Stop(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+
+	// The listed peer can connect.
+	require.NoError(t, allowed.DialPeerWithAddress(sw.NetAddress()))
+	require.Eventually(t, func() bool { return sw.Peers().Has(allowed.NodeInfo().ID()) }, time.Second, 10*time.Millisecond)
+
+	// The other peers can neither connect, nor be dialed.
+	_ = other.DialPeerWithAddress(sw.NetAddress())
+	time.Sleep(100 * time.Millisecond)
+	assert.False(t, sw.Peers().Has(other.NodeInfo().ID()))
+	err = sw.DialPeerWithAddress(other.NetAddress())
+	var rejected ErrRejected
+	require.ErrorAs(t, err, &rejected)
+	assert.True(t, rejected.IsFiltered())
+
+	// The peers removed from the list are disconnected when it is reloaded.
+	writePeerAllowlist(t, file, privKey, other.NodeInfo().ID())
+	reloaded, err := allowlist.Reload()
+	require.NoError(t, err)
+	require.True(t, reloaded)
+	sw.stopDisallowedPeers()
+	assert.False(t, sw.Peers().Has(allowed.NodeInfo().ID()))
+	require.NoError(t, sw.DialPeerWithAddress(other.NetAddress()))
+	assert.True(t, sw.Peers().Has(other.NodeInfo().ID()))
+}
+
 func TestSwitchStopPeerForError(t *testing.T) {
 	s := httptest.NewServer(promhttp.Handler())
 	defer s.Close()
@@ -445,10 +658,12 @@ func TestSwitchStopPeerForError(t *testing.T) {
 
 	// send messages to the peer from sw1
 	p := sw1.Peers().List()[0]
-	p.Send(Envelope{
+	require.True(t, p.Send(Envelope{
 		ChannelID: 0x1,
 		Message:   &p2pproto.Message{},
-	})
+	}))
+	assert.Contains(t, scrapeMetrics(),
+		fmt.Sprintf(`%s_%s_peer_send_messages_total{chID="0x1",peer_id="%s"} 1`, namespace, subsystem, p.ID()))
 
 	// stop sw2. this should cause the p to fail,
 	// which results in calling StopPeerForError internally
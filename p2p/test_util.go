@@ -146,13 +146,14 @@ func (sw *Switch) addPeerWithConnection(conn net.Conn) error {
 
 	p := newPeer(
 		pc,
-		MConnConfig(sw.config),
+		connConfigWithPeer(MConnConfig(sw.config), sw.nodeInfo, ni),
 		ni,
 		sw.reactorsByCh,
 		sw.msgTypeByChID,
 		sw.chDescs,
 		sw.StopPeerForError,
 		sw.mlc,
+		PeerMetrics(sw.metrics),
 	)
 
 	if err = sw.addPeer(p); err != nil {
@@ -193,6 +194,9 @@ func MakeSwitch(
 	}
 
 	t := NewMultiplexTransport(nodeInfo, nodeKey, MConnConfig(cfg))
+	if cfg.Transport == config.P2PTransportQUIC {
+		MultiplexTransportQUIC()(t)
+	}
 
 	if err := t.Listen(*addr); err != nil {
 		panic(err)
@@ -207,6 +211,14 @@ func MakeSwitch(
 	for ch := range sw.reactorsByCh {
 		ni.Channels = append(ni.Channels, ch)
 	}
+	reactors := make([]Reactor, 0, len(sw.reactors))
+	for _, reactor := range sw.reactors {
+		reactors = append(reactors, reactor)
+	}
+	ni.Capabilities = ReactorCapabilities(reactors...)
+	if cfg.Compression != "" && cfg.Compression != config.P2PCompressionNone {
+		ni.Capabilities = append(ni.Capabilities, CapabilityCompression)
+	}
 	nodeInfo = ni
 
 	// TODO: We need to setup reactors ahead of time so the NodeInfo is properly
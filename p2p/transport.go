@@ -2,10 +2,13 @@ package p2p
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
+	"sync"
 	"time"
 
+	"github.com/quic-go/quic-go"
 	"golang.org/x/net/netutil"
 
 	"github.com/cosmos/gogoproto/proto"
@@ -134,12 +137,41 @@ func MultiplexTransportMaxIncomingConnections(n int) MultiplexTransportOption {
 	return func(mt *MultiplexTransport) { mt.maxIncomingConnections = n }
 }
 
+// MultiplexTransportMaxIncomingConnectionsPerIP sets the maximum number of
+// simultaneous incoming connections from the same IP. Default: 0 (unlimited)
+func MultiplexTransportMaxIncomingConnectionsPerIP(n int) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.inboundLimiter.maxPerIP = n }
+}
+
+// MultiplexTransportMaxIncomingConnectionsPerSubnet sets the maximum number of
+// simultaneous incoming connections from the same /24 (IPv4) or /64 (IPv6)
+// subnet. Default: 0 (unlimited)
+func MultiplexTransportMaxIncomingConnectionsPerSubnet(n int) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.inboundLimiter.maxPerSubnet = n }
+}
+
+// MultiplexTransportQUIC makes the transport accept and dial QUIC connections,
+// on the UDP port of the addresses, instead of TCP connections.
+func MultiplexTransportQUIC() MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.quic = true }
+}
+
 // MultiplexTransport accepts and dials tcp connections and upgrades them to
-// multiplexed peers.
+// multiplexed peers, or QUIC connections if enabled (see
+// MultiplexTransportQUIC).
 type MultiplexTransport struct {
 	netAddr                NetAddress
 	listener               net.Listener
 	maxIncomingConnections int // see MaxIncomingConnections
+	inboundLimiter         *inboundConnLimiter
+
+	// QUIC listener and configs, if QUIC is enabled.
+	quic         bool
+	quicListener *quic.EarlyListener
+	quicOnce     sync.Once
+	quicErr      error
+	quicTLS      *tls.Config
+	quicConfig   *quic.Config
 
 	acceptc chan accept
 	closec  chan struct{}
@@ -181,6 +213,7 @@ func NewMultiplexTransport(
 		nodeInfo:         nodeInfo,
 		nodeKey:          nodeKey,
 		conns:            NewConnSet(),
+		inboundLimiter:   newInboundConnLimiter(0, 0),
 		resolver:         net.DefaultResolver,
 	}
 }
@@ -213,6 +246,10 @@ func (mt *MultiplexTransport) Dial(
 	addr NetAddress,
 	cfg peerConfig,
 ) (Peer, error) {
+	if mt.quic {
+		return mt.dialQUIC(addr, cfg)
+	}
+
 	c, err := addr.DialTimeout(mt.dialTimeout)
 	if err != nil {
 		return nil, err
@@ -244,6 +281,9 @@ func (mt *MultiplexTransport) Dial(
 func (mt *MultiplexTransport) Close() error {
 	close(mt.closec)
 
+	if mt.quicListener != nil {
+		return mt.quicListener.Close()
+	}
 	if mt.listener != nil {
 		return mt.listener.Close()
 	}
@@ -253,6 +293,10 @@ func (mt *MultiplexTransport) Close() error {
 
 // Listen implements transportLifecycle.
 func (mt *MultiplexTransport) Listen(addr NetAddress) error {
+	if mt.quic {
+		return mt.listenQUIC(addr)
+	}
+
 	ln, err := net.Listen("tcp", addr.DialString())
 	if err != nil {
 		return err
@@ -301,6 +345,14 @@ func (mt *MultiplexTransport) acceptPeers() {
 			return
 		}
 
+		if ip := remoteIP(c.RemoteAddr()); ip != nil {
+			if !mt.inboundLimiter.acquire(ip) {
+				_ = c.Close()
+				continue
+			}
+			c = &limitedConn{Conn: c, release: func() { mt.inboundLimiter.release(ip) }}
+		}
+
 		// Connection upgrade and filtering should be asynchronous to avoid
 		// Head-of-line blocking[0].
 		// Reference:  https://github.com/tendermint/tendermint/issues/2047
@@ -427,11 +479,29 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	// For outgoing conns, ensure connection key matches dialed key.
 	connID := PubKeyToID(secretConn.RemotePubKey())
+	if err := mt.checkDialedID(c, connID, dialedAddr); err != nil {
+		return nil, nil, err
+	}
+
+	nodeInfo, err = mt.exchangeNodeInfo(c, secretConn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := mt.checkNodeInfo(c, connID, nodeInfo); err != nil {
+		return nil, nil, err
+	}
+
+	return secretConn, nodeInfo, nil
+}
+
+// checkDialedID ensures, for outgoing conns, that the ID of the peer
+// authenticated on the connection c matches the dialed ID.
+func (mt *MultiplexTransport) checkDialedID(c net.Conn, connID ID, dialedAddr *NetAddress) error {
 	if dialedAddr != nil {
 		if dialedID := dialedAddr.ID; connID != dialedID {
-			return nil, nil, ErrRejected{
+			return ErrRejected{
 				conn: c,
 				id:   connID,
 				err: fmt.Errorf(
@@ -443,10 +513,15 @@ func (mt *MultiplexTransport) upgrade(
 			}
 		}
 	}
+	return nil
+}
 
-	nodeInfo, err = handshake(secretConn, mt.handshakeTimeout, mt.nodeInfo)
+// exchangeNodeInfo exchanges the NodeInfo with the peer of the connection c
+// over hc, and validates the one of the peer.
+func (mt *MultiplexTransport) exchangeNodeInfo(c net.Conn, hc net.Conn) (NodeInfo, error) {
+	nodeInfo, err := handshake(hc, mt.handshakeTimeout, mt.nodeInfo)
 	if err != nil {
-		return nil, nil, ErrRejected{
+		return nil, ErrRejected{
 			conn:          c,
 			err:           fmt.Errorf("handshake failed: %v", err),
 			isAuthFailure: true,
@@ -454,16 +529,21 @@ func (mt *MultiplexTransport) upgrade(
 	}
 
 	if err := nodeInfo.Validate(); err != nil {
-		return nil, nil, ErrRejected{
+		return nil, ErrRejected{
 			conn:              c,
 			err:               err,
 			isNodeInfoInvalid: true,
 		}
 	}
+	return nodeInfo, nil
+}
 
+// checkNodeInfo ensures the NodeInfo of the peer authenticated on the
+// connection c is its own, and is compatible with ours.
+func (mt *MultiplexTransport) checkNodeInfo(c net.Conn, connID ID, nodeInfo NodeInfo) error {
 	// Ensure connection key matches self reported key.
 	if connID != nodeInfo.ID() {
-		return nil, nil, ErrRejected{
+		return ErrRejected{
 			conn: c,
 			id:   connID,
 			err: fmt.Errorf(
@@ -477,7 +557,7 @@ func (mt *MultiplexTransport) upgrade(
 
 	// Reject self.
 	if mt.nodeInfo.ID() == nodeInfo.ID() {
-		return nil, nil, ErrRejected{
+		return ErrRejected{
 			addr:   *NewNetAddress(nodeInfo.ID(), c.RemoteAddr()),
 			conn:   c,
 			id:     nodeInfo.ID(),
@@ -486,7 +566,7 @@ func (mt *MultiplexTransport) upgrade(
 	}
 
 	if err := mt.nodeInfo.CompatibleWith(nodeInfo); err != nil {
-		return nil, nil, ErrRejected{
+		return ErrRejected{
 			conn:           c,
 			err:            err,
 			id:             nodeInfo.ID(),
@@ -494,7 +574,7 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	return secretConn, nodeInfo, nil
+	return nil
 }
 
 func (mt *MultiplexTransport) wrapPeer(
@@ -523,16 +603,21 @@ func (mt *MultiplexTransport) wrapPeer(
 		socketAddr,
 	)
 
+	var opts []PeerOption
+	if cfg.metrics != nil {
+		opts = append(opts, PeerMetrics(cfg.metrics))
+	}
+
 	p := newPeer(
 		peerConn,
-		mt.mConfig,
+		connConfigWithPeer(mt.mConfig, mt.nodeInfo, ni),
 		ni,
 		cfg.reactorsByCh,
 		cfg.msgTypeByChID,
 		cfg.chDescs,
 		cfg.onPeerError,
 		cfg.mlc,
-		PeerMetrics(cfg.metrics),
+		opts...,
 	)
 
 	return p
@@ -0,0 +1,335 @@
+package p2p
+
+import (
+	"context"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+)
+
+const (
+	// quicALPN is the application protocol negotiated by the QUIC peers.
+	quicALPN = "cometbft-p2p"
+
+	// Sizes of the caches of the TLS sessions and the QUIC address validation
+	// tokens of the dialed peers, which let the connections to the peers
+	// dialed before send their first data without waiting for a round trip.
+	quicSessionCacheSize = 1000
+	quicTokenStoreSize   = 1000
+)
+
+// quicConn is a QUIC connection, used as a net.Conn reading and writing its
+// handshake stream, over which the NodeInfo is exchanged. Closing it closes
+// the connection.
+type quicConn struct {
+	quic.Stream
+	conn quic.Connection
+}
+
+var _ net.Conn = (*quicConn)(nil)
+
+// LocalAddr implements net.Conn.
+func (c *quicConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+// RemoteAddr implements net.Conn.
+func (c *quicConn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// Close implements net.Conn.
+func (c *quicConn) Close() error {
+	return c.conn.CloseWithError(0, "")
+}
+
+// initQUIC creates the TLS and QUIC configs of the transport. The peers are
+// authenticated by a self-signed certificate of their node key.
+func (mt *MultiplexTransport) initQUIC() error {
+	mt.quicOnce.Do(func() {
+		mt.quicTLS, mt.quicErr = quicTLSConfig(mt.nodeKey)
+		mt.quicConfig = &quic.Config{
+			HandshakeIdleTimeout: mt.handshakeTimeout,
+			MaxIdleTimeout:       mt.mConfig.PingInterval + mt.mConfig.PongTimeout,
+			KeepAlivePeriod:      mt.mConfig.PingInterval,
+			TokenStore:           quic.NewLRUTokenStore(quicTokenStoreSize, 1),
+			Allow0RTT:            true,
+		}
+	})
+	return mt.quicErr
+}
+
+func (mt *MultiplexTransport) listenQUIC(addr NetAddress) error {
+	if err := mt.initQUIC(); err != nil {
+		return err
+	}
+	ln, err := quic.ListenAddrEarly(addr.DialString(), mt.quicTLS, mt.quicConfig)
+	if err != nil {
+		return err
+	}
+
+	mt.netAddr = addr
+	mt.quicListener = ln
+
+	go mt.acceptQUICPeers()
+
+	return nil
+}
+
+func (mt *MultiplexTransport) dialQUIC(addr NetAddress, cfg peerConfig) (Peer, error) {
+	if err := mt.initQUIC(); err != nil {
+		return nil, err
+	}
+	// The TLS sessions are cached by server name, so the ID of the peer is
+	// used as server name for the sessions to be resumed on reconnection.
+	tlsConf := mt.quicTLS.Clone()
+	tlsConf.ServerName = string(addr.ID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), mt.dialTimeout)
+	defer cancel()
+	qc, err := quic.DialAddrEarly(ctx, addr.DialString(), tlsConf, mt.quicConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	c, nodeInfo, err := mt.upgradeQUIC(qc, &addr)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.outbound = true
+
+	return mt.wrapPeer(c, nodeInfo, cfg, &addr), nil
+}
+
+func (mt *MultiplexTransport) acceptQUICPeers() {
+	// Number of incoming connections, bounded by maxIncomingConnections.
+	var incoming chan struct{}
+	if mt.maxIncomingConnections > 0 {
+		incoming = make(chan struct{}, mt.maxIncomingConnections)
+	}
+
+	for {
+		qc, err := mt.quicListener.Accept(context.Background())
+		if err != nil {
+			// If Close() has been called, silently exit.
+			select {
+			case _, ok := <-mt.closec:
+				if !ok {
+					return
+				}
+			default:
+				// Transport is not closed
+			}
+
+			mt.acceptc <- accept{err: err}
+			return
+		}
+
+		if ip := remoteIP(qc.RemoteAddr()); ip != nil {
+			if !mt.inboundLimiter.acquire(ip) {
+				_ = qc.CloseWithError(0, "too many connections")
+				continue
+			}
+			go func() {
+				<-qc.Context().Done()
+				mt.inboundLimiter.release(ip)
+			}()
+		}
+
+		if incoming != nil {
+			select {
+			case incoming <- struct{}{}:
+				go func() {
+					<-qc.Context().Done()
+					<-incoming
+				}()
+			default:
+				_ = qc.CloseWithError(0, "too many connections")
+				continue
+			}
+		}
+
+		// Connection upgrade and filtering should be asynchronous to avoid
+		// Head-of-line blocking, as for the TCP connections.
+		go func(qc quic.EarlyConnection) {
+			defer func() {
+				if r := recover(); r != nil {
+					err := ErrRejected{
+						err:           fmt.Errorf("recovered from panic: %v", r),
+						isAuthFailure: true,
+					}
+					select {
+					case mt.acceptc <- accept{err: err}:
+					case <-mt.closec:
+						// Give up if the transport was closed.
+						_ = qc.CloseWithError(0, "")
+						return
+					}
+				}
+			}()
+
+			var netAddr *NetAddress
+			c, nodeInfo, err := mt.upgradeQUIC(qc, nil)
+			if err == nil {
+				netAddr = NewNetAddress(nodeInfo.ID(), c.RemoteAddr())
+			}
+
+			select {
+			case mt.acceptc <- accept{netAddr, c, nodeInfo, err}:
+				// Make the upgraded peer available.
+			case <-mt.closec:
+				// Give up if the transport was closed.
+				_ = qc.CloseWithError(0, "")
+				return
+			}
+		}(qc)
+	}
+}
+
+// upgradeQUIC opens, or accepts, the handshake stream of the connection and
+// exchanges the NodeInfo over it. The dialer may send its NodeInfo before the
+// end of the QUIC handshake, as 0-RTT data, when reconnecting to a peer. The
+// peer is then authenticated once the QUIC handshake is complete.
+func (mt *MultiplexTransport) upgradeQUIC(
+	qc quic.EarlyConnection,
+	dialedAddr *NetAddress,
+) (_ *quicConn, nodeInfo NodeInfo, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), mt.handshakeTimeout)
+	defer cancel()
+
+	var stream quic.Stream
+	if dialedAddr != nil {
+		stream, err = qc.OpenStreamSync(ctx)
+	} else {
+		stream, err = qc.AcceptStream(ctx)
+	}
+	if err != nil {
+		_ = qc.CloseWithError(0, "")
+		return nil, nil, ErrRejected{
+			err:           fmt.Errorf("failed to open handshake stream: %w", err),
+			isAuthFailure: true,
+		}
+	}
+	c := &quicConn{Stream: stream, conn: qc}
+
+	if err := mt.filterConn(c); err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = mt.cleanup(c)
+		}
+	}()
+
+	nodeInfo, err = mt.exchangeNodeInfo(c, c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	select {
+	case <-qc.HandshakeComplete():
+	case <-qc.Context().Done():
+		return nil, nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("QUIC handshake failed: %w", context.Cause(qc.Context())),
+			isAuthFailure: true,
+		}
+	case <-ctx.Done():
+		return nil, nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("QUIC handshake failed: %w", ctx.Err()),
+			isAuthFailure: true,
+		}
+	}
+	connID, err := quicPeerID(qc)
+	if err != nil {
+		return nil, nil, ErrRejected{
+			conn:          c,
+			err:           err,
+			isAuthFailure: true,
+		}
+	}
+
+	if err := mt.checkDialedID(c, connID, dialedAddr); err != nil {
+		return nil, nil, err
+	}
+	if err := mt.checkNodeInfo(c, connID, nodeInfo); err != nil {
+		return nil, nil, err
+	}
+
+	return c, nodeInfo, nil
+}
+
+// quicTLSConfig returns the TLS config authenticating the node with a
+// self-signed certificate of its key, which must be an ed25519 key. The
+// certificates of the peers are not verified against a CA: the peers are
+// authenticated by the IDs derived from their keys, as for the secret
+// connections.
+func quicTLSConfig(nodeKey NodeKey) (*tls.Config, error) {
+	privKey, ok := nodeKey.PrivKey.(ed25519.PrivKey)
+	if !ok {
+		return nil, fmt.Errorf("QUIC requires an ed25519 node key, got %s", nodeKey.PrivKey.Type())
+	}
+	key := stded25519.PrivateKey(privKey.Bytes())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Unix(0, 0),
+		NotAfter:     time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	return &tls.Config{
+		Certificates:          []tls.Certificate{{Certificate: [][]byte{cert}, PrivateKey: key}},
+		MinVersion:            tls.VersionTLS13,
+		NextProtos:            []string{quicALPN},
+		ClientAuth:            tls.RequireAnyClientCert,
+		InsecureSkipVerify:    true, //nolint:gosec // the peers are authenticated by their IDs
+		VerifyPeerCertificate: verifyQUICPeerCertificate,
+		ClientSessionCache:    tls.NewLRUClientSessionCache(quicSessionCacheSize),
+	}, nil
+}
+
+// verifyQUICPeerCertificate checks the peer presented a single self-signed
+// certificate of an ed25519 key.
+func verifyQUICPeerCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) != 1 {
+		return fmt.Errorf("expected a single certificate, got %d", len(rawCerts))
+	}
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return err
+	}
+	if _, ok := cert.PublicKey.(stded25519.PublicKey); !ok {
+		return fmt.Errorf("expected an ed25519 certificate key, got %T", cert.PublicKey)
+	}
+	return cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature)
+}
+
+// quicPeerID returns the ID of the peer of the connection, derived from the
+// key of its certificate.
+func quicPeerID(qc quic.Connection) (ID, error) {
+	certs := qc.ConnectionState().TLS.PeerCertificates
+	if len(certs) == 0 {
+		return "", errors.New("peer presented no certificate")
+	}
+	pubKey, ok := certs[0].PublicKey.(stded25519.PublicKey)
+	if !ok {
+		return "", fmt.Errorf("expected an ed25519 certificate key, got %T", certs[0].PublicKey)
+	}
+	return PubKeyToID(ed25519.PubKey(pubKey)), nil
+}
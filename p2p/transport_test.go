@@ -186,6 +186,49 @@ func TestTransportMultiplexMaxIncomingConnections(t *testing.T) {
 	}
 }
 
+func TestTransportMultiplexMaxIncomingConnectionsPerIP(t *testing.T) {
+	pv := ed25519.GenPrivKey()
+	id := PubKeyToID(pv.PubKey())
+	mt := newMultiplexTransport(testNodeInfo(id, "transport"), NodeKey{PrivKey: pv})
+	MultiplexTransportMaxIncomingConnectionsPerIP(1)(mt)
+
+	addr, err := NewNetAddressString(IDAddressString(id, "127.0.0.1:0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mt.Listen(*addr); err != nil {
+		t.Fatal(err)
+	}
+	defer mt.Close()
+	laddr := NewNetAddress(mt.nodeKey.ID(), mt.listener.Addr())
+
+	errc := make(chan error)
+	go testDialer(*laddr, errc)
+	if err := <-errc; err != nil {
+		t.Fatalf("dialer connection failed: %v", err)
+	}
+	p, err := mt.Accept(peerConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The second connection from 127.0.0.1 is closed right away...
+	go testDialer(*laddr, errc)
+	if err := <-errc; err == nil {
+		t.Fatal("expected the second connection to be rejected")
+	}
+
+	// ...until the first one is closed.
+	mt.Cleanup(p)
+	go testDialer(*laddr, errc)
+	if err := <-errc; err != nil {
+		t.Fatalf("dialer connection failed: %v", err)
+	}
+	if _, err := mt.Accept(peerConfig{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestTransportMultiplexAcceptMultiple(t *testing.T) {
 	mt := testSetupMultiplexTransport(t)
 	laddr := NewNetAddress(mt.nodeKey.ID(), mt.listener.Addr())
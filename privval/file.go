@@ -11,6 +11,7 @@ import (
 
 	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/libs/atrest"
 	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	cmtos "github.com/cometbft/cometbft/libs/os"
@@ -80,6 +81,7 @@ type FilePVLastSignState struct {
 	SignBytes cmtbytes.HexBytes `json:"signbytes,omitempty"`
 
 	filePath string
+	cipher   *atrest.Cipher
 }
 
 func (lss *FilePVLastSignState) reset() {
@@ -141,7 +143,7 @@ func (lss *FilePVLastSignState) Save() {
 	if err != nil {
 		panic(err)
 	}
-	err = tempfile.WriteFileAtomic(outFile, jsonBytes, 0600)
+	err = atrest.WriteFile(outFile, jsonBytes, 0600, lss.cipher)
 	if err != nil {
 		panic(err)
 	}
@@ -154,14 +156,30 @@ func (lss *FilePVLastSignState) Save() {
 // NOTE: the directories containing pv.Key.filePath and pv.LastSignState.filePath must already exist.
 // It includes the LastSignature and LastSignBytes so we don't lose the signature
 // if the process crashes after signing but before the resulting consensus message is processed.
+//
+// If a WatermarkStore is set, the FilePV also advances the watermark of the
+// validator in the store before signing at a new height, round and step, so
+// that several instances of the validator can't double sign.
 type FilePV struct {
 	Key           FilePVKey
 	LastSignState FilePVLastSignState
+
+	watermarks WatermarkStore
+}
+
+// FilePVOption sets an optional parameter on the FilePV.
+type FilePVOption func(*FilePV)
+
+// WithStateCipher encrypts the FilePVLastSignState at rest with the given
+// cipher. The state saved in plaintext is still loaded, and is encrypted the
+// next time it is saved.
+func WithStateCipher(c *atrest.Cipher) FilePVOption {
+	return func(pv *FilePV) { pv.LastSignState.cipher = c }
 }
 
 // NewFilePV generates a new validator from the given key and paths.
-func NewFilePV(privKey crypto.PrivKey, keyFilePath, stateFilePath string) *FilePV {
-	return &FilePV{
+func NewFilePV(privKey crypto.PrivKey, keyFilePath, stateFilePath string, options ...FilePVOption) *FilePV {
+	pv := &FilePV{
 		Key: FilePVKey{
 			Address:  privKey.PubKey().Address(),
 			PubKey:   privKey.PubKey(),
@@ -173,29 +191,33 @@ func NewFilePV(privKey crypto.PrivKey, keyFilePath, stateFilePath string) *FileP
 			filePath: stateFilePath,
 		},
 	}
+	for _, option := range options {
+		option(pv)
+	}
+	return pv
 }
 
 // GenFilePV generates a new validator with randomly generated private key
 // and sets the filePaths, but does not call Save().
-func GenFilePV(keyFilePath, stateFilePath string) *FilePV {
-	return NewFilePV(ed25519.GenPrivKey(), keyFilePath, stateFilePath)
+func GenFilePV(keyFilePath, stateFilePath string, options ...FilePVOption) *FilePV {
+	return NewFilePV(ed25519.GenPrivKey(), keyFilePath, stateFilePath, options...)
 }
 
 // LoadFilePV loads a FilePV from the filePaths.  The FilePV handles double
 // signing prevention by persisting data to the stateFilePath.  If either file path
 // does not exist, the program will exit.
-func LoadFilePV(keyFilePath, stateFilePath string) *FilePV {
-	return loadFilePV(keyFilePath, stateFilePath, true)
+func LoadFilePV(keyFilePath, stateFilePath string, options ...FilePVOption) *FilePV {
+	return loadFilePV(keyFilePath, stateFilePath, true, options...)
 }
 
 // LoadFilePVEmptyState loads a FilePV from the given keyFilePath, with an empty LastSignState.
 // If the keyFilePath does not exist, the program will exit.
-func LoadFilePVEmptyState(keyFilePath, stateFilePath string) *FilePV {
-	return loadFilePV(keyFilePath, stateFilePath, false)
+func LoadFilePVEmptyState(keyFilePath, stateFilePath string, options ...FilePVOption) *FilePV {
+	return loadFilePV(keyFilePath, stateFilePath, false, options...)
 }
 
 // If loadState is true, we load from the stateFilePath. Otherwise, we use an empty LastSignState.
-func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
+func loadFilePV(keyFilePath, stateFilePath string, loadState bool, options ...FilePVOption) *FilePV {
 	keyJSONBytes, err := os.ReadFile(keyFilePath)
 	if err != nil {
 		cmtos.Exit(err.Error())
@@ -211,10 +233,15 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
 	pvKey.Address = pvKey.PubKey.Address()
 	pvKey.filePath = keyFilePath
 
+	pv := &FilePV{Key: pvKey}
+	for _, option := range options {
+		option(pv)
+	}
+
 	pvState := FilePVLastSignState{}
 
 	if loadState {
-		stateJSONBytes, err := os.ReadFile(stateFilePath)
+		stateJSONBytes, err := atrest.ReadFile(stateFilePath, pv.LastSignState.cipher)
 		if err != nil {
 			cmtos.Exit(err.Error())
 		}
@@ -225,21 +252,20 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
 	}
 
 	pvState.filePath = stateFilePath
+	pvState.cipher = pv.LastSignState.cipher
+	pv.LastSignState = pvState
 
-	return &FilePV{
-		Key:           pvKey,
-		LastSignState: pvState,
-	}
+	return pv
 }
 
 // LoadOrGenFilePV loads a FilePV from the given filePaths
 // or else generates a new one and saves it to the filePaths.
-func LoadOrGenFilePV(keyFilePath, stateFilePath string) *FilePV {
+func LoadOrGenFilePV(keyFilePath, stateFilePath string, options ...FilePVOption) *FilePV {
 	var pv *FilePV
 	if cmtos.FileExists(keyFilePath) {
-		pv = LoadFilePV(keyFilePath, stateFilePath)
+		pv = LoadFilePV(keyFilePath, stateFilePath, options...)
 	} else {
-		pv = GenFilePV(keyFilePath, stateFilePath)
+		pv = GenFilePV(keyFilePath, stateFilePath, options...)
 		pv.Save()
 	}
 	return pv
@@ -261,7 +287,7 @@ func (pv *FilePV) GetPubKey() (crypto.PubKey, error) {
 // chainID. Implements PrivValidator.
 func (pv *FilePV) SignVote(chainID string, vote *cmtproto.Vote) error {
 	if err := pv.signVote(chainID, vote); err != nil {
-		return fmt.Errorf("error signing vote: %v", err)
+		return fmt.Errorf("error signing vote: %w", err)
 	}
 	return nil
 }
@@ -270,7 +296,7 @@ func (pv *FilePV) SignVote(chainID string, vote *cmtproto.Vote) error {
 // the chainID. Implements PrivValidator.
 func (pv *FilePV) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
 	if err := pv.signProposal(chainID, proposal); err != nil {
-		return fmt.Errorf("error signing proposal: %v", err)
+		return fmt.Errorf("error signing proposal: %w", err)
 	}
 	return nil
 }
@@ -356,6 +382,9 @@ func (pv *FilePV) signVote(chainID string, vote *cmtproto.Vote) error {
 	}
 
 	// It passed the checks. Sign the vote
+	if err := pv.advanceWatermark(height, round, step); err != nil {
+		return err
+	}
 	sig, err := pv.Key.PrivKey.Sign(signBytes)
 	if err != nil {
 		return err
@@ -400,6 +429,9 @@ func (pv *FilePV) signProposal(chainID string, proposal *cmtproto.Proposal) erro
 	}
 
 	// It passed the checks. Sign the proposal
+	if err := pv.advanceWatermark(height, round, step); err != nil {
+		return err
+	}
 	sig, err := pv.Key.PrivKey.Sign(signBytes)
 	if err != nil {
 		return err
@@ -409,6 +441,22 @@ func (pv *FilePV) signProposal(chainID string, proposal *cmtproto.Proposal) erro
 	return nil
 }
 
+// advanceWatermark advances the watermark of the validator in the
+// WatermarkStore, if any, to the given height, round and step.
+// NOTE: if the process crashes after advancing the watermark but before
+// saving the LastSignState, the validator does not sign again at this height,
+// round and step.
+func (pv *FilePV) advanceWatermark(height int64, round int32, step int8) error {
+	if pv.watermarks == nil {
+		return nil
+	}
+	wm := Watermark{Height: height, Round: round, Step: step}
+	if err := pv.watermarks.AdvanceWatermark(pv.Key.Address, wm); err != nil {
+		return fmt.Errorf("failed to advance the watermark to height %v round %v step %v: %w", height, round, step, err)
+	}
+	return nil
+}
+
 // Persist height/round/step and signature
 func (pv *FilePV) saveSigned(height int64, round int32, step int8,
 	signBytes []byte, sig []byte) {
@@ -12,6 +12,7 @@ import (
 
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/crypto/tmhash"
+	"github.com/cometbft/cometbft/libs/atrest"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
@@ -32,6 +33,28 @@ func TestGenLoadValidator(t *testing.T) {
 	assert.Equal(t, height, privVal.LastSignState.Height, "expected privval.LastHeight to have been saved")
 }
 
+func TestEncryptedValidatorState(t *testing.T) {
+	privVal, tempKeyFileName, tempStateFileName := newTestFilePV(t)
+	privVal.LastSignState.Height = 100
+	privVal.Save()
+
+	c, err := atrest.NewCipher(cmtrand.Bytes(atrest.KeySize))
+	require.NoError(t, err)
+
+	// The state saved in plaintext is loaded, and encrypted once saved.
+	privVal = LoadFilePV(tempKeyFileName, tempStateFileName, WithStateCipher(c))
+	require.Equal(t, int64(100), privVal.LastSignState.Height)
+	privVal.LastSignState.Height = 101
+	privVal.Save()
+
+	data, err := os.ReadFile(tempStateFileName)
+	require.NoError(t, err)
+	require.True(t, atrest.IsEncrypted(data))
+
+	privVal = LoadFilePV(tempKeyFileName, tempStateFileName, WithStateCipher(c))
+	require.Equal(t, int64(101), privVal.LastSignState.Height)
+}
+
 func TestResetValidator(t *testing.T) {
 	privVal, _, tempStateFileName := newTestFilePV(t)
 	emptyState := FilePVLastSignState{filePath: tempStateFileName}
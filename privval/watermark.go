@@ -0,0 +1,164 @@
+package privval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/types"
+)
+
+// ErrWatermarkNotAdvanced is returned by a WatermarkStore when the watermark
+// stored for a validator is not lower than the one to advance it to: another
+// instance of the validator already signed at or after this height, round
+// and step.
+var ErrWatermarkNotAdvanced = errors.New("watermark not advanced: already signed at or after this height, round and step")
+
+// Watermark is the highest height, round and step signed by a validator.
+type Watermark struct {
+	Height int64 `json:"height,string"`
+	Round  int32 `json:"round"`
+	Step   int8  `json:"step"`
+}
+
+// Less reports whether the watermark is lower than the given one.
+func (wm Watermark) Less(other Watermark) bool {
+	if wm.Height != other.Height {
+		return wm.Height < other.Height
+	}
+	if wm.Round != other.Round {
+		return wm.Round < other.Round
+	}
+	return wm.Step < other.Step
+}
+
+// WatermarkStore is a strongly-consistent store of the watermarks of the
+// validators, shared by the instances of a validator in an active-passive
+// setup. Before signing at a new height, round and step, the FilePV advances
+// the watermark of the validator in the store, and only signs if it was
+// advanced, so that an instance whose last sign state is behind, such as a
+// passive instance taking over, can never sign at a height, round and step
+// already signed by another instance.
+//
+// Advancing the watermark must be atomic, e.g. a compare-and-swap on a store
+// replicated with a consensus protocol such as Raft.
+type WatermarkStore interface {
+	// AdvanceWatermark sets the watermark of the validator with the given
+	// address to the given one if it is higher than the stored one, and
+	// returns ErrWatermarkNotAdvanced otherwise.
+	AdvanceWatermark(address types.Address, wm Watermark) error
+}
+
+// WithWatermarkStore checks the watermark store before signing at a new
+// height, round and step. See WatermarkStore.
+func WithWatermarkStore(s WatermarkStore) FilePVOption {
+	return func(pv *FilePV) { pv.watermarks = s }
+}
+
+//-----------------------------------------------------------------------------
+
+// MemWatermarkStore is a WatermarkStore held in memory. It's only consistent
+// within a process, and is mostly useful for testing.
+type MemWatermarkStore struct {
+	mtx        cmtsync.Mutex
+	watermarks map[string]Watermark
+}
+
+var _ WatermarkStore = (*MemWatermarkStore)(nil)
+
+// NewMemWatermarkStore returns an empty MemWatermarkStore.
+func NewMemWatermarkStore() *MemWatermarkStore {
+	return &MemWatermarkStore{watermarks: make(map[string]Watermark)}
+}
+
+// AdvanceWatermark implements WatermarkStore.
+func (s *MemWatermarkStore) AdvanceWatermark(address types.Address, wm Watermark) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if !s.watermarks[string(address)].Less(wm) {
+		return ErrWatermarkNotAdvanced
+	}
+	s.watermarks[string(address)] = wm
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+// Default timeout of the requests of an HTTPWatermarkStore.
+const defaultWatermarkStoreTimeout = 3 * time.Second
+
+// HTTPWatermarkStore is a WatermarkStore served by an external service, such
+// as a plugin in front of etcd or of another strongly-consistent store.
+//
+// The watermark is advanced with a POST request to the URL of the service,
+// whose JSON body is the address of the validator, in hex, and the
+// watermark:
+//
+//	{"address": "...", "height": "5", "round": 0, "step": 2}
+//
+// The service must reply with 200 OK if the watermark was advanced, and with
+// 409 Conflict if the stored watermark is not lower. Any other reply, or a
+// failure to reach the service, is an error, so the validator does not sign.
+type HTTPWatermarkStore struct {
+	url    string
+	client *http.Client
+}
+
+var _ WatermarkStore = (*HTTPWatermarkStore)(nil)
+
+// NewHTTPWatermarkStore returns an HTTPWatermarkStore served at the given
+// http or https URL.
+func NewHTTPWatermarkStore(rawURL string) (*HTTPWatermarkStore, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid watermark store URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid watermark store URL %q: scheme must be http or https", rawURL)
+	}
+	return &HTTPWatermarkStore{
+		url:    rawURL,
+		client: &http.Client{Timeout: defaultWatermarkStoreTimeout},
+	}, nil
+}
+
+type advanceWatermarkRequest struct {
+	Address types.Address `json:"address"`
+	Watermark
+}
+
+// AdvanceWatermark implements WatermarkStore.
+func (s *HTTPWatermarkStore) AdvanceWatermark(address types.Address, wm Watermark) error {
+	body, err := json.Marshal(advanceWatermarkRequest{Address: address, Watermark: wm})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("advancing watermark: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusConflict:
+		return ErrWatermarkNotAdvanced
+	default:
+		return fmt.Errorf("advancing watermark: unexpected status %s", resp.Status)
+	}
+}
@@ -0,0 +1,100 @@
+package privval
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestWatermarkStoreFailover(t *testing.T) {
+	active, keyFile, _ := newTestFilePV(t)
+	active.Save()
+	store := NewMemWatermarkStore()
+	active.watermarks = store
+	// The passive instance has the same key, but its own, empty, state.
+	passive := LoadFilePVEmptyState(keyFile, filepath.Join(t.TempDir(), "state.json"), WithWatermarkStore(store))
+
+	block1 := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size),
+		PartSetHeader: types.PartSetHeader{Total: 5, Hash: cmtrand.Bytes(tmhash.Size)}}
+	block2 := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size),
+		PartSetHeader: types.PartSetHeader{Total: 5, Hash: cmtrand.Bytes(tmhash.Size)}}
+	height := int64(10)
+
+	vote := newVote(active.Key.Address, 0, height, 0, cmtproto.PrevoteType, block1, nil).ToProto()
+	require.NoError(t, active.SignVote("mychainid", vote))
+	// The active instance can sign the same vote again.
+	require.NoError(t, active.SignVote("mychainid", vote))
+
+	// The passive instance takes over: it can't sign at the height, round
+	// and step signed by the active instance, nor before.
+	vote = newVote(active.Key.Address, 0, height, 0, cmtproto.PrevoteType, block2, nil).ToProto()
+	err := passive.SignVote("mychainid", vote)
+	require.ErrorIs(t, err, ErrWatermarkNotAdvanced)
+	proposal := newProposal(height, 0, block2).ToProto()
+	err = passive.SignProposal("mychainid", proposal)
+	require.ErrorIs(t, err, ErrWatermarkNotAdvanced)
+
+	// It signs from the next step.
+	vote = newVote(active.Key.Address, 0, height, 0, cmtproto.PrecommitType, types.BlockID{}, nil).ToProto()
+	require.NoError(t, passive.SignVote("mychainid", vote))
+	proposal = newProposal(height, 1, block2).ToProto()
+	require.NoError(t, passive.SignProposal("mychainid", proposal))
+
+	// The former active instance can't sign anymore.
+	vote = newVote(active.Key.Address, 0, height, 0, cmtproto.PrecommitType, block1, nil).ToProto()
+	err = active.SignVote("mychainid", vote)
+	require.ErrorIs(t, err, ErrWatermarkNotAdvanced)
+	require.Equal(t, int64(height), active.LastSignState.Height)
+	require.Equal(t, stepPrevote, active.LastSignState.Step)
+}
+
+func TestHTTPWatermarkStore(t *testing.T) {
+	mem := NewMemWatermarkStore()
+	failing := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var req advanceWatermarkRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch err := mem.AdvanceWatermark(req.Address, req.Watermark); {
+		case errors.Is(err, ErrWatermarkNotAdvanced):
+			w.WriteHeader(http.StatusConflict)
+		case err != nil:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	_, err := NewHTTPWatermarkStore("tcp://127.0.0.1:1234")
+	require.Error(t, err)
+	store, err := NewHTTPWatermarkStore(srv.URL)
+	require.NoError(t, err)
+
+	addr := cmtrand.Bytes(20)
+	require.NoError(t, store.AdvanceWatermark(addr, Watermark{Height: 5, Round: 1, Step: stepPrevote}))
+	require.ErrorIs(t, store.AdvanceWatermark(addr, Watermark{Height: 5, Round: 1, Step: stepPrevote}), ErrWatermarkNotAdvanced)
+	require.ErrorIs(t, store.AdvanceWatermark(addr, Watermark{Height: 5, Round: 0, Step: stepPrecommit}), ErrWatermarkNotAdvanced)
+	require.NoError(t, store.AdvanceWatermark(addr, Watermark{Height: 5, Round: 1, Step: stepPrecommit}))
+	// Another validator has its own watermark.
+	require.NoError(t, store.AdvanceWatermark(cmtrand.Bytes(20), Watermark{Height: 1, Step: stepPropose}))
+
+	failing = true
+	err = store.AdvanceWatermark(addr, Watermark{Height: 6, Step: stepPropose})
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrWatermarkNotAdvanced)
+}
@@ -18,6 +18,11 @@ var _ p2p.Wrapper = &NewRoundStep{}
 var _ p2p.Wrapper = &HasVote{}
 var _ p2p.Wrapper = &HasProposalBlockPart{}
 var _ p2p.Wrapper = &BlockPart{}
+var _ p2p.Wrapper = &CommitCertificate{}
+var _ p2p.Wrapper = &BlockParityPart{}
+var _ p2p.Wrapper = &CompactBlock{}
+var _ p2p.Wrapper = &MissingTxsRequest{}
+var _ p2p.Wrapper = &MissingTxs{}
 
 func (m *VoteSetBits) Wrap() proto.Message {
 	cm := &Message{}
@@ -44,6 +49,36 @@ func (m *HasProposalBlockPart) Wrap() proto.Message {
 	return cm
 }
 
+func (m *CommitCertificate) Wrap() proto.Message {
+	cm := &Message{}
+	cm.Sum = &Message_CommitCertificate{CommitCertificate: m}
+	return cm
+}
+
+func (m *BlockParityPart) Wrap() proto.Message {
+	cm := &Message{}
+	cm.Sum = &Message_BlockParityPart{BlockParityPart: m}
+	return cm
+}
+
+func (m *CompactBlock) Wrap() proto.Message {
+	cm := &Message{}
+	cm.Sum = &Message_CompactBlock{CompactBlock: m}
+	return cm
+}
+
+func (m *MissingTxsRequest) Wrap() proto.Message {
+	cm := &Message{}
+	cm.Sum = &Message_MissingTxsRequest{MissingTxsRequest: m}
+	return cm
+}
+
+func (m *MissingTxs) Wrap() proto.Message {
+	cm := &Message{}
+	cm.Sum = &Message_MissingTxs{MissingTxs: m}
+	return cm
+}
+
 func (m *Vote) Wrap() proto.Message {
 	cm := &Message{}
 	cm.Sum = &Message_Vote{Vote: m}
@@ -114,6 +149,21 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_VoteSetBits:
 		return m.GetVoteSetBits(), nil
 
+	case *Message_CommitCertificate:
+		return m.GetCommitCertificate(), nil
+
+	case *Message_BlockParityPart:
+		return m.GetBlockParityPart(), nil
+
+	case *Message_CompactBlock:
+		return m.GetCompactBlock(), nil
+
+	case *Message_MissingTxsRequest:
+		return m.GetMissingTxsRequest(), nil
+
+	case *Message_MissingTxs:
+		return m.GetMissingTxs(), nil
+
 	default:
 		return nil, fmt.Errorf("unknown message: %T", msg)
 	}
@@ -670,6 +670,359 @@ func (m *VoteSetBits) GetVotes() bits.BitArray {
 	return bits.BitArray{}
 }
 
+// CommitCertificate is sent to a peer lagging behind with the commit of its height,
+// for it to add all the precommits at once.
+type CommitCertificate struct {
+	Commit *types.ExtendedCommit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+}
+
+func (m *CommitCertificate) Reset()         { *m = CommitCertificate{} }
+func (m *CommitCertificate) String() string { return proto.CompactTextString(m) }
+func (*CommitCertificate) ProtoMessage()    {}
+func (*CommitCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_81a22d2efc008981, []int{10}
+}
+func (m *CommitCertificate) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CommitCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CommitCertificate.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CommitCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitCertificate.Merge(m, src)
+}
+func (m *CommitCertificate) XXX_Size() int {
+	return m.Size()
+}
+func (m *CommitCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitCertificate proto.InternalMessageInfo
+
+func (m *CommitCertificate) GetCommit() *types.ExtendedCommit {
+	if m != nil {
+		return m.Commit
+	}
+	return nil
+}
+
+// BlockParityPart is sent when gossipping a parity part of the proposed block, computed
+// with a Reed-Solomon code, from which the missing parts of the block can be reconstructed.
+// It is only sent to the peers advertising the "consensus/erasure-coded-block-parts" capability.
+type BlockParityPart struct {
+	Height        int64               `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round         int32               `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	PartSetHeader types.PartSetHeader `protobuf:"bytes,3,opt,name=part_set_header,json=partSetHeader,proto3" json:"part_set_header"`
+	Index         uint32              `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
+	LastPartSize  uint32              `protobuf:"varint,5,opt,name=last_part_size,json=lastPartSize,proto3" json:"last_part_size,omitempty"`
+	Bytes         []byte              `protobuf:"bytes,6,opt,name=bytes,proto3" json:"bytes,omitempty"`
+}
+
+func (m *BlockParityPart) Reset()         { *m = BlockParityPart{} }
+func (m *BlockParityPart) String() string { return proto.CompactTextString(m) }
+func (*BlockParityPart) ProtoMessage()    {}
+func (*BlockParityPart) Descriptor() ([]byte, []int) {
+	return fileDescriptor_81a22d2efc008981, []int{11}
+}
+func (m *BlockParityPart) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockParityPart) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockParityPart.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlockParityPart) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockParityPart.Merge(m, src)
+}
+func (m *BlockParityPart) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockParityPart) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockParityPart.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockParityPart proto.InternalMessageInfo
+
+func (m *BlockParityPart) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BlockParityPart) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *BlockParityPart) GetPartSetHeader() types.PartSetHeader {
+	if m != nil {
+		return m.PartSetHeader
+	}
+	return types.PartSetHeader{}
+}
+
+func (m *BlockParityPart) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *BlockParityPart) GetLastPartSize() uint32 {
+	if m != nil {
+		return m.LastPartSize
+	}
+	return 0
+}
+
+func (m *BlockParityPart) GetBytes() []byte {
+	if m != nil {
+		return m.Bytes
+	}
+	return nil
+}
+
+// CompactBlock is sent instead of the parts of the proposed block, which it carries
+// without its transactions, identified by their keys. The receiver looks them up in its
+// mempool to reconstruct the block, and requests the missing ones with MissingTxsRequest.
+// It is only sent to the peers advertising the "consensus/compact-blocks" capability.
+type CompactBlock struct {
+	Height        int64               `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round         int32               `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	PartSetHeader types.PartSetHeader `protobuf:"bytes,3,opt,name=part_set_header,json=partSetHeader,proto3" json:"part_set_header"`
+	Block         *types.Block        `protobuf:"bytes,4,opt,name=block,proto3" json:"block,omitempty"`
+	TxKeys        [][]byte            `protobuf:"bytes,5,rep,name=tx_keys,json=txKeys,proto3" json:"tx_keys,omitempty"`
+}
+
+func (m *CompactBlock) Reset()         { *m = CompactBlock{} }
+func (m *CompactBlock) String() string { return proto.CompactTextString(m) }
+func (*CompactBlock) ProtoMessage()    {}
+func (*CompactBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_81a22d2efc008981, []int{12}
+}
+func (m *CompactBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CompactBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CompactBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CompactBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactBlock.Merge(m, src)
+}
+func (m *CompactBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *CompactBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactBlock proto.InternalMessageInfo
+
+func (m *CompactBlock) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *CompactBlock) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *CompactBlock) GetPartSetHeader() types.PartSetHeader {
+	if m != nil {
+		return m.PartSetHeader
+	}
+	return types.PartSetHeader{}
+}
+
+func (m *CompactBlock) GetBlock() *types.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *CompactBlock) GetTxKeys() [][]byte {
+	if m != nil {
+		return m.TxKeys
+	}
+	return nil
+}
+
+// MissingTxsRequest is sent to request the transactions of a compact block, by index,
+// missing from the mempool.
+type MissingTxsRequest struct {
+	Height        int64               `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round         int32               `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	PartSetHeader types.PartSetHeader `protobuf:"bytes,3,opt,name=part_set_header,json=partSetHeader,proto3" json:"part_set_header"`
+	Indexes       []uint32            `protobuf:"varint,4,rep,packed,name=indexes,proto3" json:"indexes,omitempty"`
+}
+
+func (m *MissingTxsRequest) Reset()         { *m = MissingTxsRequest{} }
+func (m *MissingTxsRequest) String() string { return proto.CompactTextString(m) }
+func (*MissingTxsRequest) ProtoMessage()    {}
+func (*MissingTxsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_81a22d2efc008981, []int{13}
+}
+func (m *MissingTxsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MissingTxsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MissingTxsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MissingTxsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MissingTxsRequest.Merge(m, src)
+}
+func (m *MissingTxsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *MissingTxsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MissingTxsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MissingTxsRequest proto.InternalMessageInfo
+
+func (m *MissingTxsRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *MissingTxsRequest) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *MissingTxsRequest) GetPartSetHeader() types.PartSetHeader {
+	if m != nil {
+		return m.PartSetHeader
+	}
+	return types.PartSetHeader{}
+}
+
+func (m *MissingTxsRequest) GetIndexes() []uint32 {
+	if m != nil {
+		return m.Indexes
+	}
+	return nil
+}
+
+// MissingTxs is sent in response to a MissingTxsRequest, with the requested transactions
+// in the requested order.
+type MissingTxs struct {
+	Height        int64               `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round         int32               `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	PartSetHeader types.PartSetHeader `protobuf:"bytes,3,opt,name=part_set_header,json=partSetHeader,proto3" json:"part_set_header"`
+	Txs           [][]byte            `protobuf:"bytes,4,rep,name=txs,proto3" json:"txs,omitempty"`
+}
+
+func (m *MissingTxs) Reset()         { *m = MissingTxs{} }
+func (m *MissingTxs) String() string { return proto.CompactTextString(m) }
+func (*MissingTxs) ProtoMessage()    {}
+func (*MissingTxs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_81a22d2efc008981, []int{14}
+}
+func (m *MissingTxs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MissingTxs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MissingTxs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MissingTxs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MissingTxs.Merge(m, src)
+}
+func (m *MissingTxs) XXX_Size() int {
+	return m.Size()
+}
+func (m *MissingTxs) XXX_DiscardUnknown() {
+	xxx_messageInfo_MissingTxs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MissingTxs proto.InternalMessageInfo
+
+func (m *MissingTxs) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *MissingTxs) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *MissingTxs) GetPartSetHeader() types.PartSetHeader {
+	if m != nil {
+		return m.PartSetHeader
+	}
+	return types.PartSetHeader{}
+}
+
+func (m *MissingTxs) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
 type Message struct {
 	// Types that are valid to be assigned to Sum:
 	//	*Message_NewRoundStep
@@ -682,6 +1035,11 @@ type Message struct {
 	//	*Message_VoteSetMaj23
 	//	*Message_VoteSetBits
 	//	*Message_HasProposalBlockPart
+	//	*Message_CommitCertificate
+	//	*Message_BlockParityPart
+	//	*Message_CompactBlock
+	//	*Message_MissingTxsRequest
+	//	*Message_MissingTxs
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -689,7 +1047,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{10}
+	return fileDescriptor_81a22d2efc008981, []int{15}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -754,6 +1112,21 @@ type Message_VoteSetBits struct {
 type Message_HasProposalBlockPart struct {
 	HasProposalBlockPart *HasProposalBlockPart `protobuf:"bytes,10,opt,name=has_proposal_block_part,json=hasProposalBlockPart,proto3,oneof" json:"has_proposal_block_part,omitempty"`
 }
+type Message_CommitCertificate struct {
+	CommitCertificate *CommitCertificate `protobuf:"bytes,11,opt,name=commit_certificate,json=commitCertificate,proto3,oneof" json:"commit_certificate,omitempty"`
+}
+type Message_BlockParityPart struct {
+	BlockParityPart *BlockParityPart `protobuf:"bytes,12,opt,name=block_parity_part,json=blockParityPart,proto3,oneof" json:"block_parity_part,omitempty"`
+}
+type Message_CompactBlock struct {
+	CompactBlock *CompactBlock `protobuf:"bytes,13,opt,name=compact_block,json=compactBlock,proto3,oneof" json:"compact_block,omitempty"`
+}
+type Message_MissingTxsRequest struct {
+	MissingTxsRequest *MissingTxsRequest `protobuf:"bytes,14,opt,name=missing_txs_request,json=missingTxsRequest,proto3,oneof" json:"missing_txs_request,omitempty"`
+}
+type Message_MissingTxs struct {
+	MissingTxs *MissingTxs `protobuf:"bytes,15,opt,name=missing_txs,json=missingTxs,proto3,oneof" json:"missing_txs,omitempty"`
+}
 
 func (*Message_NewRoundStep) isMessage_Sum()         {}
 func (*Message_NewValidBlock) isMessage_Sum()        {}
@@ -765,6 +1138,11 @@ func (*Message_HasVote) isMessage_Sum()              {}
 func (*Message_VoteSetMaj23) isMessage_Sum()         {}
 func (*Message_VoteSetBits) isMessage_Sum()          {}
 func (*Message_HasProposalBlockPart) isMessage_Sum() {}
+func (*Message_CommitCertificate) isMessage_Sum()    {}
+func (*Message_BlockParityPart) isMessage_Sum()      {}
+func (*Message_CompactBlock) isMessage_Sum()         {}
+func (*Message_MissingTxsRequest) isMessage_Sum()    {}
+func (*Message_MissingTxs) isMessage_Sum()           {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -843,6 +1221,41 @@ func (m *Message) GetHasProposalBlockPart() *HasProposalBlockPart {
 	return nil
 }
 
+func (m *Message) GetCommitCertificate() *CommitCertificate {
+	if x, ok := m.GetSum().(*Message_CommitCertificate); ok {
+		return x.CommitCertificate
+	}
+	return nil
+}
+
+func (m *Message) GetBlockParityPart() *BlockParityPart {
+	if x, ok := m.GetSum().(*Message_BlockParityPart); ok {
+		return x.BlockParityPart
+	}
+	return nil
+}
+
+func (m *Message) GetCompactBlock() *CompactBlock {
+	if x, ok := m.GetSum().(*Message_CompactBlock); ok {
+		return x.CompactBlock
+	}
+	return nil
+}
+
+func (m *Message) GetMissingTxsRequest() *MissingTxsRequest {
+	if x, ok := m.GetSum().(*Message_MissingTxsRequest); ok {
+		return x.MissingTxsRequest
+	}
+	return nil
+}
+
+func (m *Message) GetMissingTxs() *MissingTxs {
+	if x, ok := m.GetSum().(*Message_MissingTxs); ok {
+		return x.MissingTxs
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -856,6 +1269,11 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_VoteSetMaj23)(nil),
 		(*Message_VoteSetBits)(nil),
 		(*Message_HasProposalBlockPart)(nil),
+		(*Message_CommitCertificate)(nil),
+		(*Message_BlockParityPart)(nil),
+		(*Message_CompactBlock)(nil),
+		(*Message_MissingTxsRequest)(nil),
+		(*Message_MissingTxs)(nil),
 	}
 }
 
@@ -870,69 +1288,93 @@ func init() {
 	proto.RegisterType((*HasProposalBlockPart)(nil), "tendermint.consensus.HasProposalBlockPart")
 	proto.RegisterType((*VoteSetMaj23)(nil), "tendermint.consensus.VoteSetMaj23")
 	proto.RegisterType((*VoteSetBits)(nil), "tendermint.consensus.VoteSetBits")
+	proto.RegisterType((*CommitCertificate)(nil), "tendermint.consensus.CommitCertificate")
+	proto.RegisterType((*BlockParityPart)(nil), "tendermint.consensus.BlockParityPart")
+	proto.RegisterType((*CompactBlock)(nil), "tendermint.consensus.CompactBlock")
+	proto.RegisterType((*MissingTxsRequest)(nil), "tendermint.consensus.MissingTxsRequest")
+	proto.RegisterType((*MissingTxs)(nil), "tendermint.consensus.MissingTxs")
 	proto.RegisterType((*Message)(nil), "tendermint.consensus.Message")
 }
 
 func init() { proto.RegisterFile("tendermint/consensus/types.proto", fileDescriptor_81a22d2efc008981) }
 
 var fileDescriptor_81a22d2efc008981 = []byte{
-	// 896 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0x5f, 0x6f, 0xe3, 0x44,
-	0x10, 0xb7, 0x69, 0xd2, 0xa4, 0x93, 0xfe, 0x81, 0x55, 0xee, 0x08, 0x05, 0xd2, 0x62, 0x5e, 0xaa,
-	0x13, 0x4a, 0x50, 0xfa, 0x70, 0xd2, 0x09, 0x09, 0x08, 0x7f, 0xce, 0x77, 0xba, 0xde, 0x85, 0xcd,
-	0xe9, 0x84, 0xee, 0xc5, 0x72, 0xec, 0x25, 0x59, 0x2e, 0xf6, 0x5a, 0xde, 0x6d, 0x4b, 0x5f, 0xf9,
-	0x04, 0x7c, 0x00, 0xbe, 0x06, 0x12, 0x1f, 0xe1, 0x1e, 0xef, 0x11, 0x09, 0xa9, 0x42, 0xed, 0x47,
-	0x40, 0xbc, 0xa3, 0x1d, 0x6f, 0x9c, 0x2d, 0x75, 0x0b, 0xe5, 0x01, 0x89, 0x37, 0xdb, 0x33, 0xf3,
-	0xdb, 0x99, 0xdf, 0xcc, 0xfc, 0xd6, 0xb0, 0xab, 0x58, 0x1a, 0xb3, 0x3c, 0xe1, 0xa9, 0xea, 0x47,
-	0x22, 0x95, 0x2c, 0x95, 0x87, 0xb2, 0xaf, 0x4e, 0x32, 0x26, 0x7b, 0x59, 0x2e, 0x94, 0x20, 0xed,
-	0xa5, 0x47, 0xaf, 0xf4, 0xd8, 0x6e, 0x4f, 0xc5, 0x54, 0xa0, 0x43, 0x5f, 0x3f, 0x15, 0xbe, 0xdb,
-	0xef, 0x58, 0x68, 0x88, 0x61, 0x23, 0x6d, 0xdb, 0x67, 0xcd, 0xf9, 0x44, 0xf6, 0x27, 0x5c, 0x5d,
-	0xf0, 0xf0, 0x7e, 0x72, 0x61, 0xfd, 0x31, 0x3b, 0xa6, 0xe2, 0x30, 0x8d, 0xc7, 0x8a, 0x65, 0xe4,
-	0x36, 0xac, 0xce, 0x18, 0x9f, 0xce, 0x54, 0xc7, 0xdd, 0x75, 0xf7, 0x56, 0xa8, 0x79, 0x23, 0x6d,
-	0xa8, 0xe7, 0xda, 0xa9, 0xf3, 0xda, 0xae, 0xbb, 0x57, 0xa7, 0xc5, 0x0b, 0x21, 0x50, 0x93, 0x8a,
-	0x65, 0x9d, 0x95, 0x5d, 0x77, 0x6f, 0x83, 0xe2, 0x33, 0xb9, 0x0b, 0x1d, 0xc9, 0x22, 0x91, 0xc6,
-	0x32, 0x90, 0x3c, 0x8d, 0x58, 0x20, 0x55, 0x98, 0xab, 0x40, 0xf1, 0x84, 0x75, 0x6a, 0x88, 0x79,
-	0xcb, 0xd8, 0xc7, 0xda, 0x3c, 0xd6, 0xd6, 0xa7, 0x3c, 0x61, 0xe4, 0x0e, 0xbc, 0x31, 0x0f, 0xa5,
-	0x0a, 0x22, 0x91, 0x24, 0x5c, 0x05, 0xc5, 0x71, 0x75, 0x3c, 0x6e, 0x4b, 0x1b, 0x3e, 0xc3, 0xef,
-	0x98, 0xaa, 0xf7, 0x87, 0x0b, 0x1b, 0x8f, 0xd9, 0xf1, 0xb3, 0x70, 0xce, 0xe3, 0xe1, 0x5c, 0x44,
-	0x2f, 0x6e, 0x98, 0xf8, 0xd7, 0x70, 0x6b, 0xa2, 0xc3, 0x82, 0x4c, 0xe7, 0x26, 0x99, 0x0a, 0x66,
-	0x2c, 0x8c, 0x59, 0x8e, 0x95, 0xb4, 0x06, 0x3b, 0x3d, 0xab, 0x07, 0x05, 0x5f, 0xa3, 0x30, 0x57,
-	0x63, 0xa6, 0x7c, 0x74, 0x1b, 0xd6, 0x5e, 0x9e, 0xee, 0x38, 0x94, 0x20, 0xc6, 0x05, 0x0b, 0xf9,
-	0x18, 0x5a, 0x4b, 0x64, 0x89, 0x15, 0xb7, 0x06, 0x5d, 0x1b, 0x4f, 0x77, 0xa2, 0xa7, 0x3b, 0xd1,
-	0x1b, 0x72, 0xf5, 0x69, 0x9e, 0x87, 0x27, 0x14, 0x4a, 0x20, 0x49, 0xde, 0x86, 0x35, 0x2e, 0x0d,
-	0x09, 0x58, 0x7e, 0x93, 0x36, 0xb9, 0x2c, 0x8a, 0xf7, 0x7c, 0x68, 0x8e, 0x72, 0x91, 0x09, 0x19,
-	0xce, 0xc9, 0x47, 0xd0, 0xcc, 0xcc, 0x33, 0xd6, 0xdc, 0x1a, 0x6c, 0x57, 0xa4, 0x6d, 0x3c, 0x4c,
-	0xc6, 0x65, 0x84, 0xf7, 0xa3, 0x0b, 0xad, 0x85, 0x71, 0xf4, 0xe4, 0xd1, 0x95, 0xfc, 0x7d, 0x00,
-	0x64, 0x11, 0x13, 0x64, 0x62, 0x1e, 0xd8, 0x64, 0xbe, 0xbe, 0xb0, 0x8c, 0xc4, 0x1c, 0xfb, 0x42,
-	0xee, 0xc3, 0xba, 0xed, 0x6d, 0xe8, 0xfc, 0x9b, 0xf2, 0x4d, 0x6e, 0x2d, 0x0b, 0xcd, 0x7b, 0x01,
-	0x6b, 0xc3, 0x05, 0x27, 0x37, 0xec, 0xed, 0x87, 0x50, 0xd3, 0xdc, 0x9b, 0xb3, 0x6f, 0x57, 0xb7,
-	0xd2, 0x9c, 0x89, 0x9e, 0xde, 0x00, 0x6a, 0xcf, 0x84, 0xd2, 0x13, 0x58, 0x3b, 0x12, 0x8a, 0x19,
-	0x36, 0x2b, 0x22, 0xb5, 0x17, 0x45, 0x1f, 0xef, 0x7b, 0x17, 0x1a, 0x7e, 0x28, 0x31, 0xee, 0x66,
-	0xf9, 0xed, 0x43, 0x4d, 0xa3, 0x61, 0x7e, 0x9b, 0x55, 0xa3, 0x36, 0xe6, 0xd3, 0x94, 0xc5, 0x07,
-	0x72, 0xfa, 0xf4, 0x24, 0x63, 0x14, 0x9d, 0x35, 0x14, 0x4f, 0x63, 0xf6, 0x1d, 0x0e, 0x54, 0x9d,
-	0x16, 0x2f, 0xde, 0x73, 0x68, 0xfb, 0xa1, 0x2c, 0x7b, 0xfc, 0x2f, 0x09, 0x2b, 0xb1, 0x57, 0x6c,
-	0xec, 0x9f, 0x5d, 0x58, 0xd7, 0xd5, 0x8d, 0x99, 0x3a, 0x08, 0xbf, 0x1d, 0xec, 0xff, 0x17, 0x55,
-	0x7e, 0x01, 0xcd, 0x62, 0x79, 0x78, 0x6c, 0x36, 0xe7, 0xad, 0xcb, 0x81, 0x58, 0xe6, 0x83, 0xcf,
-	0x87, 0x5b, 0xba, 0x83, 0x67, 0xa7, 0x3b, 0x0d, 0xf3, 0x81, 0x36, 0x30, 0xf6, 0x41, 0xec, 0xfd,
-	0xee, 0x42, 0xcb, 0xa4, 0x3e, 0xe4, 0x4a, 0xfe, 0x7f, 0x32, 0x27, 0xf7, 0xa0, 0xae, 0xa7, 0x4b,
-	0xe2, 0xe2, 0xff, 0xd3, 0xc5, 0x29, 0x42, 0xbc, 0x5f, 0xeb, 0xd0, 0x38, 0x60, 0x52, 0x86, 0x53,
-	0x46, 0x1e, 0xc2, 0x66, 0xca, 0x8e, 0x8b, 0x65, 0x0d, 0x50, 0xa2, 0x8b, 0x99, 0xf6, 0x7a, 0x55,
-	0x97, 0x4b, 0xcf, 0xbe, 0x02, 0x7c, 0x87, 0xae, 0xa7, 0xf6, 0x95, 0x70, 0x00, 0x5b, 0x1a, 0xeb,
-	0x48, 0x6b, 0x6d, 0x80, 0x89, 0x22, 0x5f, 0xad, 0xc1, 0xfb, 0x57, 0x82, 0x2d, 0x75, 0xd9, 0x77,
-	0xe8, 0x46, 0x7a, 0x41, 0xa8, 0x6d, 0xd9, 0xaa, 0x90, 0x87, 0x25, 0xce, 0x62, 0xac, 0x7d, 0x4b,
-	0xb6, 0xc8, 0x97, 0x7f, 0x11, 0x98, 0x82, 0xeb, 0xf7, 0xae, 0x47, 0x18, 0x3d, 0x79, 0xe4, 0x5f,
-	0xd4, 0x17, 0xf2, 0x09, 0xc0, 0x52, 0xa6, 0x0d, 0xdb, 0x3b, 0xd5, 0x28, 0xe5, 0x5a, 0xf9, 0x0e,
-	0x5d, 0x2b, 0x85, 0x5a, 0xcb, 0x0c, 0x8a, 0xc5, 0xea, 0x65, 0xe9, 0x5d, 0xc6, 0xea, 0x29, 0xf4,
-	0x9d, 0x42, 0x32, 0xc8, 0x3d, 0x68, 0xce, 0x42, 0x19, 0x60, 0x54, 0x03, 0xa3, 0xde, 0xad, 0x8e,
-	0x32, 0xba, 0xe2, 0x3b, 0xb4, 0x31, 0x33, 0x12, 0xf3, 0x10, 0x36, 0x75, 0x1c, 0x5e, 0x55, 0x89,
-	0x5e, 0xc7, 0x4e, 0xf3, 0xba, 0x86, 0xda, 0x8b, 0xab, 0x1b, 0x7a, 0x64, 0x2f, 0xf2, 0x7d, 0xd8,
-	0x28, 0xb1, 0xf4, 0x3c, 0x75, 0xd6, 0xae, 0x23, 0xd1, 0x5a, 0x24, 0x4d, 0xe2, 0x91, 0xb5, 0x57,
-	0x11, 0xbc, 0xa9, 0x0b, 0x2a, 0x1b, 0x62, 0x31, 0x0a, 0x08, 0x79, 0xe7, 0xca, 0xfa, 0x2e, 0x69,
-	0x96, 0xef, 0xd0, 0xf6, 0xac, 0xe2, 0xfb, 0xb0, 0x0e, 0x2b, 0xf2, 0x30, 0x19, 0x7e, 0xf5, 0xf2,
-	0xac, 0xeb, 0xbe, 0x3a, 0xeb, 0xba, 0xbf, 0x9d, 0x75, 0xdd, 0x1f, 0xce, 0xbb, 0xce, 0xab, 0xf3,
-	0xae, 0xf3, 0xcb, 0x79, 0xd7, 0x79, 0x7e, 0x77, 0xca, 0xd5, 0xec, 0x70, 0xd2, 0x8b, 0x44, 0xd2,
-	0x8f, 0x44, 0xc2, 0xd4, 0xe4, 0x1b, 0xb5, 0x7c, 0x28, 0x7e, 0x99, 0xaa, 0x7e, 0xba, 0x26, 0xab,
-	0x68, 0xdb, 0xff, 0x33, 0x00, 0x00, 0xff, 0xff, 0xa6, 0xfa, 0x0b, 0x9d, 0x93, 0x09, 0x00, 0x00,
+	// 1198 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x57, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0xdf, 0xad, 0xff, 0xe6, 0xd9, 0x8e, 0x9b, 0x21, 0x6d, 0x96, 0x00, 0x8e, 0x59, 0x40, 0x58,
+	0x15, 0x38, 0x28, 0x39, 0x14, 0x55, 0x48, 0x80, 0x43, 0xe9, 0x06, 0xea, 0x36, 0x8c, 0xa3, 0xaa,
+	0xf4, 0xb2, 0x5a, 0xaf, 0xa7, 0xf6, 0x10, 0xef, 0xae, 0xd9, 0x99, 0x24, 0x76, 0x8f, 0x7c, 0x02,
+	0xee, 0xf0, 0x09, 0xb8, 0x23, 0xf1, 0x11, 0x7a, 0xac, 0xc4, 0x85, 0x53, 0x55, 0x25, 0x1f, 0x01,
+	0xf5, 0x8e, 0x66, 0x66, 0xed, 0x5d, 0xc7, 0x8e, 0x45, 0x02, 0xa2, 0xe2, 0xb6, 0x33, 0xf3, 0xde,
+	0x6f, 0xde, 0xbf, 0x79, 0xbf, 0xb7, 0x50, 0xe5, 0xc4, 0xef, 0x90, 0xd0, 0xa3, 0x3e, 0xdf, 0x74,
+	0x03, 0x9f, 0x11, 0x9f, 0x1d, 0xb2, 0x4d, 0x3e, 0x1a, 0x10, 0x56, 0x1f, 0x84, 0x01, 0x0f, 0xd0,
+	0x6a, 0x2c, 0x51, 0x9f, 0x48, 0xac, 0xaf, 0x76, 0x83, 0x6e, 0x20, 0x05, 0x36, 0xc5, 0x97, 0x92,
+	0x5d, 0x7f, 0x33, 0x81, 0x26, 0x31, 0x92, 0x48, 0xeb, 0xc9, 0xbb, 0xfa, 0xb4, 0xcd, 0x36, 0xdb,
+	0x94, 0x4f, 0x4b, 0xcc, 0xea, 0xb7, 0xfb, 0x81, 0x7b, 0xa0, 0x4e, 0xcd, 0x5f, 0x75, 0x28, 0xde,
+	0x23, 0xc7, 0x38, 0x38, 0xf4, 0x3b, 0x2d, 0x4e, 0x06, 0xe8, 0x3a, 0x64, 0x7b, 0x84, 0x76, 0x7b,
+	0xdc, 0xd0, 0xab, 0x7a, 0x2d, 0x85, 0xa3, 0x15, 0x5a, 0x85, 0x4c, 0x28, 0x84, 0x8c, 0x2b, 0x55,
+	0xbd, 0x96, 0xc1, 0x6a, 0x81, 0x10, 0xa4, 0x19, 0x27, 0x03, 0x23, 0x55, 0xd5, 0x6b, 0x25, 0x2c,
+	0xbf, 0xd1, 0x4d, 0x30, 0x18, 0x71, 0x03, 0xbf, 0xc3, 0x6c, 0x46, 0x7d, 0x97, 0xd8, 0x8c, 0x3b,
+	0x21, 0xb7, 0x39, 0xf5, 0x88, 0x91, 0x96, 0x98, 0xd7, 0xa2, 0xf3, 0x96, 0x38, 0x6e, 0x89, 0xd3,
+	0x7d, 0xea, 0x11, 0x74, 0x03, 0x56, 0xfa, 0x0e, 0xe3, 0xb6, 0x1b, 0x78, 0x1e, 0xe5, 0xb6, 0xba,
+	0x2e, 0x23, 0xaf, 0x2b, 0x8b, 0x83, 0x1d, 0xb9, 0x2f, 0x4d, 0x35, 0x5f, 0xea, 0x50, 0xba, 0x47,
+	0x8e, 0x1f, 0x38, 0x7d, 0xda, 0x69, 0x08, 0x7f, 0x2e, 0x68, 0xf8, 0x43, 0xb8, 0x26, 0xc3, 0x60,
+	0x0f, 0x84, 0x6d, 0x8c, 0x70, 0xbb, 0x47, 0x9c, 0x0e, 0x09, 0xa5, 0x27, 0x85, 0xad, 0x8d, 0x7a,
+	0x22, 0x43, 0x2a, 0x9a, 0x7b, 0x4e, 0xc8, 0x5b, 0x84, 0x5b, 0x52, 0xac, 0x91, 0x7e, 0xfa, 0x7c,
+	0x43, 0xc3, 0x48, 0x62, 0x4c, 0x9d, 0xa0, 0x4f, 0xa1, 0x10, 0x23, 0x33, 0xe9, 0x71, 0x61, 0xab,
+	0x92, 0xc4, 0x13, 0x79, 0xaa, 0x8b, 0x3c, 0xd5, 0x1b, 0x94, 0x7f, 0x1e, 0x86, 0xce, 0x08, 0xc3,
+	0x04, 0x88, 0xa1, 0x37, 0x60, 0x89, 0xb2, 0x28, 0x08, 0xd2, 0xfd, 0x3c, 0xce, 0x53, 0xa6, 0x9c,
+	0x37, 0x2d, 0xc8, 0xef, 0x85, 0xc1, 0x20, 0x60, 0x4e, 0x1f, 0x7d, 0x02, 0xf9, 0x41, 0xf4, 0x2d,
+	0x7d, 0x2e, 0x6c, 0xad, 0xcf, 0x31, 0x3b, 0x92, 0x88, 0x2c, 0x9e, 0x68, 0x98, 0x3f, 0xeb, 0x50,
+	0x18, 0x1f, 0xee, 0xdd, 0xbf, 0x7b, 0x6e, 0xfc, 0x3e, 0x00, 0x34, 0xd6, 0xb1, 0x07, 0x41, 0xdf,
+	0x4e, 0x06, 0xf3, 0xea, 0xf8, 0x64, 0x2f, 0xe8, 0xcb, 0xbc, 0xa0, 0x3b, 0x50, 0x4c, 0x4a, 0x1b,
+	0xa9, 0xbf, 0xe3, 0x7e, 0x64, 0x5b, 0x21, 0x81, 0x66, 0x1e, 0xc0, 0x52, 0x63, 0x1c, 0x93, 0x0b,
+	0xe6, 0xf6, 0x23, 0x48, 0x8b, 0xd8, 0x47, 0x77, 0x5f, 0x9f, 0x9f, 0xca, 0xe8, 0x4e, 0x29, 0x69,
+	0x6e, 0x41, 0xfa, 0x41, 0xc0, 0x45, 0x05, 0xa6, 0x8f, 0x02, 0x4e, 0x0c, 0xfd, 0x3c, 0x4d, 0x21,
+	0x85, 0xa5, 0x8c, 0xf9, 0x83, 0x0e, 0x39, 0xcb, 0x61, 0x52, 0xef, 0x62, 0xf6, 0x6d, 0x43, 0x5a,
+	0xa0, 0x49, 0xfb, 0x96, 0xe7, 0x95, 0x5a, 0x8b, 0x76, 0x7d, 0xd2, 0x69, 0xb2, 0xee, 0xfe, 0x68,
+	0x40, 0xb0, 0x14, 0x16, 0x50, 0xd4, 0xef, 0x90, 0xa1, 0x2c, 0xa8, 0x0c, 0x56, 0x0b, 0xf3, 0x11,
+	0xac, 0x5a, 0x0e, 0x9b, 0xe4, 0xf8, 0x92, 0x01, 0x9b, 0x60, 0xa7, 0x92, 0xd8, 0xbf, 0xe9, 0x50,
+	0x14, 0xde, 0xb5, 0x08, 0x6f, 0x3a, 0xdf, 0x6d, 0x6d, 0xff, 0x17, 0x5e, 0xde, 0x86, 0xbc, 0x7a,
+	0x3c, 0xb4, 0x13, 0xbd, 0x9c, 0xd7, 0x67, 0x15, 0xa5, 0x9b, 0xbb, 0x5f, 0x34, 0xca, 0x22, 0x83,
+	0x27, 0xcf, 0x37, 0x72, 0xd1, 0x06, 0xce, 0x49, 0xdd, 0xdd, 0x8e, 0xf9, 0xa7, 0x0e, 0x85, 0xc8,
+	0xf4, 0x06, 0xe5, 0xec, 0xff, 0x63, 0x39, 0xba, 0x05, 0x19, 0x51, 0x5d, 0xcc, 0xc8, 0x5c, 0xe0,
+	0xe1, 0x28, 0x15, 0xb3, 0x09, 0x2b, 0xaa, 0x4b, 0xec, 0x90, 0x90, 0xd3, 0xc7, 0xd4, 0x75, 0x38,
+	0x41, 0x1f, 0x43, 0x36, 0x6a, 0x25, 0xaa, 0xa8, 0xab, 0xb3, 0x56, 0xdd, 0x1e, 0xca, 0xad, 0x4e,
+	0xd4, 0x5f, 0x23, 0x79, 0xf3, 0x85, 0x0e, 0xe5, 0x71, 0x45, 0x51, 0x3e, 0xba, 0x44, 0x5d, 0x35,
+	0xa1, 0xfc, 0x8f, 0xda, 0x6b, 0x69, 0x90, 0xdc, 0x9c, 0x7e, 0x02, 0xa5, 0xa8, 0x4c, 0xd1, 0xbb,
+	0xb0, 0x2c, 0x59, 0x43, 0xdd, 0x44, 0x9f, 0x10, 0x19, 0xba, 0x12, 0x2e, 0x8a, 0x5d, 0x89, 0x4a,
+	0x9f, 0xc8, 0xe7, 0xd3, 0x1e, 0x89, 0xb8, 0x66, 0xab, 0x7a, 0xad, 0x88, 0xd5, 0xc2, 0xfc, 0x5d,
+	0x87, 0xe2, 0x4e, 0xe0, 0x0d, 0x1c, 0x97, 0x5f, 0x86, 0x44, 0xfe, 0x65, 0xff, 0x3e, 0x84, 0x8c,
+	0x2c, 0x83, 0xa8, 0x7e, 0xd6, 0xce, 0xa9, 0x1f, 0xac, 0xa4, 0xd0, 0x1a, 0xe4, 0xf8, 0xd0, 0x3e,
+	0x20, 0x23, 0x51, 0x2c, 0xa9, 0x5a, 0x11, 0x67, 0xf9, 0xf0, 0x6b, 0x32, 0x62, 0xe6, 0x2f, 0x3a,
+	0xac, 0x34, 0x29, 0x63, 0xd4, 0xef, 0xee, 0x0f, 0x19, 0x26, 0xdf, 0x1f, 0x12, 0xf6, 0x8a, 0x53,
+	0x67, 0x40, 0x4e, 0x66, 0x8b, 0x08, 0x42, 0x4c, 0xd5, 0x4a, 0x78, 0xbc, 0x34, 0x7f, 0xd2, 0x01,
+	0x62, 0x63, 0x5f, 0xad, 0x95, 0x57, 0x21, 0xc5, 0x87, 0xca, 0xc2, 0x22, 0x16, 0x9f, 0xe6, 0xcb,
+	0x3c, 0xe4, 0x9a, 0x84, 0x31, 0xa7, 0x4b, 0xd0, 0x57, 0xb0, 0xec, 0x93, 0x63, 0xc5, 0x7f, 0xb6,
+	0x9c, 0x7a, 0xd4, 0x8b, 0x32, 0xeb, 0xf3, 0xa6, 0xb9, 0x7a, 0x72, 0xaa, 0xb2, 0x34, 0x5c, 0xf4,
+	0x13, 0x6b, 0x61, 0xb8, 0xc0, 0x3a, 0x12, 0xe3, 0x8b, 0xad, 0x92, 0x7e, 0x45, 0x82, 0xbd, 0x73,
+	0x2e, 0x58, 0x3c, 0xea, 0x58, 0x1a, 0x2e, 0xf9, 0xc9, 0x8d, 0xa9, 0x49, 0x60, 0x0e, 0xe3, 0xc6,
+	0x38, 0x63, 0xa6, 0xb0, 0x12, 0x93, 0x00, 0xfa, 0xf2, 0x0c, 0x67, 0xab, 0xf2, 0x7b, 0x7b, 0x31,
+	0xc2, 0xde, 0xfd, 0xbb, 0xd6, 0x34, 0x65, 0xa3, 0xcf, 0x00, 0xe2, 0xc9, 0xc7, 0xc8, 0xcc, 0x26,
+	0x22, 0x46, 0x99, 0x30, 0x95, 0xa5, 0xe1, 0xa5, 0xc9, 0xec, 0x23, 0x98, 0x5b, 0xf2, 0x6f, 0x76,
+	0x76, 0x9a, 0x89, 0x75, 0x45, 0x63, 0xb7, 0x34, 0xc5, 0xc2, 0xe8, 0x16, 0xe4, 0x7b, 0x0e, 0xb3,
+	0xa5, 0x56, 0x4e, 0x6a, 0xbd, 0x35, 0x5f, 0x2b, 0xa2, 0x6a, 0x4b, 0xc3, 0xb9, 0x9e, 0xfa, 0x14,
+	0x09, 0x15, 0x7a, 0xb2, 0x7a, 0x3c, 0xc1, 0x70, 0x46, 0x7e, 0x51, 0x42, 0x93, 0x5c, 0x28, 0x12,
+	0x7a, 0x94, 0x58, 0xa3, 0x3b, 0x50, 0x9a, 0x60, 0x89, 0x16, 0x6d, 0x2c, 0x2d, 0x0a, 0x62, 0x82,
+	0x9b, 0x44, 0x10, 0x8f, 0xe2, 0x25, 0x72, 0x61, 0x4d, 0x38, 0x34, 0x49, 0x48, 0x22, 0xa2, 0x20,
+	0x21, 0x6f, 0x9c, 0xeb, 0xdf, 0xcc, 0x18, 0x60, 0x69, 0x78, 0xb5, 0x37, 0x67, 0x1f, 0x3d, 0x04,
+	0x14, 0x0d, 0xd9, 0x6e, 0x4c, 0x15, 0x46, 0x41, 0xe2, 0xbf, 0x3f, 0x1f, 0x7f, 0x86, 0x59, 0x2c,
+	0x0d, 0xaf, 0xb8, 0x67, 0x37, 0x51, 0x0b, 0x56, 0x26, 0x16, 0x53, 0x3e, 0x52, 0x86, 0x17, 0x25,
+	0xf0, 0x7b, 0x8b, 0x4b, 0x21, 0xa2, 0x18, 0x4b, 0xc3, 0xe5, 0xf6, 0xf4, 0x16, 0xda, 0x85, 0x92,
+	0xab, 0xba, 0x74, 0xf4, 0x56, 0x4a, 0x8b, 0xf2, 0x94, 0x6c, 0xe8, 0x22, 0x4f, 0x6e, 0x62, 0x8d,
+	0xbe, 0x85, 0xd7, 0x3c, 0xd5, 0x6d, 0x6c, 0x3e, 0x64, 0x76, 0xa8, 0x9a, 0xa3, 0xb1, 0xbc, 0xc8,
+	0xf5, 0x99, 0x5e, 0x2a, 0x5c, 0xf7, 0xce, 0x6e, 0xa2, 0x1d, 0x28, 0x24, 0xa0, 0x8d, 0xf2, 0x2c,
+	0xdd, 0xce, 0x83, 0xb4, 0x34, 0x0c, 0x31, 0x56, 0x23, 0x03, 0x29, 0x76, 0xe8, 0x35, 0xbe, 0x79,
+	0x7a, 0x52, 0xd1, 0x9f, 0x9d, 0x54, 0xf4, 0x17, 0x27, 0x15, 0xfd, 0xc7, 0xd3, 0x8a, 0xf6, 0xec,
+	0xb4, 0xa2, 0xfd, 0x71, 0x5a, 0xd1, 0x1e, 0xdd, 0xec, 0x52, 0xde, 0x3b, 0x6c, 0xd7, 0xdd, 0xc0,
+	0xdb, 0x74, 0x03, 0x8f, 0xf0, 0xf6, 0x63, 0x1e, 0x7f, 0xa8, 0xbf, 0xc7, 0x79, 0xff, 0x9f, 0xed,
+	0xac, 0x3c, 0xdb, 0xfe, 0x6b, 0x00, 0x3a, 0xab, 0x91, 0x5d, 0x9e, 0x0e, 0x00, 0x00,
 }
 
 func (m *NewRoundStep) Marshal() (dAtA []byte, err error) {
@@ -1389,7 +1831,7 @@ func (m *VoteSetBits) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *Message) Marshal() (dAtA []byte, err error) {
+func (m *CommitCertificate) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1399,32 +1841,304 @@ func (m *Message) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Message) MarshalTo(dAtA []byte) (int, error) {
+func (m *CommitCertificate) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CommitCertificate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Sum != nil {
+	if m.Commit != nil {
 		{
-			size := m.Sum.Size()
-			i -= size
-			if _, err := m.Sum.MarshalTo(dAtA[i:]); err != nil {
+			size, err := m.Commit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
 				return 0, err
 			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Message_NewRoundStep) MarshalTo(dAtA []byte) (int, error) {
+func (m *BlockParityPart) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockParityPart) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockParityPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Bytes) > 0 {
+		i -= len(m.Bytes)
+		copy(dAtA[i:], m.Bytes)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Bytes)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.LastPartSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.LastPartSize))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Index != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x20
+	}
+	{
+		size, err := m.PartSetHeader.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CompactBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CompactBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CompactBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TxKeys) > 0 {
+		for iNdEx := len(m.TxKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.TxKeys[iNdEx])
+			copy(dAtA[i:], m.TxKeys[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.TxKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Block != nil {
+		{
+			size, err := m.Block.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	{
+		size, err := m.PartSetHeader.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MissingTxsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MissingTxsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MissingTxsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Indexes) > 0 {
+		dAtA15 := make([]byte, len(m.Indexes)*10)
+		var j14 int
+		for _, num := range m.Indexes {
+			for num >= 1<<7 {
+				dAtA15[j14] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j14++
+			}
+			dAtA15[j14] = uint8(num)
+			j14++
+		}
+		i -= j14
+		copy(dAtA[i:], dAtA15[:j14])
+		i = encodeVarintTypes(dAtA, i, uint64(j14))
+		i--
+		dAtA[i] = 0x22
+	}
+	{
+		size, err := m.PartSetHeader.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MissingTxs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MissingTxs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MissingTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Txs[iNdEx])
+			copy(dAtA[i:], m.Txs[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Txs[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	{
+		size, err := m.PartSetHeader.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Message) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Message) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Sum != nil {
+		{
+			size := m.Sum.Size()
+			i -= size
+			if _, err := m.Sum.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Message_NewRoundStep) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
 
 func (m *Message_NewRoundStep) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
@@ -1631,42 +2345,147 @@ func (m *Message_HasProposalBlockPart) MarshalToSizedBuffer(dAtA []byte) (int, e
 	}
 	return len(dAtA) - i, nil
 }
-func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
-	offset -= sovTypes(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
-}
-func (m *NewRoundStep) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Height != 0 {
-		n += 1 + sovTypes(uint64(m.Height))
-	}
-	if m.Round != 0 {
-		n += 1 + sovTypes(uint64(m.Round))
-	}
-	if m.Step != 0 {
-		n += 1 + sovTypes(uint64(m.Step))
-	}
-	if m.SecondsSinceStartTime != 0 {
-		n += 1 + sovTypes(uint64(m.SecondsSinceStartTime))
-	}
-	if m.LastCommitRound != 0 {
-		n += 1 + sovTypes(uint64(m.LastCommitRound))
-	}
-	return n
+func (m *Message_CommitCertificate) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *NewValidBlock) Size() (n int) {
+func (m *Message_CommitCertificate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CommitCertificate != nil {
+		{
+			size, err := m.CommitCertificate.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_BlockParityPart) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_BlockParityPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.BlockParityPart != nil {
+		{
+			size, err := m.BlockParityPart.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_CompactBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_CompactBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CompactBlock != nil {
+		{
+			size, err := m.CompactBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_MissingTxsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_MissingTxsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.MissingTxsRequest != nil {
+		{
+			size, err := m.MissingTxsRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_MissingTxs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_MissingTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.MissingTxs != nil {
+		{
+			size, err := m.MissingTxs.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	return len(dAtA) - i, nil
+}
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTypes(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *NewRoundStep) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	if m.Step != 0 {
+		n += 1 + sovTypes(uint64(m.Step))
+	}
+	if m.SecondsSinceStartTime != 0 {
+		n += 1 + sovTypes(uint64(m.SecondsSinceStartTime))
+	}
+	if m.LastCommitRound != 0 {
+		n += 1 + sovTypes(uint64(m.LastCommitRound))
+	}
+	return n
+}
+
+func (m *NewValidBlock) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -1829,6 +2648,120 @@ func (m *VoteSetBits) Size() (n int) {
 	return n
 }
 
+func (m *CommitCertificate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *BlockParityPart) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	l = m.PartSetHeader.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovTypes(uint64(m.Index))
+	}
+	if m.LastPartSize != 0 {
+		n += 1 + sovTypes(uint64(m.LastPartSize))
+	}
+	l = len(m.Bytes)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *CompactBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	l = m.PartSetHeader.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.Block != nil {
+		l = m.Block.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.TxKeys) > 0 {
+		for _, b := range m.TxKeys {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MissingTxsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	l = m.PartSetHeader.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.Indexes) > 0 {
+		l = 0
+		for _, e := range m.Indexes {
+			l += sovTypes(uint64(e))
+		}
+		n += 1 + sovTypes(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *MissingTxs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	l = m.PartSetHeader.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *Message) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1959,16 +2892,602 @@ func (m *Message_HasProposalBlockPart) Size() (n int) {
 		l = m.HasProposalBlockPart.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
-	return n
-}
+	return n
+}
+func (m *Message_CommitCertificate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CommitCertificate != nil {
+		l = m.CommitCertificate.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_BlockParityPart) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockParityPart != nil {
+		l = m.BlockParityPart.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_CompactBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CompactBlock != nil {
+		l = m.CompactBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_MissingTxsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MissingTxsRequest != nil {
+		l = m.MissingTxsRequest.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_MissingTxs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MissingTxs != nil {
+		l = m.MissingTxs.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func sovTypes(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTypes(x uint64) (n int) {
+	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *NewRoundStep) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NewRoundStep: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NewRoundStep: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+			}
+			m.Step = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Step |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecondsSinceStartTime", wireType)
+			}
+			m.SecondsSinceStartTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SecondsSinceStartTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastCommitRound", wireType)
+			}
+			m.LastCommitRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastCommitRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NewValidBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NewValidBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NewValidBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockPartSetHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BlockPartSetHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockParts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BlockParts == nil {
+				m.BlockParts = &bits.BitArray{}
+			}
+			if err := m.BlockParts.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsCommit", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsCommit = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Proposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Proposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Proposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Proposal.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProposalPOL) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProposalPOL: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProposalPOL: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposalPolRound", wireType)
+			}
+			m.ProposalPolRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProposalPolRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposalPol", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ProposalPol.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
 
-func sovTypes(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozTypes(x uint64) (n int) {
-	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *NewRoundStep) Unmarshal(dAtA []byte) error {
+func (m *BlockPart) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1991,10 +3510,10 @@ func (m *NewRoundStep) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NewRoundStep: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockPart: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NewRoundStep: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockPart: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2036,10 +3555,10 @@ func (m *NewRoundStep) Unmarshal(dAtA []byte) error {
 				}
 			}
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Part", wireType)
 			}
-			m.Step = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2049,35 +3568,80 @@ func (m *NewRoundStep) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Step |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SecondsSinceStartTime", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			m.SecondsSinceStartTime = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.SecondsSinceStartTime |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
 			}
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastCommitRound", wireType)
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.LastCommitRound = 0
+			if err := m.Part.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Vote) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Vote: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Vote: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2087,11 +3651,28 @@ func (m *NewRoundStep) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LastCommitRound |= int32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Vote == nil {
+				m.Vote = &types.Vote{}
+			}
+			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2113,7 +3694,7 @@ func (m *NewRoundStep) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NewValidBlock) Unmarshal(dAtA []byte) error {
+func (m *HasVote) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2136,10 +3717,10 @@ func (m *NewValidBlock) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NewValidBlock: wiretype end group for non-group")
+			return fmt.Errorf("proto: HasVote: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NewValidBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HasVote: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2181,10 +3762,10 @@ func (m *NewValidBlock) Unmarshal(dAtA []byte) error {
 				}
 			}
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockPartSetHeader", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			var msglen int
+			m.Type = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2194,66 +3775,16 @@ func (m *NewValidBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Type |= types.SignedMsgType(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.BlockPartSetHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockParts", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.BlockParts == nil {
-				m.BlockParts = &bits.BitArray{}
-			}
-			if err := m.BlockParts.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsCommit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var v int
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2263,12 +3794,11 @@ func (m *NewValidBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Index |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.IsCommit = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2290,7 +3820,7 @@ func (m *NewValidBlock) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Proposal) Unmarshal(dAtA []byte) error {
+func (m *HasProposalBlockPart) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2313,17 +3843,36 @@ func (m *Proposal) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Proposal: wiretype end group for non-group")
+			return fmt.Errorf("proto: HasProposalBlockPart: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Proposal: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HasProposalBlockPart: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proposal", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			var msglen int
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2333,25 +3882,30 @@ func (m *Proposal) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Round |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			if err := m.Proposal.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2373,7 +3927,7 @@ func (m *Proposal) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ProposalPOL) Unmarshal(dAtA []byte) error {
+func (m *VoteSetMaj23) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2396,10 +3950,10 @@ func (m *ProposalPOL) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProposalPOL: wiretype end group for non-group")
+			return fmt.Errorf("proto: VoteSetMaj23: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProposalPOL: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VoteSetMaj23: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2423,9 +3977,9 @@ func (m *ProposalPOL) Unmarshal(dAtA []byte) error {
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProposalPolRound", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
 			}
-			m.ProposalPolRound = 0
+			m.Round = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2435,14 +3989,33 @@ func (m *ProposalPOL) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ProposalPolRound |= int32(b&0x7F) << shift
+				m.Round |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= types.SignedMsgType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProposalPol", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2469,7 +4042,7 @@ func (m *ProposalPOL) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ProposalPol.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.BlockID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2494,7 +4067,7 @@ func (m *ProposalPOL) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BlockPart) Unmarshal(dAtA []byte) error {
+func (m *VoteSetBits) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2517,10 +4090,10 @@ func (m *BlockPart) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BlockPart: wiretype end group for non-group")
+			return fmt.Errorf("proto: VoteSetBits: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BlockPart: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VoteSetBits: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2562,8 +4135,27 @@ func (m *BlockPart) Unmarshal(dAtA []byte) error {
 				}
 			}
 		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= types.SignedMsgType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Part", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2590,7 +4182,40 @@ func (m *BlockPart) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Part.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.BlockID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Votes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2615,7 +4240,7 @@ func (m *BlockPart) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Vote) Unmarshal(dAtA []byte) error {
+func (m *CommitCertificate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2638,15 +4263,15 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Vote: wiretype end group for non-group")
+			return fmt.Errorf("proto: CommitCertificate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Vote: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CommitCertificate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2673,10 +4298,10 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Vote == nil {
-				m.Vote = &types.Vote{}
+			if m.Commit == nil {
+				m.Commit = &types.ExtendedCommit{}
 			}
-			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2701,7 +4326,7 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HasVote) Unmarshal(dAtA []byte) error {
+func (m *BlockParityPart) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2724,10 +4349,10 @@ func (m *HasVote) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HasVote: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockParityPart: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HasVote: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockParityPart: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2769,10 +4394,10 @@ func (m *HasVote) Unmarshal(dAtA []byte) error {
 				}
 			}
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartSetHeader", wireType)
 			}
-			m.Type = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2782,11 +4407,25 @@ func (m *HasVote) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= types.SignedMsgType(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PartSetHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 4:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
@@ -2801,11 +4440,64 @@ func (m *HasVote) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= int32(b&0x7F) << shift
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastPartSize", wireType)
+			}
+			m.LastPartSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastPartSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bytes = append(m.Bytes[:0], dAtA[iNdEx:postIndex]...)
+			if m.Bytes == nil {
+				m.Bytes = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2827,7 +4519,7 @@ func (m *HasVote) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HasProposalBlockPart) Unmarshal(dAtA []byte) error {
+func (m *CompactBlock) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2850,10 +4542,10 @@ func (m *HasProposalBlockPart) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HasProposalBlockPart: wiretype end group for non-group")
+			return fmt.Errorf("proto: CompactBlock: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HasProposalBlockPart: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CompactBlock: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2879,7 +4571,59 @@ func (m *HasProposalBlockPart) Unmarshal(dAtA []byte) error {
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
 			}
-			m.Round = 0
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartSetHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PartSetHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2889,16 +4633,33 @@ func (m *HasProposalBlockPart) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Round |= int32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			m.Index = 0
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Block == nil {
+				m.Block = &types.Block{}
+			}
+			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxKeys", wireType)
+			}
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2908,11 +4669,24 @@ func (m *HasProposalBlockPart) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= int32(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxKeys = append(m.TxKeys, make([]byte, postIndex-iNdEx))
+			copy(m.TxKeys[len(m.TxKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2934,7 +4708,7 @@ func (m *HasProposalBlockPart) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VoteSetMaj23) Unmarshal(dAtA []byte) error {
+func (m *MissingTxsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2957,10 +4731,10 @@ func (m *VoteSetMaj23) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VoteSetMaj23: wiretype end group for non-group")
+			return fmt.Errorf("proto: MissingTxsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VoteSetMaj23: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MissingTxsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -3002,27 +4776,8 @@ func (m *VoteSetMaj23) Unmarshal(dAtA []byte) error {
 				}
 			}
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= types.SignedMsgType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PartSetHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3049,10 +4804,86 @@ func (m *VoteSetMaj23) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.BlockID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.PartSetHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Indexes = append(m.Indexes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthTypes
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthTypes
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Indexes) == 0 {
+					m.Indexes = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowTypes
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Indexes = append(m.Indexes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Indexes", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -3074,7 +4905,7 @@ func (m *VoteSetMaj23) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VoteSetBits) Unmarshal(dAtA []byte) error {
+func (m *MissingTxs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3097,10 +4928,10 @@ func (m *VoteSetBits) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VoteSetBits: wiretype end group for non-group")
+			return fmt.Errorf("proto: MissingTxs: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VoteSetBits: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MissingTxs: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -3142,27 +4973,8 @@ func (m *VoteSetBits) Unmarshal(dAtA []byte) error {
 				}
 			}
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= types.SignedMsgType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PartSetHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3189,15 +5001,15 @@ func (m *VoteSetBits) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.BlockID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.PartSetHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -3207,24 +5019,23 @@ func (m *VoteSetBits) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Votes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3626,6 +5437,181 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_HasProposalBlockPart{v}
 			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitCertificate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CommitCertificate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_CommitCertificate{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockParityPart", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &BlockParityPart{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_BlockParityPart{v}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CompactBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_CompactBlock{v}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MissingTxsRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &MissingTxsRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_MissingTxsRequest{v}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MissingTxs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &MissingTxs{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_MissingTxs{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -152,6 +152,7 @@ type DefaultNodeInfo struct {
 	Channels        []byte               `protobuf:"bytes,6,opt,name=channels,proto3" json:"channels,omitempty"`
 	Moniker         string               `protobuf:"bytes,7,opt,name=moniker,proto3" json:"moniker,omitempty"`
 	Other           DefaultNodeInfoOther `protobuf:"bytes,8,opt,name=other,proto3" json:"other"`
+	Capabilities    []string             `protobuf:"bytes,9,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
 }
 
 func (m *DefaultNodeInfo) Reset()         { *m = DefaultNodeInfo{} }
@@ -243,6 +244,13 @@ func (m *DefaultNodeInfo) GetOther() DefaultNodeInfoOther {
 	return DefaultNodeInfoOther{}
 }
 
+func (m *DefaultNodeInfo) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
 type DefaultNodeInfoOther struct {
 	TxIndex    string `protobuf:"bytes,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
 	RPCAddress string `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3" json:"rpc_address,omitempty"`
@@ -305,38 +313,39 @@ func init() {
 func init() { proto.RegisterFile("tendermint/p2p/types.proto", fileDescriptor_c8a29e659aeca578) }
 
 var fileDescriptor_c8a29e659aeca578 = []byte{
-	// 483 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0x3d, 0x8f, 0xda, 0x40,
-	0x10, 0xc5, 0xc6, 0x7c, 0xdc, 0x10, 0x8e, 0xcb, 0x0a, 0x45, 0x3e, 0x0a, 0x1b, 0xa1, 0x14, 0x54,
-	0xa0, 0x90, 0x2a, 0x5d, 0x42, 0x68, 0x50, 0xa4, 0x8b, 0xb5, 0x8a, 0x52, 0xa4, 0x41, 0xe0, 0x5d,
-	0x60, 0x85, 0xd9, 0x5d, 0xad, 0xf7, 0x12, 0xf2, 0x2f, 0xf2, 0xb3, 0xae, 0xbc, 0x32, 0x95, 0x15,
-	0x99, 0x32, 0x7f, 0x22, 0xf2, 0xae, 0x2f, 0xc7, 0xa1, 0xeb, 0xe6, 0xcd, 0x9b, 0x99, 0x37, 0xf3,
-	0x34, 0xd0, 0xd3, 0x94, 0x13, 0xaa, 0xf6, 0x8c, 0xeb, 0xb1, 0x9c, 0xc8, 0xb1, 0xfe, 0x29, 0x69,
-	0x3a, 0x92, 0x4a, 0x68, 0x81, 0x2e, 0x1f, 0xb9, 0x91, 0x9c, 0xc8, 0x5e, 0x77, 0x23, 0x36, 0xc2,
-	0x50, 0xe3, 0x22, 0xb2, 0x55, 0x83, 0x08, 0xe0, 0x86, 0xea, 0x0f, 0x84, 0x28, 0x9a, 0xa6, 0xe8,
-	0x15, 0xb8, 0x8c, 0xf8, 0x4e, 0xdf, 0x19, 0x5e, 0x4c, 0xeb, 0x79, 0x16, 0xba, 0xf3, 0x19, 0x76,
-	0x19, 0x31, 0x79, 0xe9, 0xbb, 0x27, 0xf9, 0x08, 0xbb, 0x4c, 0x22, 0x04, 0x9e, 0x14, 0x4a, 0xfb,
-	0xd5, 0xbe, 0x33, 0x6c, 0x63, 0x13, 0x0f, 0xbe, 0x40, 0x27, 0x2a, 0x46, 0xc7, 0x22, 0xf9, 0x4a,
-	0x55, 0xca, 0x04, 0x47, 0xd7, 0x50, 0x95, 0x13, 0x69, 0xe6, 0x7a, 0xd3, 0x46, 0x9e, 0x85, 0xd5,
-	0x68, 0x12, 0xe1, 0x22, 0x87, 0xba, 0x50, 0x5b, 0x25, 0x22, 0xde, 0x99, 0xe1, 0x1e, 0xb6, 0x00,
-	0x5d, 0x41, 0x75, 0x29, 0xa5, 0x19, 0xeb, 0xe1, 0x22, 0x1c, 0xfc, 0x75, 0xa1, 0x33, 0xa3, 0xeb,
-	0xe5, 0x6d, 0xa2, 0x6f, 0x04, 0xa1, 0x73, 0xbe, 0x16, 0x28, 0x82, 0x2b, 0x59, 0x2a, 0x2d, 0xbe,
-	0x5b, 0x29, 0xa3, 0xd1, 0x9a, 0x84, 0xa3, 0xa7, 0xc7, 0x8f, 0xce, 0x36, 0x9a, 0x7a, 0x77, 0x59,
-	0x58, 0xc1, 0x1d, 0x79, 0xb6, 0xe8, 0x3b, 0xe8, 0x10, 0x2b, 0xb2, 0xe0, 0x82, 0xd0, 0x05, 0x23,
-	0xe5, 0xd1, 0x2f, 0xf3, 0x2c, 0x6c, 0x9f, 0xea, 0xcf, 0x70, 0x9b, 0x9c, 0x40, 0x82, 0x42, 0x68,
-	0x25, 0x2c, 0xd5, 0x94, 0x2f, 0x96, 0x84, 0x28, 0xb3, 0xfa, 0x05, 0x06, 0x9b, 0x2a, 0xec, 0x45,
-	0x3e, 0x34, 0x38, 0xd5, 0x3f, 0x84, 0xda, 0xf9, 0x9e, 0x21, 0x1f, 0x60, 0xc1, 0x3c, 0xac, 0x5f,
-	0xb3, 0x4c, 0x09, 0x51, 0x0f, 0x9a, 0xf1, 0x76, 0xc9, 0x39, 0x4d, 0x52, 0xbf, 0xde, 0x77, 0x86,
-	0x2f, 0xf0, 0x7f, 0x5c, 0x74, 0xed, 0x05, 0x67, 0x3b, 0xaa, 0xfc, 0x86, 0xed, 0x2a, 0x21, 0x7a,
-	0x0f, 0x35, 0xa1, 0xb7, 0x54, 0xf9, 0x4d, 0x63, 0xc6, 0xeb, 0x73, 0x33, 0xce, 0x7c, 0xfc, 0x5c,
-	0xd4, 0x96, 0x8e, 0xd8, 0xc6, 0xc1, 0x0a, 0xba, 0xcf, 0x15, 0xa1, 0x6b, 0x68, 0xea, 0xc3, 0x82,
-	0x71, 0x42, 0x0f, 0xf6, 0x4b, 0x70, 0x43, 0x1f, 0xe6, 0x05, 0x44, 0x63, 0x68, 0x29, 0x19, 0x9b,
-	0xe3, 0x69, 0x9a, 0x96, 0xb6, 0x5d, 0xe6, 0x59, 0x08, 0x38, 0xfa, 0x58, 0xfe, 0x17, 0x06, 0x25,
-	0xe3, 0x32, 0x9e, 0x7e, 0xba, 0xcb, 0x03, 0xe7, 0x3e, 0x0f, 0x9c, 0x3f, 0x79, 0xe0, 0xfc, 0x3a,
-	0x06, 0x95, 0xfb, 0x63, 0x50, 0xf9, 0x7d, 0x0c, 0x2a, 0xdf, 0xde, 0x6c, 0x98, 0xde, 0xde, 0xae,
-	0x46, 0xb1, 0xd8, 0x8f, 0x63, 0xb1, 0xa7, 0x7a, 0xb5, 0xd6, 0x8f, 0x81, 0x7d, 0xe1, 0xa7, 0x8f,
-	0xbf, 0xaa, 0x9b, 0xec, 0xdb, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xae, 0xdb, 0x56, 0x6d, 0x11,
-	0x03, 0x00, 0x00,
+	// 498 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x3d, 0x8f, 0xda, 0x40,
+	0x10, 0xc5, 0xd8, 0x7c, 0x0d, 0xc7, 0x71, 0x59, 0xa1, 0xc8, 0x47, 0x61, 0x23, 0x94, 0x82, 0x0a,
+	0x14, 0x52, 0xa5, 0x4b, 0x08, 0x0d, 0x8a, 0x74, 0xb1, 0x56, 0x51, 0x8a, 0x34, 0xc8, 0xf6, 0x2e,
+	0xb0, 0xc2, 0xec, 0xae, 0xd6, 0x7b, 0x09, 0xf9, 0x15, 0xc9, 0xcf, 0xba, 0xf2, 0xca, 0x54, 0x28,
+	0x32, 0x7f, 0x24, 0xf2, 0xda, 0x97, 0x03, 0x94, 0x6e, 0xde, 0x1b, 0xcf, 0x7b, 0xb3, 0x4f, 0x63,
+	0xe8, 0x6b, 0xca, 0x09, 0x55, 0x3b, 0xc6, 0xf5, 0x44, 0x4e, 0xe5, 0x44, 0xff, 0x90, 0x34, 0x1d,
+	0x4b, 0x25, 0xb4, 0x40, 0xd7, 0xcf, 0xbd, 0xb1, 0x9c, 0xca, 0x7e, 0x6f, 0x2d, 0xd6, 0xc2, 0xb4,
+	0x26, 0x79, 0x55, 0x7c, 0x35, 0x0c, 0x00, 0xee, 0xa8, 0x7e, 0x4f, 0x88, 0xa2, 0x69, 0x8a, 0x5e,
+	0x42, 0x95, 0x11, 0xd7, 0x1a, 0x58, 0xa3, 0xd6, 0xac, 0x9e, 0x1d, 0xfc, 0xea, 0x62, 0x8e, 0xab,
+	0x8c, 0x18, 0x5e, 0xba, 0xd5, 0x13, 0x3e, 0xc0, 0x55, 0x26, 0x11, 0x02, 0x47, 0x0a, 0xa5, 0x5d,
+	0x7b, 0x60, 0x8d, 0x3a, 0xd8, 0xd4, 0xc3, 0xcf, 0xd0, 0x0d, 0x72, 0xe9, 0x58, 0x24, 0x5f, 0xa8,
+	0x4a, 0x99, 0xe0, 0xe8, 0x16, 0x6c, 0x39, 0x95, 0x46, 0xd7, 0x99, 0x35, 0xb2, 0x83, 0x6f, 0x07,
+	0xd3, 0x00, 0xe7, 0x1c, 0xea, 0x41, 0x2d, 0x4a, 0x44, 0xbc, 0x35, 0xe2, 0x0e, 0x2e, 0x00, 0xba,
+	0x01, 0x3b, 0x94, 0xd2, 0xc8, 0x3a, 0x38, 0x2f, 0x87, 0x3f, 0x6d, 0xe8, 0xce, 0xe9, 0x2a, 0xbc,
+	0x4f, 0xf4, 0x9d, 0x20, 0x74, 0xc1, 0x57, 0x02, 0x05, 0x70, 0x23, 0x4b, 0xa7, 0xe5, 0xb7, 0xc2,
+	0xca, 0x78, 0xb4, 0xa7, 0xfe, 0xf8, 0xfc, 0xf1, 0xe3, 0x8b, 0x8d, 0x66, 0xce, 0xc3, 0xc1, 0xaf,
+	0xe0, 0xae, 0xbc, 0x58, 0xf4, 0x2d, 0x74, 0x49, 0x61, 0xb2, 0xe4, 0x82, 0xd0, 0x25, 0x23, 0xe5,
+	0xa3, 0x5f, 0x64, 0x07, 0xbf, 0x73, 0xea, 0x3f, 0xc7, 0x1d, 0x72, 0x02, 0x09, 0xf2, 0xa1, 0x9d,
+	0xb0, 0x54, 0x53, 0xbe, 0x0c, 0x09, 0x51, 0x66, 0xf5, 0x16, 0x86, 0x82, 0xca, 0xe3, 0x45, 0x2e,
+	0x34, 0x38, 0xd5, 0xdf, 0x85, 0xda, 0xba, 0x8e, 0x69, 0x3e, 0xc1, 0xbc, 0xf3, 0xb4, 0x7e, 0xad,
+	0xe8, 0x94, 0x10, 0xf5, 0xa1, 0x19, 0x6f, 0x42, 0xce, 0x69, 0x92, 0xba, 0xf5, 0x81, 0x35, 0xba,
+	0xc2, 0xff, 0x70, 0x3e, 0xb5, 0x13, 0x9c, 0x6d, 0xa9, 0x72, 0x1b, 0xc5, 0x54, 0x09, 0xd1, 0x3b,
+	0xa8, 0x09, 0xbd, 0xa1, 0xca, 0x6d, 0x9a, 0x30, 0x5e, 0x5d, 0x86, 0x71, 0x91, 0xe3, 0xa7, 0xfc,
+	0xdb, 0x32, 0x91, 0x62, 0x10, 0x0d, 0xe1, 0x2a, 0x0e, 0x65, 0x18, 0xb1, 0x84, 0x69, 0x46, 0x53,
+	0xb7, 0x35, 0xb0, 0x47, 0x2d, 0x7c, 0xc6, 0x0d, 0x23, 0xe8, 0xfd, 0x4f, 0x08, 0xdd, 0x42, 0x53,
+	0xef, 0x97, 0x8c, 0x13, 0xba, 0x2f, 0x2e, 0x09, 0x37, 0xf4, 0x7e, 0x91, 0x43, 0x34, 0x81, 0xb6,
+	0x92, 0xb1, 0x09, 0x88, 0xa6, 0x69, 0x19, 0xed, 0x75, 0x76, 0xf0, 0x01, 0x07, 0x1f, 0xca, 0x1b,
+	0xc4, 0xa0, 0x64, 0x5c, 0xd6, 0xb3, 0x8f, 0x0f, 0x99, 0x67, 0x3d, 0x66, 0x9e, 0xf5, 0x27, 0xf3,
+	0xac, 0x5f, 0x47, 0xaf, 0xf2, 0x78, 0xf4, 0x2a, 0xbf, 0x8f, 0x5e, 0xe5, 0xeb, 0xeb, 0x35, 0xd3,
+	0x9b, 0xfb, 0x68, 0x1c, 0x8b, 0xdd, 0x24, 0x16, 0x3b, 0xaa, 0xa3, 0x95, 0x7e, 0x2e, 0x8a, 0x33,
+	0x3f, 0xff, 0x39, 0xa2, 0xba, 0x61, 0xdf, 0xfc, 0x1d, 0x00, 0xc7, 0xb9, 0x4a, 0xe1, 0x35, 0x03,
+	0x00, 0x00,
 }
 
 func (m *NetAddress) Marshal() (dAtA []byte, err error) {
@@ -439,6 +448,15 @@ func (m *DefaultNodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Capabilities) > 0 {
+		for iNdEx := len(m.Capabilities) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Capabilities[iNdEx])
+			copy(dAtA[i:], m.Capabilities[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Capabilities[iNdEx])))
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
 	{
 		size, err := m.Other.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -624,6 +642,12 @@ func (m *DefaultNodeInfo) Size() (n int) {
 	}
 	l = m.Other.Size()
 	n += 1 + l + sovTypes(uint64(l))
+	if len(m.Capabilities) > 0 {
+		for _, s := range m.Capabilities {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1179,6 +1203,38 @@ func (m *DefaultNodeInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Capabilities = append(m.Capabilities, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -0,0 +1,491 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: tendermint/services/admin/v1/service.proto
+
+package tendermint_services_admin_v1
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() {
+	proto.RegisterFile("tendermint/services/admin/v1/service.proto", fileDescriptor_444ef9a933bfc3c0)
+}
+
+var fileDescriptor_444ef9a933bfc3c0 = []byte{
+	// 353 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x94, 0xb1, 0x4e, 0xeb, 0x30,
+	0x14, 0x86, 0x5b, 0xe9, 0xaa, 0xba, 0x98, 0xc2, 0xe0, 0x09, 0x55, 0x28, 0x33, 0x14, 0xe4, 0x90,
+	0x96, 0x17, 0x68, 0x55, 0x21, 0x16, 0xa4, 0xaa, 0x9d, 0x19, 0x42, 0x72, 0x04, 0x51, 0x13, 0x3b,
+	0xd8, 0x71, 0x85, 0xc4, 0x4b, 0xf0, 0x58, 0x8c, 0x1d, 0x19, 0x51, 0xfb, 0x1e, 0x08, 0x25, 0xb1,
+	0xdb, 0x84, 0x21, 0x76, 0x46, 0xdb, 0xdf, 0xff, 0x7f, 0xe7, 0x2c, 0x46, 0xc3, 0x0c, 0x68, 0x08,
+	0x3c, 0x89, 0x68, 0xe6, 0x0a, 0xe0, 0xeb, 0x28, 0x00, 0xe1, 0xfa, 0x61, 0x12, 0x51, 0x77, 0xed,
+	0xe9, 0x1b, 0x92, 0x72, 0x96, 0x31, 0x7c, 0x7e, 0x60, 0x89, 0x66, 0x49, 0xc1, 0x92, 0xb5, 0x37,
+	0xb8, 0x68, 0x6c, 0x2a, 0xb1, 0xa2, 0x67, 0xf4, 0xf3, 0x1f, 0xf5, 0x27, 0xf9, 0x79, 0x59, 0x62,
+	0x38, 0x46, 0x47, 0xb3, 0xc8, 0x8f, 0x97, 0x00, 0xa1, 0xc0, 0x84, 0x34, 0x69, 0xc8, 0x1e, 0x5c,
+	0xc0, 0xab, 0x04, 0x91, 0x0d, 0x5c, 0x6b, 0x5e, 0xa4, 0x8c, 0x8a, 0xbd, 0x6d, 0x0e, 0xc0, 0xad,
+	0x6c, 0x05, 0xd8, 0xc2, 0xa6, 0x78, 0x65, 0x7b, 0x47, 0xa7, 0xb3, 0x48, 0x04, 0x8c, 0x52, 0x08,
+	0xb2, 0xfc, 0x09, 0x8f, 0x4d, 0x15, 0x55, 0x5a, 0x7b, 0x6f, 0xdb, 0x85, 0x94, 0x5c, 0xa2, 0xfe,
+	0x4c, 0x26, 0xe9, 0x24, 0x0c, 0xf9, 0x94, 0xb1, 0x15, 0xf6, 0x0c, 0x2d, 0x15, 0x56, 0x8b, 0x47,
+	0x6d, 0x22, 0x07, 0xed, 0x5d, 0x2c, 0xc5, 0xcb, 0x03, 0x24, 0x29, 0x63, 0xb1, 0x49, 0x5b, 0x65,
+	0x2d, 0xb5, 0xf5, 0x88, 0xd2, 0x72, 0x74, 0x9c, 0x8f, 0xa3, 0xad, 0x37, 0xe6, 0xc9, 0xff, 0x48,
+	0xbd, 0x16, 0x89, 0xc3, 0xaa, 0x73, 0x5f, 0x0a, 0x98, 0x73, 0x49, 0x23, 0xfa, 0x6c, 0x5a, 0xb5,
+	0xca, 0x5a, 0xae, 0x5a, 0x8f, 0x28, 0xed, 0x1b, 0x3a, 0x59, 0x80, 0x90, 0xc9, 0xde, 0x6b, 0x28,
+	0xa9, 0xc1, 0x5a, 0x3c, 0x6e, 0x95, 0x51, 0xe6, 0x00, 0xf5, 0xa6, 0x7e, 0xb0, 0x92, 0x29, 0xbe,
+	0x6a, 0x8e, 0x97, 0x94, 0x76, 0x5d, 0xdb, 0xc1, 0x4a, 0xf2, 0x88, 0xfe, 0xdd, 0xfb, 0x71, 0x86,
+	0x2f, 0x9b, 0x53, 0x39, 0xa3, 0x05, 0x43, 0x1b, 0xb4, 0xac, 0x9f, 0x9e, 0x7d, 0x6e, 0x9d, 0xee,
+	0x66, 0xeb, 0x74, 0xbf, 0xb7, 0x4e, 0xf7, 0x63, 0xe7, 0x74, 0x36, 0x3b, 0xa7, 0xf3, 0xb5, 0x73,
+	0x3a, 0x4f, 0xbd, 0xe2, 0x87, 0x1a, 0xff, 0x0e, 0x00, 0x92, 0x0f, 0xda, 0x18, 0x17, 0x05, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	// DialSeeds dials the given seeds to request peer addresses from them.
+	DialSeeds(ctx context.Context, in *DialSeedsRequest, opts ...grpc.CallOption) (*DialSeedsResponse, error)
+	// DialPeers dials the given peers, optionally adding them to the
+	// persistent, unconditional or private peers.
+	DialPeers(ctx context.Context, in *DialPeersRequest, opts ...grpc.CallOption) (*DialPeersResponse, error)
+	// DisconnectPeer gracefully disconnects from the given peer.
+	DisconnectPeer(ctx context.Context, in *DisconnectPeerRequest, opts ...grpc.CallOption) (*DisconnectPeerResponse, error)
+	// DumpAddrBook returns the quality data of the addresses of the address
+	// book: their dial attempts and successes, bans, and last crawl if the
+	// node is a seed, along with their AS.
+	DumpAddrBook(ctx context.Context, in *DumpAddrBookRequest, opts ...grpc.CallOption) (*DumpAddrBookResponse, error)
+	// FlushMempool removes all the transactions from the mempool.
+	FlushMempool(ctx context.Context, in *FlushMempoolRequest, opts ...grpc.CallOption) (*FlushMempoolResponse, error)
+	// DumpMempool returns the transactions in the mempool.
+	DumpMempool(ctx context.Context, in *DumpMempoolRequest, opts ...grpc.CallOption) (*DumpMempoolResponse, error)
+	// PausePruning pauses the pruning of the node data until ResumePruning is
+	// called.
+	PausePruning(ctx context.Context, in *PausePruningRequest, opts ...grpc.CallOption) (*PausePruningResponse, error)
+	// ResumePruning resumes the pruning paused by PausePruning.
+	ResumePruning(ctx context.Context, in *ResumePruningRequest, opts ...grpc.CallOption) (*ResumePruningResponse, error)
+	// Backup takes a snapshot of the block and state stores while the node is
+	// running.
+	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error)
+	// Halt gracefully stops the node.
+	Halt(ctx context.Context, in *HaltRequest, opts ...grpc.CallOption) (*HaltResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewAdminServiceClient(cc grpc1.ClientConn) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) DialSeeds(ctx context.Context, in *DialSeedsRequest, opts ...grpc.CallOption) (*DialSeedsResponse, error) {
+	out := new(DialSeedsResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.services.admin.v1.AdminService/DialSeeds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DialPeers(ctx context.Context, in *DialPeersRequest, opts ...grpc.CallOption) (*DialPeersResponse, error) {
+	out := new(DialPeersResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.services.admin.v1.AdminService/DialPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DisconnectPeer(ctx context.Context, in *DisconnectPeerRequest, opts ...grpc.CallOption) (*DisconnectPeerResponse, error) {
+	out := new(DisconnectPeerResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.services.admin.v1.AdminService/DisconnectPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DumpAddrBook(ctx context.Context, in *DumpAddrBookRequest, opts ...grpc.CallOption) (*DumpAddrBookResponse, error) {
+	out := new(DumpAddrBookResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.services.admin.v1.AdminService/DumpAddrBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) FlushMempool(ctx context.Context, in *FlushMempoolRequest, opts ...grpc.CallOption) (*FlushMempoolResponse, error) {
+	out := new(FlushMempoolResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.services.admin.v1.AdminService/FlushMempool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DumpMempool(ctx context.Context, in *DumpMempoolRequest, opts ...grpc.CallOption) (*DumpMempoolResponse, error) {
+	out := new(DumpMempoolResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.services.admin.v1.AdminService/DumpMempool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) PausePruning(ctx context.Context, in *PausePruningRequest, opts ...grpc.CallOption) (*PausePruningResponse, error) {
+	out := new(PausePruningResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.services.admin.v1.AdminService/PausePruning", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ResumePruning(ctx context.Context, in *ResumePruningRequest, opts ...grpc.CallOption) (*ResumePruningResponse, error) {
+	out := new(ResumePruningResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.services.admin.v1.AdminService/ResumePruning", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error) {
+	out := new(BackupResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.services.admin.v1.AdminService/Backup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) Halt(ctx context.Context, in *HaltRequest, opts ...grpc.CallOption) (*HaltResponse, error) {
+	out := new(HaltResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.services.admin.v1.AdminService/Halt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+type AdminServiceServer interface {
+	// DialSeeds dials the given seeds to request peer addresses from them.
+	DialSeeds(context.Context, *DialSeedsRequest) (*DialSeedsResponse, error)
+	// DialPeers dials the given peers, optionally adding them to the
+	// persistent, unconditional or private peers.
+	DialPeers(context.Context, *DialPeersRequest) (*DialPeersResponse, error)
+	// DisconnectPeer gracefully disconnects from the given peer.
+	DisconnectPeer(context.Context, *DisconnectPeerRequest) (*DisconnectPeerResponse, error)
+	// DumpAddrBook returns the quality data of the addresses of the address
+	// book: their dial attempts and successes, bans, and last crawl if the
+	// node is a seed, along with their AS.
+	DumpAddrBook(context.Context, *DumpAddrBookRequest) (*DumpAddrBookResponse, error)
+	// FlushMempool removes all the transactions from the mempool.
+	FlushMempool(context.Context, *FlushMempoolRequest) (*FlushMempoolResponse, error)
+	// DumpMempool returns the transactions in the mempool.
+	DumpMempool(context.Context, *DumpMempoolRequest) (*DumpMempoolResponse, error)
+	// PausePruning pauses the pruning of the node data until ResumePruning is
+	// called.
+	PausePruning(context.Context, *PausePruningRequest) (*PausePruningResponse, error)
+	// ResumePruning resumes the pruning paused by PausePruning.
+	ResumePruning(context.Context, *ResumePruningRequest) (*ResumePruningResponse, error)
+	// Backup takes a snapshot of the block and state stores while the node is
+	// running.
+	Backup(context.Context, *BackupRequest) (*BackupResponse, error)
+	// Halt gracefully stops the node.
+	Halt(context.Context, *HaltRequest) (*HaltResponse, error)
+}
+
+// UnimplementedAdminServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (*UnimplementedAdminServiceServer) DialSeeds(ctx context.Context, req *DialSeedsRequest) (*DialSeedsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DialSeeds not implemented")
+}
+func (*UnimplementedAdminServiceServer) DialPeers(ctx context.Context, req *DialPeersRequest) (*DialPeersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DialPeers not implemented")
+}
+func (*UnimplementedAdminServiceServer) DisconnectPeer(ctx context.Context, req *DisconnectPeerRequest) (*DisconnectPeerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisconnectPeer not implemented")
+}
+func (*UnimplementedAdminServiceServer) DumpAddrBook(ctx context.Context, req *DumpAddrBookRequest) (*DumpAddrBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DumpAddrBook not implemented")
+}
+func (*UnimplementedAdminServiceServer) FlushMempool(ctx context.Context, req *FlushMempoolRequest) (*FlushMempoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FlushMempool not implemented")
+}
+func (*UnimplementedAdminServiceServer) DumpMempool(ctx context.Context, req *DumpMempoolRequest) (*DumpMempoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DumpMempool not implemented")
+}
+func (*UnimplementedAdminServiceServer) PausePruning(ctx context.Context, req *PausePruningRequest) (*PausePruningResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PausePruning not implemented")
+}
+func (*UnimplementedAdminServiceServer) ResumePruning(ctx context.Context, req *ResumePruningRequest) (*ResumePruningResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumePruning not implemented")
+}
+func (*UnimplementedAdminServiceServer) Backup(ctx context.Context, req *BackupRequest) (*BackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Backup not implemented")
+}
+func (*UnimplementedAdminServiceServer) Halt(ctx context.Context, req *HaltRequest) (*HaltResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Halt not implemented")
+}
+
+func RegisterAdminServiceServer(s grpc1.Server, srv AdminServiceServer) {
+	s.RegisterService(&_AdminService_serviceDesc, srv)
+}
+
+func _AdminService_DialSeeds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DialSeedsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DialSeeds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.services.admin.v1.AdminService/DialSeeds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DialSeeds(ctx, req.(*DialSeedsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DialPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DialPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DialPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.services.admin.v1.AdminService/DialPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DialPeers(ctx, req.(*DialPeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DisconnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisconnectPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DisconnectPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.services.admin.v1.AdminService/DisconnectPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DisconnectPeer(ctx, req.(*DisconnectPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DumpAddrBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DumpAddrBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DumpAddrBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.services.admin.v1.AdminService/DumpAddrBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DumpAddrBook(ctx, req.(*DumpAddrBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_FlushMempool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushMempoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).FlushMempool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.services.admin.v1.AdminService/FlushMempool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).FlushMempool(ctx, req.(*FlushMempoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DumpMempool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DumpMempoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DumpMempool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.services.admin.v1.AdminService/DumpMempool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DumpMempool(ctx, req.(*DumpMempoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_PausePruning_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PausePruningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).PausePruning(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.services.admin.v1.AdminService/PausePruning",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).PausePruning(ctx, req.(*PausePruningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ResumePruning_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumePruningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ResumePruning(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.services.admin.v1.AdminService/ResumePruning",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ResumePruning(ctx, req.(*ResumePruningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_Backup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).Backup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.services.admin.v1.AdminService/Backup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).Backup(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_Halt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HaltRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).Halt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.services.admin.v1.AdminService/Halt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).Halt(ctx, req.(*HaltRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AdminService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tendermint.services.admin.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DialSeeds",
+			Handler:    _AdminService_DialSeeds_Handler,
+		},
+		{
+			MethodName: "DialPeers",
+			Handler:    _AdminService_DialPeers_Handler,
+		},
+		{
+			MethodName: "DisconnectPeer",
+			Handler:    _AdminService_DisconnectPeer_Handler,
+		},
+		{
+			MethodName: "DumpAddrBook",
+			Handler:    _AdminService_DumpAddrBook_Handler,
+		},
+		{
+			MethodName: "FlushMempool",
+			Handler:    _AdminService_FlushMempool_Handler,
+		},
+		{
+			MethodName: "DumpMempool",
+			Handler:    _AdminService_DumpMempool_Handler,
+		},
+		{
+			MethodName: "PausePruning",
+			Handler:    _AdminService_PausePruning_Handler,
+		},
+		{
+			MethodName: "ResumePruning",
+			Handler:    _AdminService_ResumePruning_Handler,
+		},
+		{
+			MethodName: "Backup",
+			Handler:    _AdminService_Backup_Handler,
+		},
+		{
+			MethodName: "Halt",
+			Handler:    _AdminService_Halt_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "tendermint/services/admin/v1/service.proto",
+}
@@ -0,0 +1,160 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: tendermint/services/events/v1/events_service.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() {
+	proto.RegisterFile("tendermint/services/events/v1/events_service.proto", fileDescriptor_a9ee9997562f7e3d)
+}
+
+var fileDescriptor_a9ee9997562f7e3d = []byte{
+	// 195 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x2a, 0x49, 0xcd, 0x4b,
+	0x49, 0x2d, 0xca, 0xcd, 0xcc, 0x2b, 0xd1, 0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0x2d, 0xd6,
+	0x4f, 0x2d, 0x4b, 0xcd, 0x2b, 0x29, 0xd6, 0x2f, 0x33, 0x84, 0xb2, 0xe2, 0xa1, 0x32, 0x7a, 0x05,
+	0x45, 0xf9, 0x25, 0xf9, 0x42, 0xb2, 0x08, 0x3d, 0x7a, 0x30, 0x3d, 0x7a, 0x10, 0x95, 0x7a, 0x65,
+	0x86, 0x52, 0x5a, 0xc4, 0x18, 0x09, 0x31, 0xca, 0xa8, 0x91, 0x91, 0x8b, 0xd7, 0x15, 0x2c, 0x10,
+	0x0c, 0x51, 0x29, 0x54, 0xc0, 0xc5, 0x19, 0x5c, 0x9a, 0x54, 0x9c, 0x5c, 0x94, 0x99, 0x94, 0x2a,
+	0xa4, 0xaf, 0x87, 0xd7, 0x2a, 0x3d, 0xb8, 0xca, 0xa0, 0xd4, 0xc2, 0xd2, 0xd4, 0xe2, 0x12, 0x29,
+	0x03, 0xe2, 0x35, 0x14, 0x17, 0xe4, 0xe7, 0x15, 0xa7, 0x1a, 0x30, 0x3a, 0x45, 0x9d, 0x78, 0x24,
+	0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47, 0x72, 0x8c, 0x13, 0x1e, 0xcb, 0x31, 0x5c, 0x78,
+	0x2c, 0xc7, 0x70, 0xe3, 0xb1, 0x1c, 0x43, 0x94, 0x43, 0x7a, 0x66, 0x49, 0x46, 0x69, 0x92, 0x5e,
+	0x72, 0x7e, 0xae, 0x7e, 0x72, 0x7e, 0x6e, 0x6a, 0x49, 0x52, 0x5a, 0x09, 0x82, 0x01, 0xf6, 0x81,
+	0x3e, 0x5e, 0xcf, 0x26, 0xb1, 0x81, 0x15, 0x19, 0x03, 0x06, 0x00, 0x46, 0x8c, 0x49, 0xdf, 0x67,
+	0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// EventsServiceClient is the client API for EventsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type EventsServiceClient interface {
+	// Subscribe returns a stream of the events matching the query, as the
+	// /subscribe RPC endpoint. The stream is canceled by the server if the
+	// client is too slow to read the events, unless they are dropped.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (EventsService_SubscribeClient, error)
+}
+
+type eventsServiceClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewEventsServiceClient(cc grpc1.ClientConn) EventsServiceClient {
+	return &eventsServiceClient{cc}
+}
+
+func (c *eventsServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (EventsService_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_EventsService_serviceDesc.Streams[0], "/tendermint.services.events.v1.EventsService/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventsServiceSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EventsService_SubscribeClient interface {
+	Recv() (*SubscribeResponse, error)
+	grpc.ClientStream
+}
+
+type eventsServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventsServiceSubscribeClient) Recv() (*SubscribeResponse, error) {
+	m := new(SubscribeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EventsServiceServer is the server API for EventsService service.
+type EventsServiceServer interface {
+	// Subscribe returns a stream of the events matching the query, as the
+	// /subscribe RPC endpoint. The stream is canceled by the server if the
+	// client is too slow to read the events, unless they are dropped.
+	Subscribe(*SubscribeRequest, EventsService_SubscribeServer) error
+}
+
+// UnimplementedEventsServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedEventsServiceServer struct {
+}
+
+func (*UnimplementedEventsServiceServer) Subscribe(req *SubscribeRequest, srv EventsService_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+
+func RegisterEventsServiceServer(s grpc1.Server, srv EventsServiceServer) {
+	s.RegisterService(&_EventsService_serviceDesc, srv)
+}
+
+func _EventsService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventsServiceServer).Subscribe(m, &eventsServiceSubscribeServer{stream})
+}
+
+type EventsService_SubscribeServer interface {
+	Send(*SubscribeResponse) error
+	grpc.ServerStream
+}
+
+type eventsServiceSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventsServiceSubscribeServer) Send(m *SubscribeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _EventsService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tendermint.services.events.v1.EventsService",
+	HandlerType: (*EventsServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _EventsService_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "tendermint/services/events/v1/events_service.proto",
+}
@@ -0,0 +1,164 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: tendermint/services/mempool/v1/mempool_service.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() {
+	proto.RegisterFile("tendermint/services/mempool/v1/mempool_service.proto", fileDescriptor_8da9a8f6a00981a4)
+}
+
+var fileDescriptor_8da9a8f6a00981a4 = []byte{
+	// 196 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x29, 0x49, 0xcd, 0x4b,
+	0x49, 0x2d, 0xca, 0xcd, 0xcc, 0x2b, 0xd1, 0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0x2d, 0xd6,
+	0xcf, 0x4d, 0xcd, 0x2d, 0xc8, 0xcf, 0xcf, 0xd1, 0x2f, 0x33, 0x84, 0x31, 0xe3, 0xa1, 0x72, 0x7a,
+	0x05, 0x45, 0xf9, 0x25, 0xf9, 0x42, 0x72, 0x08, 0x5d, 0x7a, 0x30, 0x5d, 0x7a, 0x50, 0xa5, 0x7a,
+	0x65, 0x86, 0x52, 0x3a, 0xc4, 0x99, 0x0a, 0x31, 0xcd, 0xa8, 0x85, 0x91, 0x8b, 0xcf, 0x17, 0x22,
+	0x12, 0x0c, 0x51, 0x2c, 0x54, 0xc4, 0xc5, 0xe9, 0x9e, 0x5a, 0xe2, 0x97, 0x5a, 0x1e, 0x52, 0x51,
+	0x2c, 0x64, 0xa0, 0x87, 0xdf, 0x3a, 0x3d, 0xb8, 0xd2, 0xa0, 0xd4, 0xc2, 0xd2, 0xd4, 0xe2, 0x12,
+	0x29, 0x43, 0x12, 0x74, 0x14, 0x17, 0xe4, 0xe7, 0x15, 0xa7, 0x1a, 0x30, 0x3a, 0x45, 0x9f, 0x78,
+	0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47, 0x72, 0x8c, 0x13, 0x1e, 0xcb, 0x31, 0x5c,
+	0x78, 0x2c, 0xc7, 0x70, 0xe3, 0xb1, 0x1c, 0x43, 0x94, 0x63, 0x7a, 0x66, 0x49, 0x46, 0x69, 0x92,
+	0x5e, 0x72, 0x7e, 0xae, 0x7e, 0x72, 0x7e, 0x6e, 0x6a, 0x49, 0x52, 0x5a, 0x09, 0x82, 0x01, 0xf6,
+	0x84, 0x3e, 0x7e, 0x1f, 0x27, 0xb1, 0x81, 0x55, 0x19, 0x03, 0x06, 0x00, 0xe4, 0xf7, 0xe5, 0x43,
+	0x70, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// MempoolServiceClient is the client API for MempoolService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MempoolServiceClient interface {
+	// GetNewTxs returns a stream of the transactions accepted into the mempool,
+	// with the response of the application to CheckTx. This is a long-lived
+	// stream that is only terminated by the server if an error occurs. The
+	// caller is expected to handle such disconnections and automatically
+	// reconnect.
+	GetNewTxs(ctx context.Context, in *GetNewTxsRequest, opts ...grpc.CallOption) (MempoolService_GetNewTxsClient, error)
+}
+
+type mempoolServiceClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewMempoolServiceClient(cc grpc1.ClientConn) MempoolServiceClient {
+	return &mempoolServiceClient{cc}
+}
+
+func (c *mempoolServiceClient) GetNewTxs(ctx context.Context, in *GetNewTxsRequest, opts ...grpc.CallOption) (MempoolService_GetNewTxsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_MempoolService_serviceDesc.Streams[0], "/tendermint.services.mempool.v1.MempoolService/GetNewTxs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mempoolServiceGetNewTxsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MempoolService_GetNewTxsClient interface {
+	Recv() (*GetNewTxsResponse, error)
+	grpc.ClientStream
+}
+
+type mempoolServiceGetNewTxsClient struct {
+	grpc.ClientStream
+}
+
+func (x *mempoolServiceGetNewTxsClient) Recv() (*GetNewTxsResponse, error) {
+	m := new(GetNewTxsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MempoolServiceServer is the server API for MempoolService service.
+type MempoolServiceServer interface {
+	// GetNewTxs returns a stream of the transactions accepted into the mempool,
+	// with the response of the application to CheckTx. This is a long-lived
+	// stream that is only terminated by the server if an error occurs. The
+	// caller is expected to handle such disconnections and automatically
+	// reconnect.
+	GetNewTxs(*GetNewTxsRequest, MempoolService_GetNewTxsServer) error
+}
+
+// UnimplementedMempoolServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedMempoolServiceServer struct {
+}
+
+func (*UnimplementedMempoolServiceServer) GetNewTxs(req *GetNewTxsRequest, srv MempoolService_GetNewTxsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetNewTxs not implemented")
+}
+
+func RegisterMempoolServiceServer(s grpc1.Server, srv MempoolServiceServer) {
+	s.RegisterService(&_MempoolService_serviceDesc, srv)
+}
+
+func _MempoolService_GetNewTxs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetNewTxsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MempoolServiceServer).GetNewTxs(m, &mempoolServiceGetNewTxsServer{stream})
+}
+
+type MempoolService_GetNewTxsServer interface {
+	Send(*GetNewTxsResponse) error
+	grpc.ServerStream
+}
+
+type mempoolServiceGetNewTxsServer struct {
+	grpc.ServerStream
+}
+
+func (x *mempoolServiceGetNewTxsServer) Send(m *GetNewTxsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _MempoolService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tendermint.services.mempool.v1.MempoolService",
+	HandlerType: (*MempoolServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetNewTxs",
+			Handler:       _MempoolService_GetNewTxs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "tendermint/services/mempool/v1/mempool_service.proto",
+}
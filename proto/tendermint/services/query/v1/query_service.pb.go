@@ -0,0 +1,318 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: tendermint/services/query/v1/query_service.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() {
+	proto.RegisterFile("tendermint/services/query/v1/query_service.proto", fileDescriptor_039bd429639f56a0)
+}
+
+var fileDescriptor_039bd429639f56a0 = []byte{
+	// 281 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0x3f, 0x4e, 0x84, 0x40,
+	0x14, 0xc6, 0x21, 0xd1, 0xcd, 0x66, 0xb4, 0x9a, 0x72, 0x63, 0xa6, 0x36, 0xfe, 0x19, 0x40, 0x0f,
+	0x60, 0xb2, 0xcd, 0xd6, 0x0a, 0x8d, 0x36, 0xba, 0x8c, 0x4f, 0x97, 0xac, 0x30, 0xbb, 0x33, 0x0f,
+	0x82, 0xb7, 0xf0, 0x0a, 0xde, 0xc6, 0x72, 0x4b, 0x4b, 0x03, 0x17, 0x31, 0x32, 0x4c, 0xb0, 0x02,
+	0xb6, 0x7b, 0x90, 0xdf, 0xf7, 0x7e, 0xf9, 0x26, 0x8f, 0xf8, 0x08, 0xd9, 0x33, 0xa8, 0x34, 0xc9,
+	0xd0, 0xd3, 0xa0, 0x8a, 0x44, 0x80, 0xf6, 0xb6, 0x39, 0xa8, 0x77, 0xaf, 0x08, 0xcc, 0xf0, 0xd8,
+	0xfe, 0xe7, 0x1b, 0x25, 0x51, 0xd2, 0x93, 0x2e, 0xc1, 0x6d, 0x82, 0x37, 0x20, 0x2f, 0x82, 0xd9,
+	0xe9, 0xf0, 0x3e, 0xb3, 0xe7, 0xea, 0xf3, 0x80, 0x1c, 0xdf, 0xfe, 0x7d, 0x87, 0x06, 0xa3, 0x09,
+	0x99, 0x2e, 0x00, 0xe7, 0x6f, 0x52, 0xac, 0xe9, 0x25, 0xef, 0xb3, 0x70, 0xcb, 0xdd, 0xc1, 0x36,
+	0x07, 0x8d, 0x33, 0x3e, 0x16, 0xd7, 0x1b, 0x99, 0x69, 0xa0, 0x4f, 0xe4, 0x70, 0x01, 0x18, 0x95,
+	0xf4, 0x6c, 0x30, 0x18, 0x95, 0x56, 0x72, 0x3e, 0x8a, 0x6d, 0x0d, 0x6b, 0x32, 0x8d, 0xca, 0x10,
+	0x96, 0x4a, 0xac, 0x86, 0xca, 0x58, 0x6e, 0x64, 0x99, 0x0e, 0x37, 0x2a, 0xdf, 0xa5, 0x8a, 0x1c,
+	0x35, 0xfd, 0x5a, 0x9f, 0xdf, 0xbf, 0xe0, 0x1f, 0x6a, 0x95, 0xc1, 0x1e, 0x89, 0xb6, 0xa0, 0x20,
+	0x93, 0x10, 0x97, 0x98, 0x6b, 0x3a, 0xf0, 0x2e, 0x86, 0xb2, 0xa6, 0x8b, 0x71, 0xb0, 0x91, 0xcc,
+	0xef, 0xbf, 0x2a, 0xe6, 0xee, 0x2a, 0xe6, 0xfe, 0x54, 0xcc, 0xfd, 0xa8, 0x99, 0xb3, 0xab, 0x99,
+	0xf3, 0x5d, 0x33, 0xe7, 0xe1, 0xe6, 0x35, 0xc1, 0x55, 0x1e, 0x73, 0x21, 0x53, 0x4f, 0xc8, 0x14,
+	0x30, 0x7e, 0xc1, 0x6e, 0x68, 0x2e, 0xcc, 0xeb, 0x3b, 0xc5, 0x78, 0xd2, 0x30, 0xd7, 0xbf, 0x03,
+	0x00, 0xa8, 0xd3, 0x99, 0x60, 0x01, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// QueryServiceClient is the client API for QueryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type QueryServiceClient interface {
+	// GetBlock retrieves a block by height or by hash.
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error)
+	// GetTx retrieves an indexed transaction by hash.
+	GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error)
+	// TxSearch returns a stream of the indexed transactions matching the query,
+	// ordered by height and index, which ends after the last one.
+	TxSearch(ctx context.Context, in *TxSearchRequest, opts ...grpc.CallOption) (QueryService_TxSearchClient, error)
+	// BlockSearch retrieves a page of the blocks matching the query, ordered by
+	// height.
+	BlockSearch(ctx context.Context, in *BlockSearchRequest, opts ...grpc.CallOption) (*BlockSearchResponse, error)
+	// Status retrieves the status of the node.
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+}
+
+type queryServiceClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewQueryServiceClient(cc grpc1.ClientConn) QueryServiceClient {
+	return &queryServiceClient{cc}
+}
+
+func (c *queryServiceClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error) {
+	out := new(GetBlockResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.services.query.v1.QueryService/GetBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryServiceClient) GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error) {
+	out := new(GetTxResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.services.query.v1.QueryService/GetTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryServiceClient) TxSearch(ctx context.Context, in *TxSearchRequest, opts ...grpc.CallOption) (QueryService_TxSearchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_QueryService_serviceDesc.Streams[0], "/tendermint.services.query.v1.QueryService/TxSearch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &queryServiceTxSearchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QueryService_TxSearchClient interface {
+	Recv() (*TxSearchResponse, error)
+	grpc.ClientStream
+}
+
+type queryServiceTxSearchClient struct {
+	grpc.ClientStream
+}
+
+func (x *queryServiceTxSearchClient) Recv() (*TxSearchResponse, error) {
+	m := new(TxSearchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *queryServiceClient) BlockSearch(ctx context.Context, in *BlockSearchRequest, opts ...grpc.CallOption) (*BlockSearchResponse, error) {
+	out := new(BlockSearchResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.services.query.v1.QueryService/BlockSearch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryServiceClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.services.query.v1.QueryService/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QueryServiceServer is the server API for QueryService service.
+type QueryServiceServer interface {
+	// GetBlock retrieves a block by height or by hash.
+	GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error)
+	// GetTx retrieves an indexed transaction by hash.
+	GetTx(context.Context, *GetTxRequest) (*GetTxResponse, error)
+	// TxSearch returns a stream of the indexed transactions matching the query,
+	// ordered by height and index, which ends after the last one.
+	TxSearch(*TxSearchRequest, QueryService_TxSearchServer) error
+	// BlockSearch retrieves a page of the blocks matching the query, ordered by
+	// height.
+	BlockSearch(context.Context, *BlockSearchRequest) (*BlockSearchResponse, error)
+	// Status retrieves the status of the node.
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+}
+
+// UnimplementedQueryServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedQueryServiceServer struct {
+}
+
+func (*UnimplementedQueryServiceServer) GetBlock(ctx context.Context, req *GetBlockRequest) (*GetBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (*UnimplementedQueryServiceServer) GetTx(ctx context.Context, req *GetTxRequest) (*GetTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTx not implemented")
+}
+func (*UnimplementedQueryServiceServer) TxSearch(req *TxSearchRequest, srv QueryService_TxSearchServer) error {
+	return status.Errorf(codes.Unimplemented, "method TxSearch not implemented")
+}
+func (*UnimplementedQueryServiceServer) BlockSearch(ctx context.Context, req *BlockSearchRequest) (*BlockSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockSearch not implemented")
+}
+func (*UnimplementedQueryServiceServer) Status(ctx context.Context, req *StatusRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+
+func RegisterQueryServiceServer(s grpc1.Server, srv QueryServiceServer) {
+	s.RegisterService(&_QueryService_serviceDesc, srv)
+}
+
+func _QueryService_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.services.query.v1.QueryService/GetBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryService_GetTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceServer).GetTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.services.query.v1.QueryService/GetTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceServer).GetTx(ctx, req.(*GetTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryService_TxSearch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TxSearchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryServiceServer).TxSearch(m, &queryServiceTxSearchServer{stream})
+}
+
+type QueryService_TxSearchServer interface {
+	Send(*TxSearchResponse) error
+	grpc.ServerStream
+}
+
+type queryServiceTxSearchServer struct {
+	grpc.ServerStream
+}
+
+func (x *queryServiceTxSearchServer) Send(m *TxSearchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _QueryService_BlockSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceServer).BlockSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.services.query.v1.QueryService/BlockSearch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceServer).BlockSearch(ctx, req.(*BlockSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryService_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.services.query.v1.QueryService/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _QueryService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tendermint.services.query.v1.QueryService",
+	HandlerType: (*QueryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBlock",
+			Handler:    _QueryService_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetTx",
+			Handler:    _QueryService_GetTx_Handler,
+		},
+		{
+			MethodName: "BlockSearch",
+			Handler:    _QueryService_BlockSearch_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _QueryService_Status_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TxSearch",
+			Handler:       _QueryService_TxSearch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "tendermint/services/query/v1/query_service.proto",
+}
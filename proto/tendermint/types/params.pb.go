@@ -35,6 +35,8 @@ type ConsensusParams struct {
 	Validator *ValidatorParams `protobuf:"bytes,3,opt,name=validator,proto3" json:"validator,omitempty"`
 	Version   *VersionParams   `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
 	Abci      *ABCIParams      `protobuf:"bytes,5,opt,name=abci,proto3" json:"abci,omitempty"`
+	Timeout   *TimeoutParams   `protobuf:"bytes,6,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Synchrony *SynchronyParams `protobuf:"bytes,7,opt,name=synchrony,proto3" json:"synchrony,omitempty"`
 }
 
 func (m *ConsensusParams) Reset()         { *m = ConsensusParams{} }
@@ -105,6 +107,20 @@ func (m *ConsensusParams) GetAbci() *ABCIParams {
 	return nil
 }
 
+func (m *ConsensusParams) GetTimeout() *TimeoutParams {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
+}
+
+func (m *ConsensusParams) GetSynchrony() *SynchronyParams {
+	if m != nil {
+		return m.Synchrony
+	}
+	return nil
+}
+
 // BlockParams contains limits on the block size.
 type BlockParams struct {
 	// Max block size, in bytes.
@@ -239,6 +255,11 @@ func (m *EvidenceParams) GetMaxBytes() int64 {
 // NOTE: uses ABCI pubkey naming, not Amino names.
 type ValidatorParams struct {
 	PubKeyTypes []string `protobuf:"bytes,1,rep,name=pub_key_types,json=pubKeyTypes,proto3" json:"pub_key_types,omitempty"`
+	// bls_aggregation_enable_height configures the first height from which the
+	// signatures of the precommits for the block, in the commits included in
+	// the blocks, are aggregated into a single BLS signature. All the
+	// validators must then use BLS12-381 keys.
+	BlsAggregationEnableHeight int64 `protobuf:"varint,2,opt,name=bls_aggregation_enable_height,json=blsAggregationEnableHeight,proto3" json:"bls_aggregation_enable_height,omitempty"`
 }
 
 func (m *ValidatorParams) Reset()         { *m = ValidatorParams{} }
@@ -281,6 +302,13 @@ func (m *ValidatorParams) GetPubKeyTypes() []string {
 	return nil
 }
 
+func (m *ValidatorParams) GetBlsAggregationEnableHeight() int64 {
+	if m != nil {
+		return m.BlsAggregationEnableHeight
+	}
+	return 0
+}
+
 // VersionParams contains the ABCI application version.
 type VersionParams struct {
 	App uint64 `protobuf:"varint,1,opt,name=app,proto3" json:"app,omitempty"`
@@ -393,6 +421,12 @@ type ABCIParams struct {
 	// passed to the application for validation in VerifyVoteExtension and given
 	// to the application to use when proposing a block during PrepareProposal.
 	VoteExtensionsEnableHeight int64 `protobuf:"varint,1,opt,name=vote_extensions_enable_height,json=voteExtensionsEnableHeight,proto3" json:"vote_extensions_enable_height,omitempty"`
+	// encrypted_txs_enable_height configures the first height from which the
+	// vote extensions of the validators carry their decryption shares of the
+	// threshold-encrypted transactions of the next height, followed by the
+	// extension of the application. It requires vote extensions to be enabled
+	// at that height.
+	EncryptedTxsEnableHeight int64 `protobuf:"varint,2,opt,name=encrypted_txs_enable_height,json=encryptedTxsEnableHeight,proto3" json:"encrypted_txs_enable_height,omitempty"`
 }
 
 func (m *ABCIParams) Reset()         { *m = ABCIParams{} }
@@ -435,6 +469,189 @@ func (m *ABCIParams) GetVoteExtensionsEnableHeight() int64 {
 	return 0
 }
 
+func (m *ABCIParams) GetEncryptedTxsEnableHeight() int64 {
+	if m != nil {
+		return m.EncryptedTxsEnableHeight
+	}
+	return 0
+}
+
+// TimeoutParams configure the timeouts of the steps of the consensus
+// algorithm, so that all the validators use the same timeouts.
+//
+// The timeouts are only set if propose is greater than 0. Otherwise, each node
+// uses the timeouts of its configuration.
+type TimeoutParams struct {
+	// How long a validator waits for a proposal block before prevoting nil.
+	Propose time.Duration `protobuf:"bytes,1,opt,name=propose,proto3,stdduration" json:"propose"`
+	// How much the propose timeout increases with each round.
+	ProposeDelta time.Duration `protobuf:"bytes,2,opt,name=propose_delta,json=proposeDelta,proto3,stdduration" json:"propose_delta"`
+	// How long a validator waits after receiving +2/3 prevotes for anything
+	// (ie. not a single block or nil).
+	Prevote time.Duration `protobuf:"bytes,3,opt,name=prevote,proto3,stdduration" json:"prevote"`
+	// How much the prevote timeout increases with each round.
+	PrevoteDelta time.Duration `protobuf:"bytes,4,opt,name=prevote_delta,json=prevoteDelta,proto3,stdduration" json:"prevote_delta"`
+	// How long a validator waits after receiving +2/3 precommits for anything
+	// (ie. not a single block or nil).
+	Precommit time.Duration `protobuf:"bytes,5,opt,name=precommit,proto3,stdduration" json:"precommit"`
+	// How much the precommit timeout increases with each round.
+	PrecommitDelta time.Duration `protobuf:"bytes,6,opt,name=precommit_delta,json=precommitDelta,proto3,stdduration" json:"precommit_delta"`
+	// How long a validator waits after committing a block, before starting on
+	// the new height.
+	Commit time.Duration `protobuf:"bytes,7,opt,name=commit,proto3,stdduration" json:"commit"`
+}
+
+func (m *TimeoutParams) Reset()         { *m = TimeoutParams{} }
+func (m *TimeoutParams) String() string { return proto.CompactTextString(m) }
+func (*TimeoutParams) ProtoMessage()    {}
+func (*TimeoutParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e12598271a686f57, []int{7}
+}
+func (m *TimeoutParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TimeoutParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TimeoutParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TimeoutParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeoutParams.Merge(m, src)
+}
+func (m *TimeoutParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *TimeoutParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeoutParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeoutParams proto.InternalMessageInfo
+
+func (m *TimeoutParams) GetPropose() time.Duration {
+	if m != nil {
+		return m.Propose
+	}
+	return 0
+}
+
+func (m *TimeoutParams) GetProposeDelta() time.Duration {
+	if m != nil {
+		return m.ProposeDelta
+	}
+	return 0
+}
+
+func (m *TimeoutParams) GetPrevote() time.Duration {
+	if m != nil {
+		return m.Prevote
+	}
+	return 0
+}
+
+func (m *TimeoutParams) GetPrevoteDelta() time.Duration {
+	if m != nil {
+		return m.PrevoteDelta
+	}
+	return 0
+}
+
+func (m *TimeoutParams) GetPrecommit() time.Duration {
+	if m != nil {
+		return m.Precommit
+	}
+	return 0
+}
+
+func (m *TimeoutParams) GetPrecommitDelta() time.Duration {
+	if m != nil {
+		return m.PrecommitDelta
+	}
+	return 0
+}
+
+func (m *TimeoutParams) GetCommit() time.Duration {
+	if m != nil {
+		return m.Commit
+	}
+	return 0
+}
+
+// SynchronyParams configure the bounds of the proposer-based timestamps
+// (PBTS), under which the time of a block is the time of its proposer, which
+// the validators only accept if they receive the proposal in time.
+type SynchronyParams struct {
+	// Bound on the difference between the clocks of the validators.
+	Precision time.Duration `protobuf:"bytes,1,opt,name=precision,proto3,stdduration" json:"precision"`
+	// Bound on the time for a proposal to reach the validators, in round 0. It
+	// increases by 10% with each round.
+	MessageDelay time.Duration `protobuf:"bytes,2,opt,name=message_delay,json=messageDelay,proto3,stdduration" json:"message_delay"`
+	// pbts_enable_height configures the first height from which the time of
+	// the blocks is set by PBTS instead of BFT time, i.e. the median time of the
+	// precommits of the last commit. 0 if PBTS is disabled.
+	PbtsEnableHeight int64 `protobuf:"varint,3,opt,name=pbts_enable_height,json=pbtsEnableHeight,proto3" json:"pbts_enable_height,omitempty"`
+}
+
+func (m *SynchronyParams) Reset()         { *m = SynchronyParams{} }
+func (m *SynchronyParams) String() string { return proto.CompactTextString(m) }
+func (*SynchronyParams) ProtoMessage()    {}
+func (*SynchronyParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e12598271a686f57, []int{8}
+}
+func (m *SynchronyParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SynchronyParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SynchronyParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SynchronyParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SynchronyParams.Merge(m, src)
+}
+func (m *SynchronyParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *SynchronyParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_SynchronyParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SynchronyParams proto.InternalMessageInfo
+
+func (m *SynchronyParams) GetPrecision() time.Duration {
+	if m != nil {
+		return m.Precision
+	}
+	return 0
+}
+
+func (m *SynchronyParams) GetMessageDelay() time.Duration {
+	if m != nil {
+		return m.MessageDelay
+	}
+	return 0
+}
+
+func (m *SynchronyParams) GetPbtsEnableHeight() int64 {
+	if m != nil {
+		return m.PbtsEnableHeight
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ConsensusParams)(nil), "tendermint.types.ConsensusParams")
 	proto.RegisterType((*BlockParams)(nil), "tendermint.types.BlockParams")
@@ -443,48 +660,66 @@ func init() {
 	proto.RegisterType((*VersionParams)(nil), "tendermint.types.VersionParams")
 	proto.RegisterType((*HashedParams)(nil), "tendermint.types.HashedParams")
 	proto.RegisterType((*ABCIParams)(nil), "tendermint.types.ABCIParams")
+	proto.RegisterType((*TimeoutParams)(nil), "tendermint.types.TimeoutParams")
+	proto.RegisterType((*SynchronyParams)(nil), "tendermint.types.SynchronyParams")
 }
 
 func init() { proto.RegisterFile("tendermint/types/params.proto", fileDescriptor_e12598271a686f57) }
 
 var fileDescriptor_e12598271a686f57 = []byte{
-	// 576 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0x3f, 0x6f, 0xd3, 0x4e,
-	0x18, 0xc7, 0x73, 0x75, 0xda, 0xa6, 0x4f, 0x7e, 0x69, 0xa2, 0xd3, 0x4f, 0xc2, 0x14, 0xe2, 0x04,
-	0x0f, 0xa8, 0x52, 0x25, 0x1b, 0x91, 0x09, 0x84, 0x54, 0x25, 0x25, 0x6a, 0x0b, 0x2a, 0x7f, 0x2c,
-	0xc4, 0xd0, 0xc5, 0x3a, 0x27, 0x57, 0xc7, 0x6a, 0xec, 0xb3, 0x7c, 0xe7, 0x28, 0x79, 0x17, 0x8c,
-	0x8c, 0x1d, 0x61, 0x65, 0xe2, 0x25, 0x74, 0xec, 0xc8, 0x04, 0x28, 0x59, 0x78, 0x19, 0xc8, 0x67,
-	0xbb, 0x6e, 0x12, 0xb6, 0xbb, 0x7b, 0x3e, 0x9f, 0xfb, 0xf3, 0x7d, 0x74, 0xd0, 0x14, 0x34, 0x18,
-	0xd2, 0xc8, 0xf7, 0x02, 0x61, 0x8a, 0x59, 0x48, 0xb9, 0x19, 0x92, 0x88, 0xf8, 0xdc, 0x08, 0x23,
-	0x26, 0x18, 0x6e, 0x14, 0x65, 0x43, 0x96, 0xf7, 0xfe, 0x77, 0x99, 0xcb, 0x64, 0xd1, 0x4c, 0x46,
-	0x29, 0xb7, 0xa7, 0xb9, 0x8c, 0xb9, 0x63, 0x6a, 0xca, 0x99, 0x13, 0x5f, 0x98, 0xc3, 0x38, 0x22,
-	0xc2, 0x63, 0x41, 0x5a, 0xd7, 0xbf, 0x6d, 0x40, 0xfd, 0x88, 0x05, 0x9c, 0x06, 0x3c, 0xe6, 0xef,
-	0xe4, 0x09, 0xb8, 0x03, 0x9b, 0xce, 0x98, 0x0d, 0x2e, 0x55, 0xd4, 0x46, 0xfb, 0xd5, 0xa7, 0x4d,
-	0x63, 0xf5, 0x2c, 0xa3, 0x97, 0x94, 0x53, 0xda, 0x4a, 0x59, 0xfc, 0x02, 0x2a, 0x74, 0xe2, 0x0d,
-	0x69, 0x30, 0xa0, 0xea, 0x86, 0xf4, 0xda, 0xeb, 0x5e, 0x3f, 0x23, 0x32, 0xf5, 0xd6, 0xc0, 0x87,
-	0xb0, 0x33, 0x21, 0x63, 0x6f, 0x48, 0x04, 0x8b, 0x54, 0x45, 0xea, 0x8f, 0xd6, 0xf5, 0x8f, 0x39,
-	0x92, 0xf9, 0x85, 0x83, 0x9f, 0xc1, 0xf6, 0x84, 0x46, 0xdc, 0x63, 0x81, 0x5a, 0x96, 0x7a, 0xeb,
-	0x1f, 0x7a, 0x0a, 0x64, 0x72, 0xce, 0xe3, 0x27, 0x50, 0x26, 0xce, 0xc0, 0x53, 0x37, 0xa5, 0xf7,
-	0x70, 0xdd, 0xeb, 0xf6, 0x8e, 0x4e, 0x33, 0x49, 0x92, 0xfa, 0x29, 0x54, 0xef, 0x24, 0x80, 0x1f,
-	0xc0, 0x8e, 0x4f, 0xa6, 0xb6, 0x33, 0x13, 0x94, 0xcb, 0xcc, 0x14, 0xab, 0xe2, 0x93, 0x69, 0x2f,
-	0x99, 0xe3, 0x7b, 0xb0, 0x9d, 0x14, 0x5d, 0xc2, 0x65, 0x2c, 0x8a, 0xb5, 0xe5, 0x93, 0xe9, 0x31,
-	0xe1, 0xaf, 0xca, 0x15, 0xa5, 0x51, 0xd6, 0xbf, 0x22, 0xd8, 0x5d, 0x4e, 0x05, 0x1f, 0x00, 0x4e,
-	0x0c, 0xe2, 0x52, 0x3b, 0x88, 0x7d, 0x5b, 0xc6, 0x9b, 0xef, 0x5b, 0xf7, 0xc9, 0xb4, 0xeb, 0xd2,
-	0x37, 0xb1, 0x2f, 0x2f, 0xc0, 0xf1, 0x19, 0x34, 0x72, 0x38, 0xef, 0x6c, 0x16, 0xff, 0x7d, 0x23,
-	0x6d, 0xbd, 0x91, 0xb7, 0xde, 0x78, 0x99, 0x01, 0xbd, 0xca, 0xf5, 0xcf, 0x56, 0xe9, 0xf3, 0xaf,
-	0x16, 0xb2, 0x76, 0xd3, 0xfd, 0xf2, 0xca, 0xf2, 0x53, 0x94, 0xe5, 0xa7, 0xe8, 0x87, 0x50, 0x5f,
-	0xe9, 0x00, 0xd6, 0xa1, 0x16, 0xc6, 0x8e, 0x7d, 0x49, 0x67, 0xb6, 0xcc, 0x4a, 0x45, 0x6d, 0x65,
-	0x7f, 0xc7, 0xaa, 0x86, 0xb1, 0xf3, 0x9a, 0xce, 0x3e, 0x24, 0x4b, 0xcf, 0x2b, 0xdf, 0xaf, 0x5a,
-	0xe8, 0xcf, 0x55, 0x0b, 0xe9, 0x07, 0x50, 0x5b, 0xea, 0x01, 0x6e, 0x80, 0x42, 0xc2, 0x50, 0xbe,
-	0xad, 0x6c, 0x25, 0xc3, 0x3b, 0xf0, 0x39, 0xfc, 0x77, 0x42, 0xf8, 0x88, 0x0e, 0x33, 0xf6, 0x31,
-	0xd4, 0x65, 0x14, 0xf6, 0x6a, 0xd6, 0x35, 0xb9, 0x7c, 0x96, 0x07, 0xae, 0x43, 0xad, 0xe0, 0x8a,
-	0xd8, 0xab, 0x39, 0x75, 0x4c, 0xb8, 0xfe, 0x16, 0xa0, 0x68, 0x2a, 0xee, 0x42, 0x73, 0xc2, 0x04,
-	0xb5, 0xe9, 0x54, 0xd0, 0x20, 0xb9, 0x1d, 0xb7, 0x69, 0x40, 0x9c, 0x31, 0xb5, 0x47, 0xd4, 0x73,
-	0x47, 0x22, 0x3b, 0x67, 0x2f, 0x81, 0xfa, 0xb7, 0x4c, 0x5f, 0x22, 0x27, 0x92, 0xe8, 0xbd, 0xff,
-	0x32, 0xd7, 0xd0, 0xf5, 0x5c, 0x43, 0x37, 0x73, 0x0d, 0xfd, 0x9e, 0x6b, 0xe8, 0xd3, 0x42, 0x2b,
-	0xdd, 0x2c, 0xb4, 0xd2, 0x8f, 0x85, 0x56, 0x3a, 0xef, 0xb8, 0x9e, 0x18, 0xc5, 0x8e, 0x31, 0x60,
-	0xbe, 0x39, 0x60, 0x3e, 0x15, 0xce, 0x85, 0x28, 0x06, 0xe9, 0x9f, 0x5d, 0xfd, 0xee, 0xce, 0x96,
-	0x5c, 0xef, 0xfc, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xb9, 0xe8, 0xce, 0x9a, 0x09, 0x04, 0x00, 0x00,
+	// 818 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x95, 0x4d, 0x8f, 0xdb, 0x44,
+	0x18, 0xc7, 0x77, 0x70, 0x36, 0xc9, 0x3e, 0xdb, 0x34, 0xd1, 0x08, 0x09, 0xb3, 0x65, 0x93, 0xc5,
+	0x07, 0x54, 0xa9, 0x28, 0x41, 0xec, 0x89, 0x97, 0x0a, 0x25, 0xdd, 0x55, 0xb7, 0x40, 0x11, 0x84,
+	0x15, 0x87, 0x5e, 0xac, 0xb1, 0xf3, 0xd4, 0xb1, 0x6a, 0x7b, 0x2c, 0xcf, 0x78, 0x15, 0x5f, 0xf9,
+	0x00, 0x88, 0x23, 0xc7, 0x1e, 0xe1, 0xc2, 0x99, 0x8f, 0xd0, 0x03, 0x87, 0x1e, 0x39, 0x01, 0xda,
+	0xbd, 0x70, 0xe3, 0x2b, 0x54, 0x33, 0x1e, 0xc7, 0x9b, 0x6c, 0x2b, 0x25, 0xb7, 0x99, 0x79, 0xfe,
+	0xbf, 0x79, 0x5e, 0x3d, 0x86, 0x43, 0x89, 0xc9, 0x0c, 0xb3, 0x38, 0x4c, 0xe4, 0x48, 0x16, 0x29,
+	0x8a, 0x51, 0xca, 0x32, 0x16, 0x8b, 0x61, 0x9a, 0x71, 0xc9, 0x69, 0xaf, 0x36, 0x0f, 0xb5, 0xf9,
+	0xe0, 0xed, 0x80, 0x07, 0x5c, 0x1b, 0x47, 0x6a, 0x55, 0xea, 0x0e, 0xfa, 0x01, 0xe7, 0x41, 0x84,
+	0x23, 0xbd, 0xf3, 0xf2, 0xa7, 0xa3, 0x59, 0x9e, 0x31, 0x19, 0xf2, 0xa4, 0xb4, 0x3b, 0xbf, 0x5b,
+	0xd0, 0x7d, 0xc0, 0x13, 0x81, 0x89, 0xc8, 0xc5, 0xb7, 0xda, 0x03, 0x3d, 0x86, 0x5d, 0x2f, 0xe2,
+	0xfe, 0x33, 0x9b, 0x1c, 0x91, 0xbb, 0xfb, 0x1f, 0x1f, 0x0e, 0xd7, 0x7d, 0x0d, 0x27, 0xca, 0x5c,
+	0xaa, 0xa7, 0xa5, 0x96, 0x7e, 0x0e, 0x6d, 0xbc, 0x08, 0x67, 0x98, 0xf8, 0x68, 0xbf, 0xa5, 0xb9,
+	0xa3, 0x9b, 0xdc, 0xa9, 0x51, 0x18, 0x74, 0x49, 0xd0, 0x2f, 0x60, 0xef, 0x82, 0x45, 0xe1, 0x8c,
+	0x49, 0x9e, 0xd9, 0x96, 0xc6, 0xdf, 0xbf, 0x89, 0xff, 0x50, 0x49, 0x0c, 0x5f, 0x33, 0xf4, 0x13,
+	0x68, 0x5d, 0x60, 0x26, 0x42, 0x9e, 0xd8, 0x0d, 0x8d, 0x0f, 0x5e, 0x83, 0x97, 0x02, 0x03, 0x57,
+	0x7a, 0xfa, 0x11, 0x34, 0x98, 0xe7, 0x87, 0xf6, 0xae, 0xe6, 0xde, 0xbb, 0xc9, 0x8d, 0x27, 0x0f,
+	0x1e, 0x19, 0x48, 0x2b, 0x95, 0x33, 0x19, 0xc6, 0xc8, 0x73, 0x69, 0x37, 0xdf, 0xe4, 0xec, 0xbc,
+	0x14, 0x54, 0xce, 0x8c, 0x5e, 0x25, 0x2a, 0x8a, 0xc4, 0x9f, 0x67, 0x3c, 0x29, 0xec, 0xd6, 0x9b,
+	0x12, 0xfd, 0xbe, 0x92, 0x54, 0x89, 0x2e, 0x19, 0xe7, 0x11, 0xec, 0x5f, 0xab, 0x3e, 0xbd, 0x03,
+	0x7b, 0x31, 0x5b, 0xb8, 0x5e, 0x21, 0x51, 0xe8, 0x7e, 0x59, 0xd3, 0x76, 0xcc, 0x16, 0x13, 0xb5,
+	0xa7, 0xef, 0x40, 0x4b, 0x19, 0x03, 0x26, 0x74, 0x4b, 0xac, 0x69, 0x33, 0x66, 0x8b, 0x87, 0x4c,
+	0x7c, 0xd9, 0x68, 0x5b, 0xbd, 0x86, 0xf3, 0x1b, 0x81, 0xdb, 0xab, 0x1d, 0xa1, 0xf7, 0x80, 0x2a,
+	0x82, 0x05, 0xe8, 0x26, 0x79, 0xec, 0xea, 0xd6, 0x56, 0xf7, 0x76, 0x63, 0xb6, 0x18, 0x07, 0xf8,
+	0x4d, 0x1e, 0xeb, 0x00, 0x04, 0x7d, 0x0c, 0xbd, 0x4a, 0x5c, 0x4d, 0x95, 0x69, 0xfd, 0xbb, 0xc3,
+	0x72, 0xec, 0x86, 0xd5, 0xd8, 0x0d, 0x4f, 0x8c, 0x60, 0xd2, 0x7e, 0xf1, 0xf7, 0x60, 0xe7, 0x97,
+	0x7f, 0x06, 0x64, 0x7a, 0xbb, 0xbc, 0xaf, 0xb2, 0xac, 0xa6, 0x62, 0xad, 0xa6, 0xe2, 0xfc, 0x48,
+	0xa0, 0xbb, 0xd6, 0x7e, 0xea, 0x40, 0x27, 0xcd, 0x3d, 0xf7, 0x19, 0x16, 0xae, 0x2e, 0x9b, 0x4d,
+	0x8e, 0xac, 0xbb, 0x7b, 0xd3, 0xfd, 0x34, 0xf7, 0xbe, 0xc2, 0xe2, 0x5c, 0x1d, 0xd1, 0x31, 0x1c,
+	0x7a, 0x91, 0x70, 0x59, 0x10, 0x64, 0x18, 0x68, 0x3f, 0x2e, 0x26, 0xcc, 0x8b, 0xd0, 0x9d, 0x63,
+	0x18, 0xcc, 0xa5, 0x29, 0xcc, 0x81, 0x17, 0x89, 0x71, 0xad, 0x39, 0xd5, 0x92, 0x33, 0xad, 0xf8,
+	0xb4, 0xfd, 0xc7, 0xf3, 0x01, 0xf9, 0xef, 0xf9, 0x80, 0x38, 0xf7, 0xa0, 0xb3, 0x32, 0x43, 0xb4,
+	0x07, 0x16, 0x4b, 0x53, 0x5d, 0x9f, 0xc6, 0x54, 0x2d, 0xaf, 0x89, 0x9f, 0xc0, 0xad, 0x33, 0x26,
+	0xe6, 0x38, 0x33, 0xda, 0x0f, 0xa0, 0xab, 0xcb, 0xe9, 0xae, 0xf7, 0xab, 0xa3, 0x8f, 0x1f, 0x57,
+	0x4d, 0x73, 0xa0, 0x53, 0xeb, 0xea, 0xd6, 0xed, 0x57, 0xaa, 0x87, 0x4c, 0x38, 0x3f, 0x11, 0x80,
+	0x7a, 0x2a, 0x55, 0x92, 0x17, 0x5c, 0xa2, 0x8b, 0x0b, 0x89, 0x89, 0x0a, 0x4f, 0xac, 0x25, 0x59,
+	0x3a, 0x3a, 0x50, 0xa2, 0xd3, 0xa5, 0xe6, 0x7a, 0x92, 0xf4, 0x3e, 0xdc, 0xc1, 0xc4, 0xcf, 0x8a,
+	0x54, 0xe2, 0xcc, 0x95, 0x0b, 0xf1, 0xda, 0x2a, 0xd9, 0x4b, 0xc9, 0xf9, 0x62, 0x05, 0x77, 0xfe,
+	0xb7, 0xa0, 0xb3, 0x32, 0xf1, 0xf4, 0x3e, 0xb4, 0xd2, 0x8c, 0xa7, 0x5c, 0xa0, 0x4d, 0x36, 0x9f,
+	0x89, 0x8a, 0xa1, 0x67, 0xd0, 0x31, 0x4b, 0x77, 0x86, 0x91, 0x64, 0xdb, 0x0c, 0xd6, 0x2d, 0x43,
+	0x9e, 0x28, 0xb0, 0x0c, 0x04, 0x55, 0xea, 0xb6, 0xb5, 0xf9, 0x1d, 0x15, 0x53, 0x06, 0xa2, 0x97,
+	0x26, 0x90, 0xc6, 0x56, 0x81, 0x68, 0xb2, 0x0c, 0x64, 0x0c, 0x7b, 0x69, 0x86, 0x3e, 0x8f, 0xe3,
+	0x50, 0xda, 0xbb, 0x9b, 0xdf, 0x52, 0x53, 0xf4, 0x6b, 0xe8, 0x2e, 0x37, 0x26, 0x9c, 0xe6, 0x16,
+	0x1f, 0xdc, 0x92, 0x2d, 0x03, 0xfa, 0x0c, 0x9a, 0x26, 0x9a, 0xd6, 0xe6, 0x97, 0x18, 0xc4, 0xf9,
+	0x93, 0x40, 0x77, 0xed, 0x99, 0xaa, 0x32, 0x0c, 0xf5, 0x33, 0x4c, 0xb6, 0xcc, 0x50, 0x53, 0xaa,
+	0xdc, 0x31, 0x0a, 0xa1, 0xdf, 0x14, 0x8c, 0x58, 0xb1, 0x55, 0xdf, 0x0d, 0x79, 0xa2, 0x40, 0xfa,
+	0x21, 0xd0, 0xd4, 0x93, 0xeb, 0x83, 0x5c, 0xbe, 0x2b, 0x3d, 0x65, 0xb9, 0x3e, 0xc0, 0x93, 0xef,
+	0x7e, 0xbd, 0xec, 0x93, 0x17, 0x97, 0x7d, 0xf2, 0xf2, 0xb2, 0x4f, 0xfe, 0xbd, 0xec, 0x93, 0x9f,
+	0xaf, 0xfa, 0x3b, 0x2f, 0xaf, 0xfa, 0x3b, 0x7f, 0x5d, 0xf5, 0x77, 0x9e, 0x1c, 0x07, 0xa1, 0x9c,
+	0xe7, 0xde, 0xd0, 0xe7, 0xf1, 0xc8, 0xe7, 0x31, 0x4a, 0xef, 0xa9, 0xac, 0x17, 0xe5, 0x4f, 0x77,
+	0xfd, 0x7f, 0xed, 0x35, 0xf5, 0xf9, 0xf1, 0xab, 0x01, 0x00, 0xc1, 0xee, 0xcd, 0xb7, 0xca, 0x07,
+	0x00, 0x00,
 }
 
 func (this *ConsensusParams) Equal(that interface{}) bool {
@@ -521,6 +756,12 @@ func (this *ConsensusParams) Equal(that interface{}) bool {
 	if !this.Abci.Equal(that1.Abci) {
 		return false
 	}
+	if !this.Timeout.Equal(that1.Timeout) {
+		return false
+	}
+	if !this.Synchrony.Equal(that1.Synchrony) {
+		return false
+	}
 	return true
 }
 func (this *BlockParams) Equal(that interface{}) bool {
@@ -607,6 +848,9 @@ func (this *ValidatorParams) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.BlsAggregationEnableHeight != that1.BlsAggregationEnableHeight {
+		return false
+	}
 	return true
 }
 func (this *VersionParams) Equal(that interface{}) bool {
@@ -682,6 +926,81 @@ func (this *ABCIParams) Equal(that interface{}) bool {
 	if this.VoteExtensionsEnableHeight != that1.VoteExtensionsEnableHeight {
 		return false
 	}
+	if this.EncryptedTxsEnableHeight != that1.EncryptedTxsEnableHeight {
+		return false
+	}
+	return true
+}
+func (this *TimeoutParams) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TimeoutParams)
+	if !ok {
+		that2, ok := that.(TimeoutParams)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Propose != that1.Propose {
+		return false
+	}
+	if this.ProposeDelta != that1.ProposeDelta {
+		return false
+	}
+	if this.Prevote != that1.Prevote {
+		return false
+	}
+	if this.PrevoteDelta != that1.PrevoteDelta {
+		return false
+	}
+	if this.Precommit != that1.Precommit {
+		return false
+	}
+	if this.PrecommitDelta != that1.PrecommitDelta {
+		return false
+	}
+	if this.Commit != that1.Commit {
+		return false
+	}
+	return true
+}
+func (this *SynchronyParams) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SynchronyParams)
+	if !ok {
+		that2, ok := that.(SynchronyParams)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Precision != that1.Precision {
+		return false
+	}
+	if this.MessageDelay != that1.MessageDelay {
+		return false
+	}
+	if this.PbtsEnableHeight != that1.PbtsEnableHeight {
+		return false
+	}
 	return true
 }
 func (m *ConsensusParams) Marshal() (dAtA []byte, err error) {
@@ -704,6 +1023,30 @@ func (m *ConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Synchrony != nil {
+		{
+			size, err := m.Synchrony.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintParams(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Timeout != nil {
+		{
+			size, err := m.Timeout.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintParams(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
 	if m.Abci != nil {
 		{
 			size, err := m.Abci.MarshalToSizedBuffer(dAtA[:i])
@@ -825,12 +1168,12 @@ func (m *EvidenceParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x18
 	}
-	n6, err6 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.MaxAgeDuration, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.MaxAgeDuration):])
-	if err6 != nil {
-		return 0, err6
+	n8, err8 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.MaxAgeDuration, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.MaxAgeDuration):])
+	if err8 != nil {
+		return 0, err8
 	}
-	i -= n6
-	i = encodeVarintParams(dAtA, i, uint64(n6))
+	i -= n8
+	i = encodeVarintParams(dAtA, i, uint64(n8))
 	i--
 	dAtA[i] = 0x12
 	if m.MaxAgeNumBlocks != 0 {
@@ -861,6 +1204,11 @@ func (m *ValidatorParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.BlsAggregationEnableHeight != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.BlsAggregationEnableHeight))
+		i--
+		dAtA[i] = 0x10
+	}
 	if len(m.PubKeyTypes) > 0 {
 		for iNdEx := len(m.PubKeyTypes) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.PubKeyTypes[iNdEx])
@@ -954,6 +1302,11 @@ func (m *ABCIParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.EncryptedTxsEnableHeight != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.EncryptedTxsEnableHeight))
+		i--
+		dAtA[i] = 0x10
+	}
 	if m.VoteExtensionsEnableHeight != 0 {
 		i = encodeVarintParams(dAtA, i, uint64(m.VoteExtensionsEnableHeight))
 		i--
@@ -962,41 +1315,168 @@ func (m *ABCIParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintParams(dAtA []byte, offset int, v uint64) int {
-	offset -= sovParams(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *TimeoutParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func NewPopulatedValidatorParams(r randyParams, easy bool) *ValidatorParams {
-	this := &ValidatorParams{}
-	v1 := r.Intn(10)
-	this.PubKeyTypes = make([]string, v1)
-	for i := 0; i < v1; i++ {
-		this.PubKeyTypes[i] = string(randStringParams(r))
+
+func (m *TimeoutParams) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TimeoutParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n9, err9 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.Commit, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Commit):])
+	if err9 != nil {
+		return 0, err9
 	}
-	if !easy && r.Intn(10) != 0 {
+	i -= n9
+	i = encodeVarintParams(dAtA, i, uint64(n9))
+	i--
+	dAtA[i] = 0x3a
+	n10, err10 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.PrecommitDelta, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.PrecommitDelta):])
+	if err10 != nil {
+		return 0, err10
 	}
-	return this
+	i -= n10
+	i = encodeVarintParams(dAtA, i, uint64(n10))
+	i--
+	dAtA[i] = 0x32
+	n11, err11 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.Precommit, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Precommit):])
+	if err11 != nil {
+		return 0, err11
+	}
+	i -= n11
+	i = encodeVarintParams(dAtA, i, uint64(n11))
+	i--
+	dAtA[i] = 0x2a
+	n12, err12 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.PrevoteDelta, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.PrevoteDelta):])
+	if err12 != nil {
+		return 0, err12
+	}
+	i -= n12
+	i = encodeVarintParams(dAtA, i, uint64(n12))
+	i--
+	dAtA[i] = 0x22
+	n13, err13 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.Prevote, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Prevote):])
+	if err13 != nil {
+		return 0, err13
+	}
+	i -= n13
+	i = encodeVarintParams(dAtA, i, uint64(n13))
+	i--
+	dAtA[i] = 0x1a
+	n14, err14 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.ProposeDelta, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.ProposeDelta):])
+	if err14 != nil {
+		return 0, err14
+	}
+	i -= n14
+	i = encodeVarintParams(dAtA, i, uint64(n14))
+	i--
+	dAtA[i] = 0x12
+	n15, err15 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.Propose, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Propose):])
+	if err15 != nil {
+		return 0, err15
+	}
+	i -= n15
+	i = encodeVarintParams(dAtA, i, uint64(n15))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func NewPopulatedVersionParams(r randyParams, easy bool) *VersionParams {
-	this := &VersionParams{}
-	this.App = uint64(uint64(r.Uint32()))
-	if !easy && r.Intn(10) != 0 {
+func (m *SynchronyParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-type randyParams interface {
-	Float32() float32
-	Float64() float64
-	Int63() int64
+func (m *SynchronyParams) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SynchronyParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.PbtsEnableHeight != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.PbtsEnableHeight))
+		i--
+		dAtA[i] = 0x18
+	}
+	n16, err16 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.MessageDelay, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.MessageDelay):])
+	if err16 != nil {
+		return 0, err16
+	}
+	i -= n16
+	i = encodeVarintParams(dAtA, i, uint64(n16))
+	i--
+	dAtA[i] = 0x12
+	n17, err17 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.Precision, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Precision):])
+	if err17 != nil {
+		return 0, err17
+	}
+	i -= n17
+	i = encodeVarintParams(dAtA, i, uint64(n17))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintParams(dAtA []byte, offset int, v uint64) int {
+	offset -= sovParams(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func NewPopulatedValidatorParams(r randyParams, easy bool) *ValidatorParams {
+	this := &ValidatorParams{}
+	v1 := r.Intn(10)
+	this.PubKeyTypes = make([]string, v1)
+	for i := 0; i < v1; i++ {
+		this.PubKeyTypes[i] = string(randStringParams(r))
+	}
+	this.BlsAggregationEnableHeight = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.BlsAggregationEnableHeight *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedVersionParams(r randyParams, easy bool) *VersionParams {
+	this := &VersionParams{}
+	this.App = uint64(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+type randyParams interface {
+	Float32() float32
+	Float64() float64
+	Int63() int64
 	Int31() int32
 	Uint32() uint32
 	Intn(n int) int
@@ -1091,6 +1571,14 @@ func (m *ConsensusParams) Size() (n int) {
 		l = m.Abci.Size()
 		n += 1 + l + sovParams(uint64(l))
 	}
+	if m.Timeout != nil {
+		l = m.Timeout.Size()
+		n += 1 + l + sovParams(uint64(l))
+	}
+	if m.Synchrony != nil {
+		l = m.Synchrony.Size()
+		n += 1 + l + sovParams(uint64(l))
+	}
 	return n
 }
 
@@ -1138,6 +1626,9 @@ func (m *ValidatorParams) Size() (n int) {
 			n += 1 + l + sovParams(uint64(l))
 		}
 	}
+	if m.BlsAggregationEnableHeight != 0 {
+		n += 1 + sovParams(uint64(m.BlsAggregationEnableHeight))
+	}
 	return n
 }
 
@@ -1177,6 +1668,48 @@ func (m *ABCIParams) Size() (n int) {
 	if m.VoteExtensionsEnableHeight != 0 {
 		n += 1 + sovParams(uint64(m.VoteExtensionsEnableHeight))
 	}
+	if m.EncryptedTxsEnableHeight != 0 {
+		n += 1 + sovParams(uint64(m.EncryptedTxsEnableHeight))
+	}
+	return n
+}
+
+func (m *TimeoutParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Propose)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.ProposeDelta)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Prevote)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.PrevoteDelta)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Precommit)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.PrecommitDelta)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Commit)
+	n += 1 + l + sovParams(uint64(l))
+	return n
+}
+
+func (m *SynchronyParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Precision)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.MessageDelay)
+	n += 1 + l + sovParams(uint64(l))
+	if m.PbtsEnableHeight != 0 {
+		n += 1 + sovParams(uint64(m.PbtsEnableHeight))
+	}
 	return n
 }
 
@@ -1395,6 +1928,78 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Timeout == nil {
+				m.Timeout = &TimeoutParams{}
+			}
+			if err := m.Timeout.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Synchrony", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Synchrony == nil {
+				m.Synchrony = &SynchronyParams{}
+			}
+			if err := m.Synchrony.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -1686,6 +2291,25 @@ func (m *ValidatorParams) Unmarshal(dAtA []byte) error {
 			}
 			m.PubKeyTypes = append(m.PubKeyTypes, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlsAggregationEnableHeight", wireType)
+			}
+			m.BlsAggregationEnableHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlsAggregationEnableHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -1912,6 +2536,441 @@ func (m *ABCIParams) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedTxsEnableHeight", wireType)
+			}
+			m.EncryptedTxsEnableHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EncryptedTxsEnableHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TimeoutParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TimeoutParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TimeoutParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Propose", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.Propose, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposeDelta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.ProposeDelta, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prevote", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.Prevote, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrevoteDelta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.PrevoteDelta, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Precommit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.Precommit, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrecommitDelta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.PrecommitDelta, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.Commit, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SynchronyParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SynchronyParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SynchronyParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Precision", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.Precision, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageDelay", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.MessageDelay, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PbtsEnableHeight", wireType)
+			}
+			m.PbtsEnableHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PbtsEnableHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -562,6 +562,10 @@ type Commit struct {
 	Round      int32       `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
 	BlockID    BlockID     `protobuf:"bytes,3,opt,name=block_id,json=blockId,proto3" json:"block_id"`
 	Signatures []CommitSig `protobuf:"bytes,4,rep,name=signatures,proto3" json:"signatures"`
+	// Aggregation of the signatures of the precommits for the block, whose
+	// signatures are then empty. Only set if the BLS aggregation of the commit
+	// signatures is enabled.
+	AggregatedSignature []byte `protobuf:"bytes,5,opt,name=aggregated_signature,json=aggregatedSignature,proto3" json:"aggregated_signature,omitempty"`
 }
 
 func (m *Commit) Reset()         { *m = Commit{} }
@@ -625,6 +629,13 @@ func (m *Commit) GetSignatures() []CommitSig {
 	return nil
 }
 
+func (m *Commit) GetAggregatedSignature() []byte {
+	if m != nil {
+		return m.AggregatedSignature
+	}
+	return nil
+}
+
 // CommitSig is a part of the Vote included in a Commit.
 type CommitSig struct {
 	BlockIdFlag      BlockIDFlag `protobuf:"varint,1,opt,name=block_id_flag,json=blockIdFlag,proto3,enum=tendermint.types.BlockIDFlag" json:"block_id_flag,omitempty"`
@@ -699,6 +710,9 @@ type ExtendedCommit struct {
 	Round              int32               `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
 	BlockID            BlockID             `protobuf:"bytes,3,opt,name=block_id,json=blockId,proto3" json:"block_id"`
 	ExtendedSignatures []ExtendedCommitSig `protobuf:"bytes,4,rep,name=extended_signatures,json=extendedSignatures,proto3" json:"extended_signatures"`
+	// Aggregation of the signatures of the precommits for the block whose
+	// signatures are empty, see Commit.
+	AggregatedSignature []byte `protobuf:"bytes,5,opt,name=aggregated_signature,json=aggregatedSignature,proto3" json:"aggregated_signature,omitempty"`
 }
 
 func (m *ExtendedCommit) Reset()         { *m = ExtendedCommit{} }
@@ -762,6 +776,13 @@ func (m *ExtendedCommit) GetExtendedSignatures() []ExtendedCommitSig {
 	return nil
 }
 
+func (m *ExtendedCommit) GetAggregatedSignature() []byte {
+	if m != nil {
+		return m.AggregatedSignature
+	}
+	return nil
+}
+
 // ExtendedCommitSig retains all the same fields as CommitSig but adds vote
 // extension-related fields. We use two signatures to ensure backwards compatibility.
 // That is the digest of the original signature is still the same in prior versions
@@ -1198,89 +1219,91 @@ func init() {
 func init() { proto.RegisterFile("tendermint/types/types.proto", fileDescriptor_d3a6e55e2345de56) }
 
 var fileDescriptor_d3a6e55e2345de56 = []byte{
-	// 1310 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x57, 0xcf, 0x6f, 0x1b, 0x45,
-	0x14, 0xce, 0xda, 0xeb, 0x5f, 0xcf, 0x76, 0xe2, 0x2c, 0x11, 0x75, 0xdd, 0xc6, 0xb1, 0x5c, 0x01,
-	0xa1, 0xa0, 0x4d, 0x95, 0x22, 0x04, 0x07, 0x0e, 0xf9, 0x45, 0x1b, 0x51, 0x27, 0xd6, 0xda, 0x2d,
-	0xa2, 0x97, 0xd5, 0xda, 0x3b, 0xb1, 0x97, 0xda, 0x3b, 0xab, 0xdd, 0x71, 0x70, 0xfa, 0x17, 0xa0,
-	0x9e, 0x7a, 0xe2, 0xd6, 0x13, 0x1c, 0xb8, 0x83, 0xc4, 0x15, 0x71, 0xea, 0xb1, 0x37, 0xb8, 0x50,
-	0x20, 0x95, 0xf8, 0x3b, 0xd0, 0xbc, 0x99, 0xdd, 0xb5, 0xe3, 0x18, 0xaa, 0xa8, 0x02, 0x89, 0x8b,
-	0xb5, 0xf3, 0xde, 0xf7, 0xde, 0xbc, 0x79, 0xdf, 0x37, 0xa3, 0x67, 0xb8, 0xca, 0x88, 0x6b, 0x13,
-	0x7f, 0xe8, 0xb8, 0x6c, 0x83, 0x9d, 0x78, 0x24, 0x10, 0xbf, 0xba, 0xe7, 0x53, 0x46, 0xb5, 0x52,
-	0xec, 0xd5, 0xd1, 0x5e, 0x59, 0xe9, 0xd1, 0x1e, 0x45, 0xe7, 0x06, 0xff, 0x12, 0xb8, 0xca, 0x5a,
-	0x8f, 0xd2, 0xde, 0x80, 0x6c, 0xe0, 0xaa, 0x33, 0x3a, 0xda, 0x60, 0xce, 0x90, 0x04, 0xcc, 0x1a,
-	0x7a, 0x12, 0xb0, 0x3a, 0xb1, 0x4d, 0xd7, 0x3f, 0xf1, 0x18, 0xe5, 0x58, 0x7a, 0x24, 0xdd, 0xd5,
-	0x09, 0xf7, 0x31, 0xf1, 0x03, 0x87, 0xba, 0x93, 0x75, 0x54, 0x6a, 0x33, 0x55, 0x1e, 0x5b, 0x03,
-	0xc7, 0xb6, 0x18, 0xf5, 0x05, 0xa2, 0xfe, 0x21, 0x14, 0x9b, 0x96, 0xcf, 0x5a, 0x84, 0xdd, 0x26,
-	0x96, 0x4d, 0x7c, 0x6d, 0x05, 0x52, 0x8c, 0x32, 0x6b, 0x50, 0x56, 0x6a, 0xca, 0x7a, 0xd1, 0x10,
-	0x0b, 0x4d, 0x03, 0xb5, 0x6f, 0x05, 0xfd, 0x72, 0xa2, 0xa6, 0xac, 0x17, 0x0c, 0xfc, 0xae, 0xf7,
-	0x41, 0xe5, 0xa1, 0x3c, 0xc2, 0x71, 0x6d, 0x32, 0x0e, 0x23, 0x70, 0xc1, 0xad, 0x9d, 0x13, 0x46,
-	0x02, 0x19, 0x22, 0x16, 0xda, 0x7b, 0x90, 0xc2, 0xfa, 0xcb, 0xc9, 0x9a, 0xb2, 0x9e, 0xdf, 0x2c,
-	0xeb, 0x13, 0x8d, 0x12, 0xe7, 0xd3, 0x9b, 0xdc, 0xbf, 0xad, 0x3e, 0x7d, 0xbe, 0xb6, 0x60, 0x08,
-	0x70, 0x7d, 0x00, 0x99, 0xed, 0x01, 0xed, 0x3e, 0xd8, 0xdf, 0x8d, 0x0a, 0x51, 0xe2, 0x42, 0xb4,
-	0x06, 0x2c, 0x79, 0x96, 0xcf, 0xcc, 0x80, 0x30, 0xb3, 0x8f, 0xa7, 0xc0, 0x4d, 0xf3, 0x9b, 0x6b,
-	0xfa, 0x59, 0x1e, 0xf4, 0xa9, 0xc3, 0xca, 0x5d, 0x8a, 0xde, 0xa4, 0xb1, 0xfe, 0xa7, 0x0a, 0x69,
-	0xd9, 0x8c, 0x8f, 0x20, 0x23, 0xdb, 0x8a, 0x1b, 0xe6, 0x37, 0x57, 0x27, 0x33, 0x4a, 0x97, 0xbe,
-	0x43, 0xdd, 0x80, 0xb8, 0xc1, 0x28, 0x90, 0xf9, 0xc2, 0x18, 0xed, 0x4d, 0xc8, 0x76, 0xfb, 0x96,
-	0xe3, 0x9a, 0x8e, 0x8d, 0x15, 0xe5, 0xb6, 0xf3, 0xa7, 0xcf, 0xd7, 0x32, 0x3b, 0xdc, 0xb6, 0xbf,
-	0x6b, 0x64, 0xd0, 0xb9, 0x6f, 0x6b, 0xaf, 0x43, 0xba, 0x4f, 0x9c, 0x5e, 0x9f, 0x61, 0x5b, 0x92,
-	0x86, 0x5c, 0x69, 0x1f, 0x80, 0xca, 0x05, 0x51, 0x56, 0x71, 0xef, 0x8a, 0x2e, 0xd4, 0xa2, 0x87,
-	0x6a, 0xd1, 0xdb, 0xa1, 0x5a, 0xb6, 0xb3, 0x7c, 0xe3, 0xc7, 0xbf, 0xad, 0x29, 0x06, 0x46, 0x68,
-	0x3b, 0x50, 0x1c, 0x58, 0x01, 0x33, 0x3b, 0xbc, 0x6d, 0x7c, 0xfb, 0x14, 0xa6, 0xb8, 0x3c, 0xdb,
-	0x10, 0xd9, 0x58, 0x59, 0x7a, 0x9e, 0x47, 0x09, 0x93, 0xad, 0xad, 0x43, 0x09, 0x93, 0x74, 0xe9,
-	0x70, 0xe8, 0x30, 0x13, 0xfb, 0x9e, 0xc6, 0xbe, 0x2f, 0x72, 0xfb, 0x0e, 0x9a, 0x6f, 0x73, 0x06,
-	0xae, 0x40, 0xce, 0xb6, 0x98, 0x25, 0x20, 0x19, 0x84, 0x64, 0xb9, 0x01, 0x9d, 0x6f, 0xc1, 0x52,
-	0xa4, 0xba, 0x40, 0x40, 0xb2, 0x22, 0x4b, 0x6c, 0x46, 0xe0, 0x0d, 0x58, 0x71, 0xc9, 0x98, 0x99,
-	0x67, 0xd1, 0x39, 0x44, 0x6b, 0xdc, 0x77, 0x6f, 0x3a, 0xe2, 0x0d, 0x58, 0xec, 0x86, 0xcd, 0x17,
-	0x58, 0x40, 0x6c, 0x31, 0xb2, 0x22, 0xec, 0x32, 0x64, 0x2d, 0xcf, 0x13, 0x80, 0x3c, 0x02, 0x32,
-	0x96, 0xe7, 0xa1, 0xeb, 0x3a, 0x2c, 0xe3, 0x19, 0x7d, 0x12, 0x8c, 0x06, 0x4c, 0x26, 0x29, 0x20,
-	0x66, 0x89, 0x3b, 0x0c, 0x61, 0x47, 0xec, 0x35, 0x28, 0x92, 0x63, 0xc7, 0x26, 0x6e, 0x97, 0x08,
-	0x5c, 0x11, 0x71, 0x85, 0xd0, 0x88, 0xa0, 0xb7, 0xa1, 0xe4, 0xf9, 0xd4, 0xa3, 0x01, 0xf1, 0x4d,
-	0xcb, 0xb6, 0x7d, 0x12, 0x04, 0xe5, 0x45, 0x91, 0x2f, 0xb4, 0x6f, 0x09, 0x73, 0xbd, 0x0c, 0xea,
-	0xae, 0xc5, 0x2c, 0xad, 0x04, 0x49, 0x36, 0x0e, 0xca, 0x4a, 0x2d, 0xb9, 0x5e, 0x30, 0xf8, 0x67,
-	0xfd, 0x87, 0x24, 0xa8, 0xf7, 0x28, 0x23, 0xda, 0x4d, 0x50, 0x39, 0x4d, 0xa8, 0xbe, 0xc5, 0xf3,
-	0xf4, 0xdc, 0x72, 0x7a, 0x2e, 0xb1, 0x1b, 0x41, 0xaf, 0x7d, 0xe2, 0x11, 0x03, 0xc1, 0x13, 0x72,
-	0x4a, 0x4c, 0xc9, 0x69, 0x05, 0x52, 0x3e, 0x1d, 0xb9, 0x36, 0xaa, 0x2c, 0x65, 0x88, 0x85, 0xb6,
-	0x07, 0xd9, 0x48, 0x25, 0xea, 0x3f, 0xa9, 0x64, 0x89, 0xab, 0x84, 0x6b, 0x58, 0x1a, 0x8c, 0x4c,
-	0x47, 0x8a, 0x65, 0x1b, 0x72, 0xd1, 0xe3, 0x25, 0xd5, 0xf6, 0x72, 0x82, 0x8d, 0xc3, 0xb4, 0x77,
-	0x60, 0x39, 0xe2, 0x3e, 0x6a, 0x9e, 0x50, 0x5c, 0x29, 0x72, 0xc8, 0xee, 0x4d, 0xc9, 0xca, 0x14,
-	0x0f, 0x50, 0x06, 0xcf, 0x15, 0xcb, 0x6a, 0x1f, 0x5f, 0xa2, 0xab, 0x90, 0x0b, 0x9c, 0x9e, 0x6b,
-	0xb1, 0x91, 0x4f, 0xa4, 0xf2, 0x62, 0x03, 0xf7, 0x92, 0x31, 0x23, 0x2e, 0x5e, 0x72, 0xa1, 0xb4,
-	0xd8, 0xa0, 0x6d, 0xc0, 0x6b, 0xd1, 0xc2, 0x8c, 0xb3, 0x08, 0x95, 0x69, 0x91, 0xab, 0x15, 0x7a,
-	0xea, 0x3f, 0x2a, 0x90, 0x16, 0x17, 0x63, 0x82, 0x06, 0xe5, 0x7c, 0x1a, 0x12, 0xf3, 0x68, 0x48,
-	0x5e, 0x9c, 0x86, 0x2d, 0x80, 0xa8, 0xcc, 0xa0, 0xac, 0xd6, 0x92, 0xeb, 0xf9, 0xcd, 0x2b, 0xb3,
-	0x89, 0x44, 0x89, 0x2d, 0xa7, 0x27, 0xef, 0xfd, 0x44, 0x50, 0xfd, 0x57, 0x05, 0x72, 0x91, 0x5f,
-	0xdb, 0x82, 0x62, 0x58, 0x97, 0x79, 0x34, 0xb0, 0x7a, 0x52, 0x8a, 0xab, 0x73, 0x8b, 0xfb, 0x78,
-	0x60, 0xf5, 0x8c, 0xbc, 0xac, 0x87, 0x2f, 0xce, 0xa7, 0x35, 0x31, 0x87, 0xd6, 0x29, 0x1d, 0x25,
-	0x2f, 0xa6, 0xa3, 0x29, 0xc6, 0xd5, 0x33, 0x8c, 0xd7, 0xff, 0x50, 0x60, 0x71, 0x6f, 0x8c, 0xe5,
-	0xdb, 0xff, 0x25, 0x55, 0xf7, 0xa5, 0xb6, 0x6c, 0x62, 0x9b, 0x33, 0x9c, 0x5d, 0x9b, 0xcd, 0x38,
-	0x5d, 0x73, 0xcc, 0x9d, 0x16, 0x66, 0x69, 0xc5, 0x1c, 0x7e, 0x9f, 0x80, 0xe5, 0x19, 0xfc, 0xff,
-	0x8f, 0xcb, 0xe9, 0xdb, 0x9b, 0x7a, 0xc9, 0xdb, 0x9b, 0x9e, 0x7b, 0x7b, 0xbf, 0x4b, 0x40, 0xb6,
-	0x89, 0xaf, 0xb4, 0x35, 0xf8, 0x37, 0xde, 0xde, 0x2b, 0x90, 0xf3, 0xe8, 0xc0, 0x14, 0x1e, 0x15,
-	0x3d, 0x59, 0x8f, 0x0e, 0x8c, 0x19, 0x99, 0xa5, 0x5e, 0xd1, 0xc3, 0x9c, 0x7e, 0x05, 0x24, 0x64,
-	0xce, 0x5e, 0x28, 0x1f, 0x0a, 0xa2, 0x15, 0x72, 0x6a, 0xba, 0xc1, 0x7b, 0x80, 0x63, 0x98, 0x32,
-	0x3b, 0xe5, 0x89, 0xb2, 0x05, 0xd2, 0x90, 0x38, 0x1e, 0x21, 0x86, 0x0c, 0x39, 0xb8, 0x95, 0xe7,
-	0xbd, 0x58, 0x86, 0xc4, 0xd5, 0xbf, 0x52, 0x00, 0xee, 0xf0, 0xce, 0xe2, 0x79, 0xf9, 0xbc, 0x13,
-	0x60, 0x09, 0xe6, 0xd4, 0xce, 0xd5, 0x79, 0xa4, 0xc9, 0xfd, 0x0b, 0xc1, 0x64, 0xdd, 0x3b, 0x50,
-	0x8c, 0xb5, 0x1d, 0x90, 0xb0, 0x98, 0x73, 0x92, 0x44, 0x63, 0x48, 0x8b, 0x30, 0xa3, 0x70, 0x3c,
-	0xb1, 0xaa, 0xff, 0xa4, 0x40, 0x0e, 0x6b, 0x6a, 0x10, 0x66, 0x4d, 0x71, 0xa8, 0x5c, 0x9c, 0xc3,
-	0x55, 0x00, 0x91, 0x26, 0x70, 0x1e, 0x12, 0xa9, 0xac, 0x1c, 0x5a, 0x5a, 0xce, 0x43, 0xa2, 0xbd,
-	0x1f, 0x35, 0x3c, 0xf9, 0xf7, 0x0d, 0x97, 0x2f, 0x46, 0xd8, 0xf6, 0x4b, 0x90, 0x71, 0x47, 0x43,
-	0x93, 0x0f, 0x1f, 0xaa, 0x50, 0xab, 0x3b, 0x1a, 0xb6, 0xc7, 0x41, 0xfd, 0x73, 0xc8, 0xb4, 0xc7,
-	0x38, 0x88, 0x73, 0x89, 0xfa, 0x94, 0xca, 0xe9, 0x4f, 0x4c, 0xdd, 0x59, 0x6e, 0xc0, 0x61, 0x47,
-	0x03, 0x95, 0x8f, 0x79, 0xe1, 0xdf, 0x02, 0xfe, 0xad, 0xe9, 0x2f, 0x39, 0xe2, 0xcb, 0xe1, 0xfe,
-	0xfa, 0xcf, 0x0a, 0x14, 0xa7, 0x6e, 0x92, 0xf6, 0x2e, 0x5c, 0x6a, 0xed, 0xdf, 0x3a, 0xd8, 0xdb,
-	0x35, 0x1b, 0xad, 0x5b, 0x66, 0xfb, 0xb3, 0xe6, 0x9e, 0x79, 0xf7, 0xe0, 0x93, 0x83, 0xc3, 0x4f,
-	0x0f, 0x4a, 0x0b, 0x95, 0xa5, 0x47, 0x4f, 0x6a, 0xf9, 0xbb, 0xee, 0x03, 0x97, 0x7e, 0xe1, 0xce,
-	0x43, 0x37, 0x8d, 0xbd, 0x7b, 0x87, 0xed, 0xbd, 0x92, 0x22, 0xd0, 0x4d, 0x9f, 0x1c, 0x53, 0x46,
-	0x10, 0x7d, 0x03, 0x2e, 0x9f, 0x83, 0xde, 0x39, 0x6c, 0x34, 0xf6, 0xdb, 0xa5, 0x44, 0x65, 0xf9,
-	0xd1, 0x93, 0x5a, 0xb1, 0xe9, 0x13, 0xa1, 0x32, 0x8c, 0xd0, 0xa1, 0x3c, 0x1b, 0x71, 0xd8, 0x3c,
-	0x6c, 0x6d, 0xdd, 0x29, 0xd5, 0x2a, 0xa5, 0x47, 0x4f, 0x6a, 0x85, 0xf0, 0xc9, 0xe0, 0xf8, 0x4a,
-	0xf6, 0xcb, 0xaf, 0xab, 0x0b, 0xdf, 0x7e, 0x53, 0x55, 0xb6, 0x1b, 0x4f, 0x4f, 0xab, 0xca, 0xb3,
-	0xd3, 0xaa, 0xf2, 0xfb, 0x69, 0x55, 0x79, 0xfc, 0xa2, 0xba, 0xf0, 0xec, 0x45, 0x75, 0xe1, 0x97,
-	0x17, 0xd5, 0x85, 0xfb, 0x37, 0x7b, 0x0e, 0xeb, 0x8f, 0x3a, 0x7a, 0x97, 0x0e, 0x37, 0xba, 0x74,
-	0x48, 0x58, 0xe7, 0x88, 0xc5, 0x1f, 0xe2, 0x6f, 0xe2, 0xd9, 0xbf, 0x6e, 0x9d, 0x34, 0xda, 0x6f,
-	0xfe, 0x15, 0x00, 0x00, 0xff, 0xff, 0x8c, 0xb6, 0xa1, 0x4e, 0x7b, 0x0e, 0x00, 0x00,
+	// 1334 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x57, 0xcb, 0x6f, 0x1b, 0x55,
+	0x17, 0xcf, 0xd8, 0xe3, 0xd7, 0xb1, 0x9d, 0x38, 0xb7, 0xd1, 0x57, 0xd7, 0x6d, 0x1c, 0xcb, 0xd5,
+	0xf7, 0x7d, 0xa1, 0xa0, 0x49, 0x49, 0x11, 0x82, 0x05, 0x8b, 0xbc, 0x68, 0x23, 0xea, 0xc4, 0x1a,
+	0xbb, 0x45, 0x74, 0x33, 0x1a, 0x7b, 0x6e, 0xc6, 0x43, 0xed, 0xb9, 0xa3, 0x99, 0xeb, 0xe0, 0xf4,
+	0x2f, 0x40, 0x5d, 0x75, 0x81, 0xd8, 0x75, 0x05, 0x0b, 0xf6, 0x20, 0xb1, 0x67, 0xd5, 0x65, 0x77,
+	0xb0, 0xa1, 0xa0, 0x54, 0xe2, 0x1f, 0xe0, 0x1f, 0x40, 0xf7, 0x31, 0x0f, 0xc7, 0x31, 0x94, 0x52,
+	0x81, 0xc4, 0xc6, 0xba, 0xf7, 0x9c, 0xdf, 0x39, 0xf7, 0x3c, 0x7e, 0x73, 0x7d, 0x2e, 0x5c, 0xa1,
+	0xd8, 0xb5, 0xb0, 0x3f, 0x72, 0x5c, 0xba, 0x41, 0x4f, 0x3c, 0x1c, 0x88, 0x5f, 0xcd, 0xf3, 0x09,
+	0x25, 0xa8, 0x12, 0x6b, 0x35, 0x2e, 0xaf, 0xad, 0xd8, 0xc4, 0x26, 0x5c, 0xb9, 0xc1, 0x56, 0x02,
+	0x57, 0x5b, 0xb3, 0x09, 0xb1, 0x87, 0x78, 0x83, 0xef, 0x7a, 0xe3, 0xa3, 0x0d, 0xea, 0x8c, 0x70,
+	0x40, 0xcd, 0x91, 0x27, 0x01, 0xab, 0x89, 0x63, 0xfa, 0xfe, 0x89, 0x47, 0x09, 0xc3, 0x92, 0x23,
+	0xa9, 0xae, 0x27, 0xd4, 0xc7, 0xd8, 0x0f, 0x1c, 0xe2, 0x26, 0xe3, 0xa8, 0x35, 0x66, 0xa2, 0x3c,
+	0x36, 0x87, 0x8e, 0x65, 0x52, 0xe2, 0x0b, 0x44, 0xf3, 0x5d, 0x28, 0xb7, 0x4d, 0x9f, 0x76, 0x30,
+	0xbd, 0x85, 0x4d, 0x0b, 0xfb, 0x68, 0x05, 0x32, 0x94, 0x50, 0x73, 0x58, 0x55, 0x1a, 0xca, 0x7a,
+	0x59, 0x17, 0x1b, 0x84, 0x40, 0x1d, 0x98, 0xc1, 0xa0, 0x9a, 0x6a, 0x28, 0xeb, 0x25, 0x9d, 0xaf,
+	0x9b, 0x03, 0x50, 0x99, 0x29, 0xb3, 0x70, 0x5c, 0x0b, 0x4f, 0x42, 0x0b, 0xbe, 0x61, 0xd2, 0xde,
+	0x09, 0xc5, 0x81, 0x34, 0x11, 0x1b, 0xf4, 0x16, 0x64, 0x78, 0xfc, 0xd5, 0x74, 0x43, 0x59, 0x2f,
+	0x6e, 0x56, 0xb5, 0x44, 0xa1, 0x44, 0x7e, 0x5a, 0x9b, 0xe9, 0xb7, 0xd5, 0x27, 0xcf, 0xd6, 0x16,
+	0x74, 0x01, 0x6e, 0x0e, 0x21, 0xb7, 0x3d, 0x24, 0xfd, 0xfb, 0xfb, 0xbb, 0x51, 0x20, 0x4a, 0x1c,
+	0x08, 0x6a, 0xc1, 0x92, 0x67, 0xfa, 0xd4, 0x08, 0x30, 0x35, 0x06, 0x3c, 0x0b, 0x7e, 0x68, 0x71,
+	0x73, 0x4d, 0x3b, 0xdb, 0x07, 0x6d, 0x2a, 0x59, 0x79, 0x4a, 0xd9, 0x4b, 0x0a, 0x9b, 0xbf, 0xa8,
+	0x90, 0x15, 0x4b, 0xf4, 0x1e, 0xe4, 0x64, 0x59, 0xf9, 0x81, 0xc5, 0xcd, 0xd5, 0xa4, 0x47, 0xa9,
+	0xd2, 0x76, 0x88, 0x1b, 0x60, 0x37, 0x18, 0x07, 0xd2, 0x5f, 0x68, 0x83, 0xfe, 0x07, 0xf9, 0xfe,
+	0xc0, 0x74, 0x5c, 0xc3, 0xb1, 0x78, 0x44, 0x85, 0xed, 0xe2, 0xe9, 0xb3, 0xb5, 0xdc, 0x0e, 0x93,
+	0xed, 0xef, 0xea, 0x39, 0xae, 0xdc, 0xb7, 0xd0, 0x7f, 0x20, 0x3b, 0xc0, 0x8e, 0x3d, 0xa0, 0xbc,
+	0x2c, 0x69, 0x5d, 0xee, 0xd0, 0x3b, 0xa0, 0x32, 0x42, 0x54, 0x55, 0x7e, 0x76, 0x4d, 0x13, 0x6c,
+	0xd1, 0x42, 0xb6, 0x68, 0xdd, 0x90, 0x2d, 0xdb, 0x79, 0x76, 0xf0, 0xa3, 0x9f, 0xd6, 0x14, 0x9d,
+	0x5b, 0xa0, 0x1d, 0x28, 0x0f, 0xcd, 0x80, 0x1a, 0x3d, 0x56, 0x36, 0x76, 0x7c, 0x86, 0xbb, 0xb8,
+	0x34, 0x5b, 0x10, 0x59, 0x58, 0x19, 0x7a, 0x91, 0x59, 0x09, 0x91, 0x85, 0xd6, 0xa1, 0xc2, 0x9d,
+	0xf4, 0xc9, 0x68, 0xe4, 0x50, 0x83, 0xd7, 0x3d, 0xcb, 0xeb, 0xbe, 0xc8, 0xe4, 0x3b, 0x5c, 0x7c,
+	0x8b, 0x75, 0xe0, 0x32, 0x14, 0x2c, 0x93, 0x9a, 0x02, 0x92, 0xe3, 0x90, 0x3c, 0x13, 0x70, 0xe5,
+	0xff, 0x61, 0x29, 0x62, 0x5d, 0x20, 0x20, 0x79, 0xe1, 0x25, 0x16, 0x73, 0xe0, 0x75, 0x58, 0x71,
+	0xf1, 0x84, 0x1a, 0x67, 0xd1, 0x05, 0x8e, 0x46, 0x4c, 0x77, 0x77, 0xda, 0xe2, 0xbf, 0xb0, 0xd8,
+	0x0f, 0x8b, 0x2f, 0xb0, 0xc0, 0xb1, 0xe5, 0x48, 0xca, 0x61, 0x97, 0x20, 0x6f, 0x7a, 0x9e, 0x00,
+	0x14, 0x39, 0x20, 0x67, 0x7a, 0x1e, 0x57, 0x5d, 0x83, 0x65, 0x9e, 0xa3, 0x8f, 0x83, 0xf1, 0x90,
+	0x4a, 0x27, 0x25, 0x8e, 0x59, 0x62, 0x0a, 0x5d, 0xc8, 0x39, 0xf6, 0x2a, 0x94, 0xf1, 0xb1, 0x63,
+	0x61, 0xb7, 0x8f, 0x05, 0xae, 0xcc, 0x71, 0xa5, 0x50, 0xc8, 0x41, 0xaf, 0x41, 0xc5, 0xf3, 0x89,
+	0x47, 0x02, 0xec, 0x1b, 0xa6, 0x65, 0xf9, 0x38, 0x08, 0xaa, 0x8b, 0xc2, 0x5f, 0x28, 0xdf, 0x12,
+	0xe2, 0x66, 0x15, 0xd4, 0x5d, 0x93, 0x9a, 0xa8, 0x02, 0x69, 0x3a, 0x09, 0xaa, 0x4a, 0x23, 0xbd,
+	0x5e, 0xd2, 0xd9, 0xb2, 0xf9, 0x6d, 0x1a, 0xd4, 0xbb, 0x84, 0x62, 0x74, 0x03, 0x54, 0xd6, 0x26,
+	0xce, 0xbe, 0xc5, 0xf3, 0xf8, 0xdc, 0x71, 0x6c, 0x17, 0x5b, 0xad, 0xc0, 0xee, 0x9e, 0x78, 0x58,
+	0xe7, 0xe0, 0x04, 0x9d, 0x52, 0x53, 0x74, 0x5a, 0x81, 0x8c, 0x4f, 0xc6, 0xae, 0xc5, 0x59, 0x96,
+	0xd1, 0xc5, 0x06, 0xed, 0x41, 0x3e, 0x62, 0x89, 0xfa, 0x47, 0x2c, 0x59, 0x62, 0x2c, 0x61, 0x1c,
+	0x96, 0x02, 0x3d, 0xd7, 0x93, 0x64, 0xd9, 0x86, 0x42, 0x74, 0x79, 0x55, 0x33, 0x7f, 0x82, 0xb0,
+	0xb1, 0x19, 0x7a, 0x1d, 0x96, 0xa3, 0xde, 0x47, 0xc5, 0x13, 0x8c, 0xab, 0x44, 0x0a, 0x59, 0xbd,
+	0x29, 0x5a, 0x19, 0xe2, 0x02, 0xca, 0xf1, 0xbc, 0x62, 0x5a, 0xed, 0x33, 0x29, 0xba, 0x02, 0x85,
+	0xc0, 0xb1, 0x5d, 0x93, 0x8e, 0x7d, 0x2c, 0x99, 0x17, 0x0b, 0x98, 0x16, 0x4f, 0x28, 0x76, 0xf9,
+	0x47, 0x2e, 0x98, 0x16, 0x0b, 0xd0, 0x06, 0x5c, 0x88, 0x36, 0x46, 0xec, 0x45, 0xb0, 0x0c, 0x45,
+	0xaa, 0x4e, 0xa8, 0x69, 0xfe, 0xaa, 0x40, 0x56, 0x7c, 0x18, 0x89, 0x36, 0x28, 0xe7, 0xb7, 0x21,
+	0x35, 0xaf, 0x0d, 0xe9, 0x97, 0x6f, 0xc3, 0x16, 0x40, 0x14, 0x66, 0x50, 0x55, 0x1b, 0xe9, 0xf5,
+	0xe2, 0xe6, 0xe5, 0x59, 0x47, 0x22, 0xc4, 0x8e, 0x63, 0xcb, 0xef, 0x3e, 0x61, 0x84, 0xde, 0x84,
+	0x15, 0xd3, 0xb6, 0x7d, 0x6c, 0x9b, 0x14, 0x5b, 0x89, 0xa4, 0x33, 0x3c, 0xe9, 0x0b, 0xb1, 0x2e,
+	0xce, 0xfa, 0x47, 0x05, 0x0a, 0x91, 0x4b, 0xb4, 0x05, 0xe5, 0x30, 0x15, 0xe3, 0x68, 0x68, 0xda,
+	0x92, 0xbd, 0xab, 0x73, 0xf3, 0x79, 0x7f, 0x68, 0xda, 0x7a, 0x51, 0xa6, 0xc0, 0x36, 0xe7, 0x33,
+	0x21, 0x35, 0x87, 0x09, 0x53, 0xd4, 0x4b, 0xbf, 0x1c, 0xf5, 0xa6, 0x48, 0xa2, 0x9e, 0x21, 0x49,
+	0xf3, 0xb3, 0x14, 0x2c, 0xee, 0x4d, 0x78, 0xf8, 0xd6, 0x3f, 0xd9, 0xdd, 0x7b, 0x92, 0x8e, 0x56,
+	0xb2, 0x31, 0x61, 0x9b, 0xaf, 0xce, 0x7a, 0x9c, 0x8e, 0x39, 0x6e, 0x37, 0x0a, 0xbd, 0x74, 0xfe,
+	0x52, 0xdb, 0xbf, 0x49, 0xc1, 0xf2, 0xcc, 0x11, 0xff, 0xbe, 0xf6, 0x4f, 0xdf, 0x11, 0x99, 0x17,
+	0xbc, 0x23, 0xb2, 0x73, 0xef, 0x88, 0xaf, 0x53, 0x90, 0x6f, 0xf3, 0xff, 0x02, 0x73, 0xf8, 0x77,
+	0xdc, 0xf0, 0x97, 0xa1, 0xe0, 0x91, 0xa1, 0x21, 0x34, 0x2a, 0xd7, 0xe4, 0x3d, 0x32, 0xd4, 0x67,
+	0x98, 0x99, 0x79, 0x45, 0xd7, 0x7f, 0xf6, 0x15, 0x34, 0x21, 0x77, 0xf6, 0x1b, 0xf4, 0xa1, 0x24,
+	0x4a, 0x21, 0x67, 0xb3, 0xeb, 0xac, 0x06, 0x6c, 0x55, 0x55, 0x66, 0x67, 0x49, 0x11, 0xb6, 0x40,
+	0xea, 0xd9, 0x41, 0x64, 0x21, 0x46, 0x99, 0x6a, 0x6a, 0x9e, 0x85, 0x60, 0xb1, 0x2e, 0x71, 0xcd,
+	0xcf, 0x15, 0x80, 0xdb, 0xac, 0xb2, 0x3c, 0x5f, 0x36, 0x55, 0x05, 0x3c, 0x04, 0x63, 0xea, 0xe4,
+	0xfa, 0xbc, 0xa6, 0xc9, 0xf3, 0x4b, 0x41, 0x32, 0xee, 0x1d, 0x28, 0xc7, 0xdc, 0x0e, 0x70, 0x18,
+	0xcc, 0x39, 0x4e, 0xa2, 0x61, 0xa7, 0x83, 0xa9, 0x5e, 0x3a, 0x4e, 0xec, 0x9a, 0xdf, 0x29, 0x50,
+	0xe0, 0x31, 0xb5, 0x30, 0x35, 0xa7, 0x7a, 0xa8, 0xbc, 0x7c, 0x0f, 0x57, 0x01, 0x84, 0x9b, 0xc0,
+	0x79, 0x80, 0x25, 0xb3, 0x0a, 0x5c, 0xd2, 0x71, 0x1e, 0x60, 0xf4, 0x76, 0x54, 0xf0, 0xf4, 0xef,
+	0x17, 0x5c, 0x5e, 0x32, 0x61, 0xd9, 0x2f, 0x42, 0xce, 0x1d, 0x8f, 0x0c, 0x36, 0xe2, 0xa8, 0x82,
+	0xad, 0xee, 0x78, 0xd4, 0x9d, 0x04, 0xcd, 0x8f, 0x21, 0xd7, 0x9d, 0xf0, 0x71, 0x9f, 0x51, 0xd4,
+	0x27, 0x44, 0xce, 0x98, 0x62, 0xb6, 0xcf, 0x33, 0x01, 0x1f, 0xa9, 0x10, 0xa8, 0x6c, 0x98, 0x0c,
+	0x1f, 0x1f, 0x6c, 0x8d, 0xb4, 0x17, 0x7c, 0x48, 0xc8, 0x27, 0xc4, 0xb5, 0xef, 0x15, 0x28, 0x4f,
+	0x7d, 0x49, 0xe8, 0x0d, 0xb8, 0xd8, 0xd9, 0xbf, 0x79, 0xb0, 0xb7, 0x6b, 0xb4, 0x3a, 0x37, 0x8d,
+	0xee, 0x47, 0xed, 0x3d, 0xe3, 0xce, 0xc1, 0x07, 0x07, 0x87, 0x1f, 0x1e, 0x54, 0x16, 0x6a, 0x4b,
+	0x0f, 0x1f, 0x37, 0x8a, 0x77, 0xdc, 0xfb, 0x2e, 0xf9, 0xc4, 0x9d, 0x87, 0x6e, 0xeb, 0x7b, 0x77,
+	0x0f, 0xbb, 0x7b, 0x15, 0x45, 0xa0, 0xdb, 0x3e, 0x3e, 0x26, 0x14, 0x73, 0xf4, 0x75, 0xb8, 0x74,
+	0x0e, 0x7a, 0xe7, 0xb0, 0xd5, 0xda, 0xef, 0x56, 0x52, 0xb5, 0xe5, 0x87, 0x8f, 0x1b, 0xe5, 0xb6,
+	0x8f, 0x05, 0xcb, 0xb8, 0x85, 0x06, 0xd5, 0x59, 0x8b, 0xc3, 0xf6, 0x61, 0x67, 0xeb, 0x76, 0xa5,
+	0x51, 0xab, 0x3c, 0x7c, 0xdc, 0x28, 0x85, 0x57, 0x06, 0xc3, 0xd7, 0xf2, 0x9f, 0x7e, 0x51, 0x5f,
+	0xf8, 0xea, 0xcb, 0xba, 0xb2, 0xdd, 0x7a, 0x72, 0x5a, 0x57, 0x9e, 0x9e, 0xd6, 0x95, 0x9f, 0x4f,
+	0xeb, 0xca, 0xa3, 0xe7, 0xf5, 0x85, 0xa7, 0xcf, 0xeb, 0x0b, 0x3f, 0x3c, 0xaf, 0x2f, 0xdc, 0xbb,
+	0x61, 0x3b, 0x74, 0x30, 0xee, 0x69, 0x7d, 0x32, 0xda, 0xe8, 0x93, 0x11, 0xa6, 0xbd, 0x23, 0x1a,
+	0x2f, 0xc4, 0x63, 0xf4, 0xec, 0x03, 0xb1, 0x97, 0xe5, 0xf2, 0x1b, 0xbf, 0x0d, 0x00, 0x66, 0xdb,
+	0xa2, 0x28, 0xe1, 0x0e, 0x00, 0x00,
 }
 
 func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
@@ -1670,6 +1693,13 @@ func (m *Commit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.AggregatedSignature) > 0 {
+		i -= len(m.AggregatedSignature)
+		copy(dAtA[i:], m.AggregatedSignature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.AggregatedSignature)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.Signatures) > 0 {
 		for iNdEx := len(m.Signatures) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1777,6 +1807,13 @@ func (m *ExtendedCommit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.AggregatedSignature) > 0 {
+		i -= len(m.AggregatedSignature)
+		copy(dAtA[i:], m.AggregatedSignature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.AggregatedSignature)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.ExtendedSignatures) > 0 {
 		for iNdEx := len(m.ExtendedSignatures) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -2336,6 +2373,10 @@ func (m *Commit) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	l = len(m.AggregatedSignature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -2381,6 +2422,10 @@ func (m *ExtendedCommit) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	l = len(m.AggregatedSignature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -3929,6 +3974,40 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregatedSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AggregatedSignature = append(m.AggregatedSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.AggregatedSignature == nil {
+				m.AggregatedSignature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -4254,6 +4333,40 @@ func (m *ExtendedCommit) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregatedSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AggregatedSignature = append(m.AggregatedSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.AggregatedSignature == nil {
+				m.AggregatedSignature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -0,0 +1,202 @@
+// Package auth authenticates the clients of the RPC and gRPC servers with API
+// keys, or JWTs, each giving access to some methods.
+//
+// The keys are listed in a JSON file, by the hex encoded SHA-256 hashes of
+// the keys, with the methods, or groups of methods, they give access to. The
+// file also lists the issuers of the JWTs accepted, with their verification
+// key and the methods their tokens may give access to:
+//
+//	{
+//	  "keys": [
+//	    {"name": "explorer", "key_sha256": "9f86d081...", "methods": ["read"]},
+//	    {"name": "wallet", "key_sha256": "60303ae2...", "methods": ["read", "broadcast_tx_sync"]},
+//	    {"name": "operator", "key_sha256": "fd61a03a...", "methods": ["*"]}
+//	  ],
+//	  "jwt_issuers": [
+//	    {"name": "https://auth.example.com", "algorithm": "EdDSA", "key": "11qYAYKx...", "methods": ["read", "broadcast"]}
+//	  ]
+//	}
+//
+// The clients send their key, or token, in the "Authorization: Bearer <key>"
+// header of their HTTP requests, or of the handshake of their WebSocket
+// connection, and in the "authorization" metadata of their gRPC calls.
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+)
+
+// The groups of methods a key can give access to.
+const (
+	// GroupRead is the group of the methods only reading the state of the
+	// node.
+	GroupRead = "read"
+	// GroupBroadcast is the group of the methods broadcasting transactions
+	// and evidence.
+	GroupBroadcast = "broadcast"
+	// GroupAll gives access to all the methods.
+	GroupAll = "*"
+)
+
+// Key is an API key of the keys file.
+type Key struct {
+	// Name of the key, e.g. the client it was issued to.
+	Name string `json:"name"`
+	// The hex encoded SHA-256 hash of the key.
+	KeySHA256 string `json:"key_sha256"`
+	// The methods and the groups of methods the key gives access to.
+	Methods []string `json:"methods"`
+}
+
+// KeysFile is the content of the keys file.
+type KeysFile struct {
+	// The API keys.
+	Keys []Key `json:"keys"`
+	// The issuers of the JWTs accepted.
+	JWTIssuers []JWTIssuer `json:"jwt_issuers"`
+}
+
+// LoadKeysFile reads the API keys and the JWT issuers of the given JSON file.
+func LoadKeysFile(file string) (*KeysFile, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the API keys: %w", err)
+	}
+	var kf KeysFile
+	if err := json.Unmarshal(content, &kf); err != nil {
+		return nil, fmt.Errorf("failed to parse the API keys: %w", err)
+	}
+	return &kf, nil
+}
+
+// keyACL is the access of a key.
+type keyACL struct {
+	name    string
+	methods map[string]struct{} // methods and groups
+}
+
+func newKeyACL(name string, methods []string) *keyACL {
+	acl := &keyACL{name: name, methods: make(map[string]struct{}, len(methods))}
+	for _, method := range methods {
+		acl.methods[method] = struct{}{}
+	}
+	return acl
+}
+
+func (acl *keyACL) allows(method, group string) bool {
+	if _, ok := acl.methods[GroupAll]; ok {
+		return true
+	}
+	if _, ok := acl.methods[method]; ok {
+		return true
+	}
+	if group == "" {
+		return false
+	}
+	_, ok := acl.methods[group]
+	return ok
+}
+
+// APIKeys authorizes the requests with an API key, or a JWT, giving access to
+// the method. It implements rpcserver.Authorizer.
+type APIKeys struct {
+	keys      map[[sha256.Size]byte]*keyACL
+	issuers   map[string]*jwtIssuer
+	anonymous map[string]struct{}
+	groupOf   func(method string) string
+	now       func() time.Time
+}
+
+var _ rpcserver.Authorizer = (*APIKeys)(nil)
+
+// NewAPIKeys returns an authorizer of the requests with the keys and the JWTs
+// of the issuers of the keys file. groupOf returns the group of a method, or
+// an empty string if the method is in no group. The anonymous methods are
+// callable without a key.
+func NewAPIKeys(kf *KeysFile, groupOf func(method string) string, anonymousMethods []string) (*APIKeys, error) {
+	keys := kf.Keys
+	a := &APIKeys{
+		keys:      make(map[[sha256.Size]byte]*keyACL, len(keys)),
+		issuers:   make(map[string]*jwtIssuer, len(kf.JWTIssuers)),
+		anonymous: make(map[string]struct{}, len(anonymousMethods)),
+		groupOf:   groupOf,
+		now:       time.Now,
+	}
+	for i, key := range keys {
+		hash, err := hex.DecodeString(key.KeySHA256)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("key %d (%s): key_sha256 must be a hex encoded SHA-256 hash", i, key.Name)
+		}
+		if len(key.Methods) == 0 {
+			return nil, fmt.Errorf("key %d (%s): no methods", i, key.Name)
+		}
+		var h [sha256.Size]byte
+		copy(h[:], hash)
+		if _, ok := a.keys[h]; ok {
+			return nil, fmt.Errorf("key %d (%s): duplicate key", i, key.Name)
+		}
+		a.keys[h] = newKeyACL(key.Name, key.Methods)
+	}
+	for i, issuer := range kf.JWTIssuers {
+		ji, err := newJWTIssuer(issuer)
+		if err != nil {
+			return nil, fmt.Errorf("JWT issuer %d (%s): %w", i, issuer.Name, err)
+		}
+		if _, ok := a.issuers[issuer.Name]; ok {
+			return nil, fmt.Errorf("JWT issuer %d (%s): duplicate issuer", i, issuer.Name)
+		}
+		a.issuers[issuer.Name] = ji
+	}
+	for _, method := range anonymousMethods {
+		a.anonymous[method] = struct{}{}
+	}
+	return a, nil
+}
+
+// AuthorizeKey returns nil if the key, or JWT, empty if none was sent, gives
+// access to the method, rpcserver.ErrUnauthenticated if the key is required
+// and missing, unknown, or an invalid or expired JWT, or
+// rpcserver.ErrForbidden.
+func (a *APIKeys) AuthorizeKey(key, method string) error {
+	if _, ok := a.anonymous[method]; ok {
+		return nil
+	}
+	if key == "" {
+		return rpcserver.ErrUnauthenticated
+	}
+	acl, ok := a.keys[sha256.Sum256([]byte(key))]
+	if !ok {
+		if len(a.issuers) > 0 && strings.Count(key, ".") == 2 {
+			return a.authorizeJWT(key, method)
+		}
+		return rpcserver.ErrUnauthenticated
+	}
+	if !acl.allows(method, a.groupOf(method)) {
+		return fmt.Errorf("%w: key %s, method %s", rpcserver.ErrForbidden, acl.name, method)
+	}
+	return nil
+}
+
+// Authorize implements rpcserver.Authorizer.
+func (a *APIKeys) Authorize(r *http.Request, method string) error {
+	return a.AuthorizeKey(bearerKey(r.Header.Get("Authorization")), method)
+}
+
+// bearerKey returns the key of an "Authorization: Bearer <key>" header, or an
+// empty string.
+func bearerKey(header string) string {
+	scheme, key, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(key)
+}
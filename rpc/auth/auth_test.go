@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+)
+
+func keyHash(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func testGroupOf(method string) string {
+	switch method {
+	case "broadcast_tx_sync", "broadcast_tx_commit":
+		return GroupBroadcast
+	default:
+		return GroupRead
+	}
+}
+
+func TestAPIKeys(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "api_keys.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"keys": [
+		{"name": "explorer", "key_sha256": "`+keyHash("explorer-key")+`", "methods": ["read"]},
+		{"name": "wallet", "key_sha256": "`+keyHash("wallet-key")+`", "methods": ["read", "broadcast_tx_sync"]},
+		{"name": "operator", "key_sha256": "`+keyHash("operator-key")+`", "methods": ["*"]}
+	]}`), 0o600))
+	keysFile, err := LoadKeysFile(file)
+	require.NoError(t, err)
+	require.Len(t, keysFile.Keys, 3)
+
+	apiKeys, err := NewAPIKeys(keysFile, testGroupOf, []string{"health"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		key, method string
+		err         error
+	}{
+		{"", "health", nil},
+		{"", "status", rpcserver.ErrUnauthenticated},
+		{"unknown-key", "status", rpcserver.ErrUnauthenticated},
+		{"explorer-key", "status", nil},
+		{"explorer-key", "broadcast_tx_sync", rpcserver.ErrForbidden},
+		{"wallet-key", "broadcast_tx_sync", nil},
+		{"wallet-key", "broadcast_tx_commit", rpcserver.ErrForbidden},
+		{"operator-key", "broadcast_tx_commit", nil},
+	}
+	for _, tc := range testCases {
+		err := apiKeys.AuthorizeKey(tc.key, tc.method)
+		if tc.err == nil {
+			assert.NoError(t, err, "%s calling %s", tc.key, tc.method)
+		} else {
+			assert.ErrorIs(t, err, tc.err, "%s calling %s", tc.key, tc.method)
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/status", nil)
+	require.NoError(t, err)
+	require.ErrorIs(t, apiKeys.Authorize(req, "status"), rpcserver.ErrUnauthenticated)
+	req.Header.Set("Authorization", "Bearer explorer-key")
+	require.NoError(t, apiKeys.Authorize(req, "status"))
+	req.Header.Set("Authorization", "Basic explorer-key")
+	require.ErrorIs(t, apiKeys.Authorize(req, "status"), rpcserver.ErrUnauthenticated)
+
+	_, err = NewAPIKeys(&KeysFile{Keys: []Key{{Name: "invalid", KeySHA256: "abcd", Methods: []string{"*"}}}}, testGroupOf, nil)
+	require.Error(t, err)
+	_, err = NewAPIKeys(&KeysFile{Keys: []Key{{Name: "none", KeySHA256: keyHash("key")}}}, testGroupOf, nil)
+	require.Error(t, err)
+	_, err = NewAPIKeys(&KeysFile{Keys: append(keysFile.Keys, keysFile.Keys[0])}, testGroupOf, nil)
+	require.Error(t, err)
+}
+
+func TestAPIKeysGRPC(t *testing.T) {
+	apiKeys, err := NewAPIKeys(&KeysFile{Keys: []Key{
+		{Name: "explorer", KeySHA256: keyHash("explorer-key"), Methods: []string{"read"}},
+	}}, func(string) string { return GroupRead }, nil)
+	require.NoError(t, err)
+
+	interceptor := apiKeys.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/tendermint.services.block.v1.BlockService/GetLatest"}
+	handler := func(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+
+	_, err = interceptor(context.Background(), nil, info, handler)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer explorer-key"))
+	res, err := interceptor(ctx, nil, info, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", res)
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+)
+
+// authorizeGRPC authorizes the call of the gRPC method, with the key in the
+// "authorization" metadata of the context.
+func (a *APIKeys) authorizeGRPC(ctx context.Context, method string) error {
+	var key string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			key = bearerKey(values[0])
+		}
+	}
+	err := a.AuthorizeKey(key, method)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, rpcserver.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+}
+
+// UnaryServerInterceptor rejects the unary calls not authorized. The methods
+// are the full names of the gRPC methods, e.g.
+// "/tendermint.services.block.v1.BlockService/GetByHeight".
+func (a *APIKeys) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.authorizeGRPC(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects the streaming calls not authorized.
+func (a *APIKeys) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorizeGRPC(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+)
+
+// The signature algorithms of the JWTs.
+const (
+	// JWTAlgorithmHS256 is HMAC with SHA-256, with a secret shared with the
+	// issuer.
+	JWTAlgorithmHS256 = "HS256"
+	// JWTAlgorithmEdDSA is Ed25519, with the public key of the issuer.
+	JWTAlgorithmEdDSA = "EdDSA"
+)
+
+// minHS256SecretSize is the minimum size of the HS256 secrets, the size of
+// the hash (RFC 7518, section 3.2).
+const minHS256SecretSize = sha256.Size
+
+// JWTIssuer is an issuer of JWTs (RFC 7519) of the keys file. The tokens
+// give access to the methods, or groups of methods, of their "methods"
+// claim, among the ones of the issuer. They must be signed with the
+// algorithm of the issuer, and have the name of the issuer as their "iss"
+// claim and an expiration time, "exp". Their "sub" claim, if any, names the
+// client in the errors.
+type JWTIssuer struct {
+	// Name of the issuer, as in the "iss" claim of its tokens.
+	Name string `json:"name"`
+	// The signature algorithm of the tokens, HS256 or EdDSA.
+	Algorithm string `json:"algorithm"`
+	// The base64 encoded secret of HS256, of at least 32 bytes, or Ed25519
+	// public key of EdDSA.
+	Key string `json:"key"`
+	// The methods and the groups of methods the tokens of the issuer may give
+	// access to.
+	Methods []string `json:"methods"`
+}
+
+// jwtIssuer verifies the tokens of an issuer.
+type jwtIssuer struct {
+	algorithm string
+	verify    func(signingInput, signature []byte) bool
+	acl       *keyACL
+}
+
+func newJWTIssuer(issuer JWTIssuer) (*jwtIssuer, error) {
+	if issuer.Name == "" {
+		return nil, errors.New("no name")
+	}
+	if len(issuer.Methods) == 0 {
+		return nil, errors.New("no methods")
+	}
+	key, err := base64.StdEncoding.DecodeString(issuer.Key)
+	if err != nil {
+		return nil, fmt.Errorf("key must be base64 encoded: %w", err)
+	}
+	ji := &jwtIssuer{algorithm: issuer.Algorithm, acl: newKeyACL(issuer.Name, issuer.Methods)}
+	switch issuer.Algorithm {
+	case JWTAlgorithmHS256:
+		if len(key) < minHS256SecretSize {
+			return nil, fmt.Errorf("HS256 secret must have at least %d bytes, got %d", minHS256SecretSize, len(key))
+		}
+		ji.verify = func(signingInput, signature []byte) bool {
+			mac := hmac.New(sha256.New, key)
+			mac.Write(signingInput)
+			return hmac.Equal(mac.Sum(nil), signature)
+		}
+	case JWTAlgorithmEdDSA:
+		if len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("EdDSA key must be an Ed25519 public key of %d bytes, got %d", ed25519.PublicKeySize, len(key))
+		}
+		ji.verify = func(signingInput, signature []byte) bool {
+			return ed25519.Verify(ed25519.PublicKey(key), signingInput, signature)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q, must be %s or %s", issuer.Algorithm, JWTAlgorithmHS256, JWTAlgorithmEdDSA)
+	}
+	return ji, nil
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+}
+
+type jwtClaims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	Expiry    *float64 `json:"exp"`
+	NotBefore *float64 `json:"nbf"`
+	Methods   []string `json:"methods"`
+}
+
+// authorizeJWT authorizes the call of the method with the token, in the
+// compact serialization of a signed JWT.
+func (a *APIKeys) authorizeJWT(token, method string) error {
+	parts := strings.Split(token, ".")
+	var (
+		header jwtHeader
+		claims jwtClaims
+	)
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return fmt.Errorf("%w: invalid JWT header: %v", rpcserver.ErrUnauthenticated, err)
+	}
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return fmt.Errorf("%w: invalid JWT claims: %v", rpcserver.ErrUnauthenticated, err)
+	}
+	issuer, ok := a.issuers[claims.Issuer]
+	if !ok {
+		return fmt.Errorf("%w: unknown JWT issuer %q", rpcserver.ErrUnauthenticated, claims.Issuer)
+	}
+	// The algorithm is the one of the issuer, not the one the token claims,
+	// so that the token cannot downgrade it, e.g. to "none".
+	if header.Algorithm != issuer.algorithm {
+		return fmt.Errorf("%w: JWT algorithm %q, expected %q", rpcserver.ErrUnauthenticated, header.Algorithm, issuer.algorithm)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !issuer.verify([]byte(parts[0]+"."+parts[1]), signature) {
+		return fmt.Errorf("%w: invalid JWT signature", rpcserver.ErrUnauthenticated)
+	}
+	now := float64(a.now().Unix())
+	if claims.Expiry == nil || now >= *claims.Expiry {
+		return fmt.Errorf("%w: JWT expired or without expiration time", rpcserver.ErrUnauthenticated)
+	}
+	if claims.NotBefore != nil && now < *claims.NotBefore {
+		return fmt.Errorf("%w: JWT not valid yet", rpcserver.ErrUnauthenticated)
+	}
+
+	group := a.groupOf(method)
+	if !newKeyACL(claims.Subject, claims.Methods).allows(method, group) || !issuer.acl.allows(method, group) {
+		return fmt.Errorf("%w: JWT of %s for %s, method %s", rpcserver.ErrForbidden, claims.Issuer, claims.Subject, method)
+	}
+	return nil
+}
+
+func decodeJWTSegment(segment string, v interface{}) error {
+	bz, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bz, v)
+}
@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+)
+
+func encodeJWT(t *testing.T, alg string, claims map[string]interface{}, sign func([]byte) []byte) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signingInput)))
+}
+
+func TestAPIKeysJWT(t *testing.T) {
+	secret := []byte(strings.Repeat("s", 32))
+	signHS256 := func(signingInput []byte) []byte {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(signingInput)
+		return mac.Sum(nil)
+	}
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signEdDSA := func(signingInput []byte) []byte {
+		return ed25519.Sign(privKey, signingInput)
+	}
+
+	apiKeys, err := NewAPIKeys(&KeysFile{
+		Keys: []Key{{Name: "explorer", KeySHA256: keyHash("explorer-key"), Methods: []string{"read"}}},
+		JWTIssuers: []JWTIssuer{
+			{Name: "hmac-issuer", Algorithm: JWTAlgorithmHS256, Key: base64.StdEncoding.EncodeToString(secret), Methods: []string{"read", "broadcast"}},
+			{Name: "ed25519-issuer", Algorithm: JWTAlgorithmEdDSA, Key: base64.StdEncoding.EncodeToString(pubKey), Methods: []string{"*"}},
+		},
+	}, func(method string) string {
+		if method == "dial_peers" {
+			return ""
+		}
+		return testGroupOf(method)
+	}, nil)
+	require.NoError(t, err)
+	now := time.Unix(1_700_000_000, 0)
+	apiKeys.now = func() time.Time { return now }
+
+	claims := func(issuer string, methods ...string) map[string]interface{} {
+		return map[string]interface{}{
+			"iss": issuer, "sub": "client", "exp": now.Add(time.Minute).Unix(), "methods": methods,
+		}
+	}
+	withClaim := func(c map[string]interface{}, key string, value interface{}) map[string]interface{} {
+		c[key] = value
+		return c
+	}
+
+	testCases := []struct {
+		name, token, method string
+		err                 error
+	}{
+		{"HS256", encodeJWT(t, "HS256", claims("hmac-issuer", "read"), signHS256), "status", nil},
+		{"EdDSA", encodeJWT(t, "EdDSA", claims("ed25519-issuer", "broadcast"), signEdDSA), "broadcast_tx_sync", nil},
+		{"API key", "explorer-key", "status", nil},
+		{"method not in token", encodeJWT(t, "HS256", claims("hmac-issuer", "read"), signHS256), "broadcast_tx_sync", rpcserver.ErrForbidden},
+		{"method not in issuer", encodeJWT(t, "HS256", claims("hmac-issuer", "*"), signHS256), "dial_peers", rpcserver.ErrForbidden},
+		{"no methods", encodeJWT(t, "HS256", claims("hmac-issuer"), signHS256), "status", rpcserver.ErrForbidden},
+		{"unknown issuer", encodeJWT(t, "HS256", claims("other-issuer", "read"), signHS256), "status", rpcserver.ErrUnauthenticated},
+		{"wrong key", encodeJWT(t, "EdDSA", claims("hmac-issuer", "read"), signEdDSA), "status", rpcserver.ErrUnauthenticated},
+		{"algorithm of another issuer", encodeJWT(t, "HS256", claims("ed25519-issuer", "read"), signHS256), "status", rpcserver.ErrUnauthenticated},
+		{"none algorithm", encodeJWT(t, "none", claims("hmac-issuer", "read"), func([]byte) []byte { return nil }), "status", rpcserver.ErrUnauthenticated},
+		{"expired", encodeJWT(t, "HS256", withClaim(claims("hmac-issuer", "read"), "exp", now.Unix()), signHS256), "status", rpcserver.ErrUnauthenticated},
+		{"no expiration", encodeJWT(t, "HS256", withClaim(claims("hmac-issuer", "read"), "exp", nil), signHS256), "status", rpcserver.ErrUnauthenticated},
+		{"not valid yet", encodeJWT(t, "HS256", withClaim(claims("hmac-issuer", "read"), "nbf", now.Add(time.Second).Unix()), signHS256), "status", rpcserver.ErrUnauthenticated},
+		{"malformed", "a.b.c", "status", rpcserver.ErrUnauthenticated},
+	}
+	for _, tc := range testCases {
+		err := apiKeys.AuthorizeKey(tc.token, tc.method)
+		if tc.err == nil {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.ErrorIs(t, err, tc.err, tc.name)
+		}
+	}
+
+	// A tampered token does not verify.
+	token := encodeJWT(t, "HS256", claims("hmac-issuer", "read"), signHS256)
+	parts := strings.Split(token, ".")
+	payload, err := json.Marshal(claims("hmac-issuer", "*"))
+	require.NoError(t, err)
+	tampered := parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + parts[2]
+	require.ErrorIs(t, apiKeys.AuthorizeKey(tampered, "broadcast_tx_sync"), rpcserver.ErrUnauthenticated)
+
+	for _, issuer := range []JWTIssuer{
+		{Name: "short-secret", Algorithm: JWTAlgorithmHS256, Key: base64.StdEncoding.EncodeToString(secret[:31]), Methods: []string{"*"}},
+		{Name: "invalid-key", Algorithm: JWTAlgorithmEdDSA, Key: base64.StdEncoding.EncodeToString(secret[:31]), Methods: []string{"*"}},
+		{Name: "none", Algorithm: "none", Key: "", Methods: []string{"*"}},
+		{Name: "no-methods", Algorithm: JWTAlgorithmHS256, Key: base64.StdEncoding.EncodeToString(secret)},
+	} {
+		_, err := NewAPIKeys(&KeysFile{JWTIssuers: []JWTIssuer{issuer}}, testGroupOf, nil)
+		require.Error(t, err, issuer.Name)
+	}
+}
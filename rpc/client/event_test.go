@@ -98,6 +98,43 @@ func TestBlockEvents(t *testing.T) {
 	}
 }
 
+// subscribe to new blocks from the first height, and make sure the past blocks
+// are replayed before the new ones, without gaps
+func TestBlockEventsFromHeight(t *testing.T) {
+	c := getHTTPClient()
+	require.NoError(t, c.Start())
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	require.NoError(t, client.WaitForHeight(c, 3, nil))
+
+	status, err := c.Status(context.Background())
+	require.NoError(t, err)
+	fromHeight := status.SyncInfo.LatestBlockHeight - 2
+
+	query := types.QueryForEvent(types.EventNewBlock).String()
+	eventCh, err := c.SubscribeFromHeight(context.Background(), query, fromHeight, 100)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := c.UnsubscribeAll(context.Background(), "TestBlockEventsFromHeight"); err != nil {
+			t.Error(err)
+		}
+	})
+
+	for height := fromHeight; height < fromHeight+10; height++ {
+		select {
+		case event := <-eventCh:
+			blockEvent, ok := event.Data.(types.EventDataNewBlock)
+			require.True(t, ok)
+			require.Equal(t, height, blockEvent.Block.Height)
+		case <-time.After(waitForEventTimeout):
+			require.FailNow(t, "timed out waiting for a block event", "height", height)
+		}
+	}
+}
+
 func TestTxEventsSentWithBroadcastTxAsync(t *testing.T) { testTxEventsSent(t, "async") }
 func TestTxEventsSentWithBroadcastTxSync(t *testing.T)  { testTxEventsSent(t, "sync") }
 
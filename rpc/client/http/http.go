@@ -289,12 +289,23 @@ func (c *baseRPCClient) broadcastTX(
 func (c *baseRPCClient) UnconfirmedTxs(
 	ctx context.Context,
 	limit *int,
+) (*ctypes.ResultUnconfirmedTxs, error) {
+	return c.UnconfirmedTxsWithQuery(ctx, "", limit)
+}
+
+func (c *baseRPCClient) UnconfirmedTxsWithQuery(
+	ctx context.Context,
+	query string,
+	limit *int,
 ) (*ctypes.ResultUnconfirmedTxs, error) {
 	result := new(ctypes.ResultUnconfirmedTxs)
 	params := make(map[string]interface{})
 	if limit != nil {
 		params["limit"] = limit
 	}
+	if query != "" {
+		params["query"] = query
+	}
 	_, err := c.caller.Call(ctx, "unconfirmed_txs", params, result)
 	if err != nil {
 		return nil, err
@@ -433,12 +444,26 @@ func (c *baseRPCClient) BlockByHash(ctx context.Context, hash []byte) (*ctypes.R
 func (c *baseRPCClient) BlockResults(
 	ctx context.Context,
 	height *int64,
+) (*ctypes.ResultBlockResults, error) {
+	return c.BlockResultsWithOptions(ctx, height, rpcclient.BlockResultsOptions{})
+}
+
+func (c *baseRPCClient) BlockResultsWithOptions(
+	ctx context.Context,
+	height *int64,
+	opts rpcclient.BlockResultsOptions,
 ) (*ctypes.ResultBlockResults, error) {
 	result := new(ctypes.ResultBlockResults)
 	params := make(map[string]interface{})
 	if height != nil {
 		params["height"] = height
 	}
+	if len(opts.EventTypes) > 0 {
+		params["event_types"] = opts.EventTypes
+	}
+	if opts.OmitTxResults {
+		params["omit_tx_results"] = true
+	}
 	_, err := c.caller.Call(ctx, "block_results", params, result)
 	if err != nil {
 		return nil, err
@@ -501,7 +526,7 @@ func (c *baseRPCClient) TxSearch(
 	ctx context.Context,
 	query string,
 	prove bool,
-	page,
+	cursor string,
 	perPage *int,
 	orderBy string,
 ) (*ctypes.ResultTxSearch, error) {
@@ -512,8 +537,8 @@ func (c *baseRPCClient) TxSearch(
 		"order_by": orderBy,
 	}
 
-	if page != nil {
-		params["page"] = page
+	if cursor != "" {
+		params["cursor"] = cursor
 	}
 	if perPage != nil {
 		params["per_page"] = perPage
@@ -663,6 +688,35 @@ func (w *WSEvents) Subscribe(ctx context.Context, _, query string,
 		return nil, err
 	}
 
+	return w.addSubscription(query, outCapacity...), nil
+}
+
+// SubscribeFromHeight subscribes to the query like Subscribe, with the
+// matching events of the blocks from the given height on sent first. The
+// events are not replayed again if the connection is reestablished.
+//
+// It returns an error if WSEvents is not running.
+func (w *WSEvents) SubscribeFromHeight(ctx context.Context, query string, fromHeight int64,
+	outCapacity ...int,
+) (out <-chan ctypes.ResultEvent, err error) {
+	if !w.IsRunning() {
+		return nil, errNotRunning
+	}
+
+	// The replayed events are sent right away: the output channel must be
+	// registered first.
+	outc := w.addSubscription(query, outCapacity...)
+	if err := w.ws.SubscribeFromHeight(ctx, query, fromHeight); err != nil {
+		w.mtx.Lock()
+		delete(w.subscriptions, query)
+		w.mtx.Unlock()
+		return nil, err
+	}
+
+	return outc, nil
+}
+
+func (w *WSEvents) addSubscription(query string, outCapacity ...int) chan ctypes.ResultEvent {
 	outCap := 1
 	if len(outCapacity) > 0 {
 		outCap = outCapacity[0]
@@ -675,7 +729,7 @@ func (w *WSEvents) Subscribe(ctx context.Context, _, query string,
 	w.subscriptions[query] = outc
 	w.mtx.Unlock()
 
-	return outc, nil
+	return outc
 }
 
 // Unsubscribe implements EventsClient by using WSClient to unsubscribe given
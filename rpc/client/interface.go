@@ -67,6 +67,8 @@ type SignClient interface {
 	Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error)
 	BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlock, error)
 	BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error)
+	BlockResultsWithOptions(ctx context.Context, height *int64,
+		opts BlockResultsOptions) (*ctypes.ResultBlockResults, error)
 	Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error)
 	HeaderByHash(ctx context.Context, hash bytes.HexBytes) (*ctypes.ResultHeader, error)
 	Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error)
@@ -74,12 +76,14 @@ type SignClient interface {
 	Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
 
 	// TxSearch defines a method to search for a paginated set of transactions by
-	// transaction event search criteria.
+	// transaction event search criteria. The cursor of the next page is
+	// returned with each page, the first page is returned for an empty cursor.
 	TxSearch(
 		ctx context.Context,
 		query string,
 		prove bool,
-		page, perPage *int,
+		cursor string,
+		perPage *int,
 		orderBy string,
 	) (*ctypes.ResultTxSearch, error)
 
@@ -135,6 +139,10 @@ type EventsClient interface {
 // MempoolClient shows us data about current mempool state.
 type MempoolClient interface {
 	UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error)
+	// UnconfirmedTxsWithQuery returns the unconfirmed txs matching the query,
+	// evaluated against the events of the txs in CheckTx, and their sender
+	// with the tx.sender key.
+	UnconfirmedTxsWithQuery(ctx context.Context, query string, limit *int) (*ctypes.ResultUnconfirmedTxs, error)
 	NumUnconfirmedTxs(context.Context) (*ctypes.ResultUnconfirmedTxs, error)
 	CheckTx(context.Context, types.Tx) (*ctypes.ResultCheckTx, error)
 }
@@ -87,19 +87,27 @@ func (c *Local) ABCIQueryWithOptions(
 }
 
 func (c *Local) BroadcastTxCommit(_ context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
-	return c.env.BroadcastTxCommit(c.ctx, tx)
+	return c.env.BroadcastTxCommit(c.ctx, tx, "", "")
 }
 
 func (c *Local) BroadcastTxAsync(_ context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	return c.env.BroadcastTxAsync(c.ctx, tx)
+	return c.env.BroadcastTxAsync(c.ctx, tx, "")
 }
 
 func (c *Local) BroadcastTxSync(_ context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	return c.env.BroadcastTxSync(c.ctx, tx)
+	return c.env.BroadcastTxSync(c.ctx, tx, "")
 }
 
 func (c *Local) UnconfirmedTxs(_ context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
-	return c.env.UnconfirmedTxs(c.ctx, limit)
+	return c.env.UnconfirmedTxs(c.ctx, limit, "")
+}
+
+func (c *Local) UnconfirmedTxsWithQuery(
+	_ context.Context,
+	query string,
+	limit *int,
+) (*ctypes.ResultUnconfirmedTxs, error) {
+	return c.env.UnconfirmedTxs(c.ctx, limit, query)
 }
 
 func (c *Local) NumUnconfirmedTxs(context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
@@ -127,21 +135,7 @@ func (c *Local) ConsensusParams(_ context.Context, height *int64) (*ctypes.Resul
 }
 
 func (c *Local) Health(context.Context) (*ctypes.ResultHealth, error) {
-	return c.env.Health(c.ctx)
-}
-
-func (c *Local) DialSeeds(_ context.Context, seeds []string) (*ctypes.ResultDialSeeds, error) {
-	return c.env.UnsafeDialSeeds(c.ctx, seeds)
-}
-
-func (c *Local) DialPeers(
-	_ context.Context,
-	peers []string,
-	persistent,
-	unconditional,
-	private bool,
-) (*ctypes.ResultDialPeers, error) {
-	return c.env.UnsafeDialPeers(c.ctx, peers, persistent, unconditional, private)
+	return c.env.Health(c.ctx, false)
 }
 
 func (c *Local) BlockchainInfo(_ context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
@@ -165,7 +159,15 @@ func (c *Local) BlockByHash(_ context.Context, hash []byte) (*ctypes.ResultBlock
 }
 
 func (c *Local) BlockResults(_ context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
-	return c.env.BlockResults(c.ctx, height)
+	return c.env.BlockResults(c.ctx, height, nil, false)
+}
+
+func (c *Local) BlockResultsWithOptions(
+	_ context.Context,
+	height *int64,
+	opts rpcclient.BlockResultsOptions,
+) (*ctypes.ResultBlockResults, error) {
+	return c.env.BlockResults(c.ctx, height, opts.EventTypes, opts.OmitTxResults)
 }
 
 func (c *Local) Header(_ context.Context, height *int64) (*ctypes.ResultHeader, error) {
@@ -185,18 +187,18 @@ func (c *Local) Validators(_ context.Context, height *int64, page, perPage *int)
 }
 
 func (c *Local) Tx(_ context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
-	return c.env.Tx(c.ctx, hash, prove)
+	return c.env.Tx(c.ctx, hash, prove, "")
 }
 
 func (c *Local) TxSearch(
 	_ context.Context,
 	query string,
 	prove bool,
-	page,
+	cursor string,
 	perPage *int,
 	orderBy string,
 ) (*ctypes.ResultTxSearch, error) {
-	return c.env.TxSearch(c.ctx, query, prove, page, perPage, orderBy)
+	return c.env.TxSearch(c.ctx, query, prove, cursor, perPage, orderBy, "")
 }
 
 func (c *Local) BlockSearch(
@@ -105,15 +105,15 @@ func (c Client) ABCIQueryWithOptions(
 }
 
 func (c Client) BroadcastTxCommit(_ context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
-	return c.env.BroadcastTxCommit(&rpctypes.Context{}, tx)
+	return c.env.BroadcastTxCommit(&rpctypes.Context{}, tx, "", "")
 }
 
 func (c Client) BroadcastTxAsync(_ context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	return c.env.BroadcastTxAsync(&rpctypes.Context{}, tx)
+	return c.env.BroadcastTxAsync(&rpctypes.Context{}, tx, "")
 }
 
 func (c Client) BroadcastTxSync(_ context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	return c.env.BroadcastTxSync(&rpctypes.Context{}, tx)
+	return c.env.BroadcastTxSync(&rpctypes.Context{}, tx, "")
 }
 
 func (c Client) CheckTx(_ context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
@@ -137,21 +137,7 @@ func (c Client) ConsensusParams(_ context.Context, height *int64) (*ctypes.Resul
 }
 
 func (c Client) Health(_ context.Context) (*ctypes.ResultHealth, error) {
-	return c.env.Health(&rpctypes.Context{})
-}
-
-func (c Client) DialSeeds(_ context.Context, seeds []string) (*ctypes.ResultDialSeeds, error) {
-	return c.env.UnsafeDialSeeds(&rpctypes.Context{}, seeds)
-}
-
-func (c Client) DialPeers(
-	_ context.Context,
-	peers []string,
-	persistent,
-	unconditional,
-	private bool,
-) (*ctypes.ResultDialPeers, error) {
-	return c.env.UnsafeDialPeers(&rpctypes.Context{}, peers, persistent, unconditional, private)
+	return c.env.Health(&rpctypes.Context{}, false)
 }
 
 func (c Client) BlockchainInfo(_ context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
@@ -160,6 +160,29 @@ func (_m *Client) BlockResults(ctx context.Context, height *int64) (*coretypes.R
 	return r0, r1
 }
 
+// BlockResultsWithOptions provides a mock function with given fields: ctx, height, opts
+func (_m *Client) BlockResultsWithOptions(ctx context.Context, height *int64, opts client.BlockResultsOptions) (*coretypes.ResultBlockResults, error) {
+	ret := _m.Called(ctx, height, opts)
+
+	var r0 *coretypes.ResultBlockResults
+	if rf, ok := ret.Get(0).(func(context.Context, *int64, client.BlockResultsOptions) *coretypes.ResultBlockResults); ok {
+		r0 = rf(ctx, height, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBlockResults)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64, client.BlockResultsOptions) error); ok {
+		r1 = rf(ctx, height, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BlockSearch provides a mock function with given fields: ctx, query, page, perPage, orderBy
 func (_m *Client) BlockSearch(ctx context.Context, query string, page *int, perPage *int, orderBy string) (*coretypes.ResultBlockSearch, error) {
 	ret := _m.Called(ctx, query, page, perPage, orderBy)
@@ -774,13 +797,13 @@ func (_m *Client) Tx(ctx context.Context, hash []byte, prove bool) (*coretypes.R
 	return r0, r1
 }
 
-// TxSearch provides a mock function with given fields: ctx, query, prove, page, perPage, orderBy
-func (_m *Client) TxSearch(ctx context.Context, query string, prove bool, page *int, perPage *int, orderBy string) (*coretypes.ResultTxSearch, error) {
-	ret := _m.Called(ctx, query, prove, page, perPage, orderBy)
+// TxSearch provides a mock function with given fields: ctx, query, prove, cursor, perPage, orderBy
+func (_m *Client) TxSearch(ctx context.Context, query string, prove bool, cursor string, perPage *int, orderBy string) (*coretypes.ResultTxSearch, error) {
+	ret := _m.Called(ctx, query, prove, cursor, perPage, orderBy)
 
 	var r0 *coretypes.ResultTxSearch
-	if rf, ok := ret.Get(0).(func(context.Context, string, bool, *int, *int, string) *coretypes.ResultTxSearch); ok {
-		r0 = rf(ctx, query, prove, page, perPage, orderBy)
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, string, *int, string) *coretypes.ResultTxSearch); ok {
+		r0 = rf(ctx, query, prove, cursor, perPage, orderBy)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*coretypes.ResultTxSearch)
@@ -788,8 +811,8 @@ func (_m *Client) TxSearch(ctx context.Context, query string, prove bool, page *
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, bool, *int, *int, string) error); ok {
-		r1 = rf(ctx, query, prove, page, perPage, orderBy)
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool, string, *int, string) error); ok {
+		r1 = rf(ctx, query, prove, cursor, perPage, orderBy)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -820,6 +843,29 @@ func (_m *Client) UnconfirmedTxs(ctx context.Context, limit *int) (*coretypes.Re
 	return r0, r1
 }
 
+// UnconfirmedTxsWithQuery provides a mock function with given fields: ctx, query, limit
+func (_m *Client) UnconfirmedTxsWithQuery(ctx context.Context, query string, limit *int) (*coretypes.ResultUnconfirmedTxs, error) {
+	ret := _m.Called(ctx, query, limit)
+
+	var r0 *coretypes.ResultUnconfirmedTxs
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int) *coretypes.ResultUnconfirmedTxs); ok {
+		r0 = rf(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultUnconfirmedTxs)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int) error); ok {
+		r1 = rf(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Unsubscribe provides a mock function with given fields: ctx, subscriber, query
 func (_m *Client) Unsubscribe(ctx context.Context, subscriber string, query string) error {
 	ret := _m.Called(ctx, subscriber, query)
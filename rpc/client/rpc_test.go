@@ -160,6 +160,20 @@ func TestHealth(t *testing.T) {
 	}
 }
 
+func TestBlockResultsWithOptions(t *testing.T) {
+	for i, c := range GetClients() {
+		h := int64(1)
+		require.NoError(t, client.WaitForHeight(c, h, nil))
+
+		filtered, err := c.BlockResultsWithOptions(context.Background(), &h,
+			client.BlockResultsOptions{EventTypes: []string{"other_event"}, OmitTxResults: true})
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.Equal(t, h, filtered.Height)
+		assert.Nil(t, filtered.TxsResults)
+		assert.Empty(t, filtered.FinalizeBlockEvents)
+	}
+}
+
 func TestGenesisAndValidators(t *testing.T) {
 	for i, c := range GetClients() {
 
@@ -510,7 +524,7 @@ func TestTxSearchWithTimeout(t *testing.T) {
 	require.NoError(t, err)
 
 	// query using a compositeKey (see kvstore application)
-	result, err := timeoutClient.TxSearch(context.Background(), "app.creator='Cosmoshi Netowoko'", false, nil, nil, "asc")
+	result, err := timeoutClient.TxSearch(context.Background(), "app.creator='Cosmoshi Netowoko'", false, "", nil, "asc")
 	require.Nil(t, err)
 	require.Greater(t, len(result.Txs), 0, "expected a lot of transactions")
 }
@@ -552,7 +566,7 @@ func TestTxSearch(t *testing.T) {
 
 	// since we're not using an isolated test server, we'll have lingering transactions
 	// from other tests as well
-	result, err := c.TxSearch(context.Background(), "tx.height >= 0", true, nil, nil, "asc")
+	result, err := c.TxSearch(context.Background(), "tx.height >= 0", true, "", nil, "asc")
 	require.NoError(t, err)
 	txCount := len(result.Txs)
 
@@ -563,7 +577,7 @@ func TestTxSearch(t *testing.T) {
 	for _, c := range GetClients() {
 
 		// now we query for the tx.
-		result, err := c.TxSearch(context.Background(), fmt.Sprintf("tx.hash='%v'", find.Hash), true, nil, nil, "asc")
+		result, err := c.TxSearch(context.Background(), fmt.Sprintf("tx.hash='%v'", find.Hash), true, "", nil, "asc")
 		require.Nil(t, err)
 		require.Len(t, result.Txs, 1)
 		require.Equal(t, find.Hash, result.Txs[0].Hash)
@@ -581,51 +595,51 @@ func TestTxSearch(t *testing.T) {
 		}
 
 		// query by height
-		result, err = c.TxSearch(context.Background(), fmt.Sprintf("tx.height=%d", find.Height), true, nil, nil, "asc")
+		result, err = c.TxSearch(context.Background(), fmt.Sprintf("tx.height=%d", find.Height), true, "", nil, "asc")
 		require.Nil(t, err)
 		require.Len(t, result.Txs, 1)
 
 		// query for non existing tx
-		result, err = c.TxSearch(context.Background(), fmt.Sprintf("tx.hash='%X'", anotherTxHash), false, nil, nil, "asc")
+		result, err = c.TxSearch(context.Background(), fmt.Sprintf("tx.hash='%X'", anotherTxHash), false, "", nil, "asc")
 		require.Nil(t, err)
 		require.Len(t, result.Txs, 0)
 
 		// query using a compositeKey (see kvstore application)
-		result, err = c.TxSearch(context.Background(), "app.creator='Cosmoshi Netowoko'", false, nil, nil, "asc")
+		result, err = c.TxSearch(context.Background(), "app.creator='Cosmoshi Netowoko'", false, "", nil, "asc")
 		require.Nil(t, err)
 		require.Greater(t, len(result.Txs), 0, "expected a lot of transactions")
 
 		// query using an index key
-		result, err = c.TxSearch(context.Background(), "app.index_key='index is working'", false, nil, nil, "asc")
+		result, err = c.TxSearch(context.Background(), "app.index_key='index is working'", false, "", nil, "asc")
 		require.Nil(t, err)
 		require.Greater(t, len(result.Txs), 0, "expected a lot of transactions")
 
 		// query using an noindex key
-		result, err = c.TxSearch(context.Background(), "app.noindex_key='index is working'", false, nil, nil, "asc")
+		result, err = c.TxSearch(context.Background(), "app.noindex_key='index is working'", false, "", nil, "asc")
 		require.Nil(t, err)
 		require.Equal(t, len(result.Txs), 0, "expected a lot of transactions")
 
 		// query using a compositeKey (see kvstore application) and height
 		result, err = c.TxSearch(context.Background(),
-			"app.creator='Cosmoshi Netowoko' AND tx.height<10000", true, nil, nil, "asc")
+			"app.creator='Cosmoshi Netowoko' AND tx.height<10000", true, "", nil, "asc")
 		require.Nil(t, err)
 		require.Greater(t, len(result.Txs), 0, "expected a lot of transactions")
 
 		// query a non existing tx with page 1 and txsPerPage 1
 		perPage := 1
-		result, err = c.TxSearch(context.Background(), "app.creator='Cosmoshi Neetowoko'", true, nil, &perPage, "asc")
+		result, err = c.TxSearch(context.Background(), "app.creator='Cosmoshi Neetowoko'", true, "", &perPage, "asc")
 		require.Nil(t, err)
 		require.Len(t, result.Txs, 0)
 
 		// check sorting
-		result, err = c.TxSearch(context.Background(), "tx.height >= 1", false, nil, nil, "asc")
+		result, err = c.TxSearch(context.Background(), "tx.height >= 1", false, "", nil, "asc")
 		require.Nil(t, err)
 		for k := 0; k < len(result.Txs)-1; k++ {
 			require.LessOrEqual(t, result.Txs[k].Height, result.Txs[k+1].Height)
 			require.LessOrEqual(t, result.Txs[k].Index, result.Txs[k+1].Index)
 		}
 
-		result, err = c.TxSearch(context.Background(), "tx.height >= 1", false, nil, nil, "desc")
+		result, err = c.TxSearch(context.Background(), "tx.height >= 1", false, "", nil, "desc")
 		require.Nil(t, err)
 		for k := 0; k < len(result.Txs)-1; k++ {
 			require.GreaterOrEqual(t, result.Txs[k].Height, result.Txs[k+1].Height)
@@ -637,18 +651,21 @@ func TestTxSearch(t *testing.T) {
 			seen      = map[int64]bool{}
 			maxHeight int64
 			pages     = int(math.Ceil(float64(txCount) / float64(perPage)))
+			cursor    string
 		)
 
 		totalTx := 0
 		for page := 1; page <= pages; page++ {
-			page := page
-			result, err := c.TxSearch(context.Background(), "tx.height >= 1", true, &page, &perPage, "asc")
+			result, err := c.TxSearch(context.Background(), "tx.height >= 1", true, cursor, &perPage, "asc")
 			require.NoError(t, err)
 			if page < pages {
 				require.Len(t, result.Txs, perPage)
+				require.NotEmpty(t, result.NextCursor)
 			} else {
 				require.LessOrEqual(t, len(result.Txs), perPage)
+				require.Empty(t, result.NextCursor)
 			}
+			cursor = result.NextCursor
 			totalTx = totalTx + len(result.Txs)
 			for _, tx := range result.Txs {
 				require.False(t, seen[tx.Height],
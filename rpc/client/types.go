@@ -9,3 +9,12 @@ type ABCIQueryOptions struct {
 
 // DefaultABCIQueryOptions are latest height (0) and prove false.
 var DefaultABCIQueryOptions = ABCIQueryOptions{Height: 0, Prove: false}
+
+// BlockResultsOptions can be used to filter the results of a BlockResults
+// call.
+type BlockResultsOptions struct {
+	// The types of the events returned, all if empty.
+	EventTypes []string
+	// Omit the results of the txs.
+	OmitTxResults bool
+}
@@ -1,13 +1,13 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sort"
 
 	"github.com/cometbft/cometbft/libs/bytes"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
-	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 	blockidxnull "github.com/cometbft/cometbft/state/indexer/block/null"
@@ -180,8 +180,17 @@ func (env *Environment) Commit(_ *rpctypes.Context, heightPtr *int64) (*ctypes.R
 // Results are for the height of the block containing the txs.
 // Thus response.results.deliver_tx[5] is the results of executing
 // getBlock(h).Txs[5]
+//
+// If event types are given, only the events of these types are returned, in
+// the FinalizeBlock events and the tx results. The tx results are omitted if
+// omitTxResults is true.
 // More: https://docs.cometbft.com/main/rpc/#/Info/block_results
-func (env *Environment) BlockResults(_ *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlockResults, error) {
+func (env *Environment) BlockResults(
+	_ *rpctypes.Context,
+	heightPtr *int64,
+	eventTypes []string,
+	omitTxResults bool,
+) (*ctypes.ResultBlockResults, error) {
 	height, err := env.getHeight(env.BlockStore.Height(), heightPtr)
 	if err != nil {
 		return nil, err
@@ -192,13 +201,14 @@ func (env *Environment) BlockResults(_ *rpctypes.Context, heightPtr *int64) (*ct
 		return nil, err
 	}
 
-	return &ctypes.ResultBlockResults{
+	res := &ctypes.ResultBlockResults{
 		Height:                height,
 		TxsResults:            results.TxResults,
 		FinalizeBlockEvents:   results.Events,
 		ValidatorUpdates:      results.ValidatorUpdates,
 		ConsensusParamUpdates: results.ConsensusParamUpdates,
-	}, nil
+	}
+	return res.Filter(eventTypes, omitTxResults), nil
 }
 
 // BlockSearch searches for a paginated set of blocks matching
@@ -214,15 +224,19 @@ func (env *Environment) BlockSearch(
 		return nil, errors.New("block indexing is disabled")
 	}
 
-	q, err := cmtquery.New(query)
+	q, err := env.parseQuery(query)
 	if err != nil {
 		return nil, err
 	}
 
-	results, err := env.BlockIndexer.Search(ctx.Context(), q)
+	searchCtx, search := env.startSearch(ctx, "block_search", query)
+	defer search.finish()
+
+	results, err := env.BlockIndexer.Search(searchCtx, q)
 	if err != nil {
 		return nil, err
 	}
+	search.results = len(results)
 
 	// sort results (must be done before pagination)
 	switch orderBy {
@@ -236,8 +250,29 @@ func (env *Environment) BlockSearch(
 		return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
 	}
 
-	// paginate results
+	// spill the results to a search job if there are too many of them
 	totalCount := len(results)
+	jobID, err := env.spillSearch(searchJobKindBlocks, totalCount, func(ctx context.Context, emit func(interface{}) error) error {
+		for _, height := range results {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if res := env.loadResultBlock(height); res != nil {
+				if err := emit(res); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if jobID != "" {
+		return &ctypes.ResultBlockSearch{Blocks: []*ctypes.ResultBlock{}, TotalCount: totalCount, JobID: jobID}, nil
+	}
+
+	// paginate results
 	perPage := env.validatePerPage(perPagePtr)
 
 	page, err := validatePage(pagePtr, perPage, totalCount)
@@ -250,17 +285,27 @@ func (env *Environment) BlockSearch(
 
 	apiResults := make([]*ctypes.ResultBlock, 0, pageSize)
 	for i := skipCount; i < skipCount+pageSize; i++ {
-		block := env.BlockStore.LoadBlock(results[i])
-		if block != nil {
-			blockMeta := env.BlockStore.LoadBlockMeta(block.Height)
-			if blockMeta != nil {
-				apiResults = append(apiResults, &ctypes.ResultBlock{
-					Block:   block,
-					BlockID: blockMeta.BlockID,
-				})
-			}
+		if res := env.loadResultBlock(results[i]); res != nil {
+			apiResults = append(apiResults, res)
 		}
 	}
 
 	return &ctypes.ResultBlockSearch{Blocks: apiResults, TotalCount: totalCount}, nil
 }
+
+// loadResultBlock returns the block at the given height along with its ID,
+// or nil if it is not in the block store.
+func (env *Environment) loadResultBlock(height int64) *ctypes.ResultBlock {
+	block := env.BlockStore.LoadBlock(height)
+	if block == nil {
+		return nil
+	}
+	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil
+	}
+	return &ctypes.ResultBlock{
+		Block:   block,
+		BlockID: blockMeta.BlockID,
+	}
+}
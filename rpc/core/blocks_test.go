@@ -105,7 +105,7 @@ func TestBlockResults(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		res, err := env.BlockResults(&rpctypes.Context{}, &tc.height)
+		res, err := env.BlockResults(&rpctypes.Context{}, &tc.height, nil, false)
 		if tc.wantErr {
 			assert.Error(t, err)
 		} else {
@@ -114,3 +114,44 @@ func TestBlockResults(t *testing.T) {
 		}
 	}
 }
+
+func TestBlockResultsFilter(t *testing.T) {
+	transfer := abci.Event{Type: "transfer", Attributes: []abci.EventAttribute{{Key: "amount", Value: "1"}}}
+	message := abci.Event{Type: "message", Attributes: []abci.EventAttribute{{Key: "sender", Value: "a"}}}
+	results := &abci.ResponseFinalizeBlock{
+		TxResults: []*abci.ExecTxResult{
+			{Code: 0, Data: []byte{0x01}, Events: []abci.Event{transfer, message}},
+			{Code: 1, Log: "not ok", Events: []abci.Event{message}},
+		},
+		Events: []abci.Event{message, transfer},
+	}
+
+	env := &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	require.NoError(t, env.StateStore.SaveFinalizeBlockResponse(100, results))
+	mockstore := &mocks.BlockStore{}
+	mockstore.On("Height").Return(int64(100))
+	mockstore.On("Base").Return(int64(1))
+	env.BlockStore = mockstore
+
+	height := int64(100)
+	res, err := env.BlockResults(&rpctypes.Context{}, &height, []string{"transfer"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []abci.Event{transfer}, res.FinalizeBlockEvents)
+	require.Len(t, res.TxsResults, 2)
+	assert.Equal(t, []abci.Event{transfer}, res.TxsResults[0].Events)
+	assert.Empty(t, res.TxsResults[1].Events)
+	assert.Equal(t, uint32(1), res.TxsResults[1].Code)
+
+	res, err = env.BlockResults(&rpctypes.Context{}, &height, nil, true)
+	require.NoError(t, err)
+	assert.Nil(t, res.TxsResults)
+	assert.Equal(t, []abci.Event{message, transfer}, res.FinalizeBlockEvents)
+
+	res, err = env.BlockResults(&rpctypes.Context{}, &height, []string{"message", "other"}, true)
+	require.NoError(t, err)
+	assert.Nil(t, res.TxsResults)
+	assert.Equal(t, []abci.Event{message}, res.FinalizeBlockEvents)
+}
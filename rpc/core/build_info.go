@@ -0,0 +1,81 @@
+package core
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sort"
+
+	"github.com/cometbft/cometbft/p2p"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/version"
+)
+
+// rpcVersions lists the path prefixes under which every RPC method is served.
+var rpcVersions = []string{"", "v1"}
+
+// BuildInfo returns the build information of the node, along with the
+// features enabled on it, the RPC methods it serves and the p2p channels it
+// supports, so that clients can adapt to the node's capabilities.
+// More: https://docs.cometbft.com/main/rpc/#/Info/build_info
+func (env *Environment) BuildInfo(*rpctypes.Context) (*ctypes.ResultBuildInfo, error) {
+	result := &ctypes.ResultBuildInfo{
+		Version:       version.TMCoreSemVer,
+		GitCommitHash: version.TMGitCommitHash,
+		GoVersion:     runtime.Version(),
+		ABCIVersion:   version.ABCISemVer,
+		P2PProtocol:   version.P2PProtocol,
+		BlockProtocol: version.BlockProtocol,
+		Modules:       []ctypes.ModuleVersion{},
+		Features:      map[string]bool{},
+		RPCMethods:    env.rpcMethods(),
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range info.Deps {
+			mod := dep
+			if dep.Replace != nil {
+				mod = dep.Replace
+			}
+			result.Modules = append(result.Modules, ctypes.ModuleVersion{
+				Path:    dep.Path,
+				Version: mod.Version,
+			})
+		}
+	}
+
+	for name, enabled := range env.Features {
+		result.Features[name] = enabled
+	}
+
+	if env.P2PTransport != nil {
+		if nodeInfo, ok := env.P2PTransport.NodeInfo().(p2p.DefaultNodeInfo); ok {
+			result.P2PChannels = nodeInfo.Channels
+		}
+	}
+
+	return result, nil
+}
+
+// rpcMethods returns the RPC methods served by the node, sorted by name.
+func (env *Environment) rpcMethods() []ctypes.RPCMethod {
+	routes := env.GetRoutes()
+	methods := make([]ctypes.RPCMethod, 0, len(routes))
+	for name, fn := range routes {
+		args := fn.ArgNames()
+		if args == nil {
+			args = []string{}
+		}
+		methods = append(methods, ctypes.RPCMethod{
+			Name:      name,
+			Args:      args,
+			Versions:  rpcVersions,
+			WSOnly:    fn.IsWS(),
+			Cacheable: fn.IsCacheable(),
+		})
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Name < methods[j].Name
+	})
+	return methods
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/cometbft/cometbft/config"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/version"
+)
+
+func TestBuildInfo(t *testing.T) {
+	env := &Environment{
+		Config:   *cfg.DefaultRPCConfig(),
+		Features: map[string]bool{"tx_index": true, "pex": false},
+	}
+
+	res, err := env.BuildInfo(&rpctypes.Context{})
+	require.NoError(t, err)
+
+	assert.Equal(t, version.TMCoreSemVer, res.Version)
+	assert.Equal(t, version.ABCISemVer, res.ABCIVersion)
+	assert.Equal(t, version.P2PProtocol, res.P2PProtocol)
+	assert.Equal(t, version.BlockProtocol, res.BlockProtocol)
+	assert.Equal(t, map[string]bool{
+		"tx_index": true,
+		"pex":      false,
+	}, res.Features)
+
+	methods := make(map[string]ctypes.RPCMethod, len(res.RPCMethods))
+	for _, m := range res.RPCMethods {
+		methods[m.Name] = m
+	}
+	assert.Len(t, methods, len(res.RPCMethods))
+
+	block, ok := methods["block"]
+	require.True(t, ok)
+	assert.Equal(t, []string{"height"}, block.Args)
+	assert.Equal(t, []string{"", "v1"}, block.Versions)
+	assert.True(t, block.Cacheable)
+	assert.False(t, block.WSOnly)
+
+	subscribe, ok := methods["subscribe"]
+	require.True(t, ok)
+	assert.True(t, subscribe.WSOnly)
+
+	_, ok = methods["build_info"]
+	assert.True(t, ok)
+
+	// The methods controlling the node are only served by the admin service
+	// of the privileged gRPC server.
+	_, ok = methods["dial_peers"]
+	assert.False(t, ok)
+}
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"fmt"
+
 	cm "github.com/cometbft/cometbft/consensus"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
@@ -50,6 +52,72 @@ func (env *Environment) Validators(
 	}, nil
 }
 
+// ValidatorsDiff returns the changes of the validator set from the given
+// height to the other one: the validators which joined the set, which were
+// removed from it, and whose voting power changed. If toPtr is not provided,
+// the latest validator set is compared.
+//
+// The joined validators and the power changes are in the order of the set at
+// the later height, and the removed validators in the order of the earlier
+// one.
+//
+// More: https://docs.cometbft.com/main/rpc/#/Info/validators_diff
+func (env *Environment) ValidatorsDiff(
+	_ *rpctypes.Context,
+	from int64,
+	toPtr *int64,
+) (*ctypes.ResultValidatorsDiff, error) {
+	latestHeight := env.latestUncommittedHeight()
+	fromHeight, err := env.getHeight(latestHeight, &from)
+	if err != nil {
+		return nil, fmt.Errorf("from: %w", err)
+	}
+	toHeight, err := env.getHeight(latestHeight, toPtr)
+	if err != nil {
+		return nil, fmt.Errorf("to: %w", err)
+	}
+	if fromHeight > toHeight {
+		return nil, fmt.Errorf("from %d must be less than or equal to to %d", fromHeight, toHeight)
+	}
+
+	fromVals, err := env.StateStore.LoadValidators(fromHeight)
+	if err != nil {
+		return nil, err
+	}
+	toVals, err := env.StateStore.LoadValidators(toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ctypes.ResultValidatorsDiff{
+		FromHeight:   fromHeight,
+		ToHeight:     toHeight,
+		Joined:       []*types.Validator{},
+		Removed:      []*types.Validator{},
+		PowerChanged: []ctypes.ValidatorPowerChange{},
+	}
+	for _, val := range toVals.Validators {
+		_, prev := fromVals.GetByAddress(val.Address)
+		switch {
+		case prev == nil:
+			result.Joined = append(result.Joined, val)
+		case prev.VotingPower != val.VotingPower:
+			result.PowerChanged = append(result.PowerChanged, ctypes.ValidatorPowerChange{
+				Address:   val.Address,
+				PubKey:    val.PubKey,
+				FromPower: prev.VotingPower,
+				ToPower:   val.VotingPower,
+			})
+		}
+	}
+	for _, val := range fromVals.Validators {
+		if !toVals.HasAddress(val.Address) {
+			result.Removed = append(result.Removed, val)
+		}
+	}
+	return result, nil
+}
+
 // DumpConsensusState dumps consensus state.
 // UNSTABLE
 // More: https://docs.cometbft.com/main/rpc/#/Info/dump_consensus_state
@@ -93,7 +161,8 @@ func (env *Environment) GetConsensusState(*rpctypes.Context) (*ctypes.ResultCons
 	return &ctypes.ResultConsensusState{RoundState: bz}, err
 }
 
-// ConsensusParams gets the consensus parameters at the given block height.
+// ConsensusParams gets the consensus parameters at the given block height,
+// along with the height at which they were last changed.
 // If no height is provided, it will fetch the latest consensus params.
 // More: https://docs.cometbft.com/main/rpc/#/Info/consensus_params
 func (env *Environment) ConsensusParams(
@@ -107,12 +176,13 @@ func (env *Environment) ConsensusParams(
 		return nil, err
 	}
 
-	consensusParams, err := env.StateStore.LoadConsensusParams(height)
+	consensusParams, lastHeightChanged, err := env.StateStore.LoadConsensusParamsInfo(height)
 	if err != nil {
 		return nil, err
 	}
 	return &ctypes.ResultConsensusParams{
-		BlockHeight:     height,
-		ConsensusParams: consensusParams,
+		BlockHeight:       height,
+		ConsensusParams:   consensusParams,
+		LastHeightChanged: lastHeightChanged,
 	}, nil
 }
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cm "github.com/cometbft/cometbft/consensus"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestConsensusParams(t *testing.T) {
+	params := *types.DefaultConsensusParams()
+
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadConsensusParamsInfo", int64(10)).Return(params, int64(7), nil)
+	stateStore.On("LoadConsensusParamsInfo", int64(3)).
+		Return(types.ConsensusParams{}, int64(0), errors.New("could not find consensus params for height #3"))
+
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Height").Return(int64(10))
+	blockStore.On("Base").Return(int64(1))
+
+	env := &Environment{
+		StateStore:       stateStore,
+		BlockStore:       blockStore,
+		ConsensusReactor: &cm.Reactor{},
+	}
+
+	testCases := []struct {
+		height  int64
+		wantErr bool
+		wantRes *ctypes.ResultConsensusParams
+	}{
+		{0, true, nil},
+		{12, true, nil},
+		{3, true, nil},
+		{10, false, &ctypes.ResultConsensusParams{
+			BlockHeight:       10,
+			ConsensusParams:   params,
+			LastHeightChanged: 7,
+		}},
+	}
+
+	for _, tc := range testCases {
+		res, err := env.ConsensusParams(&rpctypes.Context{}, &tc.height)
+		if tc.wantErr {
+			require.Error(t, err, "height %v", tc.height)
+			assert.Nil(t, res)
+		} else {
+			require.NoError(t, err, "height %v", tc.height)
+			assert.Equal(t, tc.wantRes, res)
+		}
+	}
+}
+
+func TestValidatorsDiff(t *testing.T) {
+	a, b, c := types.NewMockPV(), types.NewMockPV(), types.NewMockPV()
+	newValidator := func(pv types.PrivValidator, power int64) *types.Validator {
+		pubKey, err := pv.GetPubKey()
+		require.NoError(t, err)
+		return types.NewValidator(pubKey, power)
+	}
+	fromVals := types.NewValidatorSet([]*types.Validator{newValidator(a, 10), newValidator(b, 10)})
+	toVals := types.NewValidatorSet([]*types.Validator{newValidator(a, 20), newValidator(c, 5)})
+
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadValidators", int64(2)).Return(fromVals, nil)
+	stateStore.On("LoadValidators", int64(5)).Return(toVals, nil)
+	stateStore.On("LoadValidators", int64(11)).Return(toVals, nil)
+
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Height").Return(int64(10))
+	blockStore.On("Base").Return(int64(1))
+
+	env := &Environment{
+		StateStore:       stateStore,
+		BlockStore:       blockStore,
+		ConsensusReactor: &cm.Reactor{},
+	}
+
+	to := int64(5)
+	res, err := env.ValidatorsDiff(&rpctypes.Context{}, 2, &to)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), res.FromHeight)
+	require.Equal(t, int64(5), res.ToHeight)
+	require.Len(t, res.Joined, 1)
+	require.Equal(t, c.PrivKey.PubKey().Address(), res.Joined[0].Address)
+	require.Len(t, res.Removed, 1)
+	require.Equal(t, b.PrivKey.PubKey().Address(), res.Removed[0].Address)
+	require.Equal(t, []ctypes.ValidatorPowerChange{{
+		Address:   a.PrivKey.PubKey().Address(),
+		PubKey:    a.PrivKey.PubKey(),
+		FromPower: 10,
+		ToPower:   20,
+	}}, res.PowerChanged)
+
+	// The latest validator set is compared by default.
+	res, err = env.ValidatorsDiff(&rpctypes.Context{}, 5, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(11), res.ToHeight)
+	require.Empty(t, res.Joined)
+	require.Empty(t, res.Removed)
+	require.Empty(t, res.PowerChanged)
+
+	earlier := int64(2)
+	_, err = env.ValidatorsDiff(&rpctypes.Context{}, 5, &earlier)
+	require.Error(t, err)
+	_, err = env.ValidatorsDiff(&rpctypes.Context{}, 0, &to)
+	require.Error(t, err)
+}
@@ -23,7 +23,6 @@ Available endpoints:
 /net_info
 /num_unconfirmed_txs
 /status
-/unsafe_flush_mempool
 /unsubscribe_all?
 
 Endpoints that require arguments:
@@ -50,6 +49,7 @@ Endpoints that require arguments:
 /unconfirmed_txs?limit=_
 /unsubscribe?query=_
 /validators?height=_&page=_&per_page=_
+/wait_tx?hash=_&prove=_&timeout=_
 ```
 */
 package core
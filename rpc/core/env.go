@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/cometbft/cometbft/blocksync"
 	cfg "github.com/cometbft/cometbft/config"
+	cm "github.com/cometbft/cometbft/consensus"
 	"github.com/cometbft/cometbft/crypto"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/proxy"
@@ -50,10 +53,6 @@ type transport interface {
 }
 
 type peers interface {
-	AddPersistentPeers([]string) error
-	AddUnconditionalPeerIDs([]string) error
-	AddPrivatePeerIDs([]string) error
-	DialPeersAsync([]string) error
 	Peers() p2p.IPeerSet
 }
 
@@ -62,6 +61,21 @@ type syncReactor interface {
 	WaitSync() bool
 }
 
+// The block sync reactor, reporting its progress.
+type blockSyncReactor interface {
+	SyncStatus() blocksync.SyncStatus
+}
+
+// The state sync reactor, reporting the phase of the state sync.
+type stateSyncReactor interface {
+	SyncPhase() string
+}
+
+// The indexer service, reporting the last height it processed.
+type indexerService interface {
+	IndexedHeight() int64
+}
+
 // ----------------------------------------------
 // Environment contains objects and interfaces used by the RPC. It is expected
 // to be setup once during startup.
@@ -79,21 +93,62 @@ type Environment struct {
 	MempoolReactor   syncReactor
 	P2PPeers         peers
 	P2PTransport     transport
+	// BlockSyncReactor, StateSyncReactor and IndexerService report the
+	// progress of the syncs and of the indexer in /status, if set.
+	BlockSyncReactor blockSyncReactor
+	StateSyncReactor stateSyncReactor
+	IndexerService   indexerService
+	// CheckDBWritable, if set, checks that the database is writable, for
+	// /health?verbose=true.
+	CheckDBWritable func() error
 
 	// objects
-	PubKey       crypto.PubKey
-	GenDoc       *types.GenesisDoc // cache the genesis structure
-	TxIndexer    txindex.TxIndexer
-	BlockIndexer indexer.BlockIndexer
-	EventBus     *types.EventBus // thread safe
-	Mempool      mempl.Mempool
+	PubKey crypto.PubKey
+	GenDoc *types.GenesisDoc // cache the genesis structure
+	// GenDocPending is true if GenDoc lacks its app state (i.e. it was
+	// restored from a checkpoint), in which case the full genesis doc is
+	// loaded on demand through GenDocLoader.
+	GenDocPending bool
+	GenDocLoader  func() (*types.GenesisDoc, error)
+	TxIndexer     txindex.TxIndexer
+	BlockIndexer  indexer.BlockIndexer
+	EventBus      *types.EventBus // thread safe
+	Mempool       mempl.Mempool
+	Pruner        *sm.Pruner
+	// StorageForecaster is nil if storage forecasting is disabled.
+	StorageForecaster *sm.StorageForecaster
+	// MempoolDropLog is nil if the mempool does not record the dropped txs.
+	MempoolDropLog *mempl.DropLog
+	// ExecutionReporter is nil if execution reports are not recorded.
+	ExecutionReporter *sm.ExecutionReporter
+	// VoteRecorder is nil if vote recording is disabled.
+	VoteRecorder *cm.VoteRecorder
+	// ReplayProgress tracks the progress of the replays on startup.
+	ReplayProgress *cm.ReplayProgress
+	// ValidatorsPerf tracks the participation of the validators in the
+	// consensus.
+	ValidatorsPerf *cm.ValidatorsPerformance
 
 	Logger log.Logger
 
 	Config cfg.RPCConfig
 
+	// Features lists the optional features of the node and whether they are
+	// enabled, as reported by the /build_info endpoint.
+	Features map[string]bool
+
 	// cache of chunked genesis data.
 	genChunks []string
+	genMtx    cmtsync.Mutex
+
+	// cache of the outcomes of the broadcast requests by idempotency key.
+	idempotencyKeys *idempotencyCache
+	idempotencyMtx  cmtsync.Mutex
+
+	// jobs materializing the results of the searches exceeding the maximum
+	// number of results.
+	searchJobs    *searchJobs
+	searchJobsMtx cmtsync.Mutex
 }
 
 //----------------------------------------------
@@ -140,7 +195,7 @@ func (env *Environment) InitGenesisChunks() error {
 		return nil
 	}
 
-	if env.GenDoc == nil {
+	if env.GenDoc == nil || env.genDocPending() {
 		return nil
 	}
 
@@ -162,6 +217,30 @@ func (env *Environment) InitGenesisChunks() error {
 	return nil
 }
 
+// genDocPending returns true if the full genesis doc has yet to be loaded
+// through GenDocLoader.
+func (env *Environment) genDocPending() bool {
+	return env.GenDocPending && env.GenDocLoader != nil
+}
+
+// loadGenesis loads the full genesis doc, if it has not been loaded yet, and
+// initializes the genesis chunks.
+func (env *Environment) loadGenesis() error {
+	env.genMtx.Lock()
+	defer env.genMtx.Unlock()
+
+	if env.genDocPending() {
+		genDoc, err := env.GenDocLoader()
+		if err != nil {
+			return fmt.Errorf("failed to load genesis doc: %w", err)
+		}
+		env.GenDoc = genDoc
+		env.GenDocPending = false
+		env.genChunks = nil
+	}
+	return env.InitGenesisChunks()
+}
+
 func validateSkipCount(page, perPage int) int {
 	skipCount := (page - 1) * perPage
 	if skipCount < 0 {
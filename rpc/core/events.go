@@ -6,75 +6,149 @@ import (
 	"fmt"
 	"time"
 
+	cfg "github.com/cometbft/cometbft/config"
 	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
 	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/types"
 )
 
-const (
-	// maxQueryLength is the maximum length of a query string that will be
-	// accepted. This is just a safety check to avoid outlandish queries.
-	maxQueryLength = 512
-)
-
-// Subscribe for events via WebSocket.
+// Subscribe for events via WebSocket. If fromHeightPtr is set, the events
+// of the stored blocks from that height on, matching the query, are replayed
+// first, followed by the live events, without gaps nor duplicates.
 // More: https://docs.cometbft.com/main/rpc/#/Websocket/subscribe
-func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+func (env *Environment) Subscribe(
+	ctx *rpctypes.Context,
+	query string,
+	fromHeightPtr *int64,
+) (*ctypes.ResultSubscribe, error) {
 	addr := ctx.RemoteAddr()
 
 	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
 		return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
 	} else if env.EventBus.NumClientSubscriptions(addr) >= env.Config.MaxSubscriptionsPerClient {
 		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
-	} else if len(query) > maxQueryLength {
-		return nil, errors.New("maximum query length exceeded")
 	}
 
-	env.Logger.Info("Subscribe to query", "remote", addr, "query", query)
+	var fromHeight int64
+	if fromHeightPtr != nil {
+		fromHeight = *fromHeightPtr
+		if fromHeight <= 0 {
+			return nil, fmt.Errorf("from_height must be greater than 0, but got %d", fromHeight)
+		}
+		if base := env.BlockStore.Base(); fromHeight < base {
+			return nil, fmt.Errorf("from_height %d is lower than the earliest stored height %d", fromHeight, base)
+		}
+	}
 
-	q, err := cmtquery.New(query)
+	q, err := env.parseQuery(query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse query: %w", err)
+		return nil, err
 	}
 
+	env.Logger.Info("Subscribe to query", "remote", addr, "query", query, "from_height", fromHeight)
+
 	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
 	defer cancel()
 
-	sub, err := env.EventBus.Subscribe(subCtx, addr, q, env.Config.SubscriptionBufferSize)
+	// With the drop policy, the events published while the buffer of the
+	// subscription is full are dropped, instead of canceling it.
+	closeIfSlow := env.Config.SlowClientPolicy != cfg.SlowClientPolicyDrop
+	var (
+		sub     types.Subscription
+		dropped = func() uint64 { return 0 }
+	)
+	if closeIfSlow {
+		sub, err = env.EventBus.Subscribe(subCtx, addr, q, env.Config.SubscriptionBufferSize)
+	} else {
+		var dropping *cmtpubsub.Subscription
+		dropping, err = env.EventBus.SubscribeDropping(subCtx, addr, q, env.Config.SubscriptionBufferSize)
+		sub, dropped = dropping, dropping.Dropped
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	closeIfSlow := env.Config.CloseOnSlowClient
-
 	// Capture the current ID, since it can change in the future.
 	subscriptionID := ctx.JSONReq.ID
-	go func() {
-		for {
-			select {
-			case msg := <-sub.Out():
+	// send writes the event to the client, and returns false if the
+	// subscription must be closed.
+	send := func(msg cmtpubsub.Message) bool {
+		var (
+			resultEvent = &ctypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()}
+			resp        = rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
+		)
+		writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := ctx.WSConn.WriteRPCResponse(writeCtx, resp); err != nil {
+			env.Logger.Info("Can't write response (slow client)",
+				"to", addr, "subscriptionID", subscriptionID, "err", err, "dropped", dropped())
+
+			if closeIfSlow {
 				var (
-					resultEvent = &ctypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()}
-					resp        = rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
+					err  = errors.New("subscription was canceled (reason: slow client)")
+					resp = rpctypes.RPCServerError(subscriptionID, err)
 				)
-				writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-				defer cancel()
-				if err := ctx.WSConn.WriteRPCResponse(writeCtx, resp); err != nil {
+				if !ctx.WSConn.TryWriteRPCResponse(resp) {
 					env.Logger.Info("Can't write response (slow client)",
 						"to", addr, "subscriptionID", subscriptionID, "err", err)
+				}
+				// Not to hold the buffer of the subscription until it is full.
+				if err := env.EventBus.Unsubscribe(context.Background(), addr, q); err != nil {
+					env.Logger.Debug("Failed to unsubscribe slow client", "to", addr, "query", query, "err", err)
+				}
+				return false
+			}
+		}
+		return true
+	}
+	go func() {
+		// The live events of the blocks lower than minHeight are skipped,
+		// being replayed.
+		minHeight := fromHeight
+		var pending []cmtpubsub.Message
+		if fromHeight > 0 {
+			// The live events are held while the past ones are replayed.
+			replayed := make(chan int64, 1)
+			ok := true
+			go func() {
+				var lastHeight int64
+				lastHeight, ok = env.replayEvents(q, fromHeight, send)
+				replayed <- lastHeight
+			}()
+		replay:
+			for {
+				select {
+				case msg := <-sub.Out():
+					pending = append(pending, msg)
+				case lastHeight := <-replayed:
+					minHeight = max(minHeight, lastHeight+1)
+					break replay
+				}
+			}
+			if !ok {
+				return
+			}
+		}
+		for _, msg := range pending {
+			if height, ok := eventHeight(msg.Data()); ok && height < minHeight {
+				continue
+			}
+			if !send(msg) {
+				return
+			}
+		}
 
-					if closeIfSlow {
-						var (
-							err  = errors.New("subscription was canceled (reason: slow client)")
-							resp = rpctypes.RPCServerError(subscriptionID, err)
-						)
-						if !ctx.WSConn.TryWriteRPCResponse(resp) {
-							env.Logger.Info("Can't write response (slow client)",
-								"to", addr, "subscriptionID", subscriptionID, "err", err)
-						}
-						return
-					}
+		for {
+			select {
+			case msg := <-sub.Out():
+				if height, ok := eventHeight(msg.Data()); ok && height < minHeight {
+					continue
+				}
+				if !send(msg) {
+					return
 				}
 			case <-sub.Canceled():
 				if sub.Err() != cmtpubsub.ErrUnsubscribed {
@@ -101,6 +175,59 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.
 	return &ctypes.ResultSubscribe{}, nil
 }
 
+// replayEvents sends the events of the stored blocks from the given height on
+// matching the query, rebuilt from the stored responses of the application to
+// FinalizeBlock, until the latest block whose response is stored. It returns
+// the height of the last block replayed, and false if send did.
+func (env *Environment) replayEvents(
+	q *cmtquery.Query,
+	fromHeight int64,
+	send func(cmtpubsub.Message) bool,
+) (int64, bool) {
+	ok := true
+	bus := env.EventBus.NewReplayBus(q, func(msg cmtpubsub.Message) error {
+		ok = ok && send(msg)
+		return nil
+	})
+	height := fromHeight
+	// The latest height is read again after each block, to replay the blocks
+	// committed meanwhile too.
+	for ; ok && height <= env.BlockStore.Height(); height++ {
+		block := env.BlockStore.LoadBlock(height)
+		blockMeta := env.BlockStore.LoadBlockMeta(height)
+		if block == nil || blockMeta == nil {
+			break
+		}
+		abciResponse, err := env.StateStore.LoadFinalizeBlockResponse(height)
+		if err != nil {
+			// The block is being committed: its events are sent live.
+			env.Logger.Debug("Stopping the replay of the events", "height", height, "err", err)
+			break
+		}
+		sm.ReplayBlockEvents(env.Logger, bus, block, blockMeta.BlockID, abciResponse)
+	}
+	return height - 1, ok
+}
+
+// eventHeight returns the height of the block of the event, if the event is
+// one of the events of a block, which are replayed.
+func eventHeight(data interface{}) (int64, bool) {
+	switch data := data.(type) {
+	case types.EventDataNewBlock:
+		return data.Block.Height, true
+	case types.EventDataNewBlockHeader:
+		return data.Header.Height, true
+	case types.EventDataNewBlockEvents:
+		return data.Height, true
+	case types.EventDataNewEvidence:
+		return data.Height, true
+	case types.EventDataTx:
+		return data.Height, true
+	default:
+		return 0, false
+	}
+}
+
 // Unsubscribe from events via WebSocket.
 // More: https://docs.cometbft.com/main/rpc/#/Websocket/unsubscribe
 func (env *Environment) Unsubscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultUnsubscribe, error) {
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// ErrExecutionReportsDisabled is returned when execution reports are not
+// recorded by the node.
+var ErrExecutionReportsDisabled = errors.New("execution reports are disabled")
+
+// ExecutionReport returns the resources used to execute the block at the
+// given height: execution time, gas, events emitted, index keys added and
+// bytes written per store. If no height is provided, it defaults to the
+// latest executed block. Only the reports of the last executed blocks are
+// retained, in memory.
+// More: https://docs.cometbft.com/main/rpc/#/Info/execution_report
+func (env *Environment) ExecutionReport(_ *rpctypes.Context, heightPtr *int64) (*ctypes.ResultExecutionReport, error) {
+	if env.ExecutionReporter == nil {
+		return nil, ErrExecutionReportsDisabled
+	}
+
+	var height int64
+	if heightPtr != nil {
+		if *heightPtr <= 0 {
+			return nil, fmt.Errorf("height must be greater than 0, but got %d", *heightPtr)
+		}
+		height = *heightPtr
+	}
+	report, ok := env.ExecutionReporter.Report(height)
+	if !ok {
+		if height == 0 {
+			return nil, errors.New("no block executed yet")
+		}
+		return nil, fmt.Errorf("execution report of height %d is not available", height)
+	}
+
+	stores := make([]ctypes.StoreWritten, 0, len(report.BytesWritten))
+	for store, bytes := range report.BytesWritten {
+		stores = append(stores, ctypes.StoreWritten{Store: store, Bytes: bytes})
+	}
+	sort.Slice(stores, func(i, j int) bool {
+		return stores[i].Store < stores[j].Store
+	})
+
+	return &ctypes.ResultExecutionReport{
+		Height:         report.Height,
+		ExecutionTime:  report.ExecutionTime,
+		NumTxs:         report.NumTxs,
+		GasWanted:      report.GasWanted,
+		GasUsed:        report.GasUsed,
+		EventsEmitted:  report.EventsEmitted,
+		IndexKeysAdded: report.IndexKeysAdded,
+		BytesWritten:   stores,
+	}, nil
+}
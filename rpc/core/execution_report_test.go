@@ -0,0 +1,45 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	sm "github.com/cometbft/cometbft/state"
+)
+
+func TestExecutionReport(t *testing.T) {
+	env := &Environment{}
+	_, err := env.ExecutionReport(&rpctypes.Context{}, nil)
+	require.ErrorIs(t, err, ErrExecutionReportsDisabled)
+
+	env.ExecutionReporter = sm.NewExecutionReporter(sm.NopMetrics())
+	_, err = env.ExecutionReport(&rpctypes.Context{}, nil)
+	require.Error(t, err)
+
+	env.ExecutionReporter.Record(sm.ExecutionReport{
+		Height:        5,
+		ExecutionTime: time.Second,
+		BytesWritten: map[string]int64{
+			sm.ReportStoreTxIndex:    30,
+			sm.ReportStoreBlockstore: 10,
+			sm.ReportStoreState:      20,
+		},
+	})
+	res, err := env.ExecutionReport(&rpctypes.Context{}, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, res.Height)
+	require.Equal(t, time.Second, res.ExecutionTime)
+	require.Len(t, res.BytesWritten, 3)
+	require.Equal(t, sm.ReportStoreBlockstore, res.BytesWritten[0].Store)
+	require.EqualValues(t, 10, res.BytesWritten[0].Bytes)
+
+	height := int64(4)
+	_, err = env.ExecutionReport(&rpctypes.Context{}, &height)
+	require.Error(t, err)
+	height = 0
+	_, err = env.ExecutionReport(&rpctypes.Context{}, &height)
+	require.Error(t, err)
+}
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"fmt"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// ExtendedCommit returns the precommits which committed the block at the
+// given height, along with their vote extensions. If no height is provided,
+// it defaults to the latest height.
+//
+// The extended commit is the one seen by the node, so it may not contain the
+// same precommits as the canonical commit, included in the next block. It's
+// only stored if vote extensions were enabled at that height, and is pruned
+// with the block.
+// More: https://docs.cometbft.com/main/rpc/#/Info/extended_commit
+func (env *Environment) ExtendedCommit(_ *rpctypes.Context, heightPtr *int64) (*ctypes.ResultExtendedCommit, error) {
+	height, err := env.getHeight(env.BlockStore.Height(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	extCommit := env.BlockStore.LoadBlockExtendedCommit(height)
+	if extCommit == nil {
+		return nil, fmt.Errorf("no extended commit stored at height %d, vote extensions may not be enabled", height)
+	}
+
+	sigs := make([]ctypes.ExtendedCommitSig, len(extCommit.ExtendedSignatures))
+	for i, sig := range extCommit.ExtendedSignatures {
+		sigs[i] = ctypes.ExtendedCommitSig{
+			BlockIDFlag:        sig.BlockIDFlag,
+			ValidatorAddress:   sig.ValidatorAddress,
+			Timestamp:          sig.Timestamp,
+			Signature:          sig.Signature,
+			Extension:          sig.Extension,
+			ExtensionSignature: sig.ExtensionSignature,
+		}
+	}
+	return &ctypes.ResultExtendedCommit{
+		Height:     extCommit.Height,
+		Round:      extCommit.Round,
+		BlockID:    extCommit.BlockID,
+		Signatures: sigs,
+	}, nil
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestExtendedCommit(t *testing.T) {
+	now := time.Now()
+	blockID := types.BlockID{Hash: []byte("hash")}
+	extCommit := &types.ExtendedCommit{
+		Height:  10,
+		Round:   1,
+		BlockID: blockID,
+		ExtendedSignatures: []types.ExtendedCommitSig{{
+			CommitSig: types.CommitSig{
+				BlockIDFlag:      types.BlockIDFlagCommit,
+				ValidatorAddress: []byte("validator"),
+				Timestamp:        now,
+				Signature:        []byte("signature"),
+			},
+			Extension:          []byte("extension"),
+			ExtensionSignature: []byte("extension signature"),
+		}},
+	}
+
+	mockstore := &mocks.BlockStore{}
+	mockstore.On("Height").Return(int64(10))
+	mockstore.On("Base").Return(int64(1))
+	mockstore.On("LoadBlockExtendedCommit", int64(10)).Return(extCommit)
+	mockstore.On("LoadBlockExtendedCommit", int64(5)).Return(nil)
+	env := &Environment{BlockStore: mockstore}
+
+	testCases := []struct {
+		height  int64
+		wantErr bool
+		wantRes *ctypes.ResultExtendedCommit
+	}{
+		{0, true, nil},
+		{11, true, nil},
+		{5, true, nil},
+		{10, false, &ctypes.ResultExtendedCommit{
+			Height:  10,
+			Round:   1,
+			BlockID: blockID,
+			Signatures: []ctypes.ExtendedCommitSig{{
+				BlockIDFlag:        types.BlockIDFlagCommit,
+				ValidatorAddress:   []byte("validator"),
+				Timestamp:          now,
+				Signature:          []byte("signature"),
+				Extension:          []byte("extension"),
+				ExtensionSignature: []byte("extension signature"),
+			}},
+		}},
+	}
+
+	for _, tc := range testCases {
+		res, err := env.ExtendedCommit(&rpctypes.Context{}, &tc.height)
+		if tc.wantErr {
+			assert.Error(t, err)
+		} else {
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		}
+	}
+}
@@ -1,13 +1,102 @@
 package core
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/state/txindex/null"
 )
 
+// healthCheckTimeout is the timeout of the check of the ABCI connection.
+const healthCheckTimeout = 2 * time.Second
+
 // Health gets node health. Returns empty result (200 OK) on success, no
 // response - in case of an error.
+//
+// If verbose, the readiness of the components of the node is checked: the
+// consensus engaged, i.e. not catching up, the ABCI connection alive, the
+// database writable, the indexer lagging at most rpc.health_max_indexer_lag
+// blocks behind and at least rpc.health_min_peers peers. The request of the
+// /health?verbose=true URI then gets a 503 status if any check fails, for the
+// health checks of load balancers.
 // More: https://docs.cometbft.com/main/rpc/#/Info/health
-func (env *Environment) Health(*rpctypes.Context) (*ctypes.ResultHealth, error) {
-	return &ctypes.ResultHealth{}, nil
+func (env *Environment) Health(ctx *rpctypes.Context, verbose bool) (*ctypes.ResultHealth, error) {
+	if !verbose {
+		return &ctypes.ResultHealth{}, nil
+	}
+
+	checks := []ctypes.HealthCheck{
+		env.checkConsensusHealth(),
+		env.checkABCIHealth(ctx.Context()),
+	}
+	if env.CheckDBWritable != nil {
+		checks = append(checks, env.checkDBHealth())
+	}
+	if _, ok := env.TxIndexer.(*null.TxIndex); !ok && env.IndexerService != nil {
+		checks = append(checks, env.checkIndexerHealth())
+	}
+	checks = append(checks, env.checkPeersHealth())
+
+	result := &ctypes.ResultHealth{Status: ctypes.HealthStatusHealthy, Checks: checks}
+	for _, check := range checks {
+		if !check.Healthy {
+			result.Status = ctypes.HealthStatusUnhealthy
+			break
+		}
+	}
+	return result, nil
+}
+
+func (env *Environment) checkConsensusHealth() ctypes.HealthCheck {
+	if env.ConsensusReactor.WaitSync() {
+		return ctypes.HealthCheck{Name: "consensus", Message: "catching up"}
+	}
+	return ctypes.HealthCheck{
+		Name:    "consensus",
+		Healthy: true,
+		Message: fmt.Sprintf("height %d", env.BlockStore.Height()),
+	}
+}
+
+func (env *Environment) checkABCIHealth(ctx context.Context) ctypes.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	if _, err := env.ProxyAppQuery.Echo(ctx, "health"); err != nil {
+		return ctypes.HealthCheck{Name: "abci", Message: err.Error()}
+	}
+	return ctypes.HealthCheck{Name: "abci", Healthy: true}
+}
+
+func (env *Environment) checkDBHealth() ctypes.HealthCheck {
+	if err := env.CheckDBWritable(); err != nil {
+		return ctypes.HealthCheck{Name: "db", Message: err.Error()}
+	}
+	return ctypes.HealthCheck{Name: "db", Healthy: true}
+}
+
+func (env *Environment) checkIndexerHealth() ctypes.HealthCheck {
+	indexed := env.IndexerService.IndexedHeight()
+	if indexed == 0 {
+		return ctypes.HealthCheck{Name: "indexer", Healthy: true, Message: "no height indexed since the node started"}
+	}
+	lag := max(env.BlockStore.Height()-indexed, 0)
+	maxLag := env.Config.HealthMaxIndexerLag
+	return ctypes.HealthCheck{
+		Name:    "indexer",
+		Healthy: maxLag == 0 || lag <= maxLag,
+		Message: fmt.Sprintf("%d blocks behind, maximum %d", lag, maxLag),
+	}
+}
+
+func (env *Environment) checkPeersHealth() ctypes.HealthCheck {
+	numPeers := env.P2PPeers.Peers().Size()
+	minPeers := env.Config.HealthMinPeers
+	return ctypes.HealthCheck{
+		Name:    "peers",
+		Healthy: numPeers >= minPeers,
+		Message: fmt.Sprintf("%d peers, minimum %d", numPeers, minPeers),
+	}
 }
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/proxy"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/state/mocks"
+)
+
+type fakeSyncReactor struct{ syncing bool }
+
+func (r fakeSyncReactor) WaitSync() bool { return r.syncing }
+
+func TestHealth(t *testing.T) {
+	sw := p2p.MakeSwitch(cfg.DefaultP2PConfig(), 1,
+		func(n int, sw *p2p.Switch) *p2p.Switch { return sw })
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Height").Return(int64(100))
+
+	env := &Environment{
+		ProxyAppQuery:    proxy.NewAppConnQuery(abcicli.NewLocalClient(nil, kvstore.NewInMemoryApplication()), proxy.NopMetrics()),
+		BlockStore:       blockStore,
+		ConsensusReactor: fakeSyncReactor{},
+		P2PPeers:         sw,
+		IndexerService:   fakeIndexerService{height: 90},
+		CheckDBWritable:  func() error { return nil },
+		Config:           *cfg.DefaultRPCConfig(),
+	}
+
+	// Not verbose, no check.
+	res, err := env.Health(&rpctypes.Context{}, false)
+	require.NoError(t, err)
+	require.Equal(t, &ctypes.ResultHealth{}, res)
+	require.Equal(t, http.StatusOK, res.HTTPStatus())
+
+	// The node has no peers.
+	env.Config.HealthMinPeers = 0
+	res, err = env.Health(&rpctypes.Context{}, true)
+	require.NoError(t, err)
+	require.Equal(t, &ctypes.ResultHealth{
+		Status: ctypes.HealthStatusHealthy,
+		Checks: []ctypes.HealthCheck{
+			{Name: "consensus", Healthy: true, Message: "height 100"},
+			{Name: "abci", Healthy: true},
+			{Name: "db", Healthy: true},
+			{Name: "indexer", Healthy: true, Message: "10 blocks behind, maximum 100"},
+			{Name: "peers", Healthy: true, Message: "0 peers, minimum 0"},
+		},
+	}, res)
+	require.Equal(t, http.StatusOK, res.HTTPStatus())
+
+	env.Config.HealthMinPeers = 1
+	env.Config.HealthMaxIndexerLag = 5
+	env.ConsensusReactor = fakeSyncReactor{syncing: true}
+	env.CheckDBWritable = func() error { return errors.New("read-only") }
+	res, err = env.Health(&rpctypes.Context{}, true)
+	require.NoError(t, err)
+	require.Equal(t, &ctypes.ResultHealth{
+		Status: ctypes.HealthStatusUnhealthy,
+		Checks: []ctypes.HealthCheck{
+			{Name: "consensus", Message: "catching up"},
+			{Name: "abci", Healthy: true},
+			{Name: "db", Message: "read-only"},
+			{Name: "indexer", Message: "10 blocks behind, maximum 5"},
+			{Name: "peers", Message: "0 peers, minimum 1"},
+		},
+	}, res)
+	require.Equal(t, http.StatusServiceUnavailable, res.HTTPStatus())
+}
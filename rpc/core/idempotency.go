@@ -0,0 +1,161 @@
+package core
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/types"
+)
+
+var (
+	// ErrIdempotencyKeysDisabled is returned when a request carries an
+	// idempotency key but the node does not remember them.
+	ErrIdempotencyKeysDisabled = errors.New("idempotency keys are disabled on this node")
+	// ErrIdempotencyKeyReused is returned when an idempotency key is reused
+	// with a different transaction.
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different transaction")
+)
+
+// idempotentBroadcast is the outcome of a broadcast request carrying an
+// idempotency key.
+type idempotentBroadcast struct {
+	key     string
+	txHash  []byte
+	created time.Time
+
+	// done is closed once the CheckTx response or the error is known.
+	done    chan struct{}
+	checkTx *abci.ResponseCheckTx
+	err     error
+}
+
+// wait returns the CheckTx response of the original request, or the error it
+// failed with.
+func (b *idempotentBroadcast) wait(ctx context.Context) (*abci.ResponseCheckTx, error) {
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("broadcast confirmation not received: %w", ctx.Err())
+	case <-b.done:
+		return b.checkTx, b.err
+	}
+}
+
+// idempotencyCache remembers the outcome of the most recent broadcast
+// requests by their idempotency key, up to a maximum number of keys and for
+// a limited time.
+type idempotencyCache struct {
+	size int
+	ttl  time.Duration
+
+	mtx     cmtsync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // oldest first
+}
+
+func newIdempotencyCache(size int, ttl time.Duration) *idempotencyCache {
+	return &idempotencyCache{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// claim returns the broadcast remembered for the key, and whether it was
+// created by this call, in which case the caller must broadcast the tx and
+// complete or fail it.
+func (c *idempotencyCache) claim(key string, tx types.Tx, now time.Time) (*idempotentBroadcast, bool, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for e := c.order.Front(); e != nil; e = c.order.Front() {
+		if now.Sub(e.Value.(*idempotentBroadcast).created) < c.ttl {
+			break
+		}
+		c.removeElement(e)
+	}
+
+	txHash := tx.Hash()
+	if e, ok := c.entries[key]; ok {
+		b := e.Value.(*idempotentBroadcast)
+		if !bytes.Equal(b.txHash, txHash) {
+			return nil, false, ErrIdempotencyKeyReused
+		}
+		return b, false, nil
+	}
+
+	for c.order.Len() >= c.size {
+		c.removeElement(c.order.Front())
+	}
+	b := &idempotentBroadcast{
+		key:     key,
+		txHash:  txHash,
+		created: now,
+		done:    make(chan struct{}),
+	}
+	c.entries[key] = c.order.PushBack(b)
+	return b, true, nil
+}
+
+// complete records the CheckTx response of a claimed broadcast.
+func (c *idempotencyCache) complete(b *idempotentBroadcast, res *abci.ResponseCheckTx) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	select {
+	case <-b.done:
+		return
+	default:
+	}
+	b.checkTx = res
+	close(b.done)
+}
+
+// fail records the error of a claimed broadcast, and forgets its key so that
+// the request can be retried.
+func (c *idempotencyCache) fail(b *idempotentBroadcast, err error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	select {
+	case <-b.done:
+		return
+	default:
+	}
+	b.err = err
+	close(b.done)
+	if e, ok := c.entries[b.key]; ok && e.Value == b {
+		c.removeElement(e)
+	}
+}
+
+func (c *idempotencyCache) removeElement(e *list.Element) {
+	c.order.Remove(e)
+	delete(c.entries, e.Value.(*idempotentBroadcast).key)
+}
+
+// claimIdempotencyKey returns the broadcast remembered for the idempotency
+// key, and whether it was created by this call. It returns nil if the key is
+// empty.
+func (env *Environment) claimIdempotencyKey(key string, tx types.Tx) (*idempotentBroadcast, bool, error) {
+	if key == "" {
+		return nil, false, nil
+	}
+	if env.Config.IdempotencyKeyCacheSize == 0 {
+		return nil, false, ErrIdempotencyKeysDisabled
+	}
+
+	env.idempotencyMtx.Lock()
+	if env.idempotencyKeys == nil {
+		env.idempotencyKeys = newIdempotencyCache(env.Config.IdempotencyKeyCacheSize, env.Config.IdempotencyKeyTTL)
+	}
+	env.idempotencyMtx.Unlock()
+
+	return env.idempotencyKeys.claim(key, tx, time.Now())
+}
@@ -0,0 +1,190 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	abci "github.com/cometbft/cometbft/abci/types"
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/libs/log"
+	mpmocks "github.com/cometbft/cometbft/mempool/mocks"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestIdempotencyCache(t *testing.T) {
+	c := newIdempotencyCache(2, time.Minute)
+	now := time.Now()
+	tx1, tx2 := types.Tx("tx1"), types.Tx("tx2")
+
+	b1, claimed, err := c.claim("a", tx1, now)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	// A duplicate request gets the original broadcast.
+	b, claimed, err := c.claim("a", tx1, now)
+	require.NoError(t, err)
+	require.False(t, claimed)
+	require.Same(t, b1, b)
+
+	// The key cannot be reused for another tx.
+	_, _, err = c.claim("a", tx2, now)
+	require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+
+	// A failed broadcast releases its key.
+	b2, claimed, err := c.claim("b", tx2, now)
+	require.NoError(t, err)
+	require.True(t, claimed)
+	c.fail(b2, errors.New("mempool is full"))
+	_, err = b2.wait(context.Background())
+	require.Error(t, err)
+	_, claimed, err = c.claim("b", tx2, now)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	// The least recent key is evicted once the cache is full.
+	_, claimed, err = c.claim("c", tx2, now)
+	require.NoError(t, err)
+	require.True(t, claimed)
+	_, claimed, err = c.claim("a", tx1, now)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	// Keys expire after the TTL.
+	_, claimed, err = c.claim("c", tx2, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.True(t, claimed)
+	require.Equal(t, 1, c.order.Len())
+}
+
+type syncedReactor struct{}
+
+func (syncedReactor) WaitSync() bool { return false }
+
+func checkTxReqRes(code uint32) *abcicli.ReqRes {
+	reqRes := abcicli.NewReqRes(nil)
+	reqRes.Response = abci.ToResponseCheckTx(&abci.ResponseCheckTx{Code: code})
+	reqRes.InvokeCallback()
+	return reqRes
+}
+
+func TestBroadcastTxSyncIdempotencyKey(t *testing.T) {
+	mp := &mpmocks.Mempool{}
+	env := &Environment{
+		Mempool:        mp,
+		MempoolReactor: syncedReactor{},
+		Config:         *cfg.DefaultRPCConfig(),
+	}
+	ctx := &rpctypes.Context{}
+	tx := types.Tx("tx")
+
+	// The tx is broadcast only once for the same key.
+	mp.On("CheckTx", tx).Return(checkTxReqRes(abci.CodeTypeOK), nil).Once()
+	res, err := env.BroadcastTxSync(ctx, tx, "key")
+	require.NoError(t, err)
+	require.Equal(t, abci.CodeTypeOK, res.Code)
+	res, err = env.BroadcastTxSync(ctx, tx, "key")
+	require.NoError(t, err)
+	require.Equal(t, abci.CodeTypeOK, res.Code)
+	require.Equal(t, tx.Hash(), []byte(res.Hash))
+	mp.AssertExpectations(t)
+
+	_, err = env.BroadcastTxAsync(ctx, types.Tx("other"), "key")
+	require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+
+	// Rejected txs keep their outcome, while failed broadcasts can be retried.
+	rejected := types.Tx("rejected")
+	mp.On("CheckTx", rejected).Return(checkTxReqRes(1), nil).Once()
+	for i := 0; i < 2; i++ {
+		res, err = env.BroadcastTxSync(ctx, rejected, "rejected")
+		require.NoError(t, err)
+		require.Equal(t, uint32(1), res.Code)
+	}
+
+	failed := types.Tx("failed")
+	mp.On("CheckTx", failed).Return(nil, errors.New("mempool is full")).Twice()
+	for i := 0; i < 2; i++ {
+		_, err = env.BroadcastTxSync(ctx, failed, "failed")
+		require.Error(t, err)
+	}
+	mp.AssertExpectations(t)
+
+	// Without a key, the tx is broadcast on each request.
+	mp.On("CheckTx", tx).Return(checkTxReqRes(abci.CodeTypeOK), nil).Twice()
+	for i := 0; i < 2; i++ {
+		_, err = env.BroadcastTxSync(ctx, tx, "")
+		require.NoError(t, err)
+	}
+	mp.AssertExpectations(t)
+
+	env.Config.IdempotencyKeyCacheSize = 0
+	env.idempotencyKeys = nil
+	_, err = env.BroadcastTxSync(ctx, tx, "key")
+	require.ErrorIs(t, err, ErrIdempotencyKeysDisabled)
+}
+
+func TestBroadcastTxCommitTimeout(t *testing.T) {
+	mp := &mpmocks.Mempool{}
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	env := &Environment{
+		Mempool:        mp,
+		MempoolReactor: syncedReactor{},
+		EventBus:       eventBus,
+		Config:         *cfg.DefaultRPCConfig(),
+		Logger:         log.NewNopLogger(),
+	}
+	env.Config.TimeoutBroadcastTxCommit = time.Second
+	ctx := &rpctypes.Context{}
+	tx := types.Tx("tx")
+	mp.On("CheckTx", tx).Return(checkTxReqRes(abci.CodeTypeOK), nil)
+
+	// The tx is accepted but not committed before the timeout.
+	res, err := env.BroadcastTxCommit(ctx, tx, "", "10ms")
+	require.NoError(t, err)
+	require.Equal(t, ctypes.BroadcastTxPending, res.Status)
+	require.Equal(t, abci.CodeTypeOK, res.CheckTx.Code)
+	require.Zero(t, res.Height)
+
+	// The tx is committed before the timeout.
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(10 * time.Millisecond):
+				err := eventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{Height: 3, Tx: tx}})
+				require.NoError(t, err)
+			}
+		}
+	}()
+	res, err = env.BroadcastTxCommit(ctx, tx, "", "")
+	close(done)
+	require.NoError(t, err)
+	require.Equal(t, ctypes.BroadcastTxCommitted, res.Status)
+	require.EqualValues(t, 3, res.Height)
+
+	rejected := types.Tx("rejected")
+	mp.On("CheckTx", rejected).Return(checkTxReqRes(1), nil)
+	res, err = env.BroadcastTxCommit(ctx, rejected, "", "")
+	require.NoError(t, err)
+	require.Equal(t, ctypes.BroadcastTxRejected, res.Status)
+
+	// The timeout is bounded by timeout_broadcast_tx_commit.
+	for _, timeout := range []string{"2s", "0s", "-1s", "soon"} {
+		_, err = env.BroadcastTxCommit(ctx, tx, "", timeout)
+		require.Error(t, err, timeout)
+	}
+}
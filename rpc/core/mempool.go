@@ -14,63 +14,98 @@ import (
 
 var ErrEndpointClosedCatchingUp = errors.New("endpoint is closed while node is catching up")
 
+// ErrDroppedTxsNotRecorded is returned when the mempool of the node does not
+// record the dropped txs.
+var ErrDroppedTxsNotRecorded = errors.New("dropped txs are not recorded by the mempool")
+
 //-----------------------------------------------------------------------------
 // NOTE: tx should be signed, but this is only checked at the app level (not by CometBFT!)
 
 // BroadcastTxAsync returns right away, with no response. Does not wait for
 // CheckTx nor transaction results.
+//
+// If an idempotency key is given and a request with the same key and tx was
+// made recently, the tx is not broadcast again.
 // More: https://docs.cometbft.com/main/rpc/#/Tx/broadcast_tx_async
-func (env *Environment) BroadcastTxAsync(_ *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+func (env *Environment) BroadcastTxAsync(
+	_ *rpctypes.Context,
+	tx types.Tx,
+	idempotencyKey string,
+) (*ctypes.ResultBroadcastTx, error) {
 	if env.MempoolReactor.WaitSync() {
 		return nil, ErrEndpointClosedCatchingUp
 	}
-	_, err := env.Mempool.CheckTx(tx)
+
+	broadcast, claimed, err := env.claimIdempotencyKey(idempotencyKey, tx)
 	if err != nil {
 		return nil, err
 	}
+	if broadcast != nil && !claimed {
+		return &ctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
+	}
+
+	if err := env.broadcastTx(tx, broadcast, func(*abci.ResponseCheckTx) {}); err != nil {
+		return nil, err
+	}
 	return &ctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
 }
 
 // BroadcastTxSync returns with the response from CheckTx. Does not wait for
 // the transaction result.
+//
+// If an idempotency key is given and a request with the same key and tx was
+// made recently, the tx is not broadcast again and the response from CheckTx
+// to the original request is returned.
 // More: https://docs.cometbft.com/main/rpc/#/Tx/broadcast_tx_sync
-func (env *Environment) BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+func (env *Environment) BroadcastTxSync(
+	ctx *rpctypes.Context,
+	tx types.Tx,
+	idempotencyKey string,
+) (*ctypes.ResultBroadcastTx, error) {
 	if env.MempoolReactor.WaitSync() {
 		return nil, ErrEndpointClosedCatchingUp
 	}
 
-	resCh := make(chan *abci.ResponseCheckTx, 1)
-	reqRes, err := env.Mempool.CheckTx(tx)
+	res, err := env.broadcastTxAndWait(ctx, tx, idempotencyKey)
 	if err != nil {
 		return nil, err
 	}
-	reqRes.SetCallback(func(res *abci.Response) {
-		select {
-		case <-ctx.Context().Done():
-		case resCh <- reqRes.Response.GetCheckTx():
-		}
-	})
-	select {
-	case <-ctx.Context().Done():
-		return nil, fmt.Errorf("broadcast confirmation not received: %w", ctx.Context().Err())
-	case res := <-resCh:
-		return &ctypes.ResultBroadcastTx{
-			Code:      res.Code,
-			Data:      res.Data,
-			Log:       res.Log,
-			Codespace: res.Codespace,
-			Hash:      tx.Hash(),
-		}, nil
-	}
+	return &ctypes.ResultBroadcastTx{
+		Code:      res.Code,
+		Data:      res.Data,
+		Log:       res.Log,
+		Codespace: res.Codespace,
+		Hash:      tx.Hash(),
+	}, nil
 }
 
 // BroadcastTxCommit returns with the responses from CheckTx and ExecTxResult.
+//
+// The tx is waited for until the given timeout, a duration such as "5s", or
+// timeout_broadcast_tx_commit if empty, which also bounds it. If the tx is
+// accepted but not committed by then, the response from CheckTx is returned
+// with the pending status.
+//
+// If an idempotency key is given and a request with the same key and tx was
+// made recently, the tx is not broadcast again. The response from CheckTx to
+// the original request is returned, along with the result of the tx if it
+// was already committed.
 // More: https://docs.cometbft.com/main/rpc/#/Tx/broadcast_tx_commit
-func (env *Environment) BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+func (env *Environment) BroadcastTxCommit(
+	ctx *rpctypes.Context,
+	tx types.Tx,
+	idempotencyKey string,
+	timeout string,
+) (*ctypes.ResultBroadcastTxCommit, error) {
 	if env.MempoolReactor.WaitSync() {
 		return nil, ErrEndpointClosedCatchingUp
 	}
 
+	commitTimeout, err := env.commitTimeout(timeout)
+	if err != nil {
+		return nil, err
+	}
+
 	subscriber := ctx.RemoteAddr()
 
 	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
@@ -96,74 +131,173 @@ func (env *Environment) BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*
 	}()
 
 	// Broadcast tx and wait for CheckTx result
-	checkTxResCh := make(chan *abci.ResponseCheckTx, 1)
-	reqRes, err := env.Mempool.CheckTx(tx)
+	checkTxRes, err := env.broadcastTxAndWait(ctx, tx, idempotencyKey)
 	if err != nil {
 		env.Logger.Error("Error on broadcastTxCommit", "err", err)
-		return nil, fmt.Errorf("error on broadcastTxCommit: %v", err)
+		return nil, fmt.Errorf("error on broadcastTxCommit: %w", err)
 	}
-	reqRes.SetCallback(func(res *abci.Response) {
-		select {
-		case <-ctx.Context().Done():
-		case checkTxResCh <- reqRes.Response.GetCheckTx():
-		}
-	})
-	select {
-	case <-ctx.Context().Done():
-		return nil, fmt.Errorf("broadcast confirmation not received: %w", ctx.Context().Err())
-	case checkTxRes := <-checkTxResCh:
-		if checkTxRes.Code != abci.CodeTypeOK {
+	if checkTxRes.Code != abci.CodeTypeOK {
+		return &ctypes.ResultBroadcastTxCommit{
+			CheckTx:  *checkTxRes,
+			TxResult: abci.ExecTxResult{},
+			Hash:     tx.Hash(),
+			Status:   ctypes.BroadcastTxRejected,
+		}, nil
+	}
+
+	// A duplicate request may be made after the tx was committed.
+	if idempotencyKey != "" {
+		if txResult, err := env.TxIndexer.Get(tx.Hash()); err == nil && txResult != nil {
 			return &ctypes.ResultBroadcastTxCommit{
 				CheckTx:  *checkTxRes,
-				TxResult: abci.ExecTxResult{},
+				TxResult: txResult.Result,
 				Hash:     tx.Hash(),
+				Height:   txResult.Height,
+				Status:   ctypes.BroadcastTxCommitted,
 			}, nil
 		}
+	}
+
+	// Wait for the tx to be included in a block or timeout.
+	select {
+	case msg := <-txSub.Out(): // The tx was included in a block.
+		txResultEvent := msg.Data().(types.EventDataTx)
+		return &ctypes.ResultBroadcastTxCommit{
+			CheckTx:  *checkTxRes,
+			TxResult: txResultEvent.Result,
+			Hash:     tx.Hash(),
+			Height:   txResultEvent.Height,
+			Status:   ctypes.BroadcastTxCommitted,
+		}, nil
+	case <-txSub.Canceled():
+		var reason string
+		if txSub.Err() == nil {
+			reason = "CometBFT exited"
+		} else {
+			reason = txSub.Err().Error()
+		}
+		err = fmt.Errorf("txSub was canceled (reason: %s)", reason)
+		env.Logger.Error("Error on broadcastTxCommit", "err", err)
+		return &ctypes.ResultBroadcastTxCommit{
+			CheckTx:  *checkTxRes,
+			TxResult: abci.ExecTxResult{},
+			Hash:     tx.Hash(),
+		}, err
+	case <-time.After(commitTimeout):
+		env.Logger.Info("Timed out waiting for tx to be included in a block", "hash", tx.Hash(), "timeout", commitTimeout)
+		return &ctypes.ResultBroadcastTxCommit{
+			CheckTx:  *checkTxRes,
+			TxResult: abci.ExecTxResult{},
+			Hash:     tx.Hash(),
+			Status:   ctypes.BroadcastTxPending,
+		}, nil
+	}
+}
+
+// commitTimeout returns the duration to wait for a tx to be committed, given
+// the timeout of a request, or timeout_broadcast_tx_commit if empty, which
+// also bounds it.
+func (env *Environment) commitTimeout(timeout string) (time.Duration, error) {
+	maxTimeout := env.Config.TimeoutBroadcastTxCommit
+	if timeout == "" {
+		return maxTimeout, nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout: %w", err)
+	}
+	if d <= 0 || d > maxTimeout {
+		return 0, fmt.Errorf("timeout must be positive and at most %v (timeout_broadcast_tx_commit)", maxTimeout)
+	}
+	return d, nil
+}
+
+// broadcastTx adds the tx to the mempool and calls cb with the response from
+// CheckTx. If the request carries an idempotency key, its outcome is recorded
+// in the given broadcast.
+func (env *Environment) broadcastTx(tx types.Tx, broadcast *idempotentBroadcast, cb func(*abci.ResponseCheckTx)) error {
+	reqRes, err := env.Mempool.CheckTx(tx)
+	if err != nil {
+		if broadcast != nil {
+			env.idempotencyKeys.fail(broadcast, err)
+		}
+		return err
+	}
+	reqRes.SetCallback(func(*abci.Response) {
+		res := reqRes.Response.GetCheckTx()
+		if broadcast != nil {
+			env.idempotencyKeys.complete(broadcast, res)
+		}
+		cb(res)
+	})
+	return nil
+}
 
-		// Wait for the tx to be included in a block or timeout.
+// broadcastTxAndWait adds the tx to the mempool, or finds the broadcast of a
+// previous request with the same idempotency key, and returns the response
+// from CheckTx.
+func (env *Environment) broadcastTxAndWait(
+	ctx *rpctypes.Context,
+	tx types.Tx,
+	idempotencyKey string,
+) (*abci.ResponseCheckTx, error) {
+	broadcast, claimed, err := env.claimIdempotencyKey(idempotencyKey, tx)
+	if err != nil {
+		return nil, err
+	}
+	if broadcast != nil && !claimed {
+		return broadcast.wait(ctx.Context())
+	}
+
+	resCh := make(chan *abci.ResponseCheckTx, 1)
+	err = env.broadcastTx(tx, broadcast, func(res *abci.ResponseCheckTx) {
 		select {
-		case msg := <-txSub.Out(): // The tx was included in a block.
-			txResultEvent := msg.Data().(types.EventDataTx)
-			return &ctypes.ResultBroadcastTxCommit{
-				CheckTx:  *checkTxRes,
-				TxResult: txResultEvent.Result,
-				Hash:     tx.Hash(),
-				Height:   txResultEvent.Height,
-			}, nil
-		case <-txSub.Canceled():
-			var reason string
-			if txSub.Err() == nil {
-				reason = "CometBFT exited"
-			} else {
-				reason = txSub.Err().Error()
-			}
-			err = fmt.Errorf("txSub was canceled (reason: %s)", reason)
-			env.Logger.Error("Error on broadcastTxCommit", "err", err)
-			return &ctypes.ResultBroadcastTxCommit{
-				CheckTx:  *checkTxRes,
-				TxResult: abci.ExecTxResult{},
-				Hash:     tx.Hash(),
-			}, err
-		case <-time.After(env.Config.TimeoutBroadcastTxCommit):
-			err = errors.New("timed out waiting for tx to be included in a block")
-			env.Logger.Error("Error on broadcastTxCommit", "err", err)
-			return &ctypes.ResultBroadcastTxCommit{
-				CheckTx:  *checkTxRes,
-				TxResult: abci.ExecTxResult{},
-				Hash:     tx.Hash(),
-			}, err
+		case <-ctx.Context().Done():
+		case resCh <- res:
 		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-ctx.Context().Done():
+		return nil, fmt.Errorf("broadcast confirmation not received: %w", ctx.Context().Err())
+	case res := <-resCh:
+		return res, nil
 	}
 }
 
 // UnconfirmedTxs gets unconfirmed transactions (maximum ?limit entries)
 // including their number.
+//
+// If a query is given, only the transactions it matches are returned. The
+// query is evaluated against the events emitted by the application when it
+// checked the transactions in CheckTx, with the reserved tx.hash key and, if
+// the application assigned a sender to the transaction, tx.sender key, e.g.
+// "tx.sender='addr'". Count is then the number of the matching transactions
+// returned.
 // More: https://docs.cometbft.com/main/rpc/#/Info/unconfirmed_txs
-func (env *Environment) UnconfirmedTxs(_ *rpctypes.Context, limitPtr *int) (*ctypes.ResultUnconfirmedTxs, error) {
+func (env *Environment) UnconfirmedTxs(
+	_ *rpctypes.Context,
+	limitPtr *int,
+	query string,
+) (*ctypes.ResultUnconfirmedTxs, error) {
 	// reuse per_page validator
 	limit := env.validatePerPage(limitPtr)
 
-	txs := env.Mempool.ReapMaxTxs(limit)
+	var txs types.Txs
+	if query == "" {
+		txs = env.Mempool.ReapMaxTxs(limit)
+	} else {
+		q, err := env.parseQuery(query)
+		if err != nil {
+			return nil, err
+		}
+		txs = env.Mempool.ReapMatchingTxs(limit, func(tx types.Tx, sender string, events []abci.Event) bool {
+			matches, err := q.MatchesEvents(mempoolTxEvents(tx, sender, events), events)
+			return err == nil && matches
+		})
+	}
 	return &ctypes.ResultUnconfirmedTxs{
 		Count:      len(txs),
 		Total:      env.Mempool.Size(),
@@ -172,6 +306,29 @@ func (env *Environment) UnconfirmedTxs(_ *rpctypes.Context, limitPtr *int) (*cty
 	}, nil
 }
 
+// mempoolTxEvents returns the flattened CheckTx events of a tx in the
+// mempool, with the reserved tx.hash and tx.sender keys.
+func mempoolTxEvents(tx types.Tx, sender string, events []abci.Event) map[string][]string {
+	flattened := make(map[string][]string)
+	for _, event := range events {
+		if event.Type == "" {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key == "" {
+				continue
+			}
+			compositeKey := event.Type + "." + attr.Key
+			flattened[compositeKey] = append(flattened[compositeKey], attr.Value)
+		}
+	}
+	flattened[types.TxHashKey] = append(flattened[types.TxHashKey], fmt.Sprintf("%X", tx.Hash()))
+	if sender != "" {
+		flattened[types.TxSenderKey] = append(flattened[types.TxSenderKey], sender)
+	}
+	return flattened
+}
+
 // NumUnconfirmedTxs gets number of unconfirmed transactions.
 // More: https://docs.cometbft.com/main/rpc/#/Info/num_unconfirmed_txs
 func (env *Environment) NumUnconfirmedTxs(*rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, error) {
@@ -182,6 +339,46 @@ func (env *Environment) NumUnconfirmedTxs(*rpctypes.Context) (*ctypes.ResultUnco
 	}, nil
 }
 
+// DroppedTxs gets the number of transactions rejected or evicted from the
+// mempool for each reason, and the last dropped transactions, or only those
+// with the given ?hash.
+// More: https://docs.cometbft.com/main/rpc/#/Info/dropped_txs
+func (env *Environment) DroppedTxs(_ *rpctypes.Context, hash []byte) (*ctypes.ResultDroppedTxs, error) {
+	if env.MempoolDropLog == nil {
+		return nil, ErrDroppedTxsNotRecorded
+	}
+
+	var txKey *types.TxKey
+	if len(hash) > 0 {
+		if len(hash) != types.TxKeySize {
+			return nil, fmt.Errorf("hash must be %d bytes long, but got %d", types.TxKeySize, len(hash))
+		}
+		txKey = new(types.TxKey)
+		copy(txKey[:], hash)
+	}
+
+	counts := env.MempoolDropLog.Counts()
+	resCounts := make([]ctypes.DropCount, len(counts))
+	for i, count := range counts {
+		resCounts[i] = ctypes.DropCount{Kind: string(count.Kind), Reason: count.Reason, Count: count.Count}
+	}
+	txs := env.MempoolDropLog.Txs(txKey)
+	resTxs := make([]ctypes.DroppedTx, len(txs))
+	for i, tx := range txs {
+		resTxs[i] = ctypes.DroppedTx{
+			Hash:      tx.Hash,
+			Kind:      string(tx.Kind),
+			Reason:    tx.Reason,
+			Code:      tx.Code,
+			Codespace: tx.Codespace,
+			Log:       tx.Log,
+			Height:    tx.Height,
+			Time:      tx.Time,
+		}
+	}
+	return &ctypes.ResultDroppedTxs{Counts: resCounts, Txs: resTxs}, nil
+}
+
 // CheckTx checks the transaction without executing it. The transaction won't
 // be added to the mempool either.
 // More: https://docs.cometbft.com/main/rpc/#/Tx/check_tx
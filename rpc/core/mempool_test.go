@@ -0,0 +1,124 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	mempl "github.com/cometbft/cometbft/mempool"
+	"github.com/cometbft/cometbft/proxy"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestDroppedTxs(t *testing.T) {
+	env := &Environment{}
+	_, err := env.DroppedTxs(&rpctypes.Context{}, nil)
+	require.ErrorIs(t, err, ErrDroppedTxsNotRecorded)
+
+	appConn, err := proxy.NewLocalClientCreator(kvstore.NewInMemoryApplication()).NewABCIMempoolClient()
+	require.NoError(t, err)
+	require.NoError(t, appConn.Start())
+	t.Cleanup(func() {
+		if err := appConn.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	mp := mempl.NewCListMempool(config.TestMempoolConfig(), appConn, 0)
+	env.MempoolDropLog = mp.DropLog()
+
+	invalid, other := types.Tx("invalid"), types.Tx("other")
+	for _, tx := range []types.Tx{invalid, other} {
+		_, err = mp.CheckTx(tx)
+		require.NoError(t, err)
+	}
+	require.NoError(t, mp.FlushAppConn())
+
+	res, err := env.DroppedTxs(&rpctypes.Context{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []ctypes.DropCount{{Kind: "rejected", Reason: "check_tx", Count: 2}}, res.Counts)
+	require.Len(t, res.Txs, 2)
+
+	res, err = env.DroppedTxs(&rpctypes.Context{}, invalid.Hash())
+	require.NoError(t, err)
+	require.Len(t, res.Txs, 1)
+	require.EqualValues(t, invalid.Hash(), res.Txs[0].Hash)
+	require.Equal(t, kvstore.CodeTypeInvalidTxFormat, res.Txs[0].Code)
+
+	_, err = env.DroppedTxs(&rpctypes.Context{}, []byte("short"))
+	require.Error(t, err)
+}
+
+// transferApp is a kvstore application emitting a transfer event with the
+// key of a tx as its sender, which it also assigns to the tx.
+type transferApp struct {
+	*kvstore.Application
+}
+
+func (app *transferApp) CheckTx(ctx context.Context, req *abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	res, err := app.Application.CheckTx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	sender, _, _ := bytes.Cut(req.Tx, []byte("="))
+	res.Sender = string(sender)
+	res.Events = []abci.Event{{
+		Type:       "transfer",
+		Attributes: []abci.EventAttribute{{Key: "sender", Value: string(sender)}},
+	}}
+	return res, nil
+}
+
+func TestUnconfirmedTxsQuery(t *testing.T) {
+	appConn, err := proxy.NewLocalClientCreator(&transferApp{kvstore.NewInMemoryApplication()}).NewABCIMempoolClient()
+	require.NoError(t, err)
+	require.NoError(t, appConn.Start())
+	t.Cleanup(func() {
+		if err := appConn.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	mp := mempl.NewCListMempool(config.TestMempoolConfig(), appConn, 0)
+	env := &Environment{Mempool: mp, Config: *config.DefaultRPCConfig()}
+
+	a1, b1, a2 := types.Tx("a=1"), types.Tx("b=1"), types.Tx("a=2")
+	for _, tx := range []types.Tx{a1, b1, a2} {
+		_, err = mp.CheckTx(tx)
+		require.NoError(t, err)
+	}
+	require.NoError(t, mp.FlushAppConn())
+
+	testCases := []struct {
+		query string
+		limit int
+		txs   []types.Tx
+	}{
+		{"", 30, []types.Tx{a1, b1, a2}},
+		{"transfer.sender='a'", 30, []types.Tx{a1, a2}},
+		{"transfer.sender='a'", 1, []types.Tx{a1}},
+		{"tx.sender='b'", 30, []types.Tx{b1}},
+		{fmt.Sprintf("tx.hash='%X'", a2.Hash()), 30, []types.Tx{a2}},
+		{"transfer.sender='c'", 30, []types.Tx{}},
+	}
+	for _, tc := range testCases {
+		limit := tc.limit
+		res, err := env.UnconfirmedTxs(&rpctypes.Context{}, &limit, tc.query)
+		require.NoError(t, err, tc.query)
+		require.Equal(t, tc.txs, res.Txs, tc.query)
+		require.Equal(t, len(tc.txs), res.Count, tc.query)
+		require.Equal(t, 3, res.Total, tc.query)
+	}
+
+	_, err = env.UnconfirmedTxs(&rpctypes.Context{}, nil, "transfer.sender=")
+	require.Error(t, err)
+	_, err = env.UnconfirmedTxs(&rpctypes.Context{}, nil, strings.Repeat("a", 513))
+	require.Error(t, err)
+}
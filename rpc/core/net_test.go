@@ -6,84 +6,43 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	cfg "github.com/cometbft/cometbft/config"
-	"github.com/cometbft/cometbft/libs/log"
-	"github.com/cometbft/cometbft/p2p"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
 )
 
-func TestUnsafeDialSeeds(t *testing.T) {
-	sw := p2p.MakeSwitch(cfg.DefaultP2PConfig(), 1,
-		func(n int, sw *p2p.Switch) *p2p.Switch { return sw })
-	err := sw.Start()
-	require.NoError(t, err)
-	t.Cleanup(func() {
-		if err := sw.Stop(); err != nil {
-			t.Error(err)
-		}
-	})
-
-	env := &Environment{}
-	env.Logger = log.TestingLogger()
-	env.P2PPeers = sw
-
-	testCases := []struct {
-		seeds []string
-		isErr bool
-	}{
-		{[]string{}, true},
-		{[]string{"d51fb70907db1c6c2d5237e78379b25cf1a37ab4@127.0.0.1:41198"}, false},
-		{[]string{"127.0.0.1:41198"}, true},
+func TestGenesisLoadedOnDemand(t *testing.T) {
+	fullGenDoc := &types.GenesisDoc{ChainID: "test-chain", AppState: []byte(`{"key":"value"}`)}
+	loads := 0
+	env := &Environment{
+		GenDoc:        &types.GenesisDoc{ChainID: "test-chain"},
+		GenDocPending: true,
+		GenDocLoader: func() (*types.GenesisDoc, error) {
+			loads++
+			return fullGenDoc, nil
+		},
 	}
+	require.NoError(t, env.InitGenesisChunks())
+	assert.Equal(t, 0, loads, "genesis doc should not be loaded on startup")
 
-	for _, tc := range testCases {
-		res, err := env.UnsafeDialSeeds(&rpctypes.Context{}, tc.seeds)
-		if tc.isErr {
-			assert.Error(t, err)
-		} else {
-			assert.NoError(t, err)
-			assert.NotNil(t, res)
-		}
-	}
-}
-
-func TestUnsafeDialPeers(t *testing.T) {
-	sw := p2p.MakeSwitch(cfg.DefaultP2PConfig(), 1,
-		func(n int, sw *p2p.Switch) *p2p.Switch { return sw })
-	sw.SetAddrBook(&p2p.AddrBookMock{
-		Addrs:        make(map[string]struct{}),
-		OurAddrs:     make(map[string]struct{}),
-		PrivateAddrs: make(map[string]struct{}),
-	})
-	err := sw.Start()
+	res, err := env.Genesis(&rpctypes.Context{})
 	require.NoError(t, err)
-	t.Cleanup(func() {
-		if err := sw.Stop(); err != nil {
-			t.Error(err)
-		}
-	})
+	assert.Equal(t, fullGenDoc, res.Genesis)
 
-	env := &Environment{}
-	env.Logger = log.TestingLogger()
-	env.P2PPeers = sw
-
-	testCases := []struct {
-		peers                               []string
-		persistence, unconditional, private bool
-		isErr                               bool
-	}{
-		{[]string{}, false, false, false, true},
-		{[]string{"d51fb70907db1c6c2d5237e78379b25cf1a37ab4@127.0.0.1:41198"}, true, true, true, false},
-		{[]string{"127.0.0.1:41198"}, true, true, false, true},
-	}
-
-	for _, tc := range testCases {
-		res, err := env.UnsafeDialPeers(&rpctypes.Context{}, tc.peers, tc.persistence, tc.unconditional, tc.private)
-		if tc.isErr {
-			assert.Error(t, err)
-		} else {
-			assert.NoError(t, err)
-			assert.NotNil(t, res)
-		}
+	chunk, err := env.GenesisChunked(&rpctypes.Context{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, chunk.TotalChunks)
+	assert.Equal(t, 1, loads)
+
+	// A genesis doc without app state is not loaded again.
+	env = &Environment{
+		GenDoc: &types.GenesisDoc{ChainID: "test-chain"},
+		GenDocLoader: func() (*types.GenesisDoc, error) {
+			loads++
+			return fullGenDoc, nil
+		},
 	}
+	res, err = env.Genesis(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.Nil(t, res.Genesis.AppState)
+	assert.Equal(t, 1, loads)
 }
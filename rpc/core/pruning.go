@@ -0,0 +1,50 @@
+package core
+
+import (
+	"errors"
+	"sort"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// ErrPrunerNotAvailable is returned when the node does not run a pruner.
+var ErrPrunerNotAvailable = errors.New("pruner is not available")
+
+// PruningStatus returns the progress of the pruning of each component of the
+// node data: the height the component is requested to be pruned to, the
+// height it has been pruned to, and the outcome of the last pruning run.
+// Components are only reported once they have been through a pruning run.
+// More: https://docs.cometbft.com/main/rpc/#/Info/pruning_status
+func (env *Environment) PruningStatus(*rpctypes.Context) (*ctypes.ResultPruningStatus, error) {
+	if env.Pruner == nil {
+		return nil, ErrPrunerNotAvailable
+	}
+
+	status := env.Pruner.Status()
+	components := make([]ctypes.PruningComponentStatus, 0, len(status.Components))
+	for component, cs := range status.Components {
+		var lastErr string
+		if cs.LastError != nil {
+			lastErr = cs.LastError.Error()
+		}
+		components = append(components, ctypes.PruningComponentStatus{
+			Component:          component,
+			TargetRetainHeight: cs.TargetRetainHeight,
+			RetainHeight:       cs.RetainHeight,
+			Lag:                cs.Lag(),
+			LastPruned:         cs.LastPruned,
+			LastRunTime:        cs.LastRunTime,
+			LastError:          lastErr,
+		})
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].Component < components[j].Component
+	})
+
+	return &ctypes.ResultPruningStatus{
+		Interval:             status.Interval,
+		DataCompanionEnabled: status.DataCompanionEnabled,
+		Components:           components,
+	}, nil
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	db "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	sm "github.com/cometbft/cometbft/state"
+	blockidxkv "github.com/cometbft/cometbft/state/indexer/block/kv"
+	"github.com/cometbft/cometbft/state/txindex/kv"
+	"github.com/cometbft/cometbft/store"
+)
+
+func TestPruningStatus(t *testing.T) {
+	env := &Environment{}
+	_, err := env.PruningStatus(&rpctypes.Context{})
+	require.ErrorIs(t, err, ErrPrunerNotAvailable)
+
+	memDB := db.NewMemDB()
+	env.Pruner = sm.NewPruner(
+		sm.NewStore(db.NewMemDB(), sm.StoreOptions{}),
+		store.NewBlockStore(db.NewMemDB()),
+		blockidxkv.New(db.NewPrefixDB(memDB, []byte("block_events"))),
+		kv.NewTxIndex(memDB),
+		log.TestingLogger(),
+		sm.WithPrunerInterval(time.Minute),
+	)
+
+	res, err := env.PruningStatus(&rpctypes.Context{})
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, res.Interval)
+	require.False(t, res.DataCompanionEnabled)
+	require.Empty(t, res.Components)
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/state/indexer"
+)
+
+// parseQuery parses the query of a request, checking it does not exceed
+// max_query_length and max_query_conditions.
+func (env *Environment) parseQuery(query string) (*cmtquery.Query, error) {
+	if maxLength := env.Config.MaxQueryLength; maxLength > 0 && len(query) > maxLength {
+		return nil, fmt.Errorf("maximum query length %d exceeded", maxLength)
+	}
+	q, err := cmtquery.New(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+	if maxConditions := env.Config.MaxQueryConditions; maxConditions > 0 && len(q.Conditions()) > maxConditions {
+		return nil, fmt.Errorf("maximum number of query conditions %d exceeded", maxConditions)
+	}
+	return q, nil
+}
+
+// searchLog times a search of an indexer, to log it if it takes longer than
+// slow_query_threshold.
+type searchLog struct {
+	env     *Environment
+	method  string
+	query   string
+	remote  string
+	start   time.Time
+	stats   *indexer.ScanStats
+	results int // set by the caller once known
+}
+
+// startSearch returns the context in which to search the indexer for the
+// query, collecting the scan statistics, and the log of the search, to finish
+// once done.
+func (env *Environment) startSearch(ctx *rpctypes.Context, method, query string) (context.Context, *searchLog) {
+	l := &searchLog{
+		env:    env,
+		method: method,
+		query:  query,
+		remote: ctx.RemoteAddr(),
+		start:  time.Now(),
+		stats:  &indexer.ScanStats{},
+	}
+	return indexer.ContextWithScanStats(ctx.Context(), l.stats), l
+}
+
+// finish logs the search if it was slow.
+func (l *searchLog) finish() {
+	threshold := l.env.Config.SlowQueryThreshold
+	if threshold <= 0 {
+		return
+	}
+	if elapsed := time.Since(l.start); elapsed >= threshold {
+		l.env.Logger.Info("Slow query",
+			"method", l.method,
+			"query", l.query,
+			"remote", l.remote,
+			"duration", elapsed,
+			"results", l.results,
+			"keys_scanned", l.stats.KeysScanned(),
+		)
+	}
+}
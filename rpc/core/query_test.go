@@ -0,0 +1,76 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/cometbft/cometbft-db"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/libs/log"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/state/txindex/kv"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestParseQuery(t *testing.T) {
+	env := &Environment{Config: *cfg.DefaultRPCConfig()}
+	env.Config.MaxQueryLength = 40
+	env.Config.MaxQueryConditions = 2
+
+	_, err := env.parseQuery("tm.event = 'Tx' AND tx.height > 5")
+	require.NoError(t, err)
+	_, err = env.parseQuery("tm.event = 'Tx' AND tx.height > 5 AND a.b = 'c'")
+	require.ErrorContains(t, err, "maximum query length 40 exceeded")
+	_, err = env.parseQuery("a=1 AND b=2 AND c=3")
+	require.ErrorContains(t, err, "maximum number of query conditions 2 exceeded")
+	_, err = env.parseQuery("tm.event =")
+	require.ErrorContains(t, err, "failed to parse query")
+
+	// 0 means no limit.
+	env.Config.MaxQueryLength = 0
+	env.Config.MaxQueryConditions = 0
+	_, err = env.parseQuery(strings.Repeat("a=1 AND ", 100) + "a=1")
+	require.NoError(t, err)
+}
+
+func TestTxSearchLogsSlowQuery(t *testing.T) {
+	txIndexer := kv.NewTxIndex(db.NewMemDB())
+	for h := int64(1); h <= 5; h++ {
+		require.NoError(t, txIndexer.Index(&abci.TxResult{
+			Height: h,
+			Tx:     types.Tx([]byte{byte(h)}),
+		}))
+	}
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlock", mock.AnythingOfType("int64")).Return(func(h int64) *types.Block {
+		return &types.Block{Header: types.Header{Height: h, Time: time.Now()}}
+	})
+
+	var buf bytes.Buffer
+	env := &Environment{
+		TxIndexer:  txIndexer,
+		BlockStore: blockStore,
+		Config:     *cfg.DefaultRPCConfig(),
+		Logger:     log.NewTMLogger(log.NewSyncWriter(&buf)),
+	}
+
+	_, err := env.TxSearch(&rpctypes.Context{}, "tx.height >= 2", false, "", nil, "asc", "")
+	require.NoError(t, err)
+	require.NotContains(t, buf.String(), "Slow query")
+
+	env.Config.SlowQueryThreshold = time.Nanosecond
+	_, err = env.TxSearch(&rpctypes.Context{}, "tx.height >= 2", false, "", nil, "asc", "")
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "Slow query")
+	require.Contains(t, buf.String(), "method=tx_search")
+	require.Contains(t, buf.String(), "results=4")
+	require.Contains(t, buf.String(), "keys_scanned=5")
+}
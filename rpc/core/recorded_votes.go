@@ -0,0 +1,43 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// ErrVoteRecordingDisabled is returned when vote recording is disabled on the
+// node.
+var ErrVoteRecordingDisabled = errors.New("vote recording is disabled")
+
+// RecordedVotes returns all the votes received by the node at the given
+// height, including those of every round and the conflicting votes, along
+// with the peer they were received from and when. If no height is provided,
+// it defaults to the height being decided.
+// More: https://docs.cometbft.com/main/rpc/#/Info/recorded_votes
+func (env *Environment) RecordedVotes(_ *rpctypes.Context, heightPtr *int64) (*ctypes.ResultRecordedVotes, error) {
+	if env.VoteRecorder == nil {
+		return nil, ErrVoteRecordingDisabled
+	}
+
+	var height int64
+	switch {
+	case heightPtr == nil:
+		height = env.latestUncommittedHeight()
+	case *heightPtr <= 0:
+		return nil, fmt.Errorf("height must be greater than 0, but got %d", *heightPtr)
+	default:
+		height = *heightPtr
+	}
+
+	votes, err := env.VoteRecorder.Votes(height)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultRecordedVotes{
+		Height: height,
+		Votes:  votes,
+	}, nil
+}
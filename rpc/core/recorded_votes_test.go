@@ -0,0 +1,36 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	cm "github.com/cometbft/cometbft/consensus"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestRecordedVotes(t *testing.T) {
+	env := &Environment{}
+	height := int64(5)
+	_, err := env.RecordedVotes(&rpctypes.Context{}, &height)
+	require.ErrorIs(t, err, ErrVoteRecordingDisabled)
+
+	env.VoteRecorder = cm.NewVoteRecorder(dbm.NewMemDB(), 10)
+	vote := &types.Vote{Type: cmtproto.PrevoteType, Height: height, Signature: []byte("sig")}
+	require.NoError(t, env.VoteRecorder.Record(vote, "peer", time.Now()))
+
+	res, err := env.RecordedVotes(&rpctypes.Context{}, &height)
+	require.NoError(t, err)
+	require.Equal(t, height, res.Height)
+	require.Len(t, res.Votes, 1)
+	require.Equal(t, vote.Signature, res.Votes[0].Vote.Signature)
+
+	height = 0
+	_, err = env.RecordedVotes(&rpctypes.Context{}, &height)
+	require.Error(t, err)
+}
@@ -0,0 +1,36 @@
+package core
+
+import (
+	"errors"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// ErrReplayProgressNotTracked is returned when the node does not track the
+// progress of the replays on startup.
+var ErrReplayProgressNotTracked = errors.New("the replay progress is not tracked")
+
+// ReplayStatus returns the progress of the replay on startup: the blocks
+// replayed to the application by the handshake, or the messages of the last
+// height replayed from the WAL. While the blocks are replayed, before the RPC
+// server is started, this endpoint is served alone on the RPC listen
+// addresses.
+// More: https://docs.cometbft.com/main/rpc/#/Info/replay_status
+func (env *Environment) ReplayStatus(*rpctypes.Context) (*ctypes.ResultReplayStatus, error) {
+	if env.ReplayProgress == nil {
+		return nil, ErrReplayProgressNotTracked
+	}
+	status := env.ReplayProgress.Status()
+	return &ctypes.ResultReplayStatus{
+		Phase:     status.Phase,
+		Running:   status.Running,
+		Height:    status.Height,
+		Done:      status.Done,
+		Total:     status.Total,
+		Messages:  status.Messages,
+		Started:   status.Started,
+		Elapsed:   status.Elapsed,
+		Remaining: status.Remaining,
+	}, nil
+}
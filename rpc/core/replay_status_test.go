@@ -0,0 +1,22 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cm "github.com/cometbft/cometbft/consensus"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+func TestReplayStatus(t *testing.T) {
+	env := &Environment{}
+	_, err := env.ReplayStatus(&rpctypes.Context{})
+	require.ErrorIs(t, err, ErrReplayProgressNotTracked)
+
+	env.ReplayProgress = cm.NewReplayProgress(cm.NopMetrics())
+	res, err := env.ReplayStatus(&rpctypes.Context{})
+	require.NoError(t, err)
+	require.Empty(t, res.Phase)
+	require.False(t, res.Running)
+}
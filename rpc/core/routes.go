@@ -1,49 +1,60 @@
 package core
 
 import (
+	"github.com/cometbft/cometbft/rpc/auth"
 	rpc "github.com/cometbft/cometbft/rpc/jsonrpc/server"
 )
 
-// TODO: better system than "unsafe" prefix
-
 type RoutesMap map[string]*rpc.RPCFunc
 
 // Routes is a map of available routes.
 func (env *Environment) GetRoutes() RoutesMap {
 	return RoutesMap{
 		// subscribe/unsubscribe are reserved for websocket events.
-		"subscribe":       rpc.NewWSRPCFunc(env.Subscribe, "query"),
+		"subscribe":       rpc.NewWSRPCFunc(env.Subscribe, "query,from_height"),
 		"unsubscribe":     rpc.NewWSRPCFunc(env.Unsubscribe, "query"),
 		"unsubscribe_all": rpc.NewWSRPCFunc(env.UnsubscribeAll, ""),
 
 		// info AP
-		"health":               rpc.NewRPCFunc(env.Health, ""),
-		"status":               rpc.NewRPCFunc(env.Status, ""),
-		"net_info":             rpc.NewRPCFunc(env.NetInfo, ""),
-		"blockchain":           rpc.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight", rpc.Cacheable()),
-		"genesis":              rpc.NewRPCFunc(env.Genesis, "", rpc.Cacheable()),
-		"genesis_chunked":      rpc.NewRPCFunc(env.GenesisChunked, "chunk", rpc.Cacheable()),
-		"block":                rpc.NewRPCFunc(env.Block, "height", rpc.Cacheable("height")),
-		"block_by_hash":        rpc.NewRPCFunc(env.BlockByHash, "hash", rpc.Cacheable()),
-		"block_results":        rpc.NewRPCFunc(env.BlockResults, "height", rpc.Cacheable("height")),
-		"commit":               rpc.NewRPCFunc(env.Commit, "height", rpc.Cacheable("height")),
-		"header":               rpc.NewRPCFunc(env.Header, "height", rpc.Cacheable("height")),
-		"header_by_hash":       rpc.NewRPCFunc(env.HeaderByHash, "hash", rpc.Cacheable()),
-		"check_tx":             rpc.NewRPCFunc(env.CheckTx, "tx"),
-		"tx":                   rpc.NewRPCFunc(env.Tx, "hash,prove", rpc.Cacheable()),
-		"tx_search":            rpc.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by"),
-		"block_search":         rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by"),
-		"validators":           rpc.NewRPCFunc(env.Validators, "height,page,per_page", rpc.Cacheable("height")),
-		"dump_consensus_state": rpc.NewRPCFunc(env.DumpConsensusState, ""),
-		"consensus_state":      rpc.NewRPCFunc(env.GetConsensusState, ""),
-		"consensus_params":     rpc.NewRPCFunc(env.ConsensusParams, "height", rpc.Cacheable("height")),
-		"unconfirmed_txs":      rpc.NewRPCFunc(env.UnconfirmedTxs, "limit"),
-		"num_unconfirmed_txs":  rpc.NewRPCFunc(env.NumUnconfirmedTxs, ""),
+		"health":                 rpc.NewRPCFunc(env.Health, "verbose"),
+		"status":                 rpc.NewRPCFunc(env.Status, ""),
+		"build_info":             rpc.NewRPCFunc(env.BuildInfo, ""),
+		"net_info":               rpc.NewRPCFunc(env.NetInfo, ""),
+		"blockchain":             rpc.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight", rpc.Cacheable()),
+		"genesis":                rpc.NewRPCFunc(env.Genesis, "", rpc.Cacheable()),
+		"genesis_chunked":        rpc.NewRPCFunc(env.GenesisChunked, "chunk", rpc.Cacheable()),
+		"block":                  rpc.NewRPCFunc(env.Block, "height", rpc.Cacheable("height")),
+		"block_by_hash":          rpc.NewRPCFunc(env.BlockByHash, "hash", rpc.Cacheable()),
+		"block_results":          rpc.NewRPCFunc(env.BlockResults, "height,event_types,omit_tx_results", rpc.Cacheable("height")),
+		"commit":                 rpc.NewRPCFunc(env.Commit, "height", rpc.Cacheable("height")),
+		"extended_commit":        rpc.NewRPCFunc(env.ExtendedCommit, "height", rpc.Cacheable("height")),
+		"header":                 rpc.NewRPCFunc(env.Header, "height", rpc.Cacheable("height")),
+		"header_by_hash":         rpc.NewRPCFunc(env.HeaderByHash, "hash", rpc.Cacheable()),
+		"check_tx":               rpc.NewRPCFunc(env.CheckTx, "tx"),
+		"tx":                     rpc.NewRPCFunc(env.Tx, "hash,prove,proof_format", rpc.Cacheable()),
+		"wait_tx":                rpc.NewRPCFunc(env.WaitTx, "hash,prove,proof_format,timeout"),
+		"tx_search":              rpc.NewRPCFunc(env.TxSearch, "query,prove,cursor,per_page,order_by,proof_format"),
+		"block_search":           rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by"),
+		"validators":             rpc.NewRPCFunc(env.Validators, "height,page,per_page", rpc.Cacheable("height")),
+		"validators_diff":        rpc.NewRPCFunc(env.ValidatorsDiff, "from,to", rpc.Cacheable("to")),
+		"dump_consensus_state":   rpc.NewRPCFunc(env.DumpConsensusState, ""),
+		"consensus_state":        rpc.NewRPCFunc(env.GetConsensusState, ""),
+		"consensus_params":       rpc.NewRPCFunc(env.ConsensusParams, "height", rpc.Cacheable("height")),
+		"unconfirmed_txs":        rpc.NewRPCFunc(env.UnconfirmedTxs, "limit,query"),
+		"num_unconfirmed_txs":    rpc.NewRPCFunc(env.NumUnconfirmedTxs, ""),
+		"dropped_txs":            rpc.NewRPCFunc(env.DroppedTxs, "hash"),
+		"pruning_status":         rpc.NewRPCFunc(env.PruningStatus, ""),
+		"storage_forecast":       rpc.NewRPCFunc(env.StorageForecast, ""),
+		"execution_report":       rpc.NewRPCFunc(env.ExecutionReport, "height"),
+		"recorded_votes":         rpc.NewRPCFunc(env.RecordedVotes, "height"),
+		"replay_status":          rpc.NewRPCFunc(env.ReplayStatus, ""),
+		"validators_performance": rpc.NewRPCFunc(env.ValidatorsPerformance, ""),
+		"search_job":             rpc.NewRPCFunc(env.SearchJob, "job_id,page,per_page"),
 
 		// tx broadcast API
-		"broadcast_tx_commit": rpc.NewRPCFunc(env.BroadcastTxCommit, "tx"),
-		"broadcast_tx_sync":   rpc.NewRPCFunc(env.BroadcastTxSync, "tx"),
-		"broadcast_tx_async":  rpc.NewRPCFunc(env.BroadcastTxAsync, "tx"),
+		"broadcast_tx_commit": rpc.NewRPCFunc(env.BroadcastTxCommit, "tx,idempotency_key,timeout"),
+		"broadcast_tx_sync":   rpc.NewRPCFunc(env.BroadcastTxSync, "tx,idempotency_key"),
+		"broadcast_tx_async":  rpc.NewRPCFunc(env.BroadcastTxAsync, "tx,idempotency_key"),
 
 		// abci API
 		"abci_query": rpc.NewRPCFunc(env.ABCIQuery, "path,data,height,prove"),
@@ -54,10 +65,20 @@ func (env *Environment) GetRoutes() RoutesMap {
 	}
 }
 
-// AddUnsafeRoutes adds unsafe routes.
-func (env *Environment) AddUnsafeRoutes(routes RoutesMap) {
-	// control API
-	routes["dial_seeds"] = rpc.NewRPCFunc(env.UnsafeDialSeeds, "seeds")
-	routes["dial_peers"] = rpc.NewRPCFunc(env.UnsafeDialPeers, "peers,persistent,unconditional,private")
-	routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(env.UnsafeFlushMempool, "")
+// broadcastRoutes are the routes broadcasting transactions and evidence.
+var broadcastRoutes = map[string]struct{}{
+	"broadcast_tx_commit": {},
+	"broadcast_tx_sync":   {},
+	"broadcast_tx_async":  {},
+	"broadcast_evidence":  {},
+}
+
+// RouteGroup returns the group of the route with the given name, to which the
+// API keys can give access: the broadcast routes are in their own group, the
+// others only read the state of the node.
+func RouteGroup(route string) string {
+	if _, ok := broadcastRoutes[route]; ok {
+		return auth.GroupBroadcast
+	}
+	return auth.GroupRead
 }
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/rpc/auth"
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+)
+
+func TestRouteGroup(t *testing.T) {
+	env := &Environment{}
+	routes := env.GetRoutes()
+	for route := range routes {
+		expected := auth.GroupRead
+		if strings.HasPrefix(route, "broadcast_") {
+			expected = auth.GroupBroadcast
+		}
+		assert.Equal(t, expected, RouteGroup(route), route)
+	}
+}
+
+func TestRoutesOpenAPISpec(t *testing.T) {
+	env := &Environment{}
+	routes := env.GetRoutes()
+
+	doc := rpcserver.OpenAPISpec(routes, rpcserver.OpenAPIInfo{Title: "CometBFT RPC", Version: "test"})
+	_, err := json.Marshal(doc)
+	require.NoError(t, err)
+	for route, rpcFunc := range routes {
+		if rpcFunc.IsWS() {
+			assert.NotContains(t, doc.Paths, "/"+route)
+			continue
+		}
+		require.Contains(t, doc.Paths, "/"+route)
+		assert.Len(t, doc.Paths["/"+route].Get.Parameters, len(rpcFunc.ArgNames()), route)
+	}
+	status := doc.Components.Schemas["core.types.ResultStatus"]
+	require.NotNil(t, status)
+	assert.Contains(t, status.Properties, "sync_info")
+}
@@ -0,0 +1,319 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+const (
+	searchJobKindTxs    = "txs"
+	searchJobKindBlocks = "blocks"
+)
+
+var (
+	// ErrSearchJobNotFound is returned when a search job does not exist or
+	// has expired.
+	ErrSearchJobNotFound = errors.New("search job not found or expired")
+	// ErrTooManySearchJobs is returned when a search exceeds the maximum
+	// number of results while the node already keeps the maximum number of
+	// search jobs.
+	ErrTooManySearchJobs = errors.New("too many search jobs, retry later or narrow the query")
+)
+
+// searchFunc runs the search of a search job, passing each of its results to
+// emit, in order, until the context is canceled.
+type searchFunc func(ctx context.Context, emit func(result interface{}) error) error
+
+// searchJob runs a search exceeding the maximum number of results, and
+// materializes its results to a temporary file, one JSON-encoded result per
+// line, so that they can be paginated without holding them in memory.
+type searchJob struct {
+	id         string
+	kind       string
+	totalCount int
+	created    time.Time
+	cancel     context.CancelFunc
+
+	mtx     cmtsync.Mutex
+	status  string
+	err     error
+	path    string
+	offsets []int64 // offset of each result in the file, followed by its size
+}
+
+// run runs the search of the job, writing its results to the job's temporary
+// file.
+func (j *searchJob) run(ctx context.Context, f *os.File, search searchFunc) {
+	err := j.materialize(ctx, f, search)
+
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	if err != nil {
+		j.status = ctypes.SearchJobFailed
+		j.err = err
+		return
+	}
+	j.status = ctypes.SearchJobDone
+}
+
+func (j *searchJob) materialize(ctx context.Context, f *os.File, search searchFunc) error {
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	offsets := make([]int64, 0, j.totalCount+1)
+	var offset int64
+	err := search(ctx, func(res interface{}) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		bz, err := cmtjson.Marshal(res)
+		if err != nil {
+			return err
+		}
+		offsets = append(offsets, offset)
+		n, err := w.Write(append(bz, '\n'))
+		if err != nil {
+			return err
+		}
+		offset += int64(n)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	offsets = append(offsets, offset)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+
+	j.mtx.Lock()
+	j.offsets = offsets
+	j.totalCount = len(offsets) - 1
+	j.mtx.Unlock()
+	return nil
+}
+
+// page returns the results in [skip, skip+count), decoded into the values
+// returned by newResult.
+func (j *searchJob) page(skip, count int, newResult func() interface{}) ([]interface{}, error) {
+	j.mtx.Lock()
+	total := len(j.offsets) - 1
+	if skip < 0 || count < 0 || skip+count > total {
+		j.mtx.Unlock()
+		return nil, fmt.Errorf("search job results [%d, %d) out of range [0, %d)", skip, skip+count, cmtmath.MaxInt(total, 0))
+	}
+	start, end := j.offsets[skip], j.offsets[skip+count]
+	j.mtx.Unlock()
+
+	f, err := os.Open(j.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(io.NewSectionReader(f, start, end-start))
+	results := make([]interface{}, 0, count)
+	for i := 0; i < count; i++ {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			return nil, fmt.Errorf("reading search job result %d: %w", skip+i, err)
+		}
+		res := newResult()
+		if err := cmtjson.Unmarshal(line, res); err != nil {
+			return nil, fmt.Errorf("decoding search job result %d: %w", skip+i, err)
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// remove stops the job if it is still running, and deletes its file.
+func (j *searchJob) remove() {
+	j.cancel()
+	// The job may still be writing to the file, in which case its space is
+	// reclaimed once the job stops and closes it.
+	_ = os.Remove(j.path)
+}
+
+// searchJobs keeps the search jobs for a limited time, up to a maximum
+// number of jobs.
+type searchJobs struct {
+	max int
+	ttl time.Duration
+
+	mtx  cmtsync.Mutex
+	jobs map[string]*searchJob
+}
+
+func newSearchJobs(max int, ttl time.Duration) *searchJobs {
+	return &searchJobs{
+		max:  max,
+		ttl:  ttl,
+		jobs: make(map[string]*searchJob),
+	}
+}
+
+// start creates a job running the search, of about totalCount results, and
+// materializing its results in the background.
+func (s *searchJobs) start(kind string, totalCount int, search searchFunc, now time.Time) (*searchJob, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.expire(now)
+	if len(s.jobs) >= s.max {
+		return nil, ErrTooManySearchJobs
+	}
+
+	f, err := os.CreateTemp("", "cometbft-search-*.jsonl")
+	if err != nil {
+		return nil, fmt.Errorf("creating search job file: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	j := &searchJob{
+		id:         hex.EncodeToString(cmtrand.Bytes(16)),
+		kind:       kind,
+		totalCount: totalCount,
+		created:    now,
+		cancel:     cancel,
+		status:     ctypes.SearchJobRunning,
+		path:       f.Name(),
+	}
+	s.jobs[j.id] = j
+	go j.run(ctx, f, search)
+	return j, nil
+}
+
+func (s *searchJobs) get(id string, now time.Time) (*searchJob, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.expire(now)
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrSearchJobNotFound
+	}
+	return j, nil
+}
+
+func (s *searchJobs) expire(now time.Time) {
+	for id, j := range s.jobs {
+		if now.Sub(j.created) >= s.ttl {
+			j.remove()
+			delete(s.jobs, id)
+		}
+	}
+}
+
+// spillSearch starts a search job running the search if it returns more than
+// the maximum number of results, and returns its ID, or an empty ID if the
+// results must be returned directly.
+func (env *Environment) spillSearch(kind string, totalCount int, search searchFunc) (string, error) {
+	if !env.searchSpills(totalCount) {
+		return "", nil
+	}
+
+	env.searchJobsMtx.Lock()
+	if env.searchJobs == nil {
+		env.searchJobs = newSearchJobs(env.Config.MaxSearchJobs, env.Config.SearchJobTTL)
+	}
+	env.searchJobsMtx.Unlock()
+
+	j, err := env.searchJobs.start(kind, totalCount, search, time.Now())
+	if err != nil {
+		return "", err
+	}
+	return j.id, nil
+}
+
+// searchSpills returns true if a search with the given number of results
+// exceeds the maximum number of results, and is spilled to a search job.
+func (env *Environment) searchSpills(totalCount int) bool {
+	return env.Config.MaxSearchResults != 0 && totalCount > env.Config.MaxSearchResults
+}
+
+// SearchJob returns the status of a search job created by /tx_search or
+// /block_search for a search exceeding the maximum number of results and,
+// once the job is done, a page of its results.
+// More: https://docs.cometbft.com/main/rpc/#/Info/search_job
+func (env *Environment) SearchJob(
+	_ *rpctypes.Context,
+	jobID string,
+	pagePtr, perPagePtr *int,
+) (*ctypes.ResultSearchJob, error) {
+	env.searchJobsMtx.Lock()
+	jobs := env.searchJobs
+	env.searchJobsMtx.Unlock()
+	if jobs == nil {
+		return nil, ErrSearchJobNotFound
+	}
+
+	j, err := jobs.get(jobID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	j.mtx.Lock()
+	result := &ctypes.ResultSearchJob{
+		JobID:      j.id,
+		Status:     j.status,
+		TotalCount: j.totalCount,
+	}
+	if j.err != nil {
+		result.Error = j.err.Error()
+	}
+	j.mtx.Unlock()
+	if result.Status != ctypes.SearchJobDone {
+		return result, nil
+	}
+
+	totalCount := result.TotalCount
+	perPage := env.validatePerPage(perPagePtr)
+	page, err := validatePage(pagePtr, perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
+	skipCount := validateSkipCount(page, perPage)
+	pageSize := cmtmath.MinInt(perPage, totalCount-skipCount)
+
+	switch j.kind {
+	case searchJobKindTxs:
+		results, err := j.page(skipCount, pageSize, func() interface{} { return new(ctypes.ResultTx) })
+		if err != nil {
+			return nil, err
+		}
+		result.Txs = make([]*ctypes.ResultTx, 0, len(results))
+		for _, r := range results {
+			result.Txs = append(result.Txs, r.(*ctypes.ResultTx))
+		}
+	case searchJobKindBlocks:
+		results, err := j.page(skipCount, pageSize, func() interface{} { return new(ctypes.ResultBlock) })
+		if err != nil {
+			return nil, err
+		}
+		result.Blocks = make([]*ctypes.ResultBlock, 0, len(results))
+		for _, r := range results {
+			result.Blocks = append(result.Blocks, r.(*ctypes.ResultBlock))
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,148 @@
+package core
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/cometbft/cometbft-db"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cfg "github.com/cometbft/cometbft/config"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/state/txindex/kv"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestTxSearchSpillsToSearchJob(t *testing.T) {
+	txIndexer := kv.NewTxIndex(db.NewMemDB())
+	for h := int64(1); h <= 5; h++ {
+		require.NoError(t, txIndexer.Index(&abci.TxResult{
+			Height: h,
+			Tx:     types.Tx([]byte{byte(h)}),
+		}))
+	}
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlock", mock.AnythingOfType("int64")).Return(func(h int64) *types.Block {
+		return &types.Block{Header: types.Header{Height: h, Time: time.Now()}}
+	})
+
+	config := cfg.DefaultRPCConfig()
+	config.MaxSearchResults = 5
+	env := &Environment{TxIndexer: txIndexer, BlockStore: blockStore, Config: *config}
+
+	// Searches within the maximum number of results return them directly.
+	res, err := env.TxSearch(&rpctypes.Context{}, "tx.height >= 1", false, "", nil, "asc", "")
+	require.NoError(t, err)
+	require.Empty(t, res.JobID)
+	require.Len(t, res.Txs, 5)
+
+	// The results of the search are paginated once, not searched for again.
+	perPage := 2
+	res, err = env.TxSearch(&rpctypes.Context{}, "tx.height >= 1", false, "", &perPage, "asc", "")
+	require.NoError(t, err)
+	require.Len(t, res.Txs, 2)
+	require.Equal(t, 5, res.TotalCount)
+	require.NotEmpty(t, res.NextCursor)
+	res, err = env.TxSearch(&rpctypes.Context{}, "tx.height >= 1", false, res.NextCursor, &perPage, "asc", "")
+	require.NoError(t, err)
+	require.Len(t, res.Txs, 2)
+	require.EqualValues(t, 3, res.Txs[0].Height)
+
+	// The job searches for the results after the first ones.
+	env.Config.MaxSearchResults = 3
+	res, err = env.TxSearch(&rpctypes.Context{}, "tx.height >= 1", false, "", &perPage, "asc", "")
+	require.NoError(t, err)
+	require.NotEmpty(t, res.JobID)
+	require.Empty(t, res.Txs)
+	t.Cleanup(func() { env.searchJobs.expire(time.Now().Add(time.Hour)) })
+	require.Equal(t, 5, res.TotalCount)
+
+	var job *ctypes.ResultSearchJob
+	require.Eventually(t, func() bool {
+		job, err = env.SearchJob(&rpctypes.Context{}, res.JobID, nil, nil)
+		require.NoError(t, err)
+		return job.Status != ctypes.SearchJobRunning
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, ctypes.SearchJobDone, job.Status)
+	require.Equal(t, 5, job.TotalCount)
+	require.Len(t, job.Txs, 5)
+
+	page := 2
+	job, err = env.SearchJob(&rpctypes.Context{}, res.JobID, &page, &perPage)
+	require.NoError(t, err)
+	require.Len(t, job.Txs, 2)
+	require.EqualValues(t, 3, job.Txs[0].Height)
+	require.EqualValues(t, 4, job.Txs[1].Height)
+
+	_, err = env.SearchJob(&rpctypes.Context{}, "unknown", nil, nil)
+	require.ErrorIs(t, err, ErrSearchJobNotFound)
+}
+
+func TestSearchJobsExpire(t *testing.T) {
+	jobs := newSearchJobs(1, time.Minute)
+	now := time.Now()
+	t.Cleanup(func() { jobs.expire(now.Add(time.Hour)) })
+	search := searchTxs(3)
+
+	j, err := jobs.start(searchJobKindTxs, 3, search, now)
+	require.NoError(t, err)
+
+	// The number of jobs is bounded.
+	_, err = jobs.start(searchJobKindTxs, 3, search, now)
+	require.ErrorIs(t, err, ErrTooManySearchJobs)
+
+	// Jobs and their file are removed once expired.
+	_, err = jobs.start(searchJobKindTxs, 3, search, now.Add(time.Minute))
+	require.NoError(t, err)
+	_, err = jobs.get(j.id, now.Add(time.Minute))
+	require.ErrorIs(t, err, ErrSearchJobNotFound)
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(j.path)
+		return os.IsNotExist(err)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSearchJobPageOutOfRange(t *testing.T) {
+	jobs := newSearchJobs(1, time.Minute)
+	now := time.Now()
+	t.Cleanup(func() { jobs.expire(now.Add(time.Hour)) })
+	search := searchTxs(3)
+
+	j, err := jobs.start(searchJobKindTxs, 3, search, now)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		j.mtx.Lock()
+		defer j.mtx.Unlock()
+		return j.status == ctypes.SearchJobDone
+	}, time.Second, 10*time.Millisecond)
+
+	newResult := func() interface{} { return new(ctypes.ResultTx) }
+	results, err := j.page(1, 2, newResult)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.EqualValues(t, 2, results[1].(*ctypes.ResultTx).Height)
+
+	_, err = j.page(2, 2, newResult)
+	require.Error(t, err)
+	_, err = j.page(-1, 1, newResult)
+	require.Error(t, err)
+}
+
+// searchTxs returns a search of n txs, at heights 0 to n-1.
+func searchTxs(n int) searchFunc {
+	return func(_ context.Context, emit func(interface{}) error) error {
+		for i := 0; i < n; i++ {
+			if err := emit(&ctypes.ResultTx{Height: int64(i)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/cometbft/cometbft/p2p"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/state/txindex/null"
 	"github.com/cometbft/cometbft/types"
 )
 
@@ -71,9 +72,32 @@ func (env *Environment) Status(*rpctypes.Context) (*ctypes.ResultStatus, error)
 		},
 	}
 
+	env.addSyncTelemetry(&result.SyncInfo)
+
 	return result, nil
 }
 
+// addSyncTelemetry adds the progress of block sync, state sync and of the
+// indexer to the sync info.
+func (env *Environment) addSyncTelemetry(info *ctypes.SyncInfo) {
+	if env.BlockSyncReactor != nil && info.CatchingUp {
+		if status := env.BlockSyncReactor.SyncStatus(); status.Syncing {
+			info.CatchupBlocksPerSecond = status.BlocksPerSecond
+			info.CatchupMaxPeerHeight = status.MaxPeerHeight
+			info.CatchupRemaining = status.Remaining
+		}
+	}
+	if env.StateSyncReactor != nil {
+		info.StateSyncPhase = env.StateSyncReactor.SyncPhase()
+	}
+	if _, ok := env.TxIndexer.(*null.TxIndex); !ok && env.IndexerService != nil {
+		if height := env.IndexerService.IndexedHeight(); height > 0 {
+			info.IndexerHeight = height
+			info.IndexerBacklog = max(info.LatestBlockHeight-height, 0)
+		}
+	}
+}
+
 func (env *Environment) validatorAtHeight(h int64) *types.Validator {
 	valsWithH, err := env.StateStore.LoadValidators(h)
 	if err != nil {
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/blocksync"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/state/txindex/null"
+	"github.com/cometbft/cometbft/statesync"
+)
+
+type fakeBlockSyncReactor struct{ status blocksync.SyncStatus }
+
+func (r fakeBlockSyncReactor) SyncStatus() blocksync.SyncStatus { return r.status }
+
+type fakeStateSyncReactor struct{ phase string }
+
+func (r fakeStateSyncReactor) SyncPhase() string { return r.phase }
+
+type fakeIndexerService struct{ height int64 }
+
+func (s fakeIndexerService) IndexedHeight() int64 { return s.height }
+
+func TestStatusSyncTelemetry(t *testing.T) {
+	env := &Environment{}
+	info := ctypes.SyncInfo{LatestBlockHeight: 100, CatchingUp: true}
+	env.addSyncTelemetry(&info)
+	require.Equal(t, ctypes.SyncInfo{LatestBlockHeight: 100, CatchingUp: true}, info)
+
+	env.BlockSyncReactor = fakeBlockSyncReactor{blocksync.SyncStatus{
+		Syncing:         true,
+		Height:          101,
+		MaxPeerHeight:   150,
+		BlocksPerSecond: 10,
+		Remaining:       5 * time.Second,
+	}}
+	env.StateSyncReactor = fakeStateSyncReactor{statesync.SyncPhaseCompleted}
+	env.IndexerService = fakeIndexerService{90}
+	env.addSyncTelemetry(&info)
+	require.Equal(t, ctypes.SyncInfo{
+		LatestBlockHeight:      100,
+		CatchingUp:             true,
+		CatchupBlocksPerSecond: 10,
+		CatchupMaxPeerHeight:   150,
+		CatchupRemaining:       5 * time.Second,
+		StateSyncPhase:         statesync.SyncPhaseCompleted,
+		IndexerHeight:          90,
+		IndexerBacklog:         10,
+	}, info)
+
+	// The block sync progress is not reported once caught up, nor the indexer
+	// progress if indexing is disabled.
+	env.TxIndexer = &null.TxIndex{}
+	info = ctypes.SyncInfo{LatestBlockHeight: 100}
+	env.addSyncTelemetry(&info)
+	require.Equal(t, ctypes.SyncInfo{
+		LatestBlockHeight: 100,
+		StateSyncPhase:    statesync.SyncPhaseCompleted,
+	}, info)
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"errors"
+	"sort"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+var (
+	// ErrStorageForecastDisabled is returned when storage forecasting is
+	// disabled on the node.
+	ErrStorageForecastDisabled = errors.New("storage forecasting is disabled")
+	// ErrStorageForecastNotReady is returned when the size of the databases
+	// has not been measured yet.
+	ErrStorageForecastNotReady = errors.New("storage forecast is not available yet")
+)
+
+// StorageForecast returns the size and growth rate of the node databases,
+// along with the number of days until the volume holding them is full at the
+// current growth rate (-1 if they are not growing).
+// More: https://docs.cometbft.com/main/rpc/#/Info/storage_forecast
+func (env *Environment) StorageForecast(*rpctypes.Context) (*ctypes.ResultStorageForecast, error) {
+	if env.StorageForecaster == nil {
+		return nil, ErrStorageForecastDisabled
+	}
+	forecast, ok := env.StorageForecaster.Forecast()
+	if !ok {
+		return nil, ErrStorageForecastNotReady
+	}
+
+	stores := make([]ctypes.StoreForecast, 0, len(forecast.Stores))
+	for store, sf := range forecast.Stores {
+		stores = append(stores, ctypes.StoreForecast{
+			Store:      store,
+			Size:       sf.Size,
+			GrowthRate: sf.GrowthRate,
+		})
+	}
+	sort.Slice(stores, func(i, j int) bool {
+		return stores[i].Store < stores[j].Store
+	})
+
+	return &ctypes.ResultStorageForecast{
+		Time:          forecast.Time,
+		Window:        forecast.Window,
+		FreeBytes:     forecast.FreeBytes,
+		TotalBytes:    forecast.TotalBytes,
+		GrowthRate:    forecast.GrowthRate,
+		DaysUntilFull: forecast.DaysUntilFull,
+		Stores:        stores,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	sm "github.com/cometbft/cometbft/state"
+)
+
+func TestStorageForecast(t *testing.T) {
+	env := &Environment{}
+	_, err := env.StorageForecast(&rpctypes.Context{})
+	require.ErrorIs(t, err, ErrStorageForecastDisabled)
+
+	env.StorageForecaster = sm.NewStorageForecaster(t.TempDir(), log.TestingLogger(),
+		sm.WithStorageForecasterInterval(time.Hour),
+	)
+	_, err = env.StorageForecast(&rpctypes.Context{})
+	require.ErrorIs(t, err, ErrStorageForecastNotReady)
+
+	require.NoError(t, env.StorageForecaster.Start())
+	t.Cleanup(func() {
+		require.NoError(t, env.StorageForecaster.Stop())
+	})
+
+	var res *ctypes.ResultStorageForecast
+	require.Eventually(t, func() bool {
+		res, err = env.StorageForecast(&rpctypes.Context{})
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.EqualValues(t, -1, res.DaysUntilFull)
+	require.Len(t, res.Stores, 4)
+	require.Equal(t, "blockstore", res.Stores[0].Store)
+	require.Zero(t, res.Stores[0].Size)
+}
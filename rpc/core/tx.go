@@ -1,28 +1,43 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"sort"
 	"time"
 
-	cmtmath "github.com/cometbft/cometbft/libs/math"
-	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/state/txindex"
 	"github.com/cometbft/cometbft/state/txindex/null"
 	"github.com/cometbft/cometbft/types"
 )
 
+// waitTxIndexInterval is the interval at which WaitTx looks a committed tx up
+// in the index, until it is indexed.
+const waitTxIndexInterval = 10 * time.Millisecond
+
 // Tx allows you to query the transaction results. `nil` could mean the
 // transaction is in the mempool, invalidated, or was not sent in the first
-// place.
+// place. If prove is true, the proof of the transaction is returned in the
+// given format, the default one if empty.
 // More: https://docs.cometbft.com/main/rpc/#/Info/tx
-func (env *Environment) Tx(_ *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
+func (env *Environment) Tx(
+	_ *rpctypes.Context,
+	hash []byte,
+	prove bool,
+	proofFormat string,
+) (*ctypes.ResultTx, error) {
 	// if index is disabled, return error
 	if _, ok := env.TxIndexer.(*null.TxIndex); ok {
 		return nil, fmt.Errorf("transaction indexing is disabled")
 	}
+	if err := validateProofFormat(prove, proofFormat); err != nil {
+		return nil, err
+	}
 
 	r, err := env.TxIndexer.Get(hash)
 	if err != nil {
@@ -33,109 +48,289 @@ func (env *Environment) Tx(_ *rpctypes.Context, hash []byte, prove bool) (*ctype
 		return nil, fmt.Errorf("tx (%X) not found", hash)
 	}
 
-	var proof types.TxProof
-	if prove {
-		block := env.BlockStore.LoadBlock(r.Height)
-		proof = block.Data.Txs.Proof(int(r.Index))
-	}
-
-	return &ctypes.ResultTx{
+	result := &ctypes.ResultTx{
 		Hash:     hash,
 		Height:   r.Height,
 		Index:    r.Index,
 		TxResult: r.Result,
 		Tx:       r.Tx,
-		Proof:    proof,
-	}, nil
+	}
+	if prove {
+		block := env.BlockStore.LoadBlock(r.Height)
+		setProof(result, block.Data.Txs.Proof(int(r.Index)), proofFormat)
+	}
+	return result, nil
+}
+
+// WaitTx waits until the transaction with the given hash is committed and
+// indexed, and returns it as Tx does, instead of polling Tx. The timeout is a
+// duration, e.g. "5s", defaulting to timeout_broadcast_tx_commit if empty,
+// which also bounds it. An error is returned if the transaction is not
+// indexed by then.
+// More: https://docs.cometbft.com/main/rpc/#/Info/wait_tx
+func (env *Environment) WaitTx(
+	ctx *rpctypes.Context,
+	hash []byte,
+	prove bool,
+	proofFormat string,
+	timeout string,
+) (*ctypes.ResultTx, error) {
+	// if index is disabled, return error
+	if _, ok := env.TxIndexer.(*null.TxIndex); ok {
+		return nil, fmt.Errorf("transaction indexing is disabled")
+	}
+	if err := validateProofFormat(prove, proofFormat); err != nil {
+		return nil, err
+	}
+	waitTimeout, err := env.commitTimeout(timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriber := ctx.RemoteAddr()
+
+	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
+		return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
+	} else if env.EventBus.NumClientSubscriptions(subscriber) >= env.Config.MaxSubscriptionsPerClient {
+		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
+	}
+
+	// Subscribe before looking the tx up, not to miss it if it is committed
+	// in between.
+	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
+	defer cancel()
+	q := types.EventQueryTxHash(hash)
+	txSub, err := env.EventBus.Subscribe(subCtx, subscriber, q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to tx: %w", err)
+	}
+	defer func() {
+		if err := env.EventBus.Unsubscribe(context.Background(), subscriber, q); err != nil {
+			env.Logger.Error("Error unsubscribing from eventBus", "err", err)
+		}
+	}()
+
+	timer := time.NewTimer(waitTimeout)
+	defer timer.Stop()
+
+	if r, err := env.TxIndexer.Get(hash); err != nil {
+		return nil, err
+	} else if r == nil {
+		select {
+		case <-txSub.Out(): // The tx was included in a block.
+		case <-txSub.Canceled():
+			reason := "CometBFT exited"
+			if txSub.Err() != nil {
+				reason = txSub.Err().Error()
+			}
+			return nil, fmt.Errorf("txSub was canceled (reason: %s)", reason)
+		case <-timer.C:
+			return nil, fmt.Errorf("timed out waiting for tx (%X) to be committed", hash)
+		case <-ctx.Context().Done():
+			return nil, ctx.Context().Err()
+		}
+	}
+
+	// The tx is indexed shortly after its event is published.
+	ticker := time.NewTicker(waitTxIndexInterval)
+	defer ticker.Stop()
+	for {
+		r, err := env.TxIndexer.Get(hash)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			return env.Tx(ctx, hash, prove, proofFormat)
+		}
+		select {
+		case <-ticker.C:
+		case <-timer.C:
+			return nil, fmt.Errorf("timed out waiting for tx (%X) to be indexed", hash)
+		case <-ctx.Context().Done():
+			return nil, ctx.Context().Err()
+		}
+	}
 }
 
 // TxSearch allows you to query for multiple transactions results. It returns a
-// list of transactions (maximum ?per_page entries) and the total count.
+// page of transactions (maximum ?per_page entries), the total count and, if
+// there are more transactions, the cursor of the next page. The total count
+// is the number of transactions matching the query, counted for the first
+// page only: it is -1 for the pages after a cursor, whose search stops once
+// the page is full. The proofs are returned as by Tx.
 // More: https://docs.cometbft.com/main/rpc/#/Info/tx_search
 func (env *Environment) TxSearch(
 	ctx *rpctypes.Context,
 	query string,
 	prove bool,
-	pagePtr, perPagePtr *int,
+	cursor string,
+	perPagePtr *int,
 	orderBy string,
+	proofFormat string,
 ) (*ctypes.ResultTxSearch, error) {
 	// if index is disabled, return error
 	if _, ok := env.TxIndexer.(*null.TxIndex); ok {
 		return nil, errors.New("transaction indexing is disabled")
-	} else if len(query) > maxQueryLength {
-		return nil, errors.New("maximum query length exceeded")
 	}
-
-	q, err := cmtquery.New(query)
-	if err != nil {
+	if err := validateProofFormat(prove, proofFormat); err != nil {
 		return nil, err
 	}
 
-	results, err := env.TxIndexer.Search(ctx.Context(), q)
+	q, err := env.parseQuery(query)
 	if err != nil {
 		return nil, err
 	}
 
-	// sort results (must be done before pagination)
+	// the indexer sorts the results (must be done before pagination)
+	var pagination txindex.Pagination
 	switch orderBy {
 	case "desc":
-		sort.Slice(results, func(i, j int) bool {
-			if results[i].Height == results[j].Height {
-				return results[i].Index > results[j].Index
-			}
-			return results[i].Height > results[j].Height
-		})
+		pagination.OrderDesc = true
 	case "asc", "":
-		sort.Slice(results, func(i, j int) bool {
-			if results[i].Height == results[j].Height {
-				return results[i].Index < results[j].Index
-			}
-			return results[i].Height < results[j].Height
-		})
 	default:
 		return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
 	}
-
-	// paginate results
-	totalCount := len(results)
+	if cursor != "" {
+		after, err := txindex.ParseCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		pagination.After = &after
+	}
 	perPage := env.validatePerPage(perPagePtr)
+	pagination.Limit = perPage
+	// A new search may be spilled to a search job: up to the maximum number
+	// of results are returned, all of them if it is not spilled, and reused
+	// for the job and the first page.
+	mayBeSpilled := cursor == "" && env.Config.MaxSearchResults > perPage
+	if mayBeSpilled {
+		pagination.Limit = env.Config.MaxSearchResults
+	}
+
+	searchCtx, search := env.startSearch(ctx, "tx_search", query)
+	defer search.finish()
 
-	page, err := validatePage(pagePtr, perPage, totalCount)
+	page, err := env.TxIndexer.SearchPage(searchCtx, q, pagination)
 	if err != nil {
 		return nil, err
 	}
 
-	skipCount := validateSkipCount(page, perPage)
-	pageSize := cmtmath.MinInt(perPage, totalCount-skipCount)
+	// spill the results of a new search to a search job if there are too
+	// many of them
+	totalCount := page.TotalCount
+	search.results = totalCount
+	if totalCount < 0 {
+		search.results = len(page.Results)
+	}
+	if cursor == "" && env.searchSpills(totalCount) {
+		jobID, err := env.spillSearch(searchJobKindTxs, totalCount, func(ctx context.Context, emit func(interface{}) error) error {
+			var block *types.Block
+			for {
+				for _, r := range page.Results {
+					// results are sorted by height, so only the current block is needed
+					if block == nil || block.Height != r.Height {
+						if block = env.BlockStore.LoadBlock(r.Height); block == nil {
+							continue
+						}
+					}
+					if err := emit(makeResultTx(r, block, prove, proofFormat)); err != nil {
+						return err
+					}
+				}
+				if page.Next == nil {
+					return nil
+				}
+				// the job resumes the search after the results it got, by
+				// pages of the maximum number of results
+				pagination.After, pagination.Limit = page.Next, env.Config.MaxSearchResults
+				var err error
+				if page, err = env.TxIndexer.SearchPage(ctx, q, pagination); err != nil {
+					return err
+				}
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &ctypes.ResultTxSearch{Txs: []*ctypes.ResultTx{}, TotalCount: totalCount, JobID: jobID}, nil
+	}
+	if mayBeSpilled && len(page.Results) > perPage {
+		page.Results = page.Results[:perPage]
+		last := page.Results[perPage-1]
+		page.Next = &txindex.Cursor{Height: last.Height, Index: last.Index}
+	}
 
-	apiResults := make([]*ctypes.ResultTx, 0, pageSize)
+	apiResults := make([]*ctypes.ResultTx, 0, len(page.Results))
 
 	blocks := make(map[int64]*types.Block)
 
-	for i := skipCount; i < skipCount+pageSize; i++ {
-		r := results[i]
-
+	for _, r := range page.Results {
 		if _, ok := blocks[r.Height]; !ok {
 			blocks[r.Height] = env.BlockStore.LoadBlock(r.Height)
 		}
 
-		block := blocks[r.Height]
+		apiResults = append(apiResults, makeResultTx(r, blocks[r.Height], prove, proofFormat))
+	}
 
-		var proof types.TxProof
-		if prove {
-			proof = block.Data.Txs.Proof(int(r.Index)) // XXX: overflow on 32-bit machines
-		}
+	result := &ctypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}
+	if page.Next != nil {
+		result.NextCursor = page.Next.String()
+	}
+	return result, nil
+}
 
-		apiResults = append(apiResults, &ctypes.ResultTx{
-			Hash:      types.Tx(r.Tx).Hash(),
-			Height:    r.Height,
-			Index:     r.Index,
-			TxResult:  r.Result,
-			Timestamp: block.Time.Format(time.RFC3339),
-			Tx:        r.Tx,
-			Proof:     proof,
-		})
+func makeResultTx(r *abci.TxResult, block *types.Block, prove bool, proofFormat string) *ctypes.ResultTx {
+	result := &ctypes.ResultTx{
+		Hash:      types.Tx(r.Tx).Hash(),
+		Height:    r.Height,
+		Index:     r.Index,
+		TxResult:  r.Result,
+		Timestamp: block.Time.Format(time.RFC3339),
+		Tx:        r.Tx,
 	}
+	if prove {
+		setProof(result, block.Data.Txs.Proof(int(r.Index)), proofFormat) // XXX: overflow on 32-bit machines
+	}
+	return result
+}
 
-	return &ctypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}, nil
+// validateProofFormat returns an error if the proof format is unknown, or
+// given without requesting the proofs.
+func validateProofFormat(prove bool, proofFormat string) error {
+	switch proofFormat {
+	case "":
+		return nil
+	case ctypes.ProofFormatDefault, ctypes.ProofFormatProto, ctypes.ProofFormatOps, ctypes.ProofFormatSiblings:
+		if !prove {
+			return errors.New("proof_format requires prove to be true")
+		}
+		return nil
+	default:
+		return fmt.Errorf("expected proof_format to be either %q, %q, %q, %q or empty",
+			ctypes.ProofFormatDefault, ctypes.ProofFormatProto, ctypes.ProofFormatOps, ctypes.ProofFormatSiblings)
+	}
+}
+
+// setProof sets the proof of the result in the given format.
+func setProof(result *ctypes.ResultTx, proof types.TxProof, proofFormat string) {
+	switch proofFormat {
+	case ctypes.ProofFormatProto:
+		pbProof := proof.ToProto()
+		bz, err := pbProof.Marshal()
+		if err != nil {
+			panic(err)
+		}
+		result.ProofProto = bz
+	case ctypes.ProofFormatOps:
+		result.ProofOps = &cmtcrypto.ProofOps{
+			Ops: []cmtcrypto.ProofOp{merkle.NewLeafOp(&proof.Proof).ProofOp()},
+		}
+	case ctypes.ProofFormatSiblings:
+		result.ProofSiblings = proof.Proof.Siblings()
+	default:
+		result.Proof = proof
+	}
 }
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	db "github.com/cometbft/cometbft-db"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/state/txindex/kv"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestTxProofFormats(t *testing.T) {
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=2"), types.Tx("c=3")}
+	block := &types.Block{Header: types.Header{Height: 1}, Data: types.Data{Txs: txs}}
+	dataHash := txs.Hash()
+
+	txIndexer := kv.NewTxIndex(db.NewMemDB())
+	for i, tx := range txs {
+		require.NoError(t, txIndexer.Index(&abci.TxResult{Height: 1, Index: uint32(i), Tx: tx}))
+	}
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlock", int64(1)).Return(block)
+	env := &Environment{TxIndexer: txIndexer, BlockStore: blockStore}
+
+	tx := txs[1]
+	res, err := env.Tx(&rpctypes.Context{}, tx.Hash(), false, "")
+	require.NoError(t, err)
+	require.Zero(t, res.Proof)
+
+	res, err = env.Tx(&rpctypes.Context{}, tx.Hash(), true, "")
+	require.NoError(t, err)
+	require.NoError(t, res.Proof.Validate(dataHash))
+
+	res, err = env.Tx(&rpctypes.Context{}, tx.Hash(), true, ctypes.ProofFormatProto)
+	require.NoError(t, err)
+	require.Zero(t, res.Proof)
+	var pbProof cmtproto.TxProof
+	require.NoError(t, pbProof.Unmarshal(res.ProofProto))
+	proof, err := types.TxProofFromProto(pbProof)
+	require.NoError(t, err)
+	require.NoError(t, proof.Validate(dataHash))
+
+	res, err = env.Tx(&rpctypes.Context{}, tx.Hash(), true, ctypes.ProofFormatOps)
+	require.NoError(t, err)
+	require.NoError(t, merkle.DefaultProofRuntime().VerifyValue(res.ProofOps, dataHash, "/1", tx.Hash()))
+
+	search, err := env.TxSearch(&rpctypes.Context{}, "tx.height = 1", true, "", nil, "", ctypes.ProofFormatSiblings)
+	require.NoError(t, err)
+	require.Len(t, search.Txs, len(txs))
+	for _, res := range search.Txs {
+		require.NotEmpty(t, res.ProofSiblings)
+		require.Nil(t, res.ProofOps)
+	}
+
+	_, err = env.Tx(&rpctypes.Context{}, tx.Hash(), false, ctypes.ProofFormatProto)
+	require.Error(t, err)
+	_, err = env.Tx(&rpctypes.Context{}, tx.Hash(), true, "xml")
+	require.Error(t, err)
+}
+
+func TestWaitTx(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	txIndexer := kv.NewTxIndex(db.NewMemDB())
+	env := &Environment{
+		TxIndexer: txIndexer,
+		EventBus:  eventBus,
+		Config:    *cfg.DefaultRPCConfig(),
+		Logger:    log.NewNopLogger(),
+	}
+	env.Config.TimeoutBroadcastTxCommit = 5 * time.Second
+	ctx := &rpctypes.Context{}
+
+	// The tx is committed, then indexed, while waiting.
+	tx := types.Tx("a=1")
+	txResult := abci.TxResult{Height: 2, Index: 0, Tx: tx}
+	go func() {
+		for eventBus.NumClients() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		if err := eventBus.PublishEventTx(types.EventDataTx{TxResult: txResult}); err != nil {
+			t.Error(err)
+		}
+		time.Sleep(20 * time.Millisecond)
+		if err := txIndexer.Index(&txResult); err != nil {
+			t.Error(err)
+		}
+	}()
+	res, err := env.WaitTx(ctx, tx.Hash(), false, "", "")
+	require.NoError(t, err)
+	require.EqualValues(t, 2, res.Height)
+	require.Equal(t, tx, res.Tx)
+
+	// The tx is already indexed.
+	res, err = env.WaitTx(ctx, tx.Hash(), false, "", "10ms")
+	require.NoError(t, err)
+	require.EqualValues(t, 2, res.Height)
+
+	// The tx is not committed before the timeout.
+	_, err = env.WaitTx(ctx, types.Tx("b=2").Hash(), false, "", "10ms")
+	require.ErrorContains(t, err, "timed out waiting for tx")
+
+	// The timeout is bounded by timeout_broadcast_tx_commit.
+	_, err = env.WaitTx(ctx, tx.Hash(), false, "", "6s")
+	require.Error(t, err)
+	require.Zero(t, eventBus.NumClients())
+}
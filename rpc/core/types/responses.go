@@ -2,12 +2,16 @@ package coretypes
 
 import (
 	"encoding/json"
+	"net/http"
 	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	cstypes "github.com/cometbft/cometbft/consensus/types"
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/merkle"
 	"github.com/cometbft/cometbft/libs/bytes"
 	"github.com/cometbft/cometbft/p2p"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/cometbft/cometbft/types"
 )
@@ -58,6 +62,48 @@ type ResultBlockResults struct {
 	ValidatorUpdates      []abci.ValidatorUpdate    `json:"validator_updates"`
 	ConsensusParamUpdates *cmtproto.ConsensusParams `json:"consensus_param_updates"`
 	AppHash               []byte                    `json:"app_hash"`
+	// Unverified lists the fields a light client proxy could not verify. See
+	// the Unverified constants.
+	Unverified []string `json:"unverified,omitempty"`
+}
+
+// Filter returns the results with only the events of the given types, or all
+// the events if none is given, and without the results of the txs if
+// omitTxResults is true. The results are not modified.
+func (r *ResultBlockResults) Filter(eventTypes []string, omitTxResults bool) *ResultBlockResults {
+	filtered := *r
+	if omitTxResults {
+		filtered.TxsResults = nil
+	}
+	if len(eventTypes) == 0 {
+		return &filtered
+	}
+	types := make(map[string]struct{}, len(eventTypes))
+	for _, eventType := range eventTypes {
+		types[eventType] = struct{}{}
+	}
+	filterEvents := func(events []abci.Event) []abci.Event {
+		kept := make([]abci.Event, 0, len(events))
+		for _, event := range events {
+			if _, ok := types[event.Type]; ok {
+				kept = append(kept, event)
+			}
+		}
+		return kept
+	}
+	filtered.FinalizeBlockEvents = filterEvents(r.FinalizeBlockEvents)
+	if filtered.TxsResults != nil {
+		filtered.TxsResults = make([]*abci.ExecTxResult, len(r.TxsResults))
+		for i, txResult := range r.TxsResults {
+			if txResult == nil {
+				continue
+			}
+			txResultCopy := *txResult
+			txResultCopy.Events = filterEvents(txResult.Events)
+			filtered.TxsResults[i] = &txResultCopy
+		}
+	}
+	return &filtered
 }
 
 // NewResultCommit is a helper to initialize the ResultCommit with
@@ -87,6 +133,23 @@ type SyncInfo struct {
 	EarliestBlockTime   time.Time      `json:"earliest_block_time"`
 
 	CatchingUp bool `json:"catching_up"`
+
+	// Moving average of the number of blocks synced per second by block sync,
+	// the highest height reported by the peers, and the estimated time left
+	// to reach it. Zero when not catching up, or if unknown.
+	CatchupBlocksPerSecond float64       `json:"catchup_blocks_per_second"`
+	CatchupMaxPeerHeight   int64         `json:"catchup_max_peer_height"`
+	CatchupRemaining       time.Duration `json:"catchup_remaining"`
+
+	// Phase of the state sync in progress, or the phase the last one ended
+	// in. Empty if no state sync was started.
+	StateSyncPhase string `json:"state_sync_phase"`
+
+	// Last height processed by the indexer, and the number of blocks stored
+	// but not yet processed by it. Zero if indexing is disabled or no height
+	// was processed since the node started.
+	IndexerHeight  int64 `json:"indexer_height"`
+	IndexerBacklog int64 `json:"indexer_backlog"`
 }
 
 // Info about the node's validator
@@ -111,6 +174,149 @@ func (s *ResultStatus) TxIndexEnabled() bool {
 	return s.NodeInfo.Other.TxIndex == "on"
 }
 
+// Build information and feature matrix of the node
+type ResultBuildInfo struct {
+	Version       string          `json:"version"`
+	GitCommitHash string          `json:"git_commit_hash"`
+	GoVersion     string          `json:"go_version"`
+	ABCIVersion   string          `json:"abci_version"`
+	P2PProtocol   uint64          `json:"p2p_protocol"`
+	BlockProtocol uint64          `json:"block_protocol"`
+	Modules       []ModuleVersion `json:"modules"`
+	Features      map[string]bool `json:"features"`
+	RPCMethods    []RPCMethod     `json:"rpc_methods"`
+	P2PChannels   bytes.HexBytes  `json:"p2p_channels"`
+}
+
+// Version of a Go module the node binary was built with
+type ModuleVersion struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+// Description of an RPC method supported by the node
+type RPCMethod struct {
+	Name      string   `json:"name"`
+	Args      []string `json:"args"`
+	Versions  []string `json:"versions"`
+	WSOnly    bool     `json:"ws_only"`
+	Cacheable bool     `json:"cacheable"`
+}
+
+// Progress of the pruning of the node data
+type ResultPruningStatus struct {
+	Interval             time.Duration            `json:"interval"`
+	DataCompanionEnabled bool                     `json:"data_companion_enabled"`
+	Components           []PruningComponentStatus `json:"components"`
+}
+
+// Progress of the pruning of one component of the node data
+type PruningComponentStatus struct {
+	Component          string    `json:"component"`
+	TargetRetainHeight int64     `json:"target_retain_height"`
+	RetainHeight       int64     `json:"retain_height"`
+	Lag                int64     `json:"lag"`
+	LastPruned         uint64    `json:"last_pruned"`
+	LastRunTime        time.Time `json:"last_run_time"`
+	LastError          string    `json:"last_error,omitempty"`
+}
+
+// Storage usage forecast of the node databases
+type ResultStorageForecast struct {
+	Time          time.Time       `json:"time"`
+	Window        time.Duration   `json:"window"`
+	FreeBytes     uint64          `json:"free_bytes"`
+	TotalBytes    uint64          `json:"total_bytes"`
+	GrowthRate    float64         `json:"growth_rate"`
+	DaysUntilFull float64         `json:"days_until_full"`
+	Stores        []StoreForecast `json:"stores"`
+}
+
+// Size and growth rate of the database of a store
+type StoreForecast struct {
+	Store      string  `json:"store"`
+	Size       int64   `json:"size"`
+	GrowthRate float64 `json:"growth_rate"`
+}
+
+// Resources used to execute a block
+type ResultExecutionReport struct {
+	Height         int64          `json:"height"`
+	ExecutionTime  time.Duration  `json:"execution_time"`
+	NumTxs         int            `json:"num_txs"`
+	GasWanted      int64          `json:"gas_wanted"`
+	GasUsed        int64          `json:"gas_used"`
+	EventsEmitted  int            `json:"events_emitted"`
+	IndexKeysAdded int            `json:"index_keys_added"`
+	BytesWritten   []StoreWritten `json:"bytes_written"`
+}
+
+// Number of bytes written to a store for a block
+type StoreWritten struct {
+	Store string `json:"store"`
+	Bytes int64  `json:"bytes"`
+}
+
+// Precommits which committed a block, with their vote extensions
+type ResultExtendedCommit struct {
+	Height     int64               `json:"height"`
+	Round      int32               `json:"round"`
+	BlockID    types.BlockID       `json:"block_id"`
+	Signatures []ExtendedCommitSig `json:"signatures"`
+}
+
+// Precommit of a validator, with its vote extension
+type ExtendedCommitSig struct {
+	BlockIDFlag        types.BlockIDFlag `json:"block_id_flag"`
+	ValidatorAddress   types.Address     `json:"validator_address"`
+	Timestamp          time.Time         `json:"timestamp"`
+	Signature          []byte            `json:"signature"`
+	Extension          []byte            `json:"extension"`
+	ExtensionSignature []byte            `json:"extension_signature"`
+}
+
+// Progress of the replay on startup
+type ResultReplayStatus struct {
+	Phase     string        `json:"phase"`
+	Running   bool          `json:"running"`
+	Height    int64         `json:"height"`
+	Done      int64         `json:"done"`
+	Total     int64         `json:"total"`
+	Messages  int64         `json:"messages"`
+	Started   time.Time     `json:"started"`
+	Elapsed   time.Duration `json:"elapsed"`
+	Remaining time.Duration `json:"remaining"`
+}
+
+// Participation of the validators in the consensus, as observed by the node
+// between SinceHeight and Height
+type ResultValidatorsPerformance struct {
+	SinceHeight int64                  `json:"since_height"`
+	Height      int64                  `json:"height"`
+	Validators  []ValidatorPerformance `json:"validators"`
+}
+
+// Participation of a validator in the consensus
+type ValidatorPerformance struct {
+	Address          bytes.HexBytes `json:"address"`
+	Heights          int64          `json:"heights"`
+	Signed           int64          `json:"signed"`
+	LastSignedHeight int64          `json:"last_signed_height"`
+	Rounds           int64          `json:"rounds"`
+	Prevotes         int64          `json:"prevotes"`
+	Precommits       int64          `json:"precommits"`
+	ProposerRounds   int64          `json:"proposer_rounds"`
+	ProposalsTimely  int64          `json:"proposals_timely"`
+	ProposalsLate    int64          `json:"proposals_late"`
+	ProposalsMissed  int64          `json:"proposals_missed"`
+}
+
+// Votes received by the node at a height
+type ResultRecordedVotes struct {
+	Height int64                   `json:"height"`
+	Votes  []*cstypes.RecordedVote `json:"votes"`
+}
+
 // Info about peer connections
 type ResultNetInfo struct {
 	Listening bool     `json:"listening"`
@@ -119,16 +325,6 @@ type ResultNetInfo struct {
 	Peers     []Peer   `json:"peers"`
 }
 
-// Log from dialing seeds
-type ResultDialSeeds struct {
-	Log string `json:"log"`
-}
-
-// Log from dialing peers
-type ResultDialPeers struct {
-	Log string `json:"log"`
-}
-
 // A peer
 type Peer struct {
 	NodeInfo         p2p.DefaultNodeInfo  `json:"node_info"`
@@ -147,10 +343,31 @@ type ResultValidators struct {
 	Total int `json:"total"`
 }
 
+// Changes of the validator set between two heights
+type ResultValidatorsDiff struct {
+	FromHeight int64 `json:"from_height"`
+	ToHeight   int64 `json:"to_height"`
+	// Validators in the set at ToHeight but not at FromHeight
+	Joined []*types.Validator `json:"joined"`
+	// Validators in the set at FromHeight but not at ToHeight
+	Removed []*types.Validator `json:"removed"`
+	// Validators in both sets with a different voting power
+	PowerChanged []ValidatorPowerChange `json:"power_changed"`
+}
+
+// The voting power of a validator at both heights of a ResultValidatorsDiff
+type ValidatorPowerChange struct {
+	Address   bytes.HexBytes `json:"address"`
+	PubKey    crypto.PubKey  `json:"pub_key"`
+	FromPower int64          `json:"from_power"`
+	ToPower   int64          `json:"to_power"`
+}
+
 // ConsensusParams for given height
 type ResultConsensusParams struct {
-	BlockHeight     int64                 `json:"block_height"`
-	ConsensusParams types.ConsensusParams `json:"consensus_params"`
+	BlockHeight       int64                 `json:"block_height"`
+	ConsensusParams   types.ConsensusParams `json:"consensus_params"`
+	LastHeightChanged int64                 `json:"last_height_changed"`
 }
 
 // Info about the consensus state.
@@ -187,7 +404,19 @@ type ResultBroadcastTxCommit struct {
 	TxResult abci.ExecTxResult    `json:"tx_result"`
 	Hash     bytes.HexBytes       `json:"hash"`
 	Height   int64                `json:"height"`
-}
+	// Whether the tx was committed, rejected by CheckTx, or accepted but
+	// not committed before the timeout.
+	Status string `json:"status"`
+}
+
+// The statuses of the txs broadcast by /broadcast_tx_commit.
+const (
+	BroadcastTxCommitted = "committed"
+	BroadcastTxRejected  = "rejected"
+	// BroadcastTxPending is the status of a tx accepted in the mempool, but
+	// not committed before the timeout. It may still be committed later.
+	BroadcastTxPending = "pending"
+)
 
 // ResultCheckTx wraps abci.ResponseCheckTx.
 type ResultCheckTx struct {
@@ -202,19 +431,101 @@ type ResultTx struct {
 	TxResult  abci.ExecTxResult `json:"tx_result"`
 	Timestamp string            `json:"timestamp,omitempty"`
 	Tx        types.Tx          `json:"tx"`
-	Proof     types.TxProof     `json:"proof,omitempty"`
-}
+	// The proof of the inclusion of the tx in the data hash of its block, if
+	// requested, in one of the fields below depending on the proof format.
+	Proof types.TxProof `json:"proof,omitempty"`
+	// The protobuf encoding of the TxProof.
+	ProofProto []byte `json:"proof_proto,omitempty"`
+	// A single merkle.LeafOp proving the hash of the tx at the key path
+	// "/<index>".
+	ProofOps *cmtcrypto.ProofOps `json:"proof_ops,omitempty"`
+	// The siblings hashed in turn with the leaf hash of the tx, i.e. the
+	// SHA-256 hash of 0x00 followed by the hash of the tx, to compute the
+	// data hash of the block.
+	ProofSiblings []merkle.ProofSibling `json:"proof_siblings,omitempty"`
+	// Unverified lists the fields a light client proxy could not verify. See
+	// the Unverified constants.
+	Unverified []string `json:"unverified,omitempty"`
+}
+
+// The fields of the results which a light client proxy may not be able to
+// verify against its trusted headers, listed in the Unverified field of the
+// results. Full nodes never set it. The log, info and codespace of the ABCI
+// responses are never verified.
+const (
+	// The result of a tx of the latest block, only verified once the next
+	// block, committing to the results of the block, is produced.
+	UnverifiedTxResult = "tx_result"
+	// The events of the result of a tx, not committed to by the headers.
+	UnverifiedTxResultEvents = "tx_result.events"
+	// Whether the txs found by a search are all the txs matching the query.
+	UnverifiedTotalCount = "total_count"
+	// The events of the results of the txs of a block.
+	UnverifiedTxsResultsEvents = "txs_results.events"
+	// The events of the finalization of a block.
+	UnverifiedFinalizeBlockEvents = "finalize_block_events"
+	// The validator updates of a block.
+	UnverifiedValidatorUpdates = "validator_updates"
+	// The consensus parameter updates of a block.
+	UnverifiedConsensusParamUpdates = "consensus_param_updates"
+	// The response of an ABCI query without a proof, e.g. an error or the
+	// response of a query of something else than a key of a store.
+	UnverifiedResponse = "response"
+)
+
+// The formats of the proofs of the txs returned by /tx and /tx_search.
+const (
+	// ProofFormatDefault is the JSON encoding of the TxProof.
+	ProofFormatDefault  = "default"
+	ProofFormatProto    = "proto"
+	ProofFormatOps      = "ops"
+	ProofFormatSiblings = "siblings"
+)
 
 // Result of searching for txs
 type ResultTxSearch struct {
-	Txs        []*ResultTx `json:"txs"`
-	TotalCount int         `json:"total_count"`
+	Txs []*ResultTx `json:"txs"`
+	// TotalCount is the number of transactions matching the query, over all
+	// the pages. It is only counted for the first page, and is -1 for the
+	// pages after a cursor.
+	TotalCount int `json:"total_count"`
+	// NextCursor is the cursor of the next page of results, empty if there
+	// are no more results.
+	NextCursor string `json:"next_cursor,omitempty"`
+	// JobID is set instead of Txs if the search exceeded the maximum number
+	// of results, in which case they can be retrieved with /search_job.
+	JobID string `json:"job_id,omitempty"`
+	// Unverified lists the fields a light client proxy could not verify. See
+	// the Unverified constants.
+	Unverified []string `json:"unverified,omitempty"`
 }
 
 // ResultBlockSearch defines the RPC response type for a block search by events.
 type ResultBlockSearch struct {
 	Blocks     []*ResultBlock `json:"blocks"`
 	TotalCount int            `json:"total_count"`
+	// JobID is set instead of Blocks if the search exceeded the maximum
+	// number of results, in which case they can be retrieved with
+	// /search_job.
+	JobID string `json:"job_id,omitempty"`
+}
+
+// Statuses of a search job.
+const (
+	SearchJobRunning = "running"
+	SearchJobDone    = "done"
+	SearchJobFailed  = "failed"
+)
+
+// ResultSearchJob is the status of a search job and, once it is done, a page
+// of the results of the search.
+type ResultSearchJob struct {
+	JobID      string         `json:"job_id"`
+	Status     string         `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	TotalCount int            `json:"total_count"`
+	Txs        []*ResultTx    `json:"txs,omitempty"`
+	Blocks     []*ResultBlock `json:"blocks,omitempty"`
 }
 
 // List of mempool txs
@@ -225,6 +536,31 @@ type ResultUnconfirmedTxs struct {
 	Txs        []types.Tx `json:"txs"`
 }
 
+// Txs rejected or evicted from the mempool
+type ResultDroppedTxs struct {
+	Counts []DropCount `json:"counts"`
+	Txs    []DroppedTx `json:"txs"`
+}
+
+// Number of txs dropped from the mempool for a reason
+type DropCount struct {
+	Kind   string `json:"kind"`
+	Reason string `json:"reason"`
+	Count  int64  `json:"count"`
+}
+
+// Tx rejected or evicted from the mempool
+type DroppedTx struct {
+	Hash      bytes.HexBytes `json:"hash"`
+	Kind      string         `json:"kind"`
+	Reason    string         `json:"reason"`
+	Code      uint32         `json:"code"`
+	Codespace string         `json:"codespace,omitempty"`
+	Log       string         `json:"log,omitempty"`
+	Height    int64          `json:"height"`
+	Time      time.Time      `json:"time"`
+}
+
 // Info abci msg
 type ResultABCIInfo struct {
 	Response abci.ResponseInfo `json:"response"`
@@ -233,6 +569,9 @@ type ResultABCIInfo struct {
 // Query abci msg
 type ResultABCIQuery struct {
 	Response abci.ResponseQuery `json:"response"`
+	// Unverified lists the fields a light client proxy could not verify. See
+	// the Unverified constants.
+	Unverified []string `json:"unverified,omitempty"`
 }
 
 // Result of broadcasting evidence
@@ -242,13 +581,39 @@ type ResultBroadcastEvidence struct {
 
 // empty results
 type (
-	ResultUnsafeFlushMempool struct{}
-	ResultUnsafeProfile      struct{}
-	ResultSubscribe          struct{}
-	ResultUnsubscribe        struct{}
-	ResultHealth             struct{}
+	ResultUnsafeProfile struct{}
+	ResultSubscribe     struct{}
+	ResultUnsubscribe   struct{}
+)
+
+// The overall statuses of the node reported by /health.
+const (
+	HealthStatusHealthy   = "healthy"
+	HealthStatusUnhealthy = "unhealthy"
 )
 
+// Node health. Empty unless the checks of the components were requested.
+type ResultHealth struct {
+	Status string        `json:"status,omitempty"`
+	Checks []HealthCheck `json:"checks,omitempty"`
+}
+
+// HTTPStatus returns 503 if the node is unhealthy, 200 otherwise.
+func (r *ResultHealth) HTTPStatus() int {
+	if r.Status == HealthStatusUnhealthy {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+// The readiness of a component of the node.
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	// Details of the status of the component, e.g. why it is unhealthy.
+	Message string `json:"message,omitempty"`
+}
+
 // Event data from a subscription
 type ResultEvent struct {
 	Query  string              `json:"query"`
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/p2p"
 )
 
@@ -33,3 +34,27 @@ func TestStatusIndexer(t *testing.T) {
 		assert.Equal(t, tc.expected, status.TxIndexEnabled())
 	}
 }
+
+func TestBlockResultsFilter(t *testing.T) {
+	transfer := abci.Event{Type: "transfer"}
+	message := abci.Event{Type: "message"}
+	results := &ResultBlockResults{
+		Height:              1,
+		TxsResults:          []*abci.ExecTxResult{{Events: []abci.Event{transfer, message}}, nil},
+		FinalizeBlockEvents: []abci.Event{message, transfer},
+	}
+
+	filtered := results.Filter([]string{"message"}, false)
+	assert.Equal(t, []abci.Event{message}, filtered.FinalizeBlockEvents)
+	assert.Equal(t, []abci.Event{message}, filtered.TxsResults[0].Events)
+	assert.Nil(t, filtered.TxsResults[1])
+
+	// The results are not modified.
+	assert.Equal(t, []abci.Event{transfer, message}, results.TxsResults[0].Events)
+	assert.Equal(t, []abci.Event{message, transfer}, results.FinalizeBlockEvents)
+
+	assert.Equal(t, results, results.Filter(nil, false))
+	filtered = results.Filter(nil, true)
+	assert.Nil(t, filtered.TxsResults)
+	assert.Equal(t, results.FinalizeBlockEvents, filtered.FinalizeBlockEvents)
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"errors"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// ErrValidatorsPerformanceNotTracked is returned when the node does not track
+// the participation of the validators in the consensus.
+var ErrValidatorsPerformanceNotTracked = errors.New("the performance of the validators is not tracked")
+
+// ValidatorsPerformance returns the participation of the validators in the
+// consensus, as observed by the node since it started: the commits they
+// signed, the rounds in which their prevotes and precommits were received, and
+// whether their proposals were received during the propose step.
+// More: https://docs.cometbft.com/main/rpc/#/Info/validators_performance
+func (env *Environment) ValidatorsPerformance(*rpctypes.Context) (*ctypes.ResultValidatorsPerformance, error) {
+	if env.ValidatorsPerf == nil {
+		return nil, ErrValidatorsPerformanceNotTracked
+	}
+	report := env.ValidatorsPerf.Report()
+	validators := make([]ctypes.ValidatorPerformance, len(report.Validators))
+	for i, s := range report.Validators {
+		validators[i] = ctypes.ValidatorPerformance{
+			Address:          s.Address,
+			Heights:          s.Heights,
+			Signed:           s.Signed,
+			LastSignedHeight: s.LastSignedHeight,
+			Rounds:           s.Rounds,
+			Prevotes:         s.Prevotes,
+			Precommits:       s.Precommits,
+			ProposerRounds:   s.ProposerRounds,
+			ProposalsTimely:  s.ProposalsTimely,
+			ProposalsLate:    s.ProposalsLate,
+			ProposalsMissed:  s.ProposalsMissed,
+		}
+	}
+	return &ctypes.ResultValidatorsPerformance{
+		SinceHeight: report.SinceHeight,
+		Height:      report.LastHeight,
+		Validators:  validators,
+	}, nil
+}
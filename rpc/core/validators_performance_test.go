@@ -0,0 +1,22 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cm "github.com/cometbft/cometbft/consensus"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+func TestValidatorsPerformance(t *testing.T) {
+	env := &Environment{}
+	_, err := env.ValidatorsPerformance(&rpctypes.Context{})
+	require.ErrorIs(t, err, ErrValidatorsPerformanceNotTracked)
+
+	env.ValidatorsPerf = cm.NewValidatorsPerformance(cm.NopMetrics())
+	res, err := env.ValidatorsPerformance(&rpctypes.Context{})
+	require.NoError(t, err)
+	require.Zero(t, res.SinceHeight)
+	require.Empty(t, res.Validators)
+}
@@ -23,6 +23,9 @@ type Client interface {
 	VersionServiceClient
 	BlockServiceClient
 	BlockResultsServiceClient
+	MempoolServiceClient
+	EventsServiceClient
+	QueryServiceClient
 
 	// Close the connection to the server. Any subsequent requests will fail.
 	Close() error
@@ -35,6 +38,9 @@ type clientBuilder struct {
 	versionServiceEnabled      bool
 	blockServiceEnabled        bool
 	blockResultsServiceEnabled bool
+	mempoolServiceEnabled      bool
+	eventsServiceEnabled       bool
+	queryServiceEnabled        bool
 }
 
 func newClientBuilder() *clientBuilder {
@@ -44,6 +50,9 @@ func newClientBuilder() *clientBuilder {
 		versionServiceEnabled:      true,
 		blockServiceEnabled:        true,
 		blockResultsServiceEnabled: true,
+		mempoolServiceEnabled:      true,
+		eventsServiceEnabled:       true,
+		queryServiceEnabled:        true,
 	}
 }
 
@@ -57,6 +66,9 @@ type client struct {
 	VersionServiceClient
 	BlockServiceClient
 	BlockResultsServiceClient
+	MempoolServiceClient
+	EventsServiceClient
+	QueryServiceClient
 }
 
 // Close implements Client.
@@ -95,6 +107,39 @@ func WithBlockServiceEnabled(enabled bool) Option {
 	}
 }
 
+// WithMempoolServiceEnabled allows control of whether or not to create a
+// client for interacting with the mempool service of a CometBFT node.
+//
+// If disabled and the client attempts to access the mempool service API, the
+// client will panic.
+func WithMempoolServiceEnabled(enabled bool) Option {
+	return func(b *clientBuilder) {
+		b.mempoolServiceEnabled = enabled
+	}
+}
+
+// WithEventsServiceEnabled allows control of whether or not to create a
+// client for interacting with the events service of a CometBFT node.
+//
+// If disabled and the client attempts to access the events service API, the
+// client will panic.
+func WithEventsServiceEnabled(enabled bool) Option {
+	return func(b *clientBuilder) {
+		b.eventsServiceEnabled = enabled
+	}
+}
+
+// WithQueryServiceEnabled allows control of whether or not to create a
+// client for interacting with the query service of a CometBFT node.
+//
+// If disabled and the client attempts to access the query service API, the
+// client will panic.
+func WithQueryServiceEnabled(enabled bool) Option {
+	return func(b *clientBuilder) {
+		b.queryServiceEnabled = enabled
+	}
+}
+
 // WithGRPCDialOption allows passing lower-level gRPC dial options through to
 // the gRPC dialer when creating the client.
 func WithGRPCDialOption(opt ggrpc.DialOption) Option {
@@ -133,10 +178,25 @@ func New(ctx context.Context, addr string, opts ...Option) (Client, error) {
 	if builder.blockResultsServiceEnabled {
 		blockResultServiceClient = newBlockResultsServiceClient(conn)
 	}
+	mempoolServiceClient := newDisabledMempoolServiceClient()
+	if builder.mempoolServiceEnabled {
+		mempoolServiceClient = newMempoolServiceClient(conn)
+	}
+	eventsServiceClient := newDisabledEventsServiceClient()
+	if builder.eventsServiceEnabled {
+		eventsServiceClient = newEventsServiceClient(conn)
+	}
+	queryServiceClient := newDisabledQueryServiceClient()
+	if builder.queryServiceEnabled {
+		queryServiceClient = newQueryServiceClient(conn)
+	}
 	return &client{
 		conn:                      conn,
 		VersionServiceClient:      versionServiceClient,
 		BlockServiceClient:        blockServiceClient,
 		BlockResultsServiceClient: blockResultServiceClient,
+		MempoolServiceClient:      mempoolServiceClient,
+		EventsServiceClient:       eventsServiceClient,
+		QueryServiceClient:        queryServiceClient,
 	}, nil
 }
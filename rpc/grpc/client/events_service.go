@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	eventssvc "github.com/cometbft/cometbft/proto/tendermint/services/events/v1"
+	"github.com/cometbft/cometbft/types"
+	"github.com/cosmos/gogoproto/grpc"
+)
+
+// EventResult type used in Subscribe and sent to the client via a channel. It
+// holds an event matching the query of the subscription, as for the /subscribe
+// RPC endpoint.
+type EventResult struct {
+	Data   types.TMEventData
+	Events map[string][]string
+	// Dropped is the number of events dropped so far by the server, if the
+	// subscription drops the events when its buffer is full.
+	Dropped uint64
+	Error   error
+}
+
+type subscribeConfig struct {
+	bufferSize   uint32
+	dropWhenFull bool
+	chSize       uint
+}
+
+type SubscribeOption func(*subscribeConfig)
+
+// SubscribeBufferSize sets the number of events buffered by the server for the
+// subscription. If not used or set to 0, the maximum buffer size of the server
+// is used.
+func SubscribeBufferSize(sz uint32) SubscribeOption {
+	return func(opts *subscribeConfig) {
+		opts.bufferSize = sz
+	}
+}
+
+// SubscribeDropWhenFull makes the server drop the events when the buffer of
+// the subscription is full, instead of canceling the subscription.
+func SubscribeDropWhenFull(drop bool) SubscribeOption {
+	return func(opts *subscribeConfig) {
+		opts.dropWhenFull = drop
+	}
+}
+
+// SubscribeChannelSize allows control over the channel size. If not used or
+// the channel size is set to 0, an unbuffered channel will be created.
+func SubscribeChannelSize(sz uint) SubscribeOption {
+	return func(opts *subscribeConfig) {
+		opts.chSize = sz
+	}
+}
+
+// EventsServiceClient provides the events of a node
+type EventsServiceClient interface {
+	// Subscribe sends the events matching the query to the resulting output
+	// channel, as they are published. The query has the syntax of the
+	// /subscribe RPC endpoint.
+	Subscribe(ctx context.Context, query string, opts ...SubscribeOption) (<-chan EventResult, error)
+}
+
+type eventsServiceClient struct {
+	client eventssvc.EventsServiceClient
+}
+
+func newEventsServiceClient(conn grpc.ClientConn) EventsServiceClient {
+	return &eventsServiceClient{
+		client: eventssvc.NewEventsServiceClient(conn),
+	}
+}
+
+// Subscribe implements EventsServiceClient Subscribe
+func (c *eventsServiceClient) Subscribe(ctx context.Context, query string, opts ...SubscribeOption) (<-chan EventResult, error) {
+	cfg := &subscribeConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	req := eventssvc.SubscribeRequest{
+		Query:        query,
+		BufferSize:   cfg.bufferSize,
+		DropWhenFull: cfg.dropWhenFull,
+	}
+
+	subscribeClient, err := c.client.Subscribe(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting a stream for the events: %w", err)
+	}
+
+	resultCh := make(chan EventResult, cfg.chSize)
+
+	go func(client eventssvc.EventsService_SubscribeClient) {
+		defer close(resultCh)
+		for {
+			response, err := client.Recv()
+			if err != nil {
+				res := EventResult{Error: fmt.Errorf("error receiving an event from a stream: %w", err)}
+				select {
+				case <-ctx.Done():
+				case resultCh <- res:
+				}
+				return
+			}
+			res := EventResult{
+				Events:  make(map[string][]string, len(response.Events)),
+				Dropped: response.Dropped,
+			}
+			for _, attr := range response.Events {
+				res.Events[attr.Key] = attr.Values
+			}
+			if err := cmtjson.Unmarshal(response.Data, &res.Data); err != nil {
+				res.Error = fmt.Errorf("error decoding the data of an event: %w", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case resultCh <- res:
+			}
+		}
+	}(subscribeClient)
+
+	return resultCh, nil
+}
+
+type disabledEventsServiceClient struct{}
+
+func newDisabledEventsServiceClient() EventsServiceClient {
+	return &disabledEventsServiceClient{}
+}
+
+// Subscribe implements EventsServiceClient Subscribe - disabled client
+func (*disabledEventsServiceClient) Subscribe(context.Context, string, ...SubscribeOption) (<-chan EventResult, error) {
+	panic("events service client is disabled")
+}
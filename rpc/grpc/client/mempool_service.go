@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	mempoolsvc "github.com/cometbft/cometbft/proto/tendermint/services/mempool/v1"
+	"github.com/cometbft/cometbft/types"
+	"github.com/cosmos/gogoproto/grpc"
+)
+
+// NewTxResult type used in GetNewTxs and sent to the client via a channel. It
+// holds a tx accepted into the mempool, with the response of the application
+// to CheckTx.
+type NewTxResult struct {
+	Tx      types.Tx
+	CheckTx *abci.ResponseCheckTx
+	Error   error
+}
+
+type getNewTxsConfig struct {
+	chSize uint
+}
+
+type GetNewTxsOption func(*getNewTxsConfig)
+
+// GetNewTxsChannelSize allows control over the channel size. If not used or
+// the channel size is set to 0, an unbuffered channel will be created.
+func GetNewTxsChannelSize(sz uint) GetNewTxsOption {
+	return func(opts *getNewTxsConfig) {
+		opts.chSize = sz
+	}
+}
+
+// MempoolServiceClient provides information about the mempool
+type MempoolServiceClient interface {
+	// GetNewTxs sends the txs accepted into the mempool to the resulting
+	// output channel, as they are accepted. Unlike GetLatestHeight, no tx is
+	// skipped if the channel is full: the stream is canceled by the server
+	// instead if the client is too slow.
+	GetNewTxs(ctx context.Context, opts ...GetNewTxsOption) (<-chan NewTxResult, error)
+}
+
+type mempoolServiceClient struct {
+	client mempoolsvc.MempoolServiceClient
+}
+
+func newMempoolServiceClient(conn grpc.ClientConn) MempoolServiceClient {
+	return &mempoolServiceClient{
+		client: mempoolsvc.NewMempoolServiceClient(conn),
+	}
+}
+
+// GetNewTxs implements MempoolServiceClient GetNewTxs
+func (c *mempoolServiceClient) GetNewTxs(ctx context.Context, opts ...GetNewTxsOption) (<-chan NewTxResult, error) {
+	req := mempoolsvc.GetNewTxsRequest{}
+
+	newTxsClient, err := c.client.GetNewTxs(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting a stream for the new mempool txs: %w", err)
+	}
+
+	cfg := &getNewTxsConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	resultCh := make(chan NewTxResult, cfg.chSize)
+
+	go func(client mempoolsvc.MempoolService_GetNewTxsClient) {
+		defer close(resultCh)
+		for {
+			response, err := client.Recv()
+			if err != nil {
+				res := NewTxResult{Error: fmt.Errorf("error receiving a new mempool tx from a stream: %w", err)}
+				select {
+				case <-ctx.Done():
+				case resultCh <- res:
+				}
+				return
+			}
+			res := NewTxResult{Tx: response.Tx, CheckTx: response.CheckTx}
+			select {
+			case <-ctx.Done():
+				return
+			case resultCh <- res:
+			}
+		}
+	}(newTxsClient)
+
+	return resultCh, nil
+}
+
+type disabledMempoolServiceClient struct{}
+
+func newDisabledMempoolServiceClient() MempoolServiceClient {
+	return &disabledMempoolServiceClient{}
+}
+
+// GetNewTxs implements MempoolServiceClient GetNewTxs - disabled client
+func (*disabledMempoolServiceClient) GetNewTxs(context.Context, ...GetNewTxsOption) (<-chan NewTxResult, error) {
+	panic("mempool service client is disabled")
+}
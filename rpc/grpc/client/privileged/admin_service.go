@@ -0,0 +1,234 @@
+package privileged
+
+import (
+	"context"
+	"time"
+
+	"github.com/cosmos/gogoproto/grpc"
+	gogotypes "github.com/cosmos/gogoproto/types"
+
+	v1 "github.com/cometbft/cometbft/proto/tendermint/services/admin/v1"
+	"github.com/cometbft/cometbft/types"
+)
+
+// DialPeersOptions controls how the peers dialed via the admin service are
+// treated by the node.
+type DialPeersOptions struct {
+	Persistent    bool
+	Unconditional bool
+	Private       bool
+}
+
+// BackupInfo describes a backup of the block and state stores taken via the
+// admin service.
+type BackupInfo struct {
+	Dir              string
+	StateHeight      int64
+	BlockStoreHeight int64
+}
+
+// KnownAddress is the quality data of an address of the address book of the
+// node.
+type KnownAddress struct {
+	Address string
+	// Source is empty if the address was added by the node itself.
+	Source string
+	// BucketType is either "new" or "old".
+	BucketType string
+	Attempts   int32
+	// The zero time means never.
+	LastAttempt time.Time
+	LastSuccess time.Time
+	BannedUntil time.Time
+	LastCrawled time.Time
+	Network     string
+}
+
+type AdminServiceClient interface {
+	DialSeeds(ctx context.Context, seeds []string) error
+	DialPeers(ctx context.Context, peers []string, opts DialPeersOptions) error
+	DisconnectPeer(ctx context.Context, peerID string) error
+	DumpAddrBook(ctx context.Context) ([]KnownAddress, error)
+	FlushMempool(ctx context.Context) error
+	// DumpMempool returns up to limit transactions from the mempool, 0
+	// meaning all of them.
+	DumpMempool(ctx context.Context, limit uint64) (types.Txs, error)
+	PausePruning(ctx context.Context) error
+	ResumePruning(ctx context.Context) error
+	Backup(ctx context.Context, dir string) (BackupInfo, error)
+	Halt(ctx context.Context) error
+}
+
+type adminServiceClient struct {
+	inner v1.AdminServiceClient
+}
+
+func newAdminServiceClient(conn grpc.ClientConn) AdminServiceClient {
+	return &adminServiceClient{
+		inner: v1.NewAdminServiceClient(conn),
+	}
+}
+
+// DialSeeds implements AdminServiceClient.
+func (c *adminServiceClient) DialSeeds(ctx context.Context, seeds []string) error {
+	_, err := c.inner.DialSeeds(ctx, &v1.DialSeedsRequest{Seeds: seeds})
+	return err
+}
+
+// DialPeers implements AdminServiceClient.
+func (c *adminServiceClient) DialPeers(ctx context.Context, peers []string, opts DialPeersOptions) error {
+	_, err := c.inner.DialPeers(ctx, &v1.DialPeersRequest{
+		Peers:         peers,
+		Persistent:    opts.Persistent,
+		Unconditional: opts.Unconditional,
+		Private:       opts.Private,
+	})
+	return err
+}
+
+// DisconnectPeer implements AdminServiceClient.
+func (c *adminServiceClient) DisconnectPeer(ctx context.Context, peerID string) error {
+	_, err := c.inner.DisconnectPeer(ctx, &v1.DisconnectPeerRequest{PeerId: peerID})
+	return err
+}
+
+// DumpAddrBook implements AdminServiceClient.
+func (c *adminServiceClient) DumpAddrBook(ctx context.Context) ([]KnownAddress, error) {
+	res, err := c.inner.DumpAddrBook(ctx, &v1.DumpAddrBookRequest{})
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]KnownAddress, len(res.Addresses))
+	for i, addr := range res.Addresses {
+		addrs[i] = KnownAddress{
+			Address:     addr.Address,
+			Source:      addr.Source,
+			BucketType:  addr.BucketType,
+			Attempts:    addr.Attempts,
+			LastAttempt: timeFromProto(addr.LastAttempt),
+			LastSuccess: timeFromProto(addr.LastSuccess),
+			BannedUntil: timeFromProto(addr.BannedUntil),
+			LastCrawled: timeFromProto(addr.LastCrawled),
+			Network:     addr.Network,
+		}
+	}
+	return addrs, nil
+}
+
+// timeFromProto returns the time of ts, or the zero time if ts is unset.
+func timeFromProto(ts *gogotypes.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	t, err := gogotypes.TimestampFromProto(ts)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// FlushMempool implements AdminServiceClient.
+func (c *adminServiceClient) FlushMempool(ctx context.Context) error {
+	_, err := c.inner.FlushMempool(ctx, &v1.FlushMempoolRequest{})
+	return err
+}
+
+// DumpMempool implements AdminServiceClient.
+func (c *adminServiceClient) DumpMempool(ctx context.Context, limit uint64) (types.Txs, error) {
+	res, err := c.inner.DumpMempool(ctx, &v1.DumpMempoolRequest{Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	txs := make(types.Txs, len(res.Txs))
+	for i, tx := range res.Txs {
+		txs[i] = tx
+	}
+	return txs, nil
+}
+
+// PausePruning implements AdminServiceClient.
+func (c *adminServiceClient) PausePruning(ctx context.Context) error {
+	_, err := c.inner.PausePruning(ctx, &v1.PausePruningRequest{})
+	return err
+}
+
+// ResumePruning implements AdminServiceClient.
+func (c *adminServiceClient) ResumePruning(ctx context.Context) error {
+	_, err := c.inner.ResumePruning(ctx, &v1.ResumePruningRequest{})
+	return err
+}
+
+// Backup implements AdminServiceClient.
+func (c *adminServiceClient) Backup(ctx context.Context, dir string) (BackupInfo, error) {
+	res, err := c.inner.Backup(ctx, &v1.BackupRequest{Dir: dir})
+	if err != nil {
+		return BackupInfo{}, err
+	}
+	return BackupInfo{
+		Dir:              res.Dir,
+		StateHeight:      res.StateHeight,
+		BlockStoreHeight: res.BlockStoreHeight,
+	}, nil
+}
+
+// Halt implements AdminServiceClient.
+func (c *adminServiceClient) Halt(ctx context.Context) error {
+	_, err := c.inner.Halt(ctx, &v1.HaltRequest{})
+	return err
+}
+
+type disabledAdminServiceClient struct{}
+
+func newDisabledAdminServiceClient() AdminServiceClient {
+	return &disabledAdminServiceClient{}
+}
+
+// DialSeeds implements AdminServiceClient.
+func (*disabledAdminServiceClient) DialSeeds(context.Context, []string) error {
+	panic("admin service client is disabled")
+}
+
+// DialPeers implements AdminServiceClient.
+func (*disabledAdminServiceClient) DialPeers(context.Context, []string, DialPeersOptions) error {
+	panic("admin service client is disabled")
+}
+
+// DisconnectPeer implements AdminServiceClient.
+func (*disabledAdminServiceClient) DisconnectPeer(context.Context, string) error {
+	panic("admin service client is disabled")
+}
+
+// DumpAddrBook implements AdminServiceClient.
+func (*disabledAdminServiceClient) DumpAddrBook(context.Context) ([]KnownAddress, error) {
+	panic("admin service client is disabled")
+}
+
+// FlushMempool implements AdminServiceClient.
+func (*disabledAdminServiceClient) FlushMempool(context.Context) error {
+	panic("admin service client is disabled")
+}
+
+// DumpMempool implements AdminServiceClient.
+func (*disabledAdminServiceClient) DumpMempool(context.Context, uint64) (types.Txs, error) {
+	panic("admin service client is disabled")
+}
+
+// PausePruning implements AdminServiceClient.
+func (*disabledAdminServiceClient) PausePruning(context.Context) error {
+	panic("admin service client is disabled")
+}
+
+// ResumePruning implements AdminServiceClient.
+func (*disabledAdminServiceClient) ResumePruning(context.Context) error {
+	panic("admin service client is disabled")
+}
+
+// Backup implements AdminServiceClient.
+func (*disabledAdminServiceClient) Backup(context.Context, string) (BackupInfo, error) {
+	panic("admin service client is disabled")
+}
+
+// Halt implements AdminServiceClient.
+func (*disabledAdminServiceClient) Halt(context.Context) error {
+	panic("admin service client is disabled")
+}
@@ -17,6 +17,7 @@ type Option func(*clientBuilder)
 // a CometBFT node via the privileged gRPC server.
 type Client interface {
 	PruningServiceClient
+	AdminServiceClient
 
 	// Close the connection to the server. Any subsequent requests will fail.
 	Close() error
@@ -27,6 +28,7 @@ type clientBuilder struct {
 	grpcOpts   []ggrpc.DialOption
 
 	pruningServiceEnabled bool
+	adminServiceEnabled   bool
 }
 
 func newClientBuilder() *clientBuilder {
@@ -34,6 +36,7 @@ func newClientBuilder() *clientBuilder {
 		dialerFunc:            defaultDialerFunc,
 		grpcOpts:              make([]ggrpc.DialOption, 0),
 		pruningServiceEnabled: true,
+		adminServiceEnabled:   true,
 	}
 }
 
@@ -45,6 +48,7 @@ type client struct {
 	conn *ggrpc.ClientConn
 
 	PruningServiceClient
+	AdminServiceClient
 }
 
 // Close implements Client.
@@ -72,6 +76,17 @@ func WithPruningServiceEnabled(enabled bool) Option {
 	}
 }
 
+// WithAdminServiceEnabled allows control of whether or not to create a client
+// for interacting with the admin service of a CometBFT node.
+//
+// If disabled and the client attempts to access the admin service API, the
+// client will panic.
+func WithAdminServiceEnabled(enabled bool) Option {
+	return func(b *clientBuilder) {
+		b.adminServiceEnabled = enabled
+	}
+}
+
 // WithGRPCDialOption allows passing lower-level gRPC dial options through to
 // the gRPC dialer when creating the client.
 func WithGRPCDialOption(opt ggrpc.DialOption) Option {
@@ -103,8 +118,13 @@ func New(ctx context.Context, addr string, opts ...Option) (Client, error) {
 	if builder.pruningServiceEnabled {
 		pruningServiceClient = newPruningServiceClient(conn)
 	}
+	adminServiceClient := newDisabledAdminServiceClient()
+	if builder.adminServiceEnabled {
+		adminServiceClient = newAdminServiceClient(conn)
+	}
 	return &client{
 		conn:                 conn,
 		PruningServiceClient: pruningServiceClient,
+		AdminServiceClient:   adminServiceClient,
 	}, nil
 }
@@ -0,0 +1,313 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cosmos/gogoproto/grpc"
+	gogotypes "github.com/cosmos/gogoproto/types"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cryptoenc "github.com/cometbft/cometbft/crypto/encoding"
+	"github.com/cometbft/cometbft/p2p"
+	querysvc "github.com/cometbft/cometbft/proto/tendermint/services/query/v1"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Tx is an indexed transaction returned by the CometBFT QueryService gRPC
+// API.
+type Tx struct {
+	Hash     []byte             `json:"hash"`
+	Height   int64              `json:"height"`
+	Index    uint32             `json:"index"`
+	TxResult *abci.ExecTxResult `json:"tx_result"`
+	Tx       types.Tx           `json:"tx"`
+	// The proof of the inclusion of the transaction in its block, nil if
+	// not requested.
+	Proof *types.TxProof `json:"proof,omitempty"`
+}
+
+func txFromProto(ptx *querysvc.ResultTx) (*Tx, error) {
+	tx := &Tx{
+		Hash:     ptx.Hash,
+		Height:   ptx.Height,
+		Index:    ptx.Index,
+		TxResult: ptx.TxResult,
+		Tx:       ptx.Tx,
+	}
+	if ptx.Proof != nil {
+		proof, err := types.TxProofFromProto(*ptx.Proof)
+		if err != nil {
+			return nil, err
+		}
+		tx.Proof = &proof
+	}
+	return tx, nil
+}
+
+// TxSearchResult type used in TxSearch and sent to the client via a channel.
+// It holds a transaction matching the query, with the cursor to resume the
+// search after it.
+type TxSearchResult struct {
+	Tx     *Tx
+	Cursor string
+	Error  error
+}
+
+type txSearchConfig struct {
+	prove     bool
+	orderDesc bool
+	cursor    string
+	chSize    uint
+}
+
+type TxSearchOption func(*txSearchConfig)
+
+// TxSearchProve requests the proofs of the inclusion of the transactions.
+func TxSearchProve(prove bool) TxSearchOption {
+	return func(opts *txSearchConfig) {
+		opts.prove = prove
+	}
+}
+
+// TxSearchOrderDesc orders the transactions by decreasing heights and
+// indexes, instead of increasing ones.
+func TxSearchOrderDesc(orderDesc bool) TxSearchOption {
+	return func(opts *txSearchConfig) {
+		opts.orderDesc = orderDesc
+	}
+}
+
+// TxSearchCursor resumes the search after the transaction with the given
+// cursor, as sent in a previous TxSearchResult.
+func TxSearchCursor(cursor string) TxSearchOption {
+	return func(opts *txSearchConfig) {
+		opts.cursor = cursor
+	}
+}
+
+// TxSearchChannelSize allows control over the channel size. If not used or
+// the channel size is set to 0, an unbuffered channel will be created.
+func TxSearchChannelSize(sz uint) TxSearchOption {
+	return func(opts *txSearchConfig) {
+		opts.chSize = sz
+	}
+}
+
+// BlockSearchResult is a page of the blocks matching a query.
+type BlockSearchResult struct {
+	Blocks []*Block `json:"blocks"`
+	// The number of blocks matching the query, over all the pages.
+	TotalCount int64 `json:"total_count"`
+}
+
+// QueryServiceClient provides the blocks, transactions and status of a node.
+type QueryServiceClient interface {
+	// GetBlockByHash attempts to retrieve the block with the given hash.
+	GetBlockByHash(ctx context.Context, hash []byte) (*Block, error)
+
+	// GetTx attempts to retrieve the indexed transaction with the given hash,
+	// with the proof of its inclusion in its block if prove is set.
+	GetTx(ctx context.Context, hash []byte, prove bool) (*Tx, error)
+
+	// TxSearch sends the indexed transactions matching the query to the
+	// resulting output channel, ordered by height and index. The channel is
+	// closed after the last one, or after a result with an error.
+	TxSearch(ctx context.Context, query string, opts ...TxSearchOption) (<-chan TxSearchResult, error)
+
+	// BlockSearch attempts to retrieve a page of the blocks matching the
+	// query, ordered by height. The page is 1-based, the default per page
+	// is used if perPage is 0.
+	BlockSearch(ctx context.Context, query string, page, perPage uint32, orderDesc bool) (*BlockSearchResult, error)
+
+	// GetStatus attempts to retrieve the status of the node.
+	GetStatus(ctx context.Context) (*ctypes.ResultStatus, error)
+}
+
+type queryServiceClient struct {
+	client querysvc.QueryServiceClient
+}
+
+func newQueryServiceClient(conn grpc.ClientConn) QueryServiceClient {
+	return &queryServiceClient{
+		client: querysvc.NewQueryServiceClient(conn),
+	}
+}
+
+// GetBlockByHash implements QueryServiceClient.
+func (c *queryServiceClient) GetBlockByHash(ctx context.Context, hash []byte) (*Block, error) {
+	res, err := c.client.GetBlock(ctx, &querysvc.GetBlockRequest{Hash: hash})
+	if err != nil {
+		return nil, err
+	}
+
+	return blockFromProto(res.Block.BlockId, res.Block.Block)
+}
+
+// GetTx implements QueryServiceClient.
+func (c *queryServiceClient) GetTx(ctx context.Context, hash []byte, prove bool) (*Tx, error) {
+	res, err := c.client.GetTx(ctx, &querysvc.GetTxRequest{Hash: hash, Prove: prove})
+	if err != nil {
+		return nil, err
+	}
+
+	return txFromProto(res.Tx)
+}
+
+// TxSearch implements QueryServiceClient.
+func (c *queryServiceClient) TxSearch(ctx context.Context, query string, opts ...TxSearchOption) (<-chan TxSearchResult, error) {
+	cfg := &txSearchConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	txSearchClient, err := c.client.TxSearch(ctx, &querysvc.TxSearchRequest{
+		Query:     query,
+		Prove:     cfg.prove,
+		OrderDesc: cfg.orderDesc,
+		Cursor:    cfg.cursor,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting a stream for the tx search: %w", err)
+	}
+
+	resultCh := make(chan TxSearchResult, cfg.chSize)
+	go func(client querysvc.QueryService_TxSearchClient) {
+		defer close(resultCh)
+		for {
+			var res TxSearchResult
+			response, err := client.Recv()
+			if err == nil {
+				res.Tx, err = txFromProto(response.Tx)
+				res.Cursor = response.Cursor
+			}
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return
+				}
+				res.Error = fmt.Errorf("error receiving a tx from a stream: %w", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case resultCh <- res:
+			}
+			if res.Error != nil {
+				return
+			}
+		}
+	}(txSearchClient)
+
+	return resultCh, nil
+}
+
+// BlockSearch implements QueryServiceClient.
+func (c *queryServiceClient) BlockSearch(ctx context.Context, query string, page, perPage uint32, orderDesc bool) (*BlockSearchResult, error) {
+	res, err := c.client.BlockSearch(ctx, &querysvc.BlockSearchRequest{
+		Query:     query,
+		OrderDesc: orderDesc,
+		Page:      page,
+		PerPage:   perPage,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*Block, 0, len(res.Blocks))
+	for _, b := range res.Blocks {
+		block, err := blockFromProto(b.BlockId, b.Block)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+	return &BlockSearchResult{Blocks: blocks, TotalCount: res.TotalCount}, nil
+}
+
+// GetStatus implements QueryServiceClient.
+func (c *queryServiceClient) GetStatus(ctx context.Context) (*ctypes.ResultStatus, error) {
+	res, err := c.client.Status(ctx, &querysvc.StatusRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	nodeInfo, err := p2p.DefaultNodeInfoFromToProto(res.NodeInfo)
+	if err != nil {
+		return nil, err
+	}
+	latestBlockTime, err := timeFromProto(res.SyncInfo.LatestBlockTime)
+	if err != nil {
+		return nil, err
+	}
+	earliestBlockTime, err := timeFromProto(res.SyncInfo.EarliestBlockTime)
+	if err != nil {
+		return nil, err
+	}
+	status := &ctypes.ResultStatus{
+		NodeInfo: nodeInfo,
+		SyncInfo: ctypes.SyncInfo{
+			LatestBlockHash:     res.SyncInfo.LatestBlockHash,
+			LatestAppHash:       res.SyncInfo.LatestAppHash,
+			LatestBlockHeight:   res.SyncInfo.LatestBlockHeight,
+			LatestBlockTime:     latestBlockTime,
+			EarliestBlockHash:   res.SyncInfo.EarliestBlockHash,
+			EarliestAppHash:     res.SyncInfo.EarliestAppHash,
+			EarliestBlockHeight: res.SyncInfo.EarliestBlockHeight,
+			EarliestBlockTime:   earliestBlockTime,
+			CatchingUp:          res.SyncInfo.CatchingUp,
+		},
+		ValidatorInfo: ctypes.ValidatorInfo{
+			Address:     res.ValidatorInfo.Address,
+			VotingPower: res.ValidatorInfo.VotingPower,
+		},
+	}
+	if res.ValidatorInfo.PubKey != nil {
+		status.ValidatorInfo.PubKey, err = cryptoenc.PubKeyFromProto(*res.ValidatorInfo.PubKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+func timeFromProto(ts *gogotypes.Timestamp) (time.Time, error) {
+	if ts == nil {
+		return time.Time{}, nil
+	}
+	return gogotypes.TimestampFromProto(ts)
+}
+
+type disabledQueryServiceClient struct{}
+
+func newDisabledQueryServiceClient() QueryServiceClient {
+	return &disabledQueryServiceClient{}
+}
+
+// GetBlockByHash implements QueryServiceClient - disabled client
+func (*disabledQueryServiceClient) GetBlockByHash(context.Context, []byte) (*Block, error) {
+	panic("query service client is disabled")
+}
+
+// GetTx implements QueryServiceClient - disabled client
+func (*disabledQueryServiceClient) GetTx(context.Context, []byte, bool) (*Tx, error) {
+	panic("query service client is disabled")
+}
+
+// TxSearch implements QueryServiceClient - disabled client
+func (*disabledQueryServiceClient) TxSearch(context.Context, string, ...TxSearchOption) (<-chan TxSearchResult, error) {
+	panic("query service client is disabled")
+}
+
+// BlockSearch implements QueryServiceClient - disabled client
+func (*disabledQueryServiceClient) BlockSearch(context.Context, string, uint32, uint32, bool) (*BlockSearchResult, error) {
+	panic("query service client is disabled")
+}
+
+// GetStatus implements QueryServiceClient - disabled client
+func (*disabledQueryServiceClient) GetStatus(context.Context) (*ctypes.ResultStatus, error) {
+	panic("query service client is disabled")
+}
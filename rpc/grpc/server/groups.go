@@ -0,0 +1,37 @@
+package server
+
+import (
+	"strings"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionalphapb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+
+	"github.com/cometbft/cometbft/rpc/auth"
+)
+
+// serviceGroups are the groups of the methods of the services of the server,
+// by service name.
+var serviceGroups = map[string]string{
+	"tendermint.services.version.v1.VersionService":            auth.GroupRead,
+	"tendermint.services.block.v1.BlockService":                auth.GroupRead,
+	"tendermint.services.block_results.v1.BlockResultsService": auth.GroupRead,
+	"tendermint.services.mempool.v1.MempoolService":            auth.GroupRead,
+	"tendermint.services.events.v1.EventsService":              auth.GroupRead,
+	"tendermint.services.query.v1.QueryService":                auth.GroupRead,
+	healthpb.Health_ServiceDesc.ServiceName:                    auth.GroupRead,
+	reflectionpb.ServerReflection_ServiceDesc.ServiceName:      auth.GroupRead,
+	reflectionalphapb.ServerReflection_ServiceDesc.ServiceName: auth.GroupRead,
+}
+
+// MethodGroup returns the group of the gRPC method with the given full name,
+// e.g. "/tendermint.services.block.v1.BlockService/GetByHeight", to which the
+// API keys can give access: all the services of the server only read the
+// state of the node. The methods of unknown services are in no group.
+func MethodGroup(method string) string {
+	service, _, ok := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	if !ok {
+		return ""
+	}
+	return serviceGroups[service]
+}
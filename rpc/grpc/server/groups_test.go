@@ -0,0 +1,27 @@
+package server_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/rpc/auth"
+	grpcserver "github.com/cometbft/cometbft/rpc/grpc/server"
+)
+
+func TestMethodGroup(t *testing.T) {
+	testCases := []struct {
+		method string
+		group  string
+	}{
+		{"/tendermint.services.block.v1.BlockService/GetByHeight", auth.GroupRead},
+		{"/tendermint.services.query.v1.QueryService/TxSearch", auth.GroupRead},
+		{"/grpc.health.v1.Health/Check", auth.GroupRead},
+		{"/tendermint.services.admin.v1.AdminService/Halt", ""},
+		{"/unknown.Service/Method", ""},
+		{"malformed", ""},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.group, grpcserver.MethodGroup(tc.method), tc.method)
+	}
+}
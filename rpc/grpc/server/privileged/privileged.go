@@ -7,7 +7,10 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/cometbft/cometbft/libs/log"
+	pbadminsvc "github.com/cometbft/cometbft/proto/tendermint/services/admin/v1"
 	pbpruningsvc "github.com/cometbft/cometbft/proto/tendermint/services/pruning/v1"
+	grpcserver "github.com/cometbft/cometbft/rpc/grpc/server"
+	"github.com/cometbft/cometbft/rpc/grpc/server/services/adminservice"
 	"github.com/cometbft/cometbft/rpc/grpc/server/services/pruningservice"
 	sm "github.com/cometbft/cometbft/state"
 )
@@ -19,6 +22,7 @@ type Option func(*serverBuilder)
 type serverBuilder struct {
 	listener       net.Listener
 	pruningService pbpruningsvc.PruningServiceServer
+	adminService   pbadminsvc.AdminServiceServer
 	logger         log.Logger
 	grpcOpts       []grpc.ServerOption
 }
@@ -31,13 +35,21 @@ func newServerBuilder(listener net.Listener) *serverBuilder {
 	}
 }
 
-// WithVersionService enables the version service on the CometBFT server.
+// WithPruningService enables the pruning service on the CometBFT server.
 func WithPruningService(pruner *sm.Pruner, logger log.Logger) Option {
 	return func(b *serverBuilder) {
 		b.pruningService = pruningservice.New(pruner, logger)
 	}
 }
 
+// WithAdminService enables the admin service on the CometBFT server, operating
+// on the node components in the given environment.
+func WithAdminService(env adminservice.Environment, logger log.Logger) Option {
+	return func(b *serverBuilder) {
+		b.adminService = adminservice.New(env, logger)
+	}
+}
+
 // WithLogger enables logging using the given logger. If not specified, the
 // gRPC server does not log anything.
 func WithLogger(logger log.Logger) Option {
@@ -69,6 +81,12 @@ func Serve(listener net.Listener, opts ...Option) error {
 		pbpruningsvc.RegisterPruningServiceServer(server, b.pruningService)
 		b.logger.Debug("Registered pruning service")
 	}
+	if b.adminService != nil {
+		pbadminsvc.RegisterAdminServiceServer(server, b.adminService)
+		b.logger.Debug("Registered admin service")
+	}
+	grpcserver.RegisterStandardServices(server)
+	b.logger.Debug("Registered health and reflection services")
 	b.logger.Info("serve", "msg", fmt.Sprintf("Starting privileged gRPC server on %s", listener.Addr()))
 	return server.Serve(b.listener)
 }
@@ -15,8 +15,14 @@ import (
 
 	"github.com/cometbft/cometbft/libs/log"
 	pbblocksvc "github.com/cometbft/cometbft/proto/tendermint/services/block/v1"
+	pbeventssvc "github.com/cometbft/cometbft/proto/tendermint/services/events/v1"
+	pbmempoolsvc "github.com/cometbft/cometbft/proto/tendermint/services/mempool/v1"
+	pbquerysvc "github.com/cometbft/cometbft/proto/tendermint/services/query/v1"
 	pbversionsvc "github.com/cometbft/cometbft/proto/tendermint/services/version/v1"
 	"github.com/cometbft/cometbft/rpc/grpc/server/services/blockservice"
+	"github.com/cometbft/cometbft/rpc/grpc/server/services/eventsservice"
+	"github.com/cometbft/cometbft/rpc/grpc/server/services/mempoolservice"
+	"github.com/cometbft/cometbft/rpc/grpc/server/services/queryservice"
 	"github.com/cometbft/cometbft/rpc/grpc/server/services/versionservice"
 	"github.com/cometbft/cometbft/types"
 )
@@ -30,6 +36,9 @@ type serverBuilder struct {
 	versionService      pbversionsvc.VersionServiceServer
 	blockService        pbblocksvc.BlockServiceServer
 	blockResultsService brs.BlockResultsServiceServer
+	mempoolService      pbmempoolsvc.MempoolServiceServer
+	eventsService       pbeventssvc.EventsServiceServer
+	queryService        pbquerysvc.QueryServiceServer
 	logger              log.Logger
 	grpcOpts            []grpc.ServerOption
 }
@@ -78,6 +87,29 @@ func WithBlockResultsService(bs *store.BlockStore, ss sm.Store, logger log.Logge
 	}
 }
 
+// WithMempoolService enables the mempool service on the CometBFT server.
+func WithMempoolService(eventBus *types.EventBus, logger log.Logger) Option {
+	return func(b *serverBuilder) {
+		b.mempoolService = mempoolservice.New(eventBus, logger)
+	}
+}
+
+// WithEventsService enables the events service on the CometBFT server. Each
+// stream subscribes to the event bus as a client, up to maxClients in total
+// with the other subscribers, and buffers up to maxBufferSize events.
+func WithEventsService(eventBus *types.EventBus, maxClients, maxBufferSize int, logger log.Logger) Option {
+	return func(b *serverBuilder) {
+		b.eventsService = eventsservice.New(eventBus, maxClients, maxBufferSize, logger)
+	}
+}
+
+// WithQueryService enables the query service on the CometBFT server.
+func WithQueryService(env queryservice.Environment, logger log.Logger) Option {
+	return func(b *serverBuilder) {
+		b.queryService = queryservice.New(env, logger)
+	}
+}
+
 // WithLogger enables logging using the given logger. If not specified, the
 // gRPC server does not log anything.
 func WithLogger(logger log.Logger) Option {
@@ -117,6 +149,20 @@ func Serve(listener net.Listener, opts ...Option) error {
 		brs.RegisterBlockResultsServiceServer(server, b.blockResultsService)
 		b.logger.Debug("Registered block results service")
 	}
+	if b.mempoolService != nil {
+		pbmempoolsvc.RegisterMempoolServiceServer(server, b.mempoolService)
+		b.logger.Debug("Registered mempool service")
+	}
+	if b.eventsService != nil {
+		pbeventssvc.RegisterEventsServiceServer(server, b.eventsService)
+		b.logger.Debug("Registered events service")
+	}
+	if b.queryService != nil {
+		pbquerysvc.RegisterQueryServiceServer(server, b.queryService)
+		b.logger.Debug("Registered query service")
+	}
+	RegisterStandardServices(server)
+	b.logger.Debug("Registered health and reflection services")
 	b.logger.Info("serve", "msg", fmt.Sprintf("Starting gRPC server on %s", listener.Addr()))
 	return server.Serve(b.listener)
 }
@@ -0,0 +1,286 @@
+package adminservice
+
+import (
+	context "context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	gogotypes "github.com/cosmos/gogoproto/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cometbft/cometbft/internal/rpctrace"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/pex"
+	v1 "github.com/cometbft/cometbft/proto/tendermint/services/admin/v1"
+	"github.com/cometbft/cometbft/store"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Peers is the subset of the p2p switch operated on by the admin service.
+type Peers interface {
+	AddPersistentPeers(addrs []string) error
+	AddUnconditionalPeerIDs(ids []string) error
+	AddPrivatePeerIDs(ids []string) error
+	DialPeersAsync(addrs []string) error
+	Peers() p2p.IPeerSet
+	StopPeerGracefully(peer p2p.Peer)
+}
+
+// AddrBook is the subset of the PEX reactor dumping the quality data of the
+// known addresses.
+type AddrBook interface {
+	KnownAddresses() []pex.KnownAddressInfo
+}
+
+// Mempool is the subset of the mempool operated on by the admin service.
+type Mempool interface {
+	Flush()
+	ReapMaxTxs(max int) types.Txs
+}
+
+// Pruner is the subset of the pruner operated on by the admin service.
+type Pruner interface {
+	Pause()
+	Resume()
+}
+
+// Environment contains the node components operated on by the admin service.
+// The operations on a component left unset fail with the Unimplemented code.
+type Environment struct {
+	Peers Peers
+	// AddrBook is nil if the PEX reactor is disabled.
+	AddrBook AddrBook
+	Mempool  Mempool
+	Pruner   Pruner
+	// Backup takes a snapshot of the block and state stores into the given
+	// directory.
+	Backup func(dir string) (*store.BackupInfo, error)
+	// Halt initiates the graceful shutdown of the node, and returns without
+	// waiting for it to complete.
+	Halt func() error
+}
+
+type adminServiceServer struct {
+	env    Environment
+	logger log.Logger
+}
+
+// New creates a new CometBFT admin service server.
+func New(env Environment, logger log.Logger) v1.AdminServiceServer {
+	return &adminServiceServer{
+		env:    env,
+		logger: logger.With("service", "AdminService"),
+	}
+}
+
+func (s *adminServiceServer) DialSeeds(_ context.Context, request *v1.DialSeedsRequest) (*v1.DialSeedsResponse, error) {
+	if s.env.Peers == nil {
+		return nil, status.Error(codes.Unimplemented, "Peer management is not supported by this node")
+	}
+	if len(request.Seeds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "No seeds provided")
+	}
+	logger := s.logger.With("endpoint", "DialSeeds")
+	traceID, err := rpctrace.New()
+	if err != nil {
+		logger.Error("Error generating RPC trace ID", "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error - see logs for details")
+	}
+	logger.Info("Dialing seeds", "seeds", request.Seeds, "traceID", traceID)
+	if err := s.env.Peers.DialPeersAsync(request.Seeds); err != nil {
+		logger.Error("Cannot dial seeds", "err", err, "traceID", traceID)
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to dial seeds: %s (trace ID: %s)", err, traceID)
+	}
+	return &v1.DialSeedsResponse{}, nil
+}
+
+func (s *adminServiceServer) DialPeers(_ context.Context, request *v1.DialPeersRequest) (*v1.DialPeersResponse, error) {
+	if s.env.Peers == nil {
+		return nil, status.Error(codes.Unimplemented, "Peer management is not supported by this node")
+	}
+	if len(request.Peers) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "No peers provided")
+	}
+	ids := make([]string, 0, len(request.Peers))
+	for _, peer := range request.Peers {
+		spl := strings.Split(peer, "@")
+		if len(spl) != 2 {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid peer address %q: %s", peer, p2p.ErrNetAddressNoID{Addr: peer})
+		}
+		ids = append(ids, spl[0])
+	}
+	logger := s.logger.With("endpoint", "DialPeers")
+	traceID, err := rpctrace.New()
+	if err != nil {
+		logger.Error("Error generating RPC trace ID", "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error - see logs for details")
+	}
+	logger.Info("Dialing peers", "peers", request.Peers, "persistent", request.Persistent,
+		"unconditional", request.Unconditional, "private", request.Private, "traceID", traceID)
+
+	if request.Persistent {
+		if err := s.env.Peers.AddPersistentPeers(request.Peers); err != nil {
+			logger.Error("Cannot add persistent peers", "err", err, "traceID", traceID)
+			return nil, status.Errorf(codes.InvalidArgument, "Failed to add persistent peers: %s (trace ID: %s)", err, traceID)
+		}
+	}
+	if request.Private {
+		if err := s.env.Peers.AddPrivatePeerIDs(ids); err != nil {
+			logger.Error("Cannot add private peers", "err", err, "traceID", traceID)
+			return nil, status.Errorf(codes.InvalidArgument, "Failed to add private peers: %s (trace ID: %s)", err, traceID)
+		}
+	}
+	if request.Unconditional {
+		if err := s.env.Peers.AddUnconditionalPeerIDs(ids); err != nil {
+			logger.Error("Cannot add unconditional peers", "err", err, "traceID", traceID)
+			return nil, status.Errorf(codes.InvalidArgument, "Failed to add unconditional peers: %s (trace ID: %s)", err, traceID)
+		}
+	}
+	if err := s.env.Peers.DialPeersAsync(request.Peers); err != nil {
+		logger.Error("Cannot dial peers", "err", err, "traceID", traceID)
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to dial peers: %s (trace ID: %s)", err, traceID)
+	}
+	return &v1.DialPeersResponse{}, nil
+}
+
+func (s *adminServiceServer) DisconnectPeer(_ context.Context, request *v1.DisconnectPeerRequest) (*v1.DisconnectPeerResponse, error) {
+	if s.env.Peers == nil {
+		return nil, status.Error(codes.Unimplemented, "Peer management is not supported by this node")
+	}
+	peer := s.env.Peers.Peers().Get(p2p.ID(request.PeerId))
+	if peer == nil {
+		return nil, status.Errorf(codes.NotFound, "Not connected to peer %q", request.PeerId)
+	}
+	s.logger.Info("Disconnecting from peer", "endpoint", "DisconnectPeer", "peer", peer.ID())
+	s.env.Peers.StopPeerGracefully(peer)
+	return &v1.DisconnectPeerResponse{}, nil
+}
+
+func (s *adminServiceServer) DumpAddrBook(context.Context, *v1.DumpAddrBookRequest) (*v1.DumpAddrBookResponse, error) {
+	if s.env.AddrBook == nil {
+		return nil, status.Error(codes.Unimplemented, "The address book is not available: the PEX reactor is disabled")
+	}
+	infos := s.env.AddrBook.KnownAddresses()
+	addrs := make([]*v1.KnownAddress, len(infos))
+	for i, info := range infos {
+		bucketType := "new"
+		if info.Old {
+			bucketType = "old"
+		}
+		addrs[i] = &v1.KnownAddress{
+			Address:     info.Addr.String(),
+			BucketType:  bucketType,
+			Attempts:    info.Attempts,
+			LastAttempt: timestampProto(info.LastAttempt),
+			LastSuccess: timestampProto(info.LastSuccess),
+			BannedUntil: timestampProto(info.BannedUntil),
+			LastCrawled: timestampProto(info.LastCrawled),
+			Network:     info.Network,
+		}
+		if info.Src != nil {
+			addrs[i].Source = info.Src.String()
+		}
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i].Address < addrs[j].Address })
+	return &v1.DumpAddrBookResponse{Addresses: addrs}, nil
+}
+
+// timestampProto returns the timestamp of t, or nil if t is zero.
+func timestampProto(t time.Time) *gogotypes.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	ts, err := gogotypes.TimestampProto(t)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
+
+func (s *adminServiceServer) FlushMempool(context.Context, *v1.FlushMempoolRequest) (*v1.FlushMempoolResponse, error) {
+	if s.env.Mempool == nil {
+		return nil, status.Error(codes.Unimplemented, "Mempool management is not supported by this node")
+	}
+	s.logger.Info("Flushing mempool", "endpoint", "FlushMempool")
+	s.env.Mempool.Flush()
+	return &v1.FlushMempoolResponse{}, nil
+}
+
+func (s *adminServiceServer) DumpMempool(_ context.Context, request *v1.DumpMempoolRequest) (*v1.DumpMempoolResponse, error) {
+	if s.env.Mempool == nil {
+		return nil, status.Error(codes.Unimplemented, "Mempool management is not supported by this node")
+	}
+	limit := -1
+	if request.Limit > 0 && request.Limit < uint64(^uint(0)>>1) {
+		limit = int(request.Limit)
+	}
+	txs := s.env.Mempool.ReapMaxTxs(limit)
+	resp := &v1.DumpMempoolResponse{Txs: make([][]byte, len(txs))}
+	for i, tx := range txs {
+		resp.Txs[i] = tx
+	}
+	return resp, nil
+}
+
+func (s *adminServiceServer) PausePruning(context.Context, *v1.PausePruningRequest) (*v1.PausePruningResponse, error) {
+	if s.env.Pruner == nil {
+		return nil, status.Error(codes.Unimplemented, "Pruning control is not supported by this node")
+	}
+	s.env.Pruner.Pause()
+	return &v1.PausePruningResponse{}, nil
+}
+
+func (s *adminServiceServer) ResumePruning(context.Context, *v1.ResumePruningRequest) (*v1.ResumePruningResponse, error) {
+	if s.env.Pruner == nil {
+		return nil, status.Error(codes.Unimplemented, "Pruning control is not supported by this node")
+	}
+	s.env.Pruner.Resume()
+	return &v1.ResumePruningResponse{}, nil
+}
+
+func (s *adminServiceServer) Backup(_ context.Context, request *v1.BackupRequest) (*v1.BackupResponse, error) {
+	if s.env.Backup == nil {
+		return nil, status.Error(codes.Unimplemented, "Backups are not supported by this node")
+	}
+	if !filepath.IsAbs(request.Dir) {
+		return nil, status.Errorf(codes.InvalidArgument, "Backup directory must be an absolute path, got %q", request.Dir)
+	}
+	logger := s.logger.With("endpoint", "Backup")
+	traceID, err := rpctrace.New()
+	if err != nil {
+		logger.Error("Error generating RPC trace ID", "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error - see logs for details")
+	}
+	info, err := s.env.Backup(request.Dir)
+	if err != nil {
+		logger.Error("Cannot back up stores", "dir", request.Dir, "err", err, "traceID", traceID)
+		return nil, status.Errorf(codes.Internal, "Failed to back up stores (see logs for trace ID: %s)", traceID)
+	}
+	return &v1.BackupResponse{
+		Dir:              info.Dir,
+		StateHeight:      info.StateHeight,
+		BlockStoreHeight: info.BlockStoreHeight,
+	}, nil
+}
+
+func (s *adminServiceServer) Halt(context.Context, *v1.HaltRequest) (*v1.HaltResponse, error) {
+	if s.env.Halt == nil {
+		return nil, status.Error(codes.Unimplemented, "Halting is not supported by this node")
+	}
+	logger := s.logger.With("endpoint", "Halt")
+	traceID, err := rpctrace.New()
+	if err != nil {
+		logger.Error("Error generating RPC trace ID", "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error - see logs for details")
+	}
+	logger.Info("Halting node", "traceID", traceID)
+	if err := s.env.Halt(); err != nil {
+		logger.Error("Cannot halt node", "err", err, "traceID", traceID)
+		return nil, status.Errorf(codes.Internal, "Failed to halt node (see logs for trace ID: %s)", traceID)
+	}
+	return &v1.HaltResponse{}, nil
+}
@@ -0,0 +1,208 @@
+package adminservice_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/mock"
+	"github.com/cometbft/cometbft/p2p/pex"
+	v1 "github.com/cometbft/cometbft/proto/tendermint/services/admin/v1"
+	"github.com/cometbft/cometbft/rpc/grpc/server/services/adminservice"
+	"github.com/cometbft/cometbft/store"
+	"github.com/cometbft/cometbft/types"
+)
+
+type testPeers struct {
+	peers      *p2p.PeerSet
+	persistent []string
+	private    []string
+	dialed     []string
+	stopped    []p2p.ID
+}
+
+func (p *testPeers) AddPersistentPeers(addrs []string) error {
+	p.persistent = append(p.persistent, addrs...)
+	return nil
+}
+
+func (*testPeers) AddUnconditionalPeerIDs([]string) error { return nil }
+
+func (p *testPeers) AddPrivatePeerIDs(ids []string) error {
+	p.private = append(p.private, ids...)
+	return nil
+}
+
+func (p *testPeers) DialPeersAsync(addrs []string) error {
+	p.dialed = append(p.dialed, addrs...)
+	return nil
+}
+
+func (p *testPeers) Peers() p2p.IPeerSet { return p.peers }
+
+func (p *testPeers) StopPeerGracefully(peer p2p.Peer) {
+	p.stopped = append(p.stopped, peer.ID())
+}
+
+type testAddrBook struct {
+	infos []pex.KnownAddressInfo
+}
+
+func (b testAddrBook) KnownAddresses() []pex.KnownAddressInfo { return b.infos }
+
+type testMempool struct {
+	txs types.Txs
+}
+
+func (m *testMempool) Flush() { m.txs = nil }
+
+func (m *testMempool) ReapMaxTxs(max int) types.Txs {
+	if max < 0 || max > len(m.txs) {
+		return m.txs
+	}
+	return m.txs[:max]
+}
+
+type testPruner struct {
+	paused bool
+}
+
+func (p *testPruner) Pause()  { p.paused = true }
+func (p *testPruner) Resume() { p.paused = false }
+
+func TestAdminServicePeers(t *testing.T) {
+	ctx := context.Background()
+	peer := mock.NewPeer(net.IP{127, 0, 0, 1})
+	peers := &testPeers{peers: p2p.NewPeerSet()}
+	require.NoError(t, peers.peers.Add(peer))
+	svc := adminservice.New(adminservice.Environment{Peers: peers}, log.TestingLogger())
+
+	_, err := svc.DialPeers(ctx, &v1.DialPeersRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = svc.DialPeers(ctx, &v1.DialPeersRequest{Peers: []string{"127.0.0.1:26656"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	addr := "deadbeef@127.0.0.1:26656"
+	_, err = svc.DialPeers(ctx, &v1.DialPeersRequest{
+		Peers:      []string{addr},
+		Persistent: true,
+		Private:    true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{addr}, peers.persistent)
+	require.Equal(t, []string{"deadbeef"}, peers.private)
+	require.Equal(t, []string{addr}, peers.dialed)
+
+	_, err = svc.DisconnectPeer(ctx, &v1.DisconnectPeerRequest{PeerId: "unknown"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = svc.DisconnectPeer(ctx, &v1.DisconnectPeerRequest{PeerId: string(peer.ID())})
+	require.NoError(t, err)
+	require.Equal(t, []p2p.ID{peer.ID()}, peers.stopped)
+}
+
+func TestAdminServiceDumpAddrBook(t *testing.T) {
+	ctx := context.Background()
+	svc := adminservice.New(adminservice.Environment{}, log.TestingLogger())
+	_, err := svc.DumpAddrBook(ctx, &v1.DumpAddrBookRequest{})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+
+	addr1, err := p2p.NewNetAddressString("d51fb70907db1c6c2d5237e78379b25cf1a37ab4@1.2.3.4:26656")
+	require.NoError(t, err)
+	addr2, err := p2p.NewNetAddressString("0bd0d1a7ad8a4e1df7c0a1b5e1e6cb6e3f3b0c1a@1.2.3.5:26656")
+	require.NoError(t, err)
+	lastAttempt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	svc = adminservice.New(adminservice.Environment{AddrBook: testAddrBook{infos: []pex.KnownAddressInfo{
+		{Addr: addr1, Src: addr2, Attempts: 2, LastAttempt: lastAttempt, Network: "1.2"},
+		{Addr: addr2, Old: true, Network: "1.2"},
+	}}}, log.TestingLogger())
+
+	res, err := svc.DumpAddrBook(ctx, &v1.DumpAddrBookRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Addresses, 2)
+	require.Equal(t, addr2.String(), res.Addresses[0].Address)
+	require.Equal(t, "old", res.Addresses[0].BucketType)
+	require.Empty(t, res.Addresses[0].Source)
+	require.Nil(t, res.Addresses[0].LastAttempt)
+	require.Equal(t, addr1.String(), res.Addresses[1].Address)
+	require.Equal(t, "new", res.Addresses[1].BucketType)
+	require.Equal(t, addr2.String(), res.Addresses[1].Source)
+	require.Equal(t, int32(2), res.Addresses[1].Attempts)
+	require.Equal(t, lastAttempt.Unix(), res.Addresses[1].LastAttempt.Seconds)
+}
+
+func TestAdminServiceMempool(t *testing.T) {
+	ctx := context.Background()
+	mempool := &testMempool{txs: types.Txs{types.Tx("a"), types.Tx("b"), types.Tx("c")}}
+	svc := adminservice.New(adminservice.Environment{Mempool: mempool}, log.TestingLogger())
+
+	res, err := svc.DumpMempool(ctx, &v1.DumpMempoolRequest{Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("a"), []byte("b")}, res.Txs)
+	res, err = svc.DumpMempool(ctx, &v1.DumpMempoolRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Txs, 3)
+
+	_, err = svc.FlushMempool(ctx, &v1.FlushMempoolRequest{})
+	require.NoError(t, err)
+	require.Empty(t, mempool.txs)
+}
+
+func TestAdminServicePruningBackupHalt(t *testing.T) {
+	ctx := context.Background()
+	pruner := &testPruner{}
+	halted := false
+	svc := adminservice.New(adminservice.Environment{
+		Pruner: pruner,
+		Backup: func(dir string) (*store.BackupInfo, error) {
+			return &store.BackupInfo{Dir: dir, StateHeight: 5, BlockStoreHeight: 6}, nil
+		},
+		Halt: func() error {
+			halted = true
+			return nil
+		},
+	}, log.TestingLogger())
+
+	_, err := svc.PausePruning(ctx, &v1.PausePruningRequest{})
+	require.NoError(t, err)
+	require.True(t, pruner.paused)
+	_, err = svc.ResumePruning(ctx, &v1.ResumePruningRequest{})
+	require.NoError(t, err)
+	require.False(t, pruner.paused)
+
+	_, err = svc.Backup(ctx, &v1.BackupRequest{Dir: "relative"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	res, err := svc.Backup(ctx, &v1.BackupRequest{Dir: "/backup"})
+	require.NoError(t, err)
+	require.Equal(t, &v1.BackupResponse{Dir: "/backup", StateHeight: 5, BlockStoreHeight: 6}, res)
+
+	_, err = svc.Halt(ctx, &v1.HaltRequest{})
+	require.NoError(t, err)
+	require.True(t, halted)
+
+	// The operations on the components left unset are not supported.
+	_, err = svc.FlushMempool(ctx, &v1.FlushMempoolRequest{})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+	_, err = svc.DialSeeds(ctx, &v1.DialSeedsRequest{Seeds: []string{"a@b:1"}})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestAdminServiceErrors(t *testing.T) {
+	ctx := context.Background()
+	svc := adminservice.New(adminservice.Environment{
+		Backup: func(string) (*store.BackupInfo, error) { return nil, errors.New("boom") },
+		Halt:   func() error { return errors.New("boom") },
+	}, log.TestingLogger())
+
+	_, err := svc.Backup(ctx, &v1.BackupRequest{Dir: "/backup"})
+	require.Equal(t, codes.Internal, status.Code(err))
+	_, err = svc.Halt(ctx, &v1.HaltRequest{})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
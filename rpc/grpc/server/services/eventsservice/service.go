@@ -0,0 +1,140 @@
+package eventsservice
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/cometbft/cometbft/internal/rpctrace"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
+	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	eventssvc "github.com/cometbft/cometbft/proto/tendermint/services/events/v1"
+	"github.com/cometbft/cometbft/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxQueryLength is the maximum length of a query, as for the /subscribe RPC
+// endpoint.
+const maxQueryLength = 512
+
+type eventsServiceServer struct {
+	eventBus      *types.EventBus
+	maxClients    int
+	maxBufferSize int
+	logger        log.Logger
+}
+
+// New creates a new CometBFT events service server. Each stream subscribes to
+// the event bus as a client, up to maxClients, and buffers up to
+// maxBufferSize events.
+func New(eventBus *types.EventBus, maxClients, maxBufferSize int, logger log.Logger) eventssvc.EventsServiceServer {
+	return &eventsServiceServer{
+		eventBus:      eventBus,
+		maxClients:    maxClients,
+		maxBufferSize: maxBufferSize,
+		logger:        logger.With("service", "EventsService"),
+	}
+}
+
+// Subscribe implements v1.EventsServiceServer Subscribe method
+func (s *eventsServiceServer) Subscribe(req *eventssvc.SubscribeRequest, stream eventssvc.EventsService_SubscribeServer) error {
+	logger := s.logger.With("endpoint", "Subscribe")
+
+	if len(req.Query) > maxQueryLength {
+		return status.Error(codes.InvalidArgument, "Maximum query length exceeded")
+	}
+	q, err := cmtquery.New(req.Query)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Failed to parse query: %s", err)
+	}
+	bufferSize := s.maxBufferSize
+	if req.BufferSize > 0 {
+		if int(req.BufferSize) > s.maxBufferSize {
+			return status.Errorf(codes.InvalidArgument, "Buffer size must be at most %d", s.maxBufferSize)
+		}
+		bufferSize = int(req.BufferSize)
+	}
+	if s.eventBus.NumClients() >= s.maxClients {
+		return status.Errorf(codes.ResourceExhausted, "Maximum number of subscription clients %d reached", s.maxClients)
+	}
+
+	traceID, err := rpctrace.New()
+	if err != nil {
+		logger.Error("Error generating RPC trace ID", "err", err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+
+	// The trace ID is reused as a unique subscriber ID
+	var (
+		sub     types.Subscription
+		dropped = func() uint64 { return 0 }
+	)
+	if req.DropWhenFull {
+		var dropping *cmtpubsub.Subscription
+		dropping, err = s.eventBus.SubscribeDropping(stream.Context(), traceID, q, bufferSize)
+		sub, dropped = dropping, dropping.Dropped
+	} else {
+		sub, err = s.eventBus.Subscribe(stream.Context(), traceID, q, bufferSize)
+	}
+	if err != nil {
+		logger.Error("Cannot subscribe to events", "err", err, "traceID", traceID)
+		return status.Errorf(codes.Internal, "Cannot subscribe to events (see logs for trace ID: %s)", traceID)
+	}
+	defer func() {
+		if err := s.eventBus.Unsubscribe(context.Background(), traceID, q); err != nil &&
+			!errors.Is(err, cmtpubsub.ErrSubscriptionNotFound) {
+			logger.Error("Failed to unsubscribe from events", "err", err, "traceID", traceID)
+		}
+	}()
+
+	for {
+		select {
+		case msg := <-sub.Out():
+			res, err := getResponseFromMsg(msg)
+			if err != nil {
+				logger.Error("Failed to encode event", "err", err, "traceID", traceID)
+				return status.Errorf(codes.Internal, "Internal server error (see logs for trace ID: %s)", traceID)
+			}
+			res.Dropped = dropped()
+			if err := stream.Send(res); err != nil {
+				logger.Error("Failed to stream event", "err", err, "traceID", traceID)
+				return status.Errorf(codes.Unavailable, "Cannot send stream response (see logs for trace ID: %s)", traceID)
+			}
+		case <-sub.Canceled():
+			switch sub.Err() {
+			case cmtpubsub.ErrUnsubscribed:
+				return status.Error(codes.Canceled, "Subscription terminated")
+			case nil:
+				return status.Error(codes.Canceled, "Subscription canceled without errors")
+			case cmtpubsub.ErrOutOfCapacity:
+				return status.Error(codes.ResourceExhausted, "Subscription canceled: client not reading fast enough")
+			default:
+				logger.Info("Subscription canceled with errors", "err", sub.Err(), "traceID", traceID)
+				return status.Errorf(codes.Canceled, "Subscription canceled with errors (see logs for trace ID: %s)", traceID)
+			}
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+	}
+}
+
+func getResponseFromMsg(msg cmtpubsub.Message) (*eventssvc.SubscribeResponse, error) {
+	data, err := cmtjson.Marshal(msg.Data())
+	if err != nil {
+		return nil, err
+	}
+	events := msg.Events()
+	keys := make([]string, 0, len(events))
+	for key := range events {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	attrs := make([]*eventssvc.EventAttribute, len(keys))
+	for i, key := range keys {
+		attrs[i] = &eventssvc.EventAttribute{Key: key, Values: events[key]}
+	}
+	return &eventssvc.SubscribeResponse{Data: data, Events: attrs}, nil
+}
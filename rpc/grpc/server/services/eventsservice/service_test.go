@@ -0,0 +1,123 @@
+package eventsservice_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	grpcclient "github.com/cometbft/cometbft/rpc/grpc/client"
+	grpcserver "github.com/cometbft/cometbft/rpc/grpc/server"
+	"github.com/cometbft/cometbft/types"
+)
+
+func startServer(t *testing.T, maxClients, maxBufferSize int) (*types.EventBus, grpcclient.Client) {
+	t.Helper()
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = grpcserver.Serve(listener, grpcserver.WithEventsService(eventBus, maxClients, maxBufferSize, log.NewNopLogger()))
+	}()
+	t.Cleanup(func() { listener.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := grpcclient.New(ctx, listener.Addr().String(), grpcclient.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return eventBus, client
+}
+
+func TestEventsServiceSubscribe(t *testing.T) {
+	eventBus, client := startServer(t, 10, 100)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resultCh, err := client.Subscribe(ctx, "tm.event = 'Tx' AND tx.height = 2")
+	require.NoError(t, err)
+	// Wait for the server to subscribe to the events.
+	require.Eventually(t, func() bool { return eventBus.NumClients() > 0 }, 5*time.Second, 10*time.Millisecond)
+
+	for height := int64(1); height <= 3; height++ {
+		err := eventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{Height: height, Tx: types.Tx("a=1")}})
+		require.NoError(t, err)
+	}
+	select {
+	case <-ctx.Done():
+		require.Fail(t, "did not receive an event")
+	case result := <-resultCh:
+		require.NoError(t, result.Error)
+		data, ok := result.Data.(types.EventDataTx)
+		require.True(t, ok)
+		require.Equal(t, int64(2), data.Height)
+		require.Equal(t, []string{"2"}, result.Events["tx.height"])
+		require.Equal(t, []string{types.EventTx}, result.Events[types.EventTypeKey])
+		require.Zero(t, result.Dropped)
+	}
+}
+
+func TestEventsServiceSubscribeInvalid(t *testing.T) {
+	_, client := startServer(t, 10, 100)
+
+	testCases := map[string]struct {
+		query string
+		opts  []grpcclient.SubscribeOption
+	}{
+		"invalid query":       {query: "tm.event ="},
+		"buffer size too big": {query: "tm.event = 'Tx'", opts: []grpcclient.SubscribeOption{grpcclient.SubscribeBufferSize(101)}},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			resultCh, err := client.Subscribe(ctx, tc.query, tc.opts...)
+			require.NoError(t, err)
+			result := <-resultCh
+			require.Error(t, result.Error)
+			require.Contains(t, result.Error.Error(), "InvalidArgument")
+		})
+	}
+}
+
+func TestEventsServiceSubscribeDropWhenFull(t *testing.T) {
+	eventBus, client := startServer(t, 10, 100)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resultCh, err := client.Subscribe(ctx, "tm.event = 'NewRound'",
+		grpcclient.SubscribeBufferSize(1), grpcclient.SubscribeDropWhenFull(true))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return eventBus.NumClients() > 0 }, 5*time.Second, 10*time.Millisecond)
+
+	// Without reading the channel, the events not fitting in the buffers of
+	// the stream are dropped, without canceling the subscription.
+	const numEvents = 1000
+	for i := 0; i < numEvents; i++ {
+		require.NoError(t, eventBus.PublishEventNewRound(types.EventDataNewRound{Round: int32(i)}))
+	}
+	require.Eventually(t, func() bool {
+		for {
+			select {
+			case result := <-resultCh:
+				require.NoError(t, result.Error)
+				if result.Dropped > 0 {
+					return true
+				}
+			default:
+				return false
+			}
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+}
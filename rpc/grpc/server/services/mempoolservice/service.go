@@ -0,0 +1,95 @@
+package mempoolservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cometbft/cometbft/internal/rpctrace"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
+	mempoolsvc "github.com/cometbft/cometbft/proto/tendermint/services/mempool/v1"
+	"github.com/cometbft/cometbft/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Number of accepted txs buffered for a stream before it is canceled for being
+// too slow.
+const newTxsBufferSize = 100
+
+type mempoolServiceServer struct {
+	eventBus *types.EventBus
+	logger   log.Logger
+}
+
+// New creates a new CometBFT mempool service server.
+func New(eventBus *types.EventBus, logger log.Logger) mempoolsvc.MempoolServiceServer {
+	return &mempoolServiceServer{
+		eventBus: eventBus,
+		logger:   logger.With("service", "MempoolService"),
+	}
+}
+
+// GetNewTxs implements v1.MempoolServiceServer GetNewTxs method
+func (s *mempoolServiceServer) GetNewTxs(_ *mempoolsvc.GetNewTxsRequest, stream mempoolsvc.MempoolService_GetNewTxsServer) error {
+	logger := s.logger.With("endpoint", "GetNewTxs")
+
+	traceID, err := rpctrace.New()
+	if err != nil {
+		logger.Error("Error generating RPC trace ID", "err", err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+
+	// The trace ID is reused as a unique subscriber ID
+	sub, err := s.eventBus.Subscribe(context.Background(), traceID, types.EventQueryNewMempoolTx, newTxsBufferSize)
+	if err != nil {
+		logger.Error("Cannot subscribe to new mempool tx events", "err", err, "traceID", traceID)
+		return status.Errorf(codes.Internal, "Cannot subscribe to new mempool tx events (see logs for trace ID: %s)", traceID)
+	}
+	defer func() {
+		if err := s.eventBus.Unsubscribe(context.Background(), traceID, types.EventQueryNewMempoolTx); err != nil &&
+			!errors.Is(err, cmtpubsub.ErrSubscriptionNotFound) {
+			logger.Error("Failed to unsubscribe from new mempool tx events", "err", err, "traceID", traceID)
+		}
+	}()
+
+	for {
+		select {
+		case msg := <-sub.Out():
+			res, err := getResponseFromMsg(msg)
+			if err != nil {
+				logger.Error("Failed to extract tx from subscription message", "err", err, "traceID", traceID)
+				return status.Errorf(codes.Internal, "Internal server error (see logs for trace ID: %s)", traceID)
+			}
+			if err := stream.Send(res); err != nil {
+				logger.Error("Failed to stream new mempool tx", "err", err, "traceID", traceID)
+				return status.Errorf(codes.Unavailable, "Cannot send stream response (see logs for trace ID: %s)", traceID)
+			}
+		case <-sub.Canceled():
+			switch sub.Err() {
+			case cmtpubsub.ErrUnsubscribed:
+				return status.Error(codes.Canceled, "Subscription terminated")
+			case nil:
+				return status.Error(codes.Canceled, "Subscription canceled without errors")
+			default:
+				logger.Info("Subscription canceled with errors", "err", sub.Err(), "traceID", traceID)
+				return status.Errorf(codes.Canceled, "Subscription canceled with errors (see logs for trace ID: %s)", traceID)
+			}
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+	}
+}
+
+func getResponseFromMsg(msg cmtpubsub.Message) (*mempoolsvc.GetNewTxsResponse, error) {
+	switch eventType := msg.Data().(type) {
+	case types.EventDataNewMempoolTx:
+		return &mempoolsvc.GetNewTxsResponse{
+			Tx:      eventType.Tx,
+			CheckTx: &eventType.Result,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected event type: %v", eventType)
+	}
+}
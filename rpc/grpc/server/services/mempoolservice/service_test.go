@@ -0,0 +1,63 @@
+package mempoolservice_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	grpcclient "github.com/cometbft/cometbft/rpc/grpc/client"
+	grpcserver "github.com/cometbft/cometbft/rpc/grpc/server"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestMempoolServiceGetNewTxs(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = grpcserver.Serve(listener, grpcserver.WithMempoolService(eventBus, log.NewNopLogger()))
+	}()
+	t.Cleanup(func() { listener.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := grpcclient.New(ctx, listener.Addr().String(), grpcclient.WithInsecure())
+	require.NoError(t, err)
+	defer client.Close()
+
+	resultCh, err := client.GetNewTxs(ctx)
+	require.NoError(t, err)
+	// Wait for the server to subscribe to the new mempool tx events.
+	require.Eventually(t, func() bool { return eventBus.NumClients() > 0 }, 5*time.Second, 10*time.Millisecond)
+
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=2")}
+	for i, tx := range txs {
+		err := eventBus.PublishEventNewMempoolTx(types.EventDataNewMempoolTx{
+			Tx:     tx,
+			Result: abci.ResponseCheckTx{GasWanted: int64(i + 1)},
+		})
+		require.NoError(t, err)
+	}
+	for i, tx := range txs {
+		select {
+		case <-ctx.Done():
+			require.Fail(t, "did not receive a new mempool tx")
+		case result := <-resultCh:
+			require.NoError(t, result.Error)
+			require.Equal(t, tx, result.Tx)
+			require.Equal(t, int64(i+1), result.CheckTx.GasWanted)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package queryservice
+
+import (
+	context "context"
+	"errors"
+	"sort"
+
+	gogotypes "github.com/cosmos/gogoproto/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cryptoenc "github.com/cometbft/cometbft/crypto/encoding"
+	"github.com/cometbft/cometbft/internal/rpctrace"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	v1 "github.com/cometbft/cometbft/proto/tendermint/services/query/v1"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/state/indexer"
+	blockidxnull "github.com/cometbft/cometbft/state/indexer/block/null"
+	"github.com/cometbft/cometbft/state/txindex"
+	"github.com/cometbft/cometbft/state/txindex/null"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	// txSearchBatchSize is the number of transactions loaded from the
+	// indexer at once when streaming the results of a search.
+	txSearchBatchSize = 100
+
+	defaultPerPage = 30
+	maxPerPage     = 100
+)
+
+// Environment contains the node components queried by the query service.
+type Environment struct {
+	BlockStore   sm.BlockStore
+	TxIndexer    txindex.TxIndexer
+	BlockIndexer indexer.BlockIndexer
+	// Status returns the status of the node, as the status JSON-RPC endpoint.
+	Status func() (*ctypes.ResultStatus, error)
+}
+
+type queryServiceServer struct {
+	env    Environment
+	logger log.Logger
+}
+
+// New creates a new CometBFT query service server.
+func New(env Environment, logger log.Logger) v1.QueryServiceServer {
+	return &queryServiceServer{
+		env:    env,
+		logger: logger.With("service", "QueryService"),
+	}
+}
+
+// GetBlock implements v1.QueryServiceServer.
+func (s *queryServiceServer) GetBlock(_ context.Context, req *v1.GetBlockRequest) (*v1.GetBlockResponse, error) {
+	logger := s.logger.With("endpoint", "GetBlock")
+
+	var block *types.Block
+	var blockMeta *types.BlockMeta
+	switch {
+	case len(req.Hash) > 0:
+		block = s.env.BlockStore.LoadBlockByHash(req.Hash)
+		if block == nil {
+			return nil, status.Errorf(codes.NotFound, "Block not found for hash %X", req.Hash)
+		}
+		blockMeta = s.env.BlockStore.LoadBlockMetaByHash(req.Hash)
+	default:
+		height := req.Height
+		if height < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "Height cannot be negative, but got %d", height)
+		}
+		if height == 0 {
+			height = s.env.BlockStore.Height()
+			if height < 1 {
+				return nil, status.Error(codes.NotFound, "No block data yet")
+			}
+		}
+		block = s.env.BlockStore.LoadBlock(height)
+		if block == nil {
+			return nil, status.Errorf(codes.NotFound, "Block not found for height %d", height)
+		}
+		blockMeta = s.env.BlockStore.LoadBlockMeta(height)
+	}
+
+	result, err := s.resultBlock(block, blockMeta, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.GetBlockResponse{Block: result}, nil
+}
+
+// GetTx implements v1.QueryServiceServer.
+func (s *queryServiceServer) GetTx(_ context.Context, req *v1.GetTxRequest) (*v1.GetTxResponse, error) {
+	if _, ok := s.env.TxIndexer.(*null.TxIndex); ok {
+		return nil, status.Error(codes.Unimplemented, "Transaction indexing is disabled")
+	}
+	logger := s.logger.With("endpoint", "GetTx")
+
+	r, err := s.env.TxIndexer.Get(req.Hash)
+	if err != nil {
+		return nil, s.internalError(logger, "Failed to load the transaction from the indexer", err)
+	}
+	if r == nil {
+		return nil, status.Errorf(codes.NotFound, "Transaction not found for hash %X", req.Hash)
+	}
+
+	tx, err := s.resultTx(req.Hash, r, req.Prove, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.GetTxResponse{Tx: tx}, nil
+}
+
+// TxSearch implements v1.QueryServiceServer. The transactions are loaded from
+// the indexer in batches, as they are streamed.
+func (s *queryServiceServer) TxSearch(req *v1.TxSearchRequest, stream v1.QueryService_TxSearchServer) error {
+	if _, ok := s.env.TxIndexer.(*null.TxIndex); ok {
+		return status.Error(codes.Unimplemented, "Transaction indexing is disabled")
+	}
+	logger := s.logger.With("endpoint", "TxSearch")
+
+	q, err := cmtquery.New(req.Query)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid query: %s", err)
+	}
+	pagination := txindex.Pagination{OrderDesc: req.OrderDesc, Limit: txSearchBatchSize}
+	if req.Cursor != "" {
+		cursor, err := txindex.ParseCursor(req.Cursor)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "Invalid cursor: %s", err)
+		}
+		pagination.After = &cursor
+	}
+
+	ctx := stream.Context()
+	for {
+		page, err := s.env.TxIndexer.SearchPage(ctx, q, pagination)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return status.FromContextError(err).Err()
+			}
+			return s.internalError(logger, "Failed to search the transactions", err)
+		}
+		for _, r := range page.Results {
+			tx, err := s.resultTx(types.Tx(r.Tx).Hash(), r, req.Prove, logger)
+			if err != nil {
+				return err
+			}
+			cursor := txindex.Cursor{Height: r.Height, Index: r.Index}
+			if err := stream.Send(&v1.TxSearchResponse{Tx: tx, Cursor: cursor.String()}); err != nil {
+				logger.Error("Error sending search result", "err", err)
+				return err
+			}
+		}
+		if page.Next == nil {
+			return nil
+		}
+		pagination.After = page.Next
+	}
+}
+
+// BlockSearch implements v1.QueryServiceServer.
+func (s *queryServiceServer) BlockSearch(ctx context.Context, req *v1.BlockSearchRequest) (*v1.BlockSearchResponse, error) {
+	if _, ok := s.env.BlockIndexer.(*blockidxnull.BlockerIndexer); ok {
+		return nil, status.Error(codes.Unimplemented, "Block indexing is disabled")
+	}
+	logger := s.logger.With("endpoint", "BlockSearch")
+
+	q, err := cmtquery.New(req.Query)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid query: %s", err)
+	}
+	heights, err := s.env.BlockIndexer.Search(ctx, q)
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, status.FromContextError(err).Err()
+		}
+		return nil, s.internalError(logger, "Failed to search the blocks", err)
+	}
+	if req.OrderDesc {
+		sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+	} else {
+		sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	}
+
+	perPage := int(req.PerPage)
+	if perPage == 0 {
+		perPage = defaultPerPage
+	} else if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+	page := int(req.Page)
+	if page == 0 {
+		page = 1
+	}
+	totalCount := len(heights)
+	pages := (totalCount-1)/perPage + 1
+	if page > pages {
+		return nil, status.Errorf(codes.InvalidArgument, "Page should be within [1, %d] range, given %d", pages, page)
+	}
+	heights = heights[(page-1)*perPage : min(page*perPage, totalCount)]
+
+	blocks := make([]*v1.ResultBlock, 0, len(heights))
+	for _, height := range heights {
+		block := s.env.BlockStore.LoadBlock(height)
+		if block == nil {
+			// The block was pruned, or is not stored yet.
+			continue
+		}
+		result, err := s.resultBlock(block, s.env.BlockStore.LoadBlockMeta(height), logger)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, result)
+	}
+	return &v1.BlockSearchResponse{Blocks: blocks, TotalCount: int64(totalCount)}, nil
+}
+
+// Status implements v1.QueryServiceServer.
+func (s *queryServiceServer) Status(context.Context, *v1.StatusRequest) (*v1.StatusResponse, error) {
+	logger := s.logger.With("endpoint", "Status")
+
+	st, err := s.env.Status()
+	if err != nil {
+		return nil, s.internalError(logger, "Failed to get the status of the node", err)
+	}
+	latestBlockTime, err := gogotypes.TimestampProto(st.SyncInfo.LatestBlockTime)
+	if err != nil {
+		return nil, s.internalError(logger, "Failed to convert the latest block time", err)
+	}
+	earliestBlockTime, err := gogotypes.TimestampProto(st.SyncInfo.EarliestBlockTime)
+	if err != nil {
+		return nil, s.internalError(logger, "Failed to convert the earliest block time", err)
+	}
+	validatorInfo := &v1.ValidatorInfo{
+		Address:     st.ValidatorInfo.Address,
+		VotingPower: st.ValidatorInfo.VotingPower,
+	}
+	if st.ValidatorInfo.PubKey != nil {
+		pk, err := cryptoenc.PubKeyToProto(st.ValidatorInfo.PubKey)
+		if err != nil {
+			return nil, s.internalError(logger, "Failed to convert the validator public key", err)
+		}
+		validatorInfo.PubKey = &pk
+	}
+
+	return &v1.StatusResponse{
+		NodeInfo: st.NodeInfo.ToProto(),
+		SyncInfo: &v1.SyncInfo{
+			LatestBlockHash:     st.SyncInfo.LatestBlockHash,
+			LatestAppHash:       st.SyncInfo.LatestAppHash,
+			LatestBlockHeight:   st.SyncInfo.LatestBlockHeight,
+			LatestBlockTime:     latestBlockTime,
+			EarliestBlockHash:   st.SyncInfo.EarliestBlockHash,
+			EarliestAppHash:     st.SyncInfo.EarliestAppHash,
+			EarliestBlockHeight: st.SyncInfo.EarliestBlockHeight,
+			EarliestBlockTime:   earliestBlockTime,
+			CatchingUp:          st.SyncInfo.CatchingUp,
+		},
+		ValidatorInfo: validatorInfo,
+	}, nil
+}
+
+func (s *queryServiceServer) resultBlock(block *types.Block, blockMeta *types.BlockMeta, logger log.Logger) (*v1.ResultBlock, error) {
+	if blockMeta == nil {
+		logger.Error("Failed to load block meta when block was successfully loaded", "height", block.Height)
+		return nil, status.Error(codes.Internal, "Internal server error - see logs for details")
+	}
+	bp, err := block.ToProto()
+	if err != nil {
+		return nil, s.internalError(logger, "Failed to convert the block to its Protobuf representation", err)
+	}
+	blockID := blockMeta.BlockID.ToProto()
+	return &v1.ResultBlock{BlockId: &blockID, Block: bp}, nil
+}
+
+func (s *queryServiceServer) resultTx(hash []byte, r *abci.TxResult, prove bool, logger log.Logger) (*v1.ResultTx, error) {
+	tx := &v1.ResultTx{
+		Hash:     hash,
+		Height:   r.Height,
+		Index:    r.Index,
+		TxResult: &r.Result,
+		Tx:       r.Tx,
+	}
+	if prove {
+		block := s.env.BlockStore.LoadBlock(r.Height)
+		if block == nil {
+			return nil, status.Errorf(codes.NotFound, "Block not found for height %d, cannot prove the transaction", r.Height)
+		}
+		if int(r.Index) >= len(block.Txs) {
+			logger.Error("Indexed transaction not found in its block", "height", r.Height, "index", r.Index)
+			return nil, status.Error(codes.Internal, "Internal server error - see logs for details")
+		}
+		proof := block.Txs.Proof(int(r.Index)).ToProto()
+		tx.Proof = &proof
+	}
+	return tx, nil
+}
+
+// internalError logs the given error with a new trace ID, and returns an
+// Internal error referring to it.
+func (s *queryServiceServer) internalError(logger log.Logger, msg string, err error) error {
+	traceID, traceErr := rpctrace.New()
+	if traceErr != nil {
+		logger.Error("Error generating RPC trace ID", "err", traceErr)
+		return status.Error(codes.Internal, "Internal server error - see logs for details")
+	}
+	logger.Error(msg, "err", err, "traceID", traceID)
+	return status.Errorf(codes.Internal, "%s (see logs for trace ID: %s)", msg, traceID)
+}
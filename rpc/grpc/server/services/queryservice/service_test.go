@@ -0,0 +1,151 @@
+package queryservice_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	grpcclient "github.com/cometbft/cometbft/rpc/grpc/client"
+	grpcserver "github.com/cometbft/cometbft/rpc/grpc/server"
+	"github.com/cometbft/cometbft/rpc/grpc/server/services/queryservice"
+	blockidxkv "github.com/cometbft/cometbft/state/indexer/block/kv"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/state/txindex"
+	"github.com/cometbft/cometbft/state/txindex/kv"
+	"github.com/cometbft/cometbft/types"
+)
+
+func startQueryService(t *testing.T, env queryservice.Environment) grpcclient.Client {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = grpcserver.Serve(listener, grpcserver.WithQueryService(env, log.NewNopLogger()))
+	}()
+	t.Cleanup(func() { listener.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := grpcclient.New(ctx, listener.Addr().String(), grpcclient.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestQueryServiceTxSearch(t *testing.T) {
+	// More transactions than loaded from the indexer at once.
+	const numTxs = 250
+	txIndexer := kv.NewTxIndex(dbm.NewMemDB())
+	for height := 0; height < numTxs/10; height++ {
+		batch := txindex.NewBatch(10)
+		for index := 0; index < 10; index++ {
+			i := 10*height + index
+			require.NoError(t, batch.Add(&abci.TxResult{
+				Height: int64(height + 1),
+				Index:  uint32(index),
+				Tx:     types.Tx(fmt.Sprintf("tx%d", i)),
+				Result: abci.ExecTxResult{
+					Events: []abci.Event{{
+						Type:       "account",
+						Attributes: []abci.EventAttribute{{Key: "number", Value: fmt.Sprint(i % 2), Index: true}},
+					}},
+				},
+			}))
+		}
+		require.NoError(t, txIndexer.AddBatch(batch))
+	}
+	client := startQueryService(t, queryservice.Environment{TxIndexer: txIndexer})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	search := func(opts ...grpcclient.TxSearchOption) []grpcclient.TxSearchResult {
+		resultCh, err := client.TxSearch(ctx, "account.number = 1", opts...)
+		require.NoError(t, err)
+		var results []grpcclient.TxSearchResult
+		for result := range resultCh {
+			require.NoError(t, result.Error)
+			results = append(results, result)
+		}
+		return results
+	}
+
+	results := search()
+	require.Len(t, results, numTxs/2)
+	for i, result := range results {
+		tx := types.Tx(fmt.Sprintf("tx%d", 2*i+1))
+		assert.Equal(t, tx, result.Tx.Tx)
+		assert.Equal(t, []byte(tx.Hash()), result.Tx.Hash)
+		assert.Nil(t, result.Tx.Proof)
+	}
+
+	// The search resumes after the transaction with the given cursor.
+	resumed := search(grpcclient.TxSearchCursor(results[59].Cursor))
+	assert.Equal(t, results[60:], resumed)
+
+	desc := search(grpcclient.TxSearchOrderDesc(true))
+	require.Len(t, desc, numTxs/2)
+	assert.Equal(t, results[len(results)-1].Tx, desc[0].Tx)
+	assert.Equal(t, results[0].Tx, desc[len(desc)-1].Tx)
+
+	resultCh, err := client.TxSearch(ctx, "account.number = 1", grpcclient.TxSearchCursor("invalid"))
+	require.NoError(t, err)
+	result := <-resultCh
+	require.Error(t, result.Error)
+}
+
+func TestQueryServiceBlocks(t *testing.T) {
+	block := types.MakeBlock(2, types.Txs{types.Tx("a=1")}, &types.Commit{}, nil)
+	block.ProposerAddress = cmtrand.Bytes(crypto.AddressSize)
+	parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(t, err)
+	blockMeta := types.NewBlockMeta(block, parts)
+
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Height").Return(int64(2))
+	blockStore.On("LoadBlock", int64(2)).Return(block)
+	blockStore.On("LoadBlockMeta", int64(2)).Return(blockMeta)
+	blockStore.On("LoadBlockByHash", []byte(block.Hash())).Return(block)
+	blockStore.On("LoadBlockMetaByHash", []byte(block.Hash())).Return(blockMeta)
+	blockStore.On("LoadBlockByHash", []byte("unknown")).Return(nil)
+	blockIndexer := blockidxkv.New(dbm.NewMemDB())
+	require.NoError(t, blockIndexer.Index(types.EventDataNewBlockEvents{
+		Height: 2,
+		Events: []abci.Event{{
+			Type:       "begin_event",
+			Attributes: []abci.EventAttribute{{Key: "proposer", Value: "FCAA001", Index: true}},
+		}},
+	}))
+	client := startQueryService(t, queryservice.Environment{
+		BlockStore:   blockStore,
+		BlockIndexer: blockIndexer,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	res, err := client.GetBlockByHash(ctx, block.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, blockMeta.BlockID, *res.BlockID)
+	assert.Equal(t, block.Hash(), res.Block.Hash())
+
+	_, err = client.GetBlockByHash(ctx, []byte("unknown"))
+	require.Error(t, err)
+
+	found, err := client.BlockSearch(ctx, "begin_event.proposer = 'FCAA001'", 0, 0, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, found.TotalCount)
+	require.Len(t, found.Blocks, 1)
+	assert.Equal(t, block.Hash(), found.Blocks[0].Block.Hash())
+
+	_, err = client.BlockSearch(ctx, "begin_event.proposer = 'FCAA001'", 2, 0, false)
+	require.Error(t, err)
+}
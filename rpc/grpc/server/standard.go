@@ -0,0 +1,40 @@
+package server
+
+import (
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionalphapb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// HealthCheckMethods are the full names of the methods of the standard
+// health service, callable without an API key by the probes of the
+// orchestrators, e.g. Kubernetes.
+var HealthCheckMethods = []string{
+	"/" + healthpb.Health_ServiceDesc.ServiceName + "/Check",
+	"/" + healthpb.Health_ServiceDesc.ServiceName + "/Watch",
+}
+
+// RegisterStandardServices registers the standard grpc.health.v1 and server
+// reflection services on the server, after its other services. The health
+// service reports the server, and each of its other services, as serving.
+// The reflection service resolves the descriptors generated with gogoproto,
+// as those of CometBFT.
+func RegisterStandardServices(server *grpc.Server) {
+	healthServer := health.NewServer()
+	for name := range server.GetServiceInfo() {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	opts := reflection.ServerOptions{
+		Services:           server,
+		DescriptorResolver: gogoproto.HybridResolver,
+	}
+	// Many clients, e.g. grpcurl, still only support v1alpha.
+	reflectionpb.RegisterServerReflectionServer(server, reflection.NewServerV1(opts))
+	reflectionalphapb.RegisterServerReflectionServer(server, reflection.NewServer(opts))
+}
@@ -0,0 +1,65 @@
+package server_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+
+	grpcserver "github.com/cometbft/cometbft/rpc/grpc/server"
+)
+
+func TestStandardServices(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = grpcserver.Serve(listener, grpcserver.WithVersionService())
+	}()
+	t.Cleanup(func() { listener.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, listener.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	versionService := "tendermint.services.version.v1.VersionService"
+	health := healthpb.NewHealthClient(conn)
+	for _, service := range []string{"", versionService} {
+		res, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err, service)
+		require.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status, service)
+	}
+	_, err = health.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	require.Error(t, err)
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	var services []string
+	for _, service := range res.GetListServicesResponse().GetService() {
+		services = append(services, service.Name)
+	}
+	require.Contains(t, services, versionService)
+	require.Contains(t, services, healthpb.Health_ServiceDesc.ServiceName)
+
+	// The descriptors generated with gogoproto are resolved.
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: versionService},
+	}))
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	require.Nil(t, res.GetErrorResponse())
+	require.NotEmpty(t, res.GetFileDescriptorResponse().GetFileDescriptorProto())
+}
@@ -44,6 +44,9 @@ type WSClient struct { //nolint: maligned
 	// Callback, which will be called each time after successful reconnect.
 	onReconnect func()
 
+	// the headers of the handshake requests, e.g. with an API key
+	requestHeader http.Header
+
 	// internal channels
 	send            chan types.RPCRequest // user requests
 	backlog         chan types.RPCRequest // stores a single user request received during a conn failure
@@ -162,6 +165,15 @@ func OnReconnect(cb func()) func(*WSClient) {
 	}
 }
 
+// RequestHeader sets the headers sent in the handshake requests, e.g. an
+// "Authorization: Bearer <key>" header with an API key.
+// It should only be used in the constructor - not Goroutine-safe.
+func RequestHeader(header http.Header) func(*WSClient) {
+	return func(c *WSClient) {
+		c.requestHeader = header
+	}
+}
+
 // String returns WS client full address.
 func (c *WSClient) String() string {
 	return fmt.Sprintf("WSClient{%s (%s)}", c.Address, c.Endpoint)
@@ -267,6 +279,9 @@ func (c *WSClient) dial() error {
 		Proxy:   http.ProxyFromEnvironment,
 	}
 	rHeader := http.Header{}
+	for key, values := range c.requestHeader {
+		rHeader[key] = values
+	}
 	conn, _, err := dialer.Dial(c.protocol+"://"+c.Address+c.Endpoint, rHeader) //nolint:bodyclose
 	if err != nil {
 		return err
@@ -532,6 +547,14 @@ func (c *WSClient) Subscribe(ctx context.Context, query string) error {
 	return c.Call(ctx, "subscribe", params)
 }
 
+// SubscribeFromHeight subscribes to a query, replaying first the matching
+// events of the blocks from the given height on. Note the server must have a
+// "subscribe" route defined, accepting a "from_height".
+func (c *WSClient) SubscribeFromHeight(ctx context.Context, query string, fromHeight int64) error {
+	params := map[string]interface{}{"query": query, "from_height": fromHeight}
+	return c.Call(ctx, "subscribe", params)
+}
+
 // Unsubscribe from a query. Note the server must have a "unsubscribe" route
 // defined.
 func (c *WSClient) Unsubscribe(ctx context.Context, query string) error {
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cometbft/cometbft/libs/log"
+	types "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+var (
+	// ErrUnauthenticated is returned for the requests without a valid API
+	// key to methods requiring one.
+	ErrUnauthenticated = errors.New("missing or invalid API key")
+
+	// ErrForbidden is returned for the requests with an API key not giving
+	// access to the method.
+	ErrForbidden = errors.New("the API key gives no access to the method")
+)
+
+// Authorizer authorizes the requests of the clients to the methods, given the
+// HTTP request of the call, or of the handshake of the WebSocket connection.
+type Authorizer interface {
+	// Authorize returns ErrUnauthenticated or ErrForbidden, possibly wrapped,
+	// if the request is not authorized to call the method.
+	Authorize(r *http.Request, method string) error
+}
+
+// WithAuthorizer rejects the requests the given authorizer does not authorize.
+func WithAuthorizer(authorizer Authorizer) RegisterOption {
+	return func(cfg *registerConfig) {
+		cfg.authorizer = authorizer
+	}
+}
+
+// Authorization rejects the requests over WebSocket the given authorizer does
+// not authorize. It should only be used in the constructor - not
+// Goroutine-safe.
+func Authorization(authorizer Authorizer) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.authorizer = authorizer
+	}
+}
+
+// authorize returns nil if the authorizer is nil or authorizes the request.
+func authorize(authorizer Authorizer, r *http.Request, method string) error {
+	if authorizer == nil {
+		return nil
+	}
+	return authorizer.Authorize(r, method)
+}
+
+// authStatus returns the HTTP status of the responses to the requests not
+// authorized.
+func authStatus(err error) int {
+	if errors.Is(err, ErrForbidden) {
+		return http.StatusForbidden
+	}
+	return http.StatusUnauthorized
+}
+
+// requireAuth rejects the requests to the function of the handler the
+// authorizer does not authorize, with a 401 or 403 status.
+func requireAuth(funcName string, handler http.HandlerFunc, authorizer Authorizer, logger log.Logger) http.HandlerFunc {
+	if authorizer == nil {
+		return handler
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := authorizer.Authorize(r, funcName); err != nil {
+			res := types.RPCServerError(types.JSONRPCIntID(-1), err)
+			if wErr := WriteRPCResponseHTTPError(w, authStatus(err), res); wErr != nil {
+				logger.Error("failed to write response", "err", wErr)
+			}
+			return
+		}
+		handler(w, r)
+	}
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cometbft/cometbft/libs/log"
+	types "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// headerAuthorizer authorizes the requests whose "Method" header is the
+// method.
+type headerAuthorizer struct{}
+
+func (headerAuthorizer) Authorize(r *http.Request, method string) error {
+	switch r.Header.Get("Method") {
+	case "":
+		return ErrUnauthenticated
+	case method:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrForbidden, method)
+	}
+}
+
+func TestAuthorizedHandlers(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"c": NewRPCFunc(func(ctx *types.Context) (string, error) { return "foo", nil }, ""),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.NewTMLogger(new(bytes.Buffer)), WithAuthorizer(headerAuthorizer{}))
+
+	for _, tc := range []struct {
+		header string
+		status int
+	}{
+		{"", http.StatusUnauthorized},
+		{"d", http.StatusForbidden},
+		{"c", http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost/c", nil)
+		req.Header.Set("Method", tc.header)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		assert.Equal(t, tc.status, rec.Code, tc.header)
+
+		req = httptest.NewRequest(http.MethodPost, "http://localhost/",
+			strings.NewReader(`{"jsonrpc": "2.0", "method": "c", "id": 0}`))
+		req.Header.Set("Method", tc.header)
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		assert.Equal(t, tc.status, rec.Code, tc.header)
+	}
+}
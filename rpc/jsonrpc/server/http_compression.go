@@ -0,0 +1,163 @@
+package server
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// minCompressionSize is the size of the smallest response compressed. Smaller
+// responses would hardly shrink.
+const minCompressionSize = 1024
+
+var (
+	gzipWriters  = sync.Pool{New: func() interface{} { return gzip.NewWriter(io.Discard) }}
+	flateWriters = sync.Pool{New: func() interface{} {
+		w, _ := flate.NewWriter(io.Discard, flate.DefaultCompression)
+		return w
+	}}
+)
+
+// compressWriter compresses the data written to a writer. It is implemented
+// by the gzip and flate writers.
+type compressWriter interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+// CompressionHandler compresses the responses of the handler with gzip or
+// deflate, if accepted by the client in the Accept-Encoding header of its
+// request. Small responses and WebSocket connections are not compressed.
+func CompressionHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Header.Get("Upgrade") != "" {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Accept-Encoding")
+		cw := &compressResponseWriter{ResponseWriter: w, encoding: encoding}
+		defer cw.close()
+		handler.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding returns the encoding of the response, "gzip", "deflate",
+// or an empty string if the client accepts neither, given its Accept-Encoding
+// header.
+func negotiateEncoding(acceptEncoding string) string {
+	var deflate bool
+	for _, entry := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(entry, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				continue
+			}
+		}
+		switch coding {
+		case "gzip", "*":
+			return "gzip"
+		case "deflate":
+			deflate = true
+		}
+	}
+	if deflate {
+		return "deflate"
+	}
+	return ""
+}
+
+// compressResponseWriter compresses the response if its first write is large
+// enough, and if not already encoded.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding string
+
+	status  int
+	started bool
+	writer  compressWriter // nil if the response is not compressed
+}
+
+func (w *compressResponseWriter) WriteHeader(status int) {
+	if !w.started {
+		w.status = status
+	}
+}
+
+func (w *compressResponseWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.start(len(p))
+	}
+	if w.writer == nil {
+		return w.ResponseWriter.Write(p)
+	}
+	return w.writer.Write(p)
+}
+
+// start decides, on the first write of the given size, whether to compress
+// the response, and writes its header.
+func (w *compressResponseWriter) start(size int) {
+	w.started = true
+	header := w.Header()
+	if size >= minCompressionSize && header.Get("Content-Encoding") == "" &&
+		w.status != http.StatusNoContent && w.status != http.StatusNotModified {
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		if w.encoding == "gzip" {
+			w.writer = gzipWriters.Get().(*gzip.Writer)
+		} else {
+			w.writer = flateWriters.Get().(*flate.Writer)
+		}
+		w.writer.Reset(w.ResponseWriter)
+	}
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+}
+
+// close flushes the compressed response, and writes the header of an empty
+// response.
+func (w *compressResponseWriter) close() {
+	if !w.started {
+		w.start(0)
+	}
+	if w.writer == nil {
+		return
+	}
+	_ = w.writer.Close()
+	if w.encoding == "gzip" {
+		gzipWriters.Put(w.writer)
+	} else {
+		flateWriters.Put(w.writer)
+	}
+	w.writer = nil
+}
+
+// Flush implements http.Flusher.
+func (w *compressResponseWriter) Flush() {
+	if w.writer != nil {
+		if f, ok := w.writer.(interface{ Flush() error }); ok {
+			_ = f.Flush()
+		}
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker.
+func (w *compressResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("hijack not supported")
+	}
+	return h.Hijack()
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	for acceptEncoding, expected := range map[string]string{
+		"":                          "",
+		"identity":                  "",
+		"gzip":                      "gzip",
+		"deflate, gzip;q=1.0":       "gzip",
+		"deflate":                   "deflate",
+		"gzip;q=0, deflate":         "deflate",
+		"GZIP":                      "gzip",
+		"*":                         "gzip",
+		"br, deflate;q=0, gzip;q=0": "",
+	} {
+		assert.Equal(t, expected, negotiateEncoding(acceptEncoding), acceptEncoding)
+	}
+}
+
+func TestCompressionHandler(t *testing.T) {
+	large := strings.Repeat(`{"key":"value"}`, 1000)
+	handler := CompressionHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Path == "/small" {
+			_, _ = w.Write([]byte("{}"))
+			return
+		}
+		_, _ = w.Write([]byte(large))
+	}))
+
+	get := func(path, acceptEncoding string) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost"+path, nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+
+	res := get("/large", "gzip")
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "gzip", res.Header.Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", res.Header.Get("Vary"))
+	gz, err := gzip.NewReader(res.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, large, string(body))
+
+	res = get("/large", "deflate")
+	defer res.Body.Close()
+	require.Equal(t, "deflate", res.Header.Get("Content-Encoding"))
+	body, err = io.ReadAll(flate.NewReader(res.Body))
+	require.NoError(t, err)
+	assert.Equal(t, large, string(body))
+
+	// The small responses, and the responses to the clients not accepting
+	// compressed ones, are not compressed.
+	for _, tc := range []struct{ path, acceptEncoding string }{
+		{"/small", "gzip"},
+		{"/large", ""},
+	} {
+		res = get(tc.path, tc.acceptEncoding)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Empty(t, res.Header.Get("Content-Encoding"))
+		body, err = io.ReadAll(res.Body)
+		require.NoError(t, err)
+		if tc.path == "/small" {
+			assert.Equal(t, "{}", string(body))
+		} else {
+			assert.Equal(t, large, string(body))
+		}
+	}
+}
@@ -3,12 +3,14 @@ package server
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
@@ -18,7 +20,7 @@ import (
 // HTTP + JSON handler
 
 // jsonrpc calls grab the given method's function info and runs reflect.Call
-func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.HandlerFunc {
+func makeJSONRPCHandler(funcMap map[string]*RPCFunc, cfg *registerConfig, logger log.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		b, err := io.ReadAll(r.Body)
 		if err != nil {
@@ -42,8 +44,10 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 		var (
 			requests  []types.RPCRequest
 			responses []types.RPCResponse
+			batch     = true
 		)
 		if err := json.Unmarshal(b, &requests); err != nil {
+			batch = false
 			// next, try to unmarshal as a single request
 			var request types.RPCRequest
 			if err := json.Unmarshal(b, &request); err != nil {
@@ -56,80 +60,161 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 			requests = []types.RPCRequest{request}
 		}
 
+		if len(requests) == 0 {
+			res := types.RPCInvalidRequestError(nil, errors.New("empty batch"))
+			if wErr := WriteRPCResponseHTTPError(w, http.StatusBadRequest, res); wErr != nil {
+				logger.Error("failed to write response", "err", wErr)
+			}
+			return
+		}
+		if cfg.maxBatchSize > 0 && len(requests) > cfg.maxBatchSize {
+			res := types.RPCInvalidRequestError(nil,
+				fmt.Errorf("batch of %d requests exceeds the maximum of %d", len(requests), cfg.maxBatchSize),
+			)
+			if wErr := WriteRPCResponseHTTPError(w, http.StatusRequestEntityTooLarge, res); wErr != nil {
+				logger.Error("failed to write response", "err", wErr)
+			}
+			return
+		}
+
+		results := make([]jsonRPCResult, len(requests))
+		if cfg.batchConcurrency > 1 && len(requests) > 1 {
+			var (
+				wg  sync.WaitGroup
+				sem = make(chan struct{}, cfg.batchConcurrency)
+			)
+			for i := range requests {
+				sem <- struct{}{}
+				wg.Add(1)
+				go func(i int) {
+					defer func() {
+						<-sem
+						wg.Done()
+					}()
+					results[i] = handleJSONRPCRequest(funcMap, cfg, r, &requests[i], logger)
+				}(i)
+			}
+			wg.Wait()
+		} else {
+			for i := range requests {
+				results[i] = handleJSONRPCRequest(funcMap, cfg, r, &requests[i], logger)
+			}
+		}
+
 		// Set the default response cache to true unless
 		// 1. Any RPC request error.
 		// 2. Any RPC request doesn't allow to be cached.
 		// 3. Any RPC request has the height argument and the value is 0 (the default).
 		cache := true
-		for _, request := range requests {
-			request := request
-
-			// A Notification is a Request object without an "id" member.
-			// The Server MUST NOT reply to a Notification, including those that are within a batch request.
-			if request.ID == nil {
-				logger.Debug(
-					"HTTPJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)",
-					"req", request,
-				)
+		// The status of the response to a single request, not in a batch,
+		// rejected by the rate limiter or the authorizer.
+		rejectedStatus := 0
+		for _, result := range results {
+			if result.response == nil {
 				continue
 			}
-			trimmedPath := strings.Trim(r.URL.Path, "/")
-			if trimmedPath != "" && trimmedPath != "v1" {
-				responses = append(
-					responses,
-					types.RPCInvalidRequestError(request.ID, fmt.Errorf("path %s is invalid", r.URL.Path)),
-				)
-				cache = false
-				continue
-			}
-			rpcFunc, ok := funcMap[request.Method]
-			if !ok || (rpcFunc.ws) {
-				responses = append(responses, types.RPCMethodNotFoundError(request.ID))
-				cache = false
-				continue
-			}
-			ctx := &types.Context{JSONReq: &request, HTTPReq: r}
-			args := []reflect.Value{reflect.ValueOf(ctx)}
-			if len(request.Params) > 0 {
-				fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
-				if err != nil {
-					responses = append(
-						responses,
-						types.RPCInvalidParamsError(request.ID, fmt.Errorf("error converting json params to arguments: %w", err)),
-					)
-					cache = false
-					continue
-				}
-				args = append(args, fnArgs...)
-			}
-
-			if cache && !rpcFunc.cacheableWithArgs(args) {
-				cache = false
+			responses = append(responses, *result.response)
+			cache = cache && result.cacheable
+			if result.rejectedStatus != 0 {
+				rejectedStatus = result.rejectedStatus
 			}
+		}
 
-			returns := rpcFunc.f.Call(args)
-			result, err := unreflectResult(returns)
-			if err != nil {
-				responses = append(responses, types.RPCInternalError(request.ID, err))
-				continue
+		if !batch && len(responses) == 1 && rejectedStatus != 0 {
+			if wErr := WriteRPCResponseHTTPError(w, rejectedStatus, responses[0]); wErr != nil {
+				logger.Error("failed to write response", "err", wErr)
 			}
-			responses = append(responses, types.NewRPCSuccessResponse(request.ID, result))
+			return
 		}
 
 		if len(responses) > 0 {
-			var wErr error
+			var headers []httpHeader
 			if cache {
-				wErr = WriteCacheableRPCResponseHTTP(w, responses...)
-			} else {
-				wErr = WriteRPCResponseHTTP(w, responses...)
+				headers = append(headers, cacheControlHeader)
+			}
+			// The responses to a batch are an array, even if of a single
+			// response.
+			var v interface{} = responses
+			if !batch {
+				v = responses[0]
 			}
-			if wErr != nil {
+			if wErr := writeJSONHTTP(w, headers, v); wErr != nil {
 				logger.Error("failed to write responses", "err", wErr)
 			}
 		}
 	}
 }
 
+// jsonRPCResult is the outcome of a request of a JSON-RPC call.
+type jsonRPCResult struct {
+	response       *types.RPCResponse // nil for a notification
+	cacheable      bool
+	rejectedStatus int // the HTTP status if rate limited or not authorized
+}
+
+// handleJSONRPCRequest calls the function of the request, one of the requests
+// of the HTTP request r. It is called concurrently for the requests of a
+// batch if the batch concurrency is above 1.
+func handleJSONRPCRequest(
+	funcMap map[string]*RPCFunc,
+	cfg *registerConfig,
+	r *http.Request,
+	request *types.RPCRequest,
+	logger log.Logger,
+) jsonRPCResult {
+	// A Notification is a Request object without an "id" member.
+	// The Server MUST NOT reply to a Notification, including those that are within a batch request.
+	if request.ID == nil {
+		logger.Debug(
+			"HTTPJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)",
+			"req", request,
+		)
+		return jsonRPCResult{}
+	}
+	failed := func(res types.RPCResponse) jsonRPCResult {
+		return jsonRPCResult{response: &res}
+	}
+	trimmedPath := strings.Trim(r.URL.Path, "/")
+	if trimmedPath != "" && trimmedPath != "v1" {
+		return failed(types.RPCInvalidRequestError(request.ID, fmt.Errorf("path %s is invalid", r.URL.Path)))
+	}
+	rpcFunc, ok := funcMap[request.Method]
+	if !ok || (rpcFunc.ws) {
+		return failed(types.RPCMethodNotFoundError(request.ID))
+	}
+	if !cfg.rateLimiter.Allow(r.RemoteAddr, request.Method) {
+		res := failed(types.RPCServerError(request.ID, ErrRateLimited))
+		res.rejectedStatus = http.StatusTooManyRequests
+		return res
+	}
+	if err := authorize(cfg.authorizer, r, request.Method); err != nil {
+		res := failed(types.RPCServerError(request.ID, err))
+		res.rejectedStatus = authStatus(err)
+		return res
+	}
+	ctx := &types.Context{JSONReq: request, HTTPReq: r}
+	args := []reflect.Value{reflect.ValueOf(ctx)}
+	if len(request.Params) > 0 {
+		fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
+		if err != nil {
+			return failed(types.RPCInvalidParamsError(
+				request.ID, fmt.Errorf("error converting json params to arguments: %w", err),
+			))
+		}
+		args = append(args, fnArgs...)
+	}
+
+	returns := rpcFunc.f.Call(args)
+	result, err := unreflectResult(returns)
+	var res types.RPCResponse
+	if err != nil {
+		res = types.RPCInternalError(request.ID, err)
+	} else {
+		res = types.NewRPCSuccessResponse(request.ID, result)
+	}
+	return jsonRPCResult{response: &res, cacheable: rpcFunc.cacheableWithArgs(args)}
+}
+
 func handleInvalidJSONRPCPaths(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		trimmedPath := strings.Trim(r.URL.Path, "/")
@@ -171,12 +256,17 @@ func arrayParamsToArgs(
 	params []json.RawMessage,
 	argsOffset int,
 ) ([]reflect.Value, error) {
-	if len(rpcFunc.argNames) != len(params) {
-		return nil, fmt.Errorf("expected %v parameters (%v), got %v (%v)",
+	if len(params) > len(rpcFunc.argNames) {
+		return nil, fmt.Errorf("expected at most %v parameters (%v), got %v (%v)",
 			len(rpcFunc.argNames), rpcFunc.argNames, len(params), params)
 	}
 
-	values := make([]reflect.Value, len(params))
+	// Omitted trailing parameters are set to their default value, as they are
+	// when omitted by name.
+	values := make([]reflect.Value, len(rpcFunc.argNames))
+	for i := len(params); i < len(values); i++ {
+		values[i] = reflect.Zero(rpcFunc.args[i+argsOffset])
+	}
 	for i, p := range params {
 		argType := rpcFunc.args[i+argsOffset]
 		val := reflect.New(argType)
@@ -3,11 +3,14 @@ package server
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -46,7 +49,7 @@ func TestRPCParams(t *testing.T) {
 		{`{"jsonrpc": "2.0", "method": "y", "id": "0"}`, "Method not found", types.JSONRPCStringID("0")},
 		// id not captured in JSON parsing failures
 		{`{"method": "c", "id": "0", "params": a}`, "invalid character", nil},
-		{`{"method": "c", "id": "0", "params": ["a"]}`, "got 1", types.JSONRPCStringID("0")},
+		{`{"method": "c", "id": "0", "params": ["a", "10", "b"]}`, "got 3", types.JSONRPCStringID("0")},
 		{`{"method": "c", "id": "0", "params": ["a", "b"]}`, "invalid character", types.JSONRPCStringID("0")},
 		{`{"method": "c", "id": "0", "params": [1, 1]}`, "of type string", types.JSONRPCStringID("0")},
 
@@ -57,6 +60,8 @@ func TestRPCParams(t *testing.T) {
 		{`{"jsonrpc": "2.0", "method": "c", "id": "0", "params": null}`, "", types.JSONRPCStringID("0")},
 		{`{"method": "c", "id": "0", "params": {}}`, "", types.JSONRPCStringID("0")},
 		{`{"method": "c", "id": "0", "params": ["a", "10"]}`, "", types.JSONRPCStringID("0")},
+		// omitted trailing params default to zero values
+		{`{"method": "c", "id": "0", "params": ["a"]}`, "", types.JSONRPCStringID("0")},
 	}
 
 	for i, tt := range tests {
@@ -216,6 +221,80 @@ func TestRPCNotificationInBatch(t *testing.T) {
 	}
 }
 
+func TestRPCBatch(t *testing.T) {
+	// Both calls wait for the other one, so they succeed only if concurrent.
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	funcMap := map[string]*RPCFunc{
+		"wait": NewRPCFunc(func(ctx *types.Context, s string) (string, error) {
+			arrived.Done()
+			done := make(chan struct{})
+			go func() {
+				arrived.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+				return s, nil
+			case <-time.After(5 * time.Second):
+				return "", errors.New("timed out")
+			}
+		}, "s"),
+		"c": NewRPCFunc(func(ctx *types.Context) (string, error) { return "foo", nil }, ""),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.NewTMLogger(new(bytes.Buffer)),
+		WithMaxBatchSize(3), WithBatchConcurrency(2))
+
+	post := func(payload string) (int, []byte) {
+		req := httptest.NewRequest(http.MethodPost, "http://localhost/", strings.NewReader(payload))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		blob, err := io.ReadAll(rec.Body)
+		require.NoError(t, err)
+		return rec.Code, blob
+	}
+
+	// The responses are in the order of the requests.
+	code, blob := post(`[
+		{"jsonrpc": "2.0", "method": "wait", "id": 0, "params": ["a"]},
+		{"jsonrpc": "2.0", "method": "wait", "id": 1, "params": ["b"]}
+	]`)
+	require.Equal(t, http.StatusOK, code)
+	var responses []types.RPCResponse
+	require.NoError(t, json.Unmarshal(blob, &responses))
+	require.Len(t, responses, 2)
+	for i, res := range responses {
+		require.Nil(t, res.Error)
+		assert.Equal(t, types.JSONRPCIntID(i), res.ID)
+	}
+	assert.Equal(t, `"a"`, string(responses[0].Result))
+	assert.Equal(t, `"b"`, string(responses[1].Result))
+
+	// A batch of a single request is responded to with an array.
+	code, blob = post(`[{"jsonrpc": "2.0", "method": "c", "id": 0}]`)
+	require.Equal(t, http.StatusOK, code)
+	responses = nil
+	require.NoError(t, json.Unmarshal(blob, &responses))
+	require.Len(t, responses, 1)
+	assert.Nil(t, responses[0].Error)
+
+	code, _ = post(`[]`)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, blob = post(`[
+		{"jsonrpc": "2.0", "method": "c", "id": 0},
+		{"jsonrpc": "2.0", "method": "c", "id": 1},
+		{"jsonrpc": "2.0", "method": "c", "id": 2},
+		{"jsonrpc": "2.0", "method": "c", "id": 3}
+	]`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+	var res types.RPCResponse
+	require.NoError(t, json.Unmarshal(blob, &res))
+	require.NotNil(t, res.Error)
+	assert.Contains(t, res.Error.Data, "exceeds the maximum of 3")
+}
+
 func TestUnknownRPCPath(t *testing.T) {
 	mux := testMux()
 	req, _ := http.NewRequest("GET", "http://localhost/unknownrpcpath", nil)
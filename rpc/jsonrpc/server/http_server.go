@@ -32,6 +32,9 @@ type Config struct {
 	MaxBodyBytes int64
 	// mirrors http.Server#MaxHeaderBytes
 	MaxHeaderBytes int
+	// CompressResponses compresses the responses for the clients accepting
+	// it, see CompressionHandler.
+	CompressResponses bool
 }
 
 // DefaultConfig returns a default configuration.
@@ -47,13 +50,14 @@ func DefaultConfig() *Config {
 
 // Serve creates a http.Server and calls Serve with the given listener. It
 // wraps handler with RecoverAndLogHandler and a handler, which limits the max
-// body size to config.MaxBodyBytes.
+// body size to config.MaxBodyBytes, and with CompressionHandler if
+// config.CompressResponses is set.
 //
 // NOTE: This function blocks - you may want to call it in a go-routine.
 func Serve(listener net.Listener, handler http.Handler, logger log.Logger, config *Config) error {
 	logger.Info("serve", "msg", log.NewLazySprintf("Starting RPC HTTP server on %s", listener.Addr()))
 	s := &http.Server{
-		Handler:           RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes}, logger),
+		Handler:           serverHandler(handler, logger, config),
 		ReadTimeout:       config.ReadTimeout,
 		ReadHeaderTimeout: config.ReadTimeout,
 		WriteTimeout:      config.WriteTimeout,
@@ -66,7 +70,8 @@ func Serve(listener net.Listener, handler http.Handler, logger log.Logger, confi
 
 // Serve creates a http.Server and calls ServeTLS with the given listener,
 // certFile and keyFile. It wraps handler with RecoverAndLogHandler and a
-// handler, which limits the max body size to config.MaxBodyBytes.
+// handler, which limits the max body size to config.MaxBodyBytes, and with
+// CompressionHandler if config.CompressResponses is set.
 //
 // NOTE: This function blocks - you may want to call it in a go-routine.
 func ServeTLS(
@@ -79,7 +84,7 @@ func ServeTLS(
 	logger.Info("serve tls", "msg", log.NewLazySprintf("Starting RPC HTTPS server on %s (cert: %q, key: %q)",
 		listener.Addr(), certFile, keyFile))
 	s := &http.Server{
-		Handler:           RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes}, logger),
+		Handler:           serverHandler(handler, logger, config),
 		ReadTimeout:       config.ReadTimeout,
 		ReadHeaderTimeout: config.ReadTimeout,
 		WriteTimeout:      config.WriteTimeout,
@@ -91,6 +96,14 @@ func ServeTLS(
 	return err
 }
 
+func serverHandler(handler http.Handler, logger log.Logger, config *Config) http.Handler {
+	handler = maxBytesHandler{h: handler, n: config.MaxBodyBytes}
+	if config.CompressResponses {
+		handler = CompressionHandler(handler)
+	}
+	return RecoverAndLogHandler(handler, logger)
+}
+
 // WriteRPCResponseHTTPError marshals res as JSON (with indent) and writes it
 // to w.
 //
@@ -124,7 +137,7 @@ func WriteRPCResponseHTTP(w http.ResponseWriter, res ...types.RPCResponse) error
 // it to w. Adds cache-control to the response header and sets the expiry to
 // one day.
 func WriteCacheableRPCResponseHTTP(w http.ResponseWriter, res ...types.RPCResponse) error {
-	return writeRPCResponseHTTP(w, []httpHeader{{"Cache-Control", "public, max-age=86400"}}, res...)
+	return writeRPCResponseHTTP(w, []httpHeader{cacheControlHeader}, res...)
 }
 
 type httpHeader struct {
@@ -132,6 +145,9 @@ type httpHeader struct {
 	value string
 }
 
+// cacheControlHeader sets the expiry of the cacheable responses to one day.
+var cacheControlHeader = httpHeader{"Cache-Control", "public, max-age=86400"}
+
 func writeRPCResponseHTTP(w http.ResponseWriter, headers []httpHeader, res ...types.RPCResponse) error {
 	var v interface{}
 	if len(res) == 1 {
@@ -139,7 +155,17 @@ func writeRPCResponseHTTP(w http.ResponseWriter, headers []httpHeader, res ...ty
 	} else {
 		v = res
 	}
+	return writeJSONHTTP(w, headers, v)
+}
+
+// writeJSONHTTP marshals v as JSON and writes it to w, with a 200 status.
+func writeJSONHTTP(w http.ResponseWriter, headers []httpHeader, v interface{}) error {
+	return writeJSONHTTPStatus(w, http.StatusOK, headers, v)
+}
 
+// writeJSONHTTPStatus marshals v as JSON and writes it to w, with the given
+// status.
+func writeJSONHTTPStatus(w http.ResponseWriter, status int, headers []httpHeader, v interface{}) error {
 	jsonBytes, err := json.Marshal(v)
 	if err != nil {
 		return fmt.Errorf("json marshal: %w", err)
@@ -148,7 +174,7 @@ func writeRPCResponseHTTP(w http.ResponseWriter, headers []httpHeader, res ...ty
 	for _, header := range headers {
 		w.Header().Set(header.name, header.value)
 	}
-	w.WriteHeader(200)
+	w.WriteHeader(status)
 	_, err = w.Write(jsonBytes)
 	return err
 }
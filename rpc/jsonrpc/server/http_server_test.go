@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -24,6 +25,13 @@ type sampleResult struct {
 	Value string `json:"value"`
 }
 
+// statusResult is a result responded with the given HTTP status.
+type statusResult struct {
+	Status int `json:"status"`
+}
+
+func (r *statusResult) HTTPStatus() int { return r.Status }
+
 func TestMaxOpenConnections(t *testing.T) {
 	const max = 5 // max simultaneous connections
 
@@ -153,3 +161,32 @@ func TestWriteRPCResponseHTTPError(t *testing.T) {
 	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
 	assert.Equal(t, `{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"Internal error","data":"foo"}}`, string(body))
 }
+
+func TestStatusResult(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"status": NewRPCFunc(func(_ *types.Context, status int) (*statusResult, error) {
+			return &statusResult{status}, nil
+		}, "status"),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger())
+
+	for _, status := range []int{http.StatusOK, http.StatusServiceUnavailable} {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost/status?status=%d", status), nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		res := rec.Result()
+		body, err := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, status, res.StatusCode)
+		assert.Equal(t, fmt.Sprintf(`{"jsonrpc":"2.0","id":-1,"result":{"status":"%d"}}`, status), string(body))
+	}
+
+	// The JSON-RPC responses keep the 200 status.
+	req := httptest.NewRequest(http.MethodPost, "http://localhost/",
+		strings.NewReader(`{"jsonrpc":"2.0","id":0,"method":"status","params":{"status":"503"}}`))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+}
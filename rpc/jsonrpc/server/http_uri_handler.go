@@ -17,8 +17,16 @@ import (
 
 var reInt = regexp.MustCompile(`^-?[0-9]+$`)
 
+// StatusResult is implemented by the results of the RPC functions responded
+// with an HTTP status other than 200 OK to the requests of their URI, e.g.
+// 503 to the health checks of an unhealthy node. The responses to the
+// JSON-RPC requests keep the 200 status.
+type StatusResult interface {
+	HTTPStatus() int
+}
+
 // convert from a function name to the http handler
-func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWriter, *http.Request) {
+func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) http.HandlerFunc {
 	// Always return -1 as there's no ID here.
 	dummyID := types.JSONRPCIntID(-1) // URIClientRequestID
 
@@ -65,7 +73,9 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 		}
 
 		resp := types.NewRPCSuccessResponse(dummyID, result)
-		if rpcFunc.cacheableWithArgs(args) {
+		if status := resultStatus(returns[0]); status != http.StatusOK {
+			err = writeJSONHTTPStatus(w, status, nil, resp)
+		} else if rpcFunc.cacheableWithArgs(args) {
 			err = WriteCacheableRPCResponseHTTP(w, resp)
 		} else {
 			err = WriteRPCResponseHTTP(w, resp)
@@ -77,6 +87,36 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 	}
 }
 
+// resultStatus returns the HTTP status of the response to the request of a
+// URI, given the result of the function.
+func resultStatus(result reflect.Value) int {
+	if result.Kind() == reflect.Ptr && result.IsNil() {
+		return http.StatusOK
+	}
+	if sr, ok := result.Interface().(StatusResult); ok {
+		return sr.HTTPStatus()
+	}
+	return http.StatusOK
+}
+
+// limitRate rejects the requests to the function of the handler exceeding the
+// rate limits of the limiter, with a 429 status.
+func limitRate(funcName string, handler http.HandlerFunc, limiter *RateLimiter, logger log.Logger) http.HandlerFunc {
+	if limiter == nil {
+		return handler
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !limiter.Allow(r.RemoteAddr, funcName) {
+			res := types.RPCServerError(types.JSONRPCIntID(-1), ErrRateLimited)
+			if wErr := WriteRPCResponseHTTPError(w, http.StatusTooManyRequests, res); wErr != nil {
+				logger.Error("failed to write response", "err", wErr)
+			}
+			return
+		}
+		handler(w, r)
+	}
+}
+
 // Covert an http query to a list of properly typed values.
 // To be properly decoded the arg must be a concrete type from CometBFT (if its an interface).
 func httpParamsToArgs(rpcFunc *RPCFunc, r *http.Request) ([]reflect.Value, error) {
@@ -0,0 +1,327 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+const (
+	// openAPIVersion is the version of the OpenAPI specification of the
+	// documents generated by OpenAPISpec.
+	openAPIVersion = "3.0.3"
+
+	// openAPIPath is the path of the OpenAPI description of the functions.
+	openAPIPath = "openapi.json"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf(new(json.Marshaler)).Elem()
+)
+
+// OpenAPIDocument is an OpenAPI description of the HTTP endpoints of the RPC
+// functions.
+type OpenAPIDocument struct {
+	OpenAPI    string                     `json:"openapi"`
+	Info       OpenAPIInfo                `json:"info"`
+	Paths      map[string]OpenAPIPathItem `json:"paths"`
+	Components OpenAPIComponents          `json:"components"`
+}
+
+// OpenAPIInfo is the title and the version of the API.
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenAPIPathItem is the description of an endpoint.
+type OpenAPIPathItem struct {
+	Get *OpenAPIOperation `json:"get,omitempty"`
+}
+
+// OpenAPIOperation is the description of a call of an endpoint.
+type OpenAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Parameters  []OpenAPIParameter         `json:"parameters,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses"`
+}
+
+// OpenAPIParameter is the description of an argument of an RPC function, in
+// the query of the URI.
+type OpenAPIParameter struct {
+	Name   string         `json:"name"`
+	In     string         `json:"in"`
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPIResponse is the description of a response of an endpoint.
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIMediaType is the schema of the content of a response.
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPIComponents are the schemas referenced by the document.
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas"`
+}
+
+// OpenAPISchema is the JSON schema of a value, or a reference to a schema of
+// the components.
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Description          string                    `json:"description,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+// OpenAPISpec returns the OpenAPI description of the HTTP endpoints of the
+// functions, i.e. of the "/<function>?<arg>=<value>" URIs. The schemas of the
+// arguments and the results follow the JSON encoding of the libs/json
+// package, e.g. 64-bit integers are strings. The functions only available via
+// WebSocket are not described.
+func OpenAPISpec(funcMap map[string]*RPCFunc, info OpenAPIInfo) *OpenAPIDocument {
+	g := &openAPIGenerator{
+		schemas: map[string]*OpenAPISchema{
+			"RPCError": {
+				Type: "object",
+				Properties: map[string]*OpenAPISchema{
+					"code":    {Type: "integer"},
+					"message": {Type: "string"},
+					"data":    {Type: "string"},
+				},
+			},
+		},
+		names: make(map[reflect.Type]string),
+	}
+	doc := &OpenAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info:    info,
+		Paths:   make(map[string]OpenAPIPathItem, len(funcMap)),
+	}
+	// Sorted, for the names of the schemas of the types of the same name to
+	// be stable.
+	names := make([]string, 0, len(funcMap))
+	for name := range funcMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rpcFunc := funcMap[name]
+		if rpcFunc.ws {
+			continue
+		}
+		op := &OpenAPIOperation{
+			OperationID: name,
+			Responses: map[string]OpenAPIResponse{
+				"200": {
+					Description: "The result of the call.",
+					Content:     jsonContent(g.envelope(rpcFunc.returns[0], false)),
+				},
+				"default": {
+					Description: "The error of the call.",
+					Content:     jsonContent(g.envelope(nil, true)),
+				},
+			},
+		}
+		// The first argument is the context.
+		for i, argName := range rpcFunc.argNames {
+			op.Parameters = append(op.Parameters, OpenAPIParameter{
+				Name:   argName,
+				In:     "query",
+				Schema: g.schema(rpcFunc.args[i+1]),
+			})
+		}
+		doc.Paths["/"+name] = OpenAPIPathItem{Get: op}
+	}
+	doc.Components.Schemas = g.schemas
+	return doc
+}
+
+func jsonContent(schema *OpenAPISchema) map[string]OpenAPIMediaType {
+	return map[string]OpenAPIMediaType{"application/json": {Schema: schema}}
+}
+
+// openAPIGenerator generates the schemas of the types, adding the schemas of
+// the structs to the components.
+type openAPIGenerator struct {
+	schemas map[string]*OpenAPISchema
+	names   map[reflect.Type]string // names of the schemas of the structs
+}
+
+// envelope returns the schema of a JSON-RPC response with a result of the
+// given type, or with an error.
+func (g *openAPIGenerator) envelope(result reflect.Type, isError bool) *OpenAPISchema {
+	schema := &OpenAPISchema{
+		Type: "object",
+		Properties: map[string]*OpenAPISchema{
+			"jsonrpc": {Type: "string"},
+			"id":      {Type: "integer"},
+		},
+	}
+	if isError {
+		schema.Properties["error"] = &OpenAPISchema{Ref: "#/components/schemas/RPCError"}
+	} else {
+		schema.Properties["result"] = g.schema(result)
+	}
+	return schema
+}
+
+// schema returns the schema of the JSON encoding of the type by libs/json.
+func (g *openAPIGenerator) schema(t reflect.Type) *OpenAPISchema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+	schema := g.valueSchema(t)
+	if nullable && schema.Ref == "" {
+		schema.Nullable = true
+	}
+	return schema
+}
+
+func (g *openAPIGenerator) valueSchema(t reflect.Type) *OpenAPISchema {
+	if t == timeType {
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		// Encoded by the type itself. The types encoding bytes, e.g. HexBytes,
+		// are assumed to encode them as strings.
+		switch {
+		case t.Kind() == reflect.String,
+			t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+			return &OpenAPISchema{Type: "string", Description: t.String()}
+		default:
+			return &OpenAPISchema{Description: t.String()}
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &OpenAPISchema{Type: "integer"}
+	case reflect.Int, reflect.Int64:
+		return &OpenAPISchema{Type: "string", Format: "int64"}
+	case reflect.Uint, reflect.Uint64:
+		return &OpenAPISchema{Type: "string", Format: "uint64"}
+	case reflect.Float32, reflect.Float64:
+		return &OpenAPISchema{Type: "number"}
+	case reflect.String:
+		return &OpenAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &OpenAPISchema{Type: "string", Format: "byte"}
+		}
+		return &OpenAPISchema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &OpenAPISchema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Interface:
+		// Registered types are wrapped in a type/value envelope.
+		return &OpenAPISchema{
+			Type: "object",
+			Properties: map[string]*OpenAPISchema{
+				"type":  {Type: "string"},
+				"value": {},
+			},
+			Nullable: true,
+		}
+	case reflect.Struct:
+		return &OpenAPISchema{Ref: "#/components/schemas/" + g.structSchema(t)}
+	default:
+		return &OpenAPISchema{Description: t.String()}
+	}
+}
+
+// structSchema adds the schema of the struct to the components, if not
+// already, and returns its name.
+func (g *openAPIGenerator) structSchema(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := schemaName(t)
+	// Distinguish the types of the same name in packages of the same name.
+	for i := 2; g.schemas[name] != nil; i++ {
+		name = fmt.Sprintf("%s_%d", schemaName(t), i)
+	}
+	schema := &OpenAPISchema{Type: "object", Properties: make(map[string]*OpenAPISchema)}
+	// Added before the fields, for the recursive types.
+	g.names[t] = name
+	g.schemas[name] = schema
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		jsonName := field.Name
+		if tag := field.Tag.Get("json"); tag == "-" {
+			continue
+		} else if tagName, _, _ := strings.Cut(tag, ","); tagName != "" {
+			jsonName = tagName
+		}
+		schema.Properties[jsonName] = g.schema(field.Type)
+	}
+	return name
+}
+
+// schemaName returns the name of the schema of a named type, prefixed with
+// the last two elements of its package path, e.g. "core.types.ResultStatus",
+// as many packages share the same name.
+func schemaName(t reflect.Type) string {
+	name := t.Name()
+	if name == "" {
+		name = "Struct"
+	}
+	// Replace the characters not allowed in the names of the schemas, e.g.
+	// of the type parameters.
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+	if pkgPath := t.PkgPath(); pkgPath != "" {
+		elems := strings.Split(pkgPath, "/")
+		if len(elems) > 2 {
+			elems = elems[len(elems)-2:]
+		}
+		name = strings.Join(elems, ".") + "." + name
+	}
+	return name
+}
+
+// makeOpenAPIHandler returns a handler of the requests of the OpenAPI
+// description of the functions, generated once.
+func makeOpenAPIHandler(funcMap map[string]*RPCFunc, info OpenAPIInfo, logger log.Logger) http.HandlerFunc {
+	spec, err := json.Marshal(OpenAPISpec(funcMap, info))
+	if err != nil {
+		// Only the types of the functions are marshaled.
+		panic(err)
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(spec); err != nil {
+			logger.Error("failed to write the OpenAPI description", "err", err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/libs/log"
+	types "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+type openAPITestResult struct {
+	Height  int64             `json:"height"`
+	Round   int32             `json:"round"`
+	Hash    cmtbytes.HexBytes `json:"hash"`
+	Data    []byte            `json:"data,omitempty"`
+	Time    time.Time         `json:"time"`
+	Hidden  string            `json:"-"`
+	Next    *openAPITestResult
+	Value   interface{} `json:"value"`
+	private bool
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"result": NewRPCFunc(func(ctx *types.Context, height *int64, prove bool) (*openAPITestResult, error) {
+			return nil, nil
+		}, "height,prove"),
+		"subscribe": NewWSRPCFunc(func(ctx *types.Context, query string) (string, error) { return "", nil }, "query"),
+	}
+	doc := OpenAPISpec(funcMap, OpenAPIInfo{Title: "Test", Version: "1.0.0"})
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Equal(t, OpenAPIInfo{Title: "Test", Version: "1.0.0"}, doc.Info)
+
+	// The functions only available via WebSocket are not described.
+	require.Len(t, doc.Paths, 1)
+	op := doc.Paths["/result"].Get
+	require.NotNil(t, op)
+	assert.Equal(t, "result", op.OperationID)
+	require.Len(t, op.Parameters, 2)
+	assert.Equal(t, OpenAPIParameter{
+		Name: "height", In: "query", Schema: &OpenAPISchema{Type: "string", Format: "int64", Nullable: true},
+	}, op.Parameters[0])
+	assert.Equal(t, OpenAPIParameter{
+		Name: "prove", In: "query", Schema: &OpenAPISchema{Type: "boolean"},
+	}, op.Parameters[1])
+
+	result := op.Responses["200"].Content["application/json"].Schema.Properties["result"]
+	require.NotNil(t, result)
+	assert.Equal(t, "#/components/schemas/jsonrpc.server.openAPITestResult", result.Ref)
+	schema := doc.Components.Schemas["jsonrpc.server.openAPITestResult"]
+	require.NotNil(t, schema)
+	assert.Len(t, schema.Properties, 7)
+	assert.Equal(t, &OpenAPISchema{Type: "string", Format: "int64"}, schema.Properties["height"])
+	assert.Equal(t, &OpenAPISchema{Type: "integer"}, schema.Properties["round"])
+	assert.Equal(t, "string", schema.Properties["hash"].Type)
+	assert.Equal(t, &OpenAPISchema{Type: "string", Format: "byte"}, schema.Properties["data"])
+	assert.Equal(t, &OpenAPISchema{Type: "string", Format: "date-time"}, schema.Properties["time"])
+	assert.Equal(t, result.Ref, schema.Properties["Next"].Ref)
+	assert.Contains(t, schema.Properties["value"].Properties, "type")
+	assert.Contains(t, schema.Properties["value"].Properties, "value")
+
+	errSchema := op.Responses["default"].Content["application/json"].Schema.Properties["error"]
+	require.NotNil(t, errSchema)
+	assert.Contains(t, doc.Components.Schemas, "RPCError")
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"c": NewRPCFunc(func(ctx *types.Context, s string) (string, error) { return s, nil }, "s"),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.NewTMLogger(new(bytes.Buffer)), WithOpenAPIInfo("Test", "1.0.0"))
+
+	for _, path := range []string{"/openapi.json", "/v1/openapi.json"} {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost"+path, nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, path)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var doc OpenAPIDocument
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc), path)
+		assert.Equal(t, "Test", doc.Info.Title)
+		assert.Contains(t, doc.Paths, "/c")
+	}
+}
@@ -148,6 +148,7 @@ func TestParseJSONRPC(t *testing.T) {
 		{`{"name": "john", "height": "22"}`, 22, "john", false},
 		// defaults
 		{`{"name": "solo", "unused": "stuff"}`, 0, "solo", false},
+		{`["7"]`, 7, "", false},
 		// should fail - wrong types/length
 		{`["flew", 7]`, 0, "", true},
 		{`[7,"flew",100]`, 0, "", true},
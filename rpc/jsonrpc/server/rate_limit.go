@@ -0,0 +1,175 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned for the requests of a client exceeding the rate
+// limit of a method.
+var ErrRateLimited = errors.New("rate limit exceeded, retry later")
+
+// rateLimitPruneInterval is how often the buckets of the clients idle long
+// enough to be full are dropped.
+const rateLimitPruneInterval = time.Minute
+
+// RateLimiter limits the rate of the requests of each client IP to each
+// method, with a token bucket per client IP and method.
+type RateLimiter struct {
+	rate        float64            // requests per second, 0 is unlimited
+	burst       int                // 0 means the rate, rounded up
+	methodRates map[string]float64 // overrides of the rate, 0 is unlimited
+	allowlist   []*net.IPNet
+
+	mtx       sync.Mutex
+	buckets   map[rateLimitKey]*tokenBucket
+	lastPrune time.Time
+}
+
+type rateLimitKey struct {
+	ip     string
+	method string
+}
+
+// NewRateLimiter returns a limiter of the requests of each client IP to each
+// method to the given rate, in requests per second, or to the rate of the
+// method in methodRates, if any. A rate of 0 disables the limit. The burst is
+// the number of requests accepted at once, the rate rounded up if 0. The
+// clients whose IP matches an IP or CIDR range of the allowlist are not
+// limited.
+func NewRateLimiter(rate float64, burst int, methodRates map[string]float64, allowlist []string) (*RateLimiter, error) {
+	if rate < 0 || burst < 0 {
+		return nil, errors.New("rate and burst must not be negative")
+	}
+	l := &RateLimiter{
+		rate:        rate,
+		burst:       burst,
+		methodRates: make(map[string]float64, len(methodRates)),
+		buckets:     make(map[rateLimitKey]*tokenBucket),
+	}
+	for method, r := range methodRates {
+		if r < 0 {
+			return nil, fmt.Errorf("rate of %s must not be negative", method)
+		}
+		l.methodRates[method] = r
+	}
+	for _, entry := range allowlist {
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP %q in the allowlist", entry)
+			}
+			bits := 8 * len(ip.To16())
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			l.allowlist = append(l.allowlist, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR range %q in the allowlist: %w", entry, err)
+		}
+		l.allowlist = append(l.allowlist, ipNet)
+	}
+	return l, nil
+}
+
+// Allow returns true, and consumes a token, if a request of the client with
+// the given remote address to the method is within the rate limit. A nil
+// limiter allows all the requests.
+func (l *RateLimiter) Allow(remoteAddr, method string) bool {
+	if l == nil {
+		return true
+	}
+	rate, ok := l.methodRates[method]
+	if !ok {
+		rate = l.rate
+	}
+	if rate == 0 {
+		return true
+	}
+	ip := remoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		ip = host
+	}
+	if l.isAllowlisted(ip) {
+		return true
+	}
+
+	now := time.Now()
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if now.Sub(l.lastPrune) >= rateLimitPruneInterval {
+		l.prune(now)
+	}
+	key := rateLimitKey{ip: ip, method: method}
+	bucket, ok := l.buckets[key]
+	if !ok {
+		burst := float64(l.burst)
+		if burst == 0 {
+			burst = math.Ceil(rate)
+		}
+		bucket = &tokenBucket{rate: rate, burst: burst, tokens: burst, last: now}
+		l.buckets[key] = bucket
+	}
+	bucket.refill(now)
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+func (l *RateLimiter) isAllowlisted(ip string) bool {
+	if len(l.allowlist) == 0 {
+		return false
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, ipNet := range l.allowlist {
+		if ipNet.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// prune drops the buckets refilled to their burst, which are created again
+// with as many tokens.
+func (l *RateLimiter) prune(now time.Time) {
+	for key, bucket := range l.buckets {
+		bucket.refill(now)
+		if bucket.tokens >= bucket.burst {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastPrune = now
+}
+
+// tokenBucket is a token bucket refilled at a constant rate, up to a burst.
+type tokenBucket struct {
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens accumulated since the last refill.
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+}
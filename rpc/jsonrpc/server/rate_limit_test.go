@@ -0,0 +1,95 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+	types "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter, err := NewRateLimiter(2, 0, map[string]float64{"status": 0, "tx_search": 0.5},
+		[]string{"10.0.0.0/8", "192.168.1.1"})
+	require.NoError(t, err)
+
+	// The burst defaults to the rate.
+	assert.True(t, limiter.Allow("1.2.3.4:1000", "block"))
+	assert.True(t, limiter.Allow("1.2.3.4:1001", "block"))
+	assert.False(t, limiter.Allow("1.2.3.4:1002", "block"))
+
+	// The buckets are per client IP and method.
+	assert.True(t, limiter.Allow("1.2.3.5:1000", "block"))
+	assert.True(t, limiter.Allow("1.2.3.4:1000", "commit"))
+
+	// The rates of the methods override the default one.
+	assert.True(t, limiter.Allow("1.2.3.4:1000", "tx_search"))
+	assert.False(t, limiter.Allow("1.2.3.4:1000", "tx_search"))
+	for i := 0; i < 10; i++ {
+		assert.True(t, limiter.Allow("1.2.3.4:1000", "status"))
+	}
+
+	// The allowlisted clients are not limited.
+	for i := 0; i < 10; i++ {
+		assert.True(t, limiter.Allow("10.1.2.3:1000", "block"))
+		assert.True(t, limiter.Allow("192.168.1.1:1000", "block"))
+	}
+	assert.True(t, limiter.Allow("192.168.1.2:1000", "block"))
+	assert.True(t, limiter.Allow("192.168.1.2:1000", "block"))
+	assert.False(t, limiter.Allow("192.168.1.2:1000", "block"))
+
+	_, err = NewRateLimiter(1, 0, nil, []string{"localhost"})
+	require.Error(t, err)
+	_, err = NewRateLimiter(1, 0, map[string]float64{"block": -1}, nil)
+	require.Error(t, err)
+}
+
+func TestRateLimitedHandlers(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"c": NewRPCFunc(func(ctx *types.Context) (string, error) { return "foo", nil }, ""),
+	}
+	limiter, err := NewRateLimiter(0, 0, map[string]float64{"c": 1}, nil)
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.NewTMLogger(new(bytes.Buffer)), WithRateLimiter(limiter))
+
+	get := func() int {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost/c", nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	assert.Equal(t, http.StatusOK, get())
+	assert.Equal(t, http.StatusTooManyRequests, get())
+
+	// The requests of a batch are limited one by one.
+	req := httptest.NewRequest(http.MethodPost, "http://localhost/",
+		strings.NewReader(`[{"jsonrpc": "2.0", "method": "c", "id": 0}, {"jsonrpc": "2.0", "method": "c", "id": 1}]`))
+	req.RemoteAddr = "1.2.3.4:1000"
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	blob, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	var responses []types.RPCResponse
+	require.NoError(t, json.Unmarshal(blob, &responses))
+	require.Len(t, responses, 2)
+	assert.Nil(t, responses[0].Error)
+	require.NotNil(t, responses[1].Error)
+	assert.Contains(t, responses[1].Error.Data, ErrRateLimited.Error())
+
+	req = httptest.NewRequest(http.MethodPost, "http://localhost/",
+		strings.NewReader(`{"jsonrpc": "2.0", "method": "c", "id": 0}`))
+	req.RemoteAddr = "1.2.3.4:1000"
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
@@ -13,17 +13,77 @@ import (
 // general jsonrpc and websocket handlers for all functions. "result" is the
 // interface on which the result objects are registered, and is popualted with
 // every RPCResponse
-func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger log.Logger) {
+func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger log.Logger, opts ...RegisterOption) {
+	cfg := &registerConfig{openAPIInfo: OpenAPIInfo{Title: "RPC", Version: "0.0.0"}}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
 	// HTTP endpoints
 	for funcName, rpcFunc := range funcMap {
-		mux.HandleFunc("/"+funcName, makeHTTPHandler(rpcFunc, logger))
-		mux.HandleFunc("/v1/"+funcName, makeHTTPHandler(rpcFunc, logger))
+		handler := makeHTTPHandler(rpcFunc, logger)
+		handler = requireAuth(funcName, handler, cfg.authorizer, logger)
+		handler = limitRate(funcName, handler, cfg.rateLimiter, logger)
+		mux.HandleFunc("/"+funcName, handler)
+		mux.HandleFunc("/v1/"+funcName, handler)
+	}
+
+	// OpenAPI description of the HTTP endpoints, unless shadowed by a function
+	if _, ok := funcMap[openAPIPath]; !ok {
+		handler := makeOpenAPIHandler(funcMap, cfg.openAPIInfo, logger)
+		mux.HandleFunc("/"+openAPIPath, handler)
+		mux.HandleFunc("/v1/"+openAPIPath, handler)
 	}
 
 	// JSONRPC endpoints
-	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, logger)))
-	mux.HandleFunc("/v1", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, logger)))
-	mux.HandleFunc("/v1/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, logger)))
+	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, cfg, logger)))
+	mux.HandleFunc("/v1", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, cfg, logger)))
+	mux.HandleFunc("/v1/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, cfg, logger)))
+}
+
+// RegisterOption configures the handlers added by RegisterRPCFuncs.
+type RegisterOption func(*registerConfig)
+
+type registerConfig struct {
+	rateLimiter      *RateLimiter
+	authorizer       Authorizer
+	maxBatchSize     int // 0 is unlimited
+	batchConcurrency int // the requests of a batch are sequential if <= 1
+	openAPIInfo      OpenAPIInfo
+}
+
+// WithRateLimiter rejects the requests exceeding the rate limits of the
+// given limiter.
+func WithRateLimiter(limiter *RateLimiter) RegisterOption {
+	return func(cfg *registerConfig) {
+		cfg.rateLimiter = limiter
+	}
+}
+
+// WithMaxBatchSize rejects the batches of more than the given number of
+// JSON-RPC requests. 0 means no limit.
+func WithMaxBatchSize(size int) RegisterOption {
+	return func(cfg *registerConfig) {
+		cfg.maxBatchSize = size
+	}
+}
+
+// WithBatchConcurrency executes up to the given number of the JSON-RPC
+// requests of a batch concurrently. The responses are in the order of the
+// requests regardless. Values of 1 or less execute the requests one by one,
+// in order.
+func WithBatchConcurrency(concurrency int) RegisterOption {
+	return func(cfg *registerConfig) {
+		cfg.batchConcurrency = concurrency
+	}
+}
+
+// WithOpenAPIInfo sets the title and the version of the API in the OpenAPI
+// description served at /openapi.json.
+func WithOpenAPIInfo(title, version string) RegisterOption {
+	return func(cfg *registerConfig) {
+		cfg.openAPIInfo = OpenAPIInfo{Title: title, Version: version}
+	}
 }
 
 type Option func(*RPCFunc)
@@ -74,6 +134,21 @@ func NewWSRPCFunc(f interface{}, args string, options ...Option) *RPCFunc {
 	return newRPCFunc(f, args, options...)
 }
 
+// ArgNames returns the names of the arguments accepted by the function.
+func (f *RPCFunc) ArgNames() []string {
+	return f.argNames
+}
+
+// IsWS returns true if the function is only available via WebSocket.
+func (f *RPCFunc) IsWS() bool {
+	return f.ws
+}
+
+// IsCacheable returns true if responses of the function may be cached.
+func (f *RPCFunc) IsCacheable() bool {
+	return f.cacheable
+}
+
 // cacheableWithArgs returns whether or not a call to this function is cacheable,
 // given the specified arguments.
 func (f *RPCFunc) cacheableWithArgs(args []reflect.Value) bool {
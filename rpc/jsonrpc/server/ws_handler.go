@@ -86,6 +86,8 @@ func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Requ
 
 	// register connection
 	con := newWSConnection(wsConn, wm.funcMap, wm.wsConnOptions...)
+	// The requests over the connection are authorized with the handshake.
+	con.httpReq = r
 	con.SetLogger(wm.logger.With("remote", wsConn.RemoteAddr()))
 	wm.logger.Info("New websocket connection", "remote", con.remoteAddr)
 	err = con.Start() // BLOCKING
@@ -136,6 +138,14 @@ type wsConnection struct {
 	// callback which is called upon disconnect
 	onDisconnect func(remoteAddr string)
 
+	// limits the rate of the requests, nil if unlimited
+	rateLimiter *RateLimiter
+
+	// authorizes the requests, given the handshake request, nil if all the
+	// requests are authorized
+	authorizer Authorizer
+	httpReq    *http.Request
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
@@ -217,6 +227,14 @@ func ReadLimit(readLimit int64) func(*wsConnection) {
 	}
 }
 
+// RateLimit rejects the requests exceeding the rate limits of the given
+// limiter. It should only be used in the constructor - not Goroutine-safe.
+func RateLimit(limiter *RateLimiter) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.rateLimiter = limiter
+	}
+}
+
 // OnStart implements service.Service by starting the read and write routines. It
 // blocks until there's some error.
 func (wsc *wsConnection) OnStart() error {
@@ -363,6 +381,19 @@ func (wsc *wsConnection) readRoutine() {
 				continue
 			}
 
+			if !wsc.rateLimiter.Allow(wsc.remoteAddr, request.Method) {
+				if err := wsc.WriteRPCResponse(writeCtx, types.RPCServerError(request.ID, ErrRateLimited)); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
+				}
+				continue
+			}
+			if err := authorize(wsc.authorizer, wsc.httpReq, request.Method); err != nil {
+				if err := wsc.WriteRPCResponse(writeCtx, types.RPCServerError(request.ID, err)); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
+				}
+				continue
+			}
+
 			ctx := &types.Context{JSONReq: &request, WSConn: wsc}
 			args := []reflect.Value{reflect.ValueOf(ctx)}
 			if len(request.Params) > 0 {
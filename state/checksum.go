@@ -0,0 +1,79 @@
+package state
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+
+	dbm "github.com/cometbft/cometbft-db"
+)
+
+// Records written by the state store (states, validator sets, consensus
+// params and ABCI responses) are stored alongside a CRC-32C checksum of their
+// value, under a separate key. The checksum is written in the same batch as
+// the record, so that both are always persisted together.
+//
+// Records written before checksums were introduced have no checksum, and
+// cannot be verified.
+
+var (
+	checksumKeyPrefix = []byte("checksum:")
+	checksumTable     = crc32.MakeTable(crc32.Castagnoli)
+)
+
+func calcChecksumKey(key []byte) []byte {
+	return append(append([]byte{}, checksumKeyPrefix...), key...)
+}
+
+func calcChecksum(value []byte) []byte {
+	return binary.BigEndian.AppendUint32(nil, crc32.Checksum(value, checksumTable))
+}
+
+// setRecord writes the record and its checksum to the database.
+func (store dbStore) setRecord(key, value []byte, sync bool) error {
+	batch := store.db.NewBatch()
+	defer batch.Close()
+	if err := setRecordInBatch(batch, key, value); err != nil {
+		return err
+	}
+	if sync {
+		return batch.WriteSync()
+	}
+	return batch.Write()
+}
+
+func setRecordInBatch(batch dbm.Batch, key, value []byte) error {
+	if err := batch.Set(key, value); err != nil {
+		return err
+	}
+	return batch.Set(calcChecksumKey(key), calcChecksum(value))
+}
+
+func deleteRecordInBatch(batch dbm.Batch, key []byte) error {
+	if err := batch.Delete(key); err != nil {
+		return err
+	}
+	return batch.Delete(calcChecksumKey(key))
+}
+
+// getRecord reads the record stored under the given key. If the store is
+// configured to verify checksums, ErrCorruptedRecord is returned if the
+// record does not match its checksum.
+func (store dbStore) getRecord(key []byte) ([]byte, error) {
+	value, err := store.db.Get(key)
+	if err != nil || len(value) == 0 || !store.VerifyChecksums {
+		return value, err
+	}
+	checksum, err := store.db.Get(calcChecksumKey(key))
+	if err != nil {
+		return nil, err
+	}
+	// The record was written before checksums were introduced.
+	if len(checksum) == 0 {
+		return value, nil
+	}
+	if !bytes.Equal(checksum, calcChecksum(value)) {
+		return nil, ErrCorruptedRecord{Key: string(key), Err: ErrChecksumMismatch}
+	}
+	return value, nil
+}
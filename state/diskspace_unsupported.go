@@ -0,0 +1,10 @@
+//go:build !(linux || darwin || freebsd)
+
+package state
+
+import "errors"
+
+// diskSpace is not supported on this platform.
+func diskSpace(string) (free, total uint64, err error) {
+	return 0, 0, errors.New("disk space reporting is not supported on this platform")
+}
@@ -0,0 +1,177 @@
+package state
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/cometbft/cometbft/crypto/threshold"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/mempool"
+	"github.com/cometbft/cometbft/types"
+)
+
+// decryptionSharesPrefix marks a vote extension carrying the decryption
+// shares of the validator for the encrypted txs of the next height. The
+// layout of the extension is:
+//
+//	prefix (4 bytes) | number of shares (4 bytes, big endian) |
+//	shares (tx key | decryption share) | extension of the application
+//
+// The extension signature covers the whole extension, but the application
+// only sees its own extension, in VerifyVoteExtension and PrepareProposal.
+// The shares are those of the TDH2 scheme of the crypto/threshold package,
+// which documents its construction and references.
+var decryptionSharesPrefix = []byte{0xce, 0x7e, 0x5a, 0x01}
+
+const (
+	decryptionSharesHeaderSize = 4 + 4
+	txDecryptionShareSize      = tmhash.Size + threshold.DecryptionShareSize
+)
+
+var errInvalidDecryptionShares = errors.New("invalid decryption shares in vote extension")
+
+// BlockExecutorWithThresholdDecryption enables the threshold decryption of
+// the encrypted txs: once the encrypted txs are enabled by the consensus
+// params, see types.ABCIParams.EncryptedTxsEnabled, the executor adds the
+// decryption shares of its key share for the encrypted txs of the next height
+// to its vote extensions, and decrypts the encrypted txs of the blocks it
+// proposes with the shares of the last extended commit.
+func BlockExecutorWithThresholdDecryption(keyFile *threshold.KeyFile) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.thresholdKey = keyFile
+	}
+}
+
+// extendVoteWithDecryptionShares returns the vote extension carrying the
+// decryption shares of the encrypted txs of the mempool to be decrypted at
+// the next height, followed by the extension of the application. The shares
+// that do not fit in the max size of a vote extension are left out, and the
+// extension carries no shares if the node has no key share.
+func (blockExec *BlockExecutor) extendVoteWithDecryptionShares(height int64, appExtension []byte) []byte {
+	var txs types.Txs
+	if blockExec.thresholdKey != nil {
+		txs = blockExec.mempool.EncryptedTxs(height + 1)
+	}
+	maxShares := (types.MaxVoteExtensionSize - decryptionSharesHeaderSize - len(appExtension)) / txDecryptionShareSize
+	if maxShares < 0 {
+		maxShares = 0
+	}
+	shares := make([]byte, 0, txDecryptionShareSize*cmtmath.MinInt(len(txs), maxShares))
+	count := 0
+	for _, tx := range txs {
+		if count == maxShares {
+			blockExec.logger.Info("vote extension full, some encrypted txs will not be decrypted",
+				"height", height+1, "encrypted_txs", len(txs), "shares", count)
+			break
+		}
+		share, err := mempool.NewTxDecryptionShare(blockExec.thresholdKey.PublicKey, blockExec.thresholdKey.Share, tx)
+		if err != nil {
+			blockExec.logger.Debug("cannot compute decryption share of encrypted tx",
+				"tx", tx.Hash(), "err", err)
+			continue
+		}
+		key := tx.Key()
+		shares = append(shares, key[:]...)
+		shares = append(shares, share.Bytes()...)
+		count++
+	}
+
+	ext := make([]byte, decryptionSharesHeaderSize, decryptionSharesHeaderSize+len(shares)+len(appExtension))
+	copy(ext, decryptionSharesPrefix)
+	binary.BigEndian.PutUint32(ext[len(decryptionSharesPrefix):], uint32(count))
+	ext = append(ext, shares...)
+	return append(ext, appExtension...)
+}
+
+// splitVoteExtension returns the decryption shares, keyed by tx, and the
+// extension of the application of a vote extension built by
+// extendVoteWithDecryptionShares. The shares are not verified.
+func splitVoteExtension(ext []byte) (map[types.TxKey]*threshold.DecryptionShare, []byte, error) {
+	if len(ext) < decryptionSharesHeaderSize || !bytes.HasPrefix(ext, decryptionSharesPrefix) {
+		return nil, nil, errInvalidDecryptionShares
+	}
+	count := int(binary.BigEndian.Uint32(ext[len(decryptionSharesPrefix):]))
+	ext = ext[decryptionSharesHeaderSize:]
+	if count > len(ext)/txDecryptionShareSize {
+		return nil, nil, errInvalidDecryptionShares
+	}
+	shares := make(map[types.TxKey]*threshold.DecryptionShare, count)
+	for i := 0; i < count; i++ {
+		var key types.TxKey
+		copy(key[:], ext)
+		share, err := threshold.DecryptionShareFromBytes(ext[tmhash.Size:txDecryptionShareSize])
+		if err != nil {
+			return nil, nil, errInvalidDecryptionShares
+		}
+		shares[key] = share
+		ext = ext[txDecryptionShareSize:]
+	}
+	return shares, ext, nil
+}
+
+// appVoteExtension returns the extension of the application of a vote
+// extension of height h, stripping its decryption shares if the encrypted txs
+// are enabled at h.
+func appVoteExtension(params types.ABCIParams, h int64, ext []byte) ([]byte, error) {
+	if !params.EncryptedTxsEnabled(h) {
+		return ext, nil
+	}
+	_, appExtension, err := splitVoteExtension(ext)
+	return appExtension, err
+}
+
+// decryptTxs replaces the encrypted txs with their plaintext, decrypted with
+// the decryption shares of the extended commit. The encrypted txs that cannot
+// be decrypted, for lack of valid shares, are left out of the returned txs.
+func (blockExec *BlockExecutor) decryptTxs(height int64, txs types.Txs, extCommit *types.ExtendedCommit) types.Txs {
+	var sharesByValidator []map[types.TxKey]*threshold.DecryptionShare
+	decrypted := make(types.Txs, 0, len(txs))
+	for _, tx := range txs {
+		if !mempool.IsEncryptedTx(tx) {
+			decrypted = append(decrypted, tx)
+			continue
+		}
+		if decryptionHeight, _, err := mempool.DecodeEncryptedTx(tx); err != nil || decryptionHeight != height {
+			blockExec.logger.Info("leaving out encrypted tx of another height",
+				"tx", tx.Hash(), "height", height, "decryption_height", decryptionHeight)
+			continue
+		}
+		if sharesByValidator == nil {
+			sharesByValidator = decryptionSharesOfCommit(extCommit)
+		}
+		key := tx.Key()
+		shares := make([]*threshold.DecryptionShare, 0, len(sharesByValidator))
+		for _, s := range sharesByValidator {
+			if share, ok := s[key]; ok {
+				shares = append(shares, share)
+			}
+		}
+		plaintext, err := mempool.DecryptTx(blockExec.thresholdKey.PublicKey, tx, shares)
+		if err != nil {
+			blockExec.logger.Info("leaving out encrypted tx that cannot be decrypted",
+				"tx", tx.Hash(), "height", height, "shares", len(shares), "err", err)
+			continue
+		}
+		decrypted = append(decrypted, plaintext)
+	}
+	return decrypted
+}
+
+// decryptionSharesOfCommit returns the decryption shares of the vote
+// extensions of the precommits for the block of the extended commit.
+func decryptionSharesOfCommit(extCommit *types.ExtendedCommit) []map[types.TxKey]*threshold.DecryptionShare {
+	shares := make([]map[types.TxKey]*threshold.DecryptionShare, 0, len(extCommit.ExtendedSignatures))
+	for _, ecs := range extCommit.ExtendedSignatures {
+		if ecs.BlockIDFlag != types.BlockIDFlagCommit {
+			continue
+		}
+		s, _, err := splitVoteExtension(ecs.Extension)
+		if err != nil {
+			continue
+		}
+		shares = append(shares, s)
+	}
+	return shares
+}
@@ -0,0 +1,197 @@
+package state_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	abcimocks "github.com/cometbft/cometbft/abci/types/mocks"
+	"github.com/cometbft/cometbft/crypto/threshold"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/mempool"
+	mpmocks "github.com/cometbft/cometbft/mempool/mocks"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/proxy"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/store"
+	"github.com/cometbft/cometbft/types"
+)
+
+// TestThresholdDecryption tests that the validators deliver their decryption
+// shares of the encrypted txs of the next height in their vote extensions,
+// and that the next proposer passes the decrypted txs to PrepareProposal.
+func TestThresholdDecryption(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	state, stateDB, privVals := makeState(3, 1)
+	state.ConsensusParams.ABCI.VoteExtensionsEnableHeight = 1
+	state.ConsensusParams.ABCI.EncryptedTxsEnableHeight = 1
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{DiscardABCIResponses: false})
+	pk, keyShares, err := threshold.GenerateKeys(3, 2)
+	require.NoError(t, err)
+
+	// encTx is decrypted, but not undecryptableTx, which only one validator
+	// has in its mempool.
+	encTx, err := mempool.EncryptTx(pk, 2, types.Tx("secret=1"))
+	require.NoError(t, err)
+	undecryptableTx, err := mempool.EncryptTx(pk, 2, types.Tx("secret=2"))
+	require.NoError(t, err)
+
+	block := makeBlock(state, 1, new(types.Commit))
+	bps, err := block.MakePartSet(testPartSize)
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+	extCommit, votes, err := makeValidCommit(1, blockID, state.Validators, privVals)
+	require.NoError(t, err)
+
+	newBlockExec := func(app abci.Application, mp mempool.Mempool, options ...sm.BlockExecutorOption) *sm.BlockExecutor {
+		proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app), proxy.NopMetrics())
+		require.NoError(t, proxyApp.Start())
+		t.Cleanup(func() { _ = proxyApp.Stop() })
+		return sm.NewBlockExecutor(
+			stateStore,
+			log.NewNopLogger(),
+			proxyApp.Consensus(),
+			mp,
+			sm.EmptyEvidencePool{},
+			store.NewBlockStore(dbm.NewMemDB()),
+			options...,
+		)
+	}
+
+	thresholdDecryption := func(keyShare *threshold.KeyShare) sm.BlockExecutorOption {
+		return sm.BlockExecutorWithThresholdDecryption(&threshold.KeyFile{PublicKey: pk, Share: keyShare})
+	}
+
+	// Each validator extends its precommit with its decryption shares, and
+	// the extension of the application.
+	for i, vote := range votes {
+		appExtension := []byte(fmt.Sprintf("extension-%d", i))
+		app := &abcimocks.Application{}
+		app.On("ExtendVote", mock.Anything, mock.Anything).Return(
+			&abci.ResponseExtendVote{VoteExtension: appExtension}, nil)
+		app.On("VerifyVoteExtension", mock.Anything, &abci.RequestVerifyVoteExtension{
+			Hash:             vote.BlockID.Hash,
+			ValidatorAddress: vote.ValidatorAddress,
+			Height:           vote.Height,
+			VoteExtension:    appExtension,
+		}).Return(&abci.ResponseVerifyVoteExtension{Status: abci.ResponseVerifyVoteExtension_ACCEPT}, nil)
+		mp := &mpmocks.Mempool{}
+		encTxs := types.Txs{encTx}
+		if i == 0 {
+			encTxs = append(encTxs, undecryptableTx)
+		}
+		mp.On("EncryptedTxs", int64(2)).Return(encTxs)
+
+		blockExec := newBlockExec(app, mp, thresholdDecryption(keyShares[i]))
+		ext, err := blockExec.ExtendVote(ctx, vote, block, state)
+		require.NoError(t, err)
+		assert.True(t, bytes.HasSuffix(ext, appExtension))
+
+		// The application only verifies its own extension, whether or not
+		// the node takes part in the threshold decryption.
+		vote.Extension = ext
+		require.NoError(t, blockExec.VerifyVoteExtension(ctx, vote, state))
+		require.NoError(t, newBlockExec(app, mp).VerifyVoteExtension(ctx, vote, state))
+		// The extensions must be framed, even without shares.
+		vote.Extension = appExtension
+		require.ErrorIs(t, blockExec.VerifyVoteExtension(ctx, vote, state), types.ErrInvalidVoteExtension)
+		vote.Extension = ext[:len(ext)-len(appExtension)-1]
+		require.ErrorIs(t, blockExec.VerifyVoteExtension(ctx, vote, state), types.ErrInvalidVoteExtension)
+
+		extCommit.ExtendedSignatures[i].Extension = ext
+		extCommit.ExtendedSignatures[i].ExtensionSignature = []byte("signature")
+		mp.AssertExpectations(t)
+	}
+
+	// The shares of the absent validator are ignored.
+	extCommit.ExtendedSignatures[2] = types.ExtendedCommitSig{CommitSig: types.CommitSig{BlockIDFlag: types.BlockIDFlagAbsent}}
+
+	plainTx := types.Tx("plain=1")
+	app := &abcimocks.Application{}
+	app.On("PrepareProposal", mock.Anything, mock.MatchedBy(func(req *abci.RequestPrepareProposal) bool {
+		return assert.Equal(t, [][]byte{plainTx, []byte("secret=1")}, req.Txs) &&
+			assert.Equal(t, []byte("extension-0"), req.LocalLastCommit.Votes[0].VoteExtension) &&
+			assert.Equal(t, []byte("extension-1"), req.LocalLastCommit.Votes[1].VoteExtension) &&
+			assert.Equal(t, cmtproto.BlockIDFlag(types.BlockIDFlagAbsent), req.LocalLastCommit.Votes[2].BlockIdFlag)
+	})).Return(&abci.ResponsePrepareProposal{Txs: [][]byte{plainTx, []byte("secret=1")}}, nil)
+	mp := &mpmocks.Mempool{}
+	mp.On("ReapMaxBytesMaxGas", mock.Anything, mock.Anything).Return(types.Txs{plainTx, encTx, undecryptableTx})
+	mp.On("GasWanted", mock.Anything).Return(int64(0))
+
+	blockExec := newBlockExec(app, mp, thresholdDecryption(keyShares[0]))
+	pa, _ := state.Validators.GetByIndex(0)
+	proposal, err := blockExec.CreateProposalBlock(ctx, 2, state, extCommit, pa)
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{plainTx, types.Tx("secret=1")}, proposal.Txs)
+	app.AssertExpectations(t)
+}
+
+// TestVoteExtensionFraming tests that the vote extensions are only framed
+// with the decryption shares if the encrypted txs are enabled by the
+// consensus params, and passed to the application unchanged otherwise.
+func TestVoteExtensionFraming(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	state, stateDB, privVals := makeState(1, 1)
+	state.ConsensusParams.ABCI.VoteExtensionsEnableHeight = 1
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{DiscardABCIResponses: false})
+
+	block := makeBlock(state, 1, new(types.Commit))
+	bps, err := block.MakePartSet(testPartSize)
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+	_, votes, err := makeValidCommit(1, blockID, state.Validators, privVals)
+	require.NoError(t, err)
+	vote := votes[0]
+
+	// An extension of the application that looks like a framed one.
+	appExtension := []byte{0xce, 0x7e, 0x5a, 0x01, 0xff}
+	app := &abcimocks.Application{}
+	app.On("ExtendVote", mock.Anything, mock.Anything).Return(
+		&abci.ResponseExtendVote{VoteExtension: appExtension}, nil)
+	app.On("VerifyVoteExtension", mock.Anything, &abci.RequestVerifyVoteExtension{
+		Hash:             vote.BlockID.Hash,
+		ValidatorAddress: vote.ValidatorAddress,
+		Height:           vote.Height,
+		VoteExtension:    appExtension,
+	}).Return(&abci.ResponseVerifyVoteExtension{Status: abci.ResponseVerifyVoteExtension_ACCEPT}, nil)
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app), proxy.NopMetrics())
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() { _ = proxyApp.Stop() })
+
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.NewNopLogger(),
+		proxyApp.Consensus(),
+		&mpmocks.Mempool{},
+		sm.EmptyEvidencePool{},
+		store.NewBlockStore(dbm.NewMemDB()),
+	)
+	ext, err := blockExec.ExtendVote(ctx, vote, block, state)
+	require.NoError(t, err)
+	assert.Equal(t, appExtension, ext)
+	vote.Extension = ext
+	require.NoError(t, blockExec.VerifyVoteExtension(ctx, vote, state))
+
+	// Without a key share, the extension is framed with no shares.
+	state.ConsensusParams.ABCI.EncryptedTxsEnableHeight = 1
+	ext, err = blockExec.ExtendVote(ctx, vote, block, state)
+	require.NoError(t, err)
+	assert.NotEqual(t, appExtension, ext)
+	assert.True(t, bytes.HasSuffix(ext, appExtension))
+	vote.Extension = ext
+	require.NoError(t, blockExec.VerifyVoteExtension(ctx, vote, state))
+	vote.Extension = appExtension
+	require.ErrorIs(t, blockExec.VerifyVoteExtension(ctx, vote, state), types.ErrInvalidVoteExtension)
+}
@@ -74,6 +74,13 @@ type (
 	ErrCannotLoadState struct {
 		Err error
 	}
+
+	// ErrCorruptedRecord is returned when a record of the state store does
+	// not match its checksum or cannot be decoded.
+	ErrCorruptedRecord struct {
+		Key string
+		Err error
+	}
 )
 
 func (e ErrUnknownBlock) Error() string {
@@ -162,6 +169,7 @@ var (
 	ErrFinalizeBlockResponsesNotPersisted = errors.New("node is not persisting finalize block responses")
 	ErrPrunerCannotLowerRetainHeight      = errors.New("cannot set a height lower than previously requested - heights might have already been pruned")
 	ErrInvalidRetainHeight                = errors.New("retain height cannot be less or equal than 0")
+	ErrChecksumMismatch                   = errors.New("checksum mismatch")
 )
 
 func (e ErrCannotLoadState) Error() string {
@@ -171,3 +179,11 @@ func (e ErrCannotLoadState) Error() string {
 func (e ErrCannotLoadState) Unwrap() error {
 	return e.Err
 }
+
+func (e ErrCorruptedRecord) Error() string {
+	return fmt.Sprintf("state store record %q has been corrupted or its spec has changed: %v", e.Key, e.Err)
+}
+
+func (e ErrCorruptedRecord) Unwrap() error {
+	return e.Err
+}
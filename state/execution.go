@@ -8,12 +8,15 @@ import (
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	cryptoenc "github.com/cometbft/cometbft/crypto/encoding"
+	"github.com/cometbft/cometbft/crypto/threshold"
 	"github.com/cometbft/cometbft/libs/fail"
 	"github.com/cometbft/cometbft/libs/log"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	"github.com/cometbft/cometbft/mempool"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/cometbft/cometbft/proxy"
 	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
 )
 
 //-----------------------------------------------------------------------------
@@ -45,6 +48,39 @@ type BlockExecutor struct {
 	logger log.Logger
 
 	metrics *Metrics
+
+	// reporter is nil if execution reports are not recorded.
+	reporter *ExecutionReporter
+
+	// blockTime maps the time of the local clock to the time of the blocks,
+	// nil if they are the same.
+	blockTime func(time.Time) time.Time
+
+	// optimisticExecution is true if the blocks may be executed
+	// optimistically, see ExecuteBlockOptimistically.
+	optimisticExecution bool
+	// optimistic execution of the block the node prevoted for, if any.
+	oeMtx cmtsync.Mutex
+	oe    *optimisticExecution
+
+	// thresholdKey is nil if the threshold decryption of the encrypted txs
+	// is disabled.
+	thresholdKey *threshold.KeyFile
+}
+
+// optimisticExecution is the execution of a block by the application before
+// the block is decided.
+type optimisticExecution struct {
+	height int64
+	hash   []byte
+	cancel context.CancelFunc
+	done   chan struct{} // closed once FinalizeBlock returned, or was skipped
+
+	// discarded is true once the response must not be used. Guarded by oeMtx.
+	discarded bool
+
+	resp *abci.ResponseFinalizeBlock
+	err  error
 }
 
 type BlockExecutorOption func(executor *BlockExecutor)
@@ -61,6 +97,31 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithExecutionReporter records the execution report of each
+// block with the given reporter.
+func BlockExecutorWithExecutionReporter(reporter *ExecutionReporter) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.reporter = reporter
+	}
+}
+
+// BlockExecutorWithBlockTime sets the time of the blocks at each time of the
+// local clock, e.g. to compress the time of the blocks of a test network.
+func BlockExecutorWithBlockTime(blockTime func(time.Time) time.Time) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.blockTime = blockTime
+	}
+}
+
+// BlockExecutorWithOptimisticExecution enables the optimistic execution of
+// the blocks, see ExecuteBlockOptimistically. The application must support
+// it, as declared by ResponseInfo.OptimisticExecution.
+func BlockExecutorWithOptimisticExecution() BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.optimisticExecution = true
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -94,6 +155,14 @@ func (blockExec *BlockExecutor) Store() Store {
 	return blockExec.store
 }
 
+// BlockTime returns the time of the blocks at t, the time of the local clock.
+func (blockExec *BlockExecutor) BlockTime(t time.Time) time.Time {
+	if blockExec.blockTime == nil {
+		return t
+	}
+	return blockExec.blockTime(t)
+}
+
 // SetEventBus - sets the event bus for publishing block related events.
 // If not called, it defaults to types.NopEventBus.
 func (blockExec *BlockExecutor) SetEventBus(eventBus types.BlockEventPublisher) {
@@ -124,21 +193,47 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	evidence, evSize := blockExec.evpool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
 
 	// Fetch a limited amount of valid txs
-	maxDataBytes := types.MaxDataBytes(maxBytes, evSize, state.Validators.Size())
+	var maxDataBytes int64
+	if height > state.InitialHeight && state.ConsensusParams.Validator.BLSAggregationEnabled(height-1) {
+		maxDataBytes = types.MaxDataBytesAggregatedCommit(maxBytes, evSize, state.Validators.Size())
+	} else {
+		maxDataBytes = types.MaxDataBytes(maxBytes, evSize, state.Validators.Size())
+	}
 	maxReapBytes := maxDataBytes
 	if emptyMaxBytes {
 		maxReapBytes = -1
 	}
 
 	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxReapBytes, maxGas)
+	// The extensions of the last commit carry the decryption shares of the
+	// encrypted txs of the height, if enabled.
+	encryptedTxs := height > state.InitialHeight && state.ConsensusParams.ABCI.EncryptedTxsEnabled(height-1)
+	if encryptedTxs && blockExec.thresholdKey != nil {
+		txs = blockExec.decryptTxs(height, txs, lastExtCommit)
+	}
 	commit := lastExtCommit.ToCommit()
-	block := state.MakeBlock(height, txs, commit, evidence, proposerAddr)
+	if commit.Height >= 1 && state.ConsensusParams.Validator.BLSAggregationEnabled(commit.Height) {
+		var err error
+		if commit, err = commit.AggregateSignatures(); err != nil {
+			return nil, err
+		}
+	}
+	block := state.makeBlock(height, txs, commit, evidence, proposerAddr, blockExec.BlockTime(cmttime.Now()))
+	localLastCommit := buildExtendedCommitInfoFromStore(lastExtCommit, blockExec.store, state.InitialHeight, state.ConsensusParams.ABCI)
+	// The application only sees its own extensions.
+	if encryptedTxs {
+		for i, vote := range localLastCommit.Votes {
+			if _, appExtension, err := splitVoteExtension(vote.VoteExtension); err == nil {
+				localLastCommit.Votes[i].VoteExtension = appExtension
+			}
+		}
+	}
 	rpp, err := blockExec.proxyApp.PrepareProposal(
 		ctx,
 		&abci.RequestPrepareProposal{
 			MaxTxBytes:         maxDataBytes,
 			Txs:                block.Txs.ToSliceOfBytes(),
-			LocalLastCommit:    buildExtendedCommitInfoFromStore(lastExtCommit, blockExec.store, state.InitialHeight, state.ConsensusParams.ABCI),
+			LocalLastCommit:    localLastCommit,
 			Misbehavior:        block.Evidence.Evidence.ToABCI(),
 			Height:             block.Height,
 			Time:               block.Time,
@@ -162,8 +257,16 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	if err := txl.Validate(maxDataBytes); err != nil {
 		return nil, err
 	}
+	// As in ProcessProposal, the max gas is only enforced if the application
+	// reports the gas of the txs it returns.
+	if maxGas > -1 && rpp.GasWanted != nil {
+		if gasWanted := rpp.GasWanted.Value; gasWanted < 0 || gasWanted > maxGas {
+			return nil, fmt.Errorf("transactions returned by PrepareProposal want %d gas, exceeding the max gas of a block %d",
+				gasWanted, maxGas)
+		}
+	}
 
-	return state.MakeBlock(height, txl, commit, evidence, proposerAddr), nil
+	return state.makeBlock(height, txl, commit, evidence, proposerAddr, blockExec.BlockTime(cmttime.Now())), nil
 }
 
 func (blockExec *BlockExecutor) ProcessProposal(
@@ -186,7 +289,16 @@ func (blockExec *BlockExecutor) ProcessProposal(
 	if resp.IsStatusUnknown() {
 		panic(fmt.Sprintf("ProcessProposal responded with status %s", resp.Status.String()))
 	}
-
+	// The gas of the block can only be known by the application, so the max
+	// gas is not enforced unless the application reports it, as the
+	// applications predating the field do not.
+	if maxGas := state.ConsensusParams.Block.MaxGas; maxGas > -1 && resp.GasWanted != nil {
+		if gasWanted := resp.GasWanted.Value; gasWanted < 0 || gasWanted > maxGas {
+			blockExec.logger.Info("rejecting the proposal exceeding the max gas of a block",
+				"height", block.Height, "gas_wanted", gasWanted, "max_gas", maxGas)
+			return false, nil
+		}
+	}
 	return resp.IsAccepted(), nil
 }
 
@@ -216,16 +328,7 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	}
 
 	startTime := time.Now().UnixNano()
-	abciResponse, err := blockExec.proxyApp.FinalizeBlock(context.TODO(), &abci.RequestFinalizeBlock{
-		Hash:               block.Hash(),
-		NextValidatorsHash: block.NextValidatorsHash,
-		ProposerAddress:    block.ProposerAddress,
-		Height:             block.Height,
-		Time:               block.Time,
-		DecidedLastCommit:  buildLastCommitInfoFromStore(block, blockExec.store, state.InitialHeight),
-		Misbehavior:        block.Evidence.Evidence.ToABCI(),
-		Txs:                block.Txs.ToSliceOfBytes(),
-	})
+	abciResponse, err := blockExec.finalizeBlock(state, block)
 	endTime := time.Now().UnixNano()
 	blockExec.metrics.BlockProcessingTime.Observe(float64(endTime-startTime) / 1000000)
 	if err != nil {
@@ -300,6 +403,11 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	fail.Fail() // XXX
 
+	if blockExec.reporter != nil {
+		executionTime := time.Duration(time.Now().UnixNano() - startTime)
+		blockExec.reporter.Record(newExecutionReport(block, abciResponse, &state, executionTime))
+	}
+
 	// Prune old heights, if requested by ABCI app.
 	if retainHeight > 0 && blockExec.pruner != nil {
 		err := blockExec.pruner.SetApplicationBlockRetainHeight(retainHeight)
@@ -315,6 +423,133 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	return state, nil
 }
 
+// ExecuteBlockOptimistically starts executing the block in the background,
+// before it is decided, so that ApplyBlock uses the response of the
+// application if the block is decided. The optimistic execution of another
+// block, if any, is discarded first, see DiscardOptimisticExecution.
+// Otherwise, ApplyBlock discards the response and executes the decided block.
+//
+// The application must discard the state resulting from an optimistic
+// execution when FinalizeBlock is called again at the same height, so it is a
+// no-op unless enabled with BlockExecutorWithOptimisticExecution, for the
+// applications declaring it in ResponseInfo.OptimisticExecution.
+//
+// The block must have been validated. It is a no-op if the block is already
+// executed optimistically, or if vote extensions are enabled at its height:
+// the application must be done with ExtendVote and VerifyVoteExtension at a
+// height before FinalizeBlock is called at that height.
+func (blockExec *BlockExecutor) ExecuteBlockOptimistically(state State, block *types.Block) {
+	if !blockExec.optimisticExecution || state.ConsensusParams.ABCI.VoteExtensionsEnabled(block.Height) {
+		return
+	}
+
+	blockExec.oeMtx.Lock()
+	defer blockExec.oeMtx.Unlock()
+
+	prev := blockExec.oe
+	if prev != nil && !prev.discarded && prev.height == block.Height && bytes.Equal(prev.hash, block.Hash()) {
+		return
+	}
+	if prev != nil {
+		blockExec.discardOptimisticExecution(prev)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	oe := &optimisticExecution{
+		height: block.Height,
+		hash:   block.Hash(),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	blockExec.oe = oe
+	req := blockExec.finalizeBlockRequest(state, block)
+	blockExec.logger.Debug("executing block optimistically", "height", block.Height, "hash", block.Hash())
+
+	go func() {
+		defer close(oe.done)
+		defer cancel()
+		// The application executes a single block at a time.
+		if prev != nil {
+			<-prev.done
+		}
+		if err := ctx.Err(); err != nil {
+			// Discarded before it started.
+			oe.err = err
+			return
+		}
+		oe.resp, oe.err = blockExec.proxyApp.FinalizeBlock(ctx, req)
+	}()
+}
+
+// DiscardOptimisticExecution discards the optimistic execution of a block, if
+// any, e.g. when the consensus moves to a new round, in which another block
+// may be decided. The execution is skipped if it has not started yet, and
+// aborted if the client of the application supports it. Otherwise, the next
+// call to FinalizeBlock, optimistic or not, waits for it to return, the
+// application being expected to discard its resulting state.
+func (blockExec *BlockExecutor) DiscardOptimisticExecution() {
+	blockExec.oeMtx.Lock()
+	defer blockExec.oeMtx.Unlock()
+
+	if blockExec.oe != nil {
+		blockExec.discardOptimisticExecution(blockExec.oe)
+	}
+}
+
+// discardOptimisticExecution marks the optimistic execution as discarded and
+// aborts it. The caller must hold oeMtx.
+func (blockExec *BlockExecutor) discardOptimisticExecution(oe *optimisticExecution) {
+	if oe.discarded {
+		return
+	}
+	oe.discarded = true
+	oe.cancel()
+	blockExec.metrics.OptimisticExecutions.With("result", "discarded").Add(1)
+	blockExec.logger.Info("discarding optimistic execution of block",
+		"height", oe.height, "hash", fmt.Sprintf("%X", oe.hash))
+}
+
+// finalizeBlock executes the block, reusing the response of its optimistic
+// execution, if any. The optimistic execution of another block is discarded.
+func (blockExec *BlockExecutor) finalizeBlock(state State, block *types.Block) (*abci.ResponseFinalizeBlock, error) {
+	blockExec.oeMtx.Lock()
+	oe := blockExec.oe
+	blockExec.oe = nil
+	if oe != nil && (oe.height != block.Height || !bytes.Equal(oe.hash, block.Hash())) {
+		blockExec.discardOptimisticExecution(oe)
+	}
+	blockExec.oeMtx.Unlock()
+
+	if oe != nil {
+		// Wait for the optimistic execution to return, even if discarded, as
+		// the application executes a single block at a time.
+		<-oe.done
+		if !oe.discarded {
+			if oe.err == nil {
+				blockExec.metrics.OptimisticExecutions.With("result", "used").Add(1)
+				return oe.resp, nil
+			}
+			blockExec.metrics.OptimisticExecutions.With("result", "discarded").Add(1)
+			blockExec.logger.Info("discarding failed optimistic execution of block",
+				"height", oe.height, "hash", fmt.Sprintf("%X", oe.hash), "err", oe.err)
+		}
+	}
+
+	return blockExec.proxyApp.FinalizeBlock(context.TODO(), blockExec.finalizeBlockRequest(state, block))
+}
+
+func (blockExec *BlockExecutor) finalizeBlockRequest(state State, block *types.Block) *abci.RequestFinalizeBlock {
+	return &abci.RequestFinalizeBlock{
+		Hash:               block.Hash(),
+		NextValidatorsHash: block.NextValidatorsHash,
+		ProposerAddress:    block.ProposerAddress,
+		Height:             block.Height,
+		Time:               block.Time,
+		DecidedLastCommit:  buildLastCommitInfoFromStore(block, blockExec.store, state.InitialHeight),
+		Misbehavior:        block.Evidence.Evidence.ToABCI(),
+		Txs:                block.Txs.ToSliceOfBytes(),
+	}
+}
+
 func (blockExec *BlockExecutor) ExtendVote(
 	ctx context.Context,
 	vote *types.Vote,
@@ -343,15 +578,24 @@ func (blockExec *BlockExecutor) ExtendVote(
 	if err != nil {
 		panic(fmt.Errorf("ExtendVote call failed: %w", err))
 	}
+	if state.ConsensusParams.ABCI.EncryptedTxsEnabled(vote.Height) {
+		return blockExec.extendVoteWithDecryptionShares(vote.Height, resp.VoteExtension), nil
+	}
 	return resp.VoteExtension, nil
 }
 
-func (blockExec *BlockExecutor) VerifyVoteExtension(ctx context.Context, vote *types.Vote) error {
+func (blockExec *BlockExecutor) VerifyVoteExtension(ctx context.Context, vote *types.Vote, state State) error {
+	// The decryption shares are verified by the proposer decrypting the txs:
+	// the other validators may not have the encrypted txs.
+	ext, err := appVoteExtension(state.ConsensusParams.ABCI, vote.Height, vote.Extension)
+	if err != nil {
+		return types.ErrInvalidVoteExtension
+	}
 	req := abci.RequestVerifyVoteExtension{
 		Hash:             vote.BlockID.Hash,
 		ValidatorAddress: vote.ValidatorAddress,
 		Height:           vote.Height,
-		VoteExtension:    vote.Extension,
+		VoteExtension:    ext,
 	}
 
 	resp, err := blockExec.proxyApp.VerifyVoteExtension(ctx, &req)
@@ -716,6 +960,20 @@ func fireEvents(
 	}
 }
 
+// ReplayBlockEvents publishes the events of the given committed block, built
+// from the response of the application to FinalizeBlock, in the order they
+// were published when the block was committed. The validator set updates are
+// not published again.
+func ReplayBlockEvents(
+	logger log.Logger,
+	eventBus types.BlockEventPublisher,
+	block *types.Block,
+	blockID types.BlockID,
+	abciResponse *abci.ResponseFinalizeBlock,
+) {
+	fireEvents(logger, eventBus, block, blockID, abciResponse, nil)
+}
+
 //----------------------------------------------------------------------------------------------------
 // Execute block without state. TODO: eliminate
 
@@ -0,0 +1,152 @@
+package state
+
+import (
+	"sync"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Number of heights whose execution report is retained by the
+// ExecutionReporter.
+const executionReportsRetained = 1000
+
+// Stores whose written bytes are reported in the execution reports.
+const (
+	ReportStoreBlockstore = "blockstore"
+	ReportStoreState      = "state"
+	ReportStoreTxIndex    = "tx_index"
+)
+
+// ExecutionReport reports the resources used to execute a block.
+type ExecutionReport struct {
+	Height int64
+	// Time spent executing the block, from FinalizeBlock to saving the new
+	// state.
+	ExecutionTime time.Duration
+	NumTxs        int
+	// Gas wanted and used by the transactions of the block, as returned by
+	// the application.
+	GasWanted int64
+	GasUsed   int64
+	// Number of events emitted by the application for the block and its
+	// transactions.
+	EventsEmitted int
+	// Number of keys added to the indices for the block and its transactions
+	// by the kv indexer.
+	IndexKeysAdded int
+	// Number of bytes of data written to each store for the block, excluding
+	// the keys and the database overhead.
+	BytesWritten map[string]int64
+}
+
+// ExecutionReporter retains the execution reports of the last executed
+// blocks, and exposes them as metrics.
+type ExecutionReporter struct {
+	metrics *Metrics
+
+	mtx     sync.Mutex
+	reports []ExecutionReport // ring buffer indexed by height
+	latest  int64
+}
+
+// NewExecutionReporter returns an ExecutionReporter updating the given
+// metrics.
+func NewExecutionReporter(metrics *Metrics) *ExecutionReporter {
+	return &ExecutionReporter{
+		metrics: metrics,
+		reports: make([]ExecutionReport, executionReportsRetained),
+	}
+}
+
+// Record retains the report and updates the metrics.
+func (r *ExecutionReporter) Record(report ExecutionReport) {
+	r.metrics.BlockExecutionTime.Set(report.ExecutionTime.Seconds())
+	r.metrics.BlockGasUsed.Set(float64(report.GasUsed))
+	r.metrics.BlockEventsEmitted.Set(float64(report.EventsEmitted))
+	r.metrics.BlockIndexKeysAdded.Set(float64(report.IndexKeysAdded))
+	for store, bytes := range report.BytesWritten {
+		r.metrics.BlockBytesWritten.With("store", store).Set(float64(bytes))
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.reports[report.Height%executionReportsRetained] = report
+	r.latest = report.Height
+}
+
+// Report returns the execution report of the block at the given height, or
+// of the latest executed block if height is 0. It returns false if the
+// report is not retained.
+func (r *ExecutionReporter) Report(height int64) (ExecutionReport, bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if height == 0 {
+		height = r.latest
+	}
+	report := r.reports[height%executionReportsRetained]
+	if height <= 0 || report.Height != height {
+		return ExecutionReport{}, false
+	}
+	return report, true
+}
+
+// newExecutionReport returns the execution report of the block, given the
+// response of the application and the new state.
+func newExecutionReport(
+	block *types.Block,
+	abciResponse *abci.ResponseFinalizeBlock,
+	state *State,
+	executionTime time.Duration,
+) ExecutionReport {
+	report := ExecutionReport{
+		Height:         block.Height,
+		ExecutionTime:  executionTime,
+		NumTxs:         len(block.Txs),
+		EventsEmitted:  len(abciResponse.Events),
+		IndexKeysAdded: 1 + countIndexedAttributes(abciResponse.Events),
+		BytesWritten: map[string]int64{
+			ReportStoreBlockstore: int64(block.Size()),
+			ReportStoreState:      int64(abciResponse.Size()),
+		},
+	}
+	if stateProto, err := state.ToProto(); err == nil {
+		report.BytesWritten[ReportStoreState] += int64(stateProto.Size())
+	}
+
+	for i, txResult := range abciResponse.TxResults {
+		report.GasWanted += txResult.GasWanted
+		report.GasUsed += txResult.GasUsed
+		report.EventsEmitted += len(txResult.Events)
+		// The kv indexer indexes every transaction by hash and height, and
+		// by its indexed event attributes.
+		report.IndexKeysAdded += 2 + countIndexedAttributes(txResult.Events)
+		result := abci.TxResult{
+			Height: block.Height,
+			Index:  uint32(i),
+			Tx:     block.Txs[i],
+			Result: *txResult,
+		}
+		report.BytesWritten[ReportStoreTxIndex] += int64(result.Size())
+	}
+	return report
+}
+
+// countIndexedAttributes returns the number of event attributes indexed by
+// the kv indexer.
+func countIndexedAttributes(events []abci.Event) int {
+	n := 0
+	for _, event := range events {
+		if len(event.Type) == 0 {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if len(attr.Key) > 0 && attr.GetIndex() {
+				n++
+			}
+		}
+	}
+	return n
+}
@@ -0,0 +1,30 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sm "github.com/cometbft/cometbft/state"
+)
+
+func TestExecutionReporter(t *testing.T) {
+	reporter := sm.NewExecutionReporter(sm.NopMetrics())
+	for h := int64(1); h <= sm.ExecutionReportsRetained+10; h++ {
+		reporter.Record(sm.ExecutionReport{Height: h, NumTxs: int(h)})
+	}
+
+	latest, ok := reporter.Report(0)
+	require.True(t, ok)
+	require.EqualValues(t, sm.ExecutionReportsRetained+10, latest.Height)
+
+	report, ok := reporter.Report(20)
+	require.True(t, ok)
+	require.Equal(t, 20, report.NumTxs)
+
+	// The oldest reports are not retained.
+	_, ok = reporter.Report(10)
+	require.False(t, ok)
+	_, ok = reporter.Report(sm.ExecutionReportsRetained + 11)
+	require.False(t, ok)
+}
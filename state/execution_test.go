@@ -3,9 +3,11 @@ package state_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	gogotypes "github.com/cosmos/gogoproto/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -13,6 +15,8 @@ import (
 	dbm "github.com/cometbft/cometbft-db"
 
 	abciclientmocks "github.com/cometbft/cometbft/abci/client/mocks"
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abciserver "github.com/cometbft/cometbft/abci/server"
 	abci "github.com/cometbft/cometbft/abci/types"
 	abcimocks "github.com/cometbft/cometbft/abci/types/mocks"
 	"github.com/cometbft/cometbft/crypto"
@@ -21,6 +25,7 @@ import (
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	"github.com/cometbft/cometbft/internal/test"
 	"github.com/cometbft/cometbft/libs/log"
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
 	mpmocks "github.com/cometbft/cometbft/mempool/mocks"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	cmtversion "github.com/cometbft/cometbft/proto/tendermint/version"
@@ -79,6 +84,370 @@ func TestApplyBlock(t *testing.T) {
 	assert.EqualValues(t, 1, state.Version.Consensus.App, "App version wasn't updated")
 }
 
+func TestApplyBlockExecutionReport(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	mp := &mpmocks.Mempool{}
+	mp.On("Lock").Return()
+	mp.On("Unlock").Return()
+	mp.On("FlushAppConn", mock.Anything).Return(nil)
+	mp.On("Update",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).Return(nil)
+	reporter := sm.NewExecutionReporter(sm.NopMetrics())
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mp, sm.EmptyEvidencePool{}, blockStore, sm.BlockExecutorWithExecutionReporter(reporter))
+
+	_, ok := reporter.Report(0)
+	require.False(t, ok)
+
+	block := makeBlock(state, 1, new(types.Commit))
+	bps, err := block.MakePartSet(testPartSize)
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+
+	_, err = blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+
+	report, ok := reporter.Report(0)
+	require.True(t, ok)
+	require.EqualValues(t, 1, report.Height)
+	require.Equal(t, len(block.Txs), report.NumTxs)
+	require.Positive(t, report.ExecutionTime)
+	// The block is indexed by height, and each tx by hash and height.
+	require.Equal(t, 1+2*len(block.Txs), report.IndexKeysAdded)
+	require.EqualValues(t, block.Size(), report.BytesWritten[sm.ReportStoreBlockstore])
+	require.Positive(t, report.BytesWritten[sm.ReportStoreState])
+	require.Positive(t, report.BytesWritten[sm.ReportStoreTxIndex])
+
+	_, ok = reporter.Report(2)
+	require.False(t, ok)
+}
+
+// finalizeRecordingApp records the hashes of the blocks passed to FinalizeBlock,
+// signaling each call on started, if set.
+type finalizeRecordingApp struct {
+	testApp
+	finalized [][]byte
+	started   chan struct{}
+}
+
+func (app *finalizeRecordingApp) FinalizeBlock(ctx context.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+	app.finalized = append(app.finalized, req.Hash)
+	if app.started != nil {
+		app.started <- struct{}{}
+	}
+	return app.testApp.FinalizeBlock(ctx, req)
+}
+
+func TestApplyBlockOptimisticExecution(t *testing.T) {
+	app := &finalizeRecordingApp{started: make(chan struct{}, 10)}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	mp := &mpmocks.Mempool{}
+	mp.On("Lock").Return()
+	mp.On("Unlock").Return()
+	mp.On("FlushAppConn", mock.Anything).Return(nil)
+	mp.On("Update",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).Return(nil)
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mp, sm.EmptyEvidencePool{}, blockStore, sm.BlockExecutorWithOptimisticExecution())
+
+	// The block executed optimistically is decided: it's executed once.
+	block := makeBlock(state, 1, new(types.Commit))
+	bps, err := block.MakePartSet(testPartSize)
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+
+	blockExec.ExecuteBlockOptimistically(state, block)
+	blockExec.ExecuteBlockOptimistically(state, block)
+	state, err = blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{block.Hash()}, app.finalized)
+	<-app.started
+
+	// Another block is decided: the optimistic execution is discarded and
+	// the decided block executed.
+	app.finalized = nil
+	extCommit, _, err := makeValidCommit(1, blockID, state.LastValidators, privVals)
+	require.NoError(t, err)
+	commit := extCommit.ToCommit()
+	other := state.MakeBlock(2, test.MakeNTxs(1, 3), commit, nil, state.Validators.GetProposer().Address)
+	block = makeBlock(state, 2, commit)
+	bps, err = block.MakePartSet(testPartSize)
+	require.NoError(t, err)
+	blockID = types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+	require.NotEqual(t, other.Hash(), block.Hash())
+
+	blockExec.ExecuteBlockOptimistically(state, other)
+	<-app.started
+	_, err = blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{other.Hash(), block.Hash()}, app.finalized)
+}
+
+func TestApplyBlockOptimisticExecutionRoundChange(t *testing.T) {
+	app := &finalizeRecordingApp{started: make(chan struct{}, 10)}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	mp := &mpmocks.Mempool{}
+	mp.On("Lock").Return()
+	mp.On("Unlock").Return()
+	mp.On("FlushAppConn", mock.Anything).Return(nil)
+	mp.On("Update",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).Return(nil)
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mp, sm.EmptyEvidencePool{}, blockStore, sm.BlockExecutorWithOptimisticExecution())
+
+	// The round changes after the block is executed optimistically, and the
+	// block is prevoted anew: it's executed again, after the first execution
+	// is discarded.
+	block := makeBlock(state, 1, new(types.Commit))
+	bps, err := block.MakePartSet(testPartSize)
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+
+	blockExec.ExecuteBlockOptimistically(state, block)
+	<-app.started
+	blockExec.DiscardOptimisticExecution()
+	blockExec.ExecuteBlockOptimistically(state, block)
+	state, err = blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{block.Hash(), block.Hash()}, app.finalized)
+	<-app.started
+
+	// The round changes after the block is executed optimistically, and
+	// another block is decided: the discarded execution is not used.
+	app.finalized = nil
+	extCommit, _, err := makeValidCommit(1, blockID, state.LastValidators, privVals)
+	require.NoError(t, err)
+	commit := extCommit.ToCommit()
+	other := state.MakeBlock(2, test.MakeNTxs(1, 3), commit, nil, state.Validators.GetProposer().Address)
+	block = makeBlock(state, 2, commit)
+	bps, err = block.MakePartSet(testPartSize)
+	require.NoError(t, err)
+	blockID = types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+
+	blockExec.ExecuteBlockOptimistically(state, other)
+	<-app.started
+	blockExec.DiscardOptimisticExecution()
+	// Discarding again is a no-op.
+	blockExec.DiscardOptimisticExecution()
+	_, err = blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{other.Hash(), block.Hash()}, app.finalized)
+}
+
+func TestApplyBlockOptimisticExecutionVoteExtensions(t *testing.T) {
+	app := &finalizeRecordingApp{started: make(chan struct{}, 10)}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	state.ConsensusParams.ABCI.VoteExtensionsEnableHeight = 1
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	mp := &mpmocks.Mempool{}
+	mp.On("Lock").Return()
+	mp.On("Unlock").Return()
+	mp.On("FlushAppConn", mock.Anything).Return(nil)
+	mp.On("Update",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).Return(nil)
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mp, sm.EmptyEvidencePool{}, blockStore, sm.BlockExecutorWithOptimisticExecution())
+
+	// The vote extensions of the height may still be requested after the
+	// node prevotes: the block is not executed optimistically.
+	other := state.MakeBlock(1, test.MakeNTxs(1, 3), new(types.Commit), nil, state.Validators.GetProposer().Address)
+	block := makeBlock(state, 1, new(types.Commit))
+	bps, err := block.MakePartSet(testPartSize)
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+	require.NotEqual(t, other.Hash(), block.Hash())
+
+	blockExec.ExecuteBlockOptimistically(state, other)
+	select {
+	case <-app.started:
+		t.Fatal("block executed optimistically with vote extensions enabled")
+	case <-time.After(100 * time.Millisecond):
+	}
+	_, err = blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{block.Hash()}, app.finalized)
+}
+
+func TestApplyBlockOptimisticExecutionDisabled(t *testing.T) {
+	app := &finalizeRecordingApp{started: make(chan struct{}, 10)}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	mp := &mpmocks.Mempool{}
+	mp.On("Lock").Return()
+	mp.On("Unlock").Return()
+	mp.On("FlushAppConn", mock.Anything).Return(nil)
+	mp.On("Update",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).Return(nil)
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mp, sm.EmptyEvidencePool{}, blockStore)
+
+	// The optimistic execution is not enabled: the block is executed once
+	// it's decided only.
+	block := makeBlock(state, 1, new(types.Commit))
+	bps, err := block.MakePartSet(testPartSize)
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+
+	blockExec.ExecuteBlockOptimistically(state, block)
+	select {
+	case <-app.started:
+		t.Fatal("block executed optimistically without the optimistic execution enabled")
+	case <-time.After(100 * time.Millisecond):
+	}
+	_, err = blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{block.Hash()}, app.finalized)
+}
+
+// TestApplyBlockOptimisticExecutionSocketApp ensures an application served
+// over a socket, which declares the optimistic execution, ends up with the
+// state of the decided block only when another block was executed
+// optimistically at the same height.
+func TestApplyBlockOptimisticExecutionSocketApp(t *testing.T) {
+	sockPath := fmt.Sprintf("unix:///tmp/optimistic_execution_%v.sock", cmtrand.Str(6))
+	server := abciserver.NewSocketServer(sockPath, kvstore.NewInMemoryApplication())
+	server.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	require.NoError(t, server.Start())
+	defer server.Stop() //nolint:errcheck // ignore for tests
+
+	proxyApp := proxy.NewAppConns(proxy.NewRemoteClientCreator(sockPath, "socket", true), proxy.NopMetrics())
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	info, err := proxyApp.Query().Info(context.Background(), proxy.RequestInfo)
+	require.NoError(t, err)
+	require.True(t, info.OptimisticExecution)
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	mp := &mpmocks.Mempool{}
+	mp.On("Lock").Return()
+	mp.On("Unlock").Return()
+	mp.On("FlushAppConn", mock.Anything).Return(nil)
+	mp.On("Update",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).Return(nil)
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mp, sm.EmptyEvidencePool{}, blockStore, sm.BlockExecutorWithOptimisticExecution())
+
+	proposer := state.Validators.GetProposer().Address
+	other := state.MakeBlock(1, types.Txs{types.Tx("a=1"), types.Tx("b=1")}, new(types.Commit), nil, proposer)
+	block := state.MakeBlock(1, types.Txs{types.Tx("c=2")}, new(types.Commit), nil, proposer)
+	bps, err := block.MakePartSet(testPartSize)
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+
+	// Wait for the application to execute the other block before the
+	// decided one.
+	blockExec.ExecuteBlockOptimistically(state, other)
+	require.Eventually(t, func() bool {
+		info, err := proxyApp.Query().Info(context.Background(), proxy.RequestInfo)
+		require.NoError(t, err)
+		return info.LastBlockHeight == 1
+	}, time.Second, 10*time.Millisecond)
+
+	state, err = blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+
+	info, err = proxyApp.Query().Info(context.Background(), proxy.RequestInfo)
+	require.NoError(t, err)
+	assert.Equal(t, `{"size":1}`, info.Data)
+	assert.EqualValues(t, 1, info.LastBlockHeight)
+	assert.Equal(t, state.AppHash, info.LastBlockAppHash)
+	for key, value := range map[string]string{"a": "", "b": "", "c": "2"} {
+		res, err := proxyApp.Query().Query(context.Background(), &abci.RequestQuery{Data: []byte(key)})
+		require.NoError(t, err)
+		assert.Equal(t, value, string(res.Value), "key %s", key)
+	}
+}
+
 // TestFinalizeBlockDecidedLastCommit ensures we correctly send the
 // DecidedLastCommit to the application. The test ensures that the
 // DecidedLastCommit properly reflects which validators signed the preceding
@@ -368,7 +737,10 @@ func TestProcessProposal(t *testing.T) {
 
 	logger := log.NewNopLogger()
 	app := &abcimocks.Application{}
-	app.On("ProcessProposal", mock.Anything, mock.Anything).Return(&abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}, nil)
+	app.On("ProcessProposal", mock.Anything, mock.Anything).Return(&abci.ResponseProcessProposal{
+		Status:    abci.ResponseProcessProposal_ACCEPT,
+		GasWanted: &gogotypes.Int64Value{Value: int64(len(txs))},
+	}, nil)
 
 	cc := proxy.NewLocalClientCreator(app)
 	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
@@ -445,6 +817,131 @@ func TestProcessProposal(t *testing.T) {
 	app.AssertCalled(t, "ProcessProposal", context.TODO(), expectedRpp)
 }
 
+func TestProcessProposalMaxGas(t *testing.T) {
+	const height = 1
+	txs := test.MakeNTxs(height, 10)
+
+	app := &abcimocks.Application{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, height)
+	state.ConsensusParams.Block.MaxGas = 10
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.NewNopLogger(),
+		proxyApp.Consensus(),
+		new(mpmocks.Mempool),
+		sm.EmptyEvidencePool{},
+		store.NewBlockStore(dbm.NewMemDB()),
+	)
+
+	block := makeBlock(state, height, new(types.Commit))
+	block.Txs = txs
+
+	// The gas of the block, as reported by the application, is within the
+	// max gas.
+	app.On("ProcessProposal", mock.Anything, mock.Anything).Return(&abci.ResponseProcessProposal{
+		Status:    abci.ResponseProcessProposal_ACCEPT,
+		GasWanted: &gogotypes.Int64Value{Value: 10},
+	}, nil).Once()
+	acceptBlock, err := blockExec.ProcessProposal(block, state)
+	require.NoError(t, err)
+	require.True(t, acceptBlock)
+
+	// The block exceeds the max gas: it's rejected even if the application
+	// accepts it.
+	app.On("ProcessProposal", mock.Anything, mock.Anything).Return(&abci.ResponseProcessProposal{
+		Status:    abci.ResponseProcessProposal_ACCEPT,
+		GasWanted: &gogotypes.Int64Value{Value: 11},
+	}, nil).Once()
+	acceptBlock, err = blockExec.ProcessProposal(block, state)
+	require.NoError(t, err)
+	require.False(t, acceptBlock)
+
+	// The application does not report the gas of the block: the max gas is
+	// not enforced.
+	app.On("ProcessProposal", mock.Anything, mock.Anything).Return(&abci.ResponseProcessProposal{
+		Status: abci.ResponseProcessProposal_ACCEPT,
+	}, nil).Once()
+	acceptBlock, err = blockExec.ProcessProposal(block, state)
+	require.NoError(t, err)
+	require.True(t, acceptBlock)
+
+	// A rejected block stays rejected whatever its gas.
+	app.On("ProcessProposal", mock.Anything, mock.Anything).Return(&abci.ResponseProcessProposal{
+		Status:    abci.ResponseProcessProposal_REJECT,
+		GasWanted: &gogotypes.Int64Value{Value: 10},
+	}, nil).Once()
+	acceptBlock, err = blockExec.ProcessProposal(block, state)
+	require.NoError(t, err)
+	require.False(t, acceptBlock)
+
+	// The gas is not checked when there is no max gas.
+	state.ConsensusParams.Block.MaxGas = -1
+	app.On("ProcessProposal", mock.Anything, mock.Anything).Return(&abci.ResponseProcessProposal{
+		Status:    abci.ResponseProcessProposal_ACCEPT,
+		GasWanted: &gogotypes.Int64Value{Value: 11},
+	}, nil).Once()
+	acceptBlock, err = blockExec.ProcessProposal(block, state)
+	require.NoError(t, err)
+	require.True(t, acceptBlock)
+	app.On("ProcessProposal", mock.Anything, mock.Anything).Return(&abci.ResponseProcessProposal{
+		Status: abci.ResponseProcessProposal_ACCEPT,
+	}, nil).Once()
+	acceptBlock, err = blockExec.ProcessProposal(block, state)
+	require.NoError(t, err)
+	require.True(t, acceptBlock)
+	app.AssertExpectations(t)
+}
+
+// TestProcessProposalMaxGasUpgrade checks that a chain setting the max gas
+// keeps accepting blocks with transactions once its nodes are upgraded, while
+// its application still predates the gas wanted of ResponseProcessProposal.
+func TestProcessProposalMaxGasUpgrade(t *testing.T) {
+	const height = 1
+
+	// The response of an application predating the gas wanted, accepting the
+	// proposal, as received from the wire.
+	resp := new(abci.ResponseProcessProposal)
+	require.NoError(t, resp.Unmarshal([]byte{0x08, byte(abci.ResponseProcessProposal_ACCEPT)}))
+	require.Nil(t, resp.GasWanted)
+
+	app := &abcimocks.Application{}
+	app.On("ProcessProposal", mock.Anything, mock.Anything).Return(resp, nil)
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, height)
+	state.ConsensusParams.Block.MaxGas = 1
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.NewNopLogger(),
+		proxyApp.Consensus(),
+		new(mpmocks.Mempool),
+		sm.EmptyEvidencePool{},
+		store.NewBlockStore(dbm.NewMemDB()),
+	)
+
+	block := makeBlock(state, height, new(types.Commit))
+	block.Txs = test.MakeNTxs(height, 10)
+	acceptBlock, err := blockExec.ProcessProposal(block, state)
+	require.NoError(t, err)
+	require.True(t, acceptBlock)
+}
+
 func TestValidateValidatorUpdates(t *testing.T) {
 	pubkey1 := ed25519.GenPrivKey().PubKey()
 	pubkey2 := ed25519.GenPrivKey().PubKey()
@@ -800,6 +1297,54 @@ func TestPrepareProposalTxsAllIncluded(t *testing.T) {
 	mp.AssertExpectations(t)
 }
 
+// TestCreateProposalBlockBlockTime tests that, under PBTS, the time of the
+// proposed block is taken from the block time of the block executor.
+func TestCreateProposalBlockBlockTime(t *testing.T) {
+	const height = 2
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	state, stateDB, privVals := makeState(1, height)
+	state.ConsensusParams.Synchrony.PBTSEnableHeight = state.InitialHeight
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+
+	evpool := &mocks.EvidencePool{}
+	evpool.On("PendingEvidence", mock.Anything).Return([]types.Evidence{}, int64(0))
+
+	mp := &mpmocks.Mempool{}
+	mp.On("ReapMaxBytesMaxGas", mock.Anything, mock.Anything).Return(types.Txs{})
+
+	app := &abcimocks.Application{}
+	app.On("PrepareProposal", mock.Anything, mock.Anything).Return(&abci.ResponsePrepareProposal{}, nil)
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	// The time of the blocks runs an hour ahead of the local clock.
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		mp,
+		evpool,
+		store.NewBlockStore(dbm.NewMemDB()),
+		sm.BlockExecutorWithBlockTime(func(t time.Time) time.Time { return t.Add(time.Hour) }),
+	)
+	pa, _ := state.Validators.GetByIndex(0)
+	commit, _, err := makeValidCommit(height, types.BlockID{}, state.Validators, privVals)
+	require.NoError(t, err)
+	start := cmttime.Now()
+	block, err := blockExec.CreateProposalBlock(ctx, height, state, commit, pa)
+	require.NoError(t, err)
+	assert.False(t, block.Time.Before(start.Add(time.Hour)))
+	assert.False(t, block.Time.After(cmttime.Now().Add(time.Hour)))
+	assert.Equal(t, start.Add(time.Hour), blockExec.BlockTime(start))
+}
+
 // TestPrepareProposalReorderTxs tests that CreateBlock produces a block with transactions
 // in the order matching the order they are returned from PrepareProposal.
 func TestPrepareProposalReorderTxs(t *testing.T) {
@@ -908,6 +1453,76 @@ func TestPrepareProposalErrorOnTooManyTxs(t *testing.T) {
 	mp.AssertExpectations(t)
 }
 
+// TestPrepareProposalErrorOnMaxGas tests that the block creation logic returns
+// an error if the gas of the txs returned by PrepareProposal exceeds the max gas.
+func TestPrepareProposalErrorOnMaxGas(t *testing.T) {
+	const height = 2
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	state, stateDB, privVals := makeState(1, height)
+	state.ConsensusParams.Block.MaxGas = 10
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+
+	evpool := &mocks.EvidencePool{}
+	evpool.On("PendingEvidence", mock.Anything).Return([]types.Evidence{}, int64(0))
+
+	txs := test.MakeNTxs(height, 10)
+	mp := &mpmocks.Mempool{}
+	mp.On("ReapMaxBytesMaxGas", mock.Anything, mock.Anything).Return(txs)
+
+	app := &abcimocks.Application{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.NewNopLogger(),
+		proxyApp.Consensus(),
+		mp,
+		evpool,
+		blockStore,
+	)
+	pa, _ := state.Validators.GetByIndex(0)
+	commit, _, err := makeValidCommit(height, types.BlockID{}, state.Validators, privVals)
+	require.NoError(t, err)
+
+	// The application adds a tx, exceeding the max gas.
+	app.On("PrepareProposal", mock.Anything, mock.Anything).Return(&abci.ResponsePrepareProposal{
+		Txs:       append(txs, types.Tx("added")).ToSliceOfBytes(),
+		GasWanted: &gogotypes.Int64Value{Value: 11},
+	}, nil).Once()
+	block, err := blockExec.CreateProposalBlock(ctx, height, state, commit, pa)
+	require.Nil(t, block)
+	require.ErrorContains(t, err, "exceeding the max gas of a block")
+
+	// The gas of the txs is within the max gas.
+	app.On("PrepareProposal", mock.Anything, mock.Anything).Return(&abci.ResponsePrepareProposal{
+		Txs:       txs.ToSliceOfBytes(),
+		GasWanted: &gogotypes.Int64Value{Value: 10},
+	}, nil).Once()
+	block, err = blockExec.CreateProposalBlock(ctx, height, state, commit, pa)
+	require.NoError(t, err)
+	require.Len(t, block.Txs, len(txs))
+
+	// The application does not report the gas of the txs: the max gas is not
+	// enforced.
+	app.On("PrepareProposal", mock.Anything, mock.Anything).Return(&abci.ResponsePrepareProposal{
+		Txs: append(txs, types.Tx("added")).ToSliceOfBytes(),
+	}, nil).Once()
+	block, err = blockExec.CreateProposalBlock(ctx, height, state, commit, pa)
+	require.NoError(t, err)
+	require.Len(t, block.Txs, len(txs)+1)
+
+	app.AssertExpectations(t)
+}
+
 // TestPrepareProposalErrorOnPrepareProposalError tests when the client returns an error
 // upon calling PrepareProposal on it.
 func TestPrepareProposalErrorOnPrepareProposalError(t *testing.T) {
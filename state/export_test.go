@@ -1,6 +1,8 @@
 package state
 
 import (
+	"time"
+
 	dbm "github.com/cometbft/cometbft-db"
 
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -18,7 +20,10 @@ import (
 // easily testable from outside of the package.
 //
 
-const ValSetCheckpointInterval = valSetCheckpointInterval
+const (
+	ValSetCheckpointInterval = valSetCheckpointInterval
+	ExecutionReportsRetained = executionReportsRetained
+)
 
 // UpdateState is an alias for updateState exported from execution.go,
 // exclusively and explicitly for testing.
@@ -71,3 +76,15 @@ func Int64ToBytes(val int64) []byte {
 func Int64FromBytes(val []byte) int64 {
 	return int64FromBytes(val)
 }
+
+// Sample is an alias for the private sample method in storage_forecast.go,
+// exported exclusively and explicitly for testing.
+func (f *StorageForecaster) Sample(now time.Time) {
+	f.sample(now)
+}
+
+// SetDiskSpaceFunc replaces the function used by the StorageForecaster to
+// measure the available disk space, exclusively and explicitly for testing.
+func (f *StorageForecaster) SetDiskSpaceFunc(fn func(path string) (free, total uint64, err error)) {
+	f.diskSpace = fn
+}
@@ -28,7 +28,11 @@ func IndexerFromConfig(cfg *config.Config, dbProvider config.DBProvider, chainID
 			return nil, nil, err
 		}
 
-		return kv.NewTxIndex(store), blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events"))), nil
+		var options []kv.TxIndexOption
+		if cfg.Storage.Compression.Compresses(config.CompressedStoreTxIndex) {
+			options = append(options, kv.WithCompression())
+		}
+		return kv.NewTxIndex(store, options...), blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events"))), nil
 
 	case "psql":
 		conn := cfg.TxIndex.PsqlConn
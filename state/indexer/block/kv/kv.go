@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"sort"
 	"strconv"
@@ -63,6 +64,70 @@ func (idx *BlockerIndexer) Has(height int64) (bool, error) {
 	return idx.store.Has(key)
 }
 
+// LatestHeight returns the highest indexed height, or 0 if no height is
+// indexed. It scans the whole height index and is meant for offline tooling.
+func (idx *BlockerIndexer) LatestHeight() (int64, error) {
+	prefix, err := orderedcode.Append(nil, types.BlockHeightKey)
+	if err != nil {
+		return 0, err
+	}
+	it, err := dbm.IteratePrefix(idx.store, prefix)
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+
+	var latest int64
+	for ; it.Valid(); it.Next() {
+		var (
+			blockHeightKeyPrefix string
+			height               int64
+		)
+		remaining, err := orderedcode.Parse(string(it.Key()), &blockHeightKeyPrefix, &height)
+		if err != nil || len(remaining) != 0 {
+			continue
+		}
+		if height > latest {
+			latest = height
+		}
+	}
+	return latest, it.Error()
+}
+
+// TruncateAbove removes the heights indexed above the given height, along
+// with their events. It returns the number of removed heights.
+func (idx *BlockerIndexer) TruncateAbove(height int64) (int64, error) {
+	it, err := idx.store.Iterator(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	var keys [][]byte
+	affectedHeights := make(map[int64]struct{})
+	for ; it.Valid(); it.Next() {
+		if keyBelongsToHeightRange(it.Key(), height+1, math.MaxInt64) {
+			keys = append(keys, it.Key())
+			affectedHeights[getHeightFromKey(it.Key())] = struct{}{}
+		}
+	}
+	if err := it.Error(); err != nil {
+		it.Close()
+		return 0, err
+	}
+	it.Close()
+
+	batch := idx.store.NewBatch()
+	defer batch.Close()
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return 0, err
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return 0, err
+	}
+	return int64(len(affectedHeights)), nil
+}
+
 // Index indexes FinalizeBlock events for a given block by its height.
 // The following is indexed:
 //
@@ -230,7 +295,7 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 	default:
 	}
 
-	conditions := q.Syntax()
+	conditions := q.Conditions()
 
 	// conditions to skip because they're handled before "everything else"
 	skipIndexes := make([]int, 0)
@@ -399,6 +464,7 @@ func (idx *BlockerIndexer) matchRange(
 	}
 
 	tmpHeights := make(map[string][]byte)
+	scanStats := indexer.ScanStatsFromContext(ctx)
 
 	it, err := dbm.IteratePrefix(idx.store, startKey)
 	if err != nil {
@@ -408,6 +474,7 @@ func (idx *BlockerIndexer) matchRange(
 
 LOOP:
 	for ; it.Valid(); it.Next() {
+		scanStats.AddKey()
 		var (
 			eventValue string
 			err        error
@@ -543,6 +610,7 @@ func (idx *BlockerIndexer) match(
 	}
 
 	tmpHeights := make(map[string][]byte)
+	scanStats := indexer.ScanStatsFromContext(ctx)
 
 	switch {
 	case c.Op == syntax.TEq:
@@ -553,6 +621,7 @@ func (idx *BlockerIndexer) match(
 		defer it.Close()
 
 		for ; it.Valid(); it.Next() {
+			scanStats.AddKey()
 
 			keyHeight, err := parseHeightFromEventKey(it.Key())
 			if err != nil {
@@ -592,6 +661,7 @@ func (idx *BlockerIndexer) match(
 		defer it.Close()
 
 		for ; it.Valid(); it.Next() {
+			scanStats.AddKey()
 
 			keyHeight, err := parseHeightFromEventKey(it.Key())
 			if err != nil {
@@ -634,6 +704,7 @@ func (idx *BlockerIndexer) match(
 		defer it.Close()
 
 		for ; it.Valid(); it.Next() {
+			scanStats.AddKey()
 			eventValue, err := parseValueFromEventKey(it.Key())
 			if err != nil {
 				continue
@@ -57,6 +57,43 @@ func TestBlockerIndexer_Prune(t *testing.T) {
 	require.True(t, emptyIntersection(keys1, keys3))
 }
 
+func TestBlockerIndexer_TruncateAbove(t *testing.T) {
+	store := db.NewPrefixDB(db.NewMemDB(), []byte("block_events"))
+	indexer := blockidxkv.New(store)
+
+	latest, err := indexer.LatestHeight()
+	require.NoError(t, err)
+	require.Zero(t, latest)
+
+	var keysAtHeight3 [][]byte
+	for height := int64(1); height <= 12; height++ {
+		require.NoError(t, indexer.Index(getEventsForTesting(height)))
+		if height == 3 {
+			keysAtHeight3 = blockidxkv.GetKeys(*indexer)
+		}
+	}
+	require.NoError(t, indexer.SetRetainHeight(2))
+
+	latest, err = indexer.LatestHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(12), latest)
+
+	removed, err := indexer.TruncateAbove(3)
+	require.NoError(t, err)
+	require.Equal(t, int64(9), removed)
+
+	latest, err = indexer.LatestHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), latest)
+
+	keys := blockidxkv.GetKeys(*indexer)
+	require.True(t, isEqualSets(keysAtHeight3, setDiff(keys, [][]byte{blockidxkv.BlockIndexerRetainHeightKey})))
+
+	retainHeight, err := indexer.GetRetainHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), retainHeight)
+}
+
 func BenchmarkBlockerIndexer_Prune(_ *testing.B) {
 	config := test.ResetTestRoot("block_indexer")
 	defer func() {
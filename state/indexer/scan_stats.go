@@ -0,0 +1,43 @@
+package indexer
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type scanStatsKey struct{}
+
+// ScanStats are the statistics of the searches of an indexer, e.g. to find
+// the queries scanning most of the index. They are safe for concurrent use.
+type ScanStats struct {
+	keys atomic.Int64
+}
+
+// ContextWithScanStats returns a context in which the searches of the
+// indexers add their statistics to the given ones.
+func ContextWithScanStats(ctx context.Context, stats *ScanStats) context.Context {
+	return context.WithValue(ctx, scanStatsKey{}, stats)
+}
+
+// ScanStatsFromContext returns the statistics of the searches in the context,
+// or nil if none.
+func ScanStatsFromContext(ctx context.Context) *ScanStats {
+	stats, _ := ctx.Value(scanStatsKey{}).(*ScanStats)
+	return stats
+}
+
+// AddKey counts a key scanned by a search. It does nothing if the statistics
+// are nil.
+func (s *ScanStats) AddKey() {
+	if s != nil {
+		s.keys.Add(1)
+	}
+}
+
+// KeysScanned returns the number of keys scanned by the searches.
+func (s *ScanStats) KeysScanned() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.keys.Load()
+}
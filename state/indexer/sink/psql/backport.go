@@ -73,6 +73,12 @@ func (BackportTxIndexer) Search(context.Context, *query.Query) ([]*abci.TxResult
 	return nil, errors.New("the TxIndexer.Search method is not supported")
 }
 
+// SearchPage is implemented to satisfy the TxIndexer interface, but it is not
+// supported by the psql event sink and reports an error for all inputs.
+func (BackportTxIndexer) SearchPage(context.Context, *query.Query, txindex.Pagination) (*txindex.Page, error) {
+	return nil, errors.New("the TxIndexer.SearchPage method is not supported")
+}
+
 func (BackportTxIndexer) SetLogger(log.Logger) {}
 
 // BlockIndexer returns a bridge that implements the CometBFT v0.34 block
@@ -88,6 +88,102 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "block_indexer_base_height",
 			Help:      "BlockIndexerBaseHeight shows the first height at which block indices are available",
 		}, labels).With(labelsAndValues...),
+		PrunerTargetRetainHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pruner_target_retain_height",
+			Help:      "PrunerTargetRetainHeight is the height below which the pruner is requested to prune the data of a component.",
+		}, append(labels, "component")).With(labelsAndValues...),
+		PrunerHeightsPruned: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pruner_heights_pruned",
+			Help:      "PrunerHeightsPruned is the number of heights pruned for a component during the last pruning run.",
+		}, append(labels, "component")).With(labelsAndValues...),
+		PrunerLag: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pruner_lag",
+			Help:      "PrunerLag is the number of heights the pruner has yet to prune to reach the target retain height of a component.",
+		}, append(labels, "component")).With(labelsAndValues...),
+		PrunerErrors: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pruner_errors",
+			Help:      "PrunerErrors is the number of pruning runs of a component that failed.",
+		}, append(labels, "component")).With(labelsAndValues...),
+		StoreSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "store_size",
+			Help:      "StoreSize is the size in bytes of the database of a store.",
+		}, append(labels, "store")).With(labelsAndValues...),
+		StoreGrowthRate: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "store_growth_rate",
+			Help:      "StoreGrowthRate is the growth rate in bytes per second of the database of a store over the storage forecast window.",
+		}, append(labels, "store")).With(labelsAndValues...),
+		StorageFreeBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "storage_free_bytes",
+			Help:      "StorageFreeBytes is the space available in bytes on the volume holding the databases.",
+		}, labels).With(labelsAndValues...),
+		StorageDaysUntilFull: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "storage_days_until_full",
+			Help:      "StorageDaysUntilFull is the number of days until the volume holding the databases is full at the current growth rate, or -1 if the databases are not growing.",
+		}, labels).With(labelsAndValues...),
+		StorageForecastWarnings: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "storage_forecast_warnings",
+			Help:      "StorageForecastWarnings is the number of times the volume holding the databases was projected to be full within the warning threshold.",
+		}, labels).With(labelsAndValues...),
+		OversizedTxEvents: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "oversized_tx_events",
+			Help:      "OversizedTxEvents is the number of tx events exceeding the maximum event size, by action taken on them: truncated, tx_data_dropped or dropped.",
+		}, append(labels, "action")).With(labelsAndValues...),
+		BlockExecutionTime: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_execution_time",
+			Help:      "BlockExecutionTime is the time in seconds spent executing the last block, from FinalizeBlock to saving the new state.",
+		}, labels).With(labelsAndValues...),
+		BlockGasUsed: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_gas_used",
+			Help:      "BlockGasUsed is the gas used by the transactions of the last block.",
+		}, labels).With(labelsAndValues...),
+		BlockEventsEmitted: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_events_emitted",
+			Help:      "BlockEventsEmitted is the number of events emitted by the application for the last block and its transactions.",
+		}, labels).With(labelsAndValues...),
+		BlockIndexKeysAdded: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_index_keys_added",
+			Help:      "BlockIndexKeysAdded is the number of keys added to the indices for the last block and its transactions by the kv indexer.",
+		}, labels).With(labelsAndValues...),
+		BlockBytesWritten: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_bytes_written",
+			Help:      "BlockBytesWritten is the number of bytes of data written to a store for the last block.",
+		}, append(labels, "store")).With(labelsAndValues...),
+		OptimisticExecutions: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "optimistic_executions",
+			Help:      "OptimisticExecutions is the number of optimistic executions of blocks, by result: used if the block was decided, discarded otherwise.",
+		}, append(labels, "result")).With(labelsAndValues...),
 	}
 }
 
@@ -105,5 +201,21 @@ func NopMetrics() *Metrics {
 		ABCIResultsBaseHeight:                  discard.NewGauge(),
 		TxIndexerBaseHeight:                    discard.NewGauge(),
 		BlockIndexerBaseHeight:                 discard.NewGauge(),
+		PrunerTargetRetainHeight:               discard.NewGauge(),
+		PrunerHeightsPruned:                    discard.NewGauge(),
+		PrunerLag:                              discard.NewGauge(),
+		PrunerErrors:                           discard.NewCounter(),
+		StoreSize:                              discard.NewGauge(),
+		StoreGrowthRate:                        discard.NewGauge(),
+		StorageFreeBytes:                       discard.NewGauge(),
+		StorageDaysUntilFull:                   discard.NewGauge(),
+		StorageForecastWarnings:                discard.NewCounter(),
+		OversizedTxEvents:                      discard.NewCounter(),
+		BlockExecutionTime:                     discard.NewGauge(),
+		BlockGasUsed:                           discard.NewGauge(),
+		BlockEventsEmitted:                     discard.NewGauge(),
+		BlockIndexKeysAdded:                    discard.NewGauge(),
+		BlockBytesWritten:                      discard.NewGauge(),
+		OptimisticExecutions:                   discard.NewCounter(),
 	}
 }
@@ -62,4 +62,67 @@ type Metrics struct {
 	// BlockIndexerBaseHeight shows the first height at which
 	// block indices are available
 	BlockIndexerBaseHeight metrics.Gauge
+
+	// PrunerTargetRetainHeight is the height below which the pruner is
+	// requested to prune the data of a component.
+	PrunerTargetRetainHeight metrics.Gauge `metrics_labels:"component"`
+
+	// PrunerHeightsPruned is the number of heights pruned for a component
+	// during the last pruning run.
+	PrunerHeightsPruned metrics.Gauge `metrics_labels:"component"`
+
+	// PrunerLag is the number of heights the pruner has yet to prune to
+	// reach the target retain height of a component.
+	PrunerLag metrics.Gauge `metrics_labels:"component"`
+
+	// PrunerErrors is the number of pruning runs of a component that failed.
+	PrunerErrors metrics.Counter `metrics_labels:"component"`
+
+	// StoreSize is the size in bytes of the database of a store.
+	StoreSize metrics.Gauge `metrics_labels:"store"`
+
+	// StoreGrowthRate is the growth rate in bytes per second of the database
+	// of a store over the storage forecast window.
+	StoreGrowthRate metrics.Gauge `metrics_labels:"store"`
+
+	// StorageFreeBytes is the space available in bytes on the volume holding
+	// the databases.
+	StorageFreeBytes metrics.Gauge
+
+	// StorageDaysUntilFull is the number of days until the volume holding the
+	// databases is full at the current growth rate, or -1 if the databases
+	// are not growing.
+	StorageDaysUntilFull metrics.Gauge
+
+	// StorageForecastWarnings is the number of times the volume holding the
+	// databases was projected to be full within the warning threshold.
+	StorageForecastWarnings metrics.Counter
+
+	// OversizedTxEvents is the number of tx events exceeding the maximum
+	// event size, by action taken on them: truncated, tx_data_dropped or
+	// dropped.
+	OversizedTxEvents metrics.Counter `metrics_labels:"action"`
+
+	// BlockExecutionTime is the time in seconds spent executing the last
+	// block, from FinalizeBlock to saving the new state.
+	BlockExecutionTime metrics.Gauge
+
+	// BlockGasUsed is the gas used by the transactions of the last block.
+	BlockGasUsed metrics.Gauge
+
+	// BlockEventsEmitted is the number of events emitted by the application
+	// for the last block and its transactions.
+	BlockEventsEmitted metrics.Gauge
+
+	// BlockIndexKeysAdded is the number of keys added to the indices for the
+	// last block and its transactions by the kv indexer.
+	BlockIndexKeysAdded metrics.Gauge
+
+	// BlockBytesWritten is the number of bytes of data written to a store
+	// for the last block.
+	BlockBytesWritten metrics.Gauge `metrics_labels:"store"`
+
+	// OptimisticExecutions is the number of optimistic executions of blocks,
+	// by result: used if the block was decided, discarded otherwise.
+	OptimisticExecutions metrics.Counter `metrics_labels:"result"`
 }
@@ -188,6 +188,37 @@ func (_m *Store) LoadConsensusParams(height int64) (types.ConsensusParams, error
 	return r0, r1
 }
 
+// LoadConsensusParamsInfo provides a mock function with given fields: height
+func (_m *Store) LoadConsensusParamsInfo(height int64) (types.ConsensusParams, int64, error) {
+	ret := _m.Called(height)
+
+	var r0 types.ConsensusParams
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(int64) (types.ConsensusParams, int64, error)); ok {
+		return rf(height)
+	}
+	if rf, ok := ret.Get(0).(func(int64) types.ConsensusParams); ok {
+		r0 = rf(height)
+	} else {
+		r0 = ret.Get(0).(types.ConsensusParams)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) int64); ok {
+		r1 = rf(height)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(int64) error); ok {
+		r2 = rf(height)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // LoadFinalizeBlockResponse provides a mock function with given fields: height
 func (_m *Store) LoadFinalizeBlockResponse(height int64) (*abcitypes.ResponseFinalizeBlock, error) {
 	ret := _m.Called(height)
@@ -3,6 +3,7 @@ package state
 import (
 	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cometbft/cometbft/config"
@@ -36,15 +37,74 @@ type Pruner struct {
 	blockIndexer indexer.BlockIndexer
 	txIndexer    txindex.TxIndexer
 	interval     time.Duration
+	keepRecent   PrunerKeepRecent
 	observer     PrunerObserver
 	metrics      *Metrics
+	// Are the pruning runs paused?
+	paused atomic.Bool
+
+	statusMtx sync.Mutex
+	status    map[string]PrunerComponentStatus
+}
+
+// Components of the node data pruned by the Pruner.
+const (
+	PrunerComponentBlocks       = "blocks"
+	PrunerComponentABCIResults  = "abci_results"
+	PrunerComponentTxIndexer    = "tx_indexer"
+	PrunerComponentBlockIndexer = "block_indexer"
+)
+
+// PrunerComponentStatus reports the progress of the pruning of one component
+// of the node data.
+type PrunerComponentStatus struct {
+	// Height below which data is requested to be pruned.
+	TargetRetainHeight int64
+	// Height below which data has been pruned.
+	RetainHeight int64
+	// Number of heights pruned during the last pruning run.
+	LastPruned uint64
+	// Time of the last pruning run.
+	LastRunTime time.Time
+	// Error that occurred during the last pruning run, if any.
+	LastError error
+}
+
+// Lag returns the number of heights that have yet to be pruned to reach the
+// target retain height.
+func (s PrunerComponentStatus) Lag() int64 {
+	if s.TargetRetainHeight <= s.RetainHeight {
+		return 0
+	}
+	return s.TargetRetainHeight - s.RetainHeight
+}
+
+// PrunerStatus reports the progress of the Pruner.
+type PrunerStatus struct {
+	Interval             time.Duration
+	DataCompanionEnabled bool
+	Paused               bool
+	// Status of each component that has been through at least one pruning
+	// run, indexed by component.
+	Components map[string]PrunerComponentStatus
+}
+
+// PrunerKeepRecent is the number of most recent heights the Pruner keeps for
+// each type of data, 0 meaning that the type of data is only pruned to the
+// retain heights set by the application and the data companion.
+type PrunerKeepRecent struct {
+	Blocks        int64
+	ABCIResponses int64
+	TxIndex       int64
+	BlockIndex    int64
 }
 
 type prunerConfig struct {
-	dcEnabled bool
-	interval  time.Duration
-	observer  PrunerObserver
-	metrics   *Metrics
+	dcEnabled  bool
+	interval   time.Duration
+	keepRecent PrunerKeepRecent
+	observer   PrunerObserver
+	metrics    *Metrics
 }
 
 func defaultPrunerConfig() *prunerConfig {
@@ -74,6 +134,12 @@ func WithPrunerInterval(t time.Duration) PrunerOption {
 	return func(p *prunerConfig) { p.interval = t }
 }
 
+// WithPrunerKeepRecent sets the number of most recent heights to keep for each
+// type of data, which the pruner prunes independently of each other.
+func WithPrunerKeepRecent(keepRecent PrunerKeepRecent) PrunerOption {
+	return func(p *prunerConfig) { p.keepRecent = keepRecent }
+}
+
 func WithPrunerObserver(obs PrunerObserver) PrunerOption {
 	return func(p *prunerConfig) { p.observer = obs }
 }
@@ -107,9 +173,11 @@ func NewPruner(
 		stateStore:   stateStore,
 		logger:       logger,
 		interval:     cfg.interval,
+		keepRecent:   cfg.keepRecent,
 		observer:     cfg.observer,
 		metrics:      cfg.metrics,
 		dcEnabled:    cfg.dcEnabled,
+		status:       make(map[string]PrunerComponentStatus),
 	}
 	p.BaseService = *service.NewBaseService(logger, "Pruner", p)
 	return p
@@ -121,10 +189,12 @@ func (p *Pruner) SetObserver(obs PrunerObserver) {
 
 func (p *Pruner) OnStart() error {
 	go p.pruneBlocks()
-	// We only care about pruning ABCI results if the data companion has been
-	// enabled.
-	if p.dcEnabled {
+	// We only care about pruning ABCI results and indexes if the data companion
+	// has been enabled, or if they must be pruned to a number of recent heights.
+	if p.dcEnabled || p.keepRecent.ABCIResponses > 0 {
 		go p.pruneABCIResponses()
+	}
+	if p.dcEnabled || p.keepRecent.TxIndex > 0 || p.keepRecent.BlockIndex > 0 {
 		go p.pruneIndexesRoutine()
 	}
 	p.observer.PrunerStarted(p.interval)
@@ -303,6 +373,68 @@ func (p *Pruner) GetBlockIndexerRetainHeight() (int64, error) {
 	return p.blockIndexer.GetRetainHeight()
 }
 
+// Status returns the progress of the pruning of each component of the node
+// data.
+func (p *Pruner) Status() PrunerStatus {
+	p.statusMtx.Lock()
+	defer p.statusMtx.Unlock()
+
+	components := make(map[string]PrunerComponentStatus, len(p.status))
+	for component, status := range p.status {
+		components[component] = status
+	}
+	return PrunerStatus{
+		Interval:             p.interval,
+		DataCompanionEnabled: p.dcEnabled,
+		Paused:               p.Paused(),
+		Components:           components,
+	}
+}
+
+// Pause pauses the pruning runs until Resume is called. A run in progress is
+// completed, and the retain heights can still be set while the pruner is
+// paused.
+func (p *Pruner) Pause() {
+	if !p.paused.Swap(true) {
+		p.logger.Info("Pruning paused")
+	}
+}
+
+// Resume resumes the pruning runs paused by Pause.
+func (p *Pruner) Resume() {
+	if p.paused.Swap(false) {
+		p.logger.Info("Pruning resumed")
+	}
+}
+
+// Paused returns true if the pruning runs are paused.
+func (p *Pruner) Paused() bool {
+	return p.paused.Load()
+}
+
+// recordRun records the outcome of a pruning run of the given component, and
+// updates the corresponding metrics.
+func (p *Pruner) recordRun(component string, targetRetainHeight, retainHeight int64, pruned uint64, err error) {
+	status := PrunerComponentStatus{
+		TargetRetainHeight: targetRetainHeight,
+		RetainHeight:       retainHeight,
+		LastPruned:         pruned,
+		LastRunTime:        time.Now(),
+		LastError:          err,
+	}
+
+	p.statusMtx.Lock()
+	p.status[component] = status
+	p.statusMtx.Unlock()
+
+	p.metrics.PrunerTargetRetainHeight.With("component", component).Set(float64(targetRetainHeight))
+	p.metrics.PrunerHeightsPruned.With("component", component).Set(float64(pruned))
+	p.metrics.PrunerLag.With("component", component).Set(float64(status.Lag()))
+	if err != nil {
+		p.metrics.PrunerErrors.With("component", component).Add(1)
+	}
+}
+
 func (p *Pruner) pruneABCIResponses() {
 	p.logger.Info("Started pruning ABCI responses", "interval", p.interval.String())
 	lastRetainHeight := int64(0)
@@ -311,6 +443,10 @@ func (p *Pruner) pruneABCIResponses() {
 		case <-p.Quit():
 			return
 		default:
+			if p.Paused() {
+				time.Sleep(p.interval)
+				continue
+			}
 			newRetainHeight := p.pruneABCIResToRetainHeight(lastRetainHeight)
 			if newRetainHeight != lastRetainHeight {
 				p.observer.PrunerPrunedABCIRes(&ABCIResponsesPrunedInfo{
@@ -332,6 +468,10 @@ func (p *Pruner) pruneBlocks() {
 		case <-p.Quit():
 			return
 		default:
+			if p.Paused() {
+				time.Sleep(p.interval)
+				continue
+			}
 			newRetainHeight := p.pruneBlocksToRetainHeight(lastRetainHeight)
 			if newRetainHeight != lastRetainHeight {
 				p.observer.PrunerPrunedBlocks(&BlocksPrunedInfo{
@@ -354,8 +494,16 @@ func (p *Pruner) pruneIndexesRoutine() {
 		case <-p.Quit():
 			return
 		default:
-			lastTxIndexerRetainHeight = p.pruneTxIndexerToRetainHeight(lastTxIndexerRetainHeight)
-			lastBlockIndexerRetainHeight = p.pruneBlockIndexerToRetainHeight(lastBlockIndexerRetainHeight)
+			if p.Paused() {
+				time.Sleep(p.interval)
+				continue
+			}
+			if p.dcEnabled || p.keepRecent.TxIndex > 0 {
+				lastTxIndexerRetainHeight = p.pruneTxIndexerToRetainHeight(lastTxIndexerRetainHeight)
+			}
+			if p.dcEnabled || p.keepRecent.BlockIndex > 0 {
+				lastBlockIndexerRetainHeight = p.pruneBlockIndexerToRetainHeight(lastBlockIndexerRetainHeight)
+			}
 			// TODO call observer
 			time.Sleep(p.interval)
 		}
@@ -363,7 +511,7 @@ func (p *Pruner) pruneIndexesRoutine() {
 }
 
 func (p *Pruner) pruneTxIndexerToRetainHeight(lastRetainHeight int64) int64 {
-	targetRetainHeight, err := p.GetTxIndexerRetainHeight()
+	targetRetainHeight, err := p.findRetainHeight(p.keepRecent.TxIndex, p.GetTxIndexerRetainHeight)
 	if err != nil {
 		// Indexer retain height has not yet been set - do not log any
 		// errors at this time.
@@ -375,6 +523,7 @@ func (p *Pruner) pruneTxIndexerToRetainHeight(lastRetainHeight int64) int64 {
 	}
 
 	if lastRetainHeight >= targetRetainHeight {
+		p.recordRun(PrunerComponentTxIndexer, targetRetainHeight, lastRetainHeight, 0, nil)
 		return lastRetainHeight
 	}
 
@@ -385,11 +534,12 @@ func (p *Pruner) pruneTxIndexerToRetainHeight(lastRetainHeight int64) int64 {
 		p.metrics.TxIndexerBaseHeight.Set(float64(newTxIndexerRetainHeight))
 		p.logger.Debug("Pruned tx indexer", "count", numPrunedTxIndexer, "newTxIndexerRetainHeight", newTxIndexerRetainHeight)
 	}
+	p.recordRun(PrunerComponentTxIndexer, targetRetainHeight, newTxIndexerRetainHeight, uint64(numPrunedTxIndexer), err)
 	return newTxIndexerRetainHeight
 }
 
 func (p *Pruner) pruneBlockIndexerToRetainHeight(lastRetainHeight int64) int64 {
-	targetRetainHeight, err := p.GetBlockIndexerRetainHeight()
+	targetRetainHeight, err := p.findRetainHeight(p.keepRecent.BlockIndex, p.GetBlockIndexerRetainHeight)
 	if err != nil {
 		// Indexer retain height has not yet been set - do not log any
 		// errors at this time.
@@ -401,6 +551,7 @@ func (p *Pruner) pruneBlockIndexerToRetainHeight(lastRetainHeight int64) int64 {
 	}
 
 	if lastRetainHeight >= targetRetainHeight {
+		p.recordRun(PrunerComponentBlockIndexer, targetRetainHeight, lastRetainHeight, 0, nil)
 		return lastRetainHeight
 	}
 
@@ -411,12 +562,14 @@ func (p *Pruner) pruneBlockIndexerToRetainHeight(lastRetainHeight int64) int64 {
 		p.metrics.BlockIndexerBaseHeight.Set(float64(newBlockIndexerRetainHeight))
 		p.logger.Debug("Pruned block indexer", "count", numPrunedBlockIndexer, "newBlockIndexerRetainHeight", newBlockIndexerRetainHeight)
 	}
+	p.recordRun(PrunerComponentBlockIndexer, targetRetainHeight, newBlockIndexerRetainHeight, uint64(numPrunedBlockIndexer), err)
 	return newBlockIndexerRetainHeight
 }
 
 func (p *Pruner) pruneBlocksToRetainHeight(lastRetainHeight int64) int64 {
 	targetRetainHeight := p.findMinBlockRetainHeight()
 	if targetRetainHeight == lastRetainHeight {
+		p.recordRun(PrunerComponentBlocks, targetRetainHeight, p.bs.Base(), 0, nil)
 		return lastRetainHeight
 	}
 	pruned, evRetainHeight, err := p.pruneBlocksToHeight(targetRetainHeight)
@@ -429,11 +582,12 @@ func (p *Pruner) pruneBlocksToRetainHeight(lastRetainHeight int64) int64 {
 		p.metrics.BlockStoreBaseHeight.Set(float64(newRetainHeight))
 		p.logger.Debug("Pruned blocks", "count", pruned, "evidenceRetainHeight", evRetainHeight, "newRetainHeight", newRetainHeight)
 	}
+	p.recordRun(PrunerComponentBlocks, targetRetainHeight, newRetainHeight, pruned, err)
 	return newRetainHeight
 }
 
 func (p *Pruner) pruneABCIResToRetainHeight(lastRetainHeight int64) int64 {
-	targetRetainHeight, err := p.stateStore.GetABCIResRetainHeight()
+	targetRetainHeight, err := p.findRetainHeight(p.keepRecent.ABCIResponses, p.stateStore.GetABCIResRetainHeight)
 	if err != nil {
 		p.logger.Error("Failed to get ABCI response retain height", "err", err)
 		if errors.Is(err, ErrKeyNotFound) {
@@ -443,6 +597,7 @@ func (p *Pruner) pruneABCIResToRetainHeight(lastRetainHeight int64) int64 {
 	}
 
 	if lastRetainHeight == targetRetainHeight {
+		p.recordRun(PrunerComponentABCIResults, targetRetainHeight, lastRetainHeight, 0, nil)
 		return lastRetainHeight
 	}
 
@@ -452,12 +607,14 @@ func (p *Pruner) pruneABCIResToRetainHeight(lastRetainHeight int64) int64 {
 	numPruned, newRetainHeight, err := p.stateStore.PruneABCIResponses(targetRetainHeight)
 	if err != nil {
 		p.logger.Error("Failed to prune ABCI responses", "err", err, "targetRetainHeight", targetRetainHeight)
+		p.recordRun(PrunerComponentABCIResults, targetRetainHeight, lastRetainHeight, 0, err)
 		return lastRetainHeight
 	}
 	if numPruned > 0 {
 		p.logger.Info("Pruned ABCI responses", "heights", numPruned, "newRetainHeight", newRetainHeight)
 		p.metrics.ABCIResultsBaseHeight.Set(float64(newRetainHeight))
 	}
+	p.recordRun(PrunerComponentABCIResults, targetRetainHeight, newRetainHeight, uint64(numPruned), nil)
 	return newRetainHeight
 }
 
@@ -467,10 +624,16 @@ func (p *Pruner) findMinBlockRetainHeight() int64 {
 		p.logger.Error("Unexpected error fetching application retain height", "err", err)
 		return 0
 	}
+	// Both the application and the node operator may request blocks to be
+	// pruned.
+	retainHeight := appRetainHeight
+	if keepRecentRetainHeight := p.keepRecentRetainHeight(p.keepRecent.Blocks); keepRecentRetainHeight > retainHeight {
+		retainHeight = keepRecentRetainHeight
+	}
 	// We only care about the companion retain height if pruning is configured
 	// to respect the companion's retain height.
 	if !p.dcEnabled {
-		return appRetainHeight
+		return retainHeight
 	}
 	dcRetainHeight, err := p.stateStore.GetCompanionBlockRetainHeight()
 	if err != nil {
@@ -479,12 +642,46 @@ func (p *Pruner) findMinBlockRetainHeight() int64 {
 	}
 	// If we are here, both heights were set and the companion is enabled, so
 	// we pick the minimum.
-	if appRetainHeight < dcRetainHeight {
-		return appRetainHeight
+	if retainHeight < dcRetainHeight {
+		return retainHeight
 	}
 	return dcRetainHeight
 }
 
+// findRetainHeight returns the height below which a type of data that is
+// pruned by the data companion, or to its number of most recent heights to
+// keep, must be pruned. If both apply, the data companion retain height,
+// returned by getCompanionRetainHeight, caps the pruning.
+func (p *Pruner) findRetainHeight(keepRecent int64, getCompanionRetainHeight func() (int64, error)) (int64, error) {
+	if keepRecent == 0 {
+		return getCompanionRetainHeight()
+	}
+	retainHeight := p.keepRecentRetainHeight(keepRecent)
+	if !p.dcEnabled {
+		return retainHeight, nil
+	}
+	dcRetainHeight, err := getCompanionRetainHeight()
+	if err != nil {
+		return 0, err
+	}
+	if dcRetainHeight < retainHeight {
+		return dcRetainHeight, nil
+	}
+	return retainHeight, nil
+}
+
+// keepRecentRetainHeight returns the height below which data must be pruned
+// to keep the given number of most recent heights, or 0 if it is not pruned.
+func (p *Pruner) keepRecentRetainHeight(keepRecent int64) int64 {
+	if keepRecent == 0 {
+		return 0
+	}
+	if retainHeight := p.bs.Height() - keepRecent + 1; retainHeight > 1 {
+		return retainHeight
+	}
+	return 0
+}
+
 func (p *Pruner) pruneBlocksToHeight(height int64) (uint64, int64, error) {
 	if height <= 0 {
 		return 0, 0, ErrInvalidRetainHeight
@@ -220,3 +220,63 @@ func getEventsAndResults(height int64) (types.EventDataNewBlockEvents, *abci.TxR
 	}
 	return events, txResult1, txResult2
 }
+
+func TestPrunerStatus(t *testing.T) {
+	pruner, txIndexer, _, _ := createTestSetup(t)
+
+	status := pruner.Status()
+	require.Empty(t, status.Components)
+	require.False(t, status.DataCompanionEnabled)
+
+	for height := int64(1); height <= 4; height++ {
+		_, txResult1, txResult2 := getEventsAndResults(height)
+		err := txIndexer.Index(txResult1)
+		require.NoError(t, err)
+		err = txIndexer.Index(txResult2)
+		require.NoError(t, err)
+	}
+
+	err := pruner.SetTxIndexerRetainHeight(3)
+	require.NoError(t, err)
+	newRetainHeight := pruner.PruneTxIndexerToRetainHeight(0)
+	require.Equal(t, int64(3), newRetainHeight)
+
+	status = pruner.Status()
+	require.Len(t, status.Components, 1)
+	txStatus, ok := status.Components[sm.PrunerComponentTxIndexer]
+	require.True(t, ok)
+	require.Equal(t, int64(3), txStatus.TargetRetainHeight)
+	require.Equal(t, int64(3), txStatus.RetainHeight)
+	require.Greater(t, txStatus.LastPruned, uint64(0))
+	require.Zero(t, txStatus.Lag())
+	require.NoError(t, txStatus.LastError)
+	require.False(t, txStatus.LastRunTime.IsZero())
+
+	// Nothing left to prune.
+	pruner.PruneTxIndexerToRetainHeight(newRetainHeight)
+	txStatus = pruner.Status().Components[sm.PrunerComponentTxIndexer]
+	require.Equal(t, int64(3), txStatus.RetainHeight)
+	require.Zero(t, txStatus.LastPruned)
+}
+
+func TestPrunerPauseResume(t *testing.T) {
+	pruner, _, _, _ := createTestSetup(t)
+
+	require.False(t, pruner.Paused())
+	pruner.Pause()
+	require.True(t, pruner.Paused())
+	require.True(t, pruner.Status().Paused)
+
+	// Retain heights can still be set while paused.
+	require.NoError(t, pruner.SetTxIndexerRetainHeight(2))
+
+	pruner.Resume()
+	require.False(t, pruner.Paused())
+	require.False(t, pruner.Status().Paused)
+}
+
+func TestPrunerComponentStatusLag(t *testing.T) {
+	require.Equal(t, int64(5), sm.PrunerComponentStatus{TargetRetainHeight: 10, RetainHeight: 5}.Lag())
+	require.Zero(t, sm.PrunerComponentStatus{TargetRetainHeight: 10, RetainHeight: 10}.Lag())
+	require.Zero(t, sm.PrunerComponentStatus{TargetRetainHeight: 0, RetainHeight: 10}.Lag())
+}
@@ -0,0 +1,71 @@
+/*
+  This file defines the database schema for the PostgresQL ("psql") state store
+  implementation in CometBFT. The operator must create a database and install
+  this schema before using the database to store the state.
+
+  The tables do not clash with those of the psql event sink, so that both can
+  be installed in the same database.
+ */
+
+-- The state_records table records the latest state of the chain and the
+-- response to the latest block, along with the height they were saved at.
+CREATE TABLE state_records (
+  name   VARCHAR PRIMARY KEY,
+  height BIGINT NOT NULL,
+  -- The protobuf wire encoding of the record.
+  data   BYTEA NOT NULL
+);
+
+-- The state_heights table records the retain heights set by the application
+-- and the data companion, and other heights the state store keeps track of.
+CREATE TABLE state_heights (
+  name   VARCHAR PRIMARY KEY,
+  height BIGINT NOT NULL
+);
+
+-- The validator_sets table records the validator set of each height.
+-- The full set is only stored at the heights it changed, and at regular
+-- checkpoints. At the other heights, it is derived from the latest full set
+-- stored at or after the height it last changed.
+CREATE TABLE validator_sets (
+  height              BIGINT PRIMARY KEY,
+  last_height_changed BIGINT NOT NULL,
+  -- The protobuf wire encoding of the ValidatorSet message, or NULL.
+  validator_set       BYTEA NULL
+);
+
+-- The validators table records the validators of each full validator set
+-- stored in validator_sets.
+CREATE TABLE validators (
+  height            BIGINT NOT NULL REFERENCES validator_sets(height) ON DELETE CASCADE,
+  -- The hex-encoded address of the validator.
+  address           VARCHAR NOT NULL,
+  voting_power      BIGINT NOT NULL,
+  proposer_priority BIGINT NOT NULL,
+
+  UNIQUE (height, address)
+);
+
+-- Index validators by address, to look up the heights a validator was part of
+-- the validator set.
+CREATE INDEX idx_validators_address ON validators(address);
+
+-- The consensus_params table records the consensus params of each height.
+-- Like validator sets, the params are only stored at the heights they changed.
+CREATE TABLE consensus_params (
+  height              BIGINT PRIMARY KEY,
+  last_height_changed BIGINT NOT NULL,
+  -- The protobuf wire encoding of the ConsensusParams message, or NULL.
+  consensus_params    BYTEA NULL
+);
+
+-- The finalize_block_responses table records the response of the application
+-- to each block.
+CREATE TABLE finalize_block_responses (
+  height   BIGINT PRIMARY KEY,
+  -- The hex-encoded app hash returned by the application.
+  app_hash VARCHAR NOT NULL,
+  num_txs  INTEGER NOT NULL,
+  -- The protobuf wire encoding of the ResponseFinalizeBlock message.
+  response BYTEA NOT NULL
+);
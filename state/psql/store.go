@@ -0,0 +1,697 @@
+// Package psql implements a state store backed by a PostgreSQL database.
+package psql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	// BackendName is the name of the state store backend, to be used in the
+	// configuration of the node.
+	BackendName = "psql"
+
+	driverName = "postgres"
+
+	// persist validators every valSetCheckpointInterval blocks to avoid
+	// LoadValidators taking too much time, as the default state store does.
+	valSetCheckpointInterval = 100000
+)
+
+// Names of the records in the state_records table.
+const (
+	recordState                     = "state"
+	recordLastFinalizeBlockResponse = "last_finalize_block_response"
+)
+
+// Names of the heights in the state_heights table.
+const (
+	heightAppRetain               = "app_retain_height"
+	heightCompanionBlockRetain    = "companion_block_retain_height"
+	heightABCIResultsRetain       = "abci_results_retain_height"
+	heightLastABCIResponsesRetain = "last_abci_responses_retain_height"
+	heightOfflineStateSync        = "offline_state_sync_height"
+)
+
+func init() {
+	sm.RegisterStoreBackend(BackendName, func(_ dbm.DB, options sm.StoreOptions) (sm.Store, error) {
+		if options.BackendConn == "" {
+			return nil, errors.New("the psql connection settings cannot be empty")
+		}
+		return NewStore(options.BackendConn, options)
+	})
+}
+
+// Store is a state store keeping the state in a PostgreSQL database, using
+// the schema defined in state/psql/schema.sql.
+//
+// The checksums of the records are not verified, as the integrity of the data
+// is left to the database.
+type Store struct {
+	db *sql.DB
+
+	discardABCIResponses bool
+}
+
+var _ sm.Store = (*Store)(nil)
+
+// NewStore constructs a state store associated with the PostgreSQL database
+// specified by connStr.
+func NewStore(connStr string, options sm.StoreOptions) (*Store, error) {
+	db, err := sql.Open(driverName, connStr)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{
+		db:                   db,
+		discardABCIResponses: options.DiscardABCIResponses,
+	}, nil
+}
+
+// DB returns the underlying Postgres connection used by the store.
+// This is exported to support testing.
+func (s *Store) DB() *sql.DB { return s.db }
+
+// queryer is implemented by both database connections and transactions.
+type queryer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// runInTransaction executes query in a fresh database transaction.
+// If query reports an error, the transaction is rolled back and the
+// error from query is reported to the caller.
+// Otherwise, the result of committing the transaction is returned.
+func runInTransaction(db *sql.DB, query func(*sql.Tx) error) error {
+	dbtx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := query(dbtx); err != nil {
+		_ = dbtx.Rollback() // report the initial error, not the rollback
+		return err
+	}
+	return dbtx.Commit()
+}
+
+// LoadFromDBOrGenesisFile loads the most recent state from the database, or
+// creates a new one from the given genesisFilePath.
+func (s *Store) LoadFromDBOrGenesisFile(genesisFilePath string) (sm.State, error) {
+	state, err := s.Load()
+	if err != nil {
+		return sm.State{}, err
+	}
+	if state.IsEmpty() {
+		return sm.MakeGenesisStateFromFile(genesisFilePath)
+	}
+	return state, nil
+}
+
+// LoadFromDBOrGenesisDoc loads the most recent state from the database, or
+// creates a new one from the given genesisDoc.
+func (s *Store) LoadFromDBOrGenesisDoc(genesisDoc *types.GenesisDoc) (sm.State, error) {
+	state, err := s.Load()
+	if err != nil {
+		return sm.State{}, err
+	}
+	if state.IsEmpty() {
+		return sm.MakeGenesisState(genesisDoc)
+	}
+	return state, nil
+}
+
+// Load loads the latest state from the database, or an empty state if none
+// was saved.
+func (s *Store) Load() (sm.State, error) {
+	_, bz, err := getRecord(s.db, recordState)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sm.State{}, nil
+	}
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	sp := new(cmtstate.State)
+	if err := proto.Unmarshal(bz, sp); err != nil {
+		return sm.State{}, sm.ErrCorruptedRecord{Key: recordState, Err: err}
+	}
+	state, err := sm.FromProto(sp)
+	if err != nil {
+		return sm.State{}, err
+	}
+	return *state, nil
+}
+
+// Save persists the state, along with the validator sets and consensus params
+// of the next heights.
+func (s *Store) Save(state sm.State) error {
+	return runInTransaction(s.db, func(tx *sql.Tx) error {
+		nextHeight := state.LastBlockHeight + 1
+		// If first block, save validators for the block.
+		if nextHeight == 1 {
+			nextHeight = state.InitialHeight
+			// This extra logic due to validator set changes being delayed 1 block.
+			// It may get overwritten due to InitChain validator updates.
+			if err := saveValidatorsInfo(tx, nextHeight, nextHeight, state.Validators); err != nil {
+				return err
+			}
+		}
+		// Save next validators.
+		if err := saveValidatorsInfo(tx, nextHeight+1, state.LastHeightValidatorsChanged, state.NextValidators); err != nil {
+			return err
+		}
+		// Save next consensus params.
+		if err := saveConsensusParamsInfo(tx, nextHeight,
+			state.LastHeightConsensusParamsChanged, state.ConsensusParams); err != nil {
+			return err
+		}
+		return setRecord(tx, recordState, state.LastBlockHeight, state.Bytes())
+	})
+}
+
+// Bootstrap saves a new state, used e.g. by state sync when starting from
+// non-zero height.
+func (s *Store) Bootstrap(state sm.State) error {
+	return runInTransaction(s.db, func(tx *sql.Tx) error {
+		height := state.LastBlockHeight + 1
+		if height == 1 {
+			height = state.InitialHeight
+		}
+
+		if height > 1 && !state.LastValidators.IsNilOrEmpty() {
+			if err := saveValidatorsInfo(tx, height-1, height-1, state.LastValidators); err != nil {
+				return err
+			}
+		}
+		if err := saveValidatorsInfo(tx, height, height, state.Validators); err != nil {
+			return err
+		}
+		if err := saveValidatorsInfo(tx, height+1, height+1, state.NextValidators); err != nil {
+			return err
+		}
+		if err := saveConsensusParamsInfo(tx, height,
+			state.LastHeightConsensusParamsChanged, state.ConsensusParams); err != nil {
+			return err
+		}
+		return setRecord(tx, recordState, state.LastBlockHeight, state.Bytes())
+	})
+}
+
+// PruneStates deletes the validator sets, consensus params and ABCI responses
+// between the given heights (including from, excluding to). The validator
+// sets at and above evidenceThresholdHeight are kept for evidence
+// verification.
+func (s *Store) PruneStates(from int64, to int64, evidenceThresholdHeight int64) error {
+	if from <= 0 || to <= 0 {
+		return fmt.Errorf("from height %v and to height %v must be greater than 0", from, to)
+	}
+	if from >= to {
+		return fmt.Errorf("from height %v must be lower than to height %v", from, to)
+	}
+
+	return runInTransaction(s.db, func(tx *sql.Tx) error {
+		// Store the full validator set and consensus params at the lowest
+		// heights kept, so that the heights above them can still be loaded
+		// once the heights they last changed at are pruned.
+		keepValsHeight := to
+		if evidenceThresholdHeight < to {
+			keepValsHeight = evidenceThresholdHeight
+		}
+		if err := storeFullValidatorSet(tx, keepValsHeight); err != nil {
+			return fmt.Errorf("validators at height %v not found: %w", keepValsHeight, err)
+		}
+		if err := storeFullConsensusParams(tx, to); err != nil {
+			return fmt.Errorf("consensus params at height %v not found: %w", to, err)
+		}
+
+		if _, err := tx.Exec(`DELETE FROM validator_sets WHERE height >= $1 AND height < $2 AND height < $3`,
+			from, to, evidenceThresholdHeight); err != nil {
+			return fmt.Errorf("pruning validator sets: %w", err)
+		}
+		if _, err := tx.Exec(`DELETE FROM consensus_params WHERE height >= $1 AND height < $2`,
+			from, to); err != nil {
+			return fmt.Errorf("pruning consensus params: %w", err)
+		}
+		if _, err := tx.Exec(`DELETE FROM finalize_block_responses WHERE height >= $1 AND height < $2`,
+			from, to); err != nil {
+			return fmt.Errorf("pruning ABCI responses: %w", err)
+		}
+		return nil
+	})
+}
+
+// PruneABCIResponses prunes all ABCI responses up to, but not including, the
+// given height. On success, returns the number of heights pruned and the new
+// retain height.
+func (s *Store) PruneABCIResponses(targetRetainHeight int64) (int64, int64, error) {
+	if s.discardABCIResponses {
+		return 0, 0, nil
+	}
+	lastRetainHeight, err := getHeight(s.db, heightLastABCIResponsesRetain)
+	if errors.Is(err, sm.ErrKeyNotFound) {
+		lastRetainHeight = 1
+	} else if err != nil {
+		return 0, 0, fmt.Errorf("failed to look up last ABCI responses retain height: %w", err)
+	}
+
+	if targetRetainHeight <= lastRetainHeight {
+		return 0, targetRetainHeight, nil
+	}
+	err = runInTransaction(s.db, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM finalize_block_responses WHERE height < $1`, targetRetainHeight); err != nil {
+			return fmt.Errorf("failed to delete ABCI responses: %w", err)
+		}
+		return setHeight(tx, heightLastABCIResponsesRetain, targetRetainHeight)
+	})
+	if err != nil {
+		return 0, lastRetainHeight, err
+	}
+	return targetRetainHeight - lastRetainHeight, targetRetainHeight, nil
+}
+
+// LoadFinalizeBlockResponse loads the response to the block at the given
+// height.
+func (s *Store) LoadFinalizeBlockResponse(height int64) (*abci.ResponseFinalizeBlock, error) {
+	if s.discardABCIResponses {
+		return nil, sm.ErrFinalizeBlockResponsesNotPersisted
+	}
+
+	var bz []byte
+	err := s.db.QueryRow(`SELECT response FROM finalize_block_responses WHERE height = $1`, height).Scan(&bz)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sm.ErrNoABCIResponsesForHeight{Height: height}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(abci.ResponseFinalizeBlock)
+	if err := resp.Unmarshal(bz); err != nil {
+		return nil, sm.ErrCorruptedRecord{Key: fmt.Sprintf("finalize_block_responses:%d", height), Err: err}
+	}
+	return resp, nil
+}
+
+// LoadLastFinalizeBlockResponse loads the response to the latest block, which
+// must be at the given height.
+func (s *Store) LoadLastFinalizeBlockResponse(height int64) (*abci.ResponseFinalizeBlock, error) {
+	_, bz, err := getRecord(s.db, recordLastFinalizeBlockResponse)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("no last ABCI response has been persisted")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info := new(cmtstate.ABCIResponsesInfo)
+	if err := info.Unmarshal(bz); err != nil {
+		return nil, sm.ErrCorruptedRecord{Key: recordLastFinalizeBlockResponse, Err: err}
+	}
+	if height != info.GetHeight() {
+		return nil, fmt.Errorf("expected height %d but last stored abci responses was at height %d", height, info.GetHeight())
+	}
+	if info.ResponseFinalizeBlock == nil {
+		return nil, sm.ErrCorruptedRecord{Key: recordLastFinalizeBlockResponse, Err: errors.New("empty response")}
+	}
+	return info.ResponseFinalizeBlock, nil
+}
+
+// SaveFinalizeBlockResponse persists the response to the block at the given
+// height, and records it as the response to the latest block.
+//
+// CONTRACT: height must be monotonically increasing every time this is called.
+func (s *Store) SaveFinalizeBlockResponse(height int64, resp *abci.ResponseFinalizeBlock) error {
+	var dtxs []*abci.ExecTxResult
+	// strip nil values,
+	for _, tx := range resp.TxResults {
+		if tx != nil {
+			dtxs = append(dtxs, tx)
+		}
+	}
+	resp.TxResults = dtxs
+
+	bz, err := resp.Marshal()
+	if err != nil {
+		return err
+	}
+	info := &cmtstate.ABCIResponsesInfo{
+		ResponseFinalizeBlock: resp,
+		Height:                height,
+	}
+	infoBz, err := info.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return runInTransaction(s.db, func(tx *sql.Tx) error {
+		if !s.discardABCIResponses {
+			if _, err := tx.Exec(`
+INSERT INTO finalize_block_responses (height, app_hash, num_txs, response)
+  VALUES ($1, $2, $3, $4)
+  ON CONFLICT (height) DO UPDATE
+  SET app_hash = EXCLUDED.app_hash, num_txs = EXCLUDED.num_txs, response = EXCLUDED.response;
+`, height, fmt.Sprintf("%X", resp.AppHash), len(resp.TxResults), bz); err != nil {
+				return fmt.Errorf("saving ABCI responses: %w", err)
+			}
+		}
+		// We always save the last ABCI response for crash recovery.
+		return setRecord(tx, recordLastFinalizeBlockResponse, height, infoBz)
+	})
+}
+
+// SaveApplicationRetainHeight persists the application retain height.
+func (s *Store) SaveApplicationRetainHeight(height int64) error {
+	return setHeight(s.db, heightAppRetain, height)
+}
+
+// GetApplicationRetainHeight returns the retain height set by the application.
+func (s *Store) GetApplicationRetainHeight() (int64, error) {
+	return getHeight(s.db, heightAppRetain)
+}
+
+// SaveCompanionBlockRetainHeight persists the block retain height set by the
+// data companion.
+func (s *Store) SaveCompanionBlockRetainHeight(height int64) error {
+	return setHeight(s.db, heightCompanionBlockRetain, height)
+}
+
+// GetCompanionBlockRetainHeight returns the block retain height set by the
+// data companion.
+func (s *Store) GetCompanionBlockRetainHeight() (int64, error) {
+	return getHeight(s.db, heightCompanionBlockRetain)
+}
+
+// SaveABCIResRetainHeight persists the ABCI results retain height set by the
+// data companion.
+func (s *Store) SaveABCIResRetainHeight(height int64) error {
+	return setHeight(s.db, heightABCIResultsRetain, height)
+}
+
+// GetABCIResRetainHeight returns the ABCI results retain height set by the
+// data companion.
+func (s *Store) GetABCIResRetainHeight() (int64, error) {
+	return getHeight(s.db, heightABCIResultsRetain)
+}
+
+// SetOfflineStateSyncHeight persists the height at which the store was
+// bootstrapped after out of band state sync.
+func (s *Store) SetOfflineStateSyncHeight(height int64) error {
+	return setHeight(s.db, heightOfflineStateSync, height)
+}
+
+// GetOfflineStateSyncHeight returns the height at which the store was
+// bootstrapped after out of band state sync.
+func (s *Store) GetOfflineStateSyncHeight() (int64, error) {
+	height, err := getHeight(s.db, heightOfflineStateSync)
+	if errors.Is(err, sm.ErrKeyNotFound) {
+		return 0, errors.New("value empty")
+	}
+	return height, err
+}
+
+// LoadValidators loads the validator set of the given height.
+// Returns ErrNoValSetForHeight if the validator set can't be found for this
+// height.
+func (s *Store) LoadValidators(height int64) (*types.ValidatorSet, error) {
+	vs, _, err := loadValidators(s.db, height)
+	return vs, err
+}
+
+// LoadConsensusParams loads the consensus params of the given height.
+func (s *Store) LoadConsensusParams(height int64) (types.ConsensusParams, error) {
+	params, _, err := s.LoadConsensusParamsInfo(height)
+	return params, err
+}
+
+// LoadConsensusParamsInfo loads the consensus params of the given height,
+// together with the height at which they were last changed.
+func (s *Store) LoadConsensusParamsInfo(height int64) (types.ConsensusParams, int64, error) {
+	params, lastHeightChanged, _, err := loadConsensusParams(s.db, height)
+	if err != nil {
+		return types.ConsensusParams{}, 0, fmt.Errorf("could not find consensus params for height #%d: %w", height, err)
+	}
+	return types.ConsensusParamsFromProto(*params), lastHeightChanged, nil
+}
+
+// Close closes the connection with the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+//-----------------------------------------------------------------------------
+
+func getRecord(q queryer, name string) (int64, []byte, error) {
+	var (
+		height int64
+		bz     []byte
+	)
+	err := q.QueryRow(`SELECT height, data FROM state_records WHERE name = $1`, name).Scan(&height, &bz)
+	return height, bz, err
+}
+
+func setRecord(q queryer, name string, height int64, bz []byte) error {
+	_, err := q.Exec(`
+INSERT INTO state_records (name, height, data) VALUES ($1, $2, $3)
+  ON CONFLICT (name) DO UPDATE SET height = EXCLUDED.height, data = EXCLUDED.data;
+`, name, height, bz)
+	if err != nil {
+		return fmt.Errorf("saving %s: %w", name, err)
+	}
+	return nil
+}
+
+func getHeight(q queryer, name string) (int64, error) {
+	var height int64
+	err := q.QueryRow(`SELECT height FROM state_heights WHERE name = $1`, name).Scan(&height)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, sm.ErrKeyNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	if height < 0 {
+		return 0, sm.ErrInvalidHeightValue
+	}
+	return height, nil
+}
+
+func setHeight(q queryer, name string, height int64) error {
+	_, err := q.Exec(`
+INSERT INTO state_heights (name, height) VALUES ($1, $2)
+  ON CONFLICT (name) DO UPDATE SET height = EXCLUDED.height;
+`, name, height)
+	if err != nil {
+		return fmt.Errorf("saving %s: %w", name, err)
+	}
+	return nil
+}
+
+// saveValidatorsInfo persists the validator set of the given height, in full
+// only if it changed at this height or the height is a checkpoint.
+func saveValidatorsInfo(tx *sql.Tx, height, lastHeightChanged int64, valSet *types.ValidatorSet) error {
+	if lastHeightChanged > height {
+		return errors.New("lastHeightChanged cannot be greater than ValidatorsInfo height")
+	}
+	var full *types.ValidatorSet
+	if height == lastHeightChanged || height%valSetCheckpointInterval == 0 {
+		full = valSet
+	}
+	return upsertValidatorSet(tx, height, lastHeightChanged, full)
+}
+
+// upsertValidatorSet persists the validator set of the given height, or only
+// the height it last changed at if valSet is nil.
+func upsertValidatorSet(tx *sql.Tx, height, lastHeightChanged int64, valSet *types.ValidatorSet) error {
+	var bz interface{} // NULL unless the full set is stored
+	if valSet != nil {
+		pv, err := valSet.ToProto()
+		if err != nil {
+			return err
+		}
+		if bz, err = proto.Marshal(pv); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM validators WHERE height = $1`, height); err != nil {
+		return fmt.Errorf("saving validators: %w", err)
+	}
+	if _, err := tx.Exec(`
+INSERT INTO validator_sets (height, last_height_changed, validator_set) VALUES ($1, $2, $3)
+  ON CONFLICT (height) DO UPDATE
+  SET last_height_changed = EXCLUDED.last_height_changed, validator_set = EXCLUDED.validator_set;
+`, height, lastHeightChanged, bz); err != nil {
+		return fmt.Errorf("saving validator set: %w", err)
+	}
+	if valSet == nil {
+		return nil
+	}
+	for _, val := range valSet.Validators {
+		if _, err := tx.Exec(`
+INSERT INTO validators (height, address, voting_power, proposer_priority) VALUES ($1, $2, $3, $4);
+`, height, val.Address.String(), val.VotingPower, val.ProposerPriority); err != nil {
+			return fmt.Errorf("saving validators: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadValidators loads the validator set of the given height, and whether it
+// is stored in full at this height.
+func loadValidators(q queryer, height int64) (*types.ValidatorSet, bool, error) {
+	var (
+		lastHeightChanged int64
+		bz                []byte
+	)
+	err := q.QueryRow(`SELECT last_height_changed, validator_set FROM validator_sets WHERE height = $1`,
+		height).Scan(&lastHeightChanged, &bz)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, sm.ErrNoValSetForHeight{Height: height}
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	storedHeight := height
+	if bz == nil {
+		// The set is derived from the latest full set stored since it last
+		// changed.
+		err := q.QueryRow(`
+SELECT height, validator_set FROM validator_sets
+  WHERE height <= $1 AND height >= $2 AND validator_set IS NOT NULL
+  ORDER BY height DESC LIMIT 1;
+`, height, lastHeightChanged).Scan(&storedHeight, &bz)
+		if err != nil {
+			return nil, false,
+				fmt.Errorf("couldn't find validators changed at height %d (height %d was originally requested): %w",
+					lastHeightChanged,
+					height,
+					err,
+				)
+		}
+	}
+
+	pv := new(cmtproto.ValidatorSet)
+	if err := proto.Unmarshal(bz, pv); err != nil {
+		return nil, false, sm.ErrCorruptedRecord{Key: fmt.Sprintf("validator_sets:%d", storedHeight), Err: err}
+	}
+	vs, err := types.ValidatorSetFromProto(pv)
+	if err != nil {
+		return nil, false, err
+	}
+	if storedHeight < height {
+		vs.IncrementProposerPriority(cmtmath.SafeConvertInt32(height - storedHeight)) // mutate
+	}
+	return vs, storedHeight == height, nil
+}
+
+// storeFullValidatorSet stores the full validator set at the given height if
+// it is not already.
+func storeFullValidatorSet(tx *sql.Tx, height int64) error {
+	vs, stored, err := loadValidators(tx, height)
+	if err != nil || stored {
+		return err
+	}
+	var lastHeightChanged int64
+	if err := tx.QueryRow(`SELECT last_height_changed FROM validator_sets WHERE height = $1`,
+		height).Scan(&lastHeightChanged); err != nil {
+		return err
+	}
+	return upsertValidatorSet(tx, height, lastHeightChanged, vs)
+}
+
+// saveConsensusParamsInfo persists the consensus params of the given height,
+// in full only if they changed at this height.
+func saveConsensusParamsInfo(tx *sql.Tx, nextHeight, changeHeight int64, params types.ConsensusParams) error {
+	var full *cmtproto.ConsensusParams
+	if changeHeight == nextHeight {
+		pp := params.ToProto()
+		full = &pp
+	}
+	return upsertConsensusParams(tx, nextHeight, changeHeight, full)
+}
+
+// upsertConsensusParams persists the consensus params of the given height, or
+// only the height they last changed at if params is nil.
+func upsertConsensusParams(tx *sql.Tx, height, lastHeightChanged int64, params *cmtproto.ConsensusParams) error {
+	var bz interface{} // NULL unless the full params are stored
+	if params != nil {
+		var err error
+		if bz, err = proto.Marshal(params); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(`
+INSERT INTO consensus_params (height, last_height_changed, consensus_params) VALUES ($1, $2, $3)
+  ON CONFLICT (height) DO UPDATE
+  SET last_height_changed = EXCLUDED.last_height_changed, consensus_params = EXCLUDED.consensus_params;
+`, height, lastHeightChanged, bz); err != nil {
+		return fmt.Errorf("saving consensus params: %w", err)
+	}
+	return nil
+}
+
+// loadConsensusParams loads the consensus params of the given height, the
+// height they last changed at, and whether they are stored in full at this
+// height.
+func loadConsensusParams(q queryer, height int64) (*cmtproto.ConsensusParams, int64, bool, error) {
+	var (
+		lastHeightChanged int64
+		bz                []byte
+	)
+	err := q.QueryRow(`SELECT last_height_changed, consensus_params FROM consensus_params WHERE height = $1`,
+		height).Scan(&lastHeightChanged, &bz)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, 0, false, errors.New("value retrieved from db is empty")
+	}
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	stored := bz != nil
+	if !stored {
+		// The params are those of the latest full params stored since they
+		// last changed.
+		err := q.QueryRow(`
+SELECT consensus_params FROM consensus_params
+  WHERE height <= $1 AND height >= $2 AND consensus_params IS NOT NULL
+  ORDER BY height DESC LIMIT 1;
+`, height, lastHeightChanged).Scan(&bz)
+		if err != nil {
+			return nil, 0, false, fmt.Errorf(
+				"couldn't find consensus params at height %d as last changed from height %d: %w",
+				lastHeightChanged,
+				height,
+				err,
+			)
+		}
+	}
+
+	params := new(cmtproto.ConsensusParams)
+	if err := proto.Unmarshal(bz, params); err != nil {
+		return nil, 0, false, sm.ErrCorruptedRecord{Key: fmt.Sprintf("consensus_params:%d", height), Err: err}
+	}
+	return params, lastHeightChanged, stored, nil
+}
+
+// storeFullConsensusParams stores the full consensus params at the given
+// height if they are not already.
+func storeFullConsensusParams(tx *sql.Tx, height int64) error {
+	params, lastHeightChanged, stored, err := loadConsensusParams(tx, height)
+	if err != nil || stored {
+		return err
+	}
+	return upsertConsensusParams(tx, height, lastHeightChanged, params)
+}
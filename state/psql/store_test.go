@@ -0,0 +1,223 @@
+package psql
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/ory/dockertest"
+	"github.com/ory/dockertest/docker"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/types"
+
+	// Register the Postgres database driver.
+	_ "github.com/lib/pq"
+)
+
+// A hook that test cases can call to obtain a store backed by the shared
+// database instance. This is initialized in TestMain (see below).
+var testStore func(t *testing.T, options sm.StoreOptions) *Store
+
+const (
+	user     = "postgres"
+	password = "secret"
+	port     = "5432"
+	dsn      = "postgres://%s:%s@localhost:%s/%s?sslmode=disable"
+	dbName   = "postgres"
+)
+
+func TestMain(m *testing.M) {
+	// Set up docker and start a container running PostgreSQL.
+	pool, err := dockertest.NewPool(os.Getenv("DOCKER_URL"))
+	if err != nil {
+		log.Fatalf("Creating docker pool: %v", err)
+	}
+
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "postgres",
+		Tag:        "13",
+		Env: []string{
+			"POSTGRES_USER=" + user,
+			"POSTGRES_PASSWORD=" + password,
+			"POSTGRES_DB=" + dbName,
+			"listen_addresses = '*'",
+		},
+		ExposedPorts: []string{port},
+	}, func(config *docker.HostConfig) {
+		// set AutoRemove to true so that stopped container goes away by itself
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{
+			Name: "no",
+		}
+	})
+	if err != nil {
+		log.Fatalf("Starting docker pool: %v", err)
+	}
+	const expireSeconds = 60
+	_ = resource.Expire(expireSeconds)
+
+	conn := fmt.Sprintf(dsn, user, password, resource.GetPort(port+"/tcp"), dbName)
+	var db *sql.DB
+	if err := pool.Retry(func() error {
+		if db, err = sql.Open(driverName, conn); err != nil {
+			return err
+		}
+		return db.Ping()
+	}); err != nil {
+		log.Fatalf("Connecting to database: %v", err)
+	}
+
+	schema, err := os.ReadFile("schema.sql")
+	if err != nil {
+		log.Fatalf("Reading schema: %v", err)
+	}
+
+	// Each test runs against a fresh schema.
+	testStore = func(t *testing.T, options sm.StoreOptions) *Store {
+		_, err := db.Exec(`DROP TABLE IF EXISTS state_records, state_heights, validators,
+  validator_sets, consensus_params, finalize_block_responses CASCADE;`)
+		require.NoError(t, err)
+		_, err = db.Exec(string(schema))
+		require.NoError(t, err)
+
+		store, err := NewStore(conn, options)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = store.Close() })
+		return store
+	}
+
+	code := m.Run()
+
+	if err := pool.Purge(resource); err != nil {
+		log.Printf("WARNING: Purging pool failed: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		log.Printf("WARNING: Closing database failed: %v", err)
+	}
+	os.Exit(code)
+}
+
+func makeState(t *testing.T, lastBlockHeight int64) sm.State {
+	t.Helper()
+	val := types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+	valSet := types.NewValidatorSet([]*types.Validator{val})
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		ChainID:         "test-chain",
+		InitialHeight:   1,
+		ConsensusParams: types.DefaultConsensusParams(),
+		Validators:      []types.GenesisValidator{{PubKey: val.PubKey, Power: val.VotingPower}},
+	})
+	require.NoError(t, err)
+	state.LastBlockHeight = lastBlockHeight
+	state.Validators = valSet
+	state.NextValidators = valSet.CopyIncrementProposerPriority(1)
+	return state
+}
+
+func TestStoreSaveLoad(t *testing.T) {
+	store := testStore(t, sm.StoreOptions{})
+
+	state, err := store.Load()
+	require.NoError(t, err)
+	require.True(t, state.IsEmpty())
+
+	state = makeState(t, 0)
+	require.NoError(t, store.Save(state))
+	for h := int64(1); h < 5; h++ {
+		state.LastBlockHeight = h
+		state.Validators = state.NextValidators.Copy()
+		state.NextValidators = state.NextValidators.CopyIncrementProposerPriority(1)
+		require.NoError(t, store.Save(state))
+	}
+
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	require.Equal(t, state.Bytes(), loaded.Bytes())
+
+	// The validator sets are derived from the set stored when they changed.
+	vals, err := store.LoadValidators(6)
+	require.NoError(t, err)
+	require.Equal(t, state.NextValidators.Hash(), vals.Hash())
+	require.Equal(t, state.NextValidators.Proposer, vals.Proposer)
+
+	_, err = store.LoadValidators(100)
+	require.ErrorAs(t, err, &sm.ErrNoValSetForHeight{})
+
+	params, lastHeightChanged, err := store.LoadConsensusParamsInfo(5)
+	require.NoError(t, err)
+	require.Equal(t, state.ConsensusParams, params)
+	require.EqualValues(t, 1, lastHeightChanged)
+
+	// Pruning keeps the heights above the pruned ones loadable.
+	require.NoError(t, store.PruneStates(1, 4, 4))
+	_, err = store.LoadValidators(2)
+	require.Error(t, err)
+	vals, err = store.LoadValidators(6)
+	require.NoError(t, err)
+	require.Equal(t, state.NextValidators.Hash(), vals.Hash())
+	_, err = store.LoadConsensusParams(5)
+	require.NoError(t, err)
+}
+
+func TestStoreFinalizeBlockResponses(t *testing.T) {
+	store := testStore(t, sm.StoreOptions{})
+
+	for h := int64(1); h <= 10; h++ {
+		resp := &abci.ResponseFinalizeBlock{
+			TxResults: []*abci.ExecTxResult{{Code: 0, Data: []byte{byte(h)}}, nil},
+			AppHash:   []byte{byte(h)},
+		}
+		require.NoError(t, store.SaveFinalizeBlockResponse(h, resp))
+	}
+
+	resp, err := store.LoadFinalizeBlockResponse(3)
+	require.NoError(t, err)
+	require.Len(t, resp.TxResults, 1)
+	require.Equal(t, []byte{3}, resp.AppHash)
+
+	_, err = store.LoadLastFinalizeBlockResponse(9)
+	require.Error(t, err)
+	resp, err = store.LoadLastFinalizeBlockResponse(10)
+	require.NoError(t, err)
+	require.Equal(t, []byte{10}, resp.AppHash)
+
+	pruned, retainHeight, err := store.PruneABCIResponses(5)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, pruned)
+	require.EqualValues(t, 5, retainHeight)
+	_, err = store.LoadFinalizeBlockResponse(4)
+	require.ErrorAs(t, err, &sm.ErrNoABCIResponsesForHeight{})
+	_, err = store.LoadFinalizeBlockResponse(5)
+	require.NoError(t, err)
+}
+
+func TestStoreRetainHeights(t *testing.T) {
+	store := testStore(t, sm.StoreOptions{})
+
+	_, err := store.GetApplicationRetainHeight()
+	require.ErrorIs(t, err, sm.ErrKeyNotFound)
+
+	require.NoError(t, store.SaveApplicationRetainHeight(10))
+	require.NoError(t, store.SaveCompanionBlockRetainHeight(11))
+	require.NoError(t, store.SaveABCIResRetainHeight(12))
+	require.NoError(t, store.SetOfflineStateSyncHeight(13))
+
+	height, err := store.GetApplicationRetainHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 10, height)
+	height, err = store.GetCompanionBlockRetainHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 11, height)
+	height, err = store.GetABCIResRetainHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 12, height)
+	height, err = store.GetOfflineStateSyncHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 13, height)
+}
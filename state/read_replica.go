@@ -0,0 +1,53 @@
+package state
+
+import (
+	"errors"
+
+	dbm "github.com/cometbft/cometbft-db"
+)
+
+// ErrReadOnly is returned when writing to a read-only database.
+var ErrReadOnly = errors.New("database is read-only")
+
+// readOnlyDB is a read-only view of a database written to by another store.
+// Closing it leaves the underlying database open, as it is owned by the
+// writer.
+type readOnlyDB struct {
+	dbm.DB
+}
+
+var _ dbm.DB = readOnlyDB{}
+
+// NewReadOnlyDB returns a read-only view of db, rejecting the writes with
+// ErrReadOnly. Closing the view does not close db.
+func NewReadOnlyDB(db dbm.DB) dbm.DB {
+	if ro, ok := db.(readOnlyDB); ok {
+		return ro
+	}
+	return readOnlyDB{DB: db}
+}
+
+func (readOnlyDB) Set([]byte, []byte) error     { return ErrReadOnly }
+func (readOnlyDB) SetSync([]byte, []byte) error { return ErrReadOnly }
+func (readOnlyDB) Delete([]byte) error          { return ErrReadOnly }
+func (readOnlyDB) DeleteSync([]byte) error      { return ErrReadOnly }
+func (readOnlyDB) NewBatch() dbm.Batch          { return readOnlyBatch{} }
+func (readOnlyDB) Close() error                 { return nil }
+
+type readOnlyBatch struct{}
+
+func (readOnlyBatch) Set([]byte, []byte) error { return ErrReadOnly }
+func (readOnlyBatch) Delete([]byte) error      { return ErrReadOnly }
+func (readOnlyBatch) Write() error             { return ErrReadOnly }
+func (readOnlyBatch) WriteSync() error         { return ErrReadOnly }
+func (readOnlyBatch) Close() error             { return nil }
+
+// NewReadReplicaFromBackend creates a read replica of the state store using
+// the backend registered under the given name, for serving queries without
+// contending with the store used by consensus.
+//
+// The replica reads db through a read-only view, and the backends keeping the
+// state in an external database open their own connections to it.
+func NewReadReplicaFromBackend(name string, db dbm.DB, options StoreOptions) (Store, error) {
+	return NewStoreFromBackend(name, NewReadOnlyDB(db), options)
+}
@@ -238,15 +238,31 @@ func (state State) MakeBlock(
 	evidence []types.Evidence,
 	proposerAddress []byte,
 ) *types.Block {
+	return state.makeBlock(height, txs, lastCommit, evidence, proposerAddress, cmttime.Now())
+}
+
+// makeBlock builds a block as MakeBlock, now being the time of the proposer
+// under PBTS.
+func (state State) makeBlock(
+	height int64,
+	txs []types.Tx,
+	lastCommit *types.Commit,
+	evidence []types.Evidence,
+	proposerAddress []byte,
+	now time.Time,
+) *types.Block {
 
 	// Build base block with block data.
 	block := types.MakeBlock(height, txs, lastCommit, evidence)
 
 	// Set time.
 	var timestamp time.Time
-	if height == state.InitialHeight {
+	switch {
+	case state.ConsensusParams.Synchrony.PBTSEnabled(height):
+		timestamp = now
+	case height == state.InitialHeight:
 		timestamp = state.LastBlockTime // genesis time
-	} else {
+	default:
 		timestamp = MedianTime(lastCommit, state.LastValidators)
 	}
 
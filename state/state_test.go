@@ -1065,6 +1065,13 @@ func TestConsensusParamsChangesSaveLoad(t *testing.T) {
 		assert.Nil(t, err, fmt.Sprintf("expected no err at height %d", testCase.height))
 		assert.EqualValues(t, testCase.params, p, fmt.Sprintf(`unexpected consensus params at
                 height %d`, testCase.height))
+
+		// Every block returns consensus param updates, so the params are
+		// considered changed at each height.
+		p, changed, err := stateStore.LoadConsensusParamsInfo(testCase.height)
+		assert.Nil(t, err, fmt.Sprintf("expected no err at height %d", testCase.height))
+		assert.EqualValues(t, testCase.params, p)
+		assert.Equal(t, testCase.height, changed)
 	}
 }
 
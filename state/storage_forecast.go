@@ -0,0 +1,262 @@
+package state
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+)
+
+// Databases whose size is tracked by the StorageForecaster. The block indexer
+// shares its database with the tx indexer, and is tracked as part of it.
+var forecastedStores = []string{"blockstore", "state", "tx_index", "evidence"}
+
+// StoreForecast reports the size and growth rate of the database of a store.
+type StoreForecast struct {
+	// Size of the database, in bytes.
+	Size int64
+	// Growth rate of the database over the forecast window, in bytes per
+	// second. It is negative if the database shrank, e.g. due to pruning.
+	GrowthRate float64
+}
+
+// StorageForecast reports the growth of the node databases, and when the
+// volume holding them is projected to be full.
+type StorageForecast struct {
+	// Time of the last measurement.
+	Time time.Time
+	// Time period between the first and the last measurements from which the
+	// growth rates are computed.
+	Window time.Duration
+	// Space available on the volume holding the databases, in bytes.
+	FreeBytes uint64
+	// Total space of the volume holding the databases, in bytes.
+	TotalBytes uint64
+	// Combined growth rate of the databases, in bytes per second.
+	GrowthRate float64
+	// Number of days until the volume holding the databases is full at the
+	// current growth rate, or -1 if the databases are not growing or the
+	// available space is unknown.
+	DaysUntilFull float64
+	// Size and growth rate of each database, indexed by store.
+	Stores map[string]StoreForecast
+}
+
+type storageSample struct {
+	time  time.Time
+	sizes map[string]int64
+}
+
+// StorageForecaster is a service that periodically measures the size of the
+// node databases and projects, from their growth rate over a sliding window,
+// when the volume holding them will be full. A warning is logged when the
+// volume is projected to be full within the configured number of days.
+type StorageForecaster struct {
+	service.BaseService
+	logger log.Logger
+
+	dbDir     string
+	interval  time.Duration
+	window    time.Duration
+	warnDays  uint
+	metrics   *Metrics
+	diskSpace func(path string) (free, total uint64, err error)
+
+	mtx      sync.Mutex
+	samples  []storageSample
+	forecast *StorageForecast
+	warned   bool
+}
+
+type storageForecasterConfig struct {
+	interval time.Duration
+	window   time.Duration
+	warnDays uint
+	metrics  *Metrics
+}
+
+func defaultStorageForecasterConfig() *storageForecasterConfig {
+	return &storageForecasterConfig{
+		interval: config.DefaultStorageForecastInterval,
+		window:   config.DefaultStorageForecastWindow,
+		warnDays: 0,
+		metrics:  NopMetrics(),
+	}
+}
+
+type StorageForecasterOption func(*storageForecasterConfig)
+
+// WithStorageForecasterInterval allows control over the interval between
+// each measurement of the size of the databases.
+func WithStorageForecasterInterval(t time.Duration) StorageForecasterOption {
+	return func(f *storageForecasterConfig) { f.interval = t }
+}
+
+// WithStorageForecasterWindow allows control over the time period over which
+// the growth rate of the databases is computed.
+func WithStorageForecasterWindow(t time.Duration) StorageForecasterOption {
+	return func(f *storageForecasterConfig) { f.window = t }
+}
+
+// WithStorageForecasterWarnDays enables a warning when the volume holding the
+// databases is projected to be full in less than the given number of days.
+func WithStorageForecasterWarnDays(days uint) StorageForecasterOption {
+	return func(f *storageForecasterConfig) { f.warnDays = days }
+}
+
+func WithStorageForecasterMetrics(metrics *Metrics) StorageForecasterOption {
+	return func(f *storageForecasterConfig) { f.metrics = metrics }
+}
+
+// NewStorageForecaster creates a service that forecasts the storage usage of
+// the databases held in the given directory.
+func NewStorageForecaster(dbDir string, logger log.Logger, options ...StorageForecasterOption) *StorageForecaster {
+	cfg := defaultStorageForecasterConfig()
+	for _, opt := range options {
+		opt(cfg)
+	}
+	f := &StorageForecaster{
+		logger:    logger,
+		dbDir:     dbDir,
+		interval:  cfg.interval,
+		window:    cfg.window,
+		warnDays:  cfg.warnDays,
+		metrics:   cfg.metrics,
+		diskSpace: diskSpace,
+	}
+	f.BaseService = *service.NewBaseService(logger, "StorageForecaster", f)
+	return f
+}
+
+func (f *StorageForecaster) OnStart() error {
+	go f.forecastRoutine()
+	return nil
+}
+
+// Forecast returns the latest storage usage forecast, or false if the size of
+// the databases has not been measured yet.
+func (f *StorageForecaster) Forecast() (StorageForecast, bool) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.forecast == nil {
+		return StorageForecast{}, false
+	}
+	forecast := *f.forecast
+	forecast.Stores = make(map[string]StoreForecast, len(f.forecast.Stores))
+	for store, sf := range f.forecast.Stores {
+		forecast.Stores[store] = sf
+	}
+	return forecast, true
+}
+
+func (f *StorageForecaster) forecastRoutine() {
+	f.logger.Info("Started storage forecaster", "interval", f.interval.String(), "window", f.window.String())
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+	for {
+		f.sample(time.Now())
+		select {
+		case <-f.Quit():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sample measures the size of the databases and updates the forecast.
+func (f *StorageForecaster) sample(now time.Time) {
+	sizes := make(map[string]int64, len(forecastedStores))
+	for _, store := range forecastedStores {
+		size, err := dirSize(filepath.Join(f.dbDir, store+".db"))
+		if err != nil {
+			f.logger.Error("Failed to measure database size", "store", store, "err", err)
+			continue
+		}
+		sizes[store] = size
+	}
+	free, total, err := f.diskSpace(f.dbDir)
+	if err != nil {
+		f.logger.Error("Failed to measure available disk space", "dir", f.dbDir, "err", err)
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.samples = append(f.samples, storageSample{time: now, sizes: sizes})
+	// Discard the samples older than the window, keeping the most recent of
+	// them so that the growth rate is computed over at least the window.
+	cutoff := now.Add(-f.window)
+	for len(f.samples) > 2 && !f.samples[1].time.After(cutoff) {
+		f.samples = f.samples[1:]
+	}
+
+	first := f.samples[0]
+	elapsed := now.Sub(first.time)
+	forecast := &StorageForecast{
+		Time:          now,
+		Window:        elapsed,
+		FreeBytes:     free,
+		TotalBytes:    total,
+		DaysUntilFull: -1,
+		Stores:        make(map[string]StoreForecast, len(sizes)),
+	}
+	for store, size := range sizes {
+		sf := StoreForecast{Size: size}
+		if firstSize, ok := first.sizes[store]; ok && elapsed > 0 {
+			sf.GrowthRate = float64(size-firstSize) / elapsed.Seconds()
+		}
+		forecast.GrowthRate += sf.GrowthRate
+		forecast.Stores[store] = sf
+
+		f.metrics.StoreSize.With("store", store).Set(float64(size))
+		f.metrics.StoreGrowthRate.With("store", store).Set(sf.GrowthRate)
+	}
+	if err == nil && forecast.GrowthRate > 0 {
+		forecast.DaysUntilFull = float64(free) / forecast.GrowthRate / (24 * time.Hour).Seconds()
+	}
+	f.forecast = forecast
+
+	f.metrics.StorageFreeBytes.Set(float64(free))
+	f.metrics.StorageDaysUntilFull.Set(forecast.DaysUntilFull)
+
+	warn := f.warnDays > 0 && forecast.DaysUntilFull >= 0 && forecast.DaysUntilFull < float64(f.warnDays)
+	if warn && !f.warned {
+		f.logger.Error("Storage volume projected to be full soon",
+			"daysUntilFull", forecast.DaysUntilFull,
+			"freeBytes", free,
+			"growthRate", forecast.GrowthRate,
+		)
+		f.metrics.StorageForecastWarnings.Add(1)
+	}
+	f.warned = warn
+}
+
+// dirSize returns the combined size of the regular files in the given
+// directory, or 0 if it does not exist.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	return size, err
+}
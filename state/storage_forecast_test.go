@@ -0,0 +1,69 @@
+package state_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+	sm "github.com/cometbft/cometbft/state"
+)
+
+func TestStorageForecaster(t *testing.T) {
+	dbDir := t.TempDir()
+	writeFile := func(store string, size int) {
+		dir := filepath.Join(dbDir, store+".db")
+		require.NoError(t, os.MkdirAll(dir, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "000001.ldb"), make([]byte, size), 0o600))
+	}
+
+	const free = 3 * 24 * 3600 // 3 days at 1 byte per second
+	forecaster := sm.NewStorageForecaster(dbDir, log.TestingLogger(),
+		sm.WithStorageForecasterWindow(24*time.Hour),
+		sm.WithStorageForecasterWarnDays(7),
+	)
+	forecaster.SetDiskSpaceFunc(func(string) (uint64, uint64, error) {
+		return free, 10 * free, nil
+	})
+
+	_, ok := forecaster.Forecast()
+	require.False(t, ok)
+
+	start := time.Now()
+	writeFile("blockstore", 1000)
+	writeFile("state", 500)
+	forecaster.Sample(start)
+
+	forecast, ok := forecaster.Forecast()
+	require.True(t, ok)
+	require.EqualValues(t, -1, forecast.DaysUntilFull)
+	require.Len(t, forecast.Stores, 4)
+	require.EqualValues(t, 1000, forecast.Stores["blockstore"].Size)
+	require.EqualValues(t, 500, forecast.Stores["state"].Size)
+	require.Zero(t, forecast.Stores["tx_index"].Size)
+
+	// The block store grows by 1 byte per second, the state store shrinks.
+	writeFile("blockstore", 1000+3600)
+	writeFile("state", 200)
+	forecaster.Sample(start.Add(time.Hour))
+
+	forecast, ok = forecaster.Forecast()
+	require.True(t, ok)
+	require.Equal(t, time.Hour, forecast.Window)
+	require.EqualValues(t, free, forecast.FreeBytes)
+	require.InDelta(t, 1, forecast.Stores["blockstore"].GrowthRate, 1e-9)
+	require.InDelta(t, -300.0/3600, forecast.Stores["state"].GrowthRate, 1e-9)
+	require.InDelta(t, 1-300.0/3600, forecast.GrowthRate, 1e-9)
+	require.InDelta(t, 3/(1-300.0/3600), forecast.DaysUntilFull, 1e-9)
+
+	// Samples older than the window are discarded.
+	forecaster.Sample(start.Add(26 * time.Hour))
+	forecast, ok = forecaster.Forecast()
+	require.True(t, ok)
+	require.Equal(t, 25*time.Hour, forecast.Window)
+	require.Zero(t, forecast.Stores["blockstore"].GrowthRate)
+	require.EqualValues(t, -1, forecast.DaysUntilFull)
+}
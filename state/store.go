@@ -10,8 +10,8 @@ import (
 	dbm "github.com/cometbft/cometbft-db"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/compress"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
-	cmtos "github.com/cometbft/cometbft/libs/os"
 	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/cometbft/cometbft/types"
@@ -75,6 +75,9 @@ type Store interface {
 	LoadLastFinalizeBlockResponse(height int64) (*abci.ResponseFinalizeBlock, error)
 	// LoadConsensusParams loads the consensus params for a given height
 	LoadConsensusParams(height int64) (types.ConsensusParams, error)
+	// LoadConsensusParamsInfo loads the consensus params for a given height,
+	// along with the height at which they last changed
+	LoadConsensusParamsInfo(height int64) (types.ConsensusParams, int64, error)
 	// Save overwrites the previous state with the updated one
 	Save(state State) error
 	// SaveFinalizeBlockResponse saves ABCIResponses for a given height
@@ -118,6 +121,20 @@ type StoreOptions struct {
 	// the store will maintain only the response object from the latest
 	// height.
 	DiscardABCIResponses bool
+
+	// VerifyChecksums determines whether or not the store verifies the
+	// checksum of the states, validator sets, consensus params and ABCI
+	// responses it loads, returning ErrCorruptedRecord on mismatch.
+	VerifyChecksums bool
+
+	// CompressABCIResponses determines whether or not the store compresses
+	// the ABCI responses of each height with zstd. The responses are read
+	// whether or not they were compressed.
+	CompressABCIResponses bool
+
+	// BackendConn is the connection string of the state store backends
+	// keeping the state in an external database, such as "psql".
+	BackendConn string
 }
 
 var _ Store = (*dbStore)(nil)
@@ -178,7 +195,7 @@ func (store dbStore) Load() (State, error) {
 }
 
 func (store dbStore) loadState(key []byte) (state State, err error) {
-	buf, err := store.db.Get(key)
+	buf, err := store.getRecord(key)
 	if err != nil {
 		return state, err
 	}
@@ -191,8 +208,7 @@ func (store dbStore) loadState(key []byte) (state State, err error) {
 	err = proto.Unmarshal(buf, sp)
 	if err != nil {
 		// DATA HAS BEEN CORRUPTED OR THE SPEC HAS CHANGED
-		cmtos.Exit(fmt.Sprintf(`LoadState: Data has been corrupted or its spec has changed:
-		%v\n`, err))
+		return state, ErrCorruptedRecord{Key: string(key), Err: err}
 	}
 
 	sm, err := FromProto(sp)
@@ -229,7 +245,7 @@ func (store dbStore) save(state State, key []byte) error {
 		state.LastHeightConsensusParamsChanged, state.ConsensusParams); err != nil {
 		return err
 	}
-	err := store.db.SetSync(key, state.Bytes())
+	err := store.setRecord(key, state.Bytes(), true)
 	if err != nil {
 		return err
 	}
@@ -263,7 +279,7 @@ func (store dbStore) Bootstrap(state State) error {
 		return err
 	}
 
-	return store.db.SetSync(stateKey, state.Bytes())
+	return store.setRecord(stateKey, state.Bytes(), true)
 }
 
 // PruneStates deletes states between the given heights (including from, excluding to). It is not
@@ -282,7 +298,7 @@ func (store dbStore) PruneStates(from int64, to int64, evidenceThresholdHeight i
 		return fmt.Errorf("from height %v must be lower than to height %v", from, to)
 	}
 
-	valInfo, err := loadValidatorsInfo(store.db, min(to, evidenceThresholdHeight))
+	valInfo, err := store.loadValidatorsInfo(min(to, evidenceThresholdHeight))
 	if err != nil {
 		return fmt.Errorf("validators at height %v not found: %w", to, err)
 	}
@@ -312,7 +328,7 @@ func (store dbStore) PruneStates(from int64, to int64, evidenceThresholdHeight i
 		// params, otherwise they will panic if they're retrieved directly (instead of
 		// indirectly via a LastHeightChanged pointer).
 		if keepVals[h] {
-			v, err := loadValidatorsInfo(store.db, h)
+			v, err := store.loadValidatorsInfo(h)
 			if err != nil || v.ValidatorSet == nil {
 				vip, err := store.LoadValidators(h)
 				if err != nil {
@@ -331,13 +347,13 @@ func (store dbStore) PruneStates(from int64, to int64, evidenceThresholdHeight i
 				if err != nil {
 					return err
 				}
-				err = batch.Set(calcValidatorsKey(h), bz)
+				err = setRecordInBatch(batch, calcValidatorsKey(h), bz)
 				if err != nil {
 					return err
 				}
 			}
 		} else if h < evidenceThresholdHeight {
-			err = batch.Delete(calcValidatorsKey(h))
+			err = deleteRecordInBatch(batch, calcValidatorsKey(h))
 			if err != nil {
 				return err
 			}
@@ -364,19 +380,19 @@ func (store dbStore) PruneStates(from int64, to int64, evidenceThresholdHeight i
 					return err
 				}
 
-				err = batch.Set(calcConsensusParamsKey(h), bz)
+				err = setRecordInBatch(batch, calcConsensusParamsKey(h), bz)
 				if err != nil {
 					return err
 				}
 			}
 		} else {
-			err = batch.Delete(calcConsensusParamsKey(h))
+			err = deleteRecordInBatch(batch, calcConsensusParamsKey(h))
 			if err != nil {
 				return err
 			}
 		}
 
-		err = batch.Delete(calcABCIResponsesKey(h))
+		err = deleteRecordInBatch(batch, calcABCIResponsesKey(h))
 		if err != nil {
 			return err
 		}
@@ -424,7 +440,7 @@ func (store dbStore) PruneABCIResponses(targetRetainHeight int64) (int64, int64,
 	batchPruned := int64(0)
 
 	for h := lastRetainHeight; h < targetRetainHeight; h++ {
-		if err := batch.Delete(calcABCIResponsesKey(h)); err != nil {
+		if err := deleteRecordInBatch(batch, calcABCIResponsesKey(h)); err != nil {
 			return pruned, lastRetainHeight + pruned, fmt.Errorf("failed to delete ABCI responses at height %d: %w", h, err)
 		}
 		batchPruned++
@@ -465,13 +481,17 @@ func (store dbStore) LoadFinalizeBlockResponse(height int64) (*abci.ResponseFina
 		return nil, ErrFinalizeBlockResponsesNotPersisted
 	}
 
-	buf, err := store.db.Get(calcABCIResponsesKey(height))
+	buf, err := store.getRecord(calcABCIResponsesKey(height))
 	if err != nil {
 		return nil, err
 	}
 	if len(buf) == 0 {
 		return nil, ErrNoABCIResponsesForHeight{height}
 	}
+	buf, err = compress.Decompress(buf)
+	if err != nil {
+		return nil, ErrCorruptedRecord{Key: string(calcABCIResponsesKey(height)), Err: err}
+	}
 
 	resp := new(abci.ResponseFinalizeBlock)
 	err = resp.Unmarshal(buf)
@@ -482,8 +502,7 @@ func (store dbStore) LoadFinalizeBlockResponse(height int64) (*abci.ResponseFina
 		rerr := legacyResp.Unmarshal(buf)
 		if rerr != nil {
 			// DATA HAS BEEN CORRUPTED OR THE SPEC HAS CHANGED
-			cmtos.Exit(fmt.Sprintf(`LoadFinalizeBlockResponse: Data has been corrupted or its spec has
-					changed: %v\n`, err))
+			return nil, ErrCorruptedRecord{Key: string(calcABCIResponsesKey(height)), Err: err}
 		}
 		// The state store contains the old format. Migrate to
 		// the new ResponseFinalizeBlock format. Note that the
@@ -503,7 +522,7 @@ func (store dbStore) LoadFinalizeBlockResponse(height int64) (*abci.ResponseFina
 // This method is used for recovering in the case that we called the Commit ABCI
 // method on the application but crashed before persisting the results.
 func (store dbStore) LoadLastFinalizeBlockResponse(height int64) (*abci.ResponseFinalizeBlock, error) {
-	bz, err := store.db.Get(lastABCIResponseKey)
+	bz, err := store.getRecord(lastABCIResponseKey)
 	if err != nil {
 		return nil, err
 	}
@@ -515,8 +534,8 @@ func (store dbStore) LoadLastFinalizeBlockResponse(height int64) (*abci.Response
 	info := new(cmtstate.ABCIResponsesInfo)
 	err = info.Unmarshal(bz)
 	if err != nil {
-		cmtos.Exit(fmt.Sprintf(`LoadLastFinalizeBlockResponse: Data has been corrupted or its spec has
-			changed: %v\n`, err))
+		// DATA HAS BEEN CORRUPTED OR THE SPEC HAS CHANGED
+		return nil, ErrCorruptedRecord{Key: string(lastABCIResponseKey), Err: err}
 	}
 
 	// Here we validate the result by comparing its height to the expected height.
@@ -561,7 +580,10 @@ func (store dbStore) SaveFinalizeBlockResponse(height int64, resp *abci.Response
 		if err != nil {
 			return err
 		}
-		if err := store.db.Set(calcABCIResponsesKey(height), bz); err != nil {
+		if store.CompressABCIResponses {
+			bz = compress.Compress(bz)
+		}
+		if err := store.setRecord(calcABCIResponsesKey(height), bz, false); err != nil {
 			return err
 		}
 	}
@@ -577,7 +599,7 @@ func (store dbStore) SaveFinalizeBlockResponse(height int64, resp *abci.Response
 		return err
 	}
 
-	return store.db.SetSync(lastABCIResponseKey, bz)
+	return store.setRecord(lastABCIResponseKey, bz, true)
 }
 
 func (store dbStore) getValue(key []byte) ([]byte, error) {
@@ -670,13 +692,17 @@ func (store dbStore) setLastABCIResponsesRetainHeight(height int64) error {
 // LoadValidators loads the ValidatorSet for a given height.
 // Returns ErrNoValSetForHeight if the validator set can't be found for this height.
 func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
-	valInfo, err := loadValidatorsInfo(store.db, height)
+	valInfo, err := store.loadValidatorsInfo(height)
 	if err != nil {
+		var errCorrupted ErrCorruptedRecord
+		if errors.As(err, &errCorrupted) {
+			return nil, err
+		}
 		return nil, ErrNoValSetForHeight{height}
 	}
 	if valInfo.ValidatorSet == nil {
 		lastStoredHeight := lastStoredHeightFor(height, valInfo.LastHeightChanged)
-		valInfo2, err := loadValidatorsInfo(store.db, lastStoredHeight)
+		valInfo2, err := store.loadValidatorsInfo(lastStoredHeight)
 		if err != nil || valInfo2.ValidatorSet == nil {
 			return nil,
 				fmt.Errorf("couldn't find validators at height %d (height %d was originally requested): %w",
@@ -715,8 +741,8 @@ func lastStoredHeightFor(height, lastHeightChanged int64) int64 {
 }
 
 // CONTRACT: Returned ValidatorsInfo can be mutated.
-func loadValidatorsInfo(db dbm.DB, height int64) (*cmtstate.ValidatorsInfo, error) {
-	buf, err := db.Get(calcValidatorsKey(height))
+func (store dbStore) loadValidatorsInfo(height int64) (*cmtstate.ValidatorsInfo, error) {
+	buf, err := store.getRecord(calcValidatorsKey(height))
 	if err != nil {
 		return nil, err
 	}
@@ -729,8 +755,7 @@ func loadValidatorsInfo(db dbm.DB, height int64) (*cmtstate.ValidatorsInfo, erro
 	err = v.Unmarshal(buf)
 	if err != nil {
 		// DATA HAS BEEN CORRUPTED OR THE SPEC HAS CHANGED
-		cmtos.Exit(fmt.Sprintf(`LoadValidators: Data has been corrupted or its spec has changed:
-        %v\n`, err))
+		return nil, ErrCorruptedRecord{Key: string(calcValidatorsKey(height)), Err: err}
 	}
 	// TODO: ensure that buf is completely read.
 
@@ -764,7 +789,7 @@ func (store dbStore) saveValidatorsInfo(height, lastHeightChanged int64, valSet
 		return err
 	}
 
-	err = store.db.Set(calcValidatorsKey(height), bz)
+	err = store.setRecord(calcValidatorsKey(height), bz, false)
 	if err != nil {
 		return err
 	}
@@ -778,19 +803,26 @@ func (store dbStore) saveValidatorsInfo(height, lastHeightChanged int64, valSet
 
 // LoadConsensusParams loads the ConsensusParams for a given height.
 func (store dbStore) LoadConsensusParams(height int64) (types.ConsensusParams, error) {
+	params, _, err := store.LoadConsensusParamsInfo(height)
+	return params, err
+}
+
+// LoadConsensusParamsInfo loads the ConsensusParams in effect at a given
+// height, together with the height at which they were last changed.
+func (store dbStore) LoadConsensusParamsInfo(height int64) (types.ConsensusParams, int64, error) {
 	var (
 		empty   = types.ConsensusParams{}
 		emptypb = cmtproto.ConsensusParams{}
 	)
 	paramsInfo, err := store.loadConsensusParamsInfo(height)
 	if err != nil {
-		return empty, fmt.Errorf("could not find consensus params for height #%d: %w", height, err)
+		return empty, 0, fmt.Errorf("could not find consensus params for height #%d: %w", height, err)
 	}
 
 	if paramsInfo.ConsensusParams.Equal(&emptypb) {
 		paramsInfo2, err := store.loadConsensusParamsInfo(paramsInfo.LastHeightChanged)
 		if err != nil {
-			return empty, fmt.Errorf(
+			return empty, 0, fmt.Errorf(
 				"couldn't find consensus params at height %d as last changed from height %d: %w",
 				paramsInfo.LastHeightChanged,
 				height,
@@ -801,11 +833,11 @@ func (store dbStore) LoadConsensusParams(height int64) (types.ConsensusParams, e
 		paramsInfo = paramsInfo2
 	}
 
-	return types.ConsensusParamsFromProto(paramsInfo.ConsensusParams), nil
+	return types.ConsensusParamsFromProto(paramsInfo.ConsensusParams), paramsInfo.LastHeightChanged, nil
 }
 
 func (store dbStore) loadConsensusParamsInfo(height int64) (*cmtstate.ConsensusParamsInfo, error) {
-	buf, err := store.db.Get(calcConsensusParamsKey(height))
+	buf, err := store.getRecord(calcConsensusParamsKey(height))
 	if err != nil {
 		return nil, err
 	}
@@ -816,8 +848,7 @@ func (store dbStore) loadConsensusParamsInfo(height int64) (*cmtstate.ConsensusP
 	paramsInfo := new(cmtstate.ConsensusParamsInfo)
 	if err = paramsInfo.Unmarshal(buf); err != nil {
 		// DATA HAS BEEN CORRUPTED OR THE SPEC HAS CHANGED
-		cmtos.Exit(fmt.Sprintf(`LoadConsensusParams: Data has been corrupted or its spec has changed:
-                %v\n`, err))
+		return nil, ErrCorruptedRecord{Key: string(calcConsensusParamsKey(height)), Err: err}
 	}
 	// TODO: ensure that buf is completely read.
 
@@ -841,7 +872,7 @@ func (store dbStore) saveConsensusParamsInfo(nextHeight, changeHeight int64, par
 		return err
 	}
 
-	err = store.db.Set(calcConsensusParamsKey(nextHeight), bz)
+	err = store.setRecord(calcConsensusParamsKey(nextHeight), bz, false)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,72 @@
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// DefaultStoreBackend is the state store backend keeping the state in the
+// state database of the node.
+const DefaultStoreBackend = "kv"
+
+// StoreCreator creates a state store. The state database of the node is
+// passed to it, and may be ignored by the backends keeping the state
+// elsewhere.
+type StoreCreator func(db dbm.DB, options StoreOptions) (Store, error)
+
+var (
+	storeBackendsMtx cmtsync.RWMutex
+	storeBackends    = map[string]StoreCreator{
+		DefaultStoreBackend: func(db dbm.DB, options StoreOptions) (Store, error) {
+			return NewStore(db, options), nil
+		},
+	}
+)
+
+// RegisterStoreBackend registers a state store backend under the given name,
+// which can then be selected in the configuration of the node.
+//
+// Should only be called in init() functions, as it panics on error.
+func RegisterStoreBackend(name string, creator StoreCreator) {
+	if name == "" || creator == nil {
+		panic("state store backends must have a name and a creator")
+	}
+
+	storeBackendsMtx.Lock()
+	defer storeBackendsMtx.Unlock()
+	if _, ok := storeBackends[name]; ok {
+		panic(fmt.Sprintf("state store backend %q is already registered", name))
+	}
+	storeBackends[name] = creator
+}
+
+// NewStoreFromBackend creates a state store using the backend registered
+// under the given name.
+func NewStoreFromBackend(name string, db dbm.DB, options StoreOptions) (Store, error) {
+	storeBackendsMtx.RLock()
+	creator, ok := storeBackends[name]
+	storeBackendsMtx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown state store backend %q, expected one of %s",
+			name, strings.Join(StoreBackends(), ", "))
+	}
+	return creator(db, options)
+}
+
+// StoreBackends returns the names of the registered state store backends.
+func StoreBackends() []string {
+	storeBackendsMtx.RLock()
+	defer storeBackendsMtx.RUnlock()
+
+	names := make([]string, 0, len(storeBackends))
+	for name := range storeBackends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,61 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	sm "github.com/cometbft/cometbft/state"
+)
+
+func TestStoreBackends(t *testing.T) {
+	stateDB := dbm.NewMemDB()
+
+	store, err := sm.NewStoreFromBackend(sm.DefaultStoreBackend, stateDB, sm.StoreOptions{})
+	require.NoError(t, err)
+	state, err := store.Load()
+	require.NoError(t, err)
+	require.True(t, state.IsEmpty())
+
+	_, err = sm.NewStoreFromBackend("unknown", stateDB, sm.StoreOptions{})
+	require.ErrorContains(t, err, `unknown state store backend "unknown"`)
+
+	var options sm.StoreOptions
+	sm.RegisterStoreBackend("test", func(db dbm.DB, o sm.StoreOptions) (sm.Store, error) {
+		options = o
+		return sm.NewStore(db, o), nil
+	})
+	require.Contains(t, sm.StoreBackends(), "test")
+	_, err = sm.NewStoreFromBackend("test", stateDB, sm.StoreOptions{BackendConn: "conn"})
+	require.NoError(t, err)
+	require.Equal(t, "conn", options.BackendConn)
+
+	require.Panics(t, func() {
+		sm.RegisterStoreBackend(sm.DefaultStoreBackend, func(dbm.DB, sm.StoreOptions) (sm.Store, error) {
+			return nil, nil
+		})
+	})
+}
+
+func TestReadReplicaFromBackend(t *testing.T) {
+	stateDB := dbm.NewMemDB()
+	store := sm.NewStore(stateDB, sm.StoreOptions{})
+	replica, err := sm.NewReadReplicaFromBackend(sm.DefaultStoreBackend, stateDB, sm.StoreOptions{})
+	require.NoError(t, err)
+
+	// The replica reads what the store writes, but cannot write itself.
+	require.NoError(t, store.SaveApplicationRetainHeight(10))
+	height, err := replica.GetApplicationRetainHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 10, height)
+	require.ErrorIs(t, replica.SaveApplicationRetainHeight(20), sm.ErrReadOnly)
+
+	// Closing the replica leaves the database of the store open.
+	require.NoError(t, replica.Close())
+	require.NoError(t, store.SaveApplicationRetainHeight(20))
+	height, err = store.GetApplicationRetainHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 20, height)
+}
@@ -3,6 +3,7 @@ package state_test
 import (
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,11 +17,9 @@ import (
 	dbm "github.com/cometbft/cometbft-db"
 
 	abci "github.com/cometbft/cometbft/abci/types"
-	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/internal/test"
 	"github.com/cometbft/cometbft/libs/log"
-	cmtrand "github.com/cometbft/cometbft/libs/rand"
 	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
 	sm "github.com/cometbft/cometbft/state"
 	"github.com/cometbft/cometbft/store"
@@ -134,7 +133,7 @@ func TestPruneStates(t *testing.T) {
 
 			// Generate a bunch of state data. Validators change for heights ending with 3, and
 			// parameters when ending with 5.
-			validator := &types.Validator{Address: cmtrand.Bytes(crypto.AddressSize), VotingPower: 100, PubKey: pk}
+			validator := &types.Validator{Address: pk.Address(), VotingPower: 100, PubKey: pk}
 			validatorSet := &types.ValidatorSet{
 				Validators: []*types.Validator{validator},
 				Proposer:   validator,
@@ -223,6 +222,131 @@ func TestPruneStates(t *testing.T) {
 	}
 }
 
+func TestLoadConsensusParamsInfo(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	val, _ := types.RandValidator(true, 10)
+	vals := types.NewValidatorSet([]*types.Validator{val})
+
+	// Consensus params change at heights ending with 5.
+	paramsChanged := int64(0)
+	for h := int64(1); h <= 20; h++ {
+		if paramsChanged == 0 || h%10 == 5 {
+			paramsChanged = h
+		}
+		err := stateStore.Save(sm.State{
+			InitialHeight:   1,
+			LastBlockHeight: h - 1,
+			Validators:      vals,
+			NextValidators:  vals,
+			LastValidators:  vals,
+			ConsensusParams: types.ConsensusParams{
+				Block: types.BlockParams{MaxBytes: 10e6 + paramsChanged},
+			},
+			LastHeightValidatorsChanged:      1,
+			LastHeightConsensusParamsChanged: paramsChanged,
+		})
+		require.NoError(t, err)
+	}
+
+	for h, changed := range map[int64]int64{1: 1, 4: 1, 5: 5, 14: 5, 15: 15, 20: 15} {
+		params, lastHeightChanged, err := stateStore.LoadConsensusParamsInfo(h)
+		require.NoError(t, err, "height %v", h)
+		require.Equal(t, changed, lastHeightChanged, "height %v", h)
+		require.Equal(t, 10e6+changed, params.Block.MaxBytes, "height %v", h)
+	}
+
+	// Pruning keeps the params at the height they last changed, so heights
+	// above the pruned range still resolve to it.
+	err := stateStore.PruneStates(1, 18, 17)
+	require.NoError(t, err)
+
+	for h := int64(1); h <= 20; h++ {
+		params, lastHeightChanged, err := stateStore.LoadConsensusParamsInfo(h)
+		if h == 15 || h >= 18 {
+			require.NoError(t, err, "height %v", h)
+			require.EqualValues(t, 15, lastHeightChanged, "height %v", h)
+			require.EqualValues(t, 10e6+15, params.Block.MaxBytes, "height %v", h)
+		} else {
+			require.Error(t, err, "height %v", h)
+			require.Zero(t, lastHeightChanged, "height %v", h)
+			require.Empty(t, params, "height %v", h)
+		}
+	}
+}
+
+func TestStoreChecksums(t *testing.T) {
+	stateDB := dbm.NewMemDB()
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		VerifyChecksums: true,
+	})
+	val, _ := types.RandValidator(true, 10)
+	vals := types.NewValidatorSet([]*types.Validator{val})
+	state := sm.State{
+		InitialHeight:                    1,
+		LastBlockHeight:                  0,
+		Validators:                       vals,
+		NextValidators:                   vals,
+		LastValidators:                   vals,
+		ConsensusParams:                  *types.DefaultConsensusParams(),
+		LastHeightValidatorsChanged:      1,
+		LastHeightConsensusParamsChanged: 1,
+	}
+	require.NoError(t, stateStore.Save(state))
+	require.NoError(t, stateStore.SaveFinalizeBlockResponse(1, &abci.ResponseFinalizeBlock{AppHash: []byte("apphash")}))
+
+	_, err := stateStore.Load()
+	require.NoError(t, err)
+	_, err = stateStore.LoadValidators(1)
+	require.NoError(t, err)
+	_, err = stateStore.LoadFinalizeBlockResponse(1)
+	require.NoError(t, err)
+
+	corrupt := func(key string) {
+		bz, err := stateDB.Get([]byte(key))
+		require.NoError(t, err)
+		require.NotEmpty(t, bz)
+		bz = append([]byte{}, bz...)
+		bz[len(bz)-1] ^= 0xff
+		require.NoError(t, stateDB.Set([]byte(key), bz))
+	}
+
+	corrupt("stateKey")
+	_, err = stateStore.Load()
+	var errCorrupted sm.ErrCorruptedRecord
+	require.ErrorAs(t, err, &errCorrupted)
+	require.Equal(t, "stateKey", errCorrupted.Key)
+	require.ErrorIs(t, err, sm.ErrChecksumMismatch)
+
+	corrupt("validatorsKey:1")
+	_, err = stateStore.LoadValidators(1)
+	require.ErrorIs(t, err, sm.ErrChecksumMismatch)
+
+	corrupt("abciResponsesKey:1")
+	_, err = stateStore.LoadFinalizeBlockResponse(1)
+	require.ErrorIs(t, err, sm.ErrChecksumMismatch)
+
+	corrupt("lastABCIResponseKey")
+	_, err = stateStore.LoadLastFinalizeBlockResponse(1)
+	require.ErrorIs(t, err, sm.ErrChecksumMismatch)
+
+	// Records without checksum, written by previous versions, are not verified.
+	require.NoError(t, stateDB.Delete([]byte("checksum:validatorsKey:1")))
+	require.NoError(t, sm.SaveValidatorsInfo(stateDB, 4, 4, vals))
+	require.NoError(t, stateDB.Delete([]byte("checksum:validatorsKey:4")))
+	_, err = stateStore.LoadValidators(4)
+	require.NoError(t, err)
+
+	// Records that cannot be decoded are reported as corrupted, whether or
+	// not checksums are verified.
+	require.NoError(t, stateDB.Set([]byte("stateKey"), []byte("garbage")))
+	require.NoError(t, stateDB.Delete([]byte("checksum:stateKey")))
+	_, err = sm.NewStore(stateDB, sm.StoreOptions{}).Load()
+	require.ErrorAs(t, err, &errCorrupted)
+	require.NotErrorIs(t, err, sm.ErrChecksumMismatch)
+}
+
 func TestTxResultsHash(t *testing.T) {
 	txResults := []*abci.ExecTxResult{
 		{Code: 32, Data: []byte("Hello"), Log: "Huh?"},
@@ -352,6 +476,74 @@ func TestMinRetainHeight(t *testing.T) {
 	require.Equal(t, int64(10), minHeight)
 }
 
+func TestMinRetainHeightKeepRecent(t *testing.T) {
+	state, bs, txIndexer, blockIndexer, callbackF, stateStore := makeStateAndBlockStoreAndIndexers()
+	defer callbackF()
+	state.LastBlockHeight = 9
+	fillStore(t, 10, stateStore, bs, state, nil)
+	require.NoError(t, initStateStoreRetainHeights(stateStore, 0, 0, 0))
+
+	keepRecent := sm.PrunerKeepRecent{Blocks: 4}
+	pruner := sm.NewPruner(stateStore, bs, blockIndexer, txIndexer, log.TestingLogger(), sm.WithPrunerKeepRecent(keepRecent))
+	require.Equal(t, int64(7), pruner.FindMinRetainHeight())
+
+	// The highest of the application and the keep-recent retain heights wins.
+	require.NoError(t, stateStore.SaveApplicationRetainHeight(9))
+	require.Equal(t, int64(9), pruner.FindMinRetainHeight())
+	require.NoError(t, stateStore.SaveApplicationRetainHeight(5))
+	require.Equal(t, int64(7), pruner.FindMinRetainHeight())
+
+	// The data companion caps the pruning.
+	pruner = sm.NewPruner(stateStore, bs, blockIndexer, txIndexer, log.TestingLogger(),
+		sm.WithPrunerKeepRecent(keepRecent), sm.WithPrunerCompanionEnabled())
+	require.NoError(t, stateStore.SaveCompanionBlockRetainHeight(6))
+	require.Equal(t, int64(6), pruner.FindMinRetainHeight())
+
+	// A window larger than the chain does not prune anything.
+	pruner = sm.NewPruner(stateStore, bs, blockIndexer, txIndexer, log.TestingLogger(),
+		sm.WithPrunerKeepRecent(sm.PrunerKeepRecent{Blocks: 100}))
+	require.Equal(t, int64(5), pruner.FindMinRetainHeight())
+}
+
+func TestABCIResPruningKeepRecent(t *testing.T) {
+	state, bs, txIndexer, blockIndexer, callbackF, stateStore := makeStateAndBlockStoreAndIndexers()
+	defer callbackF()
+	state.LastBlockHeight = 9
+	response := &abci.ResponseFinalizeBlock{
+		TxResults: []*abci.ExecTxResult{
+			{Code: 32, Data: []byte("Hello"), Log: "Huh?"},
+		},
+	}
+	fillStore(t, 10, stateStore, bs, state, response)
+
+	// Without the data companion, the responses are pruned to the window.
+	pruner := sm.NewPruner(stateStore, bs, blockIndexer, txIndexer, log.TestingLogger(),
+		sm.WithPrunerKeepRecent(sm.PrunerKeepRecent{ABCIResponses: 3}))
+	require.Equal(t, int64(8), pruner.PruneABCIResToRetainHeight(0))
+	for h := int64(1); h < 8; h++ {
+		_, err := stateStore.LoadFinalizeBlockResponse(h)
+		require.Error(t, err)
+	}
+	for h := int64(8); h <= 10; h++ {
+		_, err := stateStore.LoadFinalizeBlockResponse(h)
+		require.NoError(t, err)
+	}
+
+	// With the data companion, its retain height caps the pruning.
+	state, bs, txIndexer, blockIndexer, callbackF2, stateStore := makeStateAndBlockStoreAndIndexers()
+	defer callbackF2()
+	state.LastBlockHeight = 9
+	fillStore(t, 10, stateStore, bs, state, response)
+	pruner = sm.NewPruner(stateStore, bs, blockIndexer, txIndexer, log.TestingLogger(),
+		sm.WithPrunerKeepRecent(sm.PrunerKeepRecent{ABCIResponses: 3}), sm.WithPrunerCompanionEnabled())
+	require.NoError(t, stateStore.SaveABCIResRetainHeight(5))
+	require.Equal(t, int64(5), pruner.PruneABCIResToRetainHeight(0))
+	_, err := stateStore.LoadFinalizeBlockResponse(4)
+	require.Error(t, err)
+	_, err = stateStore.LoadFinalizeBlockResponse(5)
+	require.NoError(t, err)
+}
+
 func TestABCIResPruningStandalone(t *testing.T) {
 	stateDB := dbm.NewMemDB()
 	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
@@ -502,6 +694,40 @@ func TestFinalizeBlockResponsePruning(t *testing.T) {
 	})
 }
 
+func TestCompressABCIResponses(t *testing.T) {
+	stateDB := dbm.NewMemDB()
+	resp := &abci.ResponseFinalizeBlock{
+		TxResults: []*abci.ExecTxResult{
+			{Code: 1, Log: strings.Repeat("insufficient funds; ", 100)},
+		},
+		AppHash: []byte("apphash"),
+	}
+	uncompressed, err := resp.Marshal()
+	require.NoError(t, err)
+
+	// Written uncompressed.
+	require.NoError(t, sm.NewStore(stateDB, sm.StoreOptions{}).SaveFinalizeBlockResponse(1, resp))
+
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		CompressABCIResponses: true,
+		VerifyChecksums:       true,
+	})
+	require.NoError(t, stateStore.SaveFinalizeBlockResponse(2, resp))
+	bz, err := stateDB.Get([]byte("abciResponsesKey:2"))
+	require.NoError(t, err)
+	require.Less(t, len(bz), len(uncompressed))
+
+	// Both the compressed and the uncompressed responses are read, whether
+	// or not the store compresses them.
+	for _, store := range []sm.Store{stateStore, sm.NewStore(stateDB, sm.StoreOptions{})} {
+		for _, height := range []int64{1, 2} {
+			loaded, err := store.LoadFinalizeBlockResponse(height)
+			require.NoError(t, err)
+			assert.Equal(t, resp, loaded)
+		}
+	}
+}
+
 func TestLastFinalizeBlockResponses(t *testing.T) {
 	// create an empty state store.
 	t.Run("Not persisting responses", func(t *testing.T) {
@@ -12,10 +12,16 @@ func TxPreCheck(state State) mempl.PreCheckFunc {
 	if maxBytes == -1 {
 		maxBytes = int64(types.MaxBlockSizeBytes)
 	}
-	maxDataBytes := types.MaxDataBytesNoEvidence(
-		maxBytes,
-		state.Validators.Size(),
-	)
+	var maxDataBytes int64
+	if state.LastBlockHeight >= 1 && state.ConsensusParams.Validator.BLSAggregationEnabled(state.LastBlockHeight) {
+		// The last commit of the next block is aggregated.
+		maxDataBytes = types.MaxDataBytesAggregatedCommit(maxBytes, 0, state.Validators.Size())
+	} else {
+		maxDataBytes = types.MaxDataBytesNoEvidence(
+			maxBytes,
+			state.Validators.Size(),
+		)
+	}
 	return mempl.PreCheckMaxBytes(maxDataBytes)
 }
 
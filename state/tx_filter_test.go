@@ -47,3 +47,20 @@ func TestTxFilter(t *testing.T) {
 		}
 	}
 }
+
+func TestTxFilterAggregatedCommit(t *testing.T) {
+	genDoc := randomGenesisDoc()
+	genDoc.ConsensusParams.Block.MaxBytes = 3000
+	genDoc.ConsensusParams.Evidence.MaxBytes = 1500
+
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	state, err := stateStore.LoadFromDBOrGenesisDoc(genDoc)
+	require.NoError(t, err)
+	// The last commit of the next block is aggregated, and bigger.
+	state.LastBlockHeight = 1
+	state.ConsensusParams.Validator.BLSAggregationEnableHeight = 1
+
+	f := sm.TxPreCheck(state)
+	assert.Nil(t, f(types.Tx(cmtrand.Bytes(2024))))
+	assert.NotNil(t, f(types.Tx(cmtrand.Bytes(2025))))
+}
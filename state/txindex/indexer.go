@@ -2,6 +2,8 @@ package txindex
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/binary"
 	"errors"
 
 	"github.com/cometbft/cometbft/libs/log"
@@ -29,6 +31,10 @@ type TxIndexer interface {
 	// Search allows you to query for transactions.
 	Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error)
 
+	// SearchPage returns a page of the transactions matching the query, in
+	// the order of their heights and indexes.
+	SearchPage(ctx context.Context, q *query.Query, pagination Pagination) (*Page, error)
+
 	//Set Logger
 	SetLogger(l log.Logger)
 
@@ -65,3 +71,67 @@ func (b *Batch) Size() int {
 
 // ErrorEmptyHash indicates empty hash
 var ErrorEmptyHash = errors.New("transaction hash cannot be empty")
+
+// Cursor is the position, a height and an index in the block, of the last
+// transaction of a page of search results: the next page starts after it.
+type Cursor struct {
+	Height int64
+	Index  uint32
+}
+
+// ErrInvalidCursor indicates a cursor that cannot be parsed.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// String returns the opaque encoding of the cursor, parsed by ParseCursor.
+func (c Cursor) String() string {
+	bz := make([]byte, 12)
+	binary.BigEndian.PutUint64(bz, uint64(c.Height))
+	binary.BigEndian.PutUint32(bz[8:], c.Index)
+	return base64.RawURLEncoding.EncodeToString(bz)
+}
+
+// Before reports whether the cursor precedes other in increasing order.
+func (c Cursor) Before(other Cursor) bool {
+	if c.Height == other.Height {
+		return c.Index < other.Index
+	}
+	return c.Height < other.Height
+}
+
+// ParseCursor parses a cursor encoded with Cursor.String.
+func ParseCursor(s string) (Cursor, error) {
+	bz, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(bz) != 12 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	c := Cursor{
+		Height: int64(binary.BigEndian.Uint64(bz)),
+		Index:  binary.BigEndian.Uint32(bz[8:]),
+	}
+	if c.Height <= 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// Pagination selects a page of search results.
+type Pagination struct {
+	// OrderDesc orders the results by decreasing heights and indexes,
+	// instead of increasing ones.
+	OrderDesc bool
+	// After is the cursor of the previous page, nil for the first page.
+	After *Cursor
+	// Limit is the maximum number of results of the page, all of them if 0.
+	Limit int
+}
+
+// Page is a page of search results.
+type Page struct {
+	Results []*abci.TxResult
+	// TotalCount is the number of transactions matching the query, over all
+	// the pages. It is only counted for the first page, without a cursor, and
+	// is -1 for the next ones.
+	TotalCount int
+	// Next is the cursor of the next page, nil if this page is the last one.
+	Next *Cursor
+}
@@ -2,6 +2,8 @@ package txindex
 
 import (
 	"context"
+	"fmt"
+	"sync/atomic"
 
 	"github.com/cometbft/cometbft/libs/service"
 	"github.com/cometbft/cometbft/state/indexer"
@@ -23,6 +25,24 @@ type IndexerService struct {
 	blockIdxr        indexer.BlockIndexer
 	eventBus         *types.EventBus
 	terminateOnError bool
+
+	intents       *IntentLog         // nil if the intents are not recorded
+	resultsLoader BlockResultsLoader // loads the heights to re-index
+
+	height atomic.Int64 // last height processed
+}
+
+// IndexerServiceOption sets an optional parameter on the IndexerService.
+type IndexerServiceOption func(*IndexerService)
+
+// WithIntentLog records the heights being indexed in the given intent log.
+// When starting, the service re-indexes the heights with a pending intent,
+// using the blocks and results returned by loader.
+func WithIntentLog(intents *IntentLog, loader BlockResultsLoader) IndexerServiceOption {
+	return func(is *IndexerService) {
+		is.intents = intents
+		is.resultsLoader = loader
+	}
 }
 
 // NewIndexerService returns a new service instance.
@@ -31,6 +51,7 @@ func NewIndexerService(
 	blockIdxr indexer.BlockIndexer,
 	eventBus *types.EventBus,
 	terminateOnError bool,
+	options ...IndexerServiceOption,
 ) *IndexerService {
 
 	is := &IndexerService{
@@ -39,13 +60,58 @@ func NewIndexerService(
 		eventBus:         eventBus,
 		terminateOnError: terminateOnError,
 	}
+	for _, option := range options {
+		option(is)
+	}
 	is.BaseService = *service.NewBaseService(nil, "IndexerService", is)
 	return is
 }
 
+// recoverIntents re-indexes the heights with an intent left behind by a crash
+// or an indexing failure. The intents of the heights that cannot be
+// re-indexed are kept, to retry on the next start.
+func (is *IndexerService) recoverIntents() error {
+	heights, err := is.intents.Pending()
+	if err != nil {
+		return fmt.Errorf("loading index intents: %w", err)
+	}
+
+	for _, height := range heights {
+		is.Logger.Info("re-indexing height with a pending index intent", "height", height)
+		txs, resp, err := is.resultsLoader(height)
+		if err == nil {
+			err = IndexBlockResults(is.blockIdxr, is.txIdxr, height, txs, resp)
+		}
+		if err != nil {
+			if is.terminateOnError {
+				return fmt.Errorf("re-indexing height %d: %w", height, err)
+			}
+			is.Logger.Error("failed to re-index height with a pending index intent", "height", height, "err", err)
+			continue
+		}
+		if err := is.intents.Clear(height); err != nil {
+			return fmt.Errorf("clearing index intent of height %d: %w", height, err)
+		}
+	}
+	return nil
+}
+
+// IndexedHeight returns the last height whose block and transactions were
+// processed by the service since it started, whether indexing them succeeded
+// or not, or 0 if none was.
+func (is *IndexerService) IndexedHeight() int64 {
+	return is.height.Load()
+}
+
 // OnStart implements service.Service by subscribing for all transactions
 // and indexing them by events.
 func (is *IndexerService) OnStart() error {
+	if is.intents != nil {
+		if err := is.recoverIntents(); err != nil {
+			return err
+		}
+	}
+
 	// Use SubscribeUnbuffered here to ensure both subscriptions does not get
 	// canceled due to not pulling messages fast enough. Cause this might
 	// sometimes happen when there are no other subscribers.
@@ -72,6 +138,22 @@ func (is *IndexerService) OnStart() error {
 				height := eventNewBlockEvents.Height
 				numTxs := eventNewBlockEvents.NumTxs
 
+				// Record the intent before writing any index key, so that the
+				// height is re-indexed on the next start if indexing does not
+				// complete.
+				if is.intents != nil {
+					if err := is.intents.Record(height); err != nil {
+						is.Logger.Error("failed to record index intent", "height", height, "err", err)
+						if is.terminateOnError {
+							if err := is.Stop(); err != nil {
+								is.Logger.Error("failed to stop", "err", err)
+							}
+							return
+						}
+					}
+				}
+
+				indexed := true
 				batch := NewBatch(numTxs)
 
 				for i := int64(0); i < numTxs; i++ {
@@ -79,6 +161,7 @@ func (is *IndexerService) OnStart() error {
 					txResult := msg2.Data().(types.EventDataTx).TxResult
 
 					if err = batch.Add(&txResult); err != nil {
+						indexed = false
 						is.Logger.Error(
 							"failed to add tx to batch",
 							"height", height,
@@ -96,6 +179,7 @@ func (is *IndexerService) OnStart() error {
 				}
 
 				if err := is.blockIdxr.Index(eventNewBlockEvents); err != nil {
+					indexed = false
 					is.Logger.Error("failed to index block", "height", height, "err", err)
 					if is.terminateOnError {
 						if err := is.Stop(); err != nil {
@@ -108,6 +192,7 @@ func (is *IndexerService) OnStart() error {
 				}
 
 				if err = is.txIdxr.AddBatch(batch); err != nil {
+					indexed = false
 					is.Logger.Error("failed to index block txs", "height", height, "err", err)
 					if is.terminateOnError {
 						if err := is.Stop(); err != nil {
@@ -118,6 +203,15 @@ func (is *IndexerService) OnStart() error {
 				} else {
 					is.Logger.Debug("indexed transactions", "height", height, "num_txs", numTxs)
 				}
+
+				// Keep the intent of the heights that failed to be indexed, to
+				// re-index them on the next start.
+				if is.intents != nil && indexed {
+					if err := is.intents.Clear(height); err != nil {
+						is.Logger.Error("failed to clear index intent", "height", height, "err", err)
+					}
+				}
+				is.height.Store(height)
 			}
 		}
 	}()
@@ -1,6 +1,7 @@
 package txindex_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -18,7 +19,8 @@ import (
 )
 
 func TestIndexerServiceIndexesBlocks(t *testing.T) {
-	_, txIndexer, blockIndexer, eventBus := createTestSetup(t)
+	service, txIndexer, blockIndexer, eventBus := createTestSetup(t)
+	require.Zero(t, service.IndexedHeight())
 
 	height := int64(1)
 
@@ -46,6 +48,71 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	res, err = txIndexer.Get(types.Tx(fmt.Sprintf("bar%d", height)).Hash())
 	require.NoError(t, err)
 	require.Equal(t, txResult2, res)
+
+	require.Equal(t, height, service.IndexedHeight())
+}
+
+func TestIndexerServiceIntentLog(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() { _ = eventBus.Stop() })
+
+	store := db.NewMemDB()
+	txIndexer := kv.NewTxIndex(store)
+	blockIndexer := blockidxkv.New(db.NewPrefixDB(store, []byte("block_events")))
+
+	// Heights 1 and 2 were left with an intent, but only the results of
+	// height 1 are available.
+	intents := txindex.NewIntentLog(db.NewMemDB())
+	require.NoError(t, intents.Record(2))
+	require.NoError(t, intents.Record(1))
+	pending, err := intents.Pending()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2}, pending)
+
+	loader := func(height int64) (types.Txs, *abci.ResponseFinalizeBlock, error) {
+		if height != 1 {
+			return nil, nil, errors.New("no results")
+		}
+		events, txResult1, txResult2 := getEventsAndResults(height)
+		return types.Txs{txResult1.Tx, txResult2.Tx}, &abci.ResponseFinalizeBlock{
+			Events:    events.Events,
+			TxResults: []*abci.ExecTxResult{&txResult1.Result, &txResult2.Result},
+		}, nil
+	}
+
+	service := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false,
+		txindex.WithIntentLog(intents, loader))
+	service.SetLogger(log.TestingLogger())
+	require.NoError(t, service.Start())
+	t.Cleanup(func() { _ = service.Stop() })
+
+	// Height 1 is re-indexed when starting, and the intent of height 2 is
+	// kept to retry on the next start.
+	ok, err := blockIndexer.Has(1)
+	require.NoError(t, err)
+	require.True(t, ok)
+	res, err := txIndexer.Get(types.Tx("bar1").Hash())
+	require.NoError(t, err)
+	require.EqualValues(t, 1, res.Index)
+	pending, err = intents.Pending()
+	require.NoError(t, err)
+	require.Equal(t, []int64{2}, pending)
+
+	// The intents of the heights indexed while running are cleared.
+	events, txResult1, txResult2 := getEventsAndResults(3)
+	require.NoError(t, eventBus.PublishEventNewBlockEvents(events))
+	require.NoError(t, eventBus.PublishEventTx(types.EventDataTx{TxResult: *txResult1}))
+	require.NoError(t, eventBus.PublishEventTx(types.EventDataTx{TxResult: *txResult2}))
+	require.Eventually(t, func() bool {
+		ok, err := blockIndexer.Has(3)
+		require.NoError(t, err)
+		pending, err = intents.Pending()
+		require.NoError(t, err)
+		return ok && len(pending) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, []int64{2}, pending)
 }
 
 func createTestSetup(t *testing.T) (*txindex.IndexerService, *kv.TxIndex, indexer.BlockIndexer, *types.EventBus) {
@@ -0,0 +1,121 @@
+package txindex
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/state/indexer"
+	"github.com/cometbft/cometbft/types"
+)
+
+const intentKeyPrefix = "indexIntent:"
+
+// IntentLog is a write-ahead log of the heights being indexed. An intent is
+// recorded before writing the index keys of a block and cleared once both the
+// block and its transactions are indexed, so that a height with an intent
+// left behind by a crash or an indexing failure can be re-indexed.
+type IntentLog struct {
+	db dbm.DB
+}
+
+// NewIntentLog returns an intent log keeping the intents in db, which may be
+// shared with other stores.
+func NewIntentLog(db dbm.DB) *IntentLog {
+	return &IntentLog{db: db}
+}
+
+func intentKey(height int64) []byte {
+	return []byte(fmt.Sprintf("%s%d", intentKeyPrefix, height))
+}
+
+// Record durably records the intent of indexing the given height.
+func (l *IntentLog) Record(height int64) error {
+	return l.db.SetSync(intentKey(height), []byte{})
+}
+
+// Clear clears the intent of indexing the given height, once it is indexed.
+func (l *IntentLog) Clear(height int64) error {
+	return l.db.Delete(intentKey(height))
+}
+
+// Pending returns the heights with an intent that was not cleared, in
+// ascending order.
+func (l *IntentLog) Pending() ([]int64, error) {
+	it, err := dbm.IteratePrefix(l.db, []byte(intentKeyPrefix))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var heights []int64
+	for ; it.Valid(); it.Next() {
+		key := strings.TrimPrefix(string(it.Key()), intentKeyPrefix)
+		height, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index intent key %q: %w", it.Key(), err)
+		}
+		heights = append(heights, height)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights, nil
+}
+
+// BlockResultsLoader loads the transactions of the block at the given height
+// and the response of the application to it, to re-index the height.
+type BlockResultsLoader func(height int64) (types.Txs, *abci.ResponseFinalizeBlock, error)
+
+// IndexBlockResults indexes the block events and transaction results of the
+// block at the given height to the given indexers.
+func IndexBlockResults(
+	blockIndexer indexer.BlockIndexer,
+	txIndexer TxIndexer,
+	height int64,
+	txs types.Txs,
+	resp *abci.ResponseFinalizeBlock,
+) error {
+	if len(txs) != len(resp.TxResults) {
+		return fmt.Errorf("block at height %d has %d txs, but %d tx results",
+			height, len(txs), len(resp.TxResults))
+	}
+
+	numTxs := len(resp.TxResults)
+	if numTxs > 0 {
+		batch := NewBatch(int64(numTxs))
+
+		for idx, txResult := range resp.TxResults {
+			tr := abci.TxResult{
+				Height: height,
+				Index:  uint32(idx),
+				Tx:     txs[idx],
+				Result: *txResult,
+			}
+
+			if err := batch.Add(&tr); err != nil {
+				return fmt.Errorf("adding tx to batch: %w", err)
+			}
+		}
+
+		if err := txIndexer.AddBatch(batch); err != nil {
+			return fmt.Errorf("tx event re-index at height %d failed: %w", height, err)
+		}
+	}
+
+	e := types.EventDataNewBlockEvents{
+		Height: height,
+		Events: resp.Events,
+		NumTxs: int64(numTxs),
+	}
+	if err := blockIndexer.Index(e); err != nil {
+		return fmt.Errorf("block event re-index at height %d failed: %w", height, err)
+	}
+
+	return nil
+}
@@ -21,8 +21,10 @@ import (
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	idxutil "github.com/cometbft/cometbft/internal/indexer"
+	"github.com/cometbft/cometbft/libs/compress"
 	"github.com/cometbft/cometbft/libs/pubsub/query"
 	"github.com/cometbft/cometbft/libs/pubsub/query/syntax"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	"github.com/cometbft/cometbft/state/indexer"
 	"github.com/cometbft/cometbft/state/txindex"
 	"github.com/cometbft/cometbft/types"
@@ -43,6 +45,15 @@ type TxIndex struct {
 	store dbm.DB
 	// Number the events in the event list
 	eventSeq int64
+	// compress is true if the tx results are compressed with zstd.
+	compress bool
+
+	// maxHeight is an upper bound of the heights of the indexed txs, which
+	// bounds the scans of the searches after a cursor. It is loaded from the
+	// height index by the first search needing it.
+	maxHeightMtx    cmtsync.Mutex
+	maxHeight       int64
+	maxHeightLoaded bool
 
 	log log.Logger
 }
@@ -175,11 +186,131 @@ func (txi *TxIndex) getIndexerRetainHeight() (int64, error) {
 	return height, nil
 }
 
+// LatestHeight returns the highest height at which a transaction is indexed,
+// or 0 if no transaction is indexed. It scans the whole height index and is
+// meant for offline tooling.
+func (txi *TxIndex) LatestHeight() (int64, error) {
+	it, err := dbm.IteratePrefix(txi.store, startKey(types.TxHeightKey))
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+
+	var latest int64
+	for ; it.Valid(); it.Next() {
+		height, err := extractHeightFromKey(it.Key())
+		if err != nil {
+			return 0, fmt.Errorf("invalid tx height key %q: %w", it.Key(), err)
+		}
+		if height > latest {
+			latest = height
+		}
+	}
+	return latest, it.Error()
+}
+
+// TruncateAbove removes the transactions indexed above the given height,
+// along with their events. It returns the number of removed transactions.
+// It scans the whole store and is meant for offline tooling.
+func (txi *TxIndex) TruncateAbove(height int64) (int64, error) {
+	// Find the txs whose indexed result is above the height. The same tx may
+	// have been included at several heights, in which case only the latest
+	// result is indexed.
+	it, err := dbm.IteratePrefix(txi.store, startKey(types.TxHeightKey))
+	if err != nil {
+		return 0, err
+	}
+	var hashes [][]byte
+	for ; it.Valid(); it.Next() {
+		keyHeight, err := extractHeightFromKey(it.Key())
+		if err != nil {
+			it.Close()
+			return 0, fmt.Errorf("invalid tx height key %q: %w", it.Key(), err)
+		}
+		if keyHeight > height {
+			hashes = append(hashes, it.Value())
+		}
+	}
+	if err := it.Error(); err != nil {
+		it.Close()
+		return 0, err
+	}
+	it.Close()
+
+	removed := make(map[string]struct{})
+	for _, hash := range hashes {
+		result, err := txi.Get(hash)
+		if err != nil {
+			return 0, err
+		}
+		if result != nil && result.Height > height {
+			removed[string(hash)] = struct{}{}
+		}
+	}
+
+	// Remove the height and event keys above the height, as well as those of
+	// the removed txs at lower heights.
+	it, err = txi.store.Iterator(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		if !isTagKey(it.Key()) {
+			continue
+		}
+		if _, ok := removed[string(it.Value())]; ok {
+			keys = append(keys, it.Key())
+			continue
+		}
+		if keyHeight, err := extractHeightFromKey(it.Key()); err == nil && keyHeight > height {
+			keys = append(keys, it.Key())
+		}
+	}
+	if err := it.Error(); err != nil {
+		it.Close()
+		return 0, err
+	}
+	it.Close()
+
+	batch := txi.store.NewBatch()
+	defer batch.Close()
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return 0, err
+		}
+	}
+	for hash := range removed {
+		if err := batch.Delete([]byte(hash)); err != nil {
+			return 0, err
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return 0, err
+	}
+	return int64(len(removed)), nil
+}
+
+// TxIndexOption sets an optional parameter on the TxIndex.
+type TxIndexOption func(*TxIndex)
+
+// WithCompression compresses the tx results with zstd. The tx results are
+// read whether or not they were compressed.
+func WithCompression() TxIndexOption {
+	return func(txi *TxIndex) {
+		txi.compress = true
+	}
+}
+
 // NewTxIndex creates new KV indexer.
-func NewTxIndex(store dbm.DB) *TxIndex {
-	return &TxIndex{
+func NewTxIndex(store dbm.DB, options ...TxIndexOption) *TxIndex {
+	txi := &TxIndex{
 		store: store,
 	}
+	for _, option := range options {
+		option(txi)
+	}
+	return txi
 }
 
 func (txi *TxIndex) SetLogger(l log.Logger) {
@@ -200,6 +331,10 @@ func (txi *TxIndex) Get(hash []byte) (*abci.TxResult, error) {
 	if rawBytes == nil {
 		return nil, nil
 	}
+	rawBytes, err = compress.Decompress(rawBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error reading TxResult: %w", err)
+	}
 
 	txResult := new(abci.TxResult)
 	err = proto.Unmarshal(rawBytes, txResult)
@@ -233,7 +368,7 @@ func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
 			return err
 		}
 
-		rawBytes, err := proto.Marshal(result)
+		rawBytes, err := txi.marshalResult(result)
 		if err != nil {
 			return err
 		}
@@ -244,7 +379,23 @@ func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
 		}
 	}
 
-	return storeBatch.WriteSync()
+	if err := storeBatch.WriteSync(); err != nil {
+		return err
+	}
+	for _, result := range b.Ops {
+		txi.heightIndexed(result.Height)
+	}
+	return nil
+}
+
+// marshalResult returns the encoding of the tx result, compressed if the
+// index is.
+func (txi *TxIndex) marshalResult(result *abci.TxResult) ([]byte, error) {
+	rawBytes, err := proto.Marshal(result)
+	if err != nil || !txi.compress {
+		return rawBytes, err
+	}
+	return compress.Compress(rawBytes), nil
 }
 
 func (txi *TxIndex) deleteResult(result *abci.TxResult, batch dbm.Batch) error {
@@ -304,7 +455,7 @@ func (txi *TxIndex) Index(result *abci.TxResult) error {
 		return err
 	}
 
-	rawBytes, err := proto.Marshal(result)
+	rawBytes, err := txi.marshalResult(result)
 	if err != nil {
 		return err
 	}
@@ -314,7 +465,42 @@ func (txi *TxIndex) Index(result *abci.TxResult) error {
 		return err
 	}
 
-	return b.WriteSync()
+	if err := b.WriteSync(); err != nil {
+		return err
+	}
+	txi.heightIndexed(result.Height)
+	return nil
+}
+
+// heightIndexed raises the upper bound of the heights of the indexed txs to
+// the height of an indexed tx.
+func (txi *TxIndex) heightIndexed(height int64) {
+	txi.maxHeightMtx.Lock()
+	defer txi.maxHeightMtx.Unlock()
+	if height > txi.maxHeight {
+		txi.maxHeight = height
+	}
+}
+
+// heightBound returns an upper bound of the heights of the indexed txs,
+// scanning the height index the first time.
+func (txi *TxIndex) heightBound() (int64, error) {
+	txi.maxHeightMtx.Lock()
+	loaded := txi.maxHeightLoaded
+	txi.maxHeightMtx.Unlock()
+	if !loaded {
+		// The txs indexed during the scan raise the bound themselves.
+		latest, err := txi.LatestHeight()
+		if err != nil {
+			return 0, err
+		}
+		txi.heightIndexed(latest)
+	}
+
+	txi.maxHeightMtx.Lock()
+	defer txi.maxHeightMtx.Unlock()
+	txi.maxHeightLoaded = true
+	return txi.maxHeight, nil
 }
 
 func (txi *TxIndex) deleteEvents(result *abci.TxResult, batch dbm.Batch) error {
@@ -392,9 +578,169 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 // Search will exit early and return any result fetched so far,
 // when a message is received on the context chan.
 func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
+	positions, err := txi.search(ctx, q, nil, false, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*abci.TxResult, 0, len(positions))
+RESULTS_LOOP:
+	for h := range positions {
+		res, err := txi.Get([]byte(h))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
+		}
+		results = append(results, res)
+		// Potentially exit early.
+		select {
+		case <-ctx.Done():
+			break RESULTS_LOOP
+		default:
+		}
+	}
+
+	return results, nil
+}
+
+// SearchPage performs a search using the given query, like Search, and
+// returns a page of its results. The matching transactions are ordered by
+// their positions in the keys of the index, so only the transactions of the
+// page are loaded.
+//
+// The first page counts all the matching transactions. The pages after a
+// cursor do not: their scans seek past the keys of the transactions up to
+// the cursor, and cover growing ranges of heights after it, until the page is
+// full.
+func (txi *TxIndex) SearchPage(
+	ctx context.Context,
+	q *query.Query,
+	pagination txindex.Pagination,
+) (*txindex.Page, error) {
+	var (
+		matches []positionMatch
+		page    = &txindex.Page{TotalCount: -1}
+	)
+	if pagination.After == nil {
+		positions, err := txi.search(ctx, q, nil, false, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		matches = sortedMatches(positions, pagination.OrderDesc)
+		page.TotalCount = len(matches)
+	} else {
+		var err error
+		if matches, err = txi.searchAfterCursor(ctx, q, pagination); err != nil {
+			return nil, err
+		}
+	}
+
+	if pagination.Limit > 0 && len(matches) > pagination.Limit {
+		matches = matches[:pagination.Limit]
+		next := matches[len(matches)-1].pos
+		page.Next = &next
+	}
+	page.Results = make([]*abci.TxResult, 0, len(matches))
+	for _, m := range matches {
+		res, err := txi.Get([]byte(m.hash))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Tx{%X}: %w", m.hash, err)
+		}
+		if res != nil {
+			page.Results = append(page.Results, res)
+		}
+	}
+	return page, nil
+}
+
+// positionMatch is the hash of a matching tx and its position.
+type positionMatch struct {
+	hash string
+	pos  txindex.Cursor
+}
+
+// sortedMatches returns the matches of a search in the order of their
+// positions.
+func sortedMatches(positions map[string]txindex.Cursor, desc bool) []positionMatch {
+	matches := make([]positionMatch, 0, len(positions))
+	for h, pos := range positions {
+		matches = append(matches, positionMatch{h, pos})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return positionBefore(matches[i].pos, matches[j].pos, desc)
+	})
+	return matches
+}
+
+// searchAfterCursor returns the ordered matches of the search after the
+// cursor of the pagination, up to one more than its limit, if any. It
+// searches the heights after the cursor by ranges doubling in size, and stops
+// once the matches of the page and the next one are found.
+func (txi *TxIndex) searchAfterCursor(
+	ctx context.Context,
+	q *query.Query,
+	pagination txindex.Pagination,
+) ([]positionMatch, error) {
+	after, desc := pagination.After, pagination.OrderDesc
+	maxHeight, err := txi.heightBound()
+	if err != nil {
+		return nil, err
+	}
+	// The first range is the height of the cursor, and the whole range of
+	// the heights after it without a limit.
+	size := int64(1)
+	if pagination.Limit <= 0 {
+		size = math.MaxInt64
+	}
+	var matches []positionMatch
+	for from := after.Height; from >= 1 && from <= maxHeight; {
+		lo, hi := from, from
+		if desc {
+			lo = max(1, from-(size-1))
+		} else {
+			hi = from + min(size-1, maxHeight-from)
+		}
+		positions, err := txi.search(ctx, q, after, desc, lo, hi)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, sortedMatches(positions, desc)...)
+		if (pagination.Limit > 0 && len(matches) > pagination.Limit) || ctx.Err() != nil {
+			break
+		}
+		if desc {
+			from = lo - 1
+		} else {
+			from = hi + 1
+		}
+		if size <= math.MaxInt64/2 {
+			size *= 2
+		}
+	}
+	return matches, nil
+}
+
+// search returns the hashes of the transactions matching the query, with
+// their positions. If after is not nil, only those after it, in the order
+// of the search, with a height in [minHeight, maxHeight], are returned.
+func (txi *TxIndex) search(
+	ctx context.Context,
+	q *query.Query,
+	after *txindex.Cursor,
+	desc bool,
+	minHeight, maxHeight int64,
+) (map[string]txindex.Cursor, error) {
+	scan := &positionScan{
+		positions: make(map[string]txindex.Cursor),
+		after:     after,
+		desc:      desc,
+		minHeight: minHeight,
+		maxHeight: maxHeight,
+		stats:     indexer.ScanStatsFromContext(ctx),
+	}
+	positions := scan.positions
 	select {
 	case <-ctx.Done():
-		return make([]*abci.TxResult, 0), nil
+		return positions, nil
 
 	default:
 	}
@@ -403,7 +749,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 	filteredHashes := make(map[string][]byte)
 
 	// get a list of conditions (like "tx.height > 5")
-	conditions := q.Syntax()
+	conditions := q.Conditions()
 
 	// if there is a hash condition, return the result immediately
 	hash, ok, err := lookForHash(conditions)
@@ -413,12 +759,11 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		res, err := txi.Get(hash)
 		switch {
 		case err != nil:
-			return []*abci.TxResult{}, fmt.Errorf("error while retrieving the result: %w", err)
-		case res == nil:
-			return []*abci.TxResult{}, nil
-		default:
-			return []*abci.TxResult{res}, nil
+			return positions, fmt.Errorf("error while retrieving the result: %w", err)
+		case res != nil:
+			scan.add(hash, txindex.Cursor{Height: res.Height, Index: res.Index})
 		}
+		return positions, nil
 	}
 
 	// conditions to skip because they're handled before "everything else"
@@ -451,7 +796,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 				continue
 			}
 			if !hashesInitialized {
-				filteredHashes = txi.matchRange(ctx, qr, startKey(qr.Key), filteredHashes, true, heightInfo)
+				filteredHashes = txi.matchRange(ctx, qr, startKey(qr.Key), filteredHashes, scan, true, heightInfo)
 				hashesInitialized = true
 
 				// Ignore any remaining conditions if the first condition resulted
@@ -460,7 +805,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 					break
 				}
 			} else {
-				filteredHashes = txi.matchRange(ctx, qr, startKey(qr.Key), filteredHashes, false, heightInfo)
+				filteredHashes = txi.matchRange(ctx, qr, startKey(qr.Key), filteredHashes, scan, false, heightInfo)
 			}
 		}
 	}
@@ -474,7 +819,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		}
 
 		if !hashesInitialized {
-			filteredHashes = txi.match(ctx, c, startKeyForCondition(c, heightInfo.height), filteredHashes, true, heightInfo)
+			filteredHashes = txi.match(ctx, c, startKeyForCondition(c, heightInfo.height), filteredHashes, scan, true, heightInfo)
 			hashesInitialized = true
 
 			// Ignore any remaining conditions if the first condition resulted
@@ -483,33 +828,18 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 				break
 			}
 		} else {
-			filteredHashes = txi.match(ctx, c, startKeyForCondition(c, heightInfo.height), filteredHashes, false, heightInfo)
+			filteredHashes = txi.match(ctx, c, startKeyForCondition(c, heightInfo.height), filteredHashes, scan, false, heightInfo)
 		}
 	}
 
-	results := make([]*abci.TxResult, 0, len(filteredHashes))
-	resultMap := make(map[string]struct{})
-RESULTS_LOOP:
+	if scan.err != nil {
+		return nil, scan.err
+	}
+	matched := make(map[string]txindex.Cursor, len(filteredHashes))
 	for _, h := range filteredHashes {
-
-		res, err := txi.Get(h)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
-		}
-		hashString := string(h)
-		if _, ok := resultMap[hashString]; !ok {
-			resultMap[hashString] = struct{}{}
-			results = append(results, res)
-		}
-		// Potentially exit early.
-		select {
-		case <-ctx.Done():
-			break RESULTS_LOOP
-		default:
-		}
+		matched[string(h)] = positions[string(h)]
 	}
-
-	return results, nil
+	return matched, nil
 }
 
 func lookForHash(conditions []syntax.Condition) (hash []byte, ok bool, err error) {
@@ -522,11 +852,219 @@ func lookForHash(conditions []syntax.Condition) (hash []byte, ok bool, err error
 	return
 }
 
-func (txi *TxIndex) setTmpHashes(tmpHeights map[string][]byte, it dbm.Iterator) {
+func (txi *TxIndex) setTmpHashes(tmpHeights map[string][]byte, scan *positionScan, it dbm.Iterator) {
+	pos, err := extractPositionFromKey(it.Key())
+	if err != nil {
+		if scan.after != nil {
+			return
+		}
+	} else if !scan.add(it.Value(), pos) {
+		return
+	}
 	eventSeq := extractEventSeqFromKey(it.Key())
 	tmpHeights[string(it.Value())+eventSeq] = it.Value()
 }
 
+// positionScan records the positions of the txs matched by the scans of a
+// search. The scans of a search resuming after a cursor skip the txs up to
+// the cursor, in the order of the search, seeking past the keys whose
+// heights are out of the bounds of the txs after the cursor.
+type positionScan struct {
+	positions map[string]txindex.Cursor
+	after     *txindex.Cursor
+	desc      bool
+	// minHeight and maxHeight bound the heights of the txs after the cursor.
+	minHeight int64
+	maxHeight int64
+	// stats counts the keys sought past, as scanned.
+	stats *indexer.ScanStats
+	// err is the error which ended a scan, if any.
+	err error
+}
+
+// add records the position of the tx, returning false if the tx is skipped.
+func (s *positionScan) add(hash []byte, pos txindex.Cursor) bool {
+	if s.after != nil && (!positionBefore(*s.after, pos, s.desc) ||
+		pos.Height < s.minHeight || pos.Height > s.maxHeight) {
+		return false
+	}
+	s.positions[string(hash)] = pos
+	return true
+}
+
+// seekKey returns the key to seek to, past the given key, if the height of
+// the key is out of the bounds of the scan, or nil otherwise.
+func (s *positionScan) seekKey(key []byte) ([]byte, error) {
+	if s.after == nil || !isTagKey(key) {
+		return nil, nil
+	}
+	// The height is the second to last part of the key.
+	end := bytes.LastIndex(key, []byte(tagKeySeparator))
+	start := bytes.LastIndex(key[:end], []byte(tagKeySeparator)) + 1
+	height := string(key[start:end])
+	h, err := strconv.ParseInt(height, 10, 64)
+	if err != nil || (s.minHeight <= h && h <= s.maxHeight) {
+		return nil, nil
+	}
+	seek := append([]byte{}, key[:start]...)
+	next, err := nextHeight(height, s.minHeight, s.maxHeight)
+	if err != nil {
+		return nil, err
+	}
+	if next != "" {
+		return append(seek, next...), nil
+	}
+	// None of the next keys with the same prefix has a height in the bounds:
+	// seek past all of them, ':' being the byte after the digits.
+	return append(seek, ':'), nil
+}
+
+// nextHeight returns the lexicographically smallest height after the given
+// one whose value is within [minHeight, maxHeight], or "" if there is none.
+// The heights of the keys are decimal strings, which are ordered
+// lexicographically rather than by value: the keys of the heights in the
+// bounds are interleaved with the others, e.g. 10 and 100 are before 9.
+func nextHeight(height string, minHeight, maxHeight int64) (string, error) {
+	lo, hi := strconv.FormatInt(minHeight, 10), strconv.FormatInt(maxHeight, 10)
+	// The heights after the given one are first the longer ones it prefixes,
+	// then those with a greater digit after a common prefix.
+	for i := len(height); i >= 0; i-- {
+		first := byte('0')
+		if i < len(height) {
+			first = height[i] + 1
+		}
+		for c := first; c <= '9'; c++ {
+			if prefix := height[:i] + string(c); heightPrefixInBounds(prefix, lo, hi) {
+				return smallestHeight(prefix, lo, hi)
+			}
+		}
+	}
+	return "", nil
+}
+
+// smallestHeight returns the lexicographically smallest height with the
+// given prefix whose value is within [lo, hi], which must exist.
+func smallestHeight(prefix, lo, hi string) (string, error) {
+	if compareHeights(lo, prefix) <= 0 && compareHeights(prefix, hi) <= 0 {
+		return prefix, nil
+	}
+	for c := byte('0'); c <= '9'; c++ {
+		if next := prefix + string(c); heightPrefixInBounds(next, lo, hi) {
+			return smallestHeight(next, lo, hi)
+		}
+	}
+	return "", fmt.Errorf("no height in [%s, %s] with prefix %s", lo, hi, prefix)
+}
+
+// heightPrefixInBounds reports whether there is a height with the given
+// prefix whose value is within [lo, hi].
+func heightPrefixInBounds(prefix, lo, hi string) bool {
+	if prefix[0] == '0' {
+		return false
+	}
+	for n := len(prefix); n <= len(hi); n++ {
+		smallest := prefix + strings.Repeat("0", n-len(prefix))
+		largest := prefix + strings.Repeat("9", n-len(prefix))
+		if compareHeights(largest, lo) >= 0 && compareHeights(smallest, hi) <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// compareHeights compares the values of two decimal heights.
+func compareHeights(a, b string) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
+
+// iterateAfterCursor iterates over the keys with the given prefix, like
+// dbm.IteratePrefix, seeking past the keys whose heights are out of the
+// bounds of the scan, if it resumes after a cursor.
+func (txi *TxIndex) iterateAfterCursor(prefix []byte, scan *positionScan) (dbm.Iterator, error) {
+	it, err := dbm.IteratePrefix(txi.store, prefix)
+	if err != nil || scan.after == nil {
+		return it, err
+	}
+	_, end := it.Domain()
+	c := &cursorIterator{Iterator: it, store: txi.store, end: end, scan: scan}
+	c.seek()
+	return c, nil
+}
+
+// cursorIterator is an iterator over the keys of a prefix which seeks past
+// the keys whose heights are out of the bounds of a scan.
+type cursorIterator struct {
+	dbm.Iterator // nil once past the end
+	store        dbm.DB
+	end          []byte
+	scan         *positionScan
+	err          error
+}
+
+func (c *cursorIterator) Valid() bool {
+	return c.Iterator != nil && c.Iterator.Valid()
+}
+
+func (c *cursorIterator) Next() {
+	c.Iterator.Next()
+	c.seek()
+}
+
+func (c *cursorIterator) Error() error {
+	if c.err != nil || c.Iterator == nil {
+		return c.err
+	}
+	return c.Iterator.Error()
+}
+
+func (c *cursorIterator) Close() error {
+	if c.Iterator == nil {
+		return nil
+	}
+	return c.Iterator.Close()
+}
+
+// seek moves the iterator to the next key whose height is in the bounds of
+// the scan, if the current one is not.
+func (c *cursorIterator) seek() {
+	for c.Valid() {
+		key, err := c.scan.seekKey(c.Iterator.Key())
+		if err != nil {
+			// Not an error of the store: the scan ends, and the search
+			// returns the error.
+			c.scan.err = err
+			_ = c.Iterator.Close()
+			c.Iterator = nil
+			return
+		}
+		if key == nil {
+			return
+		}
+		c.scan.stats.AddKey()
+		err = c.Iterator.Close()
+		c.Iterator = nil
+		if err == nil && (c.end == nil || bytes.Compare(key, c.end) < 0) {
+			c.Iterator, err = c.store.Iterator(key, c.end)
+		}
+		if err != nil {
+			c.Iterator, c.err = nil, err
+			return
+		}
+	}
+}
+
+// positionBefore reports whether the position a precedes b, in increasing
+// order or, if desc, in decreasing order.
+func positionBefore(a, b txindex.Cursor, desc bool) bool {
+	if desc {
+		return b.Before(a)
+	}
+	return a.Before(b)
+}
+
 // match returns all matching txs by hash that meet a given condition and start
 // key. An already filtered result (filteredHashes) is provided such that any
 // non-intersecting matches are removed.
@@ -537,6 +1075,7 @@ func (txi *TxIndex) match(
 	c syntax.Condition,
 	startKeyBz []byte,
 	filteredHashes map[string][]byte,
+	scan *positionScan,
 	firstRun bool,
 	heightInfo HeightInfo,
 ) map[string][]byte {
@@ -547,10 +1086,11 @@ func (txi *TxIndex) match(
 	}
 
 	tmpHashes := make(map[string][]byte)
+	scanStats := indexer.ScanStatsFromContext(ctx)
 
 	switch {
 	case c.Op == syntax.TEq:
-		it, err := dbm.IteratePrefix(txi.store, startKeyBz)
+		it, err := txi.iterateAfterCursor(startKeyBz, scan)
 		if err != nil {
 			panic(err)
 		}
@@ -558,6 +1098,7 @@ func (txi *TxIndex) match(
 
 	EQ_LOOP:
 		for ; it.Valid(); it.Next() {
+			scanStats.AddKey()
 
 			// If we have a height range in a query, we need only transactions
 			// for this height
@@ -574,7 +1115,7 @@ func (txi *TxIndex) match(
 			if !withinBounds {
 				continue
 			}
-			txi.setTmpHashes(tmpHashes, it)
+			txi.setTmpHashes(tmpHashes, scan, it)
 			// Potentially exit early.
 			select {
 			case <-ctx.Done():
@@ -589,7 +1130,7 @@ func (txi *TxIndex) match(
 	case c.Op == syntax.TExists:
 		// XXX: can't use startKeyBz here because c.Operand is nil
 		// (e.g. "account.owner/<nil>/" won't match w/ a single row)
-		it, err := dbm.IteratePrefix(txi.store, startKey(c.Tag))
+		it, err := txi.iterateAfterCursor(startKey(c.Tag), scan)
 		if err != nil {
 			panic(err)
 		}
@@ -597,6 +1138,7 @@ func (txi *TxIndex) match(
 
 	EXISTS_LOOP:
 		for ; it.Valid(); it.Next() {
+			scanStats.AddKey()
 			keyHeight, err := extractHeightFromKey(it.Key())
 			if err != nil {
 				txi.log.Error("failure to parse height from key:", err)
@@ -610,7 +1152,7 @@ func (txi *TxIndex) match(
 			if !withinBounds {
 				continue
 			}
-			txi.setTmpHashes(tmpHashes, it)
+			txi.setTmpHashes(tmpHashes, scan, it)
 
 			// Potentially exit early.
 			select {
@@ -627,7 +1169,7 @@ func (txi *TxIndex) match(
 		// XXX: startKey does not apply here.
 		// For example, if startKey = "account.owner/an/" and search query = "account.owner CONTAINS an"
 		// we can't iterate with prefix "account.owner/an/" because we might miss keys like "account.owner/Ulan/"
-		it, err := dbm.IteratePrefix(txi.store, startKey(c.Tag))
+		it, err := txi.iterateAfterCursor(startKey(c.Tag), scan)
 		if err != nil {
 			panic(err)
 		}
@@ -635,6 +1177,7 @@ func (txi *TxIndex) match(
 
 	CONTAINS_LOOP:
 		for ; it.Valid(); it.Next() {
+			scanStats.AddKey()
 			if !isTagKey(it.Key()) {
 				continue
 			}
@@ -653,7 +1196,7 @@ func (txi *TxIndex) match(
 				if !withinBounds {
 					continue
 				}
-				txi.setTmpHashes(tmpHashes, it)
+				txi.setTmpHashes(tmpHashes, scan, it)
 			}
 
 			// Potentially exit early.
@@ -711,6 +1254,7 @@ func (txi *TxIndex) matchRange(
 	qr indexer.QueryRange,
 	startKey []byte,
 	filteredHashes map[string][]byte,
+	scan *positionScan,
 	firstRun bool,
 	heightInfo HeightInfo,
 ) map[string][]byte {
@@ -721,8 +1265,9 @@ func (txi *TxIndex) matchRange(
 	}
 
 	tmpHashes := make(map[string][]byte)
+	scanStats := indexer.ScanStatsFromContext(ctx)
 
-	it, err := dbm.IteratePrefix(txi.store, startKey)
+	it, err := txi.iterateAfterCursor(startKey, scan)
 	if err != nil {
 		panic(err)
 	}
@@ -730,6 +1275,7 @@ func (txi *TxIndex) matchRange(
 
 LOOP:
 	for ; it.Valid(); it.Next() {
+		scanStats.AddKey()
 		if !isTagKey(it.Key()) {
 			continue
 		}
@@ -771,7 +1317,7 @@ LOOP:
 				txi.log.Error("failed to parse bounds:", err)
 			} else {
 				if withinBounds {
-					txi.setTmpHashes(tmpHashes, it)
+					txi.setTmpHashes(tmpHashes, scan, it)
 				}
 			}
 
@@ -841,6 +1387,22 @@ func extractHeightFromKey(key []byte) (int64, error) {
 
 	return strconv.ParseInt(parts[len(parts)-2], 10, 64)
 }
+
+// extractPositionFromKey returns the height and index of the transaction of
+// an event key.
+func extractPositionFromKey(key []byte) (txindex.Cursor, error) {
+	parts := strings.SplitN(string(key), tagKeySeparator, -1)
+	height, err := strconv.ParseInt(parts[len(parts)-2], 10, 64)
+	if err != nil {
+		return txindex.Cursor{}, err
+	}
+	index, err := strconv.ParseUint(strings.SplitN(parts[len(parts)-1], eventSeqSeparator, 2)[0], 10, 32)
+	if err != nil {
+		return txindex.Cursor{}, err
+	}
+	return txindex.Cursor{Height: height, Index: uint32(index)}, nil
+}
+
 func extractValueFromKey(key []byte) string {
 	keyString := string(key)
 	parts := strings.SplitN(keyString, tagKeySeparator, -1)
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	blockidxkv "github.com/cometbft/cometbft/state/indexer/block/kv"
@@ -18,6 +19,7 @@ import (
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/libs/pubsub/query"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	"github.com/cometbft/cometbft/state/indexer"
 	"github.com/cometbft/cometbft/state/txindex"
 	"github.com/cometbft/cometbft/types"
 )
@@ -68,6 +70,40 @@ func TestTxIndex(t *testing.T) {
 	assert.True(t, proto.Equal(txResult2, loadedTxResult2))
 }
 
+func TestTxIndexCompression(t *testing.T) {
+	store := db.NewMemDB()
+	indexer := NewTxIndex(store, WithCompression())
+
+	txResult := &abci.TxResult{
+		Height: 1,
+		Index:  0,
+		Tx:     types.Tx("HELLO WORLD"),
+		Result: abci.ExecTxResult{
+			Code: abci.CodeTypeOK,
+			Log:  strings.Repeat("transfer succeeded; ", 100),
+		},
+	}
+	uncompressed, err := proto.Marshal(txResult)
+	require.NoError(t, err)
+	hash := types.Tx(txResult.Tx).Hash()
+
+	require.NoError(t, indexer.Index(txResult))
+	rawBytes, err := store.Get(hash)
+	require.NoError(t, err)
+	assert.Less(t, len(rawBytes), len(uncompressed))
+
+	// The compressed results are read by an index without compression, and
+	// the other way around.
+	loadedTxResult, err := NewTxIndex(store).Get(hash)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(txResult, loadedTxResult))
+
+	require.NoError(t, store.Set(hash, uncompressed))
+	loadedTxResult, err = indexer.Get(hash)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(txResult, loadedTxResult))
+}
+
 func TestTxIndex_Prune(t *testing.T) {
 	indexer := NewTxIndex(db.NewMemDB())
 
@@ -143,6 +179,61 @@ func TestTxIndex_Prune(t *testing.T) {
 	assert.True(t, proto.Equal(txResult2, loadedTxResult2))
 }
 
+func TestTxIndex_TruncateAbove(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+
+	latest, err := indexer.LatestHeight()
+	require.NoError(t, err)
+	require.Zero(t, latest)
+
+	events := []abci.Event{
+		{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: "1", Index: true}}},
+	}
+	var keysAtHeight2 [][]byte
+	for height := int64(1); height <= 10; height++ {
+		txResult := txResultWithEvents(events)
+		txResult.Height = height
+		txResult.Tx = types.Tx(fmt.Sprintf("tx%d", height))
+		require.NoError(t, indexer.Index(txResult))
+		if height == 2 {
+			keysAtHeight2 = GetKeys(indexer)
+		}
+	}
+	// Index an earlier tx again at a later height.
+	txResult := txResultWithEvents(events)
+	txResult.Height = 11
+	txResult.Tx = types.Tx("tx1")
+	require.NoError(t, indexer.Index(txResult))
+
+	latest, err = indexer.LatestHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(11), latest)
+
+	removed, err := indexer.TruncateAbove(2)
+	require.NoError(t, err)
+	require.Equal(t, int64(9), removed)
+
+	latest, err = indexer.LatestHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), latest)
+
+	// Only the keys of the txs at heights 1 and 2 are left, except for the
+	// result of the tx that was re-indexed at a later height.
+	keys := GetKeys(indexer)
+	require.True(t, isSubset(keys, keysAtHeight2))
+	result, err := indexer.Get(types.Tx("tx2").Hash())
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	result, err = indexer.Get(types.Tx("tx1").Hash())
+	require.NoError(t, err)
+	require.Nil(t, result)
+
+	results, err := indexer.Search(context.Background(), query.MustCompile("account.number = 1"))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, int64(2), results[0].Height)
+}
+
 func TestTxSearch(t *testing.T) {
 	indexer := NewTxIndex(db.NewMemDB())
 
@@ -184,6 +275,9 @@ func TestTxSearch(t *testing.T) {
 		{"account.number >= 1 AND account.number <= 5", 1},
 		// search by range and another key
 		{"account.number >= 1 AND account.owner = 'Ivan' AND account.number <= 5", 0},
+		// search with match.events, the default of the indexer
+		{"account.number >= 1 AND match.events = 1", 1},
+		{"account.number >= 1 AND account.owner = '/Ivan/' AND match.events = 1", 0},
 		// search by range (lower bound)
 		{"account.number >= 1", 1},
 		// search by range (upper bound)
@@ -425,6 +519,33 @@ func TestTxSearchWithCancelation(t *testing.T) {
 	assert.Empty(t, results)
 }
 
+func TestTxSearchScanStats(t *testing.T) {
+	txIndexer := NewTxIndex(db.NewMemDB())
+	for h := int64(1); h <= 3; h++ {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{{Key: "owner", Value: "Ivan", Index: true}}},
+		})
+		txResult.Height = h
+		txResult.Tx = types.Tx(fmt.Sprintf("tx%d", h))
+		require.NoError(t, txIndexer.Index(txResult))
+	}
+
+	stats := &indexer.ScanStats{}
+	ctx := indexer.ContextWithScanStats(context.Background(), stats)
+	results, err := txIndexer.Search(ctx, query.MustCompile(`account.owner = 'Ivan'`))
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.EqualValues(t, 3, stats.KeysScanned())
+
+	results, err = txIndexer.Search(ctx, query.MustCompile(`tx.height >= 2`))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.EqualValues(t, 6, stats.KeysScanned())
+
+	// Without statistics in the context, none are collected.
+	require.Nil(t, indexer.ScanStatsFromContext(context.Background()))
+}
+
 func TestTxSearchDeprecatedIndexing(t *testing.T) {
 	indexer := NewTxIndex(db.NewMemDB())
 
@@ -739,6 +860,203 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	require.Len(t, results, 3)
 }
 
+func TestTxSearchPage(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+
+	// 3 heights of 3 txs, the last one of each without the event searched.
+	var want []*abci.TxResult
+	for h := int64(3); h >= 1; h-- {
+		for i := uint32(0); i < 3; i++ {
+			value := "1"
+			if i == 2 {
+				value = "2"
+			}
+			txResult := txResultWithEvents([]abci.Event{
+				{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: value, Index: true}}},
+			})
+			txResult.Tx = types.Tx(fmt.Sprintf("tx %d/%d", h, i))
+			txResult.Height, txResult.Index = h, i
+			require.NoError(t, indexer.Index(txResult))
+			if value == "1" {
+				want = append(want, txResult)
+			}
+		}
+	}
+	slices.SortFunc(want, func(a, b *abci.TxResult) int {
+		if a.Height != b.Height {
+			return int(a.Height - b.Height)
+		}
+		return int(a.Index) - int(b.Index)
+	})
+
+	ctx := context.Background()
+	for _, q := range []*query.Query{
+		query.MustCompile(`account.number = 1`),
+		query.MustCompile(`account.number >= 1 AND account.number < 2`),
+	} {
+		for _, desc := range []bool{false, true} {
+			var got []*abci.TxResult
+			pagination := txindex.Pagination{OrderDesc: desc, Limit: 4}
+			for {
+				page, err := indexer.SearchPage(ctx, q, pagination)
+				require.NoError(t, err)
+				// Only the first page counts the matching txs.
+				if pagination.After == nil {
+					require.Equal(t, len(want), page.TotalCount)
+				} else {
+					require.Equal(t, -1, page.TotalCount)
+				}
+				require.LessOrEqual(t, len(page.Results), pagination.Limit)
+				got = append(got, page.Results...)
+				if page.Next == nil {
+					break
+				}
+				next, err := txindex.ParseCursor(page.Next.String())
+				require.NoError(t, err)
+				require.Equal(t, *page.Next, next)
+				pagination.After = &next
+			}
+			require.Len(t, got, len(want))
+			for i := range got {
+				expected := want[i]
+				if desc {
+					expected = want[len(want)-1-i]
+				}
+				assert.True(t, proto.Equal(expected, got[i]), "%v: result %d (desc: %v)", q, i, desc)
+			}
+		}
+	}
+
+	// All the results without a limit.
+	q := query.MustCompile(`account.number = 1`)
+	page, err := indexer.SearchPage(ctx, q, txindex.Pagination{})
+	require.NoError(t, err)
+	assert.Len(t, page.Results, len(want))
+	assert.Nil(t, page.Next)
+
+	_, err = txindex.ParseCursor("not a cursor")
+	require.ErrorIs(t, err, txindex.ErrInvalidCursor)
+}
+
+func TestTxSearchPageSeeksCursor(t *testing.T) {
+	txIndexer := NewTxIndex(db.NewMemDB())
+
+	// The heights of 1, 2 and 3 digits are interleaved in the keys.
+	const maxHeight = 150
+	for h := int64(1); h <= maxHeight; h++ {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: "1", Index: true}}},
+		})
+		txResult.Tx = types.Tx(fmt.Sprintf("tx %d", h))
+		txResult.Height = h
+		require.NoError(t, txIndexer.Index(txResult))
+	}
+
+	for _, q := range []*query.Query{
+		query.MustCompile(`account.number = 1`),
+		query.MustCompile(`account.number EXISTS`),
+		query.MustCompile(`account.number >= 1`),
+	} {
+		for _, desc := range []bool{false, true} {
+			var heights []int64
+			pagination := txindex.Pagination{OrderDesc: desc, Limit: 7}
+			for {
+				page, err := txIndexer.SearchPage(context.Background(), q, pagination)
+				require.NoError(t, err)
+				for _, res := range page.Results {
+					heights = append(heights, res.Height)
+				}
+				if page.Next == nil {
+					break
+				}
+				pagination.After = page.Next
+			}
+			require.Len(t, heights, maxHeight, "%v (desc: %v)", q, desc)
+			for i, h := range heights {
+				expected := int64(i + 1)
+				if desc {
+					expected = maxHeight - int64(i)
+				}
+				require.Equal(t, expected, h, "%v (desc: %v)", q, desc)
+			}
+		}
+	}
+
+	// The scans of a deep page seek to the heights after the cursor instead
+	// of scanning the keys before it.
+	q := query.MustCompile(`account.number = 1`)
+	stats := &indexer.ScanStats{}
+	ctx := indexer.ContextWithScanStats(context.Background(), stats)
+	page, err := txIndexer.SearchPage(ctx, q, txindex.Pagination{
+		After: &txindex.Cursor{Height: 140},
+		Limit: 7,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Results, 7)
+	require.EqualValues(t, 141, page.Results[0].Height)
+	// The keys of the heights 140 to 150, by ranges of 1, 2, 4 and 8
+	// heights, along with those sought past.
+	require.EqualValues(t, 20, stats.KeysScanned())
+
+	stats = &indexer.ScanStats{}
+	ctx = indexer.ContextWithScanStats(context.Background(), stats)
+	page, err = txIndexer.SearchPage(ctx, q, txindex.Pagination{
+		After:     &txindex.Cursor{Height: 10},
+		Limit:     7,
+		OrderDesc: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Results, 7)
+	require.EqualValues(t, 9, page.Results[0].Height)
+	// The keys of the heights 1 to 10, by ranges of 1, 2, 4 and 8 heights,
+	// along with those sought past.
+	require.EqualValues(t, 23, stats.KeysScanned())
+
+	// The scans of a page stop once it is full, instead of scanning all the
+	// keys after the cursor.
+	stats = &indexer.ScanStats{}
+	ctx = indexer.ContextWithScanStats(context.Background(), stats)
+	page, err = txIndexer.SearchPage(ctx, q, txindex.Pagination{
+		After: &txindex.Cursor{Height: 10},
+		Limit: 7,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Results, 7)
+	require.EqualValues(t, 11, page.Results[0].Height)
+	require.Equal(t, &txindex.Cursor{Height: 17}, page.Next)
+	require.Less(t, stats.KeysScanned(), int64(maxHeight/4))
+}
+
+func TestNextHeight(t *testing.T) {
+	testCases := []struct {
+		height   string
+		min, max int64
+		expected string
+	}{
+		// the heights are ordered 1, 10, 100, ..., 109, 11, 110, ..., 2, ...
+		{"1", 140, 150, "140"},
+		{"1", 5, 150, "10"},
+		{"15", 140, 150, "150"},
+		{"16", 140, 150, ""},
+		{"10", 140, 1500, "1000"},
+		{"101", 140, 1500, "1010"},
+		{"100", 1, 10, "2"},
+		{"20", 1, 10, "3"},
+		{"9", 1, 10, ""},
+		{"99", 1, 100, ""},
+		{"1", 1, 1, ""},
+	}
+	for _, tc := range testCases {
+		next, err := nextHeight(tc.height, tc.min, tc.max)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, next,
+			"next height after %s in [%d, %d]", tc.height, tc.min, tc.max)
+	}
+
+	_, err := smallestHeight("5", "1", "3")
+	require.Error(t, err)
+}
+
 func txResultWithEvents(events []abci.Event) *abci.TxResult {
 	tx := types.Tx("HELLO WORLD")
 	return &abci.TxResult{
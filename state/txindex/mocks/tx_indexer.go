@@ -155,6 +155,32 @@ func (_m *TxIndexer) Search(ctx context.Context, q *query.Query) ([]*types.TxRes
 	return r0, r1
 }
 
+// SearchPage provides a mock function with given fields: ctx, q, pagination
+func (_m *TxIndexer) SearchPage(ctx context.Context, q *query.Query, pagination txindex.Pagination) (*txindex.Page, error) {
+	ret := _m.Called(ctx, q, pagination)
+
+	var r0 *txindex.Page
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *query.Query, txindex.Pagination) (*txindex.Page, error)); ok {
+		return rf(ctx, q, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *query.Query, txindex.Pagination) *txindex.Page); ok {
+		r0 = rf(ctx, q, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*txindex.Page)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *query.Query, txindex.Pagination) error); ok {
+		r1 = rf(ctx, q, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetLogger provides a mock function with given fields: l
 func (_m *TxIndexer) SetLogger(l log.Logger) {
 	_m.Called(l)
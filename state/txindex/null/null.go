@@ -47,6 +47,10 @@ func (txi *TxIndex) Search(_ context.Context, _ *query.Query) ([]*abci.TxResult,
 	return []*abci.TxResult{}, nil
 }
 
+func (txi *TxIndex) SearchPage(context.Context, *query.Query, txindex.Pagination) (*txindex.Page, error) {
+	return &txindex.Page{Results: []*abci.TxResult{}}, nil
+}
+
 func (txi *TxIndex) SetLogger(log.Logger) {
 
 }
@@ -88,6 +88,10 @@ func validateBlock(state State, block *types.Block) error {
 			return errors.New("initial block can't have LastCommit signatures")
 		}
 	} else {
+		if block.LastCommit.IsAggregated() != state.ConsensusParams.Validator.BLSAggregationEnabled(block.Height-1) {
+			return fmt.Errorf("invalid block LastCommit: expected aggregated %t, got %t",
+				state.ConsensusParams.Validator.BLSAggregationEnabled(block.Height-1), block.LastCommit.IsAggregated())
+		}
 		// LastCommit.Signatures length is checked in VerifyCommit.
 		if err := state.LastValidators.VerifyCommit(
 			state.ChainID, state.LastBlockID, block.Height-1, block.LastCommit); err != nil {
@@ -110,8 +114,30 @@ func validateBlock(state State, block *types.Block) error {
 		)
 	}
 
-	// Validate block Time
+	// Validate block Time. Under PBTS, the time of the block is the one of its
+	// proposer, whose timeliness is checked by the validators in consensus,
+	// and which must be after the genesis time at the initial height.
 	switch {
+	case block.Height < state.InitialHeight:
+		return fmt.Errorf("block height %v lower than initial height %v",
+			block.Height, state.InitialHeight)
+
+	case state.ConsensusParams.Synchrony.PBTSEnabled(block.Height):
+		if block.Height == state.InitialHeight {
+			genesisTime := state.LastBlockTime
+			if !block.Time.After(genesisTime) {
+				return fmt.Errorf("block time %v is not after genesis time %v",
+					block.Time,
+					genesisTime,
+				)
+			}
+		} else if !block.Time.After(state.LastBlockTime) {
+			return fmt.Errorf("block time %v not greater than last block time %v",
+				block.Time,
+				state.LastBlockTime,
+			)
+		}
+
 	case block.Height > state.InitialHeight:
 		if !block.Time.After(state.LastBlockTime) {
 			return fmt.Errorf("block time %v not greater than last block time %v",
@@ -135,10 +161,6 @@ func validateBlock(state State, block *types.Block) error {
 				genesisTime,
 			)
 		}
-
-	default:
-		return fmt.Errorf("block height %v lower than initial height %v",
-			block.Height, state.InitialHeight)
 	}
 
 	// Check evidence doesn't exceed the limit amount of bytes.
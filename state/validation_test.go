@@ -125,6 +125,44 @@ func TestValidateBlockHeader(t *testing.T) {
 	assert.Contains(t, err.Error(), "lower than initial height")
 }
 
+func TestValidateBlockTimePBTS(t *testing.T) {
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	state.ConsensusParams.Synchrony.PBTSEnableHeight = state.InitialHeight
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		&mpmocks.Mempool{},
+		sm.EmptyEvidencePool{},
+		store.NewBlockStore(dbm.NewMemDB()),
+	)
+
+	// The time of the block is the one of the proposer, and only needs to be
+	// after the last block time, here the genesis time.
+	block := makeBlock(state, 1, &types.Commit{})
+	require.True(t, block.Time.After(state.LastBlockTime))
+	require.NoError(t, blockExec.ValidateBlock(state, block))
+
+	block.Time = state.LastBlockTime.Add(time.Hour)
+	require.NoError(t, blockExec.ValidateBlock(state, block))
+
+	// At the initial height, the time of the block is checked against the
+	// genesis time.
+	for _, blockTime := range []time.Time{state.LastBlockTime, state.LastBlockTime.Add(-time.Hour)} {
+		block.Time = blockTime
+		err := blockExec.ValidateBlock(state, block)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "genesis time")
+	}
+}
+
 func TestValidateBlockCommit(t *testing.T) {
 	proxyApp := newTestApp()
 	require.NoError(t, proxyApp.Start())
@@ -0,0 +1,287 @@
+package statesync
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/proxy"
+)
+
+// The layout of a local snapshot, written by ExportSnapshot: a directory with
+// the metadata of the snapshot in snapshot.json, and its chunks in
+// chunks/<index>. The directory can also be given as a tar archive, optionally
+// gzip compressed, of its content.
+const (
+	localSnapshotMetadataFile = "snapshot.json"
+	localSnapshotChunksDir    = "chunks"
+)
+
+// localSnapshotMetadata is the content of the snapshot.json file of a local
+// snapshot.
+type localSnapshotMetadata struct {
+	Height   uint64            `json:"height"`
+	Format   uint32            `json:"format"`
+	Chunks   uint32            `json:"chunks"`
+	Hash     cmtbytes.HexBytes `json:"hash"`
+	Metadata []byte            `json:"metadata"`
+}
+
+// localSnapshot is a snapshot read from a local directory, or extracted from a
+// tar archive.
+type localSnapshot struct {
+	snapshot  *snapshot
+	dir       string
+	extracted bool // whether dir was extracted from an archive, and must be removed
+}
+
+// openLocalSnapshot opens the local snapshot of the directory or tar archive
+// at the path. An archive is extracted to a new directory of tempDir, removed
+// by Close.
+func openLocalSnapshot(snapshotPath, tempDir string) (*localSnapshot, error) {
+	info, err := os.Stat(snapshotPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open local snapshot: %w", err)
+	}
+	l := &localSnapshot{dir: snapshotPath}
+	if !info.IsDir() {
+		l.dir, err = os.MkdirTemp(tempDir, "tm-statesync-local")
+		if err != nil {
+			return nil, fmt.Errorf("unable to create temp dir for local snapshot: %w", err)
+		}
+		l.extracted = true
+		if err := extractLocalSnapshot(snapshotPath, l.dir); err != nil {
+			_ = l.Close()
+			return nil, fmt.Errorf("failed to extract local snapshot %v: %w", snapshotPath, err)
+		}
+	}
+	if err := l.load(); err != nil {
+		_ = l.Close()
+		return nil, fmt.Errorf("invalid local snapshot %v: %w", snapshotPath, err)
+	}
+	return l, nil
+}
+
+// load reads the metadata of the snapshot, and checks that all its chunks are
+// present.
+func (l *localSnapshot) load() error {
+	bz, err := os.ReadFile(filepath.Join(l.dir, localSnapshotMetadataFile))
+	if err != nil {
+		return err
+	}
+	metadata, err := parseLocalSnapshotMetadata(bz)
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < metadata.Chunks; i++ {
+		if _, err := os.Stat(l.chunkPath(i)); err != nil {
+			return fmt.Errorf("missing chunk %v: %w", i, err)
+		}
+	}
+	l.snapshot = &snapshot{
+		Height:   metadata.Height,
+		Format:   metadata.Format,
+		Chunks:   metadata.Chunks,
+		Hash:     metadata.Hash,
+		Metadata: metadata.Metadata,
+	}
+	return nil
+}
+
+// parseLocalSnapshotMetadata parses and validates the content of a
+// snapshot.json file.
+func parseLocalSnapshotMetadata(bz []byte) (*localSnapshotMetadata, error) {
+	var metadata localSnapshotMetadata
+	if err := json.Unmarshal(bz, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse %v: %w", localSnapshotMetadataFile, err)
+	}
+	if metadata.Height == 0 {
+		return nil, errors.New("snapshot height cannot be 0")
+	}
+	if len(metadata.Hash) == 0 {
+		return nil, errors.New("snapshot has no hash")
+	}
+	if metadata.Chunks == 0 {
+		return nil, errors.New("snapshot has no chunks")
+	}
+	return &metadata, nil
+}
+
+func (l *localSnapshot) chunkPath(index uint32) string {
+	return filepath.Join(l.dir, localSnapshotChunksDir, strconv.FormatUint(uint64(index), 10))
+}
+
+// loadChunk reads a chunk of the snapshot.
+func (l *localSnapshot) loadChunk(index uint32) (*chunk, error) {
+	bz, err := os.ReadFile(l.chunkPath(index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chunk %v: %w", index, err)
+	}
+	return &chunk{
+		Height: l.snapshot.Height,
+		Format: l.snapshot.Format,
+		Index:  index,
+		Chunk:  bz,
+	}, nil
+}
+
+// Close removes the directory extracted from an archive, if any.
+func (l *localSnapshot) Close() error {
+	if !l.extracted {
+		return nil
+	}
+	if err := os.RemoveAll(l.dir); err != nil {
+		return fmt.Errorf("failed to clean up local snapshot dir %v: %w", l.dir, err)
+	}
+	return nil
+}
+
+// extractLocalSnapshot extracts the snapshot.json file and the chunks of the
+// tar archive, optionally gzip compressed, to the directory. The other entries
+// are ignored.
+func extractLocalSnapshot(archive, dir string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	// Detect the gzip compression by its magic number rather than by the
+	// extension of the file.
+	if magic, err := r.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer gr.Close()
+		return extractLocalSnapshotTar(tar.NewReader(gr), dir)
+	}
+	return extractLocalSnapshotTar(tar.NewReader(r), dir)
+}
+
+func extractLocalSnapshotTar(tr *tar.Reader, dir string) error {
+	if err := os.Mkdir(filepath.Join(dir, localSnapshotChunksDir), 0o700); err != nil {
+		return err
+	}
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		// Only the known entries are extracted, so the paths of the archive
+		// cannot escape the directory.
+		name := path.Clean(header.Name)
+		dirName, fileName := path.Split(name)
+		switch {
+		case name == localSnapshotMetadataFile:
+		case path.Clean(dirName) == localSnapshotChunksDir:
+			if _, err := strconv.ParseUint(fileName, 10, 32); err != nil {
+				continue
+			}
+		default:
+			continue
+		}
+		if err := extractFile(tr, filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			return fmt.Errorf("failed to extract %v: %w", header.Name, err)
+		}
+	}
+}
+
+func extractFile(r io.Reader, file string) error {
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ExportSnapshot writes the snapshot of the app at the height, or its latest
+// snapshot if the height is 0, to a new directory, as read by the state sync
+// from a local snapshot. The snapshot of the highest format is exported if the
+// app has several at the height. It returns the exported snapshot.
+func ExportSnapshot(ctx context.Context, conn proxy.AppConnSnapshot, height uint64, dir string) (*abci.Snapshot, error) {
+	resp, err := conn.ListSnapshots(ctx, &abci.RequestListSnapshots{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	snapshots := resp.Snapshots
+	sort.Slice(snapshots, func(i, j int) bool {
+		a, b := snapshots[i], snapshots[j]
+		return a.Height > b.Height || (a.Height == b.Height && a.Format > b.Format)
+	})
+	var s *abci.Snapshot
+	for _, snapshot := range snapshots {
+		if height == 0 || snapshot.Height == height {
+			s = snapshot
+			break
+		}
+	}
+	if s == nil {
+		if height == 0 {
+			return nil, errors.New("the app has no snapshots")
+		}
+		return nil, fmt.Errorf("the app has no snapshot at height %v", height)
+	}
+
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.Mkdir(filepath.Join(dir, localSnapshotChunksDir), 0o700); err != nil {
+		return nil, err
+	}
+	l := &localSnapshot{dir: dir}
+	for i := uint32(0); i < s.Chunks; i++ {
+		resp, err := conn.LoadSnapshotChunk(ctx, &abci.RequestLoadSnapshotChunk{
+			Height: s.Height,
+			Format: s.Format,
+			Chunk:  i,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load chunk %v: %w", i, err)
+		}
+		if resp.Chunk == nil {
+			return nil, fmt.Errorf("chunk %v is missing", i)
+		}
+		if err := os.WriteFile(l.chunkPath(i), resp.Chunk, 0o600); err != nil {
+			return nil, err
+		}
+	}
+	// The metadata is written last, so an interrupted export is not a valid
+	// local snapshot.
+	bz, err := json.MarshalIndent(localSnapshotMetadata{
+		Height:   s.Height,
+		Format:   s.Format,
+		Chunks:   s.Chunks,
+		Hash:     s.Hash,
+		Metadata: s.Metadata,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, localSnapshotMetadataFile), bz, 0o600); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
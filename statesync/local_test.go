@@ -0,0 +1,250 @@
+package statesync
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	cmtversion "github.com/cometbft/cometbft/proto/tendermint/version"
+	"github.com/cometbft/cometbft/proxy"
+	proxymocks "github.com/cometbft/cometbft/proxy/mocks"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/statesync/mocks"
+	"github.com/cometbft/cometbft/types"
+)
+
+// exportTestSnapshot exports a snapshot at height 2, with 3 chunks, to a new
+// directory.
+func exportTestSnapshot(t *testing.T) string {
+	t.Helper()
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("ListSnapshots", mock.Anything, &abci.RequestListSnapshots{}).Return(&abci.ResponseListSnapshots{
+		Snapshots: []*abci.Snapshot{
+			{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}},
+			{Height: 2, Format: 1, Chunks: 3, Hash: []byte{2}, Metadata: []byte("metadata")},
+			{Height: 2, Format: 0, Chunks: 1, Hash: []byte{3}},
+		},
+	}, nil)
+	for i := uint32(0); i < 3; i++ {
+		conn.On("LoadSnapshotChunk", mock.Anything, &abci.RequestLoadSnapshotChunk{
+			Height: 2, Format: 1, Chunk: i,
+		}).Return(&abci.ResponseLoadSnapshotChunk{Chunk: []byte{2, 1, byte(i)}}, nil)
+	}
+
+	dir := filepath.Join(t.TempDir(), "snapshot")
+	s, err := ExportSnapshot(context.Background(), conn, 0, dir)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, s.Height)
+	assert.EqualValues(t, 1, s.Format)
+	conn.AssertExpectations(t)
+	return dir
+}
+
+// archiveTestSnapshot writes a tar archive, optionally gzip compressed, of the
+// content of the snapshot directory.
+func archiveTestSnapshot(t *testing.T, dir string, compress bool) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "snapshot.tar")
+	f, err := os.Create(file)
+	require.NoError(t, err)
+	defer f.Close()
+	var w io.Writer = f
+	if compress {
+		gw := gzip.NewWriter(f)
+		defer gw.Close()
+		w = gw
+	}
+	tw := tar.NewWriter(w)
+	defer tw.Close()
+
+	// An entry escaping the directory is ignored.
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "../evil", Mode: 0o600, Size: 1}))
+	_, err = tw.Write([]byte{1})
+	require.NoError(t, err)
+
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		bz, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		err = tw.WriteHeader(&tar.Header{Name: "./" + filepath.ToSlash(name), Mode: 0o600, Size: int64(len(bz))})
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(bz)
+		return err
+	})
+	require.NoError(t, err)
+	return file
+}
+
+func TestOpenLocalSnapshot(t *testing.T) {
+	dir := exportTestSnapshot(t)
+	expected := &snapshot{Height: 2, Format: 1, Chunks: 3, Hash: []byte{2}, Metadata: []byte("metadata")}
+
+	testcases := map[string]string{
+		"directory":   dir,
+		"tar":         archiveTestSnapshot(t, dir, false),
+		"tar.gz":      archiveTestSnapshot(t, dir, true),
+		"no snapshot": "",
+	}
+	for name, path := range testcases {
+		t.Run(name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			if path == "" {
+				_, err := openLocalSnapshot(filepath.Join(dir, "missing"), tempDir)
+				require.Error(t, err)
+				return
+			}
+			local, err := openLocalSnapshot(path, tempDir)
+			require.NoError(t, err)
+			assert.Equal(t, expected, local.snapshot)
+			for i := uint32(0); i < 3; i++ {
+				c, err := local.loadChunk(i)
+				require.NoError(t, err)
+				assert.Equal(t, &chunk{Height: 2, Format: 1, Index: i, Chunk: []byte{2, 1, byte(i)}}, c)
+			}
+
+			// The directory extracted from an archive is removed, and nothing
+			// was extracted outside of it.
+			require.NoError(t, local.Close())
+			assert.DirExists(t, dir)
+			entries, err := os.ReadDir(tempDir)
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	}
+
+	// A snapshot with a missing chunk is invalid.
+	require.NoError(t, os.Remove(filepath.Join(dir, localSnapshotChunksDir, "1")))
+	_, err := openLocalSnapshot(dir, t.TempDir())
+	require.Error(t, err)
+}
+
+func TestExportSnapshot_noSnapshot(t *testing.T) {
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("ListSnapshots", mock.Anything, &abci.RequestListSnapshots{}).Return(&abci.ResponseListSnapshots{
+		Snapshots: []*abci.Snapshot{{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}}},
+	}, nil)
+
+	dir := filepath.Join(t.TempDir(), "snapshot")
+	_, err := ExportSnapshot(context.Background(), conn, 2, dir)
+	require.Error(t, err)
+	assert.NoDirExists(t, dir)
+}
+
+func TestSyncer_SyncLocal(t *testing.T) {
+	local, err := openLocalSnapshot(exportTestSnapshot(t), "")
+	require.NoError(t, err)
+
+	state := sm.State{
+		ChainID: "chain",
+		Version: cmtstate.Version{
+			Consensus: cmtversion.Consensus{App: testAppVersion},
+		},
+		LastBlockHeight: 2,
+		AppHash:         []byte("app_hash"),
+	}
+	commit := &types.Commit{BlockID: types.BlockID{Hash: []byte("blockhash")}}
+
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, uint64(2)).Return([]byte("app_hash"), nil)
+	stateProvider.On("State", mock.Anything, uint64(2)).Return(state, nil)
+	stateProvider.On("Commit", mock.Anything, uint64(2)).Return(commit, nil)
+
+	connSnapshot := &proxymocks.AppConnSnapshot{}
+	connQuery := &proxymocks.AppConnQuery{}
+	connSnapshot.On("OfferSnapshot", mock.Anything, &abci.RequestOfferSnapshot{
+		Snapshot: &abci.Snapshot{Height: 2, Format: 1, Chunks: 3, Hash: []byte{2}, Metadata: []byte("metadata")},
+		AppHash:  []byte("app_hash"),
+	}).Times(2).Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil)
+
+	// The first time chunk 1 is applied, the app asks to refetch it and to retry the snapshot,
+	// so the chunk is loaded again from the local snapshot.
+	connSnapshot.On("ApplySnapshotChunk", mock.Anything, &abci.RequestApplySnapshotChunk{
+		Index: 0, Chunk: []byte{2, 1, 0},
+	}).Times(2).Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	connSnapshot.On("ApplySnapshotChunk", mock.Anything, &abci.RequestApplySnapshotChunk{
+		Index: 1, Chunk: []byte{2, 1, 1},
+	}).Once().Return(&abci.ResponseApplySnapshotChunk{
+		Result:        abci.ResponseApplySnapshotChunk_RETRY_SNAPSHOT,
+		RefetchChunks: []uint32{1},
+	}, nil)
+	connSnapshot.On("ApplySnapshotChunk", mock.Anything, &abci.RequestApplySnapshotChunk{
+		Index: 1, Chunk: []byte{2, 1, 1},
+	}).Once().Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	connSnapshot.On("ApplySnapshotChunk", mock.Anything, &abci.RequestApplySnapshotChunk{
+		Index: 2, Chunk: []byte{2, 1, 2},
+	}).Once().Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	connQuery.On("Info", mock.Anything, proxy.RequestInfo).Return(&abci.ResponseInfo{
+		AppVersion:       testAppVersion,
+		LastBlockHeight:  2,
+		LastBlockAppHash: []byte("app_hash"),
+	}, nil)
+
+	cfg := config.DefaultStateSyncConfig()
+	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+
+	start := time.Now()
+	newState, lastCommit, err := syncer.SyncLocal(local)
+	require.NoError(t, err)
+	assert.Equal(t, state, newState)
+	assert.Equal(t, commit, lastCommit)
+	// The chunks are not fetched from peers, with the chunk request timeout.
+	assert.Less(t, time.Since(start), cfg.ChunkRequestTimeout)
+
+	connSnapshot.AssertExpectations(t)
+	connQuery.AssertExpectations(t)
+}
+
+func TestSyncer_SyncLocal_appHashMismatch(t *testing.T) {
+	local, err := openLocalSnapshot(exportTestSnapshot(t), "")
+	require.NoError(t, err)
+
+	// The app hash verified by the light client does not match the one of
+	// the restored app.
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, uint64(2)).Return([]byte("trusted_app_hash"), nil)
+	stateProvider.On("State", mock.Anything, uint64(2)).Return(sm.State{
+		Version: cmtstate.Version{Consensus: cmtversion.Consensus{App: testAppVersion}},
+	}, nil)
+	stateProvider.On("Commit", mock.Anything, uint64(2)).Return(&types.Commit{}, nil)
+
+	connSnapshot := &proxymocks.AppConnSnapshot{}
+	connQuery := &proxymocks.AppConnQuery{}
+	connSnapshot.On("OfferSnapshot", mock.Anything, mock.Anything).Return(
+		&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil)
+	connSnapshot.On("ApplySnapshotChunk", mock.Anything, mock.Anything).Return(
+		&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	connQuery.On("Info", mock.Anything, proxy.RequestInfo).Return(&abci.ResponseInfo{
+		AppVersion:       testAppVersion,
+		LastBlockHeight:  2,
+		LastBlockAppHash: []byte("app_hash"),
+	}, nil)
+
+	cfg := config.DefaultStateSyncConfig()
+	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+
+	_, _, err = syncer.SyncLocal(local)
+	require.ErrorIs(t, err, errVerifyFailed)
+}
@@ -35,11 +35,14 @@ type Reactor struct {
 	connQuery proxy.AppConnQuery
 	tempDir   string
 	metrics   *Metrics
+	sources   []SnapshotSource
 
 	// This will only be set when a state sync is in progress. It is used to feed received
 	// snapshots and chunks into the sync.
 	mtx    cmtsync.RWMutex
 	syncer *syncer
+	// phase is the phase the last state sync ended in.
+	phase string
 }
 
 // NewReactor creates a new state sync reactor.
@@ -60,6 +63,14 @@ func NewReactor(
 	return r
 }
 
+// AddSnapshotSources adds sources of snapshots, other than the peers, to the state syncs. The
+// chunks of their snapshots are fetched from the sources, falling back to the peers.
+func (r *Reactor) AddSnapshotSources(sources ...SnapshotSource) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.sources = append(r.sources, sources...)
+}
+
 // GetChannels implements p2p.Reactor.
 func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 	return []*p2p.ChannelDescriptor{
@@ -262,14 +273,10 @@ func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 // Sync runs a state sync, returning the new state and last commit at the snapshot height.
 // The caller must store the state and commit in the state database and block store.
 func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration) (sm.State, *types.Commit, error) {
-	r.mtx.Lock()
-	if r.syncer != nil {
-		r.mtx.Unlock()
-		return sm.State{}, nil, errors.New("a state sync is already in progress")
+	syncer, err := r.startSync(stateProvider)
+	if err != nil {
+		return sm.State{}, nil, err
 	}
-	r.metrics.Syncing.Set(1)
-	r.syncer = newSyncer(r.cfg, r.Logger, r.conn, r.connQuery, stateProvider, r.tempDir)
-	r.mtx.Unlock()
 
 	hook := func() {
 		r.Logger.Debug("Requesting snapshots from known peers")
@@ -283,11 +290,71 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 
 	hook()
 
-	state, commit, err := r.syncer.SyncAny(discoveryTime, hook)
+	state, commit, err := syncer.SyncAny(discoveryTime, hook)
+	r.endSync(err)
+	return state, commit, err
+}
+
+// SyncLocal runs a state sync from the local snapshot of the directory or tar archive at the path,
+// as written by ExportSnapshot, instead of discovering snapshots and fetching their chunks from
+// the peers. The app hash of the snapshot is verified with the state provider. It returns the new
+// state and last commit at the snapshot height, which the caller must store in the state database
+// and block store.
+func (r *Reactor) SyncLocal(stateProvider StateProvider, snapshotPath string) (sm.State, *types.Commit, error) {
+	local, err := openLocalSnapshot(snapshotPath, r.tempDir)
+	if err != nil {
+		return sm.State{}, nil, err
+	}
+	defer func() {
+		if err := local.Close(); err != nil {
+			r.Logger.Error("Failed to clean up local snapshot", "err", err)
+		}
+	}()
+	r.Logger.Info("Restoring local snapshot", "path", snapshotPath, "height", local.snapshot.Height,
+		"format", local.snapshot.Format, "chunks", local.snapshot.Chunks)
 
+	syncer, err := r.startSync(stateProvider)
+	if err != nil {
+		return sm.State{}, nil, err
+	}
+	state, commit, err := syncer.SyncLocal(local)
+	r.endSync(err)
+	return state, commit, err
+}
+
+// startSync sets up the syncer of a new state sync.
+func (r *Reactor) startSync(stateProvider StateProvider) (*syncer, error) {
 	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.syncer != nil {
+		return nil, errors.New("a state sync is already in progress")
+	}
+	r.metrics.Syncing.Set(1)
+	r.syncer = newSyncer(r.cfg, r.Logger, r.conn, r.connQuery, stateProvider, r.tempDir)
+	r.syncer.AddSources(r.sources...)
+	return r.syncer, nil
+}
+
+// endSync records the end of the state sync in progress, failed if err is not nil.
+func (r *Reactor) endSync(err error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
 	r.syncer = nil
+	r.phase = SyncPhaseCompleted
+	if err != nil {
+		r.phase = SyncPhaseFailed
+	}
 	r.metrics.Syncing.Set(0)
-	r.mtx.Unlock()
-	return state, commit, err
+}
+
+// SyncPhase returns the phase of the state sync in progress, see the
+// SyncPhase constants, the phase the last one ended in, or an empty string if
+// no state sync was started.
+func (r *Reactor) SyncPhase() string {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.syncer != nil {
+		return r.syncer.Phase()
+	}
+	return r.phase
 }
@@ -41,8 +41,9 @@ func (s *snapshot) Key() snapshotKey {
 // snapshotPool discovers and aggregates snapshots across peers.
 type snapshotPool struct {
 	cmtsync.Mutex
-	snapshots     map[snapshotKey]*snapshot
-	snapshotPeers map[snapshotKey]map[p2p.ID]p2p.Peer
+	snapshots       map[snapshotKey]*snapshot
+	snapshotPeers   map[snapshotKey]map[p2p.ID]p2p.Peer
+	snapshotSources map[snapshotKey]map[string]SnapshotSource
 
 	// indexes for fast searches
 	formatIndex map[uint32]map[snapshotKey]bool
@@ -60,6 +61,7 @@ func newSnapshotPool() *snapshotPool {
 	return &snapshotPool{
 		snapshots:         make(map[snapshotKey]*snapshot),
 		snapshotPeers:     make(map[snapshotKey]map[p2p.ID]p2p.Peer),
+		snapshotSources:   make(map[snapshotKey]map[string]SnapshotSource),
 		formatIndex:       make(map[uint32]map[snapshotKey]bool),
 		heightIndex:       make(map[uint64]map[snapshotKey]bool),
 		peerIndex:         make(map[p2p.ID]map[snapshotKey]bool),
@@ -99,8 +101,39 @@ func (p *snapshotPool) Add(peer p2p.Peer, snapshot *snapshot) (bool, error) {
 	}
 	p.peerIndex[peer.ID()][key] = true
 
-	if p.snapshots[key] != nil {
+	return p.addSnapshot(key, snapshot), nil
+}
+
+// AddFromSource adds a snapshot of a snapshot source to the pool. It returns true if this was a
+// new, non-blacklisted snapshot. The source is blacklisted like a peer of the same ID.
+func (p *snapshotPool) AddFromSource(source SnapshotSource, snapshot *snapshot) (bool, error) {
+	key := snapshot.Key()
+
+	p.Lock()
+	defer p.Unlock()
+
+	switch {
+	case p.formatBlacklist[snapshot.Format]:
+		return false, nil
+	case p.peerBlacklist[p2p.ID(source.ID())]:
 		return false, nil
+	case p.snapshotBlacklist[key]:
+		return false, nil
+	}
+
+	if p.snapshotSources[key] == nil {
+		p.snapshotSources[key] = make(map[string]SnapshotSource)
+	}
+	p.snapshotSources[key][source.ID()] = source
+
+	return p.addSnapshot(key, snapshot), nil
+}
+
+// addSnapshot adds a snapshot to the indexes, returning false if already added. The caller must
+// hold the mutex lock.
+func (p *snapshotPool) addSnapshot(key snapshotKey, snapshot *snapshot) bool {
+	if p.snapshots[key] != nil {
+		return false
 	}
 	p.snapshots[key] = snapshot
 
@@ -114,7 +147,7 @@ func (p *snapshotPool) Add(peer p2p.Peer, snapshot *snapshot) (bool, error) {
 	}
 	p.heightIndex[snapshot.Height][key] = true
 
-	return true, nil
+	return true
 }
 
 // Best returns the "best" currently known snapshot, if any.
@@ -152,9 +185,25 @@ func (p *snapshotPool) GetPeers(snapshot *snapshot) []p2p.Peer {
 	return peers
 }
 
+// GetSources returns the snapshot sources for a snapshot.
+func (p *snapshotPool) GetSources(snapshot *snapshot) []SnapshotSource {
+	key := snapshot.Key()
+	p.Lock()
+	defer p.Unlock()
+
+	sources := make([]SnapshotSource, 0, len(p.snapshotSources[key]))
+	for _, source := range p.snapshotSources[key] {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(a int, b int) bool {
+		return sources[a].ID() < sources[b].ID()
+	})
+	return sources
+}
+
 // Ranked returns a list of snapshots ranked by preference. The current heuristic is very naïve,
 // preferring the snapshot with the greatest height, then greatest format, then greatest number of
-// peers. This can be improved quite a lot.
+// peers and snapshot sources. This can be improved quite a lot.
 func (p *snapshotPool) Ranked() []*snapshot {
 	p.Lock()
 	defer p.Unlock()
@@ -177,7 +226,7 @@ func (p *snapshotPool) Ranked() []*snapshot {
 			return true
 		case a.Format < b.Format:
 			return false
-		case len(p.snapshotPeers[a.Key()]) > len(p.snapshotPeers[b.Key()]):
+		case p.providers(a.Key()) > p.providers(b.Key()):
 			return true
 		default:
 			return false
@@ -187,6 +236,12 @@ func (p *snapshotPool) Ranked() []*snapshot {
 	return candidates
 }
 
+// providers returns the number of peers and snapshot sources of a snapshot. The caller must hold
+// the mutex lock.
+func (p *snapshotPool) providers(key snapshotKey) int {
+	return len(p.snapshotPeers[key]) + len(p.snapshotSources[key])
+}
+
 // Reject rejects a snapshot. Rejected snapshots will never be used again.
 func (p *snapshotPool) Reject(snapshot *snapshot) {
 	key := snapshot.Key()
@@ -208,7 +263,7 @@ func (p *snapshotPool) RejectFormat(format uint32) {
 	}
 }
 
-// RejectPeer rejects a peer. It will never be used again.
+// RejectPeer rejects a peer, or the snapshot source of the given ID. It will never be used again.
 func (p *snapshotPool) RejectPeer(peerID p2p.ID) {
 	if peerID == "" {
 		return
@@ -217,10 +272,11 @@ func (p *snapshotPool) RejectPeer(peerID p2p.ID) {
 	defer p.Unlock()
 
 	p.removePeer(peerID)
+	p.removeSource(string(peerID))
 	p.peerBlacklist[peerID] = true
 }
 
-// RemovePeer removes a peer from the pool, and any snapshots that no longer have peers.
+// RemovePeer removes a peer from the pool, and any snapshots that no longer have peers or sources.
 func (p *snapshotPool) RemovePeer(peerID p2p.ID) {
 	p.Lock()
 	defer p.Unlock()
@@ -231,13 +287,27 @@ func (p *snapshotPool) RemovePeer(peerID p2p.ID) {
 func (p *snapshotPool) removePeer(peerID p2p.ID) {
 	for key := range p.peerIndex[peerID] {
 		delete(p.snapshotPeers[key], peerID)
-		if len(p.snapshotPeers[key]) == 0 {
+		if p.providers(key) == 0 {
 			p.removeSnapshot(key)
 		}
 	}
 	delete(p.peerIndex, peerID)
 }
 
+// removeSource removes a snapshot source, and any snapshots that no longer have peers or sources.
+// The caller must hold the mutex lock.
+func (p *snapshotPool) removeSource(sourceID string) {
+	for key, sources := range p.snapshotSources {
+		if _, ok := sources[sourceID]; !ok {
+			continue
+		}
+		delete(sources, sourceID)
+		if p.providers(key) == 0 {
+			p.removeSnapshot(key)
+		}
+	}
+}
+
 // removeSnapshot removes a snapshot. The caller must hold the mutex lock.
 func (p *snapshotPool) removeSnapshot(key snapshotKey) {
 	snapshot := p.snapshots[key]
@@ -252,4 +322,5 @@ func (p *snapshotPool) removeSnapshot(key snapshotKey) {
 		delete(p.peerIndex[peerID], key)
 	}
 	delete(p.snapshotPeers, key)
+	delete(p.snapshotSources, key)
 }
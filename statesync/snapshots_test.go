@@ -301,3 +301,37 @@ func TestSnapshotPool_RemovePeer(t *testing.T) {
 	assert.EqualValues(t, "a", peers1[0].ID())
 	assert.EqualValues(t, "b", peers1[1].ID())
 }
+
+func TestSnapshotPool_AddFromSource(t *testing.T) {
+	peer := &p2pmocks.Peer{}
+	peer.On("ID").Return(p2p.ID("id"))
+	source := testSource("https://example.com/snapshot")
+	s1 := &snapshot{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}}
+	s2 := &snapshot{Height: 2, Format: 1, Chunks: 1, Hash: []byte{2}}
+
+	pool := newSnapshotPool()
+	added, err := pool.AddFromSource(source, s1)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	// Adding the same snapshot from a peer should return false, the snapshot being served by both
+	added, err = pool.Add(peer, s1)
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.Equal(t, []SnapshotSource{source}, pool.GetSources(s1))
+	assert.Len(t, pool.GetPeers(s1), 1)
+
+	// A snapshot only served by a source is kept when the peers are removed
+	added, err = pool.AddFromSource(source, s2)
+	require.NoError(t, err)
+	assert.True(t, added)
+	pool.RemovePeer(peer.ID())
+	assert.Equal(t, []*snapshot{s2, s1}, pool.Ranked())
+
+	// Rejecting the source removes its snapshots, and blacklists it
+	pool.RejectPeer(p2p.ID(source.ID()))
+	assert.Empty(t, pool.Ranked())
+	added, err = pool.AddFromSource(source, s1)
+	require.NoError(t, err)
+	assert.False(t, added)
+}
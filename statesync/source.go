@@ -0,0 +1,121 @@
+package statesync
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+)
+
+// SnapshotSource is a source of snapshots other than the peers, e.g. an HTTPS endpoint or an S3
+// bucket. The chunks of its snapshots are fetched from it, falling back to the peers advertising
+// the same snapshots if it fails.
+type SnapshotSource interface {
+	// ID identifies the source in the logs, and as the sender of its chunks. It must not be the
+	// ID of a peer.
+	ID() string
+	// Snapshots returns the snapshots of the source.
+	Snapshots(ctx context.Context) ([]*abci.Snapshot, error)
+	// LoadChunk returns a chunk of a snapshot of the source.
+	LoadChunk(ctx context.Context, height uint64, format uint32, index uint32) ([]byte, error)
+}
+
+// HTTPSnapshotSource is a snapshot source serving a snapshot exported by ExportSnapshot over
+// HTTP(S): the metadata of the snapshot at <url>/snapshot.json, and its chunks at
+// <url>/chunks/<index>.
+type HTTPSnapshotSource struct {
+	url    string
+	id     string // the URL without its password
+	client *http.Client
+}
+
+var _ SnapshotSource = (*HTTPSnapshotSource)(nil)
+
+// NewHTTPSnapshotSource returns a source of the snapshot exported at the HTTP(S) URL, or at the
+// s3://<bucket>/<prefix> URL of an S3 bucket, which must allow anonymous reads.
+func NewHTTPSnapshotSource(rawURL string, client *http.Client) (*HTTPSnapshotSource, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot source %q: %w", rawURL, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid snapshot source %q: no host", rawURL)
+	}
+	switch u.Scheme {
+	case "http", "https":
+	case "s3":
+		// The virtual-hosted-style URL of the bucket, redirected to its region.
+		u.Scheme = "https"
+		u.Host += ".s3.amazonaws.com"
+	default:
+		return nil, fmt.Errorf("invalid snapshot source %q: scheme must be http, https or s3", rawURL)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPSnapshotSource{
+		url:    strings.TrimSuffix(u.String(), "/"),
+		id:     strings.TrimSuffix(u.Redacted(), "/"),
+		client: client,
+	}, nil
+}
+
+// ID implements SnapshotSource.
+func (s *HTTPSnapshotSource) ID() string {
+	return s.id
+}
+
+// Snapshots implements SnapshotSource.
+func (s *HTTPSnapshotSource) Snapshots(ctx context.Context) ([]*abci.Snapshot, error) {
+	bz, err := s.get(ctx, localSnapshotMetadataFile, snapshotMsgSize)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := parseLocalSnapshotMetadata(bz)
+	if err != nil {
+		return nil, err
+	}
+	return []*abci.Snapshot{{
+		Height:   metadata.Height,
+		Format:   metadata.Format,
+		Chunks:   metadata.Chunks,
+		Hash:     metadata.Hash,
+		Metadata: metadata.Metadata,
+	}}, nil
+}
+
+// LoadChunk implements SnapshotSource. The source serving a single snapshot, the height and the
+// format are those of the snapshot.
+func (s *HTTPSnapshotSource) LoadChunk(ctx context.Context, _ uint64, _ uint32, index uint32) ([]byte, error) {
+	return s.get(ctx, localSnapshotChunksDir+"/"+strconv.FormatUint(uint64(index), 10), chunkMsgSize)
+}
+
+// get returns the content of the file at the path of the source, failing if larger than maxSize,
+// like the messages of the peers.
+func (s *HTTPSnapshotSource) get(ctx context.Context, path string, maxSize int) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url+"/"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get %v/%v: %v", s.id, path, resp.Status)
+	}
+	bz, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxSize)+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %v/%v: %w", s.id, path, err)
+	}
+	if len(bz) > maxSize {
+		return nil, fmt.Errorf("%v/%v is larger than %v bytes", s.id, path, maxSize)
+	}
+	return bz, nil
+}
@@ -42,6 +42,20 @@ type lightClientStateProvider struct {
 	version       cmtstate.Version
 	initialHeight int64
 	providers     map[lightprovider.Provider]string
+	blockTime     func(time.Time) time.Time
+}
+
+// StateProviderOption sets an optional parameter on the light client state
+// provider.
+type StateProviderOption func(*lightClientStateProvider)
+
+// StateProviderBlockTime sets the time of the blocks at each time of the local
+// clock, against which the light client verifies the headers, e.g. for the
+// compressed block times of a test network.
+func StateProviderBlockTime(blockTime func(time.Time) time.Time) StateProviderOption {
+	return func(s *lightClientStateProvider) {
+		s.blockTime = blockTime
+	}
 }
 
 // NewLightClientStateProvider creates a new StateProvider using a light client and RPC clients.
@@ -53,6 +67,7 @@ func NewLightClientStateProvider(
 	servers []string,
 	trustOptions light.TrustOptions,
 	logger log.Logger,
+	options ...StateProviderOption,
 ) (StateProvider, error) {
 	if len(servers) < 2 {
 		return nil, fmt.Errorf("at least 2 RPC servers are required, got %v", len(servers))
@@ -77,12 +92,17 @@ func NewLightClientStateProvider(
 	if err != nil {
 		return nil, err
 	}
-	return &lightClientStateProvider{
+	s := &lightClientStateProvider{
 		lc:            lc,
 		version:       version,
 		initialHeight: initialHeight,
 		providers:     providerRemotes,
-	}, nil
+		blockTime:     func(t time.Time) time.Time { return t },
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s, nil
 }
 
 // AppHash implements StateProvider.
@@ -91,7 +111,7 @@ func (s *lightClientStateProvider) AppHash(ctx context.Context, height uint64) (
 	defer s.Unlock()
 
 	// We have to fetch the next height, which contains the app hash for the previous height.
-	header, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height+1), time.Now())
+	header, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height+1), s.blockTime(time.Now()))
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +123,7 @@ func (s *lightClientStateProvider) AppHash(ctx context.Context, height uint64) (
 	// breaking it. We should instead have a Has(ctx, height) method which checks
 	// that the state provider has access to the necessary data for the height.
 	// We piggyback on AppHash() since it's called when adding snapshots to the pool.
-	_, err = s.lc.VerifyLightBlockAtHeight(ctx, int64(height+2), time.Now())
+	_, err = s.lc.VerifyLightBlockAtHeight(ctx, int64(height+2), s.blockTime(time.Now()))
 	if err != nil {
 		return nil, err
 	}
@@ -114,7 +134,7 @@ func (s *lightClientStateProvider) AppHash(ctx context.Context, height uint64) (
 func (s *lightClientStateProvider) Commit(ctx context.Context, height uint64) (*types.Commit, error) {
 	s.Lock()
 	defer s.Unlock()
-	header, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height), time.Now())
+	header, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height), s.blockTime(time.Now()))
 	if err != nil {
 		return nil, err
 	}
@@ -143,15 +163,15 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	//
 	// We need to fetch the NextValidators from height+2 because if the application changed
 	// the validator set at the snapshot height then this only takes effect at height+2.
-	lastLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height), time.Now())
+	lastLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height), s.blockTime(time.Now()))
 	if err != nil {
 		return sm.State{}, err
 	}
-	currentLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height+1), time.Now())
+	currentLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height+1), s.blockTime(time.Now()))
 	if err != nil {
 		return sm.State{}, err
 	}
-	nextLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height+2), time.Now())
+	nextLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height+2), s.blockTime(time.Now()))
 	if err != nil {
 		return sm.State{}, err
 	}
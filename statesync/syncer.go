@@ -47,6 +47,22 @@ var (
 	errNoSnapshots = errors.New("no suitable snapshots found")
 )
 
+// Phases of a state sync, reported by Reactor.SyncPhase.
+const (
+	// The snapshots are discovered from the peers.
+	SyncPhaseDiscovering = "discovering_snapshots"
+	// A snapshot is verified with the light client and offered to the app.
+	SyncPhaseOffering = "offering_snapshot"
+	// The chunks of the snapshot are fetched from the peers and applied by
+	// the app.
+	SyncPhaseApplyingChunks = "applying_chunks"
+	// The app hash and version of the restored app are verified.
+	SyncPhaseVerifying = "verifying_app"
+	// The state sync completed, or failed.
+	SyncPhaseCompleted = "completed"
+	SyncPhaseFailed    = "failed"
+)
+
 // syncer runs a state sync against an ABCI app. Use either SyncAny() to automatically attempt to
 // sync all snapshots in the pool (pausing to discover new ones), or Sync() to sync a specific
 // snapshot. Snapshots and chunks are fed via AddSnapshot() and AddChunk() as appropriate.
@@ -56,12 +72,15 @@ type syncer struct {
 	conn          proxy.AppConnSnapshot
 	connQuery     proxy.AppConnQuery
 	snapshots     *snapshotPool
+	sources       []SnapshotSource
 	tempDir       string
 	chunkFetchers int32
 	retryTimeout  time.Duration
 
 	mtx    cmtsync.RWMutex
 	chunks *chunkQueue
+	phase  string
+	local  *localSnapshot // set when syncing a local snapshot, to load its chunks
 }
 
 // newSyncer creates a new syncer.
@@ -86,6 +105,19 @@ func newSyncer(
 	}
 }
 
+// Phase returns the phase of the sync in progress.
+func (s *syncer) Phase() string {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.phase
+}
+
+func (s *syncer) setPhase(phase string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.phase = phase
+}
+
 // AddChunk adds a chunk to the chunk queue, if any. It returns false if the chunk has already
 // been added to the queue, or an error if there's no sync in progress.
 func (s *syncer) AddChunk(chunk *chunk) (bool, error) {
@@ -122,6 +154,52 @@ func (s *syncer) AddSnapshot(peer p2p.Peer, snapshot *snapshot) (bool, error) {
 	return added, nil
 }
 
+// AddSources adds snapshot sources, whose snapshots are discovered along with the ones of the
+// peers.
+func (s *syncer) AddSources(sources ...SnapshotSource) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.sources = append(s.sources, sources...)
+}
+
+// discoverSources adds the snapshots of the snapshot sources to the pool.
+func (s *syncer) discoverSources() {
+	s.mtx.RLock()
+	sources := s.sources
+	s.mtx.RUnlock()
+
+	for _, source := range sources {
+		ctx, cancel := context.WithTimeout(context.TODO(), s.retryTimeout)
+		snapshots, err := source.Snapshots(ctx)
+		cancel()
+		if err != nil {
+			s.logger.Error("Failed to discover snapshots of source", "source", source.ID(), "err", err)
+			continue
+		}
+		for _, snapshot := range snapshots {
+			s.addSourceSnapshot(source, snapshot)
+		}
+	}
+}
+
+func (s *syncer) addSourceSnapshot(source SnapshotSource, abciSnapshot *abci.Snapshot) {
+	added, err := s.snapshots.AddFromSource(source, &snapshot{
+		Height:   abciSnapshot.Height,
+		Format:   abciSnapshot.Format,
+		Chunks:   abciSnapshot.Chunks,
+		Hash:     abciSnapshot.Hash,
+		Metadata: abciSnapshot.Metadata,
+	})
+	if err != nil {
+		s.logger.Error("Failed to add snapshot of source", "source", source.ID(), "err", err)
+		return
+	}
+	if added {
+		s.logger.Info("Discovered new snapshot", "height", abciSnapshot.Height, "format", abciSnapshot.Format,
+			"hash", log.NewLazySprintf("%X", abciSnapshot.Hash), "source", source.ID())
+	}
+}
+
 // AddPeer adds a peer to the pool. For now we just keep it simple and send a single request
 // to discover snapshots, later we may want to do retries and stuff.
 func (s *syncer) AddPeer(peer p2p.Peer) {
@@ -147,7 +225,10 @@ func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.Stat
 		discoveryTime = 5 * minimumDiscoveryTime
 	}
 
+	s.discoverSources()
+
 	if discoveryTime > 0 {
+		s.setPhase(SyncPhaseDiscovering)
 		s.logger.Info("Discovering snapshots", "discoverTime", discoveryTime)
 		time.Sleep(discoveryTime)
 	}
@@ -169,7 +250,9 @@ func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.Stat
 			if discoveryTime == 0 {
 				return sm.State{}, nil, errNoSnapshots
 			}
+			s.setPhase(SyncPhaseDiscovering)
 			retryHook()
+			s.discoverSources()
 			s.logger.Info("sync any", "msg", log.NewLazySprintf("Discovering snapshots for %v", discoveryTime))
 			time.Sleep(discoveryTime)
 			continue
@@ -217,6 +300,10 @@ func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.Stat
 				s.snapshots.RejectPeer(peer.ID())
 				s.logger.Info("Snapshot sender rejected", "peer", peer.ID())
 			}
+			for _, source := range s.snapshots.GetSources(snapshot) {
+				s.snapshots.RejectPeer(p2p.ID(source.ID()))
+				s.logger.Info("Snapshot source rejected", "source", source.ID())
+			}
 
 		case errors.Is(err, context.DeadlineExceeded):
 			s.logger.Info("Timed out validating snapshot, rejecting", "height", snapshot.Height, "err", err)
@@ -236,6 +323,34 @@ func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.Stat
 	}
 }
 
+// SyncLocal syncs the local snapshot, loading its chunks rather than fetching
+// them from the peers. It returns the latest state and block commit which the
+// caller must use to bootstrap the node.
+func (s *syncer) SyncLocal(local *localSnapshot) (sm.State, *types.Commit, error) {
+	s.mtx.Lock()
+	s.local = local
+	s.mtx.Unlock()
+
+	chunks, err := newChunkQueue(local.snapshot, s.tempDir)
+	if err != nil {
+		return sm.State{}, nil, fmt.Errorf("failed to create chunk queue: %w", err)
+	}
+	defer chunks.Close()
+
+	for {
+		state, commit, err := s.Sync(local.snapshot, chunks)
+		if !errors.Is(err, errRetrySnapshot) {
+			if err != nil {
+				return sm.State{}, nil, fmt.Errorf("local snapshot restoration failed: %w", err)
+			}
+			return state, commit, nil
+		}
+		chunks.RetryAll()
+		s.logger.Info("Retrying local snapshot", "height", local.snapshot.Height,
+			"format", local.snapshot.Format, "hash", log.NewLazySprintf("%X", local.snapshot.Hash))
+	}
+}
+
 // Sync executes a sync for a specific snapshot, returning the latest state and block commit which
 // the caller must use to bootstrap the node.
 func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.Commit, error) {
@@ -245,6 +360,8 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 		return sm.State{}, nil, errors.New("a state sync is already in progress")
 	}
 	s.chunks = chunks
+	s.phase = SyncPhaseOffering
+	local := s.local
 	s.mtx.Unlock()
 	defer func() {
 		s.mtx.Lock()
@@ -271,11 +388,18 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 		return sm.State{}, nil, err
 	}
 
-	// Spawn chunk fetchers. They will terminate when the chunk queue is closed or context canceled.
+	s.setPhase(SyncPhaseApplyingChunks)
+
+	// Spawn chunk fetchers, or the loader of the chunks of a local snapshot. They will terminate
+	// when the chunk queue is closed or context canceled.
 	fetchCtx, cancel := context.WithCancel(context.TODO())
 	defer cancel()
-	for i := int32(0); i < s.chunkFetchers; i++ {
-		go s.fetchChunks(fetchCtx, snapshot, chunks)
+	if local != nil {
+		go s.loadChunks(fetchCtx, local, chunks)
+	} else {
+		for i := int32(0); i < s.chunkFetchers; i++ {
+			go s.fetchChunks(fetchCtx, snapshot, chunks)
+		}
 	}
 
 	pctx, pcancel := context.WithTimeout(context.TODO(), 30*time.Second)
@@ -306,6 +430,7 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	}
 
 	// Verify app and app version
+	s.setPhase(SyncPhaseVerifying)
 	if err := s.verifyApp(snapshot, state.Version.Consensus.App); err != nil {
 		return sm.State{}, nil, err
 	}
@@ -441,6 +566,12 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 		s.logger.Info("Fetching snapshot chunk", "height", snapshot.Height,
 			"format", snapshot.Format, "chunk", index, "total", chunks.Size())
 
+		// Fetch the chunk from the snapshot sources, if any, falling back to the peers.
+		if s.loadSourceChunk(ctx, snapshot, index, chunks) {
+			next = true
+			continue
+		}
+
 		ticker := time.NewTicker(s.retryTimeout)
 		defer ticker.Stop()
 
@@ -461,6 +592,65 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 	}
 }
 
+// loadChunks adds the chunks of the local snapshot to the chunk queue, including the ones to
+// refetch, until the context is canceled.
+func (s *syncer) loadChunks(ctx context.Context, local *localSnapshot, chunks *chunkQueue) {
+	for {
+		index, err := chunks.Allocate()
+		if errors.Is(err, errDone) {
+			// Keep checking until the context is canceled (restore is done), in case any
+			// chunks need to be reloaded.
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+		if err != nil {
+			s.logger.Error("Failed to allocate chunk from queue", "err", err)
+			return
+		}
+		chunk, err := local.loadChunk(index)
+		if err != nil {
+			s.logger.Error("Failed to load local snapshot chunk", "chunk", index, "err", err)
+			return
+		}
+		if _, err := chunks.Add(chunk); err != nil {
+			s.logger.Error("Failed to add chunk", "chunk", index, "err", err)
+			return
+		}
+	}
+}
+
+// loadSourceChunk loads a chunk from the first snapshot source of the snapshot serving it, and
+// adds it to the chunk queue. It returns false if no source served the chunk.
+func (s *syncer) loadSourceChunk(ctx context.Context, snapshot *snapshot, index uint32, chunks *chunkQueue) bool {
+	for _, source := range s.snapshots.GetSources(snapshot) {
+		lctx, cancel := context.WithTimeout(ctx, s.retryTimeout)
+		bz, err := source.LoadChunk(lctx, snapshot.Height, snapshot.Format, index)
+		cancel()
+		if err != nil {
+			s.logger.Error("Failed to load snapshot chunk from source", "height", snapshot.Height,
+				"format", snapshot.Format, "chunk", index, "source", source.ID(), "err", err)
+			continue
+		}
+		_, err = chunks.Add(&chunk{
+			Height: snapshot.Height,
+			Format: snapshot.Format,
+			Index:  index,
+			Chunk:  bz,
+			Sender: p2p.ID(source.ID()),
+		})
+		if err != nil {
+			s.logger.Error("Failed to add chunk", "chunk", index, "source", source.ID(), "err", err)
+			continue
+		}
+		return true
+	}
+	return false
+}
+
 // requestChunk requests a chunk from a peer.
 func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
 	peer := s.snapshots.GetPeer(snapshot)
@@ -211,8 +211,10 @@ func TestSyncer_SyncAny(t *testing.T) {
 		LastBlockAppHash: []byte("app_hash"),
 	}, nil)
 
+	require.Empty(t, syncer.Phase())
 	newState, lastCommit, err := syncer.SyncAny(0, func() {})
 	require.NoError(t, err)
+	assert.Equal(t, SyncPhaseVerifying, syncer.Phase())
 
 	time.Sleep(50 * time.Millisecond) // wait for peers to receive requests
 
@@ -0,0 +1,140 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	sm "github.com/cometbft/cometbft/state"
+)
+
+const (
+	// maxBackupAttempts is the number of times a backup is attempted before
+	// giving up, should the block and state stores keep being snapshotted at
+	// heights too far apart from each other.
+	maxBackupAttempts = 3
+
+	// backupBatchSize is the number of keys written to the backup databases
+	// per batch.
+	backupBatchSize = 1000
+)
+
+// BackupInfo describes a backup of the block and state stores.
+type BackupInfo struct {
+	// Directory holding the backup databases.
+	Dir string
+	// Height of the last block committed to the backup state store.
+	StateHeight int64
+	// Height of the last block saved to the backup block store.
+	BlockStoreHeight int64
+}
+
+// Backup copies a snapshot of the block store and of the given state database
+// into new "blockstore" and "state" databases of the given backend, in the
+// given directory. It can be called while the node is running: the snapshots
+// are taken through database iterators, which all backends serve from a
+// point-in-time view of the database.
+//
+// The state store is snapshotted first, so that the block store in the backup
+// is either at the same height or one height ahead, which the handshake with
+// the application recovers from when the node is restarted from the backup.
+// The backup is attempted again if the block store got further ahead.
+//
+// The directory must not exist or be empty.
+func Backup(bs *BlockStore, stateDB dbm.DB, backend dbm.BackendType, dir string) (*BackupInfo, error) {
+	if backend == dbm.MemDBBackend {
+		return nil, errors.New("cannot back up in-memory databases")
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("backup directory %s is not empty", dir)
+	} else if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	for attempt := 1; attempt <= maxBackupAttempts; attempt++ {
+		info, err := backup(bs.db, stateDB, backend, dir)
+		if err != nil {
+			return nil, err
+		}
+		if info.BlockStoreHeight-info.StateHeight <= 1 {
+			return info, nil
+		}
+		for _, name := range []string{"blockstore", "state"} {
+			if err := os.RemoveAll(filepath.Join(dir, name+".db")); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return nil, fmt.Errorf("block and state stores snapshotted at inconsistent heights after %d attempts", maxBackupAttempts)
+}
+
+func backup(blockStoreDB, stateDB dbm.DB, backend dbm.BackendType, dir string) (*BackupInfo, error) {
+	stateItr, err := stateDB.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer stateItr.Close()
+	blockStoreItr, err := blockStoreDB.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer blockStoreItr.Close()
+
+	stateBackup, err := dbm.NewDB("state", backend, dir)
+	if err != nil {
+		return nil, err
+	}
+	defer stateBackup.Close()
+	blockStoreBackup, err := dbm.NewDB("blockstore", backend, dir)
+	if err != nil {
+		return nil, err
+	}
+	defer blockStoreBackup.Close()
+
+	if err := copyDB(stateBackup, stateItr); err != nil {
+		return nil, fmt.Errorf("failed to back up state store: %w", err)
+	}
+	if err := copyDB(blockStoreBackup, blockStoreItr); err != nil {
+		return nil, fmt.Errorf("failed to back up block store: %w", err)
+	}
+
+	state, err := sm.NewStore(stateBackup, sm.StoreOptions{}).Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load backed up state: %w", err)
+	}
+	return &BackupInfo{
+		Dir:              dir,
+		StateHeight:      state.LastBlockHeight,
+		BlockStoreHeight: LoadBlockStoreState(blockStoreBackup).Height,
+	}, nil
+}
+
+// copyDB writes all the entries of the iterator to the database.
+func copyDB(db dbm.DB, itr dbm.Iterator) error {
+	batch := db.NewBatch()
+	defer func() {
+		batch.Close()
+	}()
+
+	n := 0
+	for ; itr.Valid(); itr.Next() {
+		if err := batch.Set(itr.Key(), itr.Value()); err != nil {
+			return err
+		}
+		n++
+		if n%backupBatchSize == 0 {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Close()
+			batch = db.NewBatch()
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return err
+	}
+	return batch.WriteSync()
+}
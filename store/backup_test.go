@@ -0,0 +1,79 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/internal/test"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+func TestBackup(t *testing.T) {
+	config := test.ResetTestRoot("backup_test")
+	defer os.RemoveAll(config.RootDir)
+
+	dataDir := t.TempDir()
+	stateDB, err := dbm.NewDB("state", dbm.GoLevelDBBackend, dataDir)
+	require.NoError(t, err)
+	defer stateDB.Close()
+	blockDB, err := dbm.NewDB("blockstore", dbm.GoLevelDBBackend, dataDir)
+	require.NoError(t, err)
+	defer blockDB.Close()
+
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+	bs := NewBlockStore(blockDB)
+
+	// The block store is one height ahead of the state, as is the case
+	// between saving a block and applying it.
+	for h := int64(1); h <= 1500; h++ {
+		block := state.MakeBlock(h, test.MakeNTxs(h, 2), new(types.Commit), nil, state.Validators.GetProposer().Address)
+		partSet, err := block.MakePartSet(2)
+		require.NoError(t, err)
+		bs.SaveBlockWithExtendedCommit(block, partSet, makeTestExtCommit(h, cmttime.Now()))
+	}
+	state.LastBlockHeight = 1499
+	state.LastValidators = state.Validators.Copy()
+	require.NoError(t, stateStore.Save(state))
+
+	_, err = Backup(bs, stateDB, dbm.MemDBBackend, t.TempDir())
+	require.Error(t, err)
+
+	notEmpty := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(notEmpty, "file"), nil, 0o600))
+	_, err = Backup(bs, stateDB, dbm.GoLevelDBBackend, notEmpty)
+	require.Error(t, err)
+
+	backupDir := filepath.Join(t.TempDir(), "backup")
+	info, err := Backup(bs, stateDB, dbm.GoLevelDBBackend, backupDir)
+	require.NoError(t, err)
+	require.Equal(t, backupDir, info.Dir)
+	require.EqualValues(t, 1499, info.StateHeight)
+	require.EqualValues(t, 1500, info.BlockStoreHeight)
+
+	// The backup can be opened and holds the same data.
+	backupStateDB, err := dbm.NewDB("state", dbm.GoLevelDBBackend, backupDir)
+	require.NoError(t, err)
+	defer backupStateDB.Close()
+	backupBlockDB, err := dbm.NewDB("blockstore", dbm.GoLevelDBBackend, backupDir)
+	require.NoError(t, err)
+	defer backupBlockDB.Close()
+
+	backupState, err := sm.NewStore(backupStateDB, sm.StoreOptions{}).Load()
+	require.NoError(t, err)
+	require.Equal(t, state.LastBlockHeight, backupState.LastBlockHeight)
+	require.Equal(t, state.ChainID, backupState.ChainID)
+
+	backupBS := NewBlockStore(backupBlockDB)
+	require.EqualValues(t, 1, backupBS.Base())
+	require.EqualValues(t, 1500, backupBS.Height())
+	require.Equal(t, bs.LoadBlock(1234).Hash(), backupBS.LoadBlock(1234).Hash())
+}
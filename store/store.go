@@ -46,6 +46,10 @@ type BlockStore struct {
 	mtx    cmtsync.RWMutex
 	base   int64
 	height int64
+
+	// readReplica is set on the read replicas of a block store, which load the
+	// base and height from the database, as they are updated by the writer.
+	readReplica bool
 }
 
 // NewBlockStore returns a new BlockStore with the given DB,
@@ -59,38 +63,64 @@ func NewBlockStore(db dbm.DB) *BlockStore {
 	}
 }
 
-func (bs *BlockStore) IsEmpty() bool {
+// ReadReplica returns a read replica of the block store, for serving queries
+// without contending with the block store used by consensus. The replica
+// reads the database of the block store through a read-only view, and loads
+// the base and height from it. Writing to the replica fails, and closing it
+// leaves the database open.
+func (bs *BlockStore) ReadReplica() *BlockStore {
+	return &BlockStore{
+		db:          sm.NewReadOnlyDB(bs.db),
+		readReplica: true,
+	}
+}
+
+// baseAndHeight returns the base and height of the block store.
+func (bs *BlockStore) baseAndHeight() (int64, int64) {
+	if bs.readReplica {
+		bss := LoadBlockStoreState(bs.db)
+		return bss.Base, bss.Height
+	}
 	bs.mtx.RLock()
 	defer bs.mtx.RUnlock()
-	return bs.base == bs.height && bs.base == 0
+	return bs.base, bs.height
+}
+
+func (bs *BlockStore) IsEmpty() bool {
+	base, height := bs.baseAndHeight()
+	return base == height && base == 0
 }
 
 // Base returns the first known contiguous block height, or 0 for empty block stores.
 func (bs *BlockStore) Base() int64 {
-	bs.mtx.RLock()
-	defer bs.mtx.RUnlock()
-	return bs.base
+	base, _ := bs.baseAndHeight()
+	return base
 }
 
 // Height returns the last known contiguous block height, or 0 for empty block stores.
 func (bs *BlockStore) Height() int64 {
-	bs.mtx.RLock()
-	defer bs.mtx.RUnlock()
-	return bs.height
+	_, height := bs.baseAndHeight()
+	return height
 }
 
 // Size returns the number of blocks in the block store.
 func (bs *BlockStore) Size() int64 {
-	bs.mtx.RLock()
-	defer bs.mtx.RUnlock()
-	if bs.height == 0 {
+	base, height := bs.baseAndHeight()
+	if height == 0 {
 		return 0
 	}
-	return bs.height - bs.base + 1
+	return height - base + 1
 }
 
 // LoadBase atomically loads the base block meta, or returns nil if no base is found.
 func (bs *BlockStore) LoadBaseMeta() *types.BlockMeta {
+	if bs.readReplica {
+		base, _ := bs.baseAndHeight()
+		if base == 0 {
+			return nil
+		}
+		return bs.LoadBlockMeta(base)
+	}
 	bs.mtx.RLock()
 	defer bs.mtx.RUnlock()
 	if bs.base == 0 {
@@ -366,6 +396,9 @@ func (bs *BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64,
 		if err := batch.Delete(calcSeenCommitKey(h)); err != nil {
 			return 0, -1, err
 		}
+		if err := batch.Delete(calcExtCommitKey(h)); err != nil {
+			return 0, -1, err
+		}
 		for p := 0; p < int(meta.BlockID.PartSetHeader.Total); p++ {
 			if err := batch.Delete(calcBlockPartKey(h, p)); err != nil {
 				return 0, -1, err
@@ -646,6 +679,9 @@ func (bs *BlockStore) DeleteLatestBlock() error {
 	if err := batch.Delete(calcSeenCommitKey(targetHeight)); err != nil {
 		return err
 	}
+	if err := batch.Delete(calcExtCommitKey(targetHeight)); err != nil {
+		return err
+	}
 	// delete last, so as to not leave keys built on meta.BlockID dangling
 	if err := batch.Delete(calcBlockMetaKey(targetHeight)); err != nil {
 		return err
@@ -486,6 +486,44 @@ func TestLoadBaseMeta(t *testing.T) {
 	require.EqualValues(t, 9, bs.Height())
 }
 
+func TestBlockStoreReadReplica(t *testing.T) {
+	config := test.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+	bs, _ := newInMemoryBlockStore()
+	replica := bs.ReadReplica()
+	require.True(t, replica.IsEmpty())
+
+	// The replica follows the blocks saved and pruned by the block store.
+	for h := int64(1); h <= 10; h++ {
+		block := state.MakeBlock(h, test.MakeNTxs(h, 10), new(types.Commit), nil, state.Validators.GetProposer().Address)
+		partSet, err := block.MakePartSet(2)
+		require.NoError(t, err)
+		seenCommit := makeTestExtCommit(h, cmttime.Now())
+		bs.SaveBlockWithExtendedCommit(block, partSet, seenCommit)
+	}
+	assert.EqualValues(t, 10, replica.Height())
+	assert.EqualValues(t, bs.LoadBlock(10).Hash(), replica.LoadBlock(10).Hash())
+
+	_, _, err = bs.PruneBlocks(4, state)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, replica.Base())
+	assert.EqualValues(t, 7, replica.Size())
+	assert.EqualValues(t, 4, replica.LoadBaseMeta().Header.Height)
+	assert.Nil(t, replica.LoadBlock(3))
+
+	// The replica cannot write, and closing it leaves the database open.
+	require.Error(t, replica.SaveSeenCommit(10, &types.Commit{}))
+	_, _, err = replica.PruneBlocks(5, state)
+	require.Error(t, err)
+	require.NoError(t, replica.Close())
+	require.NotNil(t, bs.LoadBlock(10))
+}
+
 func TestLoadBlockPart(t *testing.T) {
 	config := test.ResetTestRoot("blockchain_reactor_test")
 
@@ -608,7 +646,7 @@ func TestPruningService(t *testing.T) {
 	// Generate a bunch of state data.
 	// This is needed because the pruning is expecting to load the state from the database thus
 	// We have to have acceptable values for all fields of the state
-	validator := &types.Validator{Address: cmtrand.Bytes(crypto.AddressSize), VotingPower: 100, PubKey: pk}
+	validator := &types.Validator{Address: pk.Address(), VotingPower: 100, PubKey: pk}
 	validatorSet := &types.ValidatorSet{
 		Validators: []*types.Validator{validator},
 		Proposer:   validator,
@@ -769,9 +807,11 @@ func TestPruneBlocks(t *testing.T) {
 
 	for i := int64(1); i < 1200; i++ {
 		require.Nil(t, bs.LoadBlock(i))
+		require.Nil(t, bs.LoadBlockExtendedCommit(i))
 	}
 	for i := int64(1200); i <= 1500; i++ {
 		require.NotNil(t, bs.LoadBlock(i))
+		require.NotNil(t, bs.LoadBlockExtendedCommit(i))
 	}
 
 	// Pruning below the current base should error
@@ -16,6 +16,8 @@ import (
 	"strings"
 	"time"
 
+	gogotypes "github.com/cosmos/gogoproto/types"
+
 	"github.com/cometbft/cometbft/abci/example/kvstore"
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/crypto"
@@ -479,7 +481,11 @@ func (app *Application) PrepareProposal(
 		time.Sleep(app.cfg.PrepareProposalDelay)
 	}
 
-	return &abci.ResponsePrepareProposal{Txs: txs}, nil
+	// Each transaction wants 1 gas, as reported by CheckTx.
+	return &abci.ResponsePrepareProposal{
+		Txs:       txs,
+		GasWanted: &gogotypes.Int64Value{Value: int64(len(txs))},
+	}, nil
 }
 
 // ProcessProposal implements part of the Application interface.
@@ -519,7 +525,11 @@ func (app *Application) ProcessProposal(_ context.Context, req *abci.RequestProc
 		time.Sleep(app.cfg.ProcessProposalDelay)
 	}
 
-	return &abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}, nil
+	// Each transaction wants 1 gas, as reported by CheckTx.
+	return &abci.ResponseProcessProposal{
+		Status:    abci.ResponseProcessProposal_ACCEPT,
+		GasWanted: &gogotypes.Int64Value{Value: int64(len(req.Txs))},
+	}, nil
 }
 
 // ExtendVote will produce vote extensions in the form of random numbers to
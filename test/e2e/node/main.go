@@ -29,6 +29,7 @@ import (
 	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
 	"github.com/cometbft/cometbft/test/e2e/app"
 	e2e "github.com/cometbft/cometbft/test/e2e/pkg"
+	"github.com/cometbft/cometbft/types"
 )
 
 var logger = log.NewTMLogger(log.NewSyncWriter(os.Stdout))
@@ -189,8 +190,18 @@ func startLightClient(cfg *Config) error {
 		rpccfg.WriteTimeout = cmtcfg.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
+	// The light blocks are verified against the time of the blocks, which
+	// may be compressed.
+	genDoc, err := types.GenesisDocFromFile(cmtcfg.GenesisFile())
+	if err != nil {
+		return err
+	}
+	now := func() time.Time {
+		return cmtcfg.Consensus.BlockTime(genDoc.GenesisTime, time.Now())
+	}
+
 	p, err := lproxy.NewProxy(c, cmtcfg.RPC.ListenAddress, providers[0], rpccfg, nodeLogger,
-		lrpc.KeyPathFn(lrpc.DefaultMerkleKeyPathFn()))
+		lrpc.KeyPathFn(lrpc.DefaultMerkleKeyPathFn()), lrpc.NowFn(now))
 	if err != nil {
 		return err
 	}
@@ -102,6 +102,12 @@ type Manifest struct {
 	// Upper bound of sleep duration then gossipping votes and block parts
 	PeerGossipIntraloopSleepDuration time.Duration `toml:"peer_gossip_intraloop_sleep_duration"`
 
+	// TimeoutScale is the factor by which the consensus timeouts of all nodes
+	// are scaled, e.g. 0.1 to produce blocks 10 times faster. Defaults to 1.
+	// The times of the blocks are compressed by the same factor.
+	// Overridden by the E2E_TIMEOUT_SCALE environment variable, if set.
+	TimeoutScale float64 `toml:"timeout_scale"`
+
 	// Enable or disable e2e tests for CometBFT's expected behavior with respect
 	// to ABCI.
 	ABCITestsEnabled bool `toml:"abci_tests_enabled"`
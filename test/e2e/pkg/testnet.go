@@ -35,6 +35,10 @@ const (
 	defaultTxSizeBytes = 1024
 
 	localVersion = "cometbft/e2e-node:local-version"
+
+	// TimeoutScaleEnvVar is the environment variable overriding the timeout
+	// scale of the manifest, to run any testnet with compressed block times.
+	TimeoutScaleEnvVar = "E2E_TIMEOUT_SCALE"
 )
 
 type (
@@ -95,6 +99,7 @@ type Testnet struct {
 	VoteExtensionSize                uint
 	PeerGossipIntraloopSleepDuration time.Duration
 	ABCITestsEnabled                 bool
+	TimeoutScale                     float64
 }
 
 // Node represents a CometBFT node in a testnet.
@@ -180,6 +185,7 @@ func NewTestnetFromManifest(manifest Manifest, file string, ifd InfrastructureDa
 		VoteExtensionSize:                manifest.VoteExtensionSize,
 		PeerGossipIntraloopSleepDuration: manifest.PeerGossipIntraloopSleepDuration,
 		ABCITestsEnabled:                 manifest.ABCITestsEnabled,
+		TimeoutScale:                     manifest.TimeoutScale,
 	}
 	if len(manifest.KeyType) != 0 {
 		testnet.KeyType = manifest.KeyType
@@ -187,6 +193,15 @@ func NewTestnetFromManifest(manifest Manifest, file string, ifd InfrastructureDa
 	if manifest.InitialHeight > 0 {
 		testnet.InitialHeight = manifest.InitialHeight
 	}
+	if scale := os.Getenv(TimeoutScaleEnvVar); scale != "" {
+		testnet.TimeoutScale, err = strconv.ParseFloat(scale, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", TimeoutScaleEnvVar, scale, err)
+		}
+	}
+	if testnet.TimeoutScale == 0 {
+		testnet.TimeoutScale = 1
+	}
 	if testnet.ABCIProtocol == "" {
 		testnet.ABCIProtocol = string(ProtocolBuiltin)
 	}
@@ -355,6 +370,9 @@ func (t Testnet) Validate() error {
 	if len(t.Nodes) == 0 {
 		return errors.New("network has no nodes")
 	}
+	if t.TimeoutScale < 0 {
+		return fmt.Errorf("invalid timeout scale %v", t.TimeoutScale)
+	}
 	for _, node := range t.Nodes {
 		if err := node.Validate(t); err != nil {
 			return fmt.Errorf("invalid node %q: %w", node.Name, err)
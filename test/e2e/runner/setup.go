@@ -88,16 +88,18 @@ func Setup(testnet *e2e.Testnet, infp infra.Provider) error {
 			return err
 		}
 
-		if node.Mode == e2e.ModeLight {
-			// stop early if a light client
-			continue
-		}
-
+		// light clients also need the genesis time to compress the time
+		// of the blocks like the nodes do
 		err = genesis.SaveAs(filepath.Join(nodeDir, "config", "genesis.json"))
 		if err != nil {
 			return err
 		}
 
+		if node.Mode == e2e.ModeLight {
+			// stop early if a light client
+			continue
+		}
+
 		err = (&p2p.NodeKey{PrivKey: node.NodeKey}).SaveAs(filepath.Join(nodeDir, "config", "node_key.json"))
 		if err != nil {
 			return err
@@ -174,6 +176,8 @@ func MakeConfig(node *e2e.Node) (*config.Config, error) {
 	cfg.GRPC.VersionService.Enabled = true
 	cfg.GRPC.BlockService.Enabled = true
 	cfg.GRPC.BlockResultsService.Enabled = true
+	cfg.GRPC.MempoolService.Enabled = true
+	cfg.GRPC.QueryService.Enabled = true
 
 	cfg.P2P.ExternalAddress = fmt.Sprintf("tcp://%v", node.AddressP2P(false))
 	cfg.P2P.AddrBookStrict = false
@@ -182,6 +186,7 @@ func MakeConfig(node *e2e.Node) (*config.Config, error) {
 	cfg.StateSync.DiscoveryTime = 5 * time.Second
 	cfg.BlockSync.Version = node.BlockSyncVersion
 	cfg.Consensus.PeerGossipIntraloopSleepDuration = node.Testnet.PeerGossipIntraloopSleepDuration
+	cfg.Consensus.TimeoutScale = node.Testnet.TimeoutScale
 
 	// Assume that full nodes and validators will have a data companion
 	// attached, which will need access to the privileged gRPC endpoint.
@@ -11,6 +11,7 @@ import (
 	gogotypes "github.com/cosmos/gogoproto/types"
 
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/merkle"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	"github.com/cometbft/cometbft/libs/bits"
@@ -276,10 +277,23 @@ func BlockFromProto(bp *cmtproto.Block) (*Block, error) {
 //
 // XXX: Panics on negative result.
 func MaxDataBytes(maxBytes, evidenceBytes int64, valsCount int) int64 {
+	return maxDataBytes(maxBytes, evidenceBytes, MaxCommitBytes(valsCount))
+}
+
+// MaxDataBytesAggregatedCommit returns the maximum size of block's data, like
+// MaxDataBytes, when its last commit is aggregated, see
+// MaxAggregatedCommitBytes.
+//
+// XXX: Panics on negative result.
+func MaxDataBytesAggregatedCommit(maxBytes, evidenceBytes int64, valsCount int) int64 {
+	return maxDataBytes(maxBytes, evidenceBytes, MaxAggregatedCommitBytes(valsCount))
+}
+
+func maxDataBytes(maxBytes, evidenceBytes, commitBytes int64) int64 {
 	maxDataBytes := maxBytes -
 		MaxOverheadForBlock -
 		MaxHeaderBytes -
-		MaxCommitBytes(valsCount) -
+		commitBytes -
 		evidenceBytes
 
 	if maxDataBytes < 0 {
@@ -589,6 +603,13 @@ const (
 	// Commit sig size is made up of 64 bytes for the signature, 20 bytes for the address,
 	// 1 byte for the flag and 14 bytes for the timestamp
 	MaxCommitSigBytes int64 = 109
+
+	// Max size of an aggregated commit without any commitSigs -> MaxCommitOverheadBytes,
+	// 98 for the aggregated signature.
+	MaxAggregatedCommitOverheadBytes int64 = 192
+	// Commit sig size of a BLS12-381 validator is made up of 96 bytes for the signature,
+	// 20 bytes for the address, 1 byte for the flag and 14 bytes for the timestamp
+	MaxBLSCommitSigBytes int64 = 141
 )
 
 // CommitSig is a part of the Vote included in a Commit.
@@ -605,6 +626,16 @@ func MaxCommitBytes(valCount int) int64 {
 	return MaxCommitOverheadBytes + ((MaxCommitSigBytes + protoEncodingOverhead) * int64(valCount))
 }
 
+// MaxAggregatedCommitBytes returns the max size of a commit whose signatures
+// are aggregated, i.e. if the BLS aggregation of the commit signatures is
+// enabled, see ValidatorParams.
+func MaxAggregatedCommitBytes(valCount int) int64 {
+	// From the repeated commit sig field: 1 byte for the tag and 2 for the
+	// length
+	var protoEncodingOverhead int64 = 3
+	return MaxAggregatedCommitOverheadBytes + ((MaxBLSCommitSigBytes + protoEncodingOverhead) * int64(valCount))
+}
+
 // NewCommitSigAbsent returns new CommitSig with BlockIDFlagAbsent. Other
 // fields are all empty.
 func NewCommitSigAbsent() CommitSig {
@@ -646,6 +677,13 @@ func (cs CommitSig) BlockID(commitBlockID BlockID) BlockID {
 
 // ValidateBasic performs basic validation.
 func (cs CommitSig) ValidateBasic() error {
+	return cs.validateBasic(false)
+}
+
+// validateBasic performs basic validation. If aggregated is true, the
+// signatures of the precommits for the block must be empty, as they're
+// aggregated in the commit.
+func (cs CommitSig) validateBasic(aggregated bool) error {
 	switch cs.BlockIDFlag {
 	case BlockIDFlagAbsent:
 	case BlockIDFlagCommit:
@@ -673,6 +711,12 @@ func (cs CommitSig) ValidateBasic() error {
 			)
 		}
 		// NOTE: Timestamp validation is subtle and handled elsewhere.
+		if aggregated && cs.BlockIDFlag == BlockIDFlagCommit {
+			if len(cs.Signature) != 0 {
+				return errors.New("signature is present in an aggregated commit")
+			}
+			break
+		}
 		if len(cs.Signature) == 0 {
 			return errors.New("signature is missing")
 		}
@@ -701,12 +745,15 @@ func (cs *CommitSig) ToProto() *cmtproto.CommitSig {
 // FromProto sets a protobuf CommitSig to the given pointer.
 // It returns an error if the CommitSig is invalid.
 func (cs *CommitSig) FromProto(csp cmtproto.CommitSig) error {
+	cs.fromProto(csp)
+	return cs.ValidateBasic()
+}
+
+func (cs *CommitSig) fromProto(csp cmtproto.CommitSig) {
 	cs.BlockIDFlag = BlockIDFlag(csp.BlockIdFlag)
 	cs.ValidatorAddress = csp.ValidatorAddress
 	cs.Timestamp = csp.Timestamp
 	cs.Signature = csp.Signature
-
-	return cs.ValidateBasic()
 }
 
 //-------------------------------------
@@ -740,7 +787,13 @@ func (ecs ExtendedCommitSig) String() string {
 
 // ValidateBasic checks whether the structure is well-formed.
 func (ecs ExtendedCommitSig) ValidateBasic() error {
-	if err := ecs.CommitSig.ValidateBasic(); err != nil {
+	return ecs.validateBasic(false)
+}
+
+// validateBasic checks whether the structure is well-formed, see
+// CommitSig.validateBasic.
+func (ecs ExtendedCommitSig) validateBasic(aggregated bool) error {
+	if err := ecs.CommitSig.validateBasic(aggregated); err != nil {
 		return err
 	}
 
@@ -819,14 +872,17 @@ func (ecs *ExtendedCommitSig) ToProto() *cmtproto.ExtendedCommitSig {
 // Protobuf representation. Returns an error if the ExtendedCommitSig is
 // invalid.
 func (ecs *ExtendedCommitSig) FromProto(ecsp cmtproto.ExtendedCommitSig) error {
+	ecs.fromProto(ecsp)
+	return ecs.ValidateBasic()
+}
+
+func (ecs *ExtendedCommitSig) fromProto(ecsp cmtproto.ExtendedCommitSig) {
 	ecs.BlockIDFlag = BlockIDFlag(ecsp.BlockIdFlag)
 	ecs.ValidatorAddress = ecsp.ValidatorAddress
 	ecs.Timestamp = ecsp.Timestamp
 	ecs.Signature = ecsp.Signature
 	ecs.Extension = ecsp.Extension
 	ecs.ExtensionSignature = ecsp.ExtensionSignature
-
-	return ecs.ValidateBasic()
 }
 
 //-------------------------------------
@@ -842,6 +898,10 @@ type Commit struct {
 	Round      int32       `json:"round"`
 	BlockID    BlockID     `json:"block_id"`
 	Signatures []CommitSig `json:"signatures"`
+	// Aggregation of the signatures of the precommits for the block, whose
+	// signatures are then empty. Only set if the BLS aggregation of the commit
+	// signatures is enabled, see ValidatorParams.
+	AggregatedSignature []byte `json:"aggregated_signature,omitempty"`
 
 	// Memoized in first call to corresponding method.
 	// NOTE: can't memoize in constructor because constructor isn't used for
@@ -868,6 +928,42 @@ func (commit *Commit) GetVote(valIdx int32) *Vote {
 	}
 }
 
+// IsAggregated returns true if the signatures of the precommits for the block
+// are aggregated.
+func (commit *Commit) IsAggregated() bool {
+	return len(commit.AggregatedSignature) != 0
+}
+
+// AggregateSignatures returns a copy of the commit, whose signatures of the
+// precommits for the block are aggregated into its AggregatedSignature, along
+// with the already aggregated signatures, if any. The signatures must all be
+// BLS12-381 signatures.
+func (commit *Commit) AggregateSignatures() (*Commit, error) {
+	sigs := make([][]byte, 0, len(commit.Signatures)+1)
+	if commit.IsAggregated() {
+		sigs = append(sigs, commit.AggregatedSignature)
+	}
+	aggregated := &Commit{
+		Height:     commit.Height,
+		Round:      commit.Round,
+		BlockID:    commit.BlockID,
+		Signatures: make([]CommitSig, len(commit.Signatures)),
+	}
+	for i, commitSig := range commit.Signatures {
+		if commitSig.BlockIDFlag == BlockIDFlagCommit && len(commitSig.Signature) != 0 {
+			sigs = append(sigs, commitSig.Signature)
+			commitSig.Signature = nil
+		}
+		aggregated.Signatures[i] = commitSig
+	}
+	aggSig, err := bls12381.AggregateSignatures(sigs)
+	if err != nil {
+		return nil, fmt.Errorf("aggregating the signatures of commit %d: %w", commit.Height, err)
+	}
+	aggregated.AggregatedSignature = aggSig
+	return aggregated, nil
+}
+
 // VoteSignBytes returns the bytes of the Vote corresponding to valIdx for
 // signing.
 //
@@ -908,11 +1004,16 @@ func (commit *Commit) ValidateBasic() error {
 		if len(commit.Signatures) == 0 {
 			return errors.New("no signatures in commit")
 		}
+		if len(commit.AggregatedSignature) > MaxSignatureSize {
+			return fmt.Errorf("aggregated signature is too big (max: %d)", MaxSignatureSize)
+		}
 		for i, commitSig := range commit.Signatures {
-			if err := commitSig.ValidateBasic(); err != nil {
+			if err := commitSig.validateBasic(commit.IsAggregated()); err != nil {
 				return fmt.Errorf("wrong CommitSig #%d: %v", i, err)
 			}
 		}
+	} else if commit.IsAggregated() {
+		return errors.New("aggregated signature in empty commit")
 	}
 	return nil
 }
@@ -933,6 +1034,9 @@ func (commit *Commit) Hash() cmtbytes.HexBytes {
 
 			bs[i] = bz
 		}
+		if commit.IsAggregated() {
+			bs = append(bs, commit.AggregatedSignature)
+		}
 		commit.hash = merkle.HashFromByteSlices(bs)
 	}
 	return commit.hash
@@ -951,10 +1055,11 @@ func (commit *Commit) WrappedExtendedCommit() *ExtendedCommit {
 		}
 	}
 	return &ExtendedCommit{
-		Height:             commit.Height,
-		Round:              commit.Round,
-		BlockID:            commit.BlockID,
-		ExtendedSignatures: cs,
+		Height:              commit.Height,
+		Round:               commit.Round,
+		BlockID:             commit.BlockID,
+		ExtendedSignatures:  cs,
+		AggregatedSignature: commit.AggregatedSignature,
 	}
 }
 
@@ -973,12 +1078,14 @@ func (commit *Commit) StringIndented(indent string) string {
 %s  BlockID:    %v
 %s  Signatures:
 %s    %v
+%s  AggregatedSignature: %X
 %s}#%v`,
 		indent, commit.Height,
 		indent, commit.Round,
 		indent, commit.BlockID,
 		indent,
 		indent, strings.Join(commitSigStrings, "\n"+indent+"    "),
+		indent, cmtbytes.Fingerprint(commit.AggregatedSignature),
 		indent, commit.hash)
 }
 
@@ -998,6 +1105,7 @@ func (commit *Commit) ToProto() *cmtproto.Commit {
 	c.Height = commit.Height
 	c.Round = commit.Round
 	c.BlockID = commit.BlockID.ToProto()
+	c.AggregatedSignature = commit.AggregatedSignature
 
 	return c
 }
@@ -1018,17 +1126,18 @@ func CommitFromProto(cp *cmtproto.Commit) (*Commit, error) {
 		return nil, err
 	}
 
+	// The signatures are validated along with the commit, as they depend on
+	// whether it's aggregated.
 	sigs := make([]CommitSig, len(cp.Signatures))
 	for i := range cp.Signatures {
-		if err := sigs[i].FromProto(cp.Signatures[i]); err != nil {
-			return nil, err
-		}
+		sigs[i].fromProto(cp.Signatures[i])
 	}
 	commit.Signatures = sigs
 
 	commit.Height = cp.Height
 	commit.Round = cp.Round
 	commit.BlockID = *bi
+	commit.AggregatedSignature = cp.AggregatedSignature
 
 	return commit, commit.ValidateBasic()
 }
@@ -1042,6 +1151,9 @@ type ExtendedCommit struct {
 	Round              int32
 	BlockID            BlockID
 	ExtendedSignatures []ExtendedCommitSig
+	// Aggregation of the signatures of the precommits for the block whose
+	// signatures are empty, see Commit.
+	AggregatedSignature []byte
 
 	bitArray *bits.BitArray
 }
@@ -1085,13 +1197,25 @@ func (ec *ExtendedCommit) addSigsToVoteSet(voteSet *VoteSet) {
 // ToVoteSet constructs a VoteSet from the Commit and validator set.
 // Panics if signatures from the commit can't be added to the voteset.
 // Inverse of VoteSet.MakeCommit().
+//
+// If the commit is aggregated, the precommits for the block are added to the
+// VoteSet without signature, which keeps the aggregated signature. The caller
+// must have verified the aggregated signature, e.g. when the commit was
+// received or loaded.
 func (commit *Commit) ToVoteSet(chainID string, vals *ValidatorSet) *VoteSet {
 	voteSet := NewVoteSet(chainID, commit.Height, commit.Round, cmtproto.PrecommitType, vals)
+	if commit.IsAggregated() {
+		voteSet.aggregatedSignature = commit.AggregatedSignature
+	}
 	for idx, cs := range commit.Signatures {
 		if cs.BlockIDFlag == BlockIDFlagAbsent {
 			continue // OK, some precommits can be missing.
 		}
 		vote := commit.GetVote(int32(idx))
+		if commit.IsAggregated() && cs.BlockIDFlag == BlockIDFlagCommit {
+			voteSet.addAggregatedVote(vote)
+			continue
+		}
 		if err := vote.ValidateBasic(); err != nil {
 			panic(fmt.Errorf("failed to validate vote reconstructed from commit: %w", err))
 		}
@@ -1122,10 +1246,11 @@ func (ec *ExtendedCommit) ToCommit() *Commit {
 		cs[idx] = ecs.CommitSig
 	}
 	return &Commit{
-		Height:     ec.Height,
-		Round:      ec.Round,
-		BlockID:    ec.BlockID,
-		Signatures: cs,
+		Height:              ec.Height,
+		Round:               ec.Round,
+		BlockID:             ec.BlockID,
+		Signatures:          cs,
+		AggregatedSignature: ec.AggregatedSignature,
 	}
 }
 
@@ -1216,8 +1341,11 @@ func (ec *ExtendedCommit) ValidateBasic() error {
 		if len(ec.ExtendedSignatures) == 0 {
 			return errors.New("no signatures in commit")
 		}
+		if len(ec.AggregatedSignature) > MaxSignatureSize {
+			return fmt.Errorf("aggregated signature is too big (max: %d)", MaxSignatureSize)
+		}
 		for i, extCommitSig := range ec.ExtendedSignatures {
-			if err := extCommitSig.ValidateBasic(); err != nil {
+			if err := extCommitSig.validateBasic(len(ec.AggregatedSignature) != 0); err != nil {
 				return fmt.Errorf("wrong ExtendedCommitSig #%d: %v", i, err)
 			}
 		}
@@ -1241,6 +1369,7 @@ func (ec *ExtendedCommit) ToProto() *cmtproto.ExtendedCommit {
 	c.Height = ec.Height
 	c.Round = ec.Round
 	c.BlockID = ec.BlockID.ToProto()
+	c.AggregatedSignature = ec.AggregatedSignature
 
 	return c
 }
@@ -1261,14 +1390,13 @@ func ExtendedCommitFromProto(ecp *cmtproto.ExtendedCommit) (*ExtendedCommit, err
 
 	sigs := make([]ExtendedCommitSig, len(ecp.ExtendedSignatures))
 	for i := range ecp.ExtendedSignatures {
-		if err := sigs[i].FromProto(ecp.ExtendedSignatures[i]); err != nil {
-			return nil, err
-		}
+		sigs[i].fromProto(ecp.ExtendedSignatures[i])
 	}
 	extCommit.ExtendedSignatures = sigs
 	extCommit.Height = ecp.Height
 	extCommit.Round = ecp.Round
 	extCommit.BlockID = *bi
+	extCommit.AggregatedSignature = ecp.AggregatedSignature
 
 	return extCommit, extCommit.ValidateBasic()
 }
@@ -17,6 +17,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bls12381"
+	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/crypto/merkle"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	"github.com/cometbft/cometbft/libs/bits"
@@ -271,48 +273,64 @@ func TestCommitValidateBasic(t *testing.T) {
 }
 
 func TestMaxCommitBytes(t *testing.T) {
-	// time is varint encoded so need to pick the max.
-	// year int, month Month, day, hour, min, sec, nsec int, loc *Location
-	timestamp := time.Date(math.MaxInt64, 0, 0, 0, 0, 0, math.MaxInt64, time.UTC)
-
-	cs := CommitSig{
-		BlockIDFlag:      BlockIDFlagNil,
-		ValidatorAddress: crypto.AddressHash([]byte("validator_address")),
-		Timestamp:        timestamp,
-		Signature:        crypto.CRandBytes(MaxSignatureSize),
-	}
-
-	pbSig := cs.ToProto()
-	// test that a single commit sig doesn't exceed max commit sig bytes
-	assert.EqualValues(t, MaxCommitSigBytes, pbSig.Size())
-
-	// check size with a single commit
-	commit := &Commit{
-		Height: math.MaxInt64,
-		Round:  math.MaxInt32,
-		BlockID: BlockID{
-			Hash: tmhash.Sum([]byte("blockID_hash")),
-			PartSetHeader: PartSetHeader{
-				Total: math.MaxInt32,
-				Hash:  tmhash.Sum([]byte("blockID_part_set_header_hash")),
-			},
-		},
-		Signatures: []CommitSig{cs},
+	testCases := []struct {
+		name          string
+		signatureSize int
+		aggregated    bool
+		maxSigBytes   int64
+		maxBytes      func(int) int64
+	}{
+		{"ed25519", ed25519.SignatureSize, false, MaxCommitSigBytes, MaxCommitBytes},
+		{"bls12381 aggregated", bls12381.SignatureSize, true, MaxBLSCommitSigBytes, MaxAggregatedCommitBytes},
 	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// time is varint encoded so need to pick the max.
+			// year int, month Month, day, hour, min, sec, nsec int, loc *Location
+			timestamp := time.Date(math.MaxInt64, 0, 0, 0, 0, 0, math.MaxInt64, time.UTC)
+
+			cs := CommitSig{
+				BlockIDFlag:      BlockIDFlagNil,
+				ValidatorAddress: crypto.AddressHash([]byte("validator_address")),
+				Timestamp:        timestamp,
+				Signature:        crypto.CRandBytes(tc.signatureSize),
+			}
 
-	pb := commit.ToProto()
+			pbSig := cs.ToProto()
+			// test that a single commit sig doesn't exceed max commit sig bytes
+			assert.EqualValues(t, tc.maxSigBytes, pbSig.Size())
+
+			// check size with a single commit
+			commit := &Commit{
+				Height: math.MaxInt64,
+				Round:  math.MaxInt32,
+				BlockID: BlockID{
+					Hash: tmhash.Sum([]byte("blockID_hash")),
+					PartSetHeader: PartSetHeader{
+						Total: math.MaxInt32,
+						Hash:  tmhash.Sum([]byte("blockID_part_set_header_hash")),
+					},
+				},
+				Signatures: []CommitSig{cs},
+			}
+			if tc.aggregated {
+				commit.AggregatedSignature = crypto.CRandBytes(bls12381.SignatureSize)
+			}
 
-	assert.EqualValues(t, MaxCommitBytes(1), int64(pb.Size()))
+			pb := commit.ToProto()
 
-	// check the upper bound of the commit size
-	for i := 1; i < MaxVotesCount; i++ {
-		commit.Signatures = append(commit.Signatures, cs)
-	}
+			assert.EqualValues(t, tc.maxBytes(1), int64(pb.Size()))
 
-	pb = commit.ToProto()
+			// check the upper bound of the commit size
+			for i := 1; i < MaxVotesCount; i++ {
+				commit.Signatures = append(commit.Signatures, cs)
+			}
 
-	assert.EqualValues(t, MaxCommitBytes(MaxVotesCount), int64(pb.Size()))
+			pb = commit.ToProto()
 
+			assert.EqualValues(t, tc.maxBytes(MaxVotesCount), int64(pb.Size()))
+		})
+	}
 }
 
 func TestHeaderHash(t *testing.T) {
@@ -487,6 +505,36 @@ func TestBlockMaxDataBytes(t *testing.T) {
 	}
 }
 
+func TestBlockMaxDataBytesAggregatedCommit(t *testing.T) {
+	testCases := []struct {
+		maxBytes      int64
+		valsCount     int
+		evidenceBytes int64
+		panics        bool
+		result        int64
+	}{
+		0: {972, 1, 0, true, 0},
+		1: {973, 1, 0, false, 0},
+		2: {974, 1, 0, false, 1},
+		3: {1118, 2, 0, false, 1},
+		4: {1217, 2, 100, false, 0},
+	}
+
+	for i, tc := range testCases {
+		tc := tc
+		if tc.panics {
+			assert.Panics(t, func() {
+				MaxDataBytesAggregatedCommit(tc.maxBytes, tc.evidenceBytes, tc.valsCount)
+			}, "#%v", i)
+		} else {
+			assert.Equal(t,
+				tc.result,
+				MaxDataBytesAggregatedCommit(tc.maxBytes, tc.evidenceBytes, tc.valsCount),
+				"#%v", i)
+		}
+	}
+}
+
 func TestBlockMaxDataBytesNoEvidence(t *testing.T) {
 	testCases := []struct {
 		maxBytes  int64
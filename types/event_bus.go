@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/go-kit/kit/metrics"
+
 	"github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/libs/log"
 	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
@@ -32,20 +34,28 @@ type Subscription interface {
 // EventBus to ensure correct data types.
 type EventBus struct {
 	service.BaseService
-	pubsub *cmtpubsub.Server
+	pubsub    *cmtpubsub.Server
+	publisher eventPublisher
+
+	maxEventSize         int
+	oversizedEventPolicy OversizedEventPolicy
+	oversizedEvents      metrics.Counter
 }
 
 // NewEventBus returns a new event bus.
-func NewEventBus() *EventBus {
-	return NewEventBusWithBufferCapacity(defaultCapacity)
+func NewEventBus(options ...EventBusOption) *EventBus {
+	return NewEventBusWithBufferCapacity(defaultCapacity, options...)
 }
 
 // NewEventBusWithBufferCapacity returns a new event bus with the given buffer capacity.
-func NewEventBusWithBufferCapacity(cap int) *EventBus {
+func NewEventBusWithBufferCapacity(cap int, options ...EventBusOption) *EventBus {
 	// capacity could be exposed later if needed
 	pubsub := cmtpubsub.NewServer(cmtpubsub.BufferCapacity(cap))
-	b := &EventBus{pubsub: pubsub}
+	b := &EventBus{pubsub: pubsub, publisher: pubsub}
 	b.BaseService = *service.NewBaseService(nil, "EventBus", b)
+	for _, option := range options {
+		option(b)
+	}
 	return b
 }
 
@@ -81,6 +91,18 @@ func (b *EventBus) Subscribe(
 	return b.pubsub.Subscribe(ctx, subscriber, query, outCapacity...)
 }
 
+// SubscribeDropping subscribes like Subscribe, except the events published
+// while the buffer of the subscription is full are dropped, and counted by
+// the subscription, instead of canceling it.
+func (b *EventBus) SubscribeDropping(
+	ctx context.Context,
+	subscriber string,
+	query cmtpubsub.Query,
+	outCapacity int,
+) (*cmtpubsub.Subscription, error) {
+	return b.pubsub.SubscribeDropping(ctx, subscriber, query, outCapacity)
+}
+
 // This method can be used for a local consensus explorer and synchronous
 // testing. Do not use for for public facing / untrusted subscriptions!
 func (b *EventBus) SubscribeUnbuffered(
@@ -102,7 +124,7 @@ func (b *EventBus) UnsubscribeAll(ctx context.Context, subscriber string) error
 func (b *EventBus) Publish(eventType string, eventData TMEventData) error {
 	// no explicit deadline for publishing events
 	ctx := context.Background()
-	return b.pubsub.PublishWithEvents(ctx, eventData, map[string][]string{EventTypeKey: {eventType}})
+	return b.publisher.PublishWithEvents(ctx, eventData, map[string][]string{EventTypeKey: {eventType}})
 }
 
 // validateAndStringifyEvents takes a slice of event objects and creates a
@@ -140,7 +162,7 @@ func (b *EventBus) PublishEventNewBlock(data EventDataNewBlock) error {
 	// add predefined new block event
 	events[EventTypeKey] = append(events[EventTypeKey], EventNewBlock)
 
-	return b.pubsub.PublishWithEvents(ctx, data, events)
+	return b.publisher.PublishWithABCIEvents(ctx, data, events, data.ResultFinalizeBlock.Events)
 }
 
 func (b *EventBus) PublishEventNewBlockEvents(data EventDataNewBlockEvents) error {
@@ -152,7 +174,7 @@ func (b *EventBus) PublishEventNewBlockEvents(data EventDataNewBlockEvents) erro
 	// add predefined new block event
 	events[EventTypeKey] = append(events[EventTypeKey], EventNewBlockEvents)
 
-	return b.pubsub.PublishWithEvents(ctx, data, events)
+	return b.publisher.PublishWithABCIEvents(ctx, data, events, data.Events)
 }
 
 func (b *EventBus) PublishEventNewBlockHeader(data EventDataNewBlockHeader) error {
@@ -173,11 +195,16 @@ func (b *EventBus) PublishEventValidBlock(data EventDataRoundState) error {
 
 // PublishEventTx publishes tx event with events from Result. Note it will add
 // predefined keys (EventTypeKey, TxHashKey). Existing events with the same keys
-// will be overwritten.
+// will be overwritten. Events exceeding the maximum event size are limited
+// according to the oversized event policy, or silently dropped.
 func (b *EventBus) PublishEventTx(data EventDataTx) error {
 	// no explicit deadline for publishing events
 	ctx := context.Background()
 
+	if !b.limitTxEventSize(&data) {
+		return nil
+	}
+
 	events := b.validateAndStringifyEvents(data.Result.Events, b.Logger.With("tx", data.Tx))
 
 	// add predefined compositeKeys
@@ -185,7 +212,37 @@ func (b *EventBus) PublishEventTx(data EventDataTx) error {
 	events[TxHashKey] = append(events[TxHashKey], fmt.Sprintf("%X", Tx(data.Tx).Hash()))
 	events[TxHeightKey] = append(events[TxHeightKey], fmt.Sprintf("%d", data.Height))
 
-	return b.pubsub.PublishWithEvents(ctx, data, events)
+	return b.publisher.PublishWithABCIEvents(ctx, data, events, data.Result.Events)
+}
+
+// PublishEventEvictedTx publishes the eviction of a tx from the mempool,
+// with the predefined keys EventTypeKey and TxHashKey.
+func (b *EventBus) PublishEventEvictedTx(data EventDataEvictedTx) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+
+	events := map[string][]string{
+		EventTypeKey: {EventEvictedTx},
+		TxHashKey:    {fmt.Sprintf("%X", data.Tx.Hash())},
+	}
+	return b.publisher.PublishWithEvents(ctx, data, events)
+}
+
+// PublishEventNewMempoolTx publishes the acceptance of a new tx into the
+// mempool with events from Result. Note it will add predefined keys
+// (EventTypeKey, TxHashKey). Existing events with the same keys will be
+// overwritten.
+func (b *EventBus) PublishEventNewMempoolTx(data EventDataNewMempoolTx) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+
+	events := b.validateAndStringifyEvents(data.Result.Events, b.Logger.With("tx", data.Tx))
+
+	// add predefined compositeKeys
+	events[EventTypeKey] = []string{EventNewMempoolTx}
+	events[TxHashKey] = []string{fmt.Sprintf("%X", data.Tx.Hash())}
+
+	return b.publisher.PublishWithABCIEvents(ctx, data, events, data.Result.Events)
 }
 
 func (b *EventBus) PublishEventNewRoundStep(data EventDataRoundState) error {
@@ -268,6 +325,10 @@ func (NopEventBus) PublishEventTx(EventDataTx) error {
 	return nil
 }
 
+func (NopEventBus) PublishEventEvictedTx(EventDataEvictedTx) error {
+	return nil
+}
+
 func (NopEventBus) PublishEventNewRoundStep(EventDataRoundState) error {
 	return nil
 }
@@ -1,9 +1,11 @@
 package types
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/rand"
+	"strings"
 	"testing"
 	"time"
 
@@ -64,6 +66,216 @@ func TestEventBusPublishEventTx(t *testing.T) {
 	}
 }
 
+func TestEventBusPublishEventTxMatchEvents(t *testing.T) {
+	eventBus := NewEventBus()
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// The sender and amount of the second transfer only match across the
+	// transfer events.
+	query := "tm.event='Tx' AND transfer.sender='AddrA' AND transfer.amount>100"
+	acrossSub, err := eventBus.Subscribe(context.Background(), "test", cmtquery.MustCompile(query), 2)
+	require.NoError(t, err)
+	withinSub, err := eventBus.Subscribe(context.Background(), "test", cmtquery.MustCompile(query+" AND match.events=1"), 2)
+	require.NoError(t, err)
+
+	for i, amount := range []string{"200", "10"} {
+		err = eventBus.PublishEventTx(EventDataTx{abci.TxResult{
+			Height: 1,
+			Index:  uint32(i),
+			Tx:     Tx(fmt.Sprintf("tx%d", i)),
+			Result: abci.ExecTxResult{
+				Events: []abci.Event{
+					{Type: "transfer", Attributes: []abci.EventAttribute{
+						{Key: "sender", Value: "AddrA"},
+						{Key: "amount", Value: amount},
+					}},
+					{Type: "transfer", Attributes: []abci.EventAttribute{
+						{Key: "sender", Value: "AddrB"},
+						{Key: "amount", Value: "300"},
+					}},
+				},
+			},
+		}})
+		require.NoError(t, err)
+	}
+
+	for _, index := range []uint32{0, 1} {
+		select {
+		case msg := <-acrossSub.Out():
+			assert.Equal(t, index, msg.Data().(EventDataTx).Index)
+		case <-time.After(time.Second):
+			t.Fatal("did not receive a transaction after 1 sec.")
+		}
+	}
+	select {
+	case msg := <-withinSub.Out():
+		assert.Equal(t, uint32(0), msg.Data().(EventDataTx).Index)
+	case <-time.After(time.Second):
+		t.Fatal("did not receive a transaction after 1 sec.")
+	}
+	select {
+	case msg := <-withinSub.Out():
+		t.Fatalf("received an unexpected transaction: %v", msg.Data())
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestEventBusReplayBus(t *testing.T) {
+	eventBus := NewEventBus()
+
+	// The replay bus sends the matching events synchronously, without being
+	// started.
+	var replayed []EventDataTx
+	query := cmtquery.MustCompile("tm.event='Tx' AND transfer.sender='AddrA' AND match.events=1")
+	replayBus := eventBus.NewReplayBus(query, func(msg cmtpubsub.Message) error {
+		replayed = append(replayed, msg.Data().(EventDataTx))
+		return nil
+	})
+
+	for i, sender := range []string{"AddrA", "AddrB"} {
+		err := replayBus.PublishEventTx(EventDataTx{abci.TxResult{
+			Height: 1,
+			Index:  uint32(i),
+			Tx:     Tx(fmt.Sprintf("tx%d", i)),
+			Result: abci.ExecTxResult{
+				Events: []abci.Event{
+					{Type: "transfer", Attributes: []abci.EventAttribute{{Key: "sender", Value: sender}}},
+				},
+			},
+		}})
+		require.NoError(t, err)
+	}
+	require.NoError(t, replayBus.PublishEventNewBlockHeader(EventDataNewBlockHeader{}))
+
+	require.Len(t, replayed, 1)
+	assert.Equal(t, uint32(0), replayed[0].Index)
+}
+
+func TestEventBusPublishEventTxMaxEventSize(t *testing.T) {
+	result := abci.ExecTxResult{
+		Data: bytes.Repeat([]byte("d"), 100),
+		Events: []abci.Event{
+			{Type: "transfer", Attributes: []abci.EventAttribute{
+				{Key: "memo", Value: strings.Repeat("m", 200)},
+				{Key: "amount", Value: "10"},
+			}},
+		},
+	}
+	txResult := abci.TxResult{Height: 1, Tx: Tx("foo"), Result: result}
+	const maxSize = 200
+
+	testCases := []struct {
+		policy    OversizedEventPolicy
+		maxSize   int
+		published bool
+	}{
+		{OversizedEventTruncateAttributes, maxSize, true},
+		{OversizedEventDropTxData, maxSize, false},
+		{OversizedEventDropTxData, txResult.Size() - 100, true},
+		{OversizedEventDrop, maxSize, false},
+		{OversizedEventDrop, txResult.Size(), true},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s/%d", tc.policy, tc.maxSize), func(t *testing.T) {
+			eventBus := NewEventBus(WithMaxEventSize(tc.maxSize, tc.policy))
+			require.NoError(t, eventBus.Start())
+			t.Cleanup(func() {
+				if err := eventBus.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+			txsSub, err := eventBus.Subscribe(context.Background(), "test", EventQueryTx, 1)
+			require.NoError(t, err)
+
+			require.NoError(t, eventBus.PublishEventTx(EventDataTx{txResult}))
+			select {
+			case msg := <-txsSub.Out():
+				require.True(t, tc.published, "oversized event published")
+				edt := msg.Data().(EventDataTx)
+				require.LessOrEqual(t, edt.Size(), tc.maxSize)
+				require.Equal(t, Tx("foo"), Tx(edt.Tx))
+				require.Equal(t, "10", edt.Result.Events[0].Attributes[1].Value)
+			case <-time.After(100 * time.Millisecond):
+				require.False(t, tc.published, "event not published")
+			}
+		})
+	}
+
+	// The published event is truncated, not the original one.
+	require.Len(t, txResult.Result.Events[0].Attributes[0].Value, 200)
+}
+
+func TestEventBusPublishEventEvictedTx(t *testing.T) {
+	eventBus := NewEventBus()
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	tx := Tx("foo")
+	query := fmt.Sprintf("tm.event='EvictedTx' AND tx.hash='%X'", tx.Hash())
+	evictedSub, err := eventBus.Subscribe(context.Background(), "test", cmtquery.MustCompile(query))
+	require.NoError(t, err)
+
+	err = eventBus.PublishEventEvictedTx(EventDataEvictedTx{Tx: tx, Reason: "expired"})
+	require.NoError(t, err)
+
+	select {
+	case msg := <-evictedSub.Out():
+		edt := msg.Data().(EventDataEvictedTx)
+		assert.Equal(t, tx, edt.Tx)
+		assert.Equal(t, "expired", edt.Reason)
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive an evicted transaction after 1 sec.")
+	}
+}
+
+func TestEventBusPublishEventNewMempoolTx(t *testing.T) {
+	eventBus := NewEventBus()
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	tx := Tx("foo")
+	result := abci.ResponseCheckTx{
+		GasWanted: 10,
+		Events: []abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{{Key: "sender", Value: "alice"}}},
+		},
+	}
+
+	// PublishEventNewMempoolTx adds the tm.event and tx.hash compositeKeys, so
+	// the query below should work
+	query := fmt.Sprintf("tm.event='NewMempoolTx' AND tx.hash='%X' AND account.sender='alice'", tx.Hash())
+	txsSub, err := eventBus.Subscribe(context.Background(), "test", cmtquery.MustCompile(query))
+	require.NoError(t, err)
+
+	err = eventBus.PublishEventNewMempoolTx(EventDataNewMempoolTx{Tx: tx, Result: result})
+	require.NoError(t, err)
+
+	select {
+	case msg := <-txsSub.Out():
+		edt := msg.Data().(EventDataNewMempoolTx)
+		assert.Equal(t, tx, edt.Tx)
+		assert.Equal(t, result, edt.Result)
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive a new mempool transaction after 1 sec.")
+	}
+}
+
 func TestEventBusPublishEventNewBlock(t *testing.T) {
 	eventBus := NewEventBus()
 	err := eventBus.Start()
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"context"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
+	"github.com/cometbft/cometbft/libs/service"
+)
+
+// eventPublisher publishes the messages of the event bus with their events.
+type eventPublisher interface {
+	PublishWithEvents(ctx context.Context, msg interface{}, events map[string][]string) error
+	PublishWithABCIEvents(ctx context.Context, msg interface{}, events map[string][]string, abciEvents []abci.Event) error
+}
+
+// NewReplayBus returns an event bus which, instead of publishing the events
+// to its subscribers, passes the ones matching the given query to send, as
+// they would be received by a subscription to b with the query. It is used to
+// replay past events, e.g., to a new subscriber: unlike b, it needs not be
+// started, and sends the events synchronously.
+func (b *EventBus) NewReplayBus(query cmtpubsub.Query, send func(cmtpubsub.Message) error) *EventBus {
+	rb := &EventBus{
+		publisher:            &queryPublisher{query: query, send: send},
+		maxEventSize:         b.maxEventSize,
+		oversizedEventPolicy: b.oversizedEventPolicy,
+	}
+	rb.BaseService = *service.NewBaseService(b.Logger, "ReplayEventBus", rb)
+	return rb
+}
+
+// queryPublisher passes the messages published matching a query to a
+// function.
+type queryPublisher struct {
+	query cmtpubsub.Query
+	send  func(cmtpubsub.Message) error
+}
+
+func (p *queryPublisher) PublishWithEvents(ctx context.Context, msg interface{}, events map[string][]string) error {
+	return p.PublishWithABCIEvents(ctx, msg, events, nil)
+}
+
+func (p *queryPublisher) PublishWithABCIEvents(
+	_ context.Context,
+	msg interface{},
+	events map[string][]string,
+	abciEvents []abci.Event,
+) error {
+	var match bool
+	var err error
+	if eq, ok := p.query.(cmtpubsub.EventsQuery); ok && abciEvents != nil {
+		match, err = eq.MatchesEvents(events, abciEvents)
+	} else {
+		match, err = p.query.Matches(events)
+	}
+	if err != nil || !match {
+		return err
+	}
+	return p.send(cmtpubsub.NewMessage(msg, events))
+}
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"sort"
+
+	"github.com/go-kit/kit/metrics"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+)
+
+// OversizedEventPolicy defines what the event bus does with a tx event whose
+// serialized result exceeds the maximum event size.
+type OversizedEventPolicy string
+
+const (
+	// OversizedEventTruncateAttributes truncates the longest attribute values
+	// of the events of the tx until the result fits.
+	OversizedEventTruncateAttributes OversizedEventPolicy = "truncate_attributes"
+	// OversizedEventDropTxData drops the data returned by the application for
+	// the tx from the result.
+	OversizedEventDropTxData OversizedEventPolicy = "drop_tx_data"
+	// OversizedEventDrop drops the event.
+	OversizedEventDrop OversizedEventPolicy = "drop_event"
+)
+
+// EventBusOption sets an optional parameter on the event bus.
+type EventBusOption func(*EventBus)
+
+// WithMaxEventSize limits the serialized size of the results of the tx events
+// published on the event bus, applying the given policy to the events
+// exceeding it. The events which still exceed it are dropped.
+func WithMaxEventSize(maxBytes int, policy OversizedEventPolicy) EventBusOption {
+	return func(b *EventBus) {
+		b.maxEventSize = maxBytes
+		b.oversizedEventPolicy = policy
+	}
+}
+
+// WithOversizedEventsCounter sets a counter of the oversized tx events,
+// labeled by the "action" taken on them: "truncated", "tx_data_dropped" or
+// "dropped".
+func WithOversizedEventsCounter(counter metrics.Counter) EventBusOption {
+	return func(b *EventBus) { b.oversizedEvents = counter }
+}
+
+// limitTxEventSize applies the oversized event policy to the tx event if its
+// result exceeds the maximum event size. It returns false if the event must
+// be dropped.
+func (b *EventBus) limitTxEventSize(data *EventDataTx) bool {
+	if b.maxEventSize <= 0 || data.Size() <= b.maxEventSize {
+		return true
+	}
+	logger := b.Logger.With("height", data.Height, "index", data.Index, "size", data.Size())
+
+	action := "dropped"
+	switch b.oversizedEventPolicy {
+	case OversizedEventTruncateAttributes:
+		data.Result.Events = truncateAttributes(data.Result.Events, data.Size()-b.maxEventSize)
+		action = "truncated"
+	case OversizedEventDropTxData:
+		data.Result.Data = nil
+		action = "tx_data_dropped"
+	}
+	if data.Size() > b.maxEventSize {
+		action = "dropped"
+	}
+	if b.oversizedEvents != nil {
+		b.oversizedEvents.With("action", action).Add(1)
+	}
+	logger.Info("Tx event exceeds the maximum event size", "max_size", b.maxEventSize, "action", action)
+	return action != "dropped"
+}
+
+// truncateAttributes returns a copy of the events with their longest
+// attribute values truncated by a total of at least excess bytes, or by all
+// their bytes if there are fewer.
+func truncateAttributes(events []abci.Event, excess int) []abci.Event {
+	truncated := make([]abci.Event, len(events))
+	var attrs []*abci.EventAttribute
+	for i, event := range events {
+		truncated[i] = event
+		truncated[i].Attributes = append([]abci.EventAttribute(nil), event.Attributes...)
+		for j := range truncated[i].Attributes {
+			attrs = append(attrs, &truncated[i].Attributes[j])
+		}
+	}
+	sort.SliceStable(attrs, func(i, j int) bool {
+		return len(attrs[i].Value) > len(attrs[j].Value)
+	})
+
+	for _, attr := range attrs {
+		if excess <= 0 {
+			break
+		}
+		cut := len(attr.Value)
+		if cut > excess {
+			cut = excess
+		}
+		attr.Value = attr.Value[:len(attr.Value)-cut]
+		excess -= cut
+	}
+	return truncated
+}
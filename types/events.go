@@ -23,6 +23,13 @@ const (
 	EventTx                  = "Tx"
 	EventValidatorSetUpdates = "ValidatorSetUpdates"
 
+	// Mempool events.
+	// EvictedTx is triggered when a valid tx is removed from the mempool
+	// without being included in a block.
+	EventEvictedTx = "EvictedTx"
+	// NewMempoolTx is triggered when a new tx is accepted into the mempool.
+	EventNewMempoolTx = "NewMempoolTx"
+
 	// Internal consensus events.
 	// These are used for testing the consensus state machine.
 	// They can also be used to build real-time consensus visualizers.
@@ -52,6 +59,8 @@ func init() {
 	cmtjson.RegisterType(EventDataNewBlockEvents{}, "tendermint/event/NewBlockEvents")
 	cmtjson.RegisterType(EventDataNewEvidence{}, "tendermint/event/NewEvidence")
 	cmtjson.RegisterType(EventDataTx{}, "tendermint/event/Tx")
+	cmtjson.RegisterType(EventDataEvictedTx{}, "tendermint/event/EvictedTx")
+	cmtjson.RegisterType(EventDataNewMempoolTx{}, "tendermint/event/NewMempoolTx")
 	cmtjson.RegisterType(EventDataRoundState{}, "tendermint/event/RoundState")
 	cmtjson.RegisterType(EventDataNewRound{}, "tendermint/event/NewRound")
 	cmtjson.RegisterType(EventDataCompleteProposal{}, "tendermint/event/CompleteProposal")
@@ -89,6 +98,20 @@ type EventDataTx struct {
 	abci.TxResult
 }
 
+// EventDataEvictedTx is fired when a tx is evicted from the mempool, with the
+// reason of the eviction.
+type EventDataEvictedTx struct {
+	Tx     Tx     `json:"tx"`
+	Reason string `json:"reason"`
+}
+
+// EventDataNewMempoolTx is fired when a new tx is accepted into the mempool,
+// with the response of the application to CheckTx.
+type EventDataNewMempoolTx struct {
+	Tx     Tx                   `json:"tx"`
+	Result abci.ResponseCheckTx `json:"result"`
+}
+
 // NOTE: This goes into the replay WAL
 type EventDataRoundState struct {
 	Height int64  `json:"height"`
@@ -141,17 +164,24 @@ const (
 	// see EventBus#PublishEventTx
 	TxHeightKey = "tx.height"
 
+	// TxSenderKey is a reserved key, used to specify the sender assigned to a
+	// transaction by the application in CheckTx.
+	// see Environment#UnconfirmedTxs
+	TxSenderKey = "tx.sender"
+
 	// BlockHeightKey is a reserved key used for indexing FinalizeBlock events.
 	BlockHeightKey = "block.height"
 )
 
 var (
 	EventQueryCompleteProposal    = QueryForEvent(EventCompleteProposal)
+	EventQueryEvictedTx           = QueryForEvent(EventEvictedTx)
 	EventQueryLock                = QueryForEvent(EventLock)
 	EventQueryNewBlock            = QueryForEvent(EventNewBlock)
 	EventQueryNewBlockHeader      = QueryForEvent(EventNewBlockHeader)
 	EventQueryNewBlockEvents      = QueryForEvent(EventNewBlockEvents)
 	EventQueryNewEvidence         = QueryForEvent(EventNewEvidence)
+	EventQueryNewMempoolTx        = QueryForEvent(EventNewMempoolTx)
 	EventQueryNewRound            = QueryForEvent(EventNewRound)
 	EventQueryNewRoundStep        = QueryForEvent(EventNewRoundStep)
 	EventQueryPolka               = QueryForEvent(EventPolka)
@@ -165,7 +195,13 @@ var (
 )
 
 func EventQueryTxFor(tx Tx) cmtpubsub.Query {
-	return cmtquery.MustCompile(fmt.Sprintf("%s='%s' AND %s='%X'", EventTypeKey, EventTx, TxHashKey, tx.Hash()))
+	return EventQueryTxHash(tx.Hash())
+}
+
+// EventQueryTxHash returns the query of the event of the tx with the given
+// hash.
+func EventQueryTxHash(hash []byte) cmtpubsub.Query {
+	return cmtquery.MustCompile(fmt.Sprintf("%s='%s' AND %s='%X'", EventTypeKey, EventTx, TxHashKey, hash))
 }
 
 func QueryForEvent(eventType string) cmtpubsub.Query {
@@ -1,10 +1,12 @@
 package types
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -123,13 +125,188 @@ func GenesisDocFromJSON(jsonBlob []byte) (*GenesisDoc, error) {
 	return &genDoc, err
 }
 
+// GenesisDocFromReader reads a JSON genesis document from r, streaming it
+// field by field. The app state, needed whole by InitChain, is copied from r
+// into the GenesisDoc as raw JSON, without being parsed nor buffered whole
+// beforehand, while the rest of the document is decoded incrementally.
+// Unlike GenesisDocFromJSON, the genesis file is never read whole, although
+// the app state is still held in memory, as a json.RawMessage for InitChain.
+func GenesisDocFromReader(r io.Reader) (*GenesisDoc, error) {
+	br := bufio.NewReader(r)
+	if c, err := nextNonSpace(br); err != nil {
+		return nil, err
+	} else if c != '{' {
+		return nil, errors.New("genesis doc must be a JSON object")
+	}
+
+	var (
+		appState []byte
+		fields   = make(map[string]json.RawMessage)
+	)
+	for first := true; ; first = false {
+		c, err := nextNonSpace(br)
+		if err != nil {
+			return nil, err
+		}
+		if c == '}' && first {
+			break
+		}
+		if c != '"' {
+			return nil, fmt.Errorf("unexpected character %q in genesis doc", c)
+		}
+		if err := br.UnreadByte(); err != nil {
+			return nil, err
+		}
+		var rawKey bytes.Buffer
+		if err := copyJSONValue(br, &rawKey); err != nil {
+			return nil, err
+		}
+		var key string
+		if err := json.Unmarshal(rawKey.Bytes(), &key); err != nil {
+			return nil, err
+		}
+		if c, err := nextNonSpace(br); err != nil {
+			return nil, err
+		} else if c != ':' {
+			return nil, fmt.Errorf("unexpected character %q after %q in genesis doc", c, key)
+		}
+		if _, err := nextNonSpace(br); err != nil {
+			return nil, err
+		}
+		if err := br.UnreadByte(); err != nil {
+			return nil, err
+		}
+		var value bytes.Buffer
+		if err := copyJSONValue(br, &value); err != nil {
+			return nil, fmt.Errorf("error decoding %q: %w", key, err)
+		}
+		if !json.Valid(value.Bytes()) {
+			return nil, fmt.Errorf("error decoding %q: invalid JSON", key)
+		}
+		if key == "app_state" {
+			appState = value.Bytes()
+		} else {
+			fields[key] = value.Bytes()
+		}
+
+		if c, err = nextNonSpace(br); err != nil {
+			return nil, err
+		}
+		if c == '}' {
+			break
+		}
+		if c != ',' {
+			return nil, fmt.Errorf("unexpected character %q after %q in genesis doc", c, key)
+		}
+	}
+	// Make sure nothing follows the genesis doc.
+	if _, err := nextNonSpace(br); err != io.EOF {
+		return nil, errors.New("unexpected data after genesis doc")
+	}
+
+	jsonBlob, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	genDoc := GenesisDoc{}
+	if err := cmtjson.Unmarshal(jsonBlob, &genDoc); err != nil {
+		return nil, err
+	}
+	genDoc.AppState = appState
+
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
+
+	return &genDoc, nil
+}
+
+// nextNonSpace reads the next byte of r that is not JSON whitespace.
+func nextNonSpace(r *bufio.Reader) (byte, error) {
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch c {
+		case ' ', '\t', '\n', '\r':
+		default:
+			return c, nil
+		}
+	}
+}
+
+// copyJSONValue copies the next JSON value of r to w, as is. The value is
+// only delimited, not validated.
+func copyJSONValue(r *bufio.Reader, w *bytes.Buffer) error {
+	depth := 0
+	for {
+		c, err := r.ReadByte()
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		} else if err != nil {
+			return err
+		}
+		// A scalar, other than a string, ends with the first delimiter.
+		if depth == 0 && w.Len() > 0 {
+			switch c {
+			case ',', '}', ']', ' ', '\t', '\n', '\r':
+				return r.UnreadByte()
+			}
+		}
+		w.WriteByte(c)
+		switch c {
+		case '"':
+			if err := copyJSONString(r, w); err != nil {
+				return err
+			}
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+			if depth < 0 {
+				return fmt.Errorf("unexpected character %q", c)
+			}
+		default:
+			continue
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// copyJSONString copies the rest of a JSON string, whose opening quote was
+// read, to w.
+func copyJSONString(r *bufio.Reader, w *bytes.Buffer) error {
+	escaped := false
+	for {
+		c, err := r.ReadByte()
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		} else if err != nil {
+			return err
+		}
+		w.WriteByte(c)
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			return nil
+		}
+	}
+}
+
 // GenesisDocFromFile reads JSON data from a file and unmarshalls it into a GenesisDoc.
 func GenesisDocFromFile(genDocFile string) (*GenesisDoc, error) {
-	jsonBlob, err := os.ReadFile(genDocFile)
+	f, err := os.Open(genDocFile)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't read GenesisDoc file: %w", err)
 	}
-	genDoc, err := GenesisDocFromJSON(jsonBlob)
+	defer f.Close()
+	genDoc, err := GenesisDocFromReader(f)
 	if err != nil {
 		return nil, fmt.Errorf("error reading GenesisDoc at %s: %w", genDocFile, err)
 	}
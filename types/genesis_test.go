@@ -1,8 +1,11 @@
 package types
 
 import (
+	"bytes"
 	"os"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -53,9 +56,76 @@ func TestGenesisBad(t *testing.T) {
 	for _, testCase := range testCases {
 		_, err := GenesisDocFromJSON(testCase)
 		assert.Error(t, err, "expected error for empty genDoc json")
+		_, err = GenesisDocFromReader(bytes.NewReader(testCase))
+		assert.Error(t, err, "expected error for empty genDoc json")
+	}
+}
+
+func TestGenesisDocFromReader(t *testing.T) {
+	genDocBytes := []byte(
+		`{
+			"genesis_time": "0001-01-01T00:00:00Z",
+			"chain_id": "test-chain-QDKdJr",
+			"initial_height": "1000",
+			"validators": [{
+				"pub_key":{"type":"tendermint/PubKeyEd25519","value":"AT/+aaL1eB0477Mud9JMm8Sh8BIvOYlPGC9KkIUmFaE="},
+				"power":"10",
+				"name":""
+			}],
+			"app_hash":"",
+			"app_state":{"account_owner": "Bob", "balances": [1, 2, 3]}
+		}`,
+	)
+	expected, err := GenesisDocFromJSON(genDocBytes)
+	require.NoError(t, err)
+
+	genDoc, err := GenesisDocFromReader(bytes.NewReader(genDocBytes))
+	require.NoError(t, err)
+	assert.Equal(t, expected.ChainID, genDoc.ChainID)
+	assert.Equal(t, expected.InitialHeight, genDoc.InitialHeight)
+	assert.Equal(t, expected.ConsensusParams, genDoc.ConsensusParams)
+	assert.Equal(t, expected.Validators, genDoc.Validators)
+	assert.JSONEq(t, string(expected.AppState), string(genDoc.AppState))
+
+	// trailing data is rejected, like in GenesisDocFromJSON
+	_, err = GenesisDocFromReader(bytes.NewReader(append(genDocBytes, []byte(`{}`)...)))
+	assert.Error(t, err)
+
+	// malformed docs are rejected
+	for _, doc := range []string{
+		`{"chain_id": "test-chain", "app_state": {"a": [1, 2}}`,
+		`{"chain_id": "test-chain", "app_state": {"a": "b}`,
+		`{"chain_id": "test-chain", "app_state": {"a": tru}}`,
+		`{"chain_id": "test-chain",}`,
+		`{"chain_id" "test-chain"}`,
+		`{"chain_id": "test-chain"`,
+		`["chain_id", "test-chain"]`,
+	} {
+		_, err = GenesisDocFromReader(strings.NewReader(doc))
+		assert.Error(t, err, doc)
 	}
 }
 
+func TestGenesisDocFromReaderStreamsAppState(t *testing.T) {
+	// The app state is copied as is, strings with escaped quotes and
+	// delimiters included.
+	appState := `{"memo": "a \"}\" ]", "balances": [` + strings.Repeat(`{"a":"1"},`, 100000) + `{"a":"2"}], "n": -1.5e3}`
+	genDocBytes := []byte(`{"chain_id": "test-chain", "app_state": ` + appState + `, "initial_height": "5"}`)
+
+	// The input is read in small chunks, up to its end.
+	r := bytes.NewReader(genDocBytes)
+	genDoc, err := GenesisDocFromReader(iotest.OneByteReader(r))
+	require.NoError(t, err)
+	assert.Zero(t, r.Len())
+	assert.Equal(t, "test-chain", genDoc.ChainID)
+	assert.Equal(t, int64(5), genDoc.InitialHeight)
+	assert.Equal(t, appState, string(genDoc.AppState))
+
+	genDoc, err = GenesisDocFromReader(strings.NewReader(`{"chain_id": "test-chain", "app_state": 42}`))
+	require.NoError(t, err)
+	assert.Equal(t, "42", string(genDoc.AppState))
+}
+
 func TestGenesisGood(t *testing.T) {
 	// test a good one by raw json
 	genDocBytes := []byte(
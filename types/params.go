@@ -3,8 +3,10 @@ package types
 import (
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/crypto/secp256k1"
 	"github.com/cometbft/cometbft/crypto/tmhash"
@@ -23,11 +25,13 @@ const (
 
 	ABCIPubKeyTypeEd25519   = ed25519.KeyType
 	ABCIPubKeyTypeSecp256k1 = secp256k1.KeyType
+	ABCIPubKeyTypeBls12381  = bls12381.KeyType
 )
 
 var ABCIPubKeyTypesToNames = map[string]string{
 	ABCIPubKeyTypeEd25519:   ed25519.PubKeyName,
 	ABCIPubKeyTypeSecp256k1: secp256k1.PubKeyName,
+	ABCIPubKeyTypeBls12381:  bls12381.PubKeyName,
 }
 
 // ConsensusParams contains consensus critical parameters that determine the
@@ -38,6 +42,8 @@ type ConsensusParams struct {
 	Validator ValidatorParams `json:"validator"`
 	Version   VersionParams   `json:"version"`
 	ABCI      ABCIParams      `json:"abci"`
+	Timeout   TimeoutParams   `json:"timeout"`
+	Synchrony SynchronyParams `json:"synchrony"`
 }
 
 // BlockParams define limits on the block size and gas plus minimum time
@@ -58,6 +64,22 @@ type EvidenceParams struct {
 // NOTE: uses ABCI pubkey naming, not Amino names.
 type ValidatorParams struct {
 	PubKeyTypes []string `json:"pub_key_types"`
+	// First height from which the signatures of the precommits for the block,
+	// in the commits included in the blocks, are aggregated into a single BLS
+	// signature. 0 if the aggregation is disabled.
+	BLSAggregationEnableHeight int64 `json:"bls_aggregation_enable_height"`
+}
+
+// BLSAggregationEnabled returns true if the signatures of the commit of height
+// h are aggregated, and false otherwise.
+func (v ValidatorParams) BLSAggregationEnabled(h int64) bool {
+	if h < 1 {
+		panic(fmt.Errorf("cannot check if BLS aggregation enabled for height %d (< 1)", h))
+	}
+	if v.BLSAggregationEnableHeight == 0 {
+		return false
+	}
+	return v.BLSAggregationEnableHeight <= h
 }
 
 type VersionParams struct {
@@ -68,6 +90,9 @@ type VersionParams struct {
 // Interface.
 type ABCIParams struct {
 	VoteExtensionsEnableHeight int64 `json:"vote_extensions_enable_height"`
+	// First height from which the vote extensions carry the decryption shares
+	// of the threshold-encrypted txs of the next height. 0 if disabled.
+	EncryptedTxsEnableHeight int64 `json:"encrypted_txs_enable_height"`
 }
 
 // VoteExtensionsEnabled returns true if vote extensions are enabled at height h
@@ -82,6 +107,79 @@ func (a ABCIParams) VoteExtensionsEnabled(h int64) bool {
 	return a.VoteExtensionsEnableHeight <= h
 }
 
+// EncryptedTxsEnabled returns true if the vote extensions of height h carry
+// the decryption shares of the encrypted txs, and false otherwise.
+func (a ABCIParams) EncryptedTxsEnabled(h int64) bool {
+	if h < 1 {
+		panic(fmt.Errorf("cannot check if encrypted txs enabled for height %d (< 1)", h))
+	}
+	if a.EncryptedTxsEnableHeight == 0 {
+		return false
+	}
+	return a.EncryptedTxsEnableHeight <= h
+}
+
+// TimeoutParams configure the timeouts of the steps of the consensus
+// algorithm, so that all the validators use the same timeouts. The timeouts
+// are only set if Propose is greater than 0; otherwise, each node uses the
+// timeouts of its configuration. When set, they are used as is, neither
+// scaled nor adapted by the nodes.
+type TimeoutParams struct {
+	Propose        time.Duration `json:"propose"`
+	ProposeDelta   time.Duration `json:"propose_delta"`
+	Prevote        time.Duration `json:"prevote"`
+	PrevoteDelta   time.Duration `json:"prevote_delta"`
+	Precommit      time.Duration `json:"precommit"`
+	PrecommitDelta time.Duration `json:"precommit_delta"`
+	Commit         time.Duration `json:"commit"`
+}
+
+// IsSet returns true if the timeouts are set, and override those of the
+// configuration of the nodes.
+func (t TimeoutParams) IsSet() bool {
+	return t.Propose > 0
+}
+
+// SynchronyParams configure the bounds of the proposer-based timestamps
+// (PBTS). Under PBTS, the time of a block is the time of its proposer, and the
+// validators only prevote for a new block if they receive its proposal in
+// time, i.e. within Precision before, and MessageDelay plus Precision after,
+// the time of the block, by their own clocks.
+type SynchronyParams struct {
+	Precision    time.Duration `json:"precision"`
+	MessageDelay time.Duration `json:"message_delay"`
+	// First height from which the time of the blocks is set by PBTS instead
+	// of BFT time. 0 if PBTS is disabled.
+	PBTSEnableHeight int64 `json:"pbts_enable_height"`
+}
+
+// PBTSEnabled returns true if the time of the block of height h is set by
+// PBTS, and false otherwise.
+func (sp SynchronyParams) PBTSEnabled(h int64) bool {
+	if h < 1 {
+		panic(fmt.Errorf("cannot check if PBTS enabled for height %d (< 1)", h))
+	}
+	if sp.PBTSEnableHeight == 0 {
+		return false
+	}
+	return sp.PBTSEnableHeight <= h
+}
+
+// InRound returns the SynchronyParams to use in the given round: the
+// MessageDelay increases by 10% with each round, so that the validators
+// eventually accept the proposals even if it's set too low.
+func (sp SynchronyParams) InRound(round int32) SynchronyParams {
+	messageDelay := time.Duration(math.MaxInt64)
+	if delay := math.Pow(1.1, float64(round)) * float64(sp.MessageDelay); delay < math.MaxInt64 {
+		messageDelay = time.Duration(delay)
+	}
+	return SynchronyParams{
+		Precision:        sp.Precision,
+		MessageDelay:     messageDelay,
+		PBTSEnableHeight: sp.PBTSEnableHeight,
+	}
+}
+
 // DefaultConsensusParams returns a default ConsensusParams.
 func DefaultConsensusParams() *ConsensusParams {
 	return &ConsensusParams{
@@ -90,6 +188,8 @@ func DefaultConsensusParams() *ConsensusParams {
 		Validator: DefaultValidatorParams(),
 		Version:   DefaultVersionParams(),
 		ABCI:      DefaultABCIParams(),
+		Timeout:   DefaultTimeoutParams(),
+		Synchrony: DefaultSynchronyParams(),
 	}
 }
 
@@ -131,6 +231,21 @@ func DefaultABCIParams() ABCIParams {
 	}
 }
 
+// DefaultTimeoutParams returns a default TimeoutParams, which leaves the
+// timeouts to the configuration of the nodes.
+func DefaultTimeoutParams() TimeoutParams {
+	return TimeoutParams{}
+}
+
+// DefaultSynchronyParams returns a default SynchronyParams, with PBTS
+// disabled.
+func DefaultSynchronyParams() SynchronyParams {
+	return SynchronyParams{
+		Precision:    505 * time.Millisecond,
+		MessageDelay: 15 * time.Second,
+	}
+}
+
 func IsValidPubkeyType(params ValidatorParams, pubkeyType string) bool {
 	for i := 0; i < len(params.PubKeyTypes); i++ {
 		if params.PubKeyTypes[i] == pubkeyType {
@@ -188,11 +303,67 @@ func (params ConsensusParams) ValidateBasic() error {
 	if params.ABCI.VoteExtensionsEnableHeight < 0 {
 		return fmt.Errorf("ABCI.VoteExtensionsEnableHeight cannot be negative. Got: %d", params.ABCI.VoteExtensionsEnableHeight)
 	}
+	if params.ABCI.EncryptedTxsEnableHeight < 0 {
+		return fmt.Errorf("ABCI.EncryptedTxsEnableHeight cannot be negative. Got: %d", params.ABCI.EncryptedTxsEnableHeight)
+	}
+	if params.ABCI.EncryptedTxsEnableHeight > 0 && (params.ABCI.VoteExtensionsEnableHeight == 0 ||
+		params.ABCI.VoteExtensionsEnableHeight > params.ABCI.EncryptedTxsEnableHeight) {
+		return fmt.Errorf("ABCI.EncryptedTxsEnableHeight requires vote extensions to be enabled at that height. Got: %d, %d",
+			params.ABCI.EncryptedTxsEnableHeight, params.ABCI.VoteExtensionsEnableHeight)
+	}
+
+	timeouts := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"Propose", params.Timeout.Propose},
+		{"ProposeDelta", params.Timeout.ProposeDelta},
+		{"Prevote", params.Timeout.Prevote},
+		{"PrevoteDelta", params.Timeout.PrevoteDelta},
+		{"Precommit", params.Timeout.Precommit},
+		{"PrecommitDelta", params.Timeout.PrecommitDelta},
+		{"Commit", params.Timeout.Commit},
+	}
+	for _, timeout := range timeouts {
+		if timeout.value < 0 {
+			return fmt.Errorf("timeout.%s cannot be negative. Got: %v", timeout.name, timeout.value)
+		}
+		if !params.Timeout.IsSet() && timeout.value != 0 {
+			return fmt.Errorf("timeout.%s cannot be set without timeout.Propose. Got: %v", timeout.name, timeout.value)
+		}
+	}
+
+	if params.Synchrony.PBTSEnableHeight < 0 {
+		return fmt.Errorf("Synchrony.PBTSEnableHeight cannot be negative. Got: %d", params.Synchrony.PBTSEnableHeight)
+	}
+	if params.Synchrony.Precision < 0 {
+		return fmt.Errorf("synchrony.Precision cannot be negative. Got: %v", params.Synchrony.Precision)
+	}
+	if params.Synchrony.MessageDelay < 0 {
+		return fmt.Errorf("synchrony.MessageDelay cannot be negative. Got: %v", params.Synchrony.MessageDelay)
+	}
+	if params.Synchrony.PBTSEnableHeight > 0 && (params.Synchrony.Precision == 0 || params.Synchrony.MessageDelay == 0) {
+		return fmt.Errorf("synchrony.Precision and synchrony.MessageDelay must be greater than 0 if PBTS is enabled. Got: %v, %v",
+			params.Synchrony.Precision, params.Synchrony.MessageDelay)
+	}
 
 	if len(params.Validator.PubKeyTypes) == 0 {
 		return errors.New("len(Validator.PubKeyTypes) must be greater than 0")
 	}
 
+	if params.Validator.BLSAggregationEnableHeight < 0 {
+		return fmt.Errorf("Validator.BLSAggregationEnableHeight cannot be negative. Got: %d",
+			params.Validator.BLSAggregationEnableHeight)
+	}
+	if params.Validator.BLSAggregationEnableHeight > 0 {
+		for _, keyType := range params.Validator.PubKeyTypes {
+			if keyType != ABCIPubKeyTypeBls12381 {
+				return fmt.Errorf("Validator.PubKeyTypes can only contain %s if the BLS aggregation is enabled. Got: %s",
+					ABCIPubKeyTypeBls12381, keyType)
+			}
+		}
+	}
+
 	// Check if keyType is a known ABCIPubKeyType
 	for i := 0; i < len(params.Validator.PubKeyTypes); i++ {
 		keyType := params.Validator.PubKeyTypes[i]
@@ -206,16 +377,35 @@ func (params ConsensusParams) ValidateBasic() error {
 }
 
 func (params ConsensusParams) ValidateUpdate(updated *cmtproto.ConsensusParams, h int64) error {
-	if updated.Abci == nil {
-		return nil
+	if updated.Abci != nil {
+		if err := params.validateVoteExtensionsUpdate(updated.Abci, h); err != nil {
+			return err
+		}
+		if err := params.validateEncryptedTxsUpdate(updated.Abci, h); err != nil {
+			return err
+		}
 	}
-	if params.ABCI.VoteExtensionsEnableHeight == updated.Abci.VoteExtensionsEnableHeight {
+	if updated.Validator != nil {
+		if err := params.validateBLSAggregationUpdate(updated.Validator, h); err != nil {
+			return err
+		}
+	}
+	if updated.Synchrony != nil {
+		if err := params.validatePBTSUpdate(updated.Synchrony, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (params ConsensusParams) validateVoteExtensionsUpdate(updated *cmtproto.ABCIParams, h int64) error {
+	if params.ABCI.VoteExtensionsEnableHeight == updated.VoteExtensionsEnableHeight {
 		return nil
 	}
-	if params.ABCI.VoteExtensionsEnableHeight != 0 && updated.Abci.VoteExtensionsEnableHeight == 0 {
+	if params.ABCI.VoteExtensionsEnableHeight != 0 && updated.VoteExtensionsEnableHeight == 0 {
 		return errors.New("vote extensions cannot be disabled once enabled")
 	}
-	if updated.Abci.VoteExtensionsEnableHeight <= h {
+	if updated.VoteExtensionsEnableHeight <= h {
 		return fmt.Errorf("VoteExtensionsEnableHeight cannot be updated to a past height, "+
 			"initial height: %d, current height %d",
 			params.ABCI.VoteExtensionsEnableHeight, h)
@@ -229,6 +419,69 @@ func (params ConsensusParams) ValidateUpdate(updated *cmtproto.ConsensusParams,
 	return nil
 }
 
+func (params ConsensusParams) validateEncryptedTxsUpdate(updated *cmtproto.ABCIParams, h int64) error {
+	if params.ABCI.EncryptedTxsEnableHeight == updated.EncryptedTxsEnableHeight {
+		return nil
+	}
+	if params.ABCI.EncryptedTxsEnableHeight != 0 && updated.EncryptedTxsEnableHeight == 0 {
+		return errors.New("encrypted txs cannot be disabled once enabled")
+	}
+	if updated.EncryptedTxsEnableHeight <= h {
+		return fmt.Errorf("EncryptedTxsEnableHeight cannot be updated to a past height, "+
+			"initial height: %d, current height %d",
+			params.ABCI.EncryptedTxsEnableHeight, h)
+	}
+	if params.ABCI.EncryptedTxsEnableHeight != 0 && params.ABCI.EncryptedTxsEnableHeight <= h {
+		return fmt.Errorf("EncryptedTxsEnableHeight cannot be modified once "+
+			"the initial height has occurred, "+
+			"initial height: %d, current height %d",
+			params.ABCI.EncryptedTxsEnableHeight, h)
+	}
+	return nil
+}
+
+func (params ConsensusParams) validateBLSAggregationUpdate(updated *cmtproto.ValidatorParams, h int64) error {
+	if params.Validator.BLSAggregationEnableHeight == updated.BlsAggregationEnableHeight {
+		return nil
+	}
+	if params.Validator.BLSAggregationEnableHeight != 0 && updated.BlsAggregationEnableHeight == 0 {
+		return errors.New("BLS aggregation cannot be disabled once enabled")
+	}
+	if updated.BlsAggregationEnableHeight <= h {
+		return fmt.Errorf("BLSAggregationEnableHeight cannot be updated to a past height, "+
+			"initial height: %d, current height %d",
+			params.Validator.BLSAggregationEnableHeight, h)
+	}
+	if params.Validator.BLSAggregationEnableHeight != 0 && params.Validator.BLSAggregationEnableHeight <= h {
+		return fmt.Errorf("BLSAggregationEnableHeight cannot be modified once "+
+			"the initial height has occurred, "+
+			"initial height: %d, current height %d",
+			params.Validator.BLSAggregationEnableHeight, h)
+	}
+	return nil
+}
+
+func (params ConsensusParams) validatePBTSUpdate(updated *cmtproto.SynchronyParams, h int64) error {
+	if params.Synchrony.PBTSEnableHeight == updated.PbtsEnableHeight {
+		return nil
+	}
+	if params.Synchrony.PBTSEnableHeight != 0 && updated.PbtsEnableHeight == 0 {
+		return errors.New("PBTS cannot be disabled once enabled")
+	}
+	if updated.PbtsEnableHeight <= h {
+		return fmt.Errorf("PBTSEnableHeight cannot be updated to a past height, "+
+			"initial height: %d, current height %d",
+			params.Synchrony.PBTSEnableHeight, h)
+	}
+	if params.Synchrony.PBTSEnableHeight != 0 && params.Synchrony.PBTSEnableHeight <= h {
+		return fmt.Errorf("PBTSEnableHeight cannot be modified once "+
+			"the initial height has occurred, "+
+			"initial height: %d, current height %d",
+			params.Synchrony.PBTSEnableHeight, h)
+	}
+	return nil
+}
+
 // Hash returns a hash of a subset of the parameters to store in the block header.
 // Only the Block.MaxBytes and Block.MaxGas are included in the hash.
 // This allows the ConsensusParams to evolve more without breaking the block
@@ -276,12 +529,32 @@ func (params ConsensusParams) Update(params2 *cmtproto.ConsensusParams) Consensu
 		// Copy params2.Validator.PubkeyTypes, and set result's value to the copy.
 		// This avoids having to initialize the slice to 0 values, and then write to it again.
 		res.Validator.PubKeyTypes = append([]string{}, params2.Validator.PubKeyTypes...)
+		res.Validator.BLSAggregationEnableHeight = params2.Validator.BlsAggregationEnableHeight
 	}
 	if params2.Version != nil {
 		res.Version.App = params2.Version.App
 	}
 	if params2.Abci != nil {
 		res.ABCI.VoteExtensionsEnableHeight = params2.Abci.GetVoteExtensionsEnableHeight()
+		res.ABCI.EncryptedTxsEnableHeight = params2.Abci.GetEncryptedTxsEnableHeight()
+	}
+	if params2.Timeout != nil {
+		res.Timeout = TimeoutParams{
+			Propose:        params2.Timeout.Propose,
+			ProposeDelta:   params2.Timeout.ProposeDelta,
+			Prevote:        params2.Timeout.Prevote,
+			PrevoteDelta:   params2.Timeout.PrevoteDelta,
+			Precommit:      params2.Timeout.Precommit,
+			PrecommitDelta: params2.Timeout.PrecommitDelta,
+			Commit:         params2.Timeout.Commit,
+		}
+	}
+	if params2.Synchrony != nil {
+		res.Synchrony = SynchronyParams{
+			Precision:        params2.Synchrony.Precision,
+			MessageDelay:     params2.Synchrony.MessageDelay,
+			PBTSEnableHeight: params2.Synchrony.PbtsEnableHeight,
+		}
 	}
 	return res
 }
@@ -298,13 +571,29 @@ func (params *ConsensusParams) ToProto() cmtproto.ConsensusParams {
 			MaxBytes:        params.Evidence.MaxBytes,
 		},
 		Validator: &cmtproto.ValidatorParams{
-			PubKeyTypes: params.Validator.PubKeyTypes,
+			PubKeyTypes:                params.Validator.PubKeyTypes,
+			BlsAggregationEnableHeight: params.Validator.BLSAggregationEnableHeight,
 		},
 		Version: &cmtproto.VersionParams{
 			App: params.Version.App,
 		},
 		Abci: &cmtproto.ABCIParams{
 			VoteExtensionsEnableHeight: params.ABCI.VoteExtensionsEnableHeight,
+			EncryptedTxsEnableHeight:   params.ABCI.EncryptedTxsEnableHeight,
+		},
+		Timeout: &cmtproto.TimeoutParams{
+			Propose:        params.Timeout.Propose,
+			ProposeDelta:   params.Timeout.ProposeDelta,
+			Prevote:        params.Timeout.Prevote,
+			PrevoteDelta:   params.Timeout.PrevoteDelta,
+			Precommit:      params.Timeout.Precommit,
+			PrecommitDelta: params.Timeout.PrecommitDelta,
+			Commit:         params.Timeout.Commit,
+		},
+		Synchrony: &cmtproto.SynchronyParams{
+			Precision:        params.Synchrony.Precision,
+			MessageDelay:     params.Synchrony.MessageDelay,
+			PbtsEnableHeight: params.Synchrony.PBTSEnableHeight,
 		},
 	}
 }
@@ -321,7 +610,8 @@ func ConsensusParamsFromProto(pbParams cmtproto.ConsensusParams) ConsensusParams
 			MaxBytes:        pbParams.Evidence.MaxBytes,
 		},
 		Validator: ValidatorParams{
-			PubKeyTypes: pbParams.Validator.PubKeyTypes,
+			PubKeyTypes:                pbParams.Validator.PubKeyTypes,
+			BLSAggregationEnableHeight: pbParams.Validator.BlsAggregationEnableHeight,
 		},
 		Version: VersionParams{
 			App: pbParams.Version.App,
@@ -329,6 +619,25 @@ func ConsensusParamsFromProto(pbParams cmtproto.ConsensusParams) ConsensusParams
 	}
 	if pbParams.Abci != nil {
 		c.ABCI.VoteExtensionsEnableHeight = pbParams.Abci.GetVoteExtensionsEnableHeight()
+		c.ABCI.EncryptedTxsEnableHeight = pbParams.Abci.GetEncryptedTxsEnableHeight()
+	}
+	if pbParams.Timeout != nil {
+		c.Timeout = TimeoutParams{
+			Propose:        pbParams.Timeout.Propose,
+			ProposeDelta:   pbParams.Timeout.ProposeDelta,
+			Prevote:        pbParams.Timeout.Prevote,
+			PrevoteDelta:   pbParams.Timeout.PrevoteDelta,
+			Precommit:      pbParams.Timeout.Precommit,
+			PrecommitDelta: pbParams.Timeout.PrecommitDelta,
+			Commit:         pbParams.Timeout.Commit,
+		}
+	}
+	if pbParams.Synchrony != nil {
+		c.Synchrony = SynchronyParams{
+			Precision:        pbParams.Synchrony.Precision,
+			MessageDelay:     pbParams.Synchrony.MessageDelay,
+			PBTSEnableHeight: pbParams.Synchrony.PbtsEnableHeight,
+		}
 	}
 	return c
 }
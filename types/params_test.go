@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"math"
 	"sort"
 	"testing"
 	"time"
@@ -213,6 +214,177 @@ func TestConsensusParamsUpdate_VoteExtensionsEnableHeight(t *testing.T) {
 	})
 }
 
+func TestConsensusParamsBLSAggregation(t *testing.T) {
+	params := makeParams(1, 0, 2, 0, []string{ABCIPubKeyTypeBls12381}, 0)
+	require.NoError(t, params.ValidateBasic())
+	require.False(t, params.Validator.BLSAggregationEnabled(1))
+
+	params.Validator.BLSAggregationEnableHeight = 10
+	require.NoError(t, params.ValidateBasic())
+	require.False(t, params.Validator.BLSAggregationEnabled(9))
+	require.True(t, params.Validator.BLSAggregationEnabled(10))
+	require.Equal(t, params, ConsensusParamsFromProto(params.ToProto()))
+
+	// All the validators must use BLS keys.
+	invalid := params
+	invalid.Validator.PubKeyTypes = []string{ABCIPubKeyTypeBls12381, ABCIPubKeyTypeEd25519}
+	require.Error(t, invalid.ValidateBasic())
+	invalid = params
+	invalid.Validator.BLSAggregationEnableHeight = -1
+	require.Error(t, invalid.ValidateBasic())
+
+	update := func(h int64) *cmtproto.ConsensusParams {
+		return &cmtproto.ConsensusParams{Validator: &cmtproto.ValidatorParams{
+			PubKeyTypes:                []string{ABCIPubKeyTypeBls12381},
+			BlsAggregationEnableHeight: h,
+		}}
+	}
+	require.Equal(t, int64(20), params.Update(update(20)).Validator.BLSAggregationEnableHeight)
+
+	disabled := makeParams(1, 0, 2, 0, []string{ABCIPubKeyTypeBls12381}, 0)
+	// Enabled at a future height.
+	require.NoError(t, disabled.ValidateUpdate(update(10), 5))
+	require.Error(t, disabled.ValidateUpdate(update(5), 5))
+	// Modified before the enable height.
+	require.NoError(t, params.ValidateUpdate(update(20), 5))
+	// Modified after the enable height, or disabled.
+	require.Error(t, params.ValidateUpdate(update(20), 15))
+	require.Error(t, params.ValidateUpdate(update(0), 5))
+	require.NoError(t, params.ValidateUpdate(update(10), 15))
+}
+
+func TestConsensusParamsEncryptedTxs(t *testing.T) {
+	params := makeParams(1, 0, 2, 0, valEd25519, 5)
+	require.NoError(t, params.ValidateBasic())
+	require.False(t, params.ABCI.EncryptedTxsEnabled(10))
+
+	params.ABCI.EncryptedTxsEnableHeight = 10
+	require.NoError(t, params.ValidateBasic())
+	require.False(t, params.ABCI.EncryptedTxsEnabled(9))
+	require.True(t, params.ABCI.EncryptedTxsEnabled(10))
+	require.Equal(t, params, ConsensusParamsFromProto(params.ToProto()))
+
+	// The vote extensions must be enabled by then.
+	invalid := params
+	invalid.ABCI.VoteExtensionsEnableHeight = 0
+	require.Error(t, invalid.ValidateBasic())
+	invalid.ABCI.VoteExtensionsEnableHeight = 11
+	require.Error(t, invalid.ValidateBasic())
+	invalid = params
+	invalid.ABCI.EncryptedTxsEnableHeight = -1
+	require.Error(t, invalid.ValidateBasic())
+
+	update := func(h int64) *cmtproto.ConsensusParams {
+		return &cmtproto.ConsensusParams{Abci: &cmtproto.ABCIParams{
+			VoteExtensionsEnableHeight: 5,
+			EncryptedTxsEnableHeight:   h,
+		}}
+	}
+	require.Equal(t, int64(20), params.Update(update(20)).ABCI.EncryptedTxsEnableHeight)
+
+	disabled := makeParams(1, 0, 2, 0, valEd25519, 5)
+	// Enabled at a future height.
+	require.NoError(t, disabled.ValidateUpdate(update(10), 5))
+	require.Error(t, disabled.ValidateUpdate(update(5), 5))
+	// Modified before the enable height.
+	require.NoError(t, params.ValidateUpdate(update(20), 5))
+	// Modified after the enable height, or disabled.
+	require.Error(t, params.ValidateUpdate(update(20), 15))
+	require.Error(t, params.ValidateUpdate(update(0), 5))
+	require.NoError(t, params.ValidateUpdate(update(10), 15))
+}
+
+func TestConsensusParamsTimeout(t *testing.T) {
+	params := makeParams(1, 0, 2, 0, valEd25519, 0)
+	require.NoError(t, params.ValidateBasic())
+	require.False(t, params.Timeout.IsSet())
+
+	// The timeouts are set by the updates, and cannot be negative or set
+	// without the propose timeout.
+	timeout := TimeoutParams{
+		Propose:        2 * time.Second,
+		ProposeDelta:   500 * time.Millisecond,
+		Prevote:        time.Second,
+		PrevoteDelta:   500 * time.Millisecond,
+		Precommit:      time.Second,
+		PrecommitDelta: 500 * time.Millisecond,
+		Commit:         time.Second,
+	}
+	updated := params.Update(&cmtproto.ConsensusParams{Timeout: &cmtproto.TimeoutParams{
+		Propose:        timeout.Propose,
+		ProposeDelta:   timeout.ProposeDelta,
+		Prevote:        timeout.Prevote,
+		PrevoteDelta:   timeout.PrevoteDelta,
+		Precommit:      timeout.Precommit,
+		PrecommitDelta: timeout.PrecommitDelta,
+		Commit:         timeout.Commit,
+	}})
+	require.Equal(t, timeout, updated.Timeout)
+	require.True(t, updated.Timeout.IsSet())
+	require.NoError(t, updated.ValidateBasic())
+	require.Equal(t, updated, ConsensusParamsFromProto(updated.ToProto()))
+
+	// Updates without timeouts leave them unchanged.
+	require.Equal(t, timeout, updated.Update(&cmtproto.ConsensusParams{}).Timeout)
+
+	invalid := updated
+	invalid.Timeout.Commit = -1
+	require.Error(t, invalid.ValidateBasic())
+	invalid = updated
+	invalid.Timeout.Propose = 0
+	require.Error(t, invalid.ValidateBasic())
+}
+
+func TestConsensusParamsSynchrony(t *testing.T) {
+	params := makeParams(1, 0, 2, 0, valEd25519, 0)
+	params.Synchrony = DefaultSynchronyParams()
+	require.NoError(t, params.ValidateBasic())
+	require.False(t, params.Synchrony.PBTSEnabled(1))
+
+	params.Synchrony.PBTSEnableHeight = 10
+	require.NoError(t, params.ValidateBasic())
+	require.False(t, params.Synchrony.PBTSEnabled(9))
+	require.True(t, params.Synchrony.PBTSEnabled(10))
+	require.Equal(t, params, ConsensusParamsFromProto(params.ToProto()))
+
+	// The message delay increases by 10% with each round.
+	require.Equal(t, params.Synchrony.MessageDelay, params.Synchrony.InRound(0).MessageDelay)
+	require.Equal(t, 16500*time.Millisecond, params.Synchrony.InRound(1).MessageDelay)
+	require.Equal(t, time.Duration(math.MaxInt64), params.Synchrony.InRound(math.MaxInt32).MessageDelay)
+
+	invalid := params
+	invalid.Synchrony.PBTSEnableHeight = -1
+	require.Error(t, invalid.ValidateBasic())
+	invalid = params
+	invalid.Synchrony.MessageDelay = 0
+	require.Error(t, invalid.ValidateBasic())
+	invalid = params
+	invalid.Synchrony.Precision = -1
+	require.Error(t, invalid.ValidateBasic())
+
+	update := func(h int64) *cmtproto.ConsensusParams {
+		return &cmtproto.ConsensusParams{Synchrony: &cmtproto.SynchronyParams{
+			Precision:        params.Synchrony.Precision,
+			MessageDelay:     params.Synchrony.MessageDelay,
+			PbtsEnableHeight: h,
+		}}
+	}
+	require.Equal(t, int64(20), params.Update(update(20)).Synchrony.PBTSEnableHeight)
+	// Updates without synchrony params leave them unchanged.
+	require.Equal(t, params.Synchrony, params.Update(&cmtproto.ConsensusParams{}).Synchrony)
+
+	disabled := makeParams(1, 0, 2, 0, valEd25519, 0)
+	// Enabled at a future height.
+	require.NoError(t, disabled.ValidateUpdate(update(10), 5))
+	require.Error(t, disabled.ValidateUpdate(update(5), 5))
+	// Modified before the enable height.
+	require.NoError(t, params.ValidateUpdate(update(20), 5))
+	// Modified after the enable height, or disabled.
+	require.Error(t, params.ValidateUpdate(update(20), 15))
+	require.Error(t, params.ValidateUpdate(update(0), 5))
+	require.NoError(t, params.ValidateUpdate(update(10), 15))
+}
+
 func TestProto(t *testing.T) {
 	params := []ConsensusParams{
 		makeParams(4, 2, 3, 1, valEd25519, 1),
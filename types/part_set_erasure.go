@@ -0,0 +1,154 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/klauspost/reedsolomon"
+
+	"github.com/cometbft/cometbft/crypto/merkle"
+)
+
+// maxErasureCodedParts is the maximum number of data and parity parts of an
+// erasure coded part set.
+const maxErasureCodedParts = 256
+
+var ErrPartSetInvalidReconstruction = errors.New("error part set reconstruction does not match the hash")
+
+// NumParityParts returns the number of parity parts of a part set with the
+// given total of parts: as many as the parts, up to maxErasureCodedParts parts
+// in total. The part sets of a single part, or of maxErasureCodedParts parts
+// or more, have none.
+//
+// Any Total() parts among the parts and the parity parts of a part set are
+// enough to reconstruct its missing parts (see PartSet.ReconstructParts).
+func NumParityParts(total uint32) uint32 {
+	switch {
+	case total < 2 || total >= maxErasureCodedParts:
+		return 0
+	case total > maxErasureCodedParts-total:
+		return maxErasureCodedParts - total
+	default:
+		return total
+	}
+}
+
+// ParityParts returns the parity parts of the complete part set, computed
+// with a Reed-Solomon code, or nil if the part set has none. The parity parts
+// are the size of the first part, the last part being padded with zeros.
+func (ps *PartSet) ParityParts() ([][]byte, error) {
+	if !ps.IsComplete() {
+		return nil, errors.New("cannot compute the parity parts of an incomplete part set")
+	}
+	numParity := NumParityParts(ps.total)
+	if numParity == 0 {
+		return nil, nil
+	}
+	enc, err := reedsolomon.New(int(ps.total), int(numParity))
+	if err != nil {
+		return nil, err
+	}
+
+	ps.mtx.Lock()
+	partSize := len(ps.parts[0].Bytes)
+	shards := make([][]byte, ps.total+numParity)
+	for i, part := range ps.parts {
+		shards[i] = part.Bytes
+	}
+	ps.mtx.Unlock()
+
+	last := shards[ps.total-1]
+	if len(last) > partSize {
+		return nil, fmt.Errorf("last part is larger than the first part (%d > %d)", len(last), partSize)
+	}
+	shards[ps.total-1] = make([]byte, partSize)
+	copy(shards[ps.total-1], last)
+	for i := ps.total; i < ps.total+numParity; i++ {
+		shards[i] = make([]byte, partSize)
+	}
+	if err := enc.Encode(shards); err != nil {
+		return nil, err
+	}
+	return shards[ps.total:], nil
+}
+
+// ReconstructParts returns the missing parts of the part set, reconstructed
+// from its parts and the given parity parts, nil if missing, along with their
+// proofs. lastPartSize is the size of the last part, which is padded in the
+// parity parts. It fails if there are less than Total() parts and parity parts
+// in all, or with ErrPartSetInvalidReconstruction if the reconstructed parts
+// do not match the hash of the part set, e.g. due to an invalid parity part.
+//
+// The part set is not modified: the caller adds the returned parts.
+func (ps *PartSet) ReconstructParts(parity [][]byte, lastPartSize uint32) ([]*Part, error) {
+	if uint32(len(parity)) != NumParityParts(ps.total) || len(parity) == 0 {
+		return nil, fmt.Errorf("expected %d parity parts, got %d", NumParityParts(ps.total), len(parity))
+	}
+	partSize := -1
+	for _, p := range parity {
+		if p == nil {
+			continue
+		}
+		if partSize != -1 && len(p) != partSize {
+			return nil, errors.New("parity parts of different sizes")
+		}
+		partSize = len(p)
+	}
+	if partSize <= 0 || lastPartSize == 0 || int(lastPartSize) > partSize {
+		return nil, fmt.Errorf("invalid parity part size %d or last part size %d", partSize, lastPartSize)
+	}
+
+	ps.mtx.Lock()
+	shards := make([][]byte, int(ps.total)+len(parity))
+	for i, part := range ps.parts {
+		if part == nil {
+			continue
+		}
+		size := partSize
+		if uint32(i) == ps.total-1 {
+			size = int(lastPartSize)
+		}
+		if len(part.Bytes) != size {
+			ps.mtx.Unlock()
+			return nil, fmt.Errorf("part %d has size %d, expected %d", i, len(part.Bytes), size)
+		}
+		shards[i] = part.Bytes
+	}
+	ps.mtx.Unlock()
+
+	if last := shards[ps.total-1]; last != nil {
+		shards[ps.total-1] = make([]byte, partSize)
+		copy(shards[ps.total-1], last)
+	}
+	copy(shards[ps.total:], parity)
+
+	enc, err := reedsolomon.New(int(ps.total), len(parity))
+	if err != nil {
+		return nil, err
+	}
+	if err := enc.ReconstructData(shards); err != nil {
+		return nil, err
+	}
+
+	data := shards[:ps.total]
+	last := data[ps.total-1]
+	if !bytes.Equal(last[lastPartSize:], make([]byte, partSize-int(lastPartSize))) {
+		return nil, ErrPartSetInvalidReconstruction
+	}
+	data[ps.total-1] = last[:lastPartSize]
+	root, proofs := merkle.ProofsFromByteSlices(data)
+	if !bytes.Equal(root, ps.hash) {
+		return nil, ErrPartSetInvalidReconstruction
+	}
+
+	var parts []*Part
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	for i, part := range ps.parts {
+		if part == nil {
+			parts = append(parts, &Part{Index: uint32(i), Bytes: data[i], Proof: *proofs[i]})
+		}
+	}
+	return parts, nil
+}
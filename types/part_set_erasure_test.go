@@ -0,0 +1,90 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+)
+
+func TestNumParityParts(t *testing.T) {
+	for total, want := range map[uint32]uint32{
+		0: 0, 1: 0, 2: 2, 100: 100, 128: 128, 129: 127, 255: 1, 256: 0, 1000: 0,
+	} {
+		assert.Equal(t, want, NumParityParts(total), "total %d", total)
+	}
+}
+
+func TestPartSetReconstructParts(t *testing.T) {
+	const nParts = 10
+	lastPartSize := testPartSize / 3
+	partSet := NewPartSetFromData(cmtrand.Bytes(testPartSize*(nParts-1)+lastPartSize), testPartSize)
+	require.EqualValues(t, nParts, partSet.Total())
+
+	parity, err := partSet.ParityParts()
+	require.NoError(t, err)
+	require.Len(t, parity, nParts)
+	for _, p := range parity {
+		require.Len(t, p, testPartSize)
+	}
+
+	// Any nParts parts and parity parts are enough, e.g. the even parts and
+	// parity parts, including the last part.
+	partSet2 := NewPartSetFromHeader(partSet.Header())
+	received := make([][]byte, nParts)
+	for i := 0; i < nParts; i++ {
+		if i%2 == 1 {
+			added, err := partSet2.AddPart(partSet.GetPart(i))
+			require.True(t, added)
+			require.NoError(t, err)
+		} else {
+			received[i] = parity[i]
+		}
+	}
+	parts, err := partSet2.ReconstructParts(received, uint32(lastPartSize))
+	require.NoError(t, err)
+	require.Len(t, parts, nParts/2)
+	for _, part := range parts {
+		assert.Equal(t, partSet.GetPart(int(part.Index)).Bytes, part.Bytes)
+		added, err := partSet2.AddPart(part)
+		require.True(t, added)
+		require.NoError(t, err)
+	}
+	assert.True(t, partSet2.IsComplete())
+	assert.Equal(t, partSet.BitArray(), partSet2.BitArray())
+
+	// Or the parity parts alone.
+	parts, err = NewPartSetFromHeader(partSet.Header()).ReconstructParts(parity, uint32(lastPartSize))
+	require.NoError(t, err)
+	require.Len(t, parts, nParts)
+
+	// But not fewer.
+	partSet3 := NewPartSetFromHeader(partSet.Header())
+	received = make([][]byte, nParts)
+	copy(received, parity[:nParts-1])
+	_, err = partSet3.ReconstructParts(received, uint32(lastPartSize))
+	require.Error(t, err)
+
+	// An invalid parity part does not match the hash.
+	invalid := make([][]byte, nParts)
+	copy(invalid, parity)
+	invalid[0] = cmtrand.Bytes(testPartSize)
+	_, err = partSet3.ReconstructParts(invalid, uint32(lastPartSize))
+	require.ErrorIs(t, err, ErrPartSetInvalidReconstruction)
+
+	// Nor does a wrong size of the last part.
+	_, err = partSet3.ReconstructParts(parity, uint32(lastPartSize+1))
+	require.ErrorIs(t, err, ErrPartSetInvalidReconstruction)
+}
+
+func TestPartSetParityPartsSinglePart(t *testing.T) {
+	partSet := NewPartSetFromData(cmtrand.Bytes(100), testPartSize)
+	parity, err := partSet.ParityParts()
+	require.NoError(t, err)
+	assert.Empty(t, parity)
+
+	_, err = NewPartSetFromHeader(partSet.Header()).ParityParts()
+	require.Error(t, err)
+}
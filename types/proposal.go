@@ -45,6 +45,17 @@ func NewProposal(height int64, round int32, polRound int32, blockID BlockID) *Pr
 	}
 }
 
+// IsTimely returns true if the proposal, received at recvTime by the local
+// clock, was received in time according to the given SynchronyParams of its
+// round, as required by the proposer-based timestamps (PBTS):
+//
+//	Timestamp - Precision <= recvTime <= Timestamp + MessageDelay + Precision
+func (p *Proposal) IsTimely(recvTime time.Time, sp SynchronyParams) bool {
+	lower := p.Timestamp.Add(-sp.Precision)
+	upper := p.Timestamp.Add(sp.MessageDelay).Add(sp.Precision)
+	return !recvTime.Before(lower) && !recvTime.After(upper)
+}
+
 // ValidateBasic performs basic validation.
 func (p *Proposal) ValidateBasic() error {
 	if p.Type != cmtproto.ProposalType {
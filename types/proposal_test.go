@@ -122,6 +122,31 @@ func BenchmarkProposalVerifySignature(b *testing.B) {
 	}
 }
 
+func TestProposalIsTimely(t *testing.T) {
+	timestamp := time.Date(2023, 11, 30, 10, 0, 0, 0, time.UTC)
+	proposal := &Proposal{Timestamp: timestamp}
+	sp := SynchronyParams{Precision: 500 * time.Millisecond, MessageDelay: 2 * time.Second}
+
+	testCases := []struct {
+		name     string
+		recvTime time.Time
+		round    int32
+		timely   bool
+	}{
+		{"received at the timestamp", timestamp, 0, true},
+		{"received within the precision before", timestamp.Add(-500 * time.Millisecond), 0, true},
+		{"received too early", timestamp.Add(-501 * time.Millisecond), 0, false},
+		{"received within the delay", timestamp.Add(2500 * time.Millisecond), 0, true},
+		{"received too late", timestamp.Add(2501 * time.Millisecond), 0, false},
+		{"received late but in a later round", timestamp.Add(2501 * time.Millisecond), 1, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.timely, proposal.IsTimely(tc.recvTime, sp.InRound(tc.round)))
+		})
+	}
+}
+
 func TestProposalValidateBasic(t *testing.T) {
 
 	privVal := NewMockPV()
@@ -1,10 +1,13 @@
 package types
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
+	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/crypto/batch"
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	cmterrors "github.com/cometbft/cometbft/types/errors"
@@ -40,6 +43,11 @@ func VerifyCommit(chainID string, vals *ValidatorSet, blockID BlockID,
 	// only count the signatures that are for the block
 	count := func(c CommitSig) bool { return c.BlockIDFlag == BlockIDFlagCommit }
 
+	if commit.IsAggregated() {
+		return verifyAggregatedCommit(chainID, vals, commit, votingPowerNeeded,
+			ignore, count)
+	}
+
 	// attempt to batch verify
 	if shouldBatchVerify(vals, commit) {
 		return verifyCommitBatch(chainID, vals, commit,
@@ -73,6 +81,11 @@ func VerifyCommitLight(chainID string, vals *ValidatorSet, blockID BlockID,
 	// count all the remaining signatures
 	count := func(c CommitSig) bool { return true }
 
+	if commit.IsAggregated() {
+		return verifyAggregatedCommit(chainID, vals, commit, votingPowerNeeded,
+			ignore, count)
+	}
+
 	// attempt to batch verify
 	if shouldBatchVerify(vals, commit) {
 		return verifyCommitBatch(chainID, vals, commit,
@@ -92,7 +105,27 @@ func VerifyCommitLight(chainID string, vals *ValidatorSet, blockID BlockID,
 //
 // This method is primarily used by the light client and does not check all the
 // signatures.
+//
+// If the commit is aggregated, the aggregated signature can't be verified with
+// the public keys of the given validators only: VerifyCommitLightTrustingWithCommitVals
+// must be used instead.
 func VerifyCommitLightTrusting(chainID string, vals *ValidatorSet, commit *Commit, trustLevel cmtmath.Fraction) error {
+	return VerifyCommitLightTrustingWithCommitVals(chainID, vals, nil, commit, trustLevel)
+}
+
+// VerifyCommitLightTrustingWithCommitVals is like VerifyCommitLightTrusting,
+// but also accepts the aggregated commits, given commitVals, the validator set
+// of the commit.
+//
+// Only the voting power of the signers among the given validators is checked,
+// and that they have the same public keys in commitVals, with which the caller
+// must then verify the aggregated signature, as the light client does.
+func VerifyCommitLightTrustingWithCommitVals(
+	chainID string,
+	vals, commitVals *ValidatorSet,
+	commit *Commit,
+	trustLevel cmtmath.Fraction,
+) error {
 	// sanity checks
 	if vals == nil {
 		return errors.New("nil validator set")
@@ -117,6 +150,13 @@ func VerifyCommitLightTrusting(chainID string, vals *ValidatorSet, commit *Commi
 	// count all the remaining signatures
 	count := func(c CommitSig) bool { return true }
 
+	if commit.IsAggregated() {
+		if commitVals == nil {
+			return errors.New("the validator set of the commit is needed to verify an aggregated commit")
+		}
+		return tallyAggregatedCommit(vals, commitVals, commit, votingPowerNeeded, ignore, count)
+	}
+
 	// attempt to batch verify commit. As the validator set doesn't necessarily
 	// correspond with the validator set that signed the block we need to look
 	// up by address rather than index.
@@ -332,6 +372,130 @@ func verifyCommitSingle(
 	return nil
 }
 
+// Aggregated Verification
+
+// verifyAggregatedCommit verifies an aggregated commit, whose signatures of
+// the precommits for the block are verified at once, from the aggregated
+// signature of the commit, and the other signatures one by one.
+//
+// As the aggregated signature is verified with the public keys of all the
+// signers, all the signatures are checked, and the validators must be the ones
+// of the commit.
+// CONTRACT: both commit and validator set should have passed validate basic
+func verifyAggregatedCommit(
+	chainID string,
+	vals *ValidatorSet,
+	commit *Commit,
+	votingPowerNeeded int64,
+	ignoreSig func(CommitSig) bool,
+	countSig func(CommitSig) bool,
+) error {
+	var (
+		pubKeys            = make([]crypto.PubKey, 0, len(commit.Signatures))
+		msgs               = make([][]byte, 0, len(commit.Signatures))
+		talliedVotingPower int64
+	)
+	for idx, commitSig := range commit.Signatures {
+		if ignoreSig(commitSig) {
+			continue
+		}
+
+		val := vals.Validators[idx]
+		// the address is not part of the sign bytes, so it must be checked for
+		// the aggregated signatures, and be the one of the public key the
+		// signature is verified with
+		if !bytes.Equal(commitSig.ValidatorAddress, val.Address) {
+			return fmt.Errorf("wrong validator address (#%d): expected %v, got %v",
+				idx, val.Address, commitSig.ValidatorAddress)
+		}
+		if !bytes.Equal(val.Address, val.PubKey.Address()) {
+			return fmt.Errorf("validator address (#%d) %v is not derived from its public key", idx, val.Address)
+		}
+
+		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
+		if commitSig.BlockIDFlag == BlockIDFlagCommit {
+			pubKeys = append(pubKeys, val.PubKey)
+			msgs = append(msgs, voteSignBytes)
+		} else if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
+			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
+		}
+
+		// If this signature counts then add the voting power of the validator
+		// to the tally
+		if countSig(commitSig) {
+			talliedVotingPower += val.VotingPower
+		}
+	}
+
+	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
+		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
+	}
+
+	if !bls12381.VerifyAggregateSignature(pubKeys, msgs, commit.AggregatedSignature) {
+		return fmt.Errorf("wrong aggregated signature: %X", commit.AggregatedSignature)
+	}
+
+	return nil
+}
+
+// tallyAggregatedCommit checks that the validators, which may not be the ones
+// of the commit, have enough voting power among the signers of an aggregated
+// commit, looking them up by address. The signers which are not in the
+// validator set are skipped over.
+//
+// No signature is verified, as the aggregated signature can only be verified
+// with the public keys of all its signers, those of commitVals, the validator
+// set of the commit: the signers counted must have the same public keys in
+// both sets.
+func tallyAggregatedCommit(
+	vals, commitVals *ValidatorSet,
+	commit *Commit,
+	votingPowerNeeded int64,
+	ignoreSig func(CommitSig) bool,
+	countSig func(CommitSig) bool,
+) error {
+	var (
+		seenVals           = make(map[int32]int, len(commit.Signatures))
+		talliedVotingPower int64
+	)
+	for idx, commitSig := range commit.Signatures {
+		if ignoreSig(commitSig) {
+			continue
+		}
+
+		valIdx, val := vals.GetByAddress(commitSig.ValidatorAddress)
+		if val == nil {
+			continue
+		}
+
+		// because we are getting validators by address we need to make sure
+		// that the same validator doesn't commit twice
+		if firstIndex, ok := seenVals[valIdx]; ok {
+			secondIndex := idx
+			return fmt.Errorf("double vote from %v (%d and %d)", val, firstIndex, secondIndex)
+		}
+		seenVals[valIdx] = idx
+
+		if idx >= len(commitVals.Validators) {
+			return fmt.Errorf("no validator #%d in the validator set of the commit", idx)
+		}
+		if commitVal := commitVals.Validators[idx]; !val.PubKey.Equals(commitVal.PubKey) {
+			return fmt.Errorf("validator %v (#%d) has another public key in the validator set of the commit",
+				val.Address, idx)
+		}
+
+		if countSig(commitSig) {
+			talliedVotingPower += val.VotingPower
+		}
+	}
+
+	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
+		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
+	}
+
+	return nil
+}
+
 func verifyBasicValsAndCommit(vals *ValidatorSet, commit *Commit, height int64, blockID BlockID) error {
 	if vals == nil {
 		return errors.New("nil validator set")
@@ -1,13 +1,17 @@
 package types
 
 import (
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 )
 
@@ -271,3 +275,230 @@ func TestValidatorSet_VerifyCommitLightTrustingErrorsOnOverflow(t *testing.T) {
 		assert.Contains(t, err.Error(), "int64 overflow")
 	}
 }
+
+func TestValidatorSet_VerifyCommit_Aggregated(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	voteSet, valSet, vals := randBLSVoteSet(h, 0, 4, 10)
+	commit := makeBLSCommit(t, blockID, voteSet, vals)
+	require.NoError(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+
+	aggCommit, err := commit.AggregateSignatures()
+	require.NoError(t, err)
+	require.True(t, aggCommit.IsAggregated())
+	require.NoError(t, aggCommit.ValidateBasic())
+	assert.NotEqual(t, commit.Hash(), aggCommit.Hash())
+	for i, cs := range aggCommit.Signatures {
+		if cs.BlockIDFlag == BlockIDFlagCommit {
+			assert.Empty(t, cs.Signature, "#%d", i)
+		} else {
+			assert.NotEmpty(t, cs.Signature, "#%d", i)
+		}
+	}
+
+	assert.NoError(t, valSet.VerifyCommit(chainID, blockID, h, aggCommit))
+	assert.NoError(t, valSet.VerifyCommitLight(chainID, blockID, h, aggCommit))
+	assert.NoError(t, valSet.VerifyCommitLightTrustingWithCommitVals(chainID, valSet, aggCommit,
+		cmtmath.Fraction{Numerator: 1, Denominator: 3}))
+	// the validator set of an aggregated commit is needed to verify it
+	assert.Error(t, valSet.VerifyCommitLightTrusting(chainID, aggCommit,
+		cmtmath.Fraction{Numerator: 1, Denominator: 3}))
+
+	// the commit and its aggregated signatures survive a round trip to proto
+	pbCommit := aggCommit.ToProto()
+	fromProto, err := CommitFromProto(pbCommit)
+	require.NoError(t, err)
+	assert.Equal(t, aggCommit.Hash(), fromProto.Hash())
+
+	// the aggregated signature of another block is rejected
+	otherCommit, err := makeBLSCommit(t, makeBlockIDRandom(),
+		NewVoteSet(chainID, h, 0, cmtproto.PrecommitType, valSet), vals).AggregateSignatures()
+	require.NoError(t, err)
+	aggCommit.AggregatedSignature = otherCommit.AggregatedSignature
+	err = valSet.VerifyCommit(chainID, blockID, h, aggCommit)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong aggregated signature")
+	}
+
+	// an aggregated commit can't include the signatures of the precommits
+	// for the block
+	aggCommit.Signatures[0].Signature = commit.Signatures[0].Signature
+	assert.Error(t, aggCommit.ValidateBasic())
+}
+
+func TestValidatorSet_VerifyCommitLightTrusting_Aggregated(t *testing.T) {
+	var (
+		chainID    = "test_chain_id"
+		h          = int64(3)
+		blockID    = makeBlockIDRandom()
+		trustLevel = cmtmath.Fraction{Numerator: 1, Denominator: 3}
+	)
+
+	voteSet, valSet, vals := randBLSVoteSet(h, 0, 4, 10)
+	aggCommit, err := makeBLSCommit(t, blockID, voteSet, vals).AggregateSignatures()
+	require.NoError(t, err)
+	require.NoError(t, valSet.VerifyCommitLightTrustingWithCommitVals(chainID, valSet, aggCommit, trustLevel))
+
+	// A commit with a signer which is not a trusted validator, as after a
+	// change of the validator set: only the voting power of the trusted
+	// signers counts.
+	changed := *aggCommit
+	changed.Signatures = make([]CommitSig, len(aggCommit.Signatures))
+	copy(changed.Signatures, aggCommit.Signatures)
+	changed.Signatures[2].ValidatorAddress = crypto.AddressHash([]byte("unknown"))
+	changed.AggregatedSignature = cmtrand.Bytes(len(aggCommit.AggregatedSignature))
+	assert.NoError(t, valSet.VerifyCommitLightTrustingWithCommitVals(chainID, valSet, &changed, trustLevel))
+	err = valSet.VerifyCommitLightTrustingWithCommitVals(chainID, valSet, &changed,
+		cmtmath.Fraction{Numerator: 2, Denominator: 3})
+	assert.Equal(t, ErrNotEnoughVotingPowerSigned{Got: 20, Needed: 26}, err)
+
+	// The aggregated signature is only verified with the validators of the
+	// commit, whose addresses must match those of the signers.
+	err = valSet.VerifyCommitLight(chainID, blockID, h, &changed)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong validator address")
+	}
+	changed.Signatures[2] = aggCommit.Signatures[2]
+	err = valSet.VerifyCommitLight(chainID, blockID, h, &changed)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong aggregated signature")
+	}
+
+	// Looking up the validators by index, the addresses of the signers must
+	// match those of the validators.
+	swapped := *aggCommit
+	swapped.Signatures = make([]CommitSig, len(aggCommit.Signatures))
+	copy(swapped.Signatures, aggCommit.Signatures)
+	swapped.Signatures[0].ValidatorAddress, swapped.Signatures[1].ValidatorAddress =
+		swapped.Signatures[1].ValidatorAddress, swapped.Signatures[0].ValidatorAddress
+	err = valSet.VerifyCommitLight(chainID, blockID, h, &swapped)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong validator address")
+	}
+}
+
+// An attacker reusing the addresses of the trusted validators with their own
+// keys can't forge an aggregated commit.
+func TestValidatorSet_VerifyCommitLightTrusting_AggregatedForgedAddresses(t *testing.T) {
+	var (
+		chainID    = "test_chain_id"
+		h          = int64(3)
+		blockID    = makeBlockIDRandom()
+		trustLevel = cmtmath.Fraction{Numerator: 1, Denominator: 3}
+	)
+
+	_, trustedVals, _ := randBLSVoteSet(h, 0, 4, 10)
+
+	// the validators of the attacker have the addresses of the trusted ones
+	var (
+		forged     = make([]*Validator, trustedVals.Size())
+		forgedKeys = make(map[string]crypto.PrivKey, trustedVals.Size())
+	)
+	for i, val := range trustedVals.Validators {
+		privKey := bls12381.GenPrivKey()
+		forged[i] = &Validator{Address: val.Address, PubKey: privKey.PubKey(), VotingPower: val.VotingPower}
+		forgedKeys[string(val.Address)] = privKey
+	}
+	untrustedVals := NewValidatorSet(forged)
+
+	commit := &Commit{Height: h, BlockID: blockID, Signatures: make([]CommitSig, untrustedVals.Size())}
+	for i, val := range untrustedVals.Validators {
+		vote := &Vote{
+			ValidatorAddress: val.Address,
+			ValidatorIndex:   int32(i),
+			Height:           h,
+			Type:             cmtproto.PrecommitType,
+			BlockID:          blockID,
+			Timestamp:        time.Now(),
+		}
+		sig, err := forgedKeys[string(val.Address)].Sign(VoteSignBytes(chainID, vote.ToProto()))
+		require.NoError(t, err)
+		commit.Signatures[i] = CommitSig{
+			BlockIDFlag:      BlockIDFlagCommit,
+			ValidatorAddress: val.Address,
+			Timestamp:        vote.Timestamp,
+			Signature:        sig,
+		}
+	}
+	aggCommit, err := commit.AggregateSignatures()
+	require.NoError(t, err)
+
+	assert.Error(t, untrustedVals.ValidateBasic())
+	err = untrustedVals.VerifyCommitLight(chainID, blockID, h, aggCommit)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "not derived from its public key")
+	}
+	err = trustedVals.VerifyCommitLightTrustingWithCommitVals(chainID, untrustedVals, aggCommit, trustLevel)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "has another public key")
+	}
+}
+
+func TestCommit_ToVoteSet_Aggregated(t *testing.T) {
+	var (
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	voteSet, valSet, vals := randBLSVoteSet(h, 0, 4, 10)
+	aggCommit, err := makeBLSCommit(t, blockID, voteSet, vals).AggregateSignatures()
+	require.NoError(t, err)
+
+	voteSet2 := aggCommit.ToVoteSet("test_chain_id", valSet)
+	assert.True(t, voteSet2.HasTwoThirdsMajority())
+	commit2 := voteSet2.MakeExtendedCommit(ABCIParams{}).ToCommit()
+	assert.Equal(t, aggCommit.AggregatedSignature, commit2.AggregatedSignature)
+	assert.Equal(t, aggCommit.Hash(), commit2.Hash())
+
+	// aggregating an aggregated commit is a no-op
+	commit3, err := commit2.AggregateSignatures()
+	require.NoError(t, err)
+	assert.Equal(t, aggCommit.Hash(), commit3.Hash())
+}
+
+// randBLSVoteSet returns a vote set of precommits of validators with BLS12-381
+// keys.
+func randBLSVoteSet(height int64, round int32, numValidators int, votingPower int64) (*VoteSet, *ValidatorSet, []PrivValidator) {
+	var (
+		validators = make([]*Validator, numValidators)
+		privVals   = make([]PrivValidator, numValidators)
+	)
+	for i := 0; i < numValidators; i++ {
+		pv := NewMockPVWithParams(bls12381.GenPrivKey(), false, false)
+		validators[i] = pv.ExtractIntoValidator(votingPower)
+		privVals[i] = pv
+	}
+	valSet := NewValidatorSet(validators)
+	sort.Sort(PrivValidatorsByAddress(privVals))
+	return NewVoteSet("test_chain_id", height, round, cmtproto.PrecommitType, valSet), valSet, privVals
+}
+
+// makeBLSCommit makes a commit of the given block, whose last validator
+// precommits nil.
+func makeBLSCommit(t *testing.T, blockID BlockID, voteSet *VoteSet, vals []PrivValidator) *Commit {
+	t.Helper()
+	for i, val := range vals {
+		pubKey, err := val.GetPubKey()
+		require.NoError(t, err)
+		vote := &Vote{
+			ValidatorAddress: pubKey.Address(),
+			ValidatorIndex:   int32(i),
+			Height:           voteSet.GetHeight(),
+			Round:            voteSet.GetRound(),
+			Type:             cmtproto.PrecommitType,
+			BlockID:          blockID,
+			Timestamp:        time.Now(),
+		}
+		if i == len(vals)-1 {
+			vote.BlockID = BlockID{}
+		}
+		added, err := signAddVote(val, vote, voteSet)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	return voteSet.MakeExtendedCommit(ABCIParams{}).ToCommit()
+}
@@ -50,6 +50,13 @@ func (v *Validator) ValidateBasic() error {
 		return fmt.Errorf("validator address is the wrong size: %v", v.Address)
 	}
 
+	// The address is not part of the hash of the validator set, nor of the
+	// sign bytes of the votes, so it must be the one of the public key.
+	if !bytes.Equal(v.Address, v.PubKey.Address()) {
+		return fmt.Errorf("validator address %v is not derived from its public key, expected %v",
+			v.Address, v.PubKey.Address())
+	}
+
 	return nil
 }
 
@@ -164,6 +171,10 @@ func ValidatorFromProto(vp *cmtproto.Validator) (*Validator, error) {
 	if err != nil {
 		return nil, err
 	}
+	if !bytes.Equal(vp.GetAddress(), pk.Address()) {
+		return nil, fmt.Errorf("validator address %X is not derived from its public key, expected %v",
+			vp.GetAddress(), pk.Address())
+	}
 	v := new(Validator)
 	v.Address = vp.GetAddress()
 	v.PubKey = pk
@@ -678,6 +678,17 @@ func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Comm
 	return VerifyCommitLightTrusting(chainID, vals, commit, trustLevel)
 }
 
+// VerifyCommitLightTrustingWithCommitVals verifies that trustLevel of the
+// validator set signed this commit, of which commitVals is the validator set.
+func (vals *ValidatorSet) VerifyCommitLightTrustingWithCommitVals(
+	chainID string,
+	commitVals *ValidatorSet,
+	commit *Commit,
+	trustLevel cmtmath.Fraction,
+) error {
+	return VerifyCommitLightTrustingWithCommitVals(chainID, vals, commitVals, commit, trustLevel)
+}
+
 // findPreviousProposer reverses the compare proposer priority function to find the validator
 // with the lowest proposer priority which would have been the previous proposer.
 //
@@ -299,18 +299,16 @@ func TestProposerSelection2(t *testing.T) {
 }
 
 func TestProposerSelection3(t *testing.T) {
+	// the validators need keys matching their addresses to be serialized
 	vset := NewValidatorSet([]*Validator{
-		newValidator([]byte("avalidator_address12"), 1),
-		newValidator([]byte("bvalidator_address12"), 1),
-		newValidator([]byte("cvalidator_address12"), 1),
-		newValidator([]byte("dvalidator_address12"), 1),
+		NewValidator(ed25519.GenPrivKey().PubKey(), 1),
+		NewValidator(ed25519.GenPrivKey().PubKey(), 1),
+		NewValidator(ed25519.GenPrivKey().PubKey(), 1),
+		NewValidator(ed25519.GenPrivKey().PubKey(), 1),
 	})
 
 	proposerOrder := make([]*Validator, 4)
 	for i := 0; i < 4; i++ {
-		// need to give all validators to have keys
-		pk := ed25519.GenPrivKey().PubKey()
-		vset.Validators[i].PubKey = pk
 		proposerOrder[i] = vset.GetProposer()
 		vset.IncrementProposerPriority(1)
 	}
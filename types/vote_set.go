@@ -73,6 +73,10 @@ type VoteSet struct {
 	maj23         *BlockID               // First 2/3 majority seen
 	votesByBlock  map[string]*blockVotes // string(blockHash|blockParts) -> blockVotes
 	peerMaj23s    map[P2PID]BlockID      // Maj23 for each peer
+
+	// Aggregated signature of the votes without signature, set if the
+	// VoteSet was reconstructed from an aggregated commit.
+	aggregatedSignature []byte
 }
 
 // NewVoteSet instantiates all fields of a new vote set. This constructor requires
@@ -254,6 +258,24 @@ func (voteSet *VoteSet) getVote(valIndex int32, blockKey string) (vote *Vote, ok
 
 // Assumes signature is valid.
 // If conflicting vote exists, returns it.
+// addAggregatedVote adds a vote of an aggregated commit, whose signature is
+// part of the aggregated signature of the commit, verified by the caller.
+// Panics if the vote can't be added.
+func (voteSet *VoteSet) addAggregatedVote(vote *Vote) {
+	voteSet.mtx.Lock()
+	defer voteSet.mtx.Unlock()
+
+	_, val := voteSet.valSet.GetByIndex(vote.ValidatorIndex)
+	if val == nil {
+		panic(fmt.Sprintf("cannot find validator %d in valSet of size %d",
+			vote.ValidatorIndex, voteSet.valSet.Size()))
+	}
+	added, conflicting := voteSet.addVerifiedVote(vote, vote.BlockID.Key(), val.VotingPower)
+	if !added || conflicting != nil {
+		panic(fmt.Sprintf("failed to add aggregated vote %v to vote set", vote))
+	}
+}
+
 func (voteSet *VoteSet) addVerifiedVote(
 	vote *Vote,
 	blockKey string,
@@ -658,10 +680,11 @@ func (voteSet *VoteSet) MakeExtendedCommit(ap ABCIParams) *ExtendedCommit {
 	}
 
 	ec := &ExtendedCommit{
-		Height:             voteSet.GetHeight(),
-		Round:              voteSet.GetRound(),
-		BlockID:            *voteSet.maj23,
-		ExtendedSignatures: sigs,
+		Height:              voteSet.GetHeight(),
+		Round:               voteSet.GetRound(),
+		BlockID:             *voteSet.maj23,
+		ExtendedSignatures:  sigs,
+		AggregatedSignature: voteSet.aggregatedSignature,
 	}
 	if err := ec.EnsureExtensions(ap.VoteExtensionsEnabled(ec.Height)); err != nil {
 		panic(fmt.Errorf("problem with vote extension data when making extended commit of height %d; %w",